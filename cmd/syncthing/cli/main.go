@@ -106,6 +106,7 @@ func runInternal(c preCli, cliArgs []string) error {
 			operationCommand,
 			errorsCommand,
 			debugCommand,
+			stateCommand,
 			{
 				Name:     "-",
 				HideHelp: true,
@@ -0,0 +1,604 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cli
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/syncthing/syncthing/lib/build"
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/osutil"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sha256"
+)
+
+// The state archive is a gzipped tar file containing the configuration,
+// keys and a dump of the database, followed by a manifest describing the
+// contents with checksums.
+const (
+	stateFormatVersion = 1
+	stateManifestName  = "manifest.json"
+	stateDatabaseName  = "index.db"
+	stateMaxSmallFile  = 64 << 20
+)
+
+// stateFiles are the plain files that go into the state archive, with
+// whether they are required to exist.
+var stateFiles = []struct {
+	name     string
+	location locations.LocationEnum
+	required bool
+}{
+	{"config.xml", locations.ConfigFile, true},
+	{"cert.pem", locations.CertFile, true},
+	{"key.pem", locations.KeyFile, true},
+	{"https-cert.pem", locations.HTTPSCertFile, false},
+	{"https-key.pem", locations.HTTPSKeyFile, false},
+}
+
+type stateManifest struct {
+	FormatVersion  int                          `json:"formatVersion"`
+	Created        time.Time                    `json:"created"`
+	Version        string                       `json:"version"`
+	DeviceID       protocol.DeviceID            `json:"deviceID"`
+	IncludePending bool                         `json:"includePending"`
+	Files          map[string]stateManifestFile `json:"files"`
+}
+
+type stateManifestFile struct {
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+var stateCommand = cli.Command{
+	Name:     "state",
+	HideHelp: true,
+	Usage:    "Export or import the complete state (config, keys, database) for migration to another machine. Syncthing must not be running.",
+	Subcommands: []cli.Command{
+		{
+			Name:      "export",
+			Usage:     "Write config, keys and database to a single archive",
+			ArgsUsage: "PATH",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "include-pending",
+					Usage: "Include pending devices and folders",
+				},
+			},
+			Action: expects(1, stateExport),
+		},
+		{
+			Name:      "import",
+			Usage:     "Restore config, keys and database from an archive created by export",
+			ArgsUsage: "PATH",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "force",
+					Usage: "Overwrite existing config, keys and database. WARNING: Destructive - deletes the current state.",
+				},
+			},
+			Action: expects(1, stateImport),
+		},
+	},
+}
+
+func stateExport(c *cli.Context) error {
+	return exportState(c.Args()[0], c.Bool("include-pending"))
+}
+
+func stateImport(c *cli.Context) error {
+	return importState(c.Args()[0], c.Bool("force"))
+}
+
+func exportState(dst string, includePending bool) error {
+	out, err := os.CreateTemp(filepath.Dir(dst), ".syncthing-state-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(out.Name()) // no-op once renamed into place
+	defer out.Close()
+	gw := gzip.NewWriter(out)
+	tw := tar.NewWriter(gw)
+
+	manifest := stateManifest{
+		FormatVersion:  stateFormatVersion,
+		Created:        time.Now().Truncate(time.Second),
+		Version:        build.Version,
+		IncludePending: includePending,
+		Files:          make(map[string]stateManifestFile),
+	}
+
+	cert, err := tls.LoadX509KeyPair(locations.Get(locations.CertFile), locations.Get(locations.KeyFile))
+	if err != nil {
+		return fmt.Errorf("loading device certificate: %w", err)
+	}
+	manifest.DeviceID = protocol.NewDeviceID(cert.Certificate[0])
+
+	for _, file := range stateFiles {
+		bs, err := os.ReadFile(locations.Get(file.location))
+		if errors.Is(err, os.ErrNotExist) && !file.required {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := writeStateEntry(tw, file.name, bs); err != nil {
+			return err
+		}
+		manifest.Files[file.name] = newStateManifestFile(bs)
+	}
+
+	ldb, err := getDB()
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer ldb.Close()
+	dump, err := os.CreateTemp("", "syncthing-state-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(dump.Name())
+	defer dump.Close()
+	mf, err := dumpDatabase(ldb, dump, includePending)
+	if err != nil {
+		return fmt.Errorf("dumping database: %w", err)
+	}
+	if _, err := dump.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(stateHeader(stateDatabaseName, mf.Size)); err != nil {
+		return err
+	}
+	if _, err := io.Copy(tw, dump); err != nil {
+		return err
+	}
+	manifest.Files[stateDatabaseName] = mf
+
+	bs, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeStateEntry(tw, stateManifestName, bs); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(out.Name(), dst); err != nil {
+		return err
+	}
+	fmt.Printf("Exported state of device %s to %s\n", manifest.DeviceID, dst)
+	return nil
+}
+
+// importState restores the state from the archive. The database is
+// imported and checked in a temporary location first, then it's swapped in
+// together with the config and keys. Should any of that fail, the previous
+// state is put back.
+func importState(path string, force bool) error {
+	// First pass: verify the integrity of the archive as a whole and keep
+	// the small files, before touching anything on disk.
+	manifest, files, err := verifyStateArchive(path)
+	if err != nil {
+		return fmt.Errorf("verifying %s: %w", path, err)
+	}
+
+	cert, err := tls.X509KeyPair(files["cert.pem"], files["key.pem"])
+	if err != nil {
+		return fmt.Errorf("invalid device certificate in archive: %w", err)
+	}
+	myID := protocol.NewDeviceID(cert.Certificate[0])
+	if myID != manifest.DeviceID {
+		return fmt.Errorf("device certificate in archive (%s) does not match manifest (%s)", myID, manifest.DeviceID)
+	}
+
+	// Reading the config applies any necessary migrations.
+	cfg, originalVersion, err := config.ReadXML(bytes.NewReader(files["config.xml"]), myID)
+	if err != nil {
+		return fmt.Errorf("reading config from archive: %w", err)
+	}
+	if originalVersion > config.CurrentVersion {
+		return fmt.Errorf("config in archive (version %d) is newer than supported (version %d)", originalVersion, config.CurrentVersion)
+	}
+	var buf bytes.Buffer
+	if err := cfg.WriteXML(&buf); err != nil {
+		return err
+	}
+	files["config.xml"] = buf.Bytes()
+
+	dbLocation := locations.Get(locations.Database)
+	if !force {
+		if _, err := os.Stat(locations.Get(locations.ConfigFile)); err == nil {
+			return errors.New("a config already exists; use --force to overwrite it")
+		}
+		if entries, err := os.ReadDir(dbLocation); err == nil && len(entries) > 0 {
+			return errors.New("a database already exists; use --force to overwrite it")
+		}
+	}
+
+	// Second pass: load the database dump next to where it's going to be.
+	if err := os.MkdirAll(filepath.Dir(dbLocation), 0o700); err != nil {
+		return err
+	}
+	importLocation, err := os.MkdirTemp(filepath.Dir(dbLocation), ".syncthing-import-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(importLocation) // no-op once renamed into place
+	if err := importStateDatabase(path, importLocation, manifest.Files[stateDatabaseName]); err != nil {
+		return fmt.Errorf("importing database: %w", err)
+	}
+
+	if err := checkDatabaseUnused(dbLocation); err != nil {
+		return err
+	}
+	if err := installState(importLocation, dbLocation, files); err != nil {
+		return err
+	}
+
+	if originalVersion != config.CurrentVersion {
+		fmt.Printf("Migrated config from version %d to %d\n", originalVersion, config.CurrentVersion)
+	}
+	fmt.Printf("Imported state of device %s exported at %s by %s\n", myID, manifest.Created.Format(time.RFC3339), manifest.Version)
+	return nil
+}
+
+// checkDatabaseUnused returns an error if the database at the location is
+// in use, i.e. Syncthing is running. The database is locked while it's
+// open, so opening it fails then.
+func checkDatabaseUnused(location string) error {
+	if _, err := os.Stat(location); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	ldb, err := backend.OpenLevelDBRO(location)
+	if err != nil {
+		return fmt.Errorf("the existing database can't be replaced, Syncthing must not be running: %w", err)
+	}
+	return ldb.Close()
+}
+
+// installState moves the imported database into place and writes the
+// files from the archive, undoing what was done if a step fails.
+func installState(importLocation, dbLocation string, files map[string][]byte) (err error) {
+	var undo []func()
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+	}()
+
+	oldLocation := importLocation + ".old"
+	if err := os.Rename(dbLocation, oldLocation); err == nil {
+		undo = append(undo, func() {
+			os.RemoveAll(dbLocation)
+			os.Rename(oldLocation, dbLocation)
+		})
+		defer func() {
+			if err == nil {
+				os.RemoveAll(oldLocation)
+			}
+		}()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.Rename(importLocation, dbLocation); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(locations.Get(locations.ConfigFile)), 0o700); err != nil {
+		return err
+	}
+	// The config goes last, as it's what makes the state look complete.
+	for i := len(stateFiles) - 1; i >= 0; i-- {
+		bs, ok := files[stateFiles[i].name]
+		if !ok {
+			continue
+		}
+		path := locations.Get(stateFiles[i].location)
+		prev, readErr := os.ReadFile(path)
+		if err := writeFileAtomic(path, bs); err != nil {
+			return err
+		}
+		if readErr == nil {
+			undo = append(undo, func() { writeFileAtomic(path, prev) })
+		} else {
+			undo = append(undo, func() { os.Remove(path) })
+		}
+	}
+	return nil
+}
+
+// verifyStateArchive reads the whole archive, checking that its contents match
+// the manifest. It returns the manifest and the contents of all files except
+// the database dump.
+func verifyStateArchive(path string) (stateManifest, map[string][]byte, error) {
+	var manifest stateManifest
+	files := make(map[string][]byte)
+	sums := make(map[string]stateManifestFile)
+
+	err := readStateArchive(path, func(hdr *tar.Header, r io.Reader) error {
+		if _, ok := sums[hdr.Name]; ok {
+			return fmt.Errorf("duplicate entry %q", hdr.Name)
+		}
+		h := sha256.New()
+		var buf bytes.Buffer
+		var w io.Writer = h
+		if hdr.Name != stateDatabaseName {
+			if hdr.Size > stateMaxSmallFile {
+				return fmt.Errorf("unexpectedly large entry %q", hdr.Name)
+			}
+			w = io.MultiWriter(h, &buf)
+		}
+		n, err := io.Copy(w, r)
+		if err != nil {
+			return err
+		}
+		if hdr.Name == stateManifestName {
+			return json.Unmarshal(buf.Bytes(), &manifest)
+		}
+		sums[hdr.Name] = stateManifestFile{Size: n, SHA256: hex.EncodeToString(h.Sum(nil))}
+		if hdr.Name != stateDatabaseName {
+			files[hdr.Name] = buf.Bytes()
+		}
+		return nil
+	})
+	if err != nil {
+		return manifest, nil, err
+	}
+
+	if manifest.FormatVersion == 0 {
+		return manifest, nil, errors.New("missing manifest")
+	}
+	if manifest.FormatVersion > stateFormatVersion {
+		return manifest, nil, fmt.Errorf("unsupported archive format version %d", manifest.FormatVersion)
+	}
+	for name, want := range manifest.Files {
+		got, ok := sums[name]
+		if !ok {
+			return manifest, nil, fmt.Errorf("missing entry %q", name)
+		}
+		if got != want {
+			return manifest, nil, fmt.Errorf("checksum mismatch for %q", name)
+		}
+		delete(sums, name)
+	}
+	for name := range sums {
+		return manifest, nil, fmt.Errorf("unexpected entry %q", name)
+	}
+	for _, file := range stateFiles {
+		if _, ok := files[file.name]; file.required && !ok {
+			return manifest, nil, fmt.Errorf("missing entry %q", file.name)
+		}
+	}
+	if _, ok := manifest.Files[stateDatabaseName]; !ok {
+		return manifest, nil, fmt.Errorf("missing entry %q", stateDatabaseName)
+	}
+	return manifest, files, nil
+}
+
+func readStateArchive(path string, fn func(*tar.Header, io.Reader) error) error {
+	fd, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+	gr, err := gzip.NewReader(bufio.NewReader(fd))
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return fmt.Errorf("unexpected entry %q", hdr.Name)
+		}
+		if err := fn(hdr, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// dumpDatabase writes all database entries as length prefixed key/value
+// pairs.
+func dumpDatabase(ldb backend.Backend, w io.Writer, includePending bool) (stateManifestFile, error) {
+	it, err := ldb.NewPrefixIterator(nil)
+	if err != nil {
+		return stateManifestFile{}, err
+	}
+	defer it.Release()
+
+	h := sha256.New()
+	cw := &countingWriter{w: io.MultiWriter(w, h)}
+	bw := bufio.NewWriter(cw)
+	for it.Next() {
+		key := it.Key()
+		if !includePending && len(key) > 0 && (key[0] == db.KeyTypePendingDevice || key[0] == db.KeyTypePendingFolder) {
+			continue
+		}
+		if err := writeDumpRecord(bw, key, it.Value()); err != nil {
+			return stateManifestFile{}, err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return stateManifestFile{}, err
+	}
+	if err := bw.Flush(); err != nil {
+		return stateManifestFile{}, err
+	}
+	return newStateManifestFileFromHash(cw.n, h), nil
+}
+
+// importStateDatabase loads the database dump from the archive into a new
+// database at the location, checking it against the manifest once more.
+func importStateDatabase(path, location string, want stateManifestFile) error {
+	ldb, err := backend.OpenLevelDB(location, backend.TuningAuto)
+	if err != nil {
+		return err
+	}
+	defer ldb.Close()
+
+	err = readStateArchive(path, func(hdr *tar.Header, r io.Reader) error {
+		if hdr.Name != stateDatabaseName {
+			return nil
+		}
+		t, err := ldb.NewWriteTransaction()
+		if err != nil {
+			return err
+		}
+		defer t.Release()
+		h := sha256.New()
+		cr := &countingWriter{w: h}
+		br := bufio.NewReader(io.TeeReader(r, cr))
+		for {
+			key, val, err := readDumpRecord(br)
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return err
+			}
+			if err := t.Put(key, val); err != nil {
+				return err
+			}
+			if err := t.Checkpoint(); err != nil {
+				return err
+			}
+		}
+		if got := newStateManifestFileFromHash(cr.n, h); got != want {
+			return errors.New("database dump changed since verifying the archive")
+		}
+		return t.Commit()
+	})
+	if err != nil {
+		return err
+	}
+
+	// Bring the database schema up to date, in case the archive was made
+	// by an older version.
+	ll, err := db.NewLowlevel(ldb, events.NoopLogger)
+	if err != nil {
+		return err
+	}
+	return db.UpdateSchema(ll)
+}
+
+func writeDumpRecord(w io.Writer, key, val []byte) error {
+	var lens [8]byte
+	binary.BigEndian.PutUint32(lens[:], uint32(len(key)))
+	binary.BigEndian.PutUint32(lens[4:], uint32(len(val)))
+	if _, err := w.Write(lens[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(key); err != nil {
+		return err
+	}
+	_, err := w.Write(val)
+	return err
+}
+
+func readDumpRecord(r io.Reader) ([]byte, []byte, error) {
+	var lens [8]byte
+	if _, err := io.ReadFull(r, lens[:]); err != nil {
+		return nil, nil, err
+	}
+	kl := binary.BigEndian.Uint32(lens[:])
+	vl := binary.BigEndian.Uint32(lens[4:])
+	if kl > stateMaxSmallFile || vl > stateMaxSmallFile {
+		return nil, nil, errors.New("corrupt database dump")
+	}
+	bs := make([]byte, kl+vl)
+	if _, err := io.ReadFull(r, bs); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, nil, err
+	}
+	return bs[:kl], bs[kl:], nil
+}
+
+func writeStateEntry(tw *tar.Writer, name string, bs []byte) error {
+	if err := tw.WriteHeader(stateHeader(name, int64(len(bs)))); err != nil {
+		return err
+	}
+	_, err := tw.Write(bs)
+	return err
+}
+
+func stateHeader(name string, size int64) *tar.Header {
+	return &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0o600,
+		ModTime:  time.Now(),
+	}
+}
+
+func writeFileAtomic(path string, bs []byte) error {
+	fd, err := osutil.CreateAtomic(path)
+	if err != nil {
+		return err
+	}
+	if _, err := fd.Write(bs); err != nil {
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
+
+func newStateManifestFile(bs []byte) stateManifestFile {
+	sum := sha256.Sum256(bs)
+	return stateManifestFile{Size: int64(len(bs)), SHA256: hex.EncodeToString(sum[:])}
+}
+
+func newStateManifestFileFromHash(size int64, h hash.Hash) stateManifestFile {
+	return stateManifestFile{Size: size, SHA256: hex.EncodeToString(h.Sum(nil))}
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(bs []byte) (int, error) {
+	n, err := c.w.Write(bs)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,312 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/tlsutil"
+)
+
+// setStateDirs points the locations to a new, empty home directory.
+func setStateDirs(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, base := range []locations.BaseDirEnum{locations.ConfigBaseDir, locations.DataBaseDir} {
+		if err := locations.SetBaseDir(base, dir); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// createState creates keys, a config and a database with a few entries,
+// returning the device ID.
+func createState(t *testing.T) protocol.DeviceID {
+	t.Helper()
+	cert, err := tlsutil.NewCertificate(locations.Get(locations.CertFile), locations.Get(locations.KeyFile), "syncthing", 365)
+	if err != nil {
+		t.Fatal(err)
+	}
+	myID := protocol.NewDeviceID(cert.Certificate[0])
+
+	cfg := config.New(myID)
+	cfg.GUI.User = "exported"
+	fd, err := os.Create(locations.Get(locations.ConfigFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.WriteXML(fd); err != nil {
+		t.Fatal(err)
+	}
+	fd.Close()
+
+	putState(t, map[string]string{"key1": "value1", "key2": "value2"})
+	return myID
+}
+
+func putState(t *testing.T, kvs map[string]string) {
+	t.Helper()
+	ldb, err := backend.OpenLevelDB(locations.Get(locations.Database), backend.TuningAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ldb.Close()
+	for k, v := range kvs {
+		if err := ldb.Put([]byte(k), []byte(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func getState(t *testing.T, key string) string {
+	t.Helper()
+	ldb, err := backend.OpenLevelDBRO(locations.Get(locations.Database))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ldb.Close()
+	val, err := ldb.Get([]byte(key))
+	if backend.IsNotFound(err) {
+		return ""
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	return string(val)
+}
+
+func TestStateExportImport(t *testing.T) {
+	setStateDirs(t)
+	myID := createState(t)
+	archive := filepath.Join(t.TempDir(), "state.tar.gz")
+	if err := exportState(archive, false); err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := os.ReadFile(locations.Get(locations.CertFile))
+
+	// Importing into a new home restores everything.
+	setStateDirs(t)
+	if err := importState(archive, false); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(locations.Get(locations.CertFile)); !bytes.Equal(got, cert) {
+		t.Error("certificate differs after import")
+	}
+	wrapper, _, err := config.Load(locations.Get(locations.ConfigFile), myID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user := wrapper.GUI().User; user != "exported" {
+		t.Errorf("expected the exported config, got GUI user %q", user)
+	}
+	for key, val := range map[string]string{"key1": "value1", "key2": "value2"} {
+		if got := getState(t, key); got != val {
+			t.Errorf("expected %q for %s, got %q", val, key, got)
+		}
+	}
+
+	// Existing state isn't overwritten unless forced, and replaced
+	// entirely when it is.
+	putState(t, map[string]string{"key3": "value3"})
+	if err := importState(archive, false); err == nil {
+		t.Fatal("expected the import to refuse overwriting the state")
+	}
+	if err := importState(archive, true); err != nil {
+		t.Fatal(err)
+	}
+	if got := getState(t, "key3"); got != "" {
+		t.Errorf("expected the previous database to be replaced, got %q", got)
+	}
+	if got := getState(t, "key1"); got != "value1" {
+		t.Errorf("expected the imported database, got %q", got)
+	}
+}
+
+// rewriteStateArchive copies the archive, passing each entry through fn.
+func rewriteStateArchive(t *testing.T, src, dst string, fn func(name string, bs []byte) []byte) {
+	t.Helper()
+	var out bytes.Buffer
+	gw := gzip.NewWriter(&out)
+	tw := tar.NewWriter(gw)
+	err := readStateArchive(src, func(hdr *tar.Header, r io.Reader) error {
+		bs, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		return writeStateEntry(tw, hdr.Name, fn(hdr.Name, bs))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw.Close()
+	gw.Close()
+	if err := os.WriteFile(dst, out.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyStateArchiveCorrupted(t *testing.T) {
+	setStateDirs(t)
+	createState(t)
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "state.tar.gz")
+	if err := exportState(archive, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := verifyStateArchive(archive); err != nil {
+		t.Fatal("expected the exported archive to verify:", err)
+	}
+
+	cases := map[string]func(name string, bs []byte) []byte{
+		"changed config": func(name string, bs []byte) []byte {
+			if name == "config.xml" {
+				return bytes.Replace(bs, []byte("exported"), []byte("tampered"), 1)
+			}
+			return bs
+		},
+		"truncated database": func(name string, bs []byte) []byte {
+			if name == stateDatabaseName {
+				return bs[:len(bs)-1]
+			}
+			return bs
+		},
+		"missing key": func(name string, bs []byte) []byte {
+			if name == stateManifestName {
+				var manifest stateManifest
+				must(t, json.Unmarshal(bs, &manifest))
+				delete(manifest.Files, "key.pem")
+				bs, _ = json.Marshal(manifest)
+			}
+			return bs
+		},
+	}
+	for name, fn := range cases {
+		corrupted := filepath.Join(dir, strings.ReplaceAll(name, " ", "-"))
+		rewriteStateArchive(t, archive, corrupted, fn)
+		if _, _, err := verifyStateArchive(corrupted); err == nil {
+			t.Errorf("%s: expected verification to fail", name)
+		}
+	}
+
+	// A cut off archive isn't valid either.
+	bs, _ := os.ReadFile(archive)
+	truncated := filepath.Join(dir, "truncated")
+	must(t, os.WriteFile(truncated, bs[:len(bs)/2], 0o600))
+	if _, _, err := verifyStateArchive(truncated); err == nil {
+		t.Error("expected verification of a truncated archive to fail")
+	}
+}
+
+func TestStateImportFailureKeepsState(t *testing.T) {
+	setStateDirs(t)
+	createState(t)
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "state.tar.gz")
+	if err := exportState(archive, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// A database dump that can't be loaded, but matches the manifest, so
+	// the archive as such verifies.
+	var garbage []byte
+	corrupted := filepath.Join(dir, "corrupted")
+	rewriteStateArchive(t, archive, corrupted, func(name string, bs []byte) []byte {
+		if name == stateDatabaseName {
+			garbage = []byte("\xff\xff\xff\xffnot a dump")
+			return garbage
+		}
+		if name == stateManifestName {
+			var manifest stateManifest
+			must(t, json.Unmarshal(bs, &manifest))
+			manifest.Files[stateDatabaseName] = newStateManifestFile(garbage)
+			bs, _ = json.Marshal(manifest)
+		}
+		return bs
+	})
+	if _, _, err := verifyStateArchive(corrupted); err != nil {
+		t.Fatal("expected the archive to verify:", err)
+	}
+
+	setStateDirs(t)
+	otherID := createState(t)
+	putState(t, map[string]string{"key3": "value3"})
+	cfgBefore, _ := os.ReadFile(locations.Get(locations.ConfigFile))
+	certBefore, _ := os.ReadFile(locations.Get(locations.CertFile))
+
+	if err := importState(corrupted, true); err == nil {
+		t.Fatal("expected the import to fail")
+	}
+	if got, _ := os.ReadFile(locations.Get(locations.ConfigFile)); !bytes.Equal(got, cfgBefore) {
+		t.Error("config changed by failed import")
+	}
+	if got, _ := os.ReadFile(locations.Get(locations.CertFile)); !bytes.Equal(got, certBefore) {
+		t.Errorf("certificate of %v changed by failed import", otherID)
+	}
+	if got := getState(t, "key3"); got != "value3" {
+		t.Errorf("database changed by failed import, got %q", got)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(locations.Get(locations.Database)))
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".syncthing-import-") {
+			t.Errorf("temporary database %s left behind", entry.Name())
+		}
+	}
+}
+
+func TestStateImportRefusesRunningInstance(t *testing.T) {
+	setStateDirs(t)
+	createState(t)
+	archive := filepath.Join(t.TempDir(), "state.tar.gz")
+	if err := exportState(archive, false); err != nil {
+		t.Fatal(err)
+	}
+
+	setStateDirs(t)
+	createState(t)
+	cfgBefore, _ := os.ReadFile(locations.Get(locations.ConfigFile))
+
+	// An open database is what a running instance looks like.
+	ldb, err := backend.OpenLevelDB(locations.Get(locations.Database), backend.TuningAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = importState(archive, true)
+	ldb.Close()
+	if err == nil || !strings.Contains(err.Error(), "must not be running") {
+		t.Fatal("expected the import to refuse replacing the database in use, got", err)
+	}
+	if got, _ := os.ReadFile(locations.Get(locations.ConfigFile)); !bytes.Equal(got, cfgBefore) {
+		t.Error("config changed by refused import")
+	}
+	if got := getState(t, "key1"); got != "value1" {
+		t.Errorf("database changed by refused import, got %q", got)
+	}
+
+	// Once it's closed, the import goes through.
+	if err := importState(archive, true); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/syncthing/syncthing/cmd/syncthing/cmdutil"
 	"github.com/syncthing/syncthing/cmd/syncthing/decrypt"
 	"github.com/syncthing/syncthing/cmd/syncthing/generate"
+	"github.com/syncthing/syncthing/cmd/syncthing/service"
 	"github.com/syncthing/syncthing/lib/build"
 	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/db"
@@ -47,14 +48,19 @@ import (
 	"github.com/syncthing/syncthing/lib/locations"
 	"github.com/syncthing/syncthing/lib/logger"
 	"github.com/syncthing/syncthing/lib/osutil"
+	"github.com/syncthing/syncthing/lib/profiles"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/svcutil"
 	"github.com/syncthing/syncthing/lib/syncthing"
+	"github.com/syncthing/syncthing/lib/tlsutil"
 	"github.com/syncthing/syncthing/lib/upgrade"
 )
 
 const (
 	sigTerm = syscall.Signal(15)
+
+	// How long to wait for a running instance to stop on --takeover.
+	takeoverTimeout = 2 * time.Minute
 )
 
 const (
@@ -99,6 +105,11 @@ above.
                    "minio" for the github.com/minio/sha256-simd implementation,
                    and blank (the default) for auto detection.
 
+ STPROTOCOLCORPUS  Record sanitized copies of received protocol messages to
+                   the given directory, as a fuzzing corpus for reproducing
+                   protocol bugs. Names are replaced by hashes and file data is
+                   zeroed.
+
  STVERSIONEXTRA    Add extra information to the version string in logs and the
                    version line in the GUI. Can be set to the name of a wrapper
                    or tool controlling syncthing to communicate this to the end
@@ -139,36 +150,56 @@ var entrypoint struct {
 	Serve    serveOptions `cmd:"" help:"Run Syncthing"`
 	Generate generate.CLI `cmd:"" help:"Generate key and config, then exit"`
 	Decrypt  decrypt.CLI  `cmd:"" help:"Decrypt or verify an encrypted folder"`
+	Service  service.CLI  `cmd:"" help:"Run Syncthing in the background as a Windows service or launchd agent"`
 	Cli      struct{}     `cmd:"" help:"Command line interface for Syncthing"`
+	FSHelper fsHelperCLI  `cmd:"" name:"fs-helper" hidden:"1" help:"Serve filesystem operations for folders run as another user"`
+}
+
+// fsHelperCLI is started by Syncthing itself, as the user a folder is run
+// as or sandboxed to the folder, to perform the filesystem operations of
+// the folder.
+type fsHelperCLI struct {
+	Sandbox string `placeholder:"PATH" help:"Restrict filesystem access to the files below PATH"`
+}
+
+func (c fsHelperCLI) Run() error {
+	return fs.ServeHelperProcess(c.Sandbox)
 }
 
 // serveOptions are the options for the `syncthing serve` command.
 type serveOptions struct {
 	cmdutil.CommonOptions
-	AllowNewerConfig bool   `help:"Allow loading newer than current config version"`
-	Audit            bool   `help:"Write events to audit file"`
-	AuditFile        string `name:"auditfile" placeholder:"PATH" help:"Specify audit file (use \"-\" for stdout, \"--\" for stderr)"`
-	BrowserOnly      bool   `help:"Open GUI in browser"`
-	DataDir          string `name:"data" placeholder:"PATH" env:"STDATADIR" help:"Set data directory (database and logs)"`
-	DeviceID         bool   `help:"Show the device ID"`
-	GenerateDir      string `name:"generate" placeholder:"PATH" help:"Generate key and config in specified dir, then exit"` // DEPRECATED: replaced by subcommand!
-	GUIAddress       string `name:"gui-address" placeholder:"URL" help:"Override GUI address (e.g. \"http://192.0.2.42:8443\")"`
-	GUIAPIKey        string `name:"gui-apikey" placeholder:"API-KEY" help:"Override GUI API key"`
-	LogFile          string `name:"logfile" default:"${logFile}" placeholder:"PATH" help:"Log file name (see below)"`
-	LogFlags         int    `name:"logflags" default:"${logFlags}" placeholder:"BITS" help:"Select information in log line prefix (see below)"`
-	LogMaxFiles      int    `placeholder:"N" default:"${logMaxFiles}" name:"log-max-old-files" help:"Number of old files to keep (zero to keep only current)"`
-	LogMaxSize       int    `placeholder:"BYTES" default:"${logMaxSize}" help:"Maximum size of any file (zero to disable log rotation)"`
-	NoBrowser        bool   `help:"Do not start browser"`
-	NoRestart        bool   `env:"STNORESTART" help:"Do not restart Syncthing when exiting due to API/GUI command, upgrade, or crash"`
-	NoUpgrade        bool   `env:"STNOUPGRADE" help:"Disable automatic upgrades"`
-	Paths            bool   `help:"Show configuration paths"`
-	Paused           bool   `help:"Start with all devices and folders paused"`
-	Unpaused         bool   `help:"Start with all devices and folders unpaused"`
-	Upgrade          bool   `help:"Perform upgrade"`
-	UpgradeCheck     bool   `help:"Check for available upgrade"`
-	UpgradeTo        string `placeholder:"URL" help:"Force upgrade directly from specified URL"`
-	Verbose          bool   `help:"Print verbose log output"`
-	Version          bool   `help:"Show version"`
+	AllowNewerConfig bool          `help:"Allow loading newer than current config version"`
+	Audit            bool          `help:"Write events to audit file"`
+	AuditFile        string        `name:"auditfile" placeholder:"PATH" help:"Specify audit file (use \"-\" for stdout, \"--\" for stderr)"`
+	ConfigReload     string        `name:"config-reload" placeholder:"POLICY" env:"STCONFIGRELOAD" help:"Reload the config from disk on SIGHUP instead of restarting, combining it with runtime changes by POLICY (\"replace\" or \"merge\", see docs)"`
+	ConfigWatch      time.Duration `name:"config-watch" placeholder:"INTERVAL" env:"STCONFIGWATCH" help:"With --config-reload, also reload the config when the file changes, checking at INTERVAL"`
+	BrowserOnly      bool          `help:"Open GUI in browser"`
+	DataDir          string        `name:"data" placeholder:"PATH" env:"STDATADIR" help:"Set data directory (database and logs)"`
+	DeviceID         bool          `help:"Show the device ID"`
+	DeviceKey        string        `name:"device-key" placeholder:"REF" env:"STDEVICEKEY" help:"Use a device key held outside of key.pem, e.g. \"exec:/path/to/helper args\" (see docs)"`
+	GenerateDir      string        `name:"generate" placeholder:"PATH" help:"Generate key and config in specified dir, then exit"` // DEPRECATED: replaced by subcommand!
+	GUIAddress       string        `name:"gui-address" placeholder:"URL" help:"Override GUI address (e.g. \"http://192.0.2.42:8443\")"`
+	GUIAPIKey        string        `name:"gui-apikey" placeholder:"API-KEY" help:"Override GUI API key"`
+	LogFile          string        `name:"logfile" default:"${logFile}" placeholder:"PATH" help:"Log file name (see below)"`
+	LogFlags         int           `name:"logflags" default:"${logFlags}" placeholder:"BITS" help:"Select information in log line prefix (see below)"`
+	LogMaxFiles      int           `placeholder:"N" default:"${logMaxFiles}" name:"log-max-old-files" help:"Number of old files to keep (zero to keep only current)"`
+	LogMaxSize       int           `placeholder:"BYTES" default:"${logMaxSize}" help:"Maximum size of any file (zero to disable log rotation)"`
+	NoBrowser        bool          `help:"Do not start browser"`
+	NoRestart        bool          `env:"STNORESTART" help:"Do not restart Syncthing when exiting due to API/GUI command, upgrade, or crash"`
+	NoUpgrade        bool          `env:"STNOUPGRADE" help:"Disable automatic upgrades"`
+	Paths            bool          `help:"Show configuration paths"`
+	ProfilesAddress  string        `name:"profiles-address" default:"127.0.0.1:8390" placeholder:"ADDR" help:"Serve the profiles API and the GUIs of the profiles on this address"`
+	ProfilesDir      string        `name:"profiles-dir" placeholder:"PATH" env:"STPROFILESDIR" help:"Also run a profile for each directory in PATH (see docs)"`
+	ProfilesPorts    string        `name:"profiles-ports" default:"22001-22100" placeholder:"MIN-MAX" help:"Assign profile sync ports from this range"`
+	Paused           bool          `help:"Start with all devices and folders paused"`
+	Takeover         bool          `env:"STTAKEOVER" help:"Stop an instance already running with the same configuration directory and take over"`
+	Unpaused         bool          `help:"Start with all devices and folders unpaused"`
+	Upgrade          bool          `help:"Perform upgrade"`
+	UpgradeCheck     bool          `help:"Check for available upgrade"`
+	UpgradeTo        string        `placeholder:"URL" help:"Force upgrade directly from specified URL"`
+	Verbose          bool          `help:"Print verbose log output"`
+	Version          bool          `help:"Show version"`
 
 	// Debug options below
 	DebugDBIndirectGCInterval time.Duration `env:"STGCINDIRECTEVERY" help:"Database indirection GC interval"`
@@ -184,8 +215,9 @@ type serveOptions struct {
 	DebugResetDeltaIdxs       bool          `name:"reset-deltas" help:"Reset delta index IDs, forcing a full index exchange"`
 
 	// Internal options, not shown to users
-	InternalRestarting   bool `env:"STRESTART" hidden:"1"`
-	InternalInnerProcess bool `env:"STMONITORED" hidden:"1"`
+	InternalRestarting   bool   `env:"STRESTART" hidden:"1"`
+	InternalInnerProcess bool   `env:"STMONITORED" hidden:"1"`
+	InternalSafeMode     string `env:"STSAFEMODE" hidden:"1"`
 }
 
 func defaultVars() kong.Vars {
@@ -329,9 +361,9 @@ func (options serveOptions) Run() error {
 	}
 
 	if options.DeviceID {
-		cert, err := tls.LoadX509KeyPair(
+		cert, err := tlsutil.LoadX509KeyPair(
 			locations.Get(locations.CertFile),
-			locations.Get(locations.KeyFile),
+			deviceKey(options),
 		)
 		if err != nil {
 			l.Warnln("Error reading device ID:", err)
@@ -412,6 +444,13 @@ func (options serveOptions) Run() error {
 		return nil
 	}
 
+	if !options.InternalInnerProcess {
+		if err := ensureSingleInstance(options.Takeover); err != nil {
+			l.Warnln(err)
+			os.Exit(svcutil.ExitError.AsInt())
+		}
+	}
+
 	if options.InternalInnerProcess {
 		syncthingMain(options)
 	} else {
@@ -420,6 +459,44 @@ func (options serveOptions) Run() error {
 	return nil
 }
 
+// ensureSingleInstance checks that no other instance is running with our
+// configuration directory, as two instances sharing the database corrupt
+// it. With takeover set, the running instance is asked to shut down and
+// we wait for it to do so.
+func ensureSingleInstance(takeover bool) error {
+	lockFile := locations.Get(locations.LockFile)
+	lock, err := syncthing.LockInstance(lockFile)
+	if err == nil {
+		return lock.Release()
+	}
+	if !errors.Is(err, syncthing.ErrInstanceRunning) {
+		return fmt.Errorf("checking for a running instance: %w", err)
+	}
+
+	pid := syncthing.InstancePID(lockFile)
+	if !takeover {
+		return fmt.Errorf("Syncthing is already running (pid %d) with the configuration in %s; use --takeover to stop it and take over", pid, locations.GetBaseDir(locations.ConfigBaseDir))
+	}
+
+	l.Infof("Stopping the instance already running (pid %d)", pid)
+	if err := postViaRest("rest/system/shutdown"); err != nil {
+		return fmt.Errorf("stopping the running instance: %w", err)
+	}
+	deadline := time.Now().Add(takeoverTimeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(time.Second)
+		lock, err := syncthing.LockInstance(lockFile)
+		if err == nil {
+			l.Infoln("The running instance stopped; taking over")
+			return lock.Release()
+		}
+		if !errors.Is(err, syncthing.ErrInstanceRunning) {
+			return fmt.Errorf("checking for a running instance: %w", err)
+		}
+	}
+	return fmt.Errorf("instance (pid %d) didn't stop within %v", pid, takeoverTimeout)
+}
+
 func openGUI() error {
 	cfg, err := loadOrDefaultConfig()
 	if err != nil {
@@ -485,6 +562,12 @@ func checkUpgrade() (upgrade.Release, error) {
 }
 
 func upgradeViaRest() error {
+	return postViaRest("rest/system/upgrade")
+}
+
+// postViaRest posts to the given endpoint of the REST API of the instance
+// running with our configuration.
+func postViaRest(endpoint string) error {
 	cfg, err := loadOrDefaultConfig()
 	if err != nil {
 		return err
@@ -494,7 +577,7 @@ func upgradeViaRest() error {
 	if err != nil {
 		return err
 	}
-	u.Path = path.Join(u.Path, "rest/system/upgrade")
+	u.Path = path.Join(u.Path, endpoint)
 	target := u.String()
 	r, _ := http.NewRequest("POST", target, nil)
 	r.Header.Set("X-API-Key", cfg.GUI().APIKey)
@@ -543,10 +626,19 @@ func syncthingMain(options serveOptions) {
 	// early etc. will have it available.
 	l.Infoln(build.LongVersion)
 
+	// Hold the instance lock for as long as we run, so that a second
+	// instance won't start using the same configuration and database.
+	lock, err := syncthing.LockInstance(locations.Get(locations.LockFile))
+	if err != nil {
+		l.Warnln("Failed to lock the configuration directory:", err)
+		os.Exit(svcutil.ExitError.AsInt())
+	}
+	defer lock.Release()
+
 	// Ensure that we have a certificate and key.
 	cert, err := syncthing.LoadOrGenerateCertificate(
 		locations.Get(locations.CertFile),
-		locations.Get(locations.KeyFile),
+		deviceKey(options),
 	)
 	if err != nil {
 		l.Warnln("Failed to load/generate certificate:", err)
@@ -624,6 +716,9 @@ func syncthingMain(options serveOptions) {
 	} else if options.Paused {
 		setPauseState(cfgWrapper, true)
 	}
+	if options.InternalSafeMode != "" {
+		pauseFolders(cfgWrapper)
+	}
 
 	appOpts := syncthing.Options{
 		DeadlockTimeoutS:     options.DebugDeadlockTimeout,
@@ -658,7 +753,20 @@ func syncthingMain(options serveOptions) {
 		go autoUpgrade(cfgWrapper, app, evLogger)
 	}
 
-	setupSignalHandling(app)
+	var reloader *config.Reloader
+	if options.ConfigReload != "" {
+		policy, err := config.ParseReloadPolicy(options.ConfigReload)
+		if err != nil {
+			l.Warnln("Failed to start Syncthing:", err)
+			os.Exit(svcutil.ExitError.AsInt())
+		}
+		reloader = config.NewReloader(cfgWrapper, policy, options.ConfigWatch)
+	}
+
+	setupSignalHandling(app, reloader)
+	serviceStopped := service.Notify(func() {
+		app.Stop(svcutil.ExitSuccess)
+	})
 
 	if os.Getenv("GOMAXPROCS") == "" {
 		runtime.GOMAXPROCS(runtime.NumCPU())
@@ -682,6 +790,24 @@ func syncthingMain(options serveOptions) {
 
 	cleanConfigDirectory()
 
+	if options.InternalSafeMode != "" {
+		// Shown in the GUI, as it's a warning.
+		l.Warnf("Started in safe mode after repeated crashes, all folders were paused. Unpause them once the problem is resolved. Crash diagnostics: %s", options.InternalSafeMode)
+	}
+
+	if reloader != nil {
+		app.AddService(reloader)
+	}
+
+	if options.ProfilesDir != "" {
+		profilesMgr, err := newProfilesManager(options, appOpts)
+		if err != nil {
+			l.Warnln("Failed to start profiles:", err)
+			os.Exit(svcutil.ExitError.AsInt())
+		}
+		app.AddService(profilesMgr)
+	}
+
 	if cfgWrapper.Options().StartBrowser && !options.NoBrowser && !options.InternalRestarting {
 		// Can potentially block if the utility we are invoking doesn't
 		// fork, and just execs, hence keep it in its own routine.
@@ -698,18 +824,43 @@ func syncthingMain(options serveOptions) {
 		pprof.StopCPUProfile()
 	}
 
+	serviceStopped(int(status))
 	os.Exit(int(status))
 }
 
-func setupSignalHandling(app *syncthing.App) {
-	// Exit cleanly with "restarting" code on SIGHUP.
+func newProfilesManager(options serveOptions, appOpts syncthing.Options) (*profiles.Manager, error) {
+	minPort, maxPort, ok := strings.Cut(options.ProfilesPorts, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid profile port range %q", options.ProfilesPorts)
+	}
+	opts := profiles.Options{
+		Dir:     options.ProfilesDir,
+		Address: options.ProfilesAddress,
+		APIKey:  os.Getenv("STPROFILESAPIKEY"),
+	}
+	opts.MinPort, _ = strconv.Atoi(minPort)
+	opts.MaxPort, _ = strconv.Atoi(maxPort)
+	// Profiles keep their audit events to themselves.
+	appOpts.AuditWriter = nil
+	return profiles.New(opts, appOpts)
+}
+
+func setupSignalHandling(app *syncthing.App, reloader *config.Reloader) {
+	// Exit cleanly with "restarting" code on SIGHUP, or reload the config
+	// if asked to.
 
 	restartSign := make(chan os.Signal, 1)
 	sigHup := syscall.Signal(1)
 	signal.Notify(restartSign, sigHup)
 	go func() {
-		<-restartSign
-		app.Stop(svcutil.ExitRestart)
+		if reloader == nil {
+			<-restartSign
+			app.Stop(svcutil.ExitRestart)
+			return
+		}
+		for range restartSign {
+			reloader.Reload()
+		}
 	}()
 
 	// Exit with "success" code (no restart) on INT/TERM
@@ -767,6 +918,15 @@ func auditWriter(auditFile string) io.Writer {
 	return fd
 }
 
+// deviceKey returns the reference to the device key given on the command
+// line, or the path of the regular key file.
+func deviceKey(options serveOptions) string {
+	if options.DeviceKey != "" {
+		return options.DeviceKey
+	}
+	return locations.Get(locations.KeyFile)
+}
+
 func resetDB() error {
 	return os.RemoveAll(locations.Get(locations.Database))
 }
@@ -834,6 +994,11 @@ func autoUpgrade(cfg config.Wrapper, app *syncthing.App, evLogger events.Logger)
 		sub.Unsubscribe()
 		l.Warnf("Automatically upgraded to version %q. Restarting in 1 minute.", rel.Tag)
 		time.Sleep(time.Minute)
+		// Don't restart together with the devices we coordinate restarts
+		// with, so they don't all go down at once.
+		if err := app.Model().AcquireRestart(context.Background()); err != nil {
+			l.Warnln("Automatic upgrade: coordinating restart:", err)
+		}
 		app.Stop(svcutil.ExitUpgrade)
 		return
 	}
@@ -917,6 +1082,20 @@ func setPauseState(cfgWrapper config.Wrapper, paused bool) {
 	}
 }
 
+// pauseFolders pauses all folders, as done in safe mode. The devices stay
+// connected, so the state of the folders can still be looked into.
+func pauseFolders(cfgWrapper config.Wrapper) {
+	_, err := cfgWrapper.Modify(func(cfg *config.Configuration) {
+		for i := range cfg.Folders {
+			cfg.Folders[i].Paused = true
+		}
+	})
+	if err != nil {
+		l.Warnln("Cannot pause folders:", err)
+		os.Exit(svcutil.ExitError.AsInt())
+	}
+}
+
 func exitCodeForUpgrade(err error) int {
 	if _, ok := err.(*errNoUpgrade); ok {
 		return svcutil.ExitNoUpgradeAvailable.AsInt()
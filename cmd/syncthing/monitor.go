@@ -98,12 +98,24 @@ func monitorMain(options serveOptions) {
 
 	childEnv := childEnv()
 	first := true
+	safeMode := false
+	var exits []string // why the recent runs ended
 	for {
 		maybeReportPanics()
 
 		if t := time.Since(restarts[0]); t < restartLoopThreshold {
-			l.Warnf("%d restarts in %v; not retrying further", restartCounts, t)
-			os.Exit(svcutil.ExitError.AsInt())
+			if safeMode {
+				l.Warnf("%d restarts in %v, also in safe mode; not retrying further", restartCounts, t)
+				os.Exit(svcutil.ExitError.AsInt())
+			}
+			// Rather than giving up, which goes unnoticed until someone
+			// wonders why nothing syncs, start with the folders paused
+			// and the GUI up, so the problem can be looked into.
+			l.Warnf("%d restarts in %v; starting in safe mode with all folders paused", restartCounts, t)
+			diagnostics := writeSafeModeDiagnostics(exits)
+			childEnv = append(childEnv, "STSAFEMODE="+diagnostics)
+			safeMode = true
+			restarts = [restartCounts]time.Time{}
 		}
 
 		copy(restarts[0:], restarts[1:])
@@ -156,19 +168,29 @@ func monitorMain(options serveOptions) {
 		}()
 
 		stopped := false
-		select {
-		case s := <-stopSign:
-			l.Infof("Signal %d received; exiting", s)
-			cmd.Process.Signal(sigTerm)
-			err = <-exit
-			stopped = true
-
-		case s := <-restartSign:
-			l.Infof("Signal %d received; restarting", s)
-			cmd.Process.Signal(sigHup)
-			err = <-exit
-
-		case err = <-exit:
+	wait:
+		for {
+			select {
+			case s := <-stopSign:
+				l.Infof("Signal %d received; exiting", s)
+				cmd.Process.Signal(sigTerm)
+				err = <-exit
+				stopped = true
+
+			case s := <-restartSign:
+				if options.ConfigReload != "" {
+					// The child reloads its config and keeps running.
+					l.Infof("Signal %d received; reloading config", s)
+					cmd.Process.Signal(sigHup)
+					continue
+				}
+				l.Infof("Signal %d received; restarting", s)
+				cmd.Process.Signal(sigHup)
+				err = <-exit
+
+			case err = <-exit:
+			}
+			break wait
 		}
 
 		if err == nil {
@@ -197,6 +219,10 @@ func monitorMain(options serveOptions) {
 		}
 
 		l.Infoln("Syncthing exited:", err)
+		exits = append(exits, fmt.Sprintf("%s: %v", time.Now().Format(time.RFC3339), err))
+		if len(exits) > restartCounts {
+			exits = exits[1:]
+		}
 		time.Sleep(restartPause)
 
 		if first {
@@ -208,6 +234,40 @@ func monitorMain(options serveOptions) {
 	}
 }
 
+// writeSafeModeDiagnostics writes what is known about the crashes leading
+// to safe mode to a file, and returns its path.
+func writeSafeModeDiagnostics(exits []string) string {
+	fd, err := os.Create(locations.GetTimestamped(locations.SafeModeLog))
+	if err != nil {
+		l.Warnln("Create safe mode log:", err)
+		return "unavailable"
+	}
+	defer fd.Close()
+
+	fmt.Fprintf(fd, "Safe mode at %s\n%s\n\nRecent exits:\n", time.Now().Format(time.RFC3339), build.LongVersion)
+	for _, exit := range exits {
+		fmt.Fprintln(fd, exit)
+	}
+	panics, _ := filepath.Glob(filepath.Join(locations.GetBaseDir(locations.DataBaseDir), "panic-*.log"))
+	if len(panics) > 0 {
+		fmt.Fprintf(fd, "\nPanic logs:\n%s\n", strings.Join(panics, "\n"))
+	}
+
+	fmt.Fprintln(fd, "\nOutput of the last run:")
+	stdoutMut.Lock()
+	for _, line := range stdoutFirstLines {
+		fd.WriteString(line)
+	}
+	fd.WriteString("...\n")
+	for _, line := range stdoutLastLines {
+		fd.WriteString(line)
+	}
+	stdoutMut.Unlock()
+
+	l.Warnf("Crash diagnostics written to %q", fd.Name())
+	return fd.Name()
+}
+
 func getBinary(args0 string) (string, error) {
 	e, err := os.Executable()
 	if err == nil {
@@ -559,6 +619,9 @@ func childEnv() []string {
 		if strings.HasPrefix(str, "STMONITORED=") {
 			continue
 		}
+		if strings.HasPrefix(str, "STSAFEMODE=") {
+			continue
+		}
 		env = append(env, str)
 	}
 	env = append(env, "STMONITORED=yes")
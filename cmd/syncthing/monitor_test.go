@@ -10,8 +10,11 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/syncthing/syncthing/lib/locations"
 )
 
 func TestRotatedFile(t *testing.T) {
@@ -214,3 +217,31 @@ func TestAutoClosedFile(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestSafeModeDiagnostics(t *testing.T) {
+	dir := t.TempDir()
+	prev := locations.GetBaseDir(locations.DataBaseDir)
+	if err := locations.SetBaseDir(locations.DataBaseDir, dir); err != nil {
+		t.Fatal(err)
+	}
+	defer locations.SetBaseDir(locations.DataBaseDir, prev)
+
+	stdoutMut.Lock()
+	stdoutFirstLines = []string{"first\n"}
+	stdoutLastLines = []string{"last\n"}
+	stdoutMut.Unlock()
+
+	path := writeSafeModeDiagnostics([]string{"exit status 1"})
+	if filepath.Dir(path) != dir {
+		t.Fatalf("expected diagnostics in %s, got %s", dir, path)
+	}
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, exp := range []string{"exit status 1", "first\n...\nlast\n"} {
+		if !strings.Contains(string(bs), exp) {
+			t.Errorf("expected %q in diagnostics:\n%s", exp, bs)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// The agent is kept alive unless it exits successfully, i.e. when shut
+// down from the GUI.
+var launchdTemplate = template.Must(template.New("plist").Funcs(template.FuncMap{
+	"xml": func(s string) (string, error) {
+		var sb strings.Builder
+		err := xml.EscapeText(&sb, []byte(s))
+		return sb.String(), err
+	},
+}).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+	<dict>
+		<key>Label</key>
+		<string>{{xml .Label}}</string>
+
+		<key>ProgramArguments</key>
+		<array>
+{{- range .Args}}
+			<string>{{xml .}}</string>
+{{- end}}
+		</array>
+
+		<key>EnvironmentVariables</key>
+		<dict>
+			<key>HOME</key>
+			<string>{{xml .Home}}</string>
+		</dict>
+
+		<key>RunAtLoad</key>
+		<true/>
+
+		<key>KeepAlive</key>
+		<dict>
+			<key>SuccessfulExit</key>
+			<false/>
+		</dict>
+
+		<key>LowPriorityIO</key>
+		<true/>
+
+		<key>ProcessType</key>
+		<string>Background</string>
+
+		<key>StandardOutPath</key>
+		<string>{{xml .Stdout}}</string>
+
+		<key>StandardErrorPath</key>
+		<string>{{xml .Stderr}}</string>
+	</dict>
+</plist>
+`))
+
+// launchdPlist returns the definition of the launchd agent running the
+// binary with the given arguments, logging to logDir.
+func launchdPlist(binary string, args []string, home, logDir string) ([]byte, error) {
+	var buf bytes.Buffer
+	err := launchdTemplate.Execute(&buf, map[string]interface{}{
+		"Label":  launchdName,
+		"Args":   append([]string{binary}, args...),
+		"Home":   home,
+		"Stdout": filepath.Join(logDir, "Syncthing.log"),
+		"Stderr": filepath.Join(logDir, "Syncthing-Errors.log"),
+	})
+	return buf.Bytes(), err
+}
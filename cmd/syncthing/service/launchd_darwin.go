@@ -0,0 +1,87 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+func plistPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Library", "LaunchAgents", launchdName+".plist"), nil
+}
+
+func launchctl(args ...string) error {
+	out, err := exec.Command("launchctl", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("launchctl %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func domain() string {
+	return fmt.Sprintf("gui/%d", os.Getuid())
+}
+
+func install(binary string, args []string) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	path, err := plistPath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists, uninstall first", path)
+	}
+	logDir := filepath.Join(home, "Library", "Logs")
+	bs, err := launchdPlist(binary, args, home, logDir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, bs, 0o644); err != nil {
+		return err
+	}
+	// Loading the agent starts it, as it runs at load (i.e. login).
+	return launchctl("bootstrap", domain(), path)
+}
+
+func uninstall() error {
+	path, err := plistPath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	// Not being loaded is fine.
+	_ = launchctl("bootout", domain()+"/"+launchdName)
+	return os.Remove(path)
+}
+
+func start() error {
+	return launchctl("kickstart", domain()+"/"+launchdName)
+}
+
+func stop() error {
+	// Syncthing exits successfully on SIGTERM, so it isn't kept alive.
+	return launchctl("kill", "SIGTERM", domain()+"/"+launchdName)
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package service
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestLaunchdPlist(t *testing.T) {
+	args := []string{"serve", "--config", "/Users/a&b/config"}
+	bs, err := launchdPlist("/Applications/Syncthing.app/syncthing", args, "/Users/a&b", "/Users/a&b/Library/Logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var plist struct {
+		Dict struct {
+			Keys    []string `xml:"key"`
+			Strings []string `xml:"string"`
+			Array   struct {
+				Strings []string `xml:"string"`
+			} `xml:"array"`
+		} `xml:"dict"`
+	}
+	if err := xml.Unmarshal(bs, &plist); err != nil {
+		t.Fatalf("invalid plist: %v\n%s", err, bs)
+	}
+	expArgs := append([]string{"/Applications/Syncthing.app/syncthing"}, args...)
+	if !reflect.DeepEqual(plist.Dict.Array.Strings, expArgs) {
+		t.Errorf("expected program arguments %v, got %v", expArgs, plist.Dict.Array.Strings)
+	}
+	if plist.Dict.Strings[0] != launchdName {
+		t.Errorf("expected label %v, got %v", launchdName, plist.Dict.Strings[0])
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build !windows
+// +build !windows
+
+package service
+
+// Notify does nothing, as launchd needs no notification.
+func Notify(func()) func(exitCode int) {
+	return func(int) {}
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package service
+
+import (
+	"golang.org/x/sys/windows/svc"
+)
+
+// Notify tells the service manager that Syncthing is running, when
+// started as a Windows service, and calls stop when asked to stop. The
+// returned function must be called with the exit code once Syncthing
+// stopped.
+func Notify(stop func()) func(exitCode int) {
+	if ok, err := svc.IsWindowsService(); err != nil || !ok {
+		return func(int) {}
+	}
+	h := &handler{
+		stop:     stop,
+		exitCode: make(chan int),
+	}
+	done := make(chan struct{})
+	go func() {
+		_ = svc.Run(serviceName, h)
+		close(done)
+	}()
+	return func(exitCode int) {
+		select {
+		case h.exitCode <- exitCode:
+			<-done
+		case <-done:
+		}
+	}
+}
+
+type handler struct {
+	stop     func()
+	exitCode chan int
+}
+
+func (h *handler) Execute(_ []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	changes <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+	for {
+		select {
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				changes <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				changes <- svc.Status{State: svc.StopPending}
+				go h.stop()
+			}
+		case exitCode := <-h.exitCode:
+			// A non-zero exit code makes the service manager apply the
+			// recovery actions, i.e. restart.
+			return exitCode != 0, uint32(exitCode)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+func withService(fn func(s *mgr.Service) error) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+	s, err := m.OpenService(serviceName)
+	if err != nil {
+		return fmt.Errorf("service %s: %w", serviceName, err)
+	}
+	defer s.Close()
+	return fn(s)
+}
+
+func install(binary string, args []string) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	if s, err := m.OpenService(serviceName); err == nil {
+		s.Close()
+		return fmt.Errorf("service %s already exists, uninstall first", serviceName)
+	}
+	s, err := m.CreateService(serviceName, binary, mgr.Config{
+		DisplayName:      displayName,
+		Description:      description,
+		StartType:        mgr.StartAutomatic,
+		DelayedAutoStart: true,
+	}, args...)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	// Restart when it exits unsuccessfully, which includes restarts
+	// requested from the GUI, but not when shut down from the GUI.
+	actions := []mgr.RecoveryAction{
+		{Type: mgr.ServiceRestart, Delay: 5 * time.Second},
+		{Type: mgr.ServiceRestart, Delay: 10 * time.Second},
+		{Type: mgr.ServiceRestart, Delay: time.Minute},
+	}
+	if err := s.SetRecoveryActions(actions, uint32((24 * time.Hour).Seconds())); err != nil {
+		s.Delete()
+		return err
+	}
+	if err := s.SetRecoveryActionsOnNonCrashFailures(true); err != nil {
+		s.Delete()
+		return err
+	}
+	return s.Start()
+}
+
+func uninstall() error {
+	return withService(func(s *mgr.Service) error {
+		if status, err := s.Query(); err == nil && status.State != svc.Stopped {
+			if err := stopService(s); err != nil {
+				return err
+			}
+		}
+		return s.Delete()
+	})
+}
+
+func start() error {
+	return withService(func(s *mgr.Service) error {
+		return s.Start()
+	})
+}
+
+func stop() error {
+	return withService(stopService)
+}
+
+func stopService(s *mgr.Service) error {
+	status, err := s.Control(svc.Stop)
+	if err != nil {
+		return err
+	}
+	timeout := time.Now().Add(30 * time.Second)
+	for status.State != svc.Stopped {
+		if time.Now().After(timeout) {
+			return fmt.Errorf("service %s did not stop in time", serviceName)
+		}
+		time.Sleep(300 * time.Millisecond)
+		if status, err = s.Query(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
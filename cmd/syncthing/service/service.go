@@ -0,0 +1,94 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package service implements the `syncthing service` subcommands, which
+// register Syncthing as a Windows service or a launchd agent on macOS.
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/syncthing/syncthing/cmd/syncthing/cmdutil"
+	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/logger"
+)
+
+const (
+	serviceName = "syncthing"
+	displayName = "Syncthing"
+	description = "Syncthing continuous file synchronization"
+	launchdName = "net.syncthing.syncthing"
+)
+
+type CLI struct {
+	Install   installCmd   `cmd:"" help:"Register Syncthing to run in the background"`
+	Uninstall uninstallCmd `cmd:"" help:"Stop Syncthing and remove the registration"`
+	Start     startCmd     `cmd:"" help:"Start the registered Syncthing"`
+	Stop      stopCmd      `cmd:"" help:"Stop the registered Syncthing"`
+}
+
+type installCmd struct {
+	ConfDir string `name:"config" placeholder:"PATH" env:"STCONFDIR" help:"Set configuration directory (config and keys)"`
+	DataDir string `name:"data" placeholder:"PATH" env:"STDATADIR" help:"Set data directory (database and logs)"`
+	HomeDir string `name:"home" placeholder:"PATH" env:"STHOMEDIR" help:"Set configuration and data directory"`
+}
+
+func (c *installCmd) Run(l logger.Logger) error {
+	// The service may run as another user, or with another environment,
+	// so it's told explicitly where to find its config and data.
+	if err := cmdutil.SetConfigDataLocationsFromFlags(c.HomeDir, c.ConfDir, c.DataDir); err != nil {
+		return err
+	}
+	binary, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if binary, err = filepath.Abs(binary); err != nil {
+		return err
+	}
+	args := []string{
+		"serve",
+		"--no-browser",
+		"--no-restart",
+		"--config", locations.GetBaseDir(locations.ConfigBaseDir),
+		"--data", locations.GetBaseDir(locations.DataBaseDir),
+	}
+	if err := install(binary, args); err != nil {
+		return fmt.Errorf("installing service: %w", err)
+	}
+	l.Infoln("Installed Syncthing to run in the background")
+	return nil
+}
+
+type uninstallCmd struct{}
+
+func (uninstallCmd) Run(l logger.Logger) error {
+	if err := uninstall(); err != nil {
+		return fmt.Errorf("uninstalling service: %w", err)
+	}
+	l.Infoln("Uninstalled Syncthing")
+	return nil
+}
+
+type startCmd struct{}
+
+func (startCmd) Run() error {
+	if err := start(); err != nil {
+		return fmt.Errorf("starting service: %w", err)
+	}
+	return nil
+}
+
+type stopCmd struct{}
+
+func (stopCmd) Run() error {
+	if err := stop(); err != nil {
+		return fmt.Errorf("stopping service: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build !windows && !darwin
+// +build !windows,!darwin
+
+package service
+
+import "errors"
+
+var errUnsupported = errors.New("not supported on this platform, see the service definitions in the etc directory of the source instead")
+
+func install(string, []string) error {
+	return errUnsupported
+}
+
+func uninstall() error {
+	return errUnsupported
+}
+
+func start() error {
+	return errUnsupported
+}
+
+func stop() error {
+	return errUnsupported
+}
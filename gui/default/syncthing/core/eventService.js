@@ -65,6 +65,23 @@ angular.module('syncthing.core')
             DEVICE_PAUSED: 'DevicePaused',   // Emitted when a device has been paused
             DEVICE_RESUMED: 'DeviceResumed',   // Emitted when a device has been resumed
             CLUSTER_CONFIG_RECEIVED: 'ClusterConfigReceived',   // Emitted when receiving a remote device's cluster config
+            CORRUPTION_DETECTED: 'CorruptionDetected',   // Emitted when a folder scrub finds local data that doesn't match the database
+            EXPIRY_WARNING: 'ExpiryWarning',   // Emitted ahead of a device or folder share expiring
+            CONFLICT_CREATED: 'ConflictCreated',   // Emitted when a conflict copy of a file has been created
+            CONFLICT_RESOLVED: 'ConflictResolved',   // Emitted when a conflict copy has been removed locally
+            FOLDER_REVERTED: 'FolderReverted',   // Emitted when the local changes of a receive only folder have been reverted
+            PREALLOCATION_FAILED: 'PreallocationFailed',   // Emitted when space for a temporary file could not be allocated as configured
+            HEALTH_REPORT: 'HealthReport',   // Emitted at startup with the problems found by the self-check and how to fix them
+            CERTIFICATE_EXPIRING: 'CertificateExpiring',   // Emitted ahead of the device or GUI certificate expiring
+            ITEM_CACHE_WARMED: 'ItemCacheWarmed',   // Emitted when a pulled file has been read into the cache
+            DIRECTORY_COMPLETED: 'DirectoryCompleted',   // Emitted when a completion directory of a folder has been fully pulled
+            FOLDER_CLEANUP_SCHEDULED: 'FolderCleanupScheduled',   // Emitted when the data of a removed folder is due to be archived or deleted
+            FOLDER_CLEANUP_DONE: 'FolderCleanupDone',   // Emitted when the data of a removed folder has been archived or deleted, or that was cancelled or failed
+            FOLDER_MOVE_PROGRESS: 'FolderMoveProgress',   // Emitted periodically while folders are moved to new paths
+            FOLDER_MOVE_DONE: 'FolderMoveDone',   // Emitted when moving folders to new paths has finished, failed or was aborted
+            FOLDER_TYPE_DEGRADED: 'FolderTypeDegraded',   // Emitted when a folder acts as send only, as its filesystem is read-only
+            FOLDER_TYPE_RESTORED: 'FolderTypeRestored',   // Emitted when a degraded folder acts as its configured type again
+            FOLDER_SCAN_SUMMARY: 'FolderScanSummary',   // Emitted after each scan with the counts and sample paths of the added, changed and deleted items
             DOWNLOAD_PROGRESS: 'DownloadProgress',   // Emitted during file downloads for each folder for each file
             FAILURE: 'Failure',   // Specific errors sent to the usage reporting server for diagnosis
             FOLDER_COMPLETION: 'FolderCompletion',   //Emitted when the local or remote contents for a folder changes
@@ -1409,6 +1409,7 @@ angular.module('syncthing.core')
                     textArea.off("scroll", $scope.logging.onScroll);
                     $scope.logging.timer = null;
                     $scope.logging.entries = [];
+                    $scope.logging.token = 0;
                 });
             },
             onFacilityChange: function (facility) {
@@ -1447,15 +1448,11 @@ angular.module('syncthing.core')
                     return;
                 }
 
-                var last = null;
-                if ($scope.logging.entries.length > 0) {
-                    last = $scope.logging.entries[$scope.logging.entries.length - 1].when;
-                }
-
-                $http.get(urlbase + '/system/log' + (last ? '?since=' + encodeURIComponent(last) : '')).success(function (data) {
+                $http.get(urlbase + '/system/log?since=' + ($scope.logging.token || 0)).success(function (data) {
                     if (!$scope.logging.timer) return;
                     $scope.logging.timer = $timeout($scope.logging.fetch, 2000);
                     if (!$scope.logging.paused) {
+                        $scope.logging.token = data.token;
                         if (data.messages) {
                             $scope.logging.entries.push.apply($scope.logging.entries, data.messages);
                             // Wait for the text area to be redrawn, adding new lines, and then scroll to bottom.
@@ -1952,6 +1949,17 @@ angular.module('syncthing.core')
             $http.post(urlbase + '/system/error/clear');
         };
 
+        $scope.approveMetered = function (deviceID) {
+            $http.post(urlbase + '/system/metered/approve?device=' + encodeURIComponent(deviceID))
+                .success(refreshConnectionStats)
+                .error($scope.emitHTTPError);
+        };
+
+        $scope.resolveEmptied = function (folder, repull) {
+            $http.post(urlbase + '/folder/emptied?folder=' + encodeURIComponent(folder) + '&action=' + (repull ? 'repull' : 'delete'))
+                .error($scope.emitHTTPError);
+        };
+
         $scope.fsWatcherErrorMap = function () {
             var errs = {}
             $.each($scope.folders, function (id, cfg) {
@@ -36,11 +36,14 @@ import (
 	"github.com/rcrowley/go-metrics"
 	"github.com/thejerf/suture/v4"
 	"github.com/vitrun/qart/qr"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
 	"golang.org/x/text/runes"
 	"golang.org/x/text/transform"
 	"golang.org/x/text/unicode/norm"
 
 	"github.com/syncthing/syncthing/lib/build"
+	"github.com/syncthing/syncthing/lib/chaos"
 	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/connections"
 	"github.com/syncthing/syncthing/lib/db"
@@ -48,16 +51,22 @@ import (
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/fs"
 	"github.com/syncthing/syncthing/lib/ignore"
+	"github.com/syncthing/syncthing/lib/jobs"
 	"github.com/syncthing/syncthing/lib/locations"
 	"github.com/syncthing/syncthing/lib/logger"
+	"github.com/syncthing/syncthing/lib/messages"
 	"github.com/syncthing/syncthing/lib/model"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/rand"
+	"github.com/syncthing/syncthing/lib/selfcheck"
+	"github.com/syncthing/syncthing/lib/stats"
 	"github.com/syncthing/syncthing/lib/svcutil"
 	"github.com/syncthing/syncthing/lib/sync"
 	"github.com/syncthing/syncthing/lib/tlsutil"
 	"github.com/syncthing/syncthing/lib/upgrade"
 	"github.com/syncthing/syncthing/lib/ur"
+	"github.com/syncthing/syncthing/lib/warnings"
+	"github.com/syncthing/syncthing/lib/webhook"
 )
 
 const (
@@ -68,8 +77,12 @@ const (
 	EventSubBufferSize    = 1000
 	defaultEventTimeout   = time.Minute
 	httpsCertLifetimeDays = 820
+	certExpiryWarningTime = 30 * 24 * time.Hour
 )
 
+// How often the HTTPS certificate is checked for upcoming expiry.
+var certCheckInterval = 24 * time.Hour
+
 type service struct {
 	suture.Service
 
@@ -83,14 +96,22 @@ type service struct {
 	discoverer           discover.Manager
 	connectionsService   connections.Service
 	fss                  model.FolderSummaryService
+	webhooks             webhook.Service
+	warnings             warnings.Service
+	jobs                 jobs.Scheduler
+	selfCheck            selfcheck.Report
 	urService            *ur.Service
 	noUpgrade            bool
+	profile              *locations.Profile // nil unless serving a profile
 	tlsDefaultCommonName string
 	configChanged        chan struct{} // signals intentional listener close due to config change
 	started              chan string   // signals startup complete by sending the listener address, for testing only
 	startedOnce          chan struct{} // the service has started successfully at least once
 	startupErr           error
 	listenerAddr         net.Addr
+	httpsCert            tls.Certificate   // the certificate of the current listener
+	acme                 *autocert.Manager // obtains certificates for the current listener, when ACME is enabled
+	certWarned           time.Time         // expiry of the HTTPS certificate we last warned about
 	exitChan             chan *svcutil.FatalErr
 
 	guiErrors logger.Recorder
@@ -105,7 +126,7 @@ type Service interface {
 	WaitForStart() error
 }
 
-func New(id protocol.DeviceID, cfg config.Wrapper, assetDir, tlsDefaultCommonName string, m model.Model, defaultSub, diskSub events.BufferedSubscription, evLogger events.Logger, discoverer discover.Manager, connectionsService connections.Service, urService *ur.Service, fss model.FolderSummaryService, errors, systemLog logger.Recorder, noUpgrade bool) Service {
+func New(id protocol.DeviceID, cfg config.Wrapper, assetDir, tlsDefaultCommonName string, m model.Model, defaultSub, diskSub events.BufferedSubscription, evLogger events.Logger, discoverer discover.Manager, connectionsService connections.Service, urService *ur.Service, fss model.FolderSummaryService, webhooks webhook.Service, warnings warnings.Service, scheduler jobs.Scheduler, selfCheck selfcheck.Report, errors, systemLog logger.Recorder, noUpgrade bool, profile *locations.Profile) Service {
 	return &service{
 		id:      id,
 		cfg:     cfg,
@@ -120,10 +141,15 @@ func New(id protocol.DeviceID, cfg config.Wrapper, assetDir, tlsDefaultCommonNam
 		discoverer:           discoverer,
 		connectionsService:   connectionsService,
 		fss:                  fss,
+		webhooks:             webhooks,
+		warnings:             warnings,
+		jobs:                 scheduler,
+		selfCheck:            selfCheck,
 		urService:            urService,
 		guiErrors:            errors,
 		systemLog:            systemLog,
 		noUpgrade:            noUpgrade,
+		profile:              profile,
 		tlsDefaultCommonName: tlsDefaultCommonName,
 		configChanged:        make(chan struct{}),
 		startedOnce:          make(chan struct{}),
@@ -137,8 +163,8 @@ func (s *service) WaitForStart() error {
 }
 
 func (s *service) getListener(guiCfg config.GUIConfiguration) (net.Listener, error) {
-	httpsCertFile := locations.Get(locations.HTTPSCertFile)
-	httpsKeyFile := locations.Get(locations.HTTPSKeyFile)
+	httpsCertFile := s.profile.Get(locations.HTTPSCertFile)
+	httpsKeyFile := s.profile.Get(locations.HTTPSKeyFile)
 	cert, err := tls.LoadX509KeyPair(httpsCertFile, httpsKeyFile)
 
 	// If the certificate has expired or will expire in the next month, fail
@@ -167,9 +193,25 @@ func (s *service) getListener(guiCfg config.GUIConfiguration) (net.Listener, err
 	if err != nil {
 		return nil, err
 	}
+	s.httpsCert = cert
 	tlsCfg := tlsutil.SecureDefaultWithTLS12()
 	tlsCfg.Certificates = []tls.Certificate{cert}
 
+	s.acme = nil
+	if guiCfg.UseACME() {
+		s.acme = newACMEManager(guiCfg, s.profile.Get(locations.ACMECache))
+		// The self signed certificate remains in use for connections
+		// that don't ask for the configured domain, such as over
+		// localhost.
+		tlsCfg.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			if !strings.EqualFold(hello.ServerName, guiCfg.ACMEDomain) {
+				return &cert, nil
+			}
+			return s.acme.GetCertificate(hello)
+		}
+		tlsCfg.NextProtos = append(tlsCfg.NextProtos, acme.ALPNProto)
+	}
+
 	if guiCfg.Network() == "unix" {
 		// When listening on a UNIX socket we should unlink before bind,
 		// lest we get a "bind: address already in use". We don't
@@ -245,62 +287,115 @@ func (s *service) Serve(ctx context.Context) error {
 	restMux := httprouter.New()
 
 	// The GET handlers
+	restMux.HandlerFunc(http.MethodGet, "/rest/cluster/folders", s.getClusterFolders)         // [id] [search]
 	restMux.HandlerFunc(http.MethodGet, "/rest/cluster/pending/devices", s.getPendingDevices) // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/cluster/pending/folders", s.getPendingFolders) // [device]
+	restMux.HandlerFunc(http.MethodGet, "/rest/cluster/restarts", s.getClusterRestarts)       // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/controller/devices", s.getControllerDevices)   // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/completion", s.getDBCompletion)             // [device] [folder]
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/file", s.getDBFile)                         // folder file
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/ignores", s.getDBIgnores)                   // folder
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/need", s.getDBNeed)                         // folder [perpage] [page]
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/remoteneed", s.getDBRemoteNeed)             // device folder [perpage] [page]
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/localchanged", s.getDBLocalChanged)         // folder [perpage] [page]
+	restMux.HandlerFunc(http.MethodGet, "/rest/db/diff", s.getDBDiff)                         // device folder [perpage] [page]
+	restMux.HandlerFunc(http.MethodGet, "/rest/db/devices", s.getDBDevices)                   // folder
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/status", s.getDBStatus)                     // folder
 	restMux.HandlerFunc(http.MethodGet, "/rest/db/browse", s.getDBBrowse)                     // folder [prefix] [dirsonly] [levels]
+	restMux.HandlerFunc(http.MethodGet, "/rest/db/size", s.getDBSize)                         // [folder]
+	restMux.HandlerFunc(http.MethodGet, "/rest/db/blockpool", s.getDBBlockPool)               // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/folder/versions", s.getFolderVersions)         // folder
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/cleanups", s.getFolderCleanups)         // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/move", s.getFolderMove)                 // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/recycle", s.getFolderRecycle)           // folder [days]
 	restMux.HandlerFunc(http.MethodGet, "/rest/folder/errors", s.getFolderErrors)             // folder [perpage] [page]
 	restMux.HandlerFunc(http.MethodGet, "/rest/folder/pullerrors", s.getFolderErrors)         // folder (deprecated)
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/quarantine", s.getFolderQuarantine)     // folder [perpage] [page]
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/scrub", s.getFolderScrub)               // folder
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/freeze", s.getFolderFreeze)             // folder
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/held", s.getFolderHeld)                 // folder
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/snapshot", s.getFolderSnapshot)         // id [perpage] [page]
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/tuning", s.getFolderTuning)             // folder
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/scans", s.getFolderScans)               // folder
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/capabilities", s.getFolderCaps)         // folder
+	restMux.HandlerFunc(http.MethodGet, "/rest/folder/plaintext", s.getFolderPlaintext)       // folder file
 	restMux.HandlerFunc(http.MethodGet, "/rest/events", s.getIndexEvents)                     // [since] [limit] [timeout] [events]
 	restMux.HandlerFunc(http.MethodGet, "/rest/events/disk", s.getDiskEvents)                 // [since] [limit] [timeout]
 	restMux.HandlerFunc(http.MethodGet, "/rest/noauth/health", s.getHealth)                   // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/stats/device", s.getDeviceStats)               // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/stats/folder", s.getFolderStats)               // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/stats/transfers", s.getTransferStats)          // [from] [to] [format]
 	restMux.HandlerFunc(http.MethodGet, "/rest/svc/deviceid", s.getDeviceID)                  // id
 	restMux.HandlerFunc(http.MethodGet, "/rest/svc/lang", s.getLang)                          // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/svc/pairing", s.getPairing)                    // [device]
 	restMux.HandlerFunc(http.MethodGet, "/rest/svc/report", s.getReport)                      // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/svc/random/string", s.getRandomString)         // [length]
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/browse", s.getSystemBrowse)             // current
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/connections", s.getSystemConnections)   // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/system/connections/attempts", s.getDialLog)    // [device]
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/discovery", s.getSystemDiscovery)       // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/error", s.getSystemError)               // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/paths", s.getSystemPaths)               // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/ping", s.restPing)                      // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/system/selfcheck", s.getSystemSelfCheck)       // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/system/standby", s.getStandby)                 // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/status", s.getSystemStatus)             // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/upgrade", s.getSystemUpgrade)           // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/version", s.getSystemVersion)           // -
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/debug", s.getSystemDebug)               // -
-	restMux.HandlerFunc(http.MethodGet, "/rest/system/log", s.getSystemLog)                   // [since]
+	restMux.HandlerFunc(http.MethodGet, "/rest/system/log", s.getSystemLog)                   // [since] [level] [facility] [stream]
 	restMux.HandlerFunc(http.MethodGet, "/rest/system/log.txt", s.getSystemLogTxt)            // [since]
+	restMux.HandlerFunc(http.MethodGet, "/rest/system/log/levels", s.getSystemLogLevels)      // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/system/messages", s.getSystemMessages)         // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/system/webhooks", s.getSystemWebhooks)         // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/system/warnings", s.getSystemWarnings)         // -
+	restMux.HandlerFunc(http.MethodGet, "/rest/system/jobs", s.getSystemJobs)                 // -
 
 	// The POST handlers
+	restMux.HandlerFunc(http.MethodPost, "/rest/controller/template", s.postControllerTemplate)  // [device] <body>
+	restMux.HandlerFunc(http.MethodPost, "/rest/controller/revoke", s.postControllerRevoke)      // device [wipe]
 	restMux.HandlerFunc(http.MethodPost, "/rest/db/prio", s.postDBPrio)                          // folder file
 	restMux.HandlerFunc(http.MethodPost, "/rest/db/ignores", s.postDBIgnores)                    // folder
 	restMux.HandlerFunc(http.MethodPost, "/rest/db/override", s.postDBOverride)                  // folder
 	restMux.HandlerFunc(http.MethodPost, "/rest/db/revert", s.postDBRevert)                      // folder
 	restMux.HandlerFunc(http.MethodPost, "/rest/db/scan", s.postDBScan)                          // folder [sub...] [delay]
+	restMux.HandlerFunc(http.MethodPost, "/rest/db/blockpool/gc", s.postDBBlockPoolGC)           // -
+	restMux.HandlerFunc(http.MethodPost, "/rest/db/blockpool/scrub", s.postDBBlockPoolScrub)     // -
 	restMux.HandlerFunc(http.MethodPost, "/rest/folder/versions", s.postFolderVersionsRestore)   // folder <body>
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/recycle/restore", s.postRecycleRestore)   // folder <body>
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/recycle/purge", s.postRecyclePurge)       // folder <body>
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/quarantine", s.postFolderQuarantine)      // folder [file...]
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/move", s.postFolderMove)                  // [removesource] <body>
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/scrub", s.postFolderScrub)                // folder
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/freeze", s.postFolderFreeze)              // folder
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/unfreeze", s.postFolderUnfreeze)          // folder
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/hold", s.postFolderHold)                  // folder file...
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/release", s.postFolderRelease)            // folder [file...]
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/snapshot", s.postFolderSnapshot)          // folder [ttl]
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/emptied", s.postFolderEmptied)            // folder action
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/unlock", s.postFolderUnlock)              // folder <body>
+	restMux.HandlerFunc(http.MethodPost, "/rest/folder/lock", s.postFolderLock)                  // folder
 	restMux.HandlerFunc(http.MethodPost, "/rest/system/error", s.postSystemError)                // <body>
 	restMux.HandlerFunc(http.MethodPost, "/rest/system/error/clear", s.postSystemErrorClear)     // -
 	restMux.HandlerFunc(http.MethodPost, "/rest/system/ping", s.restPing)                        // -
 	restMux.HandlerFunc(http.MethodPost, "/rest/system/reset", s.postSystemReset)                // [folder]
-	restMux.HandlerFunc(http.MethodPost, "/rest/system/restart", s.postSystemRestart)            // -
+	restMux.HandlerFunc(http.MethodPost, "/rest/system/restart", s.postSystemRestart)            // [coordinated]
 	restMux.HandlerFunc(http.MethodPost, "/rest/system/shutdown", s.postSystemShutdown)          // -
+	restMux.HandlerFunc(http.MethodPost, "/rest/system/standby/failback", s.postFailBack)        // -
 	restMux.HandlerFunc(http.MethodPost, "/rest/system/upgrade", s.postSystemUpgrade)            // -
 	restMux.HandlerFunc(http.MethodPost, "/rest/system/pause", s.makeDevicePauseHandler(true))   // [device]
 	restMux.HandlerFunc(http.MethodPost, "/rest/system/resume", s.makeDevicePauseHandler(false)) // [device]
-	restMux.HandlerFunc(http.MethodPost, "/rest/system/debug", s.postSystemDebug)                // [enable] [disable]
+	restMux.HandlerFunc(http.MethodPost, "/rest/system/debug", s.postSystemDebug)                // [enable] [disable] [duration]
+	restMux.HandlerFunc(http.MethodPost, "/rest/system/log/levels", s.postSystemLogLevels)       // facility [level]
+	restMux.HandlerFunc(http.MethodPost, "/rest/system/metered/approve", s.postMeteredApprove)   // device
 
 	// The DELETE handlers
 	restMux.HandlerFunc(http.MethodDelete, "/rest/cluster/pending/devices", s.deletePendingDevices) // device
 	restMux.HandlerFunc(http.MethodDelete, "/rest/cluster/pending/folders", s.deletePendingFolders) // folder [device]
+	restMux.HandlerFunc(http.MethodDelete, "/rest/folder/cleanups", s.deleteFolderCleanups)         // folder
+	restMux.HandlerFunc(http.MethodDelete, "/rest/folder/move", s.deleteFolderMove)                 // -
+	restMux.HandlerFunc(http.MethodDelete, "/rest/folder/snapshot", s.deleteFolderSnapshot)         // id
+	restMux.HandlerFunc(http.MethodDelete, "/rest/db/devices", s.deleteDBDevices)                   // folder device [compact]
 
 	// Config endpoints
 
@@ -313,6 +408,7 @@ func (s *service) Serve(ctx context.Context) error {
 	configBuilder.registerConfig("/rest/config")
 	configBuilder.registerConfigInsync("/rest/config/insync") // deprecated
 	configBuilder.registerConfigRequiresRestart("/rest/config/restart-required")
+	configBuilder.registerConfigHistory("/rest/config/history")
 	configBuilder.registerFolders("/rest/config/folders")
 	configBuilder.registerDevices("/rest/config/devices")
 	configBuilder.registerFolder("/rest/config/folders/:id")
@@ -336,7 +432,9 @@ func (s *service) Serve(ctx context.Context) error {
 	debugMux.HandleFunc("/rest/debug/heapprof", s.getHeapProf)
 	debugMux.HandleFunc("/rest/debug/support", s.getSupportBundle)
 	debugMux.HandleFunc("/rest/debug/file", s.getDebugFile)
+	debugMux.HandleFunc("/rest/debug/chaos", s.getDebugChaos)
 	restMux.Handler(http.MethodGet, "/rest/debug/*method", s.whenDebugging(debugMux))
+	restMux.Handler(http.MethodPost, "/rest/debug/chaos", s.whenDebugging(http.HandlerFunc(s.postDebugChaos))) // <body>
 
 	// A handler that disables caching
 	noCacheRestMux := noCacheMiddleware(metricsMiddleware(restMux))
@@ -365,7 +463,7 @@ func (s *service) Serve(ctx context.Context) error {
 
 	// Wrap everything in CSRF protection. The /rest prefix should be
 	// protected, other requests will grant cookies.
-	var handler http.Handler = newCsrfManager(s.id.String()[:5], "/rest", guiCfg, mux, locations.Get(locations.CsrfTokens))
+	var handler http.Handler = newCsrfManager(s.id.String()[:5], "/rest", guiCfg, mux, s.profile.Get(locations.CsrfTokens))
 
 	// Add our version and ID as a header to responses
 	handler = withDetailsMiddleware(s.id, handler)
@@ -390,6 +488,19 @@ func (s *service) Serve(ctx context.Context) error {
 
 	handler = debugMiddleware(handler)
 
+	// Take the client address and protocol from trusted reverse proxies
+	if trustedProxies, err := parseTrustedProxies(guiCfg.TrustedProxies); err != nil {
+		l.Warnln("GUI/API:", err)
+	} else if len(trustedProxies) > 0 {
+		handler = forwardedMiddleware(trustedProxies, handler)
+	}
+
+	// Serve under a subpath, e.g. when sharing a host name with other
+	// services behind a reverse proxy
+	if prefix := guiCfg.PathPrefix(); prefix != "" {
+		handler = pathPrefixMiddleware(prefix, handler)
+	}
+
 	srv := http.Server{
 		Handler: handler,
 		// ReadTimeout must be longer than SyncthingController $scope.refresh
@@ -428,20 +539,53 @@ func (s *service) Serve(ctx context.Context) error {
 		}
 	}()
 
-	// Wait for stop, restart or error signals
+	// Answer ACME HTTP-01 challenges, if requested. TLS-ALPN-01 challenges
+	// are handled by the main listener.
+
+	if s.acme != nil && guiCfg.ACMEHTTPAddress != "" {
+		challengeSrv := &http.Server{
+			Addr:        guiCfg.ACMEHTTPAddress,
+			Handler:     s.acme.HTTPHandler(nil),
+			ReadTimeout: 15 * time.Second,
+			ErrorLog:    log.New(io.Discard, "", 0),
+		}
+		go func() {
+			if err := challengeSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				l.Warnln("Serving ACME challenges:", err)
+			}
+		}()
+		defer challengeSrv.Close()
+	}
+
+	// Wait for stop, restart or error signals, while keeping an eye on
+	// the HTTPS certificate.
+
+	s.checkCertificate()
+	certCheck := time.NewTicker(certCheckInterval)
+	defer certCheck.Stop()
 
 	err = nil
-	select {
-	case <-ctx.Done():
-		// Shutting down permanently
-		l.Debugln("shutting down (stop)")
-	case <-s.configChanged:
-		// Soft restart due to configuration change
-		l.Debugln("restarting (config changed)")
-	case err = <-s.exitChan:
-	case err = <-serveError:
-		// Restart due to listen/serve failure
-		l.Warnln("GUI/API:", err, "(restarting)")
+wait:
+	for {
+		select {
+		case <-ctx.Done():
+			// Shutting down permanently
+			l.Debugln("shutting down (stop)")
+		case <-s.configChanged:
+			// Soft restart due to configuration change
+			l.Debugln("restarting (config changed)")
+		case <-certCheck.C:
+			if !s.checkCertificate() {
+				continue
+			}
+			// Restart to have a new certificate generated
+			l.Infoln("Restarting GUI/API to renew the HTTPS certificate")
+		case err = <-s.exitChan:
+		case err = <-serveError:
+			// Restart due to listen/serve failure
+			l.Warnln("GUI/API:", err, "(restarting)")
+		}
+		break wait
 	}
 	// Give it a moment to shut down gracefully, e.g. if we are restarting
 	// due to a config change through the API, let that finish successfully.
@@ -470,6 +614,9 @@ func (s *service) String() string {
 }
 
 func (*service) VerifyConfiguration(_, to config.Configuration) error {
+	if _, err := parseTrustedProxies(to.GUI.TrustedProxies); err != nil {
+		return err
+	}
 	if to.GUI.Network() != "tcp" {
 		return nil
 	}
@@ -481,7 +628,7 @@ func (s *service) CommitConfiguration(from, to config.Configuration) bool {
 	// No action required when this changes, so mask the fact that it changed at all.
 	from.GUI.Debugging = to.GUI.Debugging
 
-	if to.GUI == from.GUI {
+	if reflect.DeepEqual(to.GUI, from.GUI) {
 		// No GUI changes, we're done here.
 		return true
 	}
@@ -595,16 +742,117 @@ func metricsMiddleware(h http.Handler) http.Handler {
 func redirectToHTTPSMiddleware(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.TLS == nil {
-			// Redirect HTTP requests to HTTPS
-			r.URL.Host = r.Host
-			r.URL.Scheme = "https"
-			http.Redirect(w, r, r.URL.String(), http.StatusTemporaryRedirect)
+			// Redirect HTTP requests to HTTPS. The request URI is used
+			// as-is, as the path may have had a prefix stripped.
+			http.Redirect(w, r, "https://"+r.Host+r.RequestURI, http.StatusTemporaryRedirect)
 		} else {
 			h.ServeHTTP(w, r)
 		}
 	})
 }
 
+// pathPrefixMiddleware serves the GUI and API under the given path prefix,
+// redirecting the bare prefix to the GUI.
+func pathPrefixMiddleware(prefix string, h http.Handler) http.Handler {
+	stripped := http.StripPrefix(prefix, h)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == prefix:
+			http.Redirect(w, r, prefix+"/", http.StatusMovedPermanently)
+		case strings.HasPrefix(r.URL.Path, prefix+"/"):
+			stripped.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// The headers set by reverse proxies to describe the original request.
+var forwardedHeaders = []string{"Forwarded", "X-Forwarded-For", "X-Forwarded-Host", "X-Forwarded-Proto"}
+
+// forwardedMiddleware takes the client address from the X-Forwarded-For
+// header on requests from trusted proxies. On other requests the forwarding
+// headers are removed, as they could be forged.
+func forwardedMiddleware(trusted []*net.IPNet, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !ipIsTrusted(hostIP(r.RemoteAddr), trusted) {
+			for _, hdr := range forwardedHeaders {
+				r.Header.Del(hdr)
+			}
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		// The addresses are appended by each proxy on the way, so the
+		// client is the last one not added by a trusted proxy.
+		var addrs []string
+		for _, val := range r.Header.Values("X-Forwarded-For") {
+			addrs = append(addrs, strings.Split(val, ",")...)
+		}
+		for i := len(addrs) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(addrs[i]))
+			if ip == nil {
+				break
+			}
+			r.RemoteAddr = ip.String()
+			if !ipIsTrusted(ip, trusted) {
+				break
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// parseTrustedProxies parses a list of IP addresses and networks in CIDR
+// notation.
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+			continue
+		}
+		_, ipnet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		nets = append(nets, ipnet)
+	}
+	return nets, nil
+}
+
+func ipIsTrusted(ip net.IP, trusted []*net.IPNet) bool {
+	if ip == nil {
+		return false
+	}
+	for _, ipnet := range trusted {
+		if ipnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostIP returns the IP address of a host:port address, or nil.
+func hostIP(addr string) net.IP {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	return net.ParseIP(host)
+}
+
 func noCacheMiddleware(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Cache-Control", "max-age=0, no-cache, no-store")
@@ -644,6 +892,15 @@ func (s *service) whenDebugging(h http.Handler) http.Handler {
 	})
 }
 
+func (s *service) getClusterFolders(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	folders := s.model.ClusterFolders(qs.Get("id"), qs.Get("search"))
+	if folders == nil {
+		folders = []model.AdvertisedFolder{}
+	}
+	sendJSON(w, folders)
+}
+
 func (s *service) getPendingDevices(w http.ResponseWriter, _ *http.Request) {
 	devices, err := s.model.PendingDevices()
 	if err != nil {
@@ -702,12 +959,92 @@ func (s *service) deletePendingFolders(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (s *service) getControllerDevices(w http.ResponseWriter, _ *http.Request) {
+	sendJSON(w, s.model.ManagedDevices())
+}
+
+// postControllerTemplate pushes the configuration template in the body to
+// the given managed device, or to all of them, and returns the outcome per
+// device.
+func (s *service) postControllerTemplate(w http.ResponseWriter, r *http.Request) {
+	var tmpl model.ControlTemplate
+	if err := unmarshalTo(r.Body, &tmpl); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var devices []protocol.DeviceID
+	if device := r.URL.Query().Get("device"); device != "" {
+		deviceID, err := protocol.DeviceIDFromString(device)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		devices = append(devices, deviceID)
+	} else {
+		for deviceID := range s.model.ManagedDevices() {
+			devices = append(devices, deviceID)
+		}
+	}
+
+	errs := make(map[string]error, len(devices))
+	mut := sync.NewMutex()
+	wg := sync.NewWaitGroup()
+	for _, deviceID := range devices {
+		wg.Add(1)
+		go func(deviceID protocol.DeviceID) {
+			defer wg.Done()
+			err := s.model.PushControlTemplate(r.Context(), deviceID, tmpl)
+			mut.Lock()
+			errs[deviceID.String()] = err
+			mut.Unlock()
+		}(deviceID)
+	}
+	wg.Wait()
+	sendJSON(w, errorStringMap(errs))
+}
+
+// postControllerRevoke revokes the given device and returns the outcome of
+// passing the revocation on per managed device.
+func (s *service) postControllerRevoke(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	deviceID, err := protocol.DeviceIDFromString(qs.Get("device"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	wipe := qs.Get("wipe") == "true"
+
+	res, err := s.model.RevokeDevice(r.Context(), deviceID, wipe)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	errs := make(map[string]error, len(res))
+	for id, err := range res {
+		errs[id.String()] = err
+	}
+	sendJSON(w, errorStringMap(errs))
+}
+
+func (s *service) postMeteredApprove(w http.ResponseWriter, r *http.Request) {
+	deviceID, err := protocol.DeviceIDFromString(r.URL.Query().Get("device"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.model.ApproveMetered(deviceID); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+}
+
 func (*service) restPing(w http.ResponseWriter, _ *http.Request) {
 	sendJSON(w, map[string]string{"ping": "pong"})
 }
 
-func (*service) getSystemPaths(w http.ResponseWriter, _ *http.Request) {
-	sendJSON(w, locations.ListExpandedPaths())
+func (s *service) getSystemPaths(w http.ResponseWriter, _ *http.Request) {
+	sendJSON(w, s.profile.ListExpandedPaths())
 }
 
 func (s *service) getJSMetadata(w http.ResponseWriter, _ *http.Request) {
@@ -744,14 +1081,31 @@ func (*service) getSystemDebug(w http.ResponseWriter, _ *http.Request) {
 	sendJSON(w, map[string]interface{}{
 		"facilities": names,
 		"enabled":    enabled,
+		"expires":    l.DebugExpiries(),
 	})
 }
 
 func (*service) postSystemDebug(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	q := r.URL.Query()
+	var duration time.Duration
+	if str := q.Get("duration"); str != "" {
+		secs, err := strconv.Atoi(str)
+		if err != nil || secs <= 0 {
+			http.Error(w, "invalid duration", http.StatusBadRequest)
+			return
+		}
+		duration = time.Duration(secs) * time.Second
+	}
+	expiries := l.DebugExpiries()
 	for _, f := range strings.Split(q.Get("enable"), ",") {
-		if f == "" || l.ShouldDebug(f) {
+		// Temporary debugging is extended or made permanent.
+		if _, temporary := expiries[f]; f == "" || l.ShouldDebug(f) && !temporary {
+			continue
+		}
+		if duration > 0 {
+			l.SetDebugFor(f, duration)
+			l.Infof("Enabled debug data for %q for %v", f, duration)
 			continue
 		}
 		l.SetDebug(f, true)
@@ -814,6 +1168,32 @@ func (s *service) getDBCompletion(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (s *service) getDBDevices(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	indexes, err := s.model.FolderDeviceIndexes(qs.Get("folder"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sendJSON(w, indexes)
+}
+
+func (s *service) deleteDBDevices(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	deviceID, err := protocol.DeviceIDFromString(qs.Get("device"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	compact, _ := strconv.ParseBool(qs.Get("compact"))
+
+	if err := s.model.DropDeviceIndex(qs.Get("folder"), deviceID, compact); isFolderNotFound(err) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
+
 func (s *service) getDBStatus(w http.ResponseWriter, r *http.Request) {
 	qs := r.URL.Query()
 	folder := qs.Get("folder")
@@ -824,6 +1204,33 @@ func (s *service) getDBStatus(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (s *service) getDBSize(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	if folder := qs.Get("folder"); folder != "" {
+		size, err := s.model.FolderDatabaseSize(folder)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		sendJSON(w, size)
+		return
+	}
+
+	sizes := make(map[string]db.FolderSizeReport)
+	for folder := range s.cfg.Folders() {
+		size, err := s.model.FolderDatabaseSize(folder)
+		if errors.Is(err, model.ErrFolderMissing) {
+			// Removed or paused in the meantime
+			continue
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sizes[folder] = size
+	}
+	sendJSON(w, sizes)
+}
+
 func (s *service) postDBOverride(_ http.ResponseWriter, r *http.Request) {
 	qs := r.URL.Query()
 	folder := qs.Get("folder")
@@ -917,10 +1324,74 @@ func (s *service) getDBLocalChanged(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (s *service) getDBDiff(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+
+	folder := qs.Get("folder")
+	deviceID, err := protocol.DeviceIDFromString(qs.Get("device"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, perpage := getPagingParams(qs)
+
+	diffs, err := s.model.DeviceDifferences(folder, deviceID, page, perpage)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	files := make([]map[string]interface{}, len(diffs))
+	for i, diff := range diffs {
+		file := map[string]interface{}{
+			"name":   diff.Name,
+			"status": diff.Status,
+			"local":  nil,
+			"remote": nil,
+		}
+		if diff.Local != nil {
+			file["local"] = jsonFileInfoTrunc(*diff.Local)
+		}
+		if diff.Remote != nil {
+			file["remote"] = jsonFileInfoTrunc(*diff.Remote)
+		}
+		files[i] = file
+	}
+
+	sendJSON(w, map[string]interface{}{
+		"files":   files,
+		"page":    page,
+		"perpage": perpage,
+	})
+}
+
 func (s *service) getSystemConnections(w http.ResponseWriter, _ *http.Request) {
 	sendJSON(w, s.model.ConnectionStats())
 }
 
+func (s *service) getDialLog(w http.ResponseWriter, r *http.Request) {
+	var only *protocol.DeviceID
+	if device := r.URL.Query().Get("device"); device != "" {
+		deviceID, err := protocol.DeviceIDFromString(device)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		only = &deviceID
+	}
+
+	// Device ids can't be marshalled as keys so we need to manually
+	// rebuild this map using strings.
+	attempts := make(map[string][]connections.DialAttempt)
+	for device, devAttempts := range s.connectionsService.DialAttempts() {
+		if only == nil || device == *only {
+			attempts[device.String()] = devAttempts
+		}
+	}
+	sendJSON(w, attempts)
+}
+
 func (s *service) getDeviceStats(w http.ResponseWriter, _ *http.Request) {
 	stats, err := s.model.DeviceStatistics()
 	if err != nil {
@@ -939,6 +1410,72 @@ func (s *service) getFolderStats(w http.ResponseWriter, _ *http.Request) {
 	sendJSON(w, stats)
 }
 
+func (s *service) getTransferStats(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	to := time.Now()
+	from := to.AddDate(0, 0, -30)
+	var err error
+	if str := qs.Get("from"); str != "" {
+		if from, err = time.ParseInLocation(stats.TransferDateFormat, str, time.Local); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if str := qs.Get("to"); str != "" {
+		if to, err = time.ParseInLocation(stats.TransferDateFormat, str, time.Local); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	res, err := s.model.TransferStatistics(from, to)
+	if errors.Is(err, stats.ErrTransferRangeTooLarge) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	switch qs.Get("format") {
+	case "", "json":
+		if res == nil {
+			res = []stats.DailyTransferStatistics{}
+		}
+		sendJSON(w, res)
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="transfers.csv"`)
+		_ = stats.WriteTransferStatisticsCSV(w, res)
+	default:
+		http.Error(w, "unknown format", http.StatusBadRequest)
+	}
+}
+
+func (s *service) getSystemWebhooks(w http.ResponseWriter, _ *http.Request) {
+	if s.webhooks == nil {
+		sendJSON(w, []webhook.Status{})
+		return
+	}
+	sendJSON(w, s.webhooks.Status())
+}
+
+func (s *service) getSystemWarnings(w http.ResponseWriter, _ *http.Request) {
+	if s.warnings == nil {
+		sendJSON(w, []warnings.Warning{})
+		return
+	}
+	sendJSON(w, s.warnings.Warnings())
+}
+
+func (s *service) getSystemJobs(w http.ResponseWriter, _ *http.Request) {
+	if s.jobs == nil {
+		sendJSON(w, []jobs.Status{})
+		return
+	}
+	sendJSON(w, s.jobs.Status())
+}
+
 func (s *service) getDBFile(w http.ResponseWriter, r *http.Request) {
 	qs := r.URL.Query()
 	folder := qs.Get("folder")
@@ -1016,7 +1553,47 @@ func (s *service) getDebugFile(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func (s *service) postSystemRestart(w http.ResponseWriter, _ *http.Request) {
+func (*service) getDebugChaos(w http.ResponseWriter, _ *http.Request) {
+	sendJSON(w, map[string]interface{}{
+		"enabled":  chaos.Enabled,
+		"settings": chaos.Get(),
+	})
+}
+
+func (*service) postDebugChaos(w http.ResponseWriter, r *http.Request) {
+	var settings chaos.Settings
+	if err := unmarshalTo(r.Body, &settings); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !chaos.Enabled {
+		http.Error(w, "Fault injection not enabled in this build", http.StatusNotImplemented)
+		return
+	}
+	if err := chaos.Set(settings); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	l.Warnf("Fault injection settings changed: %+v", settings)
+}
+
+func (s *service) postSystemRestart(w http.ResponseWriter, r *http.Request) {
+	if coordinated, _ := strconv.ParseBool(r.URL.Query().Get("coordinated")); coordinated {
+		// Restart once the peers we coordinate restarts with allow it.
+		s.flushResponse(`{"ok": "waiting for peers"}`, w)
+		go func() {
+			if err := s.model.AcquireRestart(context.Background()); err != nil {
+				l.Warnln("Coordinated restart:", err)
+				return
+			}
+			s.fatal(&svcutil.FatalErr{
+				Err:    errors.New("coordinated restart initiated by rest API"),
+				Status: svcutil.ExitRestart,
+			})
+		}()
+		return
+	}
+
 	s.flushResponse(`{"ok": "restarting"}`, w)
 
 	s.fatal(&svcutil.FatalErr{
@@ -1025,6 +1602,20 @@ func (s *service) postSystemRestart(w http.ResponseWriter, _ *http.Request) {
 	})
 }
 
+func (s *service) getClusterRestarts(w http.ResponseWriter, _ *http.Request) {
+	sendJSON(w, s.model.RestartCoordination())
+}
+
+func (s *service) getStandby(w http.ResponseWriter, _ *http.Request) {
+	sendJSON(w, s.model.StandbyStatus())
+}
+
+func (s *service) postFailBack(w http.ResponseWriter, _ *http.Request) {
+	if err := s.model.FailBack(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
+
 func (s *service) postSystemReset(w http.ResponseWriter, r *http.Request) {
 	qs := r.URL.Query()
 	folder := qs.Get("folder")
@@ -1088,7 +1679,7 @@ func (s *service) getSystemStatus(w http.ResponseWriter, _ *http.Request) {
 	if s.cfg.Options().LocalAnnEnabled || s.cfg.Options().GlobalAnnEnabled {
 		res["discoveryEnabled"] = true
 		discoStatus := s.discoverer.ChildErrors()
-		res["discoveryStatus"] = discoveryStatusMap(discoStatus)
+		res["discoveryStatus"] = discoveryStatusMap(discoStatus, s.discoverer.ChildNextAttempts())
 		res["discoveryMethods"] = len(discoStatus) // DEPRECATED: Redundant, only for backwards compatibility, should be removed.
 		discoErrors := make(map[string]*string, len(discoStatus))
 		for s, e := range discoStatus {
@@ -1118,6 +1709,10 @@ func (s *service) getSystemError(w http.ResponseWriter, _ *http.Request) {
 	})
 }
 
+func (s *service) getSystemSelfCheck(w http.ResponseWriter, _ *http.Request) {
+	sendJSON(w, s.selfCheck)
+}
+
 func (*service) postSystemError(_ http.ResponseWriter, r *http.Request) {
 	bs, _ := io.ReadAll(r.Body)
 	r.Body.Close()
@@ -1130,17 +1725,143 @@ func (s *service) postSystemErrorClear(_ http.ResponseWriter, _ *http.Request) {
 
 func (s *service) getSystemLog(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
-	since, err := time.Parse(time.RFC3339, q.Get("since"))
+	filter, err := newLogFilter(q.Get("level"), q.Get("facility"))
 	if err != nil {
-		l.Debugln(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
-	sendJSON(w, map[string][]logger.Line{
-		"messages": s.systemLog.Since(since),
-	})
-}
 
-func (s *service) getSystemLogTxt(w http.ResponseWriter, r *http.Request) {
-	q := r.URL.Query()
+	// The since parameter is either the token from a previous response or,
+	// for compatibility, a timestamp.
+	token, err := strconv.ParseInt(q.Get("since"), 10, 64)
+	if err != nil && q.Get("since") != "" {
+		if filter.since, err = time.Parse(time.RFC3339, q.Get("since")); err != nil {
+			l.Debugln(err)
+		}
+	}
+	lines, changed := s.systemLog.After(token)
+	if len(lines) > 0 {
+		token = lines[len(lines)-1].Seq
+	}
+
+	if stream, _ := strconv.ParseBool(q.Get("stream")); !stream {
+		sendJSON(w, map[string]interface{}{
+			"messages": filter.apply(lines),
+			"token":    token,
+		})
+		return
+	}
+
+	// Stream the lines as they are logged, one JSON object per line, until
+	// the client goes away.
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	enc := json.NewEncoder(w)
+	flusher := w.(http.Flusher)
+	for {
+		for _, line := range filter.apply(lines) {
+			if err := enc.Encode(line); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+		lines, changed = s.systemLog.After(token)
+		if len(lines) > 0 {
+			token = lines[len(lines)-1].Seq
+		}
+	}
+}
+
+// A logFilter selects log lines by minimum level, facility and time.
+type logFilter struct {
+	level      logger.LogLevel
+	facilities map[string]struct{} // all if empty
+	since      time.Time
+}
+
+func newLogFilter(level, facilities string) (logFilter, error) {
+	var f logFilter
+	if level != "" {
+		var err error
+		if f.level, err = logger.ParseLogLevel(level); err != nil {
+			return f, err
+		}
+	}
+	if facilities != "" {
+		f.facilities = make(map[string]struct{})
+		for _, facility := range strings.Split(facilities, ",") {
+			f.facilities[facility] = struct{}{}
+		}
+	}
+	return f, nil
+}
+
+func (f logFilter) apply(lines []logger.Line) []logger.Line {
+	res := make([]logger.Line, 0, len(lines))
+	for _, line := range lines {
+		if line.Level < f.level || !line.When.After(f.since) {
+			continue
+		}
+		if _, ok := f.facilities[line.Facility]; len(f.facilities) > 0 && !ok {
+			continue
+		}
+		res = append(res, line)
+	}
+	return res
+}
+
+func (*service) getSystemMessages(w http.ResponseWriter, _ *http.Request) {
+	sendJSON(w, messages.Catalog())
+}
+
+func (*service) getSystemLogLevels(w http.ResponseWriter, _ *http.Request) {
+	levels := make(map[string]string)
+	for facility, level := range l.FacilityLevels() {
+		levels[facility] = level.String()
+	}
+	sendJSON(w, map[string]interface{}{
+		"facilities": l.Facilities(),
+		"levels":     levels,
+		"default":    logger.DefaultLevel.String(),
+	})
+}
+
+// postSystemLogLevels sets the log level of a facility in the config, from
+// where it is applied. Without a level the facility gets the default one.
+func (s *service) postSystemLogLevels(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	facility := q.Get("facility")
+	if _, ok := l.Facilities()[facility]; !ok {
+		http.Error(w, "unknown facility", http.StatusBadRequest)
+		return
+	}
+	level := logger.DefaultLevel
+	if str := q.Get("level"); str != "" && str != "default" {
+		var err error
+		if level, err = logger.ParseLogLevel(str); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	waiter, err := s.cfg.Modify(func(cfg *config.Configuration) {
+		cfg.Options.SetFacilityLogLevel(facility, level)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	waiter.Wait()
+	l.Infof("Set log level for %q to %v", facility, level)
+}
+
+func (s *service) getSystemLogTxt(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
 	since, err := time.Parse(time.RFC3339, q.Get("since"))
 	if err != nil {
 		l.Debugln(err)
@@ -1184,7 +1905,7 @@ func (s *service) getSupportBundle(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Panic files
-	if panicFiles, err := filepath.Glob(filepath.Join(locations.GetBaseDir(locations.ConfigBaseDir), "panic*")); err == nil {
+	if panicFiles, err := filepath.Glob(filepath.Join(s.profile.GetBaseDir(locations.ConfigBaseDir), "panic*")); err == nil {
 		for _, f := range panicFiles {
 			if panicFile, err := os.ReadFile(f); err != nil {
 				l.Warnf("Support bundle: failed to load %s: %s", filepath.Base(f), err)
@@ -1195,7 +1916,7 @@ func (s *service) getSupportBundle(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Archived log (default on Windows)
-	if logFile, err := os.ReadFile(locations.Get(locations.LogFile)); err == nil {
+	if logFile, err := os.ReadFile(s.profile.Get(locations.LogFile)); err == nil {
 		files = append(files, fileEntry{name: "log-ondisk.txt", data: logFile})
 	}
 
@@ -1256,7 +1977,7 @@ func (s *service) getSupportBundle(w http.ResponseWriter, r *http.Request) {
 
 	// Set zip file name and path
 	zipFileName := fmt.Sprintf("support-bundle-%s-%s.zip", s.id.Short().String(), time.Now().Format("2006-01-02T150405"))
-	zipFilePath := filepath.Join(locations.GetBaseDir(locations.ConfigBaseDir), zipFileName)
+	zipFilePath := filepath.Join(s.profile.GetBaseDir(locations.ConfigBaseDir), zipFileName)
 
 	// Write buffer zip to local zip file (back up)
 	if err := os.WriteFile(zipFilePath, zipFilesBuffer.Bytes(), 0o600); err != nil {
@@ -1587,6 +2308,29 @@ func (*service) getHealth(w http.ResponseWriter, _ *http.Request) {
 	sendJSON(w, map[string]string{"status": "OK"})
 }
 
+func (s *service) getPairing(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	name := ""
+	if dev, ok := s.cfg.Device(s.id); ok {
+		name = dev.Name
+	}
+	res := map[string]interface{}{
+		"deviceID": s.id,
+		"name":     name,
+		"payload":  protocol.PairingURI(s.id, name),
+	}
+	if str := qs.Get("device"); str != "" {
+		device, err := protocol.DeviceIDFromString(str)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		res["device"] = device
+		res["sas"] = protocol.NewPairingSAS(s.id, device)
+	}
+	sendJSON(w, res)
+}
+
 func (*service) getQR(w http.ResponseWriter, r *http.Request) {
 	qs := r.URL.Query()
 	text := qs.Get("text")
@@ -1664,6 +2408,264 @@ func (s *service) postFolderVersionsRestore(w http.ResponseWriter, r *http.Reque
 	sendJSON(w, errorStringMap(ferr))
 }
 
+func (s *service) getFolderRecycle(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	var since time.Time
+	if qs.Has("days") {
+		days, err := strconv.Atoi(qs.Get("days"))
+		if err != nil || days < 0 {
+			http.Error(w, "invalid days", http.StatusBadRequest)
+			return
+		}
+		since = time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	}
+	items, err := s.model.RecycledItems(qs.Get("folder"), since)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sendJSON(w, items)
+}
+
+func (s *service) postRecycleRestore(w http.ResponseWriter, r *http.Request) {
+	// Restoring from the recycle bin is the same as restoring versions,
+	// under the name clients of the recycle API expect.
+	s.postFolderVersionsRestore(w, r)
+}
+
+func (s *service) postRecyclePurge(w http.ResponseWriter, r *http.Request) {
+	var versions map[string]time.Time
+	if err := unmarshalTo(r.Body, &versions); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ferr, err := s.model.PurgeFolderVersions(r.URL.Query().Get("folder"), versions)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sendJSON(w, errorStringMap(ferr))
+}
+
+func (s *service) getFolderScrub(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	status, err := s.model.ScrubStatus(qs.Get("folder"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sendJSON(w, status)
+}
+
+func (s *service) postFolderScrub(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	if err := s.model.ScrubFolder(qs.Get("folder")); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+}
+
+func (s *service) getFolderFreeze(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	status, err := s.model.FreezeStatus(qs.Get("folder"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sendJSON(w, status)
+}
+
+func (s *service) getFolderHeld(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	held, err := s.model.HeldPaths(qs.Get("folder"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sendJSON(w, held)
+}
+
+func (s *service) getFolderSnapshot(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	page, perpage := getPagingParams(qs)
+	snap, files, err := s.model.BackupSnapshotFiles(qs.Get("id"), page, perpage)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sendJSON(w, map[string]interface{}{
+		"snapshot": snap,
+		"files":    toJsonFileInfoSlice(files),
+		"page":     page,
+		"perpage":  perpage,
+	})
+}
+
+func (s *service) postFolderSnapshot(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	var ttl time.Duration
+	if v := qs.Get("ttl"); v != "" {
+		secs, err := strconv.Atoi(v)
+		if err != nil || secs < 0 {
+			http.Error(w, "ttl must be a number of seconds", http.StatusBadRequest)
+			return
+		}
+		ttl = time.Duration(secs) * time.Second
+	}
+	snap, err := s.model.CreateBackupSnapshot(qs.Get("folder"), ttl)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sendJSON(w, snap)
+}
+
+func (s *service) deleteFolderSnapshot(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	if err := s.model.ReleaseBackupSnapshot(qs.Get("id")); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+}
+
+func (s *service) getFolderScans(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	summaries, err := s.model.ScanSummaries(qs.Get("folder"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sendJSON(w, summaries)
+}
+
+func (s *service) getFolderCaps(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	caps, err := s.model.FolderCapabilities(qs.Get("folder"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sendJSON(w, caps)
+}
+
+func (s *service) getFolderTuning(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	folder, ok := s.cfg.Folder(qs.Get("folder"))
+	if !ok {
+		http.Error(w, "No folder with given ID", http.StatusNotFound)
+		return
+	}
+	sendJSON(w, map[string]interface{}{
+		"profile": folder.Profile,
+		"tuning":  folder.Tuning(),
+	})
+}
+
+func (s *service) postFolderFreeze(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	if err := s.model.FreezeFolder(qs.Get("folder")); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+}
+
+func (s *service) postFolderUnfreeze(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	if err := s.model.UnfreezeFolder(qs.Get("folder")); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+}
+
+func (s *service) postFolderHold(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	if err := s.model.HoldPaths(qs.Get("folder"), qs["file"]); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+}
+
+func (s *service) postFolderRelease(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	if err := s.model.ReleasePaths(qs.Get("folder"), qs["file"]); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+}
+
+func (s *service) postFolderEmptied(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	var repull bool
+	switch qs.Get("action") {
+	case "delete":
+	case "repull":
+		repull = true
+	default:
+		http.Error(w, "action must be delete or repull", http.StatusBadRequest)
+		return
+	}
+	if err := s.model.ResolveEmptiedFolder(qs.Get("folder"), repull); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+}
+
+func (s *service) getDBBlockPool(w http.ResponseWriter, _ *http.Request) {
+	sendJSON(w, s.model.BlockPoolStatus())
+}
+
+func (s *service) postDBBlockPoolGC(_ http.ResponseWriter, _ *http.Request) {
+	s.model.CollectBlockPool()
+}
+
+func (s *service) postDBBlockPoolScrub(_ http.ResponseWriter, _ *http.Request) {
+	s.model.ScrubBlockPool()
+}
+
+func (s *service) postFolderUnlock(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	var req struct {
+		Password string `json:"password"`
+	}
+	if err := unmarshalTo(r.Body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.model.UnlockFolder(qs.Get("folder"), req.Password); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+}
+
+func (s *service) getFolderPlaintext(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	file := qs.Get("file")
+	fd, err := s.model.OpenPlaintext(qs.Get("folder"), file)
+	if fs.IsNotExist(err) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer fd.Close()
+	info, err := fd.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.ServeContent(w, r, filepath.Base(file), info.ModTime(), fd)
+}
+
+func (s *service) postFolderLock(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	if err := s.model.LockFolder(qs.Get("folder")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+}
+
 func (s *service) getFolderErrors(w http.ResponseWriter, r *http.Request) {
 	qs := r.URL.Query()
 	folder := qs.Get("folder")
@@ -1675,24 +2677,98 @@ func (s *service) getFolderErrors(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	start := (page - 1) * perpage
-	if start >= len(errors) {
-		errors = nil
-	} else {
-		errors = errors[start:]
-		if perpage < len(errors) {
-			errors = errors[:perpage]
-		}
+	sendJSON(w, map[string]interface{}{
+		"folder":  folder,
+		"errors":  fileErrorsPage(errors, page, perpage),
+		"page":    page,
+		"perpage": perpage,
+	})
+}
+
+func (s *service) getFolderCleanups(w http.ResponseWriter, _ *http.Request) {
+	sendJSON(w, s.model.FolderCleanups())
+}
+
+func (s *service) deleteFolderCleanups(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	if err := s.model.CancelFolderCleanup(qs.Get("folder")); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+}
+
+func (s *service) getFolderMove(w http.ResponseWriter, _ *http.Request) {
+	move, ok := s.model.FolderMove()
+	if !ok {
+		http.Error(w, "no folder move", http.StatusNotFound)
+		return
+	}
+	sendJSON(w, move)
+}
+
+func (s *service) postFolderMove(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	removeSource, _ := strconv.ParseBool(qs.Get("removesource"))
+
+	var targets map[string]string
+	if err := unmarshalTo(r.Body, &targets); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.model.MoveFolders(targets, removeSource); isFolderNotFound(err) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
+
+func (s *service) deleteFolderMove(w http.ResponseWriter, _ *http.Request) {
+	if err := s.model.AbortFolderMove(); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	}
+}
+
+func (s *service) getFolderQuarantine(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	folder := qs.Get("folder")
+	page, perpage := getPagingParams(qs)
+
+	items, err := s.model.FolderQuarantine(folder)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
 	}
 
 	sendJSON(w, map[string]interface{}{
 		"folder":  folder,
-		"errors":  errors,
+		"items":   fileErrorsPage(items, page, perpage),
+		"total":   len(items),
 		"page":    page,
 		"perpage": perpage,
 	})
 }
 
+func (s *service) postFolderQuarantine(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	if err := s.model.RetryQuarantined(qs.Get("folder"), qs["file"]); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+}
+
+func fileErrorsPage(errors []model.FileError, page, perpage int) []model.FileError {
+	start := (page - 1) * perpage
+	if start >= len(errors) {
+		return nil
+	}
+	errors = errors[start:]
+	if perpage < len(errors) {
+		errors = errors[:perpage]
+	}
+	return errors
+}
+
 func (*service) getSystemBrowse(w http.ResponseWriter, r *http.Request) {
 	qs := r.URL.Query()
 	current := qs.Get("current")
@@ -1922,24 +2998,66 @@ func addressIsLocalhost(addr string) bool {
 	}
 }
 
+// checkCertificate returns true if the HTTPS certificate should be
+// regenerated, which happens when the listener is restarted. Certificates
+// that aren't ours to regenerate are warned about ahead of expiry instead.
+func (s *service) checkCertificate() bool {
+	if err := shouldRegenerateCertificate(s.httpsCert); err != nil {
+		l.Infoln("HTTPS certificate needs to be renewed:", err)
+		return true
+	}
+	leaf, err := certificateLeaf(s.httpsCert)
+	if err != nil {
+		return false
+	}
+	if time.Until(leaf.NotAfter) > certExpiryWarningTime || s.certWarned.Equal(leaf.NotAfter) {
+		return false
+	}
+	s.certWarned = leaf.NotAfter
+	l.Warnf("The HTTPS certificate in %s expires at %v and must be renewed manually", s.profile.Get(locations.HTTPSCertFile), leaf.NotAfter.Format(time.RFC3339))
+	s.evLogger.Log(events.CertificateExpiring, map[string]interface{}{
+		"certificate": "gui",
+		"expires":     leaf.NotAfter,
+	})
+	return false
+}
+
+// newACMEManager returns the manager that obtains and renews the HTTPS
+// certificate for the configured domain.
+func newACMEManager(guiCfg config.GUIConfiguration, cacheDir string) *autocert.Manager {
+	m := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(cacheDir),
+		HostPolicy: autocert.HostWhitelist(guiCfg.ACMEDomain),
+		Email:      guiCfg.ACMEEmail,
+	}
+	if guiCfg.ACMEDirectory != "" {
+		m.Client = &acme.Client{DirectoryURL: guiCfg.ACMEDirectory}
+	}
+	return m
+}
+
+func certificateLeaf(cert tls.Certificate) (*x509.Certificate, error) {
+	if cert.Leaf != nil {
+		// Leaf can be nil or not, depending on how parsed the certificate
+		// was when we got it.
+		return cert.Leaf, nil
+	}
+	if len(cert.Certificate) < 1 {
+		// can't happen
+		return nil, errors.New("no certificate in certificate")
+	}
+	return x509.ParseCertificate(cert.Certificate[0])
+}
+
 // shouldRegenerateCertificate checks for certificate expiry or other known
 // issues with our API/GUI certificate and returns either nil (leave the
 // certificate alone) or an error describing the reason the certificate
 // should be regenerated.
 func shouldRegenerateCertificate(cert tls.Certificate) error {
-	leaf := cert.Leaf
-	if leaf == nil {
-		// Leaf can be nil or not, depending on how parsed the certificate
-		// was when we got it.
-		if len(cert.Certificate) < 1 {
-			// can't happen
-			return errors.New("no certificate in certificate")
-		}
-		var err error
-		leaf, err = x509.ParseCertificate(cert.Certificate[0])
-		if err != nil {
-			return err
-		}
+	leaf, err := certificateLeaf(cert)
+	if err != nil {
+		return err
 	}
 
 	if leaf.Subject.String() != leaf.Issuer.String() || len(leaf.IPAddresses) != 0 {
@@ -1961,7 +3079,7 @@ func shouldRegenerateCertificate(cert tls.Certificate) error {
 	if leaf.NotAfter.Before(time.Now()) {
 		return errors.New("certificate has expired")
 	}
-	if leaf.NotAfter.Before(time.Now().Add(30 * 24 * time.Hour)) {
+	if leaf.NotAfter.Before(time.Now().Add(certExpiryWarningTime)) {
 		return errors.New("certificate will soon expire")
 	}
 
@@ -1994,15 +3112,20 @@ func errorString(err error) *string {
 }
 
 type discoveryStatusEntry struct {
-	Error *string `json:"error"`
+	Error       *string    `json:"error"`
+	NextAttempt *time.Time `json:"nextAttempt,omitempty"`
 }
 
-func discoveryStatusMap(errs map[string]error) map[string]discoveryStatusEntry {
+func discoveryStatusMap(errs map[string]error, nextAttempts map[string]time.Time) map[string]discoveryStatusEntry {
 	out := make(map[string]discoveryStatusEntry, len(errs))
 	for s, e := range errs {
-		out[s] = discoveryStatusEntry{
+		entry := discoveryStatusEntry{
 			Error: errorString(e),
 		}
+		if next, ok := nextAttempts[s]; ok {
+			entry.NextAttempt = &next
+		}
+		out[s] = entry
 	}
 	return out
 }
@@ -10,9 +10,15 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -40,6 +46,7 @@ import (
 	modelmocks "github.com/syncthing/syncthing/lib/model/mocks"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/rand"
+	"github.com/syncthing/syncthing/lib/selfcheck"
 	"github.com/syncthing/syncthing/lib/svcutil"
 	"github.com/syncthing/syncthing/lib/sync"
 	"github.com/syncthing/syncthing/lib/tlsutil"
@@ -148,7 +155,7 @@ func TestStopAfterBrokenConfig(t *testing.T) {
 	}
 	w := config.Wrap("/dev/null", cfg, protocol.LocalDeviceID, events.NoopLogger)
 
-	srv := New(protocol.LocalDeviceID, w, "", "syncthing", nil, nil, nil, events.NoopLogger, nil, nil, nil, nil, nil, nil, false).(*service)
+	srv := New(protocol.LocalDeviceID, w, "", "syncthing", nil, nil, nil, events.NoopLogger, nil, nil, nil, nil, nil, nil, nil, selfcheck.Report{}, nil, nil, false, nil).(*service)
 	defer os.Remove(token)
 
 	srv.started = make(chan string)
@@ -413,6 +420,12 @@ func TestAPIServiceRequests(t *testing.T) {
 			Type:   "application/json",
 			Prefix: "{",
 		},
+		{
+			URL:    "/rest/system/selfcheck",
+			Code:   200,
+			Type:   "application/json",
+			Prefix: "{",
+		},
 		{
 			URL:    "/rest/system/version",
 			Code:   200,
@@ -431,6 +444,24 @@ func TestAPIServiceRequests(t *testing.T) {
 			Type:   "application/json",
 			Prefix: "{",
 		},
+		{
+			URL:    "/rest/system/log?level=warning&facility=api",
+			Code:   200,
+			Type:   "application/json",
+			Prefix: "{",
+		},
+		{
+			URL:    "/rest/system/log?level=loud",
+			Code:   400,
+			Type:   "text/plain",
+			Prefix: "",
+		},
+		{
+			URL:    "/rest/system/log/levels",
+			Code:   200,
+			Type:   "application/json",
+			Prefix: "{",
+		},
 		{
 			URL:    "/rest/system/log.txt?since=0",
 			Code:   200,
@@ -703,14 +734,21 @@ func startHTTP(cfg config.Wrapper) (string, context.CancelFunc, error) {
 				Message: "Test message",
 			},
 		})
+		l.AfterReturns([]logger.Line{
+			{
+				When:    time.Now(),
+				Message: "Test message",
+				Seq:     1,
+			},
+		}, make(chan struct{}))
 	}
 	addrChan := make(chan string)
 	mockedSummary := &modelmocks.FolderSummaryService{}
 	mockedSummary.SummaryReturns(new(model.FolderSummary), nil)
 
 	// Instantiate the API service
-	urService := ur.New(cfg, m, connections, false)
-	svc := New(protocol.LocalDeviceID, cfg, assetDir, "syncthing", m, eventSub, diskEventSub, events.NoopLogger, discoverer, connections, urService, mockedSummary, errorLog, systemLog, false).(*service)
+	urService := ur.New(cfg, m, connections, nil, false)
+	svc := New(protocol.LocalDeviceID, cfg, assetDir, "syncthing", m, eventSub, diskEventSub, events.NoopLogger, discoverer, connections, urService, mockedSummary, nil, nil, nil, selfcheck.Report{}, errorLog, systemLog, false, nil).(*service)
 	defer os.Remove(token)
 	svc.started = addrChan
 
@@ -1135,6 +1173,92 @@ func TestHostCheck(t *testing.T) {
 	}
 }
 
+func TestPathPrefix(t *testing.T) {
+	t.Parallel()
+
+	cfg := newMockedConfig()
+	cfg.GUIReturns(config.GUIConfiguration{RawAddress: "127.0.0.1:0", RawPathPrefix: "/sync/"})
+	baseURL, cancel, err := startHTTP(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+
+	cli := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	cases := []struct {
+		path   string
+		status int
+	}{
+		{"/sync/rest/noauth/health", http.StatusOK},
+		{"/sync/", http.StatusOK},
+		{"/sync", http.StatusMovedPermanently},
+		{"/rest/noauth/health", http.StatusNotFound},
+		{"/syncthing/", http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		resp, err := cli.Get(baseURL + tc.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s: expected %d, not %s", tc.path, tc.status, resp.Status)
+		}
+	}
+}
+
+func TestForwardedMiddleware(t *testing.T) {
+	t.Parallel()
+
+	trusted, err := parseTrustedProxies([]string{"192.0.2.1", "10.0.0.0/8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseTrustedProxies([]string{"proxy.example.com"}); err == nil {
+		t.Error("expected an error for a host name")
+	}
+
+	var got *http.Request
+	h := forwardedMiddleware(trusted, http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = r
+	}))
+
+	cases := []struct {
+		remote    string
+		forwarded string
+		client    string
+		keepProto bool
+	}{
+		// Not through a trusted proxy
+		{"203.0.113.5:1234", "198.51.100.7", "203.0.113.5:1234", false},
+		// Through a trusted proxy
+		{"192.0.2.1:1234", "198.51.100.7", "198.51.100.7", true},
+		// Through a chain of proxies, the client inserted a bogus address
+		{"10.0.0.1:1234", "192.0.2.99, 198.51.100.7, 10.1.2.3", "198.51.100.7", true},
+		// Without an X-Forwarded-For header
+		{"10.0.0.1:1234", "", "10.0.0.1:1234", true},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = tc.remote
+		if tc.forwarded != "" {
+			req.Header.Set("X-Forwarded-For", tc.forwarded)
+		}
+		req.Header.Set("X-Forwarded-Proto", "https")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		if got.RemoteAddr != tc.client {
+			t.Errorf("%s via %s: expected client %s, got %s", tc.forwarded, tc.remote, tc.client, got.RemoteAddr)
+		}
+		if keep := got.Header.Get("X-Forwarded-Proto") != ""; keep != tc.keepProto {
+			t.Errorf("%s: expected forwarded headers to be kept: %v", tc.remote, tc.keepProto)
+		}
+	}
+}
+
 func TestAddressIsLocalhost(t *testing.T) {
 	t.Parallel()
 
@@ -1247,7 +1371,7 @@ func TestEventMasks(t *testing.T) {
 	cfg := newMockedConfig()
 	defSub := new(eventmocks.BufferedSubscription)
 	diskSub := new(eventmocks.BufferedSubscription)
-	svc := New(protocol.LocalDeviceID, cfg, "", "syncthing", nil, defSub, diskSub, events.NoopLogger, nil, nil, nil, nil, nil, nil, false).(*service)
+	svc := New(protocol.LocalDeviceID, cfg, "", "syncthing", nil, defSub, diskSub, events.NoopLogger, nil, nil, nil, nil, nil, nil, nil, selfcheck.Report{}, nil, nil, false, nil).(*service)
 	defer os.Remove(token)
 
 	if mask := svc.getEventMask(""); mask != DefaultEventMask {
@@ -1373,6 +1497,95 @@ func TestShouldRegenerateCertificate(t *testing.T) {
 	}
 }
 
+func TestCheckCertificate(t *testing.T) {
+	evLogger := events.NewLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go evLogger.Serve(ctx)
+	sub := evLogger.Subscribe(events.CertificateExpiring)
+	defer sub.Unsubscribe()
+
+	// Our own certificates are regenerated ahead of expiry.
+	crt, err := tlsutil.NewCertificateInMemory("foo.example.com", 29)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &service{httpsCert: crt, evLogger: evLogger}
+	if !s.checkCertificate() {
+		t.Error("expected the certificate to need renewal")
+	}
+
+	// Other certificates are warned about, once.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "foo.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(10 * 24 * time.Hour),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.httpsCert = tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	for i := 0; i < 2; i++ {
+		if s.checkCertificate() {
+			t.Error("expected a foreign certificate to be left alone")
+		}
+	}
+	if _, err := sub.Poll(time.Second); err != nil {
+		t.Fatal("expected a CertificateExpiring event:", err)
+	}
+	if _, err := sub.Poll(100 * time.Millisecond); err == nil {
+		t.Error("expected a single CertificateExpiring event")
+	}
+}
+
+func TestACMEListener(t *testing.T) {
+	guiCfg := config.GUIConfiguration{
+		RawAddress: "127.0.0.1:0",
+		RawUseTLS:  true,
+		ACMEDomain: "sync.example.com",
+	}
+	s := &service{tlsDefaultCommonName: "syncthing"}
+	listener, err := s.getListener(guiCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	if s.acme == nil {
+		t.Fatal("expected an ACME manager")
+	}
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Read(make([]byte, 1))
+	}()
+
+	// Connections for other names get the self signed certificate,
+	// without asking the ACME directory.
+	conn, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{
+		ServerName:         "localhost",
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	peer := conn.ConnectionState().PeerCertificates
+	if len(peer) == 0 || !bytes.Equal(peer[0].Raw, s.httpsCert.Certificate[0]) {
+		t.Error("expected the self signed certificate")
+	}
+}
+
 func TestConfigChanges(t *testing.T) {
 	t.Parallel()
 
@@ -1497,6 +1710,47 @@ func TestConfigChanges(t *testing.T) {
 	}
 }
 
+func TestSystemLogStream(t *testing.T) {
+	t.Parallel()
+
+	log := logger.New()
+	log.SetFlags(0)
+	rec := logger.NewRecorder(log, logger.LevelDebug, 10, 0)
+	f := log.NewFacility("streamtest", "Streaming test")
+	log.SetLevel("streamtest", logger.LevelDebug)
+	f.Infoln("first")
+
+	svc := &service{systemLog: rec}
+	srv := httptest.NewServer(http.HandlerFunc(svc.getSystemLog))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?stream=true&level=info&facility=streamtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	dec := json.NewDecoder(resp.Body)
+
+	var line logger.Line
+	if err := dec.Decode(&line); err != nil {
+		t.Fatal(err)
+	}
+	if line.Message != "first" || line.Facility != "streamtest" || line.Level != logger.LevelInfo {
+		t.Errorf("unexpected line %+v", line)
+	}
+
+	// Debug lines and other facilities are filtered out.
+	f.Debugln("filtered")
+	log.Warnln("filtered")
+	f.Warnln("second")
+	if err := dec.Decode(&line); err != nil {
+		t.Fatal(err)
+	}
+	if line.Message != "second" || line.Seq != 4 {
+		t.Errorf("unexpected line %+v", line)
+	}
+}
+
 func TestSanitizedHostname(t *testing.T) {
 	cases := []struct {
 		in, out string
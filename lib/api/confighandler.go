@@ -8,8 +8,10 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/julienschmidt/httprouter"
 
@@ -56,6 +58,80 @@ func (c *configMuxBuilder) registerConfigRequiresRestart(path string) {
 	})
 }
 
+func (c *configMuxBuilder) registerConfigHistory(path string) {
+	c.HandlerFunc(http.MethodGet, path, func(w http.ResponseWriter, _ *http.Request) {
+		entries, err := c.cfg.History()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sendJSON(w, entries)
+	})
+
+	c.Handle(http.MethodGet, path+"/:id", func(w http.ResponseWriter, _ *http.Request, p httprouter.Params) {
+		cfg, ok := c.historyVersion(w, p.ByName("id"))
+		if !ok {
+			return
+		}
+		sendJSON(w, cfg)
+	})
+
+	c.Handle(http.MethodGet, path+"/:id/diff", func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		from, ok := c.historyVersion(w, p.ByName("id"))
+		if !ok {
+			return
+		}
+		to, toName := c.cfg.RawCopy(), "current"
+		if toID := r.URL.Query().Get("to"); toID != "" {
+			if to, ok = c.historyVersion(w, toID); !ok {
+				return
+			}
+			toName = toID
+		}
+		diff, err := config.Diff(from, to, p.ByName("id"), toName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(diff))
+	})
+
+	c.Handle(http.MethodPost, path+"/:id/rollback", func(w http.ResponseWriter, _ *http.Request, p httprouter.Params) {
+		id, err := strconv.Atoi(p.ByName("id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		waiter, err := c.cfg.Rollback(id)
+		if errors.Is(err, config.ErrNoSuchHistoryEntry) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		c.finish(w, waiter)
+	})
+}
+
+func (c *configMuxBuilder) historyVersion(w http.ResponseWriter, idStr string) (config.Configuration, bool) {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return config.Configuration{}, false
+	}
+	cfg, err := c.cfg.HistoryVersion(id)
+	if errors.Is(err, config.ErrNoSuchHistoryEntry) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return config.Configuration{}, false
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return config.Configuration{}, false
+	}
+	return cfg, true
+}
+
 func (c *configMuxBuilder) registerFolders(path string) {
 	c.HandlerFunc(http.MethodGet, path, func(w http.ResponseWriter, _ *http.Request) {
 		sendJSON(w, c.cfg.FolderList())
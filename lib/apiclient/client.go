@@ -0,0 +1,238 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package apiclient is a typed client for the Syncthing REST API, covering
+// the config, db, events and system endpoints.
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRetries      = 3
+	defaultRetryBackoff = time.Second
+	maxRetryBackoff     = 30 * time.Second
+)
+
+// A StatusError is returned when the API responds with a status other than
+// 200 OK.
+type StatusError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s %s: %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%s %s: %d %s: %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// IsNotFound returns true if the error is a 404 Not Found response, which
+// is what most endpoints answer for an unknown folder or device.
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
+// Client talks to the REST API of a single Syncthing instance. It is safe
+// for concurrent use.
+type Client struct {
+	base         *url.URL
+	apiKey       string
+	httpClient   *http.Client
+	retries      int
+	retryBackoff time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient makes the client use the given HTTP client, e.g. to dial
+// a unix socket or to verify the GUI certificate.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries sets how often idempotent requests are retried on network
+// errors and temporary server errors, with exponential backoff starting at
+// the given duration. Zero retries disables retrying.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.retryBackoff = backoff
+	}
+}
+
+// New returns a client for the GUI/API address (e.g.
+// "https://127.0.0.1:8384"), authenticating with the API key. As the GUI
+// usually uses a self signed certificate, it isn't verified by default.
+func New(address, apiKey string, opts ...Option) (*Client, error) {
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	base, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("parsing address: %w", err)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %q", base.Scheme)
+	}
+	base.Path = strings.TrimSuffix(base.Path, "/")
+
+	c := &Client{
+		base:   base,
+		apiKey: apiKey,
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: true,
+				},
+			},
+		},
+		retries:      defaultRetries,
+		retryBackoff: defaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	return c.do(ctx, http.MethodGet, path, query, nil, out)
+}
+
+func (c *Client) post(ctx context.Context, path string, query url.Values, in, out interface{}) error {
+	return c.do(ctx, http.MethodPost, path, query, in, out)
+}
+
+func (c *Client) put(ctx context.Context, path string, in interface{}) error {
+	return c.do(ctx, http.MethodPut, path, nil, in, nil)
+}
+
+func (c *Client) delete(ctx context.Context, path string, query url.Values) error {
+	return c.do(ctx, http.MethodDelete, path, query, nil, nil)
+}
+
+// do performs the request, JSON encoding the body unless it is a string,
+// and decodes the response into out if non-nil. Requests other than POST
+// are idempotent and thus retried.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	var body []byte
+	switch in := in.(type) {
+	case nil:
+	case string:
+		body = []byte(in)
+	default:
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+
+	u := *c.base
+	u.Path += path
+	u.RawQuery = query.Encode()
+
+	retries := c.retries
+	if method == http.MethodPost {
+		retries = 0
+	}
+	backoff := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		retryAfter, err := c.doOnce(ctx, method, u.String(), path, body, out)
+		if err == nil || attempt >= retries || !retryable(err) {
+			return err
+		}
+		wait := backoff
+		if retryAfter > wait {
+			wait = retryAfter
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
+func (c *Client) doOnce(ctx context.Context, method, target, path string, body []byte, out interface{}) (time.Duration, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("X-API-Key", c.apiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		var retryAfter time.Duration
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+			retryAfter = time.Duration(secs) * time.Second
+		}
+		return retryAfter, &StatusError{
+			Method:     method,
+			Path:       path,
+			StatusCode: resp.StatusCode,
+			Message:    strings.TrimSpace(string(msg)),
+		}
+	}
+
+	if out == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return 0, err
+	}
+	return 0, json.NewDecoder(resp.Body).Decode(out)
+}
+
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		// Network level error
+		var urlErr *url.Error
+		return errors.As(err, &urlErr)
+	}
+	switch statusErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
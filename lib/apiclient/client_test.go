@@ -0,0 +1,152 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+)
+
+const testAPIKey = "abc123"
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != testAPIKey {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	c, err := New(srv.URL, testAPIKey, WithRetries(2, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestRetries(t *testing.T) {
+	calls := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			http.Error(w, "starting", http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(config.OptionsConfiguration{MaxSendKbps: 42})
+	})
+
+	opts, err := c.Options(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 || opts.MaxSendKbps != 42 {
+		t.Errorf("unexpected result %v after %d calls", opts.MaxSendKbps, calls)
+	}
+
+	// Requests with side effects aren't retried.
+	calls = 0
+	if err := c.Restart(context.Background()); err == nil {
+		t.Error("expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("restart was requested %d times", calls)
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "No folder with given ID", http.StatusNotFound)
+	})
+
+	_, err := c.Folder(context.Background(), "missing")
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if err.Error() != "GET /rest/config/folders/missing: 404 Not Found: No folder with given ID" {
+		t.Errorf("unexpected error message %q", err)
+	}
+
+	c.apiKey = "wrong"
+	if err := c.Ping(context.Background()); IsNotFound(err) || err == nil {
+		t.Errorf("expected forbidden error, got %v", err)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	const total = 2*DefaultPerPage + 10
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/db/need" || r.URL.Query().Get("folder") != "default" {
+			http.NotFound(w, r)
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perpage, _ := strconv.Atoi(r.URL.Query().Get("perpage"))
+		var res NeedPage
+		for i := (page - 1) * perpage; i < total && i < page*perpage; i++ {
+			if i == 0 {
+				res.Progress = append(res.Progress, File{Name: "0"})
+				continue
+			}
+			res.Rest = append(res.Rest, File{Name: strconv.Itoa(i)})
+		}
+		json.NewEncoder(w).Encode(res)
+	})
+
+	files, err := c.AllNeeded(context.Background(), "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != total {
+		t.Fatalf("expected %d files, got %d", total, len(files))
+	}
+	for i, f := range files {
+		if f.Name != strconv.Itoa(i) {
+			t.Fatalf("file %d is named %q", i, f.Name)
+		}
+	}
+}
+
+func TestWatchEvents(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("events") != "LocalIndexUpdated,StateChanged" {
+			http.Error(w, "unexpected events", http.StatusBadRequest)
+			return
+		}
+		since, _ := strconv.Atoi(r.URL.Query().Get("since"))
+		fmt.Fprintf(w, `[{"id":%d,"type":"StateChanged","data":{"folder":"default"}}]`, since+1)
+	})
+
+	var ids []int
+	errStop := fmt.Errorf("stop")
+	err := c.WatchEvents(context.Background(), 10, func(ev events.Event) error {
+		if ev.Type != events.StateChanged || ev.Data.(map[string]interface{})["folder"] != "default" {
+			t.Errorf("unexpected event %v", ev)
+		}
+		ids = append(ids, ev.SubscriptionID)
+		if len(ids) == 3 {
+			return errStop
+		}
+		return nil
+	}, events.LocalIndexUpdated, events.StateChanged)
+	if err != errStop {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(ids) != "[11 12 13]" {
+		t.Errorf("unexpected event IDs %v", ids)
+	}
+}
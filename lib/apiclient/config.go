@@ -0,0 +1,88 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package apiclient
+
+import (
+	"context"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// Config returns the complete configuration.
+func (c *Client) Config(ctx context.Context) (config.Configuration, error) {
+	var cfg config.Configuration
+	err := c.get(ctx, "/rest/config", nil, &cfg)
+	return cfg, err
+}
+
+// SetConfig replaces the complete configuration.
+func (c *Client) SetConfig(ctx context.Context, cfg config.Configuration) error {
+	return c.put(ctx, "/rest/config", cfg)
+}
+
+// RequiresRestart returns whether a configuration change that was made
+// requires a restart to take effect.
+func (c *Client) RequiresRestart(ctx context.Context) (bool, error) {
+	var res struct {
+		RequiresRestart bool `json:"requiresRestart"`
+	}
+	err := c.get(ctx, "/rest/config/restart-required", nil, &res)
+	return res.RequiresRestart, err
+}
+
+func (c *Client) Folders(ctx context.Context) ([]config.FolderConfiguration, error) {
+	var folders []config.FolderConfiguration
+	err := c.get(ctx, "/rest/config/folders", nil, &folders)
+	return folders, err
+}
+
+func (c *Client) Folder(ctx context.Context, id string) (config.FolderConfiguration, error) {
+	var folder config.FolderConfiguration
+	err := c.get(ctx, "/rest/config/folders/"+id, nil, &folder)
+	return folder, err
+}
+
+// SetFolder adds the folder or replaces the existing one with the same ID.
+func (c *Client) SetFolder(ctx context.Context, folder config.FolderConfiguration) error {
+	return c.put(ctx, "/rest/config/folders/"+folder.ID, folder)
+}
+
+func (c *Client) RemoveFolder(ctx context.Context, id string) error {
+	return c.delete(ctx, "/rest/config/folders/"+id, nil)
+}
+
+func (c *Client) Devices(ctx context.Context) ([]config.DeviceConfiguration, error) {
+	var devices []config.DeviceConfiguration
+	err := c.get(ctx, "/rest/config/devices", nil, &devices)
+	return devices, err
+}
+
+func (c *Client) Device(ctx context.Context, id protocol.DeviceID) (config.DeviceConfiguration, error) {
+	var device config.DeviceConfiguration
+	err := c.get(ctx, "/rest/config/devices/"+id.String(), nil, &device)
+	return device, err
+}
+
+// SetDevice adds the device or replaces the existing one with the same ID.
+func (c *Client) SetDevice(ctx context.Context, device config.DeviceConfiguration) error {
+	return c.put(ctx, "/rest/config/devices/"+device.DeviceID.String(), device)
+}
+
+func (c *Client) RemoveDevice(ctx context.Context, id protocol.DeviceID) error {
+	return c.delete(ctx, "/rest/config/devices/"+id.String(), nil)
+}
+
+func (c *Client) Options(ctx context.Context) (config.OptionsConfiguration, error) {
+	var opts config.OptionsConfiguration
+	err := c.get(ctx, "/rest/config/options", nil, &opts)
+	return opts, err
+}
+
+func (c *Client) SetOptions(ctx context.Context, opts config.OptionsConfiguration) error {
+	return c.put(ctx, "/rest/config/options", opts)
+}
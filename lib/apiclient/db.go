@@ -0,0 +1,225 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package apiclient
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/model"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// DefaultPerPage is the page size used when iterating over paginated
+// results.
+const DefaultPerPage = 1000
+
+// A File is a file as listed by the db endpoints.
+type File struct {
+	Name          string    `json:"name"`
+	Type          string    `json:"type"`
+	Size          int64     `json:"size"`
+	Deleted       bool      `json:"deleted"`
+	Invalid       bool      `json:"invalid"`
+	Ignored       bool      `json:"ignored"`
+	MustRescan    bool      `json:"mustRescan"`
+	NoPermissions bool      `json:"noPermissions"`
+	Permissions   string    `json:"permissions"`
+	Modified      time.Time `json:"modified"`
+	ModifiedBy    string    `json:"modifiedBy"`
+	Sequence      int64     `json:"sequence"`
+	Version       []string  `json:"version"`
+	LocalFlags    uint32    `json:"localFlags"`
+	NumBlocks     *int      `json:"numBlocks"` // nil if unknown
+}
+
+// A NeedPage is a page of the files needed by the local device, split into
+// those currently being pulled, those queued and the rest.
+type NeedPage struct {
+	Progress []File `json:"progress"`
+	Queued   []File `json:"queued"`
+	Rest     []File `json:"rest"`
+	Page     int    `json:"page"`
+	PerPage  int    `json:"perpage"`
+}
+
+// Files returns all files of the page, in pull order.
+func (p NeedPage) Files() []File {
+	files := make([]File, 0, len(p.Progress)+len(p.Queued)+len(p.Rest))
+	files = append(files, p.Progress...)
+	files = append(files, p.Queued...)
+	return append(files, p.Rest...)
+}
+
+type FilePage struct {
+	Files   []File `json:"files"`
+	Page    int    `json:"page"`
+	PerPage int    `json:"perpage"`
+}
+
+type FileErrorPage struct {
+	Folder  string            `json:"folder"`
+	Errors  []model.FileError `json:"errors"`
+	Page    int               `json:"page"`
+	PerPage int               `json:"perpage"`
+}
+
+// Completion is the completion status of a folder, or all folders, on a
+// device.
+type Completion struct {
+	Completion  float64 `json:"completion"`
+	GlobalBytes int64   `json:"globalBytes"`
+	NeedBytes   int64   `json:"needBytes"`
+	GlobalItems int     `json:"globalItems"`
+	NeedItems   int     `json:"needItems"`
+	NeedDeletes int     `json:"needDeletes"`
+	Sequence    int64   `json:"sequence"`
+	RemoteState string  `json:"remoteState"`
+}
+
+func pageQuery(folder string, page, perpage int) url.Values {
+	return url.Values{
+		"folder":  {folder},
+		"page":    {strconv.Itoa(page)},
+		"perpage": {strconv.Itoa(perpage)},
+	}
+}
+
+// FolderStatus returns the summary of the folder's state and contents.
+func (c *Client) FolderStatus(ctx context.Context, folder string) (model.FolderSummary, error) {
+	var summary model.FolderSummary
+	err := c.get(ctx, "/rest/db/status", url.Values{"folder": {folder}}, &summary)
+	return summary, err
+}
+
+// Completion returns the completion of the folder on the device. An empty
+// folder means all folders, protocol.LocalDeviceID the local device.
+func (c *Client) Completion(ctx context.Context, folder string, device protocol.DeviceID) (Completion, error) {
+	query := url.Values{"folder": {folder}}
+	if device != protocol.LocalDeviceID {
+		query.Set("device", device.String())
+	}
+	var comp Completion
+	err := c.get(ctx, "/rest/db/completion", query, &comp)
+	return comp, err
+}
+
+// Scan requests a scan of the given subdirectories of the folder, or of all
+// of it if none are given.
+func (c *Client) Scan(ctx context.Context, folder string, subs ...string) error {
+	return c.post(ctx, "/rest/db/scan", url.Values{"folder": {folder}, "sub": subs}, nil, nil)
+}
+
+// Override overrides the remote changes of a send only folder.
+func (c *Client) Override(ctx context.Context, folder string) error {
+	return c.post(ctx, "/rest/db/override", url.Values{"folder": {folder}}, nil, nil)
+}
+
+// Revert reverts the local changes of a receive only folder.
+func (c *Client) Revert(ctx context.Context, folder string) error {
+	return c.post(ctx, "/rest/db/revert", url.Values{"folder": {folder}}, nil, nil)
+}
+
+// Need returns a page, starting at one, of the files the local device needs
+// in the folder.
+func (c *Client) Need(ctx context.Context, folder string, page, perpage int) (NeedPage, error) {
+	var res NeedPage
+	err := c.get(ctx, "/rest/db/need", pageQuery(folder, page, perpage), &res)
+	return res, err
+}
+
+// AllNeeded returns all files the local device needs in the folder, in
+// pull order, fetching them page by page.
+func (c *Client) AllNeeded(ctx context.Context, folder string) ([]File, error) {
+	var all []File
+	err := eachPage(func(page int) (int, error) {
+		res, err := c.Need(ctx, folder, page, DefaultPerPage)
+		files := res.Files()
+		all = append(all, files...)
+		return len(files), err
+	})
+	return all, err
+}
+
+// LocalChanged returns a page, starting at one, of the locally changed
+// files of a receive only folder.
+func (c *Client) LocalChanged(ctx context.Context, folder string, page, perpage int) (FilePage, error) {
+	var res FilePage
+	err := c.get(ctx, "/rest/db/localchanged", pageQuery(folder, page, perpage), &res)
+	return res, err
+}
+
+func (c *Client) AllLocalChanged(ctx context.Context, folder string) ([]File, error) {
+	var all []File
+	err := eachPage(func(page int) (int, error) {
+		res, err := c.LocalChanged(ctx, folder, page, DefaultPerPage)
+		all = append(all, res.Files...)
+		return len(res.Files), err
+	})
+	return all, err
+}
+
+// FolderTuning returns the folder's profile and the performance settings
+// resolved from it.
+func (c *Client) FolderTuning(ctx context.Context, folder string) (config.FolderProfile, config.FolderTuning, error) {
+	var res struct {
+		Profile config.FolderProfile `json:"profile"`
+		Tuning  config.FolderTuning  `json:"tuning"`
+	}
+	err := c.get(ctx, "/rest/folder/tuning", url.Values{"folder": {folder}}, &res)
+	return res.Profile, res.Tuning, err
+}
+
+// ScanSummaries returns what the recent scans of the folder found, most
+// recent first.
+func (c *Client) ScanSummaries(ctx context.Context, folder string) ([]model.ScanSummary, error) {
+	var summaries []model.ScanSummary
+	err := c.get(ctx, "/rest/folder/scans", url.Values{"folder": {folder}}, &summaries)
+	return summaries, err
+}
+
+// FolderCapabilities returns what the filesystem of the folder allowed
+// when probed on start.
+func (c *Client) FolderCapabilities(ctx context.Context, folder string) (model.FolderCapabilities, error) {
+	var caps model.FolderCapabilities
+	err := c.get(ctx, "/rest/folder/capabilities", url.Values{"folder": {folder}}, &caps)
+	return caps, err
+}
+
+// FolderErrors returns a page, starting at one, of the folder's errors.
+func (c *Client) FolderErrors(ctx context.Context, folder string, page, perpage int) (FileErrorPage, error) {
+	var res FileErrorPage
+	err := c.get(ctx, "/rest/folder/errors", pageQuery(folder, page, perpage), &res)
+	return res, err
+}
+
+func (c *Client) AllFolderErrors(ctx context.Context, folder string) ([]model.FileError, error) {
+	var all []model.FileError
+	err := eachPage(func(page int) (int, error) {
+		res, err := c.FolderErrors(ctx, folder, page, DefaultPerPage)
+		all = append(all, res.Errors...)
+		return len(res.Errors), err
+	})
+	return all, err
+}
+
+// eachPage calls fetch for consecutive pages of DefaultPerPage items until
+// it returns a partial page or an error.
+func eachPage(fetch func(page int) (int, error)) error {
+	for page := 1; ; page++ {
+		n, err := fetch(page)
+		if err != nil {
+			return err
+		}
+		if n < DefaultPerPage {
+			return nil
+		}
+	}
+}
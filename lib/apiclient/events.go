@@ -0,0 +1,71 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package apiclient
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/events"
+)
+
+// eventPollTimeout is how long the API waits for new events before
+// answering a poll with an empty list.
+const eventPollTimeout = time.Minute
+
+// Events returns the events after the given event ID, waiting up to the
+// timeout for any to happen. Without types, the API's default set of
+// events is returned. Event data is decoded into generic JSON values.
+func (c *Client) Events(ctx context.Context, since int, timeout time.Duration, types ...events.EventType) ([]events.Event, error) {
+	query := url.Values{
+		"since":   {strconv.Itoa(since)},
+		"timeout": {strconv.Itoa(int(timeout / time.Second))},
+	}
+	if len(types) > 0 {
+		names := make([]string, len(types))
+		for i, t := range types {
+			names[i] = t.String()
+		}
+		query.Set("events", strings.Join(names, ","))
+	}
+	var evs []events.Event
+	err := c.get(ctx, "/rest/events", query, &evs)
+	return evs, err
+}
+
+// DiskEvents returns the local and remote change events after the given
+// event ID, waiting up to the timeout for any to happen.
+func (c *Client) DiskEvents(ctx context.Context, since int, timeout time.Duration) ([]events.Event, error) {
+	query := url.Values{
+		"since":   {strconv.Itoa(since)},
+		"timeout": {strconv.Itoa(int(timeout / time.Second))},
+	}
+	var evs []events.Event
+	err := c.get(ctx, "/rest/events/disk", query, &evs)
+	return evs, err
+}
+
+// WatchEvents long-polls for events after the given event ID and calls fn
+// for each of them in order, until the context is canceled or fn returns
+// an error.
+func (c *Client) WatchEvents(ctx context.Context, since int, fn func(events.Event) error, types ...events.EventType) error {
+	for {
+		evs, err := c.Events(ctx, since, eventPollTimeout, types...)
+		if err != nil {
+			return err
+		}
+		for _, ev := range evs {
+			if err := fn(ev); err != nil {
+				return err
+			}
+			since = ev.SubscriptionID
+		}
+	}
+}
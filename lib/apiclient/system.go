@@ -0,0 +1,168 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package apiclient
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/connections"
+	"github.com/syncthing/syncthing/lib/jobs"
+	"github.com/syncthing/syncthing/lib/logger"
+	"github.com/syncthing/syncthing/lib/model"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+type SystemStatus struct {
+	MyID                    protocol.DeviceID                            `json:"myID"`
+	Goroutines              int                                          `json:"goroutines"`
+	Alloc                   uint64                                       `json:"alloc"`
+	Sys                     uint64                                       `json:"sys"`
+	Tilde                   string                                       `json:"tilde"`
+	PathSeparator           string                                       `json:"pathSeparator"`
+	DiscoveryEnabled        bool                                         `json:"discoveryEnabled"`
+	ConnectionServiceStatus map[string]connections.ListenerStatusEntry   `json:"connectionServiceStatus"`
+	LastDialStatus          map[string]connections.ConnectionStatusEntry `json:"lastDialStatus"`
+	UptimeS                 int                                          `json:"uptime"`
+	StartTime               time.Time                                    `json:"startTime"`
+	GUIAddressOverridden    bool                                         `json:"guiAddressOverridden"`
+	GUIAddressUsed          string                                       `json:"guiAddressUsed"`
+}
+
+type Version struct {
+	Version     string   `json:"version"`
+	Codename    string   `json:"codename"`
+	LongVersion string   `json:"longVersion"`
+	Extra       string   `json:"extra"`
+	OS          string   `json:"os"`
+	Arch        string   `json:"arch"`
+	IsBeta      bool     `json:"isBeta"`
+	IsCandidate bool     `json:"isCandidate"`
+	IsRelease   bool     `json:"isRelease"`
+	Date        string   `json:"date"`
+	Tags        []string `json:"tags"`
+	Stamp       string   `json:"stamp"`
+	User        string   `json:"user"`
+	Container   bool     `json:"container"`
+}
+
+// Pairing is what to show for pairing with another device: the payload for
+// a QR code and, given the other device, the short authentication string.
+type Pairing struct {
+	DeviceID protocol.DeviceID    `json:"deviceID"`
+	Name     string               `json:"name"`
+	Payload  string               `json:"payload"`
+	Device   *protocol.DeviceID   `json:"device,omitempty"`
+	SAS      *protocol.PairingSAS `json:"sas,omitempty"`
+}
+
+type Connections struct {
+	Connections map[string]model.ConnectionInfo `json:"connections"`
+	Total       protocol.Statistics             `json:"total"`
+}
+
+// Ping checks that the API is reachable and the API key accepted.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.get(ctx, "/rest/system/ping", nil, nil)
+}
+
+func (c *Client) SystemStatus(ctx context.Context) (SystemStatus, error) {
+	var status SystemStatus
+	err := c.get(ctx, "/rest/system/status", nil, &status)
+	return status, err
+}
+
+func (c *Client) Version(ctx context.Context) (Version, error) {
+	var version Version
+	err := c.get(ctx, "/rest/system/version", nil, &version)
+	return version, err
+}
+
+// Connections returns the connection state and statistics of all
+// configured devices, keyed by device ID.
+func (c *Client) Connections(ctx context.Context) (Connections, error) {
+	var conns Connections
+	err := c.get(ctx, "/rest/system/connections", nil, &conns)
+	return conns, err
+}
+
+// DialAttempts returns the recent dial attempts of each device, oldest
+// first, keyed by device ID.
+func (c *Client) DialAttempts(ctx context.Context) (map[string][]connections.DialAttempt, error) {
+	var attempts map[string][]connections.DialAttempt
+	err := c.get(ctx, "/rest/system/connections/attempts", nil, &attempts)
+	return attempts, err
+}
+
+// Errors returns the errors shown in the GUI.
+func (c *Client) Errors(ctx context.Context) ([]logger.Line, error) {
+	var res struct {
+		Errors []logger.Line `json:"errors"`
+	}
+	err := c.get(ctx, "/rest/system/error", nil, &res)
+	return res.Errors, err
+}
+
+func (c *Client) ClearErrors(ctx context.Context) error {
+	return c.post(ctx, "/rest/system/error/clear", nil, nil, nil)
+}
+
+// PauseDevice pauses the device, or all devices given the empty device ID.
+func (c *Client) PauseDevice(ctx context.Context, device protocol.DeviceID) error {
+	return c.post(ctx, "/rest/system/pause", deviceQuery(device), nil, nil)
+}
+
+// ResumeDevice resumes the device, or all devices given the empty device
+// ID.
+func (c *Client) ResumeDevice(ctx context.Context, device protocol.DeviceID) error {
+	return c.post(ctx, "/rest/system/resume", deviceQuery(device), nil, nil)
+}
+
+func (c *Client) Restart(ctx context.Context) error {
+	return c.post(ctx, "/rest/system/restart", nil, nil, nil)
+}
+
+func (c *Client) Shutdown(ctx context.Context) error {
+	return c.post(ctx, "/rest/system/shutdown", nil, nil, nil)
+}
+
+// Pairing returns the pairing details of this device, including the short
+// authentication string with the given device unless it's empty.
+func (c *Client) Pairing(ctx context.Context, device protocol.DeviceID) (Pairing, error) {
+	var res Pairing
+	err := c.get(ctx, "/rest/svc/pairing", deviceQuery(device), &res)
+	return res, err
+}
+
+// StandbyStatus returns our standby role and the active standbys preferred
+// as sources.
+func (c *Client) StandbyStatus(ctx context.Context) (model.StandbyStatus, error) {
+	var status model.StandbyStatus
+	err := c.get(ctx, "/rest/system/standby", nil, &status)
+	return status, err
+}
+
+// FailBack makes an active standby give up its role to the primary.
+func (c *Client) FailBack(ctx context.Context) error {
+	return c.post(ctx, "/rest/system/standby/failback", nil, nil, nil)
+}
+
+// Jobs returns the schedule and last runs of the periodic jobs, such as
+// scans and database maintenance.
+func (c *Client) Jobs(ctx context.Context) ([]jobs.Status, error) {
+	var status []jobs.Status
+	err := c.get(ctx, "/rest/system/jobs", nil, &status)
+	return status, err
+}
+
+func deviceQuery(device protocol.DeviceID) url.Values {
+	if device == protocol.EmptyDeviceID {
+		return nil
+	}
+	return url.Values{"device": {device.String()}}
+}
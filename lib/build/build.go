@@ -37,6 +37,10 @@ var (
 	LongVersion string
 	Extra       string
 
+	// Set by the "mobile" build tag, for builds targeting mobile and
+	// embedded integrations.
+	IsMobile bool
+
 	allowedVersionExp = regexp.MustCompile(`^v\d+\.\d+\.\d+(-[a-z0-9]+)*(\.\d+)*(\+\d+-g[0-9a-f]+)?(-[^\s]+)?$`)
 
 	envTags = []string{
@@ -0,0 +1,104 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package chaos implements fault injection, to reproduce rare sync issues.
+// It is only active in binaries built with the "chaos" tag; otherwise
+// Enabled is false and the hooks in the protocol and filesystem code are
+// compiled out.
+package chaos
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// ErrInjected is returned by filesystem operations that fail by injection.
+var ErrInjected = errors.New("injected fault")
+
+var errNotEnabled = errors.New("fault injection is not enabled in this build")
+
+// Settings control which faults are injected. Rates are probabilities
+// between zero and one.
+type Settings struct {
+	// Outgoing protocol messages are dropped with this probability,
+	// except for cluster configs and close messages.
+	MessageDropRate float64 `json:"messageDropRate"`
+	// Outgoing protocol messages are delayed by up to this many
+	// milliseconds.
+	MessageDelayMaxMs int `json:"messageDelayMaxMs"`
+	// Filesystem operations that modify files, or open them, fail with
+	// this probability.
+	FSErrorRate float64 `json:"fsErrorRate"`
+}
+
+func (s Settings) validate() error {
+	if s.MessageDropRate < 0 || s.MessageDropRate > 1 || s.FSErrorRate < 0 || s.FSErrorRate > 1 {
+		return errors.New("rates must be between 0 and 1")
+	}
+	if s.MessageDelayMaxMs < 0 {
+		return errors.New("delay must not be negative")
+	}
+	return nil
+}
+
+var (
+	mut      = sync.NewMutex()
+	settings Settings
+	rnd      = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// Get returns the current settings.
+func Get() Settings {
+	mut.Lock()
+	defer mut.Unlock()
+	return settings
+}
+
+// Set replaces the current settings. It fails if fault injection isn't
+// enabled in this build.
+func Set(s Settings) error {
+	if !Enabled {
+		return errNotEnabled
+	}
+	if err := s.validate(); err != nil {
+		return err
+	}
+	mut.Lock()
+	settings = s
+	mut.Unlock()
+	return nil
+}
+
+// DropMessage returns true if an outgoing message should be dropped.
+func DropMessage() bool {
+	mut.Lock()
+	defer mut.Unlock()
+	return settings.MessageDropRate > 0 && rnd.Float64() < settings.MessageDropRate
+}
+
+// MessageDelay returns how long to hold back an outgoing message.
+func MessageDelay() time.Duration {
+	mut.Lock()
+	defer mut.Unlock()
+	if settings.MessageDelayMaxMs <= 0 {
+		return 0
+	}
+	return time.Duration(rnd.Intn(settings.MessageDelayMaxMs+1)) * time.Millisecond
+}
+
+// FSError returns ErrInjected if a filesystem operation should fail, nil
+// otherwise.
+func FSError() error {
+	mut.Lock()
+	defer mut.Unlock()
+	if settings.FSErrorRate > 0 && rnd.Float64() < settings.FSErrorRate {
+		return ErrInjected
+	}
+	return nil
+}
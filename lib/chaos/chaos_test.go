@@ -0,0 +1,53 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package chaos
+
+import (
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	defer func() {
+		mut.Lock()
+		settings = Settings{}
+		mut.Unlock()
+	}()
+
+	if !Enabled {
+		if err := Set(Settings{FSErrorRate: 1}); err == nil {
+			t.Fatal("expected error when not enabled")
+		}
+		if FSError() != nil || DropMessage() || MessageDelay() != 0 {
+			t.Error("expected no faults")
+		}
+		return
+	}
+
+	for _, s := range []Settings{{MessageDropRate: 2}, {FSErrorRate: -1}, {MessageDelayMaxMs: -1}} {
+		if err := Set(s); err == nil {
+			t.Errorf("expected %+v to be invalid", s)
+		}
+	}
+
+	if err := Set(Settings{MessageDropRate: 1, MessageDelayMaxMs: 10, FSErrorRate: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if FSError() != ErrInjected || !DropMessage() {
+		t.Error("expected faults")
+	}
+	for i := 0; i < 100; i++ {
+		if d := MessageDelay(); d < 0 || d > 10e6 {
+			t.Fatal("delay out of range:", d)
+		}
+	}
+	if err := Set(Settings{}); err != nil {
+		t.Fatal(err)
+	}
+	if FSError() != nil || DropMessage() || MessageDelay() != 0 {
+		t.Error("expected no faults")
+	}
+}
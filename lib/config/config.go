@@ -105,6 +105,10 @@ func New(myID protocol.DeviceID) Configuration {
 
 	structutil.SetDefaults(&cfg)
 
+	if build.IsMobile {
+		cfg.ApplyMobileProfile()
+	}
+
 	// Can't happen.
 	if err := cfg.prepare(myID); err != nil {
 		l.Warnln("bug: error in preparing new folder:", err)
@@ -223,6 +227,11 @@ func (cfg Configuration) Copy() Configuration {
 	newCfg.Options = cfg.Options.Copy()
 	newCfg.GUI = cfg.GUI.Copy()
 
+	newCfg.Webhooks = make([]WebhookConfiguration, len(cfg.Webhooks))
+	for i := range newCfg.Webhooks {
+		newCfg.Webhooks[i] = cfg.Webhooks[i].Copy()
+	}
+
 	// DeviceIDs are values
 	newCfg.IgnoredDevices = make([]ObservedDevice, len(cfg.IgnoredDevices))
 	copy(newCfg.IgnoredDevices, cfg.IgnoredDevices)
@@ -257,11 +266,14 @@ func (cfg *Configuration) prepare(myID protocol.DeviceID) error {
 
 	cfg.prepareIgnoredDevices(existingDevices)
 
+	cfg.prepareWebhooks()
+
 	cfg.Defaults.prepare(myID, existingDevices)
 
 	cfg.removeDeprecatedProtocols()
 
 	structutil.FillNilExceptDeprecated(cfg)
+	cfg.prepareExpiries()
 
 	// TestIssue1750 relies on migrations happening after preparing options.
 	cfg.applyMigrations()
@@ -269,6 +281,39 @@ func (cfg *Configuration) prepare(myID protocol.DeviceID) error {
 	return nil
 }
 
+func (cfg *Configuration) prepareWebhooks() {
+	seen := make(map[string]struct{}, len(cfg.Webhooks))
+	webhooks := cfg.Webhooks[:0]
+	for _, hook := range cfg.Webhooks {
+		if hook.ID == "" || hook.URL == "" {
+			l.Warnf("Discarding webhook %q without ID or URL", hook.ID)
+			continue
+		}
+		if _, ok := seen[hook.ID]; ok {
+			l.Warnf("Discarding duplicate webhook %q", hook.ID)
+			continue
+		}
+		seen[hook.ID] = struct{}{}
+		hook.prepare()
+		webhooks = append(webhooks, hook)
+	}
+	cfg.Webhooks = webhooks
+}
+
+// prepareExpiries unsets expiry times that are zero, which must happen after
+// filling nil fields.
+func (cfg *Configuration) prepareExpiries() {
+	for i := range cfg.Devices {
+		cfg.Devices[i].Expires = normalizeExpiry(cfg.Devices[i].Expires)
+	}
+	cfg.Defaults.Device.Expires = normalizeExpiry(cfg.Defaults.Device.Expires)
+	for _, fcfg := range append(cfg.Folders, cfg.Defaults.Folder) {
+		for i := range fcfg.Devices {
+			fcfg.Devices[i].Expires = normalizeExpiry(fcfg.Devices[i].Expires)
+		}
+	}
+}
+
 func (cfg *Configuration) ensureMyDevice(myID protocol.DeviceID) {
 	if myID == protocol.EmptyDeviceID {
 		return
@@ -572,6 +617,18 @@ func ensureNoUntrustedTrustingSharing(f *FolderConfiguration, devices []FolderDe
 	return devices
 }
 
+func ensureNoRevokedSharing(f *FolderConfiguration, devices []FolderDeviceConfiguration, existingDevices map[protocol.DeviceID]*DeviceConfiguration) []FolderDeviceConfiguration {
+	for i := 0; i < len(devices); i++ {
+		if devCfg := existingDevices[devices[i].DeviceID]; devCfg.Revoked {
+			l.Infof("Folder %s (%s) is shared with revoked device %s (%s); unsharing.", f.ID, f.Label, devCfg.DeviceID.Short(), devCfg.Name)
+			copy(devices[i:], devices[i+1:])
+			devices = devices[:len(devices)-1]
+			i--
+		}
+	}
+	return devices
+}
+
 func cleanSymlinks(filesystem fs.Filesystem, dir string) {
 	if build.IsWindows {
 		// We don't do symlinks on Windows. Additionally, there may
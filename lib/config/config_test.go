@@ -20,6 +20,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/d4l3k/messagediff"
 
@@ -96,6 +97,11 @@ func TestDefaultValues(t *testing.T) {
 			ConnectionPriorityTCPWAN:  30,
 			ConnectionPriorityQUICWAN: 40,
 			ConnectionPriorityRelay:   50,
+			LogLevels:                 []string{},
+			UnmeteredNetworks:         []string{},
+			BlockPoolScrubIntervalS:   604800,
+			StandbyTakeoverS:          300,
+			RestorePullHints:          true,
 		},
 		Defaults: Defaults{
 			Folder: FolderConfiguration{
@@ -122,6 +128,14 @@ func TestDefaultValues(t *testing.T) {
 					MaxSingleEntrySize: 1024,
 					MaxTotalSize:       4096,
 				},
+				BandwidthWeight:       1,
+				WarmCachePatterns:     []string{},
+				CompletionDirectories: []string{},
+				ExtraStagingPaths:     []string{},
+				HeldPaths:             []string{},
+				ScanHookTimeoutS:      60,
+				EmptyFolderGuardFiles: 100,
+				RemovalGraceS:         604800,
 			},
 			Device: DeviceConfiguration{
 				Addresses:       []string{"dynamic"},
@@ -134,6 +148,7 @@ func TestDefaultValues(t *testing.T) {
 			},
 		},
 		IgnoredDevices: []ObservedDevice{},
+		Webhooks:       []WebhookConfiguration{},
 	}
 	expected.Devices = []DeviceConfiguration{expected.Defaults.Device.Copy()}
 	expected.Devices[0].DeviceID = device1
@@ -196,6 +211,11 @@ func TestDeviceConfig(t *testing.T) {
 				XattrFilter: XattrFilter{
 					Entries: []XattrFilterEntry{},
 				},
+				BandwidthWeight:       1,
+				WarmCachePatterns:     []string{},
+				CompletionDirectories: []string{},
+				ExtraStagingPaths:     []string{},
+				HeldPaths:             []string{},
 			},
 		}
 
@@ -294,6 +314,14 @@ func TestOverriddenValues(t *testing.T) {
 		ConnectionPriorityTCPWAN:  50,
 		ConnectionPriorityQUICWAN: 55,
 		ConnectionPriorityRelay:   9000,
+		LogLevels:                 []string{"model:debug"},
+		ControllerDeviceID:        device2,
+		ControllerToken:           "token",
+		BlockPoolScrubIntervalS:   3600,
+		StandbyPrimaryID:          device2,
+		StandbyTakeoverS:          600,
+		RestorePullHints:          false,
+		UnmeteredNetworks:         []string{"192.168.1.0/24"},
 	}
 	expectedPath := "/media/syncthing"
 
@@ -564,6 +592,7 @@ func TestNewSaveLoad(t *testing.T) {
 	path := "temp.xml"
 	os.Remove(path)
 	defer os.Remove(path)
+	defer os.RemoveAll(path + historyDirSuffix)
 
 	exists := func(path string) bool {
 		_, err := os.Stat(path)
@@ -597,6 +626,143 @@ func TestNewSaveLoad(t *testing.T) {
 	}
 }
 
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.xml")
+
+	orig := New(device1)
+	orig.Options.MaxSendKbps = 100
+	fd, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := orig.WriteXML(fd); err != nil {
+		t.Fatal(err)
+	}
+	fd.Close()
+
+	cfg, err := load(path, device1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cfg.stop()
+
+	setKbps := func(kbps int) {
+		t.Helper()
+		waiter, err := cfg.Modify(func(cfg *Configuration) {
+			cfg.Options.MaxSendKbps = kbps
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		waiter.Wait()
+		if err := cfg.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The first save also stores the original config. Saving an unchanged
+	// config doesn't add an entry.
+
+	setKbps(200)
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	setKbps(300)
+
+	entries, err := cfg.History()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected three history entries, got %v", entries)
+	}
+	for i, entry := range entries {
+		if entry.ID != i+1 {
+			t.Errorf("entry %d has ID %d", i, entry.ID)
+		}
+	}
+
+	first, err := cfg.HistoryVersion(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Options.MaxSendKbps != 100 {
+		t.Errorf("expected the original config as first entry, got MaxSendKbps %d", first.Options.MaxSendKbps)
+	}
+	if _, err := cfg.HistoryVersion(42); err != ErrNoSuchHistoryEntry {
+		t.Errorf("expected ErrNoSuchHistoryEntry, got %v", err)
+	}
+
+	diff, err := Diff(first, cfg.RawCopy(), "1", "current")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "-        <maxSendKbps>100</maxSendKbps>\n+        <maxSendKbps>300</maxSendKbps>\n") {
+		t.Errorf("unexpected diff:\n%s", diff)
+	}
+	if diff, _ := Diff(first, first, "1", "1"); diff != "" {
+		t.Errorf("expected no diff between the same configs, got:\n%s", diff)
+	}
+
+	// Rolling back is a change of its own, recorded in the history.
+
+	waiter, err := cfg.Rollback(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waiter.Wait()
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if kbps := cfg.Options().MaxSendKbps; kbps != 200 {
+		t.Errorf("expected MaxSendKbps 200 after rollback, got %d", kbps)
+	}
+	if entries, _ := cfg.History(); len(entries) != 4 {
+		t.Errorf("expected four history entries, got %v", entries)
+	}
+
+	// The number of entries is bounded.
+
+	for i := 0; i < maxHistoryEntries; i++ {
+		setKbps(1000 + i)
+	}
+	entries, err = cfg.History()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != maxHistoryEntries {
+		t.Errorf("expected %d history entries, got %d", maxHistoryEntries, len(entries))
+	}
+	if entries[0].ID != 5 {
+		t.Errorf("expected the oldest entries to be removed, first is %d", entries[0].ID)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	a := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"}
+	b := []string{"a", "b", "c", "D", "e", "f", "g", "h", "i", "j", "k", "l", "m"}
+	expected := `--- a
++++ b
+@@ -1,7 +1,7 @@
+ a
+ b
+ c
+-d
++D
+ e
+ f
+ g
+@@ -10,3 +10,4 @@
+ j
+ k
+ l
++m
+`
+	if diff := unifiedDiff(a, b, "a", "b"); diff != expected {
+		t.Errorf("unexpected diff:\n%s", diff)
+	}
+}
+
 func TestWindowsLineEndings(t *testing.T) {
 	if !build.IsWindows {
 		t.Skip("Windows specific")
@@ -770,6 +936,45 @@ func TestGUIConfigURL(t *testing.T) {
 	}
 }
 
+func TestGUIConfigPathPrefix(t *testing.T) {
+	testcases := [][2]string{
+		{"", "http://127.0.0.1:8080/"},
+		{"/", "http://127.0.0.1:8080/"},
+		{"syncthing", "http://127.0.0.1:8080/syncthing/"},
+		{"/syncthing/", "http://127.0.0.1:8080/syncthing/"},
+		{"/a//b/../c", "http://127.0.0.1:8080/a/c/"},
+	}
+
+	for _, tc := range testcases {
+		c := GUIConfiguration{
+			RawAddress:    "127.0.0.1:8080",
+			RawPathPrefix: tc[0],
+		}
+		u := c.URL()
+		if u != tc[1] {
+			t.Errorf("Incorrect URL %s != %s for prefix %s", u, tc[1], tc[0])
+		}
+	}
+}
+
+func TestWarmsCache(t *testing.T) {
+	cfg := FolderConfiguration{WarmCachePatterns: []string{"*.db", "models/*.bin"}}
+	cases := map[string]bool{
+		"app.db":            true,
+		"sub/dir/app.db":    true,
+		"models/large.bin":  true,
+		"large.bin":         false,
+		"sub/models/a.bin":  false,
+		"app.db-journal":    false,
+		"models/large.json": false,
+	}
+	for name, expected := range cases {
+		if cfg.WarmsCache(name) != expected {
+			t.Errorf("%v: expected %v", name, expected)
+		}
+	}
+}
+
 func TestGUIPasswordHash(t *testing.T) {
 	var c GUIConfiguration
 
@@ -1349,6 +1554,82 @@ func loadWrapTest(fs fs.Filesystem, path string, myID protocol.DeviceID, evLogge
 	return Wrap(filepath.Join(testFs.URI(), path), cfg, myID, evLogger), originalVersion, nil
 }
 
+func TestReloadMerge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.xml")
+	write := func(cfg Configuration) {
+		t.Helper()
+		fd, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cfg.WriteXML(fd); err != nil {
+			t.Fatal(err)
+		}
+		fd.Close()
+	}
+
+	orig := New(device1)
+	orig.Folders = []FolderConfiguration{{ID: "a", Path: "a"}, {ID: "b", Path: "b"}}
+	write(orig)
+
+	cfg, err := load(path, device1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cfg.stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloader := NewReloader(cfg, ReloadMerge, 10*time.Millisecond)
+	go reloader.Serve(ctx)
+
+	// A folder and a device added at runtime are kept, as the file doesn't
+	// know them. A folder removed from the file is removed.
+
+	waiter, err := cfg.Modify(func(cfg *Configuration) {
+		cfg.SetFolder(FolderConfiguration{ID: "runtime", Path: "runtime"})
+		cfg.SetDevice(DeviceConfiguration{DeviceID: device3, Name: "runtime"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waiter.Wait()
+
+	changed := orig.Copy()
+	changed.Folders = changed.Folders[:1]
+	changed.Options.MaxSendKbps = 500
+	write(changed)
+
+	deadline := time.Now().Add(10 * time.Second)
+	for cfg.Options().MaxSendKbps != 500 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the config to be reloaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	folders := cfg.Folders()
+	if _, ok := folders["a"]; !ok {
+		t.Error("expected folder from the file")
+	}
+	if _, ok := folders["b"]; ok {
+		t.Error("expected folder removed from the file to be removed")
+	}
+	if _, ok := folders["runtime"]; !ok {
+		t.Error("expected folder added at runtime to be kept")
+	}
+	if _, ok := cfg.Device(device3); !ok {
+		t.Error("expected device added at runtime to be kept")
+	}
+
+	// With the replace policy, only what's in the file remains.
+
+	replaced := reloadedConfig(ReloadReplace, cfg.RawCopy(), changed, changed.Copy())
+	if len(replaced.Folders) != 1 || replaced.Folders[0].ID != "a" {
+		t.Errorf("expected only the folder from the file, got %v", replaced.Folders)
+	}
+}
+
 func load(path string, myID protocol.DeviceID) (*testWrapper, error) {
 	cfg, _, err := Load(path, myID, events.NoopLogger)
 	if err != nil {
@@ -1548,6 +1829,65 @@ func TestUntrustedIntroducer(t *testing.T) {
 	}
 }
 
+func TestRevokedDevice(t *testing.T) {
+	cfg := New(device1)
+	cfg.Devices = append(cfg.Devices, DeviceConfiguration{DeviceID: device2, Introducer: true, Revoked: true})
+	cfg.Folders = append(cfg.Folders, FolderConfiguration{
+		ID:      "a",
+		Path:    "a",
+		Devices: []FolderDeviceConfiguration{{DeviceID: device1}, {DeviceID: device2}},
+	})
+	if err := cfg.prepare(device1); err != nil {
+		t.Fatal(err)
+	}
+
+	if folder := cfg.FolderMap()["a"]; folder.SharedWith(device2) {
+		t.Error("folder should not be shared with revoked device")
+	}
+	dev, _, _ := cfg.Device(device2)
+	if !dev.Paused || dev.Introducer {
+		t.Errorf("revoked device should be paused and not an introducer, got %+v", dev)
+	}
+
+	// A device to be wiped is connected to.
+	dev.WipeOnConnect = true
+	cfg.SetDevice(dev)
+	if err := cfg.prepare(device1); err != nil {
+		t.Fatal(err)
+	}
+	if dev, _, _ := cfg.Device(device2); dev.Paused {
+		t.Error("revoked device with pending wipe should not be paused")
+	}
+}
+
+func TestAtRestEncryptionPrepare(t *testing.T) {
+	cfg := New(device1)
+	cfg.Folders = append(cfg.Folders, FolderConfiguration{
+		ID:               "a",
+		Path:             "a",
+		AtRestEncryption: true,
+		Versioning:       VersioningConfiguration{Type: "simple"},
+		RecycleDays:      7,
+		StagingPath:      "staging",
+	}, FolderConfiguration{
+		ID:               "b",
+		Path:             "b",
+		Type:             FolderTypeReceiveEncrypted,
+		AtRestEncryption: true,
+	})
+	if err := cfg.prepare(device1); err != nil {
+		t.Fatal(err)
+	}
+
+	folders := cfg.FolderMap()
+	if a := folders["a"]; !a.AtRestEncryption || a.Versioning.Type != "" || a.RecycleDays != 0 || a.StagingPath != "" {
+		t.Errorf("expected versioning and staging to be disabled, got %+v", a)
+	}
+	if folders["b"].AtRestEncryption {
+		t.Error("receive encrypted folder should not be encrypted at rest")
+	}
+}
+
 // Verify that opening a config with myID == protocol.EmptyDeviceID doesn't add that ID to the config.
 // Done in various places where config is needed, but the device ID isn't known.
 func TestLoadEmptyDeviceID(t *testing.T) {
@@ -1655,3 +1995,126 @@ func TestCopyMatching(t *testing.T) {
 		t.Error("NoCopy")
 	}
 }
+
+func TestMobileProfile(t *testing.T) {
+	cfg := New(device1)
+	cfg.ApplyMobileProfile()
+
+	opts := cfg.Options
+	if opts.RelaysEnabled || opts.DatabaseTuning != TuningSmall || opts.MaxFolderConcurrency() != 1 || opts.MaxConcurrentIncomingRequestKiB() != mobileMaxCIRequestKiB {
+		t.Errorf("unexpected options %+v", opts)
+	}
+	if cfg.Defaults.Folder.Hashers != 1 || cfg.Defaults.Folder.Copiers != 1 {
+		t.Errorf("unexpected folder defaults %+v", cfg.Defaults.Folder)
+	}
+}
+
+func TestFolderProfileTuning(t *testing.T) {
+	fcfg := FolderConfiguration{
+		ID:                   "f",
+		Order:                PullOrderLargestFirst,
+		Copiers:              3,
+		WeakHashThresholdPct: 25,
+	}
+
+	if tuned := fcfg.Tuned(); tuned.Order != PullOrderLargestFirst || tuned.Copiers != 3 || tuned.WeakHashThresholdPct != 25 {
+		t.Errorf("custom profile changed the settings: %+v", tuned.Tuning())
+	}
+
+	fcfg.Profile = FolderProfileSmallFiles
+	tuned := fcfg.Tuned()
+	if tuned.Order != PullOrderSmallestFirst || tuned.Copiers != 4 || !tuned.DisableFsync || tuned.WeakHashThresholdPct != weakHashNever {
+		t.Errorf("profile not applied: %+v", tuned.Tuning())
+	}
+	if tuned.Tuning() != fcfg.Tuning() {
+		t.Error("resolving the tuning again changed it")
+	}
+
+	var profile FolderProfile
+	for _, p := range []FolderProfile{FolderProfileCustom, FolderProfileMedia, FolderProfileSourceCode, FolderProfileVmImages, FolderProfileSmallFiles} {
+		bs, _ := p.MarshalText()
+		if err := profile.UnmarshalText(bs); err != nil || profile != p {
+			t.Errorf("profile %v didn't survive marshalling as %q", p, bs)
+		}
+	}
+}
+
+// roundTripXML writes the config as XML and reads it back, returning both.
+func roundTripXML(t *testing.T, cfg Configuration) (string, Configuration) {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := cfg.WriteXML(buf); err != nil {
+		t.Fatal(err)
+	}
+	written := buf.String()
+	read, _, err := ReadXML(buf, device1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return written, read
+}
+
+func TestDeviceExpiresRoundTrip(t *testing.T) {
+	cfg := New(device1)
+	cfg.Devices = append(cfg.Devices, cfg.Defaults.Device.Copy())
+	cfg.Devices[1].DeviceID = device2
+
+	// Without an expiry time nothing is written, also not for configs
+	// where older versions wrote the zero time.
+	cfg.Devices[1].Expires = &time.Time{}
+	_, read := roundTripXML(t, cfg)
+	written, read := roundTripXML(t, read)
+	if strings.Contains(written, "<expires>") {
+		t.Errorf("expected no expiry time to be written, got\n%s", written)
+	}
+	for _, dev := range append(read.Devices, read.Defaults.Device) {
+		if bs, _ := json.Marshal(dev); bytes.Contains(bs, []byte("expires")) {
+			t.Errorf("expected no expiry time in JSON, got %s", bs)
+		}
+		if dev.Expires != nil {
+			t.Errorf("expected no expiry time for %v, got %v", dev.DeviceID, dev.Expires)
+		}
+	}
+
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	cfg.Devices[1].Expires = &expires
+	_, read = roundTripXML(t, cfg)
+	dev, _, _ := read.Device(device2)
+	if dev.Expires == nil || !dev.Expires.Equal(expires) {
+		t.Errorf("expected expiry time %v, got %v", expires, dev.Expires)
+	}
+}
+
+func TestFolderDeviceExpiresRoundTrip(t *testing.T) {
+	cfg := New(device1)
+	fcfg := cfg.Defaults.Folder.Copy()
+	fcfg.ID = "folder"
+	fcfg.Devices = append(fcfg.Devices, FolderDeviceConfiguration{DeviceID: device2})
+	cfg.Folders = append(cfg.Folders, fcfg)
+	cfg.Devices = append(cfg.Devices, DeviceConfiguration{DeviceID: device2})
+
+	// Without an expiry time nothing is written, also not for configs
+	// where older versions wrote the zero time.
+	cfg.Folders[0].Devices[1].Expires = &time.Time{}
+	_, read := roundTripXML(t, cfg)
+	written, read := roundTripXML(t, read)
+	if strings.Contains(written, "expires") {
+		t.Errorf("expected no expiry time to be written, got\n%s", written)
+	}
+	if bs, _ := json.Marshal(read); bytes.Contains(bs, []byte("expires")) {
+		t.Errorf("expected no expiry time in JSON, got %s", bs)
+	}
+	for _, dev := range read.Folders[0].Devices {
+		if dev.Expires != nil {
+			t.Errorf("expected no expiry time for %v, got %v", dev.DeviceID, dev.Expires)
+		}
+	}
+
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	cfg.Folders[0].Devices[1].Expires = &expires
+	_, read = roundTripXML(t, cfg)
+	dev, _ := read.Folders[0].Device(device2)
+	if dev.Expires == nil || !dev.Expires.Equal(expires) {
+		t.Errorf("expected expiry time %v, got %v", expires, dev.Expires)
+	}
+}
@@ -9,6 +9,7 @@ package config
 import (
 	"fmt"
 	"sort"
+	"time"
 )
 
 func (cfg DeviceConfiguration) Copy() DeviceConfiguration {
@@ -35,6 +36,16 @@ func (cfg *DeviceConfiguration) prepare(sharedFolders []string) {
 
 	cfg.IgnoredFolders = sortedObservedFolderSlice(ignoredFolders)
 
+	// A revoked device doesn't get to introduce devices or folders, and is
+	// only connected to if a wipe instruction is still to be delivered.
+	if cfg.Revoked {
+		cfg.Introducer = false
+		cfg.AutoAcceptFolders = false
+		cfg.Paused = !cfg.WipeOnConnect
+	} else {
+		cfg.WipeOnConnect = false
+	}
+
 	// A device cannot be simultaneously untrusted and an introducer, nor
 	// auto accept folders.
 	if cfg.Untrusted {
@@ -49,6 +60,22 @@ func (cfg *DeviceConfiguration) prepare(sharedFolders []string) {
 	}
 }
 
+// HasExpired returns true if the device has an expiry time set, and that time
+// has passed at the given point in time.
+func (cfg DeviceConfiguration) HasExpired(now time.Time) bool {
+	return cfg.Expires != nil && !cfg.Expires.IsZero() && !now.Before(*cfg.Expires)
+}
+
+// normalizeExpiry returns nil for an unset expiry time, as allocated when
+// filling nil fields or written by older versions, so that it's left out
+// when the config is saved.
+func normalizeExpiry(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return t
+}
+
 func (cfg *DeviceConfiguration) IgnoredFolder(folder string) bool {
 	for _, ignoredFolder := range cfg.IgnoredFolders {
 		if ignoredFolder.ID == folder {
@@ -5,19 +5,24 @@ package config
 
 import (
 	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 	github_com_syncthing_syncthing_lib_protocol "github.com/syncthing/syncthing/lib/protocol"
 	protocol "github.com/syncthing/syncthing/lib/protocol"
 	_ "github.com/syncthing/syncthing/proto/ext"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
 	io "io"
 	math "math"
 	math_bits "math/bits"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -44,6 +49,13 @@ type DeviceConfiguration struct {
 	MaxRequestKiB            int                                                  `protobuf:"varint,16,opt,name=max_request_kib,json=maxRequestKib,proto3,casttype=int" json:"maxRequestKiB" xml:"maxRequestKiB"`
 	Untrusted                bool                                                 `protobuf:"varint,17,opt,name=untrusted,proto3" json:"untrusted" xml:"untrusted"`
 	RemoteGUIPort            int                                                  `protobuf:"varint,18,opt,name=remote_gui_port,json=remoteGuiPort,proto3,casttype=int" json:"remoteGUIPort" xml:"remoteGUIPort"`
+	Expires                  *time.Time                                           `protobuf:"bytes,19,opt,name=expires,proto3,stdtime" json:"expires,omitempty" xml:"expires,omitempty"`
+	ManagementToken          string                                               `protobuf:"bytes,20,opt,name=management_token,json=managementToken,proto3" json:"managementToken" xml:"managementToken,omitempty"`
+	Revoked                  bool                                                 `protobuf:"varint,21,opt,name=revoked,proto3" json:"revoked" xml:"revoked"`
+	WipeOnConnect            bool                                                 `protobuf:"varint,22,opt,name=wipe_on_connect,json=wipeOnConnect,proto3" json:"wipeOnConnect" xml:"wipeOnConnect"`
+	CoordinateRestarts       bool                                                 `protobuf:"varint,23,opt,name=coordinate_restarts,json=coordinateRestarts,proto3" json:"coordinateRestarts" xml:"coordinateRestarts"`
+	Metered                  bool                                                 `protobuf:"varint,24,opt,name=metered,proto3" json:"metered" xml:"metered"`
+	MaxPullRequests          int                                                  `protobuf:"varint,25,opt,name=max_pull_requests,json=maxPullRequests,proto3,casttype=int" json:"maxPullRequests" xml:"maxPullRequests"`
 }
 
 func (m *DeviceConfiguration) Reset()         { *m = DeviceConfiguration{} }
@@ -88,72 +100,89 @@ func init() {
 }
 
 var fileDescriptor_744b782bd13071dd = []byte{
-	// 1026 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xbf, 0x6f, 0xdb, 0x46,
-	0x18, 0x15, 0xeb, 0xc4, 0xb6, 0xce, 0x3f, 0x64, 0xd3, 0x88, 0xc3, 0x18, 0x88, 0x4e, 0x50, 0x35,
-	0x28, 0x68, 0x22, 0x17, 0x6e, 0x27, 0xa3, 0x2d, 0x50, 0xc6, 0x68, 0x63, 0x18, 0x4d, 0x5c, 0x16,
-	0x5d, 0xbc, 0xb0, 0x24, 0xef, 0xac, 0x1c, 0x2c, 0xf2, 0x58, 0xf2, 0xa8, 0x58, 0x40, 0xff, 0x80,
-	0x76, 0x2b, 0x02, 0x74, 0xea, 0x92, 0xf6, 0xdf, 0xe8, 0xd0, 0xd5, 0x9b, 0x35, 0x16, 0x1d, 0x0e,
-	0x88, 0xbd, 0x71, 0x29, 0xc0, 0x31, 0x53, 0x71, 0x77, 0x14, 0x45, 0xca, 0x51, 0x50, 0xa0, 0x1b,
-	0xef, 0xbd, 0x77, 0xef, 0xdd, 0xf7, 0xe9, 0xbb, 0x13, 0xe8, 0x0c, 0x88, 0xbb, 0xeb, 0xd1, 0xe0,
-	0x94, 0xf4, 0x77, 0x11, 0x1e, 0x12, 0x0f, 0xab, 0x45, 0x12, 0x39, 0x8c, 0xd0, 0xa0, 0x17, 0x46,
-	0x94, 0x51, 0x7d, 0x51, 0x81, 0x3b, 0xdb, 0x42, 0x2d, 0x21, 0x8f, 0x0e, 0x76, 0x5d, 0x1c, 0x2a,
-	0x7e, 0xe7, 0x5e, 0xc9, 0x85, 0xba, 0x31, 0x8e, 0x86, 0x18, 0xe5, 0x54, 0x1d, 0x9f, 0x33, 0xf5,
-	0xd9, 0xfe, 0x67, 0x03, 0x6c, 0x1d, 0xc8, 0x8c, 0xc7, 0xe5, 0x0c, 0xfd, 0x4f, 0x0d, 0xd4, 0x55,
-	0xb6, 0x4d, 0x90, 0xa1, 0xb5, 0xb4, 0xee, 0xaa, 0xf9, 0x9b, 0x76, 0xc1, 0x61, 0xed, 0x6f, 0x0e,
-	0x3f, 0xee, 0x13, 0xf6, 0x3c, 0x71, 0x7b, 0x1e, 0xf5, 0x77, 0xe3, 0x51, 0xe0, 0xb1, 0xe7, 0x24,
-	0xe8, 0x97, 0xbe, 0xca, 0x27, 0xea, 0x29, 0xf7, 0xc3, 0x83, 0x2b, 0x0e, 0x97, 0x27, 0xdf, 0x29,
-	0x87, 0xcb, 0x28, 0xff, 0xce, 0x38, 0x6c, 0x9e, 0xfb, 0x83, 0xfd, 0x36, 0x41, 0x0f, 0x1d, 0xc6,
-	0xa2, 0x76, 0x2b, 0xa0, 0x08, 0x9f, 0x3a, 0xc9, 0x80, 0xed, 0xb7, 0x59, 0x94, 0xe0, 0x76, 0x7a,
-	0xd9, 0x59, 0xca, 0xc9, 0xec, 0xb2, 0x53, 0x6c, 0xfc, 0x71, 0xdc, 0xd1, 0x5e, 0x8e, 0x3b, 0x85,
-	0xe9, 0xab, 0x71, 0x47, 0xb3, 0x26, 0x2c, 0xd2, 0x8f, 0xc1, 0xad, 0xc0, 0xf1, 0xb1, 0xf1, 0x5e,
-	0x4b, 0xeb, 0xd6, 0xcd, 0x4f, 0x52, 0x0e, 0xe5, 0x3a, 0xe3, 0xf0, 0x9e, 0x8c, 0x13, 0x0b, 0xe9,
-	0xf9, 0x90, 0xfa, 0x84, 0x61, 0x3f, 0x64, 0x23, 0x91, 0xb4, 0xf5, 0x16, 0xdc, 0x92, 0x3b, 0xf5,
-	0x73, 0x50, 0x77, 0x10, 0x8a, 0x70, 0x1c, 0xe3, 0xd8, 0x58, 0x68, 0x2d, 0x74, 0xeb, 0xe6, 0x49,
-	0xca, 0xe1, 0x14, 0xcc, 0x38, 0x7c, 0x20, 0xbd, 0x73, 0xa4, 0xe4, 0xdc, 0x2a, 0x4a, 0x42, 0xa3,
-	0xc0, 0xf1, 0x89, 0x27, 0xb2, 0x36, 0x6f, 0xe8, 0xde, 0x5c, 0x76, 0x96, 0x72, 0x81, 0x35, 0xf5,
-	0xd5, 0x87, 0x60, 0xc5, 0xa3, 0x7e, 0x28, 0x56, 0x84, 0x06, 0xc6, 0xad, 0x96, 0xd6, 0x5d, 0xdf,
-	0xbb, 0xd3, 0x2b, 0x7a, 0xfc, 0x78, 0x4a, 0x9a, 0x9f, 0xa6, 0x1c, 0x96, 0xd5, 0x19, 0x87, 0xdb,
-	0xf2, 0x50, 0x25, 0x4c, 0x35, 0x3a, 0xbd, 0xec, 0x6c, 0xcc, 0x82, 0x56, 0x79, 0xab, 0x8e, 0x41,
-	0xdd, 0xc3, 0x11, 0xb3, 0x65, 0x23, 0x6f, 0xcb, 0x46, 0x3e, 0x11, 0xbf, 0x9d, 0x00, 0x9f, 0xaa,
-	0x66, 0xde, 0x57, 0xde, 0x39, 0xf0, 0x96, 0x86, 0xde, 0x9d, 0xc3, 0x59, 0x85, 0x8b, 0x7e, 0x02,
-	0x00, 0x09, 0x58, 0x44, 0x51, 0xe2, 0xe1, 0xc8, 0x58, 0x6c, 0x69, 0xdd, 0x65, 0x73, 0x3f, 0xe5,
-	0xb0, 0x84, 0x66, 0x1c, 0xde, 0x51, 0x53, 0x52, 0x40, 0x45, 0x11, 0x8d, 0x19, 0xcc, 0x2a, 0xed,
-	0xd3, 0x7f, 0xd7, 0xc0, 0x4e, 0x7c, 0x46, 0x42, 0x7b, 0x82, 0x89, 0xf1, 0xb6, 0x23, 0xec, 0xd3,
-	0xa1, 0x33, 0x88, 0x8d, 0x25, 0x19, 0x86, 0x52, 0x0e, 0x0d, 0xa1, 0x3a, 0x2c, 0x89, 0xac, 0x5c,
-	0x93, 0x71, 0xf8, 0xbe, 0x8c, 0x9e, 0x27, 0x28, 0x0e, 0x72, 0xff, 0x9d, 0x0a, 0x6b, 0x6e, 0x82,
-	0xfe, 0x87, 0x06, 0xd6, 0x8a, 0x33, 0x23, 0xdb, 0x1d, 0x19, 0xcb, 0xf2, 0xc6, 0xfd, 0xf2, 0xbf,
-	0x6e, 0x5c, 0xca, 0xe1, 0xea, 0xd4, 0xd5, 0x1c, 0x65, 0x1c, 0x76, 0xab, 0x3d, 0x44, 0xe6, 0x68,
-	0xfe, 0x9d, 0xdb, 0xbc, 0x21, 0x13, 0x37, 0x4e, 0xde, 0xb2, 0x8a, 0xad, 0xbe, 0x07, 0x16, 0x43,
-	0x27, 0x89, 0x31, 0x32, 0xea, 0xb2, 0x9b, 0x3b, 0x29, 0x87, 0x39, 0x92, 0x71, 0xb8, 0x2a, 0x23,
-	0xd5, 0xb2, 0x6d, 0xe5, 0xb8, 0xfe, 0x03, 0xd8, 0x70, 0x06, 0x03, 0xfa, 0x02, 0x23, 0x3b, 0xc0,
-	0xec, 0x05, 0x8d, 0xce, 0x62, 0x03, 0xc8, 0x2b, 0xf5, 0x75, 0xca, 0x61, 0x23, 0xe7, 0x9e, 0xe6,
-	0x54, 0xf1, 0x46, 0x54, 0xf1, 0xea, 0xa0, 0x19, 0xf3, 0x48, 0x6b, 0xd6, 0x4e, 0xff, 0x0e, 0x6c,
-	0x39, 0x09, 0xa3, 0xb6, 0xe3, 0x79, 0x38, 0x64, 0xf6, 0x29, 0x1d, 0x20, 0x1c, 0xc5, 0xc6, 0x8a,
-	0x3c, 0xfe, 0x87, 0x29, 0x87, 0x9b, 0x82, 0xfe, 0x5c, 0xb2, 0x5f, 0x28, 0x32, 0xe3, 0xf0, 0xae,
-	0x3a, 0xc2, 0x2c, 0xd3, 0xb6, 0x6e, 0xaa, 0xf5, 0x67, 0x60, 0xcd, 0x77, 0xce, 0xed, 0x18, 0x07,
-	0xc8, 0x3e, 0x73, 0xc3, 0xd8, 0x58, 0x6d, 0x69, 0xdd, 0xdb, 0xe6, 0x07, 0xe2, 0x72, 0xfa, 0xce,
-	0xf9, 0x37, 0x38, 0x40, 0x47, 0x6e, 0x28, 0x5c, 0x37, 0xa5, 0x6b, 0x09, 0x6b, 0xbf, 0xe1, 0x70,
-	0x81, 0x04, 0xcc, 0x2a, 0x0b, 0x27, 0x86, 0x11, 0xf6, 0x86, 0xca, 0x70, 0xad, 0x62, 0x68, 0x61,
-	0x6f, 0x38, 0x6b, 0x38, 0xc1, 0x2a, 0x86, 0x13, 0x50, 0x0f, 0x40, 0x83, 0xf4, 0x03, 0x1a, 0x61,
-	0x54, 0xd4, 0xbf, 0xde, 0x5a, 0xe8, 0xae, 0xec, 0x6d, 0xf7, 0xd4, 0xbf, 0x46, 0xef, 0x59, 0xfe,
-	0xaf, 0xa1, 0x6a, 0x32, 0x1f, 0x89, 0x59, 0x4c, 0x39, 0x5c, 0xcf, 0xb7, 0x4d, 0x1b, 0xb3, 0xa5,
-	0xa6, 0xaa, 0x0c, 0xb7, 0xad, 0x19, 0x99, 0xfe, 0x93, 0x06, 0x1a, 0x21, 0x0e, 0x10, 0x09, 0xfa,
-	0x45, 0x60, 0xe3, 0x9d, 0x81, 0x4f, 0x44, 0xe0, 0x15, 0x87, 0xc6, 0x01, 0x0e, 0x23, 0xec, 0x39,
-	0x0c, 0xa3, 0x63, 0x65, 0x90, 0x7b, 0xa6, 0x1c, 0x6a, 0x8f, 0x8a, 0x37, 0x28, 0x2c, 0x73, 0xa5,
-	0xd1, 0x30, 0x34, 0x6b, 0xbd, 0xc2, 0xc5, 0xfa, 0xaf, 0x1a, 0x68, 0xa8, 0x6e, 0x7e, 0x9f, 0xe0,
-	0x98, 0xd9, 0x67, 0xc4, 0x35, 0x36, 0x64, 0x3f, 0xe3, 0x2b, 0x0e, 0xd7, 0xbe, 0x12, 0x6d, 0x92,
-	0xcc, 0x11, 0x31, 0x53, 0x0e, 0xd7, 0xfc, 0x32, 0x50, 0x14, 0x5c, 0x41, 0x27, 0x4d, 0x4e, 0x2f,
-	0x3b, 0x33, 0xf2, 0x59, 0xe0, 0xe5, 0xb8, 0x53, 0x4d, 0xb0, 0x2a, 0xbc, 0xab, 0x7f, 0x06, 0xea,
-	0x49, 0xc0, 0xa2, 0x24, 0x66, 0x18, 0x19, 0x9b, 0x72, 0x26, 0x5b, 0xe2, 0x7f, 0xa6, 0x00, 0x33,
-	0x0e, 0x1b, 0xf2, 0x04, 0x05, 0xd2, 0xb6, 0xa6, 0xac, 0xac, 0x4e, 0x3c, 0x70, 0x0c, 0xdb, 0xfd,
-	0x84, 0xd8, 0x21, 0x8d, 0x98, 0xa1, 0x4f, 0xab, 0xb3, 0x24, 0xf5, 0xe5, 0xb7, 0x87, 0xc7, 0x34,
-	0x62, 0xa2, 0xba, 0xa8, 0x0c, 0x14, 0xd5, 0x55, 0xd0, 0x72, 0x75, 0x55, 0xf9, 0x2c, 0x20, 0xaa,
-	0xab, 0x24, 0x58, 0x13, 0x3e, 0x21, 0x62, 0x69, 0x1e, 0x5d, 0xbc, 0x6e, 0xd6, 0xc6, 0xaf, 0x9b,
-	0xb5, 0x8b, 0xab, 0xa6, 0x36, 0xbe, 0x6a, 0x6a, 0x3f, 0x5f, 0x37, 0x6b, 0xaf, 0xae, 0x9b, 0xda,
-	0xf8, 0xba, 0x59, 0xfb, 0xeb, 0xba, 0x59, 0x3b, 0x79, 0xf0, 0x1f, 0x1e, 0x3b, 0x35, 0x31, 0xee,
-	0xa2, 0x7c, 0xf4, 0x3e, 0xfa, 0x37, 0x00, 0x00, 0xff, 0xff, 0xbf, 0x4a, 0x4f, 0x60, 0x33, 0x09,
-	0x00, 0x00,
+	// 1312 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xcf, 0x6f, 0xdc, 0xc4,
+	0x17, 0x8f, 0xbf, 0x69, 0x93, 0xac, 0xf3, 0x63, 0x13, 0xe7, 0xdb, 0x74, 0x12, 0xd1, 0x9d, 0x95,
+	0xbb, 0x87, 0xad, 0x68, 0x37, 0x28, 0x45, 0x1c, 0x2a, 0x40, 0x62, 0x5b, 0x41, 0xab, 0x8a, 0x36,
+	0xb8, 0xe5, 0xd2, 0x8b, 0xf1, 0x7a, 0x26, 0x5b, 0x2b, 0xeb, 0x19, 0x33, 0x1e, 0xa7, 0x1b, 0x09,
+	0x71, 0x86, 0x5b, 0x55, 0x09, 0x09, 0x89, 0x4b, 0xe1, 0xdf, 0xe0, 0xc0, 0x35, 0xb7, 0xec, 0x11,
+	0x71, 0x18, 0xd4, 0xe4, 0xe6, 0xa3, 0x8f, 0x3d, 0xa1, 0x19, 0xff, 0x58, 0xdb, 0x49, 0x2a, 0x24,
+	0x6e, 0x9e, 0xcf, 0xe7, 0xbd, 0xcf, 0x9b, 0xf7, 0xe6, 0xbd, 0xf1, 0xe8, 0x9d, 0x91, 0x37, 0xd8,
+	0x76, 0x29, 0xd9, 0xf3, 0x86, 0xdb, 0x08, 0x1f, 0x78, 0x2e, 0x4e, 0x17, 0x11, 0x73, 0xb8, 0x47,
+	0x49, 0x2f, 0x60, 0x94, 0x53, 0x63, 0x2e, 0x05, 0xb7, 0x36, 0xa4, 0xb5, 0x82, 0x5c, 0x3a, 0xda,
+	0x1e, 0xe0, 0x20, 0xe5, 0xb7, 0x36, 0x4b, 0x2a, 0x74, 0x10, 0x62, 0x76, 0x80, 0x51, 0x46, 0xc1,
+	0x21, 0xa5, 0xc3, 0x11, 0x4e, 0xbd, 0x06, 0xd1, 0xde, 0x36, 0xf7, 0x7c, 0x1c, 0x72, 0xc7, 0xcf,
+	0x7d, 0xaf, 0x33, 0x1c, 0xd0, 0x70, 0xca, 0x0f, 0xe9, 0x90, 0xaa, 0x85, 0xfa, 0xca, 0x8c, 0x1a,
+	0x78, 0xcc, 0xd3, 0x4f, 0xf3, 0xd5, 0x86, 0xbe, 0x7e, 0x4f, 0xed, 0xf4, 0x6e, 0x79, 0xa7, 0xc6,
+	0x1f, 0x9a, 0xde, 0x48, 0x33, 0xb0, 0x3d, 0x04, 0xb4, 0xb6, 0xd6, 0x5d, 0xea, 0xff, 0xaa, 0x1d,
+	0x09, 0x38, 0xf3, 0x97, 0x80, 0x1f, 0x0e, 0x3d, 0xfe, 0x3c, 0x1a, 0xf4, 0x5c, 0xea, 0x6f, 0x87,
+	0x87, 0xc4, 0xe5, 0xcf, 0x3d, 0x32, 0x2c, 0x7d, 0x95, 0xf3, 0xea, 0xa5, 0xea, 0x0f, 0xee, 0x9d,
+	0x08, 0xb8, 0x90, 0x7f, 0xc7, 0x02, 0x2e, 0xa0, 0xec, 0x3b, 0x11, 0xb0, 0x35, 0xf6, 0x47, 0x77,
+	0x4c, 0x0f, 0xdd, 0x74, 0x38, 0x67, 0x66, 0x9b, 0x50, 0x84, 0xf7, 0x9c, 0x68, 0xc4, 0xef, 0x98,
+	0x9c, 0x45, 0xd8, 0x8c, 0x8f, 0x3b, 0xf3, 0x19, 0x99, 0x1c, 0x77, 0x0a, 0xc7, 0x1f, 0x26, 0x1d,
+	0xed, 0xd5, 0xa4, 0x53, 0x88, 0xbe, 0x9e, 0x74, 0x34, 0x2b, 0x67, 0x91, 0xb1, 0xab, 0x5f, 0x22,
+	0x8e, 0x8f, 0xc1, 0xff, 0xda, 0x5a, 0xb7, 0xd1, 0xff, 0x38, 0x16, 0x50, 0xad, 0x13, 0x01, 0x37,
+	0x55, 0x38, 0xb9, 0x50, 0x9a, 0x37, 0xa9, 0xef, 0x71, 0xec, 0x07, 0xfc, 0x50, 0x46, 0x5a, 0x3f,
+	0x07, 0xb7, 0x94, 0xa7, 0x31, 0xd6, 0x1b, 0x0e, 0x42, 0x0c, 0x87, 0x21, 0x0e, 0xc1, 0x6c, 0x7b,
+	0xb6, 0xdb, 0xe8, 0x3f, 0x8b, 0x05, 0x9c, 0x82, 0x89, 0x80, 0x37, 0x94, 0x76, 0x86, 0x94, 0x94,
+	0xdb, 0x45, 0x4a, 0xe8, 0x90, 0x38, 0xbe, 0xe7, 0xca, 0x58, 0x6b, 0x67, 0xec, 0xde, 0x1e, 0x77,
+	0xe6, 0x33, 0x03, 0x6b, 0xaa, 0x6b, 0x1c, 0xe8, 0x8b, 0x2e, 0xf5, 0x03, 0xb9, 0xf2, 0x28, 0x01,
+	0x97, 0xda, 0x5a, 0x77, 0x65, 0xe7, 0x4a, 0xaf, 0xa8, 0xf1, 0xdd, 0x29, 0xd9, 0xff, 0x24, 0x16,
+	0xb0, 0x6c, 0x9d, 0x08, 0xb8, 0xa1, 0x36, 0x55, 0xc2, 0xd2, 0x42, 0xc7, 0xc7, 0x9d, 0xd5, 0x3a,
+	0x68, 0x95, 0x5d, 0x0d, 0xac, 0x37, 0x5c, 0xcc, 0xb8, 0xad, 0x0a, 0x79, 0x59, 0x15, 0xf2, 0xbe,
+	0x3c, 0x3b, 0x09, 0x3e, 0x4a, 0x8b, 0x79, 0x2d, 0xd5, 0xce, 0x80, 0x73, 0x0a, 0x7a, 0xf5, 0x02,
+	0xce, 0x2a, 0x54, 0x8c, 0x67, 0xba, 0xee, 0x11, 0xce, 0x28, 0x8a, 0x5c, 0xcc, 0xc0, 0x5c, 0x5b,
+	0xeb, 0x2e, 0xf4, 0xef, 0xc4, 0x02, 0x96, 0xd0, 0x44, 0xc0, 0x2b, 0x69, 0x97, 0x14, 0x50, 0x91,
+	0x44, 0xb3, 0x86, 0x59, 0x25, 0x3f, 0xe3, 0x37, 0x4d, 0xdf, 0x0a, 0xf7, 0xbd, 0xc0, 0xce, 0x31,
+	0xd9, 0xde, 0x36, 0xc3, 0x3e, 0x3d, 0x70, 0x46, 0x21, 0x98, 0x57, 0xc1, 0x50, 0x2c, 0x20, 0x90,
+	0x56, 0x0f, 0x4a, 0x46, 0x56, 0x66, 0x93, 0x08, 0x78, 0x5d, 0x85, 0xbe, 0xc8, 0xa0, 0xd8, 0xc8,
+	0xb5, 0x77, 0x5a, 0x58, 0x17, 0x46, 0x30, 0x7e, 0xd7, 0xf4, 0xe5, 0x62, 0xcf, 0xc8, 0x1e, 0x1c,
+	0x82, 0x05, 0x35, 0x71, 0x3f, 0xfd, 0xa7, 0x89, 0x8b, 0x05, 0x5c, 0x9a, 0xaa, 0xf6, 0x0f, 0x13,
+	0x01, 0xbb, 0xd5, 0x1a, 0xa2, 0xfe, 0xe1, 0xc5, 0x33, 0xb7, 0x76, 0xc6, 0x4c, 0x4e, 0x9c, 0x9a,
+	0xb2, 0x8a, 0xac, 0xb1, 0xa3, 0xcf, 0x05, 0x4e, 0x14, 0x62, 0x04, 0x1a, 0xaa, 0x9a, 0x5b, 0xb1,
+	0x80, 0x19, 0x92, 0x08, 0xb8, 0xa4, 0x42, 0xa6, 0x4b, 0xd3, 0xca, 0x70, 0xe3, 0x3b, 0x7d, 0xd5,
+	0x19, 0x8d, 0xe8, 0x0b, 0x8c, 0x6c, 0x82, 0xf9, 0x0b, 0xca, 0xf6, 0x43, 0xa0, 0xab, 0x91, 0xfa,
+	0x2a, 0x16, 0xb0, 0x99, 0x71, 0x8f, 0x32, 0xaa, 0xb8, 0x23, 0xaa, 0x78, 0xb5, 0xd1, 0xc0, 0x45,
+	0xa4, 0x55, 0x97, 0x33, 0xbe, 0xd1, 0xd7, 0x9d, 0x88, 0x53, 0xdb, 0x71, 0x5d, 0x1c, 0x70, 0x7b,
+	0x8f, 0x8e, 0x10, 0x66, 0x21, 0x58, 0x54, 0xdb, 0xff, 0x20, 0x16, 0x70, 0x4d, 0xd2, 0x9f, 0x29,
+	0xf6, 0xf3, 0x94, 0x4c, 0x04, 0xbc, 0x9a, 0x6e, 0xa1, 0xce, 0x98, 0xd6, 0x59, 0x6b, 0xe3, 0xb1,
+	0xbe, 0xec, 0x3b, 0x63, 0x3b, 0xc4, 0x04, 0xd9, 0xfb, 0x83, 0x20, 0x04, 0x4b, 0x6d, 0xad, 0x7b,
+	0xb9, 0xff, 0xbe, 0x1c, 0x4e, 0xdf, 0x19, 0x3f, 0xc1, 0x04, 0x3d, 0x1c, 0x04, 0x52, 0x75, 0x4d,
+	0xa9, 0x96, 0x30, 0xf3, 0xad, 0x80, 0xb3, 0x1e, 0xe1, 0x56, 0xd9, 0x30, 0x17, 0x64, 0xd8, 0x3d,
+	0x48, 0x05, 0x97, 0x2b, 0x82, 0x16, 0x76, 0x0f, 0xea, 0x82, 0x39, 0x56, 0x11, 0xcc, 0x41, 0x83,
+	0xe8, 0x4d, 0x6f, 0x48, 0x28, 0xc3, 0xa8, 0xc8, 0x7f, 0xa5, 0x3d, 0xdb, 0x5d, 0xdc, 0xd9, 0xe8,
+	0xa5, 0xff, 0x9e, 0xde, 0xe3, 0xec, 0xdf, 0x93, 0xe6, 0xd4, 0xbf, 0x25, 0x7b, 0x31, 0x16, 0x70,
+	0x25, 0x73, 0x9b, 0x16, 0x66, 0x3d, 0xed, 0xaa, 0x32, 0x6c, 0x5a, 0x35, 0x33, 0xe3, 0x47, 0x4d,
+	0x6f, 0x06, 0x98, 0x20, 0x8f, 0x0c, 0x8b, 0x80, 0xcd, 0x77, 0x06, 0xbc, 0x2f, 0x03, 0x9e, 0x08,
+	0x08, 0xee, 0xe1, 0x80, 0x61, 0xd7, 0xe1, 0x18, 0xed, 0xa6, 0x02, 0x99, 0x66, 0x2c, 0xa0, 0x76,
+	0xab, 0xb8, 0x83, 0x82, 0x32, 0x57, 0x6a, 0x0d, 0xa0, 0x59, 0x2b, 0x15, 0x2e, 0x34, 0x7e, 0xd1,
+	0xf4, 0x66, 0x5a, 0xcd, 0x6f, 0x23, 0x1c, 0x72, 0x7b, 0xdf, 0x1b, 0x80, 0x55, 0x55, 0xcf, 0xf0,
+	0x44, 0xc0, 0xe5, 0x2f, 0x65, 0x99, 0x14, 0xf3, 0xd0, 0xeb, 0xc7, 0x02, 0x2e, 0xfb, 0x65, 0xa0,
+	0x48, 0xb8, 0x82, 0xe6, 0x45, 0x8e, 0x8f, 0x3b, 0x35, 0xf3, 0x3a, 0xf0, 0x6a, 0xd2, 0xa9, 0x46,
+	0xb0, 0x2a, 0xfc, 0xc0, 0xf8, 0x54, 0x6f, 0x44, 0x84, 0xb3, 0x28, 0xe4, 0x18, 0x81, 0x35, 0xd5,
+	0x93, 0x6d, 0xf9, 0x9f, 0x29, 0xc0, 0x44, 0xc0, 0xa6, 0xda, 0x41, 0x81, 0x98, 0xd6, 0x94, 0x55,
+	0xd9, 0xc9, 0x0b, 0x8e, 0x63, 0x7b, 0x18, 0x79, 0x76, 0x40, 0x19, 0x07, 0xc6, 0x34, 0x3b, 0x4b,
+	0x51, 0x5f, 0x7c, 0xfd, 0x60, 0x97, 0x32, 0x2e, 0xb3, 0x63, 0x65, 0xa0, 0xc8, 0xae, 0x82, 0x96,
+	0xb3, 0xab, 0x9a, 0xd7, 0x01, 0x99, 0x5d, 0x25, 0x82, 0x95, 0xf3, 0x91, 0x27, 0x97, 0xc6, 0xcf,
+	0x9a, 0x3e, 0x8f, 0xc7, 0x81, 0xc7, 0x70, 0x08, 0xd6, 0xdb, 0x5a, 0x77, 0x71, 0x67, 0xab, 0x97,
+	0xbe, 0x6a, 0x7a, 0xf9, 0xab, 0xa5, 0xf7, 0x34, 0x7f, 0xd5, 0xf4, 0x07, 0x47, 0x02, 0x6a, 0x72,
+	0x20, 0x33, 0x97, 0xe9, 0xb9, 0x16, 0x03, 0x79, 0x86, 0x31, 0x5f, 0xfe, 0x0d, 0x35, 0x79, 0x81,
+	0x9d, 0xf5, 0x39, 0x0f, 0xb4, 0xf2, 0xed, 0x18, 0xdf, 0xeb, 0xab, 0xbe, 0x43, 0x9c, 0x21, 0xf6,
+	0x31, 0xe1, 0x36, 0xa7, 0xfb, 0x98, 0x80, 0xff, 0xab, 0xbf, 0xde, 0x13, 0x79, 0x29, 0x4d, 0xb9,
+	0xa7, 0x92, 0x4a, 0x04, 0x84, 0x59, 0x1f, 0x54, 0xf0, 0xea, 0xad, 0xb4, 0x79, 0x21, 0x6b, 0xd5,
+	0x05, 0x8d, 0x8f, 0xf4, 0x79, 0x86, 0x0f, 0xe8, 0x3e, 0x46, 0xe0, 0x8a, 0x3a, 0xf6, 0xf7, 0x62,
+	0x01, 0x73, 0x28, 0x11, 0x70, 0x39, 0x3b, 0x18, 0xb5, 0x36, 0xad, 0x9c, 0x31, 0x76, 0xf5, 0xe6,
+	0x0b, 0x2f, 0xc0, 0x36, 0x25, 0xb6, 0x4b, 0x09, 0xc1, 0x2e, 0x07, 0x1b, 0xca, 0xbf, 0x2b, 0x8f,
+	0x57, 0x52, 0x8f, 0xc9, 0xdd, 0x94, 0x28, 0x8e, 0xb7, 0x82, 0x9a, 0x56, 0xd5, 0xca, 0x70, 0xf5,
+	0x75, 0x97, 0x52, 0x86, 0x3c, 0xe2, 0x70, 0x6c, 0x33, 0x79, 0x18, 0x8c, 0x87, 0xe0, 0xaa, 0x52,
+	0xdd, 0x89, 0x05, 0x34, 0xa6, 0xb4, 0x95, 0xb1, 0x89, 0x80, 0x20, 0x7b, 0x68, 0xd4, 0x29, 0xd3,
+	0x3a, 0xc7, 0x5e, 0xa6, 0xeb, 0x63, 0x8e, 0x19, 0x46, 0x00, 0x4c, 0xd3, 0xcd, 0xa0, 0x22, 0xdd,
+	0x6c, 0x6d, 0x5a, 0x39, 0x63, 0xd8, 0xfa, 0x9a, 0x1c, 0xde, 0x20, 0x1a, 0x8d, 0xf2, 0x09, 0x0e,
+	0xc1, 0xa6, 0x6a, 0xf0, 0xdb, 0xe9, 0x39, 0x8d, 0x77, 0xa3, 0xd1, 0x28, 0x9b, 0xa8, 0xb0, 0x78,
+	0x3a, 0xd4, 0xf0, 0xe2, 0x5a, 0xac, 0x3b, 0xf4, 0x1f, 0x1e, 0xbd, 0x69, 0xcd, 0x4c, 0xde, 0xb4,
+	0x66, 0x8e, 0x4e, 0x5a, 0xda, 0xe4, 0xa4, 0xa5, 0xbd, 0x3c, 0x6d, 0xcd, 0xbc, 0x3e, 0x6d, 0x69,
+	0x93, 0xd3, 0xd6, 0xcc, 0x9f, 0xa7, 0xad, 0x99, 0x67, 0x37, 0xfe, 0xc5, 0xff, 0x38, 0xbd, 0xd4,
+	0x06, 0x73, 0xaa, 0xab, 0x6f, 0xff, 0x33, 0x00, 0x1f, 0x4e, 0x35, 0x1a, 0x1c, 0x0c, 0x00, 0x00,
 }
 
 func (m *DeviceConfiguration) Marshal() (dAtA []byte, err error) {
@@ -176,6 +205,82 @@ func (m *DeviceConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MaxPullRequests != 0 {
+		i = encodeVarintDeviceconfiguration(dAtA, i, uint64(m.MaxPullRequests))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc8
+	}
+	if m.Metered {
+		i--
+		if m.Metered {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc0
+	}
+	if m.CoordinateRestarts {
+		i--
+		if m.CoordinateRestarts {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb8
+	}
+	if m.WipeOnConnect {
+		i--
+		if m.WipeOnConnect {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb0
+	}
+	if m.Revoked {
+		i--
+		if m.Revoked {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa8
+	}
+	if len(m.ManagementToken) > 0 {
+		i -= len(m.ManagementToken)
+		copy(dAtA[i:], m.ManagementToken)
+		i = encodeVarintDeviceconfiguration(dAtA, i, uint64(len(m.ManagementToken)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
+	if m.Expires != nil {
+		n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Expires, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Expires):])
+		if err1 != nil {
+			return 0, err1
+		}
+		i -= n1
+		i = encodeVarintDeviceconfiguration(dAtA, i, uint64(n1))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
 	if m.RemoteGUIPort != 0 {
 		i = encodeVarintDeviceconfiguration(dAtA, i, uint64(m.RemoteGUIPort))
 		i--
@@ -423,6 +528,29 @@ func (m *DeviceConfiguration) ProtoSize() (n int) {
 	if m.RemoteGUIPort != 0 {
 		n += 2 + sovDeviceconfiguration(uint64(m.RemoteGUIPort))
 	}
+	if m.Expires != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Expires)
+		n += 2 + l + sovDeviceconfiguration(uint64(l))
+	}
+	l = len(m.ManagementToken)
+	if l > 0 {
+		n += 2 + l + sovDeviceconfiguration(uint64(l))
+	}
+	if m.Revoked {
+		n += 3
+	}
+	if m.WipeOnConnect {
+		n += 3
+	}
+	if m.CoordinateRestarts {
+		n += 3
+	}
+	if m.Metered {
+		n += 3
+	}
+	if m.MaxPullRequests != 0 {
+		n += 2 + sovDeviceconfiguration(uint64(m.MaxPullRequests))
+	}
 	return n
 }
 
@@ -918,6 +1046,173 @@ func (m *DeviceConfiguration) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDeviceconfiguration
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeviceconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Expires, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ManagementToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeviceconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeviceconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ManagementToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revoked", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Revoked = bool(v != 0)
+		case 22:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WipeOnConnect", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.WipeOnConnect = bool(v != 0)
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CoordinateRestarts", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CoordinateRestarts = bool(v != 0)
+		case 24:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metered", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Metered = bool(v != 0)
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPullRequests", wireType)
+			}
+			m.MaxPullRequests = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPullRequests |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDeviceconfiguration(dAtA[iNdEx:])
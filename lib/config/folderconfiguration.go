@@ -32,6 +32,7 @@ var (
 const (
 	DefaultMarkerName          = ".stfolder"
 	EncryptionTokenName        = "syncthing-encryption_password_token"
+	AtRestTokenName            = "syncthing-at-rest-encryption_password_token"
 	maxConcurrentWritesDefault = 2
 	maxConcurrentWritesLimit   = 64
 )
@@ -41,28 +42,86 @@ func (f FolderConfiguration) Copy() FolderConfiguration {
 	c.Devices = make([]FolderDeviceConfiguration, len(f.Devices))
 	copy(c.Devices, f.Devices)
 	c.Versioning = f.Versioning.Copy()
+	c.WarmCachePatterns = make([]string, len(f.WarmCachePatterns))
+	copy(c.WarmCachePatterns, f.WarmCachePatterns)
+	c.CompletionDirectories = make([]string, len(f.CompletionDirectories))
+	copy(c.CompletionDirectories, f.CompletionDirectories)
+	c.ExtraStagingPaths = make([]string, len(f.ExtraStagingPaths))
+	copy(c.ExtraStagingPaths, f.ExtraStagingPaths)
+	c.HeldPaths = make([]string, len(f.HeldPaths))
+	copy(c.HeldPaths, f.HeldPaths)
 	return c
 }
 
 // Filesystem creates a filesystem for the path and options of this folder.
 // The fset parameter may be nil, in which case no mtime handling on top of
 // the filesystem is provided.
-func (f FolderConfiguration) Filesystem(fset *db.FileSet) fs.Filesystem {
+func (f FolderConfiguration) Filesystem(fset *db.FileSet, extra ...fs.Option) fs.Filesystem {
 	// This is intentionally not a pointer method, because things like
 	// cfg.Folders["default"].Filesystem(nil) should be valid.
-	opts := make([]fs.Option, 0, 3)
+	opts := make([]fs.Option, 0, 5+len(extra))
 	if f.FilesystemType == fs.FilesystemTypeBasic && f.JunctionsAsDirs {
 		opts = append(opts, new(fs.OptionJunctionsAsDirs))
 	}
+	if f.FilesystemType == fs.FilesystemTypeBasic && f.RunAsUser != "" {
+		opts = append(opts, &fs.OptionRunAsUser{User: f.RunAsUser})
+	}
+	if f.FilesystemType == fs.FilesystemTypeBasic && f.SandboxFilesystem {
+		opts = append(opts, new(fs.OptionSandbox))
+	}
 	if !f.CaseSensitiveFS {
 		opts = append(opts, new(fs.OptionDetectCaseConflicts))
 	}
 	if fset != nil {
 		opts = append(opts, fset.MtimeOption())
 	}
+	opts = append(opts, extra...)
 	return fs.NewFilesystem(f.FilesystemType, f.Path, opts...)
 }
 
+// StagingFilesystems returns the filesystems temporary files may be
+// written to while pulling, if a staging path is set: that of the staging
+// path followed by those of the extra staging paths. Each path may be
+// shared between folders, each of which uses a directory of its own in it.
+func (f FolderConfiguration) StagingFilesystems() []fs.Filesystem {
+	if f.StagingPath == "" {
+		return nil
+	}
+	fss := make([]fs.Filesystem, 0, 1+len(f.ExtraStagingPaths))
+	for _, path := range append([]string{f.StagingPath}, f.ExtraStagingPaths...) {
+		if path != "" {
+			fss = append(fss, f.stagingFilesystem(path))
+		}
+	}
+	return fss
+}
+
+func (f FolderConfiguration) stagingFilesystem(path string) fs.Filesystem {
+	if expanded, err := fs.ExpandTilde(path); err == nil {
+		path = expanded
+	}
+	var opts []fs.Option
+	if f.RunAsUser != "" {
+		// The temporary files end up in the folder, so they're written as
+		// the same user.
+		opts = append(opts, &fs.OptionRunAsUser{User: f.RunAsUser})
+	}
+	if f.SandboxFilesystem {
+		opts = append(opts, new(fs.OptionSandbox))
+	}
+	return fs.NewFilesystem(fs.FilesystemTypeBasic, filepath.Join(path, fs.SanitizePath(f.ID)), opts...)
+}
+
+// PreallocationMode returns how space for temporary files is allocated.
+// Folders with sparse files disabled have always done without
+// preallocation, which is kept unless another mode is chosen.
+func (f FolderConfiguration) PreallocationMode() Preallocation {
+	if f.DisableSparseFiles && f.Preallocation == PreallocationSparse {
+		return PreallocationNone
+	}
+	return f.Preallocation
+}
+
 func (f FolderConfiguration) ModTimeWindow() time.Duration {
 	dur := time.Duration(f.RawModTimeWindowS) * time.Second
 	if f.RawModTimeWindowS < 1 && build.IsAndroid {
@@ -186,10 +245,12 @@ func (f *FolderConfiguration) prepare(myID protocol.DeviceID, existingDevices ma
 	// - there are no duplicate devices
 	// - we are part of the devices
 	// - folder is not shared in trusted mode with an untrusted device
+	// - folder is not shared with a revoked device
 	f.Devices = ensureExistingDevices(f.Devices, existingDevices)
 	f.Devices = ensureNoDuplicateFolderDevices(f.Devices)
 	f.Devices = ensureDevicePresent(f.Devices, myID)
 	f.Devices = ensureNoUntrustedTrustingSharing(f, f.Devices, existingDevices)
+	f.Devices = ensureNoRevokedSharing(f, f.Devices, existingDevices)
 
 	sort.Slice(f.Devices, func(a, b int) bool {
 		return f.Devices[a].DeviceID.Compare(f.Devices[b].DeviceID) == -1
@@ -214,6 +275,10 @@ func (f *FolderConfiguration) prepare(myID protocol.DeviceID, existingDevices ma
 		f.Versioning.CleanupIntervalS = 0
 	}
 
+	if f.RecycleDays < 0 {
+		f.RecycleDays = 0
+	}
+
 	if f.WeakHashThresholdPct == 0 {
 		f.WeakHashThresholdPct = 25
 	}
@@ -228,10 +293,62 @@ func (f *FolderConfiguration) prepare(myID protocol.DeviceID, existingDevices ma
 		f.MaxConcurrentWrites = maxConcurrentWritesLimit
 	}
 
+	if f.BandwidthWeight <= 0 {
+		f.BandwidthWeight = 1
+	}
+
+	f.CompletionDirectories = cleanCompletionDirectories(f.CompletionDirectories)
+
+	if f.BlockRequestTimeoutS < 0 {
+		f.BlockRequestTimeoutS = 0
+	}
+	if f.BlockPullRetries < 0 {
+		f.BlockPullRetries = 0
+	}
+	if f.BlockBlacklistS < 0 {
+		f.BlockBlacklistS = 0
+	}
+	if f.RemovalGraceS < 0 {
+		f.RemovalGraceS = 0
+	}
+
 	if f.Type == FolderTypeReceiveEncrypted {
 		f.DisableTempIndexes = true
 		f.IgnorePerms = true
 	}
+
+	if f.Type == FolderTypeIndexOnly {
+		// There is nothing on disk to watch.
+		f.FSWatcherEnabled = false
+	}
+
+	if f.AtRestEncryption {
+		f.prepareAtRestEncryption()
+	}
+
+	if f.Deduplicate && f.Type != FolderTypeReceiveEncrypted {
+		l.Warnf("Folder %s (%s) is not receive encrypted, which is required for deduplicated storage; disabling it.", f.ID, f.Label)
+		f.Deduplicate = false
+	}
+}
+
+// prepareAtRestEncryption turns off the features that would put data next
+// to the folder without going through its encryption.
+func (f *FolderConfiguration) prepareAtRestEncryption() {
+	if f.Type == FolderTypeReceiveEncrypted || f.Type == FolderTypeIndexOnly {
+		// The data is either encrypted already or not stored at all.
+		f.AtRestEncryption = false
+		return
+	}
+	if f.Versioning.Type != "" || f.RecycleDays > 0 {
+		l.Warnf("Folder %s (%s) is encrypted at rest, which doesn't support versioning; disabling versioning.", f.ID, f.Label)
+		f.Versioning.Type = ""
+		f.RecycleDays = 0
+	}
+	if f.StagingPath != "" {
+		l.Warnf("Folder %s (%s) is encrypted at rest, which doesn't support a staging path; removing it.", f.ID, f.Label)
+		f.StagingPath = ""
+	}
 }
 
 // RequiresRestartOnly returns a copy with only the attributes that require
@@ -266,6 +383,12 @@ func (f *FolderConfiguration) SharedWith(device protocol.DeviceID) bool {
 	return ok
 }
 
+// HasExpired returns true if the share has an expiry time set, and that time
+// has passed at the given point in time.
+func (cfg FolderDeviceConfiguration) HasExpired(now time.Time) bool {
+	return cfg.Expires != nil && !cfg.Expires.IsZero() && !now.Before(*cfg.Expires)
+}
+
 func (f *FolderConfiguration) CheckAvailableSpace(req uint64) error {
 	val := f.MinDiskFree.BaseValue()
 	if val <= 0 {
@@ -282,6 +405,40 @@ func (f *FolderConfiguration) CheckAvailableSpace(req uint64) error {
 	return nil
 }
 
+// WarmsCache returns true if the named file should be read into the cache
+// after it has been pulled. Patterns containing a slash are matched against
+// the path within the folder, others against the file name only.
+func (f FolderConfiguration) WarmsCache(name string) bool {
+	name = filepath.ToSlash(name)
+	for _, pattern := range f.WarmCachePatterns {
+		subject := name
+		if !strings.Contains(pattern, "/") {
+			subject = path.Base(name)
+		}
+		if ok, _ := path.Match(pattern, subject); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanCompletionDirectories returns the directories as clean,
+// slash-separated paths relative to the folder root, without duplicates
+// and the root itself.
+func cleanCompletionDirectories(dirs []string) []string {
+	cleaned := dirs[:0]
+	seen := make(map[string]bool, len(dirs))
+	for _, dir := range dirs {
+		dir = strings.Trim(path.Clean("/"+filepath.ToSlash(dir)), "/")
+		if dir == "" || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		cleaned = append(cleaned, dir)
+	}
+	return cleaned
+}
+
 func (f XattrFilter) Permit(s string) bool {
 	if len(f.Entries) == 0 {
 		return true
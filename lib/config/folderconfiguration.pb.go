@@ -6,19 +6,24 @@ package config
 import (
 	encoding_binary "encoding/binary"
 	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 	fs "github.com/syncthing/syncthing/lib/fs"
 	github_com_syncthing_syncthing_lib_protocol "github.com/syncthing/syncthing/lib/protocol"
 	_ "github.com/syncthing/syncthing/proto/ext"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
 	io "io"
 	math "math"
 	math_bits "math/bits"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -30,6 +35,7 @@ type FolderDeviceConfiguration struct {
 	DeviceID           github_com_syncthing_syncthing_lib_protocol.DeviceID `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.DeviceID" json:"deviceID" xml:"id,attr"`
 	IntroducedBy       github_com_syncthing_syncthing_lib_protocol.DeviceID `protobuf:"bytes,2,opt,name=introduced_by,json=introducedBy,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.DeviceID" json:"introducedBy" xml:"introducedBy,attr"`
 	EncryptionPassword string                                               `protobuf:"bytes,3,opt,name=encryption_password,json=encryptionPassword,proto3" json:"encryptionPassword" xml:"encryptionPassword"`
+	Expires            *time.Time                                           `protobuf:"bytes,4,opt,name=expires,proto3,stdtime" json:"expires,omitempty" xml:"expires,attr,omitempty"`
 }
 
 func (m *FolderDeviceConfiguration) Reset()         { *m = FolderDeviceConfiguration{} }
@@ -105,6 +111,36 @@ type FolderConfiguration struct {
 	SyncXattrs              bool                        `protobuf:"varint,37,opt,name=sync_xattrs,json=syncXattrs,proto3" json:"syncXattrs" xml:"syncXattrs"`
 	SendXattrs              bool                        `protobuf:"varint,38,opt,name=send_xattrs,json=sendXattrs,proto3" json:"sendXattrs" xml:"sendXattrs"`
 	XattrFilter             XattrFilter                 `protobuf:"bytes,39,opt,name=xattr_filter,json=xattrFilter,proto3" json:"xattrFilter" xml:"xattrFilter"`
+	ScrubIntervalS          int                         `protobuf:"varint,40,opt,name=scrub_interval_s,json=scrubIntervalS,proto3,casttype=int" json:"scrubIntervalS" xml:"scrubIntervalS,attr"`
+	BandwidthWeight         int                         `protobuf:"varint,41,opt,name=bandwidth_weight,json=bandwidthWeight,proto3,casttype=int" json:"bandwidthWeight" xml:"bandwidthWeight" default:"1"`
+	StagingPath             string                      `protobuf:"bytes,42,opt,name=staging_path,json=stagingPath,proto3" json:"stagingPath" xml:"stagingPath"`
+	Transactional           bool                        `protobuf:"varint,43,opt,name=transactional,proto3" json:"transactional" xml:"transactional"`
+	RecycleDays             int                         `protobuf:"varint,44,opt,name=recycle_days,json=recycleDays,proto3,casttype=int" json:"recycleDays" xml:"recycleDays"`
+	Preallocation           Preallocation               `protobuf:"varint,45,opt,name=preallocation,proto3,enum=config.Preallocation" json:"preallocation" xml:"preallocation"`
+	AtRestEncryption        bool                        `protobuf:"varint,46,opt,name=at_rest_encryption,json=atRestEncryption,proto3" json:"atRestEncryption" xml:"atRestEncryption"`
+	WarmCachePatterns       []string                    `protobuf:"bytes,47,rep,name=warm_cache_patterns,json=warmCachePatterns,proto3" json:"warmCachePatterns" xml:"warmCachePattern,omitempty"`
+	Deduplicate             bool                        `protobuf:"varint,48,opt,name=deduplicate,proto3" json:"deduplicate" xml:"deduplicate"`
+	Frozen                  bool                        `protobuf:"varint,49,opt,name=frozen,proto3" json:"frozen" xml:"frozen"`
+	FrozenSequence          int64                       `protobuf:"varint,50,opt,name=frozen_sequence,json=frozenSequence,proto3" json:"frozenSequence" xml:"frozenSequence"`
+	PreScanCommand          string                      `protobuf:"bytes,51,opt,name=pre_scan_command,json=preScanCommand,proto3" json:"preScanCommand" xml:"preScanCommand"`
+	PostScanCommand         string                      `protobuf:"bytes,52,opt,name=post_scan_command,json=postScanCommand,proto3" json:"postScanCommand" xml:"postScanCommand"`
+	ScanHookTimeoutS        int                         `protobuf:"varint,53,opt,name=scan_hook_timeout_s,json=scanHookTimeoutS,proto3,casttype=int" json:"scanHookTimeoutS" xml:"scanHookTimeoutS" default:"60"`
+	BlockRequestTimeoutS    int                         `protobuf:"varint,54,opt,name=block_request_timeout_s,json=blockRequestTimeoutS,proto3,casttype=int" json:"blockRequestTimeoutS" xml:"blockRequestTimeoutS"`
+	BlockPullRetries        int                         `protobuf:"varint,55,opt,name=block_pull_retries,json=blockPullRetries,proto3,casttype=int" json:"blockPullRetries" xml:"blockPullRetries"`
+	BlockBlacklistS         int                         `protobuf:"varint,56,opt,name=block_blacklist_s,json=blockBlacklistS,proto3,casttype=int" json:"blockBlacklistS" xml:"blockBlacklistS"`
+	CompletionDirectories   []string                    `protobuf:"bytes,57,rep,name=completion_directories,json=completionDirectories,proto3" json:"completionDirectories" xml:"completionDirectory,omitempty"`
+	Notes                   string                      `protobuf:"bytes,58,opt,name=notes,proto3" json:"notes" xml:"notes" restart:"false"`
+	RemovalPolicy           RemovalPolicy               `protobuf:"varint,59,opt,name=removal_policy,json=removalPolicy,proto3,enum=config.RemovalPolicy" json:"removalPolicy" xml:"removalPolicy" restart:"false"`
+	RemovalGraceS           int                         `protobuf:"varint,60,opt,name=removal_grace_s,json=removalGraceS,proto3,casttype=int" json:"removalGraceS" xml:"removalGraceS" default:"604800" restart:"false"`
+	Profile                 FolderProfile               `protobuf:"varint,61,opt,name=profile,proto3,enum=config.FolderProfile" json:"profile" xml:"profile"`
+	UseChangeJournal        bool                        `protobuf:"varint,62,opt,name=use_change_journal,json=useChangeJournal,proto3" json:"useChangeJournal" xml:"useChangeJournal"`
+	RunAsUser               string                      `protobuf:"bytes,63,opt,name=run_as_user,json=runAsUser,proto3" json:"runAsUser" xml:"runAsUser"`
+	SandboxFilesystem       bool                        `protobuf:"varint,64,opt,name=sandbox_filesystem,json=sandboxFilesystem,proto3" json:"sandboxFilesystem" xml:"sandboxFilesystem"`
+	Metadata                FolderMetadata              `protobuf:"bytes,65,opt,name=metadata,proto3" json:"metadata" xml:"metadata" restart:"false"`
+	ExtraStagingPaths       []string                    `protobuf:"bytes,66,rep,name=extra_staging_paths,json=extraStagingPaths,proto3" json:"extraStagingPaths" xml:"extraStagingPath,omitempty"`
+	SyncWhenMetered         bool                        `protobuf:"varint,67,opt,name=sync_when_metered,json=syncWhenMetered,proto3" json:"syncWhenMetered" xml:"syncWhenMetered"`
+	EmptyFolderGuardFiles   int                         `protobuf:"varint,68,opt,name=empty_folder_guard_files,json=emptyFolderGuardFiles,proto3,casttype=int" json:"emptyFolderGuardFiles" xml:"emptyFolderGuardFiles" default:"100"`
+	HeldPaths               []string                    `protobuf:"bytes,69,rep,name=held_paths,json=heldPaths,proto3" json:"heldPaths" xml:"heldPath,omitempty"`
 	// Legacy deprecated
 	DeprecatedReadOnly       bool    `protobuf:"varint,9000,opt,name=read_only,json=readOnly,proto3" json:"-" xml:"ro,attr,omitempty"`                       // Deprecated: Do not use.
 	DeprecatedMinDiskFreePct float64 `protobuf:"fixed64,9001,opt,name=min_disk_free_pct,json=minDiskFreePct,proto3" json:"-" xml:"minDiskFreePct,omitempty"` // Deprecated: Do not use.
@@ -190,6 +226,49 @@ func (m *XattrFilter) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_XattrFilter proto.InternalMessageInfo
 
+// Metadata presented with the folder on all devices, e.g. an emoji as icon
+// and a color as CSS color value. It's synced with the other devices, the
+// most recently updated metadata wins.
+type FolderMetadata struct {
+	Description string    `protobuf:"bytes,1,opt,name=description,proto3" json:"description" xml:"description"`
+	Icon        string    `protobuf:"bytes,2,opt,name=icon,proto3" json:"icon" xml:"icon"`
+	Color       string    `protobuf:"bytes,3,opt,name=color,proto3" json:"color" xml:"color"`
+	Updated     time.Time `protobuf:"bytes,4,opt,name=updated,proto3,stdtime" json:"updated" xml:"updated"`
+}
+
+func (m *FolderMetadata) Reset()         { *m = FolderMetadata{} }
+func (m *FolderMetadata) String() string { return proto.CompactTextString(m) }
+func (*FolderMetadata) ProtoMessage()    {}
+func (*FolderMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44a9785876ed3afa, []int{3}
+}
+func (m *FolderMetadata) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FolderMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FolderMetadata.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FolderMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FolderMetadata.Merge(m, src)
+}
+func (m *FolderMetadata) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *FolderMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_FolderMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FolderMetadata proto.InternalMessageInfo
+
 type XattrFilterEntry struct {
 	Match  string `protobuf:"bytes,1,opt,name=match,proto3" json:"match" xml:"match,attr"`
 	Permit bool   `protobuf:"varint,2,opt,name=permit,proto3" json:"permit" xml:"permit,attr"`
@@ -199,7 +278,7 @@ func (m *XattrFilterEntry) Reset()         { *m = XattrFilterEntry{} }
 func (m *XattrFilterEntry) String() string { return proto.CompactTextString(m) }
 func (*XattrFilterEntry) ProtoMessage()    {}
 func (*XattrFilterEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_44a9785876ed3afa, []int{3}
+	return fileDescriptor_44a9785876ed3afa, []int{4}
 }
 func (m *XattrFilterEntry) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -232,6 +311,7 @@ func init() {
 	proto.RegisterType((*FolderDeviceConfiguration)(nil), "config.FolderDeviceConfiguration")
 	proto.RegisterType((*FolderConfiguration)(nil), "config.FolderConfiguration")
 	proto.RegisterType((*XattrFilter)(nil), "config.XattrFilter")
+	proto.RegisterType((*FolderMetadata)(nil), "config.FolderMetadata")
 	proto.RegisterType((*XattrFilterEntry)(nil), "config.XattrFilterEntry")
 }
 
@@ -240,158 +320,235 @@ func init() {
 }
 
 var fileDescriptor_44a9785876ed3afa = []byte{
-	// 2413 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0xcf, 0x6f, 0x24, 0x47,
-	0xf5, 0x77, 0xdb, 0xbb, 0x6b, 0xbb, 0xfc, 0xbb, 0x6c, 0xef, 0x76, 0x9c, 0xc4, 0x35, 0xe9, 0xcc,
-	0x26, 0x4e, 0xbe, 0x89, 0x77, 0xe3, 0x44, 0x91, 0x12, 0x7d, 0x03, 0x64, 0xec, 0x8c, 0x58, 0x16,
-	0x67, 0xad, 0x1e, 0x43, 0x20, 0x41, 0x6a, 0xda, 0xdd, 0x35, 0x33, 0x1d, 0xf7, 0x8f, 0xa1, 0xab,
-	0xbd, 0xf6, 0xec, 0x21, 0x0a, 0x39, 0x20, 0x24, 0x72, 0x40, 0xe6, 0x80, 0x38, 0x20, 0x45, 0x02,
-	0x21, 0x08, 0x17, 0xce, 0xfc, 0x05, 0xb9, 0x20, 0xfb, 0x84, 0x10, 0x87, 0x92, 0xe2, 0xbd, 0xcd,
-	0x71, 0x8e, 0x7b, 0x42, 0xef, 0xf5, 0x8f, 0xa9, 0x9e, 0x99, 0x48, 0x48, 0xdc, 0xa6, 0x3e, 0x9f,
-	0x57, 0xef, 0x7d, 0xfa, 0x55, 0xd5, 0xab, 0x57, 0x43, 0xaa, 0xbe, 0x77, 0x74, 0xc7, 0x89, 0xc2,
-	0xa6, 0xd7, 0xba, 0xd3, 0x8c, 0x7c, 0x97, 0xc7, 0xe9, 0xe0, 0x24, 0xb6, 0x13, 0x2f, 0x0a, 0xb7,
-	0x3b, 0x71, 0x94, 0x44, 0xf4, 0x46, 0x0a, 0x6e, 0x3c, 0x3d, 0x62, 0x9d, 0x74, 0x3b, 0x3c, 0x35,
-	0xda, 0x58, 0x57, 0x48, 0xe1, 0x3d, 0xca, 0xe1, 0x0d, 0x05, 0xee, 0x9c, 0xf8, 0x7e, 0x14, 0xbb,
-	0x3c, 0xce, 0xb8, 0x2d, 0x85, 0x7b, 0xc8, 0x63, 0xe1, 0x45, 0xa1, 0x17, 0xb6, 0xc6, 0x28, 0xd8,
-	0x60, 0x8a, 0xe5, 0x91, 0x1f, 0x39, 0xc7, 0xc3, 0xae, 0x28, 0x18, 0x34, 0xc5, 0x1d, 0x10, 0x24,
-	0x32, 0xec, 0x99, 0x0c, 0x73, 0xa2, 0x4e, 0x37, 0xb6, 0xc3, 0x16, 0x0f, 0x78, 0xd2, 0x8e, 0xdc,
-	0x8c, 0x9d, 0xe5, 0x67, 0x49, 0xfa, 0xd3, 0xf8, 0xe7, 0x14, 0x79, 0xaa, 0x8e, 0xdf, 0xb3, 0xc7,
-	0x1f, 0x7a, 0x0e, 0xdf, 0x55, 0x15, 0xd0, 0x2f, 0x35, 0x32, 0xeb, 0x22, 0x6e, 0x79, 0xae, 0xae,
-	0x55, 0xb4, 0xad, 0xf9, 0xda, 0xe7, 0xda, 0x57, 0x92, 0x4d, 0xfc, 0x5b, 0xb2, 0x37, 0x5a, 0x5e,
-	0xd2, 0x3e, 0x39, 0xda, 0x76, 0xa2, 0xe0, 0x8e, 0xe8, 0x86, 0x4e, 0xd2, 0xf6, 0xc2, 0x96, 0xf2,
-	0x0b, 0x24, 0x60, 0x10, 0x27, 0xf2, 0xb7, 0x53, 0xef, 0xf7, 0xf6, 0xae, 0x24, 0x9b, 0xc9, 0x7f,
-	0xf7, 0x24, 0x9b, 0x71, 0xb3, 0xdf, 0x7d, 0xc9, 0x16, 0xce, 0x02, 0xff, 0x6d, 0xc3, 0x73, 0x5f,
-	0xb1, 0x93, 0x24, 0x36, 0x7a, 0x17, 0xd5, 0xe9, 0xec, 0x77, 0xff, 0xa2, 0x5a, 0xd8, 0xfd, 0xf2,
-	0xb2, 0xaa, 0x9d, 0x5f, 0x56, 0x0b, 0x1f, 0x66, 0xce, 0xb8, 0xf4, 0x4f, 0x1a, 0x59, 0xf0, 0xc2,
-	0x24, 0x8e, 0xdc, 0x13, 0x87, 0xbb, 0xd6, 0x51, 0x57, 0x9f, 0x44, 0xc1, 0x9f, 0xfe, 0x4f, 0x82,
-	0x7b, 0x92, 0xcd, 0x0f, 0xbc, 0xd6, 0xba, 0x7d, 0xc9, 0x6e, 0xa5, 0x42, 0x15, 0xb0, 0x90, 0xbc,
-	0x32, 0x82, 0x82, 0x60, 0xb3, 0xe4, 0x81, 0x3a, 0x64, 0x95, 0x87, 0x4e, 0xdc, 0xed, 0x40, 0x8e,
-	0xad, 0x8e, 0x2d, 0xc4, 0x69, 0x14, 0xbb, 0xfa, 0x54, 0x45, 0xdb, 0x9a, 0xad, 0xed, 0xf4, 0x24,
-	0xa3, 0x03, 0xfa, 0x20, 0x63, 0xfb, 0x92, 0xe9, 0x18, 0x76, 0x94, 0x32, 0xcc, 0x31, 0xf6, 0xc6,
-	0x79, 0x95, 0xac, 0xa6, 0x0b, 0x5b, 0x5e, 0xd2, 0x06, 0x99, 0xcc, 0x96, 0x72, 0xb6, 0xb6, 0x7b,
-	0x25, 0xd9, 0x24, 0x7e, 0xe2, 0xa4, 0x07, 0x11, 0x36, 0x4b, 0x2b, 0x50, 0x09, 0x23, 0x97, 0x37,
-	0xed, 0x13, 0x3f, 0x79, 0xdb, 0x48, 0xe2, 0x13, 0xae, 0x2e, 0xc9, 0xf9, 0x65, 0x75, 0xf2, 0xde,
-	0xde, 0x17, 0xf0, 0x6d, 0x93, 0x9e, 0x4b, 0x7f, 0x40, 0xae, 0xfb, 0xf6, 0x11, 0xf7, 0x31, 0xe3,
-	0xb3, 0xb5, 0x6f, 0xf7, 0x24, 0x4b, 0x81, 0xbe, 0x64, 0x15, 0x74, 0x8a, 0xa3, 0xcc, 0x6f, 0xcc,
-	0x45, 0x62, 0xc7, 0xc9, 0xdb, 0x46, 0xd3, 0xf6, 0x05, 0xba, 0x25, 0x03, 0xfa, 0xd3, 0xcb, 0xea,
-	0x84, 0x99, 0x4e, 0xa6, 0x2d, 0xb2, 0xd4, 0xf4, 0x7c, 0x2e, 0xba, 0x22, 0xe1, 0x81, 0x05, 0xfb,
-	0x1b, 0x93, 0xb4, 0xb8, 0x43, 0xb7, 0x9b, 0x62, 0xbb, 0x5e, 0x50, 0x87, 0xdd, 0x0e, 0xaf, 0xbd,
-	0xdc, 0x93, 0x6c, 0xb1, 0x59, 0xc2, 0xfa, 0x92, 0xad, 0x61, 0xf4, 0x32, 0x6c, 0x98, 0x43, 0x76,
-	0x74, 0x9f, 0x5c, 0xeb, 0xd8, 0x49, 0x5b, 0xbf, 0x86, 0xf2, 0xdf, 0xea, 0x49, 0x86, 0xe3, 0xbe,
-	0x64, 0x4f, 0xe3, 0x7c, 0x18, 0x64, 0xe2, 0x8b, 0x94, 0x7c, 0x02, 0xc2, 0x67, 0x0b, 0xe6, 0xc9,
-	0x45, 0x55, 0xfb, 0xc4, 0xc4, 0x69, 0xf4, 0x80, 0x5c, 0x43, 0xb1, 0xd7, 0x33, 0xb1, 0xe9, 0xe9,
-	0xdd, 0x4e, 0x97, 0x03, 0xc5, 0x6e, 0x41, 0x88, 0x24, 0x95, 0xb8, 0x84, 0x21, 0x60, 0x50, 0x6c,
-	0xa3, 0xd9, 0x62, 0x64, 0xa2, 0x15, 0xfd, 0x09, 0x99, 0x4e, 0xf7, 0xb9, 0xd0, 0x6f, 0x54, 0xa6,
-	0xb6, 0xe6, 0x76, 0x9e, 0x2b, 0x3b, 0x1d, 0x73, 0x78, 0x6b, 0x0c, 0xb6, 0x7d, 0x4f, 0xb2, 0x7c,
-	0x66, 0x5f, 0xb2, 0x79, 0x0c, 0x95, 0x8e, 0x0d, 0x33, 0x27, 0xe8, 0x6f, 0x34, 0xb2, 0x12, 0x73,
-	0xe1, 0xd8, 0xa1, 0xe5, 0x85, 0x09, 0x8f, 0x1f, 0xda, 0xbe, 0x25, 0xf4, 0xe9, 0x8a, 0xb6, 0x75,
-	0xbd, 0xd6, 0xea, 0x49, 0xb6, 0x94, 0x92, 0xf7, 0x32, 0xae, 0xd1, 0x97, 0xec, 0x25, 0xf4, 0x34,
-	0x84, 0x0f, 0xa7, 0xe8, 0xf5, 0x37, 0xef, 0xde, 0x35, 0x9e, 0x48, 0x36, 0xe5, 0x85, 0x49, 0xef,
-	0xa2, 0xba, 0x36, 0xce, 0xfc, 0xc9, 0x45, 0xf5, 0x1a, 0xd8, 0x99, 0xc3, 0x41, 0xe8, 0xdf, 0x35,
-	0x42, 0x9b, 0xc2, 0x3a, 0xb5, 0x13, 0xa7, 0xcd, 0x63, 0x8b, 0x87, 0xf6, 0x91, 0xcf, 0x5d, 0x7d,
-	0xa6, 0xa2, 0x6d, 0xcd, 0xd4, 0x7e, 0xa5, 0x5d, 0x49, 0xb6, 0x5c, 0x6f, 0x7c, 0x90, 0xb2, 0xef,
-	0xa5, 0x64, 0x4f, 0xb2, 0xe5, 0xa6, 0x28, 0x63, 0x7d, 0xc9, 0x5e, 0x4e, 0x37, 0xc1, 0x10, 0x31,
-	0xac, 0x36, 0xdf, 0xe3, 0xeb, 0x63, 0x0d, 0x41, 0x27, 0x58, 0x9c, 0x5f, 0x56, 0x47, 0xc2, 0x9a,
-	0x23, 0x41, 0xe9, 0xdf, 0xca, 0xe2, 0x5d, 0xee, 0xdb, 0x5d, 0x4b, 0xe8, 0xb3, 0x15, 0x6d, 0x4b,
-	0xab, 0x7d, 0x06, 0xe2, 0x97, 0x0a, 0x2f, 0x7b, 0x40, 0x36, 0x20, 0xcf, 0x85, 0x9b, 0x14, 0xea,
-	0x4b, 0xf6, 0x62, 0x59, 0x7a, 0x8a, 0x0f, 0x2b, 0x7f, 0xed, 0x2e, 0xe8, 0x5e, 0x1b, 0x67, 0xf5,
-	0xe4, 0xa2, 0x3a, 0xf9, 0xda, 0xdd, 0xf3, 0xcb, 0xea, 0x70, 0x38, 0x73, 0x38, 0x18, 0xfd, 0x29,
-	0x99, 0xf7, 0x5a, 0x61, 0x14, 0x73, 0xab, 0xc3, 0xe3, 0x40, 0xe8, 0x04, 0x13, 0xfd, 0x4e, 0x4f,
-	0xb2, 0xb9, 0x14, 0x3f, 0x00, 0xb8, 0x2f, 0xd9, 0xcd, 0xb4, 0x4c, 0x0c, 0xb0, 0x62, 0xdf, 0x2e,
-	0x0f, 0x83, 0xa6, 0x3a, 0x95, 0xfe, 0x5c, 0x23, 0x8b, 0xf6, 0x49, 0x12, 0x59, 0x61, 0x14, 0x07,
-	0xb6, 0xef, 0x3d, 0xe2, 0xfa, 0x1c, 0x06, 0xf9, 0xb0, 0x27, 0xd9, 0x02, 0x30, 0xef, 0xe7, 0x44,
-	0xf1, 0xe9, 0x25, 0xf4, 0x9b, 0x96, 0x8c, 0x8e, 0x5a, 0xe5, 0xeb, 0x65, 0x96, 0xfd, 0xd2, 0x88,
-	0x2c, 0x04, 0x5e, 0x68, 0xb9, 0x9e, 0x38, 0xb6, 0x9a, 0x31, 0xe7, 0xfa, 0x7c, 0x45, 0xdb, 0x9a,
-	0xdb, 0x99, 0xcf, 0xcf, 0x53, 0xc3, 0x7b, 0xc4, 0x6b, 0xef, 0x64, 0x47, 0x67, 0x2e, 0xf0, 0xc2,
-	0x3d, 0x4f, 0x1c, 0xd7, 0x63, 0x0e, 0x8a, 0x18, 0x2a, 0x52, 0x30, 0x75, 0x0d, 0x2a, 0xb7, 0x8d,
-	0x27, 0x17, 0xd5, 0xa9, 0xd7, 0x2a, 0xb7, 0x4d, 0x75, 0x1a, 0x6d, 0x11, 0x32, 0xb8, 0xe0, 0xf5,
-	0x05, 0x8c, 0xc6, 0xf2, 0x68, 0x3f, 0x2c, 0x98, 0xf2, 0xd9, 0x7d, 0x21, 0x13, 0xa0, 0x4c, 0xed,
-	0x4b, 0xb6, 0x8c, 0xf1, 0x07, 0x90, 0x61, 0x2a, 0x3c, 0x7d, 0x87, 0x4c, 0x3b, 0x51, 0xc7, 0xe3,
-	0xb1, 0xd0, 0x17, 0xf1, 0xe8, 0x3e, 0x0f, 0x87, 0x3f, 0x83, 0x8a, 0xfb, 0x35, 0x1b, 0xe7, 0xc7,
-	0xd2, 0xcc, 0x0d, 0xe8, 0x3f, 0x34, 0x72, 0x13, 0x5a, 0x0b, 0x1e, 0x5b, 0x81, 0x7d, 0x66, 0x75,
-	0x78, 0xe8, 0x7a, 0x61, 0xcb, 0x3a, 0xf6, 0x8e, 0xf4, 0x25, 0x74, 0xf7, 0x5b, 0xd8, 0xb5, 0xab,
-	0x07, 0x68, 0xb2, 0x6f, 0x9f, 0x1d, 0xa4, 0x06, 0xf7, 0xbd, 0x5a, 0x4f, 0xb2, 0xd5, 0xce, 0x28,
-	0xdc, 0x97, 0xec, 0xa9, 0xb4, 0x7a, 0x8e, 0x72, 0x4a, 0x55, 0x18, 0x3b, 0x75, 0x3c, 0x7c, 0x7e,
-	0x59, 0x1d, 0x17, 0xdf, 0x1c, 0x63, 0x7b, 0x04, 0xe9, 0x68, 0xdb, 0xa2, 0x0d, 0xe9, 0x58, 0x1e,
-	0xa4, 0x23, 0x83, 0x8a, 0x74, 0x64, 0xe3, 0x41, 0x3a, 0x32, 0x80, 0xbe, 0x4b, 0xae, 0x63, 0x93,
-	0xa5, 0xaf, 0x60, 0x11, 0x5f, 0xc9, 0x57, 0x0c, 0xe2, 0x3f, 0x00, 0xa2, 0xa6, 0xc3, 0x2d, 0x87,
-	0x36, 0x7d, 0xc9, 0xe6, 0xd0, 0x1b, 0x8e, 0x0c, 0x33, 0x45, 0xe9, 0x7d, 0xb2, 0x90, 0x1d, 0x28,
-	0x97, 0xfb, 0x3c, 0xe1, 0x3a, 0xc5, 0xcd, 0xfe, 0x02, 0xb6, 0x14, 0x48, 0xec, 0x21, 0xde, 0x97,
-	0x8c, 0x2a, 0x47, 0x2a, 0x05, 0x0d, 0xb3, 0x64, 0x43, 0xcf, 0x88, 0x8e, 0x05, 0xba, 0x13, 0x47,
-	0xad, 0x98, 0x0b, 0xa1, 0x56, 0xea, 0x55, 0xfc, 0x3e, 0xb8, 0x75, 0xd7, 0xc1, 0xe6, 0x20, 0x33,
-	0x51, 0xeb, 0x75, 0x7a, 0x8f, 0x8d, 0x65, 0x8b, 0x6f, 0x1f, 0x3f, 0x99, 0x36, 0xc8, 0x62, 0xb6,
-	0x2f, 0x3a, 0xf6, 0x89, 0xe0, 0x96, 0xd0, 0xd7, 0x30, 0xde, 0xab, 0xf0, 0x1d, 0x29, 0x73, 0x00,
-	0x44, 0xa3, 0xf8, 0x0e, 0x15, 0x2c, 0xbc, 0x97, 0x4c, 0x29, 0x27, 0x0b, 0xb0, 0xcb, 0x20, 0xa9,
-	0xbe, 0xe7, 0x24, 0x42, 0x5f, 0x47, 0x9f, 0xdf, 0x01, 0x9f, 0x81, 0x7d, 0xb6, 0x9b, 0xe3, 0x83,
-	0x53, 0xa7, 0x80, 0xe5, 0xd2, 0x97, 0x05, 0x48, 0x2b, 0x9d, 0x59, 0x9a, 0x4d, 0x5d, 0xb2, 0xe6,
-	0x7a, 0x02, 0x4a, 0xb2, 0x25, 0x3a, 0x76, 0x2c, 0xb8, 0x85, 0x37, 0xbf, 0x7e, 0x13, 0x57, 0x02,
-	0x7b, 0xad, 0x8c, 0x6f, 0x20, 0x8d, 0x3d, 0x45, 0xd1, 0x6b, 0x8d, 0x52, 0x86, 0x39, 0xc6, 0x5e,
-	0x8d, 0x92, 0xf0, 0xa0, 0x63, 0x79, 0xa1, 0xcb, 0xcf, 0xb8, 0xd0, 0x6f, 0x8d, 0x44, 0x39, 0xe4,
-	0x41, 0xe7, 0x5e, 0xca, 0x0e, 0x47, 0x51, 0xa8, 0x41, 0x14, 0x05, 0xa4, 0x3b, 0xe4, 0x06, 0x2e,
-	0x80, 0xab, 0xeb, 0xe8, 0x77, 0xa3, 0x27, 0x59, 0x86, 0x14, 0x57, 0x7b, 0x3a, 0x34, 0xcc, 0x0c,
-	0xa7, 0x09, 0xb9, 0x75, 0xca, 0xed, 0x63, 0x0b, 0x76, 0xb5, 0x95, 0xb4, 0x63, 0x2e, 0xda, 0x91,
-	0xef, 0x5a, 0x1d, 0x27, 0xd1, 0x9f, 0xc2, 0x84, 0x43, 0x79, 0x5f, 0x03, 0x93, 0xef, 0xda, 0xa2,
-	0x7d, 0x98, 0x1b, 0x1c, 0x38, 0x49, 0x5f, 0xb2, 0x0d, 0x74, 0x39, 0x8e, 0x2c, 0x16, 0x75, 0xec,
-	0x54, 0xba, 0x4b, 0xe6, 0x02, 0x3b, 0x3e, 0xe6, 0xb1, 0x15, 0xda, 0x01, 0xd7, 0x37, 0xb0, 0xab,
-	0x32, 0xa0, 0x9c, 0xa5, 0xf0, 0xfb, 0x76, 0xc0, 0x8b, 0x72, 0x36, 0x80, 0x0c, 0x53, 0xe1, 0x69,
-	0x97, 0x6c, 0xc0, 0xeb, 0xc5, 0x8a, 0x4e, 0x43, 0x1e, 0x8b, 0xb6, 0xd7, 0xb1, 0x9a, 0x71, 0x14,
-	0x58, 0x1d, 0x3b, 0xe6, 0x61, 0xa2, 0x3f, 0x8d, 0x29, 0xf8, 0xff, 0x9e, 0x64, 0xb7, 0xc0, 0xea,
-	0x41, 0x6e, 0x54, 0x8f, 0xa3, 0xe0, 0x00, 0x4d, 0xfa, 0x92, 0x3d, 0x9b, 0x57, 0xbc, 0x71, 0xbc,
-	0x61, 0x7e, 0xd3, 0x4c, 0xfa, 0x0b, 0x8d, 0xac, 0x04, 0x91, 0x6b, 0x25, 0x5e, 0xc0, 0xad, 0x53,
-	0x2f, 0x74, 0xa3, 0x53, 0x4b, 0xe8, 0xcf, 0x60, 0xc2, 0x3e, 0xba, 0x92, 0x6c, 0xc5, 0xb4, 0x4f,
-	0xf7, 0x23, 0xf7, 0xd0, 0x0b, 0xf8, 0x07, 0xc8, 0xc2, 0xe5, 0xbd, 0x18, 0x94, 0x90, 0xa2, 0xf7,
-	0x2c, 0xc3, 0x79, 0xe6, 0xce, 0x2f, 0xab, 0xa3, 0x5e, 0xcc, 0x21, 0x1f, 0xf4, 0x53, 0x8d, 0xac,
-	0x67, 0xc7, 0xc4, 0x39, 0x89, 0x41, 0x9b, 0x75, 0x1a, 0x7b, 0x09, 0x17, 0xfa, 0xb3, 0x28, 0xe6,
-	0xfb, 0x50, 0x7a, 0xd3, 0x0d, 0x9f, 0xf1, 0x1f, 0x20, 0xdd, 0x97, 0xec, 0xb6, 0x72, 0x6a, 0x4a,
-	0x9c, 0x72, 0x78, 0x76, 0x94, 0xb3, 0xa3, 0xed, 0x98, 0xe3, 0x3c, 0x41, 0x11, 0xcb, 0xf7, 0x76,
-	0x13, 0x9e, 0x4a, 0xfa, 0xe6, 0xa0, 0x88, 0x65, 0x44, 0x1d, 0xf0, 0xe2, 0xf0, 0xab, 0xa0, 0x61,
-	0x96, 0x6c, 0xa8, 0x4f, 0x96, 0xf1, 0x09, 0x6b, 0x41, 0x2d, 0xb0, 0xd2, 0xfa, 0xca, 0xb0, 0xbe,
-	0xde, 0xcc, 0xeb, 0x6b, 0x0d, 0xf8, 0x41, 0x91, 0xc5, 0xae, 0xfe, 0xa8, 0x84, 0x15, 0x99, 0x2d,
-	0xc3, 0x86, 0x39, 0x64, 0x47, 0x3f, 0xd7, 0xc8, 0x0a, 0x6e, 0x21, 0x7c, 0x01, 0x5b, 0xe9, 0x13,
-	0x58, 0xaf, 0x60, 0xbc, 0x55, 0x78, 0x41, 0xec, 0x46, 0x9d, 0xae, 0x09, 0xdc, 0x3e, 0x52, 0xb5,
-	0xfb, 0xd0, 0x83, 0x39, 0x65, 0xb0, 0x2f, 0xd9, 0x56, 0xb1, 0x8d, 0x14, 0x5c, 0x49, 0xa3, 0x48,
-	0xec, 0xd0, 0xb5, 0x63, 0x17, 0xee, 0xff, 0x99, 0x7c, 0x60, 0x0e, 0x3b, 0xa2, 0x7f, 0x04, 0x39,
-	0x36, 0x14, 0x50, 0x1e, 0x0a, 0x2f, 0xf1, 0x1e, 0x42, 0x46, 0xf5, 0xe7, 0x30, 0x9d, 0x67, 0xd0,
-	0x10, 0xee, 0xda, 0x82, 0x37, 0x72, 0xae, 0x8e, 0x0d, 0xa1, 0x53, 0x86, 0xfa, 0x92, 0xad, 0xa7,
-	0x62, 0xca, 0x38, 0xf4, 0x40, 0x23, 0xb6, 0xa3, 0x10, 0xb4, 0x81, 0x43, 0x41, 0xcc, 0x21, 0x1b,
-	0x41, 0xff, 0xa0, 0x91, 0xe5, 0x66, 0xe4, 0xfb, 0xd1, 0xa9, 0xf5, 0xf1, 0x49, 0xe8, 0x40, 0x3b,
-	0x22, 0x74, 0x63, 0xa0, 0xf2, 0x7b, 0x39, 0xf8, 0xae, 0xd8, 0xf3, 0x62, 0x01, 0x2a, 0x3f, 0x2e,
-	0x43, 0x85, 0xca, 0x21, 0x1c, 0x55, 0x0e, 0xdb, 0x8e, 0x42, 0xa0, 0x72, 0x28, 0x88, 0xb9, 0x94,
-	0x2a, 0x2a, 0x60, 0xfa, 0x80, 0x2c, 0xc2, 0x8e, 0x1a, 0x54, 0x07, 0xfd, 0x79, 0x94, 0x08, 0x0f,
-	0xab, 0x05, 0x60, 0x8a, 0x73, 0xdd, 0x97, 0x6c, 0x35, 0xbd, 0xfc, 0x54, 0xd4, 0x30, 0xcb, 0x56,
-	0xe8, 0x90, 0x87, 0xae, 0xe2, 0xb0, 0xaa, 0x38, 0xe4, 0xa1, 0x3b, 0xc6, 0xa1, 0x8a, 0x82, 0x43,
-	0x75, 0x0c, 0x45, 0x10, 0x15, 0x9e, 0x41, 0x37, 0x2a, 0xf4, 0xdb, 0xe8, 0x0d, 0x8b, 0x20, 0xc0,
-	0x3f, 0x42, 0xb4, 0x28, 0x82, 0x03, 0xc8, 0x30, 0x15, 0x1e, 0x9d, 0x80, 0xaa, 0xcc, 0xc9, 0x0b,
-	0x8a, 0x13, 0x1e, 0xba, 0xc3, 0x4e, 0x0a, 0x08, 0x9c, 0x14, 0x03, 0x68, 0xec, 0x71, 0x3e, 0xdc,
-	0x7d, 0x09, 0x8f, 0xf5, 0x17, 0xb1, 0x07, 0x5d, 0xcd, 0x4f, 0x1c, 0x5a, 0xd5, 0x91, 0xaa, 0x6d,
-	0xe5, 0x8d, 0xef, 0xd9, 0x00, 0xec, 0x4b, 0xb6, 0x82, 0xfe, 0x15, 0xcc, 0x30, 0x55, 0x0b, 0x7a,
-	0x4c, 0x66, 0x63, 0x6e, 0xbb, 0x56, 0x14, 0xfa, 0x5d, 0xfd, 0xcf, 0x75, 0x54, 0xb9, 0x7f, 0x25,
-	0x19, 0xdd, 0xe3, 0x9d, 0x98, 0x3b, 0x76, 0xc2, 0x5d, 0x93, 0xdb, 0xee, 0x83, 0xd0, 0xef, 0xf6,
-	0x24, 0xd3, 0x5e, 0x2d, 0xfe, 0x44, 0x89, 0x23, 0x6c, 0xd6, 0x5f, 0x89, 0x02, 0x0f, 0x6e, 0xce,
-	0xa4, 0x8b, 0x7f, 0xa2, 0x8c, 0xa0, 0xba, 0x66, 0xce, 0xc4, 0x99, 0x03, 0xfa, 0x33, 0xb2, 0x52,
-	0xea, 0xe0, 0xf1, 0x36, 0xfb, 0x4b, 0x1d, 0x5f, 0x56, 0xef, 0x5d, 0x49, 0xa6, 0x0f, 0x82, 0xee,
-	0x0f, 0xfa, 0xf0, 0x03, 0x27, 0xc9, 0x43, 0x6f, 0x0e, 0xb7, 0xf1, 0x07, 0x4e, 0xa2, 0x28, 0xd0,
-	0x35, 0x73, 0xb1, 0x4c, 0xd2, 0x1f, 0x93, 0xe9, 0xb4, 0x7b, 0x11, 0xfa, 0x97, 0x75, 0xac, 0xbc,
-	0xdf, 0x82, 0x6b, 0x60, 0x10, 0x28, 0xed, 0x4a, 0x45, 0xf9, 0xe3, 0xb2, 0x29, 0x8a, 0xeb, 0xac,
-	0xdc, 0xea, 0x9a, 0x99, 0xfb, 0xa3, 0xc7, 0x64, 0x11, 0xfb, 0xba, 0xc1, 0xbe, 0xfb, 0x6b, 0x9a,
-	0xbf, 0xdd, 0x2b, 0xc9, 0x6e, 0x0d, 0x22, 0x34, 0x1c, 0x3b, 0x2c, 0x36, 0x57, 0x1e, 0xe7, 0xd9,
-	0xa2, 0xab, 0x2b, 0xa8, 0xf2, 0x87, 0x2c, 0x94, 0x38, 0xe3, 0xb3, 0x29, 0x32, 0xa7, 0x2c, 0x37,
-	0xfd, 0x88, 0x4c, 0xf3, 0x30, 0x89, 0x3d, 0x2e, 0x74, 0x0d, 0xff, 0x56, 0xd0, 0xc7, 0x6c, 0x8a,
-	0xf7, 0xc2, 0x24, 0xee, 0xd6, 0x5e, 0xcc, 0xff, 0x4d, 0xc8, 0x26, 0x14, 0x3d, 0x2f, 0x8c, 0x71,
-	0xd9, 0xae, 0xe3, 0x2f, 0x33, 0x37, 0xa0, 0xbf, 0xcb, 0x2e, 0x2f, 0xe1, 0x85, 0x2d, 0x9f, 0x5b,
-	0xc8, 0x5a, 0x02, 0x1e, 0x7d, 0x93, 0x98, 0xc2, 0x26, 0xf4, 0x45, 0x81, 0x7d, 0xd6, 0x40, 0x1e,
-	0xa3, 0x34, 0xd4, 0x97, 0xdf, 0x28, 0x55, 0xea, 0xfb, 0x76, 0xde, 0x50, 0x1e, 0x11, 0x63, 0xfc,
-	0xc0, 0x03, 0x10, 0xac, 0xcc, 0x31, 0x1c, 0x7d, 0x44, 0x16, 0x41, 0x5a, 0x12, 0x25, 0xd0, 0x40,
-	0x83, 0xa6, 0x29, 0xd4, 0x74, 0x98, 0xf5, 0x9f, 0x87, 0x40, 0x64, 0x6a, 0x9e, 0xcb, 0xd5, 0x14,
-	0xa0, 0xa2, 0xe3, 0x8d, 0xbb, 0x6f, 0xbd, 0xa9, 0xe8, 0x28, 0xcd, 0x05, 0x05, 0xc0, 0x9b, 0x25,
-	0xd4, 0xf8, 0xbd, 0x46, 0x96, 0x87, 0xd3, 0x0b, 0xcf, 0x8d, 0x00, 0x5e, 0xe3, 0xd9, 0x3f, 0x73,
-	0xff, 0x07, 0x6f, 0x0b, 0x04, 0x94, 0x3e, 0x29, 0x71, 0xda, 0xc5, 0x4b, 0x9b, 0x0c, 0x86, 0x66,
-	0x6a, 0x48, 0xeb, 0xe4, 0x06, 0x3c, 0xdc, 0xbd, 0x04, 0xf3, 0x3b, 0x53, 0xdb, 0xc6, 0xfe, 0x10,
-	0x91, 0xe2, 0x08, 0xa7, 0xc3, 0xc2, 0xcb, 0x9c, 0x32, 0x36, 0x33, 0xdb, 0xda, 0xfd, 0xaf, 0xbe,
-	0xde, 0x9c, 0xb8, 0xfc, 0x7a, 0x73, 0xe2, 0xab, 0xab, 0x4d, 0xed, 0xf2, 0x6a, 0x53, 0xfb, 0xf5,
-	0xe3, 0xcd, 0x89, 0x2f, 0x1e, 0x6f, 0x6a, 0x97, 0x8f, 0x37, 0x27, 0xfe, 0xf5, 0x78, 0x73, 0xe2,
-	0xc3, 0x97, 0xfe, 0x8b, 0x3f, 0x52, 0xd3, 0x7d, 0x74, 0x74, 0x03, 0xff, 0x50, 0x7d, 0xfd, 0x3f,
-	0x01, 0x00, 0x00, 0xff, 0xff, 0xe3, 0x79, 0xd7, 0x43, 0x6e, 0x17, 0x00, 0x00,
+	// 3641 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x5a, 0xcf, 0x73, 0x1c, 0xc7,
+	0x75, 0xe6, 0xf0, 0x37, 0x1a, 0x04, 0x08, 0x34, 0x48, 0x6a, 0x04, 0x49, 0x18, 0x68, 0xb4, 0x92,
+	0x20, 0x59, 0x02, 0x41, 0x8a, 0x66, 0x2c, 0xd9, 0xb2, 0xa5, 0x05, 0x08, 0x49, 0x56, 0x20, 0xa1,
+	0x1a, 0x74, 0xe8, 0xc8, 0x2e, 0x4f, 0x06, 0x33, 0x8d, 0xdd, 0x11, 0x66, 0x67, 0xd6, 0xdd, 0xb3,
+	0x04, 0x96, 0x07, 0x97, 0xe2, 0x43, 0x92, 0xaa, 0x38, 0x55, 0x2a, 0xe6, 0x90, 0xe4, 0x90, 0x2a,
+	0x57, 0x92, 0x4a, 0x25, 0xce, 0x25, 0xe7, 0xfc, 0x05, 0xba, 0xa4, 0x80, 0x63, 0x2a, 0x87, 0x49,
+	0x99, 0xba, 0xed, 0x71, 0x8f, 0xcc, 0x25, 0xf5, 0x5e, 0xcf, 0xf4, 0xf4, 0xcc, 0xae, 0x52, 0xae,
+	0xf2, 0x6d, 0xfb, 0xfb, 0x5e, 0xbf, 0xf7, 0xe6, 0x75, 0xf7, 0xeb, 0xd7, 0xdd, 0x4b, 0x5a, 0x71,
+	0xb4, 0x7f, 0x33, 0x48, 0x93, 0x83, 0xa8, 0x73, 0xf3, 0x20, 0x8d, 0x43, 0x2e, 0x54, 0x63, 0x20,
+	0xfc, 0x2c, 0x4a, 0x93, 0xf5, 0xbe, 0x48, 0xb3, 0x94, 0x5e, 0x54, 0xe0, 0xf2, 0x73, 0x13, 0xd2,
+	0xd9, 0xb0, 0xcf, 0x95, 0xd0, 0xf2, 0x75, 0x83, 0x94, 0xd1, 0xa3, 0x12, 0x5e, 0x36, 0xe0, 0xfe,
+	0x20, 0x8e, 0x53, 0x11, 0x72, 0x51, 0x70, 0x6b, 0x06, 0xf7, 0x90, 0x0b, 0x19, 0xa5, 0x49, 0x94,
+	0x74, 0xa6, 0x78, 0xb0, 0xec, 0x18, 0x92, 0xfb, 0x71, 0x1a, 0x1c, 0x36, 0x55, 0xad, 0x98, 0x66,
+	0x04, 0xf7, 0xe3, 0x38, 0x0d, 0x4c, 0x05, 0x26, 0x2f, 0x78, 0x2f, 0x7d, 0xe8, 0xc7, 0xfd, 0x34,
+	0x8e, 0x82, 0xe1, 0x14, 0x5e, 0x7d, 0x5a, 0x5f, 0xa4, 0x07, 0x51, 0x5c, 0x7e, 0x06, 0x05, 0xfe,
+	0x40, 0xde, 0x84, 0x0f, 0x96, 0x05, 0xf6, 0x7c, 0x81, 0x05, 0x69, 0x7f, 0x28, 0xfc, 0xa4, 0xc3,
+	0x7b, 0x3c, 0xeb, 0xa6, 0x61, 0xe9, 0x72, 0x27, 0x4d, 0x3b, 0x31, 0xbf, 0x89, 0xad, 0xfd, 0xc1,
+	0xc1, 0xcd, 0x2c, 0xea, 0x71, 0x99, 0xf9, 0xbd, 0x7e, 0x21, 0xf0, 0x92, 0xe0, 0xfd, 0x54, 0x56,
+	0x7c, 0x27, 0xed, 0xa4, 0xd8, 0xc0, 0x5f, 0x85, 0xd0, 0x0c, 0x3f, 0xce, 0xd4, 0x4f, 0xf7, 0x7f,
+	0xcf, 0x93, 0x67, 0xb7, 0xd1, 0xb5, 0x2d, 0xfe, 0x30, 0x0a, 0xf8, 0xa6, 0x19, 0x27, 0xfa, 0x1b,
+	0x8b, 0xcc, 0x84, 0x88, 0x7b, 0x51, 0x68, 0x5b, 0xab, 0xd6, 0xda, 0x95, 0xf6, 0xaf, 0xac, 0xaf,
+	0x72, 0xe7, 0xcc, 0x7f, 0xe7, 0xce, 0x9d, 0x4e, 0x94, 0x75, 0x07, 0xfb, 0xeb, 0x41, 0xda, 0xbb,
+	0x29, 0x87, 0x49, 0x90, 0x75, 0xa3, 0xa4, 0x63, 0xfc, 0x82, 0x0f, 0x41, 0x23, 0x41, 0x1a, 0xaf,
+	0x2b, 0xed, 0x1f, 0x6d, 0x3d, 0xc9, 0x9d, 0xcb, 0xe5, 0xef, 0x51, 0xee, 0x5c, 0x0e, 0x8b, 0xdf,
+	0xe3, 0xdc, 0x99, 0x3b, 0xee, 0xc5, 0xef, 0xb8, 0x51, 0xf8, 0x86, 0x9f, 0x65, 0xc2, 0x1d, 0x9d,
+	0xb4, 0x2e, 0x15, 0xbf, 0xc7, 0x27, 0x2d, 0x2d, 0xf7, 0x17, 0xa7, 0x2d, 0xeb, 0xf1, 0x69, 0x4b,
+	0xeb, 0x60, 0x25, 0x13, 0xd2, 0x7f, 0xb6, 0xc8, 0x5c, 0x94, 0x64, 0x22, 0x0d, 0x07, 0x01, 0x0f,
+	0xbd, 0xfd, 0xa1, 0x7d, 0x16, 0x1d, 0xfe, 0xe2, 0xf7, 0x72, 0x78, 0x94, 0x3b, 0x57, 0x2a, 0xad,
+	0xed, 0xe1, 0x38, 0x77, 0x9e, 0x51, 0x8e, 0x1a, 0xa0, 0x76, 0x79, 0x71, 0x02, 0x05, 0x87, 0x59,
+	0x4d, 0x03, 0x0d, 0xc8, 0x12, 0x4f, 0x02, 0x31, 0xec, 0x43, 0x8c, 0xbd, 0xbe, 0x2f, 0xe5, 0x51,
+	0x2a, 0x42, 0xfb, 0xdc, 0xaa, 0xb5, 0x36, 0xd3, 0xbe, 0x3d, 0xca, 0x1d, 0x5a, 0xd1, 0xbb, 0x05,
+	0x3b, 0xce, 0x1d, 0x1b, 0xcd, 0x4e, 0x52, 0x2e, 0x9b, 0x22, 0x4f, 0xff, 0xc1, 0x22, 0x97, 0xf8,
+	0x71, 0x3f, 0x12, 0x5c, 0xda, 0xe7, 0x57, 0xad, 0xb5, 0xd9, 0xdb, 0xcb, 0xeb, 0x6a, 0xf2, 0xac,
+	0x97, 0x93, 0x63, 0xfd, 0x7e, 0x39, 0x79, 0xda, 0xf1, 0x57, 0xb9, 0x63, 0x8d, 0x72, 0x67, 0xb1,
+	0xe8, 0xf2, 0x46, 0xda, 0x8b, 0x32, 0xde, 0xeb, 0x67, 0xf0, 0xbd, 0xcf, 0x2b, 0xc3, 0x05, 0x03,
+	0x1f, 0x55, 0xd1, 0xee, 0x97, 0xff, 0xe3, 0x58, 0xa3, 0x93, 0xd6, 0x8d, 0xe9, 0xf4, 0xf8, 0xa4,
+	0x35, 0xa9, 0x92, 0x95, 0x8e, 0xb9, 0xf9, 0x77, 0xc9, 0x92, 0x9a, 0x7d, 0xf5, 0x79, 0xb7, 0x47,
+	0xce, 0x16, 0xf3, 0x6d, 0xa6, 0xbd, 0xf9, 0x24, 0x77, 0xce, 0xe2, 0x38, 0x9c, 0x8d, 0x20, 0x0c,
+	0x2b, 0xb5, 0x69, 0xb2, 0x9a, 0xa4, 0x21, 0x3f, 0xf0, 0x07, 0x71, 0xf6, 0x8e, 0x9b, 0x89, 0x01,
+	0x37, 0xe7, 0xcd, 0xe3, 0xd3, 0xd6, 0xd9, 0x8f, 0xb6, 0x7e, 0x0d, 0x03, 0x70, 0x36, 0x0a, 0xe9,
+	0x8f, 0xc8, 0x85, 0xd8, 0xdf, 0xe7, 0x31, 0x4e, 0x8b, 0x99, 0xf6, 0x0f, 0x46, 0xb9, 0xa3, 0x80,
+	0x71, 0xee, 0xac, 0xa2, 0x52, 0x6c, 0x15, 0x7a, 0x05, 0x44, 0x46, 0x64, 0xef, 0xb8, 0x07, 0x7e,
+	0x2c, 0x51, 0x2d, 0xa9, 0xe8, 0x2f, 0x4e, 0x5b, 0x67, 0x98, 0xea, 0x4c, 0x3b, 0xe4, 0x2a, 0x2c,
+	0x69, 0x39, 0x94, 0x19, 0xef, 0x79, 0xb0, 0x94, 0x71, 0x24, 0xe7, 0x6f, 0xd3, 0xf5, 0x03, 0xb9,
+	0xbe, 0xad, 0xa9, 0xfb, 0xc3, 0x3e, 0x6f, 0xbf, 0x3e, 0xca, 0x9d, 0xf9, 0x83, 0x1a, 0x36, 0xce,
+	0x9d, 0x6b, 0x68, 0xbd, 0x0e, 0xbb, 0xac, 0x21, 0x47, 0x77, 0xc8, 0xf9, 0xbe, 0x9f, 0x75, 0x71,
+	0x34, 0x67, 0xda, 0x6f, 0x8f, 0x72, 0x07, 0xdb, 0xe3, 0xdc, 0x79, 0x0e, 0xfb, 0x43, 0xa3, 0x70,
+	0x5e, 0x87, 0xe4, 0x17, 0xe0, 0xf8, 0x8c, 0x66, 0x9e, 0x9e, 0xb4, 0xac, 0x5f, 0x30, 0xec, 0x46,
+	0x77, 0xc9, 0x79, 0x74, 0xf6, 0x42, 0xe1, 0xac, 0xca, 0x53, 0xeb, 0x6a, 0x38, 0xd0, 0xd9, 0x35,
+	0x30, 0x91, 0x29, 0x17, 0xaf, 0xa2, 0x09, 0x68, 0xe8, 0xb9, 0x3e, 0xa3, 0x5b, 0x0c, 0xa5, 0xe8,
+	0x4f, 0xc9, 0x25, 0xb5, 0x18, 0xa5, 0x7d, 0x71, 0xf5, 0xdc, 0xda, 0xec, 0xed, 0x17, 0xeb, 0x4a,
+	0xa7, 0x64, 0x98, 0xb6, 0x03, 0x6b, 0x73, 0x94, 0x3b, 0x65, 0xcf, 0x71, 0xee, 0x5c, 0x41, 0x53,
+	0xaa, 0xed, 0xb2, 0x92, 0xa0, 0x7f, 0x6d, 0x91, 0x45, 0xc1, 0x65, 0xe0, 0x27, 0x5e, 0x94, 0x64,
+	0x5c, 0x3c, 0xf4, 0x63, 0x4f, 0xda, 0x97, 0x56, 0xad, 0xb5, 0x0b, 0xed, 0xce, 0x28, 0x77, 0xae,
+	0x2a, 0xf2, 0xa3, 0x82, 0xdb, 0x1b, 0xe7, 0xce, 0x6b, 0xa8, 0xa9, 0x81, 0x37, 0x43, 0xf4, 0xd6,
+	0xdd, 0x8d, 0x0d, 0xf7, 0x69, 0xee, 0x9c, 0x8b, 0x92, 0x6c, 0x74, 0xd2, 0xba, 0x36, 0x4d, 0xfc,
+	0xe9, 0x49, 0xeb, 0x3c, 0xc8, 0xb1, 0xa6, 0x11, 0xfa, 0x1f, 0x16, 0xa1, 0x07, 0xd2, 0x3b, 0xf2,
+	0xb3, 0xa0, 0xcb, 0x85, 0xc7, 0x13, 0x7f, 0x3f, 0xe6, 0xa1, 0x7d, 0x79, 0xd5, 0x5a, 0xbb, 0xdc,
+	0xfe, 0x4b, 0xeb, 0x49, 0xee, 0x2c, 0x6c, 0xef, 0x3d, 0x50, 0xec, 0x3d, 0x45, 0x8e, 0x72, 0x67,
+	0xe1, 0x40, 0xd6, 0xb1, 0x71, 0xee, 0xbc, 0xae, 0x26, 0x41, 0x83, 0x68, 0x7a, 0x5b, 0xce, 0xf1,
+	0xeb, 0x53, 0x05, 0xc1, 0x4f, 0x90, 0x78, 0x7c, 0xda, 0x9a, 0x30, 0xcb, 0x26, 0x8c, 0xd2, 0x7f,
+	0xaf, 0x3b, 0x1f, 0xf2, 0xd8, 0x1f, 0x7a, 0xd2, 0x9e, 0x59, 0xb5, 0xd6, 0xac, 0xf6, 0x2f, 0xc1,
+	0xf9, 0xab, 0x5a, 0xcb, 0x16, 0x90, 0x7b, 0x10, 0xe7, 0x03, 0x59, 0x83, 0xc6, 0xb9, 0xf3, 0x6a,
+	0xdd, 0x75, 0x85, 0x37, 0x3d, 0xbf, 0xb5, 0x01, 0x7e, 0x5f, 0x9b, 0x26, 0xf5, 0xf4, 0xa4, 0x75,
+	0xf6, 0xd6, 0xc6, 0xe3, 0xd3, 0x56, 0xd3, 0x1c, 0x6b, 0x1a, 0xa3, 0x7f, 0x42, 0xae, 0x44, 0x9d,
+	0x24, 0x15, 0xdc, 0xeb, 0x73, 0xd1, 0x93, 0x36, 0xc1, 0x40, 0xbf, 0x3b, 0xca, 0x9d, 0x59, 0x85,
+	0xef, 0x02, 0x3c, 0xce, 0x9d, 0x1b, 0x2a, 0x4d, 0x54, 0x98, 0x9e, 0xb7, 0x0b, 0x4d, 0x90, 0x99,
+	0x5d, 0xe9, 0x9f, 0x5a, 0x64, 0xde, 0x1f, 0x64, 0xa9, 0x97, 0xa4, 0xa2, 0xe7, 0xc7, 0xd1, 0x23,
+	0x6e, 0xcf, 0xa2, 0x91, 0xcf, 0x46, 0xb9, 0x33, 0x07, 0xcc, 0x27, 0x25, 0xa1, 0x3f, 0xbd, 0x86,
+	0x7e, 0xd3, 0x90, 0xd1, 0x49, 0xa9, 0x72, 0xbc, 0x58, 0x5d, 0x2f, 0x4d, 0xc9, 0x5c, 0x2f, 0x4a,
+	0xbc, 0x30, 0x92, 0x87, 0xde, 0x81, 0xe0, 0xdc, 0xbe, 0x82, 0x19, 0xfc, 0x4a, 0xb9, 0x9e, 0xf6,
+	0xa2, 0x47, 0xbc, 0xfd, 0x6e, 0xb1, 0x74, 0x66, 0x7b, 0x51, 0xb2, 0x15, 0xc9, 0xc3, 0x6d, 0xc1,
+	0xc1, 0x23, 0x07, 0x3d, 0x32, 0x30, 0x73, 0x0c, 0x56, 0x5f, 0x76, 0x9f, 0x9e, 0xb4, 0xce, 0xdd,
+	0x5a, 0x7d, 0x99, 0x99, 0xdd, 0x68, 0x87, 0x90, 0xaa, 0x56, 0xb2, 0xe7, 0xd0, 0x9a, 0x53, 0x5a,
+	0xfb, 0x23, 0xcd, 0xd4, 0xd7, 0xee, 0x2b, 0x85, 0x03, 0x46, 0xd7, 0x71, 0xee, 0x2c, 0xa0, 0xfd,
+	0x0a, 0x72, 0x99, 0xc1, 0xd3, 0x77, 0xc9, 0xa5, 0x20, 0xed, 0x47, 0x5c, 0x48, 0x7b, 0x1e, 0x97,
+	0xee, 0x4b, 0xb0, 0xf8, 0x0b, 0x48, 0x17, 0x01, 0x45, 0xbb, 0x5c, 0x96, 0xac, 0x14, 0xa0, 0xff,
+	0x69, 0x91, 0x1b, 0x50, 0xa5, 0x71, 0xe1, 0xf5, 0xfc, 0x63, 0xaf, 0xcf, 0x93, 0x30, 0x4a, 0x3a,
+	0xde, 0x61, 0xb4, 0x6f, 0x5f, 0x45, 0x75, 0x7f, 0x03, 0xb3, 0x76, 0x69, 0x17, 0x45, 0x76, 0xfc,
+	0xe3, 0x5d, 0x25, 0xf0, 0x71, 0xd4, 0x1e, 0xe5, 0xce, 0x52, 0x7f, 0x12, 0x1e, 0xe7, 0xce, 0xb3,
+	0x2a, 0x7b, 0x4e, 0x72, 0x46, 0x56, 0x98, 0xda, 0x75, 0x3a, 0xfc, 0xf8, 0xb4, 0x35, 0xcd, 0x3e,
+	0x9b, 0x22, 0xbb, 0x0f, 0xe1, 0xe8, 0xfa, 0xb2, 0x0b, 0xe1, 0x58, 0xa8, 0xc2, 0x51, 0x40, 0x3a,
+	0x1c, 0x45, 0xbb, 0x0a, 0x47, 0x01, 0xd0, 0xf7, 0xc9, 0x05, 0xac, 0x57, 0xed, 0x45, 0x4c, 0xe2,
+	0x8b, 0xe5, 0x88, 0x81, 0xfd, 0x4f, 0x81, 0x68, 0xdb, 0xb0, 0xcb, 0xa1, 0xcc, 0x38, 0x77, 0x66,
+	0x51, 0x1b, 0xb6, 0x5c, 0xa6, 0x50, 0xfa, 0x31, 0x99, 0x2b, 0x16, 0x54, 0xc8, 0x63, 0x9e, 0x71,
+	0x9b, 0xe2, 0x64, 0x7f, 0x05, 0xeb, 0x1e, 0x24, 0xb6, 0x10, 0x1f, 0xe7, 0x0e, 0x35, 0x96, 0x94,
+	0x02, 0x5d, 0x56, 0x93, 0xa1, 0xc7, 0xc4, 0xc6, 0x04, 0xdd, 0x17, 0x69, 0x47, 0x70, 0x29, 0xcd,
+	0x4c, 0xbd, 0x84, 0xdf, 0x07, 0xbb, 0xee, 0x75, 0x90, 0xd9, 0x2d, 0x44, 0xcc, 0x7c, 0xad, 0xf6,
+	0xb1, 0xa9, 0xac, 0xfe, 0xf6, 0xe9, 0x9d, 0xe9, 0x1e, 0x99, 0x2f, 0xe6, 0x45, 0xdf, 0x1f, 0x48,
+	0xee, 0x49, 0xfb, 0x1a, 0xda, 0x7b, 0x13, 0xbe, 0x43, 0x31, 0xbb, 0x40, 0xec, 0xe9, 0xef, 0x30,
+	0x41, 0xad, 0xbd, 0x26, 0x4a, 0x39, 0x99, 0x83, 0x59, 0x06, 0x41, 0x8d, 0xa3, 0x20, 0x93, 0xf6,
+	0x75, 0xd4, 0xf9, 0x1e, 0xe8, 0xec, 0xf9, 0xc7, 0x9b, 0x25, 0x5e, 0xad, 0x3a, 0x03, 0xac, 0xa7,
+	0xbe, 0xc2, 0x80, 0xca, 0x74, 0xac, 0xd6, 0x9b, 0x86, 0xe4, 0x5a, 0x18, 0x49, 0x48, 0xc9, 0x9e,
+	0xec, 0xfb, 0x42, 0x72, 0x0f, 0x77, 0x7e, 0xfb, 0x06, 0x8e, 0x04, 0x16, 0x84, 0x05, 0xbf, 0x87,
+	0x34, 0xd6, 0x14, 0xba, 0x20, 0x9c, 0xa4, 0x5c, 0x36, 0x45, 0xde, 0xb4, 0x02, 0x65, 0x98, 0x17,
+	0x25, 0x21, 0x3f, 0xe6, 0xd2, 0x7e, 0x66, 0xc2, 0xca, 0x7d, 0xde, 0xeb, 0x7f, 0xa4, 0xd8, 0xa6,
+	0x15, 0x83, 0xaa, 0xac, 0x18, 0x20, 0xbd, 0x4d, 0x2e, 0xe2, 0x00, 0x84, 0xb6, 0x8d, 0x7a, 0x97,
+	0x47, 0xb9, 0x53, 0x20, 0x7a, 0x6b, 0x57, 0x4d, 0x97, 0x15, 0x38, 0xcd, 0xc8, 0x33, 0x47, 0xdc,
+	0x3f, 0xf4, 0x60, 0x56, 0x7b, 0x59, 0x57, 0x70, 0xd9, 0x4d, 0xe3, 0xd0, 0xeb, 0x07, 0x99, 0xfd,
+	0x2c, 0x06, 0x1c, 0xd2, 0xfb, 0x35, 0x10, 0xf9, 0xd0, 0x97, 0xdd, 0xfb, 0xa5, 0xc0, 0x6e, 0x90,
+	0x8d, 0x73, 0x67, 0x19, 0x55, 0x4e, 0x23, 0xf5, 0xa0, 0x4e, 0xed, 0x4a, 0x37, 0xc9, 0x6c, 0xcf,
+	0x17, 0x87, 0x5c, 0x78, 0x89, 0xdf, 0xe3, 0xf6, 0x32, 0x56, 0x55, 0x2e, 0xa4, 0x33, 0x05, 0x7f,
+	0xe2, 0xf7, 0xb8, 0x4e, 0x67, 0x15, 0xe4, 0x32, 0x83, 0xa7, 0x43, 0xb2, 0x0c, 0x07, 0x35, 0x2f,
+	0x3d, 0x4a, 0xb8, 0x90, 0xdd, 0xa8, 0xef, 0x1d, 0x88, 0xb4, 0xe7, 0xf5, 0x7d, 0xc1, 0x93, 0xcc,
+	0x7e, 0x0e, 0x43, 0xf0, 0xbd, 0x51, 0xee, 0x3c, 0x03, 0x52, 0x9f, 0x96, 0x42, 0xdb, 0x22, 0xed,
+	0xed, 0xa2, 0xc8, 0x38, 0x77, 0x5e, 0x28, 0x33, 0xde, 0x34, 0xde, 0x65, 0xdf, 0xd4, 0x93, 0xfe,
+	0x99, 0x45, 0x16, 0x7b, 0x69, 0xe8, 0xc1, 0x09, 0xd0, 0x3b, 0x8a, 0x92, 0x30, 0x3d, 0xf2, 0xa4,
+	0xfd, 0x3c, 0x06, 0xec, 0x27, 0x4f, 0x72, 0x67, 0x91, 0xf9, 0x47, 0x3b, 0x69, 0x08, 0x35, 0xfe,
+	0x03, 0x64, 0x61, 0xf3, 0x9e, 0xef, 0xd5, 0x10, 0x5d, 0x7b, 0xd6, 0xe1, 0x32, 0x72, 0x8f, 0x4f,
+	0x5b, 0x93, 0x5a, 0x58, 0x43, 0x07, 0xfd, 0xc2, 0x22, 0xd7, 0x8b, 0x65, 0x12, 0x0c, 0x04, 0xf8,
+	0xe6, 0x1d, 0x89, 0x28, 0xe3, 0xd2, 0x7e, 0x01, 0x9d, 0xf9, 0x43, 0x48, 0xbd, 0x6a, 0xc2, 0x17,
+	0xfc, 0x03, 0xa4, 0xc7, 0xb9, 0xf3, 0xb2, 0xb1, 0x6a, 0x6a, 0x9c, 0xb1, 0x78, 0x6e, 0x1b, 0x6b,
+	0xc7, 0xba, 0xcd, 0xa6, 0x69, 0x82, 0x24, 0x56, 0xce, 0xed, 0x03, 0x38, 0xcf, 0xd9, 0x2b, 0x55,
+	0x12, 0x2b, 0x88, 0x6d, 0xc0, 0xf5, 0xe2, 0x37, 0x41, 0x97, 0xd5, 0x64, 0x68, 0x4c, 0x16, 0xf0,
+	0x36, 0xc0, 0x83, 0x5c, 0xe0, 0xa9, 0xfc, 0xea, 0x60, 0x7e, 0xbd, 0x51, 0xe6, 0xd7, 0x36, 0xf0,
+	0x55, 0x92, 0xc5, 0xaa, 0x7e, 0xbf, 0x86, 0xe9, 0xc8, 0xd6, 0x61, 0x97, 0x35, 0xe4, 0xe8, 0xaf,
+	0x2c, 0xb2, 0x88, 0x53, 0x08, 0x0f, 0xfb, 0x9e, 0x3a, 0xed, 0xdb, 0xab, 0x68, 0x6f, 0x09, 0x4e,
+	0x10, 0x9b, 0x69, 0x7f, 0xc8, 0x80, 0xdb, 0x41, 0xaa, 0xfd, 0x31, 0xd4, 0x60, 0x41, 0x1d, 0x1c,
+	0xe7, 0xce, 0x9a, 0x9e, 0x46, 0x06, 0x6e, 0x84, 0x51, 0x66, 0x7e, 0x12, 0xfa, 0x22, 0x84, 0xfd,
+	0xff, 0x72, 0xd9, 0x60, 0x4d, 0x45, 0xf4, 0x9f, 0xc0, 0x1d, 0x1f, 0x12, 0x28, 0x4f, 0x64, 0x94,
+	0x45, 0x0f, 0x21, 0xa2, 0xf6, 0x8b, 0x18, 0xce, 0x63, 0x28, 0x08, 0x37, 0x7d, 0xc9, 0xf7, 0x4a,
+	0x6e, 0x1b, 0x0b, 0xc2, 0xa0, 0x0e, 0x8d, 0x73, 0xe7, 0xba, 0x72, 0xa6, 0x8e, 0x43, 0x0d, 0x34,
+	0x21, 0x3b, 0x09, 0x41, 0x19, 0xd8, 0x30, 0xc2, 0x1a, 0x32, 0x92, 0xfe, 0xa3, 0x45, 0x16, 0x0e,
+	0xd2, 0x38, 0x4e, 0x8f, 0xbc, 0xcf, 0x07, 0x49, 0x00, 0xe5, 0x88, 0xb4, 0xdd, 0xca, 0xcb, 0x1f,
+	0x96, 0xe0, 0xfb, 0x72, 0x2b, 0x12, 0x12, 0xbc, 0xfc, 0xbc, 0x0e, 0x69, 0x2f, 0x1b, 0x38, 0x7a,
+	0xd9, 0x94, 0x9d, 0x84, 0xc0, 0xcb, 0x86, 0x11, 0x76, 0x55, 0x79, 0xa4, 0x61, 0xfa, 0x29, 0x99,
+	0x87, 0x19, 0x55, 0x65, 0x07, 0xfb, 0x25, 0x74, 0x11, 0x0e, 0x56, 0x73, 0xc0, 0xe8, 0x75, 0x3d,
+	0xce, 0x9d, 0x25, 0xb5, 0xf9, 0x99, 0xa8, 0xcb, 0xea, 0x52, 0xa8, 0x90, 0x27, 0xa1, 0xa1, 0xb0,
+	0x65, 0x28, 0xe4, 0x49, 0x38, 0x45, 0xa1, 0x89, 0x82, 0x42, 0xb3, 0x0d, 0x49, 0x10, 0x3d, 0x3c,
+	0xf6, 0xb3, 0x4c, 0x48, 0xfb, 0x65, 0xd4, 0x86, 0x49, 0x10, 0xe0, 0x1f, 0x23, 0xaa, 0x93, 0x60,
+	0x05, 0xb9, 0xcc, 0xe0, 0x51, 0x09, 0x78, 0x55, 0x28, 0x79, 0xc5, 0x50, 0xc2, 0x93, 0xb0, 0xa9,
+	0x44, 0x43, 0xa0, 0x44, 0x37, 0xa0, 0xb0, 0xc7, 0xfe, 0xb0, 0xf7, 0x65, 0x5c, 0xd8, 0xaf, 0x62,
+	0x0d, 0xba, 0x54, 0xae, 0x38, 0x94, 0xda, 0x46, 0xaa, 0xbd, 0x56, 0x16, 0xbe, 0xc7, 0x15, 0x38,
+	0xce, 0x9d, 0x45, 0xd4, 0x6f, 0x60, 0x2e, 0x33, 0x25, 0xe8, 0x11, 0x59, 0x90, 0x81, 0x18, 0xec,
+	0x9b, 0x45, 0xc9, 0x1a, 0x66, 0xa8, 0x1d, 0x58, 0xbf, 0xc8, 0x99, 0xd5, 0xc8, 0xb3, 0x45, 0x35,
+	0x62, 0xc2, 0xaa, 0xb6, 0x37, 0xea, 0xc2, 0x29, 0x34, 0x6b, 0xa8, 0xa2, 0x29, 0x59, 0xd8, 0xf7,
+	0x93, 0xf0, 0x28, 0x0a, 0xb3, 0xae, 0x77, 0xc4, 0xa3, 0x4e, 0x37, 0xb3, 0x5f, 0x43, 0xc3, 0x70,
+	0xab, 0x71, 0x55, 0x73, 0x0f, 0x90, 0x1a, 0xe7, 0xce, 0x8b, 0x2a, 0x73, 0xd4, 0x71, 0xb3, 0x9e,
+	0x30, 0x53, 0xe2, 0x2d, 0xd6, 0xd4, 0x40, 0x3f, 0x20, 0x57, 0x64, 0xe6, 0x77, 0xa0, 0x32, 0xc6,
+	0x1b, 0x83, 0xd7, 0x71, 0x6f, 0x6b, 0x41, 0xc8, 0x0a, 0x7c, 0x57, 0x5d, 0x1c, 0xa8, 0x90, 0x19,
+	0x98, 0xcb, 0x4c, 0x09, 0xfa, 0x09, 0x99, 0xcb, 0x84, 0x9f, 0x48, 0x1f, 0x27, 0xb4, 0x1f, 0xdb,
+	0xdf, 0xaa, 0xa6, 0x5b, 0x8d, 0xd0, 0xd3, 0xad, 0x86, 0xba, 0xac, 0x2e, 0x45, 0x3f, 0x21, 0x57,
+	0x04, 0x0f, 0x86, 0x41, 0xcc, 0xbd, 0xd0, 0x1f, 0x4a, 0xfb, 0x0d, 0x8c, 0xc2, 0xb7, 0xc0, 0xb1,
+	0x02, 0xdf, 0xf2, 0x87, 0x52, 0x3b, 0x66, 0x60, 0x7a, 0x33, 0x37, 0x05, 0xa1, 0x40, 0xab, 0xdd,
+	0xcb, 0xda, 0x6f, 0x62, 0xde, 0xbc, 0xae, 0xeb, 0x60, 0x93, 0x54, 0x6e, 0xd7, 0xe4, 0xb5, 0xdb,
+	0x35, 0xd4, 0x65, 0x75, 0x29, 0xfa, 0x53, 0x42, 0xfd, 0xcc, 0x13, 0x5c, 0x66, 0x5e, 0x75, 0xd3,
+	0x66, 0xaf, 0x63, 0x2c, 0xd6, 0xe1, 0x38, 0xef, 0x67, 0x8c, 0xcb, 0xec, 0x9e, 0xe6, 0xf4, 0xf9,
+	0xb3, 0x49, 0xb8, 0x6c, 0x42, 0x96, 0xfe, 0xb9, 0x45, 0x96, 0x8e, 0x7c, 0xd1, 0xf3, 0x02, 0x3f,
+	0xe8, 0x72, 0x18, 0xb1, 0x8c, 0x8b, 0x44, 0xda, 0x37, 0x57, 0xcf, 0xad, 0xcd, 0xb4, 0x1f, 0xc0,
+	0xad, 0x1c, 0xd0, 0x9b, 0xc0, 0xee, 0x16, 0xa4, 0xbe, 0xb2, 0x6a, 0x32, 0xc6, 0xcd, 0xdc, 0xe8,
+	0xa4, 0xb5, 0xfc, 0xcd, 0x34, 0x9b, 0x54, 0x4a, 0xb7, 0xc9, 0x6c, 0xc8, 0xc3, 0x41, 0x3f, 0x8e,
+	0x02, 0x3f, 0xe3, 0xf6, 0x06, 0x7e, 0x20, 0x4e, 0x1b, 0x03, 0xd6, 0xa3, 0x63, 0x60, 0x2e, 0x33,
+	0x25, 0xa0, 0x08, 0x3c, 0x10, 0xe9, 0x23, 0x9e, 0xd8, 0xb7, 0xaa, 0x22, 0x50, 0x21, 0xba, 0x08,
+	0x54, 0x4d, 0x97, 0x15, 0x38, 0xdd, 0x23, 0x57, 0xd5, 0x2f, 0x4f, 0xf2, 0x9f, 0x0f, 0x78, 0x12,
+	0x70, 0xfb, 0xf6, 0xaa, 0xb5, 0x76, 0xae, 0xb8, 0x32, 0x43, 0x6a, 0xaf, 0x60, 0xaa, 0x2b, 0xb3,
+	0x1a, 0x0c, 0x57, 0x66, 0x35, 0x80, 0xde, 0x27, 0x0b, 0x7d, 0xc1, 0x3d, 0x3c, 0x93, 0x04, 0x69,
+	0xaf, 0xe7, 0x27, 0xa1, 0xfd, 0x16, 0x2e, 0x06, 0xd4, 0xda, 0x17, 0x7c, 0x2f, 0xf0, 0x93, 0x4d,
+	0xc5, 0x68, 0xad, 0x75, 0xd8, 0x65, 0x0d, 0x39, 0xfa, 0x63, 0xb2, 0xd8, 0x4f, 0x65, 0x56, 0x57,
+	0x7b, 0x07, 0xd5, 0xbe, 0x01, 0x0b, 0x1a, 0xc8, 0xba, 0x5e, 0xb5, 0xd3, 0x34, 0x70, 0x97, 0x35,
+	0x25, 0xe9, 0x11, 0x59, 0x42, 0xa5, 0xdd, 0x34, 0x3d, 0xc4, 0xc2, 0x2e, 0x1d, 0x64, 0x9e, 0xb4,
+	0xbf, 0x8d, 0xcb, 0xe4, 0x43, 0x98, 0x69, 0x40, 0x7f, 0x98, 0xa6, 0x87, 0xf7, 0x15, 0x09, 0x79,
+	0xea, 0x25, 0x7d, 0x6a, 0x32, 0x09, 0x23, 0x5d, 0xdc, 0xad, 0x1d, 0x3f, 0xee, 0x6e, 0xb0, 0x09,
+	0x2d, 0x50, 0x82, 0xab, 0x9a, 0x47, 0x40, 0xe8, 0x64, 0x66, 0x18, 0xbf, 0x5b, 0x95, 0xe0, 0x28,
+	0xc2, 0x94, 0x84, 0xe1, 0xc0, 0x72, 0x55, 0xe8, 0x34, 0xc8, 0xaa, 0x04, 0x9f, 0xc6, 0xd2, 0x80,
+	0x50, 0xa3, 0xd2, 0x12, 0x3c, 0x13, 0x11, 0x97, 0xf6, 0x1f, 0xa0, 0xc1, 0x6f, 0xc3, 0xd7, 0xea,
+	0x5a, 0x89, 0x29, 0x4e, 0xaf, 0xab, 0x26, 0xa1, 0x0d, 0x4d, 0x74, 0xa1, 0x1e, 0x59, 0x54, 0x46,
+	0xf6, 0x63, 0x3f, 0x38, 0x8c, 0x23, 0x18, 0x38, 0xfb, 0x3b, 0x68, 0xe3, 0x2d, 0x4c, 0xbf, 0x40,
+	0xb6, 0x4b, 0xae, 0xaa, 0x5e, 0x1a, 0xb8, 0xb6, 0xd0, 0xec, 0x40, 0xff, 0xd6, 0x22, 0x37, 0x82,
+	0xb4, 0xd7, 0x8f, 0x39, 0xde, 0xe7, 0x87, 0x91, 0xe0, 0x41, 0x96, 0xe2, 0xa7, 0xbc, 0x8d, 0x4b,
+	0xd8, 0x87, 0x33, 0x6f, 0x25, 0xb1, 0x55, 0x09, 0xe8, 0xd1, 0x9b, 0x64, 0x87, 0xf5, 0x95, 0xfc,
+	0xc2, 0xff, 0x2b, 0xc1, 0xa6, 0xab, 0xa7, 0x6d, 0x72, 0x21, 0x49, 0xa1, 0x12, 0x7f, 0x47, 0xcf,
+	0x4e, 0x05, 0xe8, 0xc3, 0x36, 0xb6, 0x26, 0x6e, 0xbb, 0xd5, 0xfd, 0x36, 0x72, 0xf4, 0x11, 0x99,
+	0x2f, 0xde, 0xb6, 0x3c, 0xf5, 0xb8, 0x65, 0x7f, 0xb7, 0x9e, 0x64, 0x99, 0x62, 0x77, 0x91, 0xc4,
+	0xd3, 0xce, 0x9c, 0x30, 0x21, 0xfd, 0x91, 0x35, 0x74, 0xba, 0xcd, 0x7a, 0x4f, 0x38, 0xe3, 0x5c,
+	0x2d, 0x8d, 0x77, 0x84, 0x1f, 0xc0, 0xb9, 0xfe, 0x7b, 0x38, 0x74, 0x3f, 0x33, 0xcc, 0x7c, 0x00,
+	0x0c, 0x0c, 0xdc, 0x1d, 0xd3, 0x8c, 0x42, 0x6b, 0xcb, 0xe0, 0xce, 0x77, 0x36, 0x36, 0x26, 0xec,
+	0x56, 0x4b, 0xe3, 0xa2, 0x92, 0xa8, 0x39, 0xa2, 0xb4, 0xd0, 0x1d, 0x72, 0xa9, 0x78, 0xba, 0xb3,
+	0xdf, 0xad, 0x7f, 0xbd, 0xba, 0xda, 0xde, 0x55, 0x64, 0xfb, 0x79, 0xb8, 0xbe, 0x29, 0x24, 0xf5,
+	0xf5, 0x4d, 0xd1, 0x76, 0x59, 0xc9, 0xc0, 0x86, 0x02, 0x97, 0x14, 0x41, 0x17, 0x6b, 0xfe, 0xcf,
+	0xd3, 0x81, 0x80, 0xcd, 0xf5, 0xfb, 0xd5, 0x86, 0x32, 0x90, 0x7c, 0x13, 0xc9, 0x1f, 0x2a, 0x4e,
+	0x4f, 0xfc, 0x26, 0xe1, 0xb2, 0x09, 0x59, 0xfa, 0x1e, 0x99, 0x15, 0x83, 0xc4, 0xf3, 0xa5, 0x37,
+	0x90, 0x5c, 0xd8, 0x3f, 0xc0, 0xb1, 0x5f, 0x1d, 0xe5, 0xce, 0x8c, 0x18, 0x24, 0xef, 0xcb, 0x1f,
+	0x49, 0x2e, 0xf4, 0x8d, 0xbe, 0x46, 0x5c, 0x56, 0xb1, 0xd4, 0x23, 0x54, 0xfa, 0x49, 0xb8, 0x9f,
+	0x1e, 0x7b, 0xd5, 0x23, 0x84, 0xfd, 0x1e, 0xfa, 0xb7, 0x01, 0x1b, 0x52, 0xc1, 0x56, 0xaf, 0x1b,
+	0xfa, 0x59, 0x6c, 0x82, 0x71, 0xd9, 0xa4, 0x34, 0xfd, 0x9c, 0x5c, 0xee, 0xf1, 0xcc, 0x0f, 0xfd,
+	0xcc, 0xb7, 0xdf, 0xc7, 0x4a, 0xef, 0x46, 0x3d, 0xa0, 0x3b, 0x05, 0xdb, 0xbe, 0x5b, 0x14, 0x7b,
+	0x5a, 0x5e, 0x3f, 0x01, 0x95, 0xc0, 0xf4, 0x99, 0xa4, 0xe5, 0x71, 0x7f, 0xe5, 0xc7, 0x99, 0xf0,
+	0x3d, 0xb3, 0x28, 0x92, 0x76, 0xbb, 0xda, 0x5f, 0x91, 0xde, 0xab, 0x0a, 0x9f, 0x6a, 0x7f, 0x6d,
+	0x32, 0x8d, 0xfd, 0xf5, 0x9b, 0x69, 0x36, 0xa9, 0x14, 0x36, 0x0e, 0xac, 0xb6, 0x8f, 0xba, 0x3c,
+	0x81, 0x93, 0x1e, 0x17, 0x3c, 0xb4, 0x37, 0x31, 0xaa, 0xb8, 0x71, 0x00, 0xf9, 0xa0, 0xcb, 0x93,
+	0x1d, 0x45, 0xe9, 0x54, 0xd4, 0xc0, 0x5d, 0xd6, 0x94, 0xa4, 0x7f, 0x65, 0x11, 0x1b, 0xcd, 0x7a,
+	0xea, 0x9d, 0xd9, 0xeb, 0x0c, 0x7c, 0x11, 0x16, 0xf7, 0x48, 0x5b, 0xb8, 0x62, 0xee, 0x43, 0x16,
+	0x42, 0x19, 0x15, 0xe1, 0x0f, 0x40, 0xa2, 0xbc, 0x4a, 0x52, 0x2f, 0x25, 0x53, 0xd9, 0xda, 0x3d,
+	0x96, 0xb9, 0x93, 0x9c, 0xbb, 0xb5, 0xb1, 0xc1, 0xa6, 0x6b, 0xa4, 0x3f, 0x23, 0xa4, 0xcb, 0xe1,
+	0x0e, 0x07, 0x23, 0x7d, 0x0f, 0x23, 0x0d, 0x57, 0x7f, 0x33, 0x80, 0x96, 0x11, 0x56, 0x37, 0x4b,
+	0x25, 0x52, 0x8f, 0x2c, 0x9d, 0x84, 0x59, 0xd5, 0x99, 0x1e, 0x92, 0x19, 0xc1, 0xfd, 0xd0, 0x4b,
+	0x93, 0x78, 0x68, 0xff, 0xcb, 0x36, 0x86, 0x70, 0xe7, 0x49, 0xee, 0xd0, 0x2d, 0xde, 0x17, 0x3c,
+	0xf0, 0x33, 0x1e, 0x32, 0xee, 0x87, 0x9f, 0x26, 0xf1, 0x70, 0x94, 0x3b, 0xd6, 0x9b, 0x7a, 0x7a,
+	0x8a, 0xb4, 0xf9, 0x80, 0x09, 0xaf, 0xb6, 0x13, 0xa8, 0x6d, 0xb1, 0xcb, 0xa2, 0x50, 0x40, 0x7f,
+	0x4e, 0x16, 0x6b, 0xb7, 0xf1, 0x78, 0x33, 0xf5, 0xaf, 0xdb, 0xf8, 0x4a, 0x72, 0xef, 0x49, 0xee,
+	0xd8, 0x95, 0xd1, 0x9d, 0xea, 0x4e, 0x7d, 0x37, 0xc8, 0x4a, 0xd3, 0x2b, 0xcd, 0x2b, 0xf9, 0xdd,
+	0x20, 0x33, 0x3c, 0xb0, 0x2d, 0x36, 0x5f, 0x27, 0xe9, 0x1f, 0x93, 0x4b, 0xea, 0x26, 0x52, 0xda,
+	0xbf, 0xd9, 0xc6, 0xe1, 0xfb, 0x3e, 0x5c, 0xe9, 0x54, 0x86, 0xd4, 0x0d, 0xb3, 0xac, 0x7f, 0x5c,
+	0xd1, 0xc5, 0x50, 0x5d, 0x8c, 0x96, 0x6d, 0xb1, 0x52, 0x1f, 0x3d, 0x24, 0xf3, 0x58, 0x63, 0x54,
+	0x67, 0xc8, 0x7f, 0x53, 0xf1, 0x83, 0x87, 0xd6, 0x67, 0x2a, 0x0b, 0x50, 0x97, 0xe8, 0x83, 0x62,
+	0x69, 0xe7, 0x05, 0x5d, 0x6b, 0x68, 0xaa, 0xfe, 0x21, 0x73, 0x35, 0xce, 0xfd, 0xe5, 0x39, 0x32,
+	0x6b, 0x1c, 0xdd, 0xe8, 0x4f, 0xc8, 0x25, 0x9e, 0xa8, 0x6d, 0xde, 0xc2, 0x27, 0x42, 0x7b, 0xca,
+	0x01, 0xef, 0x5e, 0x92, 0x89, 0x61, 0xfb, 0xd5, 0xf2, 0x65, 0xb0, 0xe8, 0xa0, 0xef, 0xaf, 0xa1,
+	0x8d, 0xc3, 0x76, 0x01, 0x7f, 0xb1, 0x52, 0x80, 0xfe, 0x5d, 0x71, 0x11, 0x25, 0xa3, 0xa4, 0x13,
+	0x73, 0x0f, 0x59, 0x0f, 0xfe, 0x35, 0x82, 0x2f, 0xbe, 0x17, 0xda, 0x07, 0x70, 0xc7, 0xd9, 0xf3,
+	0x8f, 0xf7, 0x90, 0x47, 0x2b, 0x7b, 0xe6, 0x2b, 0xce, 0x24, 0x55, 0x9b, 0xfb, 0xb7, 0xef, 0x18,
+	0x07, 0xbf, 0x29, 0x7a, 0xe0, 0x31, 0x07, 0xa4, 0xd8, 0x14, 0x0e, 0x76, 0x51, 0x70, 0x2d, 0x4b,
+	0x33, 0x3f, 0x56, 0x3e, 0x9d, 0xd3, 0xab, 0x12, 0x6e, 0x83, 0xef, 0x03, 0x51, 0x78, 0xf3, 0x62,
+	0xe9, 0x8d, 0x06, 0x0d, 0x3f, 0xee, 0x6c, 0xbc, 0x7d, 0xd7, 0xf0, 0xa3, 0xd6, 0x17, 0x3c, 0x00,
+	0x9e, 0xd5, 0x50, 0xf7, 0xcb, 0xb3, 0x64, 0xbe, 0x9e, 0x55, 0x55, 0xa5, 0x2f, 0x03, 0x11, 0xa9,
+	0xa3, 0x8c, 0x55, 0x1d, 0x10, 0x0d, 0xd8, 0xa8, 0xf4, 0x35, 0x86, 0x95, 0xbe, 0x6e, 0xd1, 0xd7,
+	0xc9, 0xf9, 0x28, 0x48, 0x93, 0xe2, 0x49, 0xfd, 0x06, 0x3c, 0x18, 0x43, 0x7b, 0x9c, 0x3b, 0x04,
+	0x7b, 0x42, 0xc3, 0x65, 0x88, 0xd1, 0x75, 0x72, 0x21, 0x48, 0xe3, 0x54, 0x14, 0x7f, 0x74, 0xc0,
+	0x97, 0x09, 0x04, 0xf4, 0xc8, 0x62, 0xcb, 0x65, 0x0a, 0xa5, 0x9f, 0x91, 0x4b, 0x83, 0x7e, 0x08,
+	0x53, 0xf1, 0x77, 0xf8, 0x03, 0x43, 0xab, 0x9c, 0x2d, 0x45, 0x17, 0xbd, 0xf9, 0x16, 0x6d, 0xfc,
+	0x9f, 0x02, 0x2b, 0x59, 0xf7, 0xef, 0x2d, 0xb2, 0xd0, 0x9c, 0x71, 0xf0, 0x9a, 0xd2, 0x83, 0xc7,
+	0xc6, 0x22, 0x1c, 0x70, 0x2c, 0x55, 0x80, 0x71, 0x0d, 0x9c, 0x05, 0x5d, 0xfd, 0x90, 0x48, 0xaa,
+	0x26, 0x53, 0x82, 0x74, 0x9b, 0x5c, 0x84, 0x77, 0xc9, 0x28, 0xb3, 0xcf, 0xea, 0xcd, 0xbc, 0x40,
+	0x74, 0x34, 0x55, 0x53, 0x6b, 0x99, 0x35, 0xda, 0xac, 0x90, 0x6d, 0x7f, 0xfc, 0xd5, 0x6f, 0x57,
+	0xce, 0x9c, 0xfe, 0x76, 0xe5, 0xcc, 0x57, 0x4f, 0x56, 0xac, 0xd3, 0x27, 0x2b, 0xd6, 0x97, 0x5f,
+	0xaf, 0x9c, 0xf9, 0xf5, 0xd7, 0x2b, 0xd6, 0xe9, 0xd7, 0x2b, 0x67, 0xfe, 0xeb, 0xeb, 0x95, 0x33,
+	0x9f, 0xbd, 0xf6, 0x3b, 0xfc, 0x99, 0x45, 0x2d, 0xad, 0xfd, 0x8b, 0x18, 0xaf, 0xb7, 0xfe, 0x6f,
+	0x00, 0x7b, 0x13, 0xf9, 0xad, 0x98, 0x25, 0x00, 0x00,
 }
 
 func (m *FolderDeviceConfiguration) Marshal() (dAtA []byte, err error) {
@@ -414,6 +571,16 @@ func (m *FolderDeviceConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
+	if m.Expires != nil {
+		n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Expires, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Expires):])
+		if err1 != nil {
+			return 0, err1
+		}
+		i -= n1
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(n1))
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.EncryptionPassword) > 0 {
 		i -= len(m.EncryptionPassword)
 		copy(dAtA[i:], m.EncryptionPassword)
@@ -511,6 +678,282 @@ func (m *FolderConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xc0
 	}
+	if len(m.HeldPaths) > 0 {
+		for iNdEx := len(m.HeldPaths) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.HeldPaths[iNdEx])
+			copy(dAtA[i:], m.HeldPaths[iNdEx])
+			i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.HeldPaths[iNdEx])))
+			i--
+			dAtA[i] = 0x4
+			i--
+			dAtA[i] = 0xaa
+		}
+	}
+	if m.EmptyFolderGuardFiles != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.EmptyFolderGuardFiles))
+		i--
+		dAtA[i] = 0x4
+		i--
+		dAtA[i] = 0xa0
+	}
+	if m.SyncWhenMetered {
+		i--
+		if m.SyncWhenMetered {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x4
+		i--
+		dAtA[i] = 0x98
+	}
+	if len(m.ExtraStagingPaths) > 0 {
+		for iNdEx := len(m.ExtraStagingPaths) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ExtraStagingPaths[iNdEx])
+			copy(dAtA[i:], m.ExtraStagingPaths[iNdEx])
+			i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.ExtraStagingPaths[iNdEx])))
+			i--
+			dAtA[i] = 0x4
+			i--
+			dAtA[i] = 0x92
+		}
+	}
+	{
+		size, err := m.Metadata.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x4
+	i--
+	dAtA[i] = 0x8a
+	if m.SandboxFilesystem {
+		i--
+		if m.SandboxFilesystem {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x4
+		i--
+		dAtA[i] = 0x80
+	}
+	if len(m.RunAsUser) > 0 {
+		i -= len(m.RunAsUser)
+		copy(dAtA[i:], m.RunAsUser)
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.RunAsUser)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xfa
+	}
+	if m.UseChangeJournal {
+		i--
+		if m.UseChangeJournal {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xf0
+	}
+	if m.Profile != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.Profile))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xe8
+	}
+	if m.RemovalGraceS != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.RemovalGraceS))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xe0
+	}
+	if m.RemovalPolicy != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.RemovalPolicy))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xd8
+	}
+	if len(m.Notes) > 0 {
+		i -= len(m.Notes)
+		copy(dAtA[i:], m.Notes)
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.Notes)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xd2
+	}
+	if len(m.CompletionDirectories) > 0 {
+		for iNdEx := len(m.CompletionDirectories) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.CompletionDirectories[iNdEx])
+			copy(dAtA[i:], m.CompletionDirectories[iNdEx])
+			i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.CompletionDirectories[iNdEx])))
+			i--
+			dAtA[i] = 0x3
+			i--
+			dAtA[i] = 0xca
+		}
+	}
+	if m.BlockBlacklistS != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.BlockBlacklistS))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xc0
+	}
+	if m.BlockPullRetries != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.BlockPullRetries))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xb8
+	}
+	if m.BlockRequestTimeoutS != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.BlockRequestTimeoutS))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xb0
+	}
+	if m.ScanHookTimeoutS != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.ScanHookTimeoutS))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xa8
+	}
+	if len(m.PostScanCommand) > 0 {
+		i -= len(m.PostScanCommand)
+		copy(dAtA[i:], m.PostScanCommand)
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.PostScanCommand)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xa2
+	}
+	if len(m.PreScanCommand) > 0 {
+		i -= len(m.PreScanCommand)
+		copy(dAtA[i:], m.PreScanCommand)
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.PreScanCommand)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x9a
+	}
+	if m.FrozenSequence != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.FrozenSequence))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.Frozen {
+		i--
+		if m.Frozen {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.Deduplicate {
+		i--
+		if m.Deduplicate {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x80
+	}
+	if len(m.WarmCachePatterns) > 0 {
+		for iNdEx := len(m.WarmCachePatterns) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.WarmCachePatterns[iNdEx])
+			copy(dAtA[i:], m.WarmCachePatterns[iNdEx])
+			i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.WarmCachePatterns[iNdEx])))
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0xfa
+		}
+	}
+	if m.AtRestEncryption {
+		i--
+		if m.AtRestEncryption {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xf0
+	}
+	if m.Preallocation != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.Preallocation))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xe8
+	}
+	if m.RecycleDays != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.RecycleDays))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xe0
+	}
+	if m.Transactional {
+		i--
+		if m.Transactional {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xd8
+	}
+	if len(m.StagingPath) > 0 {
+		i -= len(m.StagingPath)
+		copy(dAtA[i:], m.StagingPath)
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.StagingPath)))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xd2
+	}
+	if m.BandwidthWeight != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.BandwidthWeight))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xc8
+	}
+	if m.ScrubIntervalS != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.ScrubIntervalS))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xc0
+	}
 	{
 		size, err := m.XattrFilter.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -912,6 +1355,58 @@ func (m *XattrFilter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *FolderMetadata) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FolderMetadata) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FolderMetadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n6, err6 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Updated, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Updated):])
+	if err6 != nil {
+		return 0, err6
+	}
+	i -= n6
+	i = encodeVarintFolderconfiguration(dAtA, i, uint64(n6))
+	i--
+	dAtA[i] = 0x22
+	if len(m.Color) > 0 {
+		i -= len(m.Color)
+		copy(dAtA[i:], m.Color)
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.Color)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Icon) > 0 {
+		i -= len(m.Icon)
+		copy(dAtA[i:], m.Icon)
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.Icon)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *XattrFilterEntry) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
@@ -977,6 +1472,10 @@ func (m *FolderDeviceConfiguration) ProtoSize() (n int) {
 	if l > 0 {
 		n += 1 + l + sovFolderconfiguration(uint64(l))
 	}
+	if m.Expires != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Expires)
+		n += 1 + l + sovFolderconfiguration(uint64(l))
+	}
 	return n
 }
 
@@ -1107,35 +1606,141 @@ func (m *FolderConfiguration) ProtoSize() (n int) {
 	}
 	l = m.XattrFilter.ProtoSize()
 	n += 2 + l + sovFolderconfiguration(uint64(l))
-	if m.DeprecatedReadOnly {
-		n += 4
+	if m.ScrubIntervalS != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.ScrubIntervalS))
 	}
-	if m.DeprecatedMinDiskFreePct != 0 {
-		n += 11
+	if m.BandwidthWeight != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.BandwidthWeight))
 	}
-	if m.DeprecatedPullers != 0 {
-		n += 3 + sovFolderconfiguration(uint64(m.DeprecatedPullers))
+	l = len(m.StagingPath)
+	if l > 0 {
+		n += 2 + l + sovFolderconfiguration(uint64(l))
 	}
-	if m.DeprecatedScanOwnership {
-		n += 4
+	if m.Transactional {
+		n += 3
 	}
-	return n
-}
-
-func (m *XattrFilter) ProtoSize() (n int) {
-	if m == nil {
-		return 0
+	if m.RecycleDays != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.RecycleDays))
 	}
-	var l int
-	_ = l
-	if len(m.Entries) > 0 {
-		for _, e := range m.Entries {
-			l = e.ProtoSize()
-			n += 1 + l + sovFolderconfiguration(uint64(l))
+	if m.Preallocation != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.Preallocation))
+	}
+	if m.AtRestEncryption {
+		n += 3
+	}
+	if len(m.WarmCachePatterns) > 0 {
+		for _, s := range m.WarmCachePatterns {
+			l = len(s)
+			n += 2 + l + sovFolderconfiguration(uint64(l))
 		}
 	}
-	if m.MaxSingleEntrySize != 0 {
-		n += 1 + sovFolderconfiguration(uint64(m.MaxSingleEntrySize))
+	if m.Deduplicate {
+		n += 3
+	}
+	if m.Frozen {
+		n += 3
+	}
+	if m.FrozenSequence != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.FrozenSequence))
+	}
+	l = len(m.PreScanCommand)
+	if l > 0 {
+		n += 2 + l + sovFolderconfiguration(uint64(l))
+	}
+	l = len(m.PostScanCommand)
+	if l > 0 {
+		n += 2 + l + sovFolderconfiguration(uint64(l))
+	}
+	if m.ScanHookTimeoutS != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.ScanHookTimeoutS))
+	}
+	if m.BlockRequestTimeoutS != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.BlockRequestTimeoutS))
+	}
+	if m.BlockPullRetries != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.BlockPullRetries))
+	}
+	if m.BlockBlacklistS != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.BlockBlacklistS))
+	}
+	if len(m.CompletionDirectories) > 0 {
+		for _, s := range m.CompletionDirectories {
+			l = len(s)
+			n += 2 + l + sovFolderconfiguration(uint64(l))
+		}
+	}
+	l = len(m.Notes)
+	if l > 0 {
+		n += 2 + l + sovFolderconfiguration(uint64(l))
+	}
+	if m.RemovalPolicy != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.RemovalPolicy))
+	}
+	if m.RemovalGraceS != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.RemovalGraceS))
+	}
+	if m.Profile != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.Profile))
+	}
+	if m.UseChangeJournal {
+		n += 3
+	}
+	l = len(m.RunAsUser)
+	if l > 0 {
+		n += 2 + l + sovFolderconfiguration(uint64(l))
+	}
+	if m.SandboxFilesystem {
+		n += 3
+	}
+	l = m.Metadata.ProtoSize()
+	n += 2 + l + sovFolderconfiguration(uint64(l))
+	if len(m.ExtraStagingPaths) > 0 {
+		for _, s := range m.ExtraStagingPaths {
+			l = len(s)
+			n += 2 + l + sovFolderconfiguration(uint64(l))
+		}
+	}
+	if m.SyncWhenMetered {
+		n += 3
+	}
+	if m.EmptyFolderGuardFiles != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.EmptyFolderGuardFiles))
+	}
+	if len(m.HeldPaths) > 0 {
+		for _, s := range m.HeldPaths {
+			l = len(s)
+			n += 2 + l + sovFolderconfiguration(uint64(l))
+		}
+	}
+	if m.DeprecatedReadOnly {
+		n += 4
+	}
+	if m.DeprecatedMinDiskFreePct != 0 {
+		n += 11
+	}
+	if m.DeprecatedPullers != 0 {
+		n += 3 + sovFolderconfiguration(uint64(m.DeprecatedPullers))
+	}
+	if m.DeprecatedScanOwnership {
+		n += 4
+	}
+	return n
+}
+
+func (m *XattrFilter) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.ProtoSize()
+			n += 1 + l + sovFolderconfiguration(uint64(l))
+		}
+	}
+	if m.MaxSingleEntrySize != 0 {
+		n += 1 + sovFolderconfiguration(uint64(m.MaxSingleEntrySize))
 	}
 	if m.MaxTotalSize != 0 {
 		n += 1 + sovFolderconfiguration(uint64(m.MaxTotalSize))
@@ -1143,6 +1748,29 @@ func (m *XattrFilter) ProtoSize() (n int) {
 	return n
 }
 
+func (m *FolderMetadata) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovFolderconfiguration(uint64(l))
+	}
+	l = len(m.Icon)
+	if l > 0 {
+		n += 1 + l + sovFolderconfiguration(uint64(l))
+	}
+	l = len(m.Color)
+	if l > 0 {
+		n += 1 + l + sovFolderconfiguration(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Updated)
+	n += 1 + l + sovFolderconfiguration(uint64(l))
+	return n
+}
+
 func (m *XattrFilterEntry) ProtoSize() (n int) {
 	if m == nil {
 		return 0
@@ -1292,6 +1920,42 @@ func (m *FolderDeviceConfiguration) Unmarshal(dAtA []byte) error {
 			}
 			m.EncryptionPassword = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expires == nil {
+				m.Expires = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Expires, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFolderconfiguration(dAtA[iNdEx:])
@@ -2199,11 +2863,11 @@ func (m *FolderConfiguration) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 9000:
+		case 40:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedReadOnly", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ScrubIntervalS", wireType)
 			}
-			var v int
+			m.ScrubIntervalS = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFolderconfiguration
@@ -2213,28 +2877,16 @@ func (m *FolderConfiguration) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.ScrubIntervalS |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.DeprecatedReadOnly = bool(v != 0)
-		case 9001:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedMinDiskFreePct", wireType)
-			}
-			var v uint64
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
-			}
-			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-			m.DeprecatedMinDiskFreePct = float64(math.Float64frombits(v))
-		case 9002:
+		case 41:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedPullers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BandwidthWeight", wireType)
 			}
-			m.DeprecatedPullers = 0
+			m.BandwidthWeight = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFolderconfiguration
@@ -2244,16 +2896,16 @@ func (m *FolderConfiguration) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DeprecatedPullers |= int(b&0x7F) << shift
+				m.BandwidthWeight |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 9003:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedScanOwnership", wireType)
+		case 42:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StagingPath", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFolderconfiguration
@@ -2263,67 +2915,29 @@ func (m *FolderConfiguration) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.DeprecatedScanOwnership = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFolderconfiguration(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFolderconfiguration
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *XattrFilter) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFolderconfiguration
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: XattrFilter: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: XattrFilter: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			m.StagingPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 43:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Transactional", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFolderconfiguration
@@ -2333,31 +2947,55 @@ func (m *XattrFilter) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthFolderconfiguration
+			m.Transactional = bool(v != 0)
+		case 44:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecycleDays", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthFolderconfiguration
+			m.RecycleDays = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RecycleDays |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 45:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Preallocation", wireType)
 			}
-			m.Entries = append(m.Entries, XattrFilterEntry{})
-			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Preallocation = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Preallocation |= Preallocation(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 2:
+		case 46:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxSingleEntrySize", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AtRestEncryption", wireType)
 			}
-			m.MaxSingleEntrySize = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFolderconfiguration
@@ -2367,16 +3005,17 @@ func (m *XattrFilter) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxSingleEntrySize |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxTotalSize", wireType)
+			m.AtRestEncryption = bool(v != 0)
+		case 47:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WarmCachePatterns", wireType)
 			}
-			m.MaxTotalSize = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFolderconfiguration
@@ -2386,11 +3025,923 @@ func (m *XattrFilter) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxTotalSize |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.WarmCachePatterns = append(m.WarmCachePatterns, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 48:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deduplicate", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Deduplicate = bool(v != 0)
+		case 49:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Frozen", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Frozen = bool(v != 0)
+		case 50:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FrozenSequence", wireType)
+			}
+			m.FrozenSequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FrozenSequence |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 51:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreScanCommand", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreScanCommand = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 52:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PostScanCommand", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PostScanCommand = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 53:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScanHookTimeoutS", wireType)
+			}
+			m.ScanHookTimeoutS = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ScanHookTimeoutS |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 54:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockRequestTimeoutS", wireType)
+			}
+			m.BlockRequestTimeoutS = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockRequestTimeoutS |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 55:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockPullRetries", wireType)
+			}
+			m.BlockPullRetries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockPullRetries |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 56:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockBlacklistS", wireType)
+			}
+			m.BlockBlacklistS = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockBlacklistS |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 57:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompletionDirectories", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CompletionDirectories = append(m.CompletionDirectories, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 58:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Notes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Notes = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 59:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovalPolicy", wireType)
+			}
+			m.RemovalPolicy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RemovalPolicy |= RemovalPolicy(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 60:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovalGraceS", wireType)
+			}
+			m.RemovalGraceS = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RemovalGraceS |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 61:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
+			}
+			m.Profile = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Profile |= FolderProfile(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 62:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UseChangeJournal", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.UseChangeJournal = bool(v != 0)
+		case 63:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RunAsUser", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RunAsUser = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 64:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SandboxFilesystem", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SandboxFilesystem = bool(v != 0)
+		case 65:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 66:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtraStagingPaths", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExtraStagingPaths = append(m.ExtraStagingPaths, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 67:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SyncWhenMetered", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SyncWhenMetered = bool(v != 0)
+		case 68:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EmptyFolderGuardFiles", wireType)
+			}
+			m.EmptyFolderGuardFiles = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EmptyFolderGuardFiles |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 69:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeldPaths", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HeldPaths = append(m.HeldPaths, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 9000:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedReadOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DeprecatedReadOnly = bool(v != 0)
+		case 9001:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedMinDiskFreePct", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.DeprecatedMinDiskFreePct = float64(math.Float64frombits(v))
+		case 9002:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedPullers", wireType)
+			}
+			m.DeprecatedPullers = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DeprecatedPullers |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9003:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedScanOwnership", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DeprecatedScanOwnership = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFolderconfiguration(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *XattrFilter) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFolderconfiguration
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: XattrFilter: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: XattrFilter: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Entries = append(m.Entries, XattrFilterEntry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxSingleEntrySize", wireType)
+			}
+			m.MaxSingleEntrySize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxSingleEntrySize |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxTotalSize", wireType)
+			}
+			m.MaxTotalSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxTotalSize |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFolderconfiguration(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FolderMetadata) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFolderconfiguration
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FolderMetadata: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FolderMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Icon", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Icon = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Color", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Color = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Updated, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFolderconfiguration(dAtA[iNdEx:])
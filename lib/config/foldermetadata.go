@@ -0,0 +1,88 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// MaxFolderMetadataSize is the most metadata, in bytes of the description,
+// icon and color together, that is taken over from other devices.
+const MaxFolderMetadataSize = 4096
+
+func FolderMetadataFromProtocol(pm protocol.FolderMetadata) FolderMetadata {
+	m := FolderMetadata{
+		Description: pm.Description,
+		Icon:        pm.Icon,
+		Color:       pm.Color,
+	}
+	if pm.Updated != 0 {
+		m.Updated = time.Unix(0, pm.Updated).UTC()
+	}
+	return m
+}
+
+func (m FolderMetadata) ToProtocol() protocol.FolderMetadata {
+	pm := protocol.FolderMetadata{
+		Description: m.Description,
+		Icon:        m.Icon,
+		Color:       m.Color,
+	}
+	if !m.Updated.IsZero() {
+		pm.Updated = m.Updated.UnixNano()
+	}
+	return pm
+}
+
+func (m FolderMetadata) Equal(other FolderMetadata) bool {
+	return m.sameContent(other) && m.Updated.Equal(other.Updated)
+}
+
+func (m FolderMetadata) sameContent(other FolderMetadata) bool {
+	return m.Description == other.Description && m.Icon == other.Icon && m.Color == other.Color
+}
+
+func (m FolderMetadata) size() int {
+	return len(m.Description) + len(m.Icon) + len(m.Color)
+}
+
+// NewerThan returns whether the metadata should replace the other, i.e.
+// it's more recently updated. Metadata updated at the same time is ordered
+// by its contents, so all devices agree on which wins.
+func (m FolderMetadata) NewerThan(other FolderMetadata) bool {
+	if m.Updated.IsZero() || m.size() > MaxFolderMetadataSize {
+		return false
+	}
+	if !m.Updated.Equal(other.Updated) {
+		return m.Updated.After(other.Updated)
+	}
+	if m.Description != other.Description {
+		return m.Description > other.Description
+	}
+	if m.Icon != other.Icon {
+		return m.Icon > other.Icon
+	}
+	return m.Color > other.Color
+}
+
+// stampFolderMetadata sets the update time of folder metadata changed from
+// the previous configuration, unless it was changed along with the
+// contents, as when taking over metadata from another device.
+func stampFolderMetadata(from, to *Configuration) {
+	prev := from.FolderMap()
+	now := time.Now().UTC()
+	for i := range to.Folders {
+		meta := &to.Folders[i].Metadata
+		old := prev[to.Folders[i].ID].Metadata
+		if meta.sameContent(old) || !meta.Updated.Equal(old.Updated) {
+			continue
+		}
+		meta.Updated = now
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+// weakHashNever is a weak hash threshold no file can reach, disabling
+// weak hashing.
+const weakHashNever = 101
+
+// FolderTuning is the set of performance settings a folder profile
+// decides on.
+type FolderTuning struct {
+	// KeepBlockSize retains the block size of a file when it changes, as
+	// long as it isn't far off the one picked for its size, so unchanged
+	// blocks stay the same.
+	KeepBlockSize        bool      `json:"keepBlockSize"`
+	WeakHashThresholdPct int       `json:"weakHashThresholdPct"`
+	Order                PullOrder `json:"order"`
+	Copiers              int       `json:"copiers"` // zero is the default
+	DisableFsync         bool      `json:"disableFsync"`
+}
+
+var folderProfileTunings = map[FolderProfile]FolderTuning{
+	// Photos and videos are large, mostly added rather than modified and
+	// don't shift data around when they are.
+	FolderProfileMedia: {
+		WeakHashThresholdPct: weakHashNever,
+		Order:                PullOrderNewestFirst,
+	},
+	// Source trees have many small files, which are best synced quickly
+	// and in full.
+	FolderProfileSourceCode: {
+		WeakHashThresholdPct: weakHashNever,
+		Order:                PullOrderSmallestFirst,
+	},
+	// Disk images are huge and modified in place, so keeping their block
+	// layout avoids rehashing and resending unchanged data, while limiting
+	// copiers keeps the disk from thrashing.
+	FolderProfileVmImages: {
+		KeepBlockSize:        true,
+		WeakHashThresholdPct: weakHashNever,
+		Order:                PullOrderAlphabetic,
+		Copiers:              1,
+	},
+	// With many small files the per file overhead dominates, most of all
+	// syncing each of them to disk.
+	FolderProfileSmallFiles: {
+		WeakHashThresholdPct: weakHashNever,
+		Order:                PullOrderSmallestFirst,
+		Copiers:              4,
+		DisableFsync:         true,
+	},
+}
+
+func (p FolderProfile) String() string {
+	switch p {
+	case FolderProfileCustom:
+		return "custom"
+	case FolderProfileMedia:
+		return "media"
+	case FolderProfileSourceCode:
+		return "sourceCode"
+	case FolderProfileVmImages:
+		return "vmImages"
+	case FolderProfileSmallFiles:
+		return "smallFiles"
+	default:
+		return "unknown"
+	}
+}
+
+func (p FolderProfile) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *FolderProfile) UnmarshalText(bs []byte) error {
+	switch string(bs) {
+	case "media":
+		*p = FolderProfileMedia
+	case "sourceCode":
+		*p = FolderProfileSourceCode
+	case "vmImages":
+		*p = FolderProfileVmImages
+	case "smallFiles":
+		*p = FolderProfileSmallFiles
+	default:
+		*p = FolderProfileCustom
+	}
+	return nil
+}
+
+// Tuning returns the resolved performance settings of the folder: Those of
+// its profile, or the individual settings for a custom profile.
+func (f FolderConfiguration) Tuning() FolderTuning {
+	if tuning, ok := folderProfileTunings[f.Profile]; ok {
+		return tuning
+	}
+	return FolderTuning{
+		WeakHashThresholdPct: f.WeakHashThresholdPct,
+		Order:                f.Order,
+		Copiers:              f.Copiers,
+		DisableFsync:         f.DisableFsync,
+	}
+}
+
+// Tuned returns a copy of the folder configuration with the individual
+// settings replaced by the resolved ones of its profile.
+func (f FolderConfiguration) Tuned() FolderConfiguration {
+	tuning := f.Tuning()
+	f.WeakHashThresholdPct = tuning.WeakHashThresholdPct
+	f.Order = tuning.Order
+	f.Copiers = tuning.Copiers
+	f.DisableFsync = tuning.DisableFsync
+	return f
+}
@@ -0,0 +1,83 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lib/config/folderprofile.proto
+
+package config
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type FolderProfile int32
+
+const (
+	FolderProfileCustom     FolderProfile = 0
+	FolderProfileMedia      FolderProfile = 1
+	FolderProfileSourceCode FolderProfile = 2
+	FolderProfileVmImages   FolderProfile = 3
+	FolderProfileSmallFiles FolderProfile = 4
+)
+
+var FolderProfile_name = map[int32]string{
+	0: "FOLDER_PROFILE_CUSTOM",
+	1: "FOLDER_PROFILE_MEDIA",
+	2: "FOLDER_PROFILE_SOURCE_CODE",
+	3: "FOLDER_PROFILE_VM_IMAGES",
+	4: "FOLDER_PROFILE_SMALL_FILES",
+}
+
+var FolderProfile_value = map[string]int32{
+	"FOLDER_PROFILE_CUSTOM":      0,
+	"FOLDER_PROFILE_MEDIA":       1,
+	"FOLDER_PROFILE_SOURCE_CODE": 2,
+	"FOLDER_PROFILE_VM_IMAGES":   3,
+	"FOLDER_PROFILE_SMALL_FILES": 4,
+}
+
+func (FolderProfile) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0416d6c94c53e102, []int{0}
+}
+
+func init() {
+	proto.RegisterEnum("config.FolderProfile", FolderProfile_name, FolderProfile_value)
+}
+
+func init() { proto.RegisterFile("lib/config/folderprofile.proto", fileDescriptor_0416d6c94c53e102) }
+
+var fileDescriptor_0416d6c94c53e102 = []byte{
+	// 330 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xbf, 0x4e, 0x83, 0x50,
+	0x18, 0xc5, 0x2f, 0xb5, 0xe9, 0x40, 0x62, 0x42, 0xd0, 0x5a, 0xbd, 0x26, 0x37, 0x24, 0x4e, 0x3a,
+	0x14, 0xa3, 0x83, 0x83, 0x53, 0xa5, 0x60, 0x88, 0x10, 0x9a, 0x62, 0x3b, 0xb8, 0x90, 0x42, 0x2f,
+	0xf4, 0x26, 0xd0, 0xaf, 0xe1, 0xcf, 0xe0, 0x2b, 0x30, 0xf9, 0x02, 0x24, 0x0e, 0x0e, 0x4e, 0x3e,
+	0x47, 0xc7, 0x8e, 0xae, 0x2d, 0x2f, 0x62, 0xa4, 0x83, 0xd2, 0x74, 0x3b, 0xf7, 0x7e, 0xe7, 0x77,
+	0xce, 0x70, 0x78, 0x12, 0x32, 0x57, 0xf6, 0x60, 0xee, 0xb3, 0x40, 0xf6, 0x21, 0x9c, 0xd2, 0x78,
+	0x11, 0x83, 0xcf, 0x42, 0xda, 0x5d, 0xc4, 0x90, 0x82, 0xd8, 0xda, 0xde, 0xf0, 0x45, 0x4c, 0x17,
+	0x90, 0xc8, 0xd5, 0xa7, 0x9b, 0xf9, 0x72, 0x00, 0x01, 0x54, 0x8f, 0x4a, 0x6d, 0xcd, 0x57, 0x5f,
+	0x0d, 0xfe, 0x50, 0xab, 0x42, 0x06, 0xdb, 0x10, 0xf1, 0x86, 0x6f, 0x6b, 0x96, 0xd1, 0x57, 0x87,
+	0xce, 0x60, 0x68, 0x69, 0xba, 0xa1, 0x3a, 0xca, 0xc8, 0x7e, 0xb6, 0x4c, 0x01, 0xe1, 0x4e, 0x5e,
+	0x48, 0x47, 0x35, 0xb7, 0x92, 0x25, 0x29, 0x44, 0xe2, 0x35, 0x7f, 0xbc, 0xc3, 0x98, 0x6a, 0x5f,
+	0xef, 0x09, 0x1c, 0x3e, 0xc9, 0x0b, 0x49, 0xac, 0x21, 0x26, 0x9d, 0xb2, 0x89, 0x78, 0xcf, 0xe3,
+	0x1d, 0xc2, 0xb6, 0x46, 0x43, 0x45, 0x75, 0x14, 0xab, 0xaf, 0x0a, 0x0d, 0x7c, 0x9e, 0x17, 0x52,
+	0xa7, 0xc6, 0xd9, 0x90, 0xc5, 0x1e, 0x55, 0x60, 0x4a, 0xc5, 0x3b, 0xfe, 0x74, 0x07, 0x1e, 0x9b,
+	0x8e, 0x6e, 0xf6, 0x1e, 0x55, 0x5b, 0x38, 0xc0, 0x67, 0x79, 0x21, 0xb5, 0x6b, 0xe8, 0x38, 0xd2,
+	0xa3, 0x49, 0x40, 0x93, 0x7d, 0xad, 0x66, 0xcf, 0x30, 0x9c, 0x5f, 0x69, 0x0b, 0xcd, 0x7d, 0xad,
+	0xd1, 0x24, 0x0c, 0x35, 0x16, 0xd2, 0x04, 0x37, 0x3f, 0x3f, 0x08, 0x7a, 0x78, 0x5a, 0xae, 0x09,
+	0x5a, 0xad, 0x09, 0x5a, 0x6e, 0x08, 0xb7, 0xda, 0x10, 0xee, 0xad, 0x24, 0xe8, 0xbd, 0x24, 0xdc,
+	0xaa, 0x24, 0xe8, 0xbb, 0x24, 0xe8, 0xe5, 0x32, 0x60, 0xe9, 0x2c, 0x73, 0xbb, 0x1e, 0x44, 0x72,
+	0xf2, 0x3a, 0xf7, 0xd2, 0x19, 0x9b, 0x07, 0xff, 0xd4, 0xdf, 0x7c, 0x6e, 0xab, 0x1a, 0xe1, 0xf6,
+	0x67, 0x00, 0x66, 0xd6, 0x27, 0xba, 0xd3, 0x01, 0x00, 0x00,
+}
@@ -16,6 +16,8 @@ func (t FolderType) String() string {
 		return "receiveonly"
 	case FolderTypeReceiveEncrypted:
 		return "receiveencrypted"
+	case FolderTypeIndexOnly:
+		return "indexonly"
 	default:
 		return "unknown"
 	}
@@ -35,6 +37,8 @@ func (t *FolderType) UnmarshalText(bs []byte) error {
 		*t = FolderTypeReceiveOnly
 	case "receiveencrypted":
 		*t = FolderTypeReceiveEncrypted
+	case "indexonly":
+		*t = FolderTypeIndexOnly
 	default:
 		*t = FolderTypeSendReceive
 	}
@@ -9,6 +9,7 @@ package config
 import (
 	"net/url"
 	"os"
+	"path"
 	"strconv"
 	"strings"
 
@@ -86,6 +87,13 @@ func (c GUIConfiguration) UseTLS() bool {
 	return c.RawUseTLS
 }
 
+// UseACME returns true if the HTTPS certificate for the configured domain
+// should be obtained from an ACME certificate authority such as Let's
+// Encrypt.
+func (c GUIConfiguration) UseACME() bool {
+	return c.ACMEDomain != "" && c.UseTLS() && c.Network() == "tcp"
+}
+
 func (c GUIConfiguration) URL() string {
 	if strings.HasPrefix(c.RawAddress, "/") {
 		return "unix://" + c.RawAddress
@@ -94,7 +102,7 @@ func (c GUIConfiguration) URL() string {
 	u := url.URL{
 		Scheme: "http",
 		Host:   c.Address(),
-		Path:   "/",
+		Path:   c.PathPrefix() + "/",
 	}
 
 	if c.UseTLS() {
@@ -115,6 +123,16 @@ func (c GUIConfiguration) URL() string {
 	return u.String()
 }
 
+// PathPrefix returns the URL path the GUI and API are served under, such as
+// "/syncthing", or the empty string when they are served at the root.
+func (c GUIConfiguration) PathPrefix() string {
+	prefix := path.Clean("/" + strings.TrimSpace(c.RawPathPrefix))
+	if prefix == "/" {
+		return ""
+	}
+	return prefix
+}
+
 // SetHashedPassword hashes the given plaintext password and stores the new hash.
 func (c *GUIConfiguration) HashAndSetPassword(password string) error {
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), 0)
@@ -154,5 +172,8 @@ func (c *GUIConfiguration) prepare() {
 }
 
 func (c GUIConfiguration) Copy() GUIConfiguration {
-	return c
+	cp := c
+	cp.TrustedProxies = make([]string, len(c.TrustedProxies))
+	copy(cp.TrustedProxies, c.TrustedProxies)
+	return cp
 }
@@ -37,6 +37,12 @@ type GUIConfiguration struct {
 	Debugging                 bool     `protobuf:"varint,11,opt,name=debugging,proto3" json:"debugging" xml:"debugging,attr"`
 	InsecureSkipHostCheck     bool     `protobuf:"varint,12,opt,name=insecure_skip_host_check,json=insecureSkipHostCheck,proto3" json:"insecureSkipHostcheck" xml:"insecureSkipHostcheck,omitempty"`
 	InsecureAllowFrameLoading bool     `protobuf:"varint,13,opt,name=insecure_allow_frame_loading,json=insecureAllowFrameLoading,proto3" json:"insecureAllowFrameLoading" xml:"insecureAllowFrameLoading,omitempty"`
+	ACMEDomain                string   `protobuf:"bytes,14,opt,name=acme_domain,json=acmeDomain,proto3" json:"acmeDomain" xml:"acmeDomain,omitempty"`
+	ACMEEmail                 string   `protobuf:"bytes,15,opt,name=acme_email,json=acmeEmail,proto3" json:"acmeEmail" xml:"acmeEmail,omitempty"`
+	ACMEDirectory             string   `protobuf:"bytes,16,opt,name=acme_directory,json=acmeDirectory,proto3" json:"acmeDirectory" xml:"acmeDirectory,omitempty"`
+	ACMEHTTPAddress           string   `protobuf:"bytes,17,opt,name=acme_http_address,json=acmeHttpAddress,proto3" json:"acmeHTTPAddress" xml:"acmeHTTPAddress,omitempty"`
+	RawPathPrefix             string   `protobuf:"bytes,18,opt,name=path_prefix,json=pathPrefix,proto3" json:"pathPrefix" xml:"pathPrefix,omitempty"`
+	TrustedProxies            []string `protobuf:"bytes,19,rep,name=trusted_proxies,json=trustedProxies,proto3" json:"trustedProxies" xml:"trustedProxy,omitempty"`
 }
 
 func (m *GUIConfiguration) Reset()         { *m = GUIConfiguration{} }
@@ -79,60 +85,80 @@ func init() {
 func init() { proto.RegisterFile("lib/config/guiconfiguration.proto", fileDescriptor_2a9586d611855d64) }
 
 var fileDescriptor_2a9586d611855d64 = []byte{
-	// 837 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xcd, 0x6e, 0xdb, 0x46,
-	0x10, 0x16, 0x5b, 0x47, 0xb2, 0xb6, 0xae, 0x60, 0xb0, 0x4d, 0xcb, 0x04, 0x0d, 0xd7, 0x51, 0xd8,
-	0xc2, 0x01, 0x02, 0x39, 0x71, 0x5a, 0x24, 0xf0, 0xa1, 0x80, 0x1c, 0x20, 0x4d, 0x60, 0x17, 0x08,
-	0xe8, 0xfa, 0x92, 0x0b, 0xb1, 0x22, 0xd7, 0xd2, 0x42, 0xfc, 0x2b, 0x77, 0x09, 0x4b, 0x87, 0xf6,
-	0x19, 0x0a, 0xf5, 0x5c, 0xa0, 0xcf, 0xd0, 0x4b, 0x5f, 0x21, 0x37, 0xe9, 0x54, 0xe4, 0xb4, 0x40,
-	0xa4, 0x1b, 0x8f, 0x3c, 0xe6, 0x54, 0xec, 0xf2, 0x47, 0xa2, 0xac, 0xd4, 0xbd, 0xed, 0x7c, 0xf3,
-	0xcd, 0x7c, 0x33, 0xc3, 0x19, 0x10, 0xdc, 0x75, 0x49, 0xef, 0xc0, 0x0e, 0xfc, 0x0b, 0xd2, 0x3f,
-	0xe8, 0xc7, 0x24, 0x7b, 0xc5, 0x11, 0x62, 0x24, 0xf0, 0x3b, 0x61, 0x14, 0xb0, 0x40, 0xad, 0x67,
-	0xe0, 0xed, 0x5b, 0x2b, 0x54, 0x14, 0xb3, 0x81, 0x17, 0x38, 0x38, 0xa3, 0xdc, 0x6e, 0xe2, 0x11,
-	0xcb, 0x9e, 0xed, 0xb7, 0x3b, 0x60, 0xf7, 0x87, 0xf3, 0x97, 0xcf, 0x56, 0x13, 0xa9, 0x3d, 0xd0,
-	0xc0, 0x3e, 0xea, 0xb9, 0xd8, 0xd1, 0x94, 0x3d, 0x65, 0x7f, 0xfb, 0xf8, 0x45, 0xc2, 0x61, 0x01,
-	0xa5, 0x1c, 0xde, 0x1d, 0x79, 0xee, 0x51, 0x3b, 0xb7, 0x1f, 0x20, 0xc6, 0xa2, 0xf6, 0x9e, 0x83,
-	0x2f, 0x50, 0xec, 0xb2, 0xa3, 0x36, 0x8b, 0x62, 0xdc, 0x4e, 0xa6, 0xc6, 0xce, 0xaa, 0xff, 0xfd,
-	0xd4, 0xd8, 0x12, 0x0e, 0xb3, 0xc8, 0xa2, 0xfe, 0x02, 0x1a, 0xc8, 0x71, 0x22, 0x4c, 0xa9, 0xf6,
-	0xd1, 0x9e, 0xb2, 0xdf, 0x3c, 0xb6, 0xe7, 0x1c, 0x02, 0x13, 0x5d, 0x76, 0x33, 0x54, 0x28, 0xe6,
-	0x84, 0x94, 0xc3, 0x6f, 0xa4, 0x62, 0x6e, 0xaf, 0x88, 0x3d, 0x3a, 0x7c, 0xd2, 0x79, 0xd8, 0x79,
-	0xd8, 0x79, 0x74, 0xf4, 0xf4, 0xf1, 0xd3, 0x6f, 0xdb, 0xef, 0xa7, 0x46, 0xab, 0x0a, 0x4d, 0x66,
-	0xc6, 0x4a, 0x52, 0xb3, 0x48, 0xa9, 0xfe, 0xa3, 0x80, 0x2f, 0x63, 0x9f, 0x8c, 0x2c, 0x1a, 0xd8,
-	0x43, 0xcc, 0xac, 0x10, 0x47, 0x1e, 0xa1, 0x94, 0x04, 0x3e, 0xd5, 0x3e, 0x96, 0xf5, 0xfc, 0xa1,
-	0xcc, 0x39, 0xd4, 0x4c, 0x74, 0x79, 0xee, 0x93, 0xd1, 0x99, 0x64, 0xbd, 0x5a, 0x92, 0x12, 0x0e,
-	0x6f, 0xc6, 0x9b, 0x1c, 0x29, 0x87, 0x5f, 0xcb, 0x62, 0x37, 0x7a, 0x1f, 0x04, 0x1e, 0x61, 0xd8,
-	0x0b, 0xd9, 0x58, 0x8c, 0x08, 0x5e, 0xc3, 0x99, 0xcc, 0x8c, 0x0f, 0x16, 0x60, 0x6e, 0x96, 0x57,
-	0x9f, 0x83, 0xad, 0x98, 0xe2, 0x48, 0xdb, 0x92, 0x4d, 0x1c, 0x26, 0x1c, 0x4a, 0x3b, 0xe5, 0xf0,
-	0xf3, 0xac, 0x2c, 0x8a, 0xa3, 0x6a, 0x15, 0xad, 0x2a, 0x64, 0x4a, 0xbe, 0xfa, 0x1a, 0x6c, 0x87,
-	0x88, 0xd2, 0xcb, 0x20, 0x72, 0xb4, 0x1b, 0x32, 0xd7, 0xf7, 0x09, 0x87, 0x25, 0x96, 0x72, 0xa8,
-	0xc9, 0x7c, 0x05, 0x50, 0xcd, 0xa9, 0x5e, 0x85, 0xcd, 0x32, 0x56, 0xf5, 0x40, 0x53, 0x6c, 0xa4,
-	0x25, 0x56, 0x52, 0xab, 0xef, 0x29, 0xfb, 0xad, 0xc3, 0xdd, 0x4e, 0xb6, 0xaa, 0x9d, 0x6e, 0xcc,
-	0x06, 0x3f, 0x06, 0x0e, 0xce, 0xe4, 0x50, 0x6e, 0x95, 0x72, 0x05, 0xb0, 0x26, 0x77, 0x15, 0x36,
-	0xcb, 0x58, 0x15, 0x83, 0x46, 0x4c, 0xb1, 0xc5, 0x5c, 0xaa, 0x35, 0xe4, 0x3a, 0x9f, 0xce, 0x39,
-	0x6c, 0x8a, 0xc1, 0x52, 0xfc, 0xd3, 0xe9, 0x59, 0xc2, 0x61, 0x3d, 0x96, 0xaf, 0x94, 0xc3, 0x96,
-	0x54, 0x61, 0x2e, 0xcd, 0xd6, 0x3a, 0x99, 0x1a, 0xdb, 0x85, 0x91, 0x4e, 0x8d, 0x9c, 0x37, 0x99,
-	0x19, 0xcb, 0x70, 0x53, 0x82, 0x2e, 0x15, 0x32, 0x28, 0x24, 0xd6, 0x10, 0x8f, 0xb5, 0x6d, 0x39,
-	0x30, 0x21, 0x53, 0xef, 0xbe, 0x7a, 0x79, 0x82, 0xc7, 0x42, 0x03, 0x85, 0xe4, 0x04, 0x8f, 0x53,
-	0x0e, 0xbf, 0xc8, 0x3a, 0x09, 0xc9, 0x10, 0x8f, 0xab, 0x7d, 0xec, 0xae, 0x83, 0x93, 0x99, 0x91,
-	0x67, 0x30, 0xf3, 0x78, 0xf5, 0x77, 0x05, 0xdc, 0x24, 0x3e, 0xc5, 0x76, 0x1c, 0x61, 0x0b, 0x39,
-	0x1e, 0xf1, 0x2d, 0x64, 0xdb, 0xe2, 0x8e, 0x9a, 0xb2, 0x39, 0x2b, 0xe1, 0xf0, 0xb3, 0x82, 0xd0,
-	0x15, 0xfe, 0xae, 0x74, 0xa7, 0x1c, 0xde, 0x93, 0xc2, 0x1b, 0x7c, 0xd5, 0x2a, 0xee, 0xfc, 0x27,
-	0xc3, 0xdc, 0x94, 0x5c, 0x3d, 0x01, 0x37, 0xd8, 0x00, 0x7b, 0x58, 0x03, 0xb2, 0xf5, 0xef, 0x12,
-	0x0e, 0x33, 0x20, 0xe5, 0xf0, 0x4e, 0x36, 0x53, 0x61, 0xad, 0x9c, 0x6e, 0xfe, 0x10, 0x37, 0xdb,
-	0xc8, 0xdf, 0x66, 0x16, 0xa2, 0x9e, 0x83, 0xa6, 0x83, 0x7b, 0x71, 0xbf, 0x4f, 0xfc, 0xbe, 0xf6,
-	0x89, 0xec, 0xea, 0x49, 0xc2, 0xe1, 0x12, 0x2c, 0xb7, 0xb9, 0x44, 0xca, 0xcf, 0xd5, 0xaa, 0x42,
-	0xe6, 0x32, 0x48, 0xfd, 0x5b, 0x01, 0x5a, 0x39, 0x39, 0x3a, 0x24, 0xa1, 0x35, 0x08, 0x28, 0xb3,
-	0xec, 0x01, 0xb6, 0x87, 0xda, 0x8e, 0x94, 0xf9, 0x55, 0xdc, 0x75, 0xc1, 0x39, 0x1b, 0x92, 0xf0,
-	0x45, 0x40, 0x99, 0x24, 0x94, 0x77, 0xbd, 0xd1, 0xbb, 0x76, 0xd7, 0xd7, 0x70, 0xd2, 0xa9, 0xb1,
-	0x59, 0xc4, 0xbc, 0x02, 0x3f, 0x13, 0xb0, 0xfa, 0x97, 0x02, 0xbe, 0x5a, 0x7e, 0x73, 0xd7, 0x0d,
-	0x2e, 0xad, 0x8b, 0x08, 0x79, 0xd8, 0x72, 0x03, 0xe4, 0x88, 0x21, 0x7d, 0x2a, 0xab, 0xff, 0x39,
-	0xe1, 0xf0, 0x56, 0xf9, 0x75, 0x04, 0xed, 0xb9, 0x60, 0x9d, 0x66, 0xa4, 0x94, 0xc3, 0xfb, 0xd5,
-	0x05, 0x58, 0x67, 0x54, 0xbb, 0xb8, 0xf7, 0x3f, 0x78, 0xe6, 0x87, 0xe5, 0x8e, 0x4f, 0xde, 0xbc,
-	0xd3, 0x6b, 0xb3, 0x77, 0x7a, 0xed, 0xcd, 0x5c, 0x57, 0x66, 0x73, 0x5d, 0xf9, 0x6d, 0xa1, 0xd7,
-	0xfe, 0x5c, 0xe8, 0xca, 0x6c, 0xa1, 0xd7, 0xde, 0x2e, 0xf4, 0xda, 0xeb, 0xfb, 0x7d, 0xc2, 0x06,
-	0x71, 0xaf, 0x63, 0x07, 0xde, 0x01, 0x1d, 0xfb, 0x36, 0x1b, 0x10, 0xbf, 0xbf, 0xf2, 0x5a, 0xfe,
-	0xc1, 0x7a, 0x75, 0xf9, 0xbb, 0x7a, 0xfc, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd8, 0x8c, 0xef,
-	0xc0, 0x01, 0x07, 0x00, 0x00,
+	// 1156 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xbd, 0x6f, 0xdb, 0xc6,
+	0x1b, 0x36, 0x7f, 0x49, 0x6c, 0x8b, 0x8e, 0x65, 0x87, 0xce, 0x07, 0x6d, 0x24, 0x3a, 0x47, 0xe1,
+	0xaf, 0x70, 0x80, 0x40, 0x4e, 0x9c, 0x16, 0x09, 0x3c, 0x14, 0x90, 0xdd, 0xa4, 0x0e, 0xec, 0x00,
+	0x02, 0x6d, 0x2f, 0x19, 0x4a, 0x9c, 0xc8, 0xb3, 0x74, 0x90, 0xf8, 0x51, 0xde, 0x11, 0x96, 0x86,
+	0x16, 0xdd, 0xba, 0x14, 0x68, 0xa1, 0xa2, 0x63, 0x81, 0x8e, 0x9d, 0xbb, 0x14, 0xfd, 0x0f, 0xb2,
+	0x49, 0x53, 0xd1, 0xe9, 0x80, 0xd8, 0x1b, 0x47, 0x8e, 0x01, 0x0a, 0x14, 0x77, 0x94, 0xf8, 0x21,
+	0xd3, 0x4d, 0xb7, 0x7b, 0x9f, 0xe7, 0xb9, 0xf7, 0x7d, 0xee, 0xe5, 0x7b, 0x27, 0xc9, 0xf7, 0xbb,
+	0xb8, 0xb9, 0x69, 0xba, 0xce, 0x09, 0x6e, 0x6d, 0xb6, 0x02, 0x1c, 0xaf, 0x02, 0x1f, 0x52, 0xec,
+	0x3a, 0x35, 0xcf, 0x77, 0xa9, 0xab, 0xcc, 0xc6, 0xe0, 0xda, 0x6a, 0x46, 0x0a, 0x03, 0xda, 0xb6,
+	0x5d, 0x0b, 0xc5, 0x92, 0xb5, 0x12, 0xea, 0xd1, 0x78, 0x59, 0xfd, 0x7b, 0x45, 0x5e, 0xfe, 0xfc,
+	0xf8, 0xd5, 0x6e, 0x36, 0x91, 0xd2, 0x94, 0xe7, 0x90, 0x03, 0x9b, 0x5d, 0x64, 0xa9, 0xd2, 0xba,
+	0xb4, 0x31, 0xbf, 0xb3, 0x17, 0x32, 0x30, 0x81, 0x22, 0x06, 0xee, 0xf7, 0xec, 0xee, 0x76, 0x75,
+	0x1c, 0x3f, 0x82, 0x94, 0xfa, 0xd5, 0x75, 0x0b, 0x9d, 0xc0, 0xa0, 0x4b, 0xb7, 0xab, 0xd4, 0x0f,
+	0x50, 0x35, 0x1c, 0x6a, 0xd7, 0xb3, 0xfc, 0xfb, 0xa1, 0x76, 0x95, 0x13, 0xfa, 0x24, 0x8b, 0xf2,
+	0x95, 0x3c, 0x07, 0x2d, 0xcb, 0x47, 0x84, 0xa8, 0xff, 0x5b, 0x97, 0x36, 0x4a, 0x3b, 0xe6, 0x19,
+	0x03, 0xb2, 0x0e, 0x4f, 0xeb, 0x31, 0xca, 0x2b, 0x8e, 0x05, 0x11, 0x03, 0x1f, 0x89, 0x8a, 0xe3,
+	0x38, 0x53, 0xec, 0xc9, 0xd6, 0xb3, 0xda, 0xe3, 0xda, 0xe3, 0xda, 0x93, 0xed, 0xe7, 0x4f, 0x9f,
+	0x7f, 0x5c, 0x7d, 0x3f, 0xd4, 0xca, 0x79, 0x68, 0x30, 0xd2, 0x32, 0x49, 0xf5, 0x49, 0x4a, 0xe5,
+	0x4f, 0x49, 0xbe, 0x13, 0x38, 0xb8, 0x67, 0x10, 0xd7, 0xec, 0x20, 0x6a, 0x78, 0xc8, 0xb7, 0x31,
+	0x21, 0xd8, 0x75, 0x88, 0x7a, 0x45, 0xf8, 0xf9, 0x59, 0x3a, 0x63, 0x40, 0xd5, 0xe1, 0xe9, 0xb1,
+	0x83, 0x7b, 0x87, 0x42, 0xd5, 0x48, 0x45, 0x21, 0x03, 0xb7, 0x82, 0x22, 0x22, 0x62, 0xe0, 0xff,
+	0xc2, 0x6c, 0x21, 0xfb, 0xc8, 0xb5, 0x31, 0x45, 0xb6, 0x47, 0xfb, 0xbc, 0x45, 0xe0, 0x03, 0x9a,
+	0xc1, 0x48, 0xbb, 0xd4, 0x80, 0x5e, 0x5c, 0x5e, 0x79, 0x29, 0x5f, 0x0d, 0x08, 0xf2, 0xd5, 0xab,
+	0xe2, 0x10, 0x5b, 0x21, 0x03, 0x22, 0x8e, 0x18, 0xb8, 0x19, 0xdb, 0x22, 0xc8, 0xcf, 0xbb, 0x28,
+	0xe7, 0x21, 0x5d, 0xe8, 0x95, 0x37, 0xf2, 0xbc, 0x07, 0x09, 0x39, 0x75, 0x7d, 0x4b, 0xbd, 0x26,
+	0x72, 0x7d, 0x1a, 0x32, 0x90, 0x60, 0x11, 0x03, 0xaa, 0xc8, 0x37, 0x01, 0xf2, 0x39, 0x95, 0x8b,
+	0xb0, 0x9e, 0xec, 0x55, 0x6c, 0xb9, 0xc4, 0x27, 0xd2, 0xe0, 0x23, 0xa9, 0xce, 0xae, 0x4b, 0x1b,
+	0xe5, 0xad, 0xe5, 0x5a, 0x3c, 0xaa, 0xb5, 0x7a, 0x40, 0xdb, 0xaf, 0x5d, 0x0b, 0xc5, 0xe5, 0xe0,
+	0x38, 0x4a, 0xca, 0x4d, 0x80, 0xa9, 0x72, 0x17, 0x61, 0x3d, 0xd9, 0xab, 0x20, 0x79, 0x2e, 0x20,
+	0xc8, 0xa0, 0x5d, 0xa2, 0xce, 0x89, 0x71, 0x3e, 0x38, 0x63, 0xa0, 0xc4, 0x1b, 0x4b, 0xd0, 0xd1,
+	0xc1, 0x61, 0xc8, 0xc0, 0x6c, 0x20, 0x56, 0x11, 0x03, 0x65, 0x51, 0x85, 0x76, 0x49, 0x3c, 0xd6,
+	0xe1, 0x50, 0x9b, 0x9f, 0x04, 0xd1, 0x50, 0x1b, 0xeb, 0x06, 0x23, 0x2d, 0xdd, 0xae, 0x0b, 0xb0,
+	0x4b, 0x78, 0x19, 0xe8, 0x61, 0xa3, 0x83, 0xfa, 0xea, 0xbc, 0x68, 0x18, 0x2f, 0x33, 0x5b, 0x6f,
+	0xbc, 0xda, 0x47, 0x7d, 0x5e, 0x03, 0x7a, 0x78, 0x1f, 0xf5, 0x23, 0x06, 0x6e, 0xc7, 0x27, 0xf1,
+	0x70, 0x07, 0xf5, 0xf3, 0xe7, 0x58, 0x9e, 0x06, 0x07, 0x23, 0x6d, 0x9c, 0x41, 0x1f, 0xef, 0x57,
+	0x7e, 0x94, 0xe4, 0x5b, 0xd8, 0x21, 0xc8, 0x0c, 0x7c, 0x64, 0x40, 0xcb, 0xc6, 0x8e, 0x01, 0x4d,
+	0x93, 0xdf, 0xa3, 0x92, 0x38, 0x9c, 0x11, 0x32, 0xb0, 0x32, 0x11, 0xd4, 0x39, 0x5f, 0x17, 0x74,
+	0xc4, 0xc0, 0x03, 0x51, 0xb8, 0x80, 0xcb, 0xbb, 0xb8, 0xf7, 0xaf, 0x0a, 0xbd, 0x28, 0xb9, 0xb2,
+	0x2f, 0x5f, 0xa3, 0x6d, 0x64, 0x23, 0x55, 0x16, 0x47, 0xff, 0x24, 0x64, 0x20, 0x06, 0x22, 0x06,
+	0xee, 0xc5, 0x3d, 0xe5, 0x51, 0xe6, 0xea, 0x8e, 0x17, 0xfc, 0xce, 0xce, 0x8d, 0xd7, 0x7a, 0xbc,
+	0x45, 0x39, 0x96, 0x4b, 0x16, 0x6a, 0x06, 0xad, 0x16, 0x76, 0x5a, 0xea, 0x82, 0x38, 0xd5, 0xb3,
+	0x90, 0x81, 0x14, 0x4c, 0xa6, 0x39, 0x41, 0x92, 0xcf, 0x55, 0xce, 0x43, 0x7a, 0xba, 0x49, 0xf9,
+	0x5d, 0x92, 0xd5, 0xa4, 0x73, 0xa4, 0x83, 0x3d, 0xa3, 0xed, 0x12, 0x6a, 0x98, 0x6d, 0x64, 0x76,
+	0xd4, 0xeb, 0xa2, 0xcc, 0xd7, 0xfc, 0x5e, 0x4f, 0x34, 0x87, 0x1d, 0xec, 0xed, 0xb9, 0x84, 0x0a,
+	0x41, 0x72, 0xaf, 0x0b, 0xd9, 0xa9, 0x7b, 0xfd, 0x01, 0x4d, 0x34, 0xd4, 0x8a, 0x8b, 0xe8, 0x17,
+	0xe0, 0x5d, 0x0e, 0x2b, 0xbf, 0x49, 0xf2, 0xdd, 0xf4, 0x9b, 0x77, 0xbb, 0xee, 0xa9, 0x71, 0xe2,
+	0x43, 0x1b, 0x19, 0x5d, 0x17, 0x5a, 0xbc, 0x49, 0x8b, 0xc2, 0xfd, 0x97, 0x21, 0x03, 0xab, 0xc9,
+	0xd7, 0xe1, 0xb2, 0x97, 0x5c, 0x75, 0x10, 0x8b, 0x22, 0x06, 0x1e, 0xe6, 0x07, 0x60, 0x5a, 0x91,
+	0x3f, 0xc5, 0x83, 0xff, 0xa0, 0xd3, 0x2f, 0x2f, 0xa7, 0x7c, 0x2f, 0xc9, 0x0b, 0xd0, 0xb4, 0x91,
+	0x61, 0xb9, 0x36, 0xc4, 0x8e, 0x5a, 0x16, 0x93, 0xe1, 0xf0, 0x67, 0xbe, 0xbe, 0xfb, 0xfa, 0xc5,
+	0x67, 0x02, 0x0d, 0x19, 0x90, 0xb9, 0x28, 0x8e, 0x22, 0x06, 0xd6, 0xe2, 0xcb, 0x91, 0x40, 0x79,
+	0x4f, 0x37, 0x8b, 0x88, 0x68, 0xa8, 0x65, 0x72, 0xf0, 0x17, 0x3f, 0xcd, 0xaf, 0x67, 0x18, 0xe5,
+	0x5b, 0x49, 0x16, 0xa1, 0x81, 0x6c, 0x88, 0xbb, 0xea, 0x92, 0x30, 0xd4, 0xe6, 0x8f, 0x01, 0xdf,
+	0xf0, 0x82, 0x83, 0x7c, 0xcc, 0xb8, 0x44, 0x04, 0x11, 0x03, 0xab, 0x89, 0x1d, 0x81, 0xe4, 0xdd,
+	0xac, 0x14, 0xe0, 0xd1, 0x50, 0x4b, 0x13, 0xf0, 0x87, 0x22, 0x49, 0xad, 0xa7, 0xb8, 0xf2, 0xab,
+	0x24, 0x97, 0xe3, 0xde, 0x60, 0x1f, 0x99, 0xd4, 0xf5, 0xfb, 0xea, 0xb2, 0x70, 0xf3, 0x0d, 0xff,
+	0xd5, 0x59, 0x14, 0xfe, 0x27, 0x4c, 0xc8, 0xc0, 0xa2, 0x38, 0xc4, 0x04, 0x48, 0xae, 0x54, 0x0e,
+	0xcd, 0x5b, 0xbb, 0x73, 0x09, 0x17, 0x0d, 0xb5, 0x7c, 0xb2, 0xc1, 0x48, 0xcb, 0x97, 0xd3, 0xf3,
+	0xbc, 0xf2, 0x87, 0x24, 0xdf, 0x10, 0x56, 0xdb, 0x94, 0x7a, 0xc6, 0xe4, 0x37, 0xfb, 0x86, 0x70,
+	0xfb, 0x1d, 0x77, 0xbb, 0xc4, 0xb7, 0xef, 0x1d, 0x1d, 0x35, 0xd2, 0x5f, 0xee, 0x25, 0xbe, 0x21,
+	0x03, 0x45, 0x0c, 0x80, 0xc4, 0x71, 0x06, 0xcf, 0x7b, 0x5e, 0xbd, 0x94, 0x8d, 0x86, 0xda, 0x74,
+	0xca, 0xc1, 0x48, 0x9b, 0x2e, 0xac, 0xc7, 0x1a, 0x4a, 0xbd, 0x31, 0xa0, 0xfc, 0x24, 0xc9, 0x0b,
+	0x1e, 0xa4, 0x6d, 0xc3, 0xf3, 0xd1, 0x09, 0xee, 0xa9, 0x8a, 0x70, 0x4d, 0x79, 0x8b, 0x75, 0x78,
+	0xda, 0x80, 0xb4, 0xdd, 0x10, 0x04, 0x9f, 0x42, 0x2f, 0x89, 0x92, 0x29, 0x4c, 0xa1, 0xa9, 0x29,
+	0x2c, 0x22, 0xf8, 0x14, 0xa6, 0x38, 0x6f, 0x6b, 0xae, 0x84, 0x9e, 0x21, 0xf9, 0x20, 0x2e, 0x51,
+	0x3f, 0x20, 0x14, 0x59, 0x86, 0xe7, 0xbb, 0x3d, 0x8c, 0x88, 0xba, 0xb2, 0x7e, 0x65, 0xa3, 0xb4,
+	0xf3, 0x45, 0xc8, 0x40, 0x79, 0x4c, 0x35, 0x62, 0x26, 0x62, 0xe0, 0x6e, 0xfc, 0x82, 0xa6, 0xf0,
+	0xd4, 0xd7, 0xbe, 0x5d, 0x4c, 0x45, 0x43, 0x6d, 0x2a, 0x97, 0x3e, 0x15, 0xef, 0xec, 0xbf, 0x7d,
+	0x57, 0x99, 0x19, 0xbd, 0xab, 0xcc, 0xbc, 0x3d, 0xab, 0x48, 0xa3, 0xb3, 0x8a, 0xf4, 0xc3, 0x79,
+	0x65, 0xe6, 0x97, 0xf3, 0x8a, 0x34, 0x3a, 0xaf, 0xcc, 0xfc, 0x75, 0x5e, 0x99, 0x79, 0xf3, 0xb0,
+	0x85, 0x69, 0x3b, 0x68, 0xd6, 0x4c, 0xd7, 0xde, 0x24, 0x7d, 0xc7, 0xa4, 0x6d, 0xec, 0xb4, 0x32,
+	0xab, 0xf4, 0x6f, 0x66, 0x73, 0x56, 0xfc, 0xa7, 0x7c, 0xfa, 0xcf, 0x00, 0xff, 0x0b, 0xaf, 0x66,
+	0xa6, 0x0a, 0x00, 0x00,
 }
 
 func (m *GUIConfiguration) Marshal() (dAtA []byte, err error) {
@@ -155,6 +181,58 @@ func (m *GUIConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.TrustedProxies) > 0 {
+		for iNdEx := len(m.TrustedProxies) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.TrustedProxies[iNdEx])
+			copy(dAtA[i:], m.TrustedProxies[iNdEx])
+			i = encodeVarintGuiconfiguration(dAtA, i, uint64(len(m.TrustedProxies[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x9a
+		}
+	}
+	if len(m.RawPathPrefix) > 0 {
+		i -= len(m.RawPathPrefix)
+		copy(dAtA[i:], m.RawPathPrefix)
+		i = encodeVarintGuiconfiguration(dAtA, i, uint64(len(m.RawPathPrefix)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	if len(m.ACMEHTTPAddress) > 0 {
+		i -= len(m.ACMEHTTPAddress)
+		copy(dAtA[i:], m.ACMEHTTPAddress)
+		i = encodeVarintGuiconfiguration(dAtA, i, uint64(len(m.ACMEHTTPAddress)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if len(m.ACMEDirectory) > 0 {
+		i -= len(m.ACMEDirectory)
+		copy(dAtA[i:], m.ACMEDirectory)
+		i = encodeVarintGuiconfiguration(dAtA, i, uint64(len(m.ACMEDirectory)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if len(m.ACMEEmail) > 0 {
+		i -= len(m.ACMEEmail)
+		copy(dAtA[i:], m.ACMEEmail)
+		i = encodeVarintGuiconfiguration(dAtA, i, uint64(len(m.ACMEEmail)))
+		i--
+		dAtA[i] = 0x7a
+	}
+	if len(m.ACMEDomain) > 0 {
+		i -= len(m.ACMEDomain)
+		copy(dAtA[i:], m.ACMEDomain)
+		i = encodeVarintGuiconfiguration(dAtA, i, uint64(len(m.ACMEDomain)))
+		i--
+		dAtA[i] = 0x72
+	}
 	if m.InsecureAllowFrameLoading {
 		i--
 		if m.InsecureAllowFrameLoading {
@@ -327,6 +405,32 @@ func (m *GUIConfiguration) ProtoSize() (n int) {
 	if m.InsecureAllowFrameLoading {
 		n += 2
 	}
+	l = len(m.ACMEDomain)
+	if l > 0 {
+		n += 1 + l + sovGuiconfiguration(uint64(l))
+	}
+	l = len(m.ACMEEmail)
+	if l > 0 {
+		n += 1 + l + sovGuiconfiguration(uint64(l))
+	}
+	l = len(m.ACMEDirectory)
+	if l > 0 {
+		n += 2 + l + sovGuiconfiguration(uint64(l))
+	}
+	l = len(m.ACMEHTTPAddress)
+	if l > 0 {
+		n += 2 + l + sovGuiconfiguration(uint64(l))
+	}
+	l = len(m.RawPathPrefix)
+	if l > 0 {
+		n += 2 + l + sovGuiconfiguration(uint64(l))
+	}
+	if len(m.TrustedProxies) > 0 {
+		for _, s := range m.TrustedProxies {
+			l = len(s)
+			n += 2 + l + sovGuiconfiguration(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -696,6 +800,198 @@ func (m *GUIConfiguration) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.InsecureAllowFrameLoading = bool(v != 0)
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ACMEDomain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGuiconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ACMEDomain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ACMEEmail", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGuiconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ACMEEmail = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ACMEDirectory", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGuiconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ACMEDirectory = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ACMEHTTPAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGuiconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ACMEHTTPAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RawPathPrefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGuiconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RawPathPrefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrustedProxies", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGuiconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGuiconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TrustedProxies = append(m.TrustedProxies, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGuiconfiguration(dAtA[iNdEx:])
@@ -0,0 +1,335 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/osutil"
+)
+
+const (
+	maxHistoryEntries = 50
+	historyDirSuffix  = ".history"
+	historyTimeFormat = "20060102-150405"
+	historyContext    = 3
+)
+
+var ErrNoSuchHistoryEntry = errors.New("no such config history entry")
+
+// A HistoryEntry is a previously saved version of the configuration. Entries
+// are numbered in the order they were saved in.
+type HistoryEntry struct {
+	ID   int       `json:"id"`
+	Time time.Time `json:"time"`
+}
+
+func (e HistoryEntry) fileName() string {
+	return fmt.Sprintf("%06d-%s.xml", e.ID, e.Time.UTC().Format(historyTimeFormat))
+}
+
+func parseHistoryFileName(name string) (HistoryEntry, bool) {
+	idStr, rest, ok := strings.Cut(strings.TrimSuffix(name, ".xml"), "-")
+	if !ok || !strings.HasSuffix(name, ".xml") {
+		return HistoryEntry{}, false
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return HistoryEntry{}, false
+	}
+	t, err := time.Parse(historyTimeFormat, rest)
+	if err != nil {
+		return HistoryEntry{}, false
+	}
+	return HistoryEntry{ID: id, Time: t}, true
+}
+
+// History returns the stored versions of the configuration, oldest first.
+func (w *wrapper) History() ([]HistoryEntry, error) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	return w.historyLocked()
+}
+
+func (w *wrapper) historyDir() string {
+	return w.path + historyDirSuffix
+}
+
+func (w *wrapper) historyLocked() ([]HistoryEntry, error) {
+	if w.path == "" {
+		return nil, nil
+	}
+	dirents, err := os.ReadDir(w.historyDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var entries []HistoryEntry
+	for _, dirent := range dirents {
+		if entry, ok := parseHistoryFileName(dirent.Name()); ok {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(a, b int) bool {
+		return entries[a].ID < entries[b].ID
+	})
+	return entries, nil
+}
+
+// HistoryVersion returns the stored configuration with the given ID.
+func (w *wrapper) HistoryVersion(id int) (Configuration, error) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	entries, err := w.historyLocked()
+	if err != nil {
+		return Configuration{}, err
+	}
+	for _, entry := range entries {
+		if entry.ID != id {
+			continue
+		}
+		fd, err := os.Open(filepath.Join(w.historyDir(), entry.fileName()))
+		if err != nil {
+			return Configuration{}, err
+		}
+		defer fd.Close()
+		cfg, _, err := ReadXML(fd, w.myID)
+		return cfg, err
+	}
+	return Configuration{}, ErrNoSuchHistoryEntry
+}
+
+// Rollback replaces the current configuration with the stored one with the
+// given ID. Like any other change, the result needs to be saved and then
+// becomes the latest entry in the history itself.
+func (w *wrapper) Rollback(id int) (Waiter, error) {
+	to, err := w.HistoryVersion(id)
+	if err != nil {
+		return noopWaiter{}, err
+	}
+	return w.Modify(func(cfg *Configuration) {
+		*cfg = to
+	})
+}
+
+// recordHistoryLocked stores the just saved configuration as a new history
+// entry, unless it's the same as the latest one. When the history is empty,
+// the previous contents of the config file are stored first so that the
+// very first change can be rolled back as well.
+func (w *wrapper) recordHistoryLocked(prev, cur []byte) error {
+	entries, err := w.historyLocked()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(w.historyDir(), 0o700); err != nil {
+		return err
+	}
+
+	if len(entries) == 0 && len(prev) > 0 && !bytes.Equal(prev, cur) {
+		entry, err := w.addHistoryEntryLocked(prev, 1)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) > 0 {
+		latest := entries[len(entries)-1]
+		bs, err := os.ReadFile(filepath.Join(w.historyDir(), latest.fileName()))
+		if err == nil && bytes.Equal(bs, cur) {
+			return nil
+		}
+	}
+
+	id := 1
+	if len(entries) > 0 {
+		id = entries[len(entries)-1].ID + 1
+	}
+	entry, err := w.addHistoryEntryLocked(cur, id)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry)
+
+	for len(entries) > maxHistoryEntries {
+		if err := os.Remove(filepath.Join(w.historyDir(), entries[0].fileName())); err != nil {
+			return err
+		}
+		entries = entries[1:]
+	}
+	return nil
+}
+
+func (w *wrapper) addHistoryEntryLocked(bs []byte, id int) (HistoryEntry, error) {
+	entry := HistoryEntry{ID: id, Time: time.Now().UTC().Truncate(time.Second)}
+	fd, err := osutil.CreateAtomic(filepath.Join(w.historyDir(), entry.fileName()))
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	if _, err := fd.Write(bs); err != nil {
+		fd.Close()
+		return HistoryEntry{}, err
+	}
+	return entry, fd.Close()
+}
+
+// Diff returns a unified diff between the XML representations of the two
+// configurations, or the empty string if they are the same.
+func Diff(from, to Configuration, fromName, toName string) (string, error) {
+	var fromBuf, toBuf bytes.Buffer
+	if err := from.WriteXML(&fromBuf); err != nil {
+		return "", err
+	}
+	if err := to.WriteXML(&toBuf); err != nil {
+		return "", err
+	}
+	return unifiedDiff(splitLines(fromBuf.String()), splitLines(toBuf.String()), fromName, toName), nil
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	text string
+}
+
+// diffLines returns the operations turning a into b, based on the longest
+// common subsequence of lines.
+func diffLines(a, b []string) []diffOp {
+	// Most of the configuration is usually unchanged, so strip the common
+	// prefix and suffix before doing the quadratic work.
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	ma, mb := a[pre:len(a)-suf], b[pre:len(b)-suf]
+
+	// lcs[i][j] is the length of the longest common subsequence of ma[i:]
+	// and mb[j:].
+	lcs := make([][]int, len(ma)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(mb)+1)
+	}
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			switch {
+			case ma[i] == mb[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:pre] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	i, j := 0, 0
+	for i < len(ma) || j < len(mb) {
+		switch {
+		case i < len(ma) && j < len(mb) && ma[i] == mb[j]:
+			ops = append(ops, diffOp{' ', ma[i]})
+			i++
+			j++
+		case i < len(ma) && (j == len(mb) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{'-', ma[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', mb[j]})
+			j++
+		}
+	}
+	for _, line := range a[len(a)-suf:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+func unifiedDiff(a, b []string, fromName, toName string) string {
+	ops := diffLines(a, b)
+
+	// aLine[i] and bLine[i] are the number of lines of a and b preceding
+	// operation i.
+	aLine := make([]int, len(ops)+1)
+	bLine := make([]int, len(ops)+1)
+	for i, op := range ops {
+		aLine[i+1], bLine[i+1] = aLine[i], bLine[i]
+		if op.kind != '+' {
+			aLine[i+1]++
+		}
+		if op.kind != '-' {
+			bLine[i+1]++
+		}
+	}
+
+	var sb strings.Builder
+	for start := 0; start < len(ops); {
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		// Changes closer than twice the context end up in the same hunk.
+		end := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				end = i + 1
+			} else if i-end >= 2*historyContext {
+				break
+			}
+		}
+		hunkStart := start - historyContext
+		if hunkStart < 0 {
+			hunkStart = 0
+		}
+		hunkEnd := end + historyContext
+		if hunkEnd > len(ops) {
+			hunkEnd = len(ops)
+		}
+
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(aLine[hunkStart], aLine[hunkEnd]), hunkRange(bLine[hunkStart], bLine[hunkEnd]))
+		for _, op := range ops[hunkStart:hunkEnd] {
+			sb.WriteByte(op.kind)
+			sb.WriteString(op.text)
+			sb.WriteByte('\n')
+		}
+		start = hunkEnd
+	}
+	return sb.String()
+}
+
+func hunkRange(from, to int) string {
+	if to == from {
+		// An empty range refers to the line before it.
+		return fmt.Sprintf("%d,0", from)
+	}
+	return fmt.Sprintf("%d,%d", from+1, to-from)
+}
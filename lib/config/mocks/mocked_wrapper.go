@@ -137,6 +137,31 @@ type Wrapper struct {
 	gUIReturnsOnCall map[int]struct {
 		result1 config.GUIConfiguration
 	}
+	HistoryStub        func() ([]config.HistoryEntry, error)
+	historyMutex       sync.RWMutex
+	historyArgsForCall []struct {
+	}
+	historyReturns struct {
+		result1 []config.HistoryEntry
+		result2 error
+	}
+	historyReturnsOnCall map[int]struct {
+		result1 []config.HistoryEntry
+		result2 error
+	}
+	HistoryVersionStub        func(int) (config.Configuration, error)
+	historyVersionMutex       sync.RWMutex
+	historyVersionArgsForCall []struct {
+		arg1 int
+	}
+	historyVersionReturns struct {
+		result1 config.Configuration
+		result2 error
+	}
+	historyVersionReturnsOnCall map[int]struct {
+		result1 config.Configuration
+		result2 error
+	}
 	IgnoredDeviceStub        func(protocol.DeviceID) bool
 	ignoredDeviceMutex       sync.RWMutex
 	ignoredDeviceArgsForCall []struct {
@@ -259,6 +284,19 @@ type Wrapper struct {
 	requiresRestartReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	RollbackStub        func(int) (config.Waiter, error)
+	rollbackMutex       sync.RWMutex
+	rollbackArgsForCall []struct {
+		arg1 int
+	}
+	rollbackReturns struct {
+		result1 config.Waiter
+		result2 error
+	}
+	rollbackReturnsOnCall map[int]struct {
+		result1 config.Waiter
+		result2 error
+	}
 	SaveStub        func() error
 	saveMutex       sync.RWMutex
 	saveArgsForCall []struct {
@@ -966,6 +1004,126 @@ func (fake *Wrapper) GUIReturnsOnCall(i int, result1 config.GUIConfiguration) {
 	}{result1}
 }
 
+func (fake *Wrapper) History() ([]config.HistoryEntry, error) {
+	fake.historyMutex.Lock()
+	ret, specificReturn := fake.historyReturnsOnCall[len(fake.historyArgsForCall)]
+	fake.historyArgsForCall = append(fake.historyArgsForCall, struct {
+	}{})
+	stub := fake.HistoryStub
+	fakeReturns := fake.historyReturns
+	fake.recordInvocation("History", []interface{}{})
+	fake.historyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Wrapper) HistoryCallCount() int {
+	fake.historyMutex.RLock()
+	defer fake.historyMutex.RUnlock()
+	return len(fake.historyArgsForCall)
+}
+
+func (fake *Wrapper) HistoryCalls(stub func() ([]config.HistoryEntry, error)) {
+	fake.historyMutex.Lock()
+	defer fake.historyMutex.Unlock()
+	fake.HistoryStub = stub
+}
+
+func (fake *Wrapper) HistoryReturns(result1 []config.HistoryEntry, result2 error) {
+	fake.historyMutex.Lock()
+	defer fake.historyMutex.Unlock()
+	fake.HistoryStub = nil
+	fake.historyReturns = struct {
+		result1 []config.HistoryEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Wrapper) HistoryReturnsOnCall(i int, result1 []config.HistoryEntry, result2 error) {
+	fake.historyMutex.Lock()
+	defer fake.historyMutex.Unlock()
+	fake.HistoryStub = nil
+	if fake.historyReturnsOnCall == nil {
+		fake.historyReturnsOnCall = make(map[int]struct {
+			result1 []config.HistoryEntry
+			result2 error
+		})
+	}
+	fake.historyReturnsOnCall[i] = struct {
+		result1 []config.HistoryEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Wrapper) HistoryVersion(arg1 int) (config.Configuration, error) {
+	fake.historyVersionMutex.Lock()
+	ret, specificReturn := fake.historyVersionReturnsOnCall[len(fake.historyVersionArgsForCall)]
+	fake.historyVersionArgsForCall = append(fake.historyVersionArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.HistoryVersionStub
+	fakeReturns := fake.historyVersionReturns
+	fake.recordInvocation("HistoryVersion", []interface{}{arg1})
+	fake.historyVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Wrapper) HistoryVersionCallCount() int {
+	fake.historyVersionMutex.RLock()
+	defer fake.historyVersionMutex.RUnlock()
+	return len(fake.historyVersionArgsForCall)
+}
+
+func (fake *Wrapper) HistoryVersionCalls(stub func(int) (config.Configuration, error)) {
+	fake.historyVersionMutex.Lock()
+	defer fake.historyVersionMutex.Unlock()
+	fake.HistoryVersionStub = stub
+}
+
+func (fake *Wrapper) HistoryVersionArgsForCall(i int) int {
+	fake.historyVersionMutex.RLock()
+	defer fake.historyVersionMutex.RUnlock()
+	argsForCall := fake.historyVersionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Wrapper) HistoryVersionReturns(result1 config.Configuration, result2 error) {
+	fake.historyVersionMutex.Lock()
+	defer fake.historyVersionMutex.Unlock()
+	fake.HistoryVersionStub = nil
+	fake.historyVersionReturns = struct {
+		result1 config.Configuration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Wrapper) HistoryVersionReturnsOnCall(i int, result1 config.Configuration, result2 error) {
+	fake.historyVersionMutex.Lock()
+	defer fake.historyVersionMutex.Unlock()
+	fake.HistoryVersionStub = nil
+	if fake.historyVersionReturnsOnCall == nil {
+		fake.historyVersionReturnsOnCall = make(map[int]struct {
+			result1 config.Configuration
+			result2 error
+		})
+	}
+	fake.historyVersionReturnsOnCall[i] = struct {
+		result1 config.Configuration
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Wrapper) IgnoredDevice(arg1 protocol.DeviceID) bool {
 	fake.ignoredDeviceMutex.Lock()
 	ret, specificReturn := fake.ignoredDeviceReturnsOnCall[len(fake.ignoredDeviceArgsForCall)]
@@ -1599,6 +1757,70 @@ func (fake *Wrapper) RequiresRestartReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *Wrapper) Rollback(arg1 int) (config.Waiter, error) {
+	fake.rollbackMutex.Lock()
+	ret, specificReturn := fake.rollbackReturnsOnCall[len(fake.rollbackArgsForCall)]
+	fake.rollbackArgsForCall = append(fake.rollbackArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.RollbackStub
+	fakeReturns := fake.rollbackReturns
+	fake.recordInvocation("Rollback", []interface{}{arg1})
+	fake.rollbackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Wrapper) RollbackCallCount() int {
+	fake.rollbackMutex.RLock()
+	defer fake.rollbackMutex.RUnlock()
+	return len(fake.rollbackArgsForCall)
+}
+
+func (fake *Wrapper) RollbackCalls(stub func(int) (config.Waiter, error)) {
+	fake.rollbackMutex.Lock()
+	defer fake.rollbackMutex.Unlock()
+	fake.RollbackStub = stub
+}
+
+func (fake *Wrapper) RollbackArgsForCall(i int) int {
+	fake.rollbackMutex.RLock()
+	defer fake.rollbackMutex.RUnlock()
+	argsForCall := fake.rollbackArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Wrapper) RollbackReturns(result1 config.Waiter, result2 error) {
+	fake.rollbackMutex.Lock()
+	defer fake.rollbackMutex.Unlock()
+	fake.RollbackStub = nil
+	fake.rollbackReturns = struct {
+		result1 config.Waiter
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Wrapper) RollbackReturnsOnCall(i int, result1 config.Waiter, result2 error) {
+	fake.rollbackMutex.Lock()
+	defer fake.rollbackMutex.Unlock()
+	fake.RollbackStub = nil
+	if fake.rollbackReturnsOnCall == nil {
+		fake.rollbackReturnsOnCall = make(map[int]struct {
+			result1 config.Waiter
+			result2 error
+		})
+	}
+	fake.rollbackReturnsOnCall[i] = struct {
+		result1 config.Waiter
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Wrapper) Save() error {
 	fake.saveMutex.Lock()
 	ret, specificReturn := fake.saveReturnsOnCall[len(fake.saveArgsForCall)]
@@ -1833,6 +2055,10 @@ func (fake *Wrapper) Invocations() map[string][][]interface{} {
 	defer fake.foldersMutex.RUnlock()
 	fake.gUIMutex.RLock()
 	defer fake.gUIMutex.RUnlock()
+	fake.historyMutex.RLock()
+	defer fake.historyMutex.RUnlock()
+	fake.historyVersionMutex.RLock()
+	defer fake.historyVersionMutex.RUnlock()
 	fake.ignoredDeviceMutex.RLock()
 	defer fake.ignoredDeviceMutex.RUnlock()
 	fake.ignoredDevicesMutex.RLock()
@@ -1855,6 +2081,8 @@ func (fake *Wrapper) Invocations() map[string][][]interface{} {
 	defer fake.removeFolderMutex.RUnlock()
 	fake.requiresRestartMutex.RLock()
 	defer fake.requiresRestartMutex.RUnlock()
+	fake.rollbackMutex.RLock()
+	defer fake.rollbackMutex.RUnlock()
 	fake.saveMutex.RLock()
 	defer fake.saveMutex.RUnlock()
 	fake.serveMutex.RLock()
@@ -9,7 +9,10 @@ package config
 import (
 	"fmt"
 	"runtime"
+	"sort"
+	"strings"
 
+	"github.com/syncthing/syncthing/lib/logger"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/rand"
 	"github.com/syncthing/syncthing/lib/stringutil"
@@ -26,6 +29,10 @@ func (opts OptionsConfiguration) Copy() OptionsConfiguration {
 	copy(optsCopy.AlwaysLocalNets, opts.AlwaysLocalNets)
 	optsCopy.UnackedNotificationIDs = make([]string, len(opts.UnackedNotificationIDs))
 	copy(optsCopy.UnackedNotificationIDs, opts.UnackedNotificationIDs)
+	optsCopy.LogLevels = make([]string, len(opts.LogLevels))
+	copy(optsCopy.LogLevels, opts.LogLevels)
+	optsCopy.UnmeteredNetworks = make([]string, len(opts.UnmeteredNetworks))
+	copy(optsCopy.UnmeteredNetworks, opts.UnmeteredNetworks)
 	return optsCopy
 }
 
@@ -65,6 +72,55 @@ func (opts *OptionsConfiguration) prepare(guiPWIsSet bool) {
 		l.Warnln("Connection priority number for TCP over WAN must be worse (higher) than TCP over LAN. Correcting.")
 		opts.ConnectionPriorityTCPWAN = opts.ConnectionPriorityTCPLAN + 1
 	}
+
+	levels := opts.LogLevels[:0]
+	for _, entry := range opts.LogLevels {
+		if _, _, err := parseFacilityLogLevel(entry); err != nil {
+			l.Warnf("Ignoring log level %q: %v", entry, err)
+			continue
+		}
+		levels = append(levels, entry)
+	}
+	opts.LogLevels = levels
+}
+
+func parseFacilityLogLevel(entry string) (string, logger.LogLevel, error) {
+	facility, levelStr, ok := strings.Cut(entry, ":")
+	if !ok || facility == "" {
+		return "", 0, fmt.Errorf("expected facility:level")
+	}
+	level, err := logger.ParseLogLevel(levelStr)
+	if err != nil {
+		return "", 0, err
+	}
+	return facility, level, nil
+}
+
+// FacilityLogLevels returns the configured log level per facility.
+func (opts OptionsConfiguration) FacilityLogLevels() map[string]logger.LogLevel {
+	res := make(map[string]logger.LogLevel, len(opts.LogLevels))
+	for _, entry := range opts.LogLevels {
+		if facility, level, err := parseFacilityLogLevel(entry); err == nil {
+			res[facility] = level
+		}
+	}
+	return res
+}
+
+// SetFacilityLogLevel sets the log level for the given facility, or removes
+// it when the level is the default one.
+func (opts *OptionsConfiguration) SetFacilityLogLevel(facility string, level logger.LogLevel) {
+	levels := opts.FacilityLogLevels()
+	if level == logger.DefaultLevel {
+		delete(levels, facility)
+	} else {
+		levels[facility] = level
+	}
+	opts.LogLevels = make([]string, 0, len(levels))
+	for facility, level := range levels {
+		opts.LogLevels = append(opts.LogLevels, facility+":"+level.String())
+	}
+	sort.Strings(opts.LogLevels)
 }
 
 // RequiresRestartOnly returns a copy with only the attributes that require
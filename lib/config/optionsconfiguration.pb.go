@@ -7,6 +7,7 @@ import (
 	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
+	github_com_syncthing_syncthing_lib_protocol "github.com/syncthing/syncthing/lib/protocol"
 	_ "github.com/syncthing/syncthing/proto/ext"
 	io "io"
 	math "math"
@@ -89,6 +90,37 @@ type OptionsConfiguration struct {
 	ConnectionPriorityQUICWAN          int  `protobuf:"varint,57,opt,name=connection_priority_quic_wan,json=connectionPriorityQuicWan,proto3,casttype=int" json:"connectionPriorityQuicWan" xml:"connectionPriorityQuicWan" default:"40"`
 	ConnectionPriorityRelay            int  `protobuf:"varint,58,opt,name=connection_priority_relay,json=connectionPriorityRelay,proto3,casttype=int" json:"connectionPriorityRelay" xml:"connectionPriorityRelay" default:"50"`
 	ConnectionPriorityUpgradeThreshold int  `protobuf:"varint,59,opt,name=connection_priority_upgrade_threshold,json=connectionPriorityUpgradeThreshold,proto3,casttype=int" json:"connectionPriorityUpgradeThreshold" xml:"connectionPriorityUpgradeThreshold" default:"0"`
+	// The maximum rate at which index data is sent, in KiB/s over all
+	// devices and folders, zero meaning no limit.
+	MaxIndexSendKbps int `protobuf:"varint,60,opt,name=max_index_send_kbps,json=maxIndexSendKbps,proto3,casttype=int" json:"maxIndexSendKbps" xml:"maxIndexSendKbps"`
+	// When set, per day transfer statistics are written to a CSV file in
+	// this directory after the end of each day.
+	TransferStatsDumpPath string `protobuf:"bytes,61,opt,name=transfer_stats_dump_path,json=transferStatsDumpPath,proto3" json:"transferStatsDumpPath" xml:"transferStatsDumpPath"`
+	// Log levels per facility, as "facility:level", overriding the default
+	// level for the facility.
+	LogLevels []string `protobuf:"bytes,62,rep,name=log_levels,json=logLevels,proto3" json:"logLevels" xml:"logLevel"`
+	// When set, the given device may act as controller for this device,
+	// provided it presents the controller token.
+	ControllerDeviceID github_com_syncthing_syncthing_lib_protocol.DeviceID `protobuf:"bytes,63,opt,name=controller_device_id,json=controllerDeviceId,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.DeviceID" json:"controllerDeviceID" xml:"controllerDeviceID" nodefault:"true"`
+	ControllerToken    string                                               `protobuf:"bytes,64,opt,name=controller_token,json=controllerToken,proto3" json:"controllerToken" xml:"controllerToken"`
+	// When set, sync connections prefer a hybrid post-quantum key exchange
+	// (X25519 combined with ML-KEM, the standardized form of Kyber), when
+	// the TLS stack we are built with supports it.
+	PostQuantumKeyExchange bool `protobuf:"varint,65,opt,name=post_quantum_key_exchange,json=postQuantumKeyExchange,proto3" json:"postQuantumKeyExchange" xml:"postQuantumKeyExchange"`
+	// How often the chunks in the pool of deduplicated folders are
+	// verified against their hashes, zero meaning only when requested.
+	BlockPoolScrubIntervalS int `protobuf:"varint,66,opt,name=block_pool_scrub_interval_s,json=blockPoolScrubIntervalS,proto3,casttype=int" json:"blockPoolScrubIntervalS" xml:"blockPoolScrubIntervalS" default:"604800"`
+	// When set, we are the warm standby of this device and take over as the
+	// preferred source when it has been down for the takeover time, as
+	// seen by the other connected devices as well.
+	StandbyPrimaryID github_com_syncthing_syncthing_lib_protocol.DeviceID `protobuf:"bytes,67,opt,name=standby_primary_id,json=standbyPrimaryId,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.DeviceID" json:"standbyPrimaryID" xml:"standbyPrimaryID" nodefault:"true"`
+	StandbyTakeoverS int                                                  `protobuf:"varint,68,opt,name=standby_takeover_s,json=standbyTakeoverS,proto3,casttype=int" json:"standbyTakeoverS" xml:"standbyTakeoverS" default:"300"`
+	// When set, restoring versions of files tells the devices sharing the
+	// folder to pull them ahead of anything else queued.
+	RestorePullHints bool `protobuf:"varint,69,opt,name=restore_pull_hints,json=restorePullHints,proto3" json:"restorePullHints" xml:"restorePullHints" default:"true"`
+	// Connections to devices marked as metered are treated as unmetered
+	// when the remote address is in one of these networks (CIDR format).
+	UnmeteredNetworks []string `protobuf:"bytes,70,rep,name=unmetered_networks,json=unmeteredNetworks,proto3" json:"unmeteredNetworks" xml:"unmeteredNetwork,omitempty"`
 	// Legacy deprecated
 	DeprecatedUPnPEnabled        bool     `protobuf:"varint,9000,opt,name=upnp_enabled,json=upnpEnabled,proto3" json:"-" xml:"upnpEnabled,omitempty"`                                    // Deprecated: Do not use.
 	DeprecatedUPnPLeaseM         int      `protobuf:"varint,9001,opt,name=upnp_lease_m,json=upnpLeaseM,proto3,casttype=int" json:"-" xml:"upnpLeaseMinutes,omitempty"`                   // Deprecated: Do not use.
@@ -141,228 +173,260 @@ func init() {
 }
 
 var fileDescriptor_d09882599506ca03 = []byte{
-	// 3521 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x5a, 0x5d, 0x6c, 0x1d, 0x47,
-	0x15, 0xce, 0x26, 0x4d, 0xda, 0x6c, 0x1c, 0x27, 0x1e, 0x3b, 0xf6, 0x26, 0x4e, 0xbd, 0xee, 0xcd,
-	0x4d, 0xeb, 0xb6, 0x69, 0x62, 0x3b, 0x3f, 0x4d, 0x8d, 0x50, 0xf1, 0x4f, 0x4d, 0xdd, 0xd8, 0x8e,
-	0x3b, 0xb6, 0x6b, 0x54, 0x84, 0x56, 0xe3, 0xbd, 0x73, 0xed, 0xad, 0xf7, 0xee, 0xde, 0xec, 0xce,
-	0xfa, 0xa7, 0x45, 0x50, 0x15, 0x41, 0x79, 0xa3, 0x5c, 0x15, 0x90, 0x40, 0xaa, 0x8a, 0x00, 0x89,
-	0x52, 0x8a, 0x90, 0x90, 0x90, 0x40, 0x42, 0x54, 0x48, 0x48, 0x15, 0x3c, 0xf8, 0x3e, 0x21, 0x24,
-	0xca, 0xa2, 0x3a, 0x7d, 0xba, 0x0f, 0x3c, 0xdc, 0x47, 0xf3, 0x82, 0x66, 0xf6, 0x6f, 0x76, 0x77,
-	0xd6, 0xce, 0xdb, 0xdd, 0xf3, 0x9d, 0x39, 0x73, 0xbe, 0xf9, 0x39, 0x73, 0xce, 0xcc, 0x95, 0x2f,
-	0x9b, 0xc6, 0xea, 0x35, 0xdd, 0xb6, 0xaa, 0xc6, 0xda, 0x35, 0xbb, 0x4e, 0x0c, 0xdb, 0x72, 0x83,
-	0x2f, 0xcf, 0x41, 0xf4, 0xeb, 0x6a, 0xdd, 0xb1, 0x89, 0x0d, 0x4e, 0x04, 0xc2, 0x0b, 0x7d, 0x9c,
-	0x3a, 0xf1, 0x2c, 0xc3, 0x5a, 0x0b, 0x14, 0x2e, 0x9c, 0xe3, 0x00, 0xd7, 0x78, 0x1d, 0x87, 0xe2,
-	0x93, 0x78, 0x9b, 0x04, 0x3f, 0x4b, 0xef, 0x2d, 0xca, 0x3d, 0x77, 0x83, 0x1e, 0x26, 0xf9, 0x1e,
-	0xc0, 0x7b, 0x92, 0x7c, 0xd6, 0x34, 0x5c, 0x82, 0x2d, 0x0d, 0x55, 0x2a, 0x0e, 0x76, 0x5d, 0xec,
-	0x2a, 0xd2, 0xe0, 0xb1, 0xa1, 0x93, 0x13, 0xee, 0x9e, 0xaf, 0x02, 0x88, 0xb6, 0x66, 0x19, 0x3c,
-	0x1e, 0xa1, 0x2d, 0x5f, 0x3d, 0x63, 0xa6, 0x45, 0x6d, 0x5f, 0xbd, 0xbc, 0x5d, 0x33, 0xc7, 0x4a,
-	0x29, 0x79, 0x69, 0xb0, 0x82, 0xab, 0xc8, 0x33, 0xc9, 0x58, 0x29, 0xfc, 0x51, 0xda, 0xdf, 0x2d,
-	0x3f, 0x1c, 0xfe, 0x6e, 0x34, 0xcb, 0x02, 0xe3, 0x30, 0x6b, 0x1a, 0xfc, 0x57, 0x92, 0x95, 0x35,
-	0xd3, 0x5e, 0x45, 0xa6, 0x56, 0x31, 0x5c, 0xdd, 0xde, 0xc4, 0xce, 0x8e, 0xe6, 0x62, 0x67, 0x13,
-	0x3b, 0xae, 0x72, 0x94, 0x39, 0xfa, 0x3b, 0x69, 0xcf, 0x57, 0xbb, 0x21, 0xda, 0xfa, 0x32, 0xd3,
-	0x1b, 0xb7, 0xac, 0xc5, 0x00, 0x6f, 0xf9, 0xea, 0xb9, 0xb5, 0x48, 0x66, 0x7b, 0x96, 0x8e, 0x43,
-	0xa0, 0xed, 0xab, 0x57, 0x98, 0xc3, 0x22, 0x54, 0xe0, 0x77, 0x6b, 0xb7, 0xdc, 0x23, 0x52, 0x6d,
-	0xef, 0x96, 0xc5, 0x1d, 0xa4, 0x89, 0x8a, 0x7c, 0x83, 0xbd, 0x41, 0xc3, 0xa9, 0x88, 0x54, 0x28,
-	0x07, 0x9f, 0x8b, 0x08, 0x63, 0x0b, 0xad, 0x9a, 0xb8, 0xa2, 0x1c, 0x1b, 0x94, 0x86, 0x1e, 0x99,
-	0xf8, 0x80, 0x12, 0x3e, 0x1b, 0x5b, 0x7c, 0x21, 0x00, 0xf3, 0x6c, 0x43, 0xa0, 0xed, 0xab, 0x4f,
-	0x09, 0xd8, 0x86, 0x28, 0x47, 0x97, 0x38, 0x1e, 0xa6, 0x5c, 0x0b, 0xcc, 0x14, 0x01, 0xfb, 0xbb,
-	0xe5, 0x87, 0x68, 0xd3, 0x46, 0xb3, 0x9c, 0x73, 0x2a, 0x47, 0x33, 0x94, 0x83, 0x4f, 0x25, 0xb9,
-	0xcf, 0xb4, 0x75, 0x21, 0xcb, 0x87, 0x18, 0xcb, 0x9f, 0x51, 0x96, 0x67, 0x66, 0xa9, 0x4e, 0x8a,
-	0x64, 0x8f, 0x19, 0x8a, 0x32, 0x1c, 0x9f, 0x0c, 0x96, 0xa0, 0x00, 0x14, 0x50, 0x14, 0x1b, 0x29,
-	0x90, 0x73, 0x04, 0xb3, 0xfe, 0xc0, 0x73, 0xac, 0x41, 0x8e, 0xde, 0xdf, 0x25, 0xb9, 0x3b, 0xa0,
-	0x87, 0x42, 0x5b, 0x5a, 0xdd, 0x76, 0x88, 0x72, 0x7c, 0x50, 0x1a, 0x3a, 0x3e, 0xf1, 0x63, 0x4a,
-	0xad, 0x23, 0x32, 0xb5, 0x60, 0x3b, 0xa4, 0xe5, 0xab, 0x5d, 0xa9, 0xae, 0xa9, 0xb0, 0xed, 0xab,
-	0x4f, 0xe4, 0x49, 0x51, 0x84, 0x63, 0x34, 0x3a, 0x32, 0x3c, 0xfa, 0x6c, 0x69, 0xdf, 0x57, 0x8f,
-	0x19, 0x16, 0x69, 0xed, 0x96, 0x05, 0x66, 0x44, 0xc2, 0xfd, 0xdd, 0xf2, 0x71, 0xd6, 0xb4, 0xd1,
-	0x2c, 0xa7, 0x3c, 0x81, 0x79, 0x5d, 0xf0, 0xad, 0xa3, 0xf2, 0x60, 0x86, 0x4d, 0xcd, 0x33, 0x89,
-	0xa1, 0x23, 0x97, 0x44, 0x71, 0x43, 0x39, 0x31, 0x28, 0x0d, 0x9d, 0x9c, 0xf8, 0x03, 0xa5, 0xd6,
-	0x19, 0x19, 0x9c, 0x9b, 0xa4, 0x3b, 0xb9, 0xe5, 0xab, 0xdd, 0x29, 0xa3, 0x81, 0xb8, 0xed, 0xab,
-	0xb7, 0xf2, 0xf4, 0x02, 0x8c, 0x23, 0xf8, 0xd5, 0x6a, 0x75, 0x64, 0x74, 0x6c, 0xec, 0xf6, 0xf5,
-	0xdb, 0x37, 0xbe, 0x36, 0x16, 0xb0, 0x6d, 0xed, 0x96, 0x85, 0x06, 0xc5, 0xe2, 0xfd, 0xdd, 0x32,
-	0xc8, 0x1b, 0x69, 0x34, 0xcb, 0x19, 0x37, 0xe1, 0xa3, 0xe9, 0xc6, 0x11, 0xc3, 0x30, 0x18, 0x81,
-	0xbb, 0xf2, 0xe9, 0x1a, 0xda, 0xd6, 0x5c, 0x6c, 0x55, 0xb4, 0x8d, 0xd5, 0xba, 0xab, 0x3c, 0xcc,
-	0x26, 0xf3, 0xe9, 0x96, 0xaf, 0x9e, 0xaa, 0xa1, 0xed, 0x45, 0x6c, 0x55, 0xee, 0xac, 0xd6, 0x69,
-	0x70, 0xe9, 0x62, 0xb4, 0x38, 0x59, 0x34, 0x3f, 0x90, 0x57, 0x8c, 0x0c, 0x3a, 0x58, 0xdf, 0x0c,
-	0x0c, 0x3e, 0x92, 0x32, 0x08, 0xb1, 0xbe, 0x99, 0x35, 0x18, 0xc9, 0x52, 0x06, 0x23, 0x21, 0xf8,
-	0xbd, 0x24, 0xf7, 0x39, 0x58, 0xb7, 0x2d, 0x0b, 0xeb, 0x34, 0xbc, 0x6b, 0x86, 0x45, 0xb0, 0xb3,
-	0x89, 0x4c, 0xcd, 0x55, 0x4e, 0x32, 0xdb, 0xdf, 0x60, 0x41, 0x3d, 0x52, 0x99, 0x09, 0xe1, 0x45,
-	0x1a, 0x3b, 0xf8, 0x86, 0x31, 0xd0, 0xf6, 0xd5, 0x21, 0xd6, 0xb7, 0x10, 0xe5, 0x66, 0xe9, 0xd6,
-	0x70, 0xe4, 0xd2, 0xfe, 0x6e, 0xf9, 0xe8, 0xad, 0x61, 0x16, 0xdf, 0x73, 0xfd, 0x40, 0x71, 0x2f,
-	0xa0, 0x2a, 0x77, 0x3a, 0xd8, 0x44, 0x3b, 0x6e, 0x1c, 0x03, 0x64, 0x16, 0x03, 0x9e, 0x6f, 0xf9,
-	0xea, 0xe9, 0x00, 0x49, 0x36, 0x7a, 0x29, 0x74, 0x88, 0x93, 0x66, 0x77, 0x78, 0xb4, 0x63, 0x61,
-	0xba, 0x31, 0x78, 0xeb, 0xa8, 0xdc, 0x1f, 0x76, 0x14, 0x3b, 0x92, 0x0c, 0x52, 0x4d, 0x39, 0xc5,
-	0x06, 0xe9, 0x2f, 0x74, 0x0d, 0xf7, 0x41, 0xaa, 0x97, 0xa3, 0x30, 0xd7, 0xf2, 0xd5, 0x3e, 0x47,
-	0x0c, 0xc5, 0x81, 0xb6, 0x00, 0xe7, 0xbc, 0x1c, 0x19, 0xe6, 0xb6, 0x6c, 0xa1, 0xbd, 0x62, 0x88,
-	0x0e, 0xf2, 0x08, 0x1d, 0xe4, 0x22, 0x37, 0xa1, 0x12, 0xf0, 0xcc, 0x23, 0x60, 0x55, 0x3e, 0xed,
-	0x12, 0xe4, 0x10, 0x6d, 0xd5, 0xb1, 0xb7, 0x5c, 0xec, 0x28, 0x1d, 0x6c, 0xac, 0xbf, 0xd8, 0xf2,
-	0xd5, 0x0e, 0x06, 0x4c, 0x04, 0xf2, 0xb6, 0xaf, 0x3e, 0xc6, 0xe8, 0xf0, 0xc2, 0xc2, 0x91, 0x4e,
-	0x35, 0x05, 0xbf, 0x90, 0xe4, 0x73, 0x16, 0x22, 0x1a, 0x71, 0x10, 0x3d, 0xd5, 0x90, 0x19, 0x4f,
-	0x6c, 0x27, 0xeb, 0xec, 0xde, 0x9e, 0xaf, 0xca, 0xf3, 0xe3, 0x4b, 0x49, 0x58, 0x97, 0x2d, 0x44,
-	0x92, 0x39, 0x56, 0x59, 0xc7, 0x89, 0x48, 0x10, 0xc2, 0xf9, 0x06, 0xa9, 0x2f, 0x2e, 0x5c, 0x73,
-	0x5d, 0xc0, 0x6e, 0x0b, 0x91, 0xa5, 0xc8, 0x9d, 0x68, 0x41, 0xfc, 0x31, 0xe7, 0xa7, 0x89, 0x91,
-	0x8b, 0xb5, 0x9a, 0x72, 0x86, 0x2d, 0x85, 0xef, 0xd0, 0xa5, 0x70, 0x72, 0x7e, 0x7c, 0x69, 0x96,
-	0x8a, 0xe9, 0xe4, 0x9f, 0xb1, 0x10, 0x09, 0x3e, 0x0c, 0xcb, 0x23, 0x2c, 0xf9, 0x29, 0x45, 0xce,
-	0xf2, 0x72, 0xe1, 0xde, 0x68, 0xed, 0x96, 0x73, 0xed, 0xf3, 0xa2, 0x78, 0x07, 0x25, 0x1d, 0x43,
-	0xc0, 0x7b, 0x1f, 0xc8, 0xc0, 0xdf, 0x24, 0xb9, 0x2f, 0xed, 0xbc, 0x83, 0x2d, 0xbc, 0xc5, 0x56,
-	0xf2, 0x59, 0xe6, 0x7e, 0x83, 0xba, 0x7f, 0x6a, 0x7e, 0x7c, 0x09, 0x06, 0x00, 0x25, 0xd0, 0x65,
-	0x21, 0x12, 0x7d, 0xc6, 0x14, 0xca, 0x11, 0x85, 0x34, 0xc2, 0x91, 0xb8, 0xce, 0x93, 0x10, 0xd8,
-	0x10, 0x09, 0x29, 0x91, 0xeb, 0x94, 0x08, 0xef, 0x02, 0xec, 0xe1, 0xa9, 0x44, 0x52, 0x01, 0x19,
-	0x62, 0xd4, 0xb0, 0xed, 0x11, 0xcd, 0x55, 0xba, 0xd2, 0x64, 0x96, 0x02, 0x60, 0x31, 0x24, 0x13,
-	0x7d, 0xd2, 0x95, 0x5e, 0x49, 0x91, 0x49, 0x23, 0x45, 0xdb, 0x4f, 0x60, 0x43, 0x24, 0x8c, 0xb7,
-	0x1c, 0xef, 0x42, 0x9a, 0x4c, 0x24, 0x05, 0x3f, 0x91, 0x64, 0xc5, 0x73, 0xd1, 0x1a, 0xd6, 0x1c,
-	0x4c, 0xcf, 0x7d, 0xc3, 0x5a, 0xd3, 0x90, 0xae, 0xe3, 0x3a, 0xc1, 0x15, 0x05, 0x30, 0x36, 0x88,
-	0xee, 0x80, 0x65, 0x38, 0x1e, 0x4a, 0xe9, 0x0e, 0xf0, 0x9c, 0xe8, 0xab, 0xed, 0xab, 0x67, 0x19,
-	0x89, 0x44, 0xc4, 0x39, 0xcc, 0x2b, 0xa6, 0xbe, 0xe8, 0x8a, 0x4f, 0x4c, 0xc2, 0x5e, 0xe6, 0x02,
-	0x8c, 0x3c, 0x88, 0xe4, 0xe0, 0x0d, 0xb9, 0x27, 0xeb, 0x9c, 0x8b, 0xb1, 0xa5, 0x74, 0x33, 0xc7,
-	0x66, 0xf6, 0x7c, 0xf5, 0xc4, 0x32, 0x5c, 0xc4, 0xd8, 0x6a, 0xf9, 0xea, 0x09, 0xcf, 0xa1, 0xbf,
-	0xda, 0xbe, 0xda, 0x11, 0x3a, 0x44, 0x3f, 0x39, 0x67, 0x22, 0x85, 0xf8, 0x57, 0xa3, 0x59, 0x0e,
-	0x9b, 0x43, 0x90, 0x76, 0x80, 0xca, 0xc0, 0x0f, 0x24, 0xf9, 0x7c, 0xb6, 0x77, 0xcf, 0x32, 0xee,
-	0x79, 0x58, 0x33, 0x2a, 0x4a, 0x0f, 0x4b, 0x22, 0x5e, 0x0d, 0xc6, 0x66, 0x99, 0x89, 0x67, 0xa6,
-	0x82, 0xb1, 0x09, 0xbf, 0xf8, 0xb1, 0x89, 0x14, 0x4a, 0xc1, 0xa0, 0x44, 0x9f, 0x6d, 0xfe, 0x2b,
-	0x1c, 0x94, 0x08, 0xcb, 0x0e, 0x4a, 0xa4, 0x05, 0x3e, 0x96, 0xe4, 0xee, 0x9c, 0x5f, 0x8e, 0xa9,
-	0x9c, 0x63, 0x1e, 0x7d, 0x8f, 0xae, 0xbd, 0xe3, 0xcb, 0x70, 0x19, 0xce, 0xb6, 0x7c, 0xf5, 0xb8,
-	0xe7, 0x2c, 0xc3, 0xd9, 0xb6, 0xaf, 0xde, 0x8e, 0x1c, 0x81, 0xb3, 0xdc, 0xea, 0x5a, 0x27, 0xa4,
-	0xee, 0x8e, 0x5d, 0xbb, 0x56, 0x41, 0x04, 0x5d, 0x75, 0x77, 0x2c, 0x9d, 0xac, 0xd3, 0x62, 0xcd,
-	0xc2, 0xe4, 0x9a, 0x85, 0xb7, 0xa8, 0x94, 0x3a, 0x1c, 0x1a, 0x89, 0x7e, 0xec, 0xef, 0x96, 0x1f,
-	0xa0, 0x61, 0xa3, 0x59, 0x0e, 0xbc, 0x80, 0x5d, 0x19, 0x1e, 0x8e, 0x09, 0xfe, 0x23, 0xc9, 0x6a,
-	0x96, 0x42, 0xdd, 0x76, 0xe9, 0x09, 0xe7, 0x62, 0xdd, 0x73, 0xb0, 0xb9, 0xa3, 0xf4, 0xb2, 0xf0,
-	0xfb, 0x23, 0x56, 0x41, 0x2c, 0xc3, 0x05, 0xdb, 0x25, 0x33, 0x31, 0xd8, 0xf2, 0xd5, 0xb3, 0x9e,
-	0x93, 0x96, 0xb5, 0x7d, 0xf5, 0xf1, 0x90, 0x64, 0x1a, 0xe0, 0xf8, 0x56, 0x91, 0xe9, 0xb2, 0x90,
-	0x9c, 0x6f, 0x2d, 0x90, 0xd1, 0xcc, 0x93, 0xb5, 0xa0, 0xf5, 0x42, 0xd6, 0x05, 0x78, 0x31, 0x4d,
-	0x2b, 0x8d, 0x82, 0x7f, 0x0b, 0x18, 0x1a, 0x96, 0x41, 0x0c, 0x5a, 0x47, 0xd0, 0xf3, 0x4e, 0x73,
-	0x95, 0x3e, 0xb6, 0x8a, 0x7f, 0xc8, 0xaa, 0x87, 0x65, 0x38, 0x13, 0xa0, 0x53, 0x14, 0xa4, 0x01,
-	0xe3, 0x8c, 0xe7, 0xa4, 0x44, 0x71, 0xb8, 0xc8, 0xc8, 0xf9, 0x60, 0x71, 0x7b, 0x38, 0x15, 0xc0,
-	0xb3, 0x16, 0xf2, 0x22, 0x7a, 0x02, 0xd1, 0x56, 0xb4, 0x60, 0xc8, 0xb8, 0x00, 0xfb, 0xd3, 0x04,
-	0x53, 0x20, 0x78, 0x5b, 0x92, 0xfb, 0x90, 0x47, 0x6c, 0xcd, 0xab, 0xaf, 0x39, 0xa8, 0x82, 0x93,
-	0xdc, 0x64, 0x5d, 0x39, 0xcf, 0x78, 0x2d, 0xd0, 0x0a, 0x88, 0xaa, 0x2c, 0x07, 0x1a, 0xd1, 0xb1,
-	0xfe, 0x62, 0x5c, 0x2c, 0x88, 0x40, 0x9e, 0xcd, 0x28, 0x9f, 0xa8, 0x8d, 0x8c, 0x42, 0xa1, 0x35,
-	0x50, 0x93, 0xfb, 0x22, 0x1f, 0x88, 0xad, 0xd5, 0x1d, 0x3a, 0xe2, 0xec, 0x68, 0x74, 0x95, 0x0b,
-	0x6c, 0x09, 0xdd, 0xa2, 0x8e, 0x84, 0x2a, 0x4b, 0xf6, 0x82, 0x83, 0x61, 0x88, 0xb7, 0x7d, 0xf5,
-	0x42, 0x30, 0xa2, 0x02, 0xb0, 0x04, 0x85, 0x6d, 0xc0, 0xa6, 0x0c, 0x36, 0x30, 0xae, 0x6b, 0x04,
-	0xd7, 0xea, 0xb6, 0x83, 0x1c, 0x03, 0xbb, 0xda, 0xba, 0xd2, 0xcf, 0x28, 0xbf, 0x48, 0xd7, 0x25,
-	0x45, 0x97, 0x12, 0x90, 0xd2, 0xbd, 0xc4, 0x7a, 0xc9, 0x02, 0x7c, 0x69, 0x74, 0x83, 0xa7, 0x3a,
-	0x7a, 0x03, 0xe6, 0xac, 0x80, 0x1d, 0xb9, 0x5b, 0x47, 0xfa, 0x3a, 0xd6, 0x8c, 0x35, 0xcb, 0x76,
-	0x70, 0x45, 0xab, 0x1a, 0x26, 0x76, 0x95, 0x8b, 0x8c, 0xe2, 0x0c, 0x3d, 0x60, 0x18, 0x3c, 0x13,
-	0xa0, 0xd3, 0x14, 0x8c, 0x07, 0x3a, 0x87, 0xe4, 0xb6, 0x44, 0xbc, 0xd4, 0x61, 0xde, 0x0c, 0xf8,
-	0xbe, 0x24, 0x5f, 0xa8, 0x3b, 0xf6, 0x1a, 0xad, 0x2d, 0x34, 0xaf, 0x5e, 0x41, 0x04, 0xf3, 0xf9,
-	0xfa, 0xa3, 0x8c, 0xfb, 0x12, 0x4d, 0x37, 0x23, 0xad, 0x65, 0xa6, 0xc4, 0xe7, 0xe6, 0x41, 0xcd,
-	0x5b, 0x80, 0x73, 0xee, 0xdc, 0xe4, 0x06, 0x42, 0xba, 0x09, 0x8b, 0x2c, 0x82, 0xb7, 0x24, 0xb9,
-	0xd7, 0x34, 0x6a, 0x06, 0xd1, 0x56, 0x91, 0x55, 0xd9, 0x32, 0x2a, 0x64, 0x5d, 0x33, 0x2c, 0xcd,
-	0x44, 0x96, 0x32, 0xc0, 0x86, 0x64, 0x8e, 0xd5, 0x72, 0x54, 0x63, 0x22, 0x52, 0x98, 0xb1, 0x66,
-	0x91, 0x95, 0xd4, 0xdf, 0x79, 0xec, 0x80, 0x61, 0x11, 0x99, 0x02, 0x6f, 0x4a, 0x32, 0xa8, 0x19,
-	0x96, 0xb6, 0x6e, 0xd7, 0xb0, 0x56, 0x31, 0xdc, 0x0d, 0xad, 0xea, 0x60, 0xac, 0xa8, 0x83, 0xd2,
-	0xd0, 0xa9, 0xd1, 0x8e, 0xab, 0xc1, 0x45, 0xd7, 0xd5, 0x45, 0xe3, 0x75, 0x3c, 0xf1, 0xc2, 0x27,
-	0xbe, 0x7a, 0x84, 0xee, 0xea, 0x9a, 0x61, 0xbd, 0x68, 0xd7, 0xf0, 0x94, 0xe1, 0x6e, 0x4c, 0x3b,
-	0x18, 0xc7, 0xab, 0x23, 0x23, 0xe7, 0xf7, 0xc1, 0xe0, 0x65, 0xea, 0xc8, 0xb1, 0x91, 0xc1, 0xcb,
-	0x30, 0xdb, 0x1c, 0xdc, 0x97, 0xe4, 0x8e, 0x68, 0xbd, 0xb3, 0x53, 0x60, 0x90, 0x9d, 0x02, 0x7f,
-	0x66, 0x19, 0x48, 0xb4, 0x68, 0x83, 0xb3, 0xe0, 0x94, 0x93, 0x7c, 0xb6, 0x7d, 0x75, 0x2a, 0x2a,
-	0x00, 0x22, 0x99, 0xe0, 0x5c, 0x08, 0x77, 0x80, 0x9b, 0x09, 0xf1, 0x35, 0x4c, 0xd0, 0xd5, 0xd7,
-	0x5c, 0xdb, 0xa2, 0xa1, 0x34, 0x65, 0x36, 0xfd, 0xb9, 0xbf, 0x5b, 0x1e, 0x7a, 0x50, 0x53, 0x34,
-	0x5d, 0xe1, 0xfc, 0x85, 0x89, 0x1d, 0xc7, 0x04, 0x2b, 0x72, 0x17, 0x32, 0xb7, 0x68, 0x31, 0x14,
-	0x14, 0xf7, 0x16, 0x26, 0xae, 0xf2, 0x18, 0xbb, 0x53, 0xa3, 0x35, 0xe8, 0x99, 0x00, 0x64, 0x45,
-	0xf2, 0x3c, 0x26, 0x74, 0xe1, 0xf7, 0x04, 0x11, 0x26, 0x25, 0x2f, 0xc1, 0xac, 0x22, 0xf8, 0x9f,
-	0x24, 0x0f, 0xd9, 0x9b, 0xd8, 0xd9, 0x72, 0x0c, 0x42, 0x03, 0x47, 0xcd, 0x26, 0x58, 0xab, 0xe0,
-	0x4d, 0x43, 0xc7, 0x9a, 0x85, 0x6a, 0xd8, 0xd5, 0x6c, 0x4b, 0x0b, 0xeb, 0x12, 0xa5, 0x94, 0xdc,
-	0xf6, 0xf4, 0xdd, 0x8d, 0x1a, 0x41, 0xd6, 0x66, 0x0a, 0x6f, 0xce, 0x53, 0xf5, 0x96, 0xaf, 0x5e,
-	0xb2, 0x73, 0x90, 0xa1, 0x63, 0x86, 0xde, 0xb5, 0x26, 0x03, 0x53, 0x6d, 0x5f, 0x7d, 0x8e, 0x39,
-	0xf8, 0x00, 0xba, 0xc5, 0x8b, 0x92, 0x16, 0x55, 0x05, 0x7e, 0xc0, 0x07, 0xf1, 0x02, 0x7c, 0x53,
-	0x3e, 0x47, 0xc3, 0x98, 0x66, 0x58, 0x15, 0xbc, 0xad, 0xd1, 0x95, 0xbc, 0x6a, 0xda, 0xfa, 0x86,
-	0xab, 0x5c, 0x62, 0x5b, 0x9a, 0x2e, 0x1a, 0x40, 0x15, 0x66, 0x28, 0x3e, 0x67, 0x58, 0x13, 0x0c,
-	0x8d, 0x2f, 0x51, 0xf3, 0x90, 0x30, 0x71, 0x0d, 0xd2, 0x51, 0x28, 0xb0, 0x04, 0xfe, 0x45, 0xb3,
-	0x4f, 0x0b, 0xe9, 0x1b, 0xb8, 0xa2, 0x59, 0x36, 0x31, 0xaa, 0x86, 0x8e, 0x82, 0xeb, 0x80, 0x8a,
-	0xab, 0x94, 0xd9, 0xfc, 0xbe, 0x4f, 0x87, 0xbb, 0x77, 0x39, 0x50, 0x9a, 0xe7, 0x74, 0x66, 0xa6,
-	0xe8, 0x68, 0xf7, 0x7a, 0x42, 0xa4, 0xed, 0xab, 0xfd, 0x41, 0x68, 0x17, 0xc1, 0xec, 0xea, 0x50,
-	0x88, 0xb4, 0x77, 0xcb, 0x05, 0x16, 0x1b, 0xcd, 0x72, 0x81, 0x17, 0x50, 0xd8, 0xa2, 0xe2, 0x02,
-	0x28, 0x9f, 0x26, 0x0e, 0xaa, 0x56, 0x0d, 0x5d, 0xd3, 0x4d, 0xe4, 0xba, 0xca, 0x65, 0x36, 0xac,
-	0xcf, 0xd0, 0xf2, 0x35, 0x04, 0x26, 0xa9, 0xbc, 0xed, 0xab, 0x20, 0x18, 0x50, 0x4e, 0x18, 0xdf,
-	0x9b, 0xa4, 0x54, 0xc1, 0x1b, 0x72, 0x77, 0x38, 0xc4, 0x5a, 0xd5, 0x36, 0x2b, 0xd8, 0xd1, 0xea,
-	0x88, 0xac, 0x2b, 0x8f, 0xb3, 0x5d, 0x7f, 0x67, 0xcf, 0x57, 0xfb, 0xa7, 0x70, 0xdd, 0xc1, 0x3a,
-	0x22, 0xb8, 0x32, 0x15, 0x28, 0x4e, 0x33, 0xbd, 0x05, 0x44, 0xd6, 0x5b, 0xbe, 0x2a, 0x3d, 0x13,
-	0x17, 0xcb, 0x95, 0x2c, 0x7c, 0xc5, 0xae, 0x19, 0x74, 0x92, 0xc8, 0x4e, 0x49, 0x91, 0x60, 0x57,
-	0x0e, 0x07, 0x1b, 0xf2, 0x59, 0x17, 0x13, 0xcd, 0xb4, 0xb7, 0xb4, 0xba, 0x63, 0xd8, 0x8e, 0x41,
-	0x76, 0x94, 0x27, 0xd8, 0xa6, 0x18, 0x6f, 0xf9, 0x6a, 0xa7, 0x8b, 0xc9, 0xac, 0xbd, 0xb5, 0x10,
-	0x22, 0x71, 0x64, 0x4b, 0x8b, 0x0b, 0xcb, 0xf2, 0x4c, 0x73, 0xf0, 0x81, 0x24, 0xf7, 0xd6, 0xd0,
-	0x76, 0x44, 0x53, 0xb7, 0x2d, 0xdd, 0x73, 0x1c, 0x6c, 0xe9, 0x3b, 0xca, 0x10, 0x1b, 0x47, 0x97,
-	0xdd, 0x7d, 0xa0, 0xad, 0x39, 0xb4, 0x1d, 0xf8, 0x38, 0x99, 0xa8, 0xd0, 0x23, 0xbf, 0x26, 0x90,
-	0xc7, 0x47, 0xbe, 0x08, 0x8c, 0x86, 0x9c, 0x5d, 0x56, 0x88, 0xed, 0x42, 0xa1, 0x55, 0xf0, 0xa9,
-	0x24, 0x77, 0xeb, 0x0e, 0x72, 0xd7, 0x33, 0x29, 0xf9, 0x93, 0x6c, 0x5a, 0x3e, 0x64, 0x29, 0xf9,
-	0x64, 0x94, 0x92, 0xeb, 0x61, 0x4a, 0x3e, 0x1d, 0x9c, 0xcd, 0xb4, 0x59, 0x92, 0x1c, 0x0b, 0xc3,
-	0x30, 0xd3, 0xc9, 0xa7, 0xd9, 0x4c, 0x4c, 0xd7, 0x72, 0x57, 0xce, 0x08, 0x4d, 0xd6, 0xf5, 0x30,
-	0x59, 0x2f, 0x3f, 0x88, 0x19, 0x9a, 0xae, 0x4f, 0x06, 0xe9, 0x7a, 0xc6, 0x98, 0x63, 0x82, 0x9f,
-	0x4a, 0x72, 0x5f, 0x96, 0x5e, 0x74, 0x4b, 0xf2, 0x14, 0x9b, 0x7f, 0x63, 0xcf, 0x57, 0x4f, 0x4e,
-	0x42, 0xee, 0x82, 0x3f, 0x6d, 0x25, 0x7b, 0xc1, 0x2f, 0x44, 0x8b, 0x96, 0x46, 0xa3, 0x59, 0x4e,
-	0x6c, 0x43, 0xb1, 0x65, 0xf0, 0x6d, 0x49, 0xee, 0x75, 0x89, 0x67, 0x69, 0x34, 0x73, 0x42, 0xa6,
-	0xb1, 0x89, 0xb5, 0xe0, 0xee, 0xc8, 0x55, 0x9e, 0x8e, 0xf3, 0xd1, 0x6e, 0xaa, 0x71, 0x27, 0x52,
-	0x58, 0xa4, 0xf8, 0x62, 0x9c, 0x25, 0x09, 0xb0, 0x74, 0x6e, 0xcd, 0x05, 0xb4, 0x63, 0x23, 0xb7,
-	0x87, 0xa1, 0xc8, 0x1a, 0x2d, 0x59, 0x33, 0x6e, 0xd0, 0xb8, 0xea, 0x2a, 0x57, 0x98, 0x13, 0x2f,
-	0xd1, 0x44, 0x2d, 0xd5, 0x6c, 0xce, 0xb0, 0x92, 0xd4, 0x3e, 0x87, 0xf0, 0x39, 0x62, 0x2a, 0xa0,
-	0x8e, 0x0e, 0xc3, 0xbc, 0x1d, 0x9a, 0x95, 0x77, 0xb0, 0xde, 0xa3, 0x77, 0xa7, 0x67, 0x58, 0x0c,
-	0xad, 0xec, 0xf9, 0x6a, 0x27, 0x44, 0x5b, 0x8b, 0xc4, 0xe3, 0x5e, 0x9c, 0x4e, 0xb9, 0xc9, 0x67,
-	0x7c, 0x37, 0x94, 0xc8, 0x0e, 0x7d, 0x15, 0xcb, 0x58, 0x84, 0xbc, 0x3d, 0xb0, 0x29, 0x9f, 0xa1,
-	0x55, 0xe0, 0x2a, 0x72, 0xb1, 0x16, 0x3c, 0x01, 0x2a, 0x57, 0x07, 0xa5, 0xa1, 0xce, 0xd1, 0xce,
-	0x28, 0x2d, 0x5a, 0x62, 0x52, 0x76, 0x99, 0xd7, 0x19, 0xa9, 0x06, 0xb2, 0x38, 0x72, 0xa4, 0xc5,
-	0xa5, 0x41, 0x07, 0xb3, 0x29, 0x0d, 0x97, 0xc7, 0x9b, 0xcd, 0xb2, 0x04, 0x33, 0x4d, 0xc1, 0xbb,
-	0x47, 0xe5, 0x4b, 0x34, 0x6a, 0xc4, 0xe1, 0x82, 0xd6, 0x94, 0xba, 0x5d, 0xa3, 0x4b, 0xd6, 0xc1,
-	0xf7, 0x3c, 0xec, 0x12, 0x6d, 0xc3, 0x58, 0x55, 0xae, 0xb1, 0xe9, 0xf8, 0xab, 0x14, 0x3e, 0x1d,
-	0xce, 0xa1, 0xed, 0xc9, 0x19, 0x18, 0xe0, 0x77, 0x8c, 0x89, 0x96, 0xaf, 0xaa, 0x35, 0xb4, 0x1d,
-	0x6f, 0x71, 0x32, 0x13, 0xda, 0x48, 0x54, 0xe2, 0x53, 0xf0, 0x10, 0x3d, 0xae, 0x1e, 0x3b, 0xd4,
-	0xe4, 0xe1, 0x2a, 0xe1, 0x63, 0x64, 0xc6, 0x5d, 0x78, 0x48, 0xb3, 0x55, 0xf0, 0xb9, 0x24, 0xf7,
-	0xc6, 0x2f, 0x22, 0x26, 0xe2, 0xdf, 0x50, 0x87, 0xd9, 0x06, 0xfe, 0x88, 0x8e, 0x44, 0x4f, 0xf4,
-	0xa2, 0x30, 0x3b, 0x3e, 0xcf, 0x3f, 0xa3, 0xf6, 0x20, 0x81, 0x3c, 0x4e, 0xa4, 0x45, 0xa0, 0xe8,
-	0x21, 0x4b, 0x68, 0xa4, 0x40, 0xce, 0x6d, 0x7d, 0xa1, 0x53, 0x30, 0x69, 0x85, 0xb8, 0x37, 0xd8,
-	0x4d, 0xf9, 0x02, 0x7b, 0xf4, 0xa8, 0x7a, 0xa6, 0x19, 0x66, 0x35, 0xb6, 0x15, 0x95, 0xa8, 0xca,
-	0x08, 0x63, 0x3a, 0x46, 0xb3, 0x06, 0xaa, 0x35, 0xed, 0x99, 0x26, 0xcb, 0x47, 0xee, 0x5a, 0x61,
-	0x51, 0xd9, 0xf6, 0xd5, 0x8b, 0xe1, 0x91, 0x25, 0x82, 0x4b, 0xb0, 0xa0, 0x1d, 0x78, 0x49, 0x3e,
-	0x5d, 0xc5, 0x88, 0x78, 0x0e, 0xd6, 0xaa, 0x26, 0x5a, 0x73, 0x95, 0x51, 0xb6, 0xef, 0x2e, 0xd3,
-	0x93, 0x3e, 0x04, 0xa6, 0xa9, 0x3c, 0x7e, 0x20, 0xe1, 0x84, 0x25, 0x98, 0x52, 0x01, 0x5b, 0x72,
-	0x1f, 0xf7, 0x2e, 0x12, 0xd4, 0x38, 0xd8, 0xb2, 0xbd, 0xb5, 0x75, 0xe5, 0x3a, 0x5b, 0xb4, 0xcf,
-	0xb3, 0xf0, 0x1a, 0xab, 0xcc, 0x52, 0x8d, 0x17, 0x98, 0x42, 0x9c, 0xf5, 0x08, 0xd1, 0x38, 0xa3,
-	0x10, 0x37, 0x06, 0x1b, 0x72, 0x4f, 0xae, 0xe3, 0x1a, 0xda, 0x56, 0x6e, 0xb0, 0x5e, 0x9f, 0xa3,
-	0xc9, 0x60, 0xa6, 0xe1, 0x1c, 0xda, 0x6e, 0xfb, 0xaa, 0x22, 0xea, 0x72, 0x0e, 0x6d, 0xc7, 0xfd,
-	0x09, 0x9a, 0x81, 0xb7, 0x8f, 0xca, 0x6a, 0x74, 0xd9, 0xa3, 0x21, 0x93, 0xa6, 0x14, 0xb6, 0x59,
-	0xd1, 0x88, 0xe9, 0x6a, 0x34, 0x7e, 0x18, 0xb6, 0xe5, 0x2a, 0x37, 0xd9, 0x7c, 0x7d, 0x4c, 0x57,
-	0x66, 0x7f, 0x74, 0xb5, 0x32, 0x4e, 0x55, 0xef, 0x9a, 0x95, 0xa5, 0xd9, 0xc5, 0x57, 0x42, 0xbd,
-	0x96, 0xaf, 0xf6, 0x1b, 0xc5, 0x70, 0x9c, 0xef, 0x1c, 0xa0, 0x43, 0xd7, 0xe7, 0x81, 0x36, 0x0e,
-	0x86, 0x1b, 0xcd, 0xf2, 0x41, 0x0e, 0xc2, 0x7c, 0x5b, 0xd3, 0x8d, 0x40, 0xd0, 0x94, 0xe4, 0x7e,
-	0x6e, 0xdc, 0xa3, 0xc4, 0x4a, 0x23, 0x7a, 0x9d, 0x95, 0xb3, 0xb7, 0xd8, 0xf0, 0xbf, 0x43, 0x47,
-	0x41, 0x99, 0x8c, 0xf5, 0xa2, 0x34, 0x69, 0x69, 0x72, 0x61, 0x76, 0x7c, 0xbe, 0xe5, 0xab, 0x8a,
-	0x9e, 0xc7, 0xf4, 0x7a, 0x50, 0xf0, 0x3e, 0x9d, 0x99, 0xa1, 0xb4, 0xc2, 0x01, 0x49, 0x7b, 0xa3,
-	0x59, 0x2e, 0xec, 0x13, 0x16, 0xf6, 0x08, 0xfe, 0x21, 0xc9, 0x17, 0x45, 0x94, 0xee, 0x79, 0x86,
-	0xce, 0x38, 0x3d, 0xcb, 0x38, 0xbd, 0x4b, 0x39, 0x9d, 0xcf, 0xdb, 0x7f, 0x79, 0x79, 0x66, 0x32,
-	0x20, 0x75, 0x3e, 0xdf, 0xc5, 0xcb, 0x9e, 0xa1, 0x07, 0xac, 0xae, 0x14, 0xb0, 0x0a, 0x35, 0x0e,
-	0x38, 0x3a, 0x1b, 0xcd, 0x72, 0x71, 0xb7, 0xb0, 0xb8, 0xd3, 0x03, 0xe7, 0x6a, 0x0b, 0x59, 0xca,
-	0xed, 0xc3, 0xe6, 0x6a, 0xe5, 0x80, 0xb9, 0x5a, 0x39, 0x6c, 0xae, 0x56, 0x52, 0xa4, 0xae, 0xa7,
-	0x48, 0x5d, 0x2f, 0x9e, 0xab, 0x95, 0xc2, 0xb9, 0x5a, 0x39, 0x6c, 0xae, 0x28, 0xa7, 0xe7, 0x0e,
-	0x9d, 0xab, 0x95, 0x83, 0xe6, 0x6a, 0xe5, 0xd0, 0xb9, 0x4a, 0xd3, 0xba, 0x91, 0xa2, 0x75, 0xe3,
-	0x80, 0xb9, 0x5a, 0x29, 0x9e, 0x2b, 0x4a, 0xac, 0x21, 0xc9, 0xe7, 0x45, 0xc4, 0xd8, 0x6b, 0xa3,
-	0x32, 0xc6, 0x58, 0xbd, 0xd2, 0xf2, 0xd5, 0xbe, 0xbc, 0x09, 0xf6, 0x52, 0x99, 0xe4, 0xaa, 0x62,
-	0x9c, 0xbf, 0xb4, 0x4a, 0xf9, 0x7c, 0x73, 0x18, 0x16, 0xd9, 0x04, 0x7f, 0x92, 0xe4, 0xcb, 0x22,
-	0xa7, 0xe2, 0x1b, 0xcc, 0x75, 0x07, 0xbb, 0xeb, 0xb6, 0x59, 0x51, 0xbe, 0xc0, 0x1c, 0x7c, 0xad,
-	0xe5, 0xab, 0x02, 0x07, 0xc2, 0x73, 0x67, 0x29, 0xd2, 0x6e, 0xfb, 0xea, 0x8d, 0x02, 0x5f, 0xb3,
-	0xaa, 0x9c, 0xdb, 0xbc, 0xd7, 0xd2, 0x30, 0x7c, 0x80, 0xc6, 0xe0, 0xeb, 0x72, 0x87, 0x57, 0xb7,
-	0xea, 0x71, 0xf6, 0xff, 0xcb, 0x69, 0x16, 0xa3, 0xbf, 0xb2, 0xe7, 0xab, 0xe7, 0x92, 0xc2, 0x73,
-	0x79, 0xc1, 0x5a, 0x48, 0x4a, 0x01, 0x56, 0x72, 0x86, 0xd5, 0x78, 0xdd, 0xaa, 0x87, 0x00, 0x57,
-	0x6c, 0x36, 0x9a, 0x65, 0x71, 0x63, 0x45, 0x82, 0xa7, 0xb8, 0x26, 0xe0, 0xe7, 0x52, 0xd8, 0x7d,
-	0xf4, 0xf4, 0xf9, 0xc1, 0x34, 0x1b, 0xa5, 0x37, 0x59, 0xf2, 0x92, 0x36, 0x11, 0x3f, 0x83, 0xb2,
-	0xee, 0x07, 0xe3, 0xee, 0xf9, 0xe7, 0x4b, 0xce, 0x87, 0x24, 0x4b, 0xbb, 0x50, 0xac, 0x45, 0xb3,
-	0x11, 0x51, 0x2f, 0x8a, 0x04, 0xe5, 0xa4, 0x15, 0xf8, 0xad, 0x24, 0x77, 0x32, 0x37, 0x93, 0x47,
-	0xce, 0x5f, 0x05, 0x8e, 0x7e, 0x97, 0x5d, 0x66, 0xa4, 0x4d, 0x70, 0x0f, 0x9e, 0xcc, 0xd5, 0x52,
-	0xec, 0x6a, 0xfa, 0x89, 0x52, 0xe8, 0xec, 0xc5, 0x83, 0xf4, 0x1a, 0xcd, 0x72, 0x41, 0x5f, 0x8a,
-	0x04, 0x3b, 0xf8, 0x96, 0x89, 0xcb, 0xc9, 0x53, 0xe6, 0x87, 0xc5, 0x2e, 0x73, 0xcf, 0x9a, 0x19,
-	0x97, 0xd3, 0x0f, 0x91, 0xc5, 0x2e, 0x17, 0xe9, 0xe5, 0x5d, 0x8e, 0x34, 0x23, 0x97, 0xe3, 0x97,
-	0xcb, 0xaa, 0x1c, 0xfc, 0x65, 0x22, 0xae, 0x75, 0x7e, 0x3d, 0xcd, 0x92, 0xae, 0x2f, 0xa5, 0xfd,
-	0x65, 0xfb, 0x2e, 0x29, 0x7a, 0xb8, 0xc5, 0xe8, 0x24, 0x48, 0xfa, 0xe6, 0xa3, 0x83, 0x43, 0x5c,
-	0x76, 0xd3, 0x9c, 0xbf, 0xe4, 0xd5, 0xea, 0x3a, 0x51, 0x3e, 0xa2, 0x43, 0x24, 0x4d, 0xcc, 0xed,
-	0xf9, 0xea, 0xc5, 0xa4, 0xc7, 0xb9, 0xf4, 0x15, 0xed, 0x82, 0x4e, 0xd2, 0xe3, 0x54, 0xcb, 0xe1,
-	0xe9, 0xee, 0x41, 0x5e, 0x81, 0x16, 0x76, 0x3d, 0x99, 0xb2, 0xc6, 0xd5, 0x91, 0xe5, 0x2a, 0xbf,
-	0x09, 0x66, 0x69, 0x29, 0xe3, 0x02, 0x5f, 0x0e, 0x2c, 0x52, 0xc5, 0x8c, 0x0b, 0x39, 0x3c, 0x3f,
-	0x55, 0xcc, 0x93, 0x9c, 0xde, 0xc4, 0x9d, 0x4f, 0x3e, 0x1b, 0x38, 0xd2, 0xfc, 0x6c, 0xe0, 0xc8,
-	0x27, 0x7b, 0x03, 0x52, 0x73, 0x6f, 0x40, 0x7a, 0xe7, 0xfe, 0xc0, 0x91, 0xf7, 0xef, 0x0f, 0x48,
-	0xcd, 0xfb, 0x03, 0x47, 0xfe, 0x79, 0x7f, 0xe0, 0xc8, 0xab, 0x4f, 0xae, 0x19, 0x64, 0xdd, 0x5b,
-	0xbd, 0xaa, 0xdb, 0xb5, 0x6b, 0xf1, 0x65, 0x03, 0xf7, 0x2b, 0xf9, 0x0f, 0xe8, 0xea, 0x09, 0xf6,
-	0xa7, 0xcf, 0xeb, 0xff, 0x0f, 0x00, 0x00, 0xff, 0xff, 0xc9, 0x24, 0x93, 0x46, 0x60, 0x2a, 0x00,
-	0x00,
+	// 4037 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x5a, 0x5d, 0x6c, 0x1d, 0x49,
+	0x56, 0x4e, 0x27, 0x9b, 0xec, 0xa4, 0xe3, 0x38, 0x4e, 0xd9, 0xb1, 0x3b, 0x71, 0xd6, 0xed, 0xbd,
+	0x73, 0xb3, 0xeb, 0xd9, 0x49, 0x1c, 0xc7, 0x71, 0xb2, 0x99, 0xc0, 0x32, 0xeb, 0x9f, 0x98, 0xf1,
+	0xc4, 0x76, 0x3c, 0x65, 0x7b, 0x8d, 0x16, 0x41, 0xab, 0xdc, 0xb7, 0x6c, 0xf7, 0xba, 0x6f, 0xf7,
+	0x4d, 0x77, 0xb5, 0x7f, 0x76, 0x11, 0x8c, 0x16, 0xb1, 0xbb, 0x6f, 0x2c, 0xd6, 0x02, 0x12, 0x20,
+	0x34, 0x08, 0x90, 0x18, 0x96, 0x45, 0x48, 0x48, 0x48, 0x20, 0x21, 0x56, 0x08, 0xa4, 0x11, 0x3c,
+	0xd8, 0x12, 0x12, 0x42, 0xfc, 0xf4, 0x6a, 0x1c, 0x9e, 0xee, 0x03, 0x0f, 0xf7, 0xd1, 0xbc, 0xa0,
+	0x53, 0xfd, 0x57, 0xdd, 0x5d, 0x6d, 0x47, 0xe2, 0xed, 0xf6, 0xf9, 0xce, 0x39, 0x75, 0x4e, 0xfd,
+	0x9c, 0x3a, 0xe7, 0xd4, 0x55, 0xef, 0xd8, 0xd6, 0xfa, 0x7d, 0xd3, 0x75, 0x36, 0xac, 0xcd, 0xfb,
+	0x6e, 0x8b, 0x59, 0xae, 0xe3, 0x47, 0x5f, 0x81, 0x47, 0xe0, 0x6b, 0xb4, 0xe5, 0xb9, 0xcc, 0x45,
+	0x97, 0x22, 0xe2, 0xad, 0x01, 0x81, 0x9d, 0x05, 0x8e, 0xe5, 0x6c, 0x46, 0x0c, 0xb7, 0x6e, 0x08,
+	0x80, 0x6f, 0x7d, 0x93, 0xc6, 0xe4, 0xcb, 0x74, 0x8f, 0x45, 0x3f, 0x6b, 0xff, 0x60, 0xa8, 0x7d,
+	0x2f, 0xa2, 0x11, 0xa6, 0xc5, 0x11, 0xd0, 0xef, 0x2b, 0x6a, 0x8f, 0x6d, 0xf9, 0x8c, 0x3a, 0x06,
+	0x69, 0x34, 0x3c, 0xea, 0xfb, 0xd4, 0xd7, 0x94, 0xe1, 0x0b, 0x23, 0x97, 0xa7, 0xfc, 0xe3, 0x50,
+	0x47, 0x98, 0xec, 0xce, 0x73, 0x78, 0x32, 0x41, 0xdb, 0xa1, 0x7e, 0xcd, 0xce, 0x93, 0x3a, 0xa1,
+	0x7e, 0x67, 0xaf, 0x69, 0x3f, 0xad, 0xe5, 0xe8, 0xb5, 0xe1, 0x06, 0xdd, 0x20, 0x81, 0xcd, 0x9e,
+	0xd6, 0xe2, 0x1f, 0xb5, 0x93, 0xc3, 0xfa, 0x67, 0xe3, 0xdf, 0x07, 0x47, 0x75, 0x89, 0x72, 0x5c,
+	0x54, 0x8d, 0xfe, 0x47, 0x51, 0xb5, 0x4d, 0xdb, 0x5d, 0x27, 0xb6, 0xd1, 0xb0, 0x7c, 0xd3, 0xdd,
+	0xa1, 0xde, 0xbe, 0xe1, 0x53, 0x6f, 0x87, 0x7a, 0xbe, 0x76, 0x9e, 0x1b, 0xfa, 0x97, 0xca, 0x71,
+	0xa8, 0xf7, 0x62, 0xb2, 0xfb, 0xb3, 0x9c, 0x6f, 0xd2, 0x71, 0x96, 0x23, 0xbc, 0x1d, 0xea, 0x37,
+	0x36, 0x13, 0x9a, 0x1b, 0x38, 0x26, 0x8d, 0x81, 0x4e, 0xa8, 0xdf, 0xe5, 0x06, 0xcb, 0x50, 0x89,
+	0xdd, 0xed, 0xc3, 0x7a, 0x9f, 0x8c, 0xb5, 0x73, 0x58, 0x97, 0x0f, 0x90, 0x77, 0x54, 0x66, 0x1b,
+	0xee, 0x8f, 0x04, 0x67, 0x12, 0xa7, 0x62, 0x3a, 0xfa, 0x6f, 0x99, 0xc3, 0xd4, 0x21, 0xeb, 0x36,
+	0x6d, 0x68, 0x17, 0x86, 0x95, 0x91, 0x37, 0xa6, 0x3e, 0x06, 0x87, 0x7b, 0x52, 0x8d, 0xcf, 0x22,
+	0xb0, 0xec, 0x6d, 0x0c, 0x74, 0x42, 0xfd, 0x4b, 0x12, 0x6f, 0x63, 0x54, 0x70, 0x97, 0x79, 0x01,
+	0x05, 0x5f, 0x2b, 0xd4, 0x54, 0x01, 0x27, 0x87, 0xf5, 0xcf, 0x80, 0xe8, 0xc1, 0x51, 0xbd, 0x64,
+	0x54, 0xc9, 0xcd, 0x98, 0x8e, 0xfe, 0x53, 0x51, 0x07, 0x6c, 0xd7, 0x94, 0x7a, 0xf9, 0x19, 0xee,
+	0xe5, 0x1f, 0x82, 0x97, 0xd7, 0xe6, 0x5d, 0x53, 0xd4, 0xd7, 0x0e, 0xf5, 0x3e, 0xdb, 0x35, 0x4b,
+	0x36, 0x74, 0x42, 0xfd, 0xad, 0x68, 0x0b, 0xba, 0xe6, 0xeb, 0xb8, 0x28, 0x57, 0x52, 0x41, 0x17,
+	0x1c, 0x2c, 0xda, 0x83, 0x6f, 0x70, 0x81, 0x92, 0x7b, 0xff, 0xac, 0xa8, 0xbd, 0x91, 0x7b, 0x24,
+	0xd6, 0x65, 0xb4, 0x5c, 0x8f, 0x69, 0x17, 0x87, 0x95, 0x91, 0x8b, 0x53, 0xbf, 0x03, 0xae, 0x75,
+	0x25, 0xaa, 0x96, 0x5c, 0x8f, 0xb5, 0x43, 0xfd, 0x7a, 0x6e, 0x68, 0x20, 0x76, 0x42, 0xfd, 0x8b,
+	0x65, 0xa7, 0x00, 0x11, 0x3c, 0x1a, 0x7f, 0x30, 0x36, 0xfe, 0xe5, 0xda, 0x49, 0xa8, 0x5f, 0xb0,
+	0x1c, 0xd6, 0x3e, 0xac, 0x4b, 0xd4, 0xc8, 0x88, 0x27, 0x87, 0xf5, 0x8b, 0x5c, 0xf4, 0xe0, 0xa8,
+	0x9e, 0xb3, 0x04, 0x97, 0x79, 0xd1, 0xaf, 0x9e, 0x57, 0x87, 0x0b, 0xde, 0x34, 0x03, 0x9b, 0x59,
+	0x26, 0xf1, 0x59, 0x12, 0x37, 0xb4, 0x4b, 0xc3, 0xca, 0xc8, 0xe5, 0xa9, 0xbf, 0x06, 0xd7, 0xba,
+	0x13, 0x85, 0x0b, 0xd3, 0x70, 0x92, 0xdb, 0xa1, 0xde, 0x9b, 0x53, 0x1a, 0x91, 0x3b, 0xa1, 0xfe,
+	0xb8, 0xec, 0x5e, 0x84, 0x09, 0x0e, 0xfe, 0xfc, 0xc6, 0xc6, 0x83, 0xf1, 0xa7, 0x4f, 0x9f, 0x3c,
+	0x7c, 0x32, 0xf1, 0x0b, 0x4f, 0x23, 0x6f, 0xdb, 0x87, 0x75, 0xa9, 0x42, 0x39, 0xf9, 0xe4, 0xb0,
+	0x8e, 0xca, 0x4a, 0x0e, 0x8e, 0xea, 0x05, 0x33, 0xf1, 0xe7, 0xf2, 0xc2, 0x89, 0x87, 0x71, 0x30,
+	0x42, 0x2f, 0xd4, 0xab, 0x4d, 0xb2, 0x67, 0xf8, 0xd4, 0x69, 0x18, 0xdb, 0xeb, 0x2d, 0x5f, 0xfb,
+	0x2c, 0x5f, 0xcc, 0xb7, 0xdb, 0xa1, 0x7e, 0xa5, 0x49, 0xf6, 0x96, 0xa9, 0xd3, 0x78, 0xbe, 0xde,
+	0x82, 0xe0, 0x72, 0x9d, 0xbb, 0x25, 0xd0, 0x92, 0xf5, 0xc1, 0x22, 0x63, 0xa2, 0xd0, 0xa3, 0xe6,
+	0x4e, 0xa4, 0xf0, 0x8d, 0x9c, 0x42, 0x4c, 0xcd, 0x9d, 0xa2, 0xc2, 0x84, 0x96, 0x53, 0x98, 0x10,
+	0xd1, 0x5f, 0x29, 0xea, 0x80, 0x47, 0x4d, 0xd7, 0x71, 0xa8, 0x09, 0xe1, 0xdd, 0xb0, 0x1c, 0x46,
+	0xbd, 0x1d, 0x62, 0x1b, 0xbe, 0x76, 0x99, 0xeb, 0xfe, 0x65, 0x1e, 0xd4, 0x13, 0x96, 0xb9, 0x18,
+	0x5e, 0x86, 0xd8, 0x21, 0x0a, 0xa6, 0x40, 0x27, 0xd4, 0x47, 0xf8, 0xd8, 0x52, 0x54, 0x58, 0xa5,
+	0xc7, 0x63, 0x89, 0x49, 0x27, 0x87, 0xf5, 0xf3, 0x8f, 0xc7, 0x78, 0x7c, 0x2f, 0x8d, 0x83, 0xe5,
+	0xa3, 0xa0, 0x0d, 0xb5, 0xdb, 0xa3, 0x36, 0xd9, 0xf7, 0xd3, 0x18, 0xa0, 0xf2, 0x18, 0xf0, 0x6e,
+	0x3b, 0xd4, 0xaf, 0x46, 0x48, 0x76, 0xd0, 0x6b, 0xb1, 0x41, 0x02, 0xb5, 0x78, 0xc2, 0x93, 0x13,
+	0x8b, 0xf3, 0xc2, 0xe8, 0xdb, 0xe7, 0xd5, 0xc1, 0x78, 0xa0, 0xd4, 0x90, 0x6c, 0x92, 0x9a, 0xda,
+	0x15, 0x3e, 0x49, 0x7f, 0x0f, 0x7b, 0x78, 0x00, 0x03, 0x5f, 0xc9, 0x85, 0x85, 0x76, 0xa8, 0x0f,
+	0x78, 0x72, 0x28, 0x0d, 0xb4, 0x15, 0xb8, 0x60, 0xe5, 0x83, 0x31, 0xe1, 0xc8, 0x56, 0xea, 0xab,
+	0x86, 0x60, 0x92, 0x1f, 0xc0, 0x24, 0x57, 0x99, 0x89, 0xb5, 0xc8, 0xcf, 0x32, 0x82, 0xd6, 0xd5,
+	0xab, 0x3e, 0x23, 0x1e, 0x33, 0xd6, 0x3d, 0x77, 0xd7, 0xa7, 0x9e, 0xd6, 0xc5, 0xe7, 0xfa, 0x2b,
+	0xed, 0x50, 0xef, 0xe2, 0xc0, 0x54, 0x44, 0xef, 0x84, 0xfa, 0xe7, 0xb9, 0x3b, 0x22, 0xb1, 0x72,
+	0xa6, 0x73, 0xa2, 0xe8, 0x8f, 0x15, 0xf5, 0x86, 0x43, 0x98, 0xc1, 0x3c, 0x02, 0xb7, 0x1a, 0xb1,
+	0xd3, 0x85, 0xed, 0xe6, 0x83, 0xbd, 0x3c, 0x0e, 0x75, 0x75, 0x71, 0x72, 0x25, 0x0b, 0xeb, 0xaa,
+	0x43, 0x58, 0xb6, 0xc6, 0x3a, 0x1f, 0x38, 0x23, 0x49, 0x42, 0xb8, 0x28, 0x90, 0xfb, 0x12, 0xc2,
+	0xb5, 0x30, 0x04, 0xee, 0x75, 0x08, 0x5b, 0x49, 0xcc, 0x49, 0x36, 0xc4, 0xdf, 0x94, 0xec, 0xb4,
+	0x29, 0xf1, 0xa9, 0xd1, 0xd4, 0xae, 0xf1, 0xad, 0xf0, 0x1d, 0xd8, 0x0a, 0x97, 0x17, 0x27, 0x57,
+	0xe6, 0x81, 0x0c, 0x8b, 0x7f, 0xcd, 0x21, 0x2c, 0xfa, 0xb0, 0x9c, 0x80, 0x51, 0x3f, 0xdd, 0x90,
+	0x05, 0xba, 0xf4, 0x6c, 0xb4, 0x0f, 0xeb, 0x25, 0xf9, 0x32, 0x29, 0x3d, 0x41, 0xd9, 0xc0, 0x18,
+	0x89, 0xd6, 0x47, 0x34, 0xf4, 0x4f, 0x8a, 0x3a, 0x90, 0x37, 0xde, 0xa3, 0x0e, 0xdd, 0xe5, 0x3b,
+	0xb9, 0x87, 0x9b, 0x7f, 0x00, 0xe6, 0x5f, 0x59, 0x9c, 0x5c, 0xc1, 0x11, 0x00, 0x0e, 0x5c, 0x77,
+	0x08, 0x4b, 0x3e, 0x53, 0x17, 0xea, 0x89, 0x0b, 0x79, 0x44, 0x70, 0xe2, 0xa1, 0xe8, 0x84, 0x44,
+	0x87, 0x8c, 0x08, 0x8e, 0x3c, 0x04, 0x47, 0x44, 0x13, 0x70, 0x9f, 0xe8, 0x4a, 0x42, 0x95, 0x38,
+	0xc3, 0xac, 0x26, 0x75, 0x03, 0x66, 0xf8, 0xda, 0xf5, 0xbc, 0x33, 0x2b, 0x11, 0xb0, 0x1c, 0x3b,
+	0x93, 0x7c, 0xc2, 0x4e, 0x6f, 0xe4, 0x9c, 0xc9, 0x23, 0x55, 0xc7, 0x4f, 0xa2, 0x43, 0x46, 0x4c,
+	0x8f, 0x9c, 0x68, 0x42, 0xde, 0x99, 0x84, 0x8a, 0x7e, 0x57, 0x51, 0xb5, 0xc0, 0x27, 0x9b, 0xd4,
+	0xf0, 0x28, 0xdc, 0xfb, 0x96, 0xb3, 0x69, 0x10, 0xd3, 0xa4, 0x2d, 0x46, 0x1b, 0x1a, 0xe2, 0xde,
+	0x10, 0x38, 0x01, 0xab, 0x78, 0x32, 0xa6, 0xc2, 0x09, 0x08, 0xbc, 0xe4, 0xab, 0x13, 0xea, 0x3d,
+	0xdc, 0x89, 0x8c, 0x24, 0x18, 0x2c, 0x32, 0xe6, 0xbe, 0x60, 0xc7, 0x67, 0x2a, 0x71, 0x3f, 0x37,
+	0x01, 0x27, 0x16, 0x24, 0x74, 0xf4, 0x2d, 0xb5, 0xaf, 0x68, 0x9c, 0x4f, 0xa9, 0xa3, 0xf5, 0x72,
+	0xc3, 0xe6, 0x8e, 0x43, 0xfd, 0xd2, 0x2a, 0x5e, 0xa6, 0xd4, 0x69, 0x87, 0xfa, 0xa5, 0xc0, 0x83,
+	0x5f, 0x9d, 0x50, 0xef, 0x8a, 0x0d, 0x82, 0x4f, 0xc1, 0x98, 0x84, 0x21, 0xfd, 0x75, 0x70, 0x54,
+	0x8f, 0xc5, 0x31, 0xca, 0x1b, 0x00, 0x34, 0xf4, 0x9b, 0x8a, 0x7a, 0xb3, 0x38, 0x7a, 0xe0, 0x58,
+	0x2f, 0x03, 0x6a, 0x58, 0x0d, 0xad, 0x8f, 0x27, 0x11, 0x5f, 0x8f, 0xe6, 0x66, 0x95, 0x93, 0xe7,
+	0x66, 0xa2, 0xb9, 0x89, 0xbf, 0xc4, 0xb9, 0x49, 0x18, 0x6a, 0xd1, 0xa4, 0x24, 0x9f, 0x1d, 0xf1,
+	0x2b, 0x9e, 0x94, 0x04, 0x2b, 0x4e, 0x4a, 0xc2, 0x85, 0x7e, 0xac, 0xa8, 0xbd, 0x25, 0xbb, 0x3c,
+	0x5b, 0xbb, 0xc1, 0x2d, 0xfa, 0x75, 0xd8, 0x7b, 0x17, 0x57, 0xf1, 0x2a, 0x9e, 0x6f, 0x87, 0xfa,
+	0xc5, 0xc0, 0x5b, 0xc5, 0xf3, 0x9d, 0x50, 0x7f, 0x92, 0x18, 0x82, 0xe7, 0x85, 0xdd, 0xb5, 0xc5,
+	0x58, 0xcb, 0x7f, 0x7a, 0xff, 0x7e, 0x83, 0x30, 0x32, 0xea, 0xef, 0x3b, 0x26, 0xdb, 0x82, 0x62,
+	0xcd, 0xa1, 0xec, 0xbe, 0x43, 0x77, 0x81, 0x0a, 0x06, 0xc7, 0x4a, 0x92, 0x1f, 0x27, 0x87, 0xf5,
+	0xd7, 0x10, 0x3c, 0x38, 0xaa, 0x47, 0x56, 0xe0, 0xeb, 0x05, 0x3f, 0x3c, 0x1b, 0xfd, 0x44, 0x51,
+	0xf5, 0xa2, 0x0b, 0x2d, 0xd7, 0x87, 0x1b, 0xce, 0xa7, 0x66, 0xe0, 0x51, 0x7b, 0x5f, 0xeb, 0xe7,
+	0xe1, 0xf7, 0xb7, 0x79, 0x05, 0xb1, 0x8a, 0x97, 0x5c, 0x9f, 0xcd, 0xa5, 0x60, 0x3b, 0xd4, 0x7b,
+	0x02, 0x2f, 0x4f, 0xeb, 0x84, 0xfa, 0x17, 0x62, 0x27, 0xf3, 0x80, 0xe0, 0xef, 0x06, 0xb1, 0x7d,
+	0x1e, 0x92, 0xcb, 0xd2, 0x12, 0x1a, 0x64, 0x9e, 0x5c, 0x02, 0xea, 0x85, 0xa2, 0x09, 0xf8, 0x76,
+	0xde, 0xad, 0x3c, 0x8a, 0xfe, 0x4b, 0xe2, 0xa1, 0xe5, 0x58, 0xcc, 0x82, 0x3a, 0x02, 0xee, 0x3b,
+	0xc3, 0xd7, 0x06, 0xf8, 0x2e, 0xfe, 0x2d, 0x5e, 0x3d, 0xac, 0xe2, 0xb9, 0x08, 0x9d, 0x01, 0x10,
+	0x02, 0xc6, 0xb5, 0xc0, 0xcb, 0x91, 0xd2, 0x70, 0x51, 0xa0, 0x8b, 0xc1, 0xe2, 0xc9, 0x58, 0x2e,
+	0x80, 0x17, 0x35, 0x94, 0x49, 0x70, 0x03, 0x81, 0x14, 0x14, 0x0c, 0x05, 0x13, 0xf0, 0x60, 0xde,
+	0xc1, 0x1c, 0x88, 0xbe, 0xab, 0xa8, 0x03, 0x24, 0x60, 0xae, 0x11, 0xb4, 0x36, 0x3d, 0xd2, 0xa0,
+	0x59, 0x6e, 0xb2, 0xa5, 0xdd, 0xe4, 0x7e, 0x2d, 0x41, 0x05, 0x04, 0x2c, 0xab, 0x11, 0x47, 0x72,
+	0xad, 0xbf, 0x97, 0x16, 0x0b, 0x32, 0x50, 0xf4, 0x66, 0x5c, 0x4c, 0xd4, 0x1e, 0x8c, 0x63, 0xa9,
+	0x36, 0xd4, 0x54, 0x07, 0x12, 0x1b, 0x98, 0x6b, 0xb4, 0x3c, 0x98, 0x71, 0x7e, 0x35, 0xfa, 0xda,
+	0x2d, 0xbe, 0x85, 0x1e, 0x83, 0x21, 0x31, 0xcb, 0x8a, 0xbb, 0xe4, 0x51, 0x1c, 0xe3, 0x9d, 0x50,
+	0xbf, 0x15, 0xcd, 0xa8, 0x04, 0xac, 0x61, 0xa9, 0x0c, 0xda, 0x51, 0xd1, 0x36, 0xa5, 0x2d, 0x83,
+	0xd1, 0x66, 0xcb, 0xf5, 0x88, 0x67, 0x51, 0xdf, 0xd8, 0xd2, 0x06, 0xb9, 0xcb, 0xef, 0xc1, 0xbe,
+	0x04, 0x74, 0x25, 0x03, 0xc1, 0xdd, 0x37, 0xf9, 0x28, 0x45, 0x40, 0x2c, 0x8d, 0x26, 0x44, 0x57,
+	0xc7, 0x27, 0x70, 0x49, 0x0b, 0xda, 0x57, 0x7b, 0x4d, 0x62, 0x6e, 0x51, 0xc3, 0xda, 0x74, 0x5c,
+	0x8f, 0x36, 0x8c, 0x0d, 0xcb, 0xa6, 0xbe, 0x76, 0x9b, 0xbb, 0x38, 0x07, 0x17, 0x0c, 0x87, 0xe7,
+	0x22, 0x74, 0x16, 0xc0, 0x74, 0xa2, 0x4b, 0x48, 0xe9, 0x48, 0xa4, 0x5b, 0x1d, 0x97, 0xd5, 0xa0,
+	0xdf, 0x50, 0xd4, 0x5b, 0x2d, 0xcf, 0xdd, 0x84, 0xda, 0xc2, 0x08, 0x5a, 0x0d, 0xc2, 0xa8, 0x98,
+	0xaf, 0x7f, 0x8e, 0xfb, 0xbe, 0x02, 0xe9, 0x66, 0xc2, 0xb5, 0xca, 0x99, 0xc4, 0xdc, 0x3c, 0xaa,
+	0x79, 0x2b, 0x70, 0xc1, 0x9c, 0x47, 0xc2, 0x44, 0x28, 0x8f, 0x70, 0x95, 0x46, 0xf4, 0x6d, 0x45,
+	0xed, 0xb7, 0xad, 0xa6, 0xc5, 0x8c, 0x75, 0xe2, 0x34, 0x76, 0xad, 0x06, 0xdb, 0x32, 0x2c, 0xc7,
+	0xb0, 0x89, 0xa3, 0x0d, 0xf1, 0x29, 0x59, 0xe0, 0xb5, 0x1c, 0x70, 0x4c, 0x25, 0x0c, 0x73, 0xce,
+	0x3c, 0x71, 0xb2, 0xfa, 0xbb, 0x8c, 0x9d, 0x32, 0x2d, 0x32, 0x55, 0xe8, 0x43, 0x45, 0x45, 0x4d,
+	0xcb, 0x31, 0xb6, 0xdc, 0x26, 0x85, 0xee, 0xc0, 0xb6, 0xb1, 0xe1, 0x51, 0xaa, 0xe9, 0xc3, 0xca,
+	0xc8, 0x95, 0xf1, 0xae, 0xd1, 0xa8, 0xd1, 0x35, 0xba, 0x6c, 0x7d, 0x93, 0x4e, 0x3d, 0xfb, 0x24,
+	0xd4, 0xcf, 0xc1, 0xa9, 0x6e, 0x5a, 0xce, 0x7b, 0x6e, 0x93, 0xce, 0x58, 0xfe, 0xf6, 0xac, 0x47,
+	0x69, 0xba, 0x3b, 0x0a, 0x74, 0xf1, 0x1c, 0x0c, 0xdf, 0x01, 0x43, 0x2e, 0x3c, 0x18, 0xbe, 0x83,
+	0x8b, 0xe2, 0xe8, 0x95, 0xa2, 0x76, 0x25, 0xfb, 0x9d, 0xdf, 0x02, 0xc3, 0xfc, 0x16, 0xf8, 0x3b,
+	0x9e, 0x81, 0x24, 0x9b, 0x36, 0xba, 0x0b, 0xae, 0x78, 0xd9, 0x67, 0x27, 0xd4, 0x67, 0x92, 0x02,
+	0x20, 0xa1, 0x49, 0xee, 0x85, 0xf8, 0x04, 0xf8, 0x85, 0x10, 0xdf, 0xa4, 0x8c, 0x8c, 0x7e, 0xc3,
+	0x77, 0x1d, 0x08, 0xa5, 0x39, 0xb5, 0xf9, 0xcf, 0x93, 0xc3, 0xfa, 0xc8, 0xeb, 0xaa, 0x82, 0x74,
+	0x45, 0xb0, 0x17, 0x67, 0x7a, 0x3c, 0x1b, 0xad, 0xa9, 0xd7, 0x89, 0xbd, 0x0b, 0xc5, 0x50, 0x54,
+	0xdc, 0x3b, 0x94, 0xf9, 0xda, 0xe7, 0x79, 0x4f, 0x0d, 0x6a, 0xd0, 0x6b, 0x11, 0xc8, 0x8b, 0xe4,
+	0x45, 0xca, 0x60, 0xe3, 0xf7, 0x45, 0x11, 0x26, 0x47, 0xaf, 0xe1, 0x22, 0x23, 0xfa, 0x5f, 0x45,
+	0x1d, 0x81, 0x76, 0xc8, 0xae, 0x67, 0x31, 0x08, 0x1c, 0x4d, 0x97, 0x51, 0xa3, 0x41, 0x77, 0x2c,
+	0x93, 0x1a, 0x0e, 0x69, 0x52, 0xdf, 0x70, 0x1d, 0x23, 0xae, 0x4b, 0xb4, 0x5a, 0xd6, 0xed, 0x19,
+	0x78, 0x91, 0x08, 0x61, 0x2e, 0x33, 0x43, 0x77, 0x16, 0x81, 0xbd, 0x1d, 0xea, 0x6f, 0xba, 0x25,
+	0xc8, 0x32, 0x29, 0x47, 0x5f, 0x38, 0xd3, 0x91, 0xaa, 0x4e, 0xa8, 0xbf, 0xc3, 0x0d, 0x7c, 0x0d,
+	0xde, 0xea, 0x4d, 0x09, 0x45, 0x55, 0x85, 0x1d, 0xf8, 0x75, 0xac, 0x40, 0xbf, 0xa2, 0xde, 0x80,
+	0x30, 0x66, 0x58, 0x4e, 0x83, 0xee, 0x19, 0xb0, 0x93, 0xd7, 0x6d, 0xd7, 0xdc, 0xf6, 0xb5, 0x37,
+	0xf9, 0x91, 0x86, 0x4d, 0x83, 0x80, 0x61, 0x0e, 0xf0, 0x05, 0xcb, 0x99, 0xe2, 0x68, 0xda, 0x44,
+	0x2d, 0x43, 0xd2, 0xc4, 0x35, 0x4a, 0x47, 0xb1, 0x44, 0x13, 0xfa, 0x0f, 0xc8, 0x3e, 0x1d, 0x62,
+	0x6e, 0xd3, 0x86, 0xe1, 0xb8, 0xcc, 0xda, 0xb0, 0x4c, 0x12, 0xb5, 0x03, 0x1a, 0xbe, 0x56, 0xe7,
+	0xeb, 0xfb, 0x11, 0x4c, 0x77, 0xff, 0x6a, 0xc4, 0xb4, 0x28, 0xf0, 0xcc, 0xcd, 0xc0, 0x6c, 0xf7,
+	0x07, 0x52, 0xa4, 0x13, 0xea, 0x83, 0x51, 0x68, 0x97, 0xc1, 0xbc, 0x75, 0x28, 0x45, 0x3a, 0x87,
+	0xf5, 0x0a, 0x8d, 0x07, 0x47, 0xf5, 0x0a, 0x2b, 0xb0, 0x54, 0xa2, 0xe1, 0x23, 0xac, 0x5e, 0x65,
+	0x1e, 0xd9, 0xd8, 0xb0, 0x4c, 0xc3, 0xb4, 0x89, 0xef, 0x6b, 0x77, 0xf8, 0xb4, 0xde, 0x83, 0xf2,
+	0x35, 0x06, 0xa6, 0x81, 0xde, 0x09, 0x75, 0x14, 0x4d, 0xa8, 0x40, 0x4c, 0xfb, 0x26, 0x39, 0x56,
+	0xf4, 0x2d, 0xb5, 0x37, 0x9e, 0x62, 0x63, 0xc3, 0xb5, 0x1b, 0xd4, 0x33, 0x5a, 0x84, 0x6d, 0x69,
+	0x5f, 0xe0, 0xa7, 0xfe, 0xf9, 0x71, 0xa8, 0x0f, 0xce, 0xd0, 0x96, 0x47, 0x4d, 0xc2, 0x68, 0x63,
+	0x26, 0x62, 0x9c, 0xe5, 0x7c, 0x4b, 0x84, 0x6d, 0xb5, 0x43, 0x5d, 0xb9, 0x97, 0x16, 0xcb, 0x8d,
+	0x22, 0x7c, 0xd7, 0x6d, 0x5a, 0xb0, 0x48, 0x6c, 0xbf, 0xa6, 0x29, 0xf8, 0x7a, 0x09, 0x47, 0xdb,
+	0x6a, 0x8f, 0x4f, 0x99, 0x61, 0xbb, 0xbb, 0x46, 0xcb, 0xb3, 0x5c, 0xcf, 0x62, 0xfb, 0xda, 0x17,
+	0xf9, 0xa1, 0x98, 0x6c, 0x87, 0x7a, 0xb7, 0x4f, 0xd9, 0xbc, 0xbb, 0xbb, 0x14, 0x23, 0x69, 0x64,
+	0xcb, 0x93, 0x2b, 0xcb, 0xf2, 0x82, 0x38, 0xfa, 0x58, 0x51, 0xfb, 0xa1, 0xe9, 0x14, 0xbb, 0x69,
+	0xba, 0x8e, 0x19, 0x78, 0x1e, 0x75, 0xcc, 0x7d, 0x6d, 0x84, 0xcf, 0xa3, 0xcf, 0x7b, 0x1f, 0x64,
+	0x77, 0x81, 0xec, 0x45, 0x36, 0x4e, 0x67, 0x2c, 0x70, 0xe5, 0x37, 0x25, 0xf4, 0xf4, 0xca, 0x97,
+	0x81, 0xc9, 0x94, 0xf3, 0x66, 0x85, 0x5c, 0x2f, 0x96, 0x6a, 0x85, 0x1e, 0x71, 0xaf, 0xe9, 0x11,
+	0x7f, 0xab, 0x90, 0x92, 0xbf, 0xc5, 0x97, 0xe5, 0x87, 0x3c, 0x25, 0x9f, 0x4e, 0x52, 0x72, 0x33,
+	0x4e, 0xc9, 0x67, 0xa3, 0xbb, 0x19, 0xc4, 0xb2, 0xe4, 0x58, 0x1a, 0x86, 0x39, 0x4f, 0x39, 0xcd,
+	0xe6, 0x64, 0xd8, 0xcb, 0xd7, 0x4b, 0x4a, 0x20, 0x59, 0x37, 0xe3, 0x64, 0xbd, 0xfe, 0x3a, 0x6a,
+	0x20, 0x5d, 0x9f, 0x8e, 0xd2, 0xf5, 0x82, 0x32, 0xcf, 0x46, 0x7f, 0xa0, 0xa8, 0x03, 0x45, 0xf7,
+	0x92, 0x2e, 0xc9, 0x97, 0xf8, 0xfa, 0x5b, 0xd0, 0x7c, 0x98, 0xc6, 0x42, 0x83, 0x3f, 0xaf, 0xa5,
+	0xd8, 0xe0, 0x97, 0xa2, 0x55, 0x5b, 0x03, 0xfa, 0x0b, 0xa9, 0x6e, 0x2c, 0xd7, 0x8c, 0x7e, 0x4d,
+	0x51, 0xfb, 0x7d, 0x16, 0x38, 0x06, 0x64, 0x4e, 0xc4, 0xb6, 0x76, 0xa8, 0x11, 0xf5, 0x8e, 0x7c,
+	0xed, 0xed, 0x34, 0x1f, 0xed, 0x05, 0x8e, 0xe7, 0x09, 0xc3, 0x32, 0xe0, 0xcb, 0x69, 0x96, 0x24,
+	0xc1, 0xf2, 0xb9, 0xb5, 0x10, 0xd0, 0x2e, 0x3c, 0x78, 0x32, 0x86, 0x65, 0xda, 0xa0, 0x64, 0x2d,
+	0x98, 0x01, 0x71, 0xd5, 0xd7, 0xee, 0x72, 0x23, 0xde, 0x87, 0x44, 0x2d, 0x27, 0xb6, 0x60, 0x39,
+	0x59, 0x6a, 0x5f, 0x42, 0xc4, 0x1c, 0x31, 0x17, 0x50, 0xc7, 0xc7, 0x70, 0x59, 0x0f, 0x64, 0xe5,
+	0x5d, 0x7c, 0xf4, 0xe4, 0xdd, 0xe9, 0x1e, 0x8f, 0xa1, 0x0d, 0xe8, 0x74, 0x63, 0xb2, 0xbb, 0xcc,
+	0x02, 0xe1, 0xc5, 0xe9, 0x8a, 0x9f, 0x7d, 0xa6, 0xbd, 0xa1, 0x8c, 0x76, 0xe6, 0xab, 0x58, 0x41,
+	0x23, 0x16, 0xf5, 0xa1, 0x1d, 0xf5, 0x5a, 0x83, 0x30, 0xb2, 0x0e, 0x2d, 0xaa, 0xe8, 0x09, 0x50,
+	0x1b, 0x1d, 0x56, 0x46, 0xba, 0xc7, 0xbb, 0x93, 0xb4, 0x68, 0x85, 0x53, 0x79, 0x33, 0xaf, 0x3b,
+	0x61, 0x8d, 0x68, 0x69, 0xe4, 0xc8, 0x93, 0x6b, 0xc3, 0x1e, 0xe5, 0x4b, 0x1a, 0x6f, 0x8f, 0x0f,
+	0x8f, 0xea, 0x0a, 0x2e, 0x88, 0xa2, 0x1f, 0x9c, 0x57, 0xdf, 0x84, 0xa8, 0x91, 0x86, 0x0b, 0xa8,
+	0x29, 0x4d, 0xb7, 0x09, 0x5b, 0xd6, 0xa3, 0x2f, 0x03, 0xea, 0x33, 0x63, 0xdb, 0x5a, 0xd7, 0xee,
+	0xf3, 0xe5, 0xf8, 0x47, 0x25, 0x7e, 0x3a, 0x5c, 0x20, 0x7b, 0xd3, 0x73, 0x38, 0xc2, 0x9f, 0x5b,
+	0x53, 0xed, 0x50, 0xd7, 0x9b, 0x64, 0x2f, 0x3d, 0xe2, 0x6c, 0x2e, 0xd6, 0x91, 0xb1, 0xa4, 0xb7,
+	0xe0, 0x19, 0x7c, 0x42, 0x3d, 0x76, 0xa6, 0xca, 0xb3, 0x59, 0xe2, 0xc7, 0xc8, 0x82, 0xb9, 0xf8,
+	0x0c, 0xb1, 0x75, 0x78, 0xab, 0xeb, 0x4f, 0x5f, 0x44, 0x6c, 0x22, 0xbe, 0xa1, 0x8e, 0xf1, 0x03,
+	0xfc, 0x23, 0x98, 0x89, 0xbe, 0xe4, 0x45, 0x61, 0x7e, 0x72, 0x51, 0x7c, 0x46, 0xed, 0x23, 0x12,
+	0x7a, 0x9a, 0x48, 0xcb, 0x40, 0xd9, 0x43, 0x96, 0x54, 0x49, 0x05, 0x5d, 0x38, 0xfa, 0x52, 0xa3,
+	0x70, 0x26, 0x45, 0x84, 0x37, 0xd8, 0x1d, 0xf5, 0x16, 0x7f, 0xf4, 0xd8, 0x08, 0x6c, 0x3b, 0xce,
+	0x6a, 0x5c, 0x27, 0x29, 0x51, 0xb5, 0x07, 0xdc, 0xd3, 0xa7, 0x90, 0x35, 0x00, 0xd7, 0x6c, 0x60,
+	0xdb, 0x3c, 0x1f, 0x79, 0xe1, 0xc4, 0x45, 0x65, 0x27, 0xd4, 0x6f, 0xc7, 0x57, 0x96, 0x0c, 0xae,
+	0xe1, 0x0a, 0x39, 0xf4, 0xbe, 0x7a, 0x75, 0x83, 0x12, 0x16, 0x78, 0xd4, 0xd8, 0xb0, 0xc9, 0xa6,
+	0xaf, 0x8d, 0xf3, 0x73, 0x77, 0x07, 0x6e, 0xfa, 0x18, 0x98, 0x05, 0x7a, 0xfa, 0x40, 0x22, 0x10,
+	0x6b, 0x38, 0xc7, 0x82, 0x76, 0xd5, 0x01, 0xe1, 0x5d, 0x24, 0xaa, 0x71, 0xa8, 0xe3, 0x06, 0x9b,
+	0x5b, 0xda, 0x43, 0xbe, 0x69, 0xdf, 0xe5, 0xe1, 0x35, 0x65, 0x99, 0x07, 0x8e, 0x67, 0x9c, 0x21,
+	0xcd, 0x7a, 0xa4, 0x68, 0x9a, 0x51, 0xc8, 0x85, 0xd1, 0xb6, 0xda, 0x57, 0x1a, 0xb8, 0x49, 0xf6,
+	0xb4, 0x09, 0x3e, 0xea, 0x3b, 0x90, 0x0c, 0x16, 0x04, 0x17, 0xc8, 0x5e, 0x27, 0xd4, 0x35, 0xd9,
+	0x90, 0x0b, 0x64, 0x2f, 0x1d, 0x4f, 0x22, 0x86, 0xbe, 0x7b, 0x5e, 0xd5, 0x93, 0x66, 0x8f, 0x41,
+	0x6c, 0x48, 0x29, 0x5c, 0xbb, 0x61, 0x30, 0xdb, 0x37, 0x20, 0x7e, 0x58, 0xae, 0xe3, 0x6b, 0x8f,
+	0xf8, 0x7a, 0xfd, 0x18, 0x76, 0xe6, 0x60, 0xd2, 0x5a, 0x99, 0x04, 0xd6, 0x17, 0x76, 0x63, 0x65,
+	0x7e, 0xf9, 0x6b, 0x31, 0x5f, 0x3b, 0xd4, 0x07, 0xad, 0x6a, 0x38, 0xcd, 0x77, 0x4e, 0xe1, 0x81,
+	0xfd, 0x79, 0xaa, 0x8e, 0xd3, 0xe1, 0x83, 0xa3, 0xfa, 0x69, 0x06, 0xe2, 0xb2, 0xac, 0xed, 0x27,
+	0x20, 0x3a, 0x52, 0xd4, 0x41, 0x61, 0xde, 0x93, 0xc4, 0xca, 0x60, 0x66, 0x8b, 0x97, 0xb3, 0x8f,
+	0xf9, 0xf4, 0x7f, 0x1f, 0x66, 0x41, 0x9b, 0x4e, 0xf9, 0x92, 0x34, 0x69, 0x65, 0x7a, 0x69, 0x7e,
+	0x72, 0xb1, 0x1d, 0xea, 0x9a, 0x59, 0xc6, 0xcc, 0x56, 0x54, 0xf0, 0xbe, 0x5d, 0x58, 0xa1, 0x3c,
+	0xc3, 0x29, 0x49, 0xfb, 0xc1, 0x51, 0xbd, 0x72, 0x4c, 0x5c, 0x39, 0x22, 0xfa, 0x57, 0x45, 0xbd,
+	0x2d, 0x73, 0xe9, 0x65, 0x60, 0x99, 0xdc, 0xa7, 0x2f, 0x73, 0x9f, 0x7e, 0x00, 0x3e, 0xdd, 0x2c,
+	0xeb, 0xff, 0x60, 0x75, 0x6e, 0x3a, 0x72, 0xea, 0x66, 0x79, 0x88, 0x0f, 0x02, 0xcb, 0x8c, 0xbc,
+	0xba, 0x5b, 0xe1, 0x55, 0xcc, 0x71, 0xca, 0xd5, 0x79, 0x70, 0x54, 0xaf, 0x1e, 0x16, 0x57, 0x0f,
+	0x7a, 0xea, 0x5a, 0xed, 0x12, 0x47, 0x7b, 0x72, 0xd6, 0x5a, 0xad, 0x9d, 0xb2, 0x56, 0x6b, 0x67,
+	0xad, 0xd5, 0x1a, 0x71, 0xa4, 0xcf, 0x1c, 0xe9, 0xe3, 0x45, 0xe5, 0x98, 0xb8, 0x72, 0xc4, 0xd3,
+	0xd7, 0x0a, 0x7c, 0x7a, 0xe7, 0xcc, 0xb5, 0x5a, 0x3b, 0x6d, 0xad, 0xd6, 0xce, 0x5c, 0xab, 0xbc,
+	0x5b, 0x13, 0x39, 0xb7, 0x26, 0x4e, 0x59, 0xab, 0xb5, 0xea, 0xb5, 0x02, 0xc7, 0x0e, 0x14, 0xf5,
+	0xa6, 0xcc, 0x31, 0xfe, 0xda, 0xa8, 0x3d, 0xe5, 0x5e, 0x7d, 0x0d, 0x9a, 0x56, 0x65, 0x15, 0xfc,
+	0xa5, 0x32, 0xcb, 0x55, 0xe5, 0xb8, 0xd8, 0xb4, 0xca, 0xd9, 0xfc, 0x68, 0x0c, 0x57, 0xe9, 0x44,
+	0x7f, 0xab, 0xa8, 0x77, 0x64, 0x46, 0xa5, 0x1d, 0xcc, 0x2d, 0x8f, 0xfa, 0x5b, 0xae, 0xdd, 0xd0,
+	0x7e, 0x8a, 0x1b, 0xf8, 0x8d, 0x76, 0xa8, 0x4b, 0x0c, 0x88, 0xef, 0x9d, 0x95, 0x84, 0xbb, 0x13,
+	0xea, 0x13, 0x15, 0xb6, 0x16, 0x59, 0x05, 0xb3, 0x45, 0xab, 0x95, 0x31, 0xfc, 0x1a, 0xc2, 0xa8,
+	0xa1, 0xf6, 0x42, 0x76, 0x15, 0x5d, 0xad, 0xd9, 0xff, 0x0b, 0x7e, 0x9a, 0x1b, 0xfb, 0x08, 0xda,
+	0x9f, 0x4d, 0xb2, 0xc7, 0x2f, 0x47, 0xe1, 0x4f, 0x06, 0xfd, 0x49, 0x9e, 0x94, 0x03, 0xd2, 0xeb,
+	0xa1, 0x24, 0x82, 0x5e, 0xaa, 0x1a, 0xf3, 0x88, 0xe3, 0x6f, 0x50, 0x0f, 0x92, 0x78, 0xe6, 0x1b,
+	0x8d, 0xa0, 0xd9, 0x8a, 0x2a, 0xdd, 0xaf, 0xf0, 0x92, 0xea, 0x09, 0xdc, 0x81, 0x09, 0xcf, 0x32,
+	0xb0, 0xcc, 0x04, 0xcd, 0x16, 0x14, 0xa9, 0xe9, 0x1d, 0x28, 0x45, 0x6b, 0x58, 0x2e, 0x85, 0xde,
+	0x57, 0x55, 0xdb, 0xdd, 0x34, 0x6c, 0xba, 0x43, 0x6d, 0x5f, 0xfb, 0x99, 0xb4, 0xb5, 0x74, 0xd9,
+	0x76, 0x37, 0xe7, 0x39, 0xb1, 0x13, 0xea, 0xdd, 0xf1, 0x9f, 0x40, 0x22, 0x0a, 0x5c, 0x1a, 0x6f,
+	0x24, 0x1f, 0x38, 0x63, 0x44, 0x07, 0xe7, 0xf9, 0x4d, 0xca, 0x3c, 0xd7, 0xb6, 0xa9, 0x97, 0xb4,
+	0x93, 0xac, 0x86, 0xf6, 0xee, 0xb0, 0x32, 0xd2, 0x35, 0xf5, 0x13, 0x05, 0x7a, 0x81, 0xff, 0x1e,
+	0xea, 0x13, 0x9b, 0x16, 0xdb, 0x0a, 0xd6, 0x47, 0x4d, 0xb7, 0x79, 0x3f, 0xad, 0xca, 0x84, 0x5f,
+	0xf0, 0x67, 0x39, 0xfe, 0xaf, 0x38, 0xd3, 0xb5, 0x47, 0xa3, 0x06, 0xce, 0xdc, 0x0c, 0x24, 0xac,
+	0xd3, 0xa9, 0xf2, 0x84, 0x1a, 0x5f, 0xce, 0x05, 0x6a, 0x9a, 0xa2, 0x95, 0xa1, 0xda, 0xb0, 0xe3,
+	0x96, 0x52, 0x34, 0x99, 0x0a, 0x29, 0xf5, 0x7b, 0x47, 0x75, 0x05, 0x52, 0xd1, 0xb2, 0x21, 0x1f,
+	0x41, 0x52, 0x5e, 0x96, 0x68, 0xa0, 0x35, 0xb5, 0x47, 0x98, 0x13, 0xe6, 0x6e, 0x53, 0x47, 0xfb,
+	0x2a, 0x5f, 0xcb, 0xbb, 0xd0, 0xc1, 0xcb, 0xb0, 0x15, 0x80, 0x3a, 0xa1, 0x7e, 0xa3, 0x60, 0x39,
+	0xa7, 0xd7, 0x70, 0x91, 0x13, 0x05, 0xea, 0x4d, 0xfe, 0x74, 0xf4, 0x32, 0x20, 0x0e, 0x0b, 0x9a,
+	0xc6, 0x36, 0xdd, 0x37, 0xe8, 0x9e, 0xb9, 0x45, 0x9c, 0x4d, 0xaa, 0x4d, 0x66, 0x29, 0x1f, 0x30,
+	0x7d, 0x10, 0xf1, 0x3c, 0xa7, 0xfb, 0xcf, 0x62, 0x8e, 0x34, 0xe5, 0x93, 0xc3, 0x35, 0x5c, 0x21,
+	0x87, 0x7e, 0x4f, 0x51, 0x07, 0x79, 0xb7, 0xcc, 0x68, 0xb9, 0xae, 0x6d, 0xf8, 0xa6, 0x17, 0xac,
+	0x8b, 0x5d, 0xf1, 0x29, 0x7e, 0x24, 0x7e, 0x11, 0x02, 0x0c, 0x67, 0x5b, 0x72, 0x5d, 0x7b, 0x19,
+	0x98, 0xc4, 0xae, 0xf8, 0x28, 0x1f, 0xba, 0x02, 0xcf, 0xbd, 0xcb, 0x4f, 0x08, 0x4f, 0x3b, 0x27,
+	0x87, 0xf5, 0x4b, 0x11, 0x05, 0x57, 0xe9, 0x86, 0xff, 0x8f, 0x20, 0x9f, 0x11, 0xa7, 0xb1, 0xbe,
+	0x0f, 0x51, 0xa6, 0x49, 0xbc, 0x7d, 0xd8, 0x81, 0xd3, 0x7c, 0x07, 0xfe, 0xcb, 0xff, 0x77, 0x07,
+	0xf6, 0x2c, 0x47, 0xaa, 0x97, 0x22, 0xcd, 0x7c, 0xff, 0xf5, 0xf8, 0x05, 0x9a, 0x50, 0x58, 0xe7,
+	0x01, 0xe9, 0xde, 0x2b, 0x8b, 0x4b, 0x68, 0xf1, 0xbe, 0x2b, 0x0d, 0xcf, 0x77, 0x5d, 0x91, 0xbb,
+	0x81, 0xf6, 0xb2, 0x39, 0x60, 0x64, 0x9b, 0x42, 0x4b, 0xd4, 0xf0, 0xb5, 0x99, 0xb4, 0x12, 0x4f,
+	0x24, 0x56, 0x62, 0x50, 0x2c, 0xc4, 0xf3, 0x40, 0xee, 0xde, 0xcd, 0x35, 0x02, 0x1e, 0x8e, 0x8d,
+	0xe1, 0x92, 0x1e, 0xe4, 0xab, 0xc8, 0xa3, 0x3e, 0x73, 0x3d, 0x6a, 0xb4, 0xa0, 0x16, 0xd9, 0xb2,
+	0x1c, 0xe6, 0x6b, 0xcf, 0xf8, 0x6e, 0x7c, 0x06, 0x23, 0xc7, 0xe8, 0x52, 0x60, 0xdb, 0xef, 0x01,
+	0x96, 0x96, 0x93, 0x45, 0xa0, 0xb2, 0x5f, 0x56, 0x52, 0x81, 0xbe, 0xa3, 0xa8, 0x28, 0x70, 0x9a,
+	0x94, 0x51, 0x78, 0x1f, 0x72, 0x28, 0xdb, 0x75, 0xbd, 0x6d, 0x5f, 0x9b, 0xe5, 0xc1, 0x6c, 0x0d,
+	0x3a, 0x0f, 0x29, 0xba, 0x18, 0x83, 0x9d, 0x50, 0x1f, 0x8e, 0xfb, 0xa4, 0x79, 0x44, 0xe8, 0x05,
+	0xb6, 0x0f, 0xeb, 0xb7, 0xaa, 0x61, 0x5c, 0x56, 0x8a, 0x7e, 0x49, 0xed, 0x0a, 0x5a, 0x4e, 0x2b,
+	0xed, 0x11, 0xfd, 0xc9, 0x2c, 0x77, 0xfc, 0xe7, 0x8e, 0x43, 0xfd, 0x46, 0xd6, 0x9e, 0x5c, 0x5d,
+	0x72, 0x96, 0xb2, 0x86, 0x91, 0x72, 0x2f, 0x8d, 0xdc, 0x20, 0x1b, 0x03, 0x82, 0x19, 0x07, 0x47,
+	0x75, 0xb9, 0xb0, 0xa6, 0xe0, 0x2b, 0x82, 0x08, 0xfa, 0x23, 0x25, 0x1e, 0x3e, 0xf9, 0x83, 0xcc,
+	0xc7, 0xb3, 0x7c, 0xc5, 0x3f, 0xe4, 0x25, 0x6e, 0x5e, 0x45, 0xfa, 0x67, 0x19, 0xe5, 0x5e, 0x36,
+	0x15, 0x2d, 0xa7, 0x25, 0xfe, 0xc9, 0x45, 0xb0, 0x21, 0xab, 0xe5, 0x6f, 0x55, 0x73, 0x41, 0xcd,
+	0x2a, 0x1b, 0x45, 0x53, 0xb0, 0x9a, 0x49, 0xa1, 0xbf, 0x50, 0xd4, 0x6e, 0x6e, 0x66, 0xf6, 0x57,
+	0x98, 0x3f, 0x8d, 0x0c, 0xfd, 0x1e, 0x6f, 0x79, 0xe7, 0x55, 0x08, 0x7f, 0x8b, 0x51, 0xee, 0xa5,
+	0xdd, 0x1a, 0x90, 0xcf, 0xff, 0x91, 0x45, 0x6a, 0xec, 0xed, 0xd3, 0xf8, 0xa0, 0xb1, 0x2d, 0x1f,
+	0x4b, 0x53, 0x70, 0x97, 0x28, 0x99, 0x99, 0x9c, 0xfd, 0xe1, 0xe5, 0x87, 0xd5, 0x26, 0x0b, 0x7f,
+	0x7e, 0x29, 0x98, 0x9c, 0xff, 0xbb, 0x4a, 0xb5, 0xc9, 0x55, 0x7c, 0x65, 0x93, 0x13, 0xce, 0xc4,
+	0xe4, 0xe4, 0x1b, 0x6d, 0xa8, 0xd1, 0x1f, 0xeb, 0xd2, 0x8e, 0xd8, 0x9f, 0x45, 0xc7, 0xe1, 0xab,
+	0x79, 0x7b, 0x79, 0x76, 0x96, 0xb5, 0xc6, 0x84, 0xcd, 0xe8, 0x65, 0x48, 0xbe, 0x3f, 0xde, 0x25,
+	0x20, 0x3e, 0x7f, 0x8f, 0x2c, 0x3f, 0x05, 0x1a, 0x2d, 0x93, 0x69, 0x3f, 0x82, 0x29, 0x52, 0xa6,
+	0x16, 0x8e, 0x43, 0xfd, 0x76, 0x36, 0xe2, 0x42, 0xfe, 0x21, 0x6f, 0xc9, 0x64, 0xf9, 0x79, 0x6a,
+	0x96, 0xf0, 0xfc, 0xf0, 0xa8, 0xcc, 0x00, 0xed, 0xbf, 0xbe, 0x42, 0xf3, 0xcb, 0x37, 0x89, 0xe3,
+	0x6b, 0x7f, 0x1e, 0xad, 0xd2, 0x4a, 0xc1, 0x04, 0xb1, 0x69, 0xb4, 0x0c, 0x8c, 0x05, 0x13, 0x4a,
+	0x78, 0x79, 0xa9, 0xb8, 0x25, 0x25, 0xbe, 0xa9, 0xe7, 0x9f, 0x7c, 0x3a, 0x74, 0xee, 0xe8, 0xd3,
+	0xa1, 0x73, 0x9f, 0x1c, 0x0f, 0x29, 0x47, 0xc7, 0x43, 0xca, 0xf7, 0x5f, 0x0d, 0x9d, 0xfb, 0xe8,
+	0xd5, 0x90, 0x72, 0xf4, 0x6a, 0xe8, 0xdc, 0xbf, 0xbd, 0x1a, 0x3a, 0xf7, 0xf5, 0xb7, 0x5e, 0xe3,
+	0xea, 0x89, 0x3a, 0x85, 0xeb, 0x97, 0xf8, 0x15, 0xf4, 0xf0, 0xff, 0x06, 0x00, 0x4d, 0xe4, 0xcc,
+	0x44, 0x86, 0x30, 0x00, 0x00,
 }
 
 func (m *OptionsConfiguration) Marshal() (dAtA []byte, err error) {
@@ -458,6 +522,115 @@ func (m *OptionsConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xc0
 	}
+	if len(m.UnmeteredNetworks) > 0 {
+		for iNdEx := len(m.UnmeteredNetworks) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.UnmeteredNetworks[iNdEx])
+			copy(dAtA[i:], m.UnmeteredNetworks[iNdEx])
+			i = encodeVarintOptionsconfiguration(dAtA, i, uint64(len(m.UnmeteredNetworks[iNdEx])))
+			i--
+			dAtA[i] = 0x4
+			i--
+			dAtA[i] = 0xb2
+		}
+	}
+	if m.RestorePullHints {
+		i--
+		if m.RestorePullHints {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x4
+		i--
+		dAtA[i] = 0xa8
+	}
+	if m.StandbyTakeoverS != 0 {
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(m.StandbyTakeoverS))
+		i--
+		dAtA[i] = 0x4
+		i--
+		dAtA[i] = 0xa0
+	}
+	{
+		size := m.StandbyPrimaryID.ProtoSize()
+		i -= size
+		if _, err := m.StandbyPrimaryID.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x4
+	i--
+	dAtA[i] = 0x9a
+	if m.BlockPoolScrubIntervalS != 0 {
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(m.BlockPoolScrubIntervalS))
+		i--
+		dAtA[i] = 0x4
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.PostQuantumKeyExchange {
+		i--
+		if m.PostQuantumKeyExchange {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x4
+		i--
+		dAtA[i] = 0x88
+	}
+	if len(m.ControllerToken) > 0 {
+		i -= len(m.ControllerToken)
+		copy(dAtA[i:], m.ControllerToken)
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(len(m.ControllerToken)))
+		i--
+		dAtA[i] = 0x4
+		i--
+		dAtA[i] = 0x82
+	}
+	{
+		size := m.ControllerDeviceID.ProtoSize()
+		i -= size
+		if _, err := m.ControllerDeviceID.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x3
+	i--
+	dAtA[i] = 0xfa
+	if len(m.LogLevels) > 0 {
+		for iNdEx := len(m.LogLevels) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LogLevels[iNdEx])
+			copy(dAtA[i:], m.LogLevels[iNdEx])
+			i = encodeVarintOptionsconfiguration(dAtA, i, uint64(len(m.LogLevels[iNdEx])))
+			i--
+			dAtA[i] = 0x3
+			i--
+			dAtA[i] = 0xf2
+		}
+	}
+	if len(m.TransferStatsDumpPath) > 0 {
+		i -= len(m.TransferStatsDumpPath)
+		copy(dAtA[i:], m.TransferStatsDumpPath)
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(len(m.TransferStatsDumpPath)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xea
+	}
+	if m.MaxIndexSendKbps != 0 {
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(m.MaxIndexSendKbps))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xe0
+	}
 	if m.ConnectionPriorityUpgradeThreshold != 0 {
 		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(m.ConnectionPriorityUpgradeThreshold))
 		i--
@@ -1159,6 +1332,45 @@ func (m *OptionsConfiguration) ProtoSize() (n int) {
 	if m.ConnectionPriorityUpgradeThreshold != 0 {
 		n += 2 + sovOptionsconfiguration(uint64(m.ConnectionPriorityUpgradeThreshold))
 	}
+	if m.MaxIndexSendKbps != 0 {
+		n += 2 + sovOptionsconfiguration(uint64(m.MaxIndexSendKbps))
+	}
+	l = len(m.TransferStatsDumpPath)
+	if l > 0 {
+		n += 2 + l + sovOptionsconfiguration(uint64(l))
+	}
+	if len(m.LogLevels) > 0 {
+		for _, s := range m.LogLevels {
+			l = len(s)
+			n += 2 + l + sovOptionsconfiguration(uint64(l))
+		}
+	}
+	l = m.ControllerDeviceID.ProtoSize()
+	n += 2 + l + sovOptionsconfiguration(uint64(l))
+	l = len(m.ControllerToken)
+	if l > 0 {
+		n += 2 + l + sovOptionsconfiguration(uint64(l))
+	}
+	if m.PostQuantumKeyExchange {
+		n += 3
+	}
+	if m.BlockPoolScrubIntervalS != 0 {
+		n += 2 + sovOptionsconfiguration(uint64(m.BlockPoolScrubIntervalS))
+	}
+	l = m.StandbyPrimaryID.ProtoSize()
+	n += 2 + l + sovOptionsconfiguration(uint64(l))
+	if m.StandbyTakeoverS != 0 {
+		n += 2 + sovOptionsconfiguration(uint64(m.StandbyTakeoverS))
+	}
+	if m.RestorePullHints {
+		n += 3
+	}
+	if len(m.UnmeteredNetworks) > 0 {
+		for _, s := range m.UnmeteredNetworks {
+			l = len(s)
+			n += 2 + l + sovOptionsconfiguration(uint64(l))
+		}
+	}
 	if m.DeprecatedUPnPEnabled {
 		n += 4
 	}
@@ -2489,6 +2701,297 @@ func (m *OptionsConfiguration) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 60:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxIndexSendKbps", wireType)
+			}
+			m.MaxIndexSendKbps = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxIndexSendKbps |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 61:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferStatsDumpPath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TransferStatsDumpPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 62:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogLevels", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LogLevels = append(m.LogLevels, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 63:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ControllerDeviceID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ControllerDeviceID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 64:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ControllerToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ControllerToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 65:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PostQuantumKeyExchange", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PostQuantumKeyExchange = bool(v != 0)
+		case 66:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockPoolScrubIntervalS", wireType)
+			}
+			m.BlockPoolScrubIntervalS = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockPoolScrubIntervalS |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 67:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StandbyPrimaryID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.StandbyPrimaryID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 68:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StandbyTakeoverS", wireType)
+			}
+			m.StandbyTakeoverS = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StandbyTakeoverS |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 69:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RestorePullHints", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RestorePullHints = bool(v != 0)
+		case 70:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnmeteredNetworks", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UnmeteredNetworks = append(m.UnmeteredNetworks, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		case 9000:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedUPnPEnabled", wireType)
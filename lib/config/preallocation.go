@@ -0,0 +1,38 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+func (p Preallocation) String() string {
+	switch p {
+	case PreallocationSparse:
+		return "sparse"
+	case PreallocationFull:
+		return "full"
+	case PreallocationNone:
+		return "none"
+	default:
+		return "unknown"
+	}
+}
+
+func (p Preallocation) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Preallocation) UnmarshalText(bs []byte) error {
+	switch string(bs) {
+	case "sparse":
+		*p = PreallocationSparse
+	case "full":
+		*p = PreallocationFull
+	case "none":
+		*p = PreallocationNone
+	default:
+		*p = PreallocationSparse
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lib/config/preallocation.proto
+
+package config
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type Preallocation int32
+
+const (
+	PreallocationSparse Preallocation = 0
+	PreallocationFull   Preallocation = 1
+	PreallocationNone   Preallocation = 2
+)
+
+var Preallocation_name = map[int32]string{
+	0: "PREALLOCATION_SPARSE",
+	1: "PREALLOCATION_FULL",
+	2: "PREALLOCATION_NONE",
+}
+
+var Preallocation_value = map[string]int32{
+	"PREALLOCATION_SPARSE": 0,
+	"PREALLOCATION_FULL":   1,
+	"PREALLOCATION_NONE":   2,
+}
+
+func (Preallocation) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e1e46fbf76780eba, []int{0}
+}
+
+func init() {
+	proto.RegisterEnum("config.Preallocation", Preallocation_name, Preallocation_value)
+}
+
+func init() { proto.RegisterFile("lib/config/preallocation.proto", fileDescriptor_e1e46fbf76780eba) }
+
+var fileDescriptor_e1e46fbf76780eba = []byte{
+	// 241 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xcb, 0xc9, 0x4c, 0xd2,
+	0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0xd7, 0x2f, 0x28, 0x4a, 0x4d, 0xcc, 0xc9, 0xc9, 0x4f, 0x4e,
+	0x2c, 0xc9, 0xcc, 0xcf, 0xd3, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0xc8, 0x49, 0x29,
+	0x17, 0xa5, 0x16, 0xe4, 0x17, 0xeb, 0x83, 0x05, 0x93, 0x4a, 0xd3, 0xf4, 0xd3, 0xf3, 0xd3, 0xf3,
+	0xc1, 0x1c, 0x30, 0x0b, 0xa2, 0x58, 0x6b, 0x19, 0x23, 0x17, 0x6f, 0x00, 0xb2, 0x21, 0x42, 0x86,
+	0x5c, 0x22, 0x01, 0x41, 0xae, 0x8e, 0x3e, 0x3e, 0xfe, 0xce, 0x8e, 0x21, 0x9e, 0xfe, 0x7e, 0xf1,
+	0xc1, 0x01, 0x8e, 0x41, 0xc1, 0xae, 0x02, 0x0c, 0x52, 0xe2, 0x5d, 0x73, 0x15, 0x84, 0x51, 0x14,
+	0x07, 0x17, 0x24, 0x16, 0x15, 0xa7, 0x0a, 0xe9, 0x72, 0x09, 0xa1, 0x6a, 0x71, 0x0b, 0xf5, 0xf1,
+	0x11, 0x60, 0x94, 0x12, 0xed, 0x9a, 0xab, 0x20, 0x88, 0xa2, 0xc1, 0xad, 0x34, 0x27, 0x07, 0x53,
+	0xb9, 0x9f, 0xbf, 0x9f, 0xab, 0x00, 0x13, 0x16, 0xe5, 0x7e, 0xf9, 0x79, 0xa9, 0x52, 0x2c, 0x2b,
+	0x96, 0xc8, 0x31, 0x38, 0x79, 0x9f, 0x78, 0x28, 0xc7, 0x70, 0xe1, 0xa1, 0x1c, 0xc3, 0x89, 0x47,
+	0x72, 0x8c, 0x17, 0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0xb0, 0xe0, 0xb1, 0x1c, 0xe3, 0x85,
+	0xc7, 0x72, 0x0c, 0x37, 0x1e, 0xcb, 0x31, 0x44, 0x69, 0xa6, 0x67, 0x96, 0x64, 0x94, 0x26, 0xe9,
+	0x25, 0xe7, 0xe7, 0xea, 0x17, 0x57, 0xe6, 0x25, 0x97, 0x64, 0x64, 0xe6, 0xa5, 0x23, 0xb1, 0x10,
+	0xc1, 0x96, 0xc4, 0x06, 0xf6, 0xbc, 0x31, 0x60, 0x00, 0x33, 0xd1, 0xdb, 0xca, 0x4b, 0x01, 0x00,
+	0x00,
+}
@@ -0,0 +1,24 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+// The limit on concurrent incoming requests in the mobile profile, in KiB.
+const mobileMaxCIRequestKiB = 32 * 1024
+
+// ApplyMobileProfile adjusts the options and folder defaults for mobile and
+// embedded use: Small database caches, less data buffered for incoming
+// requests, one folder and hasher at a time, and no relaying. It's applied
+// to new configurations in builds with the "mobile" tag, and may be applied
+// by integrations at runtime.
+func (cfg *Configuration) ApplyMobileProfile() {
+	cfg.Options.DatabaseTuning = TuningSmall
+	cfg.Options.RawMaxCIRequestKiB = mobileMaxCIRequestKiB
+	cfg.Options.RawMaxFolderConcurrency = 1
+	cfg.Options.RelaysEnabled = false
+	cfg.Defaults.Folder.Hashers = 1
+	cfg.Defaults.Folder.Copiers = 1
+}
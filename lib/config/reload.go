@@ -0,0 +1,184 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// A ReloadPolicy decides how a config reloaded from disk is combined with
+// the running config.
+type ReloadPolicy int
+
+const (
+	// The reloaded config replaces the running config, dropping changes
+	// made at runtime that aren't in the file.
+	ReloadReplace ReloadPolicy = iota
+	// The reloaded config replaces the running config, except that folders
+	// and devices added at runtime are kept as long as the file doesn't
+	// know about them. Those removed from the file are removed.
+	ReloadMerge
+)
+
+func ParseReloadPolicy(s string) (ReloadPolicy, error) {
+	switch s {
+	case "replace":
+		return ReloadReplace, nil
+	case "merge":
+		return ReloadMerge, nil
+	default:
+		return 0, fmt.Errorf("unknown config reload policy %q", s)
+	}
+}
+
+func (p ReloadPolicy) String() string {
+	switch p {
+	case ReloadReplace:
+		return "replace"
+	case ReloadMerge:
+		return "merge"
+	default:
+		return fmt.Sprintf("unknown policy %d", int(p))
+	}
+}
+
+// The Reloader reloads the config of a wrapper from its file when asked to,
+// and, given a watch interval, when the file changes. The file is polled
+// rather than watched, as mounted config maps are replaced by swapping
+// symlinks, which notifications on the file don't catch.
+type Reloader struct {
+	cfg      Wrapper
+	policy   ReloadPolicy
+	interval time.Duration
+	trigger  chan struct{}
+
+	contents []byte        // as last read from the file
+	file     Configuration // as last read from the file
+}
+
+// NewReloader returns a reloader for the given config. A zero interval
+// disables watching the file.
+func NewReloader(cfg Wrapper, policy ReloadPolicy, interval time.Duration) *Reloader {
+	return &Reloader{
+		cfg:      cfg,
+		policy:   policy,
+		interval: interval,
+		trigger:  make(chan struct{}, 1),
+	}
+}
+
+func (r *Reloader) String() string {
+	return fmt.Sprintf("config.Reloader@%p", r)
+}
+
+// Reload schedules reloading the config from disk.
+func (r *Reloader) Reload() {
+	select {
+	case r.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (r *Reloader) Serve(ctx context.Context) error {
+	// The running config is what was in the file on startup.
+	r.contents, _ = os.ReadFile(r.cfg.ConfigPath())
+	r.file = r.cfg.RawCopy()
+
+	var tick <-chan time.Time
+	if r.interval > 0 {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-r.trigger:
+			if err := r.reload(true); err != nil {
+				l.Warnln("Failed to reload config:", err)
+			}
+		case <-tick:
+			if err := r.reload(false); err != nil {
+				l.Warnln("Failed to reload config:", err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// reload applies the config in the file, if it changed or if forced to.
+func (r *Reloader) reload(force bool) error {
+	contents, err := os.ReadFile(r.cfg.ConfigPath())
+	if err != nil {
+		return err
+	}
+	if !force && bytes.Equal(contents, r.contents) {
+		return nil
+	}
+	file, _, err := ReadXML(bytes.NewReader(contents), r.cfg.MyID())
+	if err != nil {
+		return err
+	}
+
+	prev := r.file
+	waiter, err := r.cfg.Modify(func(cfg *Configuration) {
+		*cfg = reloadedConfig(r.policy, *cfg, prev, file.Copy())
+	})
+	if err != nil {
+		return err
+	}
+	waiter.Wait()
+
+	// Saving the reloaded config changes the file again, but only to
+	// what is running already.
+	r.contents = contents
+	r.file = file
+	l.Infof("Reloaded config from %s (policy %v)", r.cfg.ConfigPath(), r.policy)
+	return nil
+}
+
+// reloadedConfig combines the running config and the one in the file
+// according to the policy. prev is what was in the file the last time, to
+// tell runtime additions from removals in the file.
+func reloadedConfig(policy ReloadPolicy, running, prev, file Configuration) Configuration {
+	if policy != ReloadMerge {
+		return file
+	}
+
+	inFile := make(map[string]bool, len(file.Folders))
+	for _, fcfg := range file.Folders {
+		inFile[fcfg.ID] = true
+	}
+	for _, fcfg := range prev.Folders {
+		inFile[fcfg.ID] = true
+	}
+	for _, fcfg := range running.Folders {
+		if !inFile[fcfg.ID] {
+			file.Folders = append(file.Folders, fcfg)
+		}
+	}
+
+	inFile = make(map[string]bool, len(file.Devices))
+	for _, dcfg := range file.Devices {
+		inFile[dcfg.DeviceID.String()] = true
+	}
+	for _, dcfg := range prev.Devices {
+		inFile[dcfg.DeviceID.String()] = true
+	}
+	for _, dcfg := range running.Devices {
+		if !inFile[dcfg.DeviceID.String()] {
+			file.Devices = append(file.Devices, dcfg)
+		}
+	}
+
+	return file
+}
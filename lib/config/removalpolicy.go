@@ -0,0 +1,38 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+func (p RemovalPolicy) String() string {
+	switch p {
+	case RemovalPolicyKeep:
+		return "keep"
+	case RemovalPolicyArchive:
+		return "archive"
+	case RemovalPolicyDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+func (p RemovalPolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *RemovalPolicy) UnmarshalText(bs []byte) error {
+	switch string(bs) {
+	case "keep":
+		*p = RemovalPolicyKeep
+	case "archive":
+		*p = RemovalPolicyArchive
+	case "delete":
+		*p = RemovalPolicyDelete
+	default:
+		*p = RemovalPolicyKeep
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lib/config/removalpolicy.proto
+
+package config
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type RemovalPolicy int32
+
+const (
+	RemovalPolicyKeep    RemovalPolicy = 0
+	RemovalPolicyArchive RemovalPolicy = 1
+	RemovalPolicyDelete  RemovalPolicy = 2
+)
+
+var RemovalPolicy_name = map[int32]string{
+	0: "REMOVAL_POLICY_KEEP",
+	1: "REMOVAL_POLICY_ARCHIVE",
+	2: "REMOVAL_POLICY_DELETE",
+}
+
+var RemovalPolicy_value = map[string]int32{
+	"REMOVAL_POLICY_KEEP":    0,
+	"REMOVAL_POLICY_ARCHIVE": 1,
+	"REMOVAL_POLICY_DELETE":  2,
+}
+
+func (RemovalPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_15276a0f424fac95, []int{0}
+}
+
+func init() {
+	proto.RegisterEnum("config.RemovalPolicy", RemovalPolicy_name, RemovalPolicy_value)
+}
+
+func init() { proto.RegisterFile("lib/config/removalpolicy.proto", fileDescriptor_15276a0f424fac95) }
+
+var fileDescriptor_15276a0f424fac95 = []byte{
+	// 261 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xcb, 0xc9, 0x4c, 0xd2,
+	0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0xd7, 0x2f, 0x4a, 0xcd, 0xcd, 0x2f, 0x4b, 0xcc, 0x29, 0xc8,
+	0xcf, 0xc9, 0x4c, 0xae, 0xd4, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0xc8, 0x49, 0x29,
+	0x17, 0xa5, 0x16, 0xe4, 0x17, 0xeb, 0x83, 0x05, 0x93, 0x4a, 0xd3, 0xf4, 0xd3, 0xf3, 0xd3, 0xf3,
+	0xc1, 0x1c, 0x30, 0x0b, 0xa2, 0x58, 0x6b, 0x3d, 0x23, 0x17, 0x6f, 0x10, 0xc4, 0x90, 0x00, 0xb0,
+	0x21, 0x42, 0x7a, 0x5c, 0xc2, 0x41, 0xae, 0xbe, 0xfe, 0x61, 0x8e, 0x3e, 0xf1, 0x01, 0xfe, 0x3e,
+	0x9e, 0xce, 0x91, 0xf1, 0xde, 0xae, 0xae, 0x01, 0x02, 0x0c, 0x52, 0xa2, 0x5d, 0x73, 0x15, 0x04,
+	0x51, 0xd4, 0x7a, 0xa7, 0xa6, 0x16, 0x08, 0x99, 0x70, 0x89, 0xa1, 0xa9, 0x77, 0x0c, 0x72, 0xf6,
+	0xf0, 0x0c, 0x73, 0x15, 0x60, 0x94, 0x92, 0xe8, 0x9a, 0xab, 0x20, 0x82, 0xa2, 0xc5, 0xb1, 0x28,
+	0x39, 0x23, 0xb3, 0x2c, 0x55, 0xc8, 0x88, 0x4b, 0x14, 0x4d, 0x97, 0x8b, 0xab, 0x8f, 0x6b, 0x88,
+	0xab, 0x00, 0x93, 0x94, 0x78, 0xd7, 0x5c, 0x05, 0x61, 0x14, 0x4d, 0x2e, 0xa9, 0x39, 0xa9, 0x25,
+	0xa9, 0x52, 0x2c, 0x2b, 0x96, 0xc8, 0x31, 0x38, 0x79, 0x9f, 0x78, 0x28, 0xc7, 0x70, 0xe1, 0xa1,
+	0x1c, 0xc3, 0x89, 0x47, 0x72, 0x8c, 0x17, 0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0xb0, 0xe0,
+	0xb1, 0x1c, 0xe3, 0x85, 0xc7, 0x72, 0x0c, 0x37, 0x1e, 0xcb, 0x31, 0x44, 0x69, 0xa6, 0x67, 0x96,
+	0x64, 0x94, 0x26, 0xe9, 0x25, 0xe7, 0xe7, 0xea, 0x17, 0x57, 0xe6, 0x25, 0x97, 0x64, 0x64, 0xe6,
+	0xa5, 0x23, 0xb1, 0x10, 0xe1, 0x97, 0xc4, 0x06, 0x0e, 0x05, 0x63, 0xc0, 0x00, 0x48, 0x6d, 0x61,
+	0x10, 0x54, 0x01, 0x00, 0x00,
+}
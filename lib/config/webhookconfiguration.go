@@ -0,0 +1,21 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+func (c WebhookConfiguration) Copy() WebhookConfiguration {
+	c.Events = append([]string(nil), c.Events...)
+	return c
+}
+
+func (c *WebhookConfiguration) prepare() {
+	if c.BatchIntervalS <= 0 {
+		c.BatchIntervalS = 10
+	}
+	if c.MaxBatchSize <= 0 {
+		c.MaxBatchSize = 100
+	}
+}
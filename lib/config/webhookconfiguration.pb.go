@@ -0,0 +1,560 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lib/config/webhookconfiguration.proto
+
+package config
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/syncthing/syncthing/proto/ext"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// A webhook delivers batches of events as JSON to an HTTP endpoint. When a
+// secret is set, the body is signed with HMAC-SHA256 and the signature sent
+// in the X-Syncthing-Signature header.
+type WebhookConfiguration struct {
+	ID             string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id" xml:"id,attr" nodefault:"true"`
+	URL            string   `protobuf:"bytes,2,opt,name=url,proto3" json:"url" xml:"url,attr"`
+	Secret         string   `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret" xml:"secret,omitempty"`
+	Events         []string `protobuf:"bytes,4,rep,name=events,proto3" json:"events" xml:"event"`
+	Paused         bool     `protobuf:"varint,5,opt,name=paused,proto3" json:"paused" xml:"paused,attr"`
+	BatchIntervalS int      `protobuf:"varint,6,opt,name=batch_interval_s,json=batchIntervalS,proto3,casttype=int" json:"batchIntervalS" xml:"batchIntervalS" default:"10"`
+	MaxBatchSize   int      `protobuf:"varint,7,opt,name=max_batch_size,json=maxBatchSize,proto3,casttype=int" json:"maxBatchSize" xml:"maxBatchSize" default:"100"`
+}
+
+func (m *WebhookConfiguration) Reset()         { *m = WebhookConfiguration{} }
+func (m *WebhookConfiguration) String() string { return proto.CompactTextString(m) }
+func (*WebhookConfiguration) ProtoMessage()    {}
+func (*WebhookConfiguration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4505edde0bb42548, []int{0}
+}
+func (m *WebhookConfiguration) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WebhookConfiguration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WebhookConfiguration.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WebhookConfiguration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookConfiguration.Merge(m, src)
+}
+func (m *WebhookConfiguration) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *WebhookConfiguration) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookConfiguration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookConfiguration proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*WebhookConfiguration)(nil), "config.WebhookConfiguration")
+}
+
+func init() {
+	proto.RegisterFile("lib/config/webhookconfiguration.proto", fileDescriptor_4505edde0bb42548)
+}
+
+var fileDescriptor_4505edde0bb42548 = []byte{
+	// 494 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x92, 0x3d, 0x6b, 0xdb, 0x40,
+	0x1c, 0xc6, 0x2d, 0x29, 0x56, 0x62, 0xa5, 0x98, 0x54, 0x94, 0x22, 0x3a, 0xe8, 0x54, 0xa1, 0x82,
+	0x0b, 0xc1, 0x2f, 0x74, 0x29, 0x1e, 0x95, 0x50, 0x30, 0xcd, 0x50, 0x64, 0x4a, 0xa1, 0x8b, 0xd1,
+	0xcb, 0xc5, 0x3a, 0xaa, 0x17, 0x23, 0x9d, 0x52, 0x27, 0x9f, 0xa2, 0xe4, 0x13, 0xe4, 0xe3, 0x64,
+	0xb3, 0xc6, 0x4e, 0x07, 0xb1, 0xa7, 0x6a, 0xd4, 0x98, 0xa9, 0xdc, 0x9d, 0x9a, 0xca, 0xd9, 0xfe,
+	0xcf, 0xf3, 0xbf, 0xe7, 0xf7, 0x70, 0xdc, 0x29, 0xef, 0x22, 0xe4, 0x8d, 0xfc, 0x34, 0xb9, 0x44,
+	0xcb, 0xd1, 0x4f, 0xe8, 0x85, 0x69, 0xfa, 0x83, 0xab, 0x22, 0x73, 0x31, 0x4a, 0x93, 0xe1, 0x2a,
+	0x4b, 0x71, 0xaa, 0xca, 0xdc, 0x7c, 0xd3, 0x83, 0x6b, 0xcc, 0x2d, 0xf3, 0xcf, 0x81, 0xf2, 0xea,
+	0x1b, 0x4f, 0x9c, 0xb5, 0x13, 0xea, 0x5c, 0x11, 0x51, 0xa0, 0x09, 0x86, 0x30, 0xe8, 0xd9, 0x67,
+	0x5b, 0x02, 0xc4, 0xd9, 0x79, 0x45, 0x80, 0x88, 0x82, 0x9a, 0x00, 0x7d, 0x1d, 0x47, 0x53, 0x13,
+	0x05, 0xa7, 0x2e, 0xc6, 0x99, 0x69, 0x24, 0x69, 0x00, 0x2f, 0xdd, 0x22, 0xc2, 0x53, 0x13, 0x67,
+	0x05, 0x34, 0xab, 0x8d, 0x75, 0xd8, 0x2c, 0x6f, 0x4b, 0x4b, 0x9c, 0x9d, 0xdf, 0x95, 0x96, 0xe0,
+	0x88, 0x28, 0x50, 0x2f, 0x14, 0xa9, 0xc8, 0x22, 0x4d, 0x64, 0xd4, 0xe9, 0x96, 0x00, 0xe9, 0xab,
+	0x73, 0x51, 0x11, 0x40, 0xdd, 0x9a, 0x80, 0x3e, 0xe3, 0x16, 0x59, 0xc4, 0xc1, 0xd5, 0xc6, 0x3a,
+	0xfa, 0x27, 0xea, 0x8d, 0x45, 0x0f, 0xdd, 0x96, 0x16, 0x8d, 0x38, 0x74, 0x56, 0xbf, 0x28, 0x72,
+	0x0e, 0xfd, 0x0c, 0x62, 0x4d, 0x62, 0xc0, 0x8f, 0x15, 0x01, 0x8d, 0x53, 0x13, 0xf0, 0x9a, 0xc1,
+	0xb8, 0x3c, 0x4d, 0x63, 0x84, 0x61, 0xbc, 0xc2, 0xd7, 0x14, 0x7a, 0xf2, 0xdc, 0x74, 0x9a, 0x94,
+	0x3a, 0x55, 0x64, 0x78, 0x05, 0x13, 0x9c, 0x6b, 0x07, 0x86, 0x34, 0xe8, 0xd9, 0x26, 0x25, 0x72,
+	0xa7, 0x26, 0xe0, 0x98, 0x11, 0x99, 0xa4, 0x98, 0x2e, 0x9b, 0x9c, 0x66, 0xaf, 0x7e, 0x52, 0xe4,
+	0x95, 0x5b, 0xe4, 0x30, 0xd0, 0xba, 0x86, 0x30, 0x38, 0xb2, 0x87, 0x34, 0xcb, 0x9d, 0x9a, 0x80,
+	0x97, 0x2c, 0xcb, 0xe5, 0xd3, 0xed, 0x8e, 0x5b, 0xda, 0x69, 0xce, 0xaa, 0x89, 0x72, 0xe2, 0xb9,
+	0xd8, 0x0f, 0x17, 0x28, 0xc1, 0x30, 0xbb, 0x72, 0xa3, 0x45, 0xae, 0xc9, 0x86, 0x30, 0xe8, 0xda,
+	0xf4, 0x01, 0xfa, 0x6c, 0x37, 0x6b, 0x56, 0xf3, 0x9a, 0x80, 0xb7, 0x8c, 0xbc, 0x6f, 0x9b, 0xc6,
+	0xd3, 0x8b, 0x4c, 0xc6, 0xe6, 0x23, 0x01, 0x12, 0x4a, 0xf0, 0xe3, 0xc6, 0x12, 0x27, 0x63, 0xe7,
+	0x19, 0x41, 0x0d, 0x95, 0x7e, 0xec, 0xae, 0x17, 0xbc, 0x33, 0x47, 0x37, 0x50, 0x3b, 0x64, 0x6d,
+	0x76, 0x45, 0xc0, 0x8b, 0xd8, 0x5d, 0xdb, 0x74, 0x31, 0x47, 0x37, 0xb0, 0x26, 0xc0, 0x60, 0x5d,
+	0x6d, 0x73, 0xaf, 0xa9, 0x5d, 0x25, 0x4d, 0xc6, 0x63, 0x67, 0x2f, 0x6f, 0x7f, 0xbe, 0x7f, 0xd0,
+	0x3b, 0xe5, 0x83, 0xde, 0xb9, 0xdf, 0xea, 0x42, 0xb9, 0xd5, 0x85, 0x5f, 0x3b, 0xbd, 0x73, 0xb7,
+	0xd3, 0x85, 0x72, 0xa7, 0x77, 0x7e, 0xef, 0xf4, 0xce, 0xf7, 0xf7, 0x4b, 0x84, 0xc3, 0xc2, 0x1b,
+	0xfa, 0x69, 0x3c, 0xca, 0xaf, 0x13, 0x1f, 0x87, 0x28, 0x59, 0xb6, 0xa6, 0xff, 0xdf, 0xdc, 0x93,
+	0xd9, 0xff, 0xfd, 0xf0, 0x77, 0x00, 0x7c, 0xfb, 0x5f, 0x05, 0xfb, 0x02, 0x00, 0x00,
+}
+
+func (m *WebhookConfiguration) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WebhookConfiguration) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WebhookConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxBatchSize != 0 {
+		i = encodeVarintWebhookconfiguration(dAtA, i, uint64(m.MaxBatchSize))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.BatchIntervalS != 0 {
+		i = encodeVarintWebhookconfiguration(dAtA, i, uint64(m.BatchIntervalS))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Paused {
+		i--
+		if m.Paused {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Events) > 0 {
+		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Events[iNdEx])
+			copy(dAtA[i:], m.Events[iNdEx])
+			i = encodeVarintWebhookconfiguration(dAtA, i, uint64(len(m.Events[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Secret) > 0 {
+		i -= len(m.Secret)
+		copy(dAtA[i:], m.Secret)
+		i = encodeVarintWebhookconfiguration(dAtA, i, uint64(len(m.Secret)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.URL) > 0 {
+		i -= len(m.URL)
+		copy(dAtA[i:], m.URL)
+		i = encodeVarintWebhookconfiguration(dAtA, i, uint64(len(m.URL)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintWebhookconfiguration(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintWebhookconfiguration(dAtA []byte, offset int, v uint64) int {
+	offset -= sovWebhookconfiguration(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *WebhookConfiguration) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovWebhookconfiguration(uint64(l))
+	}
+	l = len(m.URL)
+	if l > 0 {
+		n += 1 + l + sovWebhookconfiguration(uint64(l))
+	}
+	l = len(m.Secret)
+	if l > 0 {
+		n += 1 + l + sovWebhookconfiguration(uint64(l))
+	}
+	if len(m.Events) > 0 {
+		for _, s := range m.Events {
+			l = len(s)
+			n += 1 + l + sovWebhookconfiguration(uint64(l))
+		}
+	}
+	if m.Paused {
+		n += 2
+	}
+	if m.BatchIntervalS != 0 {
+		n += 1 + sovWebhookconfiguration(uint64(m.BatchIntervalS))
+	}
+	if m.MaxBatchSize != 0 {
+		n += 1 + sovWebhookconfiguration(uint64(m.MaxBatchSize))
+	}
+	return n
+}
+
+func sovWebhookconfiguration(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozWebhookconfiguration(x uint64) (n int) {
+	return sovWebhookconfiguration(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *WebhookConfiguration) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWebhookconfiguration
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WebhookConfiguration: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WebhookConfiguration: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field URL", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.URL = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Secret", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Secret = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Paused", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Paused = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchIntervalS", wireType)
+			}
+			m.BatchIntervalS = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BatchIntervalS |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBatchSize", wireType)
+			}
+			m.MaxBatchSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBatchSize |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWebhookconfiguration(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWebhookconfiguration
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipWebhookconfiguration(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowWebhookconfiguration
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWebhookconfiguration
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthWebhookconfiguration
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupWebhookconfiguration
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthWebhookconfiguration
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthWebhookconfiguration        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowWebhookconfiguration          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupWebhookconfiguration = fmt.Errorf("proto: unexpected end of group")
+)
@@ -10,6 +10,7 @@
 package config
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"os"
@@ -93,6 +94,10 @@ type Wrapper interface {
 	RequiresRestart() bool
 	Save() error
 
+	History() ([]HistoryEntry, error)
+	HistoryVersion(id int) (Configuration, error)
+	Rollback(id int) (Waiter, error)
+
 	Modify(ModifyFunction) (Waiter, error)
 	RemoveFolder(id string) (Waiter, error)
 	RemoveDevice(id protocol.DeviceID) (Waiter, error)
@@ -307,6 +312,7 @@ func (w *wrapper) replaceLocked(to Configuration) (Waiter, error) {
 	if err := to.prepare(w.myID); err != nil {
 		return noopWaiter{}, err
 	}
+	stampFolderMetadata(&from, &to)
 
 	for _, sub := range w.subs {
 		sub, ok := sub.(Verifier)
@@ -507,14 +513,23 @@ func (w *wrapper) Save() error {
 	w.mut.Lock()
 	defer w.mut.Unlock()
 
+	var buf bytes.Buffer
+	if err := w.cfg.WriteXML(osutil.LineEndingsWriter(&buf)); err != nil {
+		l.Debugln("WriteXML:", err)
+		return err
+	}
+
+	// The previous contents seed the history, if there is none yet.
+	prev, _ := os.ReadFile(w.path)
+
 	fd, err := osutil.CreateAtomic(w.path)
 	if err != nil {
 		l.Debugln("CreateAtomic:", err)
 		return err
 	}
 
-	if err := w.cfg.WriteXML(osutil.LineEndingsWriter(fd)); err != nil {
-		l.Debugln("WriteXML:", err)
+	if _, err := fd.Write(buf.Bytes()); err != nil {
+		l.Debugln("Write:", err)
 		fd.Close()
 		return err
 	}
@@ -524,6 +539,10 @@ func (w *wrapper) Save() error {
 		return err
 	}
 
+	if err := w.recordHistoryLocked(prev, buf.Bytes()); err != nil {
+		l.Warnln("Failed to record config history:", err)
+	}
+
 	w.evLogger.Log(events.ConfigSaved, w.cfg)
 	return nil
 }
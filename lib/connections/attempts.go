@@ -0,0 +1,104 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connections
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// dialAttemptsPerDevice is the number of recent dial attempts remembered
+// per device.
+const dialAttemptsPerDevice = 32
+
+// A DialAttempt is the outcome of dialing one address of a device.
+type DialAttempt struct {
+	When      time.Time `json:"when"`
+	Address   string    `json:"address"`
+	Mechanism string    `json:"mechanism"`
+	Priority  int       `json:"priority"`
+	DurationS float64   `json:"durationS"`
+	Error     *string   `json:"error"`
+}
+
+// dialAttemptLog keeps a ring buffer of the recent dial attempts of each
+// device.
+type dialAttemptLog struct {
+	mut      sync.Mutex
+	attempts map[protocol.DeviceID]*dialAttemptRing
+}
+
+type dialAttemptRing struct {
+	entries []DialAttempt
+	next    int // index the next attempt is stored at, once the ring is full
+}
+
+func newDialAttemptLog() *dialAttemptLog {
+	return &dialAttemptLog{
+		mut:      sync.NewMutex(),
+		attempts: make(map[protocol.DeviceID]*dialAttemptRing),
+	}
+}
+
+func (d *dialAttemptLog) record(device protocol.DeviceID, tgt dialTarget, started time.Time, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	attempt := DialAttempt{
+		When:      started.UTC().Truncate(time.Millisecond),
+		Address:   tgt.addr,
+		Priority:  tgt.priority,
+		DurationS: time.Since(started).Seconds(),
+	}
+	if tgt.uri != nil {
+		attempt.Mechanism = tgt.uri.Scheme
+	}
+	if err != nil {
+		errStr := err.Error()
+		attempt.Error = &errStr
+	}
+
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	ring, ok := d.attempts[device]
+	if !ok {
+		ring = &dialAttemptRing{}
+		d.attempts[device] = ring
+	}
+	if len(ring.entries) < dialAttemptsPerDevice {
+		ring.entries = append(ring.entries, attempt)
+		return
+	}
+	ring.entries[ring.next] = attempt
+	ring.next = (ring.next + 1) % dialAttemptsPerDevice
+}
+
+func (d *dialAttemptLog) forget(device protocol.DeviceID) {
+	d.mut.Lock()
+	delete(d.attempts, device)
+	d.mut.Unlock()
+}
+
+// DialAttempts returns the recent dial attempts of each device, oldest
+// first.
+func (d *dialAttemptLog) DialAttempts() map[protocol.DeviceID][]DialAttempt {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	res := make(map[protocol.DeviceID][]DialAttempt, len(d.attempts))
+	for device, ring := range d.attempts {
+		attempts := make([]DialAttempt, 0, len(ring.entries))
+		attempts = append(attempts, ring.entries[ring.next:]...)
+		attempts = append(attempts, ring.entries[:ring.next]...)
+		res[device] = attempts
+	}
+	return res
+}
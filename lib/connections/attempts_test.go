@@ -0,0 +1,56 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connections
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestDialAttemptLog(t *testing.T) {
+	d := newDialAttemptLog()
+	device := protocol.LocalDeviceID
+	uri, _ := url.Parse("tcp://192.0.2.42:22000")
+
+	for i := 0; i < dialAttemptsPerDevice+2; i++ {
+		tgt := dialTarget{addr: fmt.Sprintf("tcp://192.0.2.%d:22000", i), uri: uri, priority: i}
+		var err error
+		if i%2 == 0 {
+			err = errors.New("refused")
+		}
+		d.record(device, tgt, time.Now(), err)
+	}
+	d.record(device, dialTarget{addr: "canceled", uri: uri}, time.Now(), context.Canceled)
+
+	attempts := d.DialAttempts()[device]
+	if len(attempts) != dialAttemptsPerDevice {
+		t.Fatalf("expected %d attempts, got %d", dialAttemptsPerDevice, len(attempts))
+	}
+	// The two oldest attempts were overwritten, the rest is oldest first.
+	for i, attempt := range attempts {
+		if attempt.Priority != i+2 {
+			t.Fatalf("attempt %d has priority %d, expected %d", i, attempt.Priority, i+2)
+		}
+		if attempt.Mechanism != "tcp" {
+			t.Errorf("attempt %d has mechanism %q", i, attempt.Mechanism)
+		}
+		if failed := attempt.Error != nil; failed != (i%2 == 0) {
+			t.Errorf("attempt %d has unexpected error %v", i, attempt.Error)
+		}
+	}
+
+	d.forget(device)
+	if attempts := d.DialAttempts(); len(attempts) != 0 {
+		t.Errorf("expected no attempts after forgetting the device, got %v", attempts)
+	}
+}
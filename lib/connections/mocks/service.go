@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/syncthing/syncthing/lib/connections"
+	"github.com/syncthing/syncthing/lib/protocol"
 )
 
 type Service struct {
@@ -29,6 +30,16 @@ type Service struct {
 	connectionStatusReturnsOnCall map[int]struct {
 		result1 map[string]connections.ConnectionStatusEntry
 	}
+	DialAttemptsStub        func() map[protocol.DeviceID][]connections.DialAttempt
+	dialAttemptsMutex       sync.RWMutex
+	dialAttemptsArgsForCall []struct {
+	}
+	dialAttemptsReturns struct {
+		result1 map[protocol.DeviceID][]connections.DialAttempt
+	}
+	dialAttemptsReturnsOnCall map[int]struct {
+		result1 map[protocol.DeviceID][]connections.DialAttempt
+	}
 	ExternalAddressesStub        func() []string
 	externalAddressesMutex       sync.RWMutex
 	externalAddressesArgsForCall []struct {
@@ -180,6 +191,59 @@ func (fake *Service) ConnectionStatusReturnsOnCall(i int, result1 map[string]con
 	}{result1}
 }
 
+func (fake *Service) DialAttempts() map[protocol.DeviceID][]connections.DialAttempt {
+	fake.dialAttemptsMutex.Lock()
+	ret, specificReturn := fake.dialAttemptsReturnsOnCall[len(fake.dialAttemptsArgsForCall)]
+	fake.dialAttemptsArgsForCall = append(fake.dialAttemptsArgsForCall, struct {
+	}{})
+	stub := fake.DialAttemptsStub
+	fakeReturns := fake.dialAttemptsReturns
+	fake.recordInvocation("DialAttempts", []interface{}{})
+	fake.dialAttemptsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Service) DialAttemptsCallCount() int {
+	fake.dialAttemptsMutex.RLock()
+	defer fake.dialAttemptsMutex.RUnlock()
+	return len(fake.dialAttemptsArgsForCall)
+}
+
+func (fake *Service) DialAttemptsCalls(stub func() map[protocol.DeviceID][]connections.DialAttempt) {
+	fake.dialAttemptsMutex.Lock()
+	defer fake.dialAttemptsMutex.Unlock()
+	fake.DialAttemptsStub = stub
+}
+
+func (fake *Service) DialAttemptsReturns(result1 map[protocol.DeviceID][]connections.DialAttempt) {
+	fake.dialAttemptsMutex.Lock()
+	defer fake.dialAttemptsMutex.Unlock()
+	fake.DialAttemptsStub = nil
+	fake.dialAttemptsReturns = struct {
+		result1 map[protocol.DeviceID][]connections.DialAttempt
+	}{result1}
+}
+
+func (fake *Service) DialAttemptsReturnsOnCall(i int, result1 map[protocol.DeviceID][]connections.DialAttempt) {
+	fake.dialAttemptsMutex.Lock()
+	defer fake.dialAttemptsMutex.Unlock()
+	fake.DialAttemptsStub = nil
+	if fake.dialAttemptsReturnsOnCall == nil {
+		fake.dialAttemptsReturnsOnCall = make(map[int]struct {
+			result1 map[protocol.DeviceID][]connections.DialAttempt
+		})
+	}
+	fake.dialAttemptsReturnsOnCall[i] = struct {
+		result1 map[protocol.DeviceID][]connections.DialAttempt
+	}{result1}
+}
+
 func (fake *Service) ExternalAddresses() []string {
 	fake.externalAddressesMutex.Lock()
 	ret, specificReturn := fake.externalAddressesReturnsOnCall[len(fake.externalAddressesArgsForCall)]
@@ -407,6 +471,8 @@ func (fake *Service) Invocations() map[string][][]interface{} {
 	defer fake.allAddressesMutex.RUnlock()
 	fake.connectionStatusMutex.RLock()
 	defer fake.connectionStatusMutex.RUnlock()
+	fake.dialAttemptsMutex.RLock()
+	defer fake.dialAttemptsMutex.RUnlock()
 	fake.externalAddressesMutex.RLock()
 	defer fake.externalAddressesMutex.RUnlock()
 	fake.listenerStatusMutex.RLock()
@@ -163,6 +163,17 @@ func (t *relayListener) Error() error {
 	return nil
 }
 
+// NextAttempt returns when the relay pool may be looked up again, if the
+// lookups are backing off.
+func (t *relayListener) NextAttempt() time.Time {
+	t.mut.RLock()
+	defer t.mut.RUnlock()
+	if c, ok := t.client.(interface{ NextAttempt() time.Time }); ok {
+		return c.NextAttempt()
+	}
+	return time.Time{}
+}
+
 func (t *relayListener) Factory() listenerFactory {
 	return t.factory
 }
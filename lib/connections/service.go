@@ -125,13 +125,15 @@ type Service interface {
 	discover.AddressLister
 	ListenerStatus() map[string]ListenerStatusEntry
 	ConnectionStatus() map[string]ConnectionStatusEntry
+	DialAttempts() map[protocol.DeviceID][]DialAttempt
 	NATType() string
 }
 
 type ListenerStatusEntry struct {
-	Error        *string  `json:"error"`
-	LANAddresses []string `json:"lanAddresses"`
-	WANAddresses []string `json:"wanAddresses"`
+	Error        *string    `json:"error"`
+	LANAddresses []string   `json:"lanAddresses"`
+	WANAddresses []string   `json:"wanAddresses"`
+	NextAttempt  *time.Time `json:"nextAttempt,omitempty"`
 }
 
 type ConnectionStatusEntry struct {
@@ -166,6 +168,7 @@ type service struct {
 	registry             *registry.Registry
 	keyGen               *protocol.KeyGenerator
 	lanChecker           *lanChecker
+	dialAttempts         *dialAttemptLog
 
 	dialNow           chan struct{}
 	dialNowDevices    map[protocol.DeviceID]struct{}
@@ -197,6 +200,7 @@ func NewService(cfg config.Wrapper, myID protocol.DeviceID, mdl Model, tlsCfg *t
 		registry:             registry,
 		keyGen:               keyGen,
 		lanChecker:           &lanChecker{cfg},
+		dialAttempts:         newDialAttemptLog(),
 
 		dialNowDevicesMut: sync.NewMutex(),
 		dialNow:           make(chan struct{}, 1),
@@ -275,7 +279,13 @@ func (s *service) handleConns(ctx context.Context) error {
 
 		if err := s.connectionCheckEarly(remoteID, c); err != nil {
 			l.Infof("Connection from %s at %s (%s) rejected: %v", remoteID, c.RemoteAddr(), c.Type(), err)
-			c.Close()
+			if errors.Is(err, errConnLimitReached) {
+				// Tell the other side why, so it doesn't look like a
+				// network problem there.
+				go s.rejectWithClose(c, remoteID, &protocol.CloseError{Code: protocol.CloseReasonTooManyConnections, Err: err})
+			} else {
+				c.Close()
+			}
 			continue
 		}
 
@@ -290,6 +300,20 @@ func (s *service) handleConns(ctx context.Context) error {
 	}
 }
 
+// rejectWithClose exchanges hello messages and sends a close message with
+// the reason before closing the connection.
+func (s *service) rejectWithClose(c internalConn, remoteID protocol.DeviceID, reason error) {
+	defer c.Close()
+	_ = c.SetDeadline(time.Now().Add(20 * time.Second))
+	if _, err := protocol.ExchangeHello(c, s.model.GetHello(remoteID)); err != nil {
+		l.Debugf("Failed to exchange Hello messages with rejected %s at %s: %v", remoteID, c, err)
+		return
+	}
+	if err := protocol.WriteClose(c, reason); err != nil {
+		l.Debugf("Failed to send close to rejected %s at %s: %v", remoteID, c, err)
+	}
+}
+
 func (s *service) connectionCheckEarly(remoteID protocol.DeviceID, c internalConn) error {
 	if s.cfg.IgnoredDevice(remoteID) {
 		return errDeviceIgnored
@@ -804,6 +828,7 @@ func (s *service) CommitConfiguration(from, to config.Configuration) bool {
 			warningLimitersMut.Lock()
 			delete(warningLimiters, dev.DeviceID)
 			warningLimitersMut.Unlock()
+			s.dialAttempts.forget(dev.DeviceID)
 		}
 	}
 
@@ -939,6 +964,11 @@ func (s *service) ListenerStatus() map[string]ListenerStatusEntry {
 
 		status.LANAddresses = urlsToStrings(listener.LANAddresses())
 		status.WANAddresses = urlsToStrings(listener.WANAddresses())
+		if l, ok := listener.(interface{ NextAttempt() time.Time }); ok {
+			if next := l.NextAttempt(); !next.IsZero() {
+				status.NextAttempt = &next
+			}
+		}
 
 		result[addr] = status
 	}
@@ -984,6 +1014,10 @@ func (s *connectionStatusHandler) setConnectionStatus(address string, err error)
 	s.connectionStatusMut.Unlock()
 }
 
+func (s *service) DialAttempts() map[protocol.DeviceID][]DialAttempt {
+	return s.dialAttempts.DialAttempts()
+}
+
 func (s *service) NATType() string {
 	s.listenersMut.RLock()
 	defer s.listenersMut.RUnlock()
@@ -1111,12 +1145,14 @@ func (s *service) dialParallel(ctx context.Context, deviceID protocol.DeviceID,
 					wg.Done()
 					sema.Give(1)
 				}()
+				started := time.Now()
 				conn, err := tgt.Dial(ctx)
 				if err == nil {
 					// Closes the connection on error
 					err = s.validateIdentity(conn, deviceID)
 				}
 				s.setConnectionStatus(tgt.addr, err)
+				s.dialAttempts.record(deviceID, tgt, started, err)
 				if err != nil {
 					l.Debugln("dialing", deviceID, tgt.uri, "error:", err)
 				} else {
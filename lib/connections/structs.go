@@ -21,6 +21,7 @@ import (
 	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/stats"
+	"github.com/syncthing/syncthing/lib/tlsutil"
 
 	"github.com/thejerf/suture/v4"
 )
@@ -122,6 +123,10 @@ func (c internalConn) Crypto() string {
 	return fmt.Sprintf("%s-%s", tlsVersionNames[cs.Version], tlsCipherSuiteNames[cs.CipherSuite])
 }
 
+func (c internalConn) KeyExchange() string {
+	return tlsutil.KeyExchange(c.ConnectionState())
+}
+
 func (c internalConn) Transport() string {
 	transport := c.connType.Transport()
 	ip, err := osutil.IPFromAddr(c.LocalAddr())
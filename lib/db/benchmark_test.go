@@ -8,10 +8,14 @@ package db_test
 
 import (
 	"fmt"
+	"io/fs"
+	"path/filepath"
 	"testing"
 
 	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
 	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sha256"
 )
 
 var files, oneFile, firstHalf, secondHalf, changed100, unchanged100 []protocol.FileInfo
@@ -342,3 +346,62 @@ func BenchmarkNeedCount(b *testing.B) {
 
 	b.ReportAllocs()
 }
+
+// BenchmarkDeepTreeSize reports the size on disk of the index of a folder
+// with a deep directory tree, as known by us and another device, with files
+// changed by several devices.
+func BenchmarkDeepTreeSize(b *testing.B) {
+	const numFiles = 50000
+	var version protocol.Vector
+	for i := 0; i < 5; i++ {
+		version = version.Update(protocol.ShortID(i+1) << 56)
+	}
+	var files []protocol.FileInfo
+	for i := 0; i < numFiles; i++ {
+		name := fmt.Sprintf("projects/client%02d/src/main/java/org/example/module%03d/File%05d.java", i%20, i%300, i)
+		hash := sha256.Sum256([]byte(name))
+		files = append(files, protocol.FileInfo{
+			Name:      name,
+			Type:      protocol.FileInfoTypeFile,
+			Size:      1234,
+			ModifiedS: 1700000000 + int64(i),
+			Version:   version,
+			Blocks:    []protocol.BlockInfo{{Size: 1234, Hash: hash[:]}},
+		})
+	}
+
+	var size int64
+	for i := 0; i < b.N; i++ {
+		dir := b.TempDir()
+		be, err := backend.OpenLevelDB(dir, backend.TuningAuto)
+		if err != nil {
+			b.Fatal(err)
+		}
+		ldb := newLowlevel(b, be)
+		fset := newFileSet(b, "test", ldb)
+		for j := 0; j < numFiles; j += 1000 {
+			fset.Update(protocol.LocalDeviceID, files[j:j+1000])
+			fset.Update(remoteDevice0, files[j:j+1000])
+		}
+		if err := be.Compact(); err != nil {
+			b.Fatal(err)
+		}
+		ldb.Close()
+
+		size = 0
+		err = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+			if err != nil || !d.Type().IsRegular() {
+				return err
+			}
+			info, err := d.Info()
+			if err == nil {
+				size += info.Size()
+			}
+			return err
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(size)/numFiles, "bytes/file")
+}
@@ -0,0 +1,30 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package db
+
+const changeJournalKeyPrefix = "changeJournal/"
+
+// ChangeJournalState returns the state recorded for catching up with the
+// change journal of the folder, if any.
+func (db *Lowlevel) ChangeJournalState(folder string) ([]byte, bool, error) {
+	return NewMiscDataNamespace(db).Bytes(changeJournalKeyPrefix + folder)
+}
+
+// SetChangeJournalState records the state for catching up with the change
+// journal of the folder. It is dropped together with the folder.
+func (db *Lowlevel) SetChangeJournalState(folder string, state []byte) error {
+	return NewMiscDataNamespace(db).PutBytes(changeJournalKeyPrefix+folder, state)
+}
+
+// ClearChangeJournalState removes the state recorded for the folder.
+func (db *Lowlevel) ClearChangeJournalState(folder string) error {
+	return db.dropChangeJournalState([]byte(folder))
+}
+
+func (db *Lowlevel) dropChangeJournalState(folder []byte) error {
+	return NewMiscDataNamespace(db).Delete(changeJournalKeyPrefix + string(folder))
+}
@@ -9,8 +9,10 @@ package db
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/syncthing/syncthing/lib/db/backend"
 	"github.com/syncthing/syncthing/lib/events"
@@ -807,6 +809,77 @@ func TestUpdateTo14(t *testing.T) {
 	}
 }
 
+func TestVersionIndirection(t *testing.T) {
+	db := newLowlevelMemory(t)
+	defer db.Close()
+
+	// Files changed by the same devices share the version vector, which
+	// is stored once.
+	var version protocol.Vector
+	for i := 0; i <= versionIndirectionCutoff; i++ {
+		version = version.Update(protocol.ShortID(i+1) << 56)
+	}
+	folder := []byte("default")
+	trans, err := db.newReadWriteTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer trans.close()
+	var keys [][]byte
+	for _, name := range []string{"foo", "bar"} {
+		file := protocol.FileInfo{Name: name, Version: version}
+		key, err := db.keyer.GenerateDeviceFileKey(nil, folder, protocol.LocalDeviceID[:], []byte(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := trans.putFile(key, file); err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+	}
+	inline := len(mustMarshal(&protocol.FileInfo{Name: "foo", Version: version}))
+	if err := trans.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	it, err := db.NewPrefixIterator([]byte{KeyTypeVersion})
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions := 0
+	for it.Next() {
+		versions++
+	}
+	it.Release()
+	if versions != 1 {
+		t.Errorf("expected one stored version vector, got %d", versions)
+	}
+
+	ro, err := db.newReadOnlyTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ro.close()
+	for _, key := range keys {
+		bs, err := ro.Get(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(bs) >= inline {
+			t.Errorf("stored file not smaller, %d >= %d bytes", len(bs), inline)
+		}
+		f, ok, err := ro.getFileByKey(key)
+		if err != nil {
+			t.Fatal(err)
+		} else if !ok {
+			t.Fatal("file missing")
+		}
+		if !f.Version.Equal(version) {
+			t.Errorf("got version %v, expected %v", f.Version, version)
+		}
+	}
+}
+
 func TestFlushRecursion(t *testing.T) {
 	// Verify that a commit hook can write to the transaction without
 	// causing another flush and thus recursion.
@@ -1009,3 +1082,81 @@ func numBlockLists(db *Lowlevel) (int, error) {
 	}
 	return n, nil
 }
+
+func TestFolderSize(t *testing.T) {
+	db := newLowlevelMemory(t)
+	defer db.Close()
+
+	a := newFileSet(t, "a", db)
+	newFileSet(t, "b", db)
+
+	var files []protocol.FileInfo
+	for i := 0; i < 10; i++ {
+		files = append(files, protocol.FileInfo{
+			Name:    fmt.Sprintf("file%d", i),
+			Type:    protocol.FileInfoTypeFile,
+			Size:    6,
+			Version: protocol.Vector{}.Update(myID),
+			Blocks:  genBlocks(4),
+		})
+	}
+	a.Update(protocol.LocalDeviceID, files)
+
+	size, err := db.FolderSize("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size.Files.Keys != 10 || size.Globals.Keys != 10 || size.Sequences.Keys != 10 {
+		t.Errorf("unexpected key counts: %+v", size)
+	}
+	if size.Blocks.Keys != 40 {
+		t.Errorf("expected 40 block map keys, got %d", size.Blocks.Keys)
+	}
+	if size.Total.Bytes == 0 || size.Total.Keys < 70 {
+		t.Errorf("unexpected total: %+v", size.Total)
+	}
+
+	other, err := db.FolderSize("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Files.Keys != 0 || other.Globals.Keys != 0 {
+		t.Errorf("files of folder a counted for folder b: %+v", other)
+	}
+
+	// Record a sample and pretend it was taken two days ago, with half the
+	// size.
+
+	if err := db.recordFolderSize("a"); err != nil {
+		t.Fatal(err)
+	}
+	history, err := db.folderSizeHistory("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].Bytes != size.Total.Bytes {
+		t.Fatalf("unexpected history: %+v", history)
+	}
+	history[0].Time = time.Now().Add(-48 * time.Hour)
+	history[0].Bytes = size.Total.Bytes / 2
+	bs, _ := json.Marshal(history)
+	if err := NewMiscDataNamespace(db).PutBytes(sizeHistoryKeyPrefix+"a", bs); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := db.FolderSizeReport("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Total != size.Total {
+		t.Errorf("report total %+v != %+v", report.Total, size.Total)
+	}
+	if exp := (size.Total.Bytes - size.Total.Bytes/2) / 2; report.GrowthPerDay < exp-1 || report.GrowthPerDay > exp {
+		t.Errorf("expected growth of about %d bytes/day, got %d", exp, report.GrowthPerDay)
+	}
+
+	DropFolder(db, "a")
+	if history, err := db.folderSizeHistory("a"); err != nil || len(history) != 0 {
+		t.Errorf("size history not dropped with folder: %v, %v", history, err)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/db/backend"
+)
+
+const (
+	sizeSampleInterval   = 24 * time.Hour
+	sizeSampleTimeKey    = "lastSizeSampleTime"
+	sizeHistoryKeyPrefix = "folderSizeHistory/"
+	sizeHistoryLength    = 30
+)
+
+// KeySpace is the number of keys of some kind and the space they take up.
+// Bytes is the sum of key and value lengths, before any compression done by
+// the database backend.
+type KeySpace struct {
+	Keys  int64 `json:"keys"`
+	Bytes int64 `json:"bytes"`
+}
+
+func (s *KeySpace) add(o KeySpace) {
+	s.Keys += o.Keys
+	s.Bytes += o.Bytes
+}
+
+// FolderSize is the space taken up by a folder in the database, by kind of
+// key.
+type FolderSize struct {
+	Files      KeySpace `json:"files"`
+	Globals    KeySpace `json:"globals"`
+	Sequences  KeySpace `json:"sequences"`
+	Needs      KeySpace `json:"needs"`
+	Blocks     KeySpace `json:"blocks"`
+	BlockLists KeySpace `json:"blockLists"`
+	Mtimes     KeySpace `json:"mtimes"`
+	Meta       KeySpace `json:"meta"`
+	Total      KeySpace `json:"total"`
+}
+
+// FolderSizeSample is the total size of a folder in the database at some
+// point in time.
+type FolderSizeSample struct {
+	Time time.Time `json:"time"`
+	KeySpace
+}
+
+// FolderSizeReport is the current size of a folder in the database, the
+// samples taken of it over the last days and the resulting growth.
+type FolderSizeReport struct {
+	FolderSize
+	History []FolderSizeSample `json:"history"`
+	// GrowthPerDay is the average number of bytes per day the folder has
+	// grown by since the oldest sample, negative when it has shrunk.
+	GrowthPerDay int64 `json:"growthPerDay"`
+}
+
+// FolderSize returns the space currently taken up by the given folder.
+func (db *Lowlevel) FolderSize(folder string) (FolderSize, error) {
+	var size FolderSize
+
+	t, err := db.newReadOnlyTransaction()
+	if err != nil {
+		return size, err
+	}
+	defer t.close()
+
+	folderBs := []byte(folder)
+	k0, err := db.keyer.GenerateDeviceFileKey(nil, folderBs, nil, nil)
+	if err != nil {
+		return size, err
+	}
+	if size.Files, err = prefixSpace(t, k0.WithoutNameAndDevice()); err != nil {
+		return size, err
+	}
+	k1, err := db.keyer.GenerateSequenceKey(nil, folderBs, 0)
+	if err != nil {
+		return size, err
+	}
+	if size.Sequences, err = prefixSpace(t, k1.WithoutSequence()); err != nil {
+		return size, err
+	}
+	k2, err := db.keyer.GenerateGlobalVersionKey(nil, folderBs, nil)
+	if err != nil {
+		return size, err
+	}
+	if size.Globals, err = prefixSpace(t, k2.WithoutName()); err != nil {
+		return size, err
+	}
+	k3, err := db.keyer.GenerateNeedFileKey(nil, folderBs, nil)
+	if err != nil {
+		return size, err
+	}
+	if size.Needs, err = prefixSpace(t, k3.WithoutName()); err != nil {
+		return size, err
+	}
+	k4, err := db.keyer.GenerateBlockMapKey(nil, folderBs, nil, nil)
+	if err != nil {
+		return size, err
+	}
+	if size.Blocks, err = prefixSpace(t, k4.WithoutHashAndName()); err != nil {
+		return size, err
+	}
+	k5, err := db.keyer.GenerateBlockListMapKey(nil, folderBs, nil, nil)
+	if err != nil {
+		return size, err
+	}
+	if size.BlockLists, err = prefixSpace(t, k5.WithoutHashAndName()); err != nil {
+		return size, err
+	}
+	k6, err := db.keyer.GenerateMtimesKey(nil, folderBs)
+	if err != nil {
+		return size, err
+	}
+	if size.Mtimes, err = prefixSpace(t, k6); err != nil {
+		return size, err
+	}
+	k7, err := db.keyer.GenerateFolderMetaKey(nil, folderBs)
+	if err != nil {
+		return size, err
+	}
+	if size.Meta, err = prefixSpace(t, k7); err != nil {
+		return size, err
+	}
+
+	for _, s := range []KeySpace{size.Files, size.Globals, size.Sequences, size.Needs, size.Blocks, size.BlockLists, size.Mtimes, size.Meta} {
+		size.Total.add(s)
+	}
+	return size, nil
+}
+
+func prefixSpace(t readOnlyTransaction, prefix []byte) (KeySpace, error) {
+	var s KeySpace
+	it, err := t.NewPrefixIterator(prefix)
+	if err != nil {
+		return s, err
+	}
+	defer it.Release()
+	for it.Next() {
+		s.Keys++
+		s.Bytes += int64(len(it.Key()) + len(it.Value()))
+	}
+	return s, it.Error()
+}
+
+// FolderSizeReport returns the current size of the given folder together
+// with its size history.
+func (db *Lowlevel) FolderSizeReport(folder string) (FolderSizeReport, error) {
+	size, err := db.FolderSize(folder)
+	if err != nil {
+		return FolderSizeReport{}, err
+	}
+	history, err := db.folderSizeHistory(folder)
+	if err != nil {
+		return FolderSizeReport{}, err
+	}
+	report := FolderSizeReport{
+		FolderSize: size,
+		History:    history,
+	}
+	if len(history) > 0 {
+		if days := time.Since(history[0].Time).Hours() / 24; days >= 1 {
+			report.GrowthPerDay = int64(float64(size.Total.Bytes-history[0].Bytes) / days)
+		}
+	}
+	return report, nil
+}
+
+func (db *Lowlevel) folderSizeHistory(folder string) ([]FolderSizeSample, error) {
+	bs, ok, err := NewMiscDataNamespace(db).Bytes(sizeHistoryKeyPrefix + folder)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var history []FolderSizeSample
+	if err := json.Unmarshal(bs, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// recordFolderSize appends a sample of the current size of the folder to
+// its history, keeping at most sizeHistoryLength samples.
+func (db *Lowlevel) recordFolderSize(folder string) error {
+	size, err := db.FolderSize(folder)
+	if err != nil {
+		return err
+	}
+	history, err := db.folderSizeHistory(folder)
+	if err != nil {
+		// Start over rather than never recording anything again.
+		l.Debugf("Discarding size history of folder %v: %v", folder, err)
+		history = nil
+	}
+	history = append(history, FolderSizeSample{Time: time.Now().Truncate(time.Second), KeySpace: size.Total})
+	if len(history) > sizeHistoryLength {
+		history = history[len(history)-sizeHistoryLength:]
+	}
+	bs, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	return NewMiscDataNamespace(db).PutBytes(sizeHistoryKeyPrefix+folder, bs)
+}
+
+func (db *Lowlevel) dropFolderSizeHistory(folder []byte) error {
+	return NewMiscDataNamespace(db).Delete(sizeHistoryKeyPrefix + string(folder))
+}
+
+func (db *Lowlevel) runSizeSample(context.Context) error {
+	for _, folder := range db.ListFolders() {
+		if err := db.recordFolderSize(folder); backend.IsClosed(err) {
+			return nil
+		} else if err != nil {
+			l.Debugf("Recording database size of folder %v: %v", folder, err)
+		}
+	}
+	db.recordTime(sizeSampleTimeKey)
+	return nil
+}
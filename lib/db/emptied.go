@@ -0,0 +1,31 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package db
+
+const emptiedConfirmedKeyPrefix = "emptiedConfirmed/"
+
+// EmptiedConfirmed returns whether the user confirmed that the folder was
+// emptied on purpose.
+func (db *Lowlevel) EmptiedConfirmed(folder string) (bool, error) {
+	ok, _, err := NewMiscDataNamespace(db).Bool(emptiedConfirmedKeyPrefix + folder)
+	return ok, err
+}
+
+// SetEmptiedConfirmed records that the user confirmed that the folder was
+// emptied on purpose. It is dropped together with the folder.
+func (db *Lowlevel) SetEmptiedConfirmed(folder string) error {
+	return NewMiscDataNamespace(db).PutBool(emptiedConfirmedKeyPrefix+folder, true)
+}
+
+// ClearEmptiedConfirmed removes the confirmation recorded for the folder.
+func (db *Lowlevel) ClearEmptiedConfirmed(folder string) error {
+	return db.dropEmptiedConfirmed([]byte(folder))
+}
+
+func (db *Lowlevel) dropEmptiedConfirmed(folder []byte) error {
+	return NewMiscDataNamespace(db).Delete(emptiedConfirmedKeyPrefix + string(folder))
+}
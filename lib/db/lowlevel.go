@@ -21,6 +21,7 @@ import (
 	"github.com/syncthing/syncthing/lib/db/backend"
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/jobs"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/sha256"
 	"github.com/syncthing/syncthing/lib/stringutil"
@@ -48,6 +49,13 @@ const (
 
 	recheckDefaultInterval = 30 * 24 * time.Hour
 
+	// Database maintenance jobs don't run at the same time as each other.
+	jobClassDatabase = "database"
+	// Even if maintenance is due, give the system a while to get up and
+	// running and do other stuff first. (We might have migrations and
+	// stuff which would be better off running before GC.)
+	minJobStartDelay = time.Minute
+
 	needsRepairSuffix = ".needsrepair"
 )
 
@@ -68,6 +76,7 @@ type Lowlevel struct {
 	recheckInterval    time.Duration
 	oneFileSetCreated  chan struct{}
 	evLogger           events.Logger
+	jobs               jobs.Scheduler
 
 	blockFilter   *bloomFilter
 	versionFilter *bloomFilter
@@ -91,7 +100,11 @@ func NewLowlevel(backend backend.Backend, evLogger events.Logger, opts ...Option
 		opt(db)
 	}
 	db.keyer = newDefaultKeyer(db.folderIdx, db.deviceIdx)
-	db.Add(svcutil.AsService(db.gcRunner, "db.Lowlevel/gcRunner"))
+	if db.jobs == nil {
+		db.jobs = jobs.New()
+		db.Add(db.jobs)
+	}
+	db.scheduleJobs()
 	if path := db.needsRepairPath(); path != "" {
 		if _, err := os.Lstat(path); err == nil {
 			l.Infoln("Database was marked for repair - this may take a while")
@@ -126,6 +139,14 @@ func WithIndirectGCInterval(dur time.Duration) Option {
 	}
 }
 
+// WithScheduler sets the scheduler to run the maintenance jobs with, instead
+// of one of our own.
+func WithScheduler(s jobs.Scheduler) Option {
+	return func(db *Lowlevel) {
+		db.jobs = s
+	}
+}
+
 // ListFolders returns the list of folders currently in the database
 func (db *Lowlevel) ListFolders() []string {
 	return db.folderIdx.Values()
@@ -217,6 +238,16 @@ func (db *Lowlevel) updateLocalFiles(folder []byte, fs []protocol.FileInfo, meta
 			return err
 		}
 		if ok && unchanged(f, ef) {
+			if f.InodeChangeNs != ef.InodeChangeNs || f.Inode != ef.Inode || f.InodeGeneration != ef.InodeGeneration {
+				// Only the host-local inode details changed, which
+				// doesn't warrant a new sequence.
+				f.Sequence = ef.Sequence
+				l.Debugf("updating inode (local); folder=%q %v", folder, f)
+				if err := t.putFile(dk, f); err != nil {
+					return err
+				}
+				continue
+			}
 			l.Debugf("not inserting unchanged (local); folder=%q %v", folder, f)
 			continue
 		}
@@ -694,31 +725,38 @@ func (db *Lowlevel) dropPrefix(prefix []byte) error {
 	return t.Commit()
 }
 
-func (db *Lowlevel) gcRunner(ctx context.Context) error {
-	// Calculate the time for the next GC run. Even if we should run GC
-	// directly, give the system a while to get up and running and do other
-	// stuff first. (We might have migrations and stuff which would be
-	// better off running before GC.)
-	next := db.timeUntil(indirectGCTimeKey, db.indirectGCInterval)
-	if next < time.Minute {
-		next = time.Minute
-	}
+// scheduleJobs schedules the periodic database maintenance.
+func (db *Lowlevel) scheduleJobs() {
+	db.jobs.Add(jobs.Job{
+		Name:     "database/gc",
+		Class:    jobClassDatabase,
+		First:    jobStartDelay(db.timeUntil(indirectGCTimeKey, db.indirectGCInterval)),
+		Interval: func() time.Duration { return db.indirectGCInterval },
+		Run:      db.runIndirectGC,
+	})
+	db.jobs.Add(jobs.Job{
+		Name:     "database/size",
+		Class:    jobClassDatabase,
+		First:    jobStartDelay(db.timeUntil(sizeSampleTimeKey, sizeSampleInterval)),
+		Interval: func() time.Duration { return sizeSampleInterval },
+		Run:      db.runSizeSample,
+	})
+}
 
-	t := time.NewTimer(next)
-	defer t.Stop()
+func jobStartDelay(next time.Duration) time.Duration {
+	if next < minJobStartDelay {
+		return minJobStartDelay
+	}
+	return next
+}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-t.C:
-			if err := db.gcIndirect(ctx); err != nil {
-				l.Warnln("Database indirection GC failed:", err)
-			}
-			db.recordTime(indirectGCTimeKey)
-			t.Reset(db.timeUntil(indirectGCTimeKey, db.indirectGCInterval))
-		}
+func (db *Lowlevel) runIndirectGC(ctx context.Context) error {
+	err := db.gcIndirect(ctx)
+	if err != nil {
+		l.Warnln("Database indirection GC failed:", err)
 	}
+	db.recordTime(indirectGCTimeKey)
+	return err
 }
 
 // recordTime records the current time under the given key, affecting the
@@ -1049,6 +1087,22 @@ func (db *Lowlevel) loadMetadataTracker(folder string) (*metadataTracker, error)
 	return meta, nil
 }
 
+// CheckMetadata returns the stored counts of local items in the folder and
+// whether the stored metadata is present and consistent with the database.
+// It doesn't repair anything, inconsistent metadata is recalculated when the
+// folder is loaded.
+func (db *Lowlevel) CheckMetadata(folder string) (Counts, bool, error) {
+	meta := newMetadataTracker(db.keyer, db.evLogger)
+	if err := meta.fromDB(db, []byte(folder)); backend.IsClosed(err) {
+		return Counts{}, false, err
+	} else if err != nil {
+		return Counts{}, false, nil
+	}
+	counts := meta.Counts(protocol.LocalDeviceID, 0)
+	ok, err := db.verifyLocalSequence(counts.Sequence, folder)
+	return counts, ok, err
+}
+
 func (db *Lowlevel) recalcMeta(folderStr string) (*metadataTracker, error) {
 	folder := []byte(folderStr)
 
@@ -124,6 +124,20 @@ func (n NamespacedKV) Delete(key string) error {
 	return n.db.Delete(n.prefixedKey(key))
 }
 
+// Keys returns the keys stored in the namespace, without the prefix.
+func (n NamespacedKV) Keys() ([]string, error) {
+	it, err := n.db.NewPrefixIterator([]byte(n.prefix))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+	var keys []string
+	for it.Next() {
+		keys = append(keys, string(it.Key()[len(n.prefix):]))
+	}
+	return keys, it.Error()
+}
+
 func (n NamespacedKV) prefixedKey(key string) []byte {
 	return []byte(n.prefix + key)
 }
@@ -143,6 +157,24 @@ func NewFolderStatisticsNamespace(db backend.Backend, folder string) *Namespaced
 	return NewNamespacedKV(db, string(KeyTypeFolderStatistic)+folder)
 }
 
+// NewTransferStatisticsNamespace creates a KV namespace for the per day
+// transfer statistics.
+func NewTransferStatisticsNamespace(db backend.Backend) *NamespacedKV {
+	return NewNamespacedKV(db, string(KeyTypeMiscData)+"transferStatistics/")
+}
+
+// NewWebhookQueueNamespace creates a KV namespace for the queued webhook
+// deliveries of the given webhook.
+func NewWebhookQueueNamespace(db backend.Backend, id string) *NamespacedKV {
+	return NewNamespacedKV(db, string(KeyTypeMiscData)+"webhookQueue/"+id+"/")
+}
+
+// NewTempIndexNamespace creates a KV namespace for the persisted temporary
+// index of the given folder.
+func NewTempIndexNamespace(db backend.Backend, folder string) *NamespacedKV {
+	return NewNamespacedKV(db, string(KeyTypeMiscData)+"tempIndex/"+folder+"/")
+}
+
 // NewMiscDateNamespace creates a KV namespace for miscellaneous metadata.
 func NewMiscDataNamespace(db backend.Backend) *NamespacedKV {
 	return NewNamespacedKV(db, string(KeyTypeMiscData))
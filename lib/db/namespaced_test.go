@@ -157,6 +157,40 @@ func TestNamespacedReset(t *testing.T) {
 	}
 }
 
+func TestNamespacedKeys(t *testing.T) {
+	ldb := newLowlevelMemory(t)
+	defer ldb.Close()
+
+	n1 := NewNamespacedKV(ldb, "foo")
+	n2 := NewNamespacedKV(ldb, "foobar")
+
+	if err := n1.PutString("a", "yo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := n1.PutInt64("b", 42); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PutString("c", "yo"); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := n2.Keys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0] != "c" {
+		t.Errorf("Incorrect keys %v != [c]", keys)
+	}
+	// The prefixes aren't separated, so n1 sees the key of n2 as "barc".
+	keys, err = n1.Keys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 || keys[0] != "a" || keys[1] != "b" || keys[2] != "barc" {
+		t.Errorf("Incorrect keys %v != [a b barc]", keys)
+	}
+}
+
 // reset removes all entries in this namespace.
 func reset(n *NamespacedKV) {
 	tr, err := n.db.NewWriteTransaction()
@@ -276,6 +276,27 @@ func (s *Snapshot) Get(device protocol.DeviceID, file string) (protocol.FileInfo
 	return f, ok
 }
 
+func (s *Snapshot) GetTruncated(device protocol.DeviceID, file string) (FileInfoTruncated, bool) {
+	opStr := fmt.Sprintf("%s GetTruncated(%v)", s.folder, file)
+	l.Debugf(opStr)
+	key, err := s.t.keyer.GenerateDeviceFileKey(nil, []byte(s.folder), device[:], []byte(osutil.NormalizedFilename(file)))
+	if err != nil {
+		s.fatalError(err, opStr)
+	}
+	fi, ok, err := s.t.getFileTrunc(key, true)
+	if backend.IsClosed(err) {
+		return FileInfoTruncated{}, false
+	} else if err != nil {
+		s.fatalError(err, opStr)
+	}
+	if !ok {
+		return FileInfoTruncated{}, false
+	}
+	f := fi.(FileInfoTruncated)
+	f.Name = osutil.NativeFilename(f.Name)
+	return f, true
+}
+
 func (s *Snapshot) GetGlobal(file string) (protocol.FileInfo, bool) {
 	opStr := fmt.Sprintf("%s GetGlobal(%v)", s.folder, file)
 	l.Debugf(opStr)
@@ -364,6 +385,11 @@ func (s *Snapshot) GlobalSize() Counts {
 	return s.meta.Counts(protocol.GlobalDeviceID, 0)
 }
 
+// RemoteSize returns the size of the index data held for the remote device.
+func (s *Snapshot) RemoteSize(device protocol.DeviceID) Counts {
+	return s.meta.Counts(device, 0)
+}
+
 func (s *Snapshot) NeedSize(device protocol.DeviceID) Counts {
 	return s.meta.Counts(device, needFlag)
 }
@@ -442,6 +468,9 @@ func DropFolder(db *Lowlevel, folder string) {
 		db.dropMtimes,
 		db.dropFolderMeta,
 		db.dropFolderIndexIDs,
+		db.dropFolderSizeHistory,
+		db.dropChangeJournalState,
+		db.dropEmptiedConfirmed,
 		db.folderIdx.Delete,
 	}
 	for _, drop := range droppers {
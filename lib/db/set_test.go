@@ -1665,6 +1665,37 @@ func TestUpdateWithOneFileTwice(t *testing.T) {
 	}
 }
 
+func TestUpdateInodeOnly(t *testing.T) {
+	ldb := newLowlevelMemory(t)
+	defer ldb.Close()
+
+	s := newFileSet(t, "test", ldb)
+
+	file := protocol.FileInfo{
+		Name:    "foo",
+		Version: protocol.Vector{Counters: []protocol.Counter{{ID: myID, Value: 1}}},
+		Blocks:  genBlocks(2),
+	}
+	s.Update(protocol.LocalDeviceID, fileList{file})
+	seq := s.Sequence(protocol.LocalDeviceID)
+
+	// The same version with other inode details is stored, without a new
+	// sequence.
+	file.Inode = 42
+	file.InodeGeneration = 7
+	s.Update(protocol.LocalDeviceID, fileList{file})
+	if s.Sequence(protocol.LocalDeviceID) != seq {
+		t.Error("inode update resulted in a new sequence")
+	}
+
+	snap := snapshot(t, s)
+	defer snap.Release()
+	f, ok := snap.Get(protocol.LocalDeviceID, "foo")
+	if !ok || f.Inode != 42 || f.InodeGeneration != 7 || f.Sequence != seq || len(f.Blocks) != 2 {
+		t.Errorf("inode details not updated in place: %v", f)
+	}
+}
+
 // https://github.com/syncthing/syncthing/issues/6668
 func TestNeedRemoteOnly(t *testing.T) {
 	ldb := newLowlevelMemory(t)
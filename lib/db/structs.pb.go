@@ -108,12 +108,13 @@ var xxx_messageInfo_VersionList proto.InternalMessageInfo
 
 // Must be the same as FileInfo but without the blocks field
 type FileInfoTruncated struct {
-	Name       string                                              `protobuf:"bytes,1,opt,name=name,proto3" json:"name" xml:"name"`
-	Size       int64                                               `protobuf:"varint,3,opt,name=size,proto3" json:"size" xml:"size"`
-	ModifiedS  int64                                               `protobuf:"varint,5,opt,name=modified_s,json=modifiedS,proto3" json:"modifiedS" xml:"modifiedS"`
-	ModifiedBy github_com_syncthing_syncthing_lib_protocol.ShortID `protobuf:"varint,12,opt,name=modified_by,json=modifiedBy,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.ShortID" json:"modifiedBy" xml:"modifiedBy"`
-	Version    protocol.Vector                                     `protobuf:"bytes,9,opt,name=version,proto3" json:"version" xml:"version"`
-	Sequence   int64                                               `protobuf:"varint,10,opt,name=sequence,proto3" json:"sequence" xml:"sequence"`
+	Name          string                                              `protobuf:"bytes,1,opt,name=name,proto3" json:"name" xml:"name"`
+	Size          int64                                               `protobuf:"varint,3,opt,name=size,proto3" json:"size" xml:"size"`
+	ModifiedS     int64                                               `protobuf:"varint,5,opt,name=modified_s,json=modifiedS,proto3" json:"modifiedS" xml:"modifiedS"`
+	ModifiedBy    github_com_syncthing_syncthing_lib_protocol.ShortID `protobuf:"varint,12,opt,name=modified_by,json=modifiedBy,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.ShortID" json:"modifiedBy" xml:"modifiedBy"`
+	Version       protocol.Vector                                     `protobuf:"bytes,9,opt,name=version,proto3" json:"version" xml:"version"`
+	Sequence      int64                                               `protobuf:"varint,10,opt,name=sequence,proto3" json:"sequence" xml:"sequence"`
+	TransactionID int64                                               `protobuf:"varint,20,opt,name=transaction_id,json=transactionId,proto3" json:"transactionId" xml:"transactionId"`
 	// repeated BlockInfo Blocks         = 16
 	SymlinkTarget string                `protobuf:"bytes,17,opt,name=symlink_target,json=symlinkTarget,proto3" json:"symlinkTarget" xml:"symlinkTarget"`
 	BlocksHash    []byte                `protobuf:"bytes,18,opt,name=blocks_hash,json=blocksHash,proto3" json:"blocksHash" xml:"blocksHash"`
@@ -497,104 +498,106 @@ func init() {
 func init() { proto.RegisterFile("lib/db/structs.proto", fileDescriptor_5465d80e8cba02e3) }
 
 var fileDescriptor_5465d80e8cba02e3 = []byte{
-	// 1543 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcd, 0x6f, 0xdb, 0x46,
-	0x16, 0x37, 0x2d, 0xd9, 0x92, 0x46, 0xf2, 0x17, 0xb3, 0x36, 0xb4, 0xde, 0x5d, 0x51, 0x3b, 0x71,
-	0x00, 0xed, 0x07, 0x64, 0xc0, 0x41, 0x8c, 0x45, 0x80, 0x6d, 0x10, 0xc6, 0x75, 0xe2, 0x20, 0x75,
-	0xd2, 0x71, 0x90, 0x14, 0xed, 0x41, 0xe0, 0xc7, 0x58, 0x26, 0x42, 0x91, 0x2a, 0x49, 0xdb, 0x51,
-	0x6e, 0xbd, 0x14, 0xe8, 0x2d, 0x08, 0x7a, 0x28, 0x8a, 0xa2, 0x08, 0x50, 0xa0, 0x7f, 0x42, 0xff,
-	0x82, 0xa2, 0xc8, 0xd1, 0xc7, 0xa2, 0x07, 0x16, 0xb1, 0x2f, 0xad, 0x8e, 0x3a, 0xf6, 0x54, 0xcc,
-	0x9b, 0xe1, 0x70, 0x64, 0x23, 0x45, 0x92, 0xfa, 0xc6, 0xf7, 0x7b, 0xbf, 0x79, 0x12, 0xdf, 0xfc,
-	0xde, 0x07, 0xd1, 0x5f, 0x7c, 0xcf, 0x5e, 0x75, 0xed, 0xd5, 0x38, 0x89, 0xf6, 0x9d, 0x24, 0x6e,
-	0xf7, 0xa3, 0x30, 0x09, 0xf5, 0x49, 0xd7, 0x5e, 0xbe, 0x18, 0xd1, 0x7e, 0x18, 0xaf, 0x02, 0x60,
-	0xef, 0xef, 0xae, 0x76, 0xc3, 0x6e, 0x08, 0x06, 0x3c, 0x71, 0xe2, 0xb2, 0xd1, 0x0d, 0xc3, 0xae,
-	0x4f, 0x73, 0x56, 0xe2, 0xf5, 0x68, 0x9c, 0x58, 0xbd, 0xbe, 0x20, 0x2c, 0xb1, 0xf8, 0xf0, 0xe8,
-	0x84, 0xfe, 0xaa, 0x4d, 0x33, 0xbc, 0x42, 0x1f, 0x27, 0xfc, 0x11, 0x7f, 0x3d, 0x89, 0xaa, 0x9b,
-	0x9e, 0x4f, 0x1f, 0xd0, 0x28, 0xf6, 0xc2, 0x40, 0xbf, 0x83, 0x4a, 0x07, 0xfc, 0xb1, 0xae, 0x35,
-	0xb5, 0x56, 0x75, 0x6d, 0xbe, 0x9d, 0x05, 0x68, 0x3f, 0xa0, 0x4e, 0x12, 0x46, 0x66, 0xf3, 0x45,
-	0x6a, 0x4c, 0x0c, 0x53, 0x23, 0x23, 0x8e, 0x52, 0x63, 0xe6, 0x71, 0xcf, 0xbf, 0x8a, 0x85, 0x8d,
-	0x49, 0xe6, 0xd1, 0xd7, 0x51, 0xc9, 0xa5, 0x3e, 0x4d, 0xa8, 0x5b, 0x9f, 0x6c, 0x6a, 0xad, 0xb2,
-	0xf9, 0x77, 0x76, 0x4e, 0x40, 0xf2, 0x9c, 0xb0, 0x31, 0xc9, 0x3c, 0xfa, 0x15, 0x76, 0xee, 0xc0,
-	0x73, 0x68, 0x5c, 0x2f, 0x34, 0x0b, 0xad, 0x9a, 0xf9, 0x37, 0x7e, 0x0e, 0xa0, 0x51, 0x6a, 0xd4,
-	0xc4, 0x39, 0x66, 0xc3, 0x31, 0x70, 0xe8, 0x04, 0xcd, 0x79, 0xc1, 0x81, 0xe5, 0x7b, 0x6e, 0x27,
-	0x3b, 0x5e, 0x84, 0xe3, 0xff, 0x1a, 0xa6, 0xc6, 0xac, 0x70, 0x6d, 0xc8, 0x28, 0x17, 0x20, 0xca,
-	0x18, 0x8c, 0xc9, 0x29, 0x1a, 0xfe, 0x44, 0x43, 0x55, 0x91, 0x9c, 0x3b, 0x5e, 0x9c, 0xe8, 0x3e,
-	0x2a, 0x8b, 0xb7, 0x8b, 0xeb, 0x5a, 0xb3, 0xd0, 0xaa, 0xae, 0xcd, 0xb5, 0x5d, 0xbb, 0xad, 0xe4,
-	0xd0, 0xbc, 0xc6, 0x12, 0x74, 0x9c, 0x1a, 0x55, 0x62, 0x1d, 0x0a, 0x2c, 0x1e, 0xa6, 0x86, 0x3c,
-	0x77, 0x26, 0x61, 0xcf, 0x8e, 0x56, 0x54, 0x2e, 0x91, 0xcc, 0xab, 0xc5, 0x2f, 0x9e, 0x1b, 0x13,
-	0xf8, 0x9b, 0x1a, 0x5a, 0x60, 0x3f, 0xb0, 0x15, 0xec, 0x86, 0xf7, 0xa3, 0xfd, 0xc0, 0xb1, 0x58,
-	0x92, 0xfe, 0x8d, 0x8a, 0x81, 0xd5, 0xa3, 0x70, 0x4f, 0x15, 0x73, 0x69, 0x98, 0x1a, 0x60, 0x8f,
-	0x52, 0x03, 0x41, 0x74, 0x66, 0x60, 0x02, 0x18, 0xe3, 0xc6, 0xde, 0x13, 0x5a, 0x2f, 0x34, 0xb5,
-	0x56, 0x81, 0x73, 0x99, 0x2d, 0xb9, 0xcc, 0xc0, 0x04, 0x30, 0xfd, 0x1a, 0x42, 0xbd, 0xd0, 0xf5,
-	0x76, 0x3d, 0xea, 0x76, 0xe2, 0xfa, 0x14, 0x9c, 0x68, 0x0e, 0x53, 0xa3, 0x92, 0xa1, 0x3b, 0xa3,
-	0xd4, 0x98, 0x83, 0x63, 0x12, 0xc1, 0x24, 0xf7, 0xea, 0xdf, 0x69, 0xa8, 0x2a, 0x23, 0xd8, 0x83,
-	0x7a, 0xad, 0xa9, 0xb5, 0x8a, 0xe6, 0xe7, 0x1a, 0x4b, 0xcb, 0x4f, 0xa9, 0x71, 0xb9, 0xeb, 0x25,
-	0x7b, 0xfb, 0x76, 0xdb, 0x09, 0x7b, 0xab, 0xf1, 0x20, 0x70, 0x92, 0x3d, 0x2f, 0xe8, 0x2a, 0x4f,
-	0xaa, 0x68, 0xdb, 0x3b, 0x7b, 0x61, 0x94, 0x6c, 0x6d, 0x0c, 0x53, 0x43, 0xfe, 0x29, 0x73, 0x30,
-	0x4a, 0x8d, 0xf9, 0xb1, 0xdf, 0x37, 0x07, 0xf8, 0xcb, 0xa3, 0x95, 0xb7, 0x09, 0x4c, 0x94, 0xb0,
-	0xaa, 0xf8, 0x2b, 0x7f, 0x5e, 0xfc, 0x57, 0x51, 0x39, 0xa6, 0x1f, 0xef, 0xd3, 0xc0, 0xa1, 0x75,
-	0x04, 0x59, 0x6c, 0x30, 0x15, 0x64, 0xd8, 0x28, 0x35, 0x66, 0x79, 0xee, 0x05, 0x80, 0x89, 0xf4,
-	0xe9, 0x77, 0xd1, 0x6c, 0x3c, 0xe8, 0xf9, 0x5e, 0xf0, 0xa8, 0x93, 0x58, 0x51, 0x97, 0x26, 0xf5,
-	0x05, 0xb8, 0xe5, 0xd6, 0x30, 0x35, 0x66, 0x84, 0xe7, 0x3e, 0x38, 0xa4, 0x8e, 0xc7, 0x50, 0x4c,
-	0xc6, 0x59, 0xfa, 0x0d, 0x54, 0xb5, 0xfd, 0xd0, 0x79, 0x14, 0x77, 0xf6, 0xac, 0x78, 0xaf, 0xae,
-	0x37, 0xb5, 0x56, 0xcd, 0xc4, 0x2c, 0xad, 0x1c, 0xbe, 0x65, 0xc5, 0x7b, 0x32, 0xad, 0x39, 0x84,
-	0x89, 0xe2, 0xd7, 0xdf, 0x41, 0x15, 0x1a, 0x38, 0xd1, 0xa0, 0xcf, 0x0a, 0xfa, 0x02, 0x84, 0x00,
-	0x61, 0x48, 0x50, 0x0a, 0x43, 0x22, 0x98, 0xe4, 0x5e, 0xdd, 0x44, 0xc5, 0x64, 0xd0, 0xa7, 0xd0,
-	0x0b, 0x66, 0xd7, 0x96, 0xf2, 0xe4, 0x4a, 0x71, 0x0f, 0xfa, 0x94, 0xab, 0x93, 0xf1, 0xa4, 0x3a,
-	0x99, 0x81, 0x09, 0x60, 0xfa, 0x26, 0xaa, 0xf6, 0x69, 0xd4, 0xf3, 0x62, 0x5e, 0x82, 0xc5, 0xa6,
-	0xd6, 0x9a, 0x31, 0x57, 0x86, 0xa9, 0xa1, 0xc2, 0xa3, 0xd4, 0x58, 0x80, 0x93, 0x0a, 0x86, 0x89,
-	0xca, 0xd0, 0x6f, 0x2b, 0x1a, 0x0d, 0xe2, 0x7a, 0xb5, 0xa9, 0xb5, 0xa6, 0xa0, 0x4f, 0x48, 0x41,
-	0x6c, 0xc7, 0x67, 0x74, 0xb6, 0x1d, 0xe3, 0xdf, 0x52, 0xa3, 0xe0, 0x05, 0x09, 0x51, 0x68, 0xfa,
-	0x2e, 0xe2, 0x59, 0xea, 0x40, 0x8d, 0xcd, 0x40, 0xa8, 0x9b, 0xc7, 0xa9, 0x51, 0x23, 0xd6, 0xa1,
-	0xc9, 0x1c, 0x3b, 0xde, 0x13, 0xca, 0x12, 0x65, 0x67, 0x86, 0x4c, 0x94, 0x44, 0xb2, 0xc0, 0xcf,
-	0x8e, 0x56, 0xc6, 0x8e, 0x91, 0xfc, 0x90, 0xfe, 0x00, 0x95, 0xfb, 0xbe, 0x95, 0xec, 0x86, 0x51,
-	0xaf, 0x3e, 0x0b, 0x02, 0x55, 0x72, 0x78, 0x4f, 0x78, 0x36, 0xac, 0xc4, 0x32, 0xb1, 0x90, 0xa9,
-	0xe4, 0x4b, 0xb5, 0x65, 0x00, 0x26, 0xd2, 0xa7, 0x6f, 0xa0, 0xaa, 0x1f, 0x3a, 0x96, 0xdf, 0xd9,
-	0xf5, 0xad, 0x6e, 0x5c, 0xff, 0xa5, 0x04, 0x49, 0x05, 0x75, 0x00, 0xbe, 0xc9, 0x60, 0x99, 0x8c,
-	0x1c, 0xc2, 0x44, 0xf1, 0xeb, 0xb7, 0x50, 0x4d, 0x48, 0x9f, 0x6b, 0xec, 0xd7, 0x12, 0x28, 0x04,
-	0xee, 0x46, 0x38, 0x84, 0xca, 0x16, 0xd4, 0x8a, 0xe1, 0x32, 0x53, 0x19, 0xfa, 0xfb, 0xac, 0x8f,
-	0x87, 0x2e, 0xed, 0x38, 0x7b, 0x56, 0xd0, 0xa5, 0xec, 0x7e, 0x86, 0x25, 0xa8, 0x20, 0xd0, 0x3f,
-	0xf8, 0x6e, 0x80, 0x6b, 0x5b, 0xed, 0xe3, 0x0a, 0x8a, 0xc9, 0x38, 0x4b, 0x9d, 0x44, 0xd3, 0x6f,
-	0x32, 0x89, 0x08, 0x2a, 0x89, 0x81, 0x50, 0x2f, 0xc1, 0xb9, 0xff, 0x1d, 0xa7, 0x06, 0x22, 0xd6,
-	0xe1, 0x16, 0x47, 0x59, 0x14, 0x41, 0x90, 0x51, 0x84, 0xcd, 0xda, 0xba, 0xc2, 0x24, 0x19, 0x8f,
-	0x15, 0x77, 0x10, 0x76, 0x54, 0x15, 0x97, 0x21, 0x34, 0xbc, 0x5c, 0x10, 0xde, 0x1b, 0xd3, 0x31,
-	0x7f, 0xb9, 0x31, 0x14, 0x93, 0x71, 0x96, 0x98, 0x12, 0x0f, 0x51, 0x05, 0x54, 0x03, 0x63, 0xea,
-	0x36, 0x9a, 0xe6, 0x85, 0x2b, 0x86, 0xd4, 0x85, 0x5c, 0x28, 0x40, 0x62, 0xd5, 0x66, 0xfe, 0x43,
-	0xa8, 0x44, 0x50, 0x47, 0xa9, 0x51, 0xcd, 0x45, 0x89, 0x89, 0x80, 0xf1, 0xb7, 0x1a, 0x5a, 0xdc,
-	0x0a, 0x5c, 0x2f, 0xa2, 0x4e, 0x22, 0xae, 0x88, 0xc6, 0x77, 0x03, 0x7f, 0x70, 0x3e, 0x5d, 0xe5,
-	0xdc, 0x74, 0x83, 0xbf, 0x2a, 0xa2, 0xe9, 0x1b, 0xe1, 0x7e, 0x90, 0xc4, 0xfa, 0x15, 0x34, 0xb5,
-	0xeb, 0xf9, 0x34, 0x86, 0xe9, 0x38, 0x65, 0x1a, 0xc3, 0xd4, 0xe0, 0x80, 0x7c, 0x49, 0xb0, 0x64,
-	0x39, 0x73, 0xa7, 0xfe, 0x1e, 0xaa, 0xf2, 0xf7, 0x0c, 0x23, 0x8f, 0xc6, 0xd0, 0xa8, 0xa6, 0xcc,
-	0xff, 0xb0, 0x7f, 0xa2, 0xc0, 0xf2, 0x9f, 0x28, 0x98, 0x0c, 0xa4, 0x12, 0xf5, 0xeb, 0xa8, 0x2c,
-	0xda, 0x70, 0x0c, 0xa3, 0x77, 0xca, 0xbc, 0x04, 0x23, 0x40, 0x60, 0xf9, 0x08, 0x10, 0x80, 0x8c,
-	0x22, 0x29, 0xfa, 0xff, 0x73, 0xe1, 0x16, 0x21, 0xc2, 0xc5, 0x3f, 0x12, 0x6e, 0x76, 0x5e, 0xea,
-	0xb7, 0x8d, 0xa6, 0xec, 0x41, 0x42, 0xb3, 0x39, 0x5e, 0x67, 0x79, 0x00, 0x20, 0xbf, 0x6c, 0x66,
-	0x61, 0xc2, 0xd1, 0xb1, 0xa1, 0x35, 0xfd, 0x86, 0x43, 0x6b, 0x07, 0x55, 0xf8, 0xda, 0xd5, 0xf1,
-	0x5c, 0x98, 0x57, 0x35, 0x73, 0xfd, 0x38, 0x35, 0xca, 0x7c, 0x95, 0x82, 0x21, 0x5e, 0xe6, 0x84,
-	0x2d, 0x57, 0x06, 0xca, 0x00, 0x56, 0x2d, 0x92, 0x49, 0x24, 0x8f, 0x49, 0x4c, 0xed, 0x4d, 0xfa,
-	0xdb, 0xb4, 0x26, 0x51, 0x20, 0x9f, 0x6a, 0xa8, 0xc2, 0xe5, 0xb1, 0x43, 0x13, 0xfd, 0x3a, 0x9a,
-	0x76, 0xc0, 0x10, 0x15, 0x82, 0xd8, 0x1a, 0xc7, 0xdd, 0x79, 0x61, 0x70, 0x86, 0xcc, 0x15, 0x98,
-	0x98, 0x08, 0x98, 0x35, 0x15, 0x27, 0xa2, 0x56, 0xb6, 0xde, 0x16, 0x78, 0x53, 0x11, 0x90, 0xbc,
-	0x1b, 0x61, 0x63, 0x92, 0x79, 0xf0, 0x67, 0x93, 0x68, 0x51, 0x59, 0x18, 0x37, 0x68, 0x3f, 0xa2,
-	0x7c, 0xa7, 0x3b, 0xdf, 0xf5, 0x7b, 0x0d, 0x4d, 0xf3, 0x3c, 0xc2, 0xdf, 0xab, 0x99, 0xcb, 0xec,
-	0x95, 0x38, 0x72, 0x66, 0x89, 0x16, 0x38, 0x7b, 0xa7, 0xac, 0xe1, 0x15, 0xf2, 0x46, 0xf9, 0xaa,
-	0x16, 0x97, 0x37, 0xb5, 0xf5, 0x71, 0x9d, 0xbe, 0x6e, 0x83, 0xc5, 0x87, 0x68, 0x51, 0x59, 0xaf,
-	0x95, 0x54, 0x7c, 0x70, 0x66, 0xd1, 0xfe, 0xeb, 0xa9, 0x45, 0x3b, 0x27, 0x9b, 0xff, 0xcc, 0xe6,
-	0xdd, 0x2b, 0x77, 0xec, 0x33, 0x4b, 0xf5, 0x0f, 0x93, 0x68, 0xf6, 0xae, 0x1d, 0xd3, 0xe8, 0x80,
-	0xba, 0x9b, 0xa1, 0xef, 0xd2, 0x48, 0xdf, 0x46, 0x45, 0xf6, 0x09, 0x25, 0x52, 0xbf, 0xdc, 0xe6,
-	0xdf, 0x57, 0xed, 0xec, 0xfb, 0xaa, 0x7d, 0x3f, 0xfb, 0xbe, 0x32, 0x1b, 0xe2, 0xf7, 0x80, 0x9f,
-	0xef, 0x29, 0x5e, 0x8f, 0xe2, 0xa7, 0x3f, 0x1b, 0x1a, 0x01, 0x9c, 0x15, 0x9f, 0x6f, 0xd9, 0xd4,
-	0x87, 0xf4, 0x57, 0x78, 0xf1, 0x01, 0x20, 0x05, 0x05, 0x16, 0x26, 0x1c, 0xd5, 0x3f, 0x42, 0x0b,
-	0x11, 0x75, 0xa8, 0x77, 0x40, 0x3b, 0xf9, 0x9e, 0xc5, 0x6f, 0xa1, 0x3d, 0x4c, 0x8d, 0x79, 0xe1,
-	0x7c, 0x57, 0x59, 0xb7, 0x96, 0x20, 0xcc, 0x69, 0x07, 0x26, 0x67, 0xb8, 0xfa, 0x43, 0x34, 0x1f,
-	0xd1, 0x5e, 0x98, 0xa8, 0xb1, 0xf9, 0x4d, 0xfd, 0x77, 0x98, 0x1a, 0x73, 0xdc, 0xa7, 0x86, 0x5e,
-	0x14, 0xa1, 0xc7, 0x70, 0x4c, 0x4e, 0x33, 0xf1, 0xf7, 0x5a, 0x9e, 0x48, 0x5e, 0xc0, 0xe7, 0x9e,
-	0xc8, 0xec, 0x53, 0x67, 0xf2, 0x35, 0x3e, 0x75, 0xd6, 0x51, 0xc9, 0x72, 0xdd, 0x88, 0xc6, 0xbc,
-	0xe5, 0x56, 0xb8, 0x10, 0x05, 0x24, 0x65, 0x21, 0x6c, 0x4c, 0x32, 0x8f, 0x79, 0xf3, 0xc5, 0xcb,
-	0xc6, 0xc4, 0xd1, 0xcb, 0xc6, 0xc4, 0x8b, 0xe3, 0x86, 0x76, 0x74, 0xdc, 0xd0, 0x9e, 0x9e, 0x34,
-	0x26, 0x9e, 0x9f, 0x34, 0xb4, 0xa3, 0x93, 0xc6, 0xc4, 0x8f, 0x27, 0x8d, 0x89, 0x0f, 0x2f, 0xbd,
-	0xc6, 0xf7, 0x85, 0x6b, 0xdb, 0xd3, 0xf0, 0x9a, 0x97, 0x7f, 0x0f, 0x00, 0x00, 0xff, 0xff, 0x52,
-	0x5f, 0x14, 0xfe, 0xde, 0x0f, 0x00, 0x00,
+	// 1581 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcf, 0x6f, 0xdb, 0x46,
+	0x16, 0x36, 0x2d, 0xd9, 0x92, 0x46, 0xfe, 0xc9, 0xc4, 0x06, 0xd7, 0xbb, 0x2b, 0x6a, 0x27, 0x0e,
+	0xa0, 0xfd, 0x01, 0x19, 0x70, 0x10, 0x63, 0x11, 0x60, 0x37, 0x08, 0xe3, 0x3a, 0x71, 0x90, 0x3a,
+	0xe9, 0xd8, 0x48, 0x8a, 0xf6, 0x20, 0x50, 0xe4, 0x58, 0x26, 0x42, 0x91, 0x0a, 0x49, 0xdb, 0x51,
+	0x6e, 0xbd, 0x14, 0xe8, 0x2d, 0x08, 0x8a, 0xa2, 0x28, 0x8a, 0x22, 0xa7, 0xfe, 0x09, 0xfd, 0x0b,
+	0x8a, 0x22, 0x47, 0x1f, 0x8b, 0x1e, 0x58, 0xc4, 0xbe, 0xb4, 0x3a, 0xea, 0xd8, 0x53, 0x31, 0x6f,
+	0x86, 0xc3, 0x91, 0x8d, 0x14, 0x49, 0xea, 0x9b, 0xde, 0xf7, 0xbe, 0xf7, 0x28, 0xbe, 0xf9, 0xde,
+	0xbc, 0x47, 0x74, 0xd1, 0xf7, 0xda, 0x2b, 0x6e, 0x7b, 0x25, 0x4e, 0xa2, 0x7d, 0x27, 0x89, 0x9b,
+	0xbd, 0x28, 0x4c, 0x42, 0x7d, 0xdc, 0x6d, 0x2f, 0x5d, 0x8a, 0x68, 0x2f, 0x8c, 0x57, 0x00, 0x68,
+	0xef, 0xef, 0xae, 0x74, 0xc2, 0x4e, 0x08, 0x06, 0xfc, 0xe2, 0xc4, 0x25, 0xb3, 0x13, 0x86, 0x1d,
+	0x9f, 0xe6, 0xac, 0xc4, 0xeb, 0xd2, 0x38, 0xb1, 0xbb, 0x3d, 0x41, 0x58, 0x64, 0xf9, 0xe1, 0xa7,
+	0x13, 0xfa, 0x2b, 0x6d, 0x9a, 0xe1, 0x15, 0xfa, 0x24, 0xe1, 0x3f, 0xf1, 0x37, 0xe3, 0xa8, 0xba,
+	0xe1, 0xf9, 0xf4, 0x01, 0x8d, 0x62, 0x2f, 0x0c, 0xf4, 0xbb, 0xa8, 0x74, 0xc0, 0x7f, 0x1a, 0x5a,
+	0x5d, 0x6b, 0x54, 0x57, 0xe7, 0x9a, 0x59, 0x82, 0xe6, 0x03, 0xea, 0x24, 0x61, 0x64, 0xd5, 0x5f,
+	0xa6, 0xe6, 0xd8, 0x20, 0x35, 0x33, 0xe2, 0x30, 0x35, 0xa7, 0x9f, 0x74, 0xfd, 0x6b, 0x58, 0xd8,
+	0x98, 0x64, 0x1e, 0x7d, 0x0d, 0x95, 0x5c, 0xea, 0xd3, 0x84, 0xba, 0xc6, 0x78, 0x5d, 0x6b, 0x94,
+	0xad, 0xbf, 0xb1, 0x38, 0x01, 0xc9, 0x38, 0x61, 0x63, 0x92, 0x79, 0xf4, 0xab, 0x2c, 0xee, 0xc0,
+	0x73, 0x68, 0x6c, 0x14, 0xea, 0x85, 0xc6, 0x94, 0xf5, 0x57, 0x1e, 0x07, 0xd0, 0x30, 0x35, 0xa7,
+	0x44, 0x1c, 0xb3, 0x21, 0x0c, 0x1c, 0x3a, 0x41, 0xb3, 0x5e, 0x70, 0x60, 0xfb, 0x9e, 0xdb, 0xca,
+	0xc2, 0x8b, 0x10, 0xfe, 0xcf, 0x41, 0x6a, 0xce, 0x08, 0xd7, 0xba, 0xcc, 0x72, 0x01, 0xb2, 0x8c,
+	0xc0, 0x98, 0x9c, 0xa2, 0xe1, 0x4f, 0x34, 0x54, 0x15, 0xc5, 0xb9, 0xeb, 0xc5, 0x89, 0xee, 0xa3,
+	0xb2, 0x78, 0xbb, 0xd8, 0xd0, 0xea, 0x85, 0x46, 0x75, 0x75, 0xb6, 0xe9, 0xb6, 0x9b, 0x4a, 0x0d,
+	0xad, 0xeb, 0xac, 0x40, 0xc7, 0xa9, 0x59, 0x25, 0xf6, 0xa1, 0xc0, 0xe2, 0x41, 0x6a, 0xca, 0xb8,
+	0x33, 0x05, 0x7b, 0x7e, 0xb4, 0xac, 0x72, 0x89, 0x64, 0x5e, 0x2b, 0x7e, 0xf9, 0xc2, 0x1c, 0xc3,
+	0x5f, 0x4c, 0xa3, 0x79, 0xf6, 0x80, 0xcd, 0x60, 0x37, 0xdc, 0x89, 0xf6, 0x03, 0xc7, 0x66, 0x45,
+	0xfa, 0x17, 0x2a, 0x06, 0x76, 0x97, 0xc2, 0x39, 0x55, 0xac, 0xc5, 0x41, 0x6a, 0x82, 0x3d, 0x4c,
+	0x4d, 0x04, 0xd9, 0x99, 0x81, 0x09, 0x60, 0x8c, 0x1b, 0x7b, 0x4f, 0xa9, 0x51, 0xa8, 0x6b, 0x8d,
+	0x02, 0xe7, 0x32, 0x5b, 0x72, 0x99, 0x81, 0x09, 0x60, 0xfa, 0x75, 0x84, 0xba, 0xa1, 0xeb, 0xed,
+	0x7a, 0xd4, 0x6d, 0xc5, 0xc6, 0x04, 0x44, 0xd4, 0x07, 0xa9, 0x59, 0xc9, 0xd0, 0xed, 0x61, 0x6a,
+	0xce, 0x42, 0x98, 0x44, 0x30, 0xc9, 0xbd, 0xfa, 0x77, 0x1a, 0xaa, 0xca, 0x0c, 0xed, 0xbe, 0x31,
+	0x55, 0xd7, 0x1a, 0x45, 0xeb, 0x73, 0x8d, 0x95, 0xe5, 0xa7, 0xd4, 0xbc, 0xd2, 0xf1, 0x92, 0xbd,
+	0xfd, 0x76, 0xd3, 0x09, 0xbb, 0x2b, 0x71, 0x3f, 0x70, 0x92, 0x3d, 0x2f, 0xe8, 0x28, 0xbf, 0x54,
+	0xd1, 0x36, 0xb7, 0xf7, 0xc2, 0x28, 0xd9, 0x5c, 0x1f, 0xa4, 0xa6, 0xfc, 0x53, 0x56, 0x7f, 0x98,
+	0x9a, 0x73, 0x23, 0xcf, 0xb7, 0xfa, 0xf8, 0xab, 0xa3, 0xe5, 0x77, 0x49, 0x4c, 0x94, 0xb4, 0xaa,
+	0xf8, 0x2b, 0x7f, 0x5e, 0xfc, 0xd7, 0x50, 0x39, 0xa6, 0x8f, 0xf7, 0x69, 0xe0, 0x50, 0x03, 0x41,
+	0x15, 0x6b, 0x4c, 0x05, 0x19, 0x36, 0x4c, 0xcd, 0x19, 0x5e, 0x7b, 0x01, 0x60, 0x22, 0x7d, 0xfa,
+	0x63, 0x34, 0x93, 0x44, 0x76, 0x10, 0xdb, 0x4e, 0xe2, 0x85, 0x41, 0xcb, 0x73, 0x8d, 0x8b, 0x90,
+	0xe1, 0xce, 0x71, 0x6a, 0x4e, 0xef, 0xe4, 0x1e, 0xa8, 0xcc, 0xb4, 0x42, 0xdd, 0x74, 0xa5, 0xb0,
+	0x47, 0x50, 0xa6, 0xb1, 0xd1, 0x40, 0x32, 0x1a, 0xa6, 0xdf, 0x43, 0x33, 0x71, 0xbf, 0xeb, 0x7b,
+	0xc1, 0xa3, 0x56, 0x62, 0x47, 0x1d, 0x9a, 0x18, 0xf3, 0x20, 0xac, 0x06, 0x7b, 0x82, 0xf0, 0xec,
+	0x80, 0x43, 0x3e, 0x61, 0x04, 0xc5, 0x64, 0x94, 0xa5, 0xdf, 0x44, 0xd5, 0xb6, 0x1f, 0x3a, 0x8f,
+	0xe2, 0xd6, 0x9e, 0x1d, 0xef, 0x19, 0x7a, 0x5d, 0x6b, 0x4c, 0x59, 0x98, 0x9d, 0x24, 0x87, 0x6f,
+	0xdb, 0xf1, 0x9e, 0x3c, 0xc9, 0x1c, 0xc2, 0x44, 0xf1, 0xeb, 0xff, 0x47, 0x15, 0x1a, 0x38, 0x51,
+	0xbf, 0xc7, 0xee, 0x90, 0x0b, 0x90, 0x02, 0xb4, 0x28, 0x41, 0xa9, 0x45, 0x89, 0x60, 0x92, 0x7b,
+	0x75, 0x0b, 0x15, 0x93, 0x7e, 0x8f, 0xc2, 0xf5, 0x33, 0xb3, 0xba, 0x98, 0x9f, 0xa7, 0xec, 0xa7,
+	0x7e, 0x8f, 0xf2, 0x86, 0x60, 0x3c, 0xd9, 0x10, 0xcc, 0xc0, 0x04, 0x30, 0x7d, 0x03, 0x55, 0x7b,
+	0x34, 0xea, 0x7a, 0x31, 0xef, 0xfa, 0x62, 0x5d, 0x6b, 0x4c, 0x5b, 0xcb, 0x83, 0xd4, 0x54, 0xe1,
+	0x61, 0x6a, 0xce, 0x43, 0xa4, 0x82, 0x61, 0xa2, 0x32, 0xf4, 0x3b, 0x4a, 0x5b, 0x04, 0xb1, 0x51,
+	0xad, 0x6b, 0x8d, 0x09, 0xb8, 0x9a, 0xa4, 0x06, 0xb7, 0xe2, 0x33, 0xd2, 0xde, 0x8a, 0xf1, 0x6f,
+	0xa9, 0x59, 0xf0, 0x82, 0x84, 0x28, 0x34, 0x7d, 0x17, 0xf1, 0x2a, 0xb5, 0xa0, 0xad, 0xa7, 0x21,
+	0xd5, 0xad, 0xe3, 0xd4, 0x9c, 0x22, 0xf6, 0xa1, 0xc5, 0x1c, 0xdb, 0xde, 0x53, 0xca, 0x0a, 0xd5,
+	0xce, 0x0c, 0x59, 0x28, 0x89, 0x64, 0x89, 0x9f, 0x1f, 0x2d, 0x8f, 0x84, 0x91, 0x3c, 0x48, 0x7f,
+	0x80, 0xca, 0x3d, 0xdf, 0x4e, 0x76, 0xc3, 0xa8, 0x6b, 0xcc, 0x40, 0x4f, 0x28, 0x35, 0xbc, 0x2f,
+	0x3c, 0xeb, 0x76, 0x62, 0x5b, 0x58, 0x74, 0x86, 0xe4, 0x4b, 0x81, 0x67, 0x00, 0x26, 0xd2, 0xa7,
+	0xaf, 0xa3, 0xaa, 0x1f, 0x3a, 0xb6, 0xdf, 0xda, 0xf5, 0xed, 0x4e, 0x6c, 0xfc, 0x52, 0x82, 0xa2,
+	0x82, 0x3a, 0x00, 0xdf, 0x60, 0xb0, 0x2c, 0x46, 0x0e, 0x61, 0xa2, 0xf8, 0xf5, 0xdb, 0x68, 0x4a,
+	0x74, 0x1b, 0xd7, 0xd8, 0xaf, 0x25, 0x50, 0x08, 0x9c, 0x8d, 0x70, 0x08, 0x95, 0xcd, 0xab, 0x4d,
+	0xca, 0x65, 0xa6, 0x32, 0xf4, 0x0f, 0xd8, 0xe8, 0x08, 0x5d, 0xda, 0x72, 0xf6, 0xec, 0xa0, 0x43,
+	0xd9, 0xf9, 0x0c, 0x4a, 0xd0, 0x72, 0xa0, 0x7f, 0xf0, 0xdd, 0x04, 0xd7, 0x96, 0x3a, 0x3a, 0x14,
+	0x14, 0x93, 0x51, 0x96, 0x3a, 0xfc, 0x26, 0xdf, 0x66, 0xf8, 0x11, 0x54, 0x12, 0x33, 0xc8, 0x28,
+	0x41, 0xdc, 0x7f, 0x8f, 0x53, 0x13, 0x11, 0xfb, 0x70, 0x93, 0xa3, 0x2c, 0x8b, 0x20, 0xc8, 0x2c,
+	0xc2, 0x66, 0x5d, 0xae, 0x30, 0x49, 0xc6, 0x63, 0xcd, 0x1d, 0x84, 0x2d, 0x55, 0xc5, 0x65, 0x48,
+	0x0d, 0x2f, 0x17, 0x84, 0xf7, 0x47, 0x74, 0xcc, 0x5f, 0x6e, 0x04, 0xc5, 0x64, 0x94, 0x25, 0x06,
+	0xd3, 0x43, 0x54, 0x01, 0xd5, 0xc0, 0x64, 0xbc, 0x83, 0x26, 0x79, 0xe3, 0x8a, 0xb9, 0x78, 0x21,
+	0x17, 0x0a, 0x90, 0x58, 0xb7, 0x59, 0x7f, 0x17, 0x2a, 0x11, 0xd4, 0x61, 0x6a, 0x56, 0x73, 0x51,
+	0x62, 0x22, 0x60, 0xfc, 0xad, 0x86, 0x16, 0x36, 0x03, 0xd7, 0x8b, 0xa8, 0x93, 0x88, 0x23, 0xa2,
+	0xf1, 0xbd, 0xc0, 0xef, 0x9f, 0xcf, 0xad, 0x72, 0x6e, 0xba, 0xc1, 0x5f, 0x17, 0xd1, 0xe4, 0xcd,
+	0x70, 0x3f, 0x48, 0x62, 0xfd, 0x2a, 0x9a, 0xd8, 0xf5, 0x7c, 0x1a, 0xc3, 0x40, 0x9e, 0xb0, 0xcc,
+	0x41, 0x6a, 0x72, 0x40, 0xbe, 0x24, 0x58, 0xb2, 0x9d, 0xb9, 0x53, 0x7f, 0x1f, 0x55, 0xf9, 0x7b,
+	0x86, 0x91, 0x47, 0x63, 0xb8, 0xa8, 0x26, 0xac, 0x7f, 0xb3, 0x7f, 0xa2, 0xc0, 0xf2, 0x9f, 0x28,
+	0x98, 0x4c, 0xa4, 0x12, 0xf5, 0x1b, 0xa8, 0x2c, 0xae, 0xe1, 0x18, 0xa6, 0xfd, 0x84, 0x75, 0x19,
+	0xa6, 0x8e, 0xc0, 0xf2, 0xa9, 0x23, 0x00, 0x99, 0x45, 0x52, 0xf4, 0xff, 0xe5, 0xc2, 0x2d, 0x42,
+	0x86, 0x4b, 0x7f, 0x24, 0xdc, 0x2c, 0x5e, 0xea, 0xb7, 0x89, 0x26, 0xda, 0xfd, 0x84, 0x66, 0xab,
+	0x83, 0xc1, 0xea, 0x00, 0x40, 0x7e, 0xd8, 0xcc, 0xc2, 0x84, 0xa3, 0x23, 0x73, 0x72, 0xf2, 0x2d,
+	0xe7, 0xe4, 0x36, 0xaa, 0xf0, 0x4d, 0x8f, 0x8d, 0xc8, 0x79, 0x38, 0xc4, 0xb5, 0xe3, 0xd4, 0x2c,
+	0xf3, 0xed, 0x0d, 0xa6, 0x63, 0x99, 0x13, 0x36, 0x5d, 0x99, 0x28, 0x03, 0x58, 0xb7, 0x48, 0x26,
+	0x91, 0x3c, 0x26, 0x31, 0xf5, 0x6e, 0xd2, 0xdf, 0xe5, 0x6a, 0x12, 0x0d, 0xf2, 0xa9, 0x86, 0x2a,
+	0x5c, 0x1e, 0xdb, 0x34, 0xd1, 0x6f, 0xa0, 0x49, 0x07, 0x0c, 0xd1, 0x21, 0x88, 0x6d, 0x8e, 0xdc,
+	0x9d, 0x37, 0x06, 0x67, 0xc8, 0x5a, 0x81, 0x89, 0x89, 0x80, 0xd9, 0xa5, 0xe2, 0x44, 0xd4, 0xce,
+	0x36, 0xea, 0x02, 0xbf, 0x54, 0x04, 0x24, 0xcf, 0x46, 0xd8, 0x98, 0x64, 0x1e, 0xfc, 0xd9, 0x38,
+	0x5a, 0x50, 0x76, 0xd4, 0x75, 0xda, 0x8b, 0x28, 0x5f, 0x23, 0xcf, 0x77, 0xe3, 0x5f, 0x45, 0x93,
+	0xbc, 0x8e, 0xf0, 0xf7, 0xa6, 0xac, 0x25, 0xf6, 0x4a, 0x1c, 0x39, 0xb3, 0xb7, 0x0b, 0x9c, 0xbd,
+	0x53, 0x76, 0xe1, 0x15, 0xf2, 0x8b, 0xf2, 0x75, 0x57, 0x5c, 0x7e, 0xa9, 0xad, 0x8d, 0xea, 0xf4,
+	0x4d, 0x2f, 0x58, 0x7c, 0x88, 0x16, 0x94, 0x8d, 0x5e, 0x29, 0xc5, 0x87, 0x67, 0x76, 0xfb, 0xbf,
+	0x9c, 0xda, 0xed, 0x73, 0xb2, 0xf5, 0x8f, 0x6c, 0xde, 0xbd, 0x76, 0xad, 0x3f, 0xb3, 0xc7, 0xff,
+	0x30, 0x8e, 0x66, 0xee, 0xb5, 0x63, 0x1a, 0x1d, 0x50, 0x77, 0x23, 0xf4, 0x5d, 0x1a, 0xe9, 0x5b,
+	0xa8, 0xc8, 0xbe, 0xda, 0x44, 0xe9, 0x97, 0x9a, 0xfc, 0x93, 0xae, 0x99, 0x7d, 0xd2, 0x35, 0x77,
+	0xb2, 0x4f, 0x3a, 0xab, 0x26, 0x9e, 0x07, 0xfc, 0x7c, 0x4f, 0xf1, 0xba, 0x14, 0x3f, 0xfb, 0xd9,
+	0xd4, 0x08, 0xe0, 0xac, 0xf9, 0x7c, 0xbb, 0x4d, 0x7d, 0x28, 0x7f, 0x85, 0x37, 0x1f, 0x00, 0x52,
+	0x50, 0x60, 0x61, 0xc2, 0x51, 0xfd, 0x63, 0x34, 0x1f, 0x51, 0x87, 0x7a, 0x07, 0xb4, 0x95, 0xef,
+	0x59, 0xfc, 0x14, 0x9a, 0x83, 0xd4, 0x9c, 0x13, 0xce, 0xf7, 0x94, 0x75, 0x6b, 0x11, 0xd2, 0x9c,
+	0x76, 0x60, 0x72, 0x86, 0xab, 0x3f, 0x44, 0x73, 0x11, 0xed, 0x86, 0x89, 0x9a, 0x9b, 0x9f, 0xd4,
+	0x7f, 0x06, 0xa9, 0x39, 0xcb, 0x7d, 0x6a, 0xea, 0x05, 0x91, 0x7a, 0x04, 0xc7, 0xe4, 0x34, 0x13,
+	0x7f, 0xaf, 0xe5, 0x85, 0xe4, 0x0d, 0x7c, 0xee, 0x85, 0xcc, 0xbe, 0xae, 0xc6, 0xdf, 0xe0, 0xeb,
+	0x6a, 0x0d, 0x95, 0x6c, 0xd7, 0x8d, 0x68, 0xcc, 0xaf, 0xdc, 0x0a, 0x17, 0xa2, 0x80, 0xa4, 0x2c,
+	0x84, 0x8d, 0x49, 0xe6, 0xb1, 0x6e, 0xbd, 0x7c, 0x55, 0x1b, 0x3b, 0x7a, 0x55, 0x1b, 0x7b, 0x79,
+	0x5c, 0xd3, 0x8e, 0x8e, 0x6b, 0xda, 0xb3, 0x93, 0xda, 0xd8, 0x8b, 0x93, 0x9a, 0x76, 0x74, 0x52,
+	0x1b, 0xfb, 0xf1, 0xa4, 0x36, 0xf6, 0xd1, 0xe5, 0x37, 0xf8, 0xa4, 0x71, 0xdb, 0xed, 0x49, 0x78,
+	0xcd, 0x2b, 0xbf, 0x0f, 0x00, 0x5b, 0xb6, 0xaa, 0x08, 0x51, 0x10, 0x00, 0x00,
 }
 
 func (m *FileVersion) Marshal() (dAtA []byte, err error) {
@@ -738,6 +741,13 @@ func (m *FileInfoTruncated) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xc0
 	}
+	if m.TransactionID != 0 {
+		i = encodeVarintStructs(dAtA, i, uint64(m.TransactionID))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa0
+	}
 	if len(m.Encrypted) > 0 {
 		i -= len(m.Encrypted)
 		copy(dAtA[i:], m.Encrypted)
@@ -1365,6 +1375,9 @@ func (m *FileInfoTruncated) ProtoSize() (n int) {
 	if l > 0 {
 		n += 2 + l + sovStructs(uint64(l))
 	}
+	if m.TransactionID != 0 {
+		n += 2 + sovStructs(uint64(m.TransactionID))
+	}
 	if m.LocalFlags != 0 {
 		n += 2 + sovStructs(uint64(m.LocalFlags))
 	}
@@ -2234,6 +2247,25 @@ func (m *FileInfoTruncated) Unmarshal(dAtA []byte) error {
 				m.Encrypted = []byte{}
 			}
 			iNdEx = postIndex
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransactionID", wireType)
+			}
+			m.TransactionID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStructs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TransactionID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 1000:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field LocalFlags", wireType)
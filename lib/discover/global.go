@@ -25,6 +25,7 @@ import (
 	"github.com/syncthing/syncthing/lib/dialer"
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/ratecontrol"
 )
 
 type globalClient struct {
@@ -35,6 +36,7 @@ type globalClient struct {
 	noAnnounce     bool
 	noLookup       bool
 	evLogger       events.Logger
+	announceRate   *ratecontrol.Controller
 	errorHolder
 }
 
@@ -48,6 +50,14 @@ const (
 	announceErrorRetryInterval            = 5 * time.Minute
 	requestTimeout                        = 30 * time.Second
 	maxAddressChangesBetweenAnnouncements = 10
+
+	// Announcements are limited to a burst of a few, e.g. on address
+	// changes, and one a minute on average. Failed announcements back off
+	// from a minute up to an hour.
+	announceRateInterval = time.Minute
+	announceRateBurst    = 5
+	announceBackoffBase  = time.Minute
+	announceBackoffMax   = time.Hour
 )
 
 type announcement struct {
@@ -144,6 +154,7 @@ func NewGlobal(server string, cert tls.Certificate, addrList AddressLister, evLo
 		noAnnounce:     opts.noAnnounce,
 		noLookup:       opts.noLookup,
 		evLogger:       evLogger,
+		announceRate:   ratecontrol.New(announceRateInterval, announceRateBurst, announceBackoffBase, announceBackoffMax),
 	}
 	if !opts.noAnnounce {
 		// If we are supposed to announce, it's an error until we've done so.
@@ -262,6 +273,12 @@ func (c *globalClient) sendAnnouncement(ctx context.Context, timer *time.Timer)
 		return
 	}
 
+	if d := c.announceRate.Delay(); d > 0 {
+		l.Debugln(c, "announcement delayed by", d)
+		timer.Reset(d)
+		return
+	}
+
 	// The marshal doesn't fail, I promise.
 	postData, _ := json.Marshal(ann)
 
@@ -271,7 +288,7 @@ func (c *globalClient) sendAnnouncement(ctx context.Context, timer *time.Timer)
 	if err != nil {
 		l.Debugln(c, "announce POST:", err)
 		c.setError(err)
-		timer.Reset(announceErrorRetryInterval)
+		timer.Reset(c.announceRate.Failure(0))
 		return
 	}
 	l.Debugln(c, "announce POST:", resp.Status)
@@ -281,17 +298,17 @@ func (c *globalClient) sendAnnouncement(ctx context.Context, timer *time.Timer)
 		l.Debugln(c, "announce POST:", resp.Status)
 		c.setError(errors.New(resp.Status))
 
+		// The server may have a recommendation on when we should retry,
+		// which we follow unless we'd back off for longer anyway.
+		var retryAfter time.Duration
 		if h := resp.Header.Get("Retry-After"); h != "" {
-			// The server has a recommendation on when we should
-			// retry. Follow it.
 			if secs, err := strconv.Atoi(h); err == nil && secs > 0 {
 				l.Debugln(c, "announce Retry-After:", secs, err)
-				timer.Reset(time.Duration(secs) * time.Second)
-				return
+				retryAfter = time.Duration(secs) * time.Second
 			}
 		}
 
-		timer.Reset(announceErrorRetryInterval)
+		timer.Reset(c.announceRate.Failure(retryAfter))
 		return
 	}
 
@@ -302,12 +319,21 @@ func (c *globalClient) sendAnnouncement(ctx context.Context, timer *time.Timer)
 		// reannounce. Follow it.
 		if secs, err := strconv.Atoi(h); err == nil && secs > 0 {
 			l.Debugln(c, "announce Reannounce-After:", secs, err)
-			timer.Reset(time.Duration(secs) * time.Second)
+			timer.Reset(c.announceRate.Success(time.Duration(secs) * time.Second))
 			return
 		}
 	}
 
-	timer.Reset(defaultReannounceInterval)
+	timer.Reset(c.announceRate.Success(defaultReannounceInterval))
+}
+
+// NextAttempt returns when the next announcement is due, or the zero time
+// if there is none scheduled.
+func (c *globalClient) NextAttempt() time.Time {
+	if c.noAnnounce {
+		return time.Time{}
+	}
+	return c.announceRate.NextAttempt()
 }
 
 func (*globalClient) Cache() map[protocol.DeviceID]CacheEntry {
@@ -36,6 +36,7 @@ import (
 type Manager interface {
 	FinderService
 	ChildErrors() map[string]error
+	ChildNextAttempts() map[string]time.Time
 }
 
 type manager struct {
@@ -185,6 +186,22 @@ func (m *manager) ChildErrors() map[string]error {
 	return children
 }
 
+// ChildNextAttempts returns when the children that announce on a schedule
+// make their next attempt.
+func (m *manager) ChildNextAttempts() map[string]time.Time {
+	children := make(map[string]time.Time, len(m.finders))
+	m.mut.RLock()
+	for _, f := range m.finders {
+		if s, ok := f.Finder.(interface{ NextAttempt() time.Time }); ok {
+			if next := s.NextAttempt(); !next.IsZero() {
+				children[f.String()] = next
+			}
+		}
+	}
+	m.mut.RUnlock()
+	return children
+}
+
 func (m *manager) Cache() map[protocol.DeviceID]CacheEntry {
 	// Res will be the "total" cache, i.e. the union of our cache and all our
 	// children's caches.
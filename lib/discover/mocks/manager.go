@@ -4,6 +4,7 @@ package mocks
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/syncthing/syncthing/lib/discover"
 	"github.com/syncthing/syncthing/lib/protocol"
@@ -30,6 +31,16 @@ type Manager struct {
 	childErrorsReturnsOnCall map[int]struct {
 		result1 map[string]error
 	}
+	ChildNextAttemptsStub        func() map[string]time.Time
+	childNextAttemptsMutex       sync.RWMutex
+	childNextAttemptsArgsForCall []struct {
+	}
+	childNextAttemptsReturns struct {
+		result1 map[string]time.Time
+	}
+	childNextAttemptsReturnsOnCall map[int]struct {
+		result1 map[string]time.Time
+	}
 	ErrorStub        func() error
 	errorMutex       sync.RWMutex
 	errorArgsForCall []struct {
@@ -185,6 +196,59 @@ func (fake *Manager) ChildErrorsReturnsOnCall(i int, result1 map[string]error) {
 	}{result1}
 }
 
+func (fake *Manager) ChildNextAttempts() map[string]time.Time {
+	fake.childNextAttemptsMutex.Lock()
+	ret, specificReturn := fake.childNextAttemptsReturnsOnCall[len(fake.childNextAttemptsArgsForCall)]
+	fake.childNextAttemptsArgsForCall = append(fake.childNextAttemptsArgsForCall, struct {
+	}{})
+	stub := fake.ChildNextAttemptsStub
+	fakeReturns := fake.childNextAttemptsReturns
+	fake.recordInvocation("ChildNextAttempts", []interface{}{})
+	fake.childNextAttemptsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Manager) ChildNextAttemptsCallCount() int {
+	fake.childNextAttemptsMutex.RLock()
+	defer fake.childNextAttemptsMutex.RUnlock()
+	return len(fake.childNextAttemptsArgsForCall)
+}
+
+func (fake *Manager) ChildNextAttemptsCalls(stub func() map[string]time.Time) {
+	fake.childNextAttemptsMutex.Lock()
+	defer fake.childNextAttemptsMutex.Unlock()
+	fake.ChildNextAttemptsStub = stub
+}
+
+func (fake *Manager) ChildNextAttemptsReturns(result1 map[string]time.Time) {
+	fake.childNextAttemptsMutex.Lock()
+	defer fake.childNextAttemptsMutex.Unlock()
+	fake.ChildNextAttemptsStub = nil
+	fake.childNextAttemptsReturns = struct {
+		result1 map[string]time.Time
+	}{result1}
+}
+
+func (fake *Manager) ChildNextAttemptsReturnsOnCall(i int, result1 map[string]time.Time) {
+	fake.childNextAttemptsMutex.Lock()
+	defer fake.childNextAttemptsMutex.Unlock()
+	fake.ChildNextAttemptsStub = nil
+	if fake.childNextAttemptsReturnsOnCall == nil {
+		fake.childNextAttemptsReturnsOnCall = make(map[int]struct {
+			result1 map[string]time.Time
+		})
+	}
+	fake.childNextAttemptsReturnsOnCall[i] = struct {
+		result1 map[string]time.Time
+	}{result1}
+}
+
 func (fake *Manager) Error() error {
 	fake.errorMutex.Lock()
 	ret, specificReturn := fake.errorReturnsOnCall[len(fake.errorArgsForCall)]
@@ -424,6 +488,8 @@ func (fake *Manager) Invocations() map[string][][]interface{} {
 	defer fake.cacheMutex.RUnlock()
 	fake.childErrorsMutex.RLock()
 	defer fake.childErrorsMutex.RUnlock()
+	fake.childNextAttemptsMutex.RLock()
+	defer fake.childNextAttemptsMutex.RUnlock()
 	fake.errorMutex.RLock()
 	defer fake.errorMutex.RUnlock()
 	fake.lookupMutex.RLock()
@@ -58,6 +58,24 @@ const (
 	ListenAddressesChanged
 	LoginAttempt
 	Failure
+	CorruptionDetected
+	ExpiryWarning
+	ConflictCreated
+	ConflictResolved
+	FolderReverted
+	PreallocationFailed
+	HealthReport
+	CertificateExpiring
+	ItemCacheWarmed
+	DirectoryCompleted
+	FolderCleanupScheduled
+	FolderCleanupDone
+	FolderMoveProgress
+	FolderMoveDone
+	FolderTypeDegraded
+	FolderTypeRestored
+	FolderScanSummary
+	DeprecationWarning
 
 	AllEvents = (1 << iota) - 1
 )
@@ -135,6 +153,42 @@ func (t EventType) String() string {
 		return "FolderWatchStateChanged"
 	case Failure:
 		return "Failure"
+	case CorruptionDetected:
+		return "CorruptionDetected"
+	case ExpiryWarning:
+		return "ExpiryWarning"
+	case ConflictCreated:
+		return "ConflictCreated"
+	case ConflictResolved:
+		return "ConflictResolved"
+	case FolderReverted:
+		return "FolderReverted"
+	case PreallocationFailed:
+		return "PreallocationFailed"
+	case HealthReport:
+		return "HealthReport"
+	case CertificateExpiring:
+		return "CertificateExpiring"
+	case ItemCacheWarmed:
+		return "ItemCacheWarmed"
+	case DirectoryCompleted:
+		return "DirectoryCompleted"
+	case FolderCleanupScheduled:
+		return "FolderCleanupScheduled"
+	case FolderCleanupDone:
+		return "FolderCleanupDone"
+	case FolderMoveProgress:
+		return "FolderMoveProgress"
+	case FolderMoveDone:
+		return "FolderMoveDone"
+	case FolderTypeDegraded:
+		return "FolderTypeDegraded"
+	case FolderTypeRestored:
+		return "FolderTypeRestored"
+	case FolderScanSummary:
+		return "FolderScanSummary"
+	case DeprecationWarning:
+		return "DeprecationWarning"
 	default:
 		return "Unknown"
 	}
@@ -222,6 +276,42 @@ func UnmarshalEventType(s string) EventType {
 		return FolderWatchStateChanged
 	case "Failure":
 		return Failure
+	case "CorruptionDetected":
+		return CorruptionDetected
+	case "ExpiryWarning":
+		return ExpiryWarning
+	case "ConflictCreated":
+		return ConflictCreated
+	case "ConflictResolved":
+		return ConflictResolved
+	case "FolderReverted":
+		return FolderReverted
+	case "PreallocationFailed":
+		return PreallocationFailed
+	case "HealthReport":
+		return HealthReport
+	case "CertificateExpiring":
+		return CertificateExpiring
+	case "ItemCacheWarmed":
+		return ItemCacheWarmed
+	case "DirectoryCompleted":
+		return DirectoryCompleted
+	case "FolderCleanupScheduled":
+		return FolderCleanupScheduled
+	case "FolderCleanupDone":
+		return FolderCleanupDone
+	case "FolderMoveProgress":
+		return FolderMoveProgress
+	case "FolderMoveDone":
+		return FolderMoveDone
+	case "FolderTypeDegraded":
+		return FolderTypeDegraded
+	case "FolderTypeRestored":
+		return FolderTypeRestored
+	case "FolderScanSummary":
+		return FolderScanSummary
+	case "DeprecationWarning":
+		return DeprecationWarning
 	default:
 		return 0
 	}
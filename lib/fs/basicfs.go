@@ -20,10 +20,14 @@ import (
 )
 
 var (
-	errInvalidFilenameEmpty               = errors.New("name is invalid, must not be empty")
-	errInvalidFilenameWindowsSpacePeriod  = errors.New("name is invalid, must not end in space or period on Windows")
-	errInvalidFilenameWindowsReservedName = errors.New("name is invalid, contains Windows reserved name")
-	errInvalidFilenameWindowsReservedChar = errors.New("name is invalid, contains Windows reserved character")
+	// ErrInvalidFilename is wrapped by all errors about names that aren't
+	// valid on the filesystem.
+	ErrInvalidFilename = errors.New("name is invalid")
+
+	errInvalidFilenameEmpty               = fmt.Errorf("%w, must not be empty", ErrInvalidFilename)
+	errInvalidFilenameWindowsSpacePeriod  = fmt.Errorf("%w, must not end in space or period on Windows", ErrInvalidFilename)
+	errInvalidFilenameWindowsReservedName = fmt.Errorf("%w, contains Windows reserved name", ErrInvalidFilename)
+	errInvalidFilenameWindowsReservedChar = fmt.Errorf("%w, contains Windows reserved character", ErrInvalidFilename)
 )
 
 type OptionJunctionsAsDirs struct{}
@@ -20,3 +20,10 @@ func (fi basicFileInfo) InodeChangeTime() time.Time {
 	}
 	return time.Time{}
 }
+
+func (fi basicFileInfo) Inode() uint64 {
+	if sys, ok := fi.FileInfo.Sys().(*syscall.Stat_t); ok {
+		return uint64(sys.Ino)
+	}
+	return 0
+}
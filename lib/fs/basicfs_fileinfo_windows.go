@@ -62,6 +62,10 @@ func (basicFileInfo) InodeChangeTime() time.Time {
 	return time.Time{}
 }
 
+func (basicFileInfo) Inode() uint64 {
+	return 0
+}
+
 // osFileInfo converts e to os.FileInfo that is suitable
 // to be passed to os.SameFile.
 func (e *basicFileInfo) osFileInfo() os.FileInfo {
@@ -0,0 +1,31 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"syscall"
+)
+
+// InodeGeneration returns the generation number of the inode of the given
+// file, which together with the inode number tells apart a file from one
+// that reuses its inode later on. It returns syscall.ENOTSUP when the
+// filesystem or platform doesn't keep generation numbers.
+func InodeGeneration(filesystem Filesystem, name string) (uint64, error) {
+	fs, ok := unwrapFilesystem(filesystem, filesystemWrapperTypeNone)
+	if !ok {
+		return 0, syscall.ENOTSUP
+	}
+	bfs, ok := fs.(*BasicFilesystem)
+	if !ok {
+		return 0, syscall.ENOTSUP
+	}
+	name, err := bfs.rooted(name)
+	if err != nil {
+		return 0, err
+	}
+	return inodeGeneration(name)
+}
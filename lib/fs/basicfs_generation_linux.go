@@ -0,0 +1,48 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build linux
+// +build linux
+
+package fs
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// fsIocGetVersion is FS_IOC_GETVERSION, i.e. _IOR('v', 1, long), which
+// x/sys doesn't define.
+var fsIocGetVersion = func() uint {
+	dirShift := 30
+	switch runtime.GOARCH {
+	case "mips", "mipsle", "mips64", "mips64le", "ppc64", "ppc64le":
+		dirShift = 29
+	}
+	const iocRead = 2
+	return iocRead<<dirShift | uint(unsafe.Sizeof(int(0)))<<16 | 'v'<<8 | 1
+}()
+
+func inodeGeneration(path string) (uint64, error) {
+	fd, err := unix.Open(path, unix.O_RDONLY|unix.O_NOFOLLOW|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer unix.Close(fd)
+	gen, err := unix.IoctlGetUint32(fd, fsIocGetVersion)
+	switch err {
+	case nil:
+		return uint64(gen), nil
+	case unix.ENOTTY, unix.EOPNOTSUPP, unix.EINVAL:
+		// Filesystems other than ext2/3/4, btrfs and a few others.
+		return 0, syscall.ENOTSUP
+	default:
+		return 0, err
+	}
+}
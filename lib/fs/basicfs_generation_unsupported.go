@@ -0,0 +1,18 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build !linux
+// +build !linux
+
+package fs
+
+import (
+	"syscall"
+)
+
+func inodeGeneration(string) (uint64, error) {
+	return 0, syscall.ENOTSUP
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build darwin && cgo
+// +build darwin,cgo
+
+package fs
+
+/*
+#cgo LDFLAGS: -framework CoreServices
+#include <stdlib.h>
+#include <CoreServices/CoreServices.h>
+#include <dispatch/dispatch.h>
+
+extern void journalEvents(uintptr_t handle, size_t n, char **paths, FSEventStreamEventFlags *flags);
+
+static void journalCallback(ConstFSEventStreamRef stream, void *info, size_t n, void *paths, const FSEventStreamEventFlags flags[], const FSEventStreamEventId ids[]) {
+	journalEvents((uintptr_t)info, n, (char **)paths, (FSEventStreamEventFlags *)flags);
+}
+
+// journalStart replays the events for root since the given event ID on the
+// queue, passing them to journalEvents with the handle.
+static FSEventStreamRef journalStart(uintptr_t handle, const char *root, FSEventStreamEventId since, dispatch_queue_t queue) {
+	CFStringRef path = CFStringCreateWithCString(NULL, root, kCFStringEncodingUTF8);
+	CFArrayRef paths = CFArrayCreate(NULL, (const void **)&path, 1, &kCFTypeArrayCallBacks);
+	FSEventStreamContext ctx = {0, (void *)handle, NULL, NULL, NULL};
+	FSEventStreamRef stream = FSEventStreamCreate(NULL, journalCallback, &ctx, paths, since, 0, kFSEventStreamCreateFlagFileEvents | kFSEventStreamCreateFlagNoDefer);
+	CFRelease(paths);
+	CFRelease(path);
+	if (stream == NULL) {
+		return NULL;
+	}
+	FSEventStreamSetDispatchQueue(stream, queue);
+	if (!FSEventStreamStart(stream)) {
+		FSEventStreamInvalidate(stream);
+		FSEventStreamRelease(stream);
+		return NULL;
+	}
+	return stream;
+}
+
+static void journalStop(FSEventStreamRef stream) {
+	FSEventStreamStop(stream);
+	FSEventStreamInvalidate(stream);
+	FSEventStreamRelease(stream);
+}
+
+static dispatch_queue_t journalQueue() {
+	return dispatch_queue_create("net.syncthing.journal", DISPATCH_QUEUE_SERIAL);
+}
+
+static void journalReleaseQueue(dispatch_queue_t queue) {
+	dispatch_release(queue);
+}
+
+// journalDeviceUUID returns the UUID of the FSEvents database of the
+// device, which changes when the database is reset, or NULL.
+static char *journalDeviceUUID(dev_t dev) {
+	CFUUIDRef uuid = FSEventsCopyUUIDForDevice(dev);
+	if (uuid == NULL) {
+		return NULL;
+	}
+	CFStringRef str = CFUUIDCreateString(NULL, uuid);
+	CFRelease(uuid);
+	char *buf = malloc(64);
+	if (!CFStringGetCString(str, buf, 64, kCFStringEncodingUTF8)) {
+		free(buf);
+		buf = NULL;
+	}
+	CFRelease(str);
+	return buf;
+}
+*/
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"runtime/cgo"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// journalHistoryTimeout is how long we wait for FSEvents to replay the
+// history of the folder.
+const journalHistoryTimeout = 5 * time.Minute
+
+// FSEvents event flags.
+const (
+	fseventsMustScanSubDirs = 0x00000001
+	fseventsEventIdsWrapped = 0x00000008
+	fseventsHistoryDone     = 0x00000010
+)
+
+// journalCollector gathers the paths of the replayed events.
+type journalCollector struct {
+	fs      *BasicFilesystem
+	root    string
+	changed map[string]struct{}
+	reset   bool
+	done    chan struct{}
+}
+
+func (c *journalCollector) add(path string, flags uint32) {
+	if c.done == nil {
+		// Events after the end of the history.
+		return
+	}
+	if flags&fseventsHistoryDone != 0 {
+		close(c.done)
+		c.done = nil
+		return
+	}
+	if flags&fseventsEventIdsWrapped != 0 {
+		c.reset = true
+		return
+	}
+	if rel, err := c.fs.unrootedChecked(strings.TrimRight(path, "/"), []string{c.root}); err == nil {
+		c.changed[rel] = struct{}{}
+	} else if flags&fseventsMustScanSubDirs != 0 && strings.HasPrefix(c.root, path) {
+		// Events were coalesced into a parent of the root.
+		c.changed["."] = struct{}{}
+	}
+}
+
+// fseventsPosition is a position in the FSEvents database of a device,
+// formatted as "fsevents:<database UUID>:<event ID>".
+type fseventsPosition struct {
+	uuid    string
+	eventID uint64
+}
+
+func (p fseventsPosition) String() string {
+	return fmt.Sprintf("fsevents:%s:%d", p.uuid, p.eventID)
+}
+
+func parseFSEventsPosition(s string) (fseventsPosition, error) {
+	var p fseventsPosition
+	fields := strings.Split(s, ":")
+	if len(fields) != 3 || fields[0] != "fsevents" {
+		return p, fmt.Errorf("invalid journal position %q", s)
+	}
+	p.uuid = fields[1]
+	if _, err := fmt.Sscanf(fields[2], "%d", &p.eventID); err != nil {
+		return p, fmt.Errorf("invalid journal position %q: %w", s, err)
+	}
+	return p, nil
+}
+
+func (f *BasicFilesystem) journalPosition() (string, error) {
+	// Take the event ID first, so nothing happening meanwhile is missed.
+	eventID := uint64(C.FSEventsGetCurrentEventId())
+	uuid, err := f.journalDeviceUUID()
+	if err != nil {
+		return "", err
+	}
+	return fseventsPosition{uuid: uuid, eventID: eventID}.String(), nil
+}
+
+func (f *BasicFilesystem) journalChanges(ctx context.Context, since string) ([]string, string, error) {
+	pos, err := parseFSEventsPosition(since)
+	if err != nil {
+		return nil, "", err
+	}
+	eventID := uint64(C.FSEventsGetCurrentEventId())
+	uuid, err := f.journalDeviceUUID()
+	if err != nil {
+		return nil, "", err
+	}
+	if uuid != pos.uuid || pos.eventID > eventID {
+		return nil, "", ErrJournalReset
+	}
+	end := fseventsPosition{uuid: uuid, eventID: eventID}
+
+	root, err := evalSymlinks(f.root)
+	if err != nil {
+		return nil, "", err
+	}
+	c := &journalCollector{
+		fs:      f,
+		root:    root,
+		changed: make(map[string]struct{}),
+		done:    make(chan struct{}),
+	}
+	done := c.done
+	handle := cgo.NewHandle(c)
+	defer handle.Delete()
+
+	croot := C.CString(root)
+	defer C.free(unsafe.Pointer(croot))
+	queue := C.journalQueue()
+	defer C.journalReleaseQueue(queue)
+	stream := C.journalStart(C.uintptr_t(handle), croot, C.FSEventStreamEventId(pos.eventID), queue)
+	if stream == nil {
+		return nil, "", fmt.Errorf("%w: failed to start FSEvents stream", ErrJournalUnsupported)
+	}
+
+	timer := time.NewTimer(journalHistoryTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		C.journalStop(stream)
+		return nil, "", fmt.Errorf("replaying FSEvents history timed out after %v", journalHistoryTimeout)
+	case <-ctx.Done():
+		C.journalStop(stream)
+		return nil, "", ctx.Err()
+	}
+	// Stopping waits for the callbacks on the queue, so the collector
+	// isn't touched anymore afterwards.
+	C.journalStop(stream)
+
+	if c.reset {
+		return nil, "", ErrJournalReset
+	}
+	paths := make([]string, 0, len(c.changed))
+	for path := range c.changed {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, end.String(), nil
+}
+
+func (f *BasicFilesystem) journalDeviceUUID() (string, error) {
+	var st syscall.Stat_t
+	if err := syscall.Stat(f.root, &st); err != nil {
+		return "", err
+	}
+	cuuid := C.journalDeviceUUID(C.dev_t(st.Dev))
+	if cuuid == nil {
+		// No FSEvents database, e.g. on network volumes.
+		return "", ErrJournalUnsupported
+	}
+	defer C.free(unsafe.Pointer(cuuid))
+	return C.GoString(cuuid), nil
+}
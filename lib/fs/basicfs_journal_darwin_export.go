@@ -0,0 +1,33 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build darwin && cgo
+// +build darwin,cgo
+
+package fs
+
+// The exported callback lives in its own file, as a preamble can't define
+// any C functions when exporting Go ones.
+
+/*
+#include <CoreServices/CoreServices.h>
+*/
+import "C"
+
+import (
+	"runtime/cgo"
+	"unsafe"
+)
+
+//export journalEvents
+func journalEvents(handle C.uintptr_t, n C.size_t, paths **C.char, flags *C.FSEventStreamEventFlags) {
+	c := cgo.Handle(handle).Value().(*journalCollector)
+	cpaths := unsafe.Slice(paths, int(n))
+	cflags := unsafe.Slice(flags, int(n))
+	for i := range cpaths {
+		c.add(C.GoString(cpaths[i]), uint32(cflags[i]))
+	}
+}
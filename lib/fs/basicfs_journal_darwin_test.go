@@ -0,0 +1,137 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build darwin && cgo
+// +build darwin,cgo
+
+package fs
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFSEventsPosition(t *testing.T) {
+	pos := fseventsPosition{uuid: "0A1B2C3D-4E5F-6071-8293-A4B5C6D7E8F9", eventID: 123456}
+	parsed, err := parseFSEventsPosition(pos.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != pos {
+		t.Errorf("got %+v after round trip, expected %+v", parsed, pos)
+	}
+	for _, invalid := range []string{"", "fsevents:uuid", "usn:uuid:1", "fsevents:uuid:abc"} {
+		if _, err := parseFSEventsPosition(invalid); err == nil {
+			t.Errorf("expected %q to be invalid", invalid)
+		}
+	}
+}
+
+func TestJournalCollector(t *testing.T) {
+	c := &journalCollector{
+		fs:      newBasicFilesystem("/Users/someone/Sync"),
+		root:    "/Users/someone/Sync",
+		changed: make(map[string]struct{}),
+		done:    make(chan struct{}),
+	}
+	done := c.done
+	c.add("/Users/someone/Sync/foo/bar", 0)
+	c.add("/Users/someone/Synced/foo", 0)
+	c.add("/Users/someone/", fseventsMustScanSubDirs)
+	if _, ok := c.changed["foo/bar"]; !ok || len(c.changed) != 2 {
+		t.Errorf("unexpected changes %v", c.changed)
+	}
+	if _, ok := c.changed["."]; !ok {
+		t.Error("expected coalesced events in a parent to change the root")
+	}
+	if c.reset {
+		t.Error("unexpected reset")
+	}
+	c.add("", fseventsEventIdsWrapped)
+	if !c.reset {
+		t.Error("expected wrapped event IDs to reset the journal")
+	}
+
+	c.add("", fseventsHistoryDone)
+	select {
+	case <-done:
+	default:
+		t.Fatal("expected the end of the history to be signalled")
+	}
+	c.add("/Users/someone/Sync/later", 0)
+	if _, ok := c.changed["later"]; ok {
+		t.Error("event after the end of the history collected")
+	}
+}
+
+// journalTestFS returns a filesystem in a new directory, skipping the test
+// if the FSEvents database isn't available for it.
+func journalTestFS(t *testing.T) (Filesystem, string) {
+	t.Helper()
+	testFs := NewFilesystem(FilesystemTypeBasic, t.TempDir())
+	pos, err := JournalPosition(testFs)
+	if errors.Is(err, ErrJournalUnsupported) {
+		t.Skip("FSEvents database not available:", err)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	return testFs, pos
+}
+
+func TestJournalChanges(t *testing.T) {
+	testFs, pos := journalTestFS(t)
+
+	if err := testFs.MkdirAll(filepath.Join("dir", "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(testFs, filepath.Join("dir", "sub", "file"), []byte("data"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// FSEvents writes events to its database with some delay, so the
+	// replayed history may not have them right away.
+	expected := filepath.Join("dir", "sub", "file")
+	for i := 0; ; i++ {
+		changed, _, err := JournalChanges(context.Background(), testFs, pos)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, path := range changed {
+			found = found || path == expected
+		}
+		if found {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("expected %s to be reported as changed, got %v", expected, changed)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestJournalReset(t *testing.T) {
+	testFs, pos := journalTestFS(t)
+	parsed, err := parseFSEventsPosition(pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A reset database has another UUID, and event IDs after a reset
+	// start over, behind the position.
+	recreated := parsed
+	recreated.uuid = "00000000-0000-0000-0000-000000000000"
+	ahead := parsed
+	ahead.eventID = 1<<64 - 1
+	for _, reset := range []fseventsPosition{recreated, ahead} {
+		if _, _, err := JournalChanges(context.Background(), testFs, reset.String()); !errors.Is(err, ErrJournalReset) {
+			t.Errorf("expected %v for %v, got %v", ErrJournalReset, reset, err)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build !windows && !(darwin && cgo)
+// +build !windows
+// +build !darwin !cgo
+
+package fs
+
+import "context"
+
+func (*BasicFilesystem) journalPosition() (string, error) {
+	return "", ErrJournalUnsupported
+}
+
+func (*BasicFilesystem) journalChanges(context.Context, string) ([]string, string, error) {
+	return nil, "", ErrJournalUnsupported
+}
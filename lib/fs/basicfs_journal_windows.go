@@ -0,0 +1,313 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build windows
+// +build windows
+
+package fs
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	fsctlQueryUSNJournal = 0x000900f4
+	fsctlReadUSNJournal  = 0x000900bb
+
+	usnReadBufferSize = 64 << 10
+	// The offset of the file name in a USN_RECORD_V2, i.e. its size
+	// without the name.
+	usnRecordV2NameOffset = 60
+)
+
+var procOpenFileByID = windows.NewLazySystemDLL("kernel32.dll").NewProc("OpenFileById")
+
+// usnJournalData is USN_JOURNAL_DATA_V0.
+type usnJournalData struct {
+	UsnJournalID    uint64
+	FirstUsn        int64
+	NextUsn         int64
+	LowestValidUsn  int64
+	MaxUsn          int64
+	MaximumSize     uint64
+	AllocationDelta uint64
+}
+
+// readUSNJournalData is READ_USN_JOURNAL_DATA_V0, which gets us
+// USN_RECORD_V2 records.
+type readUSNJournalData struct {
+	StartUsn          int64
+	ReasonMask        uint32
+	ReturnOnlyOnClose uint32
+	Timeout           uint64
+	BytesToWaitFor    uint64
+	UsnJournalID      uint64
+}
+
+// fileIDDescriptor is FILE_ID_DESCRIPTOR, with the union sized for its
+// largest member.
+type fileIDDescriptor struct {
+	Size   uint32
+	Type   uint32
+	FileID uint64
+	_      uint64
+}
+
+// usnPosition is a position in the USN journal of a volume, formatted as
+// "usn:<drive letter>:<journal ID>:<USN>".
+type usnPosition struct {
+	volume    string
+	journalID uint64
+	usn       int64
+}
+
+func (p usnPosition) String() string {
+	return fmt.Sprintf("usn:%s:%x:%d", strings.TrimSuffix(p.volume, ":"), p.journalID, p.usn)
+}
+
+func parseUSNPosition(s string) (usnPosition, error) {
+	var p usnPosition
+	fields := strings.Split(s, ":")
+	if len(fields) != 4 || fields[0] != "usn" {
+		return p, fmt.Errorf("invalid journal position %q", s)
+	}
+	p.volume = fields[1] + ":"
+	if _, err := fmt.Sscanf(fields[2]+" "+fields[3], "%x %d", &p.journalID, &p.usn); err != nil {
+		return p, fmt.Errorf("invalid journal position %q: %w", s, err)
+	}
+	return p, nil
+}
+
+func (f *BasicFilesystem) journalPosition() (string, error) {
+	vol, volume, err := f.openVolume()
+	if err != nil {
+		return "", err
+	}
+	defer windows.CloseHandle(vol)
+	data, err := queryUSNJournal(vol)
+	if err != nil {
+		return "", err
+	}
+	return usnPosition{volume: volume, journalID: data.UsnJournalID, usn: data.NextUsn}.String(), nil
+}
+
+func (f *BasicFilesystem) journalChanges(ctx context.Context, since string) ([]string, string, error) {
+	pos, err := parseUSNPosition(since)
+	if err != nil {
+		return nil, "", err
+	}
+	vol, volume, err := f.openVolume()
+	if err != nil {
+		return nil, "", err
+	}
+	defer windows.CloseHandle(vol)
+	data, err := queryUSNJournal(vol)
+	if err != nil {
+		return nil, "", err
+	}
+	if !strings.EqualFold(volume, pos.volume) || data.UsnJournalID != pos.journalID || pos.usn < data.FirstUsn || pos.usn < data.LowestValidUsn {
+		return nil, "", ErrJournalReset
+	}
+	end := usnPosition{volume: volume, journalID: data.UsnJournalID, usn: data.NextUsn}
+
+	_, roots, err := f.watchPaths(".")
+	if err != nil {
+		return nil, "", err
+	}
+	resolver, err := newUSNPathResolver(f.root)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resolver.close()
+
+	changed := make(map[string]struct{})
+	buf := make([]byte, usnReadBufferSize)
+	read := readUSNJournalData{
+		StartUsn:     pos.usn,
+		ReasonMask:   0xffffffff,
+		UsnJournalID: data.UsnJournalID,
+	}
+	for read.StartUsn < end.usn {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
+		var n uint32
+		err := windows.DeviceIoControl(vol, fsctlReadUSNJournal, (*byte)(unsafe.Pointer(&read)), uint32(unsafe.Sizeof(read)), &buf[0], uint32(len(buf)), &n, nil)
+		if errors.Is(err, windows.ERROR_JOURNAL_ENTRY_DELETED) || errors.Is(err, windows.ERROR_JOURNAL_DELETE_IN_PROGRESS) {
+			return nil, "", ErrJournalReset
+		} else if err != nil {
+			return nil, "", fmt.Errorf("reading USN journal: %w", err)
+		}
+		if n < 8 {
+			break
+		}
+		next := int64(binary.LittleEndian.Uint64(buf))
+
+		for off := 8; off+usnRecordV2NameOffset <= int(n); {
+			size := int(binary.LittleEndian.Uint32(buf[off:]))
+			if size < usnRecordV2NameOffset || off+size > int(n) {
+				break
+			}
+			rec := buf[off : off+size]
+			off += size
+
+			if major := binary.LittleEndian.Uint16(rec[4:]); major != 2 {
+				// Volumes with 128 bit file IDs, e.g. ReFS.
+				return nil, "", ErrJournalUnsupported
+			}
+			if usn := int64(binary.LittleEndian.Uint64(rec[24:])); usn >= end.usn {
+				continue
+			}
+			dir, ok := resolver.path(binary.LittleEndian.Uint64(rec[16:]))
+			if !ok {
+				// The parent is gone. Its own removal is recorded
+				// with its parent, which we may resolve.
+				continue
+			}
+			nameLen := int(binary.LittleEndian.Uint16(rec[56:]))
+			nameOff := int(binary.LittleEndian.Uint16(rec[58:]))
+			if nameOff+nameLen > len(rec) {
+				continue
+			}
+			name := make([]uint16, nameLen/2)
+			for i := range name {
+				name[i] = binary.LittleEndian.Uint16(rec[nameOff+2*i:])
+			}
+			if rel, ok := journalRel(filepath.Join(dir, windows.UTF16ToString(name)), roots); ok {
+				changed[rel] = struct{}{}
+			}
+		}
+
+		if next <= read.StartUsn {
+			break
+		}
+		read.StartUsn = next
+	}
+
+	paths := make([]string, 0, len(changed))
+	for path := range changed {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, end.String(), nil
+}
+
+// openVolume opens the volume the filesystem is on, which usually requires
+// administrative privileges.
+func (f *BasicFilesystem) openVolume() (windows.Handle, string, error) {
+	volume := filepath.VolumeName(strings.TrimPrefix(f.root, `\\?\`))
+	if len(volume) != 2 || volume[1] != ':' {
+		// Network shares and the like.
+		return 0, "", ErrJournalUnsupported
+	}
+	path, err := windows.UTF16PtrFromString(`\\.\` + volume)
+	if err != nil {
+		return 0, "", err
+	}
+	h, err := windows.CreateFile(path, windows.GENERIC_READ, windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE, nil, windows.OPEN_EXISTING, 0, 0)
+	if err != nil {
+		return 0, "", fmt.Errorf("%w: opening volume %s: %v", ErrJournalUnsupported, volume, err)
+	}
+	return h, volume, nil
+}
+
+func queryUSNJournal(vol windows.Handle) (usnJournalData, error) {
+	var data usnJournalData
+	var n uint32
+	err := windows.DeviceIoControl(vol, fsctlQueryUSNJournal, nil, 0, (*byte)(unsafe.Pointer(&data)), uint32(unsafe.Sizeof(data)), &n, nil)
+	if errors.Is(err, windows.ERROR_JOURNAL_NOT_ACTIVE) || errors.Is(err, windows.ERROR_INVALID_FUNCTION) {
+		return data, ErrJournalUnsupported
+	} else if err != nil {
+		return data, fmt.Errorf("querying USN journal: %w", err)
+	}
+	return data, nil
+}
+
+// journalRel returns the path relative to the first root that contains it,
+// comparing case insensitively.
+func journalRel(absPath string, roots []string) (string, bool) {
+	lowerAbsPath := UnicodeLowercaseNormalized(absPath)
+	for _, root := range roots {
+		lowerRoot := strings.TrimRight(UnicodeLowercaseNormalized(root), string(PathSeparator)) + string(PathSeparator)
+		if lowerAbsPath+string(PathSeparator) == lowerRoot {
+			return ".", true
+		}
+		if strings.HasPrefix(lowerAbsPath, lowerRoot) {
+			return rel(absPath, root), true
+		}
+	}
+	return "", false
+}
+
+// The usnPathResolver finds the current path of directories by their file
+// reference number, caching the results.
+type usnPathResolver struct {
+	hint  windows.Handle
+	paths map[uint64]string
+}
+
+func newUSNPathResolver(root string) (*usnPathResolver, error) {
+	path, err := windows.UTF16PtrFromString(root)
+	if err != nil {
+		return nil, err
+	}
+	// Any handle on the volume will do as a hint for OpenFileById.
+	h, err := windows.CreateFile(path, windows.FILE_READ_ATTRIBUTES, windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE, nil, windows.OPEN_EXISTING, windows.FILE_FLAG_BACKUP_SEMANTICS, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &usnPathResolver{hint: h, paths: make(map[uint64]string)}, nil
+}
+
+func (r *usnPathResolver) path(id uint64) (string, bool) {
+	if path, ok := r.paths[id]; ok {
+		return path, path != ""
+	}
+	path, _ := r.resolve(id)
+	r.paths[id] = path
+	return path, path != ""
+}
+
+func (r *usnPathResolver) resolve(id uint64) (string, error) {
+	desc := fileIDDescriptor{FileID: id}
+	desc.Size = uint32(unsafe.Sizeof(desc))
+	ret, _, err := procOpenFileByID.Call(
+		uintptr(r.hint),
+		uintptr(unsafe.Pointer(&desc)),
+		uintptr(windows.FILE_READ_ATTRIBUTES),
+		uintptr(windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE),
+		0,
+		uintptr(windows.FILE_FLAG_BACKUP_SEMANTICS),
+	)
+	h := windows.Handle(ret)
+	if h == windows.InvalidHandle {
+		return "", err
+	}
+	defer windows.CloseHandle(h)
+
+	buf := make([]uint16, windows.MAX_LONG_PATH)
+	n, err := windows.GetFinalPathNameByHandle(h, &buf[0], uint32(len(buf)), 0)
+	if err != nil {
+		return "", err
+	}
+	if int(n) > len(buf) {
+		return "", windows.ERROR_INSUFFICIENT_BUFFER
+	}
+	return windows.UTF16ToString(buf[:n]), nil
+}
+
+func (r *usnPathResolver) close() {
+	windows.CloseHandle(r.hint)
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build windows
+// +build windows
+
+package fs
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestUSNPosition(t *testing.T) {
+	pos := usnPosition{volume: "C:", journalID: 0x1d2c3b4a, usn: 123456}
+	parsed, err := parseUSNPosition(pos.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != pos {
+		t.Errorf("got %+v after round trip, expected %+v", parsed, pos)
+	}
+	for _, invalid := range []string{"", "usn:C:1d2c", "fsevents:C:1:2", "usn:C:xyz:1", "usn:C:1:abc"} {
+		if _, err := parseUSNPosition(invalid); err == nil {
+			t.Errorf("expected %q to be invalid", invalid)
+		}
+	}
+}
+
+func TestJournalRel(t *testing.T) {
+	roots := []string{`C:\Users\Someone\Sync`}
+	cases := map[string]string{
+		`C:\Users\Someone\Sync`:             ".",
+		`c:\users\someone\sync\Foo`:         "Foo",
+		`C:\Users\Someone\Sync\Foo\Bar.txt`: `Foo\Bar.txt`,
+	}
+	for abs, expected := range cases {
+		if rel, ok := journalRel(abs, roots); !ok || rel != expected {
+			t.Errorf("journalRel(%q) = %q, %v, expected %q", abs, rel, ok, expected)
+		}
+	}
+	for _, abs := range []string{`C:\Users\Someone\Synced`, `D:\Users\Someone\Sync\Foo`} {
+		if rel, ok := journalRel(abs, roots); ok {
+			t.Errorf("journalRel(%q) = %q, expected it to be outside", abs, rel)
+		}
+	}
+}
+
+// journalTestFS returns a filesystem in a new directory, skipping the test
+// if the USN journal can't be read, which requires administrative
+// privileges.
+func journalTestFS(t *testing.T) (Filesystem, string) {
+	t.Helper()
+	testFs := NewFilesystem(FilesystemTypeBasic, t.TempDir())
+	pos, err := JournalPosition(testFs)
+	if errors.Is(err, ErrJournalUnsupported) {
+		t.Skip("USN journal not available:", err)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	return testFs, pos
+}
+
+func TestJournalChanges(t *testing.T) {
+	testFs, pos := journalTestFS(t)
+
+	if err := testFs.MkdirAll(filepath.Join("dir", "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(testFs, filepath.Join("dir", "sub", "file"), []byte("data"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(testFs, "removed", []byte("data"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := testFs.Remove("removed"); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, end, err := JournalChanges(context.Background(), testFs, pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[string]bool)
+	for _, path := range changed {
+		seen[path] = true
+	}
+	for _, path := range []string{"dir", filepath.Join("dir", "sub"), filepath.Join("dir", "sub", "file"), "removed"} {
+		if !seen[path] {
+			t.Errorf("expected %s to be reported as changed, got %v", path, changed)
+		}
+	}
+
+	// Nothing changed since the end position.
+	changed, _, err = JournalChanges(context.Background(), testFs, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("expected no changes, got %v", changed)
+	}
+}
+
+func TestJournalReset(t *testing.T) {
+	testFs, pos := journalTestFS(t)
+	parsed, err := parseUSNPosition(pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A recreated journal has another ID, and a trimmed one no longer
+	// reaches back to the position.
+	recreated := parsed
+	recreated.journalID++
+	trimmed := parsed
+	trimmed.usn = -1
+	for _, reset := range []usnPosition{recreated, trimmed} {
+		if _, _, err := JournalChanges(context.Background(), testFs, reset.String()); !errors.Is(err, ErrJournalReset) {
+			t.Errorf("expected %v for %v, got %v", ErrJournalReset, reset, err)
+		}
+	}
+}
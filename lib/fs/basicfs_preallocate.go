@@ -0,0 +1,23 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"syscall"
+)
+
+// Preallocate allocates disk space for the file up to the given size, so
+// that writing to it later cannot fail for lack of space, and extends the
+// file to that size. It returns syscall.ENOTSUP when the filesystem or
+// platform doesn't support allocating space up front.
+func Preallocate(fd File, size int64) error {
+	f, ok := unwrap(fd).(basicFile)
+	if !ok {
+		return syscall.ENOTSUP
+	}
+	return preallocate(f, size)
+}
@@ -673,3 +673,36 @@ func (testXattrFilter) Permit(name string) bool { return strings.HasPrefix(name,
 
 func (testXattrFilter) GetMaxSingleEntrySize() int { return 0 }
 func (testXattrFilter) GetMaxTotalSize() int       { return 0 }
+
+func TestPreallocate(t *testing.T) {
+	tfs, _ := setup(t)
+	fd, err := tfs.Create("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	const size = 1 << 20
+	if err := Preallocate(fd, size); errors.Is(err, syscall.ENOTSUP) || errors.Is(err, syscall.EOPNOTSUPP) {
+		t.Skip("preallocation not supported")
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	info, err := fd.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != size {
+		t.Errorf("expected size %d, got %d", size, info.Size())
+	}
+
+	// Other filesystems don't support it.
+	ffd, err := NewFilesystem(FilesystemTypeFake, rand.String(32)).Create("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ffd.Close()
+	if err := Preallocate(ffd, size); !errors.Is(err, syscall.ENOTSUP) {
+		t.Errorf("expected ENOTSUP, got %v", err)
+	}
+}
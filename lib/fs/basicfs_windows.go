@@ -377,3 +377,7 @@ func (f *BasicFilesystem) watchPaths(name string) (string, []string, error) {
 
 	return filepath.Join(absName, "..."), roots, nil
 }
+
+func isWriteProtected(err error) bool {
+	return errors.Is(err, windows.ERROR_WRITE_PROTECT)
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"os"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/chaos"
+)
+
+// The chaosFilesystem fails operations that open or modify files at the
+// rate set in the chaos package. It is only used when fault injection is
+// enabled in the build.
+type chaosFilesystem struct {
+	Filesystem
+}
+
+func (fs *chaosFilesystem) fail(op, name string) error {
+	if err := chaos.FSError(); err != nil {
+		l.Debugln(fs.Type(), fs.URI(), op, name, "failing by fault injection")
+		return &os.PathError{Op: op, Path: name, Err: err}
+	}
+	return nil
+}
+
+func (fs *chaosFilesystem) Chmod(name string, mode FileMode) error {
+	if err := fs.fail("chmod", name); err != nil {
+		return err
+	}
+	return fs.Filesystem.Chmod(name, mode)
+}
+
+func (fs *chaosFilesystem) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	if err := fs.fail("chtimes", name); err != nil {
+		return err
+	}
+	return fs.Filesystem.Chtimes(name, atime, mtime)
+}
+
+func (fs *chaosFilesystem) Create(name string) (File, error) {
+	if err := fs.fail("create", name); err != nil {
+		return nil, err
+	}
+	return fs.Filesystem.Create(name)
+}
+
+func (fs *chaosFilesystem) CreateSymlink(target, name string) error {
+	if err := fs.fail("symlink", name); err != nil {
+		return err
+	}
+	return fs.Filesystem.CreateSymlink(target, name)
+}
+
+func (fs *chaosFilesystem) Mkdir(name string, perm FileMode) error {
+	if err := fs.fail("mkdir", name); err != nil {
+		return err
+	}
+	return fs.Filesystem.Mkdir(name, perm)
+}
+
+func (fs *chaosFilesystem) MkdirAll(name string, perm FileMode) error {
+	if err := fs.fail("mkdir", name); err != nil {
+		return err
+	}
+	return fs.Filesystem.MkdirAll(name, perm)
+}
+
+func (fs *chaosFilesystem) Open(name string) (File, error) {
+	if err := fs.fail("open", name); err != nil {
+		return nil, err
+	}
+	return fs.Filesystem.Open(name)
+}
+
+func (fs *chaosFilesystem) OpenFile(name string, flags int, mode FileMode) (File, error) {
+	if err := fs.fail("open", name); err != nil {
+		return nil, err
+	}
+	return fs.Filesystem.OpenFile(name, flags, mode)
+}
+
+func (fs *chaosFilesystem) Remove(name string) error {
+	if err := fs.fail("remove", name); err != nil {
+		return err
+	}
+	return fs.Filesystem.Remove(name)
+}
+
+func (fs *chaosFilesystem) RemoveAll(name string) error {
+	if err := fs.fail("remove", name); err != nil {
+		return err
+	}
+	return fs.Filesystem.RemoveAll(name)
+}
+
+func (fs *chaosFilesystem) Rename(oldname, newname string) error {
+	if err := fs.fail("rename", oldname); err != nil {
+		return err
+	}
+	return fs.Filesystem.Rename(oldname, newname)
+}
+
+func (fs *chaosFilesystem) underlying() (Filesystem, bool) {
+	return fs.Filesystem, true
+}
+
+func (*chaosFilesystem) wrapperType() filesystemWrapperType {
+	return filesystemWrapperTypeChaos
+}
@@ -0,0 +1,574 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sha256"
+)
+
+// A deduplicated file is stored as a manifest, consisting of a header with
+// the magic and the size of the contents, followed by the hash and size of
+// each chunk of the contents in order. The chunks themselves are stored in
+// the pool, named by their hash.
+const (
+	dedupMagic      = "STDEDUP1"
+	dedupHeaderSize = len(dedupMagic) + 8
+	dedupEntrySize  = sha256.Size + 4
+)
+
+var (
+	// ErrNoDedupPool is returned by Deduplicate for filesystems without a
+	// pool.
+	ErrNoDedupPool = errors.New("filesystem has no deduplication pool")
+
+	errDedupChanged  = errors.New("file changed while being deduplicated")
+	errDedupReadOnly = errors.New("deduplicated file is opened read only")
+)
+
+type optionDedup struct {
+	pool Filesystem
+}
+
+// NewDedupOption makes the filesystem able to store the contents of files
+// in the given pool, where chunks with the same contents are stored only
+// once, regardless of how many files (of how many folders) contain them.
+// Files are moved to the pool with Deduplicate and can be read as usual
+// afterwards. Opening them for writing turns them into regular files
+// again.
+func NewDedupOption(pool Filesystem) Option {
+	return &optionDedup{
+		pool: pool,
+	}
+}
+
+func (o *optionDedup) apply(fs Filesystem) Filesystem {
+	return &dedupFS{
+		Filesystem: fs,
+		option:     o,
+	}
+}
+
+func (o *optionDedup) String() string {
+	return "dedup-" + o.pool.URI()
+}
+
+type dedupFS struct {
+	Filesystem
+	option *optionDedup
+}
+
+type dedupEntry struct {
+	hash [sha256.Size]byte
+	size int64
+}
+
+// Deduplicate moves the contents of the named file to the pool, leaving a
+// manifest in its place. The contents are split in chunks at the given
+// block boundaries, with anything after the last block in chunks of at
+// most protocol.MaxBlockSize. Files that are deduplicated already are left
+// alone.
+func Deduplicate(filesystem Filesystem, name string, blocks []protocol.BlockInfo) error {
+	dfs, ok := unwrapFilesystem(filesystem, filesystemWrapperTypeDedup)
+	if !ok {
+		return ErrNoDedupPool
+	}
+	return dfs.(*dedupFS).deduplicate(name, blocks)
+}
+
+func (f *dedupFS) deduplicate(name string, blocks []protocol.BlockInfo) error {
+	info, err := f.Filesystem.Lstat(name)
+	if err != nil {
+		return err
+	}
+	if !info.IsRegular() {
+		return fmt.Errorf("%s is not a regular file", name)
+	}
+	if _, ok, err := f.readManifest(name, info); err != nil {
+		return err
+	} else if ok {
+		return nil
+	}
+
+	fd, err := f.Filesystem.Open(name)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	var entries []dedupEntry
+	var offset int64
+	var buf []byte
+	store := func(size int64) error {
+		if int64(cap(buf)) < size {
+			buf = make([]byte, size)
+		}
+		buf = buf[:size]
+		if _, err := fd.ReadAt(buf, offset); err != nil {
+			return err
+		}
+		entry, err := f.storeChunk(buf)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+		offset += size
+		return nil
+	}
+	for _, b := range blocks {
+		if b.Offset != offset || offset+int64(b.Size) > info.Size() {
+			// The blocks don't describe this file, so the pieces
+			// wouldn't match those of other files anyway.
+			break
+		}
+		if err := store(int64(b.Size)); err != nil {
+			return err
+		}
+	}
+	for offset < info.Size() {
+		size := info.Size() - offset
+		if size > protocol.MaxBlockSize {
+			size = protocol.MaxBlockSize
+		}
+		if err := store(size); err != nil {
+			return err
+		}
+	}
+
+	// Write the manifest next to the file, and replace the file with it,
+	// unless it was changed in the meantime.
+	manifest := make([]byte, dedupHeaderSize, dedupHeaderSize+len(entries)*dedupEntrySize)
+	copy(manifest, dedupMagic)
+	binary.BigEndian.PutUint64(manifest[len(dedupMagic):], uint64(info.Size()))
+	for _, e := range entries {
+		manifest = append(manifest, e.hash[:]...)
+		manifest = binary.BigEndian.AppendUint32(manifest, uint32(e.size))
+	}
+	tempName := filepath.Join(filepath.Dir(name), TempName(filepath.Base(name)))
+	if err := WriteFile(f.Filesystem, tempName, manifest, info.Mode()); err != nil {
+		return err
+	}
+	if cur, err := fd.Stat(); err != nil || cur.Size() != info.Size() || !cur.ModTime().Equal(info.ModTime()) {
+		f.Filesystem.Remove(tempName)
+		return errDedupChanged
+	}
+	if err := f.Filesystem.Chtimes(tempName, info.ModTime(), info.ModTime()); err != nil {
+		f.Filesystem.Remove(tempName)
+		return err
+	}
+	if err := f.Filesystem.Rename(tempName, name); err != nil {
+		f.Filesystem.Remove(tempName)
+		return err
+	}
+	return nil
+}
+
+// storeChunk adds the data to the pool, unless it's there already.
+func (f *dedupFS) storeChunk(data []byte) (dedupEntry, error) {
+	entry := dedupEntry{
+		hash: sha256.Sum256(data),
+		size: int64(len(data)),
+	}
+	pool := f.option.pool
+	name := dedupChunkName(entry.hash)
+	if info, err := pool.Lstat(name); err == nil && info.Size() == entry.size {
+		// The chunk is in use again, which keeps it from being garbage
+		// collected before the manifest referencing it is in place.
+		now := time.Now()
+		return entry, pool.Chtimes(name, now, now)
+	}
+	if err := pool.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return entry, err
+	}
+	tempName := filepath.Join(filepath.Dir(name), TempName(filepath.Base(name)))
+	if err := WriteFile(pool, tempName, data, 0o644); err != nil {
+		return entry, err
+	}
+	if err := pool.Rename(tempName, name); err != nil {
+		pool.Remove(tempName)
+		return entry, err
+	}
+	return entry, nil
+}
+
+func dedupChunkName(hash [sha256.Size]byte) string {
+	return dedupChunkPath(hex.EncodeToString(hash[:]))
+}
+
+func dedupChunkPath(id string) string {
+	return filepath.Join(id[:2], id[2:])
+}
+
+// DedupPool gives access to the chunks in a deduplication pool, for
+// maintenance. Chunks are identified by the hex encoding of their hash.
+type DedupPool struct {
+	fs Filesystem
+}
+
+func NewDedupPool(pool Filesystem) *DedupPool {
+	return &DedupPool{fs: pool}
+}
+
+// Chunks calls fn with the ID, size and modification time of every chunk
+// in the pool. The modification time is when the chunk was last stored.
+func (p *DedupPool) Chunks(fn func(id string, size int64, modTime time.Time) error) error {
+	err := p.fs.Walk(".", func(path string, info FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsRegular() || IsTemporary(path) {
+			return nil
+		}
+		id := strings.ReplaceAll(path, string(PathSeparator), "")
+		if len(id) != 2*sha256.Size {
+			return nil
+		}
+		return fn(id, info.Size(), info.ModTime())
+	})
+	if IsNotExist(err) {
+		// Nothing has been stored yet
+		return nil
+	}
+	return err
+}
+
+// Verify returns whether the contents of the chunk match its ID.
+func (p *DedupPool) Verify(id string) (bool, error) {
+	fd, err := p.fs.Open(dedupChunkPath(id))
+	if err != nil {
+		return false, err
+	}
+	defer fd.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, fd); err != nil {
+		return false, err
+	}
+	return hex.EncodeToString(h.Sum(nil)) == id, nil
+}
+
+// Remove deletes the chunk from the pool.
+func (p *DedupPool) Remove(id string) error {
+	return p.fs.Remove(dedupChunkPath(id))
+}
+
+// DedupReferences calls fn with the name and the IDs of the chunks of
+// every deduplicated file in the filesystem.
+func DedupReferences(filesystem Filesystem, fn func(name string, chunks []string)) error {
+	dfs, ok := unwrapFilesystem(filesystem, filesystemWrapperTypeDedup)
+	if !ok {
+		return ErrNoDedupPool
+	}
+	f := dfs.(*dedupFS)
+	return NewWalkFilesystem(f.Filesystem).Walk(".", func(path string, info FileInfo, err error) error {
+		if err != nil {
+			if IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		m, ok, err := f.readManifest(path, info)
+		if err != nil || !ok {
+			// Unreadable files are for the scanner to report.
+			return nil
+		}
+		ids := make([]string, len(m.entries))
+		for i, e := range m.entries {
+			ids[i] = hex.EncodeToString(e.hash[:])
+		}
+		fn(path, ids)
+		return nil
+	})
+}
+
+// mayBeManifest returns false for files that can't be manifests, such
+// that those don't need to be read.
+func mayBeManifest(info FileInfo) bool {
+	size := info.Size()
+	return info.IsRegular() && size >= int64(dedupHeaderSize) && (size-int64(dedupHeaderSize))%int64(dedupEntrySize) == 0
+}
+
+// readManifest returns the size and chunks of the named file, and false if
+// it's not a manifest.
+func (f *dedupFS) readManifest(name string, info FileInfo) (*dedupManifest, bool, error) {
+	if !mayBeManifest(info) {
+		return nil, false, nil
+	}
+	fd, err := f.Filesystem.Open(name)
+	if err != nil {
+		return nil, false, err
+	}
+	defer fd.Close()
+	// Only the header is read from files that aren't manifests.
+	header := make([]byte, dedupHeaderSize)
+	if _, err := io.ReadFull(fd, header); err != nil {
+		return nil, false, err
+	}
+	if !bytes.Equal(header[:len(dedupMagic)], []byte(dedupMagic)) {
+		return nil, false, nil
+	}
+	bs, err := io.ReadAll(fd)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(bs)%dedupEntrySize != 0 {
+		return nil, false, fmt.Errorf("manifest of %s is corrupt", name)
+	}
+	m := &dedupManifest{
+		size: int64(binary.BigEndian.Uint64(header[len(dedupMagic):])),
+	}
+	var offset int64
+	for ; len(bs) > 0; bs = bs[dedupEntrySize:] {
+		var e dedupEntry
+		copy(e.hash[:], bs)
+		e.size = int64(binary.BigEndian.Uint32(bs[sha256.Size:]))
+		m.entries = append(m.entries, e)
+		m.offsets = append(m.offsets, offset)
+		offset += e.size
+	}
+	if offset != m.size {
+		return nil, false, fmt.Errorf("manifest of %s is corrupt", name)
+	}
+	return m, true, nil
+}
+
+type dedupManifest struct {
+	size    int64
+	entries []dedupEntry
+	offsets []int64
+}
+
+func (f *dedupFS) Lstat(name string) (FileInfo, error) {
+	info, err := f.Filesystem.Lstat(name)
+	if err != nil {
+		return nil, err
+	}
+	return f.contentInfo(name, info)
+}
+
+func (f *dedupFS) Stat(name string) (FileInfo, error) {
+	info, err := f.Filesystem.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	return f.contentInfo(name, info)
+}
+
+// contentInfo returns the info with the size of the contents, for
+// manifests.
+func (f *dedupFS) contentInfo(name string, info FileInfo) (FileInfo, error) {
+	m, ok, err := f.readManifest(name, info)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return info, nil
+	}
+	return dedupFileInfo{FileInfo: info, size: m.size}, nil
+}
+
+func (f *dedupFS) Open(name string) (File, error) {
+	return f.OpenFile(name, OptReadOnly, 0)
+}
+
+func (f *dedupFS) OpenFile(name string, flags int, mode FileMode) (File, error) {
+	writing := flags&(OptWriteOnly|OptReadWrite) != 0
+	if writing && flags&OptTruncate != 0 {
+		// Whatever is there is discarded anyway.
+		return f.Filesystem.OpenFile(name, flags, mode)
+	}
+
+	info, err := f.Filesystem.Stat(name)
+	if err != nil {
+		return f.Filesystem.OpenFile(name, flags, mode)
+	}
+	m, ok, err := f.readManifest(name, info)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return f.Filesystem.OpenFile(name, flags, mode)
+	}
+	if writing {
+		if err := f.restore(name, info, m); err != nil {
+			return nil, err
+		}
+		return f.Filesystem.OpenFile(name, flags, mode)
+	}
+	return &dedupFile{
+		fs:       f,
+		name:     name,
+		info:     dedupFileInfo{FileInfo: info, size: m.size},
+		manifest: m,
+	}, nil
+}
+
+// restore replaces the manifest with a regular file with the contents.
+func (f *dedupFS) restore(name string, info FileInfo, m *dedupManifest) error {
+	src := &dedupFile{fs: f, name: name, manifest: m}
+	tempName := filepath.Join(filepath.Dir(name), TempName(filepath.Base(name)))
+	dst, err := f.Filesystem.OpenFile(tempName, OptWriteOnly|OptCreate|OptTruncate, info.Mode())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		f.Filesystem.Remove(tempName)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		f.Filesystem.Remove(tempName)
+		return err
+	}
+	if err := f.Filesystem.Chtimes(tempName, info.ModTime(), info.ModTime()); err != nil {
+		f.Filesystem.Remove(tempName)
+		return err
+	}
+	return f.Filesystem.Rename(tempName, name)
+}
+
+func (f *dedupFS) SameFile(fi1, fi2 FileInfo) bool {
+	if d, ok := fi1.(dedupFileInfo); ok {
+		fi1 = d.FileInfo
+	}
+	if d, ok := fi2.(dedupFileInfo); ok {
+		fi2 = d.FileInfo
+	}
+	return f.Filesystem.SameFile(fi1, fi2)
+}
+
+func (f *dedupFS) Options() []Option {
+	return append(f.Filesystem.Options(), f.option)
+}
+
+func (f *dedupFS) underlying() (Filesystem, bool) {
+	return f.Filesystem, true
+}
+
+func (*dedupFS) wrapperType() filesystemWrapperType {
+	return filesystemWrapperTypeDedup
+}
+
+type dedupFileInfo struct {
+	FileInfo
+	size int64
+}
+
+func (d dedupFileInfo) Size() int64 {
+	return d.size
+}
+
+// A dedupFile reads the contents of a manifest from the pool.
+type dedupFile struct {
+	fs       *dedupFS
+	name     string
+	info     FileInfo
+	manifest *dedupManifest
+	mut      sync.Mutex
+	pos      int64
+}
+
+func (f *dedupFile) Name() string {
+	return f.name
+}
+
+func (*dedupFile) Close() error {
+	return nil
+}
+
+func (*dedupFile) Sync() error {
+	return nil
+}
+
+func (f *dedupFile) Stat() (FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *dedupFile) ReadAt(p []byte, off int64) (int, error) {
+	m := f.manifest
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		if pos >= m.size {
+			return n, io.EOF
+		}
+		// The chunk containing pos
+		idx := sort.Search(len(m.offsets), func(i int) bool { return m.offsets[i] > pos }) - 1
+		entry := m.entries[idx]
+		fd, err := f.fs.option.pool.Open(dedupChunkName(entry.hash))
+		if err != nil {
+			return n, err
+		}
+		want := p[n:]
+		if rem := m.offsets[idx] + entry.size - pos; int64(len(want)) > rem {
+			want = want[:rem]
+		}
+		read, err := fd.ReadAt(want, pos-m.offsets[idx])
+		fd.Close()
+		n += read
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (f *dedupFile) Read(p []byte) (int, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	n, err := f.ReadAt(p, f.pos)
+	f.pos += int64(n)
+	if errors.Is(err, io.EOF) && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (f *dedupFile) Seek(offset int64, whence int) (int64, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		offset += f.manifest.size
+	default:
+		return f.pos, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return f.pos, errors.New("negative position")
+	}
+	f.pos = offset
+	return offset, nil
+}
+
+func (*dedupFile) Write([]byte) (int, error) {
+	return 0, errDedupReadOnly
+}
+
+func (*dedupFile) WriteAt([]byte, int64) (int, error) {
+	return 0, errDedupReadOnly
+}
+
+func (*dedupFile) Truncate(int64) error {
+	return errDedupReadOnly
+}
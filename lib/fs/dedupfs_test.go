@@ -0,0 +1,114 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestDedupFS(t *testing.T) {
+	td := t.TempDir()
+	pool := NewFilesystem(FilesystemTypeBasic, filepath.Join(td, "pool"))
+	fs1 := NewFilesystem(FilesystemTypeBasic, filepath.Join(td, "one"), NewDedupOption(pool))
+	fs2 := NewFilesystem(FilesystemTypeBasic, filepath.Join(td, "two"), NewDedupOption(pool))
+
+	const blockSize = 128 << 10
+	shared := make([]byte, 3*blockSize)
+	rand.Read(shared)
+	// The second file shares the first two blocks, followed by a block
+	// and a tail of its own.
+	other := append(append([]byte(nil), shared[:2*blockSize]...), make([]byte, blockSize+100)...)
+	rand.Read(other[2*blockSize:])
+	blocks := func(size int) []protocol.BlockInfo {
+		var bs []protocol.BlockInfo
+		for off := 0; off+blockSize <= size; off += blockSize {
+			bs = append(bs, protocol.BlockInfo{Offset: int64(off), Size: blockSize})
+		}
+		return bs
+	}
+
+	mtime := time.Unix(1234567890, 0)
+	for _, tc := range []struct {
+		fs      Filesystem
+		name    string
+		content []byte
+	}{{fs1, "shared", shared}, {fs2, "shared", shared}, {fs2, "other", other}} {
+		name := tc.name
+		if err := tc.fs.MkdirAll(".", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteFile(tc.fs, name, tc.content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := tc.fs.Chtimes(name, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		if err := Deduplicate(tc.fs, name, blocks(len(tc.content))); err != nil {
+			t.Fatal(err)
+		}
+
+		info, err := tc.fs.Lstat(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() != int64(len(tc.content)) || !info.ModTime().Equal(mtime) {
+			t.Errorf("%s: got size %d and mtime %v after deduplication", name, info.Size(), info.ModTime())
+		}
+		fd, err := tc.fs.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bs, err := io.ReadAll(fd)
+		fd.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(bs, tc.content) {
+			t.Errorf("%s: content mismatch after deduplication", name)
+		}
+	}
+
+	// Three shared blocks, and the block and tail of the other file.
+	var chunks int
+	if err := pool.Walk(".", func(_ string, info FileInfo, err error) error {
+		if err == nil && info.IsRegular() {
+			chunks++
+		}
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if chunks != 5 {
+		t.Errorf("expected 5 chunks in the pool, got %d", chunks)
+	}
+
+	// Writing turns the file into a regular file again.
+	name := "shared"
+	fd, err := fs1.OpenFile(name, OptReadWrite, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fd.WriteAt([]byte("changed"), 0); err != nil {
+		t.Fatal(err)
+	}
+	fd.Close()
+	raw := NewFilesystem(FilesystemTypeBasic, filepath.Join(td, "one"))
+	info, err := raw.Lstat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != int64(len(shared)) {
+		t.Errorf("expected a regular file of %d bytes, got %d", len(shared), info.Size())
+	}
+}
@@ -0,0 +1,858 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miscreant/miscreant.go"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sha256"
+)
+
+const (
+	atRestChunkSize     = 64 << 10
+	atRestNonceSize     = chacha20poly1305.NonceSizeX
+	atRestOverhead      = atRestNonceSize + chacha20poly1305.Overhead
+	atRestDiskChunkSize = atRestChunkSize + atRestOverhead
+
+	// Encrypted names longer than this are split over directories, as
+	// done for untrusted devices, to stay within the name length limits
+	// of filesystems. Those directories have the extension added.
+	atRestMaxNameLength     = 200
+	atRestLongNameExtension = ".syncthing-long"
+)
+
+// ErrLocked is returned for all operations on a filesystem that is
+// encrypted at rest while the key is not available.
+var ErrLocked = errors.New("folder is encrypted at rest and locked")
+
+var (
+	errUndecryptableName = errors.New("name cannot be decrypted")
+	errIncompleteName    = errors.New("path leads to part of a long name")
+	atRestNameEncoding   = base32.HexEncoding.WithPadding(base32.NoPadding)
+	atRestSymlinkAD      = []byte("symlink")
+)
+
+type optionEncryption struct {
+	key        *[32]byte
+	markerName string
+}
+
+// NewEncryptionOption makes the filesystem store file names and contents
+// encrypted with the given key, while exposing them in plaintext. The
+// folder marker and the ignore file are stored as is. Without a key every
+// operation fails with ErrLocked.
+func NewEncryptionOption(key *[32]byte, markerName string) Option {
+	return &optionEncryption{
+		key:        key,
+		markerName: markerName,
+	}
+}
+
+func (o *optionEncryption) apply(fs Filesystem) Filesystem {
+	if o.key == nil {
+		return &errorFilesystem{
+			err:     ErrLocked,
+			fsType:  fs.Type(),
+			uri:     fs.URI(),
+			options: append(fs.Options(), o),
+		}
+	}
+	aead, err := chacha20poly1305.NewX(atRestSubkey(o.key, "content"))
+	if err != nil {
+		panic("bug: creating cipher: " + err.Error())
+	}
+	return &encryptedFS{
+		Filesystem: fs,
+		option:     o,
+		nameKey:    atRestSubkey(o.key, "names"),
+		content:    aead,
+	}
+}
+
+func (o *optionEncryption) String() string {
+	if o.key == nil {
+		return "encryption-locked"
+	}
+	// Filesystems with different keys must not be mistaken for each other,
+	// without the key itself becoming part of the string.
+	sum := sha256.Sum256(o.key[:])
+	return "encryption-" + hex.EncodeToString(sum[:8])
+}
+
+func atRestSubkey(key *[32]byte, purpose string) []byte {
+	subkey := make([]byte, 32)
+	kdf := hkdf.New(sha256.New, key[:], []byte("syncthing at rest"), []byte(purpose))
+	if _, err := io.ReadFull(kdf, subkey); err != nil {
+		panic("hkdf failure")
+	}
+	return subkey
+}
+
+// The encryptedFS encrypts every path component with AES-SIV and stores
+// file contents as a sequence of chunks, each encrypted with
+// XChaCha20-Poly1305 under a random nonce and bound to its position in the
+// file. Sizes reported by Stat and friends are those of the plaintext.
+type encryptedFS struct {
+	Filesystem
+	option  *optionEncryption
+	nameKey []byte
+	content cipher.AEAD
+}
+
+// isPlain returns true for the paths that are stored unencrypted, i.e. the
+// folder marker with its contents and the ignore file.
+func (f *encryptedFS) isPlain(name string) bool {
+	name = filepath.Clean(name)
+	first, _, _ := strings.Cut(name, string(PathSeparator))
+	return first == f.option.markerName || name == ".stignore"
+}
+
+// encryptName returns the encrypted name, which is split into several
+// path components if it's too long.
+func (f *encryptedFS) encryptName(name string) string {
+	aead, err := miscreant.NewAEAD("AES-SIV", f.nameKey, 0)
+	if err != nil {
+		panic("bug: creating cipher: " + err.Error())
+	}
+	enc := atRestNameEncoding.EncodeToString(aead.Seal(nil, nil, []byte(name), nil))
+	if len(enc) <= atRestMaxNameLength {
+		return enc
+	}
+	comps := make([]string, 0, len(enc)/atRestMaxNameLength+1)
+	for len(enc) > atRestMaxNameLength {
+		comps = append(comps, enc[:atRestMaxNameLength]+atRestLongNameExtension)
+		enc = enc[atRestMaxNameLength:]
+	}
+	comps = append(comps, enc)
+	return strings.Join(comps, string(PathSeparator))
+}
+
+// decryptName decrypts a name from encryptName, with the parts of a long
+// name already joined together.
+func (f *encryptedFS) decryptName(name string) (string, error) {
+	bs, err := atRestNameEncoding.DecodeString(name)
+	if err != nil {
+		return "", errUndecryptableName
+	}
+	aead, err := miscreant.NewAEAD("AES-SIV", f.nameKey, 0)
+	if err != nil {
+		return "", err
+	}
+	dec, err := aead.Open(nil, nil, bs, nil)
+	if err != nil {
+		return "", errUndecryptableName
+	}
+	return string(dec), nil
+}
+
+func (f *encryptedFS) encryptPath(name string) string {
+	name = filepath.Clean(name)
+	if name == "." || f.isPlain(name) {
+		return name
+	}
+	parts := strings.Split(name, string(PathSeparator))
+	for i, part := range parts {
+		if part == "" || part == "." || part == ".." {
+			// Leave these to the underlying filesystem to deal with, in
+			// particular to reject paths traversing upwards.
+			continue
+		}
+		parts[i] = f.encryptName(part)
+	}
+	return strings.Join(parts, string(PathSeparator))
+}
+
+func (f *encryptedFS) decryptPath(name string) (string, error) {
+	name = filepath.Clean(name)
+	if name == "." || f.isPlain(name) {
+		return name, nil
+	}
+	parts := strings.Split(name, string(PathSeparator))
+	dec := parts[:0]
+	long := ""
+	for _, part := range parts {
+		if part == "" || part == "." || part == ".." {
+			dec = append(dec, part)
+			continue
+		}
+		if strings.HasSuffix(part, atRestLongNameExtension) {
+			long += strings.TrimSuffix(part, atRestLongNameExtension)
+			continue
+		}
+		d, err := f.decryptName(long + part)
+		if err != nil {
+			return "", err
+		}
+		long = ""
+		dec = append(dec, d)
+	}
+	if long != "" {
+		return "", errIncompleteName
+	}
+	return strings.Join(dec, string(PathSeparator)), nil
+}
+
+// longNameDirs returns the directories the last component of the encrypted
+// path is split over, from the outermost one.
+func longNameDirs(enc string) []string {
+	var dirs []string
+	for dir := filepath.Dir(enc); strings.HasSuffix(filepath.Base(dir), atRestLongNameExtension); dir = filepath.Dir(dir) {
+		dirs = append([]string{dir}, dirs...)
+	}
+	return dirs
+}
+
+// createLongNameDirs creates the directories needed to create the encrypted
+// path, if its name is a long one.
+func (f *encryptedFS) createLongNameDirs(enc string) error {
+	for _, dir := range longNameDirs(enc) {
+		if err := f.Filesystem.Mkdir(dir, 0o777); err != nil && !IsExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeLongNameDirs removes the directories of a long name that was
+// removed or failed to be created, as far as they are empty.
+func (f *encryptedFS) removeLongNameDirs(enc string) {
+	dirs := longNameDirs(enc)
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := f.Filesystem.Remove(dirs[i]); err != nil {
+			return
+		}
+	}
+}
+
+// longNames returns the joined together parts of all long names within
+// the given directory of a long name.
+func (f *encryptedFS) longNames(dir, prefix string) ([]string, error) {
+	names, err := f.Filesystem.DirNames(dir)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, n := range names {
+		if !strings.HasSuffix(n, atRestLongNameExtension) {
+			res = append(res, prefix+n)
+			continue
+		}
+		more, err := f.longNames(filepath.Join(dir, n), prefix+strings.TrimSuffix(n, atRestLongNameExtension))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, more...)
+	}
+	return res, nil
+}
+
+func (f *encryptedFS) Chmod(name string, mode FileMode) error {
+	return f.Filesystem.Chmod(f.encryptPath(name), mode)
+}
+
+func (f *encryptedFS) Lchown(name, uid, gid string) error {
+	return f.Filesystem.Lchown(f.encryptPath(name), uid, gid)
+}
+
+func (f *encryptedFS) Chtimes(name string, atime, mtime time.Time) error {
+	return f.Filesystem.Chtimes(f.encryptPath(name), atime, mtime)
+}
+
+func (f *encryptedFS) Create(name string) (File, error) {
+	return f.OpenFile(name, OptReadWrite|OptCreate|OptTruncate, 0o666)
+}
+
+func (f *encryptedFS) CreateSymlink(target, name string) error {
+	if f.isPlain(name) {
+		return f.Filesystem.CreateSymlink(target, name)
+	}
+	aead, err := miscreant.NewAEAD("AES-SIV", f.nameKey, 0)
+	if err != nil {
+		return err
+	}
+	enc := atRestNameEncoding.EncodeToString(aead.Seal(nil, nil, []byte(target), atRestSymlinkAD))
+	path := f.encryptPath(name)
+	if err := f.createLongNameDirs(path); err != nil {
+		return err
+	}
+	if err := f.Filesystem.CreateSymlink(enc, path); err != nil {
+		f.removeLongNameDirs(path)
+		return err
+	}
+	return nil
+}
+
+func (f *encryptedFS) DirNames(name string) ([]string, error) {
+	path := f.encryptPath(name)
+	names, err := f.Filesystem.DirNames(path)
+	if err != nil {
+		return nil, err
+	}
+	root := filepath.Clean(name) == "."
+	dec := make([]string, 0, len(names))
+	for _, n := range names {
+		if root && f.isPlain(n) {
+			dec = append(dec, n)
+			continue
+		}
+		encNames := []string{n}
+		if strings.HasSuffix(n, atRestLongNameExtension) {
+			encNames, err = f.longNames(filepath.Join(path, n), strings.TrimSuffix(n, atRestLongNameExtension))
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, n := range encNames {
+			// Anything that doesn't decrypt wasn't written through us
+			// and isn't part of the folder.
+			if d, err := f.decryptName(n); err == nil {
+				dec = append(dec, d)
+			}
+		}
+	}
+	return dec, nil
+}
+
+func (f *encryptedFS) Lstat(name string) (FileInfo, error) {
+	info, err := f.Filesystem.Lstat(f.encryptPath(name))
+	if err != nil {
+		return nil, err
+	}
+	return f.plainInfo(name, info), nil
+}
+
+func (f *encryptedFS) Stat(name string) (FileInfo, error) {
+	info, err := f.Filesystem.Stat(f.encryptPath(name))
+	if err != nil {
+		return nil, err
+	}
+	return f.plainInfo(name, info), nil
+}
+
+func (f *encryptedFS) plainInfo(name string, info FileInfo) FileInfo {
+	if f.isPlain(name) {
+		return info
+	}
+	size := info.Size()
+	if info.IsRegular() {
+		size = atRestPlainSize(size)
+	}
+	return encryptedFileInfo{
+		FileInfo: info,
+		name:     filepath.Base(name),
+		size:     size,
+	}
+}
+
+func (f *encryptedFS) Mkdir(name string, perm FileMode) error {
+	path := f.encryptPath(name)
+	if err := f.createLongNameDirs(path); err != nil {
+		return err
+	}
+	if err := f.Filesystem.Mkdir(path, perm); err != nil {
+		f.removeLongNameDirs(path)
+		return err
+	}
+	return nil
+}
+
+func (f *encryptedFS) MkdirAll(name string, perm FileMode) error {
+	return f.Filesystem.MkdirAll(f.encryptPath(name), perm)
+}
+
+func (f *encryptedFS) Open(name string) (File, error) {
+	return f.OpenFile(name, OptReadOnly, 0)
+}
+
+func (f *encryptedFS) OpenFile(name string, flags int, mode FileMode) (File, error) {
+	if f.isPlain(name) {
+		return f.Filesystem.OpenFile(name, flags, mode)
+	}
+	// Partial writes need to read the surrounding chunk, and appending is
+	// done by ourselves as the underlying file doesn't allow positioned
+	// writes in append mode.
+	underFlags := flags &^ (OptAppend | OptWriteOnly)
+	if flags&(OptWriteOnly|OptReadWrite) != 0 {
+		underFlags |= OptReadWrite
+	}
+	path := f.encryptPath(name)
+	if flags&OptCreate != 0 {
+		if err := f.createLongNameDirs(path); err != nil {
+			return nil, err
+		}
+	}
+	fd, err := f.Filesystem.OpenFile(path, underFlags, mode)
+	if err != nil {
+		if flags&OptCreate != 0 {
+			f.removeLongNameDirs(path)
+		}
+		return nil, err
+	}
+	return &encryptedFile{
+		next:   fd,
+		fs:     f,
+		name:   name,
+		append: flags&OptAppend != 0,
+	}, nil
+}
+
+func (f *encryptedFS) ReadSymlink(name string) (string, error) {
+	target, err := f.Filesystem.ReadSymlink(f.encryptPath(name))
+	if err != nil || f.isPlain(name) {
+		return target, err
+	}
+	bs, err := atRestNameEncoding.DecodeString(target)
+	if err != nil {
+		return "", errUndecryptableName
+	}
+	aead, err := miscreant.NewAEAD("AES-SIV", f.nameKey, 0)
+	if err != nil {
+		return "", err
+	}
+	dec, err := aead.Open(nil, nil, bs, atRestSymlinkAD)
+	if err != nil {
+		return "", errUndecryptableName
+	}
+	return string(dec), nil
+}
+
+func (f *encryptedFS) Remove(name string) error {
+	path := f.encryptPath(name)
+	if err := f.Filesystem.Remove(path); err != nil {
+		return err
+	}
+	f.removeLongNameDirs(path)
+	return nil
+}
+
+func (f *encryptedFS) RemoveAll(name string) error {
+	path := f.encryptPath(name)
+	if err := f.Filesystem.RemoveAll(path); err != nil {
+		return err
+	}
+	f.removeLongNameDirs(path)
+	return nil
+}
+
+func (f *encryptedFS) Rename(oldname, newname string) error {
+	oldpath, newpath := f.encryptPath(oldname), f.encryptPath(newname)
+	if err := f.createLongNameDirs(newpath); err != nil {
+		return err
+	}
+	if err := f.Filesystem.Rename(oldpath, newpath); err != nil {
+		f.removeLongNameDirs(newpath)
+		return err
+	}
+	f.removeLongNameDirs(oldpath)
+	return nil
+}
+
+func (f *encryptedFS) Watch(name string, ignore Matcher, ctx context.Context, ignorePerms bool) (<-chan Event, <-chan error, error) {
+	events, errs, err := f.Filesystem.Watch(f.encryptPath(name), &encryptedMatcher{ignore, f}, ctx, ignorePerms)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := make(chan Event)
+	go func() {
+		for {
+			select {
+			case ev := <-events:
+				name, err := f.decryptPath(ev.Name)
+				if err != nil {
+					continue
+				}
+				select {
+				case out <- Event{Name: name, Type: ev.Type}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, errs, nil
+}
+
+func (f *encryptedFS) Hide(name string) error {
+	return f.Filesystem.Hide(f.encryptPath(name))
+}
+
+func (f *encryptedFS) Unhide(name string) error {
+	return f.Filesystem.Unhide(f.encryptPath(name))
+}
+
+// Glob only supports patterns in the last path component, as the
+// directories leading up to it are stored encrypted.
+func (f *encryptedFS) Glob(pattern string) ([]string, error) {
+	dir, base := filepath.Split(pattern)
+	if strings.ContainsAny(dir, "*?[") {
+		return nil, filepath.ErrBadPattern
+	}
+	names, err := f.DirNames(dir)
+	if IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var matches []string
+	for _, name := range names {
+		ok, err := filepath.Match(base, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matches = append(matches, filepath.Join(dir, name))
+		}
+	}
+	return matches, nil
+}
+
+func (f *encryptedFS) Usage(name string) (Usage, error) {
+	return f.Filesystem.Usage(f.encryptPath(name))
+}
+
+func (f *encryptedFS) Options() []Option {
+	return append(f.Filesystem.Options(), f.option)
+}
+
+func (f *encryptedFS) SameFile(fi1, fi2 FileInfo) bool {
+	if e, ok := fi1.(encryptedFileInfo); ok {
+		fi1 = e.FileInfo
+	}
+	if e, ok := fi2.(encryptedFileInfo); ok {
+		fi2 = e.FileInfo
+	}
+	return f.Filesystem.SameFile(fi1, fi2)
+}
+
+func (f *encryptedFS) PlatformData(name string, withOwnership, withXattrs bool, xattrFilter XattrFilter) (protocol.PlatformData, error) {
+	return f.Filesystem.PlatformData(f.encryptPath(name), withOwnership, withXattrs, xattrFilter)
+}
+
+func (f *encryptedFS) GetXattr(name string, xattrFilter XattrFilter) ([]protocol.Xattr, error) {
+	return f.Filesystem.GetXattr(f.encryptPath(name), xattrFilter)
+}
+
+func (f *encryptedFS) SetXattr(name string, xattrs []protocol.Xattr, xattrFilter XattrFilter) error {
+	return f.Filesystem.SetXattr(f.encryptPath(name), xattrs, xattrFilter)
+}
+
+func (f *encryptedFS) underlying() (Filesystem, bool) {
+	return f.Filesystem, true
+}
+
+func (*encryptedFS) wrapperType() filesystemWrapperType {
+	return filesystemWrapperTypeEncryption
+}
+
+// The encryptedMatcher lets the underlying filesystem match encrypted
+// names against plaintext patterns.
+type encryptedMatcher struct {
+	Matcher
+	fs *encryptedFS
+}
+
+func (m *encryptedMatcher) ShouldIgnore(name string) bool {
+	// The directories of a long name go with the entry they lead to.
+	for strings.HasSuffix(filepath.Base(name), atRestLongNameExtension) {
+		name = filepath.Dir(name)
+	}
+	dec, err := m.fs.decryptPath(name)
+	if err != nil {
+		return true
+	}
+	return m.Matcher.ShouldIgnore(dec)
+}
+
+type encryptedFileInfo struct {
+	FileInfo
+	name string
+	size int64
+}
+
+func (e encryptedFileInfo) Name() string {
+	return e.name
+}
+
+func (e encryptedFileInfo) Size() int64 {
+	return e.size
+}
+
+// atRestPlainSize returns the plaintext size of a file with the given size
+// on disk.
+func atRestPlainSize(diskSize int64) int64 {
+	size := diskSize / atRestDiskChunkSize * atRestChunkSize
+	if rem := diskSize % atRestDiskChunkSize; rem > atRestOverhead {
+		size += rem - atRestOverhead
+	}
+	return size
+}
+
+// atRestDiskSize returns the size on disk of a file with the given
+// plaintext size.
+func atRestDiskSize(plainSize int64) int64 {
+	size := plainSize / atRestChunkSize * atRestDiskChunkSize
+	if rem := plainSize % atRestChunkSize; rem > 0 {
+		size += rem + atRestOverhead
+	}
+	return size
+}
+
+type encryptedFile struct {
+	next   File
+	fs     *encryptedFS
+	name   string
+	append bool
+	mut    sync.Mutex
+	pos    int64
+}
+
+func (f *encryptedFile) Name() string {
+	return f.name
+}
+
+func (f *encryptedFile) Close() error {
+	return f.next.Close()
+}
+
+func (f *encryptedFile) Sync() error {
+	return f.next.Sync()
+}
+
+func (f *encryptedFile) Stat() (FileInfo, error) {
+	info, err := f.next.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return encryptedFileInfo{
+		FileInfo: info,
+		name:     filepath.Base(f.name),
+		size:     atRestPlainSize(info.Size()),
+	}, nil
+}
+
+func (f *encryptedFile) sizeLocked() (int64, error) {
+	info, err := f.next.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return atRestPlainSize(info.Size()), nil
+}
+
+// readChunk returns the plaintext of the given chunk, which is empty past
+// the end of the file.
+func (f *encryptedFile) readChunk(idx int64) ([]byte, error) {
+	buf := make([]byte, atRestDiskChunkSize)
+	n, err := f.next.ReadAt(buf, idx*atRestDiskChunkSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	if n <= atRestOverhead {
+		return nil, io.ErrUnexpectedEOF
+	}
+	nonce, ct := buf[:atRestNonceSize], buf[atRestNonceSize:n]
+	return f.fs.content.Open(ct[:0], nonce, ct, chunkAD(idx))
+}
+
+func (f *encryptedFile) writeChunk(idx int64, plain []byte) error {
+	buf := make([]byte, atRestNonceSize, atRestNonceSize+len(plain)+chacha20poly1305.Overhead)
+	if _, err := rand.Read(buf); err != nil {
+		return err
+	}
+	buf = f.fs.content.Seal(buf, buf[:atRestNonceSize], plain, chunkAD(idx))
+	_, err := f.next.WriteAt(buf, idx*atRestDiskChunkSize)
+	return err
+}
+
+func chunkAD(idx int64) []byte {
+	var ad [8]byte
+	binary.BigEndian.PutUint64(ad[:], uint64(idx))
+	return ad[:]
+}
+
+func (f *encryptedFile) ReadAt(p []byte, off int64) (int, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	return f.readAtLocked(p, off)
+}
+
+func (f *encryptedFile) readAtLocked(p []byte, off int64) (int, error) {
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		chunk, err := f.readChunk(pos / atRestChunkSize)
+		if err != nil {
+			return n, err
+		}
+		within := int(pos % atRestChunkSize)
+		if within >= len(chunk) {
+			return n, io.EOF
+		}
+		n += copy(p[n:], chunk[within:])
+	}
+	return n, nil
+}
+
+func (f *encryptedFile) WriteAt(p []byte, off int64) (int, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	if f.append {
+		return 0, errors.New("WriteAt on file opened with O_APPEND")
+	}
+	return f.writeAtLocked(p, off)
+}
+
+func (f *encryptedFile) writeAtLocked(p []byte, off int64) (int, error) {
+	size, err := f.sizeLocked()
+	if err != nil {
+		return 0, err
+	}
+	if off > size {
+		if err := f.growLocked(size, off); err != nil {
+			return 0, err
+		}
+	}
+	return f.writeChunksLocked(p, off)
+}
+
+// writeChunksLocked writes p at off, which must not be past the end of the
+// file.
+func (f *encryptedFile) writeChunksLocked(p []byte, off int64) (int, error) {
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		idx := pos / atRestChunkSize
+		within := int(pos % atRestChunkSize)
+		part := p[n:]
+		if len(part) > atRestChunkSize-within {
+			part = part[:atRestChunkSize-within]
+		}
+		var chunk []byte
+		if within > 0 || len(part) < atRestChunkSize {
+			var err error
+			if chunk, err = f.readChunk(idx); err != nil {
+				return n, err
+			}
+		}
+		if end := within + len(part); end > len(chunk) {
+			chunk = append(chunk, make([]byte, end-len(chunk))...)
+		}
+		copy(chunk[within:], part)
+		if err := f.writeChunk(idx, chunk); err != nil {
+			return n, err
+		}
+		n += len(part)
+	}
+	return n, nil
+}
+
+// growLocked zero fills the file from size to the new size, one chunk at a
+// time.
+func (f *encryptedFile) growLocked(size, newSize int64) error {
+	var zeroes [atRestChunkSize]byte
+	for size < newSize {
+		n := atRestChunkSize - size%atRestChunkSize
+		if rem := newSize - size; rem < n {
+			n = rem
+		}
+		if _, err := f.writeChunksLocked(zeroes[:n], size); err != nil {
+			return err
+		}
+		size += n
+	}
+	return nil
+}
+
+func (f *encryptedFile) Read(p []byte) (int, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	n, err := f.readAtLocked(p, f.pos)
+	f.pos += int64(n)
+	return n, err
+}
+
+func (f *encryptedFile) Write(p []byte) (int, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	if f.append {
+		size, err := f.sizeLocked()
+		if err != nil {
+			return 0, err
+		}
+		f.pos = size
+	}
+	n, err := f.writeAtLocked(p, f.pos)
+	f.pos += int64(n)
+	return n, err
+}
+
+func (f *encryptedFile) Seek(offset int64, whence int) (int64, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		size, err := f.sizeLocked()
+		if err != nil {
+			return 0, err
+		}
+		offset += size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	f.pos = offset
+	return offset, nil
+}
+
+func (f *encryptedFile) Truncate(size int64) error {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	cur, err := f.sizeLocked()
+	if err != nil {
+		return err
+	}
+	if size >= cur {
+		return f.growLocked(cur, size)
+	}
+	// Re-encrypt the chunk that becomes the last one, as it is shortened.
+	if within := size % atRestChunkSize; within > 0 {
+		idx := size / atRestChunkSize
+		chunk, err := f.readChunk(idx)
+		if err != nil {
+			return err
+		}
+		if err := f.writeChunk(idx, chunk[:within]); err != nil {
+			return err
+		}
+	}
+	return f.next.Truncate(atRestDiskSize(size))
+}
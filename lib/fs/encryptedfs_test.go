@@ -0,0 +1,252 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newEncryptedTestFS(t *testing.T, dir string, key byte) Filesystem {
+	t.Helper()
+	var k [32]byte
+	k[0] = key
+	return NewFilesystem(FilesystemTypeBasic, dir, NewEncryptionOption(&k, ".stfolder"))
+}
+
+func TestEncryptedFS(t *testing.T) {
+	td := t.TempDir()
+	efs := newEncryptedTestFS(t, td, 1)
+
+	if err := efs.MkdirAll(filepath.Join("secret dir", "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := efs.Mkdir(".stfolder", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := []byte(strings.Repeat("confidential ", 20000))
+	name := filepath.Join("secret dir", "sub", "plans.txt")
+	if err := WriteFile(efs, name, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fd, err := efs.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, err := io.ReadAll(fd)
+	fd.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bs, content) {
+		t.Error("content mismatch after round trip")
+	}
+
+	info, err := efs.Lstat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != int64(len(content)) || info.Name() != "plans.txt" {
+		t.Errorf("got size %d and name %q, expected %d and plans.txt", info.Size(), info.Name(), len(content))
+	}
+
+	names, err := efs.DirNames(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 {
+		t.Errorf("expected the directory and the marker, got %v", names)
+	}
+
+	// On disk, nothing but the marker must be recognizable.
+	raw := NewFilesystem(FilesystemTypeBasic, td)
+	rawNames, err := raw.DirNames(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range rawNames {
+		if n != ".stfolder" && strings.Contains(n, "secret") {
+			t.Errorf("plaintext name %q on disk", n)
+		}
+	}
+	err = raw.Walk(".", func(path string, info FileInfo, err error) error {
+		if err != nil || !info.IsRegular() {
+			return err
+		}
+		bs, err := os.ReadFile(filepath.Join(td, path))
+		if err != nil {
+			return err
+		}
+		if bytes.Contains(bs, []byte("confidential")) {
+			t.Errorf("plaintext content in %v", path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// With the wrong key the files are not visible.
+	other := newEncryptedTestFS(t, td, 2)
+	names, err = other.DirNames(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != ".stfolder" {
+		t.Errorf("expected only the marker with the wrong key, got %v", names)
+	}
+}
+
+func TestEncryptedFSLongNames(t *testing.T) {
+	td := t.TempDir()
+	efs := newEncryptedTestFS(t, td, 1)
+
+	// Names of the maximum length most filesystems allow encrypt to more
+	// than that.
+	dir := strings.Repeat("d", 255)
+	name := filepath.Join(dir, strings.Repeat("f", 255))
+	if err := efs.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(efs, name, []byte("content"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := efs.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, err := io.ReadAll(fd)
+	fd.Close()
+	if err != nil || string(bs) != "content" {
+		t.Fatalf("got %q, %v reading back the file", bs, err)
+	}
+	if names, err := efs.DirNames(dir); err != nil || len(names) != 1 || names[0] != filepath.Base(name) {
+		t.Errorf("expected the file to be listed, got %v, %v", names, err)
+	}
+
+	raw := NewFilesystem(FilesystemTypeBasic, td)
+	checkLengths := func() {
+		t.Helper()
+		err := raw.Walk(".", func(path string, info FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if len(info.Name()) > atRestMaxNameLength+len(atRestLongNameExtension) {
+				t.Errorf("name of %d characters on disk", len(info.Name()))
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkLengths()
+
+	// Renaming and removing doesn't leave directories behind.
+	renamed := filepath.Join(dir, strings.Repeat("r", 255))
+	if err := efs.Rename(name, renamed); err != nil {
+		t.Fatal(err)
+	}
+	if names, err := efs.DirNames(dir); err != nil || len(names) != 1 || names[0] != filepath.Base(renamed) {
+		t.Errorf("expected the renamed file to be listed, got %v, %v", names, err)
+	}
+	checkLengths()
+	if err := efs.Remove(renamed); err != nil {
+		t.Fatal(err)
+	}
+	if err := efs.Remove(dir); err != nil {
+		t.Fatal(err)
+	}
+	if names, err := raw.DirNames("."); err != nil || len(names) != 0 {
+		t.Errorf("expected nothing to remain on disk, got %v, %v", names, err)
+	}
+}
+
+func TestEncryptedFSSymlink(t *testing.T) {
+	efs := newEncryptedTestFS(t, t.TempDir(), 1)
+	if !efs.SymlinksSupported() {
+		t.Skip("symlinks not supported")
+	}
+	if err := efs.CreateSymlink("../some/target", "link"); err != nil {
+		t.Fatal(err)
+	}
+	target, err := efs.ReadSymlink("link")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target != "../some/target" {
+		t.Errorf("got target %q", target)
+	}
+}
+
+func TestEncryptedFileRandomAccess(t *testing.T) {
+	efs := newEncryptedTestFS(t, t.TempDir(), 1)
+	fd, err := efs.Create("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	// Apply the same operations to the file and to a buffer, across chunk
+	// boundaries and past the end of the file.
+	rng := rand.New(rand.NewSource(42))
+	var expected []byte
+	for i := 0; i < 50; i++ {
+		if i%10 == 9 {
+			size := rng.Int63n(4 * atRestChunkSize)
+			if err := fd.Truncate(size); err != nil {
+				t.Fatal(err)
+			}
+			if size < int64(len(expected)) {
+				expected = expected[:size]
+			} else {
+				expected = append(expected, make([]byte, size-int64(len(expected)))...)
+			}
+			continue
+		}
+		data := make([]byte, rng.Intn(2*atRestChunkSize))
+		rng.Read(data)
+		off := rng.Int63n(3 * atRestChunkSize)
+		if _, err := fd.WriteAt(data, off); err != nil {
+			t.Fatal(err)
+		}
+		if end := off + int64(len(data)); end > int64(len(expected)) {
+			expected = append(expected, make([]byte, end-int64(len(expected)))...)
+		}
+		copy(expected[off:], data)
+	}
+
+	info, err := fd.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != int64(len(expected)) {
+		t.Fatalf("got size %d, expected %d", info.Size(), len(expected))
+	}
+	got := make([]byte, len(expected)+10)
+	n, err := fd.ReadAt(got, 0)
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("expected EOF reading past the end, got %v", err)
+	}
+	if !bytes.Equal(got[:n], expected) {
+		t.Error("content mismatch")
+	}
+}
+
+func TestEncryptedFSLocked(t *testing.T) {
+	lfs := NewFilesystem(FilesystemTypeBasic, t.TempDir(), NewEncryptionOption(nil, ".stfolder"))
+	if _, err := lfs.Create("file"); !errors.Is(err, ErrLocked) {
+		t.Errorf("expected ErrLocked, got %v", err)
+	}
+}
@@ -14,9 +14,10 @@ import (
 )
 
 type errorFilesystem struct {
-	err    error
-	fsType FilesystemType
-	uri    string
+	err     error
+	fsType  FilesystemType
+	uri     string
+	options []Option
 }
 
 func (fs *errorFilesystem) Chmod(_ string, _ FileMode) error { return fs.err }
@@ -53,8 +54,8 @@ func (fs *errorFilesystem) Roots() ([]string, error)                     { retur
 func (fs *errorFilesystem) Usage(_ string) (Usage, error)                { return Usage{}, fs.err }
 func (fs *errorFilesystem) Type() FilesystemType                         { return fs.fsType }
 func (fs *errorFilesystem) URI() string                                  { return fs.uri }
-func (*errorFilesystem) Options() []Option {
-	return nil
+func (fs *errorFilesystem) Options() []Option {
+	return fs.options
 }
 func (*errorFilesystem) SameFile(_, _ FileInfo) bool { return false }
 func (fs *errorFilesystem) Watch(_ string, _ Matcher, _ context.Context, _ bool) (<-chan Event, <-chan error, error) {
@@ -1009,3 +1009,7 @@ func (*fakeFileInfo) Sys() interface{} {
 func (*fakeFileInfo) InodeChangeTime() time.Time {
 	return time.Time{}
 }
+
+func (*fakeFileInfo) Inode() uint64 {
+	return 0
+}
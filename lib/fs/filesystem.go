@@ -14,8 +14,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/syncthing/syncthing/lib/chaos"
 	"github.com/syncthing/syncthing/lib/protocol"
 )
 
@@ -29,6 +31,9 @@ const (
 	filesystemWrapperTypeWalk
 	filesystemWrapperTypeLog
 	filesystemWrapperTypeMetrics
+	filesystemWrapperTypeChaos
+	filesystemWrapperTypeEncryption
+	filesystemWrapperTypeDedup
 )
 
 type XattrFilter interface {
@@ -111,6 +116,7 @@ type FileInfo interface {
 	Owner() int
 	Group() int
 	InodeChangeTime() time.Time // may be zero if not supported
+	Inode() uint64              // may be zero if not supported
 }
 
 // FileMode is similar to os.FileMode
@@ -216,6 +222,12 @@ func IsPermission(err error) bool {
 	return errors.Is(err, fs.ErrPermission)
 }
 
+// IsReadOnlyFilesystem returns whether the error is due to the filesystem
+// being mounted read-only or write protected.
+func IsReadOnlyFilesystem(err error) bool {
+	return errors.Is(err, syscall.EROFS) || isWriteProtected(err)
+}
+
 // IsPathSeparator is the equivalent of os.IsPathSeparator
 var IsPathSeparator = os.IsPathSeparator
 
@@ -233,16 +245,25 @@ type Option interface {
 func NewFilesystem(fsType FilesystemType, uri string, opts ...Option) Filesystem {
 	var caseOpt Option
 	var mtimeOpt Option
+	var encryptionOpt Option
+	var dedupOpt Option
+	var runAsOpt *OptionRunAsUser
+	var sandboxOpt *OptionSandbox
 	i := 0
 	for _, opt := range opts {
-		if caseOpt != nil && mtimeOpt != nil {
-			break
-		}
-		switch opt.(type) {
+		switch o := opt.(type) {
 		case *OptionDetectCaseConflicts:
 			caseOpt = opt
 		case *optionMtime:
 			mtimeOpt = opt
+		case *optionEncryption:
+			encryptionOpt = opt
+		case *optionDedup:
+			dedupOpt = opt
+		case *OptionRunAsUser:
+			runAsOpt = o
+		case *OptionSandbox:
+			sandboxOpt = o
 		default:
 			opts[i] = opt
 			i++
@@ -253,7 +274,20 @@ func NewFilesystem(fsType FilesystemType, uri string, opts ...Option) Filesystem
 	var fs Filesystem
 	switch fsType {
 	case FilesystemTypeBasic:
-		fs = newBasicFilesystem(uri, opts...)
+		if runAsOpt != nil || sandboxOpt != nil {
+			var username string
+			helperOpts := opts
+			if runAsOpt != nil {
+				username = runAsOpt.User
+				helperOpts = append(helperOpts, runAsOpt)
+			}
+			if sandboxOpt != nil {
+				helperOpts = append(helperOpts, sandboxOpt)
+			}
+			fs = newHelperFilesystem(uri, username, sandboxOpt != nil, helperOpts...)
+		} else {
+			fs = newBasicFilesystem(uri, opts...)
+		}
 	case FilesystemTypeFake:
 		fs = newFakeFilesystem(uri, opts...)
 	default:
@@ -265,7 +299,18 @@ func NewFilesystem(fsType FilesystemType, uri string, opts ...Option) Filesystem
 		}
 	}
 
-	// Case handling is the innermost, as any filesystem calls by wrappers should be case-resolved
+	// Deduplication deals in the data as stored on disk
+	if dedupOpt != nil {
+		fs = dedupOpt.apply(fs)
+	}
+
+	// Encryption is below everything else, as all other wrappers deal in
+	// plaintext names and contents
+	if encryptionOpt != nil {
+		fs = encryptionOpt.apply(fs)
+	}
+
+	// Case handling is the innermost of the remaining wrappers, as any filesystem calls by wrappers should be case-resolved
 	if caseOpt != nil {
 		fs = caseOpt.apply(fs)
 	}
@@ -276,6 +321,10 @@ func NewFilesystem(fsType FilesystemType, uri string, opts ...Option) Filesystem
 		fs = mtimeOpt.apply(fs)
 	}
 
+	if chaos.Enabled {
+		fs = &chaosFilesystem{fs}
+	}
+
 	fs = &metricsFS{next: fs}
 
 	if l.ShouldDebug("walkfs") {
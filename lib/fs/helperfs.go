@@ -0,0 +1,585 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"sync"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// The most data read in one request to the helper.
+const maxHelperReadSize = 16 << 20
+
+var (
+	errHelperExited       = errors.New("filesystem helper exited")
+	errSandboxUnsupported = errors.New("sandboxing filesystem access is not supported on this platform")
+)
+
+// OptionRunAsUser makes a basic filesystem perform all operations as the
+// given OS user, in a helper process started as that user. That requires
+// the privileges to switch users, i.e. usually running as root, and is
+// not supported on Windows.
+type OptionRunAsUser struct {
+	User string
+}
+
+func (*OptionRunAsUser) apply(fs Filesystem) Filesystem {
+	// Handled in NewFilesystem, as it replaces the basic filesystem.
+	return fs
+}
+
+func (o *OptionRunAsUser) String() string {
+	return "runAsUser-" + o.User
+}
+
+// OptionSandbox makes a basic filesystem perform all operations in a
+// helper process that can only access the files below the root, limiting
+// what a bug in handling paths or file contents can do. It's currently
+// supported on Linux only, using Landlock (kernel 5.13 and later).
+type OptionSandbox struct{}
+
+func (*OptionSandbox) apply(fs Filesystem) Filesystem {
+	// Handled in NewFilesystem, as it replaces the basic filesystem.
+	return fs
+}
+
+func (*OptionSandbox) String() string {
+	return "sandbox"
+}
+
+// helperFilesystem is a basic filesystem whose operations are performed by
+// a helper process, see ServeHelperProcess. Unsandboxed helpers are shared
+// by all filesystems of a user, sandboxed ones serve a single root.
+type helperFilesystem struct {
+	conn       *helperConn
+	root       string
+	uri        string
+	options    []Option
+	userCache  *userCache
+	groupCache *groupCache
+}
+
+func newHelperFilesystem(uri, username string, sandbox bool, opts ...Option) *helperFilesystem {
+	// The helper gets an absolute root, resolved the same way as for a
+	// basic filesystem, so tilde expansion and relative paths don't depend
+	// on the helper's environment.
+	root := newBasicFilesystem(uri).root
+	key := helperKey{user: username}
+	if sandbox {
+		key.sandbox = root
+	}
+	return &helperFilesystem{
+		conn:       globalHelperRegistry.get(key),
+		root:       root,
+		uri:        uri,
+		options:    opts,
+		userCache:  newValueCache(time.Hour, user.LookupId),
+		groupCache: newValueCache(time.Hour, user.LookupGroupId),
+	}
+}
+
+func (f *helperFilesystem) call(req *helperRequest) (*helperResponse, error) {
+	req.Root = f.root
+	resp, _, err := f.conn.call(nil, req)
+	return resp, err
+}
+
+func (f *helperFilesystem) Chmod(name string, mode FileMode) error {
+	_, err := f.call(&helperRequest{Op: helperOpChmod, Name: name, Mode: mode})
+	return err
+}
+
+func (f *helperFilesystem) Lchown(name, uid, gid string) error {
+	_, err := f.call(&helperRequest{Op: helperOpLchown, Name: name, UID: uid, GID: gid})
+	return err
+}
+
+func (f *helperFilesystem) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	_, err := f.call(&helperRequest{Op: helperOpChtimes, Name: name, Atime: atime, Mtime: mtime})
+	return err
+}
+
+func (f *helperFilesystem) Create(name string) (File, error) {
+	return f.openFile(&helperRequest{Op: helperOpCreate, Name: name})
+}
+
+func (f *helperFilesystem) Open(name string) (File, error) {
+	return f.openFile(&helperRequest{Op: helperOpOpen, Name: name})
+}
+
+func (f *helperFilesystem) OpenFile(name string, flags int, mode FileMode) (File, error) {
+	return f.openFile(&helperRequest{Op: helperOpOpenFile, Name: name, Flags: flags, Mode: mode})
+}
+
+func (f *helperFilesystem) openFile(req *helperRequest) (File, error) {
+	req.Root = f.root
+	resp, proc, err := f.conn.call(nil, req)
+	if err != nil {
+		return nil, err
+	}
+	return &helperFile{conn: f.conn, proc: proc, handle: resp.Handle, name: req.Name}, nil
+}
+
+func (f *helperFilesystem) CreateSymlink(target, name string) error {
+	_, err := f.call(&helperRequest{Op: helperOpCreateSymlink, Name: name, Target: target})
+	return err
+}
+
+func (f *helperFilesystem) DirNames(name string) ([]string, error) {
+	resp, err := f.call(&helperRequest{Op: helperOpDirNames, Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Names, nil
+}
+
+func (f *helperFilesystem) Lstat(name string) (FileInfo, error) {
+	resp, err := f.call(&helperRequest{Op: helperOpLstat, Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return helperFileInfo{resp.Stat}, nil
+}
+
+func (f *helperFilesystem) Stat(name string) (FileInfo, error) {
+	resp, err := f.call(&helperRequest{Op: helperOpStat, Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return helperFileInfo{resp.Stat}, nil
+}
+
+func (f *helperFilesystem) Mkdir(name string, perm FileMode) error {
+	_, err := f.call(&helperRequest{Op: helperOpMkdir, Name: name, Mode: perm})
+	return err
+}
+
+func (f *helperFilesystem) MkdirAll(name string, perm FileMode) error {
+	_, err := f.call(&helperRequest{Op: helperOpMkdirAll, Name: name, Mode: perm})
+	return err
+}
+
+func (f *helperFilesystem) ReadSymlink(name string) (string, error) {
+	resp, err := f.call(&helperRequest{Op: helperOpReadSymlink, Name: name})
+	if err != nil {
+		return "", err
+	}
+	return resp.Target, nil
+}
+
+func (f *helperFilesystem) Remove(name string) error {
+	_, err := f.call(&helperRequest{Op: helperOpRemove, Name: name})
+	return err
+}
+
+func (f *helperFilesystem) RemoveAll(name string) error {
+	_, err := f.call(&helperRequest{Op: helperOpRemoveAll, Name: name})
+	return err
+}
+
+func (f *helperFilesystem) Rename(oldname, newname string) error {
+	_, err := f.call(&helperRequest{Op: helperOpRename, Name: oldname, Target: newname})
+	return err
+}
+
+func (*helperFilesystem) SymlinksSupported() bool {
+	// Helpers only run on Unix-like platforms.
+	return true
+}
+
+func (*helperFilesystem) Walk(_ string, _ WalkFunc) error {
+	// implemented in WalkFilesystem
+	return errors.New("not implemented")
+}
+
+func (*helperFilesystem) Watch(_ string, _ Matcher, _ context.Context, _ bool) (<-chan Event, <-chan error, error) {
+	return nil, nil, ErrWatchNotSupported
+}
+
+func (f *helperFilesystem) Hide(name string) error {
+	_, err := f.call(&helperRequest{Op: helperOpHide, Name: name})
+	return err
+}
+
+func (f *helperFilesystem) Unhide(name string) error {
+	_, err := f.call(&helperRequest{Op: helperOpUnhide, Name: name})
+	return err
+}
+
+func (f *helperFilesystem) Glob(pattern string) ([]string, error) {
+	resp, err := f.call(&helperRequest{Op: helperOpGlob, Name: pattern})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Names, nil
+}
+
+func (f *helperFilesystem) Roots() ([]string, error) {
+	resp, err := f.call(&helperRequest{Op: helperOpRoots})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Names, nil
+}
+
+func (f *helperFilesystem) Usage(name string) (Usage, error) {
+	resp, err := f.call(&helperRequest{Op: helperOpUsage, Name: name})
+	if err != nil {
+		return Usage{}, err
+	}
+	return resp.Usage, nil
+}
+
+func (*helperFilesystem) Type() FilesystemType {
+	return FilesystemTypeBasic
+}
+
+func (f *helperFilesystem) URI() string {
+	return f.uri
+}
+
+func (f *helperFilesystem) Options() []Option {
+	return f.options
+}
+
+func (*helperFilesystem) SameFile(fi1, fi2 FileInfo) bool {
+	f1, ok1 := fi1.(helperFileInfo)
+	f2, ok2 := fi2.(helperFileInfo)
+	if !ok1 || !ok2 || f1.st.Inode == 0 {
+		return false
+	}
+	return f1.st.Dev == f2.st.Dev && f1.st.Inode == f2.st.Inode
+}
+
+func (f *helperFilesystem) PlatformData(name string, scanOwnership, scanXattrs bool, xattrFilter XattrFilter) (protocol.PlatformData, error) {
+	return unixPlatformData(f, name, f.userCache, f.groupCache, scanOwnership, scanXattrs, xattrFilter)
+}
+
+// GetXattr gets all attributes from the helper, as the filter can't be
+// sent along, and filters them here the same way the basic filesystem
+// does.
+func (f *helperFilesystem) GetXattr(name string, xattrFilter XattrFilter) ([]protocol.Xattr, error) {
+	resp, err := f.call(&helperRequest{Op: helperOpGetXattr, Name: name, AllXattrs: true})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]protocol.Xattr, 0, len(resp.Xattrs))
+	var totSize int
+	for _, xa := range resp.Xattrs {
+		if !xattrFilter.Permit(xa.Name) {
+			continue
+		}
+		if max := xattrFilter.GetMaxSingleEntrySize(); max > 0 && len(xa.Name)+len(xa.Value) > max {
+			continue
+		}
+		totSize += len(xa.Name) + len(xa.Value)
+		if max := xattrFilter.GetMaxTotalSize(); max > 0 && totSize > max {
+			continue
+		}
+		res = append(res, xa)
+	}
+	return res, nil
+}
+
+// SetXattr sends the names of the currently permitted attributes along,
+// so the helper removes only those that aren't in the new set.
+func (f *helperFilesystem) SetXattr(path string, xattrs []protocol.Xattr, xattrFilter XattrFilter) error {
+	current, err := f.GetXattr(path, xattrFilter)
+	if err != nil {
+		return fmt.Errorf("set xattrs %s: GetXattr: %w", path, err)
+	}
+	names := make([]string, 0, len(current)+len(xattrs))
+	for _, xa := range current {
+		names = append(names, xa.Name)
+	}
+	for _, xa := range xattrs {
+		names = append(names, xa.Name)
+	}
+	_, err = f.call(&helperRequest{Op: helperOpSetXattr, Name: path, Xattrs: xattrs, XattrNames: names})
+	return err
+}
+
+func (*helperFilesystem) underlying() (Filesystem, bool) {
+	return nil, false
+}
+
+func (*helperFilesystem) wrapperType() filesystemWrapperType {
+	return filesystemWrapperTypeNone
+}
+
+// helperFile is a file opened by the helper. It's only valid as long as
+// that helper process runs.
+type helperFile struct {
+	conn   *helperConn
+	proc   *helperProc
+	handle uint64
+	name   string
+}
+
+func (f *helperFile) call(req *helperRequest) (*helperResponse, error) {
+	req.Handle = f.handle
+	resp, _, err := f.conn.call(f.proc, req)
+	return resp, err
+}
+
+func (f *helperFile) Name() string {
+	return f.name
+}
+
+func (f *helperFile) Close() error {
+	_, err := f.call(&helperRequest{Op: helperOpFileClose})
+	return err
+}
+
+func (f *helperFile) Read(p []byte) (int, error) {
+	if len(p) > maxHelperReadSize {
+		p = p[:maxHelperReadSize]
+	}
+	resp, err := f.call(&helperRequest{Op: helperOpFileRead, Size: len(p)})
+	if resp == nil {
+		return 0, err
+	}
+	return copy(p, resp.Data), err
+}
+
+func (f *helperFile) ReadAt(p []byte, off int64) (int, error) {
+	var n int
+	for n < len(p) {
+		size := len(p) - n
+		if size > maxHelperReadSize {
+			size = maxHelperReadSize
+		}
+		resp, err := f.call(&helperRequest{Op: helperOpFileReadAt, Size: size, Offset: off + int64(n)})
+		if resp != nil {
+			n += copy(p[n:], resp.Data)
+		}
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (f *helperFile) Seek(offset int64, whence int) (int64, error) {
+	resp, err := f.call(&helperRequest{Op: helperOpFileSeek, Offset: offset, Whence: whence})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Offset, nil
+}
+
+func (f *helperFile) Write(p []byte) (int, error) {
+	resp, err := f.call(&helperRequest{Op: helperOpFileWrite, Data: p})
+	if resp == nil {
+		return 0, err
+	}
+	return resp.N, err
+}
+
+func (f *helperFile) WriteAt(p []byte, off int64) (int, error) {
+	resp, err := f.call(&helperRequest{Op: helperOpFileWriteAt, Data: p, Offset: off})
+	if resp == nil {
+		return 0, err
+	}
+	return resp.N, err
+}
+
+func (f *helperFile) Truncate(size int64) error {
+	_, err := f.call(&helperRequest{Op: helperOpFileTruncate, Offset: size})
+	return err
+}
+
+func (f *helperFile) Stat() (FileInfo, error) {
+	resp, err := f.call(&helperRequest{Op: helperOpFileStat})
+	if err != nil {
+		return nil, err
+	}
+	return helperFileInfo{resp.Stat}, nil
+}
+
+func (f *helperFile) Sync() error {
+	_, err := f.call(&helperRequest{Op: helperOpFileSync})
+	return err
+}
+
+// helperFileInfo is a FileInfo as returned by the helper.
+type helperFileInfo struct {
+	st helperStat
+}
+
+func (fi helperFileInfo) Name() string               { return fi.st.Name }
+func (fi helperFileInfo) Mode() FileMode             { return fi.st.Mode }
+func (fi helperFileInfo) Size() int64                { return fi.st.Size }
+func (fi helperFileInfo) ModTime() time.Time         { return fi.st.ModTime }
+func (fi helperFileInfo) IsDir() bool                { return fi.st.IsDir }
+func (helperFileInfo) Sys() interface{}              { return nil }
+func (fi helperFileInfo) IsRegular() bool            { return fi.st.IsRegular }
+func (fi helperFileInfo) IsSymlink() bool            { return fi.st.IsSymlink }
+func (fi helperFileInfo) Owner() int                 { return fi.st.Owner }
+func (fi helperFileInfo) Group() int                 { return fi.st.Group }
+func (fi helperFileInfo) InodeChangeTime() time.Time { return fi.st.InodeChangeTime }
+func (fi helperFileInfo) Inode() uint64              { return fi.st.Inode }
+
+// helperKey identifies a helper process by the user it runs as, empty for
+// the current one, and the root it's sandboxed to, if any.
+type helperKey struct {
+	user    string
+	sandbox string
+}
+
+func (k helperKey) String() string {
+	if k.sandbox != "" {
+		return fmt.Sprintf("user %q, sandboxed to %s", k.user, k.sandbox)
+	}
+	return fmt.Sprintf("user %q", k.user)
+}
+
+// helperConn is the connection to a helper process, started on first use
+// and again after it exited.
+type helperConn struct {
+	key   helperKey
+	start func(key helperKey) (io.ReadCloser, io.WriteCloser, error)
+
+	mut    sync.Mutex
+	proc   *helperProc // nil while not running
+	nextID uint64
+}
+
+// helperProc is a running helper process, with the requests awaiting a
+// response from it.
+type helperProc struct {
+	w       io.WriteCloser
+	enc     *gob.Encoder
+	pending map[uint64]chan *helperResponse
+}
+
+// call sends the request to the given helper process, or to the current
+// one when nil, starting it if needed, and waits for the response. The
+// error is that of the operation if the helper responded.
+func (c *helperConn) call(proc *helperProc, req *helperRequest) (*helperResponse, *helperProc, error) {
+	c.mut.Lock()
+	anyProc := proc == nil
+	ch := make(chan *helperResponse, 1)
+	for retried := false; ; retried = true {
+		if anyProc {
+			if c.proc == nil {
+				if err := c.startLocked(); err != nil {
+					c.mut.Unlock()
+					return nil, nil, err
+				}
+			}
+			proc = c.proc
+		} else if proc != c.proc {
+			// Opened by a helper that has since exited.
+			c.mut.Unlock()
+			return nil, nil, os.ErrClosed
+		}
+
+		c.nextID++
+		req.ID = c.nextID
+		proc.pending[req.ID] = ch
+		err := proc.enc.Encode(req)
+		if err == nil {
+			break
+		}
+
+		// The helper exited without us having noticed yet, or the
+		// connection is in a bad state; either way it's done for.
+		// Operations not on a file are retried once with a new helper.
+		delete(proc.pending, req.ID)
+		proc.w.Close()
+		if c.proc == proc {
+			c.proc = nil
+		}
+		if !anyProc || retried {
+			c.mut.Unlock()
+			return nil, nil, fmt.Errorf("filesystem helper (%v): %w", c.key, err)
+		}
+		l.Debugf("Filesystem helper (%v): sending request: %v", c.key, err)
+	}
+	c.mut.Unlock()
+
+	resp, ok := <-ch
+	if !ok {
+		return nil, nil, fmt.Errorf("%w (%v)", errHelperExited, c.key)
+	}
+	return resp, proc, resp.Err.error()
+}
+
+func (c *helperConn) startLocked() error {
+	r, w, err := c.start(c.key)
+	if err != nil {
+		return fmt.Errorf("starting filesystem helper (%v): %w", c.key, err)
+	}
+	l.Debugf("Started filesystem helper (%v)", c.key)
+	proc := &helperProc{
+		w:       w,
+		enc:     gob.NewEncoder(w),
+		pending: make(map[uint64]chan *helperResponse),
+	}
+	c.proc = proc
+	go c.readResponses(proc, r)
+	return nil
+}
+
+func (c *helperConn) readResponses(proc *helperProc, r io.ReadCloser) {
+	dec := gob.NewDecoder(r)
+	var err error
+	for {
+		resp := new(helperResponse)
+		if err = dec.Decode(resp); err != nil {
+			break
+		}
+		c.mut.Lock()
+		ch, ok := proc.pending[resp.ID]
+		delete(proc.pending, resp.ID)
+		c.mut.Unlock()
+		if ok {
+			ch <- resp
+		}
+	}
+	l.Debugf("Filesystem helper (%v) exited: %v", c.key, err)
+
+	r.Close()
+	c.mut.Lock()
+	if c.proc == proc {
+		c.proc = nil
+	}
+	proc.w.Close()
+	for _, ch := range proc.pending {
+		close(ch)
+	}
+	proc.pending = nil
+	c.mut.Unlock()
+}
+
+type helperRegistry struct {
+	mut   sync.Mutex
+	conns map[helperKey]*helperConn
+}
+
+func (r *helperRegistry) get(key helperKey) *helperConn {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	conn, ok := r.conns[key]
+	if !ok {
+		conn = &helperConn{key: key, start: startHelperProcess}
+		r.conns[key] = conn
+	}
+	return conn
+}
+
+var globalHelperRegistry = helperRegistry{conns: make(map[helperKey]*helperConn)}
@@ -0,0 +1,467 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// The helper process is passed the pipes for requests and responses as
+// these file descriptors, leaving stdout and stderr for logging.
+const (
+	helperRequestFd  = 3
+	helperResponseFd = 4
+)
+
+type helperOp int
+
+const (
+	helperOpChmod helperOp = iota
+	helperOpLchown
+	helperOpChtimes
+	helperOpCreate
+	helperOpOpen
+	helperOpOpenFile
+	helperOpCreateSymlink
+	helperOpDirNames
+	helperOpLstat
+	helperOpStat
+	helperOpMkdir
+	helperOpMkdirAll
+	helperOpReadSymlink
+	helperOpRemove
+	helperOpRemoveAll
+	helperOpRename
+	helperOpHide
+	helperOpUnhide
+	helperOpGlob
+	helperOpRoots
+	helperOpUsage
+	helperOpGetXattr
+	helperOpSetXattr
+	helperOpFileClose
+	helperOpFileRead
+	helperOpFileReadAt
+	helperOpFileSeek
+	helperOpFileWrite
+	helperOpFileWriteAt
+	helperOpFileTruncate
+	helperOpFileStat
+	helperOpFileSync
+)
+
+// helperRequest is a filesystem operation for the helper. Which of the
+// fields are used depends on the operation.
+type helperRequest struct {
+	ID         uint64
+	Op         helperOp
+	Root       string
+	Name       string
+	Target     string // for symlinks and renames
+	Mode       FileMode
+	Flags      int
+	Atime      time.Time
+	Mtime      time.Time
+	UID        string
+	GID        string
+	Handle     uint64
+	Offset     int64
+	Whence     int
+	Size       int
+	Data       []byte
+	Xattrs     []protocol.Xattr
+	XattrNames []string // the attributes to consider, unless AllXattrs
+	AllXattrs  bool
+}
+
+type helperResponse struct {
+	ID     uint64
+	Err    *helperError
+	Handle uint64
+	N      int
+	Offset int64
+	Data   []byte
+	Names  []string
+	Target string
+	Stat   helperStat
+	Usage  Usage
+	Xattrs []protocol.Xattr
+}
+
+type helperStat struct {
+	Name            string
+	Mode            FileMode
+	Size            int64
+	ModTime         time.Time
+	IsDir           bool
+	IsRegular       bool
+	IsSymlink       bool
+	Owner           int
+	Group           int
+	InodeChangeTime time.Time
+	Inode           uint64
+	Dev             uint64
+}
+
+func newHelperStat(fi FileInfo) helperStat {
+	return helperStat{
+		Name:            fi.Name(),
+		Mode:            fi.Mode(),
+		Size:            fi.Size(),
+		ModTime:         fi.ModTime(),
+		IsDir:           fi.IsDir(),
+		IsRegular:       fi.IsRegular(),
+		IsSymlink:       fi.IsSymlink(),
+		Owner:           fi.Owner(),
+		Group:           fi.Group(),
+		InodeChangeTime: fi.InodeChangeTime(),
+		Inode:           fi.Inode(),
+		Dev:             statDev(fi),
+	}
+}
+
+type helperErrorKind int
+
+const (
+	helperErrorOther helperErrorKind = iota
+	helperErrorEOF
+	helperErrorNotExist
+	helperErrorExist
+	helperErrorPermission
+	helperErrorClosed
+	helperErrorXattrsNotSupported
+)
+
+// helperError carries an error from the helper, such that errors.Is and
+// errors.As work for the errors callers check for.
+type helperError struct {
+	Msg   string
+	Kind  helperErrorKind
+	Errno uint64
+}
+
+func newHelperError(err error) *helperError {
+	if err == nil {
+		return nil
+	}
+	e := &helperError{Msg: err.Error()}
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		e.Errno = uint64(errno)
+	}
+	for kind, target := range helperErrorTargets {
+		if errors.Is(err, target) {
+			e.Kind = kind
+			break
+		}
+	}
+	return e
+}
+
+var helperErrorTargets = map[helperErrorKind]error{
+	helperErrorEOF:                io.EOF,
+	helperErrorNotExist:           fs.ErrNotExist,
+	helperErrorExist:              fs.ErrExist,
+	helperErrorPermission:         fs.ErrPermission,
+	helperErrorClosed:             fs.ErrClosed,
+	helperErrorXattrsNotSupported: ErrXattrsNotSupported,
+}
+
+// error returns the error to return to callers, nil if there is none.
+func (e *helperError) error() error {
+	if e == nil {
+		return nil
+	}
+	if e.Kind == helperErrorEOF {
+		// Compared directly by readers.
+		return io.EOF
+	}
+	return e
+}
+
+func (e *helperError) Error() string {
+	return e.Msg
+}
+
+func (e *helperError) Is(target error) bool {
+	return e.Kind != helperErrorOther && helperErrorTargets[e.Kind] == target
+}
+
+func (e *helperError) Unwrap() error {
+	if e.Errno == 0 {
+		return nil
+	}
+	return syscall.Errno(e.Errno)
+}
+
+// xattrNamesFilter permits the given attributes, without size limits.
+type xattrNamesFilter struct {
+	all   bool
+	names map[string]struct{}
+}
+
+func (f xattrNamesFilter) Permit(name string) bool {
+	if f.all {
+		return true
+	}
+	_, ok := f.names[name]
+	return ok
+}
+
+func (xattrNamesFilter) GetMaxSingleEntrySize() int { return 0 }
+func (xattrNamesFilter) GetMaxTotalSize() int       { return 0 }
+
+// ServeHelperProcess serves filesystem operations for another Syncthing
+// process, which started this one as the user to perform them as. When
+// sandbox is set, the process first restricts itself to accessing the
+// files below that root, and only serves operations on it. It returns when
+// the other process goes away.
+func ServeHelperProcess(sandbox string) error {
+	if sandbox != "" {
+		if err := sandboxSelf(sandbox); err != nil {
+			return fmt.Errorf("sandboxing filesystem helper: %w", err)
+		}
+	}
+	return serveHelper(os.NewFile(helperRequestFd, "requests"), os.NewFile(helperResponseFd, "responses"), sandbox)
+}
+
+type helperServer struct {
+	sandbox    string
+	mut        sync.Mutex
+	fss        map[string]*BasicFilesystem
+	files      map[uint64]File
+	nextHandle uint64
+
+	encMut sync.Mutex
+	enc    *gob.Encoder
+}
+
+func serveHelper(r io.Reader, w io.WriteCloser, sandbox string) error {
+	defer w.Close()
+	s := &helperServer{
+		sandbox: sandbox,
+		fss:     make(map[string]*BasicFilesystem),
+		files:   make(map[uint64]File),
+		enc:     gob.NewEncoder(w),
+	}
+	defer s.closeFiles()
+
+	// Requests are handled concurrently, like calls on a filesystem.
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	dec := gob.NewDecoder(r)
+	for {
+		req := new(helperRequest)
+		if err := dec.Decode(req); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := s.handle(req)
+			resp.ID = req.ID
+			s.encMut.Lock()
+			err := s.enc.Encode(resp)
+			s.encMut.Unlock()
+			if err != nil {
+				l.Debugln("Filesystem helper: sending response:", err)
+			}
+		}()
+	}
+}
+
+func (s *helperServer) handle(req *helperRequest) *helperResponse {
+	resp := new(helperResponse)
+	var err error
+	if req.Op >= helperOpFileClose {
+		err = s.handleFile(req, resp)
+	} else if s.sandbox != "" && req.Root != s.sandbox {
+		err = fmt.Errorf("%s is outside the sandbox: %w", req.Root, fs.ErrPermission)
+	} else {
+		err = s.handleFilesystem(s.filesystem(req.Root), req, resp)
+	}
+	resp.Err = newHelperError(err)
+	return resp
+}
+
+func (s *helperServer) handleFilesystem(ffs *BasicFilesystem, req *helperRequest, resp *helperResponse) error {
+	var err error
+	switch req.Op {
+	case helperOpChmod:
+		return ffs.Chmod(req.Name, req.Mode)
+	case helperOpLchown:
+		return ffs.Lchown(req.Name, req.UID, req.GID)
+	case helperOpChtimes:
+		return ffs.Chtimes(req.Name, req.Atime, req.Mtime)
+	case helperOpCreate, helperOpOpen, helperOpOpenFile:
+		var fd File
+		switch req.Op {
+		case helperOpCreate:
+			fd, err = ffs.Create(req.Name)
+		case helperOpOpen:
+			fd, err = ffs.Open(req.Name)
+		default:
+			fd, err = ffs.OpenFile(req.Name, req.Flags, req.Mode)
+		}
+		if err == nil {
+			resp.Handle = s.addFile(fd)
+		}
+		return err
+	case helperOpCreateSymlink:
+		return ffs.CreateSymlink(req.Target, req.Name)
+	case helperOpDirNames:
+		resp.Names, err = ffs.DirNames(req.Name)
+		return err
+	case helperOpLstat, helperOpStat:
+		var fi FileInfo
+		if req.Op == helperOpLstat {
+			fi, err = ffs.Lstat(req.Name)
+		} else {
+			fi, err = ffs.Stat(req.Name)
+		}
+		if err == nil {
+			resp.Stat = newHelperStat(fi)
+		}
+		return err
+	case helperOpMkdir:
+		return ffs.Mkdir(req.Name, req.Mode)
+	case helperOpMkdirAll:
+		return ffs.MkdirAll(req.Name, req.Mode)
+	case helperOpReadSymlink:
+		resp.Target, err = ffs.ReadSymlink(req.Name)
+		return err
+	case helperOpRemove:
+		return ffs.Remove(req.Name)
+	case helperOpRemoveAll:
+		return ffs.RemoveAll(req.Name)
+	case helperOpRename:
+		return ffs.Rename(req.Name, req.Target)
+	case helperOpHide:
+		return ffs.Hide(req.Name)
+	case helperOpUnhide:
+		return ffs.Unhide(req.Name)
+	case helperOpGlob:
+		resp.Names, err = ffs.Glob(req.Name)
+		return err
+	case helperOpRoots:
+		resp.Names, err = ffs.Roots()
+		return err
+	case helperOpUsage:
+		resp.Usage, err = ffs.Usage(req.Name)
+		return err
+	case helperOpGetXattr:
+		resp.Xattrs, err = ffs.GetXattr(req.Name, newXattrNamesFilter(req))
+		return err
+	case helperOpSetXattr:
+		return ffs.SetXattr(req.Name, req.Xattrs, newXattrNamesFilter(req))
+	default:
+		return fmt.Errorf("unknown filesystem helper operation %d", req.Op)
+	}
+}
+
+func (s *helperServer) handleFile(req *helperRequest, resp *helperResponse) error {
+	s.mut.Lock()
+	fd, ok := s.files[req.Handle]
+	if ok && req.Op == helperOpFileClose {
+		delete(s.files, req.Handle)
+	}
+	s.mut.Unlock()
+	if !ok {
+		return fs.ErrClosed
+	}
+
+	var err error
+	switch req.Op {
+	case helperOpFileClose:
+		return fd.Close()
+	case helperOpFileRead, helperOpFileReadAt:
+		size := req.Size
+		if size > maxHelperReadSize {
+			size = maxHelperReadSize
+		}
+		buf := make([]byte, size)
+		var n int
+		if req.Op == helperOpFileRead {
+			n, err = fd.Read(buf)
+		} else {
+			n, err = fd.ReadAt(buf, req.Offset)
+		}
+		resp.Data = buf[:n]
+		return err
+	case helperOpFileSeek:
+		resp.Offset, err = fd.Seek(req.Offset, req.Whence)
+		return err
+	case helperOpFileWrite:
+		resp.N, err = fd.Write(req.Data)
+		return err
+	case helperOpFileWriteAt:
+		resp.N, err = fd.WriteAt(req.Data, req.Offset)
+		return err
+	case helperOpFileTruncate:
+		return fd.Truncate(req.Offset)
+	case helperOpFileStat:
+		var fi FileInfo
+		fi, err = fd.Stat()
+		if err == nil {
+			resp.Stat = newHelperStat(fi)
+		}
+		return err
+	case helperOpFileSync:
+		return fd.Sync()
+	default:
+		return fmt.Errorf("unknown filesystem helper operation %d", req.Op)
+	}
+}
+
+func newXattrNamesFilter(req *helperRequest) xattrNamesFilter {
+	f := xattrNamesFilter{all: req.AllXattrs, names: make(map[string]struct{}, len(req.XattrNames))}
+	for _, name := range req.XattrNames {
+		f.names[name] = struct{}{}
+	}
+	return f
+}
+
+func (s *helperServer) filesystem(root string) *BasicFilesystem {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	ffs, ok := s.fss[root]
+	if !ok {
+		ffs = newBasicFilesystem(root)
+		s.fss[root] = ffs
+	}
+	return ffs
+}
+
+func (s *helperServer) addFile(fd File) uint64 {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.nextHandle++
+	s.files[s.nextHandle] = fd
+	return s.nextHandle
+}
+
+func (s *helperServer) closeFiles() {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	for handle, fd := range s.files {
+		fd.Close()
+		delete(s.files, handle)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pipeHelperStarter serves a helper in-process, keeping the request pipe
+// of the last one started to simulate it exiting.
+type pipeHelperStarter struct {
+	mut  sync.Mutex
+	reqR *io.PipeReader
+}
+
+func (s *pipeHelperStarter) start(key helperKey) (io.ReadCloser, io.WriteCloser, error) {
+	reqR, reqW := io.Pipe()
+	respR, respW := io.Pipe()
+	go serveHelper(reqR, respW, key.sandbox)
+	s.mut.Lock()
+	s.reqR = reqR
+	s.mut.Unlock()
+	return respR, reqW, nil
+}
+
+func (s *pipeHelperStarter) exit() {
+	s.mut.Lock()
+	s.reqR.CloseWithError(errHelperExited)
+	s.mut.Unlock()
+}
+
+func newTestHelperFilesystem(t *testing.T) (*helperFilesystem, *pipeHelperStarter) {
+	t.Helper()
+	starter := new(pipeHelperStarter)
+	hfs := newHelperFilesystem(t.TempDir(), "test", false)
+	hfs.conn = &helperConn{key: helperKey{user: "test"}, start: starter.start}
+	return hfs, starter
+}
+
+func TestHelperFilesystem(t *testing.T) {
+	hfs, _ := newTestHelperFilesystem(t)
+
+	if err := hfs.MkdirAll("dir/sub", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := []byte("hello, helper")
+	if err := WriteFile(hfs, "dir/file", content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Unix(1234567890, 0)
+	if err := hfs.Chtimes("dir/file", mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := hfs.Lstat("dir/file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Name() != "file" || fi.Size() != int64(len(content)) || !fi.IsRegular() || !fi.ModTime().Equal(mtime) {
+		t.Errorf("unexpected file info %+v", fi)
+	}
+
+	fd, err := hfs.Open("dir/file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, err := io.ReadAll(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bs, content) {
+		t.Errorf("read %q, expected %q", bs, content)
+	}
+	buf := make([]byte, 10)
+	if n, err := fd.ReadAt(buf, 7); err != io.EOF || n != len(content)-7 {
+		t.Errorf("ReadAt past the end returned %d, %v", n, err)
+	}
+	fdInfo, err := fd.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hfs.SameFile(fi, fdInfo) {
+		t.Error("expected the same file")
+	}
+	if err := fd.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := fd.Close(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("closing again returned %v", err)
+	}
+
+	if err := hfs.Rename("dir/file", "dir/sub/renamed"); err != nil {
+		t.Fatal(err)
+	}
+	names, err := hfs.DirNames("dir/sub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != "renamed" {
+		t.Errorf("unexpected directory contents %v", names)
+	}
+
+	if _, err := hfs.Lstat("dir/file"); !IsNotExist(err) {
+		t.Errorf("expected not exist, got %v", err)
+	}
+	if err := hfs.Mkdir("dir", 0o755); !IsExist(err) {
+		t.Errorf("expected exist, got %v", err)
+	}
+	if _, err := hfs.Lstat("../outside"); err == nil {
+		t.Error("expected an error for a path outside the root")
+	}
+}
+
+func TestHelperFilesystemRestart(t *testing.T) {
+	hfs, starter := newTestHelperFilesystem(t)
+
+	fd, err := hfs.Create("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	starter.exit()
+
+	// Files opened by the helper that exited are gone, while filesystem
+	// operations start a new helper.
+	var statErr error
+	for i := 0; i < 100; i++ {
+		if _, statErr = fd.Stat(); statErr != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if statErr == nil {
+		t.Fatal("expected an error on a file of an exited helper")
+	}
+	if _, err := hfs.Lstat("file"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHelperFilesystemSandboxRoot(t *testing.T) {
+	// The sandboxing itself needs a process of its own; the helper also
+	// refuses operations on other roots.
+	starter := new(pipeHelperStarter)
+	hfs := newHelperFilesystem(t.TempDir(), "", true)
+	hfs.conn = &helperConn{key: helperKey{sandbox: hfs.root}, start: starter.start}
+	other := newHelperFilesystem(t.TempDir(), "", true)
+	other.conn = hfs.conn
+
+	if err := hfs.Mkdir("dir", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Mkdir("dir", 0o755); !IsPermission(err) {
+		t.Errorf("expected permission denied outside the sandbox, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build !windows
+// +build !windows
+
+package fs
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+// startHelperProcess starts this executable as the helper's user, and
+// sandboxed if requested, to serve filesystem operations, returning the
+// pipes to read responses from and write requests to.
+func startHelperProcess(key helperKey) (io.ReadCloser, io.WriteCloser, error) {
+	if key.sandbox != "" && !sandboxSupported {
+		return nil, nil, errSandboxUnsupported
+	}
+	var cred *syscall.Credential
+	if key.user != "" {
+		var err error
+		cred, err = userCredential(key.user)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reqR, reqW, err := os.Pipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	respR, respW, err := os.Pipe()
+	if err != nil {
+		reqR.Close()
+		reqW.Close()
+		return nil, nil, err
+	}
+
+	args := []string{"fs-helper"}
+	if key.sandbox != "" {
+		args = append(args, "--sandbox", key.sandbox)
+	}
+	cmd := exec.Command(exe, args...)
+	cmd.Dir = "/"
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = []*os.File{reqR, respW} // helperRequestFd, helperResponseFd
+	if cred != nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{Credential: cred}
+	}
+
+	err = cmd.Start()
+	// The child has its own copies of these.
+	reqR.Close()
+	respW.Close()
+	if err != nil {
+		reqW.Close()
+		respR.Close()
+		return nil, nil, err
+	}
+	go cmd.Wait()
+	return respR, reqW, nil
+}
+
+// userCredential returns the credential to start a process as the user,
+// or nil if that's the current user anyway.
+func userCredential(username string) (*syscall.Credential, error) {
+	usr, err := user.Lookup(username)
+	if err != nil {
+		return nil, err
+	}
+	uid, err := strconv.ParseUint(usr.Uid, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	gid, err := strconv.ParseUint(usr.Gid, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	if int(uid) == os.Getuid() && int(gid) == os.Getgid() {
+		return nil, nil
+	}
+	cred := &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)}
+	if gids, err := usr.GroupIds(); err == nil {
+		for _, g := range gids {
+			if n, err := strconv.ParseUint(g, 10, 32); err == nil {
+				cred.Groups = append(cred.Groups, uint32(n))
+			}
+		}
+	}
+	return cred, nil
+}
+
+func statDev(fi FileInfo) uint64 {
+	if bfi, ok := fi.(basicFileInfo); ok {
+		if st, ok := bfi.Sys().(*syscall.Stat_t); ok {
+			return uint64(st.Dev)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,25 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"errors"
+	"io"
+)
+
+var errRunAsUserUnsupported = errors.New("running folders as another user is not supported on Windows")
+
+func startHelperProcess(key helperKey) (io.ReadCloser, io.WriteCloser, error) {
+	if key.sandbox != "" {
+		return nil, nil, errSandboxUnsupported
+	}
+	return nil, nil, errRunAsUserUnsupported
+}
+
+func statDev(FileInfo) uint64 {
+	return 0
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrJournalUnsupported is returned when there is no change journal
+	// we can read for the filesystem.
+	ErrJournalUnsupported = errors.New("change journal not supported")
+	// ErrJournalReset is returned when the change journal doesn't reach
+	// back to the given position, as it was recreated or has been trimmed
+	// since.
+	ErrJournalReset = errors.New("change journal does not reach back to the position")
+)
+
+// JournalPosition returns the current position in the change journal the
+// operating system keeps for the volume the filesystem is on, i.e. the
+// NTFS USN journal on Windows and the FSEvents database on macOS.
+func JournalPosition(filesystem Filesystem) (string, error) {
+	bfs, err := journalFilesystem(filesystem)
+	if err != nil {
+		return "", err
+	}
+	return bfs.journalPosition()
+}
+
+// JournalChanges returns the paths of the items changed since the given
+// journal position, relative to the root of the filesystem, and the
+// current position. Paths may be those of deleted items or of directories
+// whose contents changed, so scanning all of them catches up with the
+// changes.
+func JournalChanges(ctx context.Context, filesystem Filesystem, since string) ([]string, string, error) {
+	bfs, err := journalFilesystem(filesystem)
+	if err != nil {
+		return nil, "", err
+	}
+	return bfs.journalChanges(ctx, since)
+}
+
+// journalFilesystem returns the basic filesystem underlying the given one,
+// as long as the wrappers in between keep the names the journal reports.
+func journalFilesystem(filesystem Filesystem) (*BasicFilesystem, error) {
+	if _, ok := unwrapFilesystem(filesystem, filesystemWrapperTypeEncryption); ok {
+		return nil, ErrJournalUnsupported
+	}
+	fs, ok := unwrapFilesystem(filesystem, filesystemWrapperTypeNone)
+	if !ok {
+		return nil, ErrJournalUnsupported
+	}
+	bfs, ok := fs.(*BasicFilesystem)
+	if !ok {
+		return nil, ErrJournalUnsupported
+	}
+	return bfs, nil
+}
@@ -57,3 +57,10 @@ func TempNameWithPrefix(name, prefix string) string {
 func TempName(name string) string {
 	return TempNameWithPrefix(name, tempPrefix())
 }
+
+// StagingTempName returns the name of the temporary file for the given file
+// in a staging directory, which holds the temporary files of a folder
+// without its directory structure.
+func StagingTempName(name string) string {
+	return fmt.Sprintf("%s%x.tmp", tempPrefix(), sha256.Sum256([]byte(name)))
+}
@@ -23,6 +23,17 @@ func TestLongTempFilename(t *testing.T) {
 	}
 }
 
+func TestStagingTempName(t *testing.T) {
+	a := StagingTempName(filepath.Join("dir", "file"))
+	b := StagingTempName(filepath.Join("other", "file"))
+	if a == b {
+		t.Error("Files in different directories should get different names")
+	}
+	if filepath.Base(a) != a || !IsTemporary(a) {
+		t.Error("Invalid staging name", a)
+	}
+}
+
 func benchmarkTempName(b *testing.B, filename string) {
 	filename = filepath.Join("/Users/marieantoinette", filename)
 
@@ -0,0 +1,296 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package jobs runs periodic background work, such as folder scans and
+// database maintenance, from one place.
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/thejerf/suture/v4"
+
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// A Job is a piece of work run periodically.
+type Job struct {
+	// Name identifies the job, e.g. "scan/default".
+	Name string
+	// Jobs of the same class never run at the same time, a job due while
+	// another of its class runs waits for it. Jobs without a class run
+	// regardless of others.
+	Class string
+	// The delay until the first run. When negative, the job doesn't run
+	// until it's rescheduled.
+	First time.Duration
+	// Interval returns the time between the end of a run and the next one.
+	// It's called after every run, so changes in configuration apply.
+	// Zero means the job only runs when rescheduled.
+	Interval func() time.Duration
+	// The interval is varied randomly by up to this fraction of it in
+	// either direction, so jobs don't run in lockstep.
+	Jitter float64
+	Run    func(ctx context.Context) error
+}
+
+// Status describes the schedule and the last run of a job.
+type Status struct {
+	Name          string    `json:"name"`
+	Class         string    `json:"class,omitempty"`
+	Running       bool      `json:"running"`
+	Waiting       bool      `json:"waiting"` // due, but another job of the class runs
+	Runs          int       `json:"runs"`
+	LastRun       time.Time `json:"lastRun"`
+	LastDurationS float64   `json:"lastDurationS"`
+	LastError     string    `json:"lastError,omitempty"`
+	NextRun       time.Time `json:"nextRun"` // zero when not scheduled
+}
+
+type Scheduler interface {
+	suture.Service
+	// Add schedules the job, replacing any job with the same name.
+	Add(job Job)
+	// Remove unschedules the job, cancelling it if it's running.
+	Remove(name string)
+	// Reschedule sets the next run of the job to be after the delay. If the
+	// job is running, it's the next run after the current one.
+	Reschedule(name string, delay time.Duration)
+	// Trigger runs the job as soon as possible.
+	Trigger(name string)
+	// Status returns the status of all jobs, ordered by name.
+	Status() []Status
+}
+
+type scheduler struct {
+	mut     sync.Mutex
+	jobs    map[string]*job
+	busy    map[string]bool // classes with a running job
+	changed chan struct{}
+	timeNow func() time.Time
+}
+
+type job struct {
+	Job
+	next    time.Time
+	running bool
+	removed bool
+	cancel  context.CancelFunc
+	runs    int
+	lastRun time.Time
+	lastDur time.Duration
+	lastErr error
+}
+
+func New() Scheduler {
+	return &scheduler{
+		mut:     sync.NewMutex(),
+		jobs:    make(map[string]*job),
+		busy:    make(map[string]bool),
+		changed: make(chan struct{}, 1),
+		timeNow: time.Now,
+	}
+}
+
+func (s *scheduler) Serve(ctx context.Context) error {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+		case <-s.changed:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if next := s.startDue(ctx); !next.IsZero() {
+			timer.Reset(next.Sub(s.timeNow()))
+		}
+	}
+}
+
+// startDue starts the jobs that are due and whose class is free, and
+// returns when the next job not yet due is, or the zero time if there is
+// none.
+func (s *scheduler) startDue(ctx context.Context) time.Time {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	// The longest overdue job goes first when several compete for a class.
+	scheduled := make([]*job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		if !j.running && !j.next.IsZero() {
+			scheduled = append(scheduled, j)
+		}
+	}
+	sort.Slice(scheduled, func(a, b int) bool {
+		return scheduled[a].next.Before(scheduled[b].next)
+	})
+
+	now := s.timeNow()
+	var next time.Time
+	for _, j := range scheduled {
+		if j.next.After(now) {
+			if next.IsZero() || j.next.Before(next) {
+				next = j.next
+			}
+			continue
+		}
+		if j.Class != "" && s.busy[j.Class] {
+			// Started when the running job finishes.
+			continue
+		}
+		s.startLocked(ctx, j, now)
+	}
+	return next
+}
+
+func (s *scheduler) startLocked(ctx context.Context, j *job, now time.Time) {
+	l.Debugln("Starting job", j.Name)
+	ctx, j.cancel = context.WithCancel(ctx)
+	j.running = true
+	j.next = time.Time{}
+	j.lastRun = now
+	if j.Class != "" {
+		s.busy[j.Class] = true
+	}
+	go func() {
+		err := j.Run(ctx)
+		s.finished(j, err)
+	}()
+}
+
+func (s *scheduler) finished(j *job, err error) {
+	s.mut.Lock()
+	j.cancel()
+	j.running = false
+	j.runs++
+	j.lastDur = s.timeNow().Sub(j.lastRun)
+	j.lastErr = err
+	if j.Class != "" {
+		delete(s.busy, j.Class)
+	}
+	if !j.removed && j.next.IsZero() {
+		if d := j.interval(); d > 0 {
+			j.next = s.timeNow().Add(d)
+		}
+	}
+	l.Debugf("Job %s finished after %v (error: %v), next run at %v", j.Name, j.lastDur, err, j.next)
+	s.mut.Unlock()
+	s.notify()
+}
+
+// interval returns the jittered interval until the next run.
+func (j *job) interval() time.Duration {
+	if j.Interval == nil {
+		return 0
+	}
+	d := j.Interval()
+	if d <= 0 || j.Jitter <= 0 {
+		return d
+	}
+	spread := int64(j.Jitter * float64(d))
+	if spread <= 0 {
+		return d
+	}
+	return d - time.Duration(spread) + time.Duration(rand.Int63n(2*spread))
+}
+
+func (s *scheduler) Add(jb Job) {
+	s.mut.Lock()
+	if old, ok := s.jobs[jb.Name]; ok {
+		s.removeLocked(old)
+	}
+	j := &job{Job: jb}
+	if jb.First >= 0 {
+		j.next = s.timeNow().Add(jb.First)
+	}
+	s.jobs[jb.Name] = j
+	s.mut.Unlock()
+	s.notify()
+}
+
+func (s *scheduler) Remove(name string) {
+	s.mut.Lock()
+	if j, ok := s.jobs[name]; ok {
+		s.removeLocked(j)
+	}
+	s.mut.Unlock()
+}
+
+func (s *scheduler) removeLocked(j *job) {
+	delete(s.jobs, j.Name)
+	j.removed = true
+	if j.running {
+		j.cancel()
+	}
+}
+
+func (s *scheduler) Reschedule(name string, delay time.Duration) {
+	s.mut.Lock()
+	j, ok := s.jobs[name]
+	if ok {
+		j.next = s.timeNow().Add(delay)
+	}
+	s.mut.Unlock()
+	if ok {
+		s.notify()
+	}
+}
+
+func (s *scheduler) Trigger(name string) {
+	s.Reschedule(name, 0)
+}
+
+func (s *scheduler) Status() []Status {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	now := s.timeNow()
+	res := make([]Status, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		st := Status{
+			Name:          j.Name,
+			Class:         j.Class,
+			Running:       j.running,
+			Waiting:       !j.running && !j.next.IsZero() && !j.next.After(now) && s.busy[j.Class],
+			Runs:          j.runs,
+			LastRun:       j.lastRun,
+			LastDurationS: j.lastDur.Seconds(),
+			NextRun:       j.next,
+		}
+		if j.lastErr != nil {
+			st.LastError = j.lastErr.Error()
+		}
+		res = append(res, st)
+	}
+	sort.Slice(res, func(a, b int) bool {
+		return res[a].Name < res[b].Name
+	})
+	return res
+}
+
+func (s *scheduler) notify() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (s *scheduler) String() string {
+	return fmt.Sprintf("jobs.Scheduler@%p", s)
+}
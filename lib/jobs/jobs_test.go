@@ -0,0 +1,159 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSchedulerClasses(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Serve(ctx)
+
+	// Two jobs of the same class run one after the other, the one without
+	// a class regardless.
+	release := make(chan struct{})
+	started := make(chan string, 3)
+	blocking := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			started <- name
+			<-release
+			return nil
+		}
+	}
+	s.Add(Job{Name: "a", Class: "disk", Run: blocking("a")})
+	waitStarted(t, started, "a")
+	s.Add(Job{Name: "b", Class: "disk", Run: blocking("b")})
+	s.Add(Job{Name: "c", Run: blocking("c")})
+	waitStarted(t, started, "c")
+
+	select {
+	case name := <-started:
+		t.Fatalf("%s started while a runs", name)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if st := status(s, "b"); !st.Waiting {
+		t.Errorf("expected b to be waiting, got %+v", st)
+	}
+
+	release <- struct{}{}
+	release <- struct{}{}
+	waitStarted(t, started, "b")
+	close(release)
+}
+
+func TestSchedulerReschedule(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Serve(ctx)
+
+	errBoom := errors.New("boom")
+	ran := make(chan struct{}, 1)
+	s.Add(Job{
+		Name:     "job",
+		First:    time.Hour,
+		Interval: func() time.Duration { return time.Hour },
+		Jitter:   0.1,
+		Run: func(context.Context) error {
+			ran <- struct{}{}
+			return errBoom
+		},
+	})
+	if st := status(s, "job"); st.Runs != 0 || st.NextRun.Before(time.Now().Add(59*time.Minute)) {
+		t.Fatalf("unexpected status %+v", st)
+	}
+
+	s.Trigger("job")
+	select {
+	case <-ran:
+	case <-time.After(10 * time.Second):
+		t.Fatal("job not run when triggered")
+	}
+
+	// After the run, the next is an interval away, give or take the
+	// jitter.
+	var st Status
+	for i := 0; st.Runs == 0; i++ {
+		if i == 100 {
+			t.Fatal("run not recorded")
+		}
+		time.Sleep(10 * time.Millisecond)
+		st = status(s, "job")
+	}
+	if st.LastError != errBoom.Error() || st.Running {
+		t.Errorf("unexpected status %+v", st)
+	}
+	if until := time.Until(st.NextRun); until < 53*time.Minute || until > 67*time.Minute {
+		t.Errorf("next run in %v", until)
+	}
+
+	s.Remove("job")
+	if len(s.Status()) != 0 {
+		t.Error("job not removed")
+	}
+}
+
+func TestSchedulerUnscheduled(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Serve(ctx)
+
+	// A job with a negative first delay and no interval only runs when
+	// triggered.
+	ran := make(chan struct{}, 1)
+	s.Add(Job{
+		Name:  "job",
+		First: -1,
+		Run: func(context.Context) error {
+			ran <- struct{}{}
+			return nil
+		},
+	})
+	select {
+	case <-ran:
+		t.Fatal("job run without being triggered")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if st := status(s, "job"); !st.NextRun.IsZero() {
+		t.Errorf("expected the job to not be scheduled, got %+v", st)
+	}
+
+	s.Trigger("job")
+	select {
+	case <-ran:
+	case <-time.After(10 * time.Second):
+		t.Fatal("job not run when triggered")
+	}
+}
+
+func waitStarted(t *testing.T, started <-chan string, expected string) {
+	t.Helper()
+	select {
+	case name := <-started:
+		if name != expected {
+			t.Fatalf("expected %s to start, got %s", expected, name)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("%s not started", expected)
+	}
+}
+
+func status(s Scheduler, name string) Status {
+	for _, st := range s.Status() {
+		if st.Name == name {
+			return st
+		}
+	}
+	return Status{}
+}
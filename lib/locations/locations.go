@@ -28,10 +28,14 @@ const (
 	KeyFile       LocationEnum = "keyFile"
 	HTTPSCertFile LocationEnum = "httpsCertFile"
 	HTTPSKeyFile  LocationEnum = "httpsKeyFile"
+	ACMECache     LocationEnum = "acmeCache"
 	Database      LocationEnum = "database"
+	DedupPool     LocationEnum = "dedupPool"
 	LogFile       LocationEnum = "logFile"
 	CsrfTokens    LocationEnum = "csrfTokens"
+	LockFile      LocationEnum = "lockFile"
 	PanicLog      LocationEnum = "panicLog"
+	SafeModeLog   LocationEnum = "safeModeLog"
 	AuditLog      LocationEnum = "auditLog"
 	GUIAssets     LocationEnum = "guiAssets"
 	DefFolder     LocationEnum = "defFolder"
@@ -115,10 +119,14 @@ var locationTemplates = map[LocationEnum]string{
 	KeyFile:       "${config}/key.pem",
 	HTTPSCertFile: "${config}/https-cert.pem",
 	HTTPSKeyFile:  "${config}/https-key.pem",
+	ACMECache:     "${config}/acme",
 	Database:      "${data}/" + LevelDBDir,
+	DedupPool:     "${data}/blockpool",
 	LogFile:       "${data}/syncthing.log", // --logfile on Windows
 	CsrfTokens:    "${data}/csrftokens.txt",
+	LockFile:      "${config}/syncthing.lock",
 	PanicLog:      "${data}/panic-${timestamp}.log",
+	SafeModeLog:   "${data}/safe-mode-${timestamp}.log",
 	AuditLog:      "${data}/audit-${timestamp}.log",
 	GUIAssets:     "${config}/gui",
 	DefFolder:     "${userHome}/Sync",
@@ -129,6 +137,15 @@ var locations = make(map[LocationEnum]string)
 // expandLocations replaces the variables in the locations map with actual
 // directory locations.
 func expandLocations() error {
+	newLocations, err := expand(baseDirs)
+	if err != nil {
+		return err
+	}
+	locations = newLocations
+	return nil
+}
+
+func expand(baseDirs map[BaseDirEnum]string) (map[LocationEnum]string, error) {
 	newLocations := make(map[LocationEnum]string)
 	for key, dir := range locationTemplates {
 		for varName, value := range baseDirs {
@@ -137,16 +154,68 @@ func expandLocations() error {
 		var err error
 		dir, err = fs.ExpandTilde(dir)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		newLocations[key] = filepath.Clean(dir)
 	}
-	locations = newLocations
-	return nil
+	return newLocations, nil
+}
+
+// A Profile holds the locations below config and data directories of its
+// own, for serving several isolated instances from one process. A nil
+// Profile refers to the process wide locations.
+type Profile struct {
+	baseDirs  map[BaseDirEnum]string
+	locations map[LocationEnum]string
+}
+
+func NewProfile(configDir, dataDir string) (*Profile, error) {
+	p := &Profile{baseDirs: make(map[BaseDirEnum]string, len(baseDirs))}
+	for key, dir := range baseDirs {
+		p.baseDirs[key] = dir
+	}
+	for key, dir := range map[BaseDirEnum]string{ConfigBaseDir: configDir, DataBaseDir: dataDir} {
+		dir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		p.baseDirs[key] = filepath.Clean(dir)
+	}
+	var err error
+	p.locations, err = expand(p.baseDirs)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Profile) Get(location LocationEnum) string {
+	if p == nil {
+		return Get(location)
+	}
+	return p.locations[location]
+}
+
+func (p *Profile) GetBaseDir(baseDir BaseDirEnum) string {
+	if p == nil {
+		return GetBaseDir(baseDir)
+	}
+	return p.baseDirs[baseDir]
 }
 
 // ListExpandedPaths returns a machine-readable mapping of the currently configured locations.
 func ListExpandedPaths() map[string]string {
+	return listExpandedPaths(baseDirs, locations)
+}
+
+func (p *Profile) ListExpandedPaths() map[string]string {
+	if p == nil {
+		return ListExpandedPaths()
+	}
+	return listExpandedPaths(p.baseDirs, p.locations)
+}
+
+func listExpandedPaths(baseDirs map[BaseDirEnum]string, locations map[LocationEnum]string) map[string]string {
 	res := make(map[string]string, len(locations))
 	for key, path := range baseDirs {
 		res["baseDir-"+string(key)] = path
@@ -167,6 +236,7 @@ func PrettyPaths() string {
 	fmt.Fprintf(&b, "Log file:\n\t%s\n\n", Get(LogFile))
 	fmt.Fprintf(&b, "GUI override directory:\n\t%s\n\n", Get(GUIAssets))
 	fmt.Fprintf(&b, "CSRF tokens file:\n\t%s\n\n", Get(CsrfTokens))
+	fmt.Fprintf(&b, "Instance lock file:\n\t%s\n\n", Get(LockFile))
 	fmt.Fprintf(&b, "Default sync folder directory:\n\t%s\n\n", Get(DefFolder))
 	return b.String()
 }
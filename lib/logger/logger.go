@@ -30,6 +30,36 @@ const (
 	NumLevels
 )
 
+// DefaultLevel is the minimum level logged for facilities that have no
+// level set.
+const DefaultLevel = LevelVerbose
+
+var levelNames = [NumLevels]string{"debug", "verbose", "info", "warning"}
+
+var levelPrefixes = [NumLevels]string{"DEBUG: ", "VERBOSE: ", "INFO: ", "WARNING: "}
+
+func (l LogLevel) String() string {
+	if l < 0 || l >= NumLevels {
+		return fmt.Sprintf("LogLevel(%d)", int(l))
+	}
+	return levelNames[l]
+}
+
+// ParseLogLevel returns the level with the given name, as returned by
+// LogLevel.String.
+func ParseLogLevel(s string) (LogLevel, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "warn" {
+		return LevelWarn, nil
+	}
+	for l, name := range levelNames {
+		if name == s {
+			return LogLevel(l), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown log level %q", s)
+}
+
 const (
 	DefaultFlags = log.Ltime | log.Ldate
 	DebugFlags   = log.Ltime | log.Ldate | log.Lmicroseconds | log.Lshortfile
@@ -38,8 +68,13 @@ const (
 // A MessageHandler is called with the log level and message text.
 type MessageHandler func(l LogLevel, msg string)
 
+// A LineHandler is called with each log entry, including the facility it
+// was logged on.
+type LineHandler func(line Line)
+
 type Logger interface {
 	AddHandler(level LogLevel, h MessageHandler)
+	AddLineHandler(level LogLevel, h LineHandler)
 	SetFlags(flag int)
 	SetPrefix(prefix string)
 	Debugln(vals ...interface{})
@@ -52,6 +87,10 @@ type Logger interface {
 	Warnf(format string, vals ...interface{})
 	ShouldDebug(facility string) bool
 	SetDebug(facility string, enabled bool)
+	SetDebugFor(facility string, d time.Duration)
+	DebugExpiries() map[string]time.Time
+	SetLevel(facility string, level LogLevel)
+	FacilityLevels() map[string]LogLevel
 	IsTraced(facility string) bool
 	Facilities() map[string]string
 	FacilityDebugging() []string
@@ -59,12 +98,14 @@ type Logger interface {
 }
 
 type logger struct {
-	logger     *log.Logger
-	handlers   [NumLevels][]MessageHandler
-	facilities map[string]string   // facility name => description
-	debug      map[string]struct{} // only facility names with debugging enabled
-	traces     string
-	mut        sync.Mutex
+	logger       *log.Logger
+	handlers     [NumLevels][]MessageHandler
+	lineHandlers [NumLevels][]LineHandler
+	facilities   map[string]string   // facility name => description
+	levels       map[string]LogLevel // only facility names with a level other than DefaultLevel
+	expiries     map[string]*debugExpiry
+	traces       string
+	mut          sync.Mutex
 }
 
 // DefaultLogger logs to standard output with a time prefix.
@@ -84,7 +125,8 @@ func newLogger(w io.Writer) Logger {
 		logger:     log.New(w, "", DefaultFlags),
 		traces:     os.Getenv("STTRACE"),
 		facilities: make(map[string]string),
-		debug:      make(map[string]struct{}),
+		levels:     make(map[string]LogLevel),
+		expiries:   make(map[string]*debugExpiry),
 	}
 }
 
@@ -96,6 +138,14 @@ func (l *logger) AddHandler(level LogLevel, h MessageHandler) {
 	l.handlers[level] = append(l.handlers[level], h)
 }
 
+// AddLineHandler registers a new LineHandler to receive messages with the
+// specified log level or above.
+func (l *logger) AddLineHandler(level LogLevel, h LineHandler) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	l.lineHandlers[level] = append(l.lineHandlers[level], h)
+}
+
 // See log.SetFlags
 func (l *logger) SetFlags(flag int) {
 	l.logger.SetFlags(flag)
@@ -106,113 +156,201 @@ func (l *logger) SetPrefix(prefix string) {
 	l.logger.SetPrefix(prefix)
 }
 
-func (l *logger) callHandlers(level LogLevel, s string) {
+func (l *logger) callHandlers(level LogLevel, facility, s string) {
+	s = strings.TrimSpace(s)
+	var line Line
 	for ll := LevelDebug; ll <= level; ll++ {
 		for _, h := range l.handlers[ll] {
-			h(level, strings.TrimSpace(s))
+			h(level, s)
+		}
+		for _, h := range l.lineHandlers[ll] {
+			if line.When.IsZero() {
+				line = Line{
+					When:     time.Now(), // intentionally high precision
+					Message:  s,
+					Level:    level,
+					Facility: facility,
+				}
+			}
+			h(line)
 		}
 	}
 }
 
-// Debugln logs a line with a DEBUG prefix.
-func (l *logger) Debugln(vals ...interface{}) {
-	l.debugln(3, vals...)
-}
-func (l *logger) debugln(level int, vals ...interface{}) {
-	s := fmt.Sprintln(vals...)
+func (l *logger) output(calldepth int, level LogLevel, facility, s string) {
 	l.mut.Lock()
 	defer l.mut.Unlock()
-	l.logger.Output(level, "DEBUG: "+s)
-	l.callHandlers(LevelDebug, s)
+	l.logger.Output(calldepth+1, levelPrefixes[level]+s)
+	l.callHandlers(level, facility, s)
+}
+
+// Debugln logs a line with a DEBUG prefix.
+func (l *logger) Debugln(vals ...interface{}) {
+	l.output(2, LevelDebug, "", fmt.Sprintln(vals...))
 }
 
 // Debugf logs a formatted line with a DEBUG prefix.
 func (l *logger) Debugf(format string, vals ...interface{}) {
-	l.debugf(3, format, vals...)
-}
-func (l *logger) debugf(level int, format string, vals ...interface{}) {
-	s := fmt.Sprintf(format, vals...)
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	l.logger.Output(level, "DEBUG: "+s)
-	l.callHandlers(LevelDebug, s)
+	l.output(2, LevelDebug, "", fmt.Sprintf(format, vals...))
 }
 
-// Infoln logs a line with a VERBOSE prefix.
+// Verboseln logs a line with a VERBOSE prefix.
 func (l *logger) Verboseln(vals ...interface{}) {
-	s := fmt.Sprintln(vals...)
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	l.logger.Output(2, "VERBOSE: "+s)
-	l.callHandlers(LevelVerbose, s)
+	l.output(2, LevelVerbose, "", fmt.Sprintln(vals...))
 }
 
-// Infof logs a formatted line with a VERBOSE prefix.
+// Verbosef logs a formatted line with a VERBOSE prefix.
 func (l *logger) Verbosef(format string, vals ...interface{}) {
-	s := fmt.Sprintf(format, vals...)
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	l.logger.Output(2, "VERBOSE: "+s)
-	l.callHandlers(LevelVerbose, s)
+	l.output(2, LevelVerbose, "", fmt.Sprintf(format, vals...))
 }
 
 // Infoln logs a line with an INFO prefix.
 func (l *logger) Infoln(vals ...interface{}) {
-	s := fmt.Sprintln(vals...)
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	l.logger.Output(2, "INFO: "+s)
-	l.callHandlers(LevelInfo, s)
+	l.output(2, LevelInfo, "", fmt.Sprintln(vals...))
 }
 
 // Infof logs a formatted line with an INFO prefix.
 func (l *logger) Infof(format string, vals ...interface{}) {
-	s := fmt.Sprintf(format, vals...)
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	l.logger.Output(2, "INFO: "+s)
-	l.callHandlers(LevelInfo, s)
+	l.output(2, LevelInfo, "", fmt.Sprintf(format, vals...))
 }
 
 // Warnln logs a formatted line with a WARNING prefix.
 func (l *logger) Warnln(vals ...interface{}) {
-	s := fmt.Sprintln(vals...)
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	l.logger.Output(2, "WARNING: "+s)
-	l.callHandlers(LevelWarn, s)
+	l.output(2, LevelWarn, "", fmt.Sprintln(vals...))
 }
 
 // Warnf logs a formatted line with a WARNING prefix.
 func (l *logger) Warnf(format string, vals ...interface{}) {
-	s := fmt.Sprintf(format, vals...)
-	l.mut.Lock()
-	defer l.mut.Unlock()
-	l.logger.Output(2, "WARNING: "+s)
-	l.callHandlers(LevelWarn, s)
+	l.output(2, LevelWarn, "", fmt.Sprintf(format, vals...))
 }
 
 // ShouldDebug returns true if the given facility has debugging enabled.
 func (l *logger) ShouldDebug(facility string) bool {
+	return l.shouldLog(facility, LevelDebug)
+}
+
+func (l *logger) shouldLog(facility string, level LogLevel) bool {
 	l.mut.Lock()
-	_, res := l.debug[facility]
+	min, ok := l.levels[facility]
 	l.mut.Unlock()
-	return res
+	if !ok {
+		min = DefaultLevel
+	}
+	return level >= min
 }
 
 // SetDebug enabled or disables debugging for the given facility name.
 func (l *logger) SetDebug(facility string, enabled bool) {
 	l.mut.Lock()
 	defer l.mut.Unlock()
-	if _, ok := l.debug[facility]; enabled && !ok {
-		l.SetFlags(DebugFlags)
-		l.debug[facility] = struct{}{}
-	} else if !enabled && ok {
-		delete(l.debug, facility)
-		if len(l.debug) == 0 {
-			l.SetFlags(DefaultFlags)
+	l.cancelExpiryLocked(facility)
+	if enabled {
+		l.levels[facility] = LevelDebug
+	} else if level, ok := l.levels[facility]; ok && level == LevelDebug {
+		delete(l.levels, facility)
+	}
+	l.updateFlagsLocked()
+}
+
+// SetLevel sets the minimum level of messages logged for the given
+// facility. Warnings are always logged.
+func (l *logger) SetLevel(facility string, level LogLevel) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	l.cancelExpiryLocked(facility)
+	if level == DefaultLevel {
+		delete(l.levels, facility)
+	} else {
+		l.levels[facility] = level
+	}
+	l.updateFlagsLocked()
+}
+
+// debugExpiry is a temporary debug enablement, which restores the previous
+// level when it expires.
+type debugExpiry struct {
+	at      time.Time
+	timer   *time.Timer
+	prev    LogLevel
+	hadPrev bool
+}
+
+// SetDebugFor enables debug output for the facility for the given duration,
+// after which the level it had before is restored. Setting the level in the
+// meantime cancels the expiry.
+func (l *logger) SetDebugFor(facility string, d time.Duration) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	exp := &debugExpiry{at: time.Now().Add(d)}
+	if old, ok := l.expiries[facility]; ok {
+		old.timer.Stop()
+		exp.prev, exp.hadPrev = old.prev, old.hadPrev
+	} else {
+		exp.prev, exp.hadPrev = l.levels[facility]
+	}
+	exp.timer = time.AfterFunc(d, func() { l.expireDebug(facility, exp) })
+	l.expiries[facility] = exp
+	l.levels[facility] = LevelDebug
+	l.updateFlagsLocked()
+}
+
+func (l *logger) expireDebug(facility string, exp *debugExpiry) {
+	l.mut.Lock()
+	if l.expiries[facility] != exp {
+		// Cancelled or replaced in the meantime.
+		l.mut.Unlock()
+		return
+	}
+	delete(l.expiries, facility)
+	if exp.hadPrev {
+		l.levels[facility] = exp.prev
+	} else {
+		delete(l.levels, facility)
+	}
+	l.updateFlagsLocked()
+	l.mut.Unlock()
+	l.Infof("Debug data for %q expired", facility)
+}
+
+func (l *logger) cancelExpiryLocked(facility string) {
+	if exp, ok := l.expiries[facility]; ok {
+		exp.timer.Stop()
+		delete(l.expiries, facility)
+	}
+}
+
+// DebugExpiries returns the facilities with temporarily enabled debug
+// output and when it expires.
+func (l *logger) DebugExpiries() map[string]time.Time {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	res := make(map[string]time.Time, len(l.expiries))
+	for facility, exp := range l.expiries {
+		res[facility] = exp.at
+	}
+	return res
+}
+
+func (l *logger) updateFlagsLocked() {
+	for _, level := range l.levels {
+		if level == LevelDebug {
+			l.SetFlags(DebugFlags)
+			return
 		}
 	}
+	l.SetFlags(DefaultFlags)
+}
+
+// FacilityLevels returns the facilities that have a level other than
+// DefaultLevel set, and their levels.
+func (l *logger) FacilityLevels() map[string]LogLevel {
+	l.mut.Lock()
+	res := make(map[string]LogLevel, len(l.levels))
+	for facility, level := range l.levels {
+		res[facility] = level
+	}
+	l.mut.Unlock()
+	return res
 }
 
 // IsTraced returns whether the facility name is contained in STTRACE.
@@ -223,10 +361,12 @@ func (l *logger) IsTraced(facility string) bool {
 // FacilityDebugging returns the set of facilities that have debugging
 // enabled.
 func (l *logger) FacilityDebugging() []string {
-	enabled := make([]string, 0, len(l.debug))
 	l.mut.Lock()
-	for facility := range l.debug {
-		enabled = append(enabled, facility)
+	enabled := make([]string, 0, len(l.levels))
+	for facility, level := range l.levels {
+		if level == LevelDebug {
+			enabled = append(enabled, facility)
+		}
 	}
 	l.mut.Unlock()
 	return enabled
@@ -246,7 +386,9 @@ func (l *logger) Facilities() map[string]string {
 
 // NewFacility returns a new logger bound to the named facility.
 func (l *logger) NewFacility(facility, description string) Logger {
-	l.SetDebug(facility, l.IsTraced(facility))
+	if l.IsTraced(facility) {
+		l.SetDebug(facility, true)
+	}
 
 	l.mut.Lock()
 	l.facilities[facility] = description
@@ -258,20 +400,28 @@ func (l *logger) NewFacility(facility, description string) Logger {
 	}
 }
 
-// A facilityLogger is a regular logger but bound to a facility name. The
-// Debugln and Debugf methods are no-ops unless debugging has been enabled for
-// this facility on the parent logger.
+// A facilityLogger is a regular logger but bound to a facility name. Messages
+// below the level set for the facility are dropped; in particular the
+// Debugln and Debugf methods are no-ops unless debugging has been enabled
+// for this facility on the parent logger.
 type facilityLogger struct {
 	*logger
 	facility string
 }
 
+func (l *facilityLogger) output(level LogLevel, s string) {
+	if !l.shouldLog(l.facility, level) {
+		return
+	}
+	l.logger.output(3, level, l.facility, s)
+}
+
 // Debugln logs a line with a DEBUG prefix.
 func (l *facilityLogger) Debugln(vals ...interface{}) {
 	if !l.ShouldDebug(l.facility) {
 		return
 	}
-	l.logger.debugln(3, vals...)
+	l.output(LevelDebug, fmt.Sprintln(vals...))
 }
 
 // Debugf logs a formatted line with a DEBUG prefix.
@@ -279,34 +429,74 @@ func (l *facilityLogger) Debugf(format string, vals ...interface{}) {
 	if !l.ShouldDebug(l.facility) {
 		return
 	}
-	l.logger.debugf(3, format, vals...)
+	l.output(LevelDebug, fmt.Sprintf(format, vals...))
+}
+
+// Verboseln logs a line with a VERBOSE prefix.
+func (l *facilityLogger) Verboseln(vals ...interface{}) {
+	l.output(LevelVerbose, fmt.Sprintln(vals...))
+}
+
+// Verbosef logs a formatted line with a VERBOSE prefix.
+func (l *facilityLogger) Verbosef(format string, vals ...interface{}) {
+	l.output(LevelVerbose, fmt.Sprintf(format, vals...))
+}
+
+// Infoln logs a line with an INFO prefix.
+func (l *facilityLogger) Infoln(vals ...interface{}) {
+	l.output(LevelInfo, fmt.Sprintln(vals...))
+}
+
+// Infof logs a formatted line with an INFO prefix.
+func (l *facilityLogger) Infof(format string, vals ...interface{}) {
+	l.output(LevelInfo, fmt.Sprintf(format, vals...))
+}
+
+// Warnln logs a formatted line with a WARNING prefix.
+func (l *facilityLogger) Warnln(vals ...interface{}) {
+	l.output(LevelWarn, fmt.Sprintln(vals...))
+}
+
+// Warnf logs a formatted line with a WARNING prefix.
+func (l *facilityLogger) Warnf(format string, vals ...interface{}) {
+	l.output(LevelWarn, fmt.Sprintf(format, vals...))
 }
 
 // A Recorder keeps a size limited record of log events.
 type Recorder interface {
 	Since(t time.Time) []Line
+	// After returns the recorded lines with a sequence number larger than
+	// seq, and a channel that is closed when the next line is recorded.
+	After(seq int64) ([]Line, <-chan struct{})
 	Clear()
 }
 
 type recorder struct {
 	lines   []Line
 	initial int
+	seq     int64
+	changed chan struct{}
 	mut     sync.Mutex
 }
 
 // A Line represents a single log entry.
 type Line struct {
-	When    time.Time `json:"when"`
-	Message string    `json:"message"`
-	Level   LogLevel  `json:"level"`
+	When     time.Time `json:"when"`
+	Message  string    `json:"message"`
+	Level    LogLevel  `json:"level"`
+	Facility string    `json:"facility,omitempty"`
+	// Seq increases by one for every line recorded, and may be used to
+	// retrieve the lines following it.
+	Seq int64 `json:"seq,omitempty"`
 }
 
 func NewRecorder(l Logger, level LogLevel, size, initial int) Recorder {
 	r := &recorder{
 		lines:   make([]Line, 0, size),
 		initial: initial,
+		changed: make(chan struct{}),
 	}
-	l.AddHandler(level, r.append)
+	l.AddLineHandler(level, r.append)
 	return r
 }
 
@@ -329,22 +519,34 @@ func (r *recorder) Since(t time.Time) []Line {
 	return nil
 }
 
+func (r *recorder) After(seq int64) ([]Line, <-chan struct{}) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	var res []Line
+	for _, line := range r.lines {
+		if line.Seq > seq {
+			res = append(res, line)
+		}
+	}
+	return res, r.changed
+}
+
 func (r *recorder) Clear() {
 	r.mut.Lock()
 	r.lines = r.lines[:0]
 	r.mut.Unlock()
 }
 
-func (r *recorder) append(l LogLevel, msg string) {
-	line := Line{
-		When:    time.Now(), // intentionally high precision
-		Message: msg,
-		Level:   l,
-	}
-
+func (r *recorder) append(line Line) {
 	r.mut.Lock()
 	defer r.mut.Unlock()
 
+	r.seq++
+	line.Seq = r.seq
+	close(r.changed)
+	r.changed = make(chan struct{})
+
 	if len(r.lines) == cap(r.lines) {
 		if r.initial > 0 {
 			// Shift all lines one step to the left, keeping the "initial" first intact.
@@ -359,7 +561,8 @@ func (r *recorder) append(l LogLevel, msg string) {
 
 	r.lines = append(r.lines, line)
 	if len(r.lines) == r.initial {
-		r.lines = append(r.lines, Line{time.Now(), "...", l})
+		r.seq++
+		r.lines = append(r.lines, Line{When: time.Now(), Message: "...", Level: line.Level, Seq: r.seq})
 	}
 }
 
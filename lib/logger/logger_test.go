@@ -154,15 +154,139 @@ func TestRecorder(t *testing.T) {
 	}
 }
 
+func TestFacilityLevels(t *testing.T) {
+	l := New()
+	l.SetFlags(0)
+
+	var lines []Line
+	l.AddLineHandler(LevelDebug, func(line Line) {
+		lines = append(lines, line)
+	})
+
+	f0 := l.NewFacility("f0", "foo#0")
+	f1 := l.NewFacility("f1", "foo#1")
+	l.SetLevel("f0", LevelWarn)
+	l.SetLevel("f1", LevelDebug)
+
+	f0.Infoln("Info line from f0")
+	f0.Warnln("Warn line from f0")
+	f1.Debugln("Debug line from f1")
+	f1.Verboseln("Verbose line from f1")
+
+	if len(lines) != 3 {
+		t.Fatalf("Incorrect number of messages, %d != 3", len(lines))
+	}
+	if lines[0].Facility != "f0" || lines[0].Level != LevelWarn || lines[0].Message != "Warn line from f0" {
+		t.Errorf("Unexpected line %+v", lines[0])
+	}
+	if lines[1].Facility != "f1" || lines[1].Level != LevelDebug {
+		t.Errorf("Unexpected line %+v", lines[1])
+	}
+	if !l.ShouldDebug("f1") || l.ShouldDebug("f0") {
+		t.Error("Debugging should follow the level")
+	}
+
+	l.SetLevel("f0", DefaultLevel)
+	l.SetDebug("f1", false)
+	if levels := l.FacilityLevels(); len(levels) != 0 {
+		t.Errorf("Expected no levels, got %v", levels)
+	}
+}
+
+func TestSetDebugFor(t *testing.T) {
+	l := New()
+	l.SetFlags(0)
+	l.NewFacility("f0", "foo#0")
+	l.NewFacility("f1", "foo#1")
+	l.SetLevel("f0", LevelWarn)
+
+	l.SetDebugFor("f0", 10*time.Millisecond)
+	l.SetDebugFor("f1", time.Hour)
+	if !l.ShouldDebug("f0") || !l.ShouldDebug("f1") {
+		t.Fatal("Debugging should be enabled")
+	}
+	if exp := l.DebugExpiries(); len(exp) != 2 {
+		t.Fatalf("Expected two expiries, got %v", exp)
+	}
+
+	// Setting the level explicitly makes it stick.
+	l.SetDebug("f1", true)
+	if _, ok := l.DebugExpiries()["f1"]; ok {
+		t.Error("Expiry should be cancelled")
+	}
+
+	for i := 0; l.ShouldDebug("f0"); i++ {
+		if i > 1000 {
+			t.Fatal("Debugging did not expire")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if levels := l.FacilityLevels(); levels["f0"] != LevelWarn || levels["f1"] != LevelDebug {
+		t.Errorf("Unexpected levels after expiry: %v", levels)
+	}
+	if exp := l.DebugExpiries(); len(exp) != 0 {
+		t.Errorf("Expected no expiries, got %v", exp)
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	for level := LevelDebug; level < NumLevels; level++ {
+		if parsed, err := ParseLogLevel(level.String()); err != nil || parsed != level {
+			t.Errorf("Parsing %v: got %v, %v", level, parsed, err)
+		}
+	}
+	if _, err := ParseLogLevel("loud"); err == nil {
+		t.Error("Expected error for unknown level")
+	}
+}
+
+func TestRecorderAfter(t *testing.T) {
+	l := New()
+	l.SetFlags(0)
+	r := NewRecorder(l, LevelInfo, 5, 0)
+
+	lines, changed := r.After(0)
+	if len(lines) != 0 {
+		t.Fatalf("Unexpected lines %v", lines)
+	}
+	for i := 0; i < 10; i++ {
+		l.Infof("Info#%d", i)
+	}
+	select {
+	case <-changed:
+	default:
+		t.Fatal("Channel should be closed after new lines")
+	}
+
+	// The older lines are gone, the rest can be fetched in steps.
+	lines, _ = r.After(0)
+	if len(lines) != 5 || lines[0].Message != "Info#5" {
+		t.Fatalf("Unexpected lines %v", lines)
+	}
+	lines, changed = r.After(lines[2].Seq)
+	if len(lines) != 2 || lines[0].Message != "Info#8" {
+		t.Fatalf("Unexpected lines %v", lines)
+	}
+	if lines, _ = r.After(lines[1].Seq); len(lines) != 0 {
+		t.Fatalf("Unexpected lines %v", lines)
+	}
+	select {
+	case <-changed:
+		t.Fatal("Channel should not be closed without new lines")
+	default:
+	}
+}
+
 func TestStackLevel(t *testing.T) {
 	b := new(bytes.Buffer)
 	l := newLogger(b)
 
 	l.SetFlags(log.Lshortfile)
 	l.Infoln("testing")
+	l.NewFacility("f0", "foo#0").Infoln("testing")
 	res := b.String()
 
-	if !strings.Contains(res, "logger_test.go:") {
+	if strings.Count(res, "logger_test.go:") != 2 {
 		t.Logf("%q", res)
 		t.Error("Should identify this file as the source (bad level?)")
 	}
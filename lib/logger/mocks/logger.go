@@ -9,6 +9,19 @@ import (
 )
 
 type Recorder struct {
+	AfterStub        func(int64) ([]logger.Line, <-chan struct{})
+	afterMutex       sync.RWMutex
+	afterArgsForCall []struct {
+		arg1 int64
+	}
+	afterReturns struct {
+		result1 []logger.Line
+		result2 <-chan struct{}
+	}
+	afterReturnsOnCall map[int]struct {
+		result1 []logger.Line
+		result2 <-chan struct{}
+	}
 	ClearStub        func()
 	clearMutex       sync.RWMutex
 	clearArgsForCall []struct {
@@ -28,6 +41,70 @@ type Recorder struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *Recorder) After(arg1 int64) ([]logger.Line, <-chan struct{}) {
+	fake.afterMutex.Lock()
+	ret, specificReturn := fake.afterReturnsOnCall[len(fake.afterArgsForCall)]
+	fake.afterArgsForCall = append(fake.afterArgsForCall, struct {
+		arg1 int64
+	}{arg1})
+	stub := fake.AfterStub
+	fakeReturns := fake.afterReturns
+	fake.recordInvocation("After", []interface{}{arg1})
+	fake.afterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Recorder) AfterCallCount() int {
+	fake.afterMutex.RLock()
+	defer fake.afterMutex.RUnlock()
+	return len(fake.afterArgsForCall)
+}
+
+func (fake *Recorder) AfterCalls(stub func(int64) ([]logger.Line, <-chan struct{})) {
+	fake.afterMutex.Lock()
+	defer fake.afterMutex.Unlock()
+	fake.AfterStub = stub
+}
+
+func (fake *Recorder) AfterArgsForCall(i int) int64 {
+	fake.afterMutex.RLock()
+	defer fake.afterMutex.RUnlock()
+	argsForCall := fake.afterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Recorder) AfterReturns(result1 []logger.Line, result2 <-chan struct{}) {
+	fake.afterMutex.Lock()
+	defer fake.afterMutex.Unlock()
+	fake.AfterStub = nil
+	fake.afterReturns = struct {
+		result1 []logger.Line
+		result2 <-chan struct{}
+	}{result1, result2}
+}
+
+func (fake *Recorder) AfterReturnsOnCall(i int, result1 []logger.Line, result2 <-chan struct{}) {
+	fake.afterMutex.Lock()
+	defer fake.afterMutex.Unlock()
+	fake.AfterStub = nil
+	if fake.afterReturnsOnCall == nil {
+		fake.afterReturnsOnCall = make(map[int]struct {
+			result1 []logger.Line
+			result2 <-chan struct{}
+		})
+	}
+	fake.afterReturnsOnCall[i] = struct {
+		result1 []logger.Line
+		result2 <-chan struct{}
+	}{result1, result2}
+}
+
 func (fake *Recorder) Clear() {
 	fake.clearMutex.Lock()
 	fake.clearArgsForCall = append(fake.clearArgsForCall, struct {
@@ -116,6 +193,8 @@ func (fake *Recorder) SinceReturnsOnCall(i int, result1 []logger.Line) {
 func (fake *Recorder) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.afterMutex.RLock()
+	defer fake.afterMutex.RUnlock()
 	fake.clearMutex.RLock()
 	defer fake.clearMutex.RUnlock()
 	fake.sinceMutex.RLock()
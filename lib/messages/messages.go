@@ -0,0 +1,115 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package messages defines stable codes for the user-facing messages in
+// events and API responses, with their English templates. GUIs localize
+// messages by their code, while the English text may change between
+// releases.
+package messages
+
+import (
+	"errors"
+	"strings"
+	"syscall"
+
+	"github.com/syncthing/syncthing/lib/fs"
+)
+
+// A Code identifies a message independent of its wording.
+type Code string
+
+// Errors on items, as reported in folder errors.
+const (
+	FileInvalidName Code = "file.invalidName"
+	FileUnsupported Code = "file.unsupported"
+	FilePermission  Code = "file.permission"
+	FileNotFound    Code = "file.notFound"
+	FileExists      Code = "file.exists"
+	FileNoSpace     Code = "file.noSpace"
+	FileReadOnly    Code = "file.readOnly"
+	FileReceiveOnly Code = "file.receiveOnly"
+	FileUnavailable Code = "file.unavailable"
+	FileFailed      Code = "file.failed"
+)
+
+// Errors of folders, as reported in folder states.
+const (
+	FolderPathMissing      Code = "folder.pathMissing"
+	FolderPathNotDirectory Code = "folder.pathNotDirectory"
+	FolderMarkerMissing    Code = "folder.markerMissing"
+	FolderEmptied          Code = "folder.emptied"
+	FolderReadOnly         Code = "folder.readOnly"
+	FolderFailed           Code = "folder.failed"
+)
+
+// The English templates by code. Parameters are given as {%name%}, like in
+// the GUI translations.
+var catalog = map[Code]string{
+	FileInvalidName: "The name is not valid on this system.",
+	FileUnsupported: "The kind of item is not supported on this system.",
+	FilePermission:  "Permission denied.",
+	FileNotFound:    "The item does not exist.",
+	FileExists:      "The item already exists.",
+	FileNoSpace:     "There is no space left on the disk.",
+	FileReadOnly:    "The filesystem is read-only.",
+	FileReceiveOnly: "The item was changed locally in a receive only folder.",
+	FileUnavailable: "No connected device has the required version of the item.",
+	FileFailed:      "Syncing the item failed: {%error%}",
+
+	FolderPathMissing:      "The folder path is missing.",
+	FolderPathNotDirectory: "The folder path is not a directory.",
+	FolderMarkerMissing:    "The folder marker is missing, which indicates potential data loss.",
+	FolderEmptied:          "The folder is empty while many items were expected, which indicates potential data loss. Confirm whether to delete them on other devices or to pull them again.",
+	FolderReadOnly:         "The folder is on a read-only filesystem and acts as send only until it is writable again.",
+	FolderFailed:           "The folder failed: {%error%}",
+}
+
+// Catalog returns the English templates of all messages by code.
+func Catalog() map[Code]string {
+	res := make(map[Code]string, len(catalog))
+	for code, tpl := range catalog {
+		res[code] = tpl
+	}
+	return res
+}
+
+// Text returns the English text of the message, with the parameters filled
+// in. Unknown codes are returned as they are.
+func Text(code Code, params map[string]string) string {
+	tpl, ok := catalog[code]
+	if !ok {
+		return string(code)
+	}
+	if len(params) == 0 {
+		return tpl
+	}
+	oldnew := make([]string, 0, 2*len(params))
+	for name, value := range params {
+		oldnew = append(oldnew, "{%"+name+"%}", value)
+	}
+	return strings.NewReplacer(oldnew...).Replace(tpl)
+}
+
+// FileErrorCode returns the code for a filesystem error on an item, or
+// FileFailed if there is none more specific.
+func FileErrorCode(err error) Code {
+	switch {
+	case errors.Is(err, fs.ErrInvalidFilename):
+		return FileInvalidName
+	case fs.IsPermission(err):
+		return FilePermission
+	case fs.IsNotExist(err):
+		return FileNotFound
+	case fs.IsExist(err):
+		return FileExists
+	case errors.Is(err, syscall.ENOSPC):
+		return FileNoSpace
+	case fs.IsReadOnlyFilesystem(err):
+		return FileReadOnly
+	default:
+		return FileFailed
+	}
+}
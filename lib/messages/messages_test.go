@@ -0,0 +1,48 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package messages
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/fs"
+)
+
+func TestText(t *testing.T) {
+	if text := Text(FilePermission, nil); text != "Permission denied." {
+		t.Errorf("unexpected text %q", text)
+	}
+	if text := Text(FileFailed, map[string]string{"error": "boom"}); text != "Syncing the item failed: boom" {
+		t.Errorf("unexpected text %q", text)
+	}
+	if text := Text("no.such.code", nil); text != "no.such.code" {
+		t.Errorf("unexpected text %q for unknown code", text)
+	}
+}
+
+func TestFileErrorCode(t *testing.T) {
+	cases := []struct {
+		err  error
+		code Code
+	}{
+		{fmt.Errorf("%w, contains a reserved character", fs.ErrInvalidFilename), FileInvalidName},
+		{&os.PathError{Op: "open", Path: "file", Err: os.ErrPermission}, FilePermission},
+		{&os.PathError{Op: "open", Path: "file", Err: os.ErrNotExist}, FileNotFound},
+		{&os.PathError{Op: "write", Path: "file", Err: syscall.ENOSPC}, FileNoSpace},
+		{&os.PathError{Op: "open", Path: "file", Err: syscall.EROFS}, FileReadOnly},
+		{errors.New("something else"), FileFailed},
+	}
+	for _, tc := range cases {
+		if code := FileErrorCode(tc.err); code != tc.code {
+			t.Errorf("expected code %v for %v, got %v", tc.code, tc.err, code)
+		}
+	}
+}
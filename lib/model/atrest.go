@@ -0,0 +1,213 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+var (
+	errNotAtRestEncrypted = errors.New("folder is not encrypted at rest")
+	errAtRestPassword     = errors.New("wrong password for folder encrypted at rest")
+	errAtRestNoPassword   = errors.New("password must not be empty")
+	errAtRestNotEmpty     = errors.New("folder must be empty when setting the password for encryption at rest")
+	errAtRestNotRegular   = errors.New("not a regular file")
+)
+
+// atRestKeyRegistry holds the keys of the unlocked folders that are
+// encrypted at rest. Keys are only ever kept in memory, so all such
+// folders are locked after a restart. It has a lock of its own as it's
+// used while fmut is held.
+type atRestKeyRegistry struct {
+	keys map[string]*[32]byte
+	mut  sync.RWMutex
+}
+
+func newAtRestKeyRegistry() *atRestKeyRegistry {
+	return &atRestKeyRegistry{
+		keys: make(map[string]*[32]byte),
+		mut:  sync.NewRWMutex(),
+	}
+}
+
+// get returns the key of the folder, or nil if it's locked.
+func (r *atRestKeyRegistry) get(folder string) *[32]byte {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	return r.keys[folder]
+}
+
+func (r *atRestKeyRegistry) set(folder string, key *[32]byte) {
+	r.mut.Lock()
+	r.keys[folder] = key
+	r.mut.Unlock()
+}
+
+func (r *atRestKeyRegistry) remove(folder string) {
+	r.mut.Lock()
+	delete(r.keys, folder)
+	r.mut.Unlock()
+}
+
+// folderFilesystem returns the filesystem of the folder, which decrypts
+// names and contents if the folder is encrypted at rest. All operations on
+// it fail while such a folder is locked. Folders with deduplicated storage
+// share the pool of the filesystem type.
+func (m *model) folderFilesystem(cfg config.FolderConfiguration, fset *db.FileSet) fs.Filesystem {
+	var opts []fs.Option
+	if cfg.Deduplicate {
+		opts = append(opts, fs.NewDedupOption(m.dedupPoolFilesystem(cfg)))
+	}
+	if cfg.AtRestEncryption {
+		opts = append(opts, fs.NewEncryptionOption(m.atRestKeys.get(cfg.ID), cfg.MarkerName))
+	}
+	return cfg.Filesystem(fset, opts...)
+}
+
+func (m *model) dedupPoolFilesystem(cfg config.FolderConfiguration) fs.Filesystem {
+	return fs.NewFilesystem(cfg.FilesystemType, m.profile.Get(locations.DedupPool))
+}
+
+// blockPoolFolders returns all folders with deduplicated storage, including
+// paused ones, as their files keep referring to the pool.
+func (m *model) blockPoolFolders() []blockPoolFolder {
+	var folders []blockPoolFolder
+	for _, cfg := range m.cfg.Folders() {
+		if !cfg.Deduplicate {
+			continue
+		}
+		m.fmut.RLock()
+		runner := m.folderRunners[cfg.ID]
+		m.fmut.RUnlock()
+		folder := blockPoolFolder{
+			id:         cfg.ID,
+			pool:       m.dedupPoolFilesystem(cfg),
+			filesystem: m.folderFilesystem(cfg, nil),
+		}
+		if runner != nil {
+			folder.repairer = runner
+		}
+		folders = append(folders, folder)
+	}
+	return folders
+}
+
+// UnlockFolder makes the data of a folder encrypted at rest accessible,
+// using a key derived from the password. The first unlock of a folder sets
+// its password, which requires the folder to be empty, as anything already
+// in it could not be decrypted.
+func (m *model) UnlockFolder(folder, password string) error {
+	cfg, ok := m.cfg.Folder(folder)
+	if !ok {
+		return ErrFolderMissing
+	}
+	if !cfg.AtRestEncryption {
+		return errNotAtRestEncrypted
+	}
+	if password == "" {
+		return errAtRestNoPassword
+	}
+
+	token := protocol.PasswordToken(m.keyGen, folder, password)
+	stored, err := readStoredToken(cfg, atRestTokenPath(cfg))
+	switch {
+	case fs.IsNotExist(err):
+		if err := checkAtRestEmpty(cfg); err != nil {
+			return err
+		}
+		if err := writeStoredToken(token, cfg, atRestTokenPath(cfg)); err != nil {
+			return err
+		}
+		l.Infof("Set the password for encryption at rest of folder %v", cfg.Description())
+	case err != nil:
+		return err
+	case !bytes.Equal(stored, token):
+		return errAtRestPassword
+	}
+
+	m.atRestKeys.set(folder, m.keyGen.KeyFromPassword(folder, password))
+	l.Infof("Unlocked folder %v", cfg.Description())
+	return m.restartAtRestFolder(cfg)
+}
+
+// LockFolder forgets the key of a folder encrypted at rest, making its data
+// inaccessible until it's unlocked again.
+func (m *model) LockFolder(folder string) error {
+	cfg, ok := m.cfg.Folder(folder)
+	if !ok {
+		return ErrFolderMissing
+	}
+	if !cfg.AtRestEncryption {
+		return errNotAtRestEncrypted
+	}
+	if m.atRestKeys.get(folder) == nil {
+		return nil
+	}
+	m.atRestKeys.remove(folder)
+	l.Infof("Locked folder %v", cfg.Description())
+	return m.restartAtRestFolder(cfg)
+}
+
+// OpenPlaintext opens a file of an unlocked folder that is encrypted at
+// rest, for reading its plaintext. It fails with fs.ErrLocked while the
+// folder is locked.
+func (m *model) OpenPlaintext(folder, file string) (fs.File, error) {
+	cfg, ok := m.cfg.Folder(folder)
+	if !ok {
+		return nil, ErrFolderMissing
+	}
+	if !cfg.AtRestEncryption {
+		return nil, errNotAtRestEncrypted
+	}
+	if fs.IsInternal(file) {
+		return nil, fs.ErrNotExist
+	}
+	ffs := m.folderFilesystem(cfg, nil)
+	info, err := ffs.Lstat(file)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsRegular() {
+		return nil, errAtRestNotRegular
+	}
+	return ffs.Open(file)
+}
+
+func (m *model) restartAtRestFolder(cfg config.FolderConfiguration) error {
+	if cfg.Paused {
+		return nil
+	}
+	return m.restartFolder(cfg, cfg, m.cfg.Options().CacheIgnoredFiles)
+}
+
+func atRestTokenPath(cfg config.FolderConfiguration) string {
+	return filepath.Join(cfg.MarkerName, config.AtRestTokenName)
+}
+
+// checkAtRestEmpty returns an error if the folder contains anything besides
+// our metadata.
+func checkAtRestEmpty(cfg config.FolderConfiguration) error {
+	names, err := cfg.Filesystem(nil).DirNames(".")
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if name != cfg.MarkerName && !fs.IsInternal(name) {
+			return errAtRestNotEmpty
+		}
+	}
+	return nil
+}
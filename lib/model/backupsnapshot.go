@@ -0,0 +1,204 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/rand"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+const (
+	// How long a backup snapshot is kept when not given, and at most.
+	// Keeping a snapshot keeps the database from discarding the old data
+	// it refers to, so it shouldn't be open for longer than necessary.
+	backupSnapshotDefaultTTL = time.Hour
+	backupSnapshotMaxTTL     = 24 * time.Hour
+
+	// How often expired snapshots are released.
+	backupSnapshotPruneInterval = time.Minute
+)
+
+var errBackupSnapshotMissing = errors.New("no such snapshot, or it has expired")
+
+// A BackupSnapshot is a consistent view of the local items of a folder as
+// of a sequence. Listing its items gives the same result, regardless of
+// changes to the folder in the meantime, until it expires.
+type BackupSnapshot struct {
+	ID          string    `json:"id"`
+	Folder      string    `json:"folder"`
+	Sequence    int64     `json:"sequence"`
+	Created     time.Time `json:"created"`
+	Expires     time.Time `json:"expires"`
+	Files       int       `json:"files"`
+	Directories int       `json:"directories"`
+	Symlinks    int       `json:"symlinks"`
+	Bytes       int64     `json:"bytes"`
+}
+
+type backupSnapshot struct {
+	BackupSnapshot
+	snap *db.Snapshot
+}
+
+// backupSnapshots keeps the database snapshots handed out to backup tools
+// until they are released or expire.
+type backupSnapshots struct {
+	mut     sync.Mutex
+	snaps   map[string]*backupSnapshot
+	timeNow func() time.Time
+}
+
+func newBackupSnapshots() *backupSnapshots {
+	return &backupSnapshots{
+		mut:     sync.NewMutex(),
+		snaps:   make(map[string]*backupSnapshot),
+		timeNow: time.Now,
+	}
+}
+
+// Serve releases snapshots as they expire, and all of them when stopped,
+// as the database can't be closed while they're open.
+func (s *backupSnapshots) Serve(ctx context.Context) error {
+	ticker := time.NewTicker(backupSnapshotPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.prune()
+		case <-ctx.Done():
+			s.releaseWhere(func(*backupSnapshot) bool { return true })
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *backupSnapshots) add(folder string, snap *db.Snapshot, ttl time.Duration) BackupSnapshot {
+	now := s.timeNow().Truncate(time.Second)
+	local := snap.LocalSize()
+	bs := &backupSnapshot{
+		BackupSnapshot: BackupSnapshot{
+			ID:          rand.String(16),
+			Folder:      folder,
+			Sequence:    snap.Sequence(protocol.LocalDeviceID),
+			Created:     now,
+			Expires:     now.Add(ttl),
+			Files:       local.Files,
+			Directories: local.Directories,
+			Symlinks:    local.Symlinks,
+			Bytes:       local.Bytes,
+		},
+		snap: snap,
+	}
+	s.mut.Lock()
+	s.snaps[bs.ID] = bs
+	s.mut.Unlock()
+	return bs.BackupSnapshot
+}
+
+// with calls fn with the snapshot, unless it doesn't exist or has
+// expired. The snapshot is not released while fn runs.
+func (s *backupSnapshots) with(id string, fn func(*backupSnapshot)) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	bs, ok := s.snaps[id]
+	if !ok || !s.timeNow().Before(bs.Expires) {
+		return errBackupSnapshotMissing
+	}
+	fn(bs)
+	return nil
+}
+
+func (s *backupSnapshots) release(id string) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	bs, ok := s.snaps[id]
+	if !ok {
+		return errBackupSnapshotMissing
+	}
+	bs.snap.Release()
+	delete(s.snaps, id)
+	return nil
+}
+
+func (s *backupSnapshots) prune() {
+	now := s.timeNow()
+	s.releaseWhere(func(bs *backupSnapshot) bool { return !now.Before(bs.Expires) })
+}
+
+func (s *backupSnapshots) forget(folder string) {
+	s.releaseWhere(func(bs *backupSnapshot) bool { return bs.Folder == folder })
+}
+
+func (s *backupSnapshots) releaseWhere(fn func(*backupSnapshot) bool) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	for id, bs := range s.snaps {
+		if fn(bs) {
+			bs.snap.Release()
+			delete(s.snaps, id)
+		}
+	}
+}
+
+// CreateBackupSnapshot takes a snapshot of the local items of the folder,
+// kept for the given time or the default if zero.
+func (m *model) CreateBackupSnapshot(folder string, ttl time.Duration) (BackupSnapshot, error) {
+	if ttl <= 0 {
+		ttl = backupSnapshotDefaultTTL
+	} else if ttl > backupSnapshotMaxTTL {
+		ttl = backupSnapshotMaxTTL
+	}
+
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	fset := m.folderFiles[folder]
+	m.fmut.RUnlock()
+	if err != nil {
+		return BackupSnapshot{}, err
+	}
+
+	snap, err := fset.Snapshot()
+	if err != nil {
+		return BackupSnapshot{}, err
+	}
+	bs := m.backupSnaps.add(folder, snap, ttl)
+	l.Debugf("Created backup snapshot %s of folder %s at sequence %d", bs.ID, folder, bs.Sequence)
+	return bs, nil
+}
+
+// BackupSnapshotFiles returns a page of the items in the snapshot, in
+// order of their names. Deleted and ignored items are left out.
+func (m *model) BackupSnapshotFiles(id string, page, perpage int) (BackupSnapshot, []db.FileInfoTruncated, error) {
+	var info BackupSnapshot
+	files := make([]db.FileInfoTruncated, 0, perpage)
+	err := m.backupSnaps.with(id, func(bs *backupSnapshot) {
+		info = bs.BackupSnapshot
+		p := newPager(page, perpage)
+		bs.snap.WithHaveTruncated(protocol.LocalDeviceID, func(intf protocol.FileIntf) bool {
+			if intf.IsDeleted() || intf.IsInvalid() || p.skip() {
+				return true
+			}
+			files = append(files, intf.(db.FileInfoTruncated))
+			return !p.done()
+		})
+	})
+	if err != nil {
+		return BackupSnapshot{}, nil, err
+	}
+	return info, files, nil
+}
+
+// ReleaseBackupSnapshot releases the snapshot before it expires.
+func (m *model) ReleaseBackupSnapshot(id string) error {
+	return m.backupSnaps.release(id)
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestBackupSnapshotIsStable(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+
+	version := protocol.Vector{}.Update(myID.Short())
+	m.fmut.RLock()
+	fset := m.folderFiles[fcfg.ID]
+	m.fmut.RUnlock()
+	fset.Update(protocol.LocalDeviceID, []protocol.FileInfo{
+		{Name: "a", Version: version, Size: 10, Sequence: 1},
+		{Name: "b", Version: version, Size: 20, Sequence: 2},
+		{Name: "gone", Version: version, Deleted: true, Sequence: 3},
+	})
+
+	bs, err := m.CreateBackupSnapshot(fcfg.ID, time.Minute)
+	must(t, err)
+	if bs.Files != 2 || bs.Bytes != 30 {
+		t.Errorf("expected two files of 30 bytes, got %+v", bs)
+	}
+
+	// Changes after taking the snapshot aren't visible in it.
+	fset.Update(protocol.LocalDeviceID, []protocol.FileInfo{
+		{Name: "a", Version: version.Update(myID.Short()), Deleted: true, Sequence: 4},
+		{Name: "c", Version: version, Size: 30, Sequence: 5},
+	})
+
+	info, files, err := m.BackupSnapshotFiles(bs.ID, 1, 10)
+	must(t, err)
+	if info.Sequence != bs.Sequence {
+		t.Errorf("expected sequence %d, got %d", bs.Sequence, info.Sequence)
+	}
+	if len(files) != 2 || files[0].Name != "a" || files[1].Name != "b" {
+		t.Errorf("expected a and b in the snapshot, got %v", files)
+	}
+	_, files, err = m.BackupSnapshotFiles(bs.ID, 2, 1)
+	must(t, err)
+	if len(files) != 1 || files[0].Name != "b" {
+		t.Errorf("expected b on the second page, got %v", files)
+	}
+
+	must(t, m.ReleaseBackupSnapshot(bs.ID))
+	if _, _, err := m.BackupSnapshotFiles(bs.ID, 1, 10); !errors.Is(err, errBackupSnapshotMissing) {
+		t.Errorf("expected the released snapshot to be gone, got %v", err)
+	}
+}
+
+func TestBackupSnapshotExpires(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+
+	now := time.Now()
+	m.backupSnaps.timeNow = func() time.Time { return now }
+	bs, err := m.CreateBackupSnapshot(fcfg.ID, time.Minute)
+	must(t, err)
+
+	now = now.Add(2 * time.Minute)
+	if _, _, err := m.BackupSnapshotFiles(bs.ID, 1, 10); !errors.Is(err, errBackupSnapshotMissing) {
+		t.Errorf("expected the snapshot to have expired, got %v", err)
+	}
+	m.backupSnaps.prune()
+	if len(m.backupSnaps.snaps) != 0 {
+		t.Error("expected the expired snapshot to be released")
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// A deviceBlacklist holds devices until their blacklisting expires.
+type deviceBlacklist struct {
+	mut   sync.Mutex
+	until map[protocol.DeviceID]time.Time
+}
+
+func newDeviceBlacklist() *deviceBlacklist {
+	return &deviceBlacklist{
+		mut:   sync.NewMutex(),
+		until: make(map[protocol.DeviceID]time.Time),
+	}
+}
+
+func (b *deviceBlacklist) add(id protocol.DeviceID, d time.Duration) {
+	b.mut.Lock()
+	b.until[id] = time.Now().Add(d)
+	b.mut.Unlock()
+}
+
+func (b *deviceBlacklist) contains(id protocol.DeviceID) bool {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	until, ok := b.until[id]
+	if ok && time.Now().After(until) {
+		delete(b.until, id)
+		return false
+	}
+	return ok
+}
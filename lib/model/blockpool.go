@@ -0,0 +1,361 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/jobs"
+	"github.com/syncthing/syncthing/lib/semaphore"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+const (
+	BlockPoolStateIdle      = "idle"
+	BlockPoolStateScanning  = "scanning"
+	BlockPoolStateScrubbing = "scrubbing"
+
+	// Chunks are only removed as orphans when they haven't been stored
+	// for this long, as files are deduplicated while we scan.
+	blockPoolOrphanGrace = time.Hour
+
+	blockPoolGCJobName    = "blockPool/gc"
+	blockPoolScrubJobName = "blockPool/scrub"
+)
+
+// BlockPoolStatus describes the usage and integrity of the pools shared by
+// the folders with deduplicated storage.
+type BlockPoolStatus struct {
+	State     string    `json:"state"`
+	LastScan  time.Time `json:"lastScan"`
+	LastScrub time.Time `json:"lastScrub"`
+	NextScrub time.Time `json:"nextScrub"`
+	Chunks    int       `json:"chunks"`
+	Bytes     int64     `json:"bytes"`
+	// The sum of the sizes of all references, i.e. the space the chunks
+	// would take without deduplication.
+	ReferencedBytes int64 `json:"referencedBytes"`
+	References      int   `json:"references"`
+	// Number of chunks per number of references to them.
+	ReferenceCounts map[int]int         `json:"referenceCounts"`
+	Orphans         int                 `json:"orphans"`
+	OrphanBytes     int64               `json:"orphanBytes"`
+	RemovedOrphans  int                 `json:"removedOrphans"`
+	CorruptChunks   []string            `json:"corruptChunks"`
+	AffectedFiles   map[string][]string `json:"affectedFiles"` // folder -> files
+	Error           string              `json:"error,omitempty"`
+}
+
+type blockPoolFolder struct {
+	id         string
+	pool       fs.Filesystem
+	filesystem fs.Filesystem
+	repairer   repairer // nil when the folder isn't running
+}
+
+type blockPoolRef struct {
+	folder int
+	name   string
+}
+
+// The blockPool service keeps track of the chunks in the pools of folders
+// with deduplicated storage. It removes chunks no file refers to anymore,
+// and periodically verifies ("scrubs") the chunks against their hashes.
+// Files using a corrupt chunk are handed to their folder for repair.
+type blockPool struct {
+	cfg       config.Wrapper
+	folders   func() []blockPoolFolder
+	ioLimiter *semaphore.Semaphore
+	scheduler jobs.Scheduler
+	evLogger  events.Logger
+
+	mut    sync.Mutex
+	status BlockPoolStatus
+}
+
+func newBlockPool(cfg config.Wrapper, folders func() []blockPoolFolder, ioLimiter *semaphore.Semaphore, scheduler jobs.Scheduler, evLogger events.Logger) *blockPool {
+	return &blockPool{
+		cfg:       cfg,
+		folders:   folders,
+		ioLimiter: ioLimiter,
+		scheduler: scheduler,
+		evLogger:  evLogger,
+		mut:       sync.NewMutex(),
+		status:    BlockPoolStatus{State: BlockPoolStateIdle},
+	}
+}
+
+func (b *blockPool) Serve(ctx context.Context) error {
+	l.Debugln(b, "starting")
+	defer l.Debugln(b, "exiting")
+
+	// Like the folder scrubbers we don't start right away, and with no
+	// interval configured only run when explicitly requested.
+	first := time.Duration(-1)
+	if interval := b.scrubInterval(); interval > 0 {
+		first = interval
+	}
+	b.setNextScrub()
+	b.scheduler.Add(jobs.Job{
+		Name:  blockPoolGCJobName,
+		Class: jobClassDisk,
+		First: -1,
+		Run: func(ctx context.Context) error {
+			return b.run(ctx, false)
+		},
+	})
+	b.scheduler.Add(jobs.Job{
+		Name:     blockPoolScrubJobName,
+		Class:    jobClassDisk,
+		First:    first,
+		Interval: b.scrubInterval,
+		Run: func(ctx context.Context) error {
+			err := b.run(ctx, true)
+			b.setNextScrub()
+			return err
+		},
+	})
+	defer b.scheduler.Remove(blockPoolGCJobName)
+	defer b.scheduler.Remove(blockPoolScrubJobName)
+
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (b *blockPool) scrubInterval() time.Duration {
+	return time.Duration(b.cfg.Options().BlockPoolScrubIntervalS) * time.Second
+}
+
+func (b *blockPool) setNextScrub() {
+	var next time.Time
+	if interval := b.scrubInterval(); interval > 0 {
+		next = time.Now().Add(interval)
+	}
+	b.mut.Lock()
+	b.status.NextScrub = next
+	b.mut.Unlock()
+}
+
+// Collect schedules removing unreferenced chunks as soon as possible.
+func (b *blockPool) Collect() {
+	b.scheduler.Trigger(blockPoolGCJobName)
+}
+
+// Scrub schedules removing unreferenced chunks and verifying the
+// remaining ones as soon as possible.
+func (b *blockPool) Scrub() {
+	b.scheduler.Trigger(blockPoolScrubJobName)
+}
+
+// Status returns a copy of the current status.
+func (b *blockPool) Status() BlockPoolStatus {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	st := b.status
+	st.ReferenceCounts = make(map[int]int, len(b.status.ReferenceCounts))
+	for refs, chunks := range b.status.ReferenceCounts {
+		st.ReferenceCounts[refs] = chunks
+	}
+	st.CorruptChunks = append([]string(nil), b.status.CorruptChunks...)
+	st.AffectedFiles = make(map[string][]string, len(b.status.AffectedFiles))
+	for folder, files := range b.status.AffectedFiles {
+		st.AffectedFiles[folder] = append([]string(nil), files...)
+	}
+	return st
+}
+
+func (b *blockPool) String() string {
+	return fmt.Sprintf("blockPool@%p", b)
+}
+
+func (b *blockPool) setState(state string) {
+	b.mut.Lock()
+	b.status.State = state
+	b.mut.Unlock()
+}
+
+func (b *blockPool) run(ctx context.Context, scrub bool) error {
+	b.setState(BlockPoolStateScanning)
+	defer b.setState(BlockPoolStateIdle)
+
+	// Folders sharing a pool are handled together, as a chunk is only
+	// an orphan when none of them refers to it.
+	folders := b.folders()
+	pools := make(map[string][]int)
+	var uris []string
+	for i, folder := range folders {
+		uri := folder.pool.URI()
+		if _, ok := pools[uri]; !ok {
+			uris = append(uris, uri)
+		}
+		pools[uri] = append(pools[uri], i)
+	}
+
+	status := BlockPoolStatus{
+		ReferenceCounts: make(map[int]int),
+		AffectedFiles:   make(map[string][]string),
+	}
+	var firstErr error
+	for _, uri := range uris {
+		if err := b.runPool(ctx, folders, pools[uri], scrub, &status); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	status.NextScrub = b.status.NextScrub
+	status.LastScan = time.Now()
+	status.LastScrub = b.status.LastScrub
+	if scrub {
+		status.LastScrub = status.LastScan
+	} else {
+		// The corruption found by the last scrub is still of interest.
+		status.CorruptChunks = b.status.CorruptChunks
+		status.AffectedFiles = b.status.AffectedFiles
+	}
+	if firstErr != nil {
+		status.Error = firstErr.Error()
+	}
+	b.status = status
+	return firstErr
+}
+
+func (b *blockPool) runPool(ctx context.Context, folders []blockPoolFolder, idxs []int, scrub bool, status *BlockPoolStatus) error {
+	pool := fs.NewDedupPool(folders[idxs[0]].pool)
+
+	refs := make(map[string][]blockPoolRef)
+	for _, idx := range idxs {
+		err := fs.DedupReferences(folders[idx].filesystem, func(name string, chunks []string) {
+			for _, id := range chunks {
+				refs[id] = append(refs[id], blockPoolRef{idx, name})
+			}
+		})
+		if err != nil {
+			// Without all references we can't tell orphans apart.
+			return fmt.Errorf("folder %s: %w", folders[idx].id, err)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	type chunk struct {
+		id   string
+		size int64
+	}
+	var chunks []chunk
+	err := pool.Chunks(func(id string, size int64, modTime time.Time) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		status.Chunks++
+		status.Bytes += size
+		n := len(refs[id])
+		status.ReferenceCounts[n]++
+		status.References += n
+		status.ReferencedBytes += int64(n) * size
+		if n > 0 {
+			chunks = append(chunks, chunk{id, size})
+			return nil
+		}
+		status.Orphans++
+		status.OrphanBytes += size
+		if time.Since(modTime) < blockPoolOrphanGrace {
+			return nil
+		}
+		if err := pool.Remove(id); err != nil {
+			l.Debugf("%v: removing orphan %s: %v", b, id, err)
+			return nil
+		}
+		status.RemovedOrphans++
+		return nil
+	})
+	if err != nil || !scrub {
+		return err
+	}
+
+	b.setState(BlockPoolStateScrubbing)
+	l.Debugf("%v: scrubbing %d chunks", b, len(chunks))
+
+	corrupt := make(map[int][]string)
+	for _, c := range chunks {
+		ok, err := b.verify(ctx, pool, c.id)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			l.Debugf("%v: verifying %s: %v", b, c.id, err)
+			continue
+		}
+		if ok {
+			continue
+		}
+		l.Warnf("Chunk %s in the deduplication pool does not match its hash", c.id)
+		status.CorruptChunks = append(status.CorruptChunks, c.id)
+		// Removing the chunk makes the affected files fail to read,
+		// instead of returning bad data, until they are repaired.
+		if err := pool.Remove(c.id); err != nil {
+			l.Infof("Failed to remove corrupt chunk %s from the deduplication pool: %v", c.id, err)
+		}
+		for _, ref := range refs[c.id] {
+			corrupt[ref.folder] = append(corrupt[ref.folder], ref.name)
+		}
+	}
+
+	for _, idx := range idxs {
+		names := corrupt[idx]
+		if len(names) == 0 {
+			continue
+		}
+		folder := folders[idx]
+		sort.Strings(names)
+		names = dedupStrings(names)
+		status.AffectedFiles[folder.id] = names
+		for _, name := range names {
+			b.evLogger.Log(events.CorruptionDetected, map[string]interface{}{
+				"folder": folder.id,
+				"file":   name,
+			})
+		}
+		if folder.repairer == nil {
+			continue
+		}
+		if _, err := folder.repairer.RepairCorrupted(names); err != nil {
+			l.Infof("Folder %s: failed to repair files using corrupt chunks: %v", folder.id, err)
+		}
+	}
+	return nil
+}
+
+func (b *blockPool) verify(ctx context.Context, pool *fs.DedupPool, id string) (bool, error) {
+	if err := b.ioLimiter.TakeWithContext(ctx, 1); err != nil {
+		return true, err
+	}
+	defer b.ioLimiter.Give(1)
+	return pool.Verify(id)
+}
+
+// dedupStrings removes consecutive duplicates from the sorted slice.
+func dedupStrings(ss []string) []string {
+	if len(ss) < 2 {
+		return ss
+	}
+	out := ss[:1]
+	for _, s := range ss[1:] {
+		if s != out[len(out)-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
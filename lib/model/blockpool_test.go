@@ -0,0 +1,110 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/semaphore"
+	"github.com/syncthing/syncthing/lib/sha256"
+)
+
+type fakeRepairer struct {
+	names []string
+}
+
+func (r *fakeRepairer) RepairCorrupted(names []string) ([]string, error) {
+	r.names = append(r.names, names...)
+	return names, nil
+}
+
+func TestBlockPool(t *testing.T) {
+	td := t.TempDir()
+	pool := fs.NewFilesystem(fs.FilesystemTypeBasic, filepath.Join(td, "pool"))
+	one := fs.NewFilesystem(fs.FilesystemTypeBasic, filepath.Join(td, "one"), fs.NewDedupOption(pool))
+	two := fs.NewFilesystem(fs.FilesystemTypeBasic, filepath.Join(td, "two"), fs.NewDedupOption(pool))
+
+	// Both folders share the first chunk, the second is only used by
+	// the second folder.
+	shared := []byte("shared data")
+	own := []byte("data of its own")
+	for _, tc := range []struct {
+		fs      fs.Filesystem
+		content []byte
+	}{{one, shared}, {two, append(append([]byte(nil), shared...), own...)}} {
+		if err := tc.fs.MkdirAll(".", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, tc.fs, "file", tc.content)
+		blocks := []protocol.BlockInfo{{Size: len(shared)}}
+		if err := fs.Deduplicate(tc.fs, "file", blocks); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	chunkPath := func(data []byte) string {
+		hash := sha256.Sum256(data)
+		id := hex.EncodeToString(hash[:])
+		return filepath.Join(id[:2], id[2:])
+	}
+	// An old orphan is removed, a recent one is kept as the file using it
+	// may not have been deduplicated completely yet.
+	old := time.Now().Add(-2 * blockPoolOrphanGrace)
+	for _, data := range [][]byte{[]byte("old orphan"), []byte("new orphan")} {
+		name := chunkPath(data)
+		if err := pool.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, pool, name, data)
+	}
+	if err := pool.Chtimes(chunkPath([]byte("old orphan")), old, old); err != nil {
+		t.Fatal(err)
+	}
+	// Corrupt the chunk only used by the second folder.
+	writeFile(t, pool, chunkPath(own), []byte("data of its owN"))
+
+	r1, r2 := new(fakeRepairer), new(fakeRepairer)
+	b := newBlockPool(nil, func() []blockPoolFolder {
+		return []blockPoolFolder{
+			{id: "one", pool: pool, filesystem: one, repairer: r1},
+			{id: "two", pool: pool, filesystem: two, repairer: r2},
+		}
+	}, semaphore.New(1), nil, events.NoopLogger)
+
+	if err := b.run(context.Background(), true); err != nil {
+		t.Fatal(err)
+	}
+	st := b.Status()
+	if st.Chunks != 4 || st.References != 3 || st.Orphans != 2 || st.RemovedOrphans != 1 {
+		t.Errorf("unexpected status %+v", st)
+	}
+	if st.ReferenceCounts[0] != 2 || st.ReferenceCounts[1] != 1 || st.ReferenceCounts[2] != 1 {
+		t.Errorf("unexpected reference counts %v", st.ReferenceCounts)
+	}
+	if len(st.CorruptChunks) != 1 || len(st.AffectedFiles) != 1 || len(st.AffectedFiles["two"]) != 1 {
+		t.Errorf("expected one corrupt chunk affecting folder two, got %v, %v", st.CorruptChunks, st.AffectedFiles)
+	}
+	if len(r1.names) != 0 || len(r2.names) != 1 || r2.names[0] != "file" {
+		t.Errorf("expected file in folder two to be repaired, got %v and %v", r1.names, r2.names)
+	}
+
+	// The orphan and corrupt chunks are gone, the recent orphan remains.
+	if err := b.run(context.Background(), false); err != nil {
+		t.Fatal(err)
+	}
+	st = b.Status()
+	if st.Chunks != 2 || st.Orphans != 1 || st.RemovedOrphans != 0 || len(st.CorruptChunks) != 1 {
+		t.Errorf("unexpected status after collecting %+v", st)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"io"
+
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/semaphore"
+)
+
+const (
+	// How many files can wait to be read into the cache; more are skipped.
+	cacheWarmerQueueSize = 1000
+	cacheWarmerBufSize   = 128 << 10
+)
+
+// The cacheWarmer reads just pulled files in the background, so that they
+// are in the operating system's page cache when some application opens
+// them right after they are synced.
+type cacheWarmer struct {
+	folderID  string
+	mtimefs   fs.Filesystem
+	ioLimiter *semaphore.Semaphore
+	evLogger  events.Logger
+	queue     chan string
+}
+
+func newCacheWarmer(folderID string, mtimefs fs.Filesystem, ioLimiter *semaphore.Semaphore, evLogger events.Logger) *cacheWarmer {
+	return &cacheWarmer{
+		folderID:  folderID,
+		mtimefs:   mtimefs,
+		ioLimiter: ioLimiter,
+		evLogger:  evLogger,
+		queue:     make(chan string, cacheWarmerQueueSize),
+	}
+}
+
+// add queues the named file to be read, unless the queue is full.
+func (w *cacheWarmer) add(name string) {
+	select {
+	case w.queue <- name:
+	default:
+		l.Debugf("cache warmer for %v: queue full, skipping %v", w.folderID, name)
+	}
+}
+
+// close makes run return once the queued files have been read.
+func (w *cacheWarmer) close() {
+	close(w.queue)
+}
+
+func (w *cacheWarmer) run(ctx context.Context) {
+	buf := make([]byte, cacheWarmerBufSize)
+	for name := range w.queue {
+		if ctx.Err() != nil {
+			return
+		}
+		err := w.warm(ctx, name, buf)
+		if ctx.Err() != nil {
+			return
+		}
+		l.Debugf("cache warmer for %v: read %v: %v", w.folderID, name, err)
+		w.evLogger.Log(events.ItemCacheWarmed, map[string]interface{}{
+			"folder": w.folderID,
+			"item":   name,
+			"error":  events.Error(err),
+		})
+	}
+}
+
+func (w *cacheWarmer) warm(ctx context.Context, name string, buf []byte) error {
+	if err := w.ioLimiter.TakeWithContext(ctx, 1); err != nil {
+		return err
+	}
+	defer w.ioLimiter.Give(1)
+
+	fd, err := w.mtimefs.Open(name)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+	for ctx.Err() == nil {
+		if _, err := fd.Read(buf); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
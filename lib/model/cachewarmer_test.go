@@ -0,0 +1,49 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/semaphore"
+)
+
+func TestCacheWarmer(t *testing.T) {
+	ffs := fs.NewFilesystem(fs.FilesystemTypeFake, "cachewarmer")
+	writeFile(t, ffs, "app.db", []byte("data"))
+
+	evLogger := events.NewLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go evLogger.Serve(ctx)
+	sub := evLogger.Subscribe(events.ItemCacheWarmed)
+	defer sub.Unsubscribe()
+
+	w := newCacheWarmer("default", ffs, semaphore.New(1), evLogger)
+	w.add("app.db")
+	w.add("missing.db")
+	w.close()
+	w.run(ctx)
+
+	for _, name := range []string{"app.db", "missing.db"} {
+		ev, err := sub.Poll(time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := ev.Data.(map[string]interface{})
+		if data["item"] != name {
+			t.Fatalf("expected an event for %v, got %v", name, data)
+		}
+		if failed := data["error"].(*string) != nil; failed != (name == "missing.db") {
+			t.Errorf("unexpected error for %v: %v", name, data["error"])
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/rand"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+var (
+	errProbeNotCreated     = errors.New("not probed as creating a file failed")
+	errSymlinksUnsupported = errors.New("symlinks are not supported on this platform")
+)
+
+// FolderCapabilities is what the filesystem of a folder was found to allow
+// when probing it on start.
+type FolderCapabilities struct {
+	Checked  time.Time  `json:"checked"`
+	Create   Capability `json:"create"`
+	Rename   Capability `json:"rename"`
+	Delete   Capability `json:"delete"`
+	Xattrs   Capability `json:"xattrs"`
+	Symlinks Capability `json:"symlinks"`
+}
+
+// Capability is whether an operation succeeded when probing, and the error
+// if not.
+type Capability struct {
+	Supported bool   `json:"supported"`
+	Error     string `json:"error,omitempty"`
+}
+
+func newCapability(err error) Capability {
+	if err != nil {
+		return Capability{Error: err.Error()}
+	}
+	return Capability{Supported: true}
+}
+
+// probeCapabilities tries out the operations syncing needs on temporary
+// files in the root of the filesystem, cleaning up after itself.
+func probeCapabilities(ffs fs.Filesystem) FolderCapabilities {
+	caps := FolderCapabilities{Checked: time.Now()}
+	base := "capability-probe-" + rand.String(8)
+	name := fs.TempName(base)
+
+	fd, err := ffs.Create(name)
+	if err == nil {
+		_, err = fd.Write([]byte("probe"))
+		if cerr := fd.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			ffs.Remove(name)
+		}
+	}
+	caps.Create = newCapability(err)
+	if err != nil {
+		notProbed := newCapability(errProbeNotCreated)
+		caps.Rename, caps.Delete, caps.Xattrs, caps.Symlinks = notProbed, notProbed, notProbed, notProbed
+		return caps
+	}
+
+	caps.Xattrs = newCapability(ffs.SetXattr(name, []protocol.Xattr{{Name: "user.syncthing.probe", Value: []byte("1")}}, config.XattrFilter{}))
+
+	renamed := fs.TempName(base + "-renamed")
+	err = ffs.Rename(name, renamed)
+	caps.Rename = newCapability(err)
+	if err == nil {
+		name = renamed
+	}
+
+	if ffs.SymlinksSupported() {
+		link := fs.TempName(base + "-link")
+		err := ffs.CreateSymlink(name, link)
+		caps.Symlinks = newCapability(err)
+		if err == nil {
+			ffs.Remove(link)
+		}
+	} else {
+		caps.Symlinks = newCapability(errSymlinksUnsupported)
+	}
+
+	caps.Delete = newCapability(ffs.Remove(name))
+	return caps
+}
+
+// probeCapabilities probes the folder's filesystem, warning about what it
+// doesn't allow that the folder needs.
+func (f *folder) probeCapabilities() {
+	caps := probeCapabilities(f.mtimefs)
+	f.model.capabilities.set(f.ID, caps)
+
+	if f.Type != config.FolderTypeSendOnly {
+		for _, c := range []struct {
+			what string
+			Capability
+		}{
+			{"create", caps.Create},
+			{"rename", caps.Rename},
+			{"delete", caps.Delete},
+		} {
+			if !c.Supported {
+				l.Warnf("Folder %s: cannot %s files, syncing changes into it will fail: %s", f.Description(), c.what, c.Error)
+			}
+		}
+	}
+	if f.SyncXattrs && caps.Create.Supported && !caps.Xattrs.Supported {
+		l.Warnf("Folder %s: cannot set extended attributes, syncing them will fail: %s", f.Description(), caps.Xattrs.Error)
+	}
+	if caps.Create.Supported && !caps.Symlinks.Supported {
+		l.Infof("Folder %s: cannot create symlinks, they will not be synced: %s", f.Description(), caps.Symlinks.Error)
+	}
+}
+
+// folderCapabilities keeps the result of the last probe of each folder.
+type folderCapabilities struct {
+	mut  sync.Mutex
+	caps map[string]FolderCapabilities
+}
+
+func newFolderCapabilities() *folderCapabilities {
+	return &folderCapabilities{
+		mut:  sync.NewMutex(),
+		caps: make(map[string]FolderCapabilities),
+	}
+}
+
+func (c *folderCapabilities) set(folder string, caps FolderCapabilities) {
+	c.mut.Lock()
+	c.caps[folder] = caps
+	c.mut.Unlock()
+}
+
+func (c *folderCapabilities) get(folder string) FolderCapabilities {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.caps[folder]
+}
+
+func (c *folderCapabilities) forget(folder string) {
+	c.mut.Lock()
+	delete(c.caps, folder)
+	c.mut.Unlock()
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/build"
+	"github.com/syncthing/syncthing/lib/fs"
+)
+
+func TestProbeCapabilities(t *testing.T) {
+	ffs := fs.NewFilesystem(fs.FilesystemTypeBasic, t.TempDir())
+
+	caps := probeCapabilities(ffs)
+	if caps.Checked.IsZero() {
+		t.Error("probe time not set")
+	}
+	for what, c := range map[string]Capability{"create": caps.Create, "rename": caps.Rename, "delete": caps.Delete} {
+		if !c.Supported {
+			t.Errorf("expected %s to be supported: %s", what, c.Error)
+		}
+	}
+	if !build.IsWindows && !caps.Symlinks.Supported {
+		t.Error("expected symlinks to be supported:", caps.Symlinks.Error)
+	}
+
+	// Nothing is left behind.
+	names, err := ffs.DirNames(".")
+	must(t, err)
+	if len(names) != 0 {
+		t.Error("probe left files behind:", names)
+	}
+
+	// Nothing can be probed where creating fails.
+	must(t, ffs.Chmod(".", 0o500))
+	defer ffs.Chmod(".", 0o700)
+	if build.IsWindows || probeCapabilities(ffs).Create.Supported {
+		t.Skip("creating files in a read-only directory succeeds")
+	}
+	caps = probeCapabilities(ffs)
+	if caps.Rename.Supported || caps.Rename.Error != errProbeNotCreated.Error() {
+		t.Errorf("unexpected rename capability %+v", caps.Rename)
+	}
+}
+
+func TestFolderCapabilities(t *testing.T) {
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer wcfgCancel()
+	defer cleanupModel(m)
+
+	caps, err := m.FolderCapabilities(f.ID)
+	must(t, err)
+	if caps.Checked.IsZero() || !caps.Create.Supported {
+		t.Errorf("folder not probed on start: %+v", caps)
+	}
+
+	if _, err := m.FolderCapabilities("nonexistent"); err != ErrFolderMissing {
+		t.Error("expected missing folder error, got", err)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/sha256"
+)
+
+// Beyond this many changed paths in the journal we might as well scan the
+// whole folder.
+const maxJournalChanges = 10000
+
+// changeJournalState is what we record after a full scan to catch up with
+// the changes since on the next start, instead of scanning again.
+type changeJournalState struct {
+	// The journal position from before the scan.
+	Position string `json:"position"`
+	// Hashes of the folder configuration and ignore patterns the scan was
+	// done with, as changing either needs a full scan.
+	Config  string `json:"config"`
+	Ignores string `json:"ignores"`
+}
+
+// scanAll scans the whole folder. When the folder uses the change journal,
+// the journal position from before the scan is recorded once the scan
+// succeeds.
+func (f *folder) scanAll(initial bool) error {
+	if !f.UseChangeJournal {
+		return f.scanSubdirs(nil)
+	}
+
+	pos, err := fs.JournalPosition(f.mtimefs)
+	if err != nil {
+		if initial {
+			l.Infof("Not using the change journal for folder %s: %v", f.Description(), err)
+		}
+		return f.scanSubdirs(nil)
+	}
+
+	if err := f.scanSubdirs(nil); err != nil {
+		return err
+	}
+	f.saveJournalState(pos)
+	return nil
+}
+
+// catchUpFromJournal scans only the items the change journal reports as
+// changed since the last full scan. It returns false when that isn't
+// possible and the folder needs a full scan instead.
+func (f *folder) catchUpFromJournal() (bool, error) {
+	if !f.UseChangeJournal {
+		return false, nil
+	}
+
+	bs, ok, err := f.model.db.ChangeJournalState(f.ID)
+	if err != nil || !ok {
+		return false, nil
+	}
+	var state changeJournalState
+	if err := json.Unmarshal(bs, &state); err != nil {
+		l.Debugf("%v: invalid change journal state: %v", f, err)
+		return false, nil
+	}
+
+	// A full scan reports any error.
+	if err := f.getHealthErrorAndLoadIgnores(); err != nil {
+		return false, nil
+	}
+	if state.Config != f.journalConfigHash() || state.Ignores != f.ignores.Hash() {
+		l.Debugf("%v: configuration or ignores changed since the last full scan", f)
+		return false, nil
+	}
+
+	changed, pos, err := fs.JournalChanges(f.ctx, f.mtimefs, state.Position)
+	if err != nil && f.ctx.Err() != nil {
+		return true, err
+	}
+	if err != nil {
+		l.Infof("Cannot catch up with the change journal for folder %s, scanning it fully: %v", f.Description(), err)
+		return false, nil
+	}
+	if len(changed) > maxJournalChanges {
+		l.Infof("Change journal for folder %s reports %d changes, scanning it fully", f.Description(), len(changed))
+		return false, nil
+	}
+	for _, path := range changed {
+		if path == "." {
+			// The folder root itself changed, e.g. it was replaced.
+			return false, nil
+		}
+	}
+
+	l.Infof("Catching up with %d changes from the change journal for folder %s instead of scanning it fully", len(changed), f.Description())
+	if len(changed) == 0 {
+		// Scanning no subdirectories would scan everything.
+		f.setError(nil)
+		f.ScanCompleted()
+	} else if err := f.scanSubdirs(changed); err != nil {
+		return true, err
+	}
+	f.saveJournalState(pos)
+	return true, nil
+}
+
+// saveJournalState records the journal position for the next start. If the
+// scan ran into errors the failed items wouldn't be retried when catching
+// up, so any recorded position is cleared instead.
+func (f *folder) saveJournalState(pos string) {
+	f.errorsMut.Lock()
+	scanErrors := len(f.scanErrors)
+	f.errorsMut.Unlock()
+	if scanErrors > 0 {
+		l.Debugf("%v: not recording the change journal position due to scan errors", f)
+		if err := f.model.db.ClearChangeJournalState(f.ID); err != nil {
+			l.Debugf("%v: clearing change journal state: %v", f, err)
+		}
+		return
+	}
+
+	bs, err := json.Marshal(changeJournalState{
+		Position: pos,
+		Config:   f.journalConfigHash(),
+		Ignores:  f.ignores.Hash(),
+	})
+	if err != nil {
+		return
+	}
+	if err := f.model.db.SetChangeJournalState(f.ID, bs); err != nil {
+		l.Debugf("%v: recording change journal state: %v", f, err)
+	}
+}
+
+func (f *folder) journalConfigHash() string {
+	bs, _ := json.Marshal(f.FolderConfiguration)
+	return fmt.Sprintf("%x", sha256.Sum256(bs))
+}
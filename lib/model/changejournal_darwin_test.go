@@ -0,0 +1,20 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build darwin && cgo
+// +build darwin,cgo
+
+package model
+
+import "strings"
+
+// resetJournalPosition returns the position as if the FSEvents database had
+// been reset since, i.e. with another UUID.
+func resetJournalPosition(pos string) string {
+	fields := strings.Split(pos, ":")
+	fields[1] = "00000000-0000-0000-0000-000000000000"
+	return strings.Join(fields, ":")
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+)
+
+func TestChangeJournalState(t *testing.T) {
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer wcfgCancel()
+	defer cleanupModel(m)
+	f.UseChangeJournal = true
+
+	stored := func() (changeJournalState, bool) {
+		t.Helper()
+		bs, ok, err := m.db.ChangeJournalState(f.ID)
+		must(t, err)
+		var state changeJournalState
+		if ok {
+			must(t, json.Unmarshal(bs, &state))
+		}
+		return state, ok
+	}
+
+	// Without any recorded state there is nothing to catch up from.
+	if caughtUp, err := f.catchUpFromJournal(); caughtUp || err != nil {
+		t.Fatal("caught up without a recorded state:", err)
+	}
+
+	f.saveJournalState("position")
+	state, ok := stored()
+	if !ok || state.Position != "position" || state.Config != f.journalConfigHash() || state.Ignores != f.ignores.Hash() {
+		t.Fatalf("unexpected state %+v", state)
+	}
+
+	if runtime.GOOS != "windows" && runtime.GOOS != "darwin" {
+		// There's no journal to read from, so a full scan is needed.
+		if caughtUp, err := f.catchUpFromJournal(); caughtUp || err != nil {
+			t.Error("caught up without a journal:", err)
+		}
+	}
+
+	// A changed configuration needs a full scan.
+	f.RescanIntervalS++
+	if caughtUp, err := f.catchUpFromJournal(); caughtUp || err != nil {
+		t.Error("caught up with changed configuration:", err)
+	}
+
+	// Scan errors clear the state, as the failed items need retrying.
+	f.newScanError("foo", errDirHasIgnored)
+	f.saveJournalState("position2")
+	if _, ok := stored(); ok {
+		t.Error("state kept despite scan errors")
+	}
+}
+
+func TestChangeJournalCatchUp(t *testing.T) {
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer wcfgCancel()
+	defer cleanupModel(m)
+	f.folder.FolderConfiguration = newFolderConfiguration(m.cfg, f.ID, f.Label, fs.FilesystemTypeBasic, t.TempDir())
+	f.UseChangeJournal = true
+	f.fset = newFileSet(t, f.ID, m.db)
+	f.mtimefs = f.Filesystem(f.fset)
+	must(t, f.mtimefs.Mkdir(config.DefaultMarkerName, 0o755))
+
+	must(t, f.scanAll(true))
+	bs, ok, err := m.db.ChangeJournalState(f.ID)
+	must(t, err)
+	if !ok {
+		t.Skip("change journal not available")
+	}
+
+	if caughtUp, err := f.catchUpFromJournal(); !caughtUp || err != nil {
+		t.Fatal("didn't catch up with the journal:", err)
+	}
+
+	// When the journal was reset since, there's no way around a full
+	// scan.
+	var state changeJournalState
+	must(t, json.Unmarshal(bs, &state))
+	state.Position = resetJournalPosition(state.Position)
+	bs, err = json.Marshal(state)
+	must(t, err)
+	must(t, m.db.SetChangeJournalState(f.ID, bs))
+	if caughtUp, err := f.catchUpFromJournal(); caughtUp || err != nil {
+		t.Error("caught up despite a journal reset:", err)
+	}
+}
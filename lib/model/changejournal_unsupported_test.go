@@ -0,0 +1,16 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build !windows && !(darwin && cgo)
+// +build !windows
+// +build !darwin !cgo
+
+package model
+
+// resetJournalPosition is never needed without a change journal.
+func resetJournalPosition(pos string) string {
+	return pos
+}
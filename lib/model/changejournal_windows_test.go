@@ -0,0 +1,20 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build windows
+// +build windows
+
+package model
+
+import "strings"
+
+// resetJournalPosition returns the position as if the USN journal had been
+// recreated since, i.e. with another journal ID.
+func resetJournalPosition(pos string) string {
+	fields := strings.Split(pos, ":")
+	fields[2] = "0"
+	return strings.Join(fields, ":")
+}
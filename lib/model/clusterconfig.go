@@ -0,0 +1,96 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sha256"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// clusterConfigDelay is how long cluster configs are held back after they
+// were requested, such that a burst of config changes (e.g. from a setup
+// script) results in a single update per device.
+var clusterConfigDelay = 250 * time.Millisecond
+
+// The clusterConfigSender collects the devices that need a new cluster
+// config and sends to all of them in one go, after clusterConfigDelay.
+type clusterConfigSender struct {
+	send    func([]protocol.DeviceID)
+	pending deviceIDSet
+	mut     sync.Mutex
+	changed chan struct{}
+}
+
+func newClusterConfigSender(send func([]protocol.DeviceID)) *clusterConfigSender {
+	return &clusterConfigSender{
+		send:    send,
+		pending: make(deviceIDSet),
+		mut:     sync.NewMutex(),
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// add schedules sending cluster configs to the given devices.
+func (s *clusterConfigSender) add(ids []protocol.DeviceID) {
+	if len(ids) == 0 {
+		return
+	}
+	s.mut.Lock()
+	s.pending.add(ids)
+	s.mut.Unlock()
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (s *clusterConfigSender) Serve(ctx context.Context) error {
+	for {
+		select {
+		case <-s.changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		// The delay counts from the first request, not the last, so
+		// continuous changes can't hold back cluster configs forever.
+		timer := time.NewTimer(clusterConfigDelay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+
+		s.mut.Lock()
+		ids := s.pending.AsSlice()
+		s.pending = make(deviceIDSet)
+		s.mut.Unlock()
+		s.send(ids)
+	}
+}
+
+func (*clusterConfigSender) String() string {
+	return "clusterConfigSender"
+}
+
+type clusterConfigDigest [sha256.Size]byte
+
+// clusterConfigHash identifies the contents of a cluster config, to avoid
+// sending the same one to a device again. It returns false if the cluster
+// config can't be hashed, in which case it must be sent regardless.
+func clusterConfigHash(cm protocol.ClusterConfig) (clusterConfigDigest, bool) {
+	bs, err := cm.Marshal()
+	if err != nil {
+		return clusterConfigDigest{}, false
+	}
+	return sha256.Sum256(bs), true
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// An AdvertisedFolder is a folder as a device announces it in its cluster
+// config, i.e. as shared with us.
+type AdvertisedFolder struct {
+	Device     protocol.DeviceID     `json:"device"`
+	ID         string                `json:"id"`
+	Label      string                `json:"label"`
+	Notes      string                `json:"notes"`
+	Metadata   config.FolderMetadata `json:"metadata"`
+	Paused     bool                  `json:"paused"`
+	SharedWith []protocol.DeviceID   `json:"sharedWith"`
+	Time       time.Time             `json:"time"` // when the device announced it
+}
+
+func advertisedFolders(device protocol.DeviceID, folders []protocol.Folder) []AdvertisedFolder {
+	now := time.Now().Truncate(time.Second)
+	advertised := make([]AdvertisedFolder, 0, len(folders))
+	for _, folder := range folders {
+		af := AdvertisedFolder{
+			Device:     device,
+			ID:         folder.ID,
+			Label:      folder.Label,
+			Notes:      folder.Notes,
+			Metadata:   config.FolderMetadataFromProtocol(folder.Metadata),
+			Paused:     folder.Paused,
+			SharedWith: make([]protocol.DeviceID, 0, len(folder.Devices)),
+			Time:       now,
+		}
+		for _, dev := range folder.Devices {
+			if dev.ID != device {
+				af.SharedWith = append(af.SharedWith, dev.ID)
+			}
+		}
+		advertised = append(advertised, af)
+	}
+	return advertised
+}
+
+// ClusterFolders returns the folders advertised by the devices we know,
+// including ourselves, that have the given ID, if any, and whose label,
+// notes or description contain the given text, if any, ignoring case. Remote devices are
+// remembered as of their last cluster config, also while disconnected.
+func (m *model) ClusterFolders(id, text string) []AdvertisedFolder {
+	text = strings.ToLower(text)
+	matches := func(af AdvertisedFolder) bool {
+		if id != "" && af.ID != id {
+			return false
+		}
+		if text == "" {
+			return true
+		}
+		return strings.Contains(strings.ToLower(af.Label), text) || strings.Contains(strings.ToLower(af.Notes), text) || strings.Contains(strings.ToLower(af.Metadata.Description), text)
+	}
+
+	var found []AdvertisedFolder
+	for _, fcfg := range m.cfg.FolderList() {
+		af := AdvertisedFolder{
+			Device:     m.id,
+			ID:         fcfg.ID,
+			Label:      fcfg.Label,
+			Notes:      fcfg.Notes,
+			Metadata:   fcfg.Metadata,
+			Paused:     fcfg.Paused,
+			SharedWith: make([]protocol.DeviceID, 0, len(fcfg.Devices)),
+		}
+		for _, dev := range fcfg.Devices {
+			if dev.DeviceID != m.id {
+				af.SharedWith = append(af.SharedWith, dev.DeviceID)
+			}
+		}
+		if matches(af) {
+			found = append(found, af)
+		}
+	}
+
+	devices := m.cfg.Devices()
+	m.pmut.RLock()
+	for device, folders := range m.advertisedFolders {
+		if _, ok := devices[device]; !ok {
+			// Removed from the config since.
+			continue
+		}
+		for _, af := range folders {
+			if matches(af) {
+				found = append(found, af)
+			}
+		}
+	}
+	m.pmut.RUnlock()
+
+	sort.Slice(found, func(a, b int) bool {
+		if found[a].ID != found[b].ID {
+			return found[a].ID < found[b].ID
+		}
+		return found[a].Device.Compare(found[b].Device) < 0
+	})
+	return found
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestClusterFolders(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	fcfg.Label = "Photos"
+	fcfg.Notes = "Camera uploads"
+	setFolder(t, w, fcfg)
+	m, fc := setupModelWithConnectionFromWrapper(t, w)
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem(nil).URI())
+
+	cc := basicClusterConfig(myID, device1, fcfg.ID, "other")
+	cc.Folders[0].Label = "Pictures"
+	cc.Folders[0].Devices = append(cc.Folders[0].Devices, protocol.Device{ID: device2})
+	cc.Folders[1].Label = "Other"
+	cc.Folders[1].Notes = "Has photos too"
+	must(t, m.ClusterConfig(fc, cc))
+
+	folders := m.ClusterFolders(fcfg.ID, "")
+	if len(folders) != 2 {
+		t.Fatalf("expected the folder on two devices, got %v", folders)
+	}
+	local, remote := folders[0], folders[1]
+	if local.Device != myID {
+		local, remote = remote, local
+	}
+	if local.Device != myID || local.Label != "Photos" || local.Notes != "Camera uploads" {
+		t.Errorf("unexpected local folder %v", local)
+	}
+	if remote.Device != device1 || remote.Label != "Pictures" {
+		t.Errorf("unexpected remote folder %v", remote)
+	}
+	if len(remote.SharedWith) != 2 || remote.SharedWith[0] != myID || remote.SharedWith[1] != device2 {
+		t.Errorf("unexpected devices %v sharing the remote folder", remote.SharedWith)
+	}
+
+	// Searching matches labels and notes on all devices.
+	folders = m.ClusterFolders("", "photo")
+	if len(folders) != 2 || folders[0].ID != fcfg.ID || folders[1].ID != "other" {
+		t.Errorf("unexpected search result %v", folders)
+	}
+}
@@ -0,0 +1,454 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// Control message types. Status and apply are requests sent by the
+// controller, events are sent unsolicited by managed devices. Restart
+// requests and the following done messages are exchanged between peers
+// coordinating their restarts, standby announcements and probes between
+// any devices, pull hints between devices sharing a folder.
+const (
+	controlTypeStatus       = "status"
+	controlTypeApply        = "apply"
+	controlTypeEvent        = "event"
+	controlTypeRevoke       = "revoke"
+	controlTypeWipe         = "wipe"
+	controlTypeRestart      = "restart"
+	controlTypeRestartDone  = "restartDone"
+	controlTypeStandby      = "standby"
+	controlTypeStandbyProbe = "standbyProbe"
+	controlTypePullHint     = "pullHint"
+)
+
+// The number of failure events kept per managed device.
+const maxManagedDeviceEvents = 100
+
+var (
+	controlTimeout         = 30 * time.Second
+	controllerPollInterval = time.Minute
+
+	errControlUnauthorized = errors.New("not authorized as controller")
+	errControlUnknownType  = errors.New("unknown control message type")
+	errDeviceNotManaged    = errors.New("device is not managed")
+	errDeviceNotConnected  = errors.New("device is not connected")
+)
+
+// controlEventMask are the events a managed device forwards to its
+// controller.
+const controlEventMask = events.Failure | events.FolderErrors | events.CorruptionDetected
+
+// ControlTemplate is a set of folder and device configurations pushed by a
+// controller. They are added to the configuration of the managed device,
+// replacing existing ones with the same ID.
+type ControlTemplate struct {
+	Folders []config.FolderConfiguration `json:"folders"`
+	Devices []config.DeviceConfiguration `json:"devices"`
+}
+
+// ManagedNodeStatus is the status a managed device reports to its
+// controller.
+type ManagedNodeStatus struct {
+	Version     string                `json:"version"`
+	Connections int                   `json:"connections"`
+	Folders     []ManagedFolderStatus `json:"folders"`
+}
+
+type ManagedFolderStatus struct {
+	ID         string `json:"id"`
+	Label      string `json:"label"`
+	State      string `json:"state"`
+	Error      string `json:"error,omitempty"`
+	FileErrors int    `json:"fileErrors"`
+}
+
+// ManagedDeviceStatus is what the controller knows about a managed device.
+type ManagedDeviceStatus struct {
+	Connected bool               `json:"connected"`
+	Updated   time.Time          `json:"updated"`
+	Error     string             `json:"error,omitempty"`
+	Status    *ManagedNodeStatus `json:"status,omitempty"`
+	Events    []events.Event     `json:"events"`
+}
+
+// The controlService handles control messages, in both roles: As a managed
+// device it answers requests from the configured controller and forwards
+// failure events to it. As a controller it polls the status of the devices
+// that have a management token set and collects their events.
+type controlService struct {
+	model *model
+
+	mut      sync.Mutex
+	nextID   int
+	awaiting map[int]controlRequest
+	managed  map[protocol.DeviceID]*ManagedDeviceStatus
+}
+
+type controlRequest struct {
+	device protocol.DeviceID
+	resp   chan protocol.Control
+}
+
+func newControlService(m *model) *controlService {
+	return &controlService{
+		model:    m,
+		mut:      sync.NewMutex(),
+		awaiting: make(map[int]controlRequest),
+		managed:  make(map[protocol.DeviceID]*ManagedDeviceStatus),
+	}
+}
+
+func (s *controlService) Serve(ctx context.Context) error {
+	sub := s.model.evLogger.Subscribe(controlEventMask)
+	defer sub.Unsubscribe()
+
+	ticker := time.NewTicker(controllerPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case ev, ok := <-sub.C():
+			if !ok {
+				return nil
+			}
+			s.forwardEvent(ctx, ev)
+		case <-ticker.C:
+			s.pollManaged(ctx)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *controlService) String() string {
+	return fmt.Sprintf("controlService@%p", s)
+}
+
+// handle is called for every incoming control message.
+func (s *controlService) handle(conn protocol.Connection, ctrl protocol.Control) error {
+	device := conn.DeviceID()
+	if ctrl.Type == controlTypeRestart || ctrl.Type == controlTypeRestartDone {
+		// Restart coordination is between peers, without tokens.
+		if ctrl.ResponseTo != 0 {
+			s.deliver(device, ctrl)
+			return nil
+		}
+		return s.model.restarts.handle(conn, ctrl)
+	}
+	if ctrl.Type == controlTypeStandby || ctrl.Type == controlTypeStandbyProbe {
+		if ctrl.ResponseTo != 0 {
+			s.deliver(device, ctrl)
+			return nil
+		}
+		return s.model.standby.handle(conn, ctrl)
+	}
+	if ctrl.Type == controlTypePullHint {
+		return s.model.handlePullHint(device, ctrl)
+	}
+	if ctrl.ResponseTo != 0 || ctrl.Type == controlTypeEvent {
+		if !s.isManaged(device, ctrl.Token) {
+			l.Debugf("Ignoring control %v from unmanaged device %v", ctrl.Type, device)
+			return nil
+		}
+		if ctrl.ResponseTo != 0 {
+			s.deliver(device, ctrl)
+			return nil
+		}
+		var ev events.Event
+		if err := json.Unmarshal(ctrl.Payload, &ev); err != nil {
+			return fmt.Errorf("parsing event: %w", err)
+		}
+		s.updateManaged(device, func(st *ManagedDeviceStatus) {
+			st.Events = append(st.Events, ev)
+			if len(st.Events) > maxManagedDeviceEvents {
+				st.Events = st.Events[len(st.Events)-maxManagedDeviceEvents:]
+			}
+		})
+		return nil
+	}
+
+	// Applying configuration may affect the connection, so requests are
+	// handled outside of the connection's message loop.
+	go func() {
+		resp := protocol.Control{
+			ResponseTo: ctrl.ID,
+			Type:       ctrl.Type,
+			Token:      ctrl.Token,
+		}
+		payload, err := s.handleRequest(device, ctrl)
+		if err != nil {
+			l.Infof("Refusing control %v from %v: %v", ctrl.Type, device, err)
+			resp.Error = err.Error()
+		} else {
+			resp.Payload = payload
+		}
+		conn.Control(context.Background(), resp)
+	}()
+	return nil
+}
+
+func (s *controlService) handleRequest(device protocol.DeviceID, ctrl protocol.Control) ([]byte, error) {
+	opts := s.model.cfg.Options()
+	if opts.ControllerToken == "" || device != opts.ControllerDeviceID || !tokensEqual(ctrl.Token, opts.ControllerToken) {
+		return nil, errControlUnauthorized
+	}
+
+	switch ctrl.Type {
+	case controlTypeStatus:
+		return json.Marshal(s.localStatus())
+
+	case controlTypeApply:
+		var tmpl ControlTemplate
+		if err := json.Unmarshal(ctrl.Payload, &tmpl); err != nil {
+			return nil, err
+		}
+		waiter, err := s.model.cfg.Modify(func(cfg *config.Configuration) {
+			for _, dev := range tmpl.Devices {
+				cfg.SetDevice(dev)
+			}
+			for _, fcfg := range tmpl.Folders {
+				cfg.SetFolder(fcfg)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		waiter.Wait()
+		l.Infof("Applied configuration template from controller %v (%d folders, %d devices)", device, len(tmpl.Folders), len(tmpl.Devices))
+		return nil, nil
+
+	case controlTypeRevoke:
+		var req RevokeRequest
+		if err := json.Unmarshal(ctrl.Payload, &req); err != nil {
+			return nil, err
+		}
+		return nil, s.applyRevocation(device, req)
+
+	case controlTypeWipe:
+		return json.Marshal(s.wipe(device))
+
+	default:
+		return nil, errControlUnknownType
+	}
+}
+
+func (s *controlService) localStatus() ManagedNodeStatus {
+	status := ManagedNodeStatus{
+		Version:     s.model.clientVersion,
+		Connections: s.model.NumConnections(),
+		Folders:     []ManagedFolderStatus{},
+	}
+	for _, fcfg := range s.model.cfg.FolderList() {
+		fst := ManagedFolderStatus{
+			ID:    fcfg.ID,
+			Label: fcfg.Label,
+			State: "paused",
+		}
+		if !fcfg.Paused {
+			state, _, err := s.model.State(fcfg.ID)
+			fst.State = state
+			if err != nil {
+				fst.Error = err.Error()
+			}
+			if errs, err := s.model.FolderErrors(fcfg.ID); err == nil {
+				fst.FileErrors = len(errs)
+			}
+		}
+		status.Folders = append(status.Folders, fst)
+	}
+	return status
+}
+
+// forwardEvent sends the event to the controller, if there is one and it's
+// connected.
+func (s *controlService) forwardEvent(ctx context.Context, ev events.Event) {
+	opts := s.model.cfg.Options()
+	if opts.ControllerToken == "" || opts.ControllerDeviceID == protocol.EmptyDeviceID {
+		return
+	}
+	conn, ok := s.model.Connection(opts.ControllerDeviceID)
+	if !ok {
+		return
+	}
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		l.Debugln("Failed to marshal event for controller:", err)
+		return
+	}
+	conn.Control(ctx, protocol.Control{
+		Type:    controlTypeEvent,
+		Token:   opts.ControllerToken,
+		Payload: payload,
+	})
+}
+
+// pollManaged requests the status of all connected managed devices.
+func (s *controlService) pollManaged(ctx context.Context) {
+	for id, dev := range s.model.cfg.Devices() {
+		if dev.ManagementToken == "" {
+			continue
+		}
+		go func(id protocol.DeviceID) {
+			_, _ = s.refreshStatus(ctx, id)
+		}(id)
+	}
+}
+
+func (s *controlService) refreshStatus(ctx context.Context, device protocol.DeviceID) (*ManagedNodeStatus, error) {
+	bs, err := s.request(ctx, device, controlTypeStatus, nil)
+	var status ManagedNodeStatus
+	if err == nil {
+		err = json.Unmarshal(bs, &status)
+	}
+	s.updateManaged(device, func(st *ManagedDeviceStatus) {
+		st.Updated = time.Now()
+		if err != nil {
+			st.Error = err.Error()
+			return
+		}
+		st.Error = ""
+		st.Status = &status
+	})
+	if err != nil {
+		l.Debugf("Failed to get status of managed device %v: %v", device, err)
+		return nil, err
+	}
+	return &status, nil
+}
+
+// request sends a control request to the managed device and waits for the
+// response.
+func (s *controlService) request(ctx context.Context, device protocol.DeviceID, typ string, payload []byte) ([]byte, error) {
+	dev, ok := s.model.cfg.Device(device)
+	if !ok || dev.ManagementToken == "" {
+		return nil, errDeviceNotManaged
+	}
+	conn, ok := s.model.Connection(device)
+	if !ok {
+		return nil, errDeviceNotConnected
+	}
+
+	resp, err := s.exchange(ctx, conn, protocol.Control{
+		Type:    typ,
+		Token:   dev.ManagementToken,
+		Payload: payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return resp.Payload, nil
+}
+
+// exchange sends the control request over the connection and waits for the
+// response.
+func (s *controlService) exchange(ctx context.Context, conn protocol.Connection, ctrl protocol.Control) (protocol.Control, error) {
+	ctx, cancel := context.WithTimeout(ctx, controlTimeout)
+	defer cancel()
+
+	s.mut.Lock()
+	s.nextID++
+	ctrl.ID = s.nextID
+	req := controlRequest{device: conn.DeviceID(), resp: make(chan protocol.Control, 1)}
+	s.awaiting[ctrl.ID] = req
+	s.mut.Unlock()
+	defer func() {
+		s.mut.Lock()
+		delete(s.awaiting, ctrl.ID)
+		s.mut.Unlock()
+	}()
+
+	conn.Control(ctx, ctrl)
+
+	select {
+	case resp := <-req.resp:
+		return resp, nil
+	case <-ctx.Done():
+		return protocol.Control{}, ctx.Err()
+	}
+}
+
+// deliver hands the response to the request awaiting it, if any.
+func (s *controlService) deliver(device protocol.DeviceID, resp protocol.Control) {
+	s.mut.Lock()
+	req, ok := s.awaiting[resp.ResponseTo]
+	s.mut.Unlock()
+	if ok && req.device == device {
+		select {
+		case req.resp <- resp:
+		default:
+		}
+	}
+}
+
+// pushTemplate applies the template on the managed device.
+func (s *controlService) pushTemplate(ctx context.Context, device protocol.DeviceID, tmpl ControlTemplate) error {
+	bs, err := json.Marshal(tmpl)
+	if err != nil {
+		return err
+	}
+	_, err = s.request(ctx, device, controlTypeApply, bs)
+	return err
+}
+
+// managedDevices returns the status of all managed devices.
+func (s *controlService) managedDevices() map[protocol.DeviceID]ManagedDeviceStatus {
+	res := make(map[protocol.DeviceID]ManagedDeviceStatus)
+	devices := s.model.cfg.Devices()
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	for id, dev := range devices {
+		if dev.ManagementToken == "" {
+			continue
+		}
+		st := ManagedDeviceStatus{Events: []events.Event{}}
+		if known, ok := s.managed[id]; ok {
+			st = *known
+			st.Events = append([]events.Event{}, known.Events...)
+		}
+		s.model.pmut.RLock()
+		_, st.Connected = s.model.conn[id]
+		s.model.pmut.RUnlock()
+		res[id] = st
+	}
+	return res
+}
+
+func (s *controlService) isManaged(device protocol.DeviceID, token string) bool {
+	dev, ok := s.model.cfg.Device(device)
+	return ok && dev.ManagementToken != "" && tokensEqual(token, dev.ManagementToken)
+}
+
+func (s *controlService) updateManaged(device protocol.DeviceID, fn func(*ManagedDeviceStatus)) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	st, ok := s.managed[device]
+	if !ok {
+		st = &ManagedDeviceStatus{}
+		s.managed[device] = st
+	}
+	fn(st)
+}
+
+func tokensEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
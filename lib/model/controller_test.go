@@ -0,0 +1,220 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestControl(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+
+	// The model is both controller of device1 and managed by it, with
+	// control messages to device1 looped back.
+	setManagementToken := func(token string) {
+		waiter, err := w.Modify(func(cfg *config.Configuration) {
+			cfg.Options.ControllerDeviceID = device1
+			cfg.Options.ControllerToken = "token"
+			dev, _, _ := cfg.Device(device1)
+			dev.ManagementToken = token
+			cfg.SetDevice(dev)
+		})
+		must(t, err)
+		waiter.Wait()
+	}
+	setManagementToken("token")
+
+	m, fc := setupModelWithConnectionFromWrapper(t, w)
+	defer cleanupModel(m)
+	fc.ControlCalls(func(_ context.Context, ctrl protocol.Control) {
+		go func() {
+			if err := m.Control(fc, ctrl); err != nil {
+				t.Error(err)
+			}
+		}()
+	})
+
+	ctx := context.Background()
+	status, err := m.controller.refreshStatus(ctx, device1)
+	must(t, err)
+	if len(status.Folders) != 1 || status.Folders[0].ID != fcfg.ID || status.Version != "dev" {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	tmpl := ControlTemplate{Folders: []config.FolderConfiguration{newFolderConfiguration(w, "templated", "templated", fcfg.FilesystemType, "templated")}}
+	must(t, m.PushControlTemplate(ctx, device1, tmpl))
+	if _, ok := w.Folder("templated"); !ok {
+		t.Error("templated folder not added")
+	}
+
+	m.controller.forwardEvent(ctx, events.Event{Type: events.Failure, Data: "failure"})
+	for i := 0; ; i++ {
+		st := m.ManagedDevices()[device1]
+		if len(st.Events) == 1 {
+			if !st.Connected || st.Status == nil || st.Events[0].Type != events.Failure {
+				t.Errorf("unexpected managed device status %+v", st)
+			}
+			break
+		}
+		if i > 1000 {
+			t.Fatal("event not received")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// A controller with the wrong token is refused.
+	setManagementToken("wrong")
+	if _, err := m.controller.refreshStatus(ctx, device1); err == nil || err.Error() != errControlUnauthorized.Error() {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+	if st := m.ManagedDevices()[device1]; st.Error != errControlUnauthorized.Error() {
+		t.Errorf("expected error in managed device status, got %q", st.Error)
+	}
+
+	setManagementToken("")
+	if err := m.PushControlTemplate(ctx, device1, tmpl); err != errDeviceNotManaged {
+		t.Errorf("expected not managed error, got %v", err)
+	}
+	if len(m.ManagedDevices()) != 0 {
+		t.Error("unmanaged device listed")
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+
+	// As in TestControl, device1 is both our controller and managed by us.
+	waiter, err := w.Modify(func(cfg *config.Configuration) {
+		cfg.Options.ControllerDeviceID = device1
+		cfg.Options.ControllerToken = "token"
+		dev, _, _ := cfg.Device(device1)
+		dev.ManagementToken = "token"
+		cfg.SetDevice(dev)
+		cfg.SetDevice(newDeviceConfiguration(cfg.Defaults.Device, device2, "device2"))
+		fcfg.Devices = append(fcfg.Devices, config.FolderDeviceConfiguration{DeviceID: device2})
+		cfg.SetFolder(fcfg)
+	})
+	must(t, err)
+	waiter.Wait()
+
+	m, fc := setupModelWithConnectionFromWrapper(t, w)
+	defer cleanupModel(m)
+	var revocations []RevokeRequest
+	var mut sync.Mutex
+	fc.ControlCalls(func(_ context.Context, ctrl protocol.Control) {
+		if ctrl.Type == controlTypeRevoke && ctrl.ResponseTo == 0 {
+			var req RevokeRequest
+			must(t, json.Unmarshal(ctrl.Payload, &req))
+			mut.Lock()
+			revocations = append(revocations, req)
+			mut.Unlock()
+		}
+		go func() {
+			if err := m.Control(fc, ctrl); err != nil {
+				t.Error(err)
+			}
+		}()
+	})
+
+	ctx := context.Background()
+	if _, err := m.RevokeDevice(ctx, myID, false); err != errRevokeSelf {
+		t.Errorf("expected error revoking ourselves, got %v", err)
+	}
+	if _, err := m.RevokeDevice(ctx, device2, true); err != errWipeNotManaged {
+		t.Errorf("expected error wiping an unmanaged device, got %v", err)
+	}
+
+	res, err := m.RevokeDevice(ctx, device2, false)
+	must(t, err)
+	if len(res) != 1 || res[device1] != nil {
+		t.Errorf("unexpected revocation results %v", res)
+	}
+	mut.Lock()
+	if len(revocations) != 1 || len(revocations[0].Devices) != 1 || revocations[0].Devices[0] != device2 {
+		t.Errorf("unexpected revocations sent to managed device: %v", revocations)
+	}
+	mut.Unlock()
+
+	dev, _ := w.Device(device2)
+	if !dev.Revoked || !dev.Paused {
+		t.Errorf("expected device to be revoked and paused, got %+v", dev)
+	}
+	if folder, _ := w.Folder(fcfg.ID); folder.SharedWith(device2) {
+		t.Error("folder still shared with revoked device")
+	}
+
+	// Being told to wipe by our controller removes the folders shared with
+	// it.
+	if wiped := m.controller.wipe(device1); len(wiped) != 1 || wiped[0] != fcfg.ID {
+		t.Errorf("unexpected wiped folders %v", wiped)
+	}
+	if _, ok := w.Folder(fcfg.ID); ok {
+		t.Error("wiped folder still configured")
+	}
+}
+
+func TestWipeKeepsOverlappingFolders(t *testing.T) {
+	w, wCancel := newConfigWrapper(defaultCfgWrapper.RawCopy())
+	defer wCancel()
+
+	td := t.TempDir()
+	folder := func(id, path string, devices ...protocol.DeviceID) config.FolderConfiguration {
+		must(t, os.MkdirAll(path, 0o755))
+		must(t, os.WriteFile(filepath.Join(path, id), []byte("data"), 0o644))
+		fcfg := newFolderConfiguration(w, id, id, fs.FilesystemTypeBasic, path)
+		for _, dev := range devices {
+			fcfg.Devices = append(fcfg.Devices, config.FolderDeviceConfiguration{DeviceID: dev})
+		}
+		return fcfg
+	}
+	// The kept folder is inside one of the wiped ones.
+	overlapping := folder("overlapping", filepath.Join(td, "overlapping"), device1)
+	kept := folder("kept", filepath.Join(td, "overlapping", "kept"), device2)
+	wiped := folder("wiped", filepath.Join(td, "wiped"), device1, device2)
+	waiter, err := w.Modify(func(cfg *config.Configuration) {
+		cfg.SetDevice(newDeviceConfiguration(cfg.Defaults.Device, device2, "device2"))
+		cfg.Folders = []config.FolderConfiguration{overlapping, kept, wiped}
+	})
+	must(t, err)
+	waiter.Wait()
+
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+
+	if ids := m.controller.wipe(device1); len(ids) != 1 || ids[0] != wiped.ID {
+		t.Errorf("expected only %s to be wiped, got %v", wiped.ID, ids)
+	}
+	for _, id := range []string{overlapping.ID, wiped.ID} {
+		if _, ok := w.Folder(id); ok {
+			t.Errorf("wiped folder %s still configured", id)
+		}
+	}
+	if _, ok := w.Folder(kept.ID); !ok {
+		t.Error("kept folder removed")
+	}
+	if _, err := os.Stat(wiped.Path); !os.IsNotExist(err) {
+		t.Error("expected the data of the wiped folder to be deleted, got", err)
+	}
+	for _, path := range []string{filepath.Join(overlapping.Path, overlapping.ID), filepath.Join(kept.Path, kept.ID)} {
+		if _, err := os.Stat(path); err != nil {
+			t.Error("expected data overlapping a kept folder to remain:", err)
+		}
+	}
+}
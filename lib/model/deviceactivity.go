@@ -7,40 +7,117 @@
 package model
 
 import (
+	"time"
+
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/sync"
 )
 
-// deviceActivity tracks the number of outstanding requests per device and can
-// answer which device is least busy. It is safe for use from multiple
-// goroutines.
+// The weight of a new measurement in the moving average of the rate at
+// which a device answers requests.
+const deviceRateAlpha = 0.2
+
+// deviceActivity tracks the number of outstanding requests per device and
+// the rate at which each answers them, and can answer which device is the
+// least busy relative to its speed. Blocks are thereby spread over the
+// devices roughly in proportion to their throughput. It is safe for use
+// from multiple goroutines.
 type deviceActivity struct {
-	act map[protocol.DeviceID]int
-	mut sync.Mutex
+	act   map[protocol.DeviceID]int
+	rate  map[protocol.DeviceID]float64 // bytes per second, per request
+	freed chan struct{}                 // closed when a request is done
+	mut   sync.Mutex
 }
 
 func newDeviceActivity() *deviceActivity {
 	return &deviceActivity{
-		act: make(map[protocol.DeviceID]int),
-		mut: sync.NewMutex(),
+		act:   make(map[protocol.DeviceID]int),
+		rate:  make(map[protocol.DeviceID]float64),
+		freed: make(chan struct{}),
+		mut:   sync.NewMutex(),
 	}
 }
 
 // Returns the index of the least busy device, or -1 if all are too busy.
 func (m *deviceActivity) leastBusy(availability []Availability) int {
 	m.mut.Lock()
-	low := 2<<30 - 1
+	defer m.mut.Unlock()
+	return m.leastBusyLocked(availability, nil)
+}
+
+// leastBusyLocked returns the index of the device with the fewest
+// outstanding requests relative to its rate, leaving out those at their
+// limit, or -1 if there is none. Devices not measured yet are taken to be
+// as fast as the average of those that are.
+func (m *deviceActivity) leastBusyLocked(availability []Availability, limit func(protocol.DeviceID) int) int {
+	var sum float64
+	var measured int
+	for i := range availability {
+		if rate, ok := m.rate[availability[i].ID]; ok {
+			sum += rate
+			measured++
+		}
+	}
+	avg := 1.0
+	if measured > 0 {
+		avg = sum / float64(measured)
+	}
+
 	best := -1
+	var low float64
 	for i := range availability {
-		if usage := m.act[availability[i].ID]; usage < low {
-			low = usage
+		usage := m.act[availability[i].ID]
+		if limit != nil {
+			if max := limit(availability[i].ID); max > 0 && usage >= max {
+				continue
+			}
+		}
+		rate, ok := m.rate[availability[i].ID]
+		if !ok {
+			rate = avg
+		}
+		if score := float64(usage+1) / rate; best == -1 || score < low {
+			low = score
 			best = i
 		}
 	}
-	m.mut.Unlock()
 	return best
 }
 
+// Returns the index of the least busy preferred device, or of the least busy
+// device when none is preferred, or -1 if all are too busy.
+func (m *deviceActivity) leastBusyPreferring(availability []Availability, preferred func(protocol.DeviceID) bool) int {
+	best, _ := m.leastBusyLimited(availability, preferred, nil)
+	return best
+}
+
+// leastBusyLimited is like leastBusyPreferring, leaving out devices that
+// have as many outstanding requests as their limit allows. When that
+// leaves no device, it returns a channel that is closed once a request
+// is done.
+func (m *deviceActivity) leastBusyLimited(availability []Availability, preferred func(protocol.DeviceID) bool, limit func(protocol.DeviceID) int) (int, <-chan struct{}) {
+	var pref []Availability
+	var idx []int
+	for i := range availability {
+		if preferred(availability[i].ID) {
+			pref = append(pref, availability[i])
+			idx = append(idx, i)
+		}
+	}
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	if best := m.leastBusyLocked(pref, limit); best != -1 {
+		return idx[best], nil
+	}
+	if best := m.leastBusyLocked(availability, limit); best != -1 {
+		return best, nil
+	}
+	if len(availability) == 0 {
+		return -1, nil
+	}
+	return -1, m.freed
+}
+
 func (m *deviceActivity) using(availability Availability) {
 	m.mut.Lock()
 	m.act[availability.ID]++
@@ -50,5 +127,22 @@ func (m *deviceActivity) using(availability Availability) {
 func (m *deviceActivity) done(availability Availability) {
 	m.mut.Lock()
 	m.act[availability.ID]--
+	close(m.freed)
+	m.freed = make(chan struct{})
+	m.mut.Unlock()
+}
+
+// measured records that the device answered a request for the given
+// number of bytes in the given time.
+func (m *deviceActivity) measured(id protocol.DeviceID, bytes int, d time.Duration) {
+	if bytes <= 0 || d <= 0 {
+		return
+	}
+	rate := float64(bytes) / d.Seconds()
+	m.mut.Lock()
+	if prev, ok := m.rate[id]; ok {
+		rate = prev + deviceRateAlpha*(rate-prev)
+	}
+	m.rate[id] = rate
 	m.mut.Unlock()
 }
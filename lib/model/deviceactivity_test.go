@@ -8,6 +8,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/syncthing/syncthing/lib/protocol"
 )
@@ -58,3 +59,71 @@ func TestDeviceActivity(t *testing.T) {
 		t.Errorf("Least busy device should be n0 (%v) not %v", n0, lb)
 	}
 }
+
+func TestDeviceActivityPreferring(t *testing.T) {
+	n0 := Availability{protocol.DeviceID([32]byte{1, 2, 3, 4}), false}
+	n1 := Availability{protocol.DeviceID([32]byte{5, 6, 7, 8}), false}
+	devices := []Availability{n0, n1}
+	na := newDeviceActivity()
+	preferN1 := func(id protocol.DeviceID) bool { return id == n1.ID }
+
+	na.using(n1)
+	if lb := na.leastBusyPreferring(devices, preferN1); lb != 1 {
+		t.Errorf("Preferred device n1 should be selected, not %v", lb)
+	}
+	if lb := na.leastBusyPreferring(devices[:1], preferN1); lb != 0 {
+		t.Errorf("Without preferred devices n0 should be selected, not %v", lb)
+	}
+}
+
+func TestDeviceActivityWeighted(t *testing.T) {
+	fast := Availability{protocol.DeviceID([32]byte{1, 2, 3, 4}), false}
+	slow := Availability{protocol.DeviceID([32]byte{5, 6, 7, 8}), false}
+	devices := []Availability{slow, fast}
+	na := newDeviceActivity()
+	na.measured(fast.ID, 3<<20, time.Second)
+	na.measured(slow.ID, 1<<20, time.Second)
+
+	// Three times as fast, so it should get three requests for every one
+	// to the slow device.
+	counts := make(map[protocol.DeviceID]int)
+	for i := 0; i < 8; i++ {
+		lb := na.leastBusy(devices)
+		counts[devices[lb].ID]++
+		na.using(devices[lb])
+	}
+	if counts[fast.ID] != 6 || counts[slow.ID] != 2 {
+		t.Errorf("expected six requests to the fast device and two to the slow one, got %d and %d", counts[fast.ID], counts[slow.ID])
+	}
+}
+
+func TestDeviceActivityLimited(t *testing.T) {
+	n0 := Availability{protocol.DeviceID([32]byte{1, 2, 3, 4}), false}
+	n1 := Availability{protocol.DeviceID([32]byte{5, 6, 7, 8}), false}
+	devices := []Availability{n0, n1}
+	na := newDeviceActivity()
+	noPref := func(protocol.DeviceID) bool { return false }
+	limitOne := func(protocol.DeviceID) int { return 1 }
+
+	na.using(n0)
+	if lb, freed := na.leastBusyLimited(devices, noPref, limitOne); lb != 1 || freed != nil {
+		t.Errorf("expected n1 below its limit to be selected, not %v", lb)
+	}
+	na.using(n1)
+	lb, freed := na.leastBusyLimited(devices, noPref, limitOne)
+	if lb != -1 || freed == nil {
+		t.Fatalf("expected to wait with both devices at their limit, got %v", lb)
+	}
+	na.done(n0)
+	select {
+	case <-freed:
+	default:
+		t.Error("expected the wait to end when a request is done")
+	}
+	if lb, _ := na.leastBusyLimited(devices, noPref, limitOne); lb != 0 {
+		t.Errorf("expected n0 to be selected again, not %v", lb)
+	}
+	if lb, freed := na.leastBusyLimited(nil, noPref, limitOne); lb != -1 || freed != nil {
+		t.Error("expected no device and no wait without candidates")
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"errors"
+
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// How an item differs between the local device and a remote one, from the
+// point of view of the remote device.
+const (
+	DiffMissing     = "missing"     // only the local device has it
+	DiffExtra       = "extra"       // only the remote device has it
+	DiffOlder       = "older"       // the remote version is older
+	DiffNewer       = "newer"       // the remote version is newer
+	DiffConflicting = "conflicting" // the versions are concurrent
+	DiffInvalid     = "invalid"     // either side is invalid, e.g. ignored
+)
+
+var errFolderNotShared = errors.New("folder is not shared with the device")
+
+// A FileDifference is an item on which the local device and a remote one
+// disagree.
+type FileDifference struct {
+	Name   string
+	Status string
+	Local  *db.FileInfoTruncated // nil if the local device doesn't have it
+	Remote *db.FileInfoTruncated // nil if the remote device doesn't have it
+}
+
+// DeviceDifferences returns a paginated list of the items in the folder on
+// which the local device and the given remote device disagree, according
+// to the index data. Items deleted on one side and missing on the other
+// don't differ.
+func (m *model) DeviceDifferences(folder string, device protocol.DeviceID, page, perpage int) ([]FileDifference, error) {
+	m.fmut.RLock()
+	rf, ok := m.folderFiles[folder]
+	cfg := m.folderCfgs[folder]
+	m.fmut.RUnlock()
+
+	if !ok {
+		return nil, ErrFolderMissing
+	}
+	if _, ok := cfg.Device(device); !ok {
+		return nil, errFolderNotShared
+	}
+
+	snap, err := rf.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer snap.Release()
+
+	diffs := make([]FileDifference, 0, perpage)
+	p := newPager(page, perpage)
+	add := func(diff FileDifference) bool {
+		if diff.Status == "" || p.skip() {
+			return true
+		}
+		diffs = append(diffs, diff)
+		return !p.done()
+	}
+
+	// Everything the local device has, compared to the remote device,
+	// then what only the remote device has.
+	more := true
+	snap.WithHaveTruncated(protocol.LocalDeviceID, func(intf protocol.FileIntf) bool {
+		local := intf.(db.FileInfoTruncated)
+		diff := FileDifference{Name: local.Name, Local: &local}
+		if remote, ok := snap.GetTruncated(device, local.Name); ok {
+			diff.Remote = &remote
+		}
+		diff.Status = differenceStatus(diff.Local, diff.Remote)
+		more = add(diff)
+		return more
+	})
+	if !more {
+		return diffs, nil
+	}
+	snap.WithHaveTruncated(device, func(intf protocol.FileIntf) bool {
+		remote := intf.(db.FileInfoTruncated)
+		if _, ok := snap.GetTruncated(protocol.LocalDeviceID, remote.Name); ok {
+			return true
+		}
+		return add(FileDifference{
+			Name:   remote.Name,
+			Status: differenceStatus(nil, &remote),
+			Remote: &remote,
+		})
+	})
+	return diffs, nil
+}
+
+// differenceStatus returns how the items differ, or the empty string if
+// they don't.
+func differenceStatus(local, remote *db.FileInfoTruncated) string {
+	switch {
+	case local == nil && remote == nil:
+		return ""
+	case local == nil:
+		if remote.IsDeleted() {
+			return ""
+		}
+		if remote.IsInvalid() {
+			return DiffInvalid
+		}
+		return DiffExtra
+	case remote == nil:
+		if local.IsDeleted() {
+			return ""
+		}
+		if local.IsInvalid() {
+			return DiffInvalid
+		}
+		return DiffMissing
+	case local.IsInvalid() || remote.IsInvalid():
+		return DiffInvalid
+	}
+
+	switch local.FileVersion().Compare(remote.FileVersion()) {
+	case protocol.Equal:
+		return ""
+	case protocol.Greater:
+		return DiffOlder
+	case protocol.Lesser:
+		return DiffNewer
+	default:
+		return DiffConflicting
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestDeviceDifferences(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	// Frozen, so that nothing is pulled behind our back.
+	fcfg.Frozen = true
+	setFolder(t, w, fcfg)
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+
+	v1 := protocol.Vector{Counters: []protocol.Counter{{ID: myID.Short(), Value: 1}}}
+	v2 := protocol.Vector{Counters: []protocol.Counter{{ID: myID.Short(), Value: 2}}}
+	remoteV := protocol.Vector{Counters: []protocol.Counter{{ID: device1.Short(), Value: 1}}}
+	file := func(name string, version protocol.Vector) protocol.FileInfo {
+		return protocol.FileInfo{Name: name, Type: protocol.FileInfoTypeFile, Version: version, Sequence: 1}
+	}
+
+	fset := m.folderFiles[fcfg.ID]
+	deleted := file("deleted", v1)
+	deleted.Deleted = true
+	fset.Update(protocol.LocalDeviceID, []protocol.FileInfo{
+		file("conflicting", v1),
+		deleted,
+		file("invalid", v1),
+		file("missing", v1),
+		file("newer", v1),
+		file("older", v2),
+		file("same", v1),
+	})
+	invalid := file("invalid", v1)
+	invalid.RawInvalid = true
+	fset.Update(device1, []protocol.FileInfo{
+		file("conflicting", remoteV),
+		file("extra", remoteV),
+		invalid,
+		file("newer", v2),
+		file("older", v1),
+		file("same", v1),
+	})
+
+	diffs, err := m.DeviceDifferences(fcfg.ID, device1, 1, 100)
+	must(t, err)
+	expected := map[string]string{
+		"conflicting": DiffConflicting,
+		"extra":       DiffExtra,
+		"invalid":     DiffInvalid,
+		"missing":     DiffMissing,
+		"newer":       DiffNewer,
+		"older":       DiffOlder,
+	}
+	if len(diffs) != len(expected) {
+		t.Errorf("expected %d differences, got %d: %v", len(expected), len(diffs), diffs)
+	}
+	for _, diff := range diffs {
+		if expected[diff.Name] != diff.Status {
+			t.Errorf("%s: expected %q, got %q", diff.Name, expected[diff.Name], diff.Status)
+		}
+		if (diff.Local == nil) != (diff.Name == "extra") || (diff.Remote == nil) != (diff.Name == "missing") {
+			t.Errorf("%s: unexpected sides %v, %v", diff.Name, diff.Local, diff.Remote)
+		}
+	}
+
+	diffs, err = m.DeviceDifferences(fcfg.ID, device1, 2, 4)
+	must(t, err)
+	if len(diffs) != 2 || diffs[1].Name != "extra" {
+		t.Errorf("unexpected second page %v", diffs)
+	}
+
+	if _, err := m.DeviceDifferences(fcfg.ID, device2, 1, 100); err != errFolderNotShared {
+		t.Errorf("expected %v for a device the folder isn't shared with, got %v", errFolderNotShared, err)
+	}
+}
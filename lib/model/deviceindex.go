@@ -0,0 +1,110 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"errors"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+var (
+	errDropOwnIndex      = errors.New("can't drop the index data of the local device")
+	errFolderStillShared = errors.New("folder is still shared with the device")
+	errNoIndexData       = errors.New("no index data held for the device")
+)
+
+// A DeviceIndex is the index data held for a remote device in a folder.
+type DeviceIndex struct {
+	Device      protocol.DeviceID `json:"device"`
+	Shared      bool              `json:"shared"`
+	Sequence    int64             `json:"sequence"`
+	Files       int               `json:"files"`
+	Directories int               `json:"directories"`
+	Symlinks    int               `json:"symlinks"`
+	Deleted     int               `json:"deleted"`
+	Bytes       int64             `json:"bytes"`
+}
+
+// FolderDeviceIndexes returns the remote devices we hold index data for in
+// the folder, including those it's no longer shared with.
+func (m *model) FolderDeviceIndexes(folder string) ([]DeviceIndex, error) {
+	m.fmut.RLock()
+	fset, ok := m.folderFiles[folder]
+	cfg := m.folderCfgs[folder]
+	m.fmut.RUnlock()
+	if !ok {
+		return nil, ErrFolderMissing
+	}
+
+	snap, err := fset.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer snap.Release()
+
+	devices := fset.ListDevices()
+	indexes := make([]DeviceIndex, 0, len(devices))
+	for _, device := range devices {
+		_, shared := cfg.Device(device)
+		size := snap.RemoteSize(device)
+		indexes = append(indexes, DeviceIndex{
+			Device:      device,
+			Shared:      shared,
+			Sequence:    size.Sequence,
+			Files:       size.Files,
+			Directories: size.Directories,
+			Symlinks:    size.Symlinks,
+			Deleted:     size.Deleted,
+			Bytes:       size.Bytes,
+		})
+	}
+	return indexes, nil
+}
+
+// DropDeviceIndex drops the index data, including tombstones, held for the
+// device in the folder and optionally compacts the database to reclaim the
+// space. Only data of devices the folder isn't shared with can be dropped;
+// should it be shared again, the device sends its full index.
+func (m *model) DropDeviceIndex(folder string, device protocol.DeviceID, compact bool) error {
+	if device == m.id || device == protocol.LocalDeviceID || device == protocol.GlobalDeviceID {
+		return errDropOwnIndex
+	}
+
+	m.fmut.RLock()
+	fset, ok := m.folderFiles[folder]
+	cfg := m.folderCfgs[folder]
+	m.fmut.RUnlock()
+	if !ok {
+		return ErrFolderMissing
+	}
+	if _, ok := cfg.Device(device); ok {
+		return errFolderStillShared
+	}
+
+	held := false
+	for _, dev := range fset.ListDevices() {
+		if dev == device {
+			held = true
+			break
+		}
+	}
+	if !held {
+		return errNoIndexData
+	}
+
+	fset.Drop(device)
+	fset.SetIndexID(device, 0)
+	l.Infof("Dropped index data of device %v for folder %s", device, cfg.Description())
+
+	if compact {
+		if err := m.db.Compact(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestDropDeviceIndex(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	m := setupModel(t, w)
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem(nil).URI())
+
+	// Index data of a device the folder isn't shared with (anymore).
+	fset := m.folderFiles[fcfg.ID]
+	fset.Update(device1, []protocol.FileInfo{{Name: "shared", Sequence: 1, Version: protocol.Vector{}.Update(device1.Short())}})
+	fset.Update(device2, []protocol.FileInfo{
+		{Name: "file", Sequence: 1, Version: protocol.Vector{}.Update(device2.Short())},
+		{Name: "deleted", Sequence: 2, Deleted: true, Version: protocol.Vector{}.Update(device2.Short())},
+	})
+
+	indexes, err := m.FolderDeviceIndexes(fcfg.ID)
+	must(t, err)
+	if len(indexes) != 2 {
+		t.Fatalf("expected index data for two devices, got %v", indexes)
+	}
+	for _, index := range indexes {
+		switch index.Device {
+		case device1:
+			if !index.Shared || index.Files != 1 {
+				t.Errorf("unexpected index data %v for the shared device", index)
+			}
+		case device2:
+			if index.Shared || index.Files != 1 || index.Deleted != 1 || index.Sequence != 2 {
+				t.Errorf("unexpected index data %v for the unshared device", index)
+			}
+		}
+	}
+
+	if err := m.DropDeviceIndex(fcfg.ID, myID, false); err != errDropOwnIndex {
+		t.Errorf("expected %v dropping our own index, got %v", errDropOwnIndex, err)
+	}
+	if err := m.DropDeviceIndex(fcfg.ID, device1, false); err != errFolderStillShared {
+		t.Errorf("expected %v dropping a shared device's index, got %v", errFolderStillShared, err)
+	}
+
+	must(t, m.DropDeviceIndex(fcfg.ID, device2, true))
+	indexes, err = m.FolderDeviceIndexes(fcfg.ID)
+	must(t, err)
+	if len(indexes) != 1 || indexes[0].Device != device1 {
+		t.Errorf("expected only the shared device's index data, got %v", indexes)
+	}
+	snap := fsetSnapshot(t, fset)
+	defer snap.Release()
+	if _, ok := snap.Get(device2, "deleted"); ok {
+		t.Error("tombstone of the dropped device still exists")
+	}
+
+	if err := m.DropDeviceIndex(fcfg.ID, device2, false); err != errNoIndexData {
+		t.Errorf("expected %v dropping again, got %v", errNoIndexData, err)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+var errFolderEmptied = errors.New("folder is empty but the database has many items, confirm whether to delete them on other devices or to pull them again")
+
+// checkEmptied returns errFolderEmptied when the folder root has nothing
+// but our internal files, while the database has at least
+// EmptyFolderGuardFiles items for it. This is what an empty disk mounted
+// in place of the real one looks like, and scanning it would delete
+// everything on all other devices. The folder stays stopped until the user
+// resolves it.
+func (f *folder) checkEmptied() error {
+	if f.EmptyFolderGuardFiles <= 0 {
+		return nil
+	}
+	n, emptied := f.isEmptied()
+	if !emptied {
+		return nil
+	}
+	if confirmed, err := f.model.db.EmptiedConfirmed(f.ID); err != nil {
+		return err
+	} else if confirmed {
+		return nil
+	}
+	return fmt.Errorf("%w (%d items)", errFolderEmptied, n)
+}
+
+// clearEmptiedConfirmed forgets the confirmation once a full scan went
+// through, as the deletions have then been recorded.
+func (f *folder) clearEmptiedConfirmed() {
+	if err := f.model.db.ClearEmptiedConfirmed(f.ID); err != nil {
+		l.Debugf("%v: clearing emptied confirmation: %v", f, err)
+	}
+}
+
+func (f *folder) isEmptied() (int, bool) {
+	snap, err := f.dbSnapshot()
+	if err != nil {
+		return 0, false
+	}
+	local := snap.LocalSize()
+	snap.Release()
+	n := local.Files + local.Directories + local.Symlinks
+	if n < f.EmptyFolderGuardFiles {
+		return n, false
+	}
+	names, err := f.mtimefs.DirNames(".")
+	if err != nil {
+		// Reported by the path check.
+		return n, false
+	}
+	for _, name := range names {
+		if !fs.IsInternal(name) && !fs.IsTemporary(name) && name != f.MarkerName {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+// ResolveEmptied resolves a folder stopped as emptied, either by letting
+// the scan delete the items on other devices, or by forgetting the local
+// items and pulling them again.
+func (f *folder) ResolveEmptied(repull bool) error {
+	return f.doInSync(func() error {
+		if _, emptied := f.isEmptied(); !emptied {
+			return errors.New("folder is not emptied")
+		}
+		if !repull {
+			l.Infof("Folder %s: emptied folder confirmed, deleting its items on other devices", f.Description())
+			// Kept until the next full scan succeeds, so that it survives
+			// the folder being restarted before that.
+			if err := f.model.db.SetEmptiedConfirmed(f.ID); err != nil {
+				return err
+			}
+			f.ScheduleScan()
+			return nil
+		}
+		if f.Type == config.FolderTypeSendOnly {
+			return errors.New("send-only folders cannot pull items again")
+		}
+		l.Infof("Folder %s: emptied folder confirmed, pulling its items again", f.Description())
+		// Keeps the sequence, so the items we pull again are announced
+		// to other devices as new changes.
+		f.fset.Drop(protocol.LocalDeviceID)
+		f.SchedulePull()
+		return nil
+	})
+}
+
+func (m *model) ResolveEmptiedFolder(folder string, repull bool) error {
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	runner := m.folderRunners[folder]
+	m.fmut.RUnlock()
+	if err != nil {
+		return err
+	}
+	return runner.ResolveEmptied(repull)
+}
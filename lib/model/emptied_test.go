@@ -0,0 +1,112 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/rand"
+)
+
+func setupEmptiedFolder(t *testing.T) (*testModel, config.FolderConfiguration) {
+	t.Helper()
+	fcfg := config.FolderConfiguration{
+		FilesystemType:        fs.FilesystemTypeFake,
+		ID:                    "default",
+		Path:                  rand.String(32),
+		Type:                  config.FolderTypeSendReceive,
+		MarkerName:            config.DefaultMarkerName,
+		EmptyFolderGuardFiles: 2,
+	}
+	cfg, cancel := newConfigWrapper(config.Configuration{
+		Version: config.CurrentVersion,
+		Folders: []config.FolderConfiguration{fcfg},
+		Devices: []config.DeviceConfiguration{{DeviceID: device1}},
+	})
+	t.Cleanup(cancel)
+	m := newModel(t, cfg, myID, "syncthing", "dev", nil)
+
+	// The database knows about files that aren't on disk.
+	set := newFileSet(t, fcfg.ID, m.db)
+	var files []protocol.FileInfo
+	for _, name := range []string{"a", "b", "c"} {
+		files = append(files, protocol.FileInfo{Name: name, Version: protocol.Vector{}.Update(myID.Short())})
+	}
+	set.Update(protocol.LocalDeviceID, files)
+
+	m.ServeBackground()
+	t.Cleanup(func() { cleanupModel(m) })
+	return m, fcfg
+}
+
+func TestEmptiedFolderDeletes(t *testing.T) {
+	m, fcfg := setupEmptiedFolder(t)
+
+	if err := m.ScanFolder(fcfg.ID); !errors.Is(err, errFolderEmptied) {
+		t.Fatalf("expected the scan to fail as the folder is emptied, got %v", err)
+	}
+	if size := localSize(t, m, fcfg.ID); size.Files != 3 {
+		t.Fatalf("expected the files to be kept, got %v", size)
+	}
+
+	must(t, m.ResolveEmptiedFolder(fcfg.ID, false))
+	must(t, m.ScanFolder(fcfg.ID))
+	if size := localSize(t, m, fcfg.ID); size.Files != 0 || size.Deleted != 3 {
+		t.Errorf("expected the files to be deleted, got %v", size)
+	}
+
+	// The confirmation is forgotten once the folder is no longer emptied.
+	if m.ResolveEmptiedFolder(fcfg.ID, false) == nil {
+		t.Error("expected an error resolving a folder that isn't emptied")
+	}
+}
+
+func TestEmptiedFolderRepulls(t *testing.T) {
+	m, fcfg := setupEmptiedFolder(t)
+
+	if err := m.ScanFolder(fcfg.ID); !errors.Is(err, errFolderEmptied) {
+		t.Fatalf("expected the scan to fail as the folder is emptied, got %v", err)
+	}
+
+	must(t, m.ResolveEmptiedFolder(fcfg.ID, true))
+	must(t, m.ScanFolder(fcfg.ID))
+	if size := localSize(t, m, fcfg.ID); size.Files != 0 || size.Deleted != 0 {
+		t.Errorf("expected the files to be forgotten without deleting them, got %v", size)
+	}
+}
+
+func TestEmptiedFolderConfirmationSurvivesRestart(t *testing.T) {
+	m, fcfg := setupEmptiedFolder(t)
+
+	if err := m.ScanFolder(fcfg.ID); !errors.Is(err, errFolderEmptied) {
+		t.Fatalf("expected the scan to fail as the folder is emptied, got %v", err)
+	}
+
+	// Keep the confirmation from being acted upon before the restart.
+	m.scheduler.Remove("scan/" + fcfg.ID)
+	must(t, m.ResolveEmptiedFolder(fcfg.ID, false))
+	if confirmed, err := m.db.EmptiedConfirmed(fcfg.ID); err != nil {
+		t.Fatal(err)
+	} else if !confirmed {
+		t.Fatal("expected the confirmation to be recorded")
+	}
+
+	must(t, m.restartFolder(fcfg, fcfg, false))
+	must(t, m.ScanFolder(fcfg.ID))
+	if size := localSize(t, m, fcfg.ID); size.Files != 0 || size.Deleted != 3 {
+		t.Errorf("expected the files to be deleted after the restart, got %v", size)
+	}
+	if confirmed, err := m.db.EmptiedConfirmed(fcfg.ID); err != nil {
+		t.Fatal(err)
+	} else if confirmed {
+		t.Error("expected the confirmation to be cleared after a successful scan")
+	}
+}
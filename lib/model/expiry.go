@@ -0,0 +1,231 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/jobs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// How long before something expires an ExpiryWarning event is emitted.
+const expiryWarningTime = 24 * time.Hour
+
+const expiryJobName = "expiry"
+
+// The expiryService enforces expiry times set in the configuration. Devices
+// that have expired are paused and their folder shares revoked, individual
+// folder shares that have expired are revoked.
+type expiryService struct {
+	cfg       config.Wrapper
+	scheduler jobs.Scheduler
+	evLogger  events.Logger
+	timeNow   func() time.Time
+	warned    map[expiryKey]time.Time // expiry time we last warned about
+	next      time.Time               // of the next check, only used by the job
+}
+
+// expiryKey identifies something that can expire: a device (empty folder)
+// or the share of a folder with a device.
+type expiryKey struct {
+	device protocol.DeviceID
+	folder string
+}
+
+func newExpiryService(cfg config.Wrapper, scheduler jobs.Scheduler, evLogger events.Logger) *expiryService {
+	return &expiryService{
+		cfg:       cfg,
+		scheduler: scheduler,
+		evLogger:  evLogger,
+		timeNow:   time.Now,
+		warned:    make(map[expiryKey]time.Time),
+	}
+}
+
+func (s *expiryService) Serve(ctx context.Context) error {
+	s.cfg.Subscribe(s)
+	defer s.cfg.Unsubscribe(s)
+
+	s.scheduler.Add(jobs.Job{
+		Name:     expiryJobName,
+		Interval: func() time.Duration { return untilDue(s.next, s.timeNow()) },
+		Run: func(context.Context) error {
+			s.next = s.enforce()
+			return nil
+		},
+	})
+	defer s.scheduler.Remove(expiryJobName)
+
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// untilDue returns the interval for a job to run at the given time, or
+// zero if there is none.
+func untilDue(t, now time.Time) time.Duration {
+	if t.IsZero() {
+		return 0
+	}
+	if d := t.Sub(now); d > 0 {
+		return d
+	}
+	return time.Nanosecond
+}
+
+// enforce applies all expiries that are due, emits warnings for those that
+// are coming up and returns the next point in time at which something needs
+// to happen, or the zero time if nothing does.
+func (s *expiryService) enforce() time.Time {
+	now := s.timeNow()
+	var next time.Time
+	updateNext := func(t time.Time) {
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	seen := make(map[expiryKey]struct{})
+	// check returns true if the given expiry is due, otherwise it sends a
+	// warning if appropriate and schedules the next check.
+	check := func(key expiryKey, expires time.Time) bool {
+		if !now.Before(expires) {
+			return true
+		}
+		seen[key] = struct{}{}
+		warnAt := expires.Add(-expiryWarningTime)
+		if now.Before(warnAt) {
+			updateNext(warnAt)
+		} else if !s.warned[key].Equal(expires) {
+			s.warn(key, expires)
+		}
+		updateNext(expires)
+		return false
+	}
+
+	cfg := s.cfg.RawCopy()
+	expired := make(map[protocol.DeviceID]struct{})
+	for _, dev := range cfg.Devices {
+		if dev.Expires == nil || dev.Expires.IsZero() {
+			continue
+		}
+		if !check(expiryKey{device: dev.DeviceID}, *dev.Expires) {
+			continue
+		}
+		if !dev.Paused || deviceHasShares(cfg, dev.DeviceID) {
+			expired[dev.DeviceID] = struct{}{}
+		}
+	}
+	expiredShares := make(map[string]map[protocol.DeviceID]struct{})
+	for _, fcfg := range cfg.Folders {
+		for _, dev := range fcfg.Devices {
+			if dev.Expires == nil || dev.Expires.IsZero() {
+				continue
+			}
+			if _, ok := expired[dev.DeviceID]; ok {
+				continue
+			}
+			if !check(expiryKey{device: dev.DeviceID, folder: fcfg.ID}, *dev.Expires) {
+				continue
+			}
+			if expiredShares[fcfg.ID] == nil {
+				expiredShares[fcfg.ID] = make(map[protocol.DeviceID]struct{})
+			}
+			expiredShares[fcfg.ID][dev.DeviceID] = struct{}{}
+		}
+	}
+
+	// Forget about warnings for things that are gone or already expired.
+	for key := range s.warned {
+		if _, ok := seen[key]; !ok {
+			delete(s.warned, key)
+		}
+	}
+
+	if len(expired) > 0 || len(expiredShares) > 0 {
+		_, err := s.cfg.Modify(func(cfg *config.Configuration) {
+			for i := range cfg.Devices {
+				dev := &cfg.Devices[i]
+				if _, ok := expired[dev.DeviceID]; !ok {
+					continue
+				}
+				l.Infof("Device %v expired at %v, pausing it and revoking its folder shares", dev.Description(), dev.Expires.Format(time.RFC3339))
+				dev.Paused = true
+			}
+			for i := range cfg.Folders {
+				fcfg := &cfg.Folders[i]
+				remove := expired
+				if shares, ok := expiredShares[fcfg.ID]; ok {
+					remove = make(map[protocol.DeviceID]struct{}, len(expired)+len(shares))
+					for id := range expired {
+						remove[id] = struct{}{}
+					}
+					for id := range shares {
+						l.Infof("Share of folder %v with device %v expired, revoking it", fcfg.Description(), id)
+						remove[id] = struct{}{}
+					}
+				}
+				fcfg.Devices = withoutDevices(fcfg.Devices, remove)
+			}
+		})
+		if err != nil {
+			l.Warnln("Failed to apply expiry:", err)
+		}
+	}
+
+	return next
+}
+
+func (s *expiryService) warn(key expiryKey, expires time.Time) {
+	s.warned[key] = expires
+	data := map[string]interface{}{
+		"device":  key.device.String(),
+		"expires": expires,
+	}
+	if key.folder == "" {
+		l.Infof("Device %v expires at %v", key.device, expires.Format(time.RFC3339))
+	} else {
+		l.Infof("Share of folder %q with device %v expires at %v", key.folder, key.device, expires.Format(time.RFC3339))
+		data["folder"] = key.folder
+	}
+	s.evLogger.Log(events.ExpiryWarning, data)
+}
+
+func (s *expiryService) VerifyConfiguration(_, _ config.Configuration) error {
+	return nil
+}
+
+func (s *expiryService) CommitConfiguration(_, _ config.Configuration) bool {
+	s.scheduler.Trigger(expiryJobName)
+	return true
+}
+
+func (s *expiryService) String() string {
+	return fmt.Sprintf("expiryService@%p", s)
+}
+
+func deviceHasShares(cfg config.Configuration, id protocol.DeviceID) bool {
+	for _, fcfg := range cfg.Folders {
+		if _, ok := fcfg.Device(id); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutDevices(devices []config.FolderDeviceConfiguration, remove map[protocol.DeviceID]struct{}) []config.FolderDeviceConfiguration {
+	filtered := make([]config.FolderDeviceConfiguration, 0, len(devices))
+	for _, dev := range devices {
+		if _, ok := remove[dev.DeviceID]; !ok {
+			filtered = append(filtered, dev)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/jobs"
+)
+
+func TestDeviceExpiry(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	addDevice2(t, w, fcfg)
+
+	now := time.Now()
+	expires := now.Add(time.Hour)
+	waiter, err := w.Modify(func(cfg *config.Configuration) {
+		dev, _, _ := cfg.Device(device2)
+		dev.Expires = &expires
+		cfg.SetDevice(dev)
+	})
+	must(t, err)
+	waiter.Wait()
+
+	s := newExpiryService(w, jobs.New(), events.NoopLogger)
+	s.timeNow = func() time.Time { return now }
+
+	if next := s.enforce(); !next.Equal(expires) {
+		t.Errorf("expected next expiry at %v, got %v", expires, next)
+	}
+	if dev, _ := w.Device(device2); dev.Paused {
+		t.Error("device paused before expiry")
+	}
+
+	now = expires
+	if next := s.enforce(); !next.IsZero() {
+		t.Errorf("expected no further expiry, got %v", next)
+	}
+	if dev, _ := w.Device(device2); !dev.Paused {
+		t.Error("expired device not paused")
+	}
+	folder, _ := w.Folder(fcfg.ID)
+	if _, ok := folder.Device(device2); ok {
+		t.Error("expired device still shared")
+	}
+	if _, ok := folder.Device(device1); !ok {
+		t.Error("unexpired device no longer shared")
+	}
+}
+
+func TestFolderShareExpiry(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	addDevice2(t, w, fcfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	evLogger := events.NewLogger()
+	go evLogger.Serve(ctx)
+	sub := evLogger.Subscribe(events.ExpiryWarning)
+	defer sub.Unsubscribe()
+
+	now := time.Now()
+	expires := now.Add(2 * expiryWarningTime)
+	waiter, err := w.Modify(func(cfg *config.Configuration) {
+		folder, _, _ := cfg.Folder(fcfg.ID)
+		for i := range folder.Devices {
+			if folder.Devices[i].DeviceID == device2 {
+				folder.Devices[i].Expires = &expires
+			}
+		}
+		cfg.SetFolder(folder)
+	})
+	must(t, err)
+	waiter.Wait()
+
+	s := newExpiryService(w, jobs.New(), evLogger)
+	s.timeNow = func() time.Time { return now }
+
+	// Nothing happens until it's time to warn.
+	if next := s.enforce(); !next.Equal(expires.Add(-expiryWarningTime)) {
+		t.Errorf("expected next check at warning time, got %v", next)
+	}
+	if _, err := sub.Poll(100 * time.Millisecond); err != events.ErrTimeout {
+		t.Fatal("unexpected expiry warning")
+	}
+
+	// Warn once, ahead of the expiry.
+	now = expires.Add(-time.Hour)
+	if next := s.enforce(); !next.Equal(expires) {
+		t.Errorf("expected next check at %v, got %v", expires, next)
+	}
+	ev, err := sub.Poll(time.Second)
+	if err != nil {
+		t.Fatal("no expiry warning:", err)
+	}
+	if data := ev.Data.(map[string]interface{}); data["folder"] != fcfg.ID || data["device"] != device2.String() {
+		t.Errorf("unexpected expiry warning data: %v", data)
+	}
+	s.enforce()
+	if _, err := sub.Poll(100 * time.Millisecond); err != events.ErrTimeout {
+		t.Fatal("repeated expiry warning")
+	}
+
+	// Once expired only that share is revoked, the device stays.
+	now = expires
+	if next := s.enforce(); !next.IsZero() {
+		t.Errorf("expected no further expiry, got %v", next)
+	}
+	folder, _ := w.Folder(fcfg.ID)
+	if _, ok := folder.Device(device2); ok {
+		t.Error("expired share still exists")
+	}
+	if _, ok := folder.Device(device1); !ok {
+		t.Error("unexpired share no longer exists")
+	}
+	if dev, ok := w.Device(device2); !ok || dev.Paused {
+		t.Error("device affected by expired share")
+	}
+}
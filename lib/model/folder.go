@@ -10,7 +10,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/rand"
 	"path/filepath"
 	"sort"
 	"time"
@@ -20,7 +19,9 @@ import (
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/fs"
 	"github.com/syncthing/syncthing/lib/ignore"
+	"github.com/syncthing/syncthing/lib/jobs"
 	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/messages"
 	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/scanner"
@@ -36,6 +37,25 @@ import (
 // Arbitrary limit that triggers a warning on kqueue systems
 const kqueueItemCountThreshold = 10000
 
+// How often to check whether a read-only filesystem became writable.
+const readOnlyRecheckInterval = time.Minute
+
+// Periodic scans happen at a random time between 3/4 and 5/4 of the
+// configured interval.
+const scanJitter = 0.25
+
+// Pulls are triggered by index updates, which tend to come in bursts. A
+// pull starts once no further trigger arrived for pullDebounceDelay, but
+// no later than pullDebounceMin after the first trigger, or a tenth of the
+// duration of the last pull if that is longer, up to pullDebounceMax. That
+// way a single change is pulled right away, while a folder that is
+// expensive to pull isn't pulled again for every update of a burst.
+const (
+	pullDebounceDelay = 100 * time.Millisecond
+	pullDebounceMin   = time.Second
+	pullDebounceMax   = 10 * time.Second
+)
+
 type folder struct {
 	stateTracker
 	config.FolderConfiguration
@@ -54,16 +74,23 @@ type folder struct {
 	done          chan struct{}   // used externally, accessible regardless of serve
 
 	scanInterval           time.Duration
-	scanTimer              *time.Timer
-	scanDelay              chan time.Duration
 	initialScanFinished    chan struct{}
-	scanScheduled          chan struct{}
 	versionCleanupInterval time.Duration
-	versionCleanupTimer    *time.Timer
 
-	pullScheduled chan struct{}
-	pullPause     time.Duration
-	pullFailTimer *time.Timer
+	pullScheduled     chan struct{}
+	pullPause         time.Duration
+	pullFailTimer     *time.Timer
+	pullDebounceTimer *time.Timer
+	pullTriggered     time.Time     // the first trigger of the pending pull
+	lastPullDuration  time.Duration // how long the last pull took
+
+	// Whether each completion directory was complete when last checked.
+	// Only accessed from the pull.
+	completeDirs map[string]bool
+
+	// Whether the filesystem was read-only when last checked, degrading
+	// the folder to send only. Only accessed from the pull.
+	readOnly bool
 
 	scanErrors []FileError
 	pullErrors []FileError
@@ -85,6 +112,8 @@ type folder struct {
 	versioner versioner.Versioner
 
 	warnedKqueue bool
+
+	storageForecast *storageForecast // only used by the storage job
 }
 
 type syncRequest struct {
@@ -107,17 +136,13 @@ func newFolder(model *model, fset *db.FileSet, ignores *ignore.Matcher, cfg conf
 		shortID:       model.shortID,
 		fset:          fset,
 		ignores:       ignores,
-		mtimefs:       cfg.Filesystem(fset),
+		mtimefs:       model.folderFilesystem(cfg, fset),
 		modTimeWindow: cfg.ModTimeWindow(),
 		done:          make(chan struct{}),
 
 		scanInterval:           time.Duration(cfg.RescanIntervalS) * time.Second,
-		scanTimer:              time.NewTimer(0), // The first scan should be done immediately.
-		scanDelay:              make(chan time.Duration),
 		initialScanFinished:    make(chan struct{}),
-		scanScheduled:          make(chan struct{}, 1),
 		versionCleanupInterval: time.Duration(cfg.Versioning.CleanupIntervalS) * time.Second,
-		versionCleanupTimer:    time.NewTimer(time.Duration(cfg.Versioning.CleanupIntervalS) * time.Second),
 
 		pullScheduled: make(chan struct{}, 1), // This needs to be 1-buffered so that we queue a pull if we're busy when it comes.
 
@@ -134,10 +159,14 @@ func newFolder(model *model, fset *db.FileSet, ignores *ignore.Matcher, cfg conf
 		watchMut:         sync.NewMutex(),
 
 		versioner: ver,
+
+		storageForecast: newStorageForecast(),
 	}
 	f.pullPause = f.pullBasePause()
 	f.pullFailTimer = time.NewTimer(0)
 	<-f.pullFailTimer.C
+	f.pullDebounceTimer = time.NewTimer(0)
+	<-f.pullDebounceTimer.C
 
 	registerFolderMetrics(f.ID)
 
@@ -153,23 +182,18 @@ func (f *folder) Serve(ctx context.Context) error {
 	l.Debugln(f, "starting")
 	defer l.Debugln(f, "exiting")
 
-	defer func() {
-		f.scanTimer.Stop()
-		f.versionCleanupTimer.Stop()
-		f.setState(FolderIdle)
-	}()
+	defer f.setState(FolderIdle)
+
+	if f.getHealthErrorWithoutIgnores() == nil {
+		f.probeCapabilities()
+	}
 
 	if f.FSWatcherEnabled && f.getHealthErrorAndLoadIgnores() == nil {
 		f.startWatch()
 	}
 
-	// If we're configured to not do version cleanup, or we don't have a
-	// versioner, cancel and drain that timer now.
-	if f.versionCleanupInterval == 0 || f.versioner == nil {
-		if !f.versionCleanupTimer.Stop() {
-			<-f.versionCleanupTimer.C
-		}
-	}
+	f.scheduleJobs()
+	defer f.unscheduleJobs()
 
 	initialCompleted := f.initialScanFinished
 
@@ -182,6 +206,10 @@ func (f *folder) Serve(ctx context.Context) error {
 			return nil
 
 		case <-f.pullScheduled:
+			f.debouncePull()
+
+		case <-f.pullDebounceTimer.C:
+			f.pullTriggered = time.Time{}
 			_, err = f.pull()
 
 		case <-f.pullFailTimer.C:
@@ -200,23 +228,11 @@ func (f *folder) Serve(ctx context.Context) error {
 		case <-f.forcedRescanRequested:
 			err = f.handleForcedRescans()
 
-		case <-f.scanTimer.C:
-			l.Debugln(f, "Scanning due to timer")
-			err = f.scanTimerFired()
-
 		case req := <-f.doInSyncChan:
 			l.Debugln(f, "Running something due to request")
 			err = req.fn()
 			req.err <- err
 
-		case next := <-f.scanDelay:
-			l.Debugln(f, "Delaying scan")
-			f.scanTimer.Reset(next)
-
-		case <-f.scanScheduled:
-			l.Debugln(f, "Scan was scheduled")
-			f.scanTimer.Reset(0)
-
 		case fsEvents := <-f.watchChan:
 			l.Debugln(f, "Scan due to watcher")
 			err = f.scanSubdirs(fsEvents)
@@ -224,10 +240,6 @@ func (f *folder) Serve(ctx context.Context) error {
 		case <-f.restartWatchChan:
 			l.Debugln(f, "Restart watcher")
 			err = f.restartWatch()
-
-		case <-f.versionCleanupTimer.C:
-			l.Debugln(f, "Doing version cleanup")
-			f.versionCleanupTimerFired()
 		}
 
 		if err != nil {
@@ -241,23 +253,79 @@ func (f *folder) Serve(ctx context.Context) error {
 
 func (*folder) BringToFront(string) {}
 
+func (*folder) PullHint([]string) {}
+
 func (*folder) Override() {}
 
 func (*folder) Revert() {}
 
 func (f *folder) DelayScan(next time.Duration) {
-	select {
-	case f.scanDelay <- next:
-	case <-f.done:
-	}
+	l.Debugln(f, "Delaying scan")
+	f.model.scheduler.Reschedule(f.scanJobName(), next)
 }
 
 func (f *folder) ScheduleScan() {
-	// 1-buffered chan
-	select {
-	case f.scanScheduled <- struct{}{}:
-	default:
+	l.Debugln(f, "Scan was scheduled")
+	f.model.scheduler.Trigger(f.scanJobName())
+}
+
+// scheduleJobs schedules the periodic scans, the first right away, and
+// the version cleanups of the folder.
+func (f *folder) scheduleJobs() {
+	f.model.scheduler.Add(jobs.Job{
+		Name:     f.scanJobName(),
+		Interval: func() time.Duration { return f.scanInterval },
+		Jitter:   scanJitter,
+		Run: func(context.Context) error {
+			l.Debugln(f, "Scanning due to schedule")
+			return f.doInSync(f.scheduledScan)
+		},
+	})
+
+	if f.StagingPath != "" || f.versioner != nil {
+		f.model.scheduler.Add(jobs.Job{
+			Name:     f.storageJobName(),
+			Interval: func() time.Duration { return storageSampleInterval },
+			Run:      f.forecastStorage,
+		})
+	}
+
+	// Unless we're configured to not do version cleanup, or we don't
+	// have a versioner.
+	if f.versionCleanupInterval == 0 || f.versioner == nil {
+		return
 	}
+	f.model.scheduler.Add(jobs.Job{
+		Name:     f.versionCleanupJobName(),
+		First:    f.versionCleanupInterval,
+		Interval: func() time.Duration { return f.versionCleanupInterval },
+		Run: func(context.Context) error {
+			var cleanErr error
+			err := f.doInSync(func() error {
+				l.Debugln(f, "Doing version cleanup")
+				cleanErr = f.cleanVersions()
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return cleanErr
+		},
+	})
+}
+
+func (f *folder) unscheduleJobs() {
+	f.model.scheduler.Remove(f.scanJobName())
+	f.model.scheduler.Remove(f.versionCleanupJobName())
+	f.model.scheduler.Remove(f.storageJobName())
+}
+
+func (f *folder) scanJobName() string {
+	return "scan/" + f.ID
+}
+
+func (f *folder) versionCleanupJobName() string {
+	return "versionCleanup/" + f.ID
 }
 
 func (f *folder) ignoresUpdated() {
@@ -302,17 +370,6 @@ func (f *folder) doInSync(fn func() error) error {
 	}
 }
 
-func (f *folder) Reschedule() {
-	if f.scanInterval == 0 {
-		return
-	}
-	// Sleep a random time between 3/4 and 5/4 of the configured interval.
-	sleepNanos := (f.scanInterval.Nanoseconds()*3 + rand.Int63n(2*f.scanInterval.Nanoseconds())) / 4
-	interval := time.Duration(sleepNanos) * time.Nanosecond
-	l.Debugln(f, "next rescan in", interval)
-	f.scanTimer.Reset(interval)
-}
-
 func (f *folder) getHealthErrorAndLoadIgnores() error {
 	if err := f.getHealthErrorWithoutIgnores(); err != nil {
 		return err
@@ -333,8 +390,16 @@ func (f *folder) getHealthErrorWithoutIgnores() error {
 		return err
 	}
 
+	if err := f.checkEmptied(); err != nil {
+		return err
+	}
+
+	if f.AtRestEncryption && f.model.atRestKeys.get(f.ID) == nil {
+		return fs.ErrLocked
+	}
+
 	if minFree := f.model.cfg.Options().MinHomeDiskFree; minFree.Value > 0 {
-		dbPath := locations.Get(locations.Database)
+		dbPath := f.model.profile.Get(locations.Database)
 		if usage, err := fs.NewFilesystem(fs.FilesystemTypeBasic, dbPath).Usage("."); err == nil {
 			if err = config.CheckFreeSpace(minFree, usage); err != nil {
 				return fmt.Errorf("insufficient space on disk for database (%v): %w", dbPath, err)
@@ -345,6 +410,36 @@ func (f *folder) getHealthErrorWithoutIgnores() error {
 	return nil
 }
 
+// debouncePull starts or extends the wait for further triggers before
+// pulling.
+func (f *folder) debouncePull() {
+	now := time.Now()
+	if f.pullTriggered.IsZero() {
+		f.pullTriggered = now
+	}
+	maxWait := f.lastPullDuration / 10
+	if maxWait < pullDebounceMin {
+		maxWait = pullDebounceMin
+	} else if maxWait > pullDebounceMax {
+		maxWait = pullDebounceMax
+	}
+	delay := pullDebounceDelay
+	if remaining := maxWait - now.Sub(f.pullTriggered); remaining < delay {
+		delay = remaining
+	}
+	if delay < 0 {
+		delay = 0
+	}
+
+	if !f.pullDebounceTimer.Stop() {
+		select {
+		case <-f.pullDebounceTimer.C:
+		default:
+		}
+	}
+	f.pullDebounceTimer.Reset(delay)
+}
+
 func (f *folder) pull() (success bool, err error) {
 	f.pullFailTimer.Stop()
 	select {
@@ -352,6 +447,10 @@ func (f *folder) pull() (success bool, err error) {
 	default:
 	}
 
+	defer func(start time.Time) {
+		f.lastPullDuration = time.Since(start)
+	}(time.Now())
+
 	select {
 	case <-f.initialScanFinished:
 	default:
@@ -366,12 +465,25 @@ func (f *folder) pull() (success bool, err error) {
 		}
 	}()
 
+	// Completion directories are checked before pulling, to notice them
+	// becoming incomplete, and after, to notice them becoming complete.
+	defer func() {
+		if len(f.CompletionDirectories) == 0 {
+			return
+		}
+		if snap, err := f.dbSnapshot(); err == nil {
+			f.updateCompletionDirectories(snap)
+			snap.Release()
+		}
+	}()
+
 	// If there is nothing to do, don't even enter sync-waiting state.
 	abort := true
 	snap, err := f.dbSnapshot()
 	if err != nil {
 		return false, err
 	}
+	f.updateCompletionDirectories(snap)
 	snap.WithNeed(protocol.LocalDeviceID, func(intf protocol.FileIntf) bool {
 		abort = false
 		return false
@@ -385,6 +497,12 @@ func (f *folder) pull() (success bool, err error) {
 		return true, nil
 	}
 
+	// Changes stay in the index, to be applied once the folder is unfrozen.
+	if f.Frozen {
+		l.Debugln("Skipping pull of", f.Description(), "as it is frozen")
+		return true, nil
+	}
+
 	// Abort early (before acquiring a token) if there's a folder error
 	err = f.getHealthErrorWithoutIgnores()
 	if err != nil {
@@ -392,9 +510,17 @@ func (f *folder) pull() (success bool, err error) {
 		return false, err
 	}
 
+	// A folder on a read-only filesystem can't apply any changes, so it
+	// acts as send only until the filesystem is writable again.
+	if f.Type != config.FolderTypeSendOnly && f.Type != config.FolderTypeIndexOnly && f.checkReadOnly() {
+		f.pullFailTimer.Reset(readOnlyRecheckInterval)
+		return true, nil
+	}
+
 	// Send only folder doesn't do any io, it only checks for out-of-sync
-	// items that differ in metadata and updates those.
-	if f.Type != config.FolderTypeSendOnly {
+	// items that differ in metadata and updates those. Index only folders
+	// don't pull at all.
+	if f.Type != config.FolderTypeSendOnly && f.Type != config.FolderTypeIndexOnly {
 		f.setState(FolderSyncWaiting)
 
 		if err := f.ioLimiter.TakeWithContext(f.ctx, 1); err != nil {
@@ -433,17 +559,63 @@ func (f *folder) pull() (success bool, err error) {
 	return false, err
 }
 
-func (f *folder) scanSubdirs(subDirs []string) error {
+// checkReadOnly probes whether the filesystem is read-only by writing a
+// temporary file, degrading the folder to send only or restoring its type
+// when that changed.
+func (f *folder) checkReadOnly() bool {
+	name := fs.TempName("writecheck")
+	fd, err := f.mtimefs.Create(name)
+	if err == nil {
+		fd.Close()
+		f.mtimefs.Remove(name)
+	}
+	readOnly := fs.IsReadOnlyFilesystem(err)
+	if readOnly == f.readOnly {
+		return readOnly
+	}
+	f.readOnly = readOnly
+
+	if readOnly {
+		l.Warnf("Folder %v is on a read-only filesystem; it acts as send only until it's writable again", f.Description())
+		f.errorsMut.Lock()
+		f.pullErrors = nil
+		f.errorsMut.Unlock()
+		f.evLogger.Log(events.FolderTypeDegraded, map[string]interface{}{
+			"folder":        f.ID,
+			"label":         f.Label,
+			"type":          f.Type.String(),
+			"effectiveType": config.FolderTypeSendOnly.String(),
+			"reason":        "read-only filesystem",
+			"reasonCode":    messages.FolderReadOnly,
+		})
+	} else {
+		l.Infof("Folder %v is writable again; it acts as %v again", f.Description(), f.Type)
+		f.evLogger.Log(events.FolderTypeRestored, map[string]interface{}{
+			"folder": f.ID,
+			"label":  f.Label,
+			"type":   f.Type.String(),
+		})
+	}
+	return readOnly
+}
+
+func (f *folder) scanSubdirs(subDirs []string) (err error) {
 	l.Debugf("%v scanning", f)
 
 	oldHash := f.ignores.Hash()
 
-	err := f.getHealthErrorAndLoadIgnores()
+	err = f.getHealthErrorAndLoadIgnores()
 	if err != nil {
 		return err
 	}
 	f.setError(nil)
 
+	// Index only folders only keep the metadata of the cluster, anything
+	// on disk isn't announced.
+	if f.Type == config.FolderTypeIndexOnly {
+		return nil
+	}
+
 	// Check on the way out if the ignore patterns changed as part of scanning
 	// this folder. If they did we should schedule a pull of the folder so that
 	// we request things we might have suddenly become unignored and so on.
@@ -463,6 +635,12 @@ func (f *folder) scanSubdirs(subDirs []string) error {
 	}
 	defer f.ioLimiter.Give(1)
 
+	resume, err := f.quiesce()
+	if err != nil {
+		return err
+	}
+	defer resume()
+
 	metricFolderScans.WithLabelValues(f.ID).Inc()
 	ctx, cancel := context.WithCancel(f.ctx)
 	defer cancel()
@@ -497,7 +675,10 @@ func (f *folder) scanSubdirs(subDirs []string) error {
 	f.setState(FolderScanning)
 	f.clearScanErrors(subDirs)
 
-	batch := f.newScanBatch()
+	batch := f.newScanBatch(newScanSummary(subDirs, time.Now()))
+	defer func() {
+		f.scanFinished(batch.summary, err)
+	}()
 
 	// Schedule a pull after scanning, but only if we actually detected any
 	// changes.
@@ -519,7 +700,8 @@ func (f *folder) scanSubdirs(subDirs []string) error {
 		return err
 	}
 
-	if len(subDirs) == 0 {
+	fullScan := len(subDirs) == 0
+	if fullScan {
 		// If we have no specific subdirectories to traverse, set it to one
 		// empty prefix so we traverse the entire folder contents once.
 		subDirs = []string{""}
@@ -538,6 +720,10 @@ func (f *folder) scanSubdirs(subDirs []string) error {
 		return err
 	}
 
+	if fullScan {
+		f.clearEmptiedConfirmed()
+	}
+
 	f.ScanCompleted()
 	return nil
 }
@@ -547,22 +733,34 @@ const maxToRemove = 1000
 type scanBatch struct {
 	f           *folder
 	updateBatch *db.FileInfoBatch
+	inodeBatch  *db.FileInfoBatch
 	toRemove    []string
+	summary     *ScanSummary
 }
 
-func (f *folder) newScanBatch() *scanBatch {
+func (f *folder) newScanBatch(summary *ScanSummary) *scanBatch {
 	b := &scanBatch{
 		f:        f,
 		toRemove: make([]string, 0, maxToRemove),
+		summary:  summary,
 	}
 	b.updateBatch = db.NewFileInfoBatch(func(fs []protocol.FileInfo) error {
 		if err := b.f.getHealthErrorWithoutIgnores(); err != nil {
 			l.Debugf("Stopping scan of folder %s due to: %s", b.f.Description(), err)
 			return err
 		}
+		if b.f.Transactional {
+			setTransactionID(fs)
+		}
 		b.f.updateLocalsFromScanning(fs)
 		return nil
 	})
+	// Updates of host-local details only, which aren't changes to
+	// announce.
+	b.inodeBatch = db.NewFileInfoBatch(func(fs []protocol.FileInfo) error {
+		b.f.fset.Update(protocol.LocalDeviceID, fs)
+		return nil
+	})
 	return b
 }
 
@@ -579,6 +777,9 @@ func (b *scanBatch) flushToRemove() {
 
 func (b *scanBatch) Flush() error {
 	b.flushToRemove()
+	if err := b.inodeBatch.Flush(); err != nil {
+		return err
+	}
 	return b.updateBatch.Flush()
 }
 
@@ -586,6 +787,9 @@ func (b *scanBatch) FlushIfFull() error {
 	if len(b.toRemove) >= maxToRemove {
 		b.flushToRemove()
 	}
+	if err := b.inodeBatch.FlushIfFull(); err != nil {
+		return err
+	}
 	return b.updateBatch.FlushIfFull()
 }
 
@@ -622,12 +826,32 @@ func (b *scanBatch) Update(fi protocol.FileInfo, snap *db.Snapshot) bool {
 		}):
 		// What we have locally is equivalent to the global file.
 		l.Debugf("%v scanning: Merging identical locally changed item with global", b.f, fi)
+		gf.InodeChangeNs, gf.Inode, gf.InodeGeneration = fi.InodeChangeNs, fi.Inode, fi.InodeGeneration
 		fi = gf
 	}
+	prev, hadPrev := snap.Get(protocol.LocalDeviceID, fi.Name)
+	if hadPrev && fi.Version.Equal(prev.Version) && fi.LocalFlags == prev.LocalFlags {
+		b.inodeBatch.Append(fi)
+		return false
+	}
+	b.summary.record(fi, prev, hadPrev)
 	b.updateBatch.Append(fi)
 	return true
 }
 
+// scanFinished records the summary of a scan and announces it.
+func (f *folder) scanFinished(summary *ScanSummary, err error) {
+	summary.Finished = time.Now()
+	if err != nil {
+		summary.Error = err.Error()
+	}
+	f.model.scanHistory.add(f.ID, *summary)
+	f.evLogger.Log(events.FolderScanSummary, map[string]interface{}{
+		"folder":  f.ID,
+		"summary": summary,
+	})
+}
+
 func (f *folder) scanSubdirsChangedAndNew(subDirs []string, batch *scanBatch) (int, error) {
 	changes := 0
 	snap, err := f.dbSnapshot()
@@ -659,6 +883,7 @@ func (f *folder) scanSubdirsChangedAndNew(subDirs []string, batch *scanBatch) (i
 		ScanOwnership:         f.SendOwnership || f.SyncOwnership,
 		ScanXattrs:            f.SendXattrs || f.SyncXattrs,
 		XattrFilter:           f.XattrFilter,
+		KeepBlockSize:         f.Tuning().KeepBlockSize,
 	}
 	var fchan chan scanner.ScanResult
 	if f.Type == config.FolderTypeReceiveEncrypted {
@@ -683,6 +908,15 @@ func (f *folder) scanSubdirsChangedAndNew(subDirs []string, batch *scanBatch) (i
 			return changes, err
 		}
 
+		if res.File.Type == protocol.FileInfoTypeFile {
+			if cur, ok := snap.Get(protocol.LocalDeviceID, res.File.Name); ok && f.onlyInodeChanged(cur, res.File) {
+				// Rehashed due to an inode change, but the contents are
+				// the same. Record the new inode without a new version.
+				res.File.Version = cur.Version
+				res.File.ModifiedBy = cur.ModifiedBy
+			}
+		}
+
 		if batch.Update(res.File, snap) {
 			changes++
 		}
@@ -701,6 +935,17 @@ func (f *folder) scanSubdirsChangedAndNew(subDirs []string, batch *scanBatch) (i
 	return changes, nil
 }
 
+// onlyInodeChanged returns true if the scanned file differs from the
+// current one only in the host-local inode details.
+func (f *folder) onlyInodeChanged(cur, scanned protocol.FileInfo) bool {
+	return cur.LocalFlags == scanned.LocalFlags && cur.IsEquivalentOptional(scanned, protocol.FileInfoComparison{
+		ModTimeWindow:   f.modTimeWindow,
+		IgnorePerms:     f.IgnorePerms,
+		IgnoreOwnership: !f.SendOwnership && !f.SyncOwnership,
+		IgnoreXattrs:    !f.SendXattrs && !f.SyncXattrs,
+	})
+}
+
 func (f *folder) scanSubdirsDeletedAndIgnored(subDirs []string, batch *scanBatch) (int, error) {
 	var toIgnore []db.FileInfoTruncated
 	ignoredParent := ""
@@ -912,12 +1157,24 @@ func (f *folder) findRename(snap *db.Snapshot, file protocol.FileInfo, alreadyUs
 	return nf, found
 }
 
-func (f *folder) scanTimerFired() error {
-	err := f.scanSubdirs(nil)
-
+func (f *folder) scheduledScan() error {
+	initial := true
 	select {
 	case <-f.initialScanFinished:
+		initial = false
 	default:
+	}
+
+	var err error
+	caughtUp := false
+	if initial {
+		caughtUp, err = f.catchUpFromJournal()
+	}
+	if !caughtUp {
+		err = f.scanAll(initial)
+	}
+
+	if initial {
 		status := "Completed"
 		if err != nil {
 			status = "Failed"
@@ -926,27 +1183,25 @@ func (f *folder) scanTimerFired() error {
 		close(f.initialScanFinished)
 	}
 
-	f.Reschedule()
-
 	return err
 }
 
-func (f *folder) versionCleanupTimerFired() {
+func (f *folder) cleanVersions() error {
 	f.setState(FolderCleanWaiting)
 	defer f.setState(FolderIdle)
 
 	if err := f.ioLimiter.TakeWithContext(f.ctx, 1); err != nil {
-		return
+		return err
 	}
 	defer f.ioLimiter.Give(1)
 
 	f.setState(FolderCleaning)
 
 	if err := f.versioner.Clean(f.ctx); err != nil {
-		l.Infoln("Failed to clean versions in %s: %v", f.Description(), err)
+		l.Infof("Failed to clean versions in %s: %v", f.Description(), err)
+		return err
 	}
-
-	f.versionCleanupTimer.Reset(f.versionCleanupInterval)
+	return nil
 }
 
 func (f *folder) WatchError() error {
@@ -1167,8 +1422,10 @@ func (f *folder) newScanError(path string, err error) {
 	f.errorsMut.Lock()
 	l.Infof("Scanner (folder %s, item %q): %v", f.Description(), path, err)
 	f.scanErrors = append(f.scanErrors, FileError{
-		Err:  err.Error(),
-		Path: path,
+		Err:    err.Error(),
+		Path:   path,
+		Reason: errorReason(err),
+		Code:   errorCode(err),
 	})
 	f.errorsMut.Unlock()
 }
@@ -1216,16 +1473,141 @@ func (f *folder) ScheduleForceRescan(path string) {
 	}
 }
 
+// RepairCorrupted makes the given files, which have been found to not match
+// their recorded block hashes, be pulled again from other devices. It
+// returns the names of the files that will be repaired.
+func (f *folder) RepairCorrupted(names []string) ([]string, error) {
+	var repaired []string
+	err := f.doInSync(func() error {
+		var err error
+		repaired, err = f.repairCorrupted(names)
+		return err
+	})
+	return repaired, err
+}
+
+func (f *folder) repairCorrupted(names []string) ([]string, error) {
+	if f.Type == config.FolderTypeSendOnly {
+		return nil, errors.New("send-only folders cannot be repaired from other devices")
+	}
+
+	var repaired []string
+	batch := db.NewFileInfoBatch(func(fs []protocol.FileInfo) error {
+		f.updateLocals(fs)
+		return nil
+	})
+
+	snap, err := f.dbSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer snap.Release()
+
+	for _, name := range names {
+		if err := batch.FlushIfFull(); err != nil {
+			return nil, err
+		}
+
+		fi, ok := snap.Get(protocol.LocalDeviceID, name)
+		if !ok {
+			continue
+		}
+		gf, ok := snap.GetGlobal(name)
+		if !ok || !gf.Version.Equal(fi.Version) {
+			// We're not in sync on this file anyway, which will be
+			// resolved by the next scan or pull.
+			continue
+		}
+		available := false
+		for _, dev := range snap.Availability(name) {
+			if dev != protocol.LocalDeviceID {
+				available = true
+				break
+			}
+		}
+		if !available {
+			l.Infof("Folder %s: not repairing %s as no other device has it", f.Description(), name)
+			continue
+		}
+
+		// Reset the version to the empty vector, which is strictly older
+		// than the global version, making us pull it again. Good blocks
+		// get reused by the copier, corrupted ones fail verification
+		// there and are fetched from the network.
+		fi.Version = protocol.Vector{}
+		batch.Append(fi)
+		repaired = append(repaired, name)
+	}
+
+	if err := batch.Flush(); err != nil {
+		return nil, err
+	}
+
+	if len(repaired) > 0 {
+		f.SchedulePull()
+	}
+	return repaired, nil
+}
+
 func (f *folder) updateLocalsFromScanning(fs []protocol.FileInfo) {
 	f.updateLocals(fs)
 
 	f.emitDiskChangeEvents(fs, events.LocalChangeDetected)
+	f.recordChanges(fs, true)
+	for _, file := range fs {
+		if file.IsDeleted() && isConflict(file.Name) {
+			f.conflictResolved(file.Name)
+		}
+	}
 }
 
 func (f *folder) updateLocalsFromPulling(fs []protocol.FileInfo) {
 	f.updateLocals(fs)
 
 	f.emitDiskChangeEvents(fs, events.RemoteChangeDetected)
+	f.recordChanges(fs, false)
+}
+
+// recordChanges counts the changed files in the transfer statistics.
+func (f *folder) recordChanges(fs []protocol.FileInfo, local bool) {
+	for _, file := range fs {
+		if !file.IsInvalid() {
+			f.model.transferStats.Changed(f.ID, local, file.IsDeleted())
+		}
+	}
+}
+
+// conflictCreated records the creation of a conflict copy in the folder
+// statistics, and so do conflictResolved and reverted for the removal of a
+// conflict copy and reverts respectively.
+func (f *folder) conflictCreated(name string) {
+	if err := f.ConflictCreated(name); err != nil {
+		l.Debugln(f, "recording conflict:", err)
+	}
+	f.evLogger.Log(events.ConflictCreated, map[string]string{
+		"folder": f.ID,
+		"item":   name,
+	})
+}
+
+func (f *folder) conflictResolved(name string) {
+	if err := f.ConflictResolved(name); err != nil {
+		l.Debugln(f, "recording resolved conflict:", err)
+	}
+	f.evLogger.Log(events.ConflictResolved, map[string]string{
+		"folder": f.ID,
+		"item":   name,
+	})
+}
+
+func (f *folder) reverted(items int) {
+	if err := f.Reverted(); err != nil {
+		l.Debugln(f, "recording revert:", err)
+	}
+	f.evLogger.Log(events.FolderReverted, map[string]interface{}{
+		"folder": f.ID,
+		"items":  items,
+	})
 }
 
 func (f *folder) updateLocals(fs []protocol.FileInfo) {
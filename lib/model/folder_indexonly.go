@@ -0,0 +1,44 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/ignore"
+	"github.com/syncthing/syncthing/lib/semaphore"
+	"github.com/syncthing/syncthing/lib/versioner"
+)
+
+func init() {
+	folderFactories[config.FolderTypeIndexOnly] = newIndexOnlyFolder
+}
+
+// An indexOnlyFolder stores the indexes of the other devices sharing the
+// folder, so the metadata of the cluster can be inspected and backed up
+// through this device, but never pulls any data. Nothing on disk is
+// scanned and announced either, so the device is not a source of changes.
+type indexOnlyFolder struct {
+	folder
+}
+
+func newIndexOnlyFolder(model *model, fset *db.FileSet, ignores *ignore.Matcher, cfg config.FolderConfiguration, _ versioner.Versioner, evLogger events.Logger, ioLimiter *semaphore.Semaphore) service {
+	f := &indexOnlyFolder{
+		folder: newFolder(model, fset, ignores, cfg, evLogger, ioLimiter, nil),
+	}
+	f.folder.puller = f
+	return f
+}
+
+func (*indexOnlyFolder) PullErrors() []FileError {
+	return nil
+}
+
+func (*indexOnlyFolder) pull() (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestIndexOnlyFolder(t *testing.T) {
+	w, cancel := newConfigWrapper(defaultCfg)
+	defer cancel()
+	cfg := w.RawCopy()
+	fcfg := newFolderConfig()
+	fcfg.ID = "io"
+	fcfg.Label = "io"
+	fcfg.Type = config.FolderTypeIndexOnly
+	cfg.Folders = []config.FolderConfiguration{fcfg}
+	replace(t, w, cfg)
+
+	m := newModel(t, w, myID, "syncthing", "dev", nil)
+	m.ServeBackground()
+	defer cleanupModel(m)
+	<-m.started
+
+	// Files on disk are not announced.
+	ffs := fcfg.Filesystem(nil)
+	writeFilePerm(t, ffs, "local", []byte("hello\n"), 0o644)
+	must(t, m.ScanFolder("io"))
+	if size := localSize(t, m, "io"); size.TotalItems() != 0 {
+		t.Errorf("expected nothing local, got %+v", size)
+	}
+
+	// Indexes of others are kept, but nothing is pulled.
+	conn := addFakeConn(m, device1, "io")
+	conn.RequestCalls(func(_ context.Context, _, name string, _ int, _ int64, _ int, _ []byte, _ uint32, _ bool) ([]byte, error) {
+		t.Error("unexpected request for", name)
+		return nil, protocol.ErrGeneric
+	})
+	files := []protocol.FileInfo{{
+		Name:    "remote",
+		Type:    protocol.FileInfoTypeFile,
+		Size:    6,
+		Version: protocol.Vector{}.Update(device1.Short()),
+		Blocks:  []protocol.BlockInfo{{Size: 6, Hash: []byte("hash")}},
+	}}
+	must(t, m.Index(conn, "io", files))
+	if size := globalSize(t, m, "io"); size.Files != 1 {
+		t.Errorf("expected one global file, got %+v", size)
+	}
+	must(t, m.ScanFolder("io"))
+
+	fss := NewFolderSummaryService(w, m, myID, events.NoopLogger)
+	sum, err := fss.Summary("io")
+	must(t, err)
+	if sum.NeedTotalItems != 0 || sum.InSyncFiles != 1 {
+		t.Errorf("unexpected summary %+v", sum)
+	}
+}
@@ -92,6 +92,7 @@ func (f *receiveOnlyFolder) revert() error {
 		return err
 	}
 	defer snap.Release()
+	reverted := 0
 	snap.WithHave(protocol.LocalDeviceID, func(intf protocol.FileIntf) bool {
 		fi := intf.(protocol.FileInfo)
 		if !fi.IsReceiveOnlyChanged() {
@@ -145,6 +146,7 @@ func (f *receiveOnlyFolder) revert() error {
 
 		batch.Append(fi)
 		_ = batch.FlushIfFull()
+		reverted++
 
 		return true
 	})
@@ -165,8 +167,10 @@ func (f *receiveOnlyFolder) revert() error {
 			Deleted:    true,
 			Version:    protocol.Vector{},
 		})
+		reverted++
 	}
 	_ = batch.Flush()
+	f.reverted(reverted)
 
 	// We will likely have changed our local index, but that won't trigger a
 	// pull by itself. Make sure we schedule one so that we start
@@ -100,6 +100,13 @@ func TestRecvOnlyRevertDeletes(t *testing.T) {
 	if size.Files != 1 || size.Directories != 1 {
 		t.Fatalf("Local: expected 1 files and 1 directories: %+v", size)
 	}
+
+	// The revert is part of the folder statistics.
+	stats, err := m.FolderStatistics()
+	must(t, err)
+	if stats["ro"].Reverts != 1 {
+		t.Errorf("expected one revert in the statistics, got %d", stats["ro"].Reverts)
+	}
 }
 
 func TestRecvOnlyRevertNeeds(t *testing.T) {
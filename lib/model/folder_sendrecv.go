@@ -16,6 +16,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/syncthing/syncthing/lib/build"
@@ -24,6 +25,7 @@ import (
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/fs"
 	"github.com/syncthing/syncthing/lib/ignore"
+	"github.com/syncthing/syncthing/lib/messages"
 	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/scanner"
@@ -47,7 +49,8 @@ func init() {
 // to be fetched.
 type pullBlockState struct {
 	*sharedPullerState
-	block protocol.BlockInfo
+	block    protocol.BlockInfo
+	priority requestPriority
 }
 
 // A copyBlocksState is passed to copy routine if the file has blocks to be
@@ -113,6 +116,10 @@ const (
 	defaultPullerPendingKiB = 2 * protocol.MaxBlockSize / 1024
 
 	maxPullerIterations = 3
+
+	// Outdated temporary files in the staging directories are removed at
+	// most this often.
+	stagingCleanupInterval = time.Hour
 )
 
 type dbUpdateJob struct {
@@ -127,7 +134,38 @@ type sendReceiveFolder struct {
 	blockPullReorderer blockPullReorderer
 	writeLimiter       *semaphore.Semaphore
 
-	tempPullErrors map[string]string // pull errors that might be just transient
+	// Temporary files are pulled into the staging filesystem, which is the
+	// folder filesystem unless a staging path is configured.
+	stagingFs       fs.Filesystem
+	stagingTargets  []fs.Filesystem // the configured staging directories
+	staged          bool
+	stagingPrepared bool
+	stagingCleaned  time.Time
+
+	// In transactional folders, files changed in the same transaction are
+	// put in place together.
+	transactions *pullTransactions
+
+	// Reads files matching the warm cache patterns after they are pulled.
+	// Nil if there are no such patterns.
+	cacheWarmer *cacheWarmer
+
+	// Devices that recently failed to deliver a block, which aren't asked
+	// for blocks until their blacklisting expires.
+	blacklist *deviceBlacklist
+
+	// Files other devices asked us to pull first, e.g. as they were just
+	// restored there.
+	pullHints *pullHints
+
+	// The blocks available in temporary files, persisted for announcing
+	// them to other devices after a restart. Nil if temporary indexes are
+	// disabled.
+	tempIndex *tempIndex
+
+	preallocationReported atomic.Bool
+
+	tempPullErrors map[string]FileError // pull errors that might be just transient
 }
 
 func newSendReceiveFolder(model *model, fset *db.FileSet, ignores *ignore.Matcher, cfg config.FolderConfiguration, ver versioner.Versioner, evLogger events.Logger, ioLimiter *semaphore.Semaphore) service {
@@ -136,9 +174,25 @@ func newSendReceiveFolder(model *model, fset *db.FileSet, ignores *ignore.Matche
 		queue:              newJobQueue(),
 		blockPullReorderer: newBlockPullReorderer(cfg.BlockPullOrder, model.id, cfg.DeviceIDs()),
 		writeLimiter:       semaphore.New(cfg.MaxConcurrentWrites),
+		transactions:       newPullTransactions(),
+		blacklist:          newDeviceBlacklist(),
+		pullHints:          newPullHints(),
 	}
 	f.folder.puller = f
 
+	f.stagingTargets = cfg.StagingFilesystems()
+	f.staged = len(f.stagingTargets) > 0
+	if f.staged {
+		f.stagingFs = mostFreeFilesystem(f.stagingTargets)
+	} else {
+		f.stagingFs = f.mtimefs
+	}
+
+	if f.Type != config.FolderTypeReceiveEncrypted && !f.DisableTempIndexes {
+		f.tempIndex = newTempIndex(model.db, f.ID)
+		f.restoreTempIndex()
+	}
+
 	if f.Copiers == 0 {
 		f.Copiers = defaultCopiers
 	}
@@ -179,6 +233,10 @@ func (f *sendReceiveFolder) pull() (bool, error) {
 	f.pullErrors = nil
 	f.errorsMut.Unlock()
 
+	if err := f.prepareStaging(); err != nil {
+		return false, err
+	}
+
 	var err error
 	for tries := 0; tries < maxPullerIterations; tries++ {
 		select {
@@ -206,16 +264,20 @@ func (f *sendReceiveFolder) pull() (bool, error) {
 		}
 	}
 
+	if changed == 0 && f.tempIndex != nil {
+		// Nothing is needed anymore, thus there are no temporary files
+		// worth announcing.
+		f.tempIndex.clear()
+		f.model.progressEmitter.ForgetRestored(f.folderID)
+	}
+
 	f.errorsMut.Lock()
 	pullErrNum := len(f.tempPullErrors)
 	if pullErrNum > 0 {
 		f.pullErrors = make([]FileError, 0, len(f.tempPullErrors))
-		for path, err := range f.tempPullErrors {
-			l.Infof("Puller (folder %s, item %q): %v", f.Description(), path, err)
-			f.pullErrors = append(f.pullErrors, FileError{
-				Err:  err,
-				Path: path,
-			})
+		for path, fe := range f.tempPullErrors {
+			l.Infof("Puller (folder %s, item %q): %v", f.Description(), path, fe.Err)
+			f.pullErrors = append(f.pullErrors, fe)
 		}
 		f.tempPullErrors = nil
 	}
@@ -238,8 +300,9 @@ func (f *sendReceiveFolder) pull() (bool, error) {
 // flagged as needed in the folder.
 func (f *sendReceiveFolder) pullerIteration(scanChan chan<- string) (int, error) {
 	f.errorsMut.Lock()
-	f.tempPullErrors = make(map[string]string)
+	f.tempPullErrors = make(map[string]FileError)
 	f.errorsMut.Unlock()
+	f.transactions = newPullTransactions()
 
 	snap, err := f.dbSnapshot()
 	if err != nil {
@@ -282,6 +345,14 @@ func (f *sendReceiveFolder) pullerIteration(scanChan chan<- string) (int, error)
 		pullWg.Done()
 	}()
 
+	f.cacheWarmer = nil
+	if len(f.WarmCachePatterns) > 0 {
+		f.cacheWarmer = newCacheWarmer(f.folderID, f.mtimefs, f.ioLimiter, f.evLogger)
+		// The cache warmer finishes when closed below, and is not waited
+		// for, as it doesn't affect the outcome of the pull.
+		go f.cacheWarmer.run(f.ctx)
+	}
+
 	doneWg.Add(1)
 	// finisherRoutine finishes when finisherChan is closed
 	go func() {
@@ -302,6 +373,9 @@ func (f *sendReceiveFolder) pullerIteration(scanChan chan<- string) (int, error)
 	// for it to finish.
 	close(finisherChan)
 	doneWg.Wait()
+	if f.cacheWarmer != nil {
+		f.cacheWarmer.close()
+	}
 
 	if err == nil {
 		f.processDeletions(fileDeletions, dirDeletions, snap, dbUpdateChan, scanChan)
@@ -338,6 +412,12 @@ func (f *sendReceiveFolder) processNeeded(snap *db.Snapshot, dbUpdateChan chan<-
 			return true
 		}
 
+		// Changes stay in the index, to be applied once released.
+		if f.isHeld(intf) {
+			l.Debugln(f, "skipping held item", intf.FileName())
+			return true
+		}
+
 		changed++
 
 		file := intf.(protocol.FileInfo)
@@ -396,6 +476,9 @@ func (f *sendReceiveFolder) processNeeded(snap *db.Snapshot, dbUpdateChan chan<-
 			} else {
 				// Queue files for processing after directories and symlinks.
 				f.queue.Push(file.Name, file.Size, file.ModTime())
+				if f.Transactional {
+					f.transactions.add(file)
+				}
 			}
 
 		case build.IsWindows && file.IsSymlink():
@@ -450,6 +533,13 @@ func (f *sendReceiveFolder) processNeeded(snap *db.Snapshot, dbUpdateChan chan<-
 		f.queue.SortNewestFirst()
 	}
 
+	// Hinted files go first, regardless of the order.
+	for _, file := range f.pullHints.files() {
+		if f.queue.BringToFront(file) {
+			f.pullHints.done(file)
+		}
+	}
+
 	// Process the file queue.
 
 nextFile:
@@ -469,6 +559,7 @@ nextFile:
 		if !ok {
 			// File is no longer in the index. Mark it as done and drop it.
 			f.queue.Done(fileName)
+			f.transactions.resolve(fileName)
 			continue
 		}
 
@@ -476,6 +567,7 @@ nextFile:
 			// The item has changed type or status in the index while we
 			// were processing directories above.
 			f.queue.Done(fileName)
+			f.transactions.resolve(fileName)
 			continue
 		}
 
@@ -502,6 +594,7 @@ nextFile:
 			delete(fileDeletions, candidate.Name)
 
 			f.queue.Done(fileName)
+			f.transactions.resolve(fileName)
 			continue nextFile
 		}
 
@@ -1096,7 +1189,7 @@ func (f *sendReceiveFolder) handleFile(file protocol.FileInfo, snap *db.Snapshot
 
 	have, _ := blockDiff(curFile.Blocks, file.Blocks)
 
-	tempName := fs.TempName(file.Name)
+	tempName := f.stagingTempName(file.Name)
 
 	populateOffsets(file.Blocks)
 
@@ -1113,7 +1206,7 @@ func (f *sendReceiveFolder) handleFile(file protocol.FileInfo, snap *db.Snapshot
 		// Otherwise, discard the file ourselves in order for the
 		// sharedpuller not to panic when it fails to exclusively create a
 		// file which already exists
-		f.inWritableDir(f.mtimefs.Remove, tempName)
+		inWritableDir(f.stagingFs.Remove, f.stagingFs, tempName, f.IgnorePerms)
 	}
 
 	// Reorder blocks
@@ -1126,7 +1219,7 @@ func (f *sendReceiveFolder) handleFile(file protocol.FileInfo, snap *db.Snapshot
 		"action": "update",
 	})
 
-	s := newSharedPullerState(file, f.mtimefs, f.folderID, tempName, blocks, reused, f.IgnorePerms || file.NoPermissions, hasCurFile, curFile, !f.DisableSparseFiles, !f.DisableFsync)
+	s := newSharedPullerState(file, f.stagingFs, f.folderID, tempName, blocks, reused, f.IgnorePerms || file.NoPermissions, hasCurFile, curFile, f.PreallocationMode(), !f.DisableFsync)
 
 	l.Debugf("%v need file %s; copy %d, reused %v", f, file.Name, len(blocks), len(reused))
 
@@ -1141,12 +1234,12 @@ func (f *sendReceiveFolder) handleFile(file protocol.FileInfo, snap *db.Snapshot
 func (f *sendReceiveFolder) reuseBlocks(blocks []protocol.BlockInfo, reused []int, file protocol.FileInfo, tempName string) ([]protocol.BlockInfo, []int) {
 	// Check for an old temporary file which might have some blocks we could
 	// reuse.
-	tempBlocks, err := scanner.HashFile(f.ctx, f.ID, f.mtimefs, tempName, file.BlockSize(), nil, false)
+	tempBlocks, err := scanner.HashFile(f.ctx, f.ID, f.stagingFs, tempName, file.BlockSize(), nil, false)
 	if err != nil {
 		var caseErr *fs.ErrCaseConflict
 		if errors.As(err, &caseErr) {
-			if rerr := f.mtimefs.Rename(caseErr.Real, tempName); rerr == nil {
-				tempBlocks, err = scanner.HashFile(f.ctx, f.ID, f.mtimefs, tempName, file.BlockSize(), nil, false)
+			if rerr := f.stagingFs.Rename(caseErr.Real, tempName); rerr == nil {
+				tempBlocks, err = scanner.HashFile(f.ctx, f.ID, f.stagingFs, tempName, file.BlockSize(), nil, false)
 			}
 		}
 	}
@@ -1290,7 +1383,7 @@ func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan ch
 	// Hope that it's usually in the same folder, so start with that one.
 	folders := []string{f.folderID}
 	for folder, cfg := range f.model.cfg.Folders() {
-		folderFilesystems[folder] = cfg.Filesystem(nil)
+		folderFilesystems[folder] = f.model.folderFilesystem(cfg, nil)
 		if folder != f.folderID {
 			folders = append(folders, folder)
 		}
@@ -1311,6 +1404,10 @@ func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan ch
 			continue
 		}
 
+		if err := state.preallocationFailed(); err != nil {
+			f.preallocationFailed(state.file.Name, err)
+		}
+
 		if f.Type != config.FolderTypeReceiveEncrypted {
 			f.model.progressEmitter.Register(state.sharedPullerState)
 		}
@@ -1326,7 +1423,7 @@ func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan ch
 			default:
 			}
 
-			if !f.DisableSparseFiles && state.reused == 0 && block.IsEmpty() {
+			if f.skipsEmptyBlocks() && state.reused == 0 && block.IsEmpty() {
 				// The block is a block of all zeroes, and we are not reusing
 				// a temp file, so there is no need to do anything with it.
 				// If we were reusing a temp file and had this block to copy,
@@ -1420,6 +1517,10 @@ func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan ch
 				ps := pullBlockState{
 					sharedPullerState: state.sharedPullerState,
 					block:             block,
+					priority:          requestPriorityBackground,
+				}
+				if f.queue.Interactive(state.file.Name) {
+					ps.priority = requestPriorityInteractive
 				}
 				pullChan <- ps
 			} else {
@@ -1436,6 +1537,30 @@ func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan ch
 	}
 }
 
+// skipsEmptyBlocks returns whether blocks of all zeroes are left out when
+// writing temporary files, which relies on the files being extended to
+// their full size when created.
+func (f *sendReceiveFolder) skipsEmptyBlocks() bool {
+	return !f.DisableSparseFiles && f.PreallocationMode() != config.PreallocationNone
+}
+
+// preallocationFailed reports that space for a temporary file could not be
+// allocated as configured. This is common on some network filesystems and
+// not a problem for pulling, so it is reported once per folder run.
+func (f *sendReceiveFolder) preallocationFailed(name string, err error) {
+	if !f.preallocationReported.CompareAndSwap(false, true) {
+		l.Debugf("%v: preallocating %v: %v", f, name, err)
+		return
+	}
+	l.Infof("%v: Could not preallocate temporary file for %q, continuing without (%v)", f.Description(), name, err)
+	f.evLogger.Log(events.PreallocationFailed, map[string]interface{}{
+		"folder":        f.folderID,
+		"item":          name,
+		"preallocation": f.PreallocationMode().String(),
+		"error":         err.Error(),
+	})
+}
+
 func (f *sendReceiveFolder) initWeakHashFinder(state copyBlocksState) (*weakhash.Finder, fs.File) {
 	if f.Type == config.FolderTypeReceiveEncrypted {
 		l.Debugln("not weak hashing due to folder type", f.Type)
@@ -1516,11 +1641,22 @@ func (f *sendReceiveFolder) pullerRoutine(snap *db.Snapshot, in <-chan pullBlock
 			continue
 		}
 
+		// The requestScheduler then makes sure we get our fair share of
+		// the bandwidth compared to other folders.
+
+		if err := f.model.requestScheduler.take(f.ctx, f.ID, f.BandwidthWeight, bytes, state.priority); err != nil {
+			requestLimiter.Give(bytes)
+			state.fail(err)
+			out <- state.sharedPullerState
+			continue
+		}
+
 		wg.Add(1)
 
 		go func() {
 			defer wg.Done()
 			defer requestLimiter.Give(bytes)
+			defer f.model.requestScheduler.give(bytes)
 
 			f.pullBlock(state, snap, out)
 		}()
@@ -1538,7 +1674,7 @@ func (f *sendReceiveFolder) pullBlock(state pullBlockState, snap *db.Snapshot, o
 		return
 	}
 
-	if !f.DisableSparseFiles && state.reused == 0 && state.block.IsEmpty() {
+	if f.skipsEmptyBlocks() && state.reused == 0 && state.block.IsEmpty() {
 		// There is no need to request a block of all zeroes. Pretend we
 		// requested it and handled it correctly.
 		state.pullDone(state.block)
@@ -1547,7 +1683,8 @@ func (f *sendReceiveFolder) pullBlock(state pullBlockState, snap *db.Snapshot, o
 	}
 
 	var lastError error
-	candidates := f.model.availabilityInSnapshot(f.FolderConfiguration, snap, state.file, state.block)
+	candidates := f.pullCandidates(snap, state)
+	retries := 0
 loop:
 	for {
 		select {
@@ -1557,11 +1694,30 @@ loop:
 		default:
 		}
 
-		// Select the least busy device to pull the block from. If we found no
-		// feasible device at all, fail the block (and in the long run, the
-		// file).
-		found := activity.leastBusy(candidates)
+		// Select the least busy device relative to its speed to pull the
+		// block from, preferring active standbys. If all devices are at
+		// their request limit, wait for one of them to finish a request.
+		// If we found no feasible device at all, retry if so configured, or
+		// fail the block (and in the long run, the file).
+		found, freed := activity.leastBusyLimited(candidates, f.model.standby.isActiveSource, f.pullRequestLimit)
+		if freed != nil {
+			select {
+			case <-freed:
+			case <-f.ctx.Done():
+			}
+			continue
+		}
 		if found == -1 {
+			if retries < f.BlockPullRetries {
+				retries++
+				l.Debugf("%v retrying block %s/%d (%d/%d) after %v", f, state.file.Name, state.block.Offset, retries, f.BlockPullRetries, lastError)
+				select {
+				case <-time.After(time.Duration(retries) * time.Second):
+				case <-f.ctx.Done():
+				}
+				candidates = f.pullCandidates(snap, state)
+				continue
+			}
 			if lastError != nil {
 				state.fail(fmt.Errorf("pull: %w", lastError))
 			} else {
@@ -1579,12 +1735,16 @@ loop:
 		activity.using(selected)
 		var buf []byte
 		blockNo := int(state.block.Offset / int64(state.file.BlockSize()))
-		buf, lastError = f.model.requestGlobal(f.ctx, selected.ID, f.folderID, state.file.Name, blockNo, state.block.Offset, int(state.block.Size), state.block.Hash, state.block.WeakHash, selected.FromTemporary)
+		start := time.Now()
+		buf, lastError = f.requestBlock(selected, state, blockNo)
 		activity.done(selected)
 		if lastError != nil {
 			l.Debugln("request:", f.folderID, state.file.Name, state.block.Offset, state.block.Size, selected.ID.Short(), "returned error:", lastError)
+			f.blacklistDevice(selected.ID)
 			continue
 		}
+		activity.measured(selected.ID, len(buf), time.Since(start))
+		f.model.transferStats.Received(f.folderID, len(buf))
 
 		// Verify that the received block matches the desired hash, if not
 		// try pulling it from another device.
@@ -1597,6 +1757,7 @@ loop:
 		}
 		if lastError != nil {
 			l.Debugln("request:", f.folderID, state.file.Name, state.block.Offset, state.block.Size, "hash mismatch")
+			f.blacklistDevice(selected.ID)
 			continue
 		}
 
@@ -1612,6 +1773,53 @@ loop:
 	out <- state.sharedPullerState
 }
 
+// pullRequestLimit returns the maximum number of outstanding block requests
+// to the device, zero for no limit.
+func (f *sendReceiveFolder) pullRequestLimit(id protocol.DeviceID) int {
+	if dev, ok := f.model.cfg.Device(id); ok {
+		return dev.MaxPullRequests
+	}
+	return 0
+}
+
+// pullCandidates returns the devices to pull the block from, leaving out
+// blacklisted ones.
+func (f *sendReceiveFolder) pullCandidates(snap *db.Snapshot, state pullBlockState) []Availability {
+	candidates := f.model.availabilityInSnapshot(f.FolderConfiguration, snap, state.file, state.block)
+	if f.BlockBlacklistS <= 0 {
+		return candidates
+	}
+	filtered := candidates[:0]
+	for _, candidate := range candidates {
+		if !f.blacklist.contains(candidate.ID) {
+			filtered = append(filtered, candidate)
+		}
+	}
+	return filtered
+}
+
+// requestBlock requests the block from the device, giving up after the
+// configured block request timeout.
+func (f *sendReceiveFolder) requestBlock(selected Availability, state pullBlockState, blockNo int) ([]byte, error) {
+	ctx := f.ctx
+	if f.BlockRequestTimeoutS > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(f.BlockRequestTimeoutS)*time.Second)
+		defer cancel()
+	}
+	buf, err := f.model.requestGlobal(ctx, selected.ID, f.folderID, state.file.Name, blockNo, state.block.Offset, int(state.block.Size), state.block.Hash, state.block.WeakHash, selected.FromTemporary)
+	if err != nil && f.ctx.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("request timed out after %ds", f.BlockRequestTimeoutS)
+	}
+	return buf, err
+}
+
+func (f *sendReceiveFolder) blacklistDevice(id protocol.DeviceID) {
+	if f.BlockBlacklistS > 0 && f.ctx.Err() == nil {
+		f.blacklist.add(id, time.Duration(f.BlockBlacklistS)*time.Second)
+	}
+}
+
 func (f *sendReceiveFolder) performFinish(file, curFile protocol.FileInfo, hasCurFile bool, tempName string, snap *db.Snapshot, dbUpdateChan chan<- dbUpdateJob, scanChan chan<- string) error {
 	// Set the correct permission bits on the new file
 	if !f.IgnorePerms && !file.NoPermissions {
@@ -1666,6 +1874,137 @@ func (f *sendReceiveFolder) performFinish(file, curFile protocol.FileInfo, hasCu
 	return nil
 }
 
+// unstage moves a temporary file from the staging directory next to the file
+// it is going to replace, so that putting it in place is an atomic rename.
+// When the staging directory is on another device the file is copied.
+func (f *sendReceiveFolder) unstage(tempName, name string) (string, error) {
+	if !f.staged {
+		return tempName, nil
+	}
+	localName := fs.TempName(name)
+	if err := osutil.RenameOrCopy(f.CopyRangeMethod, f.stagingFs, f.mtimefs, tempName, localName); err != nil {
+		return "", fmt.Errorf("moving from staging directory: %w", err)
+	}
+	return localName, nil
+}
+
+func (f *sendReceiveFolder) stagingTempName(name string) string {
+	if f.staged {
+		return fs.StagingTempName(name)
+	}
+	return fs.TempName(name)
+}
+
+// selectStagingTarget switches to another of the configured staging
+// directories when the one in use is short of space and another isn't.
+// Temporary files in the previous one are reused on a later switch back,
+// unless they are removed by prepareStaging as outdated before.
+func (f *sendReceiveFolder) selectStagingTarget() {
+	if len(f.stagingTargets) < 2 {
+		return
+	}
+	if usage, err := f.stagingFs.Usage("."); err == nil && config.CheckFreeSpace(f.MinDiskFree, usage) == nil {
+		return
+	}
+	best := mostFreeFilesystem(f.stagingTargets)
+	if best == f.stagingFs {
+		return
+	}
+	usage, err := best.Usage(".")
+	if err != nil || config.CheckFreeSpace(f.MinDiskFree, usage) != nil {
+		return
+	}
+	l.Infof("%v: staging directory %s is low on space, switching to %s", f, f.stagingFs.URI(), best.URI())
+	f.stagingFs = best
+	f.stagingPrepared = false
+}
+
+// mostFreeFilesystem returns the filesystem with the most free space, or
+// the first one if that can't be determined for any.
+func mostFreeFilesystem(fss []fs.Filesystem) fs.Filesystem {
+	best := fss[0]
+	var bestFree uint64
+	for _, ffs := range fss {
+		if usage, err := ffs.Usage("."); err == nil && usage.Free > bestFree {
+			best, bestFree = ffs, usage.Free
+		}
+	}
+	return best
+}
+
+// prepareStaging creates the staging directory on the first pull after the
+// folder starts, or after switching to another staging directory. Once per
+// stagingCleanupInterval it removes temporary files older than the
+// configured lifetime for temporary files from all staging directories, as
+// the scanner does for those in the folder.
+func (f *sendReceiveFolder) prepareStaging() error {
+	if !f.staged {
+		return nil
+	}
+	f.selectStagingTarget()
+	if !f.stagingPrepared {
+		if err := f.stagingFs.MkdirAll(".", 0o700); err != nil {
+			return fmt.Errorf("creating staging directory: %w", err)
+		}
+		f.stagingPrepared = true
+	}
+	if time.Since(f.stagingCleaned) < stagingCleanupInterval {
+		return nil
+	}
+	if err := f.removeOutdatedStagingFiles(f.stagingFs); err != nil {
+		return err
+	}
+	for _, target := range f.stagingTargets {
+		if target == f.stagingFs {
+			continue
+		}
+		if err := f.removeOutdatedStagingFiles(target); err != nil && !fs.IsNotExist(err) {
+			l.Infof("%v: %v", f, err)
+		}
+	}
+	f.stagingCleaned = time.Now()
+	return nil
+}
+
+func (f *sendReceiveFolder) removeOutdatedStagingFiles(stagingFs fs.Filesystem) error {
+	names, err := stagingFs.DirNames(".")
+	if err != nil {
+		return fmt.Errorf("reading staging directory: %w", err)
+	}
+	lifetime := time.Duration(f.model.cfg.Options().KeepTemporariesH) * time.Hour
+	now := time.Now()
+	for _, name := range names {
+		if !fs.IsTemporary(name) {
+			continue
+		}
+		info, err := stagingFs.Lstat(name)
+		if err != nil || !info.IsRegular() || !info.ModTime().Add(lifetime).Before(now) {
+			continue
+		}
+		if err := stagingFs.Remove(name); err != nil {
+			l.Infof("%v: removing orphaned staging file: %v", f, err)
+			continue
+		}
+		l.Debugln(f, "removed orphaned staging file", name, "in", stagingFs.URI())
+	}
+	return nil
+}
+
+// restoreTempIndex announces the blocks available in temporary files left
+// behind by pulls before the folder was last stopped, until pulling the
+// files resumes.
+func (f *sendReceiveFolder) restoreTempIndex() {
+	snap, err := f.dbSnapshot()
+	if err != nil {
+		l.Debugln(f, "restoring temporary index:", err)
+		return
+	}
+	defer snap.Release()
+	states := f.tempIndex.restore(snap, f.stagingFs, f.stagingTempName, f.folderID)
+	l.Debugln(f, "restored", len(states), "temporary index entries")
+	f.model.progressEmitter.Restore(f.folderID, states)
+}
+
 func (f *sendReceiveFolder) finisherRoutine(snap *db.Snapshot, in <-chan *sharedPullerState, dbUpdateChan chan<- dbUpdateJob, scanChan chan<- string) {
 	for state := range in {
 		if closed, err := state.finalClose(); closed {
@@ -1673,39 +2012,82 @@ func (f *sendReceiveFolder) finisherRoutine(snap *db.Snapshot, in <-chan *shared
 
 			f.queue.Done(state.file.Name)
 
-			if err == nil {
-				err = f.performFinish(state.file, state.curFile, state.hasCurFile, state.tempName, snap, dbUpdateChan, scanChan)
+			if err == nil && f.transactions.hold(state) {
+				l.Debugln(f, "holding", state.file.Name, "until its transaction is complete")
+				continue
 			}
+			f.finishFile(state, err, snap, dbUpdateChan, scanChan)
+		}
+	}
 
-			if err != nil {
-				f.newPullError(state.file.Name, fmt.Errorf("finishing: %w", err))
-			} else {
-				minBlocksPerBlock := state.file.BlockSize() / protocol.MinBlockSize
-				blockStatsMut.Lock()
-				blockStats["total"] += (state.reused + state.copyTotal + state.pullTotal) * minBlocksPerBlock
-				blockStats["reused"] += state.reused * minBlocksPerBlock
-				blockStats["pulled"] += state.pullTotal * minBlocksPerBlock
-				// copyOriginShifted is counted towards copyOrigin due to progress bar reasons
-				// for reporting reasons we want to separate these.
-				blockStats["copyOrigin"] += (state.copyOrigin - state.copyOriginShifted) * minBlocksPerBlock
-				blockStats["copyOriginShifted"] += state.copyOriginShifted * minBlocksPerBlock
-				blockStats["copyElsewhere"] += (state.copyTotal - state.copyOrigin) * minBlocksPerBlock
-				blockStatsMut.Unlock()
-			}
+	// Put the files of complete transactions in place. Those of incomplete
+	// transactions are left as temporary files, to be reused on the next
+	// attempt.
+	f.transactions.take(func(states []*sharedPullerState, complete bool) {
+		var err error
+		if !complete {
+			err = errTransactionIncomplete
+		}
+		for _, state := range states {
+			f.finishFile(state, err, snap, dbUpdateChan, scanChan)
+		}
+	})
+}
 
-			if f.Type != config.FolderTypeReceiveEncrypted {
-				f.model.progressEmitter.Deregister(state)
-			}
+func (f *sendReceiveFolder) finishFile(state *sharedPullerState, err error, snap *db.Snapshot, dbUpdateChan chan<- dbUpdateJob, scanChan chan<- string) {
+	tempName := state.tempName
+	if err == nil {
+		tempName, err = f.unstage(tempName, state.file.Name)
+	}
+	if err == nil {
+		err = f.performFinish(state.file, state.curFile, state.hasCurFile, tempName, snap, dbUpdateChan, scanChan)
+	}
 
-			f.evLogger.Log(events.ItemFinished, map[string]interface{}{
-				"folder": f.folderID,
-				"item":   state.file.Name,
-				"error":  events.Error(err),
-				"type":   "file",
-				"action": "update",
-			})
+	if err == nil && f.Deduplicate {
+		// The file is in place regardless, just not deduplicated
+		if derr := fs.Deduplicate(f.mtimefs, state.file.Name, state.file.Blocks); derr != nil {
+			l.Infof("Deduplicating %v in folder %v: %v", state.file.Name, f.Description(), derr)
 		}
 	}
+
+	if err != nil {
+		f.newPullError(state.file.Name, fmt.Errorf("finishing: %w", err))
+	} else {
+		minBlocksPerBlock := state.file.BlockSize() / protocol.MinBlockSize
+		blockStatsMut.Lock()
+		blockStats["total"] += (state.reused + state.copyTotal + state.pullTotal) * minBlocksPerBlock
+		blockStats["reused"] += state.reused * minBlocksPerBlock
+		blockStats["pulled"] += state.pullTotal * minBlocksPerBlock
+		// copyOriginShifted is counted towards copyOrigin due to progress bar reasons
+		// for reporting reasons we want to separate these.
+		blockStats["copyOrigin"] += (state.copyOrigin - state.copyOriginShifted) * minBlocksPerBlock
+		blockStats["copyOriginShifted"] += state.copyOriginShifted * minBlocksPerBlock
+		blockStats["copyElsewhere"] += (state.copyTotal - state.copyOrigin) * minBlocksPerBlock
+		blockStatsMut.Unlock()
+	}
+
+	if f.Type != config.FolderTypeReceiveEncrypted {
+		f.model.progressEmitter.Deregister(state)
+	}
+	if f.tempIndex != nil {
+		if err == nil {
+			f.tempIndex.remove(state.file.Name)
+		} else {
+			f.tempIndex.set(state)
+		}
+	}
+
+	f.evLogger.Log(events.ItemFinished, map[string]interface{}{
+		"folder": f.folderID,
+		"item":   state.file.Name,
+		"error":  events.Error(err),
+		"type":   "file",
+		"action": "update",
+	})
+
+	if err == nil && f.cacheWarmer != nil && f.WarmsCache(state.file.Name) {
+		f.cacheWarmer.add(state.file.Name)
+	}
 }
 
 // Moves the given filename to the front of the job queue
@@ -1713,6 +2095,13 @@ func (f *sendReceiveFolder) BringToFront(filename string) {
 	f.queue.BringToFront(filename)
 }
 
+// PullHint brings the files to the front of the queue, now if they are
+// already queued, or else once they are.
+func (f *sendReceiveFolder) PullHint(files []string) {
+	f.pullHints.add(files)
+	f.SchedulePull()
+}
+
 func (f *sendReceiveFolder) Jobs(page, perpage int) ([]string, []string, int) {
 	return f.queue.Jobs(page, perpage)
 }
@@ -1861,6 +2250,7 @@ func (f *sendReceiveFolder) moveForConflict(name, lastModBy string, scanChan cha
 
 	newName := conflictName(name, lastModBy)
 	err := f.mtimefs.Rename(name, newName)
+	created := err == nil
 	if fs.IsNotExist(err) {
 		// We were supposed to move a file away but it does not exist. Either
 		// the user has already moved it away, or the conflict was between a
@@ -1882,6 +2272,9 @@ func (f *sendReceiveFolder) moveForConflict(name, lastModBy string, scanChan cha
 	if err == nil {
 		scanChan <- newName
 	}
+	if created {
+		f.conflictCreated(newName)
+	}
 	return err
 }
 
@@ -1904,8 +2297,12 @@ func (f *sendReceiveFolder) newPullError(path string, err error) {
 	// Establish context to differentiate from errors while scanning.
 	// Use "syncing" as opposed to "pulling" as the latter might be used
 	// for errors occurring specifically in the puller routine.
-	errStr := fmt.Sprintf("syncing: %s", err)
-	f.tempPullErrors[path] = errStr
+	f.tempPullErrors[path] = FileError{
+		Path:   path,
+		Err:    fmt.Sprintf("syncing: %s", err),
+		Reason: errorReason(err),
+		Code:   errorCode(err),
+	}
 
 	l.Debugf("%v new error for %v: %v", f, path, err)
 }
@@ -2190,8 +2587,9 @@ func (f *sendReceiveFolder) withLimiter(fn func() error) error {
 	return fn()
 }
 
-// updateFileInfoChangeTime updates the inode change time in the FileInfo,
-// because that depends on the current, new, state of the file on disk.
+// updateFileInfoChangeTime updates the inode change time and identity in the
+// FileInfo, because that depends on the current, new, state of the file on
+// disk.
 func (f *sendReceiveFolder) updateFileInfoChangeTime(file *protocol.FileInfo) error {
 	info, err := f.mtimefs.Lstat(file.Name)
 	if err != nil {
@@ -2203,13 +2601,22 @@ func (f *sendReceiveFolder) updateFileInfoChangeTime(file *protocol.FileInfo) er
 	} else {
 		file.InodeChangeNs = 0
 	}
+	file.Inode, file.InodeGeneration = 0, 0
+	if info.IsRegular() {
+		file.Inode = info.Inode()
+		if file.Inode != 0 {
+			file.InodeGeneration, _ = fs.InodeGeneration(f.mtimefs, file.Name)
+		}
+	}
 	return nil
 }
 
 // A []FileError is sent as part of an event and will be JSON serialized.
 type FileError struct {
-	Path string `json:"path"`
-	Err  string `json:"error"`
+	Path   string        `json:"path"`
+	Err    string        `json:"error"`
+	Reason string        `json:"reason"` // one of the Quarantine* reasons
+	Code   messages.Code `json:"code"`
 }
 
 type fileErrorList []FileError
@@ -118,7 +118,7 @@ func setupSendReceiveFolder(t testing.TB, files ...protocol.FileInfo) (*testMode
 	model.cancel()
 	<-model.stopped
 	f := model.folderRunners[fcfg.ID].(*sendReceiveFolder)
-	f.tempPullErrors = make(map[string]string)
+	f.tempPullErrors = make(map[string]FileError)
 	f.ctx = context.Background()
 
 	// Update index
@@ -816,6 +816,7 @@ func TestCopyOwner(t *testing.T) {
 
 	f.fset = newFileSet(t, f.ID, m.db)
 	f.mtimefs = f.Filesystem(f.fset)
+	f.stagingFs = f.mtimefs
 
 	// Create a parent dir with a certain owner/group.
 
@@ -1030,7 +1031,7 @@ func TestPullCtxCancel(t *testing.T) {
 
 	emptyState := func() pullBlockState {
 		return pullBlockState{
-			sharedPullerState: newSharedPullerState(protocol.FileInfo{}, nil, f.folderID, "", nil, nil, false, false, protocol.FileInfo{}, false, false),
+			sharedPullerState: newSharedPullerState(protocol.FileInfo{}, nil, f.folderID, "", nil, nil, false, false, protocol.FileInfo{}, config.PreallocationNone, false),
 			block:             protocol.BlockInfo{},
 		}
 	}
@@ -1197,10 +1198,10 @@ func testPullCaseOnlyDirOrSymlink(t *testing.T, dir bool) {
 		t.Error("no need to scan anything here")
 	default:
 	}
-	if errStr, ok := f.tempPullErrors[remote.Name]; !ok {
+	if fe, ok := f.tempPullErrors[remote.Name]; !ok {
 		t.Error("missing error for", remote.Name)
-	} else if !strings.Contains(errStr, "uses different upper or lowercase") {
-		t.Error("unexpected error", errStr, "for", remote.Name)
+	} else if !strings.Contains(fe.Err, "uses different upper or lowercase") {
+		t.Error("unexpected error", fe.Err, "for", remote.Name)
 	}
 }
 
@@ -1377,6 +1378,78 @@ func TestPullDeleteIgnoreChildDir(t *testing.T) {
 	}
 }
 
+func TestPullStaging(t *testing.T) {
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer wcfgCancel()
+	ffs := f.Filesystem(nil)
+	f.stagingFs = fs.NewFilesystem(fs.FilesystemTypeBasic, filepath.Join(t.TempDir(), "staging"))
+	f.staged = true
+
+	// Preparing creates the staging directory and removes outdated temp
+	// files, keeping recent ones for reuse.
+	must(t, f.prepareStaging())
+	contents := []byte("contents")
+	old := fs.StagingTempName("old")
+	writeFile(t, f.stagingFs, old, contents)
+	past := time.Now().Add(-48 * time.Hour)
+	must(t, f.stagingFs.Chtimes(old, past, past))
+	recent := fs.StagingTempName("foo")
+	writeFile(t, f.stagingFs, recent, contents)
+	// Those in staging directories no longer in use are aged out as well.
+	other := fs.NewFilesystem(fs.FilesystemTypeBasic, filepath.Join(t.TempDir(), "other"))
+	must(t, other.MkdirAll(".", 0o700))
+	writeFile(t, other, old, contents)
+	must(t, other.Chtimes(old, past, past))
+	writeFile(t, other, recent, contents)
+	f.stagingTargets = []fs.Filesystem{f.stagingFs, other}
+
+	// Cleaning up happens only once in a while.
+	must(t, f.prepareStaging())
+	if _, err := f.stagingFs.Lstat(old); err != nil {
+		t.Error("expected the temp file to be kept until the next cleanup, got", err)
+	}
+	f.stagingCleaned = time.Now().Add(-stagingCleanupInterval)
+	must(t, f.prepareStaging())
+	for _, stagingFs := range f.stagingTargets {
+		if _, err := stagingFs.Lstat(old); !fs.IsNotExist(err) {
+			t.Errorf("expected outdated temp file in %s to be removed, got %v", stagingFs.URI(), err)
+		}
+		if _, err := stagingFs.Lstat(recent); err != nil {
+			t.Errorf("expected recent temp file in %s to be kept, got %v", stagingFs.URI(), err)
+		}
+	}
+	f.stagingTargets = nil
+
+	// The finished file is moved from the staging directory, which is on
+	// another filesystem, into the folder.
+	file := protocol.FileInfo{
+		Name:        "foo",
+		Size:        int64(len(contents)),
+		Permissions: 0o644,
+		ModifiedS:   time.Now().Unix(),
+		Version:     protocol.Vector{}.Update(device1.Short()),
+	}
+	snap := dbSnapshot(t, m, f.ID)
+	defer snap.Release()
+	tempName, err := f.unstage(recent, file.Name)
+	must(t, err)
+	dbUpdateChan := make(chan dbUpdateJob, 1)
+	must(t, f.performFinish(file, protocol.FileInfo{}, false, tempName, snap, dbUpdateChan, make(chan string, 1)))
+
+	fd, err := ffs.Open(file.Name)
+	must(t, err)
+	defer fd.Close()
+	if data, err := io.ReadAll(fd); err != nil || !bytes.Equal(data, contents) {
+		t.Errorf("unexpected contents %q, %v", data, err)
+	}
+	if _, err := f.stagingFs.Lstat(recent); !fs.IsNotExist(err) {
+		t.Error("expected staging file to be gone, got", err)
+	}
+	if _, err := ffs.Lstat(fs.TempName(file.Name)); !fs.IsNotExist(err) {
+		t.Error("expected no temp file in the folder, got", err)
+	}
+}
+
 func cleanupSharedPullerState(s *sharedPullerState) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
@@ -1398,3 +1471,46 @@ func startCopier(f *sendReceiveFolder, pullChan chan<- pullBlockState, finisherC
 	}()
 	return copyChan, wg
 }
+
+func TestTempIndexRestore(t *testing.T) {
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer wcfgCancel()
+	ffs := f.Filesystem(nil)
+
+	file := setupFile("filex", []int{1, 2, 3, 4})
+	file.Version = protocol.Vector{}.Update(device1.Short())
+	f.fset.Update(device1, []protocol.FileInfo{file})
+	writeFile(t, ffs, fs.TempName(file.Name), []byte("partial"))
+
+	state := newSharedPullerState(file, ffs, f.ID, fs.TempName(file.Name), nil, []int{0, 2}, false, false, protocol.FileInfo{}, config.PreallocationNone, false)
+	f.tempIndex.set(state)
+
+	// The entry is persisted and restored while the temp file exists and
+	// is for the global version.
+	restore := func() []*sharedPullerState {
+		t.Helper()
+		snap := dbSnapshot(t, m, f.ID)
+		defer snap.Release()
+		return newTempIndex(m.db, f.ID).restore(snap, ffs, fs.TempName, f.ID)
+	}
+	states := restore()
+	if len(states) != 1 {
+		t.Fatalf("expected one restored state, got %d", len(states))
+	}
+	if states[0].file.Name != file.Name || !states[0].file.Version.Equal(file.Version) {
+		t.Errorf("unexpected restored file %v", states[0].file)
+	}
+	if av := states[0].Available(); len(av) != 2 || av[0] != 0 || av[1] != 2 {
+		t.Errorf("unexpected available blocks %v", av)
+	}
+
+	// A new global version makes the entry outdated.
+	file.Version = file.Version.Update(device2.Short())
+	f.fset.Update(device2, []protocol.FileInfo{file})
+	if states := restore(); len(states) != 0 {
+		t.Errorf("expected no restored states, got %d", len(states))
+	}
+	if states := restore(); len(states) != 0 {
+		t.Errorf("expected the outdated entry to be dropped, got %d states", len(states))
+	}
+}
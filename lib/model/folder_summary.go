@@ -20,6 +20,7 @@ import (
 	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/db"
 	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/messages"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/svcutil"
 	"github.com/syncthing/syncthing/lib/sync"
@@ -115,9 +116,10 @@ type FolderSummary struct {
 	InSyncFiles int   `json:"inSyncFiles"`
 	InSyncBytes int64 `json:"inSyncBytes"`
 
-	State        string    `json:"state"`
-	StateChanged time.Time `json:"stateChanged"`
-	Error        string    `json:"error"`
+	State        string        `json:"state"`
+	StateChanged time.Time     `json:"stateChanged"`
+	Error        string        `json:"error"`
+	ErrorCode    messages.Code `json:"errorCode"`
 
 	Version  int64 `json:"version"` // deprecated
 	Sequence int64 `json:"sequence"`
@@ -165,6 +167,10 @@ func (c *folderSummaryService) Summary(folder string) (*FolderSummary, error) {
 	if haveFcfg && fcfg.IgnoreDelete {
 		need.Deleted = 0
 	}
+	if haveFcfg && fcfg.Type == config.FolderTypeIndexOnly {
+		// Index only folders never pull, so they need nothing.
+		need = db.Counts{}
+	}
 
 	need.Bytes -= c.model.FolderProgressBytesCompleted(folder)
 	// This may happen if we are in progress of pulling files that were
@@ -190,6 +196,7 @@ func (c *folderSummaryService) Summary(folder string) (*FolderSummary, error) {
 	res.State, res.StateChanged, err = c.model.State(folder)
 	if err != nil {
 		res.Error = err.Error()
+		res.ErrorCode = folderErrorCode(err)
 	}
 
 	res.Version = ourSeq + remoteSeq  // legacy
@@ -7,8 +7,11 @@
 package model
 
 import (
+	"os"
 	"path/filepath"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/d4l3k/messagediff"
 
@@ -191,3 +194,68 @@ func TestSetPlatformData(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+// readOnlyFS simulates a filesystem mounted read-only.
+type readOnlyFS struct {
+	fs.Filesystem
+	readOnly bool
+}
+
+func (r *readOnlyFS) Create(name string) (fs.File, error) {
+	if r.readOnly {
+		return nil, &os.PathError{Op: "open", Path: name, Err: syscall.EROFS}
+	}
+	return r.Filesystem.Create(name)
+}
+
+func TestReadOnlyFilesystemDegradesFolder(t *testing.T) {
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer cleanupModelAndRemoveDir(m, f.Filesystem(nil).URI())
+	defer wcfgCancel()
+
+	rofs := &readOnlyFS{Filesystem: f.mtimefs}
+	f.mtimefs = rofs
+	if f.checkReadOnly() {
+		t.Fatal("writable filesystem detected as read-only")
+	}
+
+	rofs.readOnly = true
+	f.pullErrors = []FileError{{Path: "file", Err: "read-only file system"}}
+	if !f.checkReadOnly() {
+		t.Fatal("read-only filesystem not detected")
+	}
+	if len(f.pullErrors) != 0 {
+		t.Error("pull errors not cleared when degrading the folder")
+	}
+
+	rofs.readOnly = false
+	if f.checkReadOnly() {
+		t.Error("folder not restored once the filesystem is writable")
+	}
+	if _, err := f.mtimefs.Lstat(fs.TempName("writecheck")); !fs.IsNotExist(err) {
+		t.Error("write check left its file behind")
+	}
+}
+
+func TestDebouncePull(t *testing.T) {
+	f := &folder{pullDebounceTimer: time.NewTimer(time.Hour)}
+
+	// A single trigger pulls shortly after.
+	start := time.Now()
+	f.debouncePull()
+	<-f.pullDebounceTimer.C
+	if d := time.Since(start); d < pullDebounceDelay {
+		t.Errorf("pulled after %v, expected at least %v", d, pullDebounceDelay)
+	}
+
+	// Continued triggers delay the pull up to a tenth of how long the
+	// last pull took.
+	f.lastPullDuration = time.Minute
+	f.pullTriggered = time.Now().Add(-6 * time.Second)
+	f.debouncePull()
+	select {
+	case <-f.pullDebounceTimer.C:
+	case <-time.After(time.Second):
+		t.Fatal("pull not started after the maximum wait")
+	}
+}
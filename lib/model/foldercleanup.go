@@ -0,0 +1,377 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/jobs"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+const (
+	folderCleanupsKey    = "folderCleanups"
+	folderCleanupJobName = "folderCleanup"
+)
+
+// Results of a folder cleanup, as reported in FolderCleanupDone events.
+const (
+	cleanupArchived  = "archived"
+	cleanupDeleted   = "deleted"
+	cleanupCancelled = "cancelled"
+	cleanupFailed    = "failed"
+)
+
+var (
+	errNoSuchCleanup = errors.New("no cleanup scheduled for folder")
+	errPathInUse     = errors.New("folder or path is in use by the config")
+)
+
+// A FolderCleanup is the data of a removed folder that is due to be
+// archived or deleted according to the folder's removal policy.
+type FolderCleanup struct {
+	Folder         string               `json:"folder"`
+	Label          string               `json:"label"`
+	FilesystemType fs.FilesystemType    `json:"filesystemType"`
+	Path           string               `json:"path"`
+	Policy         config.RemovalPolicy `json:"policy"`
+	Due            time.Time            `json:"due"`
+}
+
+// The folderCleaner schedules cleanups of the data of folders removed from
+// the config and carries them out once their grace period is over. Folders
+// added again with the same ID or path in the meantime cancel the cleanup,
+// and it never touches data in use by a configured folder. Scheduled
+// cleanups are persisted, so they survive restarts.
+type folderCleaner struct {
+	cfg       config.Wrapper
+	kv        *db.NamespacedKV
+	scheduler jobs.Scheduler
+	evLogger  events.Logger
+	timeNow   func() time.Time
+	next      time.Time // of the next cleanup, only used by the job
+
+	mut      sync.Mutex
+	cleanups map[string]FolderCleanup // folder ID -> cleanup
+}
+
+func newFolderCleaner(cfg config.Wrapper, ldb *db.Lowlevel, scheduler jobs.Scheduler, evLogger events.Logger) *folderCleaner {
+	c := &folderCleaner{
+		cfg:       cfg,
+		kv:        db.NewMiscDataNamespace(ldb),
+		scheduler: scheduler,
+		evLogger:  evLogger,
+		timeNow:   time.Now,
+		mut:       sync.NewMutex(),
+		cleanups:  make(map[string]FolderCleanup),
+	}
+	if bs, ok, err := c.kv.Bytes(folderCleanupsKey); err != nil {
+		l.Warnln("Loading scheduled folder cleanups:", err)
+	} else if ok {
+		if err := json.Unmarshal(bs, &c.cleanups); err != nil {
+			l.Warnln("Loading scheduled folder cleanups:", err)
+			c.cleanups = make(map[string]FolderCleanup)
+		}
+	}
+	return c
+}
+
+func (c *folderCleaner) Serve(ctx context.Context) error {
+	c.cfg.Subscribe(c)
+	defer c.cfg.Unsubscribe(c)
+
+	c.scheduler.Add(jobs.Job{
+		Name:     folderCleanupJobName,
+		Interval: func() time.Duration { return untilDue(c.next, c.timeNow()) },
+		Run: func(context.Context) error {
+			c.next = c.cleanDue()
+			return nil
+		},
+	})
+	defer c.scheduler.Remove(folderCleanupJobName)
+
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// cleanDue carries out all cleanups that are due and returns when the next
+// one is, or the zero time if there is none.
+func (c *folderCleaner) cleanDue() time.Time {
+	now := c.timeNow()
+	var due []FolderCleanup
+	var next time.Time
+	c.mut.Lock()
+	for id, fc := range c.cleanups {
+		if !fc.Due.After(now) {
+			due = append(due, fc)
+			delete(c.cleanups, id)
+		} else if next.IsZero() || fc.Due.Before(next) {
+			next = fc.Due
+		}
+	}
+	if len(due) > 0 {
+		c.saveLocked()
+	}
+	c.mut.Unlock()
+
+	cfg := c.cfg.RawCopy()
+	for _, fc := range due {
+		c.clean(cfg, fc)
+	}
+	return next
+}
+
+func (c *folderCleaner) clean(cfg config.Configuration, fc FolderCleanup) {
+	data := map[string]interface{}{
+		"folder": fc.Folder,
+		"label":  fc.Label,
+		"path":   fc.Path,
+		"policy": fc.Policy.String(),
+	}
+
+	result, err := cleanupFolderData(cfg, fc, c.timeNow())
+	switch {
+	case errors.Is(err, errPathInUse):
+		l.Infof("Not cleaning up data of removed folder %q at %s: %v", fc.Folder, fc.Path, err)
+		data["result"] = cleanupCancelled
+		data["error"] = err.Error()
+	case err != nil:
+		l.Warnf("Failed to %s data of removed folder %q at %s: %v", fc.Policy, fc.Folder, fc.Path, err)
+		data["result"] = cleanupFailed
+		data["error"] = err.Error()
+	case fc.Policy == config.RemovalPolicyArchive:
+		l.Infof("Archived data of removed folder %q at %s", fc.Folder, result)
+		data["result"] = cleanupArchived
+		data["archive"] = result
+	default:
+		l.Infof("Deleted data of removed folder %q at %s", fc.Folder, fc.Path)
+		data["result"] = cleanupDeleted
+	}
+	c.evLogger.Log(events.FolderCleanupDone, data)
+}
+
+// cleanupFolderData archives or deletes the data of the removed folder,
+// unless it's in use by a configured folder. It returns where the data was
+// archived to, if it was.
+func cleanupFolderData(cfg config.Configuration, fc FolderCleanup, now time.Time) (string, error) {
+	if _, _, ok := cfg.Folder(fc.Folder); ok || pathInUse(cfg, fc.Path) {
+		return "", errPathInUse
+	}
+
+	dir, name := filepath.Split(fc.Path)
+	parent := fs.NewFilesystem(fc.FilesystemType, dir)
+	if _, err := parent.Lstat(name); err != nil {
+		return "", err
+	}
+
+	switch fc.Policy {
+	case config.RemovalPolicyArchive:
+		archive := name + now.Format(".removed-20060102-150405")
+		if err := parent.Rename(name, archive); err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, archive), nil
+	case config.RemovalPolicyDelete:
+		if err := overwriteFiles(parent, name); err != nil {
+			return "", err
+		}
+		return "", parent.RemoveAll(name)
+	default:
+		return "", fmt.Errorf("unknown removal policy %v", fc.Policy)
+	}
+}
+
+// overwriteFiles overwrites the contents of all files below name with
+// zeroes before they're deleted, so the data can't be recovered from the
+// disk easily. Filesystems that don't write in place (e.g. copy-on-write or
+// flash storage) may keep copies regardless.
+func overwriteFiles(filesystem fs.Filesystem, name string) error {
+	zeroes := make([]byte, 128<<10)
+	return filesystem.Walk(name, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsRegular() {
+			return nil
+		}
+		fd, err := filesystem.OpenFile(path, fs.OptWriteOnly, 0)
+		if err != nil {
+			return err
+		}
+		defer fd.Close()
+		for left := info.Size(); left > 0; left -= int64(len(zeroes)) {
+			buf := zeroes
+			if left < int64(len(buf)) {
+				buf = buf[:left]
+			}
+			if _, err := fd.Write(buf); err != nil {
+				return err
+			}
+		}
+		if err := fd.Sync(); err != nil {
+			return err
+		}
+		return fd.Close()
+	})
+}
+
+// pathInUse returns whether any configured folder uses the path, or a path
+// inside or containing it.
+func pathInUse(cfg config.Configuration, path string) bool {
+	for _, fcfg := range cfg.Folders {
+		if pathsOverlap(fcfg.Filesystem(nil).URI(), path) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathsOverlap returns whether either path is the other or contains it.
+func pathsOverlap(a, b string) bool {
+	a, b = filepath.Clean(a), filepath.Clean(b)
+	return a == b || fs.IsParent(a, b) || fs.IsParent(b, a)
+}
+
+// CommitConfiguration schedules cleanups for the removed folders with a
+// removal policy and cancels those of folders that are added again.
+func (c *folderCleaner) CommitConfiguration(from, to config.Configuration) bool {
+	toFolders := to.FolderMap()
+	now := c.timeNow()
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	changed := false
+
+	for _, fcfg := range to.Folders {
+		path := fcfg.Filesystem(nil).URI()
+		for id, fc := range c.cleanups {
+			if id == fcfg.ID || pathsOverlap(path, fc.Path) {
+				delete(c.cleanups, id)
+				changed = true
+				l.Infof("Cancelled cleanup of data of removed folder %q at %s, as it's in use again", fc.Folder, fc.Path)
+				c.evLogger.Log(events.FolderCleanupDone, map[string]interface{}{
+					"folder": fc.Folder,
+					"label":  fc.Label,
+					"path":   fc.Path,
+					"policy": fc.Policy.String(),
+					"result": cleanupCancelled,
+				})
+			}
+		}
+	}
+
+	for _, fcfg := range from.Folders {
+		if _, ok := toFolders[fcfg.ID]; ok || fcfg.RemovalPolicy == config.RemovalPolicyKeep {
+			continue
+		}
+		fc := FolderCleanup{
+			Folder:         fcfg.ID,
+			Label:          fcfg.Label,
+			FilesystemType: fcfg.FilesystemType,
+			Path:           fcfg.Filesystem(nil).URI(),
+			Policy:         fcfg.RemovalPolicy,
+			Due:            now.Add(time.Duration(fcfg.RemovalGraceS) * time.Second).Truncate(time.Second),
+		}
+		if pathInUse(to, fc.Path) {
+			l.Infof("Not cleaning up data of removed folder %s at %s: %v", fcfg.Description(), fc.Path, errPathInUse)
+			continue
+		}
+		c.cleanups[fc.Folder] = fc
+		changed = true
+		l.Infof("Data of removed folder %s at %s is due to be %sd at %v", fcfg.Description(), fc.Path, fc.Policy, fc.Due)
+		c.evLogger.Log(events.FolderCleanupScheduled, map[string]interface{}{
+			"folder": fc.Folder,
+			"label":  fc.Label,
+			"path":   fc.Path,
+			"policy": fc.Policy.String(),
+			"due":    fc.Due,
+		})
+	}
+
+	if changed {
+		c.saveLocked()
+		c.scheduler.Trigger(folderCleanupJobName)
+	}
+	return true
+}
+
+// Cleanups returns the scheduled cleanups, the next one first.
+func (c *folderCleaner) Cleanups() []FolderCleanup {
+	c.mut.Lock()
+	cleanups := make([]FolderCleanup, 0, len(c.cleanups))
+	for _, fc := range c.cleanups {
+		cleanups = append(cleanups, fc)
+	}
+	c.mut.Unlock()
+	sort.Slice(cleanups, func(a, b int) bool {
+		return cleanups[a].Due.Before(cleanups[b].Due)
+	})
+	return cleanups
+}
+
+// Cancel cancels the scheduled cleanup of the folder's data, leaving it in
+// place.
+func (c *folderCleaner) Cancel(folder string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	fc, ok := c.cleanups[folder]
+	if !ok {
+		return errNoSuchCleanup
+	}
+	delete(c.cleanups, folder)
+	c.saveLocked()
+	l.Infof("Cancelled cleanup of data of removed folder %q at %s", fc.Folder, fc.Path)
+	c.evLogger.Log(events.FolderCleanupDone, map[string]interface{}{
+		"folder": fc.Folder,
+		"label":  fc.Label,
+		"path":   fc.Path,
+		"policy": fc.Policy.String(),
+		"result": cleanupCancelled,
+	})
+	return nil
+}
+
+func (c *folderCleaner) saveLocked() {
+	if len(c.cleanups) == 0 {
+		if err := c.kv.Delete(folderCleanupsKey); err != nil {
+			l.Warnln("Saving scheduled folder cleanups:", err)
+		}
+		return
+	}
+	bs, err := json.Marshal(c.cleanups)
+	if err == nil {
+		err = c.kv.PutBytes(folderCleanupsKey, bs)
+	}
+	if err != nil {
+		l.Warnln("Saving scheduled folder cleanups:", err)
+	}
+}
+
+func (c *folderCleaner) String() string {
+	return fmt.Sprintf("folderCleaner@%p", c)
+}
+
+// FolderCleanups returns the scheduled cleanups of removed folders' data.
+func (m *model) FolderCleanups() []FolderCleanup {
+	return m.folderCleaner.Cleanups()
+}
+
+// CancelFolderCleanup cancels the scheduled cleanup of the removed
+// folder's data.
+func (m *model) CancelFolderCleanup(folder string) error {
+	return m.folderCleaner.Cancel(folder)
+}
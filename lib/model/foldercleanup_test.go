@@ -0,0 +1,100 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/jobs"
+)
+
+func TestFolderCleanup(t *testing.T) {
+	w, _, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	ldb, err := db.NewLowlevel(backend.OpenMemory(), events.NoopLogger)
+	must(t, err)
+	defer ldb.Close()
+
+	td := t.TempDir()
+	folder := func(id string, policy config.RemovalPolicy) config.FolderConfiguration {
+		path := filepath.Join(td, id)
+		must(t, os.MkdirAll(filepath.Join(path, "sub"), 0o755))
+		must(t, os.WriteFile(filepath.Join(path, "sub", "file"), []byte("data"), 0o644))
+		return config.FolderConfiguration{
+			ID:             id,
+			FilesystemType: fs.FilesystemTypeBasic,
+			Path:           path,
+			RemovalPolicy:  policy,
+			RemovalGraceS:  3600,
+		}
+	}
+	archived := folder("archived", config.RemovalPolicyArchive)
+	deleted := folder("deleted", config.RemovalPolicyDelete)
+	kept := folder("kept", config.RemovalPolicyKeep)
+	moved := folder("moved", config.RemovalPolicyDelete)
+	readded := folder("readded", config.RemovalPolicyDelete)
+
+	now := time.Now().Truncate(time.Second)
+	c := newFolderCleaner(w, ldb, jobs.New(), events.NoopLogger)
+	c.timeNow = func() time.Time { return now }
+
+	// The moved folder is replaced by another one with the same path,
+	// which protects its data from the start.
+	replacement := moved
+	replacement.ID = "replacement"
+	from := config.Configuration{Folders: []config.FolderConfiguration{archived, deleted, kept, moved, readded}}
+	to := config.Configuration{Folders: []config.FolderConfiguration{replacement}}
+	c.CommitConfiguration(from, to)
+	if cleanups := c.Cleanups(); len(cleanups) != 3 {
+		t.Fatalf("expected three cleanups, got %v", cleanups)
+	}
+
+	// Adding a folder again cancels its cleanup.
+	c.CommitConfiguration(to, config.Configuration{Folders: []config.FolderConfiguration{replacement, readded}})
+	if cleanups := c.Cleanups(); len(cleanups) != 2 {
+		t.Fatalf("expected two cleanups, got %v", cleanups)
+	}
+
+	// Scheduled cleanups survive restarts.
+	c = newFolderCleaner(w, ldb, jobs.New(), events.NoopLogger)
+	c.timeNow = func() time.Time { return now }
+	if cleanups := c.Cleanups(); len(cleanups) != 2 {
+		t.Fatalf("expected two persisted cleanups, got %v", cleanups)
+	}
+
+	if next := c.cleanDue(); !next.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected the next cleanup in an hour, got %v", next)
+	}
+	now = now.Add(time.Hour)
+	if next := c.cleanDue(); !next.IsZero() {
+		t.Errorf("expected no further cleanups, got %v", next)
+	}
+
+	if _, err := os.Stat(archived.Path); !os.IsNotExist(err) {
+		t.Error("archived folder still in place")
+	}
+	archive := archived.Path + now.Format(".removed-20060102-150405")
+	if _, err := os.Stat(filepath.Join(archive, "sub", "file")); err != nil {
+		t.Error("archived data missing:", err)
+	}
+	if _, err := os.Stat(deleted.Path); !os.IsNotExist(err) {
+		t.Error("deleted folder still exists")
+	}
+	for _, fcfg := range []config.FolderConfiguration{kept, moved, readded} {
+		if _, err := os.Stat(filepath.Join(fcfg.Path, "sub", "file")); err != nil {
+			t.Errorf("data of folder %v missing: %v", fcfg.ID, err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// adoptFolderMetadata takes over the metadata the device announces for
+// folders we share with it, where it's newer than ours. The changed
+// metadata is then announced to our other devices in turn.
+func (m *model) adoptFolderMetadata(device protocol.DeviceID, folders []protocol.Folder) {
+	newer := make(map[string]config.FolderMetadata)
+	for _, folder := range folders {
+		fcfg, ok := m.cfg.Folder(folder.ID)
+		if !ok {
+			continue
+		}
+		if dev, ok := fcfg.Device(device); !ok || dev.EncryptionPassword != "" {
+			// Untrusted devices don't get to see or set it.
+			continue
+		}
+		if meta := config.FolderMetadataFromProtocol(folder.Metadata); meta.NewerThan(fcfg.Metadata) {
+			newer[folder.ID] = meta
+		}
+	}
+	if len(newer) == 0 {
+		return
+	}
+
+	m.cfg.Modify(func(cfg *config.Configuration) {
+		for i := range cfg.Folders {
+			meta, ok := newer[cfg.Folders[i].ID]
+			if !ok || !meta.NewerThan(cfg.Folders[i].Metadata) {
+				continue
+			}
+			l.Infof("Updating metadata of folder %s as announced by %v", cfg.Folders[i].Description(), device)
+			cfg.Folders[i].Metadata = meta
+		}
+	})
+}
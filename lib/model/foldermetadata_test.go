@@ -0,0 +1,50 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestFolderMetadataAdopted(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	fcfg.Metadata = config.FolderMetadata{Description: "Ours", Icon: "📷"}
+	setFolder(t, w, fcfg)
+	m, fc := setupModelWithConnectionFromWrapper(t, w)
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem(nil).URI())
+
+	ours, _ := w.Folder(fcfg.ID)
+	if ours.Metadata.Updated.IsZero() {
+		t.Fatal("expected the update time to be set when changing the metadata")
+	}
+	cc, _ := m.generateClusterConfig(device1)
+	if len(cc.Folders) != 1 || cc.Folders[0].Metadata != ours.Metadata.ToProtocol() {
+		t.Errorf("expected the metadata in the cluster config, got %v", cc.Folders)
+	}
+
+	// Older metadata is ignored.
+	cc = basicClusterConfig(myID, device1, fcfg.ID)
+	cc.Folders[0].Metadata = protocol.FolderMetadata{Description: "Older", Updated: ours.Metadata.Updated.Add(-time.Hour).UnixNano()}
+	must(t, m.ClusterConfig(fc, cc))
+	if got, _ := w.Folder(fcfg.ID); !got.Metadata.Equal(ours.Metadata) {
+		t.Errorf("expected our metadata to be kept, got %v", got.Metadata)
+	}
+
+	// Newer metadata is taken over as is.
+	updated := ours.Metadata.Updated.Add(time.Hour)
+	cc.Folders[0].Metadata = protocol.FolderMetadata{Description: "Theirs", Color: "#3366cc", Updated: updated.UnixNano()}
+	must(t, m.ClusterConfig(fc, cc))
+	got, _ := w.Folder(fcfg.ID)
+	if exp := (config.FolderMetadata{Description: "Theirs", Color: "#3366cc", Updated: updated}); !got.Metadata.Equal(exp) {
+		t.Errorf("expected metadata %v, got %v", exp, got.Metadata)
+	}
+}
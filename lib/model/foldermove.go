@@ -0,0 +1,616 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sha256"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// States of a folder move.
+const (
+	moveCopying   = "copying"
+	moveSwitching = "switching"
+	moveDone      = "done"
+	moveFailed    = "failed"
+)
+
+const moveProgressInterval = time.Second
+
+var (
+	errNoFoldersToMove = errors.New("no folders to move")
+	errMoveInProgress  = errors.New("a folder move is already in progress")
+	errNoFolderMove    = errors.New("no folder move in progress")
+	errMoveAborted     = errors.New("folder move aborted")
+)
+
+// A FolderMoveTarget is a folder moved to a new path.
+type FolderMoveTarget struct {
+	Folder string `json:"folder"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// A FolderMove is the state of moving folders to new paths.
+type FolderMove struct {
+	Folders      []FolderMoveTarget `json:"folders"`
+	RemoveSource bool               `json:"removeSource"`
+	State        string             `json:"state"`
+	Error        string             `json:"error,omitempty"`
+	Files        int                `json:"files"`
+	FilesDone    int                `json:"filesDone"`
+	Bytes        int64              `json:"bytes"`
+	BytesDone    int64              `json:"bytesDone"`
+	Started      time.Time          `json:"started"`
+	Finished     time.Time          `json:"finished"`
+}
+
+// The folderMover moves the data of folders to new paths, e.g. on another
+// disk. The folders are paused while their data is copied and verified,
+// then their paths are switched in a single config change. The copies keep
+// modification times and permissions, but are new inodes. The inode
+// details recorded for the folders are cleared before they resume, so that
+// the next scan records the new ones instead of rehashing the data. If
+// anything fails or the move is aborted, the copies are removed and the
+// folders resume at their old paths.
+type folderMover struct {
+	cfg          config.Wrapper
+	evLogger     events.Logger
+	forgetInodes func(folder string) error
+	start        chan *FolderMove
+
+	mut       sync.Mutex
+	move      *FolderMove // the current or last move
+	abort     chan struct{}
+	lastEvent time.Time
+}
+
+func newFolderMover(cfg config.Wrapper, evLogger events.Logger, forgetInodes func(folder string) error) *folderMover {
+	return &folderMover{
+		cfg:          cfg,
+		evLogger:     evLogger,
+		forgetInodes: forgetInodes,
+		start:        make(chan *FolderMove, 1),
+		mut:          sync.NewMutex(),
+	}
+}
+
+func (c *folderMover) Serve(ctx context.Context) error {
+	for {
+		select {
+		case move := <-c.start:
+			c.mut.Lock()
+			abort := c.abort
+			c.mut.Unlock()
+			c.run(ctx, move, abort)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Start validates the targets, a map of folder IDs to new paths, and starts
+// moving the folders there.
+func (c *folderMover) Start(targets map[string]string, removeSource bool) error {
+	if len(targets) == 0 {
+		return errNoFoldersToMove
+	}
+	ids := make([]string, 0, len(targets))
+	for id := range targets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	cfg := c.cfg.RawCopy()
+	move := &FolderMove{
+		RemoveSource: removeSource,
+		State:        moveCopying,
+		Started:      time.Now().Truncate(time.Second),
+	}
+	for _, id := range ids {
+		fcfg, _, ok := cfg.Folder(id)
+		if !ok {
+			return ErrFolderMissing
+		}
+		to, err := fs.ExpandTilde(targets[id])
+		if err != nil {
+			return err
+		}
+		to = filepath.Clean(to)
+		if !filepath.IsAbs(to) {
+			return fmt.Errorf("new path %q of folder %q is not absolute", targets[id], id)
+		}
+		if pathInUse(cfg, to) {
+			return fmt.Errorf("new path %s of folder %q: %w", to, id, errPathInUse)
+		}
+		for _, t := range move.Folders {
+			if pathsOverlap(t.To, to) {
+				return fmt.Errorf("new paths %s and %s overlap", t.To, to)
+			}
+		}
+		if err := checkMoveTarget(fs.NewFilesystem(fcfg.FilesystemType, to)); err != nil {
+			return fmt.Errorf("new path %s of folder %q: %w", to, id, err)
+		}
+		// The data of other folders would be copied along and, when
+		// removing the source, deleted.
+		from := fcfg.Filesystem(nil).URI()
+		for _, other := range cfg.Folders {
+			if other.ID != id && pathsOverlap(other.Filesystem(nil).URI(), from) {
+				return fmt.Errorf("path %s of folder %q overlaps folder %q: %w", from, id, other.ID, errPathInUse)
+			}
+		}
+		move.Folders = append(move.Folders, FolderMoveTarget{
+			Folder: id,
+			From:   from,
+			To:     to,
+		})
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if c.abort != nil {
+		return errMoveInProgress
+	}
+	c.move = move
+	c.abort = make(chan struct{})
+	c.start <- move
+	return nil
+}
+
+// checkMoveTarget returns an error unless the target doesn't exist yet or
+// is an empty directory.
+func checkMoveTarget(target fs.Filesystem) error {
+	info, err := target.Lstat(".")
+	if fs.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return errors.New("exists and is not a directory")
+	}
+	names, err := target.DirNames(".")
+	if err != nil {
+		return err
+	}
+	if len(names) > 0 {
+		return errors.New("directory is not empty")
+	}
+	return nil
+}
+
+func (c *folderMover) run(ctx context.Context, move *FolderMove, abort chan struct{}) {
+	l.Infof("Moving %d folders to new paths", len(move.Folders))
+	err := c.moveFolders(ctx, move, abort)
+
+	c.mut.Lock()
+	move.Finished = time.Now().Truncate(time.Second)
+	if err != nil {
+		move.State = moveFailed
+		move.Error = err.Error()
+	} else {
+		move.State = moveDone
+	}
+	status := *move
+	c.abort = nil
+	c.mut.Unlock()
+
+	if err != nil {
+		l.Warnln("Moving folders to new paths failed, resuming at the old paths:", err)
+	} else {
+		l.Infof("Moved %d folders to new paths", len(move.Folders))
+	}
+	c.evLogger.Log(events.FolderMoveDone, status)
+}
+
+func (c *folderMover) moveFolders(ctx context.Context, move *FolderMove, abort chan struct{}) error {
+	// Pause the folders, so nothing changes their data while it's copied.
+	wasPaused := make(map[string]bool, len(move.Folders))
+	if err := c.modify(func(cfg *config.Configuration) {
+		for _, t := range move.Folders {
+			if _, i, ok := cfg.Folder(t.Folder); ok {
+				wasPaused[t.Folder] = cfg.Folders[i].Paused
+				cfg.Folders[i].Paused = true
+			}
+		}
+	}); err != nil {
+		return err
+	}
+
+	cfg := c.cfg.RawCopy()
+	fsTypes := make([]fs.FilesystemType, len(move.Folders))
+	sources := make([]fs.Filesystem, len(move.Folders))
+	for i, t := range move.Folders {
+		fcfg, _, ok := cfg.Folder(t.Folder)
+		if !ok {
+			return ErrFolderMissing
+		}
+		fsTypes[i] = fcfg.FilesystemType
+		sources[i] = fcfg.Filesystem(nil)
+	}
+
+	// Copies are removed on failure, including the directories we created
+	// for them.
+	var rollback []func() error
+	err := c.countFiles(move, sources)
+	for i, t := range move.Folders {
+		if err != nil {
+			break
+		}
+		fsType, to := fsTypes[i], t.To
+		target := fs.NewFilesystem(fsType, to)
+		if _, lerr := target.Lstat("."); lerr == nil {
+			rollback = append(rollback, func() error { return removeContents(target) })
+		} else {
+			rollback = append(rollback, func() error { return removeDir(fsType, to) })
+			err = target.MkdirAll(".", 0o700)
+		}
+		if err == nil {
+			err = c.copyFolder(ctx, abort, sources[i], target)
+		}
+	}
+	for _, t := range move.Folders {
+		if err != nil {
+			break
+		}
+		err = c.forgetInodes(t.Folder)
+	}
+	if err == nil {
+		c.setState(move, moveSwitching)
+		err = c.modify(func(cfg *config.Configuration) {
+			for _, t := range move.Folders {
+				if _, i, ok := cfg.Folder(t.Folder); ok {
+					cfg.Folders[i].Path = t.To
+					cfg.Folders[i].Paused = wasPaused[t.Folder]
+				}
+			}
+		})
+	}
+
+	if err != nil {
+		for _, fn := range rollback {
+			if rerr := fn(); rerr != nil {
+				l.Warnln("Failed to remove partial copy of folder data:", rerr)
+			}
+		}
+		if rerr := c.modify(func(cfg *config.Configuration) {
+			for _, t := range move.Folders {
+				if _, i, ok := cfg.Folder(t.Folder); ok {
+					cfg.Folders[i].Paused = wasPaused[t.Folder]
+				}
+			}
+		}); rerr != nil {
+			l.Warnln("Failed to resume folders after failed move:", rerr)
+		}
+		return err
+	}
+
+	if move.RemoveSource {
+		cfg := c.cfg.RawCopy()
+		for i, t := range move.Folders {
+			if pathInUse(cfg, t.From) {
+				l.Warnf("Not removing old data of folder %q at %s: %v", t.Folder, t.From, errPathInUse)
+				continue
+			}
+			if err := removeDir(fsTypes[i], t.From); err != nil {
+				l.Warnf("Failed to remove old data of folder %q at %s: %v", t.Folder, t.From, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *folderMover) modify(fn config.ModifyFunction) error {
+	waiter, err := c.cfg.Modify(fn)
+	if err != nil {
+		return err
+	}
+	waiter.Wait()
+	return nil
+}
+
+// countFiles sets the total number of files and bytes to copy.
+func (c *folderMover) countFiles(move *FolderMove, sources []fs.Filesystem) error {
+	files, size := 0, int64(0)
+	for _, src := range sources {
+		if err := src.Walk(".", func(_ string, info fs.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			files++
+			if info.IsRegular() {
+				size += info.Size()
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	c.mut.Lock()
+	move.Files = files
+	move.Bytes = size
+	c.mut.Unlock()
+	return nil
+}
+
+type moveDirTimes struct {
+	name  string
+	mtime time.Time
+}
+
+func (c *folderMover) copyFolder(ctx context.Context, abort chan struct{}, src, dst fs.Filesystem) error {
+	var dirs []moveDirTimes
+	buf := make([]byte, 128<<10)
+	err := src.Walk(".", func(name string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := moveAborted(ctx, abort); err != nil {
+			return err
+		}
+
+		switch {
+		case info.IsDir():
+			if name != "." {
+				if err := dst.Mkdir(name, 0o700); err != nil {
+					return err
+				}
+			}
+			if err := dst.Chmod(name, info.Mode()&fs.ModePerm); err != nil {
+				return err
+			}
+			dirs = append(dirs, moveDirTimes{name, info.ModTime()})
+		case info.IsSymlink():
+			target, err := src.ReadSymlink(name)
+			if err != nil {
+				return err
+			}
+			if err := dst.CreateSymlink(target, name); err != nil {
+				return err
+			}
+		case info.IsRegular():
+			if err := c.copyFile(ctx, abort, src, dst, name, info, buf); err != nil {
+				return fmt.Errorf("copying %s: %w", name, err)
+			}
+		}
+		c.progress(1, 0)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Directory modification times are set last, as creating their
+	// contents changes them.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := dst.Chtimes(dirs[i].name, dirs[i].mtime, dirs[i].mtime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFile copies the file and verifies the copy by reading it back.
+func (c *folderMover) copyFile(ctx context.Context, abort chan struct{}, src, dst fs.Filesystem, name string, info fs.FileInfo, buf []byte) error {
+	sfd, err := src.Open(name)
+	if err != nil {
+		return err
+	}
+	defer sfd.Close()
+	dfd, err := dst.Create(name)
+	if err != nil {
+		return err
+	}
+	defer dfd.Close()
+
+	hf := sha256.New()
+	for {
+		if err := moveAborted(ctx, abort); err != nil {
+			return err
+		}
+		n, err := sfd.Read(buf)
+		if n > 0 {
+			hf.Write(buf[:n])
+			if _, err := dfd.Write(buf[:n]); err != nil {
+				return err
+			}
+			c.progress(0, int64(n))
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	if err := dfd.Sync(); err != nil {
+		return err
+	}
+	if err := dfd.Close(); err != nil {
+		return err
+	}
+
+	vfd, err := dst.Open(name)
+	if err != nil {
+		return err
+	}
+	defer vfd.Close()
+	hv := sha256.New()
+	if _, err := io.CopyBuffer(hv, vfd, buf); err != nil {
+		return err
+	}
+	if !bytes.Equal(hf.Sum(nil), hv.Sum(nil)) {
+		return errors.New("copy differs from the original")
+	}
+
+	if err := dst.Chmod(name, info.Mode()&fs.ModePerm); err != nil {
+		return err
+	}
+	return dst.Chtimes(name, info.ModTime(), info.ModTime())
+}
+
+func moveAborted(ctx context.Context, abort chan struct{}) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-abort:
+		return errMoveAborted
+	default:
+		return nil
+	}
+}
+
+// removeContents removes everything in the filesystem, leaving its root
+// directory in place.
+func removeContents(filesystem fs.Filesystem) error {
+	names, err := filesystem.DirNames(".")
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := filesystem.RemoveAll(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeDir removes the directory and everything in it.
+func removeDir(fsType fs.FilesystemType, path string) error {
+	dir, name := filepath.Split(path)
+	return fs.NewFilesystem(fsType, dir).RemoveAll(name)
+}
+
+func (c *folderMover) setState(move *FolderMove, state string) {
+	c.mut.Lock()
+	move.State = state
+	status := *move
+	c.mut.Unlock()
+	c.evLogger.Log(events.FolderMoveProgress, status)
+}
+
+// progress accounts for copied files and bytes, emitting a progress event
+// at most every moveProgressInterval.
+func (c *folderMover) progress(files int, bytes int64) {
+	c.mut.Lock()
+	if c.move == nil {
+		c.mut.Unlock()
+		return
+	}
+	c.move.FilesDone += files
+	c.move.BytesDone += bytes
+	if time.Since(c.lastEvent) < moveProgressInterval {
+		c.mut.Unlock()
+		return
+	}
+	c.lastEvent = time.Now()
+	status := *c.move
+	c.mut.Unlock()
+	c.evLogger.Log(events.FolderMoveProgress, status)
+}
+
+// Status returns the state of the current or last folder move, if any.
+func (c *folderMover) Status() (FolderMove, bool) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if c.move == nil {
+		return FolderMove{}, false
+	}
+	return *c.move, true
+}
+
+// Abort aborts the current folder move, which then resumes the folders at
+// their old paths.
+func (c *folderMover) Abort() error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if c.abort == nil {
+		return errNoFolderMove
+	}
+	select {
+	case <-c.abort:
+	default:
+		close(c.abort)
+	}
+	return nil
+}
+
+func (c *folderMover) String() string {
+	return fmt.Sprintf("folderMover@%p", c)
+}
+
+// forgetInodes clears the inode details recorded for the local files of
+// the folder, without changing their versions.
+func (m *model) forgetInodes(folder string) error {
+	// The folder is paused while it's moved, so there is no file set to
+	// use. Creating one isn't safe concurrently with other folders.
+	m.fmut.Lock()
+	fset, ok := m.folderFiles[folder]
+	if !ok {
+		var err error
+		fset, err = db.NewFileSet(folder, m.db)
+		if err != nil {
+			m.fmut.Unlock()
+			return err
+		}
+	}
+	m.fmut.Unlock()
+
+	snap, err := fset.Snapshot()
+	if err != nil {
+		return err
+	}
+	defer snap.Release()
+	batch := db.NewFileInfoBatch(func(files []protocol.FileInfo) error {
+		fset.Update(protocol.LocalDeviceID, files)
+		return nil
+	})
+	snap.WithHave(protocol.LocalDeviceID, func(intf protocol.FileIntf) bool {
+		fi := intf.(protocol.FileInfo)
+		if fi.Inode == 0 && fi.InodeGeneration == 0 && fi.InodeChangeNs == 0 {
+			return true
+		}
+		fi.Inode, fi.InodeGeneration, fi.InodeChangeNs = 0, 0, 0
+		batch.Append(fi)
+		err = batch.FlushIfFull()
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	return batch.Flush()
+}
+
+// MoveFolders starts moving the folders to new paths, given as a map of
+// folder IDs to paths, optionally removing the data at the old paths once
+// done.
+func (m *model) MoveFolders(targets map[string]string, removeSource bool) error {
+	return m.folderMover.Start(targets, removeSource)
+}
+
+// FolderMove returns the state of the current or last folder move.
+func (m *model) FolderMove() (FolderMove, bool) {
+	return m.folderMover.Status()
+}
+
+// AbortFolderMove aborts the current folder move.
+func (m *model) AbortFolderMove() error {
+	return m.folderMover.Abort()
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestFolderMove(t *testing.T) {
+	w, _, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+
+	td := t.TempDir()
+	from := filepath.Join(td, "from")
+	must(t, os.MkdirAll(filepath.Join(from, "sub"), 0o755))
+	must(t, os.WriteFile(filepath.Join(from, "sub", "file"), []byte("data"), 0o644))
+	mtime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	must(t, os.Chtimes(filepath.Join(from, "sub", "file"), mtime, mtime))
+	fcfg := config.FolderConfiguration{
+		ID:             "moved",
+		FilesystemType: fs.FilesystemTypeBasic,
+		Path:           from,
+	}
+	setFolder(t, w, fcfg)
+
+	var forgotten []string
+	c := newFolderMover(w, events.NoopLogger, func(folder string) error {
+		forgotten = append(forgotten, folder)
+		return nil
+	})
+	ctx := context.Background()
+
+	// Targets must be outside of any folder and empty.
+	if err := c.Start(map[string]string{"moved": filepath.Join(from, "inside")}, false); err == nil {
+		t.Error("moving into the folder itself succeeded")
+	}
+	occupied := filepath.Join(td, "occupied")
+	must(t, os.MkdirAll(filepath.Join(occupied, "dir"), 0o755))
+	if err := c.Start(map[string]string{"moved": occupied}, false); err == nil {
+		t.Error("moving onto existing data succeeded")
+	}
+	if err := c.Start(map[string]string{"missing": filepath.Join(td, "to")}, false); err != ErrFolderMissing {
+		t.Errorf("expected %v moving a missing folder, got %v", ErrFolderMissing, err)
+	}
+
+	// An aborted move leaves nothing behind and resumes at the old path.
+	to := filepath.Join(td, "to")
+	must(t, c.Start(map[string]string{"moved": to}, false))
+	if err := c.Start(map[string]string{"moved": to}, false); err != errMoveInProgress {
+		t.Errorf("expected %v starting a second move, got %v", errMoveInProgress, err)
+	}
+	must(t, c.Abort())
+	c.run(ctx, <-c.start, c.abort)
+	if move, _ := c.Status(); move.State != moveFailed || move.Error != errMoveAborted.Error() {
+		t.Errorf("unexpected state of aborted move %v", move)
+	}
+	if _, err := os.Stat(to); !os.IsNotExist(err) {
+		t.Error("aborted move left the copy behind")
+	}
+	if moved := w.Folders()["moved"]; moved.Path != from || moved.Paused {
+		t.Errorf("folder not resumed at the old path after aborted move: %v", moved)
+	}
+
+	must(t, c.Start(map[string]string{"moved": to}, true))
+	c.run(ctx, <-c.start, c.abort)
+	move, _ := c.Status()
+	if move.State != moveDone || move.FilesDone != move.Files || move.BytesDone != 4 {
+		t.Errorf("unexpected state of finished move %v", move)
+	}
+	if moved := w.Folders()["moved"]; moved.Path != to || moved.Paused {
+		t.Errorf("folder not resumed at the new path: %v", moved)
+	}
+	info, err := os.Stat(filepath.Join(to, "sub", "file"))
+	must(t, err)
+	if !info.ModTime().Equal(mtime) {
+		t.Errorf("modification time %v of the copy differs from %v", info.ModTime(), mtime)
+	}
+	if _, err := os.Stat(from); !os.IsNotExist(err) {
+		t.Error("old data still exists")
+	}
+	if len(forgotten) != 1 || forgotten[0] != "moved" {
+		t.Errorf("expected the inodes of the moved folder to be forgotten once, got %v", forgotten)
+	}
+	if err := c.Abort(); err != errNoFolderMove {
+		t.Errorf("expected %v aborting after the move, got %v", errNoFolderMove, err)
+	}
+}
+
+func TestFolderMoveOverlappingFolders(t *testing.T) {
+	w, _, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+
+	td := t.TempDir()
+	outer := filepath.Join(td, "outer")
+	inner := filepath.Join(outer, "inner")
+	must(t, os.MkdirAll(inner, 0o755))
+	setFolder(t, w, config.FolderConfiguration{ID: "outer", FilesystemType: fs.FilesystemTypeBasic, Path: outer})
+	setFolder(t, w, config.FolderConfiguration{ID: "inner", FilesystemType: fs.FilesystemTypeBasic, Path: inner})
+
+	// Moving either would copy or remove the data of the other.
+	c := newFolderMover(w, events.NoopLogger, func(string) error { return nil })
+	for _, id := range []string{"outer", "inner"} {
+		if err := c.Start(map[string]string{id: filepath.Join(td, "to")}, true); !errors.Is(err, errPathInUse) {
+			t.Errorf("expected %v moving %s, got %v", errPathInUse, id, err)
+		}
+	}
+	if _, err := os.Stat(inner); err != nil {
+		t.Error("data of the inner folder is gone:", err)
+	}
+}
+
+func TestFolderMoveForgetsInodes(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	m := newModel(t, w, myID, "syncthing", "dev", nil)
+
+	set := newFileSet(t, fcfg.ID, m.db)
+	files := []protocol.FileInfo{
+		{Name: "tracked", Version: protocol.Vector{}.Update(myID.Short()), Inode: 42, InodeGeneration: 1, InodeChangeNs: 1000},
+		{Name: "untracked", Version: protocol.Vector{}.Update(myID.Short())},
+	}
+	set.Update(protocol.LocalDeviceID, files)
+	seq := set.Sequence(protocol.LocalDeviceID)
+
+	must(t, m.forgetInodes(fcfg.ID))
+
+	snap, err := set.Snapshot()
+	must(t, err)
+	defer snap.Release()
+	fi, ok := snap.Get(protocol.LocalDeviceID, "tracked")
+	if !ok {
+		t.Fatal("file is gone")
+	}
+	if fi.Inode != 0 || fi.InodeGeneration != 0 || fi.InodeChangeNs != 0 {
+		t.Errorf("expected the inode details to be cleared, got %v", fi)
+	}
+	if !fi.Version.Equal(files[0].Version) || snap.Sequence(protocol.LocalDeviceID) != seq {
+		t.Error("clearing the inode details changed the file")
+	}
+}
@@ -7,9 +7,12 @@
 package model
 
 import (
+	"errors"
 	"time"
 
+	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/messages"
 	"github.com/syncthing/syncthing/lib/sync"
 )
 
@@ -163,6 +166,7 @@ func (s *stateTracker) setError(err error) {
 
 	if err != nil {
 		eventData["error"] = err.Error()
+		eventData["errorCode"] = folderErrorCode(err)
 		s.current = FolderError
 	} else {
 		s.current = FolderIdle
@@ -179,3 +183,19 @@ func (s *stateTracker) setError(err error) {
 
 	s.evLogger.Log(events.StateChanged, eventData)
 }
+
+// folderErrorCode returns the message code for a folder error.
+func folderErrorCode(err error) messages.Code {
+	switch {
+	case errors.Is(err, config.ErrPathMissing):
+		return messages.FolderPathMissing
+	case errors.Is(err, config.ErrPathNotDirectory):
+		return messages.FolderPathNotDirectory
+	case errors.Is(err, config.ErrMarkerMissing):
+		return messages.FolderMarkerMissing
+	case errors.Is(err, errFolderEmptied):
+		return messages.FolderEmptied
+	default:
+		return messages.FolderFailed
+	}
+}
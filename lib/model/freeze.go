@@ -0,0 +1,101 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// FreezeStatus describes how far a frozen folder has diverged from the
+// state it was frozen in.
+type FreezeStatus struct {
+	Frozen bool `json:"frozen"`
+	// The local sequence when the folder was frozen, and now. Local
+	// changes are still scanned while frozen.
+	Sequence      int64 `json:"sequence"`
+	LocalSequence int64 `json:"localSequence"`
+	// The changes from other devices that are held back.
+	NeedFiles       int   `json:"needFiles"`
+	NeedDirectories int   `json:"needDirectories"`
+	NeedSymlinks    int   `json:"needSymlinks"`
+	NeedDeletes     int   `json:"needDeletes"`
+	NeedBytes       int64 `json:"needBytes"`
+}
+
+// FreezeFolder stops applying changes from other devices to the folder.
+// They are still accepted into the index, and applied once the folder is
+// unfrozen.
+func (m *model) FreezeFolder(folder string) error {
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	fset := m.folderFiles[folder]
+	m.fmut.RUnlock()
+	if err != nil {
+		return err
+	}
+	seq := fset.Sequence(protocol.LocalDeviceID)
+	return m.setFolderFrozen(folder, true, seq)
+}
+
+// UnfreezeFolder resumes applying changes from other devices to the
+// folder.
+func (m *model) UnfreezeFolder(folder string) error {
+	if _, ok := m.cfg.Folder(folder); !ok {
+		return ErrFolderMissing
+	}
+	return m.setFolderFrozen(folder, false, 0)
+}
+
+func (m *model) setFolderFrozen(folder string, frozen bool, seq int64) error {
+	waiter, err := m.cfg.Modify(func(cfg *config.Configuration) {
+		fcfg, _, ok := cfg.Folder(folder)
+		if !ok || fcfg.Frozen == frozen {
+			return
+		}
+		fcfg.Frozen = frozen
+		fcfg.FrozenSequence = seq
+		cfg.SetFolder(fcfg)
+	})
+	if err != nil {
+		return err
+	}
+	waiter.Wait()
+	if frozen {
+		l.Infof("Froze folder %v at sequence %d", folder, seq)
+	} else {
+		l.Infof("Unfroze folder %v", folder)
+	}
+	return nil
+}
+
+func (m *model) FreezeStatus(folder string) (FreezeStatus, error) {
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	cfg := m.folderCfgs[folder]
+	fset := m.folderFiles[folder]
+	m.fmut.RUnlock()
+	if err != nil {
+		return FreezeStatus{}, err
+	}
+
+	snap, err := fset.Snapshot()
+	if err != nil {
+		return FreezeStatus{}, err
+	}
+	defer snap.Release()
+	status := FreezeStatus{
+		Frozen:        cfg.Frozen,
+		Sequence:      cfg.FrozenSequence,
+		LocalSequence: snap.Sequence(protocol.LocalDeviceID),
+	}
+	if cfg.Frozen {
+		need := snap.NeedSize(protocol.LocalDeviceID)
+		status.NeedFiles, status.NeedDirectories, status.NeedSymlinks, status.NeedDeletes, status.NeedBytes = need.Files, need.Directories, need.Symlinks, need.Deleted, need.Bytes
+	}
+	return status, nil
+}
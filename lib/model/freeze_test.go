@@ -0,0 +1,67 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestFreezeFolder(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+
+	must(t, m.FreezeFolder(fcfg.ID))
+	if cfg, _ := w.Folder(fcfg.ID); !cfg.Frozen {
+		t.Fatal("expected folder to be frozen")
+	}
+
+	requested := make(chan string, 1)
+	conn := addFakeConn(m, device1, fcfg.ID)
+	conn.RequestCalls(func(_ context.Context, _, name string, _ int, _ int64, _ int, _ []byte, _ uint32, _ bool) ([]byte, error) {
+		select {
+		case requested <- name:
+		default:
+		}
+		return nil, protocol.ErrGeneric
+	})
+	files := []protocol.FileInfo{{
+		Name:    "remote",
+		Type:    protocol.FileInfoTypeFile,
+		Size:    6,
+		Version: protocol.Vector{}.Update(device1.Short()),
+		Blocks:  []protocol.BlockInfo{{Size: 6, Hash: []byte("hash")}},
+	}}
+	must(t, m.Index(conn, fcfg.ID, files))
+
+	// The change is known, but not applied.
+	select {
+	case name := <-requested:
+		t.Fatalf("unexpected request for %v while frozen", name)
+	case <-time.After(500 * time.Millisecond):
+	}
+	status, err := m.FreezeStatus(fcfg.ID)
+	must(t, err)
+	if !status.Frozen || status.NeedFiles != 1 || status.NeedBytes != 6 {
+		t.Errorf("unexpected freeze status %+v", status)
+	}
+
+	must(t, m.UnfreezeFolder(fcfg.ID))
+	select {
+	case name := <-requested:
+		if name != "remote" {
+			t.Errorf("unexpected request for %v", name)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the pull after unfreezing")
+	}
+}
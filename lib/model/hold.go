@@ -0,0 +1,175 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"errors"
+	"path/filepath"
+	"sort"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/osutil"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"golang.org/x/exp/slices"
+)
+
+// HeldPath describes a path held at its local version, with the changes
+// from other devices held back for it and anything below it.
+type HeldPath struct {
+	Path      string `json:"path"`
+	NeedItems int    `json:"needItems"`
+	NeedBytes int64  `json:"needBytes"`
+}
+
+// isHeld returns whether the change to the item must not be applied, as
+// the item or one of its parents is held. Deleting a parent of a held item
+// is held back too, as the held item stays in place.
+func (f *folder) isHeld(file protocol.FileIntf) bool {
+	name := file.FileName()
+	for _, held := range f.HeldPaths {
+		held = filepath.FromSlash(held)
+		if name == held || fs.IsParent(name, held) {
+			return true
+		}
+		if file.IsDeleted() && fs.IsParent(held, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// HoldPaths stops applying changes from other devices to the given paths
+// in the folder. They are still accepted into the index, and applied once
+// the paths are released.
+func (m *model) HoldPaths(folder string, paths []string) error {
+	if len(paths) == 0 {
+		return errors.New("no paths given")
+	}
+	canon := make([]string, len(paths))
+	for i, path := range paths {
+		var err error
+		canon[i], err = canonicalHeldPath(path)
+		if err != nil {
+			return err
+		}
+	}
+	return m.modifyHeldPaths(folder, func(held map[string]struct{}) {
+		for _, path := range canon {
+			held[path] = struct{}{}
+		}
+	})
+}
+
+// ReleasePaths resumes applying changes from other devices to the given
+// paths in the folder, or to all held paths if none are given.
+func (m *model) ReleasePaths(folder string, paths []string) error {
+	canon := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if path, err := canonicalHeldPath(path); err == nil {
+			canon = append(canon, path)
+		}
+	}
+	return m.modifyHeldPaths(folder, func(held map[string]struct{}) {
+		if len(paths) == 0 {
+			for path := range held {
+				delete(held, path)
+			}
+			return
+		}
+		for _, path := range canon {
+			delete(held, path)
+		}
+	})
+}
+
+// canonicalHeldPath returns the path as it's stored in the configuration,
+// relative to the folder root and with forward slashes.
+func canonicalHeldPath(path string) (string, error) {
+	path, err := fs.Canonicalize(osutil.NativeFilename(path))
+	if err != nil {
+		return "", err
+	}
+	if path == "." {
+		return "", errors.New("the folder root can't be held, freeze the folder instead")
+	}
+	return filepath.ToSlash(path), nil
+}
+
+func (m *model) modifyHeldPaths(folder string, fn func(held map[string]struct{})) error {
+	if _, ok := m.cfg.Folder(folder); !ok {
+		return ErrFolderMissing
+	}
+	waiter, err := m.cfg.Modify(func(cfg *config.Configuration) {
+		fcfg, _, ok := cfg.Folder(folder)
+		if !ok {
+			return
+		}
+		held := make(map[string]struct{}, len(fcfg.HeldPaths))
+		for _, path := range fcfg.HeldPaths {
+			held[path] = struct{}{}
+		}
+		fn(held)
+		paths := make([]string, 0, len(held))
+		for path := range held {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		if slices.Equal(paths, fcfg.HeldPaths) {
+			return
+		}
+		fcfg.HeldPaths = paths
+		cfg.SetFolder(fcfg)
+	})
+	if err != nil {
+		return err
+	}
+	waiter.Wait()
+	return nil
+}
+
+// HeldPaths returns the held paths of the folder, with the changes held
+// back for each.
+func (m *model) HeldPaths(folder string) ([]HeldPath, error) {
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	cfg := m.folderCfgs[folder]
+	fset := m.folderFiles[folder]
+	m.fmut.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]HeldPath, len(cfg.HeldPaths))
+	if len(res) == 0 {
+		return res, nil
+	}
+	held := make([]string, len(cfg.HeldPaths))
+	for i, path := range cfg.HeldPaths {
+		res[i].Path = path
+		held[i] = filepath.FromSlash(path)
+	}
+
+	snap, err := fset.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer snap.Release()
+	snap.WithNeedTruncated(protocol.LocalDeviceID, func(intf protocol.FileIntf) bool {
+		name := intf.FileName()
+		for i, path := range held {
+			if name == path || fs.IsParent(name, path) {
+				res[i].NeedItems++
+				if !intf.IsDeleted() {
+					res[i].NeedBytes += intf.FileSize()
+				}
+			}
+		}
+		return true
+	})
+	return res, nil
+}
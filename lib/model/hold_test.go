@@ -0,0 +1,85 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestHoldPaths(t *testing.T) {
+	m, fc, fcfg, wcfgCancel := setupModelWithConnection(t)
+	defer wcfgCancel()
+	tfs := fcfg.Filesystem(nil)
+	defer cleanupModelAndRemoveDir(m, tfs.URI())
+
+	must(t, m.HoldPaths(fcfg.ID, []string{"/held/"}))
+	if cfg, _ := m.cfg.Folder(fcfg.ID); len(cfg.HeldPaths) != 1 || cfg.HeldPaths[0] != "held" {
+		t.Fatalf("expected the path to be held as %q, got %v", "held", cfg.HeldPaths)
+	}
+
+	synced := make(chan string, 10)
+	fc.setIndexFn(func(_ context.Context, _ string, fs []protocol.FileInfo) error {
+		for _, f := range fs {
+			synced <- f.Name
+		}
+		return nil
+	})
+	waitSynced := func(name string) {
+		t.Helper()
+		timeout := time.After(10 * time.Second)
+		for {
+			select {
+			case got := <-synced:
+				if got == filepath.Join("held", "file") && name != got {
+					t.Fatal("held file was synced")
+				}
+				if got == name {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("timed out waiting for %v to sync", name)
+			}
+		}
+	}
+
+	contents := []byte("contents\n")
+	fc.addFile("held", 0o755, protocol.FileInfoTypeDirectory, nil)
+	fc.addFile(filepath.Join("held", "file"), 0o644, protocol.FileInfoTypeFile, contents)
+	fc.addFile("free", 0o644, protocol.FileInfoTypeFile, contents)
+	fc.sendIndexUpdate()
+	waitSynced("free")
+
+	if _, err := tfs.Lstat("held"); !fs.IsNotExist(err) {
+		t.Errorf("expected the held directory to not be created, got %v", err)
+	}
+	held, err := m.HeldPaths(fcfg.ID)
+	must(t, err)
+	wantBytes := fc.files[0].FileSize() + fc.files[1].FileSize()
+	if len(held) != 1 || held[0].NeedItems != 2 || held[0].NeedBytes != wantBytes {
+		t.Errorf("expected two items held back, got %+v", held)
+	}
+
+	must(t, m.ReleasePaths(fcfg.ID, nil))
+	waitSynced(filepath.Join("held", "file"))
+	if err := equalContents(tfs, filepath.Join("held", "file"), contents); err != nil {
+		t.Error("held file did not sync after release:", err)
+	}
+}
+
+func TestHoldPathsRejectsRoot(t *testing.T) {
+	for _, path := range []string{"", ".", "/", "../outside"} {
+		if _, err := canonicalHeldPath(path); err == nil {
+			t.Errorf("expected %q to be rejected", path)
+		}
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"sync"
 	"time"
 
+	"golang.org/x/time/rate"
+
 	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/db"
 	"github.com/syncthing/syncthing/lib/events"
@@ -24,16 +26,20 @@ type indexHandler struct {
 	downloads                *deviceDownloadState
 	folder                   string
 	folderIsReceiveEncrypted bool
+	indexID                  protocol.IndexID
 	prevSequence             int64
+	progress                 *indexSendProgress
+	limiter                  *rate.Limiter
 	evLogger                 events.Logger
 
 	cond   *sync.Cond
 	paused bool
+	held   bool // on a metered connection, see indexHandlerRegistry.SetMetered
 	fset   *db.FileSet
 	runner service
 }
 
-func newIndexHandler(conn protocol.Connection, downloads *deviceDownloadState, folder config.FolderConfiguration, fset *db.FileSet, runner service, startInfo *clusterConfigDeviceInfo, evLogger events.Logger) *indexHandler {
+func newIndexHandler(conn protocol.Connection, downloads *deviceDownloadState, folder config.FolderConfiguration, fset *db.FileSet, runner service, startInfo *clusterConfigDeviceInfo, progress *indexSendProgress, limiter *rate.Limiter, evLogger events.Logger) *indexHandler {
 	myIndexID := fset.IndexID(protocol.LocalDeviceID)
 	mySequence := fset.Sequence(protocol.LocalDeviceID)
 	var startSequence int64
@@ -58,6 +64,13 @@ func newIndexHandler(conn protocol.Connection, downloads *deviceDownloadState, f
 		} else {
 			l.Debugf("Device %v folder %s is delta index compatible (mlv=%d)", conn.DeviceID().Short(), folder.Description(), startInfo.local.MaxSequence)
 			startSequence = startInfo.local.MaxSequence
+			// The other side might not have processed everything we
+			// already sent on this connection when it sent the cluster
+			// config, but it will. No need to send it again.
+			if sent := progress.get(folder.ID, myIndexID); sent > startSequence && sent <= mySequence {
+				l.Debugf("Device %v folder %s resuming index transfer at sequence %d", conn.DeviceID().Short(), folder.Description(), sent)
+				startSequence = sent
+			}
 		}
 	} else if startInfo.local.IndexID != 0 {
 		// They say they've seen an index ID from us, but it's
@@ -99,7 +112,10 @@ func newIndexHandler(conn protocol.Connection, downloads *deviceDownloadState, f
 		downloads:                downloads,
 		folder:                   folder.ID,
 		folderIsReceiveEncrypted: folder.Type == config.FolderTypeReceiveEncrypted,
+		indexID:                  myIndexID,
 		prevSequence:             startSequence,
+		progress:                 progress,
+		limiter:                  limiter,
 		evLogger:                 evLogger,
 
 		fset:   fset,
@@ -113,7 +129,7 @@ func (s *indexHandler) waitForFileset(ctx context.Context) (*db.FileSet, error)
 	s.cond.L.Lock()
 	defer s.cond.L.Unlock()
 
-	for s.paused {
+	for s.paused || s.held {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
@@ -207,6 +223,18 @@ func (s *indexHandler) resume(fset *db.FileSet, runner service) {
 	s.cond.L.Unlock()
 }
 
+// hold stops sending index updates until released, without forgetting the
+// fileset like pause.
+func (s *indexHandler) hold(held bool) {
+	s.cond.L.Lock()
+	if s.held != held {
+		l.Debugf("%v: held: %v", s, held)
+	}
+	s.held = held
+	s.cond.Broadcast()
+	s.cond.L.Unlock()
+}
+
 func (s *indexHandler) pause() {
 	s.cond.L.Lock()
 	if s.paused {
@@ -226,6 +254,9 @@ func (s *indexHandler) sendIndexTo(ctx context.Context, fset *db.FileSet) error
 	batch := db.NewFileInfoBatch(nil)
 	batch.SetFlushFunc(func(fs []protocol.FileInfo) error {
 		l.Debugf("%v: Sending %d files (<%d bytes)", s, len(fs), batch.Size())
+		if err := waitForBytes(ctx, s.limiter, batch.Size()); err != nil {
+			return err
+		}
 		if initial {
 			initial = false
 			return s.conn.Index(ctx, s.folder, fs)
@@ -241,11 +272,15 @@ func (s *indexHandler) sendIndexTo(ctx context.Context, fset *db.FileSet) error
 	}
 	defer snap.Release()
 	previousWasDelete := false
+	var previousTransaction int64
 	snap.WithHaveSequence(s.prevSequence+1, func(fi protocol.FileIntf) bool {
 		// This is to make sure that renames (which is an add followed by a delete) land in the same batch.
 		// Even if the batch is full, we allow a last delete to slip in, we do this by making sure that
-		// the batch ends with a non-delete, or that the last item in the batch is already a delete
-		if batch.Full() && (!fi.IsDeleted() || previousWasDelete) {
+		// the batch ends with a non-delete, or that the last item in the batch is already a delete.
+		// Likewise the files of a transaction are never split between batches, so that the other
+		// side learns about all of them at once.
+		transaction := fi.(protocol.FileInfo).TransactionID
+		if batch.Full() && (!fi.IsDeleted() || previousWasDelete) && (transaction == 0 || transaction != previousTransaction) {
 			if err = batch.Flush(); err != nil {
 				return false
 			}
@@ -285,6 +320,7 @@ func (s *indexHandler) sendIndexTo(ctx context.Context, fset *db.FileSet) error
 		f = prepareFileInfoForIndex(f)
 
 		previousWasDelete = f.IsDeleted()
+		previousTransaction = f.TransactionID
 
 		batch.Append(f)
 		return true
@@ -301,6 +337,7 @@ func (s *indexHandler) sendIndexTo(ctx context.Context, fset *db.FileSet) error
 	}
 
 	s.prevSequence = f.Sequence
+	s.progress.set(s.folder, s.indexID, f.Sequence)
 	return err
 }
 
@@ -333,6 +370,16 @@ func (s *indexHandler) receive(fs []protocol.FileInfo, update bool, op string) e
 	}
 	fset.Update(deviceID, fs)
 
+	// The few files of a small update are pulled ahead of a backlog of
+	// larger changes, if any.
+	if update && len(fs) <= maxHintedIndexUpdate {
+		names := make([]string, len(fs))
+		for i := range fs {
+			names[i] = fs[i].Name
+		}
+		runner.PullHint(names)
+	}
+
 	seq := fset.Sequence(deviceID)
 	s.evLogger.Log(events.RemoteIndexUpdated, map[string]interface{}{
 		"device":   deviceID.String(),
@@ -362,6 +409,8 @@ func prepareFileInfoForIndex(f protocol.FileInfo) protocol.FileInfo {
 	f.LocalFlags = 0
 	f.VersionHash = nil
 	f.InodeChangeNs = 0
+	f.Inode = 0
+	f.InodeGeneration = 0
 	return f
 }
 
@@ -369,13 +418,92 @@ func (s *indexHandler) String() string {
 	return fmt.Sprintf("indexHandler@%p for %s to %s at %s", s, s.folder, s.conn.DeviceID().Short(), s.conn)
 }
 
+// indexSendProgress keeps track of the index data sent per folder, for the
+// lifetime of a connection. The other side processes messages in order, so
+// after a restart of the index handler we can continue where we left off,
+// even if the cluster config it is started from is outdated. It must not
+// outlive the connection: what was written to a connection that went down
+// may never have been processed, so a new connection resumes from the
+// sequence the other side announces.
+type indexSendProgress struct {
+	mut  sync.Mutex
+	sent map[string]indexSendState
+}
+
+type indexSendState struct {
+	indexID  protocol.IndexID
+	sequence int64
+}
+
+func newIndexSendProgress() *indexSendProgress {
+	return &indexSendProgress{
+		sent: make(map[string]indexSendState),
+	}
+}
+
+// get returns the highest sequence sent for the given folder and index ID, or
+// zero if nothing was sent.
+func (p *indexSendProgress) get(folder string, indexID protocol.IndexID) int64 {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	if state, ok := p.sent[folder]; ok && state.indexID == indexID {
+		return state.sequence
+	}
+	return 0
+}
+
+func (p *indexSendProgress) set(folder string, indexID protocol.IndexID, sequence int64) {
+	p.mut.Lock()
+	p.sent[folder] = indexSendState{indexID, sequence}
+	p.mut.Unlock()
+}
+
+func (p *indexSendProgress) forget(folder string) {
+	p.mut.Lock()
+	delete(p.sent, folder)
+	p.mut.Unlock()
+}
+
+// The burst size of the index limiter fits one full batch of index data.
+const indexLimiterBurstSize = 2 * db.MaxBatchSizeBytes
+
+// indexSendLimit returns the rate limit for index data given the options.
+func indexSendLimit(opts config.OptionsConfiguration) rate.Limit {
+	if opts.MaxIndexSendKbps <= 0 {
+		return rate.Inf
+	}
+	return 1024 * rate.Limit(opts.MaxIndexSendKbps)
+}
+
+// waitForBytes waits until the limiter allows sending size bytes, in chunks
+// no larger than its burst size.
+func waitForBytes(ctx context.Context, limiter *rate.Limiter, size int) error {
+	if limiter.Limit() == rate.Inf {
+		return nil
+	}
+	for size > 0 {
+		n := size
+		if burst := limiter.Burst(); n > burst {
+			n = burst
+		}
+		if err := limiter.WaitN(ctx, n); err != nil {
+			return err
+		}
+		size -= n
+	}
+	return nil
+}
+
 type indexHandlerRegistry struct {
 	evLogger      events.Logger
 	conn          protocol.Connection
 	downloads     *deviceDownloadState
+	progress      *indexSendProgress
+	limiter       *rate.Limiter
 	indexHandlers *serviceMap[string, *indexHandler]
 	startInfos    map[string]*clusterConfigDeviceInfo
 	folderStates  map[string]*indexHandlerFolderState
+	metered       bool
 	mut           sync.Mutex
 }
 
@@ -385,11 +513,13 @@ type indexHandlerFolderState struct {
 	runner service
 }
 
-func newIndexHandlerRegistry(conn protocol.Connection, downloads *deviceDownloadState, evLogger events.Logger) *indexHandlerRegistry {
+func newIndexHandlerRegistry(conn protocol.Connection, downloads *deviceDownloadState, limiter *rate.Limiter, evLogger events.Logger) *indexHandlerRegistry {
 	r := &indexHandlerRegistry{
 		evLogger:      evLogger,
 		conn:          conn,
 		downloads:     downloads,
+		progress:      newIndexSendProgress(),
+		limiter:       limiter,
 		indexHandlers: newServiceMap[string, *indexHandler](evLogger),
 		startInfos:    make(map[string]*clusterConfigDeviceInfo),
 		folderStates:  make(map[string]*indexHandlerFolderState),
@@ -412,13 +542,37 @@ func (r *indexHandlerRegistry) startLocked(folder config.FolderConfiguration, fs
 	r.indexHandlers.RemoveAndWait(folder.ID, 0)
 	delete(r.startInfos, folder.ID)
 
-	is := newIndexHandler(r.conn, r.downloads, folder, fset, runner, startInfo, r.evLogger)
+	is := newIndexHandler(r.conn, r.downloads, folder, fset, runner, startInfo, r.progress, r.limiter, r.evLogger)
+	is.held = r.holdsLocked(folder)
 	r.indexHandlers.Add(folder.ID, is)
 
 	// This new connection might help us get in sync.
 	runner.SchedulePull()
 }
 
+// holdsLocked returns whether sending the index for the folder is deferred
+// as the connection is metered.
+func (r *indexHandlerRegistry) holdsLocked(folder config.FolderConfiguration) bool {
+	return r.metered && !folder.SyncWhenMetered
+}
+
+// SetMetered sets whether the connection is metered, in which case sending
+// the index is deferred for folders that aren't set to sync when metered.
+func (r *indexHandlerRegistry) SetMetered(metered bool) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if r.metered == metered {
+		return
+	}
+	r.metered = metered
+	r.indexHandlers.Each(func(folder string, is *indexHandler) {
+		if state, ok := r.folderStates[folder]; ok {
+			is.hold(r.holdsLocked(state.cfg))
+		}
+	})
+}
+
 // AddIndexInfo starts an index handler for given folder, unless it is paused.
 // If it is paused, the given startInfo is stored to start the sender once the
 // folder is resumed.
@@ -448,6 +602,7 @@ func (r *indexHandlerRegistry) Remove(folder string) {
 	l.Debugf("Removing index handler for device %v and folder %v", r.conn.DeviceID().Short(), folder)
 	r.indexHandlers.RemoveAndWait(folder, 0)
 	delete(r.startInfos, folder)
+	r.progress.forget(folder)
 	l.Debugf("Removed index handler for device %v and folder %v", r.conn.DeviceID().Short(), folder)
 }
 
@@ -461,6 +616,7 @@ func (r *indexHandlerRegistry) RemoveAllExcept(except map[string]remoteFolderSta
 	r.indexHandlers.Each(func(folder string, is *indexHandler) {
 		if _, ok := except[folder]; !ok {
 			r.indexHandlers.RemoveAndWait(folder, 0)
+			r.progress.forget(folder)
 			l.Debugf("Removed index handler for device %v and folder %v (removeAllExcept)", r.conn.DeviceID().Short(), folder)
 		}
 	})
@@ -524,6 +680,7 @@ func (r *indexHandlerRegistry) folderRunningLocked(folder config.FolderConfigura
 		l.Debugf("Started index handler for device %v and folder %v in resume", r.conn.DeviceID().Short(), folder.ID)
 	} else if isOk {
 		l.Debugf("Resuming index handler for device %v and folder %v", r.conn.DeviceID().Short(), folder)
+		is.hold(r.holdsLocked(folder))
 		is.resume(fset, runner)
 	} else {
 		l.Debugf("Not resuming index handler for device %v and folder %v as none is paused and there is no start info", r.conn.DeviceID().Short(), folder.ID)
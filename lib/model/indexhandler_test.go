@@ -0,0 +1,176 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"golang.org/x/time/rate"
+
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestIndexHandlerResumesFromSentProgress(t *testing.T) {
+	ldb, err := db.NewLowlevel(backend.OpenMemory(), events.NoopLogger)
+	must(t, err)
+	defer ldb.Close()
+	fcfg := newFolderConfig()
+	fset := newFileSet(t, fcfg.ID, ldb)
+
+	var files []protocol.FileInfo
+	for i := 0; i < 10; i++ {
+		files = append(files, protocol.FileInfo{Name: fmt.Sprint("file", i), Version: protocol.Vector{}.Update(myID.Short())})
+	}
+	fset.Update(protocol.LocalDeviceID, files)
+	indexID := fset.IndexID(protocol.LocalDeviceID)
+
+	conn := newFakeConnection(device1, nil)
+	startInfo := &clusterConfigDeviceInfo{
+		local:  protocol.Device{IndexID: indexID, MaxSequence: 3},
+		remote: protocol.Device{IndexID: 1},
+	}
+	progress := newIndexSendProgress()
+	limiter := rate.NewLimiter(rate.Inf, indexLimiterBurstSize)
+
+	// Nothing sent yet, start where the other side says.
+	is := newIndexHandler(conn, nil, fcfg, fset, nil, startInfo, progress, limiter, events.NoopLogger)
+	if is.prevSequence != 3 {
+		t.Fatalf("expected start at sequence 3, got %d", is.prevSequence)
+	}
+	must(t, is.sendIndexTo(context.Background(), fset))
+	if sent := progress.get(fcfg.ID, indexID); sent != 10 {
+		t.Fatalf("expected progress at sequence 10, got %d", sent)
+	}
+
+	// A restarted handler doesn't send what was already sent, even though
+	// the cluster config doesn't reflect it.
+	is = newIndexHandler(conn, nil, fcfg, fset, nil, startInfo, progress, limiter, events.NoopLogger)
+	if is.prevSequence != 10 {
+		t.Errorf("expected resume at sequence 10, got %d", is.prevSequence)
+	}
+
+	// Progress for another index ID is irrelevant.
+	if sent := progress.get(fcfg.ID, indexID+1); sent != 0 {
+		t.Errorf("expected no progress for other index ID, got %d", sent)
+	}
+	progress.forget(fcfg.ID)
+	is = newIndexHandler(conn, nil, fcfg, fset, nil, startInfo, progress, limiter, events.NoopLogger)
+	if is.prevSequence != 3 {
+		t.Errorf("expected start at sequence 3 after forgetting, got %d", is.prevSequence)
+	}
+}
+
+func TestIndexHandlerResendsAfterReconnect(t *testing.T) {
+	ldb, err := db.NewLowlevel(backend.OpenMemory(), events.NoopLogger)
+	must(t, err)
+	defer ldb.Close()
+	fcfg := newFolderConfig()
+	fset := newFileSet(t, fcfg.ID, ldb)
+
+	var files []protocol.FileInfo
+	for i := 0; i < 10; i++ {
+		files = append(files, protocol.FileInfo{Name: fmt.Sprint("file", i), Version: protocol.Vector{}.Update(myID.Short())})
+	}
+	fset.Update(protocol.LocalDeviceID, files)
+
+	// The other side acknowledged sequence 3, in the cluster config.
+	startInfo := &clusterConfigDeviceInfo{
+		local:  protocol.Device{IndexID: fset.IndexID(protocol.LocalDeviceID), MaxSequence: 3},
+		remote: protocol.Device{IndexID: 1},
+	}
+	limiter := rate.NewLimiter(rate.Inf, indexLimiterBurstSize)
+	connect := func() (*fakeConnection, *[]string) {
+		conn := newFakeConnection(device1, nil)
+		var sent []string
+		conn.setIndexFn(func(_ context.Context, _ string, fs []protocol.FileInfo) error {
+			for _, f := range fs {
+				sent = append(sent, f.Name)
+			}
+			return nil
+		})
+		return conn, &sent
+	}
+
+	// The rest is sent, but the connection goes down before the other side
+	// processed it.
+	conn, sent := connect()
+	registry := newIndexHandlerRegistry(conn, nil, limiter, events.NoopLogger)
+	is := newIndexHandler(conn, nil, fcfg, fset, nil, startInfo, registry.progress, limiter, events.NoopLogger)
+	must(t, is.sendIndexTo(context.Background(), fset))
+	if len(*sent) != 7 {
+		t.Fatalf("expected 7 files sent, got %v", *sent)
+	}
+
+	// The unacknowledged files are sent again on the next connection.
+	conn, sent = connect()
+	registry = newIndexHandlerRegistry(conn, nil, limiter, events.NoopLogger)
+	is = newIndexHandler(conn, nil, fcfg, fset, nil, startInfo, registry.progress, limiter, events.NoopLogger)
+	if is.prevSequence != 3 {
+		t.Fatalf("expected start at the acknowledged sequence 3, got %d", is.prevSequence)
+	}
+	must(t, is.sendIndexTo(context.Background(), fset))
+	if len(*sent) != 7 {
+		t.Errorf("expected the 7 unacknowledged files to be sent again, got %v", *sent)
+	}
+}
+
+func TestWaitForBytes(t *testing.T) {
+	limiter := rate.NewLimiter(rate.Inf, 10)
+	must(t, waitForBytes(context.Background(), limiter, 100))
+
+	// Larger than the burst size, but the tokens keep coming quickly.
+	limiter.SetLimit(1 << 20)
+	must(t, waitForBytes(context.Background(), limiter, 100))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := waitForBytes(ctx, limiter, 100); err == nil {
+		t.Error("expected error for cancelled context")
+	}
+}
+
+func TestIndexHandlerKeepsTransactionsTogether(t *testing.T) {
+	ldb, err := db.NewLowlevel(backend.OpenMemory(), events.NoopLogger)
+	must(t, err)
+	defer ldb.Close()
+	fcfg := newFolderConfig()
+	fset := newFileSet(t, fcfg.ID, ldb)
+
+	// One more file than fits in a batch, with the last two in a
+	// transaction, so that the batch overflows instead of splitting the
+	// transaction.
+	var files []protocol.FileInfo
+	for i := 0; i <= db.MaxBatchSizeFiles; i++ {
+		files = append(files, protocol.FileInfo{Name: fmt.Sprintf("file%05d", i), Version: protocol.Vector{}.Update(myID.Short())})
+	}
+	files[len(files)-2].TransactionID = 1
+	files[len(files)-1].TransactionID = 1
+	fset.Update(protocol.LocalDeviceID, files)
+
+	conn := newFakeConnection(device1, nil)
+	var sent []int
+	conn.setIndexFn(func(_ context.Context, _ string, fs []protocol.FileInfo) error {
+		sent = append(sent, len(fs))
+		return nil
+	})
+	startInfo := &clusterConfigDeviceInfo{
+		local:  protocol.Device{IndexID: fset.IndexID(protocol.LocalDeviceID)},
+		remote: protocol.Device{IndexID: 1},
+	}
+	limiter := rate.NewLimiter(rate.Inf, indexLimiterBurstSize)
+	is := newIndexHandler(conn, nil, fcfg, fset, nil, startInfo, newIndexSendProgress(), limiter, events.NoopLogger)
+	must(t, is.sendIndexTo(context.Background(), fset))
+
+	if len(sent) != 1 || sent[0] != db.MaxBatchSizeFiles+1 {
+		t.Errorf("unexpected batches %v", sent)
+	}
+}
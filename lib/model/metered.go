@@ -0,0 +1,91 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/connections"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// isMeteredConnection returns whether the connection to the device is
+// metered: The device is marked as metered and the connection is neither on
+// the local network nor to one of the networks declared as unmetered.
+func isMeteredConnection(device config.DeviceConfiguration, conn protocol.ConnectionInfo, unmetered []string) bool {
+	if !device.Metered || conn.IsLocal() {
+		return false
+	}
+	if addr := conn.RemoteAddr(); addr != nil && len(unmetered) > 0 && connections.IsAllowedNetwork(addr.String(), unmetered) {
+		return false
+	}
+	return true
+}
+
+// updateMeteredPLocked determines whether syncing with the device is
+// deferred as the connection is metered and not approved by the user, and
+// returns true if it no longer is. While deferred, our index is sent and
+// files are pulled only for folders set to sync when metered.
+func (m *model) updateMeteredPLocked(deviceID protocol.DeviceID, device config.DeviceConfiguration, opts config.OptionsConfiguration) bool {
+	conn, ok := m.conn[deviceID]
+	if !ok {
+		return false
+	}
+	deferred := !m.meteredApproved[deviceID] && isMeteredConnection(device, conn, opts.UnmeteredNetworks)
+	was := m.meteredDeferred[deviceID]
+	if deferred {
+		m.meteredDeferred[deviceID] = true
+	} else {
+		delete(m.meteredDeferred, deviceID)
+	}
+	if deferred != was {
+		if deferred {
+			l.Infof("Connection to %v is metered, deferring sync of folders not set to sync when metered", deviceID)
+		} else {
+			l.Infof("Connection to %v at %s is no longer treated as metered", deviceID, conn)
+		}
+	}
+	if r, ok := m.indexHandlers.Get(deviceID); ok {
+		r.SetMetered(deferred)
+	}
+	return was && !deferred
+}
+
+// ApproveMetered resumes syncing all folders with the device over the
+// current, metered connection.
+func (m *model) ApproveMetered(deviceID protocol.DeviceID) error {
+	device, ok := m.cfg.Device(deviceID)
+	if !ok {
+		return errDeviceUnknown
+	}
+	opts := m.cfg.Options()
+
+	m.pmut.Lock()
+	if _, ok := m.conn[deviceID]; !ok {
+		m.pmut.Unlock()
+		return errDeviceNotConnected
+	}
+	m.meteredApproved[deviceID] = true
+	resumed := m.updateMeteredPLocked(deviceID, device, opts)
+	m.pmut.Unlock()
+
+	if resumed {
+		m.schedulePullsWith(deviceID)
+	}
+	return nil
+}
+
+// schedulePullsWith schedules pulls for the folders shared with the device,
+// as it might now help us get in sync.
+func (m *model) schedulePullsWith(deviceID protocol.DeviceID) {
+	m.fmut.RLock()
+	defer m.fmut.RUnlock()
+	for id, cfg := range m.folderCfgs {
+		if runner, ok := m.folderRunners[id]; ok && cfg.SharedWith(deviceID) {
+			runner.SchedulePull()
+		}
+	}
+}
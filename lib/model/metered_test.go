@@ -0,0 +1,62 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestMeteredConnectionDefersSync(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	waiter, err := w.Modify(func(cfg *config.Configuration) {
+		dev, _, _ := cfg.Device(device1)
+		dev.Metered = true
+		cfg.SetDevice(dev)
+	})
+	must(t, err)
+	waiter.Wait()
+
+	m, fc := setupModelWithConnectionFromWrapper(t, w)
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem(nil).URI())
+
+	indexSent := make(chan struct{}, 1)
+	fc.setIndexFn(func(_ context.Context, _ string, _ []protocol.FileInfo) error {
+		select {
+		case indexSent <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+
+	if ci := m.ConnectionStats()["connections"].(map[string]ConnectionInfo)[device1.String()]; !ci.Metered {
+		t.Error("expected the connection to be reported as metered")
+	}
+
+	fc.addFile("foo", 0o644, protocol.FileInfoTypeFile, []byte("data"))
+	fc.sendIndexUpdate()
+	file := fc.files[0]
+	if av := m.testAvailability(fcfg.ID, file, file.Blocks[0]); len(av) != 0 {
+		t.Errorf("expected no availability over a metered connection, got %v", av)
+	}
+
+	must(t, m.ApproveMetered(device1))
+
+	if av := m.testAvailability(fcfg.ID, file, file.Blocks[0]); len(av) != 1 || av[0].ID != device1 {
+		t.Errorf("expected availability from %v after approval, got %v", device1, av)
+	}
+	select {
+	case <-indexSent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the index to be sent after approval")
+	}
+}
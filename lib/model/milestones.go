@@ -0,0 +1,61 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// updateCompletionDirectories checks which of the completion directories
+// of the folder are complete, i.e. exist and have nothing needed in them,
+// and emits a DirectoryCompleted event for those that became complete
+// since the last check. Directories that are complete when first checked
+// are taken to have been so all along.
+func (f *folder) updateCompletionDirectories(snap *db.Snapshot) {
+	if len(f.CompletionDirectories) == 0 {
+		return
+	}
+
+	dirs := make([]string, len(f.CompletionDirectories))
+	incomplete := make(map[string]bool, len(dirs))
+	for i, dir := range f.CompletionDirectories {
+		dirs[i] = filepath.FromSlash(dir)
+		if gf, ok := snap.GetGlobalTruncated(dirs[i]); !ok || gf.IsDeleted() {
+			incomplete[dir] = true
+		}
+	}
+	snap.WithNeedTruncated(protocol.LocalDeviceID, func(intf protocol.FileIntf) bool {
+		name := intf.FileName()
+		for i, dir := range dirs {
+			if name == dir || strings.HasPrefix(name, dir+string(fs.PathSeparator)) {
+				incomplete[f.CompletionDirectories[i]] = true
+			}
+		}
+		return len(incomplete) < len(dirs)
+	})
+
+	if f.completeDirs == nil {
+		f.completeDirs = make(map[string]bool, len(dirs))
+	}
+	for _, dir := range f.CompletionDirectories {
+		complete := !incomplete[dir]
+		if wasComplete, ok := f.completeDirs[dir]; ok && !wasComplete && complete {
+			l.Debugf("%v completion directory %s is complete", f, dir)
+			f.evLogger.Log(events.DirectoryCompleted, map[string]string{
+				"folder":    f.ID,
+				"directory": dir,
+			})
+		}
+		f.completeDirs[dir] = complete
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestCompletionDirectories(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	fcfg.CompletionDirectories = []string{"dataset", "other"}
+	setFolder(t, w, fcfg)
+	m, fc := setupModelWithConnectionFromWrapper(t, w)
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem(nil).URI())
+
+	sub := m.evLogger.Subscribe(events.DirectoryCompleted)
+	defer sub.Unsubscribe()
+
+	fc.addFile("dataset", 0o755, protocol.FileInfoTypeDirectory, nil)
+	fc.addFile("dataset/part1", 0o644, protocol.FileInfoTypeFile, []byte("part1"))
+	fc.addFile("dataset/sub/part2", 0o644, protocol.FileInfoTypeFile, []byte("part2"))
+	fc.sendIndexUpdate()
+
+	ev, err := sub.Poll(10 * time.Second)
+	if err != nil {
+		t.Fatal("timed out waiting for the directory to complete")
+	}
+	data := ev.Data.(map[string]string)
+	if data["folder"] != fcfg.ID || data["directory"] != "dataset" {
+		t.Errorf("unexpected event data %v", data)
+	}
+
+	// The other directory doesn't exist, so it isn't complete.
+	if ev, err := sub.Poll(time.Second); err != events.ErrTimeout {
+		t.Errorf("expected no further events, got %v", ev)
+	}
+}
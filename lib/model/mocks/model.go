@@ -17,12 +17,44 @@ import (
 )
 
 type Model struct {
+	AbortFolderMoveStub        func() error
+	abortFolderMoveMutex       sync.RWMutex
+	abortFolderMoveArgsForCall []struct {
+	}
+	abortFolderMoveReturns struct {
+		result1 error
+	}
+	abortFolderMoveReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AcquireRestartStub        func(context.Context) error
+	acquireRestartMutex       sync.RWMutex
+	acquireRestartArgsForCall []struct {
+		arg1 context.Context
+	}
+	acquireRestartReturns struct {
+		result1 error
+	}
+	acquireRestartReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AddConnectionStub        func(protocol.Connection, protocol.Hello)
 	addConnectionMutex       sync.RWMutex
 	addConnectionArgsForCall []struct {
 		arg1 protocol.Connection
 		arg2 protocol.Hello
 	}
+	ApproveMeteredStub        func(protocol.DeviceID) error
+	approveMeteredMutex       sync.RWMutex
+	approveMeteredArgsForCall []struct {
+		arg1 protocol.DeviceID
+	}
+	approveMeteredReturns struct {
+		result1 error
+	}
+	approveMeteredReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AvailabilityStub        func(string, protocol.FileInfo, protocol.BlockInfo) ([]model.Availability, error)
 	availabilityMutex       sync.RWMutex
 	availabilityArgsForCall []struct {
@@ -38,12 +70,50 @@ type Model struct {
 		result1 []model.Availability
 		result2 error
 	}
+	BackupSnapshotFilesStub        func(string, int, int) (model.BackupSnapshot, []db.FileInfoTruncated, error)
+	backupSnapshotFilesMutex       sync.RWMutex
+	backupSnapshotFilesArgsForCall []struct {
+		arg1 string
+		arg2 int
+		arg3 int
+	}
+	backupSnapshotFilesReturns struct {
+		result1 model.BackupSnapshot
+		result2 []db.FileInfoTruncated
+		result3 error
+	}
+	backupSnapshotFilesReturnsOnCall map[int]struct {
+		result1 model.BackupSnapshot
+		result2 []db.FileInfoTruncated
+		result3 error
+	}
+	BlockPoolStatusStub        func() model.BlockPoolStatus
+	blockPoolStatusMutex       sync.RWMutex
+	blockPoolStatusArgsForCall []struct {
+	}
+	blockPoolStatusReturns struct {
+		result1 model.BlockPoolStatus
+	}
+	blockPoolStatusReturnsOnCall map[int]struct {
+		result1 model.BlockPoolStatus
+	}
 	BringToFrontStub        func(string, string)
 	bringToFrontMutex       sync.RWMutex
 	bringToFrontArgsForCall []struct {
 		arg1 string
 		arg2 string
 	}
+	CancelFolderCleanupStub        func(string) error
+	cancelFolderCleanupMutex       sync.RWMutex
+	cancelFolderCleanupArgsForCall []struct {
+		arg1 string
+	}
+	cancelFolderCleanupReturns struct {
+		result1 error
+	}
+	cancelFolderCleanupReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ClosedStub        func(protocol.Connection, error)
 	closedMutex       sync.RWMutex
 	closedArgsForCall []struct {
@@ -62,6 +132,22 @@ type Model struct {
 	clusterConfigReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ClusterFoldersStub        func(string, string) []model.AdvertisedFolder
+	clusterFoldersMutex       sync.RWMutex
+	clusterFoldersArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	clusterFoldersReturns struct {
+		result1 []model.AdvertisedFolder
+	}
+	clusterFoldersReturnsOnCall map[int]struct {
+		result1 []model.AdvertisedFolder
+	}
+	CollectBlockPoolStub        func()
+	collectBlockPoolMutex       sync.RWMutex
+	collectBlockPoolArgsForCall []struct {
+	}
 	CompletionStub        func(protocol.DeviceID, string) (model.FolderCompletion, error)
 	completionMutex       sync.RWMutex
 	completionArgsForCall []struct {
@@ -99,6 +185,32 @@ type Model struct {
 	connectionStatsReturnsOnCall map[int]struct {
 		result1 map[string]interface{}
 	}
+	ControlStub        func(protocol.Connection, protocol.Control) error
+	controlMutex       sync.RWMutex
+	controlArgsForCall []struct {
+		arg1 protocol.Connection
+		arg2 protocol.Control
+	}
+	controlReturns struct {
+		result1 error
+	}
+	controlReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateBackupSnapshotStub        func(string, time.Duration) (model.BackupSnapshot, error)
+	createBackupSnapshotMutex       sync.RWMutex
+	createBackupSnapshotArgsForCall []struct {
+		arg1 string
+		arg2 time.Duration
+	}
+	createBackupSnapshotReturns struct {
+		result1 model.BackupSnapshot
+		result2 error
+	}
+	createBackupSnapshotReturnsOnCall map[int]struct {
+		result1 model.BackupSnapshot
+		result2 error
+	}
 	CurrentFolderFileStub        func(string, string) (protocol.FileInfo, bool, error)
 	currentFolderFileMutex       sync.RWMutex
 	currentFolderFileArgsForCall []struct {
@@ -165,6 +277,22 @@ type Model struct {
 		arg1 string
 		arg2 time.Duration
 	}
+	DeviceDifferencesStub        func(string, protocol.DeviceID, int, int) ([]model.FileDifference, error)
+	deviceDifferencesMutex       sync.RWMutex
+	deviceDifferencesArgsForCall []struct {
+		arg1 string
+		arg2 protocol.DeviceID
+		arg3 int
+		arg4 int
+	}
+	deviceDifferencesReturns struct {
+		result1 []model.FileDifference
+		result2 error
+	}
+	deviceDifferencesReturnsOnCall map[int]struct {
+		result1 []model.FileDifference
+		result2 error
+	}
 	DeviceStatisticsStub        func() (map[protocol.DeviceID]stats.DeviceStatistics, error)
 	deviceStatisticsMutex       sync.RWMutex
 	deviceStatisticsArgsForCall []struct {
@@ -213,6 +341,78 @@ type Model struct {
 	downloadProgressReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DropDeviceIndexStub        func(string, protocol.DeviceID, bool) error
+	dropDeviceIndexMutex       sync.RWMutex
+	dropDeviceIndexArgsForCall []struct {
+		arg1 string
+		arg2 protocol.DeviceID
+		arg3 bool
+	}
+	dropDeviceIndexReturns struct {
+		result1 error
+	}
+	dropDeviceIndexReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FailBackStub        func() error
+	failBackMutex       sync.RWMutex
+	failBackArgsForCall []struct {
+	}
+	failBackReturns struct {
+		result1 error
+	}
+	failBackReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FolderCapabilitiesStub        func(string) (model.FolderCapabilities, error)
+	folderCapabilitiesMutex       sync.RWMutex
+	folderCapabilitiesArgsForCall []struct {
+		arg1 string
+	}
+	folderCapabilitiesReturns struct {
+		result1 model.FolderCapabilities
+		result2 error
+	}
+	folderCapabilitiesReturnsOnCall map[int]struct {
+		result1 model.FolderCapabilities
+		result2 error
+	}
+	FolderCleanupsStub        func() []model.FolderCleanup
+	folderCleanupsMutex       sync.RWMutex
+	folderCleanupsArgsForCall []struct {
+	}
+	folderCleanupsReturns struct {
+		result1 []model.FolderCleanup
+	}
+	folderCleanupsReturnsOnCall map[int]struct {
+		result1 []model.FolderCleanup
+	}
+	FolderDatabaseSizeStub        func(string) (db.FolderSizeReport, error)
+	folderDatabaseSizeMutex       sync.RWMutex
+	folderDatabaseSizeArgsForCall []struct {
+		arg1 string
+	}
+	folderDatabaseSizeReturns struct {
+		result1 db.FolderSizeReport
+		result2 error
+	}
+	folderDatabaseSizeReturnsOnCall map[int]struct {
+		result1 db.FolderSizeReport
+		result2 error
+	}
+	FolderDeviceIndexesStub        func(string) ([]model.DeviceIndex, error)
+	folderDeviceIndexesMutex       sync.RWMutex
+	folderDeviceIndexesArgsForCall []struct {
+		arg1 string
+	}
+	folderDeviceIndexesReturns struct {
+		result1 []model.DeviceIndex
+		result2 error
+	}
+	folderDeviceIndexesReturnsOnCall map[int]struct {
+		result1 []model.DeviceIndex
+		result2 error
+	}
 	FolderErrorsStub        func(string) ([]model.FileError, error)
 	folderErrorsMutex       sync.RWMutex
 	folderErrorsArgsForCall []struct {
@@ -226,6 +426,18 @@ type Model struct {
 		result1 []model.FileError
 		result2 error
 	}
+	FolderMoveStub        func() (model.FolderMove, bool)
+	folderMoveMutex       sync.RWMutex
+	folderMoveArgsForCall []struct {
+	}
+	folderMoveReturns struct {
+		result1 model.FolderMove
+		result2 bool
+	}
+	folderMoveReturnsOnCall map[int]struct {
+		result1 model.FolderMove
+		result2 bool
+	}
 	FolderProgressBytesCompletedStub        func(string) int64
 	folderProgressBytesCompletedMutex       sync.RWMutex
 	folderProgressBytesCompletedArgsForCall []struct {
@@ -237,6 +449,19 @@ type Model struct {
 	folderProgressBytesCompletedReturnsOnCall map[int]struct {
 		result1 int64
 	}
+	FolderQuarantineStub        func(string) ([]model.FileError, error)
+	folderQuarantineMutex       sync.RWMutex
+	folderQuarantineArgsForCall []struct {
+		arg1 string
+	}
+	folderQuarantineReturns struct {
+		result1 []model.FileError
+		result2 error
+	}
+	folderQuarantineReturnsOnCall map[int]struct {
+		result1 []model.FileError
+		result2 error
+	}
 	FolderStatisticsStub        func() (map[string]stats.FolderStatistics, error)
 	folderStatisticsMutex       sync.RWMutex
 	folderStatisticsArgsForCall []struct {
@@ -249,6 +474,30 @@ type Model struct {
 		result1 map[string]stats.FolderStatistics
 		result2 error
 	}
+	FreezeFolderStub        func(string) error
+	freezeFolderMutex       sync.RWMutex
+	freezeFolderArgsForCall []struct {
+		arg1 string
+	}
+	freezeFolderReturns struct {
+		result1 error
+	}
+	freezeFolderReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FreezeStatusStub        func(string) (model.FreezeStatus, error)
+	freezeStatusMutex       sync.RWMutex
+	freezeStatusArgsForCall []struct {
+		arg1 string
+	}
+	freezeStatusReturns struct {
+		result1 model.FreezeStatus
+		result2 error
+	}
+	freezeStatusReturnsOnCall map[int]struct {
+		result1 model.FreezeStatus
+		result2 error
+	}
 	GetFolderVersionsStub        func(string) (map[string][]versioner.FileVersion, error)
 	getFolderVersionsMutex       sync.RWMutex
 	getFolderVersionsArgsForCall []struct {
@@ -303,6 +552,31 @@ type Model struct {
 		result1 []*model.TreeEntry
 		result2 error
 	}
+	HeldPathsStub        func(string) ([]model.HeldPath, error)
+	heldPathsMutex       sync.RWMutex
+	heldPathsArgsForCall []struct {
+		arg1 string
+	}
+	heldPathsReturns struct {
+		result1 []model.HeldPath
+		result2 error
+	}
+	heldPathsReturnsOnCall map[int]struct {
+		result1 []model.HeldPath
+		result2 error
+	}
+	HoldPathsStub        func(string, []string) error
+	holdPathsMutex       sync.RWMutex
+	holdPathsArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	holdPathsReturns struct {
+		result1 error
+	}
+	holdPathsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	IndexStub        func(protocol.Connection, string, []protocol.FileInfo) error
 	indexMutex       sync.RWMutex
 	indexArgsForCall []struct {
@@ -359,6 +633,39 @@ type Model struct {
 		result1 []db.FileInfoTruncated
 		result2 error
 	}
+	LockFolderStub        func(string) error
+	lockFolderMutex       sync.RWMutex
+	lockFolderArgsForCall []struct {
+		arg1 string
+	}
+	lockFolderReturns struct {
+		result1 error
+	}
+	lockFolderReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ManagedDevicesStub        func() map[protocol.DeviceID]model.ManagedDeviceStatus
+	managedDevicesMutex       sync.RWMutex
+	managedDevicesArgsForCall []struct {
+	}
+	managedDevicesReturns struct {
+		result1 map[protocol.DeviceID]model.ManagedDeviceStatus
+	}
+	managedDevicesReturnsOnCall map[int]struct {
+		result1 map[protocol.DeviceID]model.ManagedDeviceStatus
+	}
+	MoveFoldersStub        func(map[string]string, bool) error
+	moveFoldersMutex       sync.RWMutex
+	moveFoldersArgsForCall []struct {
+		arg1 map[string]string
+		arg2 bool
+	}
+	moveFoldersReturns struct {
+		result1 error
+	}
+	moveFoldersReturnsOnCall map[int]struct {
+		result1 error
+	}
 	NeedFolderFilesStub        func(string, int, int) ([]db.FileInfoTruncated, []db.FileInfoTruncated, []db.FileInfoTruncated, error)
 	needFolderFilesMutex       sync.RWMutex
 	needFolderFilesArgsForCall []struct {
@@ -401,6 +708,20 @@ type Model struct {
 	onHelloReturnsOnCall map[int]struct {
 		result1 error
 	}
+	OpenPlaintextStub        func(string, string) (fs.File, error)
+	openPlaintextMutex       sync.RWMutex
+	openPlaintextArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	openPlaintextReturns struct {
+		result1 fs.File
+		result2 error
+	}
+	openPlaintextReturnsOnCall map[int]struct {
+		result1 fs.File
+		result2 error
+	}
 	OverrideStub        func(string)
 	overrideMutex       sync.RWMutex
 	overrideArgsForCall []struct {
@@ -431,6 +752,70 @@ type Model struct {
 		result1 map[string]db.PendingFolder
 		result2 error
 	}
+	PurgeFolderVersionsStub        func(string, map[string]time.Time) (map[string]error, error)
+	purgeFolderVersionsMutex       sync.RWMutex
+	purgeFolderVersionsArgsForCall []struct {
+		arg1 string
+		arg2 map[string]time.Time
+	}
+	purgeFolderVersionsReturns struct {
+		result1 map[string]error
+		result2 error
+	}
+	purgeFolderVersionsReturnsOnCall map[int]struct {
+		result1 map[string]error
+		result2 error
+	}
+	PushControlTemplateStub        func(context.Context, protocol.DeviceID, model.ControlTemplate) error
+	pushControlTemplateMutex       sync.RWMutex
+	pushControlTemplateArgsForCall []struct {
+		arg1 context.Context
+		arg2 protocol.DeviceID
+		arg3 model.ControlTemplate
+	}
+	pushControlTemplateReturns struct {
+		result1 error
+	}
+	pushControlTemplateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RecycledItemsStub        func(string, time.Time) ([]model.RecycledItem, error)
+	recycledItemsMutex       sync.RWMutex
+	recycledItemsArgsForCall []struct {
+		arg1 string
+		arg2 time.Time
+	}
+	recycledItemsReturns struct {
+		result1 []model.RecycledItem
+		result2 error
+	}
+	recycledItemsReturnsOnCall map[int]struct {
+		result1 []model.RecycledItem
+		result2 error
+	}
+	ReleaseBackupSnapshotStub        func(string) error
+	releaseBackupSnapshotMutex       sync.RWMutex
+	releaseBackupSnapshotArgsForCall []struct {
+		arg1 string
+	}
+	releaseBackupSnapshotReturns struct {
+		result1 error
+	}
+	releaseBackupSnapshotReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReleasePathsStub        func(string, []string) error
+	releasePathsMutex       sync.RWMutex
+	releasePathsArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	releasePathsReturns struct {
+		result1 error
+	}
+	releasePathsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RemoteNeedFolderFilesStub        func(string, protocol.DeviceID, int, int) ([]db.FileInfoTruncated, error)
 	remoteNeedFolderFilesMutex       sync.RWMutex
 	remoteNeedFolderFilesArgsForCall []struct {
@@ -479,6 +864,28 @@ type Model struct {
 	resetFolderReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ResolveEmptiedFolderStub        func(string, bool) error
+	resolveEmptiedFolderMutex       sync.RWMutex
+	resolveEmptiedFolderArgsForCall []struct {
+		arg1 string
+		arg2 bool
+	}
+	resolveEmptiedFolderReturns struct {
+		result1 error
+	}
+	resolveEmptiedFolderReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RestartCoordinationStub        func() model.RestartCoordinationStatus
+	restartCoordinationMutex       sync.RWMutex
+	restartCoordinationArgsForCall []struct {
+	}
+	restartCoordinationReturns struct {
+		result1 model.RestartCoordinationStatus
+	}
+	restartCoordinationReturnsOnCall map[int]struct {
+		result1 model.RestartCoordinationStatus
+	}
 	RestoreFolderVersionsStub        func(string, map[string]time.Time) (map[string]error, error)
 	restoreFolderVersionsMutex       sync.RWMutex
 	restoreFolderVersionsArgsForCall []struct {
@@ -493,11 +900,38 @@ type Model struct {
 		result1 map[string]error
 		result2 error
 	}
+	RetryQuarantinedStub        func(string, []string) error
+	retryQuarantinedMutex       sync.RWMutex
+	retryQuarantinedArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	retryQuarantinedReturns struct {
+		result1 error
+	}
+	retryQuarantinedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RevertStub        func(string)
 	revertMutex       sync.RWMutex
 	revertArgsForCall []struct {
 		arg1 string
 	}
+	RevokeDeviceStub        func(context.Context, protocol.DeviceID, bool) (map[protocol.DeviceID]error, error)
+	revokeDeviceMutex       sync.RWMutex
+	revokeDeviceArgsForCall []struct {
+		arg1 context.Context
+		arg2 protocol.DeviceID
+		arg3 bool
+	}
+	revokeDeviceReturns struct {
+		result1 map[protocol.DeviceID]error
+		result2 error
+	}
+	revokeDeviceReturnsOnCall map[int]struct {
+		result1 map[protocol.DeviceID]error
+		result2 error
+	}
 	ScanFolderStub        func(string) error
 	scanFolderMutex       sync.RWMutex
 	scanFolderArgsForCall []struct {
@@ -531,6 +965,47 @@ type Model struct {
 	scanFoldersReturnsOnCall map[int]struct {
 		result1 map[string]error
 	}
+	ScanSummariesStub        func(string) ([]model.ScanSummary, error)
+	scanSummariesMutex       sync.RWMutex
+	scanSummariesArgsForCall []struct {
+		arg1 string
+	}
+	scanSummariesReturns struct {
+		result1 []model.ScanSummary
+		result2 error
+	}
+	scanSummariesReturnsOnCall map[int]struct {
+		result1 []model.ScanSummary
+		result2 error
+	}
+	ScrubBlockPoolStub        func()
+	scrubBlockPoolMutex       sync.RWMutex
+	scrubBlockPoolArgsForCall []struct {
+	}
+	ScrubFolderStub        func(string) error
+	scrubFolderMutex       sync.RWMutex
+	scrubFolderArgsForCall []struct {
+		arg1 string
+	}
+	scrubFolderReturns struct {
+		result1 error
+	}
+	scrubFolderReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ScrubStatusStub        func(string) (model.ScrubStatus, error)
+	scrubStatusMutex       sync.RWMutex
+	scrubStatusArgsForCall []struct {
+		arg1 string
+	}
+	scrubStatusReturns struct {
+		result1 model.ScrubStatus
+		result2 error
+	}
+	scrubStatusReturnsOnCall map[int]struct {
+		result1 model.ScrubStatus
+		result2 error
+	}
 	ServeStub        func(context.Context) error
 	serveMutex       sync.RWMutex
 	serveArgsForCall []struct {
@@ -554,6 +1029,16 @@ type Model struct {
 	setIgnoresReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StandbyStatusStub        func() model.StandbyStatus
+	standbyStatusMutex       sync.RWMutex
+	standbyStatusArgsForCall []struct {
+	}
+	standbyStatusReturns struct {
+		result1 model.StandbyStatus
+	}
+	standbyStatusReturnsOnCall map[int]struct {
+		result1 model.StandbyStatus
+	}
 	StartDeadlockDetectorStub        func(time.Duration)
 	startDeadlockDetectorMutex       sync.RWMutex
 	startDeadlockDetectorArgsForCall []struct {
@@ -574,6 +1059,43 @@ type Model struct {
 		result2 time.Time
 		result3 error
 	}
+	TransferStatisticsStub        func(time.Time, time.Time) ([]stats.DailyTransferStatistics, error)
+	transferStatisticsMutex       sync.RWMutex
+	transferStatisticsArgsForCall []struct {
+		arg1 time.Time
+		arg2 time.Time
+	}
+	transferStatisticsReturns struct {
+		result1 []stats.DailyTransferStatistics
+		result2 error
+	}
+	transferStatisticsReturnsOnCall map[int]struct {
+		result1 []stats.DailyTransferStatistics
+		result2 error
+	}
+	UnfreezeFolderStub        func(string) error
+	unfreezeFolderMutex       sync.RWMutex
+	unfreezeFolderArgsForCall []struct {
+		arg1 string
+	}
+	unfreezeFolderReturns struct {
+		result1 error
+	}
+	unfreezeFolderReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UnlockFolderStub        func(string, string) error
+	unlockFolderMutex       sync.RWMutex
+	unlockFolderArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	unlockFolderReturns struct {
+		result1 error
+	}
+	unlockFolderReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UsageReportingStatsStub        func(*contract.Report, int, bool)
 	usageReportingStatsMutex       sync.RWMutex
 	usageReportingStatsArgsForCall []struct {
@@ -596,6 +1118,120 @@ type Model struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *Model) AbortFolderMove() error {
+	fake.abortFolderMoveMutex.Lock()
+	ret, specificReturn := fake.abortFolderMoveReturnsOnCall[len(fake.abortFolderMoveArgsForCall)]
+	fake.abortFolderMoveArgsForCall = append(fake.abortFolderMoveArgsForCall, struct {
+	}{})
+	stub := fake.AbortFolderMoveStub
+	fakeReturns := fake.abortFolderMoveReturns
+	fake.recordInvocation("AbortFolderMove", []interface{}{})
+	fake.abortFolderMoveMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) AbortFolderMoveCallCount() int {
+	fake.abortFolderMoveMutex.RLock()
+	defer fake.abortFolderMoveMutex.RUnlock()
+	return len(fake.abortFolderMoveArgsForCall)
+}
+
+func (fake *Model) AbortFolderMoveCalls(stub func() error) {
+	fake.abortFolderMoveMutex.Lock()
+	defer fake.abortFolderMoveMutex.Unlock()
+	fake.AbortFolderMoveStub = stub
+}
+
+func (fake *Model) AbortFolderMoveReturns(result1 error) {
+	fake.abortFolderMoveMutex.Lock()
+	defer fake.abortFolderMoveMutex.Unlock()
+	fake.AbortFolderMoveStub = nil
+	fake.abortFolderMoveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) AbortFolderMoveReturnsOnCall(i int, result1 error) {
+	fake.abortFolderMoveMutex.Lock()
+	defer fake.abortFolderMoveMutex.Unlock()
+	fake.AbortFolderMoveStub = nil
+	if fake.abortFolderMoveReturnsOnCall == nil {
+		fake.abortFolderMoveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.abortFolderMoveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) AcquireRestart(arg1 context.Context) error {
+	fake.acquireRestartMutex.Lock()
+	ret, specificReturn := fake.acquireRestartReturnsOnCall[len(fake.acquireRestartArgsForCall)]
+	fake.acquireRestartArgsForCall = append(fake.acquireRestartArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.AcquireRestartStub
+	fakeReturns := fake.acquireRestartReturns
+	fake.recordInvocation("AcquireRestart", []interface{}{arg1})
+	fake.acquireRestartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) AcquireRestartCallCount() int {
+	fake.acquireRestartMutex.RLock()
+	defer fake.acquireRestartMutex.RUnlock()
+	return len(fake.acquireRestartArgsForCall)
+}
+
+func (fake *Model) AcquireRestartCalls(stub func(context.Context) error) {
+	fake.acquireRestartMutex.Lock()
+	defer fake.acquireRestartMutex.Unlock()
+	fake.AcquireRestartStub = stub
+}
+
+func (fake *Model) AcquireRestartArgsForCall(i int) context.Context {
+	fake.acquireRestartMutex.RLock()
+	defer fake.acquireRestartMutex.RUnlock()
+	argsForCall := fake.acquireRestartArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) AcquireRestartReturns(result1 error) {
+	fake.acquireRestartMutex.Lock()
+	defer fake.acquireRestartMutex.Unlock()
+	fake.AcquireRestartStub = nil
+	fake.acquireRestartReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) AcquireRestartReturnsOnCall(i int, result1 error) {
+	fake.acquireRestartMutex.Lock()
+	defer fake.acquireRestartMutex.Unlock()
+	fake.AcquireRestartStub = nil
+	if fake.acquireRestartReturnsOnCall == nil {
+		fake.acquireRestartReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.acquireRestartReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Model) AddConnection(arg1 protocol.Connection, arg2 protocol.Hello) {
 	fake.addConnectionMutex.Lock()
 	fake.addConnectionArgsForCall = append(fake.addConnectionArgsForCall, struct {
@@ -629,6 +1265,67 @@ func (fake *Model) AddConnectionArgsForCall(i int) (protocol.Connection, protoco
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *Model) ApproveMetered(arg1 protocol.DeviceID) error {
+	fake.approveMeteredMutex.Lock()
+	ret, specificReturn := fake.approveMeteredReturnsOnCall[len(fake.approveMeteredArgsForCall)]
+	fake.approveMeteredArgsForCall = append(fake.approveMeteredArgsForCall, struct {
+		arg1 protocol.DeviceID
+	}{arg1})
+	stub := fake.ApproveMeteredStub
+	fakeReturns := fake.approveMeteredReturns
+	fake.recordInvocation("ApproveMetered", []interface{}{arg1})
+	fake.approveMeteredMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) ApproveMeteredCallCount() int {
+	fake.approveMeteredMutex.RLock()
+	defer fake.approveMeteredMutex.RUnlock()
+	return len(fake.approveMeteredArgsForCall)
+}
+
+func (fake *Model) ApproveMeteredCalls(stub func(protocol.DeviceID) error) {
+	fake.approveMeteredMutex.Lock()
+	defer fake.approveMeteredMutex.Unlock()
+	fake.ApproveMeteredStub = stub
+}
+
+func (fake *Model) ApproveMeteredArgsForCall(i int) protocol.DeviceID {
+	fake.approveMeteredMutex.RLock()
+	defer fake.approveMeteredMutex.RUnlock()
+	argsForCall := fake.approveMeteredArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) ApproveMeteredReturns(result1 error) {
+	fake.approveMeteredMutex.Lock()
+	defer fake.approveMeteredMutex.Unlock()
+	fake.ApproveMeteredStub = nil
+	fake.approveMeteredReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) ApproveMeteredReturnsOnCall(i int, result1 error) {
+	fake.approveMeteredMutex.Lock()
+	defer fake.approveMeteredMutex.Unlock()
+	fake.ApproveMeteredStub = nil
+	if fake.approveMeteredReturnsOnCall == nil {
+		fake.approveMeteredReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.approveMeteredReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Model) Availability(arg1 string, arg2 protocol.FileInfo, arg3 protocol.BlockInfo) ([]model.Availability, error) {
 	fake.availabilityMutex.Lock()
 	ret, specificReturn := fake.availabilityReturnsOnCall[len(fake.availabilityArgsForCall)]
@@ -695,6 +1392,128 @@ func (fake *Model) AvailabilityReturnsOnCall(i int, result1 []model.Availability
 	}{result1, result2}
 }
 
+func (fake *Model) BackupSnapshotFiles(arg1 string, arg2 int, arg3 int) (model.BackupSnapshot, []db.FileInfoTruncated, error) {
+	fake.backupSnapshotFilesMutex.Lock()
+	ret, specificReturn := fake.backupSnapshotFilesReturnsOnCall[len(fake.backupSnapshotFilesArgsForCall)]
+	fake.backupSnapshotFilesArgsForCall = append(fake.backupSnapshotFilesArgsForCall, struct {
+		arg1 string
+		arg2 int
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.BackupSnapshotFilesStub
+	fakeReturns := fake.backupSnapshotFilesReturns
+	fake.recordInvocation("BackupSnapshotFiles", []interface{}{arg1, arg2, arg3})
+	fake.backupSnapshotFilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *Model) BackupSnapshotFilesCallCount() int {
+	fake.backupSnapshotFilesMutex.RLock()
+	defer fake.backupSnapshotFilesMutex.RUnlock()
+	return len(fake.backupSnapshotFilesArgsForCall)
+}
+
+func (fake *Model) BackupSnapshotFilesCalls(stub func(string, int, int) (model.BackupSnapshot, []db.FileInfoTruncated, error)) {
+	fake.backupSnapshotFilesMutex.Lock()
+	defer fake.backupSnapshotFilesMutex.Unlock()
+	fake.BackupSnapshotFilesStub = stub
+}
+
+func (fake *Model) BackupSnapshotFilesArgsForCall(i int) (string, int, int) {
+	fake.backupSnapshotFilesMutex.RLock()
+	defer fake.backupSnapshotFilesMutex.RUnlock()
+	argsForCall := fake.backupSnapshotFilesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Model) BackupSnapshotFilesReturns(result1 model.BackupSnapshot, result2 []db.FileInfoTruncated, result3 error) {
+	fake.backupSnapshotFilesMutex.Lock()
+	defer fake.backupSnapshotFilesMutex.Unlock()
+	fake.BackupSnapshotFilesStub = nil
+	fake.backupSnapshotFilesReturns = struct {
+		result1 model.BackupSnapshot
+		result2 []db.FileInfoTruncated
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *Model) BackupSnapshotFilesReturnsOnCall(i int, result1 model.BackupSnapshot, result2 []db.FileInfoTruncated, result3 error) {
+	fake.backupSnapshotFilesMutex.Lock()
+	defer fake.backupSnapshotFilesMutex.Unlock()
+	fake.BackupSnapshotFilesStub = nil
+	if fake.backupSnapshotFilesReturnsOnCall == nil {
+		fake.backupSnapshotFilesReturnsOnCall = make(map[int]struct {
+			result1 model.BackupSnapshot
+			result2 []db.FileInfoTruncated
+			result3 error
+		})
+	}
+	fake.backupSnapshotFilesReturnsOnCall[i] = struct {
+		result1 model.BackupSnapshot
+		result2 []db.FileInfoTruncated
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *Model) BlockPoolStatus() model.BlockPoolStatus {
+	fake.blockPoolStatusMutex.Lock()
+	ret, specificReturn := fake.blockPoolStatusReturnsOnCall[len(fake.blockPoolStatusArgsForCall)]
+	fake.blockPoolStatusArgsForCall = append(fake.blockPoolStatusArgsForCall, struct {
+	}{})
+	stub := fake.BlockPoolStatusStub
+	fakeReturns := fake.blockPoolStatusReturns
+	fake.recordInvocation("BlockPoolStatus", []interface{}{})
+	fake.blockPoolStatusMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) BlockPoolStatusCallCount() int {
+	fake.blockPoolStatusMutex.RLock()
+	defer fake.blockPoolStatusMutex.RUnlock()
+	return len(fake.blockPoolStatusArgsForCall)
+}
+
+func (fake *Model) BlockPoolStatusCalls(stub func() model.BlockPoolStatus) {
+	fake.blockPoolStatusMutex.Lock()
+	defer fake.blockPoolStatusMutex.Unlock()
+	fake.BlockPoolStatusStub = stub
+}
+
+func (fake *Model) BlockPoolStatusReturns(result1 model.BlockPoolStatus) {
+	fake.blockPoolStatusMutex.Lock()
+	defer fake.blockPoolStatusMutex.Unlock()
+	fake.BlockPoolStatusStub = nil
+	fake.blockPoolStatusReturns = struct {
+		result1 model.BlockPoolStatus
+	}{result1}
+}
+
+func (fake *Model) BlockPoolStatusReturnsOnCall(i int, result1 model.BlockPoolStatus) {
+	fake.blockPoolStatusMutex.Lock()
+	defer fake.blockPoolStatusMutex.Unlock()
+	fake.BlockPoolStatusStub = nil
+	if fake.blockPoolStatusReturnsOnCall == nil {
+		fake.blockPoolStatusReturnsOnCall = make(map[int]struct {
+			result1 model.BlockPoolStatus
+		})
+	}
+	fake.blockPoolStatusReturnsOnCall[i] = struct {
+		result1 model.BlockPoolStatus
+	}{result1}
+}
+
 func (fake *Model) BringToFront(arg1 string, arg2 string) {
 	fake.bringToFrontMutex.Lock()
 	fake.bringToFrontArgsForCall = append(fake.bringToFrontArgsForCall, struct {
@@ -728,6 +1547,67 @@ func (fake *Model) BringToFrontArgsForCall(i int) (string, string) {
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *Model) CancelFolderCleanup(arg1 string) error {
+	fake.cancelFolderCleanupMutex.Lock()
+	ret, specificReturn := fake.cancelFolderCleanupReturnsOnCall[len(fake.cancelFolderCleanupArgsForCall)]
+	fake.cancelFolderCleanupArgsForCall = append(fake.cancelFolderCleanupArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.CancelFolderCleanupStub
+	fakeReturns := fake.cancelFolderCleanupReturns
+	fake.recordInvocation("CancelFolderCleanup", []interface{}{arg1})
+	fake.cancelFolderCleanupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) CancelFolderCleanupCallCount() int {
+	fake.cancelFolderCleanupMutex.RLock()
+	defer fake.cancelFolderCleanupMutex.RUnlock()
+	return len(fake.cancelFolderCleanupArgsForCall)
+}
+
+func (fake *Model) CancelFolderCleanupCalls(stub func(string) error) {
+	fake.cancelFolderCleanupMutex.Lock()
+	defer fake.cancelFolderCleanupMutex.Unlock()
+	fake.CancelFolderCleanupStub = stub
+}
+
+func (fake *Model) CancelFolderCleanupArgsForCall(i int) string {
+	fake.cancelFolderCleanupMutex.RLock()
+	defer fake.cancelFolderCleanupMutex.RUnlock()
+	argsForCall := fake.cancelFolderCleanupArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) CancelFolderCleanupReturns(result1 error) {
+	fake.cancelFolderCleanupMutex.Lock()
+	defer fake.cancelFolderCleanupMutex.Unlock()
+	fake.CancelFolderCleanupStub = nil
+	fake.cancelFolderCleanupReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) CancelFolderCleanupReturnsOnCall(i int, result1 error) {
+	fake.cancelFolderCleanupMutex.Lock()
+	defer fake.cancelFolderCleanupMutex.Unlock()
+	fake.CancelFolderCleanupStub = nil
+	if fake.cancelFolderCleanupReturnsOnCall == nil {
+		fake.cancelFolderCleanupReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.cancelFolderCleanupReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Model) Closed(arg1 protocol.Connection, arg2 error) {
 	fake.closedMutex.Lock()
 	fake.closedArgsForCall = append(fake.closedArgsForCall, struct {
@@ -823,6 +1703,92 @@ func (fake *Model) ClusterConfigReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Model) ClusterFolders(arg1 string, arg2 string) []model.AdvertisedFolder {
+	fake.clusterFoldersMutex.Lock()
+	ret, specificReturn := fake.clusterFoldersReturnsOnCall[len(fake.clusterFoldersArgsForCall)]
+	fake.clusterFoldersArgsForCall = append(fake.clusterFoldersArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ClusterFoldersStub
+	fakeReturns := fake.clusterFoldersReturns
+	fake.recordInvocation("ClusterFolders", []interface{}{arg1, arg2})
+	fake.clusterFoldersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) ClusterFoldersCallCount() int {
+	fake.clusterFoldersMutex.RLock()
+	defer fake.clusterFoldersMutex.RUnlock()
+	return len(fake.clusterFoldersArgsForCall)
+}
+
+func (fake *Model) ClusterFoldersCalls(stub func(string, string) []model.AdvertisedFolder) {
+	fake.clusterFoldersMutex.Lock()
+	defer fake.clusterFoldersMutex.Unlock()
+	fake.ClusterFoldersStub = stub
+}
+
+func (fake *Model) ClusterFoldersArgsForCall(i int) (string, string) {
+	fake.clusterFoldersMutex.RLock()
+	defer fake.clusterFoldersMutex.RUnlock()
+	argsForCall := fake.clusterFoldersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) ClusterFoldersReturns(result1 []model.AdvertisedFolder) {
+	fake.clusterFoldersMutex.Lock()
+	defer fake.clusterFoldersMutex.Unlock()
+	fake.ClusterFoldersStub = nil
+	fake.clusterFoldersReturns = struct {
+		result1 []model.AdvertisedFolder
+	}{result1}
+}
+
+func (fake *Model) ClusterFoldersReturnsOnCall(i int, result1 []model.AdvertisedFolder) {
+	fake.clusterFoldersMutex.Lock()
+	defer fake.clusterFoldersMutex.Unlock()
+	fake.ClusterFoldersStub = nil
+	if fake.clusterFoldersReturnsOnCall == nil {
+		fake.clusterFoldersReturnsOnCall = make(map[int]struct {
+			result1 []model.AdvertisedFolder
+		})
+	}
+	fake.clusterFoldersReturnsOnCall[i] = struct {
+		result1 []model.AdvertisedFolder
+	}{result1}
+}
+
+func (fake *Model) CollectBlockPool() {
+	fake.collectBlockPoolMutex.Lock()
+	fake.collectBlockPoolArgsForCall = append(fake.collectBlockPoolArgsForCall, struct {
+	}{})
+	stub := fake.CollectBlockPoolStub
+	fake.recordInvocation("CollectBlockPool", []interface{}{})
+	fake.collectBlockPoolMutex.Unlock()
+	if stub != nil {
+		fake.CollectBlockPoolStub()
+	}
+}
+
+func (fake *Model) CollectBlockPoolCallCount() int {
+	fake.collectBlockPoolMutex.RLock()
+	defer fake.collectBlockPoolMutex.RUnlock()
+	return len(fake.collectBlockPoolArgsForCall)
+}
+
+func (fake *Model) CollectBlockPoolCalls(stub func()) {
+	fake.collectBlockPoolMutex.Lock()
+	defer fake.collectBlockPoolMutex.Unlock()
+	fake.CollectBlockPoolStub = stub
+}
+
 func (fake *Model) Completion(arg1 protocol.DeviceID, arg2 string) (model.FolderCompletion, error) {
 	fake.completionMutex.Lock()
 	ret, specificReturn := fake.completionReturnsOnCall[len(fake.completionArgsForCall)]
@@ -1005,71 +1971,198 @@ func (fake *Model) ConnectionStatsReturnsOnCall(i int, result1 map[string]interf
 	}{result1}
 }
 
-func (fake *Model) CurrentFolderFile(arg1 string, arg2 string) (protocol.FileInfo, bool, error) {
-	fake.currentFolderFileMutex.Lock()
-	ret, specificReturn := fake.currentFolderFileReturnsOnCall[len(fake.currentFolderFileArgsForCall)]
-	fake.currentFolderFileArgsForCall = append(fake.currentFolderFileArgsForCall, struct {
-		arg1 string
-		arg2 string
+func (fake *Model) Control(arg1 protocol.Connection, arg2 protocol.Control) error {
+	fake.controlMutex.Lock()
+	ret, specificReturn := fake.controlReturnsOnCall[len(fake.controlArgsForCall)]
+	fake.controlArgsForCall = append(fake.controlArgsForCall, struct {
+		arg1 protocol.Connection
+		arg2 protocol.Control
 	}{arg1, arg2})
-	stub := fake.CurrentFolderFileStub
-	fakeReturns := fake.currentFolderFileReturns
-	fake.recordInvocation("CurrentFolderFile", []interface{}{arg1, arg2})
-	fake.currentFolderFileMutex.Unlock()
+	stub := fake.ControlStub
+	fakeReturns := fake.controlReturns
+	fake.recordInvocation("Control", []interface{}{arg1, arg2})
+	fake.controlMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2, ret.result3
+		return ret.result1
 	}
-	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+	return fakeReturns.result1
 }
 
-func (fake *Model) CurrentFolderFileCallCount() int {
-	fake.currentFolderFileMutex.RLock()
-	defer fake.currentFolderFileMutex.RUnlock()
-	return len(fake.currentFolderFileArgsForCall)
+func (fake *Model) ControlCallCount() int {
+	fake.controlMutex.RLock()
+	defer fake.controlMutex.RUnlock()
+	return len(fake.controlArgsForCall)
 }
 
-func (fake *Model) CurrentFolderFileCalls(stub func(string, string) (protocol.FileInfo, bool, error)) {
-	fake.currentFolderFileMutex.Lock()
-	defer fake.currentFolderFileMutex.Unlock()
-	fake.CurrentFolderFileStub = stub
+func (fake *Model) ControlCalls(stub func(protocol.Connection, protocol.Control) error) {
+	fake.controlMutex.Lock()
+	defer fake.controlMutex.Unlock()
+	fake.ControlStub = stub
 }
 
-func (fake *Model) CurrentFolderFileArgsForCall(i int) (string, string) {
-	fake.currentFolderFileMutex.RLock()
-	defer fake.currentFolderFileMutex.RUnlock()
-	argsForCall := fake.currentFolderFileArgsForCall[i]
+func (fake *Model) ControlArgsForCall(i int) (protocol.Connection, protocol.Control) {
+	fake.controlMutex.RLock()
+	defer fake.controlMutex.RUnlock()
+	argsForCall := fake.controlArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *Model) CurrentFolderFileReturns(result1 protocol.FileInfo, result2 bool, result3 error) {
-	fake.currentFolderFileMutex.Lock()
-	defer fake.currentFolderFileMutex.Unlock()
-	fake.CurrentFolderFileStub = nil
-	fake.currentFolderFileReturns = struct {
-		result1 protocol.FileInfo
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+func (fake *Model) ControlReturns(result1 error) {
+	fake.controlMutex.Lock()
+	defer fake.controlMutex.Unlock()
+	fake.ControlStub = nil
+	fake.controlReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *Model) CurrentFolderFileReturnsOnCall(i int, result1 protocol.FileInfo, result2 bool, result3 error) {
-	fake.currentFolderFileMutex.Lock()
-	defer fake.currentFolderFileMutex.Unlock()
-	fake.CurrentFolderFileStub = nil
-	if fake.currentFolderFileReturnsOnCall == nil {
-		fake.currentFolderFileReturnsOnCall = make(map[int]struct {
-			result1 protocol.FileInfo
-			result2 bool
-			result3 error
+func (fake *Model) ControlReturnsOnCall(i int, result1 error) {
+	fake.controlMutex.Lock()
+	defer fake.controlMutex.Unlock()
+	fake.ControlStub = nil
+	if fake.controlReturnsOnCall == nil {
+		fake.controlReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.currentFolderFileReturnsOnCall[i] = struct {
-		result1 protocol.FileInfo
-		result2 bool
-		result3 error
+	fake.controlReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) CreateBackupSnapshot(arg1 string, arg2 time.Duration) (model.BackupSnapshot, error) {
+	fake.createBackupSnapshotMutex.Lock()
+	ret, specificReturn := fake.createBackupSnapshotReturnsOnCall[len(fake.createBackupSnapshotArgsForCall)]
+	fake.createBackupSnapshotArgsForCall = append(fake.createBackupSnapshotArgsForCall, struct {
+		arg1 string
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.CreateBackupSnapshotStub
+	fakeReturns := fake.createBackupSnapshotReturns
+	fake.recordInvocation("CreateBackupSnapshot", []interface{}{arg1, arg2})
+	fake.createBackupSnapshotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) CreateBackupSnapshotCallCount() int {
+	fake.createBackupSnapshotMutex.RLock()
+	defer fake.createBackupSnapshotMutex.RUnlock()
+	return len(fake.createBackupSnapshotArgsForCall)
+}
+
+func (fake *Model) CreateBackupSnapshotCalls(stub func(string, time.Duration) (model.BackupSnapshot, error)) {
+	fake.createBackupSnapshotMutex.Lock()
+	defer fake.createBackupSnapshotMutex.Unlock()
+	fake.CreateBackupSnapshotStub = stub
+}
+
+func (fake *Model) CreateBackupSnapshotArgsForCall(i int) (string, time.Duration) {
+	fake.createBackupSnapshotMutex.RLock()
+	defer fake.createBackupSnapshotMutex.RUnlock()
+	argsForCall := fake.createBackupSnapshotArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) CreateBackupSnapshotReturns(result1 model.BackupSnapshot, result2 error) {
+	fake.createBackupSnapshotMutex.Lock()
+	defer fake.createBackupSnapshotMutex.Unlock()
+	fake.CreateBackupSnapshotStub = nil
+	fake.createBackupSnapshotReturns = struct {
+		result1 model.BackupSnapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) CreateBackupSnapshotReturnsOnCall(i int, result1 model.BackupSnapshot, result2 error) {
+	fake.createBackupSnapshotMutex.Lock()
+	defer fake.createBackupSnapshotMutex.Unlock()
+	fake.CreateBackupSnapshotStub = nil
+	if fake.createBackupSnapshotReturnsOnCall == nil {
+		fake.createBackupSnapshotReturnsOnCall = make(map[int]struct {
+			result1 model.BackupSnapshot
+			result2 error
+		})
+	}
+	fake.createBackupSnapshotReturnsOnCall[i] = struct {
+		result1 model.BackupSnapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) CurrentFolderFile(arg1 string, arg2 string) (protocol.FileInfo, bool, error) {
+	fake.currentFolderFileMutex.Lock()
+	ret, specificReturn := fake.currentFolderFileReturnsOnCall[len(fake.currentFolderFileArgsForCall)]
+	fake.currentFolderFileArgsForCall = append(fake.currentFolderFileArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.CurrentFolderFileStub
+	fakeReturns := fake.currentFolderFileReturns
+	fake.recordInvocation("CurrentFolderFile", []interface{}{arg1, arg2})
+	fake.currentFolderFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *Model) CurrentFolderFileCallCount() int {
+	fake.currentFolderFileMutex.RLock()
+	defer fake.currentFolderFileMutex.RUnlock()
+	return len(fake.currentFolderFileArgsForCall)
+}
+
+func (fake *Model) CurrentFolderFileCalls(stub func(string, string) (protocol.FileInfo, bool, error)) {
+	fake.currentFolderFileMutex.Lock()
+	defer fake.currentFolderFileMutex.Unlock()
+	fake.CurrentFolderFileStub = stub
+}
+
+func (fake *Model) CurrentFolderFileArgsForCall(i int) (string, string) {
+	fake.currentFolderFileMutex.RLock()
+	defer fake.currentFolderFileMutex.RUnlock()
+	argsForCall := fake.currentFolderFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) CurrentFolderFileReturns(result1 protocol.FileInfo, result2 bool, result3 error) {
+	fake.currentFolderFileMutex.Lock()
+	defer fake.currentFolderFileMutex.Unlock()
+	fake.CurrentFolderFileStub = nil
+	fake.currentFolderFileReturns = struct {
+		result1 protocol.FileInfo
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *Model) CurrentFolderFileReturnsOnCall(i int, result1 protocol.FileInfo, result2 bool, result3 error) {
+	fake.currentFolderFileMutex.Lock()
+	defer fake.currentFolderFileMutex.Unlock()
+	fake.CurrentFolderFileStub = nil
+	if fake.currentFolderFileReturnsOnCall == nil {
+		fake.currentFolderFileReturnsOnCall = make(map[int]struct {
+			result1 protocol.FileInfo
+			result2 bool
+			result3 error
+		})
+	}
+	fake.currentFolderFileReturnsOnCall[i] = struct {
+		result1 protocol.FileInfo
+		result2 bool
+		result3 error
 	}{result1, result2, result3}
 }
 
@@ -1305,6 +2398,73 @@ func (fake *Model) DelayScanArgsForCall(i int) (string, time.Duration) {
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *Model) DeviceDifferences(arg1 string, arg2 protocol.DeviceID, arg3 int, arg4 int) ([]model.FileDifference, error) {
+	fake.deviceDifferencesMutex.Lock()
+	ret, specificReturn := fake.deviceDifferencesReturnsOnCall[len(fake.deviceDifferencesArgsForCall)]
+	fake.deviceDifferencesArgsForCall = append(fake.deviceDifferencesArgsForCall, struct {
+		arg1 string
+		arg2 protocol.DeviceID
+		arg3 int
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DeviceDifferencesStub
+	fakeReturns := fake.deviceDifferencesReturns
+	fake.recordInvocation("DeviceDifferences", []interface{}{arg1, arg2, arg3, arg4})
+	fake.deviceDifferencesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) DeviceDifferencesCallCount() int {
+	fake.deviceDifferencesMutex.RLock()
+	defer fake.deviceDifferencesMutex.RUnlock()
+	return len(fake.deviceDifferencesArgsForCall)
+}
+
+func (fake *Model) DeviceDifferencesCalls(stub func(string, protocol.DeviceID, int, int) ([]model.FileDifference, error)) {
+	fake.deviceDifferencesMutex.Lock()
+	defer fake.deviceDifferencesMutex.Unlock()
+	fake.DeviceDifferencesStub = stub
+}
+
+func (fake *Model) DeviceDifferencesArgsForCall(i int) (string, protocol.DeviceID, int, int) {
+	fake.deviceDifferencesMutex.RLock()
+	defer fake.deviceDifferencesMutex.RUnlock()
+	argsForCall := fake.deviceDifferencesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *Model) DeviceDifferencesReturns(result1 []model.FileDifference, result2 error) {
+	fake.deviceDifferencesMutex.Lock()
+	defer fake.deviceDifferencesMutex.Unlock()
+	fake.DeviceDifferencesStub = nil
+	fake.deviceDifferencesReturns = struct {
+		result1 []model.FileDifference
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) DeviceDifferencesReturnsOnCall(i int, result1 []model.FileDifference, result2 error) {
+	fake.deviceDifferencesMutex.Lock()
+	defer fake.deviceDifferencesMutex.Unlock()
+	fake.DeviceDifferencesStub = nil
+	if fake.deviceDifferencesReturnsOnCall == nil {
+		fake.deviceDifferencesReturnsOnCall = make(map[int]struct {
+			result1 []model.FileDifference
+			result2 error
+		})
+	}
+	fake.deviceDifferencesReturnsOnCall[i] = struct {
+		result1 []model.FileDifference
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Model) DeviceStatistics() (map[protocol.DeviceID]stats.DeviceStatistics, error) {
 	fake.deviceStatisticsMutex.Lock()
 	ret, specificReturn := fake.deviceStatisticsReturnsOnCall[len(fake.deviceStatisticsArgsForCall)]
@@ -1552,82 +2712,80 @@ func (fake *Model) DownloadProgressReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *Model) FolderErrors(arg1 string) ([]model.FileError, error) {
-	fake.folderErrorsMutex.Lock()
-	ret, specificReturn := fake.folderErrorsReturnsOnCall[len(fake.folderErrorsArgsForCall)]
-	fake.folderErrorsArgsForCall = append(fake.folderErrorsArgsForCall, struct {
+func (fake *Model) DropDeviceIndex(arg1 string, arg2 protocol.DeviceID, arg3 bool) error {
+	fake.dropDeviceIndexMutex.Lock()
+	ret, specificReturn := fake.dropDeviceIndexReturnsOnCall[len(fake.dropDeviceIndexArgsForCall)]
+	fake.dropDeviceIndexArgsForCall = append(fake.dropDeviceIndexArgsForCall, struct {
 		arg1 string
-	}{arg1})
-	stub := fake.FolderErrorsStub
-	fakeReturns := fake.folderErrorsReturns
-	fake.recordInvocation("FolderErrors", []interface{}{arg1})
-	fake.folderErrorsMutex.Unlock()
+		arg2 protocol.DeviceID
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.DropDeviceIndexStub
+	fakeReturns := fake.dropDeviceIndexReturns
+	fake.recordInvocation("DropDeviceIndex", []interface{}{arg1, arg2, arg3})
+	fake.dropDeviceIndexMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
-		return ret.result1, ret.result2
+		return ret.result1
 	}
-	return fakeReturns.result1, fakeReturns.result2
+	return fakeReturns.result1
 }
 
-func (fake *Model) FolderErrorsCallCount() int {
-	fake.folderErrorsMutex.RLock()
-	defer fake.folderErrorsMutex.RUnlock()
-	return len(fake.folderErrorsArgsForCall)
+func (fake *Model) DropDeviceIndexCallCount() int {
+	fake.dropDeviceIndexMutex.RLock()
+	defer fake.dropDeviceIndexMutex.RUnlock()
+	return len(fake.dropDeviceIndexArgsForCall)
 }
 
-func (fake *Model) FolderErrorsCalls(stub func(string) ([]model.FileError, error)) {
-	fake.folderErrorsMutex.Lock()
-	defer fake.folderErrorsMutex.Unlock()
-	fake.FolderErrorsStub = stub
+func (fake *Model) DropDeviceIndexCalls(stub func(string, protocol.DeviceID, bool) error) {
+	fake.dropDeviceIndexMutex.Lock()
+	defer fake.dropDeviceIndexMutex.Unlock()
+	fake.DropDeviceIndexStub = stub
 }
 
-func (fake *Model) FolderErrorsArgsForCall(i int) string {
-	fake.folderErrorsMutex.RLock()
-	defer fake.folderErrorsMutex.RUnlock()
-	argsForCall := fake.folderErrorsArgsForCall[i]
-	return argsForCall.arg1
+func (fake *Model) DropDeviceIndexArgsForCall(i int) (string, protocol.DeviceID, bool) {
+	fake.dropDeviceIndexMutex.RLock()
+	defer fake.dropDeviceIndexMutex.RUnlock()
+	argsForCall := fake.dropDeviceIndexArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *Model) FolderErrorsReturns(result1 []model.FileError, result2 error) {
-	fake.folderErrorsMutex.Lock()
-	defer fake.folderErrorsMutex.Unlock()
-	fake.FolderErrorsStub = nil
-	fake.folderErrorsReturns = struct {
-		result1 []model.FileError
-		result2 error
-	}{result1, result2}
+func (fake *Model) DropDeviceIndexReturns(result1 error) {
+	fake.dropDeviceIndexMutex.Lock()
+	defer fake.dropDeviceIndexMutex.Unlock()
+	fake.DropDeviceIndexStub = nil
+	fake.dropDeviceIndexReturns = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *Model) FolderErrorsReturnsOnCall(i int, result1 []model.FileError, result2 error) {
-	fake.folderErrorsMutex.Lock()
-	defer fake.folderErrorsMutex.Unlock()
-	fake.FolderErrorsStub = nil
-	if fake.folderErrorsReturnsOnCall == nil {
-		fake.folderErrorsReturnsOnCall = make(map[int]struct {
-			result1 []model.FileError
-			result2 error
+func (fake *Model) DropDeviceIndexReturnsOnCall(i int, result1 error) {
+	fake.dropDeviceIndexMutex.Lock()
+	defer fake.dropDeviceIndexMutex.Unlock()
+	fake.DropDeviceIndexStub = nil
+	if fake.dropDeviceIndexReturnsOnCall == nil {
+		fake.dropDeviceIndexReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.folderErrorsReturnsOnCall[i] = struct {
-		result1 []model.FileError
-		result2 error
-	}{result1, result2}
+	fake.dropDeviceIndexReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
-func (fake *Model) FolderProgressBytesCompleted(arg1 string) int64 {
-	fake.folderProgressBytesCompletedMutex.Lock()
-	ret, specificReturn := fake.folderProgressBytesCompletedReturnsOnCall[len(fake.folderProgressBytesCompletedArgsForCall)]
-	fake.folderProgressBytesCompletedArgsForCall = append(fake.folderProgressBytesCompletedArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	stub := fake.FolderProgressBytesCompletedStub
-	fakeReturns := fake.folderProgressBytesCompletedReturns
-	fake.recordInvocation("FolderProgressBytesCompleted", []interface{}{arg1})
-	fake.folderProgressBytesCompletedMutex.Unlock()
+func (fake *Model) FailBack() error {
+	fake.failBackMutex.Lock()
+	ret, specificReturn := fake.failBackReturnsOnCall[len(fake.failBackArgsForCall)]
+	fake.failBackArgsForCall = append(fake.failBackArgsForCall, struct {
+	}{})
+	stub := fake.FailBackStub
+	fakeReturns := fake.failBackReturns
+	fake.recordInvocation("FailBack", []interface{}{})
+	fake.failBackMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
@@ -1635,59 +2793,53 @@ func (fake *Model) FolderProgressBytesCompleted(arg1 string) int64 {
 	return fakeReturns.result1
 }
 
-func (fake *Model) FolderProgressBytesCompletedCallCount() int {
-	fake.folderProgressBytesCompletedMutex.RLock()
-	defer fake.folderProgressBytesCompletedMutex.RUnlock()
-	return len(fake.folderProgressBytesCompletedArgsForCall)
-}
-
-func (fake *Model) FolderProgressBytesCompletedCalls(stub func(string) int64) {
-	fake.folderProgressBytesCompletedMutex.Lock()
-	defer fake.folderProgressBytesCompletedMutex.Unlock()
-	fake.FolderProgressBytesCompletedStub = stub
+func (fake *Model) FailBackCallCount() int {
+	fake.failBackMutex.RLock()
+	defer fake.failBackMutex.RUnlock()
+	return len(fake.failBackArgsForCall)
 }
 
-func (fake *Model) FolderProgressBytesCompletedArgsForCall(i int) string {
-	fake.folderProgressBytesCompletedMutex.RLock()
-	defer fake.folderProgressBytesCompletedMutex.RUnlock()
-	argsForCall := fake.folderProgressBytesCompletedArgsForCall[i]
-	return argsForCall.arg1
+func (fake *Model) FailBackCalls(stub func() error) {
+	fake.failBackMutex.Lock()
+	defer fake.failBackMutex.Unlock()
+	fake.FailBackStub = stub
 }
 
-func (fake *Model) FolderProgressBytesCompletedReturns(result1 int64) {
-	fake.folderProgressBytesCompletedMutex.Lock()
-	defer fake.folderProgressBytesCompletedMutex.Unlock()
-	fake.FolderProgressBytesCompletedStub = nil
-	fake.folderProgressBytesCompletedReturns = struct {
-		result1 int64
+func (fake *Model) FailBackReturns(result1 error) {
+	fake.failBackMutex.Lock()
+	defer fake.failBackMutex.Unlock()
+	fake.FailBackStub = nil
+	fake.failBackReturns = struct {
+		result1 error
 	}{result1}
 }
 
-func (fake *Model) FolderProgressBytesCompletedReturnsOnCall(i int, result1 int64) {
-	fake.folderProgressBytesCompletedMutex.Lock()
-	defer fake.folderProgressBytesCompletedMutex.Unlock()
-	fake.FolderProgressBytesCompletedStub = nil
-	if fake.folderProgressBytesCompletedReturnsOnCall == nil {
-		fake.folderProgressBytesCompletedReturnsOnCall = make(map[int]struct {
-			result1 int64
+func (fake *Model) FailBackReturnsOnCall(i int, result1 error) {
+	fake.failBackMutex.Lock()
+	defer fake.failBackMutex.Unlock()
+	fake.FailBackStub = nil
+	if fake.failBackReturnsOnCall == nil {
+		fake.failBackReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.folderProgressBytesCompletedReturnsOnCall[i] = struct {
-		result1 int64
+	fake.failBackReturnsOnCall[i] = struct {
+		result1 error
 	}{result1}
 }
 
-func (fake *Model) FolderStatistics() (map[string]stats.FolderStatistics, error) {
-	fake.folderStatisticsMutex.Lock()
-	ret, specificReturn := fake.folderStatisticsReturnsOnCall[len(fake.folderStatisticsArgsForCall)]
-	fake.folderStatisticsArgsForCall = append(fake.folderStatisticsArgsForCall, struct {
-	}{})
-	stub := fake.FolderStatisticsStub
-	fakeReturns := fake.folderStatisticsReturns
-	fake.recordInvocation("FolderStatistics", []interface{}{})
-	fake.folderStatisticsMutex.Unlock()
+func (fake *Model) FolderCapabilities(arg1 string) (model.FolderCapabilities, error) {
+	fake.folderCapabilitiesMutex.Lock()
+	ret, specificReturn := fake.folderCapabilitiesReturnsOnCall[len(fake.folderCapabilitiesArgsForCall)]
+	fake.folderCapabilitiesArgsForCall = append(fake.folderCapabilitiesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FolderCapabilitiesStub
+	fakeReturns := fake.folderCapabilitiesReturns
+	fake.recordInvocation("FolderCapabilities", []interface{}{arg1})
+	fake.folderCapabilitiesMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1695,19 +2847,508 @@ func (fake *Model) FolderStatistics() (map[string]stats.FolderStatistics, error)
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *Model) FolderStatisticsCallCount() int {
-	fake.folderStatisticsMutex.RLock()
-	defer fake.folderStatisticsMutex.RUnlock()
-	return len(fake.folderStatisticsArgsForCall)
+func (fake *Model) FolderCapabilitiesCallCount() int {
+	fake.folderCapabilitiesMutex.RLock()
+	defer fake.folderCapabilitiesMutex.RUnlock()
+	return len(fake.folderCapabilitiesArgsForCall)
 }
 
-func (fake *Model) FolderStatisticsCalls(stub func() (map[string]stats.FolderStatistics, error)) {
-	fake.folderStatisticsMutex.Lock()
-	defer fake.folderStatisticsMutex.Unlock()
-	fake.FolderStatisticsStub = stub
+func (fake *Model) FolderCapabilitiesCalls(stub func(string) (model.FolderCapabilities, error)) {
+	fake.folderCapabilitiesMutex.Lock()
+	defer fake.folderCapabilitiesMutex.Unlock()
+	fake.FolderCapabilitiesStub = stub
 }
 
-func (fake *Model) FolderStatisticsReturns(result1 map[string]stats.FolderStatistics, result2 error) {
+func (fake *Model) FolderCapabilitiesArgsForCall(i int) string {
+	fake.folderCapabilitiesMutex.RLock()
+	defer fake.folderCapabilitiesMutex.RUnlock()
+	argsForCall := fake.folderCapabilitiesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) FolderCapabilitiesReturns(result1 model.FolderCapabilities, result2 error) {
+	fake.folderCapabilitiesMutex.Lock()
+	defer fake.folderCapabilitiesMutex.Unlock()
+	fake.FolderCapabilitiesStub = nil
+	fake.folderCapabilitiesReturns = struct {
+		result1 model.FolderCapabilities
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) FolderCapabilitiesReturnsOnCall(i int, result1 model.FolderCapabilities, result2 error) {
+	fake.folderCapabilitiesMutex.Lock()
+	defer fake.folderCapabilitiesMutex.Unlock()
+	fake.FolderCapabilitiesStub = nil
+	if fake.folderCapabilitiesReturnsOnCall == nil {
+		fake.folderCapabilitiesReturnsOnCall = make(map[int]struct {
+			result1 model.FolderCapabilities
+			result2 error
+		})
+	}
+	fake.folderCapabilitiesReturnsOnCall[i] = struct {
+		result1 model.FolderCapabilities
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) FolderCleanups() []model.FolderCleanup {
+	fake.folderCleanupsMutex.Lock()
+	ret, specificReturn := fake.folderCleanupsReturnsOnCall[len(fake.folderCleanupsArgsForCall)]
+	fake.folderCleanupsArgsForCall = append(fake.folderCleanupsArgsForCall, struct {
+	}{})
+	stub := fake.FolderCleanupsStub
+	fakeReturns := fake.folderCleanupsReturns
+	fake.recordInvocation("FolderCleanups", []interface{}{})
+	fake.folderCleanupsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) FolderCleanupsCallCount() int {
+	fake.folderCleanupsMutex.RLock()
+	defer fake.folderCleanupsMutex.RUnlock()
+	return len(fake.folderCleanupsArgsForCall)
+}
+
+func (fake *Model) FolderCleanupsCalls(stub func() []model.FolderCleanup) {
+	fake.folderCleanupsMutex.Lock()
+	defer fake.folderCleanupsMutex.Unlock()
+	fake.FolderCleanupsStub = stub
+}
+
+func (fake *Model) FolderCleanupsReturns(result1 []model.FolderCleanup) {
+	fake.folderCleanupsMutex.Lock()
+	defer fake.folderCleanupsMutex.Unlock()
+	fake.FolderCleanupsStub = nil
+	fake.folderCleanupsReturns = struct {
+		result1 []model.FolderCleanup
+	}{result1}
+}
+
+func (fake *Model) FolderCleanupsReturnsOnCall(i int, result1 []model.FolderCleanup) {
+	fake.folderCleanupsMutex.Lock()
+	defer fake.folderCleanupsMutex.Unlock()
+	fake.FolderCleanupsStub = nil
+	if fake.folderCleanupsReturnsOnCall == nil {
+		fake.folderCleanupsReturnsOnCall = make(map[int]struct {
+			result1 []model.FolderCleanup
+		})
+	}
+	fake.folderCleanupsReturnsOnCall[i] = struct {
+		result1 []model.FolderCleanup
+	}{result1}
+}
+
+func (fake *Model) FolderDatabaseSize(arg1 string) (db.FolderSizeReport, error) {
+	fake.folderDatabaseSizeMutex.Lock()
+	ret, specificReturn := fake.folderDatabaseSizeReturnsOnCall[len(fake.folderDatabaseSizeArgsForCall)]
+	fake.folderDatabaseSizeArgsForCall = append(fake.folderDatabaseSizeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FolderDatabaseSizeStub
+	fakeReturns := fake.folderDatabaseSizeReturns
+	fake.recordInvocation("FolderDatabaseSize", []interface{}{arg1})
+	fake.folderDatabaseSizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) FolderDatabaseSizeCallCount() int {
+	fake.folderDatabaseSizeMutex.RLock()
+	defer fake.folderDatabaseSizeMutex.RUnlock()
+	return len(fake.folderDatabaseSizeArgsForCall)
+}
+
+func (fake *Model) FolderDatabaseSizeCalls(stub func(string) (db.FolderSizeReport, error)) {
+	fake.folderDatabaseSizeMutex.Lock()
+	defer fake.folderDatabaseSizeMutex.Unlock()
+	fake.FolderDatabaseSizeStub = stub
+}
+
+func (fake *Model) FolderDatabaseSizeArgsForCall(i int) string {
+	fake.folderDatabaseSizeMutex.RLock()
+	defer fake.folderDatabaseSizeMutex.RUnlock()
+	argsForCall := fake.folderDatabaseSizeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) FolderDatabaseSizeReturns(result1 db.FolderSizeReport, result2 error) {
+	fake.folderDatabaseSizeMutex.Lock()
+	defer fake.folderDatabaseSizeMutex.Unlock()
+	fake.FolderDatabaseSizeStub = nil
+	fake.folderDatabaseSizeReturns = struct {
+		result1 db.FolderSizeReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) FolderDatabaseSizeReturnsOnCall(i int, result1 db.FolderSizeReport, result2 error) {
+	fake.folderDatabaseSizeMutex.Lock()
+	defer fake.folderDatabaseSizeMutex.Unlock()
+	fake.FolderDatabaseSizeStub = nil
+	if fake.folderDatabaseSizeReturnsOnCall == nil {
+		fake.folderDatabaseSizeReturnsOnCall = make(map[int]struct {
+			result1 db.FolderSizeReport
+			result2 error
+		})
+	}
+	fake.folderDatabaseSizeReturnsOnCall[i] = struct {
+		result1 db.FolderSizeReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) FolderDeviceIndexes(arg1 string) ([]model.DeviceIndex, error) {
+	fake.folderDeviceIndexesMutex.Lock()
+	ret, specificReturn := fake.folderDeviceIndexesReturnsOnCall[len(fake.folderDeviceIndexesArgsForCall)]
+	fake.folderDeviceIndexesArgsForCall = append(fake.folderDeviceIndexesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FolderDeviceIndexesStub
+	fakeReturns := fake.folderDeviceIndexesReturns
+	fake.recordInvocation("FolderDeviceIndexes", []interface{}{arg1})
+	fake.folderDeviceIndexesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) FolderDeviceIndexesCallCount() int {
+	fake.folderDeviceIndexesMutex.RLock()
+	defer fake.folderDeviceIndexesMutex.RUnlock()
+	return len(fake.folderDeviceIndexesArgsForCall)
+}
+
+func (fake *Model) FolderDeviceIndexesCalls(stub func(string) ([]model.DeviceIndex, error)) {
+	fake.folderDeviceIndexesMutex.Lock()
+	defer fake.folderDeviceIndexesMutex.Unlock()
+	fake.FolderDeviceIndexesStub = stub
+}
+
+func (fake *Model) FolderDeviceIndexesArgsForCall(i int) string {
+	fake.folderDeviceIndexesMutex.RLock()
+	defer fake.folderDeviceIndexesMutex.RUnlock()
+	argsForCall := fake.folderDeviceIndexesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) FolderDeviceIndexesReturns(result1 []model.DeviceIndex, result2 error) {
+	fake.folderDeviceIndexesMutex.Lock()
+	defer fake.folderDeviceIndexesMutex.Unlock()
+	fake.FolderDeviceIndexesStub = nil
+	fake.folderDeviceIndexesReturns = struct {
+		result1 []model.DeviceIndex
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) FolderDeviceIndexesReturnsOnCall(i int, result1 []model.DeviceIndex, result2 error) {
+	fake.folderDeviceIndexesMutex.Lock()
+	defer fake.folderDeviceIndexesMutex.Unlock()
+	fake.FolderDeviceIndexesStub = nil
+	if fake.folderDeviceIndexesReturnsOnCall == nil {
+		fake.folderDeviceIndexesReturnsOnCall = make(map[int]struct {
+			result1 []model.DeviceIndex
+			result2 error
+		})
+	}
+	fake.folderDeviceIndexesReturnsOnCall[i] = struct {
+		result1 []model.DeviceIndex
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) FolderErrors(arg1 string) ([]model.FileError, error) {
+	fake.folderErrorsMutex.Lock()
+	ret, specificReturn := fake.folderErrorsReturnsOnCall[len(fake.folderErrorsArgsForCall)]
+	fake.folderErrorsArgsForCall = append(fake.folderErrorsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FolderErrorsStub
+	fakeReturns := fake.folderErrorsReturns
+	fake.recordInvocation("FolderErrors", []interface{}{arg1})
+	fake.folderErrorsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) FolderErrorsCallCount() int {
+	fake.folderErrorsMutex.RLock()
+	defer fake.folderErrorsMutex.RUnlock()
+	return len(fake.folderErrorsArgsForCall)
+}
+
+func (fake *Model) FolderErrorsCalls(stub func(string) ([]model.FileError, error)) {
+	fake.folderErrorsMutex.Lock()
+	defer fake.folderErrorsMutex.Unlock()
+	fake.FolderErrorsStub = stub
+}
+
+func (fake *Model) FolderErrorsArgsForCall(i int) string {
+	fake.folderErrorsMutex.RLock()
+	defer fake.folderErrorsMutex.RUnlock()
+	argsForCall := fake.folderErrorsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) FolderErrorsReturns(result1 []model.FileError, result2 error) {
+	fake.folderErrorsMutex.Lock()
+	defer fake.folderErrorsMutex.Unlock()
+	fake.FolderErrorsStub = nil
+	fake.folderErrorsReturns = struct {
+		result1 []model.FileError
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) FolderErrorsReturnsOnCall(i int, result1 []model.FileError, result2 error) {
+	fake.folderErrorsMutex.Lock()
+	defer fake.folderErrorsMutex.Unlock()
+	fake.FolderErrorsStub = nil
+	if fake.folderErrorsReturnsOnCall == nil {
+		fake.folderErrorsReturnsOnCall = make(map[int]struct {
+			result1 []model.FileError
+			result2 error
+		})
+	}
+	fake.folderErrorsReturnsOnCall[i] = struct {
+		result1 []model.FileError
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) FolderMove() (model.FolderMove, bool) {
+	fake.folderMoveMutex.Lock()
+	ret, specificReturn := fake.folderMoveReturnsOnCall[len(fake.folderMoveArgsForCall)]
+	fake.folderMoveArgsForCall = append(fake.folderMoveArgsForCall, struct {
+	}{})
+	stub := fake.FolderMoveStub
+	fakeReturns := fake.folderMoveReturns
+	fake.recordInvocation("FolderMove", []interface{}{})
+	fake.folderMoveMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) FolderMoveCallCount() int {
+	fake.folderMoveMutex.RLock()
+	defer fake.folderMoveMutex.RUnlock()
+	return len(fake.folderMoveArgsForCall)
+}
+
+func (fake *Model) FolderMoveCalls(stub func() (model.FolderMove, bool)) {
+	fake.folderMoveMutex.Lock()
+	defer fake.folderMoveMutex.Unlock()
+	fake.FolderMoveStub = stub
+}
+
+func (fake *Model) FolderMoveReturns(result1 model.FolderMove, result2 bool) {
+	fake.folderMoveMutex.Lock()
+	defer fake.folderMoveMutex.Unlock()
+	fake.FolderMoveStub = nil
+	fake.folderMoveReturns = struct {
+		result1 model.FolderMove
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *Model) FolderMoveReturnsOnCall(i int, result1 model.FolderMove, result2 bool) {
+	fake.folderMoveMutex.Lock()
+	defer fake.folderMoveMutex.Unlock()
+	fake.FolderMoveStub = nil
+	if fake.folderMoveReturnsOnCall == nil {
+		fake.folderMoveReturnsOnCall = make(map[int]struct {
+			result1 model.FolderMove
+			result2 bool
+		})
+	}
+	fake.folderMoveReturnsOnCall[i] = struct {
+		result1 model.FolderMove
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *Model) FolderProgressBytesCompleted(arg1 string) int64 {
+	fake.folderProgressBytesCompletedMutex.Lock()
+	ret, specificReturn := fake.folderProgressBytesCompletedReturnsOnCall[len(fake.folderProgressBytesCompletedArgsForCall)]
+	fake.folderProgressBytesCompletedArgsForCall = append(fake.folderProgressBytesCompletedArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FolderProgressBytesCompletedStub
+	fakeReturns := fake.folderProgressBytesCompletedReturns
+	fake.recordInvocation("FolderProgressBytesCompleted", []interface{}{arg1})
+	fake.folderProgressBytesCompletedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) FolderProgressBytesCompletedCallCount() int {
+	fake.folderProgressBytesCompletedMutex.RLock()
+	defer fake.folderProgressBytesCompletedMutex.RUnlock()
+	return len(fake.folderProgressBytesCompletedArgsForCall)
+}
+
+func (fake *Model) FolderProgressBytesCompletedCalls(stub func(string) int64) {
+	fake.folderProgressBytesCompletedMutex.Lock()
+	defer fake.folderProgressBytesCompletedMutex.Unlock()
+	fake.FolderProgressBytesCompletedStub = stub
+}
+
+func (fake *Model) FolderProgressBytesCompletedArgsForCall(i int) string {
+	fake.folderProgressBytesCompletedMutex.RLock()
+	defer fake.folderProgressBytesCompletedMutex.RUnlock()
+	argsForCall := fake.folderProgressBytesCompletedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) FolderProgressBytesCompletedReturns(result1 int64) {
+	fake.folderProgressBytesCompletedMutex.Lock()
+	defer fake.folderProgressBytesCompletedMutex.Unlock()
+	fake.FolderProgressBytesCompletedStub = nil
+	fake.folderProgressBytesCompletedReturns = struct {
+		result1 int64
+	}{result1}
+}
+
+func (fake *Model) FolderProgressBytesCompletedReturnsOnCall(i int, result1 int64) {
+	fake.folderProgressBytesCompletedMutex.Lock()
+	defer fake.folderProgressBytesCompletedMutex.Unlock()
+	fake.FolderProgressBytesCompletedStub = nil
+	if fake.folderProgressBytesCompletedReturnsOnCall == nil {
+		fake.folderProgressBytesCompletedReturnsOnCall = make(map[int]struct {
+			result1 int64
+		})
+	}
+	fake.folderProgressBytesCompletedReturnsOnCall[i] = struct {
+		result1 int64
+	}{result1}
+}
+
+func (fake *Model) FolderQuarantine(arg1 string) ([]model.FileError, error) {
+	fake.folderQuarantineMutex.Lock()
+	ret, specificReturn := fake.folderQuarantineReturnsOnCall[len(fake.folderQuarantineArgsForCall)]
+	fake.folderQuarantineArgsForCall = append(fake.folderQuarantineArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FolderQuarantineStub
+	fakeReturns := fake.folderQuarantineReturns
+	fake.recordInvocation("FolderQuarantine", []interface{}{arg1})
+	fake.folderQuarantineMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) FolderQuarantineCallCount() int {
+	fake.folderQuarantineMutex.RLock()
+	defer fake.folderQuarantineMutex.RUnlock()
+	return len(fake.folderQuarantineArgsForCall)
+}
+
+func (fake *Model) FolderQuarantineCalls(stub func(string) ([]model.FileError, error)) {
+	fake.folderQuarantineMutex.Lock()
+	defer fake.folderQuarantineMutex.Unlock()
+	fake.FolderQuarantineStub = stub
+}
+
+func (fake *Model) FolderQuarantineArgsForCall(i int) string {
+	fake.folderQuarantineMutex.RLock()
+	defer fake.folderQuarantineMutex.RUnlock()
+	argsForCall := fake.folderQuarantineArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) FolderQuarantineReturns(result1 []model.FileError, result2 error) {
+	fake.folderQuarantineMutex.Lock()
+	defer fake.folderQuarantineMutex.Unlock()
+	fake.FolderQuarantineStub = nil
+	fake.folderQuarantineReturns = struct {
+		result1 []model.FileError
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) FolderQuarantineReturnsOnCall(i int, result1 []model.FileError, result2 error) {
+	fake.folderQuarantineMutex.Lock()
+	defer fake.folderQuarantineMutex.Unlock()
+	fake.FolderQuarantineStub = nil
+	if fake.folderQuarantineReturnsOnCall == nil {
+		fake.folderQuarantineReturnsOnCall = make(map[int]struct {
+			result1 []model.FileError
+			result2 error
+		})
+	}
+	fake.folderQuarantineReturnsOnCall[i] = struct {
+		result1 []model.FileError
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) FolderStatistics() (map[string]stats.FolderStatistics, error) {
+	fake.folderStatisticsMutex.Lock()
+	ret, specificReturn := fake.folderStatisticsReturnsOnCall[len(fake.folderStatisticsArgsForCall)]
+	fake.folderStatisticsArgsForCall = append(fake.folderStatisticsArgsForCall, struct {
+	}{})
+	stub := fake.FolderStatisticsStub
+	fakeReturns := fake.folderStatisticsReturns
+	fake.recordInvocation("FolderStatistics", []interface{}{})
+	fake.folderStatisticsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) FolderStatisticsCallCount() int {
+	fake.folderStatisticsMutex.RLock()
+	defer fake.folderStatisticsMutex.RUnlock()
+	return len(fake.folderStatisticsArgsForCall)
+}
+
+func (fake *Model) FolderStatisticsCalls(stub func() (map[string]stats.FolderStatistics, error)) {
+	fake.folderStatisticsMutex.Lock()
+	defer fake.folderStatisticsMutex.Unlock()
+	fake.FolderStatisticsStub = stub
+}
+
+func (fake *Model) FolderStatisticsReturns(result1 map[string]stats.FolderStatistics, result2 error) {
 	fake.folderStatisticsMutex.Lock()
 	defer fake.folderStatisticsMutex.Unlock()
 	fake.FolderStatisticsStub = nil
@@ -1733,6 +3374,131 @@ func (fake *Model) FolderStatisticsReturnsOnCall(i int, result1 map[string]stats
 	}{result1, result2}
 }
 
+func (fake *Model) FreezeFolder(arg1 string) error {
+	fake.freezeFolderMutex.Lock()
+	ret, specificReturn := fake.freezeFolderReturnsOnCall[len(fake.freezeFolderArgsForCall)]
+	fake.freezeFolderArgsForCall = append(fake.freezeFolderArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FreezeFolderStub
+	fakeReturns := fake.freezeFolderReturns
+	fake.recordInvocation("FreezeFolder", []interface{}{arg1})
+	fake.freezeFolderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) FreezeFolderCallCount() int {
+	fake.freezeFolderMutex.RLock()
+	defer fake.freezeFolderMutex.RUnlock()
+	return len(fake.freezeFolderArgsForCall)
+}
+
+func (fake *Model) FreezeFolderCalls(stub func(string) error) {
+	fake.freezeFolderMutex.Lock()
+	defer fake.freezeFolderMutex.Unlock()
+	fake.FreezeFolderStub = stub
+}
+
+func (fake *Model) FreezeFolderArgsForCall(i int) string {
+	fake.freezeFolderMutex.RLock()
+	defer fake.freezeFolderMutex.RUnlock()
+	argsForCall := fake.freezeFolderArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) FreezeFolderReturns(result1 error) {
+	fake.freezeFolderMutex.Lock()
+	defer fake.freezeFolderMutex.Unlock()
+	fake.FreezeFolderStub = nil
+	fake.freezeFolderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) FreezeFolderReturnsOnCall(i int, result1 error) {
+	fake.freezeFolderMutex.Lock()
+	defer fake.freezeFolderMutex.Unlock()
+	fake.FreezeFolderStub = nil
+	if fake.freezeFolderReturnsOnCall == nil {
+		fake.freezeFolderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.freezeFolderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) FreezeStatus(arg1 string) (model.FreezeStatus, error) {
+	fake.freezeStatusMutex.Lock()
+	ret, specificReturn := fake.freezeStatusReturnsOnCall[len(fake.freezeStatusArgsForCall)]
+	fake.freezeStatusArgsForCall = append(fake.freezeStatusArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FreezeStatusStub
+	fakeReturns := fake.freezeStatusReturns
+	fake.recordInvocation("FreezeStatus", []interface{}{arg1})
+	fake.freezeStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) FreezeStatusCallCount() int {
+	fake.freezeStatusMutex.RLock()
+	defer fake.freezeStatusMutex.RUnlock()
+	return len(fake.freezeStatusArgsForCall)
+}
+
+func (fake *Model) FreezeStatusCalls(stub func(string) (model.FreezeStatus, error)) {
+	fake.freezeStatusMutex.Lock()
+	defer fake.freezeStatusMutex.Unlock()
+	fake.FreezeStatusStub = stub
+}
+
+func (fake *Model) FreezeStatusArgsForCall(i int) string {
+	fake.freezeStatusMutex.RLock()
+	defer fake.freezeStatusMutex.RUnlock()
+	argsForCall := fake.freezeStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) FreezeStatusReturns(result1 model.FreezeStatus, result2 error) {
+	fake.freezeStatusMutex.Lock()
+	defer fake.freezeStatusMutex.Unlock()
+	fake.FreezeStatusStub = nil
+	fake.freezeStatusReturns = struct {
+		result1 model.FreezeStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) FreezeStatusReturnsOnCall(i int, result1 model.FreezeStatus, result2 error) {
+	fake.freezeStatusMutex.Lock()
+	defer fake.freezeStatusMutex.Unlock()
+	fake.FreezeStatusStub = nil
+	if fake.freezeStatusReturnsOnCall == nil {
+		fake.freezeStatusReturnsOnCall = make(map[int]struct {
+			result1 model.FreezeStatus
+			result2 error
+		})
+	}
+	fake.freezeStatusReturnsOnCall[i] = struct {
+		result1 model.FreezeStatus
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Model) GetFolderVersions(arg1 string) (map[string][]versioner.FileVersion, error) {
 	fake.getFolderVersionsMutex.Lock()
 	ret, specificReturn := fake.getFolderVersionsReturnsOnCall[len(fake.getFolderVersionsArgsForCall)]
@@ -1990,6 +3756,137 @@ func (fake *Model) GlobalDirectoryTreeReturnsOnCall(i int, result1 []*model.Tree
 	}{result1, result2}
 }
 
+func (fake *Model) HeldPaths(arg1 string) ([]model.HeldPath, error) {
+	fake.heldPathsMutex.Lock()
+	ret, specificReturn := fake.heldPathsReturnsOnCall[len(fake.heldPathsArgsForCall)]
+	fake.heldPathsArgsForCall = append(fake.heldPathsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.HeldPathsStub
+	fakeReturns := fake.heldPathsReturns
+	fake.recordInvocation("HeldPaths", []interface{}{arg1})
+	fake.heldPathsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) HeldPathsCallCount() int {
+	fake.heldPathsMutex.RLock()
+	defer fake.heldPathsMutex.RUnlock()
+	return len(fake.heldPathsArgsForCall)
+}
+
+func (fake *Model) HeldPathsCalls(stub func(string) ([]model.HeldPath, error)) {
+	fake.heldPathsMutex.Lock()
+	defer fake.heldPathsMutex.Unlock()
+	fake.HeldPathsStub = stub
+}
+
+func (fake *Model) HeldPathsArgsForCall(i int) string {
+	fake.heldPathsMutex.RLock()
+	defer fake.heldPathsMutex.RUnlock()
+	argsForCall := fake.heldPathsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) HeldPathsReturns(result1 []model.HeldPath, result2 error) {
+	fake.heldPathsMutex.Lock()
+	defer fake.heldPathsMutex.Unlock()
+	fake.HeldPathsStub = nil
+	fake.heldPathsReturns = struct {
+		result1 []model.HeldPath
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) HeldPathsReturnsOnCall(i int, result1 []model.HeldPath, result2 error) {
+	fake.heldPathsMutex.Lock()
+	defer fake.heldPathsMutex.Unlock()
+	fake.HeldPathsStub = nil
+	if fake.heldPathsReturnsOnCall == nil {
+		fake.heldPathsReturnsOnCall = make(map[int]struct {
+			result1 []model.HeldPath
+			result2 error
+		})
+	}
+	fake.heldPathsReturnsOnCall[i] = struct {
+		result1 []model.HeldPath
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) HoldPaths(arg1 string, arg2 []string) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.holdPathsMutex.Lock()
+	ret, specificReturn := fake.holdPathsReturnsOnCall[len(fake.holdPathsArgsForCall)]
+	fake.holdPathsArgsForCall = append(fake.holdPathsArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.HoldPathsStub
+	fakeReturns := fake.holdPathsReturns
+	fake.recordInvocation("HoldPaths", []interface{}{arg1, arg2Copy})
+	fake.holdPathsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) HoldPathsCallCount() int {
+	fake.holdPathsMutex.RLock()
+	defer fake.holdPathsMutex.RUnlock()
+	return len(fake.holdPathsArgsForCall)
+}
+
+func (fake *Model) HoldPathsCalls(stub func(string, []string) error) {
+	fake.holdPathsMutex.Lock()
+	defer fake.holdPathsMutex.Unlock()
+	fake.HoldPathsStub = stub
+}
+
+func (fake *Model) HoldPathsArgsForCall(i int) (string, []string) {
+	fake.holdPathsMutex.RLock()
+	defer fake.holdPathsMutex.RUnlock()
+	argsForCall := fake.holdPathsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) HoldPathsReturns(result1 error) {
+	fake.holdPathsMutex.Lock()
+	defer fake.holdPathsMutex.Unlock()
+	fake.HoldPathsStub = nil
+	fake.holdPathsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) HoldPathsReturnsOnCall(i int, result1 error) {
+	fake.holdPathsMutex.Lock()
+	defer fake.holdPathsMutex.Unlock()
+	fake.HoldPathsStub = nil
+	if fake.holdPathsReturnsOnCall == nil {
+		fake.holdPathsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.holdPathsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Model) Index(arg1 protocol.Connection, arg2 string, arg3 []protocol.FileInfo) error {
 	var arg3Copy []protocol.FileInfo
 	if arg3 != nil {
@@ -2259,6 +4156,182 @@ func (fake *Model) LocalChangedFolderFilesReturnsOnCall(i int, result1 []db.File
 	}{result1, result2}
 }
 
+func (fake *Model) LockFolder(arg1 string) error {
+	fake.lockFolderMutex.Lock()
+	ret, specificReturn := fake.lockFolderReturnsOnCall[len(fake.lockFolderArgsForCall)]
+	fake.lockFolderArgsForCall = append(fake.lockFolderArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.LockFolderStub
+	fakeReturns := fake.lockFolderReturns
+	fake.recordInvocation("LockFolder", []interface{}{arg1})
+	fake.lockFolderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) LockFolderCallCount() int {
+	fake.lockFolderMutex.RLock()
+	defer fake.lockFolderMutex.RUnlock()
+	return len(fake.lockFolderArgsForCall)
+}
+
+func (fake *Model) LockFolderCalls(stub func(string) error) {
+	fake.lockFolderMutex.Lock()
+	defer fake.lockFolderMutex.Unlock()
+	fake.LockFolderStub = stub
+}
+
+func (fake *Model) LockFolderArgsForCall(i int) string {
+	fake.lockFolderMutex.RLock()
+	defer fake.lockFolderMutex.RUnlock()
+	argsForCall := fake.lockFolderArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) LockFolderReturns(result1 error) {
+	fake.lockFolderMutex.Lock()
+	defer fake.lockFolderMutex.Unlock()
+	fake.LockFolderStub = nil
+	fake.lockFolderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) LockFolderReturnsOnCall(i int, result1 error) {
+	fake.lockFolderMutex.Lock()
+	defer fake.lockFolderMutex.Unlock()
+	fake.LockFolderStub = nil
+	if fake.lockFolderReturnsOnCall == nil {
+		fake.lockFolderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.lockFolderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) ManagedDevices() map[protocol.DeviceID]model.ManagedDeviceStatus {
+	fake.managedDevicesMutex.Lock()
+	ret, specificReturn := fake.managedDevicesReturnsOnCall[len(fake.managedDevicesArgsForCall)]
+	fake.managedDevicesArgsForCall = append(fake.managedDevicesArgsForCall, struct {
+	}{})
+	stub := fake.ManagedDevicesStub
+	fakeReturns := fake.managedDevicesReturns
+	fake.recordInvocation("ManagedDevices", []interface{}{})
+	fake.managedDevicesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) ManagedDevicesCallCount() int {
+	fake.managedDevicesMutex.RLock()
+	defer fake.managedDevicesMutex.RUnlock()
+	return len(fake.managedDevicesArgsForCall)
+}
+
+func (fake *Model) ManagedDevicesCalls(stub func() map[protocol.DeviceID]model.ManagedDeviceStatus) {
+	fake.managedDevicesMutex.Lock()
+	defer fake.managedDevicesMutex.Unlock()
+	fake.ManagedDevicesStub = stub
+}
+
+func (fake *Model) ManagedDevicesReturns(result1 map[protocol.DeviceID]model.ManagedDeviceStatus) {
+	fake.managedDevicesMutex.Lock()
+	defer fake.managedDevicesMutex.Unlock()
+	fake.ManagedDevicesStub = nil
+	fake.managedDevicesReturns = struct {
+		result1 map[protocol.DeviceID]model.ManagedDeviceStatus
+	}{result1}
+}
+
+func (fake *Model) ManagedDevicesReturnsOnCall(i int, result1 map[protocol.DeviceID]model.ManagedDeviceStatus) {
+	fake.managedDevicesMutex.Lock()
+	defer fake.managedDevicesMutex.Unlock()
+	fake.ManagedDevicesStub = nil
+	if fake.managedDevicesReturnsOnCall == nil {
+		fake.managedDevicesReturnsOnCall = make(map[int]struct {
+			result1 map[protocol.DeviceID]model.ManagedDeviceStatus
+		})
+	}
+	fake.managedDevicesReturnsOnCall[i] = struct {
+		result1 map[protocol.DeviceID]model.ManagedDeviceStatus
+	}{result1}
+}
+
+func (fake *Model) MoveFolders(arg1 map[string]string, arg2 bool) error {
+	fake.moveFoldersMutex.Lock()
+	ret, specificReturn := fake.moveFoldersReturnsOnCall[len(fake.moveFoldersArgsForCall)]
+	fake.moveFoldersArgsForCall = append(fake.moveFoldersArgsForCall, struct {
+		arg1 map[string]string
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.MoveFoldersStub
+	fakeReturns := fake.moveFoldersReturns
+	fake.recordInvocation("MoveFolders", []interface{}{arg1, arg2})
+	fake.moveFoldersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) MoveFoldersCallCount() int {
+	fake.moveFoldersMutex.RLock()
+	defer fake.moveFoldersMutex.RUnlock()
+	return len(fake.moveFoldersArgsForCall)
+}
+
+func (fake *Model) MoveFoldersCalls(stub func(map[string]string, bool) error) {
+	fake.moveFoldersMutex.Lock()
+	defer fake.moveFoldersMutex.Unlock()
+	fake.MoveFoldersStub = stub
+}
+
+func (fake *Model) MoveFoldersArgsForCall(i int) (map[string]string, bool) {
+	fake.moveFoldersMutex.RLock()
+	defer fake.moveFoldersMutex.RUnlock()
+	argsForCall := fake.moveFoldersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) MoveFoldersReturns(result1 error) {
+	fake.moveFoldersMutex.Lock()
+	defer fake.moveFoldersMutex.Unlock()
+	fake.MoveFoldersStub = nil
+	fake.moveFoldersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) MoveFoldersReturnsOnCall(i int, result1 error) {
+	fake.moveFoldersMutex.Lock()
+	defer fake.moveFoldersMutex.Unlock()
+	fake.MoveFoldersStub = nil
+	if fake.moveFoldersReturnsOnCall == nil {
+		fake.moveFoldersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.moveFoldersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Model) NeedFolderFiles(arg1 string, arg2 int, arg3 int) ([]db.FileInfoTruncated, []db.FileInfoTruncated, []db.FileInfoTruncated, error) {
 	fake.needFolderFilesMutex.Lock()
 	ret, specificReturn := fake.needFolderFilesReturnsOnCall[len(fake.needFolderFilesArgsForCall)]
@@ -2442,9 +4515,74 @@ func (fake *Model) OnHelloReturnsOnCall(i int, result1 error) {
 			result1 error
 		})
 	}
-	fake.onHelloReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.onHelloReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) OpenPlaintext(arg1 string, arg2 string) (fs.File, error) {
+	fake.openPlaintextMutex.Lock()
+	ret, specificReturn := fake.openPlaintextReturnsOnCall[len(fake.openPlaintextArgsForCall)]
+	fake.openPlaintextArgsForCall = append(fake.openPlaintextArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.OpenPlaintextStub
+	fakeReturns := fake.openPlaintextReturns
+	fake.recordInvocation("OpenPlaintext", []interface{}{arg1, arg2})
+	fake.openPlaintextMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) OpenPlaintextCallCount() int {
+	fake.openPlaintextMutex.RLock()
+	defer fake.openPlaintextMutex.RUnlock()
+	return len(fake.openPlaintextArgsForCall)
+}
+
+func (fake *Model) OpenPlaintextCalls(stub func(string, string) (fs.File, error)) {
+	fake.openPlaintextMutex.Lock()
+	defer fake.openPlaintextMutex.Unlock()
+	fake.OpenPlaintextStub = stub
+}
+
+func (fake *Model) OpenPlaintextArgsForCall(i int) (string, string) {
+	fake.openPlaintextMutex.RLock()
+	defer fake.openPlaintextMutex.RUnlock()
+	argsForCall := fake.openPlaintextArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) OpenPlaintextReturns(result1 fs.File, result2 error) {
+	fake.openPlaintextMutex.Lock()
+	defer fake.openPlaintextMutex.Unlock()
+	fake.OpenPlaintextStub = nil
+	fake.openPlaintextReturns = struct {
+		result1 fs.File
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) OpenPlaintextReturnsOnCall(i int, result1 fs.File, result2 error) {
+	fake.openPlaintextMutex.Lock()
+	defer fake.openPlaintextMutex.Unlock()
+	fake.OpenPlaintextStub = nil
+	if fake.openPlaintextReturnsOnCall == nil {
+		fake.openPlaintextReturnsOnCall = make(map[int]struct {
+			result1 fs.File
+			result2 error
+		})
+	}
+	fake.openPlaintextReturnsOnCall[i] = struct {
+		result1 fs.File
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *Model) Override(arg1 string) {
@@ -2599,6 +4737,327 @@ func (fake *Model) PendingFoldersReturnsOnCall(i int, result1 map[string]db.Pend
 	}{result1, result2}
 }
 
+func (fake *Model) PurgeFolderVersions(arg1 string, arg2 map[string]time.Time) (map[string]error, error) {
+	fake.purgeFolderVersionsMutex.Lock()
+	ret, specificReturn := fake.purgeFolderVersionsReturnsOnCall[len(fake.purgeFolderVersionsArgsForCall)]
+	fake.purgeFolderVersionsArgsForCall = append(fake.purgeFolderVersionsArgsForCall, struct {
+		arg1 string
+		arg2 map[string]time.Time
+	}{arg1, arg2})
+	stub := fake.PurgeFolderVersionsStub
+	fakeReturns := fake.purgeFolderVersionsReturns
+	fake.recordInvocation("PurgeFolderVersions", []interface{}{arg1, arg2})
+	fake.purgeFolderVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) PurgeFolderVersionsCallCount() int {
+	fake.purgeFolderVersionsMutex.RLock()
+	defer fake.purgeFolderVersionsMutex.RUnlock()
+	return len(fake.purgeFolderVersionsArgsForCall)
+}
+
+func (fake *Model) PurgeFolderVersionsCalls(stub func(string, map[string]time.Time) (map[string]error, error)) {
+	fake.purgeFolderVersionsMutex.Lock()
+	defer fake.purgeFolderVersionsMutex.Unlock()
+	fake.PurgeFolderVersionsStub = stub
+}
+
+func (fake *Model) PurgeFolderVersionsArgsForCall(i int) (string, map[string]time.Time) {
+	fake.purgeFolderVersionsMutex.RLock()
+	defer fake.purgeFolderVersionsMutex.RUnlock()
+	argsForCall := fake.purgeFolderVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) PurgeFolderVersionsReturns(result1 map[string]error, result2 error) {
+	fake.purgeFolderVersionsMutex.Lock()
+	defer fake.purgeFolderVersionsMutex.Unlock()
+	fake.PurgeFolderVersionsStub = nil
+	fake.purgeFolderVersionsReturns = struct {
+		result1 map[string]error
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) PurgeFolderVersionsReturnsOnCall(i int, result1 map[string]error, result2 error) {
+	fake.purgeFolderVersionsMutex.Lock()
+	defer fake.purgeFolderVersionsMutex.Unlock()
+	fake.PurgeFolderVersionsStub = nil
+	if fake.purgeFolderVersionsReturnsOnCall == nil {
+		fake.purgeFolderVersionsReturnsOnCall = make(map[int]struct {
+			result1 map[string]error
+			result2 error
+		})
+	}
+	fake.purgeFolderVersionsReturnsOnCall[i] = struct {
+		result1 map[string]error
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) PushControlTemplate(arg1 context.Context, arg2 protocol.DeviceID, arg3 model.ControlTemplate) error {
+	fake.pushControlTemplateMutex.Lock()
+	ret, specificReturn := fake.pushControlTemplateReturnsOnCall[len(fake.pushControlTemplateArgsForCall)]
+	fake.pushControlTemplateArgsForCall = append(fake.pushControlTemplateArgsForCall, struct {
+		arg1 context.Context
+		arg2 protocol.DeviceID
+		arg3 model.ControlTemplate
+	}{arg1, arg2, arg3})
+	stub := fake.PushControlTemplateStub
+	fakeReturns := fake.pushControlTemplateReturns
+	fake.recordInvocation("PushControlTemplate", []interface{}{arg1, arg2, arg3})
+	fake.pushControlTemplateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) PushControlTemplateCallCount() int {
+	fake.pushControlTemplateMutex.RLock()
+	defer fake.pushControlTemplateMutex.RUnlock()
+	return len(fake.pushControlTemplateArgsForCall)
+}
+
+func (fake *Model) PushControlTemplateCalls(stub func(context.Context, protocol.DeviceID, model.ControlTemplate) error) {
+	fake.pushControlTemplateMutex.Lock()
+	defer fake.pushControlTemplateMutex.Unlock()
+	fake.PushControlTemplateStub = stub
+}
+
+func (fake *Model) PushControlTemplateArgsForCall(i int) (context.Context, protocol.DeviceID, model.ControlTemplate) {
+	fake.pushControlTemplateMutex.RLock()
+	defer fake.pushControlTemplateMutex.RUnlock()
+	argsForCall := fake.pushControlTemplateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Model) PushControlTemplateReturns(result1 error) {
+	fake.pushControlTemplateMutex.Lock()
+	defer fake.pushControlTemplateMutex.Unlock()
+	fake.PushControlTemplateStub = nil
+	fake.pushControlTemplateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) PushControlTemplateReturnsOnCall(i int, result1 error) {
+	fake.pushControlTemplateMutex.Lock()
+	defer fake.pushControlTemplateMutex.Unlock()
+	fake.PushControlTemplateStub = nil
+	if fake.pushControlTemplateReturnsOnCall == nil {
+		fake.pushControlTemplateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pushControlTemplateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) RecycledItems(arg1 string, arg2 time.Time) ([]model.RecycledItem, error) {
+	fake.recycledItemsMutex.Lock()
+	ret, specificReturn := fake.recycledItemsReturnsOnCall[len(fake.recycledItemsArgsForCall)]
+	fake.recycledItemsArgsForCall = append(fake.recycledItemsArgsForCall, struct {
+		arg1 string
+		arg2 time.Time
+	}{arg1, arg2})
+	stub := fake.RecycledItemsStub
+	fakeReturns := fake.recycledItemsReturns
+	fake.recordInvocation("RecycledItems", []interface{}{arg1, arg2})
+	fake.recycledItemsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) RecycledItemsCallCount() int {
+	fake.recycledItemsMutex.RLock()
+	defer fake.recycledItemsMutex.RUnlock()
+	return len(fake.recycledItemsArgsForCall)
+}
+
+func (fake *Model) RecycledItemsCalls(stub func(string, time.Time) ([]model.RecycledItem, error)) {
+	fake.recycledItemsMutex.Lock()
+	defer fake.recycledItemsMutex.Unlock()
+	fake.RecycledItemsStub = stub
+}
+
+func (fake *Model) RecycledItemsArgsForCall(i int) (string, time.Time) {
+	fake.recycledItemsMutex.RLock()
+	defer fake.recycledItemsMutex.RUnlock()
+	argsForCall := fake.recycledItemsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) RecycledItemsReturns(result1 []model.RecycledItem, result2 error) {
+	fake.recycledItemsMutex.Lock()
+	defer fake.recycledItemsMutex.Unlock()
+	fake.RecycledItemsStub = nil
+	fake.recycledItemsReturns = struct {
+		result1 []model.RecycledItem
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) RecycledItemsReturnsOnCall(i int, result1 []model.RecycledItem, result2 error) {
+	fake.recycledItemsMutex.Lock()
+	defer fake.recycledItemsMutex.Unlock()
+	fake.RecycledItemsStub = nil
+	if fake.recycledItemsReturnsOnCall == nil {
+		fake.recycledItemsReturnsOnCall = make(map[int]struct {
+			result1 []model.RecycledItem
+			result2 error
+		})
+	}
+	fake.recycledItemsReturnsOnCall[i] = struct {
+		result1 []model.RecycledItem
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) ReleaseBackupSnapshot(arg1 string) error {
+	fake.releaseBackupSnapshotMutex.Lock()
+	ret, specificReturn := fake.releaseBackupSnapshotReturnsOnCall[len(fake.releaseBackupSnapshotArgsForCall)]
+	fake.releaseBackupSnapshotArgsForCall = append(fake.releaseBackupSnapshotArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReleaseBackupSnapshotStub
+	fakeReturns := fake.releaseBackupSnapshotReturns
+	fake.recordInvocation("ReleaseBackupSnapshot", []interface{}{arg1})
+	fake.releaseBackupSnapshotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) ReleaseBackupSnapshotCallCount() int {
+	fake.releaseBackupSnapshotMutex.RLock()
+	defer fake.releaseBackupSnapshotMutex.RUnlock()
+	return len(fake.releaseBackupSnapshotArgsForCall)
+}
+
+func (fake *Model) ReleaseBackupSnapshotCalls(stub func(string) error) {
+	fake.releaseBackupSnapshotMutex.Lock()
+	defer fake.releaseBackupSnapshotMutex.Unlock()
+	fake.ReleaseBackupSnapshotStub = stub
+}
+
+func (fake *Model) ReleaseBackupSnapshotArgsForCall(i int) string {
+	fake.releaseBackupSnapshotMutex.RLock()
+	defer fake.releaseBackupSnapshotMutex.RUnlock()
+	argsForCall := fake.releaseBackupSnapshotArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) ReleaseBackupSnapshotReturns(result1 error) {
+	fake.releaseBackupSnapshotMutex.Lock()
+	defer fake.releaseBackupSnapshotMutex.Unlock()
+	fake.ReleaseBackupSnapshotStub = nil
+	fake.releaseBackupSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) ReleaseBackupSnapshotReturnsOnCall(i int, result1 error) {
+	fake.releaseBackupSnapshotMutex.Lock()
+	defer fake.releaseBackupSnapshotMutex.Unlock()
+	fake.ReleaseBackupSnapshotStub = nil
+	if fake.releaseBackupSnapshotReturnsOnCall == nil {
+		fake.releaseBackupSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseBackupSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) ReleasePaths(arg1 string, arg2 []string) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.releasePathsMutex.Lock()
+	ret, specificReturn := fake.releasePathsReturnsOnCall[len(fake.releasePathsArgsForCall)]
+	fake.releasePathsArgsForCall = append(fake.releasePathsArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.ReleasePathsStub
+	fakeReturns := fake.releasePathsReturns
+	fake.recordInvocation("ReleasePaths", []interface{}{arg1, arg2Copy})
+	fake.releasePathsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) ReleasePathsCallCount() int {
+	fake.releasePathsMutex.RLock()
+	defer fake.releasePathsMutex.RUnlock()
+	return len(fake.releasePathsArgsForCall)
+}
+
+func (fake *Model) ReleasePathsCalls(stub func(string, []string) error) {
+	fake.releasePathsMutex.Lock()
+	defer fake.releasePathsMutex.Unlock()
+	fake.ReleasePathsStub = stub
+}
+
+func (fake *Model) ReleasePathsArgsForCall(i int) (string, []string) {
+	fake.releasePathsMutex.RLock()
+	defer fake.releasePathsMutex.RUnlock()
+	argsForCall := fake.releasePathsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) ReleasePathsReturns(result1 error) {
+	fake.releasePathsMutex.Lock()
+	defer fake.releasePathsMutex.Unlock()
+	fake.ReleasePathsStub = nil
+	fake.releasePathsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) ReleasePathsReturnsOnCall(i int, result1 error) {
+	fake.releasePathsMutex.Lock()
+	defer fake.releasePathsMutex.Unlock()
+	fake.ReleasePathsStub = nil
+	if fake.releasePathsReturnsOnCall == nil {
+		fake.releasePathsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releasePathsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Model) RemoteNeedFolderFiles(arg1 string, arg2 protocol.DeviceID, arg3 int, arg4 int) ([]db.FileInfoTruncated, error) {
 	fake.remoteNeedFolderFilesMutex.Lock()
 	ret, specificReturn := fake.remoteNeedFolderFilesReturnsOnCall[len(fake.remoteNeedFolderFilesArgsForCall)]
@@ -2804,6 +5263,121 @@ func (fake *Model) ResetFolderReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Model) ResolveEmptiedFolder(arg1 string, arg2 bool) error {
+	fake.resolveEmptiedFolderMutex.Lock()
+	ret, specificReturn := fake.resolveEmptiedFolderReturnsOnCall[len(fake.resolveEmptiedFolderArgsForCall)]
+	fake.resolveEmptiedFolderArgsForCall = append(fake.resolveEmptiedFolderArgsForCall, struct {
+		arg1 string
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.ResolveEmptiedFolderStub
+	fakeReturns := fake.resolveEmptiedFolderReturns
+	fake.recordInvocation("ResolveEmptiedFolder", []interface{}{arg1, arg2})
+	fake.resolveEmptiedFolderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) ResolveEmptiedFolderCallCount() int {
+	fake.resolveEmptiedFolderMutex.RLock()
+	defer fake.resolveEmptiedFolderMutex.RUnlock()
+	return len(fake.resolveEmptiedFolderArgsForCall)
+}
+
+func (fake *Model) ResolveEmptiedFolderCalls(stub func(string, bool) error) {
+	fake.resolveEmptiedFolderMutex.Lock()
+	defer fake.resolveEmptiedFolderMutex.Unlock()
+	fake.ResolveEmptiedFolderStub = stub
+}
+
+func (fake *Model) ResolveEmptiedFolderArgsForCall(i int) (string, bool) {
+	fake.resolveEmptiedFolderMutex.RLock()
+	defer fake.resolveEmptiedFolderMutex.RUnlock()
+	argsForCall := fake.resolveEmptiedFolderArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) ResolveEmptiedFolderReturns(result1 error) {
+	fake.resolveEmptiedFolderMutex.Lock()
+	defer fake.resolveEmptiedFolderMutex.Unlock()
+	fake.ResolveEmptiedFolderStub = nil
+	fake.resolveEmptiedFolderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) ResolveEmptiedFolderReturnsOnCall(i int, result1 error) {
+	fake.resolveEmptiedFolderMutex.Lock()
+	defer fake.resolveEmptiedFolderMutex.Unlock()
+	fake.ResolveEmptiedFolderStub = nil
+	if fake.resolveEmptiedFolderReturnsOnCall == nil {
+		fake.resolveEmptiedFolderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resolveEmptiedFolderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) RestartCoordination() model.RestartCoordinationStatus {
+	fake.restartCoordinationMutex.Lock()
+	ret, specificReturn := fake.restartCoordinationReturnsOnCall[len(fake.restartCoordinationArgsForCall)]
+	fake.restartCoordinationArgsForCall = append(fake.restartCoordinationArgsForCall, struct {
+	}{})
+	stub := fake.RestartCoordinationStub
+	fakeReturns := fake.restartCoordinationReturns
+	fake.recordInvocation("RestartCoordination", []interface{}{})
+	fake.restartCoordinationMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) RestartCoordinationCallCount() int {
+	fake.restartCoordinationMutex.RLock()
+	defer fake.restartCoordinationMutex.RUnlock()
+	return len(fake.restartCoordinationArgsForCall)
+}
+
+func (fake *Model) RestartCoordinationCalls(stub func() model.RestartCoordinationStatus) {
+	fake.restartCoordinationMutex.Lock()
+	defer fake.restartCoordinationMutex.Unlock()
+	fake.RestartCoordinationStub = stub
+}
+
+func (fake *Model) RestartCoordinationReturns(result1 model.RestartCoordinationStatus) {
+	fake.restartCoordinationMutex.Lock()
+	defer fake.restartCoordinationMutex.Unlock()
+	fake.RestartCoordinationStub = nil
+	fake.restartCoordinationReturns = struct {
+		result1 model.RestartCoordinationStatus
+	}{result1}
+}
+
+func (fake *Model) RestartCoordinationReturnsOnCall(i int, result1 model.RestartCoordinationStatus) {
+	fake.restartCoordinationMutex.Lock()
+	defer fake.restartCoordinationMutex.Unlock()
+	fake.RestartCoordinationStub = nil
+	if fake.restartCoordinationReturnsOnCall == nil {
+		fake.restartCoordinationReturnsOnCall = make(map[int]struct {
+			result1 model.RestartCoordinationStatus
+		})
+	}
+	fake.restartCoordinationReturnsOnCall[i] = struct {
+		result1 model.RestartCoordinationStatus
+	}{result1}
+}
+
 func (fake *Model) RestoreFolderVersions(arg1 string, arg2 map[string]time.Time) (map[string]error, error) {
 	fake.restoreFolderVersionsMutex.Lock()
 	ret, specificReturn := fake.restoreFolderVersionsReturnsOnCall[len(fake.restoreFolderVersionsArgsForCall)]
@@ -2853,20 +5427,87 @@ func (fake *Model) RestoreFolderVersionsReturns(result1 map[string]error, result
 	}{result1, result2}
 }
 
-func (fake *Model) RestoreFolderVersionsReturnsOnCall(i int, result1 map[string]error, result2 error) {
-	fake.restoreFolderVersionsMutex.Lock()
-	defer fake.restoreFolderVersionsMutex.Unlock()
-	fake.RestoreFolderVersionsStub = nil
-	if fake.restoreFolderVersionsReturnsOnCall == nil {
-		fake.restoreFolderVersionsReturnsOnCall = make(map[int]struct {
-			result1 map[string]error
-			result2 error
+func (fake *Model) RestoreFolderVersionsReturnsOnCall(i int, result1 map[string]error, result2 error) {
+	fake.restoreFolderVersionsMutex.Lock()
+	defer fake.restoreFolderVersionsMutex.Unlock()
+	fake.RestoreFolderVersionsStub = nil
+	if fake.restoreFolderVersionsReturnsOnCall == nil {
+		fake.restoreFolderVersionsReturnsOnCall = make(map[int]struct {
+			result1 map[string]error
+			result2 error
+		})
+	}
+	fake.restoreFolderVersionsReturnsOnCall[i] = struct {
+		result1 map[string]error
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) RetryQuarantined(arg1 string, arg2 []string) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.retryQuarantinedMutex.Lock()
+	ret, specificReturn := fake.retryQuarantinedReturnsOnCall[len(fake.retryQuarantinedArgsForCall)]
+	fake.retryQuarantinedArgsForCall = append(fake.retryQuarantinedArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.RetryQuarantinedStub
+	fakeReturns := fake.retryQuarantinedReturns
+	fake.recordInvocation("RetryQuarantined", []interface{}{arg1, arg2Copy})
+	fake.retryQuarantinedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) RetryQuarantinedCallCount() int {
+	fake.retryQuarantinedMutex.RLock()
+	defer fake.retryQuarantinedMutex.RUnlock()
+	return len(fake.retryQuarantinedArgsForCall)
+}
+
+func (fake *Model) RetryQuarantinedCalls(stub func(string, []string) error) {
+	fake.retryQuarantinedMutex.Lock()
+	defer fake.retryQuarantinedMutex.Unlock()
+	fake.RetryQuarantinedStub = stub
+}
+
+func (fake *Model) RetryQuarantinedArgsForCall(i int) (string, []string) {
+	fake.retryQuarantinedMutex.RLock()
+	defer fake.retryQuarantinedMutex.RUnlock()
+	argsForCall := fake.retryQuarantinedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) RetryQuarantinedReturns(result1 error) {
+	fake.retryQuarantinedMutex.Lock()
+	defer fake.retryQuarantinedMutex.Unlock()
+	fake.RetryQuarantinedStub = nil
+	fake.retryQuarantinedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) RetryQuarantinedReturnsOnCall(i int, result1 error) {
+	fake.retryQuarantinedMutex.Lock()
+	defer fake.retryQuarantinedMutex.Unlock()
+	fake.RetryQuarantinedStub = nil
+	if fake.retryQuarantinedReturnsOnCall == nil {
+		fake.retryQuarantinedReturnsOnCall = make(map[int]struct {
+			result1 error
 		})
 	}
-	fake.restoreFolderVersionsReturnsOnCall[i] = struct {
-		result1 map[string]error
-		result2 error
-	}{result1, result2}
+	fake.retryQuarantinedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
 }
 
 func (fake *Model) Revert(arg1 string) {
@@ -2901,6 +5542,72 @@ func (fake *Model) RevertArgsForCall(i int) string {
 	return argsForCall.arg1
 }
 
+func (fake *Model) RevokeDevice(arg1 context.Context, arg2 protocol.DeviceID, arg3 bool) (map[protocol.DeviceID]error, error) {
+	fake.revokeDeviceMutex.Lock()
+	ret, specificReturn := fake.revokeDeviceReturnsOnCall[len(fake.revokeDeviceArgsForCall)]
+	fake.revokeDeviceArgsForCall = append(fake.revokeDeviceArgsForCall, struct {
+		arg1 context.Context
+		arg2 protocol.DeviceID
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.RevokeDeviceStub
+	fakeReturns := fake.revokeDeviceReturns
+	fake.recordInvocation("RevokeDevice", []interface{}{arg1, arg2, arg3})
+	fake.revokeDeviceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) RevokeDeviceCallCount() int {
+	fake.revokeDeviceMutex.RLock()
+	defer fake.revokeDeviceMutex.RUnlock()
+	return len(fake.revokeDeviceArgsForCall)
+}
+
+func (fake *Model) RevokeDeviceCalls(stub func(context.Context, protocol.DeviceID, bool) (map[protocol.DeviceID]error, error)) {
+	fake.revokeDeviceMutex.Lock()
+	defer fake.revokeDeviceMutex.Unlock()
+	fake.RevokeDeviceStub = stub
+}
+
+func (fake *Model) RevokeDeviceArgsForCall(i int) (context.Context, protocol.DeviceID, bool) {
+	fake.revokeDeviceMutex.RLock()
+	defer fake.revokeDeviceMutex.RUnlock()
+	argsForCall := fake.revokeDeviceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Model) RevokeDeviceReturns(result1 map[protocol.DeviceID]error, result2 error) {
+	fake.revokeDeviceMutex.Lock()
+	defer fake.revokeDeviceMutex.Unlock()
+	fake.RevokeDeviceStub = nil
+	fake.revokeDeviceReturns = struct {
+		result1 map[protocol.DeviceID]error
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) RevokeDeviceReturnsOnCall(i int, result1 map[protocol.DeviceID]error, result2 error) {
+	fake.revokeDeviceMutex.Lock()
+	defer fake.revokeDeviceMutex.Unlock()
+	fake.RevokeDeviceStub = nil
+	if fake.revokeDeviceReturnsOnCall == nil {
+		fake.revokeDeviceReturnsOnCall = make(map[int]struct {
+			result1 map[protocol.DeviceID]error
+			result2 error
+		})
+	}
+	fake.revokeDeviceReturnsOnCall[i] = struct {
+		result1 map[protocol.DeviceID]error
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Model) ScanFolder(arg1 string) error {
 	fake.scanFolderMutex.Lock()
 	ret, specificReturn := fake.scanFolderReturnsOnCall[len(fake.scanFolderArgsForCall)]
@@ -3082,6 +5789,219 @@ func (fake *Model) ScanFoldersReturnsOnCall(i int, result1 map[string]error) {
 	}{result1}
 }
 
+func (fake *Model) ScanSummaries(arg1 string) ([]model.ScanSummary, error) {
+	fake.scanSummariesMutex.Lock()
+	ret, specificReturn := fake.scanSummariesReturnsOnCall[len(fake.scanSummariesArgsForCall)]
+	fake.scanSummariesArgsForCall = append(fake.scanSummariesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ScanSummariesStub
+	fakeReturns := fake.scanSummariesReturns
+	fake.recordInvocation("ScanSummaries", []interface{}{arg1})
+	fake.scanSummariesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) ScanSummariesCallCount() int {
+	fake.scanSummariesMutex.RLock()
+	defer fake.scanSummariesMutex.RUnlock()
+	return len(fake.scanSummariesArgsForCall)
+}
+
+func (fake *Model) ScanSummariesCalls(stub func(string) ([]model.ScanSummary, error)) {
+	fake.scanSummariesMutex.Lock()
+	defer fake.scanSummariesMutex.Unlock()
+	fake.ScanSummariesStub = stub
+}
+
+func (fake *Model) ScanSummariesArgsForCall(i int) string {
+	fake.scanSummariesMutex.RLock()
+	defer fake.scanSummariesMutex.RUnlock()
+	argsForCall := fake.scanSummariesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) ScanSummariesReturns(result1 []model.ScanSummary, result2 error) {
+	fake.scanSummariesMutex.Lock()
+	defer fake.scanSummariesMutex.Unlock()
+	fake.ScanSummariesStub = nil
+	fake.scanSummariesReturns = struct {
+		result1 []model.ScanSummary
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) ScanSummariesReturnsOnCall(i int, result1 []model.ScanSummary, result2 error) {
+	fake.scanSummariesMutex.Lock()
+	defer fake.scanSummariesMutex.Unlock()
+	fake.ScanSummariesStub = nil
+	if fake.scanSummariesReturnsOnCall == nil {
+		fake.scanSummariesReturnsOnCall = make(map[int]struct {
+			result1 []model.ScanSummary
+			result2 error
+		})
+	}
+	fake.scanSummariesReturnsOnCall[i] = struct {
+		result1 []model.ScanSummary
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) ScrubBlockPool() {
+	fake.scrubBlockPoolMutex.Lock()
+	fake.scrubBlockPoolArgsForCall = append(fake.scrubBlockPoolArgsForCall, struct {
+	}{})
+	stub := fake.ScrubBlockPoolStub
+	fake.recordInvocation("ScrubBlockPool", []interface{}{})
+	fake.scrubBlockPoolMutex.Unlock()
+	if stub != nil {
+		fake.ScrubBlockPoolStub()
+	}
+}
+
+func (fake *Model) ScrubBlockPoolCallCount() int {
+	fake.scrubBlockPoolMutex.RLock()
+	defer fake.scrubBlockPoolMutex.RUnlock()
+	return len(fake.scrubBlockPoolArgsForCall)
+}
+
+func (fake *Model) ScrubBlockPoolCalls(stub func()) {
+	fake.scrubBlockPoolMutex.Lock()
+	defer fake.scrubBlockPoolMutex.Unlock()
+	fake.ScrubBlockPoolStub = stub
+}
+
+func (fake *Model) ScrubFolder(arg1 string) error {
+	fake.scrubFolderMutex.Lock()
+	ret, specificReturn := fake.scrubFolderReturnsOnCall[len(fake.scrubFolderArgsForCall)]
+	fake.scrubFolderArgsForCall = append(fake.scrubFolderArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ScrubFolderStub
+	fakeReturns := fake.scrubFolderReturns
+	fake.recordInvocation("ScrubFolder", []interface{}{arg1})
+	fake.scrubFolderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) ScrubFolderCallCount() int {
+	fake.scrubFolderMutex.RLock()
+	defer fake.scrubFolderMutex.RUnlock()
+	return len(fake.scrubFolderArgsForCall)
+}
+
+func (fake *Model) ScrubFolderCalls(stub func(string) error) {
+	fake.scrubFolderMutex.Lock()
+	defer fake.scrubFolderMutex.Unlock()
+	fake.ScrubFolderStub = stub
+}
+
+func (fake *Model) ScrubFolderArgsForCall(i int) string {
+	fake.scrubFolderMutex.RLock()
+	defer fake.scrubFolderMutex.RUnlock()
+	argsForCall := fake.scrubFolderArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) ScrubFolderReturns(result1 error) {
+	fake.scrubFolderMutex.Lock()
+	defer fake.scrubFolderMutex.Unlock()
+	fake.ScrubFolderStub = nil
+	fake.scrubFolderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) ScrubFolderReturnsOnCall(i int, result1 error) {
+	fake.scrubFolderMutex.Lock()
+	defer fake.scrubFolderMutex.Unlock()
+	fake.ScrubFolderStub = nil
+	if fake.scrubFolderReturnsOnCall == nil {
+		fake.scrubFolderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.scrubFolderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) ScrubStatus(arg1 string) (model.ScrubStatus, error) {
+	fake.scrubStatusMutex.Lock()
+	ret, specificReturn := fake.scrubStatusReturnsOnCall[len(fake.scrubStatusArgsForCall)]
+	fake.scrubStatusArgsForCall = append(fake.scrubStatusArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ScrubStatusStub
+	fakeReturns := fake.scrubStatusReturns
+	fake.recordInvocation("ScrubStatus", []interface{}{arg1})
+	fake.scrubStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) ScrubStatusCallCount() int {
+	fake.scrubStatusMutex.RLock()
+	defer fake.scrubStatusMutex.RUnlock()
+	return len(fake.scrubStatusArgsForCall)
+}
+
+func (fake *Model) ScrubStatusCalls(stub func(string) (model.ScrubStatus, error)) {
+	fake.scrubStatusMutex.Lock()
+	defer fake.scrubStatusMutex.Unlock()
+	fake.ScrubStatusStub = stub
+}
+
+func (fake *Model) ScrubStatusArgsForCall(i int) string {
+	fake.scrubStatusMutex.RLock()
+	defer fake.scrubStatusMutex.RUnlock()
+	argsForCall := fake.scrubStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) ScrubStatusReturns(result1 model.ScrubStatus, result2 error) {
+	fake.scrubStatusMutex.Lock()
+	defer fake.scrubStatusMutex.Unlock()
+	fake.ScrubStatusStub = nil
+	fake.scrubStatusReturns = struct {
+		result1 model.ScrubStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) ScrubStatusReturnsOnCall(i int, result1 model.ScrubStatus, result2 error) {
+	fake.scrubStatusMutex.Lock()
+	defer fake.scrubStatusMutex.Unlock()
+	fake.ScrubStatusStub = nil
+	if fake.scrubStatusReturnsOnCall == nil {
+		fake.scrubStatusReturnsOnCall = make(map[int]struct {
+			result1 model.ScrubStatus
+			result2 error
+		})
+	}
+	fake.scrubStatusReturnsOnCall[i] = struct {
+		result1 model.ScrubStatus
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Model) Serve(arg1 context.Context) error {
 	fake.serveMutex.Lock()
 	ret, specificReturn := fake.serveReturnsOnCall[len(fake.serveArgsForCall)]
@@ -3210,6 +6130,59 @@ func (fake *Model) SetIgnoresReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Model) StandbyStatus() model.StandbyStatus {
+	fake.standbyStatusMutex.Lock()
+	ret, specificReturn := fake.standbyStatusReturnsOnCall[len(fake.standbyStatusArgsForCall)]
+	fake.standbyStatusArgsForCall = append(fake.standbyStatusArgsForCall, struct {
+	}{})
+	stub := fake.StandbyStatusStub
+	fakeReturns := fake.standbyStatusReturns
+	fake.recordInvocation("StandbyStatus", []interface{}{})
+	fake.standbyStatusMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) StandbyStatusCallCount() int {
+	fake.standbyStatusMutex.RLock()
+	defer fake.standbyStatusMutex.RUnlock()
+	return len(fake.standbyStatusArgsForCall)
+}
+
+func (fake *Model) StandbyStatusCalls(stub func() model.StandbyStatus) {
+	fake.standbyStatusMutex.Lock()
+	defer fake.standbyStatusMutex.Unlock()
+	fake.StandbyStatusStub = stub
+}
+
+func (fake *Model) StandbyStatusReturns(result1 model.StandbyStatus) {
+	fake.standbyStatusMutex.Lock()
+	defer fake.standbyStatusMutex.Unlock()
+	fake.StandbyStatusStub = nil
+	fake.standbyStatusReturns = struct {
+		result1 model.StandbyStatus
+	}{result1}
+}
+
+func (fake *Model) StandbyStatusReturnsOnCall(i int, result1 model.StandbyStatus) {
+	fake.standbyStatusMutex.Lock()
+	defer fake.standbyStatusMutex.Unlock()
+	fake.StandbyStatusStub = nil
+	if fake.standbyStatusReturnsOnCall == nil {
+		fake.standbyStatusReturnsOnCall = make(map[int]struct {
+			result1 model.StandbyStatus
+		})
+	}
+	fake.standbyStatusReturnsOnCall[i] = struct {
+		result1 model.StandbyStatus
+	}{result1}
+}
+
 func (fake *Model) StartDeadlockDetector(arg1 time.Duration) {
 	fake.startDeadlockDetectorMutex.Lock()
 	fake.startDeadlockDetectorArgsForCall = append(fake.startDeadlockDetectorArgsForCall, struct {
@@ -3309,6 +6282,194 @@ func (fake *Model) StateReturnsOnCall(i int, result1 string, result2 time.Time,
 	}{result1, result2, result3}
 }
 
+func (fake *Model) TransferStatistics(arg1 time.Time, arg2 time.Time) ([]stats.DailyTransferStatistics, error) {
+	fake.transferStatisticsMutex.Lock()
+	ret, specificReturn := fake.transferStatisticsReturnsOnCall[len(fake.transferStatisticsArgsForCall)]
+	fake.transferStatisticsArgsForCall = append(fake.transferStatisticsArgsForCall, struct {
+		arg1 time.Time
+		arg2 time.Time
+	}{arg1, arg2})
+	stub := fake.TransferStatisticsStub
+	fakeReturns := fake.transferStatisticsReturns
+	fake.recordInvocation("TransferStatistics", []interface{}{arg1, arg2})
+	fake.transferStatisticsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) TransferStatisticsCallCount() int {
+	fake.transferStatisticsMutex.RLock()
+	defer fake.transferStatisticsMutex.RUnlock()
+	return len(fake.transferStatisticsArgsForCall)
+}
+
+func (fake *Model) TransferStatisticsCalls(stub func(time.Time, time.Time) ([]stats.DailyTransferStatistics, error)) {
+	fake.transferStatisticsMutex.Lock()
+	defer fake.transferStatisticsMutex.Unlock()
+	fake.TransferStatisticsStub = stub
+}
+
+func (fake *Model) TransferStatisticsArgsForCall(i int) (time.Time, time.Time) {
+	fake.transferStatisticsMutex.RLock()
+	defer fake.transferStatisticsMutex.RUnlock()
+	argsForCall := fake.transferStatisticsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) TransferStatisticsReturns(result1 []stats.DailyTransferStatistics, result2 error) {
+	fake.transferStatisticsMutex.Lock()
+	defer fake.transferStatisticsMutex.Unlock()
+	fake.TransferStatisticsStub = nil
+	fake.transferStatisticsReturns = struct {
+		result1 []stats.DailyTransferStatistics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) TransferStatisticsReturnsOnCall(i int, result1 []stats.DailyTransferStatistics, result2 error) {
+	fake.transferStatisticsMutex.Lock()
+	defer fake.transferStatisticsMutex.Unlock()
+	fake.TransferStatisticsStub = nil
+	if fake.transferStatisticsReturnsOnCall == nil {
+		fake.transferStatisticsReturnsOnCall = make(map[int]struct {
+			result1 []stats.DailyTransferStatistics
+			result2 error
+		})
+	}
+	fake.transferStatisticsReturnsOnCall[i] = struct {
+		result1 []stats.DailyTransferStatistics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) UnfreezeFolder(arg1 string) error {
+	fake.unfreezeFolderMutex.Lock()
+	ret, specificReturn := fake.unfreezeFolderReturnsOnCall[len(fake.unfreezeFolderArgsForCall)]
+	fake.unfreezeFolderArgsForCall = append(fake.unfreezeFolderArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.UnfreezeFolderStub
+	fakeReturns := fake.unfreezeFolderReturns
+	fake.recordInvocation("UnfreezeFolder", []interface{}{arg1})
+	fake.unfreezeFolderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) UnfreezeFolderCallCount() int {
+	fake.unfreezeFolderMutex.RLock()
+	defer fake.unfreezeFolderMutex.RUnlock()
+	return len(fake.unfreezeFolderArgsForCall)
+}
+
+func (fake *Model) UnfreezeFolderCalls(stub func(string) error) {
+	fake.unfreezeFolderMutex.Lock()
+	defer fake.unfreezeFolderMutex.Unlock()
+	fake.UnfreezeFolderStub = stub
+}
+
+func (fake *Model) UnfreezeFolderArgsForCall(i int) string {
+	fake.unfreezeFolderMutex.RLock()
+	defer fake.unfreezeFolderMutex.RUnlock()
+	argsForCall := fake.unfreezeFolderArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Model) UnfreezeFolderReturns(result1 error) {
+	fake.unfreezeFolderMutex.Lock()
+	defer fake.unfreezeFolderMutex.Unlock()
+	fake.UnfreezeFolderStub = nil
+	fake.unfreezeFolderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) UnfreezeFolderReturnsOnCall(i int, result1 error) {
+	fake.unfreezeFolderMutex.Lock()
+	defer fake.unfreezeFolderMutex.Unlock()
+	fake.UnfreezeFolderStub = nil
+	if fake.unfreezeFolderReturnsOnCall == nil {
+		fake.unfreezeFolderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unfreezeFolderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) UnlockFolder(arg1 string, arg2 string) error {
+	fake.unlockFolderMutex.Lock()
+	ret, specificReturn := fake.unlockFolderReturnsOnCall[len(fake.unlockFolderArgsForCall)]
+	fake.unlockFolderArgsForCall = append(fake.unlockFolderArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UnlockFolderStub
+	fakeReturns := fake.unlockFolderReturns
+	fake.recordInvocation("UnlockFolder", []interface{}{arg1, arg2})
+	fake.unlockFolderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) UnlockFolderCallCount() int {
+	fake.unlockFolderMutex.RLock()
+	defer fake.unlockFolderMutex.RUnlock()
+	return len(fake.unlockFolderArgsForCall)
+}
+
+func (fake *Model) UnlockFolderCalls(stub func(string, string) error) {
+	fake.unlockFolderMutex.Lock()
+	defer fake.unlockFolderMutex.Unlock()
+	fake.UnlockFolderStub = stub
+}
+
+func (fake *Model) UnlockFolderArgsForCall(i int) (string, string) {
+	fake.unlockFolderMutex.RLock()
+	defer fake.unlockFolderMutex.RUnlock()
+	argsForCall := fake.unlockFolderArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) UnlockFolderReturns(result1 error) {
+	fake.unlockFolderMutex.Lock()
+	defer fake.unlockFolderMutex.Unlock()
+	fake.UnlockFolderStub = nil
+	fake.unlockFolderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) UnlockFolderReturnsOnCall(i int, result1 error) {
+	fake.unlockFolderMutex.Lock()
+	defer fake.unlockFolderMutex.Unlock()
+	fake.UnlockFolderStub = nil
+	if fake.unlockFolderReturnsOnCall == nil {
+		fake.unlockFolderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unlockFolderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Model) UsageReportingStats(arg1 *contract.Report, arg2 int, arg3 bool) {
 	fake.usageReportingStatsMutex.Lock()
 	fake.usageReportingStatsArgsForCall = append(fake.usageReportingStatsArgsForCall, struct {
@@ -3407,22 +6568,42 @@ func (fake *Model) WatchErrorReturnsOnCall(i int, result1 error) {
 func (fake *Model) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.abortFolderMoveMutex.RLock()
+	defer fake.abortFolderMoveMutex.RUnlock()
+	fake.acquireRestartMutex.RLock()
+	defer fake.acquireRestartMutex.RUnlock()
 	fake.addConnectionMutex.RLock()
 	defer fake.addConnectionMutex.RUnlock()
+	fake.approveMeteredMutex.RLock()
+	defer fake.approveMeteredMutex.RUnlock()
 	fake.availabilityMutex.RLock()
 	defer fake.availabilityMutex.RUnlock()
+	fake.backupSnapshotFilesMutex.RLock()
+	defer fake.backupSnapshotFilesMutex.RUnlock()
+	fake.blockPoolStatusMutex.RLock()
+	defer fake.blockPoolStatusMutex.RUnlock()
 	fake.bringToFrontMutex.RLock()
 	defer fake.bringToFrontMutex.RUnlock()
+	fake.cancelFolderCleanupMutex.RLock()
+	defer fake.cancelFolderCleanupMutex.RUnlock()
 	fake.closedMutex.RLock()
 	defer fake.closedMutex.RUnlock()
 	fake.clusterConfigMutex.RLock()
 	defer fake.clusterConfigMutex.RUnlock()
+	fake.clusterFoldersMutex.RLock()
+	defer fake.clusterFoldersMutex.RUnlock()
+	fake.collectBlockPoolMutex.RLock()
+	defer fake.collectBlockPoolMutex.RUnlock()
 	fake.completionMutex.RLock()
 	defer fake.completionMutex.RUnlock()
 	fake.connectionMutex.RLock()
 	defer fake.connectionMutex.RUnlock()
 	fake.connectionStatsMutex.RLock()
 	defer fake.connectionStatsMutex.RUnlock()
+	fake.controlMutex.RLock()
+	defer fake.controlMutex.RUnlock()
+	fake.createBackupSnapshotMutex.RLock()
+	defer fake.createBackupSnapshotMutex.RUnlock()
 	fake.currentFolderFileMutex.RLock()
 	defer fake.currentFolderFileMutex.RUnlock()
 	fake.currentGlobalFileMutex.RLock()
@@ -3433,6 +6614,8 @@ func (fake *Model) Invocations() map[string][][]interface{} {
 	defer fake.dBSnapshotMutex.RUnlock()
 	fake.delayScanMutex.RLock()
 	defer fake.delayScanMutex.RUnlock()
+	fake.deviceDifferencesMutex.RLock()
+	defer fake.deviceDifferencesMutex.RUnlock()
 	fake.deviceStatisticsMutex.RLock()
 	defer fake.deviceStatisticsMutex.RUnlock()
 	fake.dismissPendingDeviceMutex.RLock()
@@ -3441,12 +6624,32 @@ func (fake *Model) Invocations() map[string][][]interface{} {
 	defer fake.dismissPendingFolderMutex.RUnlock()
 	fake.downloadProgressMutex.RLock()
 	defer fake.downloadProgressMutex.RUnlock()
+	fake.dropDeviceIndexMutex.RLock()
+	defer fake.dropDeviceIndexMutex.RUnlock()
+	fake.failBackMutex.RLock()
+	defer fake.failBackMutex.RUnlock()
+	fake.folderCapabilitiesMutex.RLock()
+	defer fake.folderCapabilitiesMutex.RUnlock()
+	fake.folderCleanupsMutex.RLock()
+	defer fake.folderCleanupsMutex.RUnlock()
+	fake.folderDatabaseSizeMutex.RLock()
+	defer fake.folderDatabaseSizeMutex.RUnlock()
+	fake.folderDeviceIndexesMutex.RLock()
+	defer fake.folderDeviceIndexesMutex.RUnlock()
 	fake.folderErrorsMutex.RLock()
 	defer fake.folderErrorsMutex.RUnlock()
+	fake.folderMoveMutex.RLock()
+	defer fake.folderMoveMutex.RUnlock()
 	fake.folderProgressBytesCompletedMutex.RLock()
 	defer fake.folderProgressBytesCompletedMutex.RUnlock()
+	fake.folderQuarantineMutex.RLock()
+	defer fake.folderQuarantineMutex.RUnlock()
 	fake.folderStatisticsMutex.RLock()
 	defer fake.folderStatisticsMutex.RUnlock()
+	fake.freezeFolderMutex.RLock()
+	defer fake.freezeFolderMutex.RUnlock()
+	fake.freezeStatusMutex.RLock()
+	defer fake.freezeStatusMutex.RUnlock()
 	fake.getFolderVersionsMutex.RLock()
 	defer fake.getFolderVersionsMutex.RUnlock()
 	fake.getHelloMutex.RLock()
@@ -3455,6 +6658,10 @@ func (fake *Model) Invocations() map[string][][]interface{} {
 	defer fake.getMtimeMappingMutex.RUnlock()
 	fake.globalDirectoryTreeMutex.RLock()
 	defer fake.globalDirectoryTreeMutex.RUnlock()
+	fake.heldPathsMutex.RLock()
+	defer fake.heldPathsMutex.RUnlock()
+	fake.holdPathsMutex.RLock()
+	defer fake.holdPathsMutex.RUnlock()
 	fake.indexMutex.RLock()
 	defer fake.indexMutex.RUnlock()
 	fake.indexUpdateMutex.RLock()
@@ -3463,42 +6670,84 @@ func (fake *Model) Invocations() map[string][][]interface{} {
 	defer fake.loadIgnoresMutex.RUnlock()
 	fake.localChangedFolderFilesMutex.RLock()
 	defer fake.localChangedFolderFilesMutex.RUnlock()
+	fake.lockFolderMutex.RLock()
+	defer fake.lockFolderMutex.RUnlock()
+	fake.managedDevicesMutex.RLock()
+	defer fake.managedDevicesMutex.RUnlock()
+	fake.moveFoldersMutex.RLock()
+	defer fake.moveFoldersMutex.RUnlock()
 	fake.needFolderFilesMutex.RLock()
 	defer fake.needFolderFilesMutex.RUnlock()
 	fake.numConnectionsMutex.RLock()
 	defer fake.numConnectionsMutex.RUnlock()
 	fake.onHelloMutex.RLock()
 	defer fake.onHelloMutex.RUnlock()
+	fake.openPlaintextMutex.RLock()
+	defer fake.openPlaintextMutex.RUnlock()
 	fake.overrideMutex.RLock()
 	defer fake.overrideMutex.RUnlock()
 	fake.pendingDevicesMutex.RLock()
 	defer fake.pendingDevicesMutex.RUnlock()
 	fake.pendingFoldersMutex.RLock()
 	defer fake.pendingFoldersMutex.RUnlock()
+	fake.purgeFolderVersionsMutex.RLock()
+	defer fake.purgeFolderVersionsMutex.RUnlock()
+	fake.pushControlTemplateMutex.RLock()
+	defer fake.pushControlTemplateMutex.RUnlock()
+	fake.recycledItemsMutex.RLock()
+	defer fake.recycledItemsMutex.RUnlock()
+	fake.releaseBackupSnapshotMutex.RLock()
+	defer fake.releaseBackupSnapshotMutex.RUnlock()
+	fake.releasePathsMutex.RLock()
+	defer fake.releasePathsMutex.RUnlock()
 	fake.remoteNeedFolderFilesMutex.RLock()
 	defer fake.remoteNeedFolderFilesMutex.RUnlock()
 	fake.requestMutex.RLock()
 	defer fake.requestMutex.RUnlock()
 	fake.resetFolderMutex.RLock()
 	defer fake.resetFolderMutex.RUnlock()
+	fake.resolveEmptiedFolderMutex.RLock()
+	defer fake.resolveEmptiedFolderMutex.RUnlock()
+	fake.restartCoordinationMutex.RLock()
+	defer fake.restartCoordinationMutex.RUnlock()
 	fake.restoreFolderVersionsMutex.RLock()
 	defer fake.restoreFolderVersionsMutex.RUnlock()
+	fake.retryQuarantinedMutex.RLock()
+	defer fake.retryQuarantinedMutex.RUnlock()
 	fake.revertMutex.RLock()
 	defer fake.revertMutex.RUnlock()
+	fake.revokeDeviceMutex.RLock()
+	defer fake.revokeDeviceMutex.RUnlock()
 	fake.scanFolderMutex.RLock()
 	defer fake.scanFolderMutex.RUnlock()
 	fake.scanFolderSubdirsMutex.RLock()
 	defer fake.scanFolderSubdirsMutex.RUnlock()
 	fake.scanFoldersMutex.RLock()
 	defer fake.scanFoldersMutex.RUnlock()
+	fake.scanSummariesMutex.RLock()
+	defer fake.scanSummariesMutex.RUnlock()
+	fake.scrubBlockPoolMutex.RLock()
+	defer fake.scrubBlockPoolMutex.RUnlock()
+	fake.scrubFolderMutex.RLock()
+	defer fake.scrubFolderMutex.RUnlock()
+	fake.scrubStatusMutex.RLock()
+	defer fake.scrubStatusMutex.RUnlock()
 	fake.serveMutex.RLock()
 	defer fake.serveMutex.RUnlock()
 	fake.setIgnoresMutex.RLock()
 	defer fake.setIgnoresMutex.RUnlock()
+	fake.standbyStatusMutex.RLock()
+	defer fake.standbyStatusMutex.RUnlock()
 	fake.startDeadlockDetectorMutex.RLock()
 	defer fake.startDeadlockDetectorMutex.RUnlock()
 	fake.stateMutex.RLock()
 	defer fake.stateMutex.RUnlock()
+	fake.transferStatisticsMutex.RLock()
+	defer fake.transferStatisticsMutex.RUnlock()
+	fake.unfreezeFolderMutex.RLock()
+	defer fake.unfreezeFolderMutex.RUnlock()
+	fake.unlockFolderMutex.RLock()
+	defer fake.unlockFolderMutex.RUnlock()
 	fake.usageReportingStatsMutex.RLock()
 	defer fake.usageReportingStatsMutex.RUnlock()
 	fake.watchErrorMutex.RLock()
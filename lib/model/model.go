@@ -21,12 +21,14 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"sort"
 	"strings"
 	stdsync "sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/thejerf/suture/v4"
+	"golang.org/x/time/rate"
 
 	"github.com/syncthing/syncthing/lib/build"
 	"github.com/syncthing/syncthing/lib/config"
@@ -35,6 +37,8 @@ import (
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/fs"
 	"github.com/syncthing/syncthing/lib/ignore"
+	"github.com/syncthing/syncthing/lib/jobs"
+	"github.com/syncthing/syncthing/lib/locations"
 	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/scanner"
@@ -49,6 +53,7 @@ import (
 type service interface {
 	suture.Service
 	BringToFront(string)
+	PullHint(files []string)
 	Override()
 	Revert()
 	DelayScan(d time.Duration)
@@ -59,6 +64,9 @@ type service interface {
 	Errors() []FileError
 	WatchError() error
 	ScheduleForceRescan(path string)
+	Retry(paths []string)
+	RepairCorrupted(names []string) ([]string, error)
+	ResolveEmptied(repull bool) error
 	GetStatistics() (stats.FolderStatistics, error)
 
 	getState() (folderState, time.Time, error)
@@ -81,9 +89,22 @@ type Model interface {
 	ScanFolderSubdirs(folder string, subs []string) error
 	State(folder string) (string, time.Time, error)
 	FolderErrors(folder string) ([]FileError, error)
+	ScanSummaries(folder string) ([]ScanSummary, error)
+	FolderCapabilities(folder string) (FolderCapabilities, error)
+	FolderQuarantine(folder string) ([]FileError, error)
+	ClusterFolders(id, text string) []AdvertisedFolder
+	FolderCleanups() []FolderCleanup
+	CancelFolderCleanup(folder string) error
+	MoveFolders(targets map[string]string, removeSource bool) error
+	FolderMove() (FolderMove, bool)
+	AbortFolderMove() error
+	FolderDeviceIndexes(folder string) ([]DeviceIndex, error)
+	DropDeviceIndex(folder string, device protocol.DeviceID, compact bool) error
+	RetryQuarantined(folder string, paths []string) error
 	WatchError(folder string) error
 	Override(folder string)
 	Revert(folder string)
+	ResolveEmptiedFolder(folder string, repull bool) error
 	BringToFront(folder, file string)
 	LoadIgnores(folder string) ([]string, []string, error)
 	CurrentIgnores(folder string) ([]string, []string, error)
@@ -91,11 +112,32 @@ type Model interface {
 
 	GetFolderVersions(folder string) (map[string][]versioner.FileVersion, error)
 	RestoreFolderVersions(folder string, versions map[string]time.Time) (map[string]error, error)
+	RecycledItems(folder string, since time.Time) ([]RecycledItem, error)
+	PurgeFolderVersions(folder string, versions map[string]time.Time) (map[string]error, error)
+
+	ScrubFolder(folder string) error
+	ScrubStatus(folder string) (ScrubStatus, error)
+	FreezeFolder(folder string) error
+	UnfreezeFolder(folder string) error
+	FreezeStatus(folder string) (FreezeStatus, error)
+	HoldPaths(folder string, paths []string) error
+	ReleasePaths(folder string, paths []string) error
+	HeldPaths(folder string) ([]HeldPath, error)
+	CollectBlockPool()
+	ScrubBlockPool()
+	BlockPoolStatus() BlockPoolStatus
+	UnlockFolder(folder, password string) error
+	LockFolder(folder string) error
+	OpenPlaintext(folder, file string) (fs.File, error)
 
 	DBSnapshot(folder string) (*db.Snapshot, error)
 	NeedFolderFiles(folder string, page, perpage int) ([]db.FileInfoTruncated, []db.FileInfoTruncated, []db.FileInfoTruncated, error)
 	RemoteNeedFolderFiles(folder string, device protocol.DeviceID, page, perpage int) ([]db.FileInfoTruncated, error)
 	LocalChangedFolderFiles(folder string, page, perpage int) ([]db.FileInfoTruncated, error)
+	CreateBackupSnapshot(folder string, ttl time.Duration) (BackupSnapshot, error)
+	BackupSnapshotFiles(id string, page, perpage int) (BackupSnapshot, []db.FileInfoTruncated, error)
+	ReleaseBackupSnapshot(id string) error
+	DeviceDifferences(folder string, device protocol.DeviceID, page, perpage int) ([]FileDifference, error)
 	FolderProgressBytesCompleted(folder string) int64
 
 	CurrentFolderFile(folder string, file string) (protocol.FileInfo, bool, error)
@@ -107,6 +149,8 @@ type Model interface {
 	ConnectionStats() map[string]interface{}
 	DeviceStatistics() (map[protocol.DeviceID]stats.DeviceStatistics, error)
 	FolderStatistics() (map[string]stats.FolderStatistics, error)
+	FolderDatabaseSize(folder string) (db.FolderSizeReport, error)
+	TransferStatistics(from, to time.Time) ([]stats.DailyTransferStatistics, error)
 	UsageReportingStats(report *contract.Report, version int, preview bool)
 
 	PendingDevices() (map[protocol.DeviceID]db.ObservedDevice, error)
@@ -114,6 +158,15 @@ type Model interface {
 	DismissPendingDevice(device protocol.DeviceID) error
 	DismissPendingFolder(device protocol.DeviceID, folder string) error
 
+	ManagedDevices() map[protocol.DeviceID]ManagedDeviceStatus
+	AcquireRestart(ctx context.Context) error
+	RestartCoordination() RestartCoordinationStatus
+	StandbyStatus() StandbyStatus
+	FailBack() error
+	PushControlTemplate(ctx context.Context, device protocol.DeviceID, tmpl ControlTemplate) error
+	RevokeDevice(ctx context.Context, device protocol.DeviceID, wipe bool) (map[protocol.DeviceID]error, error)
+	ApproveMetered(device protocol.DeviceID) error
+
 	StartDeadlockDetector(timeout time.Duration)
 	GlobalDirectoryTree(folder, prefix string, levels int, dirsOnly bool) ([]*TreeEntry, error)
 }
@@ -128,7 +181,9 @@ type model struct {
 	clientVersion  string
 	db             *db.Lowlevel
 	protectedFiles []string
+	profile        *locations.Profile
 	evLogger       events.Logger
+	scheduler      jobs.Scheduler
 
 	// constant or concurrency safe fields
 	finder          *db.BlockFinder
@@ -140,9 +195,26 @@ type model struct {
 	// folderIOLimiter limits the number of concurrent I/O heavy operations,
 	// such as scans and pulls.
 	folderIOLimiter *semaphore.Semaphore
-	fatalChan       chan error
-	started         chan struct{}
-	keyGen          *protocol.KeyGenerator
+	// requestScheduler shares the outgoing block requests fairly between
+	// folders
+	requestScheduler *requestScheduler
+	// indexLimiter limits the rate of outgoing index data
+	indexLimiter  *rate.Limiter
+	transferStats *stats.TransferStatistics
+	controller    *controlService
+	restarts      *restartCoordinator
+	standby       *standbyService
+	scanHistory   *scanHistory
+	capabilities  *folderCapabilities
+	backupSnaps   *backupSnapshots
+	fatalChan     chan error
+	started       chan struct{}
+	keyGen        *protocol.KeyGenerator
+	atRestKeys    *atRestKeyRegistry
+	ccSender      *clusterConfigSender
+	blockPool     *blockPool
+	folderCleaner *folderCleaner
+	folderMover   *folderMover
 
 	// fields protected by fmut
 	fmut                           sync.RWMutex
@@ -156,6 +228,7 @@ type model struct {
 	folderVersioners               map[string]versioner.Versioner                         // folder -> versioner (may be nil)
 	folderEncryptionPasswordTokens map[string][]byte                                      // folder -> encryption token (may be missing, and only for encryption type folders)
 	folderEncryptionFailures       map[string]map[protocol.DeviceID]error                 // folder -> device -> error regarding encryption consistency (may be missing)
+	folderScrubbers                *serviceMap[string, *scrubber]                         // folder -> block verification service
 
 	// fields protected by pmut
 	pmut                sync.RWMutex
@@ -165,6 +238,11 @@ type model struct {
 	helloMessages       map[protocol.DeviceID]protocol.Hello
 	deviceDownloads     map[protocol.DeviceID]*deviceDownloadState
 	remoteFolderStates  map[protocol.DeviceID]map[string]remoteFolderState // deviceID -> folders
+	advertisedFolders   map[protocol.DeviceID][]AdvertisedFolder           // deviceID -> folders in the last cluster config received
+	ccSent              map[protocol.DeviceID]clusterConfigDigest          // deviceID -> last cluster config sent
+	meteredDeferred     map[protocol.DeviceID]bool                         // devices with sync deferred as the connection is metered
+	meteredApproved     map[protocol.DeviceID]bool                         // devices the user approved syncing with over a metered connection
+	remoteCloses        map[protocol.DeviceID]RemoteClose                  // why devices last closed the connection to us
 	indexHandlers       *serviceMap[protocol.DeviceID, *indexHandlerRegistry]
 
 	// for testing only
@@ -204,7 +282,7 @@ var (
 // NewModel creates and starts a new model. The model starts in read-only mode,
 // where it sends index information to connected peers and responds to requests
 // for file data without altering the local folder in any way.
-func NewModel(cfg config.Wrapper, id protocol.DeviceID, clientName, clientVersion string, ldb *db.Lowlevel, protectedFiles []string, evLogger events.Logger, keyGen *protocol.KeyGenerator) Model {
+func NewModel(cfg config.Wrapper, id protocol.DeviceID, clientName, clientVersion string, ldb *db.Lowlevel, protectedFiles []string, profile *locations.Profile, evLogger events.Logger, keyGen *protocol.KeyGenerator, scheduler jobs.Scheduler) Model {
 	spec := svcutil.SpecWithDebugLogger(l)
 	m := &model{
 		Supervisor: suture.New("model", spec),
@@ -216,7 +294,9 @@ func NewModel(cfg config.Wrapper, id protocol.DeviceID, clientName, clientVersio
 		clientVersion:  clientVersion,
 		db:             ldb,
 		protectedFiles: protectedFiles,
+		profile:        profile,
 		evLogger:       evLogger,
+		scheduler:      scheduler,
 
 		// constant or concurrency safe fields
 		finder:               db.NewBlockFinder(ldb),
@@ -224,9 +304,16 @@ func NewModel(cfg config.Wrapper, id protocol.DeviceID, clientName, clientVersio
 		shortID:              id.Short(),
 		globalRequestLimiter: semaphore.New(1024 * cfg.Options().MaxConcurrentIncomingRequestKiB()),
 		folderIOLimiter:      semaphore.New(cfg.Options().MaxFolderConcurrency()),
+		requestScheduler:     newRequestScheduler(requestSchedulerCapacity(cfg.Options())),
+		indexLimiter:         rate.NewLimiter(indexSendLimit(cfg.Options()), indexLimiterBurstSize),
+		transferStats:        stats.NewTransferStatistics(ldb, cfg),
+		scanHistory:          newScanHistory(),
+		capabilities:         newFolderCapabilities(),
+		backupSnaps:          newBackupSnapshots(),
 		fatalChan:            make(chan error),
 		started:              make(chan struct{}),
 		keyGen:               keyGen,
+		atRestKeys:           newAtRestKeyRegistry(),
 
 		// fields protected by fmut
 		fmut:                           sync.NewRWMutex(),
@@ -239,6 +326,7 @@ func NewModel(cfg config.Wrapper, id protocol.DeviceID, clientName, clientVersio
 		folderVersioners:               make(map[string]versioner.Versioner),
 		folderEncryptionPasswordTokens: make(map[string][]byte),
 		folderEncryptionFailures:       make(map[string]map[protocol.DeviceID]error),
+		folderScrubbers:                newServiceMap[string, *scrubber](evLogger),
 
 		// fields protected by pmut
 		pmut:                sync.NewRWMutex(),
@@ -248,13 +336,41 @@ func NewModel(cfg config.Wrapper, id protocol.DeviceID, clientName, clientVersio
 		helloMessages:       make(map[protocol.DeviceID]protocol.Hello),
 		deviceDownloads:     make(map[protocol.DeviceID]*deviceDownloadState),
 		remoteFolderStates:  make(map[protocol.DeviceID]map[string]remoteFolderState),
+		advertisedFolders:   make(map[protocol.DeviceID][]AdvertisedFolder),
+		ccSent:              make(map[protocol.DeviceID]clusterConfigDigest),
+		meteredDeferred:     make(map[protocol.DeviceID]bool),
+		meteredApproved:     make(map[protocol.DeviceID]bool),
+		remoteCloses:        make(map[protocol.DeviceID]RemoteClose),
 		indexHandlers:       newServiceMap[protocol.DeviceID, *indexHandlerRegistry](evLogger),
 	}
 	for devID := range cfg.Devices() {
 		m.deviceStatRefs[devID] = stats.NewDeviceStatisticsReference(m.db, devID)
 	}
+	if m.scheduler == nil {
+		// Running standalone, e.g. in tests, with a scheduler of our own.
+		m.scheduler = jobs.New()
+		m.Add(m.scheduler)
+	}
 	m.Add(m.progressEmitter)
 	m.Add(m.indexHandlers)
+	m.Add(m.folderScrubbers)
+	m.Add(newExpiryService(cfg, m.scheduler, evLogger))
+	m.Add(m.transferStats)
+	m.Add(m.backupSnaps)
+	m.controller = newControlService(m)
+	m.Add(m.controller)
+	m.restarts = newRestartCoordinator(m)
+	m.Add(m.restarts)
+	m.standby = newStandbyService(m, ldb)
+	m.Add(m.standby)
+	m.ccSender = newClusterConfigSender(m.sendClusterConfigNow)
+	m.Add(m.ccSender)
+	m.blockPool = newBlockPool(cfg, m.blockPoolFolders, m.folderIOLimiter, m.scheduler, evLogger)
+	m.Add(m.blockPool)
+	m.folderCleaner = newFolderCleaner(cfg, ldb, m.scheduler, evLogger)
+	m.Add(m.folderCleaner)
+	m.folderMover = newFolderMover(cfg, evLogger, m.forgetInodes)
+	m.Add(m.folderMover)
 	m.Add(svcutil.AsService(m.serve, m.String()))
 
 	return m
@@ -410,16 +526,20 @@ func (m *model) addAndStartFolderLockedWithIgnores(cfg config.FolderConfiguratio
 		if err != nil {
 			panic(fmt.Errorf("creating versioner: %w", err))
 		}
+	} else if cfg.Type == config.FolderTypeReceiveOnly && cfg.RecycleDays > 0 {
+		ver = versioner.NewRecycleBin(cfg, cfg.RecycleDays)
 	}
 	m.folderVersioners[folder] = ver
 
-	p := folderFactory(m, fset, ignores, cfg, ver, m.evLogger, m.folderIOLimiter)
+	// The folder runs with the settings resolved from its profile.
+	p := folderFactory(m, fset, ignores, cfg.Tuned(), ver, m.evLogger, m.folderIOLimiter)
 
 	m.folderRunners[folder] = p
 
 	m.warnAboutOverwritingProtectedFiles(cfg, ignores)
 
 	m.folderRunnerToken[folder] = m.Add(p)
+	m.folderScrubbers.Add(folder, newScrubber(cfg, fset, m.folderFilesystem(cfg, fset), p, m.folderIOLimiter, m.scheduler, m.evLogger))
 
 	l.Infof("Ready to synchronize %s (%s)", cfg.Description(), cfg.Type)
 }
@@ -488,6 +608,10 @@ func (m *model) removeFolder(cfg config.FolderConfiguration) {
 	}
 
 	m.cleanupFolderLocked(cfg)
+	m.atRestKeys.remove(cfg.ID)
+	m.scanHistory.forget(cfg.ID)
+	m.backupSnaps.forget(cfg.ID)
+	m.capabilities.forget(cfg.ID)
 	m.indexHandlers.Each(func(_ protocol.DeviceID, r *indexHandlerRegistry) {
 		r.Remove(cfg.ID)
 	})
@@ -497,6 +621,7 @@ func (m *model) removeFolder(cfg config.FolderConfiguration) {
 
 	// Remove it from the database
 	db.DropFolder(m.db, cfg.ID)
+	newTempIndex(m.db, cfg.ID).clear()
 }
 
 // Need to hold lock on m.fmut when calling this.
@@ -510,6 +635,8 @@ func (m *model) cleanupFolderLocked(cfg config.FolderConfiguration) {
 	delete(m.folderVersioners, cfg.ID)
 	delete(m.folderEncryptionPasswordTokens, cfg.ID)
 	delete(m.folderEncryptionFailures, cfg.ID)
+	m.folderScrubbers.Remove(cfg.ID)
+	m.progressEmitter.ForgetRestored(cfg.ID)
 }
 
 func (m *model) restartFolder(from, to config.FolderConfiguration, cacheIgnoredFiles bool) error {
@@ -712,7 +839,12 @@ type ConnectionInfo struct {
 	ClientVersion string `json:"clientVersion"`
 	Type          string `json:"type"`
 	IsLocal       bool   `json:"isLocal"`
+	Metered       bool   `json:"metered"` // sync is deferred as the connection is metered
 	Crypto        string `json:"crypto"`
+	KeyExchange   string `json:"keyExchange"`
+	// Why the device closed the last connection, if it said and we
+	// haven't connected since.
+	RemoteClose *RemoteClose `json:"remoteClose,omitempty"`
 }
 
 // NumConnections returns the current number of active connected devices.
@@ -740,10 +872,15 @@ func (m *model) ConnectionStats() map[string]interface{} {
 			ClientVersion: strings.TrimSpace(versionString),
 			Paused:        deviceCfg.Paused,
 		}
+		if rc, ok := m.remoteCloses[device]; ok {
+			ci.RemoteClose = &rc
+		}
 		if conn, ok := m.conn[device]; ok {
 			ci.Type = conn.Type()
 			ci.IsLocal = conn.IsLocal()
+			ci.Metered = m.meteredDeferred[device]
 			ci.Crypto = conn.Crypto()
+			ci.KeyExchange = conn.KeyExchange()
 			ci.Connected = ok
 			ci.Statistics = conn.Statistics()
 			if addr := conn.RemoteAddr(); addr != nil {
@@ -796,6 +933,24 @@ func (m *model) FolderStatistics() (map[string]stats.FolderStatistics, error) {
 	return res, nil
 }
 
+// FolderDatabaseSize returns the space taken up by the folder in the
+// database and how that has changed over the last days.
+func (m *model) FolderDatabaseSize(folder string) (db.FolderSizeReport, error) {
+	m.fmut.RLock()
+	_, ok := m.folderCfgs[folder]
+	m.fmut.RUnlock()
+	if !ok {
+		return db.FolderSizeReport{}, ErrFolderMissing
+	}
+	return m.db.FolderSizeReport(folder)
+}
+
+// TransferStatistics returns the per day and folder transfer statistics
+// between the given dates, inclusive.
+func (m *model) TransferStatistics(from, to time.Time) ([]stats.DailyTransferStatistics, error) {
+	return m.transferStats.Get(from, to)
+}
+
 type FolderCompletion struct {
 	CompletionPct float64
 	GlobalBytes   int64
@@ -847,6 +1002,17 @@ func (comp *FolderCompletion) setComplectionPct() {
 	}
 }
 
+func (comp *FolderCompletion) summary() stats.CompletionSummary {
+	return stats.CompletionSummary{
+		CompletionPct: comp.CompletionPct,
+		GlobalBytes:   comp.GlobalBytes,
+		NeedBytes:     comp.NeedBytes,
+		GlobalItems:   comp.GlobalItems,
+		NeedItems:     comp.NeedItems,
+		NeedDeletes:   comp.NeedDeletes,
+	}
+}
+
 // Map returns the members as a map, e.g. used in api to serialize as JSON.
 func (comp *FolderCompletion) Map() map[string]interface{} {
 	return map[string]interface{}{
@@ -1132,10 +1298,10 @@ func (m *model) handleIndex(conn protocol.Connection, folder string, fs []protoc
 
 	if cfg, ok := m.cfg.Folder(folder); !ok || !cfg.SharedWith(deviceID) {
 		l.Warnf("%v for unexpected folder ID %q sent from device %q; ensure that the folder exists and that this device is selected under \"Share With\" in the folder configuration.", op, folder, deviceID)
-		return fmt.Errorf("%s: %w", folder, ErrFolderMissing)
+		return &protocol.CloseError{Code: protocol.CloseReasonFolderMissing, Folder: folder, Err: fmt.Errorf("%s: %w", folder, ErrFolderMissing)}
 	} else if cfg.Paused {
 		l.Debugf("%v for paused folder (ID %q) sent from device %q.", op, folder, deviceID)
-		return fmt.Errorf("%s: %w", folder, ErrFolderPaused)
+		return &protocol.CloseError{Code: protocol.CloseReasonFolderPaused, Folder: folder, Err: fmt.Errorf("%s: %w", folder, ErrFolderPaused)}
 	}
 
 	m.pmut.RLock()
@@ -1254,6 +1420,7 @@ func (m *model) ClusterConfig(conn protocol.Connection, cm protocol.ClusterConfi
 
 	m.pmut.Lock()
 	m.remoteFolderStates[deviceID] = states
+	m.advertisedFolders[deviceID] = advertisedFolders(deviceID, cm.Folders)
 	m.pmut.Unlock()
 
 	m.evLogger.Log(events.ClusterConfigReceived, ClusterConfigReceivedEventData{
@@ -1289,6 +1456,8 @@ func (m *model) ClusterConfig(conn protocol.Connection, cm protocol.ClusterConfi
 		})
 	}
 
+	m.adoptFolderMetadata(deviceID, cm.Folders)
+
 	return nil
 }
 
@@ -1374,7 +1543,7 @@ func (m *model) ccHandleFolders(folders []protocol.Folder, deviceCfg config.Devi
 				m.evLogger.Log(events.Failure, err.Error())
 				l.Warnln(msg)
 			}
-			return tempIndexFolders, seenFolders, err
+			return tempIndexFolders, seenFolders, &protocol.CloseError{Code: protocol.CloseReasonEncryptionMismatch, Folder: folder.ID, Err: err}
 		}
 		m.fmut.Lock()
 		if devErrs, ok := m.folderEncryptionFailures[folder.ID]; ok {
@@ -1533,7 +1702,15 @@ func (m *model) ccCheckEncryption(fcfg config.FolderConfiguration, folderDevice
 	return nil
 }
 
+// sendClusterConfig schedules sending new cluster configs to the given
+// devices. Requests are batched, see clusterConfigSender.
 func (m *model) sendClusterConfig(ids []protocol.DeviceID) {
+	m.ccSender.add(ids)
+}
+
+// sendClusterConfigNow sends new cluster configs to the given devices,
+// skipping those that would get the same cluster config as last time.
+func (m *model) sendClusterConfigNow(ids []protocol.DeviceID) {
 	if len(ids) == 0 {
 		return
 	}
@@ -1547,9 +1724,25 @@ func (m *model) sendClusterConfig(ids []protocol.DeviceID) {
 	m.pmut.RUnlock()
 	// Generating cluster-configs acquires fmut -> must happen outside of pmut.
 	for _, conn := range ccConns {
-		cm, passwords := m.generateClusterConfig(conn.DeviceID())
+		deviceID := conn.DeviceID()
+		cm, passwords := m.generateClusterConfig(deviceID)
+		digest, ok := clusterConfigHash(cm)
+		m.pmut.Lock()
+		if m.conn[deviceID] != conn {
+			// Replaced or closed meanwhile, the new connection gets an
+			// up to date cluster config anyway.
+			m.pmut.Unlock()
+			continue
+		}
+		if ok && m.ccSent[deviceID] == digest {
+			m.pmut.Unlock()
+			l.Debugf("Not resending unchanged cluster config to %v", deviceID.Short())
+			continue
+		}
+		m.ccSent[deviceID] = digest
+		m.pmut.Unlock()
 		conn.SetFolderPasswords(passwords)
-		go conn.ClusterConfig(cm)
+		conn.ClusterConfig(cm)
 	}
 }
 
@@ -1791,6 +1984,13 @@ func (m *model) Closed(conn protocol.Connection, err error) {
 	delete(m.helloMessages, device)
 	delete(m.deviceDownloads, device)
 	delete(m.remoteFolderStates, device)
+	delete(m.ccSent, device)
+	delete(m.meteredDeferred, device)
+	delete(m.meteredApproved, device)
+	var rerr *protocol.RemoteCloseError
+	if errors.As(err, &rerr) {
+		m.remoteCloses[device] = newRemoteClose(rerr)
+	}
 	closed := m.closed[device]
 	delete(m.closed, device)
 	m.indexHandlers.RemoveAndWait(device, 0)
@@ -1800,10 +2000,15 @@ func (m *model) Closed(conn protocol.Connection, err error) {
 	m.deviceDidClose(device, time.Since(conn.EstablishedAt()))
 
 	l.Infof("Connection to %s at %s closed: %v", device, conn, err)
-	m.evLogger.Log(events.DeviceDisconnected, map[string]string{
+	ev := map[string]string{
 		"id":    device.String(),
 		"error": err.Error(),
-	})
+	}
+	if rerr != nil {
+		ev["code"] = closeReasonCode(rerr.Code)
+		ev["folder"] = rerr.Folder
+	}
+	m.evLogger.Log(events.DeviceDisconnected, ev)
 	close(closed)
 }
 
@@ -1905,7 +2110,7 @@ func (m *model) Request(conn protocol.Connection, folder, name string, _, size i
 	// Grab the FS after limiting, as it causes I/O and we want to minimize
 	// the race time between the symlink check and the read.
 
-	folderFs := folderCfg.Filesystem(nil)
+	folderFs := m.folderFilesystem(folderCfg, nil)
 
 	if err := osutil.TraversesSymlink(folderFs, filepath.Dir(name)); err != nil {
 		l.Debugf("%v REQ(in) traversal check: %s - %s: %q / %q o=%d s=%d", m, err, deviceID, folder, name, offset, size)
@@ -1915,16 +2120,28 @@ func (m *model) Request(conn protocol.Connection, folder, name string, _, size i
 	// Only check temp files if the flag is set, and if we are set to advertise
 	// the temp indexes.
 	if fromTemporary && !folderCfg.DisableTempIndexes {
-		tempFn := fs.TempName(name)
+		tempFs, tempFn := folderFs, fs.TempName(name)
+		if stagingFss := folderCfg.StagingFilesystems(); len(stagingFss) > 0 {
+			// The temporary file is in whichever staging directory was
+			// in use when it was created.
+			tempFs, tempFn = stagingFss[0], fs.StagingTempName(name)
+			for _, stagingFs := range stagingFss[1:] {
+				if _, err := tempFs.Lstat(tempFn); err == nil {
+					break
+				}
+				tempFs = stagingFs
+			}
+		}
 
-		if info, err := folderFs.Lstat(tempFn); err != nil || !info.IsRegular() {
+		if info, err := tempFs.Lstat(tempFn); err != nil || !info.IsRegular() {
 			// Reject reads for anything that doesn't exist or is something
 			// other than a regular file.
 			l.Debugf("%v REQ(in) failed stating temp file (%v): %s: %q / %q o=%d s=%d", m, err, deviceID, folder, name, offset, size)
 			return nil, protocol.ErrNoSuchFile
 		}
-		_, err := readOffsetIntoBuf(folderFs, tempFn, offset, res.data)
+		_, err := readOffsetIntoBuf(tempFs, tempFn, offset, res.data)
 		if err == nil && scanner.Validate(res.data, hash, weakHash) {
+			m.transferStats.Sent(folder, len(res.data))
 			return res, nil
 		}
 		// Fall through to reading from a non-temp file, just in case the temp
@@ -1959,6 +2176,7 @@ func (m *model) Request(conn protocol.Connection, folder, name string, _, size i
 		return nil, protocol.ErrNoSuchFile
 	}
 
+	m.transferStats.Sent(folder, len(res.data))
 	return res, nil
 }
 
@@ -2071,7 +2289,7 @@ func (m *model) GetMtimeMapping(folder string, file string) (fs.MtimeMapping, er
 	if !ok {
 		return fs.MtimeMapping{}, ErrFolderMissing
 	}
-	return fs.GetMtimeMapping(fcfg.Filesystem(ffs), file)
+	return fs.GetMtimeMapping(m.folderFilesystem(fcfg, ffs), file)
 }
 
 // Connection returns the current connection for device, and a boolean whether a connection was found.
@@ -2249,14 +2467,16 @@ func (m *model) AddConnection(conn protocol.Connection, hello protocol.Hello) {
 	}
 
 	m.conn[deviceID] = conn
+	delete(m.remoteCloses, deviceID)
 	closed := make(chan struct{})
 	m.closed[deviceID] = closed
 	m.deviceDownloads[deviceID] = newDeviceDownloadState()
-	indexRegistry := newIndexHandlerRegistry(conn, m.deviceDownloads[deviceID], m.evLogger)
+	indexRegistry := newIndexHandlerRegistry(conn, m.deviceDownloads[deviceID], m.indexLimiter, m.evLogger)
 	for id, fcfg := range m.folderCfgs {
 		indexRegistry.RegisterFolderState(fcfg, m.folderFiles[id], m.folderRunners[id])
 	}
 	m.indexHandlers.Add(deviceID, indexRegistry)
+	m.updateMeteredPLocked(deviceID, device, m.cfg.Options())
 	m.fmut.RUnlock()
 	// 0: default, <0: no limiting
 	switch {
@@ -2290,6 +2510,13 @@ func (m *model) AddConnection(conn protocol.Connection, hello protocol.Hello) {
 
 	// Acquires fmut, so has to be done outside of pmut.
 	cm, passwords := m.generateClusterConfig(deviceID)
+	if digest, ok := clusterConfigHash(cm); ok {
+		m.pmut.Lock()
+		if m.conn[deviceID] == conn {
+			m.ccSent[deviceID] = digest
+		}
+		m.pmut.Unlock()
+	}
 	conn.SetFolderPasswords(passwords)
 	conn.ClusterConfig(cm)
 
@@ -2307,6 +2534,7 @@ func (m *model) AddConnection(conn protocol.Connection, hello protocol.Hello) {
 	}
 
 	m.deviceWasSeen(deviceID)
+	m.controller.deviceConnected(device)
 }
 
 func (m *model) DownloadProgress(conn protocol.Connection, folder string, updates []protocol.FileDownloadProgressUpdate) error {
@@ -2334,6 +2562,49 @@ func (m *model) DownloadProgress(conn protocol.Connection, folder string, update
 	return nil
 }
 
+func (m *model) Control(conn protocol.Connection, ctrl protocol.Control) error {
+	return m.controller.handle(conn, ctrl)
+}
+
+// ManagedDevices returns what is known about the devices managed by us, as
+// controller.
+func (m *model) ManagedDevices() map[protocol.DeviceID]ManagedDeviceStatus {
+	return m.controller.managedDevices()
+}
+
+// PushControlTemplate applies the folder and device configurations in the
+// template on the managed device.
+func (m *model) PushControlTemplate(ctx context.Context, device protocol.DeviceID, tmpl ControlTemplate) error {
+	return m.controller.pushTemplate(ctx, device, tmpl)
+}
+
+// RevokeDevice revokes the device locally and on all managed devices, which
+// unshare all folders from it and stop connecting to it. With wipe set, the
+// device is told to delete the data of the folders shared with us when it
+// connects next.
+func (m *model) RevokeDevice(ctx context.Context, device protocol.DeviceID, wipe bool) (map[protocol.DeviceID]error, error) {
+	return m.controller.revoke(ctx, device, wipe)
+}
+
+// AcquireRestart blocks until the peers we coordinate restarts with allow
+// us to restart, or the context is canceled.
+func (m *model) AcquireRestart(ctx context.Context) error {
+	return m.restarts.Acquire(ctx)
+}
+
+func (m *model) RestartCoordination() RestartCoordinationStatus {
+	return m.restarts.Status()
+}
+
+func (m *model) StandbyStatus() StandbyStatus {
+	return m.standby.Status()
+}
+
+// FailBack gives up the active role taken over from our primary.
+func (m *model) FailBack() error {
+	return m.standby.FailBack()
+}
+
 func (m *model) deviceWasSeen(deviceID protocol.DeviceID) {
 	m.fmut.RLock()
 	sr, ok := m.deviceStatRefs[deviceID]
@@ -2349,7 +2620,37 @@ func (m *model) deviceDidClose(deviceID protocol.DeviceID, duration time.Duratio
 	m.fmut.RUnlock()
 	if ok {
 		_ = sr.LastConnectionDuration(duration)
+		if comp, ok := m.lastCompletion(deviceID); ok {
+			_ = sr.LastCompletion(comp)
+		}
+	}
+}
+
+// lastCompletion returns the completion of the device in the folders
+// shared with it, to be kept for while it's offline.
+func (m *model) lastCompletion(deviceID protocol.DeviceID) (stats.LastCompletion, bool) {
+	last := stats.LastCompletion{
+		At:      time.Now().Truncate(time.Second),
+		Folders: make(map[string]stats.CompletionSummary),
+	}
+	var total FolderCompletion
+	for _, fcfg := range m.cfg.FolderList() {
+		if fcfg.Paused || !fcfg.SharedWith(deviceID) {
+			continue
+		}
+		comp, err := m.folderCompletion(deviceID, fcfg.ID)
+		if err != nil {
+			// Not running, e.g. when shutting down.
+			continue
+		}
+		total.add(comp)
+		last.Folders[fcfg.ID] = comp.summary()
+	}
+	if len(last.Folders) == 0 {
+		return stats.LastCompletion{}, false
 	}
+	last.CompletionSummary = total.summary()
+	return last, true
 }
 
 func (m *model) requestGlobal(ctx context.Context, deviceID protocol.DeviceID, folder, name string, blockNo int, offset int64, size int, hash []byte, weakHash uint32, fromTemporary bool) ([]byte, error) {
@@ -2476,12 +2777,17 @@ func (m *model) generateClusterConfig(device protocol.DeviceID) (protocol.Cluste
 		protocolFolder := protocol.Folder{
 			ID:                 folderCfg.ID,
 			Label:              folderCfg.Label,
-			ReadOnly:           folderCfg.Type == config.FolderTypeSendOnly,
+			Notes:              folderCfg.Notes,
+			ReadOnly:           folderCfg.Type == config.FolderTypeSendOnly || folderCfg.Type == config.FolderTypeIndexOnly,
 			IgnorePermissions:  folderCfg.IgnorePerms,
 			IgnoreDelete:       folderCfg.IgnoreDelete,
 			DisableTempIndexes: folderCfg.DisableTempIndexes,
 		}
 
+		if dev, _ := folderCfg.Device(device); dev.EncryptionPassword == "" {
+			protocolFolder.Metadata = folderCfg.Metadata.ToProtocol()
+		}
+
 		fs := m.folderFiles[folderCfg.ID]
 
 		// Even if we aren't paused, if we haven't started the folder yet
@@ -2555,6 +2861,24 @@ func (m *model) FolderErrors(folder string) ([]FileError, error) {
 	return runner.Errors(), nil
 }
 
+// ScanSummaries returns what the recent scans of the folder found, most
+// recent first.
+func (m *model) ScanSummaries(folder string) ([]ScanSummary, error) {
+	if _, ok := m.cfg.Folder(folder); !ok {
+		return nil, ErrFolderMissing
+	}
+	return m.scanHistory.get(folder), nil
+}
+
+// FolderCapabilities returns what the filesystem of the folder allowed when
+// probed on start, which is the zero value if the folder hasn't started.
+func (m *model) FolderCapabilities(folder string) (FolderCapabilities, error) {
+	if _, ok := m.cfg.Folder(folder); !ok {
+		return FolderCapabilities{}, ErrFolderMissing
+	}
+	return m.capabilities.get(folder), nil
+}
+
 func (m *model) WatchError(folder string) error {
 	m.fmut.RLock()
 	err := m.checkFolderRunningLocked(folder)
@@ -2596,6 +2920,53 @@ func (m *model) Revert(folder string) {
 	runner.Revert()
 }
 
+// ScrubFolder starts verifying the local data of the folder against the
+// block hashes in the database, regardless of the configured interval.
+func (m *model) ScrubFolder(folder string) error {
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	scrubber, ok := m.folderScrubbers.Get(folder)
+	m.fmut.RUnlock()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrFolderNotRunning
+	}
+	scrubber.Trigger()
+	return nil
+}
+
+func (m *model) ScrubStatus(folder string) (ScrubStatus, error) {
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	scrubber, ok := m.folderScrubbers.Get(folder)
+	m.fmut.RUnlock()
+	if err != nil {
+		return ScrubStatus{}, err
+	}
+	if !ok {
+		return ScrubStatus{}, ErrFolderNotRunning
+	}
+	return scrubber.Status(), nil
+}
+
+// CollectBlockPool starts removing the chunks no file refers to anymore
+// from the pools of folders with deduplicated storage.
+func (m *model) CollectBlockPool() {
+	m.blockPool.Collect()
+}
+
+// ScrubBlockPool starts verifying the chunks in the pools of folders with
+// deduplicated storage, regardless of the configured interval.
+func (m *model) ScrubBlockPool() {
+	m.blockPool.Scrub()
+}
+
+func (m *model) BlockPoolStatus() BlockPoolStatus {
+	return m.blockPool.Status()
+}
+
 type TreeEntry struct {
 	Name     string                `json:"name"`
 	ModTime  time.Time             `json:"modTime"`
@@ -2703,7 +3074,6 @@ func (m *model) GetFolderVersions(folder string) (map[string][]versioner.FileVer
 func (m *model) RestoreFolderVersions(folder string, versions map[string]time.Time) (map[string]error, error) {
 	m.fmut.RLock()
 	err := m.checkFolderRunningLocked(folder)
-	fcfg := m.folderCfgs[folder]
 	ver := m.folderVersioners[folder]
 	m.fmut.RUnlock()
 	if err != nil {
@@ -2714,21 +3084,79 @@ func (m *model) RestoreFolderVersions(folder string, versions map[string]time.Ti
 	}
 
 	restoreErrors := make(map[string]error)
+	restored := make([]string, 0, len(versions))
 
 	for file, version := range versions {
 		if err := ver.Restore(file, version); err != nil {
 			restoreErrors[file] = err
+		} else {
+			restored = append(restored, file)
 		}
 	}
 
-	// Trigger scan
-	if !fcfg.FSWatcherEnabled {
-		go func() { _ = m.ScanFolder(folder) }()
+	if len(restored) > 0 {
+		go m.restoreAndAnnounce(folder, restored)
 	}
 
 	return restoreErrors, nil
 }
 
+// RecycledItem is a version of a file that was deleted or replaced, as
+// kept by the versioner.
+type RecycledItem struct {
+	Name string `json:"name"`
+	versioner.FileVersion
+}
+
+// RecycledItems returns the versions of files archived since the given
+// time, newest first.
+func (m *model) RecycledItems(folder string, since time.Time) ([]RecycledItem, error) {
+	versions, err := m.GetFolderVersions(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []RecycledItem{}
+	for name, fvs := range versions {
+		for _, fv := range fvs {
+			if fv.VersionTime.Before(since) {
+				continue
+			}
+			items = append(items, RecycledItem{Name: name, FileVersion: fv})
+		}
+	}
+	sort.Slice(items, func(a, b int) bool {
+		if !items[a].VersionTime.Equal(items[b].VersionTime) {
+			return items[a].VersionTime.After(items[b].VersionTime)
+		}
+		return items[a].Name < items[b].Name
+	})
+	return items, nil
+}
+
+// PurgeFolderVersions permanently removes the given versions from the
+// versioner.
+func (m *model) PurgeFolderVersions(folder string, versions map[string]time.Time) (map[string]error, error) {
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	ver := m.folderVersioners[folder]
+	m.fmut.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	if ver == nil {
+		return nil, errNoVersioner
+	}
+
+	purgeErrors := make(map[string]error)
+	for file, version := range versions {
+		if err := ver.Purge(file, version); err != nil {
+			purgeErrors[file] = err
+		}
+	}
+	return purgeErrors, nil
+}
+
 func (m *model) Availability(folder string, file protocol.FileInfo, block protocol.BlockInfo) ([]Availability, error) {
 	// The slightly unusual locking sequence here is because we need to hold
 	// pmut for the duration (as the value returned from foldersFiles can
@@ -2770,6 +3198,9 @@ func (m *model) availabilityInSnapshotPRlocked(cfg config.FolderConfiguration, s
 		if state := m.remoteFolderStates[device][cfg.ID]; state != remoteFolderValid {
 			continue
 		}
+		if m.meteredDeferred[device] && !cfg.SyncWhenMetered {
+			continue
+		}
 		_, ok := m.conn[device]
 		if ok {
 			availabilities = append(availabilities, Availability{ID: device, FromTemporary: false})
@@ -2819,6 +3250,9 @@ func (*model) VerifyConfiguration(from, to config.Configuration) error {
 		if ok && from.Type != to.Type && (from.Type == config.FolderTypeReceiveEncrypted || to.Type == config.FolderTypeReceiveEncrypted) {
 			return errors.New("folder type must not be changed from/to receive-encrypted")
 		}
+		if ok && from.AtRestEncryption != to.AtRestEncryption {
+			return errors.New("encryption at rest must not be enabled or disabled on an existing folder")
+		}
 	}
 	return nil
 }
@@ -2893,6 +3327,11 @@ func (m *model) CommitConfiguration(from, to config.Configuration) bool {
 			}
 		}
 
+		// Labels, notes and metadata are advertised to the other devices.
+		if fromCfg.Label != toCfg.Label || fromCfg.Notes != toCfg.Notes || !fromCfg.Metadata.Equal(toCfg.Metadata) {
+			clusterConfigDevices.add(toCfg.DeviceIDs())
+		}
+
 		// Emit the folder pause/resume event
 		if fromCfg.Paused != toCfg.Paused {
 			eventType := events.FolderResumed
@@ -2951,7 +3390,7 @@ func (m *model) CommitConfiguration(from, to config.Configuration) bool {
 	}
 	m.fmut.Unlock()
 
-	m.pmut.RLock()
+	m.pmut.Lock()
 	for _, id := range closeDevices {
 		delete(clusterConfigDevices, id)
 		if conn, ok := m.conn[id]; ok {
@@ -2964,7 +3403,16 @@ func (m *model) CommitConfiguration(from, to config.Configuration) bool {
 			go conn.Close(errDeviceRemoved)
 		}
 	}
-	m.pmut.RUnlock()
+	var unmetered []protocol.DeviceID
+	for id := range m.conn {
+		if toCfg, ok := toDevices[id]; ok && m.updateMeteredPLocked(id, toCfg, to.Options) {
+			unmetered = append(unmetered, id)
+		}
+	}
+	m.pmut.Unlock()
+	for _, id := range unmetered {
+		m.schedulePullsWith(id)
+	}
 	// Generating cluster-configs acquires fmut -> must happen outside of pmut.
 	m.sendClusterConfig(clusterConfigDevices.AsSlice())
 
@@ -2973,6 +3421,8 @@ func (m *model) CommitConfiguration(from, to config.Configuration) bool {
 
 	m.globalRequestLimiter.SetCapacity(1024 * to.Options.MaxConcurrentIncomingRequestKiB())
 	m.folderIOLimiter.SetCapacity(to.Options.MaxFolderConcurrency())
+	m.requestScheduler.setCapacity(requestSchedulerCapacity(to.Options))
+	m.indexLimiter.SetLimit(indexSendLimit(to.Options))
 
 	// Some options don't require restart as those components handle it fine
 	// by themselves. Compare the options structs containing only the
@@ -3272,7 +3722,11 @@ type storedEncryptionToken struct {
 }
 
 func readEncryptionToken(cfg config.FolderConfiguration) ([]byte, error) {
-	fd, err := cfg.Filesystem(nil).Open(encryptionTokenPath(cfg))
+	return readStoredToken(cfg, encryptionTokenPath(cfg))
+}
+
+func readStoredToken(cfg config.FolderConfiguration, path string) ([]byte, error) {
+	fd, err := cfg.Filesystem(nil).Open(path)
 	if err != nil {
 		return nil, err
 	}
@@ -3285,8 +3739,11 @@ func readEncryptionToken(cfg config.FolderConfiguration) ([]byte, error) {
 }
 
 func writeEncryptionToken(token []byte, cfg config.FolderConfiguration) error {
-	tokenName := encryptionTokenPath(cfg)
-	fd, err := cfg.Filesystem(nil).OpenFile(tokenName, fs.OptReadWrite|fs.OptCreate, 0o666)
+	return writeStoredToken(token, cfg, encryptionTokenPath(cfg))
+}
+
+func writeStoredToken(token []byte, cfg config.FolderConfiguration, path string) error {
+	fd, err := cfg.Filesystem(nil).OpenFile(path, fs.OptReadWrite|fs.OptCreate, 0o666)
 	if err != nil {
 		return err
 	}
@@ -3112,6 +3112,38 @@ func TestDeviceWasSeen(t *testing.T) {
 	}
 }
 
+func TestDeviceLastCompletion(t *testing.T) {
+	m, fc, fcfg, wcfgCancel := setupModelWithConnection(t)
+	defer wcfgCancel()
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem(nil).URI())
+
+	fc.addFile("bar", 0o644, protocol.FileInfoTypeFile, []byte("data"))
+	fc.sendIndexUpdate()
+	m.fmut.RLock()
+	fset := m.folderFiles[fcfg.ID]
+	m.fmut.RUnlock()
+	fset.Update(protocol.LocalDeviceID, []protocol.FileInfo{
+		{Name: "foo", Size: 100, Version: protocol.Vector{}.Update(myID.Short()), Sequence: 1},
+	})
+
+	m.Closed(fc, errors.New("testing"))
+
+	stats, err := m.DeviceStatistics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	comp := stats[device1].LastCompletion
+	if comp == nil {
+		t.Fatal("expected the completion to be kept on disconnect")
+	}
+	if comp.NeedItems != 1 || comp.NeedBytes != 100 || comp.CompletionPct >= 100 {
+		t.Errorf("expected the device to need the file, got %+v", comp.CompletionSummary)
+	}
+	if folder, ok := comp.Folders[fcfg.ID]; !ok || folder.NeedItems != 1 {
+		t.Errorf("expected the completion of %v, got %+v", fcfg.ID, comp.Folders)
+	}
+}
+
 func TestNewLimitedRequestResponse(t *testing.T) {
 	l0 := semaphore.New(0)
 	l1 := semaphore.New(1024)
@@ -3673,6 +3705,47 @@ func testConfigChangeTriggersClusterConfigs(t *testing.T, expectFirst, expectSec
 	}
 }
 
+func TestClusterConfigBatched(t *testing.T) {
+	wcfg, _, wcfgCancel := newDefaultCfgWrapper()
+	defer wcfgCancel()
+	m := setupModel(t, wcfg)
+	defer cleanupModel(m)
+
+	ccs := make(chan protocol.ClusterConfig, 10)
+	fc := newFakeConnection(device1, m)
+	fc.ClusterConfigCalls(func(cc protocol.ClusterConfig) {
+		ccs <- cc
+	})
+	m.AddConnection(fc, protocol.Hello{})
+	<-ccs
+
+	// A burst of changes results in one cluster config with all of them.
+	for i := 0; i < 5; i++ {
+		fcfg := newFolderConfig()
+		fcfg.ID = fmt.Sprintf("burst%d", i)
+		setFolder(t, wcfg, fcfg)
+	}
+	select {
+	case cc := <-ccs:
+		if len(cc.Folders) != 6 {
+			t.Errorf("expected 6 folders in cluster config, got %d", len(cc.Folders))
+		}
+	case <-time.After(10 * clusterConfigDelay):
+		t.Fatal("timed out before receiving cluster config")
+	}
+	time.Sleep(2 * clusterConfigDelay)
+	if len(ccs) != 0 {
+		t.Errorf("expected a single cluster config, got %d more", len(ccs))
+	}
+
+	// An unchanged cluster config isn't sent again.
+	m.sendClusterConfig([]protocol.DeviceID{device1})
+	time.Sleep(2 * clusterConfigDelay)
+	if len(ccs) != 0 {
+		t.Error("unchanged cluster config was sent again")
+	}
+}
+
 // The end result of the tested scenario is that the global version entry has an
 // empty version vector and is not deleted, while everything is actually deleted.
 // That then causes these files to be considered as needed, while they are not.
@@ -4102,6 +4175,69 @@ func equalStringsInAnyOrder(a, b []string) bool {
 	return true
 }
 
+func TestAtRestEncryption(t *testing.T) {
+	w, cancel := newConfigWrapper(defaultCfgWrapper.RawCopy())
+	defer cancel()
+	fcfg := newFolderConfig()
+	fcfg.AtRestEncryption = true
+	waiter, err := w.Modify(func(cfg *config.Configuration) {
+		cfg.SetFolder(fcfg)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waiter.Wait()
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+	fcfg, _ = w.Folder(fcfg.ID)
+
+	if err := m.ScanFolder(fcfg.ID); !errors.Is(err, fs.ErrLocked) {
+		t.Fatalf("expected the folder to be locked, got %v", err)
+	}
+
+	// Data that was there before the password was set can't be encrypted.
+	plainFs := fcfg.Filesystem(nil)
+	writeFile(t, plainFs, "plain", []byte("data"))
+	if err := m.UnlockFolder(fcfg.ID, "pass"); err != errAtRestNotEmpty {
+		t.Fatalf("expected %v, got %v", errAtRestNotEmpty, err)
+	}
+	must(t, plainFs.Remove("plain"))
+
+	must(t, m.UnlockFolder(fcfg.ID, "pass"))
+	writeFile(t, m.folderFilesystem(fcfg, nil), "secret", []byte("data"))
+	must(t, m.ScanFolder(fcfg.ID))
+	if _, ok, _ := m.CurrentFolderFile(fcfg.ID, "secret"); !ok {
+		t.Error("expected the encrypted file to be scanned")
+	}
+	if _, err := plainFs.Lstat("secret"); !fs.IsNotExist(err) {
+		t.Error("expected the file name to be encrypted on disk")
+	}
+	fd, err := m.OpenPlaintext(fcfg.ID, "secret")
+	must(t, err)
+	bs, err := io.ReadAll(fd)
+	fd.Close()
+	if err != nil || string(bs) != "data" {
+		t.Errorf("expected the plaintext, got %q, %v", bs, err)
+	}
+
+	if err := m.UnlockFolder(fcfg.ID, "wrong"); err != errAtRestPassword {
+		t.Errorf("expected %v, got %v", errAtRestPassword, err)
+	}
+
+	must(t, m.LockFolder(fcfg.ID))
+	if err := m.ScanFolder(fcfg.ID); !errors.Is(err, fs.ErrLocked) {
+		t.Errorf("expected the folder to be locked, got %v", err)
+	}
+	if _, err := m.OpenPlaintext(fcfg.ID, "secret"); !errors.Is(err, fs.ErrLocked) {
+		t.Errorf("expected the plaintext to be unavailable while locked, got %v", err)
+	}
+	must(t, m.UnlockFolder(fcfg.ID, "pass"))
+	must(t, m.ScanFolder(fcfg.ID))
+	if _, ok, _ := m.CurrentFolderFile(fcfg.ID, "secret"); !ok {
+		t.Error("expected the file to remain after unlocking again")
+	}
+}
+
 // modtimeTruncatingFS is a FileSystem that returns modification times only
 // to the closest two `trunc` interval.
 type modtimeTruncatingFS struct {
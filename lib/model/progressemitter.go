@@ -20,12 +20,14 @@ import (
 type ProgressEmitter struct {
 	cfg                config.Wrapper
 	registry           map[string]map[string]*sharedPullerState // folder: name: puller
+	restored           map[string]map[string]*sharedPullerState // folder: name: puller restored from the database, only announced to peers
 	interval           time.Duration
 	minBlocks          int
 	sentDownloadStates map[protocol.DeviceID]*sentDownloadState // States representing what we've sent to the other peer via DownloadProgress messages.
 	connections        map[protocol.DeviceID]protocol.Connection
 	foldersByConns     map[protocol.DeviceID][]string
 	disabled           bool
+	resend             bool // a new connection should get the restored pullers
 	evLogger           events.Logger
 	mut                sync.Mutex
 
@@ -48,6 +50,7 @@ func NewProgressEmitter(cfg config.Wrapper, evLogger events.Logger) *ProgressEmi
 	t := &ProgressEmitter{
 		cfg:                cfg,
 		registry:           make(map[string]map[string]*sharedPullerState),
+		restored:           make(map[string]map[string]*sharedPullerState),
 		timer:              time.NewTimer(time.Millisecond),
 		sentDownloadStates: make(map[protocol.DeviceID]*sentDownloadState),
 		connections:        make(map[protocol.DeviceID]protocol.Connection),
@@ -79,7 +82,7 @@ func (t *ProgressEmitter) Serve(ctx context.Context) error {
 			l.Debugln("progress emitter: timer - looking after", len(t.registry))
 
 			newLastUpdated := lastUpdate
-			newCount = t.lenRegistryLocked()
+			newCount = t.lenRegistryLocked() + t.lenRestoredLocked()
 			var progressUpdates []progressUpdate
 			for _, pullers := range t.registry {
 				for _, puller := range pullers {
@@ -89,9 +92,10 @@ func (t *ProgressEmitter) Serve(ctx context.Context) error {
 				}
 			}
 
-			if !newLastUpdated.Equal(lastUpdate) || newCount != lastCount {
+			if !newLastUpdated.Equal(lastUpdate) || newCount != lastCount || t.resend {
 				lastUpdate = newLastUpdated
 				lastCount = newCount
+				t.resend = false
 				t.sendDownloadProgressEventLocked()
 				progressUpdates = t.computeProgressUpdates()
 			} else {
@@ -137,7 +141,8 @@ func (t *ProgressEmitter) computeProgressUpdates() []progressUpdate {
 	for id, conn := range t.connections {
 		for _, folder := range t.foldersByConns[id] {
 			pullers, ok := t.registry[folder]
-			if !ok {
+			restored := t.restored[folder]
+			if !ok && len(restored) == 0 {
 				// There's never been any puller registered for this folder yet
 				continue
 			}
@@ -157,6 +162,12 @@ func (t *ProgressEmitter) computeProgressUpdates() []progressUpdate {
 				}
 				activePullers = append(activePullers, puller)
 			}
+			for name, puller := range restored {
+				if _, ok := pullers[name]; ok || len(puller.file.Blocks) <= t.minBlocks {
+					continue
+				}
+				activePullers = append(activePullers, puller)
+			}
 
 			// For every new puller that hasn't yet been seen, it will send all the blocks the puller has available
 			// For every existing puller, it will check for new blocks, and send update for the new blocks only
@@ -258,6 +269,46 @@ func (t *ProgressEmitter) Register(s *sharedPullerState) {
 		t.registry[s.folder] = make(map[string]*sharedPullerState)
 	}
 	t.registry[s.folder][s.file.Name] = s
+	delete(t.restored[s.folder], s.file.Name)
+}
+
+// Restore sets the pullers of the folder that were in progress before a
+// restart, as restored from the database. Their available blocks are
+// announced to peers until a puller for the same file is registered or the
+// restored pullers are forgotten, but they are not part of the download
+// progress.
+func (t *ProgressEmitter) Restore(folder string, states []*sharedPullerState) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	if t.disabled {
+		l.Debugln("progress emitter: disabled, skip restoring")
+		return
+	}
+	l.Debugln("progress emitter: restoring", len(states), "pullers for", folder)
+	if len(states) == 0 {
+		delete(t.restored, folder)
+		return
+	}
+	if t.emptyLocked() && t.lenRestoredLocked() == 0 {
+		t.timer.Reset(t.interval)
+	}
+	restored := make(map[string]*sharedPullerState, len(states))
+	for _, s := range states {
+		restored[s.file.Name] = s
+	}
+	t.restored[folder] = restored
+}
+
+// ForgetRestored stops announcing the restored pullers of the folder.
+func (t *ProgressEmitter) ForgetRestored(folder string) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	if len(t.restored[folder]) == 0 {
+		return
+	}
+	l.Debugln("progress emitter: forgetting restored pullers for", folder)
+	delete(t.restored, folder)
+	t.timer.Reset(t.interval)
 }
 
 // Deregister a puller which will stop broadcasting pullers state.
@@ -303,6 +354,13 @@ func (t *ProgressEmitter) lenRegistryLocked() (out int) {
 	return out
 }
 
+func (t *ProgressEmitter) lenRestoredLocked() (out int) {
+	for _, pullers := range t.restored {
+		out += len(pullers)
+	}
+	return out
+}
+
 func (t *ProgressEmitter) emptyLocked() bool {
 	for _, pullers := range t.registry {
 		if len(pullers) != 0 {
@@ -317,6 +375,10 @@ func (t *ProgressEmitter) temporaryIndexSubscribe(conn protocol.Connection, fold
 	defer t.mut.Unlock()
 	t.connections[conn.DeviceID()] = conn
 	t.foldersByConns[conn.DeviceID()] = folders
+	if t.lenRestoredLocked() != 0 {
+		t.resend = true
+		t.timer.Reset(t.interval)
+	}
 }
 
 func (t *ProgressEmitter) temporaryIndexUnsubscribe(conn protocol.Connection) {
@@ -339,6 +401,7 @@ func (t *ProgressEmitter) clearLocked() {
 		}
 	}
 	t.registry = make(map[string]map[string]*sharedPullerState)
+	t.restored = make(map[string]map[string]*sharedPullerState)
 	t.sentDownloadStates = make(map[protocol.DeviceID]*sentDownloadState)
 	t.connections = make(map[protocol.DeviceID]protocol.Connection)
 	t.foldersByConns = make(map[protocol.DeviceID][]string)
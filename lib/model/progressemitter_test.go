@@ -469,6 +469,68 @@ func TestSendDownloadProgressMessages(t *testing.T) {
 	}
 }
 
+func TestRestoredDownloadProgress(t *testing.T) {
+	c, cfgCancel := newConfigWrapper(config.Configuration{Version: config.CurrentVersion})
+	defer os.Remove(c.ConfigPath())
+	defer cfgCancel()
+	waiter, err := c.Modify(func(cfg *config.Configuration) {
+		cfg.Options.ProgressUpdateIntervalS = 60 // irrelevant, but must be positive
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waiter.Wait()
+
+	fc := newFakeConnection(protocol.DeviceID{}, nil)
+	p := NewProgressEmitter(c, events.NoopLogger)
+
+	v1 := (protocol.Vector{}).Update(0)
+	file := protocol.FileInfo{
+		Name:    "file",
+		Version: v1,
+		Blocks:  make([]protocol.BlockInfo, 4),
+	}
+	restored := newSharedPullerState(file, nil, "folder", "", nil, []int{1, 2}, false, false, protocol.FileInfo{}, config.PreallocationNone, false)
+	p.Restore("folder", []*sharedPullerState{restored})
+
+	// A new connection gets the restored blocks.
+	p.temporaryIndexSubscribe(fc, []string{"folder"})
+	if !p.resend {
+		t.Error("expected a resend to be scheduled for the new connection")
+	}
+	sendMsgs(p)
+	if len(fc.downloadProgressMessages) != 1 {
+		t.Fatalf("expected one message, got %d", len(fc.downloadProgressMessages))
+	}
+	upd := fc.downloadProgressMessages[0].updates
+	if len(upd) != 1 || upd[0].UpdateType != protocol.FileDownloadProgressUpdateTypeAppend || len(upd[0].BlockIndexes) != 2 {
+		t.Fatalf("unexpected updates %v", upd)
+	}
+	fc.downloadProgressMessages = nil
+
+	// The restored puller is replaced by a registered one for the same file.
+	puller := newSharedPullerState(file, nil, "folder", "", nil, []int{1, 2, 3}, false, false, protocol.FileInfo{}, config.PreallocationNone, false)
+	p.Register(puller)
+	if len(p.restored["folder"]) != 0 {
+		t.Error("expected the restored puller to be replaced")
+	}
+	p.Deregister(puller)
+
+	// Forgetting restored pullers makes the peer forget the blocks.
+	p.Restore("folder", []*sharedPullerState{restored})
+	sendMsgs(p)
+	fc.downloadProgressMessages = nil
+	p.ForgetRestored("folder")
+	sendMsgs(p)
+	if len(fc.downloadProgressMessages) != 1 {
+		t.Fatalf("expected one message, got %d", len(fc.downloadProgressMessages))
+	}
+	upd = fc.downloadProgressMessages[0].updates
+	if len(upd) != 1 || upd[0].UpdateType != protocol.FileDownloadProgressUpdateTypeForget {
+		t.Errorf("unexpected updates %v", upd)
+	}
+}
+
 func sendMsgs(p *ProgressEmitter) {
 	p.mut.Lock()
 	updates := p.computeProgressUpdates()
@@ -0,0 +1,150 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+const (
+	// The most files we hint about at once. Beyond that, the normal pull
+	// order is as good as any.
+	maxPullHintFiles = 1000
+
+	// How long a hint is kept when the index update for the files hasn't
+	// arrived yet.
+	pullHintTimeout = 5 * time.Minute
+
+	// Index updates with at most this many files are hinted as well, so
+	// small changes don't wait for a large pull in progress.
+	maxHintedIndexUpdate = 100
+)
+
+// pullHint is the payload of a pull hint control message, telling another
+// device to pull the files ahead of anything else queued, e.g. as they
+// were just restored.
+type pullHint struct {
+	Folder string   `json:"folder"`
+	Files  []string `json:"files"`
+}
+
+// restoreAndAnnounce scans the restored files right away, so the index
+// update goes out to the other devices without waiting for the watcher or
+// the next full scan, and then hints them to pull those files first.
+func (m *model) restoreAndAnnounce(folder string, files []string) {
+	if err := m.ScanFolderSubdirs(folder, files); err != nil {
+		l.Debugf("Scanning restored files in folder %s: %v", folder, err)
+		return
+	}
+	if m.cfg.Options().RestorePullHints {
+		m.sendPullHints(folder, files)
+	}
+}
+
+// sendPullHints sends a pull hint for the files to the connected devices
+// sharing the folder.
+func (m *model) sendPullHints(folder string, files []string) {
+	fcfg, ok := m.cfg.Folder(folder)
+	if !ok {
+		return
+	}
+	if len(files) > maxPullHintFiles {
+		files = files[:maxPullHintFiles]
+	}
+	payload, err := json.Marshal(pullHint{Folder: folder, Files: files})
+	if err != nil {
+		return
+	}
+	for _, device := range fcfg.DeviceIDs() {
+		if device == m.id {
+			continue
+		}
+		if conn, ok := m.Connection(device); ok {
+			l.Debugf("Sending pull hint for %d files in folder %s to %v", len(files), folder, device)
+			go conn.Control(context.Background(), protocol.Control{Type: controlTypePullHint, Payload: payload})
+		}
+	}
+}
+
+// handlePullHint is called for incoming pull hints, which are accepted
+// from any device the folder is shared with.
+func (m *model) handlePullHint(device protocol.DeviceID, ctrl protocol.Control) error {
+	var hint pullHint
+	if err := json.Unmarshal(ctrl.Payload, &hint); err != nil {
+		return fmt.Errorf("parsing pull hint: %w", err)
+	}
+	if fcfg, ok := m.cfg.Folder(hint.Folder); !ok || fcfg.Paused || !fcfg.SharedWith(device) {
+		l.Debugf("Ignoring pull hint for folder %s from %v", hint.Folder, device)
+		return nil
+	}
+	if len(hint.Files) > maxPullHintFiles {
+		hint.Files = hint.Files[:maxPullHintFiles]
+	}
+
+	m.fmut.RLock()
+	runner, ok := m.folderRunners[hint.Folder]
+	m.fmut.RUnlock()
+	if ok {
+		l.Debugf("Pull hint for %d files in folder %s from %v", len(hint.Files), hint.Folder, device)
+		runner.PullHint(hint.Files)
+	}
+	return nil
+}
+
+// pullHints holds the files hinted to be pulled first, until they are
+// pulled or the hint expires.
+type pullHints struct {
+	mut   sync.Mutex
+	until map[string]time.Time
+}
+
+func newPullHints() *pullHints {
+	return &pullHints{
+		mut:   sync.NewMutex(),
+		until: make(map[string]time.Time),
+	}
+}
+
+func (h *pullHints) add(files []string) {
+	until := time.Now().Add(pullHintTimeout)
+	h.mut.Lock()
+	for _, file := range files {
+		if _, ok := h.until[file]; !ok && len(h.until) >= maxPullHintFiles {
+			break
+		}
+		h.until[file] = until
+	}
+	h.mut.Unlock()
+}
+
+// files returns the hinted files, dropping expired hints.
+func (h *pullHints) files() []string {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+	now := time.Now()
+	files := make([]string, 0, len(h.until))
+	for file, until := range h.until {
+		if now.After(until) {
+			delete(h.until, file)
+			continue
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+func (h *pullHints) done(file string) {
+	h.mut.Lock()
+	delete(h.until, file)
+	h.mut.Unlock()
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestPullHints(t *testing.T) {
+	m, fc, fcfg, wcfgCancel := setupModelWithConnection(t)
+	defer wcfgCancel()
+	defer cleanupModel(m)
+
+	// Pull hints to device1 are handled by the model itself.
+	received := make(chan struct{}, 1)
+	fc.ControlCalls(func(_ context.Context, ctrl protocol.Control) {
+		if ctrl.Type != controlTypePullHint {
+			return
+		}
+		if err := m.Control(fc, ctrl); err != nil {
+			t.Error(err)
+		}
+		received <- struct{}{}
+	})
+
+	m.sendPullHints(fcfg.ID, []string{"restored"})
+	select {
+	case <-received:
+	case <-time.After(10 * time.Second):
+		t.Fatal("pull hint not sent")
+	}
+
+	f := m.folderRunners[fcfg.ID].(*sendReceiveFolder)
+	if files := f.pullHints.files(); len(files) != 1 || files[0] != "restored" {
+		t.Fatalf("unexpected hinted files %v", files)
+	}
+
+	// Hints for folders not shared with the device are ignored.
+	must(t, m.handlePullHint(device2, protocol.Control{Type: controlTypePullHint, Payload: []byte(`{"folder":"default","files":["other"]}`)}))
+	if files := f.pullHints.files(); len(files) != 1 {
+		t.Errorf("expected hint from device2 to be ignored, got %v", files)
+	}
+}
+
+func TestPullHintsOrder(t *testing.T) {
+	q := newJobQueue()
+	hints := newPullHints()
+	hints.add([]string{"restored", "missing"})
+
+	// Once queued, the hinted file goes first, and the hint is kept for
+	// the file not queued yet.
+	q.Push("other", 0, time.Time{})
+	q.Push("restored", 0, time.Time{})
+	for _, file := range hints.files() {
+		if q.BringToFront(file) {
+			hints.done(file)
+		}
+	}
+	if file, _ := q.Pop(); file != "restored" {
+		t.Errorf("expected restored first, got %v", file)
+	}
+	if files := hints.files(); len(files) != 1 || files[0] != "missing" {
+		t.Errorf("expected only the missing file hinted, got %v", files)
+	}
+}
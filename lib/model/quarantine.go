@@ -0,0 +1,161 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/messages"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// Why an item is quarantined, i.e. can't be synced as things are.
+const (
+	QuarantineInvalidName = "invalidName" // the name isn't valid on this system
+	QuarantineUnsupported = "unsupported" // the kind of item isn't supported on this system
+	QuarantinePermission  = "permission"  // access to the item was denied
+	QuarantineReceiveOnly = "receiveOnly" // changed locally in a receive only folder
+	QuarantineUnavailable = "unavailable" // no connected device has the needed version
+	QuarantineFailed      = "failed"      // any other error
+)
+
+// errorReason returns the quarantine reason for an error on an item.
+func errorReason(err error) string {
+	switch {
+	case errors.Is(err, fs.ErrInvalidFilename):
+		return QuarantineInvalidName
+	case errors.Is(err, errIncompatibleSymlink):
+		return QuarantineUnsupported
+	case fs.IsPermission(err):
+		return QuarantinePermission
+	case errors.Is(err, errNotAvailable), errors.Is(err, errNoDevice):
+		return QuarantineUnavailable
+	default:
+		return QuarantineFailed
+	}
+}
+
+// errorCode returns the message code for an error on an item.
+func errorCode(err error) messages.Code {
+	switch {
+	case errors.Is(err, errIncompatibleSymlink):
+		return messages.FileUnsupported
+	case errors.Is(err, errNotAvailable), errors.Is(err, errNoDevice):
+		return messages.FileUnavailable
+	default:
+		return messages.FileErrorCode(err)
+	}
+}
+
+// FolderQuarantine returns the items in the folder that aren't synced,
+// with the reason why: Those that failed to scan or sync, those of a kind
+// not supported on this system and those changed locally in a receive only
+// folder. Items with errors are only listed once, with the error.
+func (m *model) FolderQuarantine(folder string) ([]FileError, error) {
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	runner := m.folderRunners[folder]
+	rf := m.folderFiles[folder]
+	m.fmut.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	items := runner.Errors()
+	seen := make(map[string]struct{}, len(items))
+	for _, fe := range items {
+		seen[fe.Path] = struct{}{}
+	}
+
+	snap, err := rf.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer snap.Release()
+
+	snap.WithHaveTruncated(protocol.LocalDeviceID, func(intf protocol.FileIntf) bool {
+		f := intf.(db.FileInfoTruncated)
+		if _, ok := seen[f.Name]; ok {
+			return true
+		}
+		switch {
+		case f.IsUnsupported():
+			items = append(items, FileError{
+				Path:   f.Name,
+				Err:    "unsupported item type",
+				Reason: QuarantineUnsupported,
+				Code:   messages.FileUnsupported,
+			})
+		case f.IsReceiveOnlyChanged():
+			items = append(items, FileError{
+				Path:   f.Name,
+				Err:    "changed locally in a receive only folder",
+				Reason: QuarantineReceiveOnly,
+				Code:   messages.FileReceiveOnly,
+			})
+		}
+		return true
+	})
+
+	sort.Sort(fileErrorList(items))
+	return items, nil
+}
+
+// RetryQuarantined forgets the errors for the given items, or all of them
+// if none are given, and has them rescanned and synced again. Items
+// changed in a receive only folder stay quarantined until reverted.
+func (m *model) RetryQuarantined(folder string, paths []string) error {
+	m.fmut.RLock()
+	err := m.checkFolderRunningLocked(folder)
+	runner := m.folderRunners[folder]
+	m.fmut.RUnlock()
+	if err != nil {
+		return err
+	}
+	runner.Retry(paths)
+	return nil
+}
+
+// Retry forgets the errors for the given items, or all of them if none are
+// given, then schedules a rescan of the items and a pull.
+func (f *folder) Retry(paths []string) {
+	f.errorsMut.Lock()
+	if len(paths) == 0 {
+		f.scanErrors = nil
+		f.pullErrors = nil
+	} else {
+		retry := make(map[string]struct{}, len(paths))
+		for _, path := range paths {
+			retry[path] = struct{}{}
+		}
+		f.scanErrors = withoutFileErrors(f.scanErrors, retry)
+		f.pullErrors = withoutFileErrors(f.pullErrors, retry)
+	}
+	f.errorsMut.Unlock()
+
+	if len(paths) == 0 {
+		f.ScheduleScan()
+	} else {
+		for _, path := range paths {
+			f.ScheduleForceRescan(path)
+		}
+	}
+	f.SchedulePull()
+}
+
+func withoutFileErrors(errs []FileError, paths map[string]struct{}) []FileError {
+	filtered := errs[:0]
+	for _, fe := range errs {
+		if _, ok := paths[fe.Path]; !ok {
+			filtered = append(filtered, fe)
+		}
+	}
+	return filtered
+}
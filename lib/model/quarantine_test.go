@@ -0,0 +1,63 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/messages"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestFolderQuarantine(t *testing.T) {
+	unsupported := protocol.FileInfo{Name: "link", Type: protocol.FileInfoTypeSymlink, Version: protocol.Vector{}.Update(myID.Short())}
+	unsupported.SetUnsupported()
+	changed := protocol.FileInfo{Name: "changed", Version: protocol.Vector{}.Update(myID.Short()), LocalFlags: protocol.FlagLocalReceiveOnly}
+	synced := protocol.FileInfo{Name: "synced", Version: protocol.Vector{}.Update(myID.Short())}
+	m, f, wcfgCancel := setupSendReceiveFolder(t, unsupported, changed, synced)
+	defer wcfgCancel()
+	defer cleanupModel(m)
+
+	f.newScanError("denied", fmt.Errorf("reading: %w", os.ErrPermission))
+	f.newScanError("nul", fmt.Errorf("%w, contains Windows reserved name", fs.ErrInvalidFilename))
+	f.newScanError("broken", errors.New("something else"))
+
+	items, err := m.FolderQuarantine(f.ID)
+	must(t, err)
+	expected := []FileError{
+		{Path: "broken", Reason: QuarantineFailed, Code: messages.FileFailed},
+		{Path: "changed", Reason: QuarantineReceiveOnly, Code: messages.FileReceiveOnly},
+		{Path: "denied", Reason: QuarantinePermission, Code: messages.FilePermission},
+		{Path: "link", Reason: QuarantineUnsupported, Code: messages.FileUnsupported},
+		{Path: "nul", Reason: QuarantineInvalidName, Code: messages.FileInvalidName},
+	}
+	if len(items) != len(expected) {
+		t.Fatalf("expected %d quarantined items, got %v", len(expected), items)
+	}
+	for i, item := range items {
+		if item.Path != expected[i].Path || item.Reason != expected[i].Reason || item.Code != expected[i].Code {
+			t.Errorf("expected %v at %d, got %v", expected[i], i, item)
+		}
+	}
+
+	// Retrying an item forgets its error until it fails again.
+	must(t, m.RetryQuarantined(f.ID, []string{"denied"}))
+	items, err = m.FolderQuarantine(f.ID)
+	must(t, err)
+	for _, item := range items {
+		if item.Path == "denied" {
+			t.Error("retried item is still quarantined")
+		}
+	}
+	if len(items) != len(expected)-1 {
+		t.Errorf("expected %d quarantined items, got %v", len(expected)-1, items)
+	}
+}
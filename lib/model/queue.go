@@ -15,9 +15,10 @@ import (
 )
 
 type jobQueue struct {
-	progress []string
-	queued   []jobQueueEntry
-	mut      sync.Mutex
+	progress    []string
+	queued      []jobQueueEntry
+	interactive map[string]struct{} // brought to front, until done
+	mut         sync.Mutex
 }
 
 type jobQueueEntry struct {
@@ -28,7 +29,8 @@ type jobQueueEntry struct {
 
 func newJobQueue() *jobQueue {
 	return &jobQueue{
-		mut: sync.NewMutex(),
+		interactive: make(map[string]struct{}),
+		mut:         sync.NewMutex(),
 	}
 }
 
@@ -54,7 +56,9 @@ func (q *jobQueue) Pop() (string, bool) {
 	return f, true
 }
 
-func (q *jobQueue) BringToFront(filename string) {
+// BringToFront moves the file to the front of the queue, returning whether
+// it was queued or in progress.
+func (q *jobQueue) BringToFront(filename string) bool {
 	q.mut.Lock()
 	defer q.mut.Unlock()
 
@@ -67,15 +71,35 @@ func (q *jobQueue) BringToFront(filename string) {
 				// Put the selected element at the front
 				q.queued[0] = cur
 			}
-			return
+			q.interactive[filename] = struct{}{}
+			return true
+		}
+	}
+	// Blocks of a file in progress can still be hurried along.
+	for _, cur := range q.progress {
+		if cur == filename {
+			q.interactive[filename] = struct{}{}
+			return true
 		}
 	}
+	return false
+}
+
+// Interactive returns whether the file was brought to front, i.e. someone
+// is waiting for it.
+func (q *jobQueue) Interactive(filename string) bool {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+	_, ok := q.interactive[filename]
+	return ok
 }
 
 func (q *jobQueue) Done(file string) {
 	q.mut.Lock()
 	defer q.mut.Unlock()
 
+	delete(q.interactive, file)
+
 	for i := range q.progress {
 		if q.progress[i] == file {
 			copy(q.progress[i:], q.progress[i+1:])
@@ -139,6 +163,7 @@ func (q *jobQueue) Reset() {
 	defer q.mut.Unlock()
 	q.progress = nil
 	q.queued = nil
+	q.interactive = make(map[string]struct{})
 }
 
 func (q *jobQueue) lenQueued() int {
@@ -163,6 +163,35 @@ func TestBringToFront(t *testing.T) {
 	}
 }
 
+func TestBringToFrontInteractive(t *testing.T) {
+	q := newJobQueue()
+	q.Push("f1", 0, time.Time{})
+	q.Push("f2", 0, time.Time{})
+	q.Push("f3", 0, time.Time{})
+
+	// Files brought to front are interactive until done, whether they are
+	// queued or already in progress.
+	q.Pop()
+	q.BringToFront("f1")
+	q.BringToFront("f3")
+	q.BringToFront("nonexistent")
+	for _, file := range []string{"f1", "f3"} {
+		if !q.Interactive(file) {
+			t.Errorf("%s isn't interactive", file)
+		}
+	}
+	for _, file := range []string{"f2", "nonexistent"} {
+		if q.Interactive(file) {
+			t.Errorf("%s is interactive", file)
+		}
+	}
+
+	q.Done("f1")
+	if q.Interactive("f1") {
+		t.Error("f1 is interactive after being done")
+	}
+}
+
 func TestShuffle(t *testing.T) {
 	q := newJobQueue()
 	q.Push("f1", 0, time.Time{})
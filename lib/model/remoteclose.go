@@ -0,0 +1,46 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// RemoteClose describes why the other device last closed the connection
+// to us.
+type RemoteClose struct {
+	Code    string    `json:"code"` // e.g. "folderPaused", empty if not given
+	Folder  string    `json:"folder,omitempty"`
+	Message string    `json:"message"`
+	When    time.Time `json:"when"`
+}
+
+func newRemoteClose(err *protocol.RemoteCloseError) RemoteClose {
+	return RemoteClose{
+		Code:    closeReasonCode(err.Code),
+		Folder:  err.Folder,
+		Message: err.Reason,
+		When:    time.Now().Truncate(time.Second),
+	}
+}
+
+func closeReasonCode(code protocol.CloseReason) string {
+	switch code {
+	case protocol.CloseReasonFolderPaused:
+		return "folderPaused"
+	case protocol.CloseReasonFolderMissing:
+		return "folderMissing"
+	case protocol.CloseReasonEncryptionMismatch:
+		return "encryptionMismatch"
+	case protocol.CloseReasonTooManyConnections:
+		return "tooManyConnections"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestRemoteCloseReason(t *testing.T) {
+	m, fc, fcfg, wCancel := setupModelWithConnection(t)
+	defer wCancel()
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem(nil).URI())
+
+	// An index for a folder we don't share is rejected with a reason.
+	err := m.Index(fc, "unknown", nil)
+	var cerr *protocol.CloseError
+	if !errors.As(err, &cerr) || cerr.Code != protocol.CloseReasonFolderMissing || cerr.Folder != "unknown" {
+		t.Errorf("expected a folder missing close error, got %v", err)
+	}
+	if !errors.Is(err, ErrFolderMissing) {
+		t.Errorf("expected the close error to wrap %v", ErrFolderMissing)
+	}
+
+	fc.Close(&protocol.RemoteCloseError{Code: protocol.CloseReasonFolderPaused, Folder: fcfg.ID, Reason: "folder is paused"})
+
+	ci := m.ConnectionStats()["connections"].(map[string]ConnectionInfo)[device1.String()]
+	if ci.RemoteClose == nil {
+		t.Fatal("expected the remote close reason to be reported")
+	}
+	if ci.RemoteClose.Code != "folderPaused" || ci.RemoteClose.Folder != fcfg.ID || ci.RemoteClose.Message != "folder is paused" {
+		t.Errorf("unexpected remote close reason %+v", ci.RemoteClose)
+	}
+}
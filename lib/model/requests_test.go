@@ -1222,7 +1222,7 @@ func TestRequestIndexSenderClusterConfigBeforeStart(t *testing.T) {
 
 	// Add connection (sends incoming cluster config) before starting the new model
 	m = &testModel{
-		model:    NewModel(m.cfg, m.id, m.clientName, m.clientVersion, m.db, m.protectedFiles, m.evLogger, protocol.NewKeyGenerator()).(*model),
+		model:    NewModel(m.cfg, m.id, m.clientName, m.clientVersion, m.db, m.protectedFiles, m.profile, m.evLogger, protocol.NewKeyGenerator(), nil).(*model),
 		evCancel: m.evCancel,
 		stopped:  make(chan struct{}),
 	}
@@ -1461,3 +1461,95 @@ func TestRequestGlobalInvalidToValid(t *testing.T) {
 		}
 	}
 }
+
+func TestRequestBlockRetry(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	fcfg.BlockRequestTimeoutS = 1
+	fcfg.BlockPullRetries = 1
+	setFolder(t, w, fcfg)
+	m, fc := setupModelWithConnectionFromWrapper(t, w)
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem(nil).URI())
+
+	// The first request doesn't get an answer and times out, the retry
+	// succeeds.
+	var requestsMut sync.Mutex
+	requests := 0
+	fc.RequestCalls(func(ctx context.Context, folder, name string, blockNo int, offset int64, size int, hash []byte, weakHash uint32, fromTemporary bool) ([]byte, error) {
+		requestsMut.Lock()
+		requests++
+		first := requests == 1
+		requestsMut.Unlock()
+		if first {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return fc.fileData[name], nil
+	})
+	done := make(chan struct{}, 1)
+	fc.setIndexFn(func(_ context.Context, folder string, fs []protocol.FileInfo) error {
+		for _, f := range fs {
+			if f.Name == "file" {
+				select {
+				case done <- struct{}{}:
+				default:
+				}
+			}
+		}
+		return nil
+	})
+
+	fc.addFile("file", 0o644, protocol.FileInfoTypeFile, []byte("data"))
+	fc.sendIndexUpdate()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the file to be pulled")
+	}
+	requestsMut.Lock()
+	defer requestsMut.Unlock()
+	if requests != 2 {
+		t.Errorf("expected two requests, got %d", requests)
+	}
+}
+
+func TestRequestBlockBlacklist(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	fcfg.BlockPullRetries = 1
+	fcfg.BlockBlacklistS = 3600
+	setFolder(t, w, fcfg)
+	m, fc := setupModelWithConnectionFromWrapper(t, w)
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem(nil).URI())
+
+	// The only device fails and is blacklisted, so the retry doesn't ask
+	// it again and the pull fails.
+	var requestsMut sync.Mutex
+	requests := 0
+	fc.RequestCalls(func(ctx context.Context, folder, name string, blockNo int, offset int64, size int, hash []byte, weakHash uint32, fromTemporary bool) ([]byte, error) {
+		requestsMut.Lock()
+		requests++
+		requestsMut.Unlock()
+		return nil, protocol.ErrGeneric
+	})
+
+	fc.addFile("file", 0o644, protocol.FileInfoTypeFile, []byte("data"))
+	fc.sendIndexUpdate()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		errs, err := m.FolderErrors(fcfg.ID)
+		must(t, err)
+		if len(errs) > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the pull to fail")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	requestsMut.Lock()
+	defer requestsMut.Unlock()
+	if requests != 1 {
+		t.Errorf("expected a single request, got %d", requests)
+	}
+}
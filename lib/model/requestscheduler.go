@@ -0,0 +1,187 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+
+	"github.com/syncthing/syncthing/lib/config"
+)
+
+// The requestScheduler limits the amount of data in outstanding block
+// requests across all folders and hands out the available capacity using
+// weighted fair queuing. When the receive bandwidth is capped, this keeps
+// folders with many large files from starving the others: each folder gets
+// a share of the bandwidth proportional to its weight, as long as it has
+// requests to make. Requests of a higher priority are granted before all
+// others, regardless of fairness.
+type requestScheduler struct {
+	mut        sync.Mutex
+	capacity   int // zero means unlimited
+	inFlight   int
+	vtime      float64            // start tag of the last granted request
+	lastFinish map[string]float64 // finish tag of the last request per folder
+	queue      requestQueue
+	seq        int
+}
+
+type scheduledRequest struct {
+	size     int
+	priority requestPriority
+	start    float64
+	finish   float64
+	seq      int // preserves arrival order for equal finish tags
+	index    int // position in the queue, -1 when granted
+	ready    chan struct{}
+}
+
+// The requestPriority of a block request says how urgently the data is
+// needed.
+type requestPriority int
+
+const (
+	requestPriorityBackground  requestPriority = iota // pulling changes
+	requestPriorityInteractive                        // someone is waiting for the data
+)
+
+func newRequestScheduler(capacity int) *requestScheduler {
+	return &requestScheduler{
+		capacity:   capacity,
+		lastFinish: make(map[string]float64),
+	}
+}
+
+// requestSchedulerCapacity returns the request capacity to use with the
+// given options: one second worth of data at the receive rate limit, or
+// unlimited if there is no limit.
+func requestSchedulerCapacity(opts config.OptionsConfiguration) int {
+	if opts.MaxRecvKbps <= 0 {
+		return 0
+	}
+	return 1024 * opts.MaxRecvKbps
+}
+
+// take blocks until size bytes of request capacity have been granted to
+// the given folder, or the context is cancelled. The capacity must be
+// returned using give once the request has completed.
+func (s *requestScheduler) take(ctx context.Context, folder string, weight, size int, priority requestPriority) error {
+	if weight <= 0 {
+		weight = 1
+	}
+
+	s.mut.Lock()
+	start := s.vtime
+	if last := s.lastFinish[folder]; last > start {
+		start = last
+	}
+	req := &scheduledRequest{
+		size:     size,
+		priority: priority,
+		start:    start,
+		finish:   start + float64(size)/float64(weight),
+		seq:      s.seq,
+		ready:    make(chan struct{}),
+	}
+	s.seq++
+	s.lastFinish[folder] = req.finish
+	heap.Push(&s.queue, req)
+	s.dispatchLocked()
+	s.mut.Unlock()
+
+	select {
+	case <-req.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.mut.Lock()
+	if req.index >= 0 {
+		heap.Remove(&s.queue, req.index)
+		s.mut.Unlock()
+		return ctx.Err()
+	}
+	s.mut.Unlock()
+	// Granted concurrently with the cancellation.
+	s.give(size)
+	return ctx.Err()
+}
+
+// give returns capacity taken by a previous call to take.
+func (s *requestScheduler) give(size int) {
+	s.mut.Lock()
+	s.inFlight -= size
+	s.dispatchLocked()
+	s.mut.Unlock()
+}
+
+func (s *requestScheduler) setCapacity(capacity int) {
+	s.mut.Lock()
+	s.capacity = capacity
+	s.dispatchLocked()
+	s.mut.Unlock()
+}
+
+func (s *requestScheduler) dispatchLocked() {
+	for s.queue.Len() > 0 {
+		req := s.queue[0]
+		// A request larger than the capacity is let through on its own,
+		// rather than blocking forever.
+		if s.capacity > 0 && s.inFlight > 0 && s.inFlight+req.size > s.capacity {
+			return
+		}
+		heap.Pop(&s.queue)
+		s.inFlight += req.size
+		if req.start > s.vtime {
+			s.vtime = req.start
+		}
+		close(req.ready)
+	}
+	// Nothing is waiting, so there is no history to be fair about.
+	if s.inFlight == 0 {
+		s.vtime = 0
+		s.lastFinish = make(map[string]float64)
+	}
+}
+
+// requestQueue is a heap of requests ordered by priority, then finish tag.
+type requestQueue []*scheduledRequest
+
+func (q requestQueue) Len() int { return len(q) }
+
+func (q requestQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	if q[i].finish != q[j].finish {
+		return q[i].finish < q[j].finish
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q requestQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *requestQueue) Push(x interface{}) {
+	req := x.(*scheduledRequest)
+	req.index = len(*q)
+	*q = append(*q, req)
+}
+
+func (q *requestQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	req := old[n-1]
+	old[n-1] = nil
+	req.index = -1
+	*q = old[:n-1]
+	return req
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRequestSchedulerWeights(t *testing.T) {
+	const size = 128 << 10
+	s := newRequestScheduler(size)
+	ctx := context.Background()
+
+	// Occupy all capacity, so that everything else queues up.
+	must(t, s.take(ctx, "blocker", 1, size, requestPriorityBackground))
+
+	granted := make(chan string)
+	for i := 0; i < 20; i++ {
+		for _, folder := range []string{"media", "docs"} {
+			weight := 1
+			if folder == "docs" {
+				weight = 3
+			}
+			folder := folder
+			go func() {
+				if err := s.take(ctx, folder, weight, size, requestPriorityBackground); err != nil {
+					t.Error(err)
+				}
+				granted <- folder
+			}()
+		}
+	}
+	// Wait for all requests to be queued.
+	for {
+		s.mut.Lock()
+		n := s.queue.Len()
+		s.mut.Unlock()
+		if n == 40 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	s.give(size)
+	counts := make(map[string]int)
+	for i := 0; i < 16; i++ {
+		counts[<-granted]++
+		s.give(size)
+	}
+	if counts["docs"] != 12 || counts["media"] != 4 {
+		t.Errorf("expected 3:1 split, got %v", counts)
+	}
+	for i := 16; i < 40; i++ {
+		<-granted
+		s.give(size)
+	}
+}
+
+func TestRequestSchedulerCancel(t *testing.T) {
+	s := newRequestScheduler(1)
+	must(t, s.take(context.Background(), "a", 1, 1, requestPriorityBackground))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.take(ctx, "b", 1, 1, requestPriorityBackground); err == nil {
+		t.Fatal("expected error from cancelled take")
+	}
+	if s.queue.Len() != 0 {
+		t.Fatal("cancelled request still queued")
+	}
+
+	// Large requests pass when nothing else is in flight, and capacity can
+	// be lifted entirely.
+	s.give(1)
+	must(t, s.take(context.Background(), "b", 1, 10, requestPriorityBackground))
+	s.setCapacity(0)
+	must(t, s.take(context.Background(), "a", 1, 10, requestPriorityBackground))
+}
+
+func TestRequestSchedulerPriority(t *testing.T) {
+	s := newRequestScheduler(1)
+	ctx := context.Background()
+	must(t, s.take(ctx, "a", 1, 1, requestPriorityBackground))
+
+	// A background request of a folder with a higher weight queues up
+	// first, but the interactive one is granted first regardless.
+	queued := func() int {
+		s.mut.Lock()
+		defer s.mut.Unlock()
+		return s.queue.Len()
+	}
+	granted := make(chan requestPriority)
+	take := func(folder string, weight int, priority requestPriority) {
+		if err := s.take(ctx, folder, weight, 1, priority); err != nil {
+			t.Error(err)
+		}
+		granted <- priority
+	}
+	go take("b", 10, requestPriorityBackground)
+	for queued() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	go take("a", 1, requestPriorityInteractive)
+	for queued() != 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	s.give(1)
+	if prio := <-granted; prio != requestPriorityInteractive {
+		t.Error("expected the interactive request to be granted first")
+	}
+	s.give(1)
+	<-granted
+}
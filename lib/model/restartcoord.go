@@ -0,0 +1,334 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+var (
+	// restartLease is how long a peer refuses other restarts after
+	// granting one, unless the restarted device reports back earlier. A
+	// peer that disconnected less than this ago may still be restarting.
+	restartLease = 15 * time.Minute
+	// restartRetryInterval is the base interval between attempts to get
+	// the permission to restart, randomized to break ties.
+	restartRetryInterval = time.Minute
+)
+
+var errRestartDenied = errors.New("restart denied by peer")
+
+// restartReply is the payload of the response to a restart request.
+type restartReply struct {
+	Granted bool   `json:"granted"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// RestartCoordinationStatus describes the state of restart coordination
+// with the peers that have coordinateRestarts set.
+type RestartCoordinationStatus struct {
+	// Acquiring is true while we're waiting for permission to restart,
+	// Holding once we got it.
+	Acquiring bool `json:"acquiring"`
+	Holding   bool `json:"holding"`
+	// The peer we allowed to restart, if any, and until when.
+	GrantedTo    *protocol.DeviceID `json:"grantedTo,omitempty"`
+	GrantExpires *time.Time         `json:"grantExpires,omitempty"`
+	// The last answer of each peer to our restart request.
+	Peers map[protocol.DeviceID]RestartPeerStatus `json:"peers"`
+}
+
+type RestartPeerStatus struct {
+	Connected bool      `json:"connected"`
+	Answered  time.Time `json:"answered,omitempty"`
+	Granted   bool      `json:"granted"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// The restartCoordinator makes sure that among a group of devices that
+// coordinate restarts with each other, only one restarts (e.g. to upgrade)
+// at a time. Before restarting, a device asks each connected peer for
+// permission; a peer grants it to one device at a time for the restart
+// lease, until that device connects again after restarting. Concurrent
+// requests are resolved in favour of the lower device ID.
+type restartCoordinator struct {
+	model *model
+
+	mut          sync.Mutex
+	acquiring    bool
+	holding      bool
+	grantedTo    protocol.DeviceID
+	grantExpires time.Time
+	disconnected map[protocol.DeviceID]time.Time
+	peers        map[protocol.DeviceID]RestartPeerStatus
+	timeNow      func() time.Time
+}
+
+func newRestartCoordinator(m *model) *restartCoordinator {
+	return &restartCoordinator{
+		model:        m,
+		mut:          sync.NewMutex(),
+		disconnected: make(map[protocol.DeviceID]time.Time),
+		peers:        make(map[protocol.DeviceID]RestartPeerStatus),
+		timeNow:      time.Now,
+	}
+}
+
+func (c *restartCoordinator) Serve(ctx context.Context) error {
+	sub := c.model.evLogger.Subscribe(events.DeviceConnected | events.DeviceDisconnected)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev, ok := <-sub.C():
+			if !ok {
+				return nil
+			}
+			data, _ := ev.Data.(map[string]string)
+			device, err := protocol.DeviceIDFromString(data["id"])
+			if err != nil || !c.isPeer(device) {
+				continue
+			}
+			if ev.Type == events.DeviceDisconnected {
+				c.mut.Lock()
+				c.disconnected[device] = c.timeNow()
+				c.mut.Unlock()
+				continue
+			}
+			c.reportBack(ctx, device)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *restartCoordinator) String() string {
+	return fmt.Sprintf("restartCoordinator@%p", c)
+}
+
+func (c *restartCoordinator) isPeer(device protocol.DeviceID) bool {
+	dev, ok := c.model.cfg.Device(device)
+	return ok && dev.CoordinateRestarts
+}
+
+// reportBack tells a newly connected peer that we're up, releasing a grant
+// it may hold for our restart.
+func (c *restartCoordinator) reportBack(ctx context.Context, device protocol.DeviceID) {
+	c.mut.Lock()
+	holding := c.holding
+	c.mut.Unlock()
+	if holding {
+		// About to restart, the grant is still needed.
+		return
+	}
+	if conn, ok := c.model.Connection(device); ok {
+		conn.Control(ctx, protocol.Control{Type: controlTypeRestartDone})
+	}
+}
+
+// handle is called for incoming restart requests and done messages.
+func (c *restartCoordinator) handle(conn protocol.Connection, ctrl protocol.Control) error {
+	device := conn.DeviceID()
+
+	if ctrl.Type == controlTypeRestartDone {
+		c.mut.Lock()
+		if c.grantedTo == device {
+			c.grantedTo = protocol.EmptyDeviceID
+			c.grantExpires = time.Time{}
+			l.Infof("Device %v is back after its coordinated restart", device)
+		}
+		c.mut.Unlock()
+		return nil
+	}
+
+	reply := c.decide(device)
+	payload, err := json.Marshal(reply)
+	if err != nil {
+		return err
+	}
+	go conn.Control(context.Background(), protocol.Control{
+		ResponseTo: ctrl.ID,
+		Type:       controlTypeRestart,
+		Payload:    payload,
+	})
+	return nil
+}
+
+// decide answers a peer's request to restart.
+func (c *restartCoordinator) decide(device protocol.DeviceID) restartReply {
+	if !c.isPeer(device) {
+		// We don't coordinate with this device, so it may do as it
+		// pleases.
+		return restartReply{Granted: true}
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	now := c.timeNow()
+	switch {
+	case c.holding:
+		return restartReply{Reason: "restarting itself"}
+	case c.acquiring && c.model.id.Compare(device) < 0:
+		return restartReply{Reason: "waiting to restart itself"}
+	case c.grantedTo != protocol.EmptyDeviceID && c.grantedTo != device && now.Before(c.grantExpires):
+		return restartReply{Reason: fmt.Sprintf("device %v is restarting", c.grantedTo.Short())}
+	}
+	c.grantedTo = device
+	c.grantExpires = now.Add(restartLease)
+	l.Infof("Allowing device %v to restart", device)
+	return restartReply{Granted: true}
+}
+
+// Acquire blocks until all peers allow us to restart, or the context is
+// canceled. Once acquired we refuse restarts of peers until we're back.
+func (c *restartCoordinator) Acquire(ctx context.Context) error {
+	c.mut.Lock()
+	if c.holding {
+		c.mut.Unlock()
+		return nil
+	}
+	c.acquiring = true
+	c.mut.Unlock()
+	defer func() {
+		c.mut.Lock()
+		c.acquiring = false
+		c.mut.Unlock()
+	}()
+
+	for {
+		err := c.tryAcquire(ctx)
+		if err == nil {
+			c.mut.Lock()
+			c.holding = true
+			c.mut.Unlock()
+			l.Infoln("Got permission to restart from all coordinating peers")
+			return nil
+		}
+		l.Infoln("Waiting to restart:", err)
+
+		wait := restartRetryInterval/2 + time.Duration(rand.Int63n(int64(restartRetryInterval)))
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// tryAcquire asks all peers for permission to restart, releasing the
+// grants again if any of them refuses.
+func (c *restartCoordinator) tryAcquire(ctx context.Context) error {
+	var granted []protocol.Connection
+	defer func() {
+		// Only set when we failed to get all grants.
+		for _, conn := range granted {
+			conn.Control(context.Background(), protocol.Control{Type: controlTypeRestartDone})
+		}
+	}()
+
+	for id, dev := range c.model.cfg.Devices() {
+		if !dev.CoordinateRestarts || id == c.model.id {
+			continue
+		}
+		conn, ok := c.model.Connection(id)
+		if !ok {
+			if err := c.checkDisconnectedPeer(id, dev); err != nil {
+				return err
+			}
+			continue
+		}
+
+		reply, err := c.request(ctx, conn)
+		c.setPeer(id, RestartPeerStatus{
+			Connected: true,
+			Answered:  c.timeNow(),
+			Granted:   err == nil && reply.Granted,
+			Reason:    reply.Reason,
+		})
+		if err != nil {
+			return fmt.Errorf("asking device %v: %w", id.Short(), err)
+		}
+		if !reply.Granted {
+			return fmt.Errorf("device %v: %w: %s", id.Short(), errRestartDenied, reply.Reason)
+		}
+		granted = append(granted, conn)
+	}
+
+	granted = nil
+	return nil
+}
+
+// checkDisconnectedPeer returns an error if a peer that isn't connected may
+// be restarting at the moment. Peers that have been gone for longer don't
+// hold us back.
+func (c *restartCoordinator) checkDisconnectedPeer(id protocol.DeviceID, dev config.DeviceConfiguration) error {
+	c.mut.Lock()
+	since, ok := c.disconnected[id]
+	c.mut.Unlock()
+	c.setPeer(id, RestartPeerStatus{})
+	if ok && !dev.Paused && c.timeNow().Sub(since) < restartLease {
+		return fmt.Errorf("device %v disconnected recently and may be restarting", id.Short())
+	}
+	return nil
+}
+
+func (c *restartCoordinator) request(ctx context.Context, conn protocol.Connection) (restartReply, error) {
+	var reply restartReply
+	resp, err := c.model.controller.exchange(ctx, conn, protocol.Control{Type: controlTypeRestart})
+	if err != nil {
+		return reply, err
+	}
+	if resp.Error != "" {
+		return reply, errors.New(resp.Error)
+	}
+	err = json.Unmarshal(resp.Payload, &reply)
+	return reply, err
+}
+
+func (c *restartCoordinator) setPeer(id protocol.DeviceID, st RestartPeerStatus) {
+	c.mut.Lock()
+	c.peers[id] = st
+	c.mut.Unlock()
+}
+
+func (c *restartCoordinator) Status() RestartCoordinationStatus {
+	connected := make(map[protocol.DeviceID]bool)
+	for id, dev := range c.model.cfg.Devices() {
+		if dev.CoordinateRestarts && id != c.model.id {
+			_, connected[id] = c.model.Connection(id)
+		}
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	st := RestartCoordinationStatus{
+		Acquiring: c.acquiring,
+		Holding:   c.holding,
+		Peers:     make(map[protocol.DeviceID]RestartPeerStatus),
+	}
+	if c.grantedTo != protocol.EmptyDeviceID && c.timeNow().Before(c.grantExpires) {
+		grantedTo, expires := c.grantedTo, c.grantExpires
+		st.GrantedTo = &grantedTo
+		st.GrantExpires = &expires
+	}
+	for id, isConnected := range connected {
+		peer := c.peers[id]
+		peer.Connected = isConnected
+		st.Peers[id] = peer
+	}
+	return st
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestRestartCoordination(t *testing.T) {
+	w, _, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	waiter, err := w.Modify(func(cfg *config.Configuration) {
+		for _, id := range []protocol.DeviceID{device1, device2} {
+			dev, _, _ := cfg.Device(id)
+			if dev.DeviceID != id {
+				dev = newDeviceConfiguration(cfg.Defaults.Device, id, id.Short().String())
+			}
+			dev.CoordinateRestarts = true
+			cfg.SetDevice(dev)
+		}
+	})
+	must(t, err)
+	waiter.Wait()
+
+	// Restart requests to device1 are answered by the model itself.
+	m, fc := setupModelWithConnectionFromWrapper(t, w)
+	defer cleanupModel(m)
+	fc.ControlCalls(func(_ context.Context, ctrl protocol.Control) {
+		go func() {
+			if err := m.Control(fc, ctrl); err != nil {
+				t.Error(err)
+			}
+		}()
+	})
+	c := m.restarts
+
+	// Only one peer is allowed to restart at a time.
+	if reply := c.decide(device2); !reply.Granted {
+		t.Fatal("restart of device2 denied:", reply.Reason)
+	}
+	if reply := c.decide(device1); reply.Granted {
+		t.Error("restart of device1 allowed while device2 is restarting")
+	}
+
+	// So we can't restart either, and time out waiting.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := c.Acquire(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected timeout, got %v", err)
+	}
+	if st := c.Status(); st.Holding || st.Peers[device1].Granted || st.Peers[device1].Reason == "" || !st.Peers[device1].Connected {
+		t.Errorf("unexpected status %+v", st)
+	}
+
+	// Once device2 is back, we get to restart, and refuse others from
+	// then on.
+	must(t, c.handle(device2Conn, protocol.Control{Type: controlTypeRestartDone}))
+	must(t, c.Acquire(context.Background()))
+	if st := c.Status(); !st.Holding || !st.Peers[device1].Granted {
+		t.Errorf("unexpected status %+v", st)
+	}
+	if reply := c.decide(device2); reply.Granted {
+		t.Error("restart of device2 allowed while restarting ourselves")
+	}
+
+	// Devices we don't coordinate with aren't held back.
+	if reply := c.decide(protocol.DeviceID{42}); !reply.Granted {
+		t.Error("restart of unrelated device denied")
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+var (
+	errRevokeSelf        = errors.New("cannot revoke the local device")
+	errWipeNotManaged    = errors.New("wiping requires the device to be managed by us")
+	errRevocationInvalid = errors.New("no devices to revoke")
+)
+
+// RevokeRequest is sent by a controller to its managed devices, with all
+// the devices it has revoked.
+type RevokeRequest struct {
+	Devices []protocol.DeviceID `json:"devices"`
+}
+
+// revoke marks the device as revoked, which unshares all folders from it
+// (dropping its index data) and stops connecting to it. The revocation is
+// passed on to the managed devices, the outcome of which is returned per
+// device. With wipe set, the revoked device is instructed to delete the data
+// of the folders shared with us once it connects.
+func (s *controlService) revoke(ctx context.Context, device protocol.DeviceID, wipe bool) (map[protocol.DeviceID]error, error) {
+	if device == s.model.id {
+		return nil, errRevokeSelf
+	}
+	dev, ok := s.model.cfg.Device(device)
+	if !ok {
+		return nil, errDeviceUnknown
+	}
+	if wipe && dev.ManagementToken == "" {
+		return nil, errWipeNotManaged
+	}
+
+	waiter, err := s.model.cfg.Modify(func(cfg *config.Configuration) {
+		dev, _, ok := cfg.Device(device)
+		if !ok {
+			return
+		}
+		dev.Revoked = true
+		dev.WipeOnConnect = wipe
+		cfg.SetDevice(dev)
+	})
+	if err != nil {
+		return nil, err
+	}
+	waiter.Wait()
+	l.Infof("Revoked device %v (%s)", device, dev.Name)
+
+	if wipe {
+		if _, ok := s.model.Connection(device); ok {
+			go s.deliverWipe(device)
+		}
+	}
+
+	res := make(map[protocol.DeviceID]error)
+	mut := sync.NewMutex()
+	wg := sync.NewWaitGroup()
+	for id, managed := range s.model.cfg.Devices() {
+		if managed.ManagementToken == "" || managed.Revoked {
+			continue
+		}
+		wg.Add(1)
+		go func(id protocol.DeviceID) {
+			defer wg.Done()
+			err := s.sendRevocations(ctx, id)
+			mut.Lock()
+			res[id] = err
+			mut.Unlock()
+		}(id)
+	}
+	wg.Wait()
+	return res, nil
+}
+
+// sendRevocations passes all our revocations on to the managed device.
+func (s *controlService) sendRevocations(ctx context.Context, device protocol.DeviceID) error {
+	var req RevokeRequest
+	for id, dev := range s.model.cfg.Devices() {
+		if dev.Revoked && id != device {
+			req.Devices = append(req.Devices, id)
+		}
+	}
+	if len(req.Devices) == 0 {
+		return nil
+	}
+	bs, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	_, err = s.request(ctx, device, controlTypeRevoke, bs)
+	return err
+}
+
+// applyRevocation revokes the devices on request of our controller. Devices
+// we don't know about are of no concern.
+func (s *controlService) applyRevocation(controller protocol.DeviceID, req RevokeRequest) error {
+	if len(req.Devices) == 0 {
+		return errRevocationInvalid
+	}
+	var revoked []protocol.DeviceID
+	waiter, err := s.model.cfg.Modify(func(cfg *config.Configuration) {
+		revoked = revoked[:0]
+		for _, id := range req.Devices {
+			if id == s.model.id || id == controller {
+				continue
+			}
+			dev, _, ok := cfg.Device(id)
+			if !ok || dev.Revoked {
+				continue
+			}
+			dev.Revoked = true
+			cfg.SetDevice(dev)
+			revoked = append(revoked, id)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	waiter.Wait()
+	for _, id := range revoked {
+		l.Infof("Revoked device %v on request of controller %v", id, controller)
+	}
+	return nil
+}
+
+// deliverWipe instructs the revoked device to wipe the folders shared with
+// us. Once it has done so there is no reason to connect to it anymore.
+func (s *controlService) deliverWipe(device protocol.DeviceID) {
+	bs, err := s.request(context.Background(), device, controlTypeWipe, nil)
+	if err != nil {
+		l.Infof("Failed to deliver wipe instruction to revoked device %v: %v", device, err)
+		return
+	}
+	var folders []string
+	if err := json.Unmarshal(bs, &folders); err != nil {
+		l.Debugln("Parsing wipe response:", err)
+	}
+	l.Infof("Revoked device %v wiped %d folders", device, len(folders))
+
+	waiter, err := s.model.cfg.Modify(func(cfg *config.Configuration) {
+		dev, _, ok := cfg.Device(device)
+		if !ok {
+			return
+		}
+		dev.WipeOnConnect = false
+		cfg.SetDevice(dev)
+	})
+	if err != nil {
+		l.Warnln("Failed to update revoked device after wipe:", err)
+		return
+	}
+	waiter.Wait()
+}
+
+// wipe removes the folders shared with the controller and deletes their
+// data, as instructed by the controller after revoking this device. Data at
+// a path that is also used by a remaining folder, or inside or containing
+// one, is kept. It returns the IDs of the folders whose data was deleted.
+func (s *controlService) wipe(controller protocol.DeviceID) []string {
+	var wiped []config.FolderConfiguration
+	for _, fcfg := range s.model.cfg.FolderList() {
+		if fcfg.SharedWith(controller) {
+			wiped = append(wiped, fcfg)
+		}
+	}
+	ids := make([]string, 0, len(wiped))
+	if len(wiped) == 0 {
+		return ids
+	}
+	l.Warnf("Wiping %d folders on instruction of controller %v", len(wiped), controller)
+
+	waiter, err := s.model.cfg.Modify(func(cfg *config.Configuration) {
+		folders := cfg.Folders[:0]
+		for _, fcfg := range cfg.Folders {
+			if !fcfg.SharedWith(controller) {
+				folders = append(folders, fcfg)
+			}
+		}
+		cfg.Folders = folders
+	})
+	if err != nil {
+		l.Warnln("Failed to remove wiped folders:", err)
+		return ids
+	}
+	waiter.Wait()
+
+	cfg := s.model.cfg.RawCopy()
+	for _, fcfg := range wiped {
+		ffs := fcfg.Filesystem(nil)
+		if pathInUse(cfg, ffs.URI()) {
+			l.Warnf("Not deleting data of wiped folder %s at %s: %v", fcfg.Description(), ffs.URI(), errPathInUse)
+			continue
+		}
+		if err := ffs.RemoveAll("."); err != nil {
+			l.Warnf("Failed to delete data of wiped folder %v: %v", fcfg.Description(), err)
+			continue
+		}
+		ids = append(ids, fcfg.ID)
+	}
+	return ids
+}
+
+// deviceConnected delivers a pending wipe instruction to a revoked device,
+// or brings a managed device up to date with our revocations.
+func (s *controlService) deviceConnected(dev config.DeviceConfiguration) {
+	switch {
+	case dev.Revoked && dev.WipeOnConnect:
+		go s.deliverWipe(dev.DeviceID)
+	case dev.ManagementToken != "":
+		go func() {
+			if err := s.sendRevocations(context.Background(), dev.DeviceID); err != nil {
+				l.Debugf("Failed to send revocations to managed device %v: %v", dev.DeviceID, err)
+			}
+		}()
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kballard/go-shellquote"
+)
+
+// quiesce runs the pre scan command of the folder, which lets applications
+// bring their data into a consistent state before it's hashed. The returned
+// function runs the post scan command and must be called once scanning is
+// done, whether it succeeded or not.
+func (f *folder) quiesce() (func(), error) {
+	if f.PreScanCommand != "" {
+		if err := f.runScanHook("pre scan", f.PreScanCommand); err != nil {
+			// Scanning data in an unknown state is what the hook is
+			// supposed to prevent, so we don't.
+			return nil, err
+		}
+	}
+	return func() {
+		if f.PostScanCommand == "" {
+			return
+		}
+		if err := f.runScanHook("post scan", f.PostScanCommand); err != nil {
+			l.Warnf("Folder %s: %v", f.Description(), err)
+		}
+	}, nil
+}
+
+func (f *folder) runScanHook(hook, command string) error {
+	words, err := shellquote.Split(command)
+	if err != nil {
+		return fmt.Errorf("%s command is invalid: %w", hook, err)
+	}
+	if len(words) == 0 {
+		return nil
+	}
+	replacements := map[string]string{
+		"%FOLDER_ID%":         f.ID,
+		"%FOLDER_FILESYSTEM%": f.mtimefs.Type().String(),
+		"%FOLDER_PATH%":       f.mtimefs.URI(),
+	}
+	for i, word := range words {
+		for key, val := range replacements {
+			word = strings.ReplaceAll(word, key, val)
+		}
+		words[i] = word
+	}
+
+	ctx := f.ctx
+	if f.ScanHookTimeoutS > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(f.ScanHookTimeoutS)*time.Second)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, words[0], words[1:]...)
+	// Don't wait for children holding on to the output once it's killed.
+	cmd.WaitDelay = time.Second
+	// filter STGUIAUTH and STGUIAPIKEY from environment variables
+	for _, x := range os.Environ() {
+		if !strings.HasPrefix(x, "STGUIAUTH=") && !strings.HasPrefix(x, "STGUIAPIKEY=") {
+			cmd.Env = append(cmd.Env, x)
+		}
+	}
+	out, err := cmd.CombinedOutput()
+	l.Debugf("%v %s command output: %s", f, hook, out)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%s command timed out after %ds", hook, f.ScanHookTimeoutS)
+	}
+	if err != nil {
+		if len(out) > 0 {
+			return fmt.Errorf("%s command failed: %w: %s", hook, err, strings.TrimSpace(string(out)))
+		}
+		return fmt.Errorf("%s command failed: %w", hook, err)
+	}
+	return nil
+}
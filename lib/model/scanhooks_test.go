@@ -0,0 +1,50 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestScanHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell commands")
+	}
+
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer wcfgCancel()
+	defer cleanupModel(m)
+
+	td := t.TempDir()
+	log := filepath.Join(td, "log")
+	f.PreScanCommand = `sh -c "echo pre %FOLDER_ID% >> ` + log + `"`
+	f.PostScanCommand = `sh -c "echo post >> ` + log + `"`
+	must(t, f.scanSubdirs(nil))
+
+	bs, err := os.ReadFile(log)
+	must(t, err)
+	if got, exp := string(bs), "pre "+f.ID+"\npost\n"; got != exp {
+		t.Errorf("expected hooks to log %q, got %q", exp, got)
+	}
+
+	// A failing pre scan command prevents scanning, but still resumes.
+	f.PreScanCommand = `sh -c "echo failed; exit 1"`
+	if err := f.scanSubdirs(nil); err == nil || !strings.Contains(err.Error(), "failed") {
+		t.Errorf("expected scan to fail, got %v", err)
+	}
+
+	// So does one that doesn't finish in time.
+	f.PreScanCommand = "sleep 10"
+	f.ScanHookTimeoutS = 1
+	if err := f.scanSubdirs(nil); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected scan to time out, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+const (
+	// The number of scan summaries kept per folder.
+	maxScanSummaries = 10
+	// The number of paths of each kind of change kept in a summary.
+	maxScanSummarySamples = 10
+)
+
+// ScanSummary describes what a scan of a folder found.
+type ScanSummary struct {
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	// The scanned subdirectories, none for the whole folder.
+	Subdirs []string    `json:"subdirs,omitempty"`
+	Added   ScanChanges `json:"added"`
+	Changed ScanChanges `json:"changed"`
+	Deleted ScanChanges `json:"deleted"`
+	// The total size of the added and changed files.
+	Bytes int64  `json:"bytes"`
+	Error string `json:"error,omitempty"`
+}
+
+// ScanChanges counts the items of a kind of change, with a sample of their
+// paths.
+type ScanChanges struct {
+	Count   int      `json:"count"`
+	Samples []string `json:"samples"`
+}
+
+func (c *ScanChanges) add(name string) {
+	c.Count++
+	if len(c.Samples) < maxScanSummarySamples {
+		c.Samples = append(c.Samples, name)
+	}
+}
+
+func newScanSummary(subDirs []string, started time.Time) *ScanSummary {
+	return &ScanSummary{
+		Started: started,
+		Subdirs: append([]string(nil), subDirs...),
+		Added:   ScanChanges{Samples: []string{}},
+		Changed: ScanChanges{Samples: []string{}},
+		Deleted: ScanChanges{Samples: []string{}},
+	}
+}
+
+// record adds an item the scan updated, given what we had before. Items
+// becoming ignored and metadata only changes to deleted items aren't
+// changes of what is on disk, so they aren't counted.
+func (s *ScanSummary) record(fi protocol.FileInfo, prev protocol.FileInfo, hadPrev bool) {
+	existed := hadPrev && !prev.IsDeleted() && !prev.IsIgnored()
+	switch {
+	case fi.IsIgnored():
+	case fi.IsDeleted():
+		if existed {
+			s.Deleted.add(fi.Name)
+		}
+	case existed:
+		s.Changed.add(fi.Name)
+		s.addBytes(fi)
+	default:
+		s.Added.add(fi.Name)
+		s.addBytes(fi)
+	}
+}
+
+func (s *ScanSummary) addBytes(fi protocol.FileInfo) {
+	if !fi.IsDirectory() && !fi.IsSymlink() {
+		s.Bytes += fi.Size
+	}
+}
+
+// scanHistory keeps the most recent scan summaries of each folder.
+type scanHistory struct {
+	mut       sync.Mutex
+	summaries map[string][]ScanSummary
+}
+
+func newScanHistory() *scanHistory {
+	return &scanHistory{
+		mut:       sync.NewMutex(),
+		summaries: make(map[string][]ScanSummary),
+	}
+}
+
+func (h *scanHistory) add(folder string, summary ScanSummary) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+	summaries := append(h.summaries[folder], summary)
+	if len(summaries) > maxScanSummaries {
+		summaries = summaries[len(summaries)-maxScanSummaries:]
+	}
+	h.summaries[folder] = summaries
+}
+
+// get returns the summaries of the folder, most recent first.
+func (h *scanHistory) get(folder string) []ScanSummary {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+	summaries := h.summaries[folder]
+	res := make([]ScanSummary, len(summaries))
+	for i, summary := range summaries {
+		res[len(summaries)-1-i] = summary
+	}
+	return res
+}
+
+func (h *scanHistory) forget(folder string) {
+	h.mut.Lock()
+	delete(h.summaries, folder)
+	h.mut.Unlock()
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestScanSummaries(t *testing.T) {
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer wcfgCancel()
+	defer cleanupModel(m)
+	ffs := f.Filesystem(nil)
+	// The folder has been scanned on startup.
+	summaries, err := m.ScanSummaries(f.ID)
+	must(t, err)
+	initial := len(summaries)
+
+	for i := 0; i < maxScanSummarySamples+2; i++ {
+		writeFile(t, ffs, fmt.Sprintf("file%d", i), []byte("data"))
+	}
+	must(t, f.scanSubdirs(nil))
+
+	writeFile(t, ffs, "file0", []byte("changed"))
+	must(t, ffs.Remove("file1"))
+	must(t, f.scanSubdirs(nil))
+
+	summaries, err = m.ScanSummaries(f.ID)
+	must(t, err)
+	if len(summaries) != initial+2 {
+		t.Fatalf("expected %d summaries, got %d", initial+2, len(summaries))
+	}
+
+	// Most recent first.
+	last := summaries[0]
+	if last.Added.Count != 0 || last.Changed.Count != 1 || last.Deleted.Count != 1 || last.Bytes != 7 {
+		t.Errorf("unexpected summary of second scan %+v", last)
+	}
+	if fmt.Sprint(last.Changed.Samples, last.Deleted.Samples) != "[file0] [file1]" {
+		t.Errorf("unexpected samples %v and %v", last.Changed.Samples, last.Deleted.Samples)
+	}
+
+	first := summaries[1]
+	if first.Added.Count != maxScanSummarySamples+2 || len(first.Added.Samples) != maxScanSummarySamples || first.Bytes != 4*(maxScanSummarySamples+2) {
+		t.Errorf("unexpected summary of first scan %+v", first)
+	}
+
+	// Only the last few summaries are kept.
+	for i := 0; i < maxScanSummaries; i++ {
+		must(t, f.scanSubdirs(nil))
+	}
+	summaries, err = m.ScanSummaries(f.ID)
+	must(t, err)
+	if len(summaries) != maxScanSummaries || summaries[0].Added.Count+summaries[0].Changed.Count+summaries[0].Deleted.Count != 0 {
+		t.Errorf("unexpected summaries %+v", summaries)
+	}
+
+	if _, err := m.ScanSummaries("missing"); err != ErrFolderMissing {
+		t.Errorf("expected %v, got %v", ErrFolderMissing, err)
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/jobs"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/semaphore"
+	"github.com/syncthing/syncthing/lib/sha256"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// Jobs reading through all the data on disk, like scrubbing, don't run at
+// the same time as each other.
+const jobClassDisk = "disk"
+
+const (
+	ScrubStateIdle      = "idle"
+	ScrubStateScrubbing = "scrubbing"
+)
+
+// ScrubStatus describes the progress and outcome of the block verification
+// ("scrubbing") of a folder.
+type ScrubStatus struct {
+	State         string    `json:"state"`
+	LastStarted   time.Time `json:"lastStarted"`
+	LastFinished  time.Time `json:"lastFinished"`
+	NextScheduled time.Time `json:"nextScheduled"`
+	FilesChecked  int       `json:"filesChecked"`
+	BytesChecked  int64     `json:"bytesChecked"`
+	BytesTotal    int64     `json:"bytesTotal"`
+	CorruptFiles  []string  `json:"corruptFiles"`
+	RepairedFiles []string  `json:"repairedFiles"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// repairer is implemented by the folder runners, which are the only ones
+// allowed to touch the local index of a folder.
+type repairer interface {
+	RepairCorrupted(names []string) ([]string, error)
+}
+
+// The scrubber periodically re-reads the local files of a folder and
+// verifies their contents against the block hashes recorded in the
+// database. Files that don't match are reported, and handed to the folder
+// for repair by pulling them again from other devices.
+type scrubber struct {
+	folderID      string
+	interval      time.Duration
+	modTimeWindow time.Duration
+	fset          *db.FileSet
+	mtimefs       fs.Filesystem
+	repairer      repairer
+	ioLimiter     *semaphore.Semaphore
+	scheduler     jobs.Scheduler
+	evLogger      events.Logger
+
+	mut    sync.Mutex
+	status ScrubStatus
+}
+
+func newScrubber(cfg config.FolderConfiguration, fset *db.FileSet, mtimefs fs.Filesystem, repairer repairer, ioLimiter *semaphore.Semaphore, scheduler jobs.Scheduler, evLogger events.Logger) *scrubber {
+	return &scrubber{
+		folderID:      cfg.ID,
+		interval:      time.Duration(cfg.ScrubIntervalS) * time.Second,
+		modTimeWindow: cfg.ModTimeWindow(),
+		fset:          fset,
+		mtimefs:       mtimefs,
+		repairer:      repairer,
+		ioLimiter:     ioLimiter,
+		scheduler:     scheduler,
+		evLogger:      evLogger,
+		mut:           sync.NewMutex(),
+		status:        ScrubStatus{State: ScrubStateIdle},
+	}
+}
+
+func (s *scrubber) Serve(ctx context.Context) error {
+	l.Debugln(s, "starting")
+	defer l.Debugln(s, "exiting")
+
+	// A scrub is never run right away on startup, as there is enough
+	// going on at that point already. With no interval configured
+	// scrubbing only happens when explicitly requested.
+	first := time.Duration(-1)
+	if s.interval > 0 {
+		first = s.interval
+		s.setNextScheduled(time.Now().Add(s.interval))
+	}
+	s.scheduler.Add(jobs.Job{
+		Name:     s.jobName(),
+		Class:    jobClassDisk,
+		First:    first,
+		Interval: func() time.Duration { return s.interval },
+		Run:      s.runScrub,
+	})
+	defer s.scheduler.Remove(s.jobName())
+
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (s *scrubber) runScrub(ctx context.Context) error {
+	err := s.scrub(ctx)
+	if s.interval > 0 {
+		s.setNextScheduled(time.Now().Add(s.interval))
+	}
+	return err
+}
+
+// Trigger schedules a scrub to start as soon as possible.
+func (s *scrubber) Trigger() {
+	s.scheduler.Trigger(s.jobName())
+}
+
+// Status returns a copy of the current scrub status.
+func (s *scrubber) Status() ScrubStatus {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	st := s.status
+	st.CorruptFiles = append([]string(nil), s.status.CorruptFiles...)
+	st.RepairedFiles = append([]string(nil), s.status.RepairedFiles...)
+	return st
+}
+
+func (s *scrubber) String() string {
+	return fmt.Sprintf("scrubber/%s@%p", s.folderID, s)
+}
+
+func (s *scrubber) jobName() string {
+	return "scrub/" + s.folderID
+}
+
+func (s *scrubber) setNextScheduled(t time.Time) {
+	s.mut.Lock()
+	s.status.NextScheduled = t
+	s.mut.Unlock()
+}
+
+func (s *scrubber) scrub(ctx context.Context) error {
+	snap, err := s.fset.Snapshot()
+	if err != nil {
+		return err
+	}
+	var names []string
+	snap.WithHaveTruncated(protocol.LocalDeviceID, func(fi protocol.FileIntf) bool {
+		if fi.IsDeleted() || fi.IsInvalid() || fi.FileType() != protocol.FileInfoTypeFile {
+			return true
+		}
+		names = append(names, fi.FileName())
+		return true
+	})
+	total := snap.LocalSize().Bytes
+	snap.Release()
+
+	s.mut.Lock()
+	s.status = ScrubStatus{
+		State:       ScrubStateScrubbing,
+		LastStarted: time.Now(),
+		BytesTotal:  total,
+	}
+	s.mut.Unlock()
+
+	l.Debugf("%v: scrubbing %d files", s, len(names))
+
+	var corrupt []string
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			s.finish(corrupt, nil, err)
+			return err
+		}
+		ok, checked, err := s.verifyFile(ctx, name)
+		if err != nil {
+			// Files that disappeared or can't be read are the business
+			// of the scanner, not ours.
+			l.Debugf("%v: verifying %s: %v", s, name, err)
+		} else if !ok {
+			l.Warnf("Folder %s: file %s does not match its recorded block hashes", s.folderID, name)
+			corrupt = append(corrupt, name)
+			s.evLogger.Log(events.CorruptionDetected, map[string]interface{}{
+				"folder": s.folderID,
+				"file":   name,
+			})
+		}
+		s.mut.Lock()
+		s.status.FilesChecked++
+		s.status.BytesChecked += checked
+		s.status.CorruptFiles = corrupt
+		s.mut.Unlock()
+	}
+
+	var repaired []string
+	if len(corrupt) > 0 {
+		repaired, err = s.repairer.RepairCorrupted(corrupt)
+		if err != nil {
+			l.Infof("Folder %s: failed to repair corrupted files: %v", s.folderID, err)
+		}
+	}
+
+	s.finish(corrupt, repaired, err)
+	return nil
+}
+
+func (s *scrubber) finish(corrupt, repaired []string, err error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.status.State = ScrubStateIdle
+	s.status.LastFinished = time.Now()
+	s.status.CorruptFiles = corrupt
+	s.status.RepairedFiles = repaired
+	if err != nil {
+		s.status.Error = err.Error()
+	}
+}
+
+// verifyFile returns whether the named file matches the block hashes in
+// the database and the number of bytes read. Files that have changed on
+// disk since they were last scanned are considered fine, as a later scan
+// will take care of them.
+func (s *scrubber) verifyFile(ctx context.Context, name string) (bool, int64, error) {
+	if err := s.ioLimiter.TakeWithContext(ctx, 1); err != nil {
+		return true, 0, err
+	}
+	defer s.ioLimiter.Give(1)
+
+	snap, err := s.fset.Snapshot()
+	if err != nil {
+		return true, 0, err
+	}
+	fi, ok := snap.Get(protocol.LocalDeviceID, name)
+	snap.Release()
+	if !ok || fi.IsDeleted() || fi.IsInvalid() || fi.Type != protocol.FileInfoTypeFile {
+		return true, 0, nil
+	}
+
+	if changed, err := s.changedOnDisk(fi); err != nil || changed {
+		return true, 0, err
+	}
+
+	fd, err := s.mtimefs.Open(name)
+	if err != nil {
+		return true, 0, err
+	}
+	defer fd.Close()
+
+	var read int64
+	buf := make([]byte, fi.BlockSize())
+	for _, block := range fi.Blocks {
+		if err := ctx.Err(); err != nil {
+			return true, read, err
+		}
+		bs := buf[:block.Size]
+		n, err := fd.ReadAt(bs, block.Offset)
+		read += int64(n)
+		if err != nil {
+			return true, read, err
+		}
+		if hash := sha256.Sum256(bs); !bytes.Equal(hash[:], block.Hash) {
+			// Make sure the file wasn't simply modified while we were
+			// reading it.
+			if changed, err := s.changedOnDisk(fi); err != nil || changed {
+				return true, read, err
+			}
+			return false, read, nil
+		}
+	}
+
+	return true, read, nil
+}
+
+func (s *scrubber) changedOnDisk(fi protocol.FileInfo) (bool, error) {
+	info, err := s.mtimefs.Lstat(fi.Name)
+	if err != nil {
+		return false, err
+	}
+	return info.Size() != fi.Size || !protocol.ModTimeEqual(info.ModTime(), fi.ModTime(), s.modTimeWindow), nil
+}
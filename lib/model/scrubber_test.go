@@ -0,0 +1,103 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/jobs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestScrubDetectsAndRepairsCorruption(t *testing.T) {
+	m, fc, fcfg, wcfgCancel := setupModelWithConnection(t)
+	defer wcfgCancel()
+	defer cleanupModel(m)
+	ffs := fcfg.Filesystem(nil)
+
+	name := "file"
+	writeFile(t, ffs, name, []byte("the original contents"))
+	must(t, m.ScanFolder(fcfg.ID))
+
+	fi, ok := m.testCurrentFolderFile(fcfg.ID, name)
+	if !ok {
+		t.Fatal("file missing after scan")
+	}
+
+	m.fmut.RLock()
+	s, ok := m.folderScrubbers.Get(fcfg.ID)
+	m.fmut.RUnlock()
+	if !ok {
+		t.Fatal("no scrubber for folder")
+	}
+
+	// An intact folder is fine.
+	must(t, s.scrub(context.Background()))
+	if st := s.Status(); len(st.CorruptFiles) != 0 || st.FilesChecked != 1 || st.BytesChecked != fi.Size {
+		t.Fatalf("unexpected status after clean scrub: %+v", st)
+	}
+
+	// Overwrite the contents without changing size or modification time,
+	// which the scanner would not notice.
+	writeFile(t, ffs, name, []byte("the modified contents"))
+	must(t, ffs.Chtimes(name, fi.ModTime(), fi.ModTime()))
+
+	must(t, s.scrub(context.Background()))
+	st := s.Status()
+	if len(st.CorruptFiles) != 1 || st.CorruptFiles[0] != name {
+		t.Fatalf("expected %v to be corrupt, got %+v", name, st)
+	}
+	if len(st.RepairedFiles) != 0 {
+		t.Fatalf("nothing should be repaired without another source, got %v", st.RepairedFiles)
+	}
+
+	// Once the other device announces the same version, the file gets
+	// repaired.
+	must(t, m.Index(fc, fcfg.ID, []protocol.FileInfo{fi}))
+	must(t, s.scrub(context.Background()))
+	st = s.Status()
+	if len(st.RepairedFiles) != 1 || st.RepairedFiles[0] != name {
+		t.Fatalf("expected %v to be repaired, got %+v", name, st)
+	}
+}
+
+func TestMaintenanceJobsScheduled(t *testing.T) {
+	m, _, fcfg, wcfgCancel := setupModelWithConnection(t)
+	defer wcfgCancel()
+	defer cleanupModel(m)
+
+	expected := map[string]string{
+		"scrub/" + fcfg.ID:    jobClassDisk,
+		blockPoolGCJobName:    jobClassDisk,
+		blockPoolScrubJobName: jobClassDisk,
+		expiryJobName:         "",
+		folderCleanupJobName:  "",
+	}
+	statuses := make(map[string]jobs.Status)
+	for i := 0; len(statuses) < len(expected); i++ {
+		if i == 100 {
+			t.Fatalf("expected jobs %v, got %v", expected, statuses)
+		}
+		time.Sleep(10 * time.Millisecond)
+		for _, st := range m.scheduler.Status() {
+			if _, ok := expected[st.Name]; ok {
+				statuses[st.Name] = st
+			}
+		}
+	}
+	for name, class := range expected {
+		if statuses[name].Class != class {
+			t.Errorf("expected job %s in class %q, got %q", name, class, statuses[name].Class)
+		}
+	}
+	// Garbage collection of the block pool only runs when requested.
+	if st := statuses[blockPoolGCJobName]; !st.NextRun.IsZero() {
+		t.Errorf("expected %s to not be scheduled, got %+v", blockPoolGCJobName, st)
+	}
+}
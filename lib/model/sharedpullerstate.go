@@ -12,6 +12,7 @@ import (
 	"io"
 	"time"
 
+	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/fs"
 	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/protocol"
@@ -31,7 +32,7 @@ type sharedPullerState struct {
 	ignorePerms bool
 	hasCurFile  bool              // Whether curFile is set
 	curFile     protocol.FileInfo // The file as it exists now in our database
-	sparse      bool
+	prealloc    config.Preallocation
 	created     time.Time
 	fsync       bool
 
@@ -46,12 +47,13 @@ type sharedPullerState struct {
 	pullNeeded        int             // Number of block pulls still pending
 	updated           time.Time       // Time when any of the counters above were last updated
 	closed            bool            // True if the file has been finalClosed.
+	preallocErr       error           // Why space for the temporary file could not be allocated as configured
 	available         []int           // Indexes of the blocks that are available in the temporary file
 	availableUpdated  time.Time       // Time when list of available blocks was last updated
 	mut               sync.RWMutex    // Protects the above
 }
 
-func newSharedPullerState(file protocol.FileInfo, fs fs.Filesystem, folderID, tempName string, blocks []protocol.BlockInfo, reused []int, ignorePerms, hasCurFile bool, curFile protocol.FileInfo, sparse bool, fsync bool) *sharedPullerState {
+func newSharedPullerState(file protocol.FileInfo, fs fs.Filesystem, folderID, tempName string, blocks []protocol.BlockInfo, reused []int, ignorePerms, hasCurFile bool, curFile protocol.FileInfo, prealloc config.Preallocation, fsync bool) *sharedPullerState {
 	return &sharedPullerState{
 		file:             file,
 		fs:               fs,
@@ -68,7 +70,7 @@ func newSharedPullerState(file protocol.FileInfo, fs fs.Filesystem, folderID, te
 		hasCurFile:       hasCurFile,
 		curFile:          curFile,
 		mut:              sync.NewRWMutex(),
-		sparse:           sparse,
+		prealloc:         prealloc,
 		fsync:            fsync,
 		created:          time.Now(),
 	}
@@ -189,15 +191,26 @@ func (s *sharedPullerState) tempFileInWritableDir(_ string) error {
 
 	// Don't truncate symlink files, as that will mean that the path will
 	// contain a bunch of nulls.
-	if s.sparse && !s.file.IsSymlink() {
+	if s.prealloc != config.PreallocationNone && !s.file.IsSymlink() {
 		size := s.file.Size
 		// Trailer added to encrypted files
 		if len(s.file.Encrypted) > 0 {
 			size += encryptionTrailerSize(s.file)
 		}
+		if s.prealloc == config.PreallocationFull {
+			// Allocating the space up front isn't possible on all
+			// filesystems, in which case we make do with what truncating
+			// gives us below.
+			if err := fs.Preallocate(fd, size); err != nil {
+				s.preallocErr = fmt.Errorf("allocating space: %w", err)
+			}
+		}
 		// Truncate sets the size of the file. This creates a sparse file or a
 		// space reservation, depending on the underlying filesystem.
 		if err := fd.Truncate(size); err != nil {
+			if s.preallocErr == nil {
+				s.preallocErr = fmt.Errorf("setting size: %w", err)
+			}
 			// The truncate call failed. That can happen in some cases when
 			// space reservation isn't possible or over some network
 			// filesystems... This generally doesn't matter.
@@ -226,6 +239,15 @@ func (s *sharedPullerState) tempFileInWritableDir(_ string) error {
 	return nil
 }
 
+// preallocationFailed returns the error that kept space for the temporary
+// file from being allocated as configured, if any. Pulling the file
+// continues regardless.
+func (s *sharedPullerState) preallocationFailed() error {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.preallocErr
+}
+
 // fail sets the error on the puller state compose of error, and marks the
 // sharedPullerState as failed. Is a no-op when called on an already failed state.
 func (s *sharedPullerState) fail(err error) {
@@ -9,7 +9,9 @@ package model
 import (
 	"testing"
 
+	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/rand"
 	"github.com/syncthing/syncthing/lib/sync"
 )
@@ -36,3 +38,40 @@ func TestReadOnlyDir(t *testing.T) {
 	s.fail(nil)
 	s.finalClose()
 }
+
+func TestTempFilePreallocation(t *testing.T) {
+	cases := []struct {
+		prealloc config.Preallocation
+		size     int64
+	}{
+		{config.PreallocationSparse, 1 << 20},
+		{config.PreallocationFull, 1 << 20},
+		{config.PreallocationNone, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.prealloc.String(), func(t *testing.T) {
+			// The fake filesystem doesn't support allocating space, which
+			// is reported but otherwise the same as a sparse file.
+			ffs := fs.NewFilesystem(fs.FilesystemTypeFake, rand.String(32))
+			file := protocol.FileInfo{Name: "file", Size: 1 << 20}
+			s := newSharedPullerState(file, ffs, "folder", ".temp_name", nil, nil, false, false, protocol.FileInfo{}, tc.prealloc, false)
+
+			if _, err := s.tempFile(); err != nil {
+				t.Fatal(err)
+			}
+			info, err := ffs.Lstat(".temp_name")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Size() != tc.size {
+				t.Errorf("expected size %d, got %d", tc.size, info.Size())
+			}
+			if err := s.preallocationFailed(); (err != nil) != (tc.prealloc == config.PreallocationFull) {
+				t.Errorf("unexpected preallocation error %v", err)
+			}
+
+			s.fail(nil)
+			s.finalClose()
+		})
+	}
+}
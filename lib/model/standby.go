@@ -0,0 +1,358 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+const (
+	standbyActiveKey      = "standbyActive"
+	standbyActiveSinceKey = "standbyActiveSince"
+)
+
+var (
+	// standbyCheckInterval is how often a standby checks whether its
+	// primary is down.
+	standbyCheckInterval = 30 * time.Second
+
+	errNotStandby       = errors.New("not configured as a standby")
+	errStandbyNotActive = errors.New("standby is not active")
+)
+
+// standbyState is the payload of standby messages, announcing whether the
+// sender is an active standby.
+type standbyState struct {
+	Active bool `json:"active"`
+}
+
+// standbyProbe is the payload of a standby's request whether a device is
+// connected to its primary.
+type standbyProbe struct {
+	Primary protocol.DeviceID `json:"primary"`
+}
+
+// standbyProbeReply is the payload of the response to a standby probe.
+type standbyProbeReply struct {
+	Connected bool `json:"connected"`
+}
+
+// StandbyStatus describes our standby role and the active standbys we
+// prefer as sources.
+type StandbyStatus struct {
+	// The primary we are the standby of, if any.
+	Primary          *protocol.DeviceID `json:"primary,omitempty"`
+	PrimaryConnected bool               `json:"primaryConnected"`
+	PrimaryLastSeen  *time.Time         `json:"primaryLastSeen,omitempty"`
+	TakeoverS        int                `json:"takeoverS"`
+	// Active is true once we took over from the primary, until failing
+	// back.
+	Active      bool       `json:"active"`
+	ActiveSince *time.Time `json:"activeSince,omitempty"`
+	// Connected devices that announced they are an active standby.
+	ActiveSources []protocol.DeviceID `json:"activeSources"`
+}
+
+// The standbyService implements warm standby for a primary device: A
+// standby shares the primary's folders and, when the primary has been down
+// for the takeover time and none of the other connected devices can reach
+// it either, takes over the active role. Devices prefer an active standby
+// as the source of blocks, until it's told to fail back. The active role is
+// persisted so that it survives restarts.
+type standbyService struct {
+	model *model
+	kv    *db.NamespacedKV
+
+	mut             sync.Mutex
+	active          bool
+	activeSince     time.Time
+	primaryLastSeen time.Time
+	activeSources   map[protocol.DeviceID]bool
+	timeNow         func() time.Time
+}
+
+func newStandbyService(m *model, ldb *db.Lowlevel) *standbyService {
+	s := &standbyService{
+		model:         m,
+		kv:            db.NewMiscDataNamespace(ldb),
+		mut:           sync.NewMutex(),
+		activeSources: make(map[protocol.DeviceID]bool),
+		timeNow:       time.Now,
+	}
+	if active, _, err := s.kv.Bool(standbyActiveKey); err != nil {
+		l.Warnln("Loading standby state:", err)
+	} else if active {
+		s.active = true
+		s.activeSince, _, _ = s.kv.Time(standbyActiveSinceKey)
+	}
+	// Give the primary the takeover time to show up after we start.
+	s.primaryLastSeen = s.timeNow()
+	return s
+}
+
+func (s *standbyService) Serve(ctx context.Context) error {
+	sub := s.model.evLogger.Subscribe(events.DeviceConnected | events.DeviceDisconnected)
+	defer sub.Unsubscribe()
+
+	ticker := time.NewTicker(standbyCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case ev, ok := <-sub.C():
+			if !ok {
+				return nil
+			}
+			data, _ := ev.Data.(map[string]string)
+			device, err := protocol.DeviceIDFromString(data["id"])
+			if err != nil {
+				continue
+			}
+			if device == s.model.cfg.Options().StandbyPrimaryID {
+				s.mut.Lock()
+				s.primaryLastSeen = s.timeNow()
+				s.mut.Unlock()
+			}
+			if ev.Type == events.DeviceConnected {
+				s.announce(ctx, device)
+			}
+		case <-ticker.C:
+			s.check(ctx)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *standbyService) String() string {
+	return fmt.Sprintf("standbyService@%p", s)
+}
+
+// check takes over the active role when the primary has been down for the
+// takeover time and none of the connected devices sees it either.
+func (s *standbyService) check(ctx context.Context) {
+	opts := s.model.cfg.Options()
+	primary := opts.StandbyPrimaryID
+	if primary == protocol.EmptyDeviceID || primary == s.model.id {
+		return
+	}
+	if _, ok := s.model.Connection(primary); ok {
+		s.mut.Lock()
+		s.primaryLastSeen = s.timeNow()
+		s.mut.Unlock()
+		return
+	}
+
+	s.mut.Lock()
+	active := s.active
+	down := s.timeNow().Sub(s.primaryLastSeen)
+	s.mut.Unlock()
+	if active || down < time.Duration(opts.StandbyTakeoverS)*time.Second {
+		return
+	}
+
+	// Only take over when the primary is down, not when it's just us that
+	// can't reach it.
+	for _, conn := range s.connections() {
+		reply, err := s.probe(ctx, conn, primary)
+		if err != nil {
+			l.Debugf("Standby probe of %v: %v", conn.DeviceID(), err)
+			continue
+		}
+		if reply.Connected {
+			l.Debugf("Not taking over from %v, device %v is connected to it", primary, conn.DeviceID())
+			return
+		}
+	}
+
+	l.Warnf("Primary device %v has been down for %v, taking over as active standby", primary, down.Truncate(time.Second))
+	s.setActive(ctx, true)
+}
+
+// probe asks a device whether it is connected to the primary.
+func (s *standbyService) probe(ctx context.Context, conn protocol.Connection, primary protocol.DeviceID) (standbyProbeReply, error) {
+	var reply standbyProbeReply
+	payload, err := json.Marshal(standbyProbe{Primary: primary})
+	if err != nil {
+		return reply, err
+	}
+	resp, err := s.model.controller.exchange(ctx, conn, protocol.Control{Type: controlTypeStandbyProbe, Payload: payload})
+	if err != nil {
+		return reply, err
+	}
+	if resp.Error != "" {
+		return reply, errors.New(resp.Error)
+	}
+	err = json.Unmarshal(resp.Payload, &reply)
+	return reply, err
+}
+
+// FailBack gives up the active role, letting the primary take over again.
+func (s *standbyService) FailBack() error {
+	if s.model.cfg.Options().StandbyPrimaryID == protocol.EmptyDeviceID {
+		return errNotStandby
+	}
+	s.mut.Lock()
+	active := s.active
+	s.mut.Unlock()
+	if !active {
+		return errStandbyNotActive
+	}
+	l.Infoln("Failing back to the primary device")
+	s.setActive(context.Background(), false)
+	return nil
+}
+
+func (s *standbyService) setActive(ctx context.Context, active bool) {
+	s.mut.Lock()
+	s.active = active
+	s.activeSince = time.Time{}
+	if active {
+		s.activeSince = s.timeNow()
+	} else {
+		// Start counting the takeover time anew.
+		s.primaryLastSeen = s.timeNow()
+	}
+	since := s.activeSince
+	s.mut.Unlock()
+
+	if err := s.kv.PutBool(standbyActiveKey, active); err != nil {
+		l.Warnln("Saving standby state:", err)
+	}
+	if err := s.kv.PutTime(standbyActiveSinceKey, since); err != nil {
+		l.Warnln("Saving standby state:", err)
+	}
+
+	for _, conn := range s.connections() {
+		s.announce(ctx, conn.DeviceID())
+	}
+}
+
+// connections returns the connections to the other configured devices.
+func (s *standbyService) connections() []protocol.Connection {
+	var conns []protocol.Connection
+	for id := range s.model.cfg.Devices() {
+		if id == s.model.id {
+			continue
+		}
+		if conn, ok := s.model.Connection(id); ok {
+			conns = append(conns, conn)
+		}
+	}
+	return conns
+}
+
+// announce tells a device whether we're an active standby. Devices that
+// aren't a standby have nothing to say.
+func (s *standbyService) announce(ctx context.Context, device protocol.DeviceID) {
+	s.mut.Lock()
+	active := s.active
+	s.mut.Unlock()
+	if !active && s.model.cfg.Options().StandbyPrimaryID == protocol.EmptyDeviceID {
+		return
+	}
+	payload, _ := json.Marshal(standbyState{Active: active})
+	if conn, ok := s.model.Connection(device); ok {
+		go conn.Control(ctx, protocol.Control{Type: controlTypeStandby, Payload: payload})
+	}
+}
+
+// handle is called for incoming standby announcements and probes.
+func (s *standbyService) handle(conn protocol.Connection, ctrl protocol.Control) error {
+	device := conn.DeviceID()
+
+	if ctrl.Type == controlTypeStandby {
+		var state standbyState
+		if err := json.Unmarshal(ctrl.Payload, &state); err != nil {
+			return fmt.Errorf("parsing standby state: %w", err)
+		}
+		s.mut.Lock()
+		if state.Active != s.activeSources[device] {
+			if state.Active {
+				l.Infof("Device %v is an active standby, preferring it as a source", device)
+			} else {
+				l.Infof("Device %v is no longer an active standby", device)
+			}
+		}
+		if state.Active {
+			s.activeSources[device] = true
+		} else {
+			delete(s.activeSources, device)
+		}
+		s.mut.Unlock()
+		return nil
+	}
+
+	var probe standbyProbe
+	if err := json.Unmarshal(ctrl.Payload, &probe); err != nil {
+		return fmt.Errorf("parsing standby probe: %w", err)
+	}
+	var reply standbyProbeReply
+	_, reply.Connected = s.model.Connection(probe.Primary)
+	payload, err := json.Marshal(reply)
+	if err != nil {
+		return err
+	}
+	go conn.Control(context.Background(), protocol.Control{
+		ResponseTo: ctrl.ID,
+		Type:       controlTypeStandbyProbe,
+		Payload:    payload,
+	})
+	return nil
+}
+
+// isActiveSource returns whether the device announced it is an active
+// standby.
+func (s *standbyService) isActiveSource(device protocol.DeviceID) bool {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.activeSources[device]
+}
+
+func (s *standbyService) Status() StandbyStatus {
+	opts := s.model.cfg.Options()
+	st := StandbyStatus{
+		TakeoverS:     opts.StandbyTakeoverS,
+		ActiveSources: []protocol.DeviceID{},
+	}
+	if primary := opts.StandbyPrimaryID; primary != protocol.EmptyDeviceID {
+		st.Primary = &primary
+		_, st.PrimaryConnected = s.model.Connection(primary)
+	}
+
+	s.mut.Lock()
+	if st.Primary != nil {
+		lastSeen := s.primaryLastSeen
+		st.PrimaryLastSeen = &lastSeen
+	}
+	st.Active = s.active
+	if s.active && !s.activeSince.IsZero() {
+		since := s.activeSince
+		st.ActiveSince = &since
+	}
+	sources := make([]protocol.DeviceID, 0, len(s.activeSources))
+	for id := range s.activeSources {
+		sources = append(sources, id)
+	}
+	s.mut.Unlock()
+
+	for _, id := range sources {
+		if _, ok := s.model.Connection(id); ok {
+			st.ActiveSources = append(st.ActiveSources, id)
+		}
+	}
+	return st
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestStandbyTakeover(t *testing.T) {
+	w, _, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	waiter, err := w.Modify(func(cfg *config.Configuration) {
+		cfg.Options.StandbyPrimaryID = device2
+		cfg.Options.StandbyTakeoverS = 60
+	})
+	must(t, err)
+	waiter.Wait()
+
+	// Standby messages to device1 are answered by the model itself.
+	m, fc := setupModelWithConnectionFromWrapper(t, w)
+	defer cleanupModel(m)
+	fc.ControlCalls(func(_ context.Context, ctrl protocol.Control) {
+		go func() {
+			if err := m.Control(fc, ctrl); err != nil {
+				t.Error(err)
+			}
+		}()
+	})
+	s := m.standby
+
+	// The primary hasn't been down for long enough.
+	s.check(context.Background())
+	if st := s.Status(); st.Active || st.Primary == nil || *st.Primary != device2 || st.PrimaryConnected {
+		t.Fatalf("unexpected status %+v", st)
+	}
+	if err := s.FailBack(); err != errStandbyNotActive {
+		t.Errorf("expected %v, got %v", errStandbyNotActive, err)
+	}
+
+	// Once it has, we take over, as device1 doesn't see it either, and
+	// announce that to device1, which is the model itself here.
+	s.timeNow = func() time.Time { return time.Now().Add(time.Hour) }
+	s.check(context.Background())
+	if st := s.Status(); !st.Active || st.ActiveSince == nil {
+		t.Fatalf("unexpected status %+v", st)
+	}
+	for i := 0; !s.isActiveSource(device1); i++ {
+		if i == 100 {
+			t.Fatal("announcement not received")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The active role is persisted.
+	if !newStandbyService(m.model, m.db).active {
+		t.Error("active role not persisted")
+	}
+
+	must(t, s.FailBack())
+	if st := s.Status(); st.Active || st.ActiveSince != nil {
+		t.Errorf("unexpected status %+v", st)
+	}
+	for i := 0; s.isActiveSource(device1); i++ {
+		if i == 100 {
+			t.Fatal("fail back not announced")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if newStandbyService(m.model, m.db).active {
+		t.Error("fail back not persisted")
+	}
+}
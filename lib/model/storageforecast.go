@@ -0,0 +1,146 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/versioner"
+)
+
+const (
+	// How often the free space of the storage used by a folder is sampled.
+	storageSampleInterval = 10 * time.Minute
+
+	// The growth rate is taken over the samples in this window.
+	storageForecastWindow = 24 * time.Hour
+
+	// Samples must span at least this long before forecasting, so a single
+	// large pull doesn't set off a warning.
+	storageForecastMinSpan = time.Hour
+
+	// We warn when the storage is forecast to reach the minimum free space
+	// within this time.
+	storageForecastWarnWithin = 7 * 24 * time.Hour
+)
+
+type storageSample struct {
+	when time.Time
+	free uint64
+}
+
+// storageTarget tracks the free space of one directory used by the folder.
+type storageTarget struct {
+	samples []storageSample
+	warned  bool
+}
+
+// storageForecast tracks the free space of the staging and versions
+// directories of a folder and warns when, at the rate it's been shrinking,
+// it's going to fall below the minimum free space soon.
+type storageForecast struct {
+	targets map[string]*storageTarget
+}
+
+func newStorageForecast() *storageForecast {
+	return &storageForecast{targets: make(map[string]*storageTarget)}
+}
+
+func (f *folder) storageJobName() string {
+	return "storage/" + f.ID
+}
+
+// storageTargets returns the directories the folder writes to besides the
+// folder itself, by description.
+func (f *folder) storageTargets() map[string]fs.Filesystem {
+	targets := make(map[string]fs.Filesystem)
+	for _, ffs := range f.StagingFilesystems() {
+		targets["staging directory "+ffs.URI()] = ffs
+	}
+	if f.versioner != nil && f.Versioning.Type != "external" {
+		ffs := versioner.VersionsFilesystem(f.FolderConfiguration)
+		targets["versions directory "+ffs.URI()] = ffs
+	}
+	return targets
+}
+
+func (f *folder) forecastStorage(_ context.Context) error {
+	now := time.Now()
+	targets := f.storageTargets()
+	for what := range f.storageForecast.targets {
+		if _, ok := targets[what]; !ok {
+			delete(f.storageForecast.targets, what)
+		}
+	}
+	for what, ffs := range targets {
+		usage, err := ffs.Usage(".")
+		if err != nil {
+			l.Debugf("%v: checking usage of %s: %v", f, what, err)
+			continue
+		}
+		t, ok := f.storageForecast.targets[what]
+		if !ok {
+			t = &storageTarget{}
+			f.storageForecast.targets[what] = t
+		}
+		eta, ok := t.add(now, usage, f.MinDiskFree)
+		switch {
+		case ok && eta < storageForecastWarnWithin && !t.warned:
+			l.Warnf("Folder %s: the %s will be full in about %s at the current rate", f.Description(), what, formatForecast(eta))
+			t.warned = true
+		case !ok || eta >= storageForecastWarnWithin:
+			t.warned = false
+		}
+	}
+	return nil
+}
+
+// add records a sample and returns the time until the free space falls
+// below the minimum, if it's shrinking.
+func (t *storageTarget) add(now time.Time, usage fs.Usage, minFree config.Size) (time.Duration, bool) {
+	t.samples = append(t.samples, storageSample{when: now, free: usage.Free})
+	cutoff := now.Add(-storageForecastWindow)
+	i := 0
+	for i < len(t.samples)-1 && t.samples[i].when.Before(cutoff) {
+		i++
+	}
+	t.samples = t.samples[i:]
+
+	first, last := t.samples[0], t.samples[len(t.samples)-1]
+	span := last.when.Sub(first.when)
+	if span < storageForecastMinSpan || last.free >= first.free {
+		return 0, false
+	}
+	rate := float64(first.free-last.free) / span.Seconds() // bytes per second
+
+	var reserve float64
+	if minFree.Percentage() {
+		reserve = minFree.BaseValue() / 100 * float64(usage.Total)
+	} else {
+		reserve = minFree.BaseValue()
+	}
+	left := float64(last.free) - reserve
+	if left <= 0 {
+		// Already below, which the folder health check reports.
+		return 0, false
+	}
+	return time.Duration(left / rate * float64(time.Second)), true
+}
+
+func formatForecast(d time.Duration) string {
+	if d >= 48*time.Hour {
+		return fmt.Sprintf("%d days", d/(24*time.Hour))
+	}
+	if d >= 2*time.Hour {
+		return fmt.Sprintf("%d hours", d/time.Hour)
+	}
+	return fmt.Sprintf("%d minutes", d/time.Minute)
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+)
+
+func TestStorageForecast(t *testing.T) {
+	t.Parallel()
+
+	const gb = 1000 * 1000 * 1000
+	start := time.Now()
+	minFree := config.Size{Value: 1, Unit: "GB"}
+
+	var st storageTarget
+	if _, ok := st.add(start, fs.Usage{Free: 100 * gb, Total: 1000 * gb}, minFree); ok {
+		t.Fatal("unexpected forecast from a single sample")
+	}
+	if _, ok := st.add(start.Add(30*time.Minute), fs.Usage{Free: 99 * gb, Total: 1000 * gb}, minFree); ok {
+		t.Fatal("unexpected forecast from too short a span")
+	}
+
+	// 0.9 GB per hour, with 90 GB left above the minimum.
+	eta, ok := st.add(start.Add(10*time.Hour), fs.Usage{Free: 91 * gb, Total: 1000 * gb}, minFree)
+	if !ok {
+		t.Fatal("expected a forecast")
+	}
+	if eta < 99*time.Hour || eta > 101*time.Hour {
+		t.Errorf("forecast %v, expected about 100h", eta)
+	}
+
+	// Samples outside the window are dropped, leaving no shrinkage.
+	if _, ok := st.add(start.Add(40*time.Hour), fs.Usage{Free: 91 * gb, Total: 1000 * gb}, minFree); ok {
+		t.Error("unexpected forecast after the space stopped shrinking")
+	}
+	if len(st.samples) != 1 {
+		t.Errorf("expected old samples to be dropped, have %d", len(st.samples))
+	}
+}
+
+func TestStorageForecastPercentage(t *testing.T) {
+	t.Parallel()
+
+	const gb = 1000 * 1000 * 1000
+	start := time.Now()
+	minFree := config.Size{Value: 5, Unit: "%"}
+
+	var st storageTarget
+	st.add(start, fs.Usage{Free: 70 * gb, Total: 1000 * gb}, minFree)
+	// Ten GB per hour, with 10 GB left above the 50 GB reserve.
+	eta, ok := st.add(start.Add(time.Hour), fs.Usage{Free: 60 * gb, Total: 1000 * gb}, minFree)
+	if !ok {
+		t.Fatal("expected a forecast")
+	}
+	if eta < 59*time.Minute || eta > 61*time.Minute {
+		t.Errorf("forecast %v, expected about 1h", eta)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+const tempIndexKey = "files"
+
+// tempIndexEntry is what we know about a temporary file left behind by an
+// unfinished pull: the version of the file it is for and the indexes of the
+// blocks it contains.
+type tempIndexEntry struct {
+	Version   protocol.Vector `json:"version"`
+	Available []int           `json:"available"`
+}
+
+// tempIndex persists the blocks available in temporary files of a folder,
+// so that they can be announced to other devices right after a restart
+// instead of only once pulling the file resumes.
+type tempIndex struct {
+	kv    *db.NamespacedKV
+	mut   sync.Mutex
+	files map[string]tempIndexEntry
+}
+
+func newTempIndex(ldb *db.Lowlevel, folder string) *tempIndex {
+	t := &tempIndex{
+		kv:    db.NewTempIndexNamespace(ldb, folder),
+		mut:   sync.NewMutex(),
+		files: make(map[string]tempIndexEntry),
+	}
+	if bs, ok, err := t.kv.Bytes(tempIndexKey); err != nil {
+		l.Debugln("loading temporary index:", err)
+	} else if ok {
+		if err := json.Unmarshal(bs, &t.files); err != nil {
+			l.Debugln("loading temporary index:", err)
+			t.files = make(map[string]tempIndexEntry)
+		}
+	}
+	return t
+}
+
+// set records the available blocks of the puller's temporary file.
+func (t *tempIndex) set(s *sharedPullerState) {
+	available := s.Available()
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	if len(available) == 0 {
+		t.removeLocked(s.file.Name)
+		return
+	}
+	t.files[s.file.Name] = tempIndexEntry{
+		Version:   s.file.Version,
+		Available: append([]int(nil), available...),
+	}
+	t.saveLocked()
+}
+
+func (t *tempIndex) remove(name string) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	t.removeLocked(name)
+}
+
+func (t *tempIndex) removeLocked(name string) {
+	if _, ok := t.files[name]; !ok {
+		return
+	}
+	delete(t.files, name)
+	t.saveLocked()
+}
+
+func (t *tempIndex) clear() {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	if len(t.files) == 0 {
+		return
+	}
+	t.files = make(map[string]tempIndexEntry)
+	t.saveLocked()
+}
+
+func (t *tempIndex) saveLocked() {
+	var err error
+	if len(t.files) == 0 {
+		err = t.kv.Delete(tempIndexKey)
+	} else {
+		var bs []byte
+		if bs, err = json.Marshal(t.files); err == nil {
+			err = t.kv.PutBytes(tempIndexKey, bs)
+		}
+	}
+	if err != nil {
+		l.Debugln("saving temporary index:", err)
+	}
+}
+
+// restore returns puller states for the entries whose temporary file still
+// exists and is for the current global version of the file, for announcing
+// to other devices. Other entries are dropped.
+func (t *tempIndex) restore(snap *db.Snapshot, tempFs fs.Filesystem, tempName func(string) string, folder string) []*sharedPullerState {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	var states []*sharedPullerState
+	changed := false
+	for name, entry := range t.files {
+		file, ok := snap.GetGlobal(name)
+		if !ok || !file.Version.Equal(entry.Version) || !validAvailable(entry.Available, len(file.Blocks)) {
+			delete(t.files, name)
+			changed = true
+			continue
+		}
+		tempFn := tempName(name)
+		if info, err := tempFs.Lstat(tempFn); err != nil || !info.IsRegular() {
+			delete(t.files, name)
+			changed = true
+			continue
+		}
+		states = append(states, newSharedPullerState(file, tempFs, folder, tempFn, nil, entry.Available, false, false, protocol.FileInfo{}, config.PreallocationNone, false))
+	}
+	if changed {
+		t.saveLocked()
+	}
+	return states
+}
+
+func validAvailable(available []int, blocks int) bool {
+	for _, idx := range available {
+		if idx < 0 || idx >= blocks {
+			return false
+		}
+	}
+	return true
+}
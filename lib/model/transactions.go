@@ -0,0 +1,102 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"errors"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/rand"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+var errTransactionIncomplete = errors.New("other files of the same transaction are not ready yet")
+
+// setTransactionID marks the files as changed in the same transaction, in
+// transactional folders. All changes within a scan batch form a
+// transaction.
+func setTransactionID(fs []protocol.FileInfo) {
+	var id int64
+	for id == 0 {
+		id = rand.Int63()
+	}
+	for i := range fs {
+		fs[i].TransactionID = id
+	}
+}
+
+// pullTransactions keeps track of the files being pulled per transaction
+// during a puller iteration, so that the files of a transaction are only
+// put in place once all of them are ready.
+type pullTransactions struct {
+	mut     sync.Mutex
+	ids     map[string]int64 // file name -> transaction
+	pending map[int64]int    // transaction -> files neither ready nor resolved
+	ready   map[int64][]*sharedPullerState
+}
+
+func newPullTransactions() *pullTransactions {
+	return &pullTransactions{
+		mut:     sync.NewMutex(),
+		ids:     make(map[string]int64),
+		pending: make(map[int64]int),
+		ready:   make(map[int64][]*sharedPullerState),
+	}
+}
+
+// add registers a file queued for pulling.
+func (t *pullTransactions) add(file protocol.FileInfo) {
+	if file.TransactionID == 0 {
+		return
+	}
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	t.ids[file.Name] = file.TransactionID
+	t.pending[file.TransactionID]++
+}
+
+// resolve marks a queued file as not needing to be pulled after all, as it
+// was renamed into place or no longer needs pulling.
+func (t *pullTransactions) resolve(name string) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	if id, ok := t.ids[name]; ok {
+		delete(t.ids, name)
+		t.pending[id]--
+	}
+}
+
+// hold keeps back a successfully pulled file if it is part of a
+// transaction and returns true, otherwise it returns false.
+func (t *pullTransactions) hold(state *sharedPullerState) bool {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	id, ok := t.ids[state.file.Name]
+	if !ok {
+		return false
+	}
+	delete(t.ids, state.file.Name)
+	t.pending[id]--
+	t.ready[id] = append(t.ready[id], state)
+	return true
+}
+
+// take calls fn with the held files of each transaction, and whether all
+// files of the transaction are ready, then forgets about them.
+func (t *pullTransactions) take(fn func(states []*sharedPullerState, complete bool)) {
+	t.mut.Lock()
+	ready := t.ready
+	complete := make(map[int64]bool, len(ready))
+	for id := range ready {
+		complete[id] = t.pending[id] == 0
+	}
+	t.ready = make(map[int64][]*sharedPullerState)
+	t.mut.Unlock()
+	for id, states := range ready {
+		fn(states, complete[id])
+	}
+}
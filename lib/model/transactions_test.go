@@ -0,0 +1,64 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestPullTransactions(t *testing.T) {
+	tr := newPullTransactions()
+	state := func(name string) *sharedPullerState {
+		return &sharedPullerState{file: protocol.FileInfo{Name: name}}
+	}
+	tr.add(protocol.FileInfo{Name: "a", TransactionID: 1})
+	tr.add(protocol.FileInfo{Name: "b", TransactionID: 1})
+	tr.add(protocol.FileInfo{Name: "c", TransactionID: 1})
+	tr.add(protocol.FileInfo{Name: "d", TransactionID: 2})
+	tr.add(protocol.FileInfo{Name: "e", TransactionID: 2})
+	tr.add(protocol.FileInfo{Name: "f"})
+
+	if tr.hold(state("f")) {
+		t.Error("file without transaction held")
+	}
+	// b was renamed into place instead of pulled, e failed.
+	tr.resolve("b")
+	for _, name := range []string{"a", "c", "d"} {
+		if !tr.hold(state(name)) {
+			t.Errorf("file %v not held", name)
+		}
+	}
+
+	res := make(map[string]bool)
+	tr.take(func(states []*sharedPullerState, complete bool) {
+		for _, s := range states {
+			res[s.file.Name] = complete
+		}
+	})
+	if len(res) != 3 || !res["a"] || !res["c"] || res["d"] {
+		t.Errorf("unexpected outcome %v", res)
+	}
+
+	tr.take(func([]*sharedPullerState, bool) {
+		t.Error("transactions not forgotten")
+	})
+}
+
+func TestSetTransactionID(t *testing.T) {
+	fs := make([]protocol.FileInfo, 3)
+	setTransactionID(fs)
+	id := fs[0].TransactionID
+	if id == 0 || fs[1].TransactionID != id || fs[2].TransactionID != id {
+		t.Errorf("unexpected transaction IDs %v, %v, %v", fs[0].TransactionID, fs[1].TransactionID, fs[2].TransactionID)
+	}
+	setTransactionID(fs)
+	if fs[0].TransactionID == id {
+		t.Error("transaction ID reused")
+	}
+}
@@ -0,0 +1,529 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package profiles serves several isolated instances ("profiles") from one
+// process. Each profile has a config, database, certificates and folders of
+// its own, in a directory below the profiles directory. Profiles get a sync
+// port each from a shared range, and their GUI and REST API are served
+// below /profiles/<name>/ on a shared listener.
+package profiles
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thejerf/suture/v4"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/netutil"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/svcutil"
+	"github.com/syncthing/syncthing/lib/sync"
+	"github.com/syncthing/syncthing/lib/syncthing"
+)
+
+const (
+	StateStarting = "starting"
+	StateRunning  = "running"
+	StateStopped  = "stopped"
+	StateFailed   = "failed"
+
+	// The path below which the GUI of each profile is served.
+	pathPrefix = "/profiles/"
+)
+
+var (
+	errInvalidName   = errors.New("invalid profile name")
+	errProfileExists = errors.New("profile already exists")
+	errNoSuchProfile = errors.New("no such profile")
+	errNoFreePort    = errors.New("no free sync port left in the range")
+	errNoAuth        = errors.New("a GUI user and password are required")
+	errNotRunning    = errors.New("profile is not running")
+
+	profileNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+)
+
+type Options struct {
+	// Each directory in it is the home of a profile.
+	Dir string
+	// Where the profiles API and the GUIs of the profiles are served.
+	Address string
+	// Required for listing and creating profiles. Profile management is
+	// disabled without one.
+	APIKey string
+	// The range sync ports are assigned from, one per profile.
+	MinPort, MaxPort int
+}
+
+// Profile describes the state of a profile.
+type Profile struct {
+	Name       string            `json:"name"`
+	State      string            `json:"state"`
+	DeviceID   protocol.DeviceID `json:"deviceID"`
+	ListenPort int               `json:"listenPort"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// The Manager runs the profiles and serves the profiles API.
+type Manager struct {
+	*suture.Supervisor
+	opts    Options
+	appOpts []syncthing.Option
+
+	mut      sync.Mutex
+	profiles map[string]*profile
+}
+
+// New returns a manager running the profiles that exist in the profiles
+// directory. The app options are used for every profile.
+func New(opts Options, appOpts ...syncthing.Option) (*Manager, error) {
+	if opts.MinPort <= 0 || opts.MaxPort < opts.MinPort {
+		return nil, fmt.Errorf("invalid sync port range %d-%d", opts.MinPort, opts.MaxPort)
+	}
+	m := &Manager{
+		Supervisor: suture.New("profiles", svcutil.SpecWithDebugLogger(l)),
+		opts:       opts,
+		appOpts:    appOpts,
+		mut:        sync.NewMutex(),
+		profiles:   make(map[string]*profile),
+	}
+
+	entries, err := os.ReadDir(opts.Dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !profileNameRe.MatchString(entry.Name()) {
+			continue
+		}
+		p := m.newProfile(entry.Name())
+		p.port = listenPort(p.locations.Get(locations.ConfigFile), opts.MinPort, opts.MaxPort)
+		m.profiles[p.name] = p
+	}
+	for _, p := range m.profiles {
+		m.Add(p)
+	}
+	m.Add(svcutil.AsService(m.serveAPI, m.String()))
+
+	return m, nil
+}
+
+func (m *Manager) String() string {
+	return fmt.Sprintf("profiles@%p", m)
+}
+
+func (m *Manager) newProfile(name string) *profile {
+	dir := filepath.Join(m.opts.Dir, name)
+	// The directory was validated to be a plain name, so this can't fail
+	// unless the working directory is gone.
+	locs, err := locations.NewProfile(dir, dir)
+	if err != nil {
+		panic(err)
+	}
+	return &profile{
+		name:      name,
+		locations: locs,
+		appOpts:   m.appOpts,
+		mut:       sync.NewMutex(),
+		state:     StateStarting,
+	}
+}
+
+// Profiles returns the state of all profiles, sorted by name.
+func (m *Manager) Profiles() []Profile {
+	m.mut.Lock()
+	profiles := make([]Profile, 0, len(m.profiles))
+	for _, p := range m.profiles {
+		profiles = append(profiles, p.status())
+	}
+	m.mut.Unlock()
+	sort.Slice(profiles, func(a, b int) bool {
+		return profiles[a].Name < profiles[b].Name
+	})
+	return profiles
+}
+
+// Create sets up a new profile, with the given GUI credentials, and starts
+// it.
+func (m *Manager) Create(name, user, password string) (Profile, error) {
+	if !profileNameRe.MatchString(name) {
+		return Profile{}, errInvalidName
+	}
+	if user == "" || password == "" {
+		return Profile{}, errNoAuth
+	}
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	if _, ok := m.profiles[name]; ok {
+		return Profile{}, errProfileExists
+	}
+	port, err := m.freePortLocked()
+	if err != nil {
+		return Profile{}, err
+	}
+
+	p := m.newProfile(name)
+	p.port = port
+	if err := p.create(user, password); err != nil {
+		return Profile{}, err
+	}
+	m.profiles[name] = p
+	m.Add(p)
+	l.Infof("Created profile %s with sync port %d", name, port)
+	return p.status(), nil
+}
+
+func (m *Manager) freePortLocked() (int, error) {
+	used := make(map[int]bool, len(m.profiles))
+	for _, p := range m.profiles {
+		used[p.port] = true
+	}
+	for port := m.opts.MinPort; port <= m.opts.MaxPort; port++ {
+		if !used[port] {
+			return port, nil
+		}
+	}
+	return 0, errNoFreePort
+}
+
+func (m *Manager) get(name string) (*profile, bool) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	p, ok := m.profiles[name]
+	return p, ok
+}
+
+func (m *Manager) serveAPI(ctx context.Context) error {
+	listener, err := net.Listen("tcp", m.opts.Address)
+	if err != nil {
+		return err
+	}
+	l.Infoln("Serving profiles on", listener.Addr())
+
+	srv := &http.Server{
+		Handler:           m.Handler(),
+		ReadHeaderTimeout: 15 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	if err := srv.Serve(listener); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// Handler serves the profiles API at /rest/profiles, and the GUI of each
+// profile below /profiles/<name>/.
+func (m *Manager) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/profiles", m.handleProfiles)
+	mux.HandleFunc(pathPrefix, m.handleProfile)
+	return mux
+}
+
+func (m *Manager) handleProfiles(w http.ResponseWriter, r *http.Request) {
+	if m.opts.APIKey == "" {
+		http.Error(w, "profile management is disabled", http.StatusForbidden)
+		return
+	}
+	key := r.Header.Get("X-API-Key")
+	if bearer := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "); key == "" && bearer != r.Header.Get("Authorization") {
+		key = bearer
+	}
+	if subtle.ConstantTimeCompare([]byte(key), []byte(m.opts.APIKey)) != 1 {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		sendJSON(w, m.Profiles())
+	case http.MethodPost:
+		var req struct {
+			Name     string `json:"name"`
+			User     string `json:"user"`
+			Password string `json:"password"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		p, err := m.Create(req.Name, req.User, req.Password)
+		switch {
+		case errors.Is(err, errProfileExists):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, errInvalidName), errors.Is(err, errNoAuth):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		default:
+			sendJSON(w, p)
+		}
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (m *Manager) handleProfile(w http.ResponseWriter, r *http.Request) {
+	name, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, pathPrefix), "/")
+	p, ok := m.get(name)
+	if !ok {
+		http.Error(w, errNoSuchProfile.Error(), http.StatusNotFound)
+		return
+	}
+	addr, err := p.guiAddress()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	proxy := &httputil.ReverseProxy{
+		Director: func(r *http.Request) {
+			// The GUI only accepts requests for the address it listens
+			// on. The client address is passed on in X-Forwarded-For.
+			r.URL.Scheme = "http"
+			r.URL.Host = addr
+			r.Host = addr
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+type profile struct {
+	name      string
+	locations *locations.Profile
+	appOpts   []syncthing.Option
+	port      int // set before the profile is started
+
+	mut      sync.Mutex
+	state    string
+	err      error
+	deviceID protocol.DeviceID
+	cfg      config.Wrapper // while running
+	guiAddr  string
+}
+
+func (p *profile) String() string {
+	return fmt.Sprintf("profile/%s@%p", p.name, p)
+}
+
+func (p *profile) status() Profile {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	st := Profile{
+		Name:       p.name,
+		State:      p.state,
+		DeviceID:   p.deviceID,
+		ListenPort: p.port,
+	}
+	if p.err != nil {
+		st.Error = p.err.Error()
+	}
+	return st
+}
+
+// guiAddress returns the address the GUI of the profile listens on, as
+// long as access to it is protected.
+func (p *profile) guiAddress() (string, error) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	if p.state != StateRunning {
+		return "", errNotRunning
+	}
+	if !p.cfg.GUI().IsAuthEnabled() {
+		return "", errNoAuth
+	}
+	return p.guiAddr, nil
+}
+
+func (p *profile) setState(state string, err error) {
+	p.mut.Lock()
+	p.state = state
+	p.err = err
+	if state != StateRunning {
+		p.cfg = nil
+	}
+	p.mut.Unlock()
+}
+
+// create writes the certificate and initial config of a new profile.
+func (p *profile) create(user, password string) error {
+	if err := os.MkdirAll(p.locations.GetBaseDir(locations.ConfigBaseDir), 0o700); err != nil {
+		return err
+	}
+	cert, err := syncthing.LoadOrGenerateCertificate(p.locations.Get(locations.CertFile), p.locations.Get(locations.KeyFile))
+	if err != nil {
+		return err
+	}
+	myID := protocol.NewDeviceID(cert.Certificate[0])
+	cfg := config.New(myID)
+	cfg.GUI.User = user
+	if err := cfg.GUI.HashAndSetPassword(password); err != nil {
+		return err
+	}
+	cfg.Options.RawListenAddresses = []string{
+		netutil.AddressURL("tcp", net.JoinHostPort("0.0.0.0", strconv.Itoa(p.port))),
+		"dynamic+https://relays.syncthing.net/endpoint",
+		netutil.AddressURL("quic", net.JoinHostPort("0.0.0.0", strconv.Itoa(p.port))),
+	}
+	return config.Wrap(p.locations.Get(locations.ConfigFile), cfg, myID, events.NoopLogger).Save()
+}
+
+func (p *profile) Serve(ctx context.Context) error {
+	p.setState(StateStarting, nil)
+	status, err := p.run(ctx)
+	switch {
+	case ctx.Err() != nil:
+		p.setState(StateStopped, nil)
+		return ctx.Err()
+	case err != nil:
+		l.Warnf("Profile %s: %v", p.name, err)
+		p.setState(StateFailed, err)
+		return err
+	case status == svcutil.ExitRestart || status == svcutil.ExitUpgrade:
+		p.setState(StateStarting, nil)
+		return fmt.Errorf("profile %s: restart requested", p.name)
+	default:
+		// Shut down from its own GUI.
+		l.Infof("Profile %s stopped", p.name)
+		p.setState(StateStopped, nil)
+		return suture.ErrDoNotRestart
+	}
+}
+
+func (p *profile) run(ctx context.Context) (svcutil.ExitStatus, error) {
+	cert, err := syncthing.LoadOrGenerateCertificate(p.locations.Get(locations.CertFile), p.locations.Get(locations.KeyFile))
+	if err != nil {
+		return svcutil.ExitError, fmt.Errorf("loading certificate: %w", err)
+	}
+
+	// The event logger and config service must outlive the app.
+	early := suture.New(p.String(), svcutil.SpecWithDebugLogger(l))
+	earlyCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	early.ServeBackground(earlyCtx)
+	evLogger := events.NewLogger()
+	early.Add(evLogger)
+
+	cfg, err := syncthing.LoadConfigAtStartup(p.locations.Get(locations.ConfigFile), cert, evLogger, false, true, true)
+	if err != nil {
+		return svcutil.ExitError, err
+	}
+	early.Add(cfg)
+
+	guiAddr, err := freeLoopbackAddress()
+	if err != nil {
+		return svcutil.ExitError, err
+	}
+	waiter, err := cfg.Modify(func(cfg *config.Configuration) {
+		// The GUI is only reachable through the manager, which passes on
+		// the client address.
+		cfg.GUI.Enabled = true
+		cfg.GUI.RawAddress = guiAddr
+		cfg.GUI.RawUseTLS = false
+		cfg.GUI.RawPathPrefix = pathPrefix + p.name
+		cfg.GUI.TrustedProxies = []string{"127.0.0.1/32", "::1/128"}
+		cfg.Options.StartBrowser = false
+	})
+	if err != nil {
+		return svcutil.ExitError, err
+	}
+	waiter.Wait()
+
+	ldb, err := syncthing.OpenDBBackend(p.locations.Get(locations.Database), cfg.Options().DatabaseTuning)
+	if err != nil {
+		return svcutil.ExitError, fmt.Errorf("opening database: %w", err)
+	}
+	opts := append(append([]syncthing.Option{}, p.appOpts...), syncthing.WithProfile(p.locations), syncthing.WithNoUpgrade())
+	app, err := syncthing.New(cfg, ldb, evLogger, cert, opts...)
+	if err != nil {
+		ldb.Close()
+		return svcutil.ExitError, err
+	}
+	if err := app.Start(); err != nil {
+		return svcutil.ExitError, err
+	}
+
+	p.mut.Lock()
+	p.state = StateRunning
+	p.deviceID = app.DeviceID()
+	p.cfg = cfg
+	p.guiAddr = guiAddr
+	p.mut.Unlock()
+	l.Infof("Profile %s running as %v", p.name, app.DeviceID())
+
+	stopped := make(chan struct{})
+	go func() {
+		app.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-ctx.Done():
+		return app.Stop(svcutil.ExitSuccess), nil
+	case <-stopped:
+		return app.Wait(), app.Error()
+	}
+}
+
+// listenPort returns the sync port in the given range that the config at
+// path listens on, or zero.
+func listenPort(path string, minPort, maxPort int) int {
+	fd, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer fd.Close()
+	cfg, _, err := config.ReadXML(fd, protocol.EmptyDeviceID)
+	if err != nil {
+		return 0
+	}
+	for _, addr := range cfg.Options.RawListenAddresses {
+		u, err := url.Parse(addr)
+		if err != nil {
+			continue
+		}
+		if port, err := strconv.Atoi(u.Port()); err == nil && port >= minPort && port <= maxPort {
+			return port
+		}
+	}
+	return 0
+}
+
+func freeLoopbackAddress() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer listener.Close()
+	return listener.Addr().String(), nil
+}
+
+func sendJSON(w http.ResponseWriter, jsonObject interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(jsonObject); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
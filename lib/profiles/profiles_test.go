@@ -0,0 +1,113 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package profiles
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProfiles(t *testing.T) {
+	m, err := New(Options{
+		Dir:     t.TempDir(),
+		Address: "127.0.0.1:0",
+		APIKey:  "secret",
+		MinPort: 42101,
+		MaxPort: 42102,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.ServeBackground(ctx)
+
+	srv := httptest.NewServer(m.Handler())
+	defer srv.Close()
+
+	request := func(method, path, key, body string) (int, string) {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		bs, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(bs)
+	}
+
+	if code, _ := request(http.MethodGet, "/rest/profiles", "wrong", ""); code != http.StatusForbidden {
+		t.Errorf("expected listing with the wrong key to be forbidden, got %d", code)
+	}
+	if code, _ := request(http.MethodPost, "/rest/profiles", "secret", `{"name":"../x","user":"u","password":"p"}`); code != http.StatusBadRequest {
+		t.Errorf("expected invalid name to be rejected, got %d", code)
+	}
+	if code, _ := request(http.MethodPost, "/rest/profiles", "secret", `{"name":"x"}`); code != http.StatusBadRequest {
+		t.Errorf("expected profile without GUI credentials to be rejected, got %d", code)
+	}
+
+	code, body := request(http.MethodPost, "/rest/profiles", "secret", `{"name":"alice","user":"alice","password":"pass"}`)
+	if code != http.StatusOK {
+		t.Fatalf("creating profile: %d %s", code, body)
+	}
+	var p Profile
+	if err := json.Unmarshal([]byte(body), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.ListenPort != 42101 {
+		t.Errorf("expected first port of the range, got %d", p.ListenPort)
+	}
+	if code, _ := request(http.MethodPost, "/rest/profiles", "secret", `{"name":"alice","user":"alice","password":"pass"}`); code != http.StatusConflict {
+		t.Errorf("expected duplicate profile to be rejected, got %d", code)
+	}
+
+	// Wait for the GUI of the profile to come up behind the proxy.
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		code, body = request(http.MethodGet, "/profiles/alice/rest/noauth/health", "", "")
+		if code == http.StatusOK {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("profile GUI not reachable: %d %s", code, body)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !strings.Contains(body, "OK") {
+		t.Errorf("unexpected health response %q", body)
+	}
+
+	// Everything else requires the credentials of the profile.
+	if code, _ := request(http.MethodGet, "/profiles/alice/rest/system/status", "", ""); code != http.StatusUnauthorized && code != http.StatusForbidden {
+		t.Errorf("expected unauthenticated request to be refused, got %d", code)
+	}
+	if code, _ := request(http.MethodGet, "/profiles/bob/rest/noauth/health", "", ""); code != http.StatusNotFound {
+		t.Errorf("expected unknown profile to be not found, got %d", code)
+	}
+
+	code, body = request(http.MethodGet, "/rest/profiles", "secret", "")
+	var profiles []Profile
+	if err := json.Unmarshal([]byte(body), &profiles); err != nil {
+		t.Fatal(code, err)
+	}
+	if len(profiles) != 1 || profiles[0].Name != "alice" || profiles[0].State != StateRunning {
+		t.Errorf("unexpected profiles %+v", profiles)
+	}
+}
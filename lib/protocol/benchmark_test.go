@@ -194,3 +194,7 @@ func (*fakeModel) Closed(Connection, error) {
 func (*fakeModel) DownloadProgress(Connection, string, []FileDownloadProgressUpdate) error {
 	return nil
 }
+
+func (*fakeModel) Control(Connection, Control) error {
+	return nil
+}
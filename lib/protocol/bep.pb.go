@@ -35,6 +35,7 @@ const (
 	MessageTypeDownloadProgress MessageType = 5
 	MessageTypePing             MessageType = 6
 	MessageTypeClose            MessageType = 7
+	MessageTypeControl          MessageType = 8
 )
 
 var MessageType_name = map[int32]string{
@@ -46,6 +47,7 @@ var MessageType_name = map[int32]string{
 	5: "MESSAGE_TYPE_DOWNLOAD_PROGRESS",
 	6: "MESSAGE_TYPE_PING",
 	7: "MESSAGE_TYPE_CLOSE",
+	8: "MESSAGE_TYPE_CONTROL",
 }
 
 var MessageType_value = map[string]int32{
@@ -57,6 +59,7 @@ var MessageType_value = map[string]int32{
 	"MESSAGE_TYPE_DOWNLOAD_PROGRESS": 5,
 	"MESSAGE_TYPE_PING":              6,
 	"MESSAGE_TYPE_CLOSE":             7,
+	"MESSAGE_TYPE_CONTROL":           8,
 }
 
 func (x MessageType) String() string {
@@ -210,6 +213,40 @@ func (FileDownloadProgressUpdateType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_311ef540e10d9705, []int{5}
 }
 
+type CloseReason int32
+
+const (
+	CloseReasonUnknown            CloseReason = 0
+	CloseReasonFolderPaused       CloseReason = 1
+	CloseReasonFolderMissing      CloseReason = 2
+	CloseReasonEncryptionMismatch CloseReason = 3
+	CloseReasonTooManyConnections CloseReason = 4
+)
+
+var CloseReason_name = map[int32]string{
+	0: "CLOSE_REASON_UNKNOWN",
+	1: "CLOSE_REASON_FOLDER_PAUSED",
+	2: "CLOSE_REASON_FOLDER_MISSING",
+	3: "CLOSE_REASON_ENCRYPTION_MISMATCH",
+	4: "CLOSE_REASON_TOO_MANY_CONNECTIONS",
+}
+
+var CloseReason_value = map[string]int32{
+	"CLOSE_REASON_UNKNOWN":              0,
+	"CLOSE_REASON_FOLDER_PAUSED":        1,
+	"CLOSE_REASON_FOLDER_MISSING":       2,
+	"CLOSE_REASON_ENCRYPTION_MISMATCH":  3,
+	"CLOSE_REASON_TOO_MANY_CONNECTIONS": 4,
+}
+
+func (x CloseReason) String() string {
+	return proto.EnumName(CloseReason_name, int32(x))
+}
+
+func (CloseReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_311ef540e10d9705, []int{6}
+}
+
 type Hello struct {
 	DeviceName    string `protobuf:"bytes,1,opt,name=device_name,json=deviceName,proto3" json:"deviceName" xml:"deviceName"`
 	ClientName    string `protobuf:"bytes,2,opt,name=client_name,json=clientName,proto3" json:"clientName" xml:"clientName"`
@@ -325,14 +362,16 @@ func (m *ClusterConfig) XXX_DiscardUnknown() {
 var xxx_messageInfo_ClusterConfig proto.InternalMessageInfo
 
 type Folder struct {
-	ID                 string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id" xml:"id"`
-	Label              string   `protobuf:"bytes,2,opt,name=label,proto3" json:"label" xml:"label"`
-	ReadOnly           bool     `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"readOnly" xml:"readOnly"`
-	IgnorePermissions  bool     `protobuf:"varint,4,opt,name=ignore_permissions,json=ignorePermissions,proto3" json:"ignorePermissions" xml:"ignorePermissions"`
-	IgnoreDelete       bool     `protobuf:"varint,5,opt,name=ignore_delete,json=ignoreDelete,proto3" json:"ignoreDelete" xml:"ignoreDelete"`
-	DisableTempIndexes bool     `protobuf:"varint,6,opt,name=disable_temp_indexes,json=disableTempIndexes,proto3" json:"disableTempIndexes" xml:"disableTempIndexes"`
-	Paused             bool     `protobuf:"varint,7,opt,name=paused,proto3" json:"paused" xml:"paused"`
-	Devices            []Device `protobuf:"bytes,16,rep,name=devices,proto3" json:"devices" xml:"device"`
+	ID                 string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id" xml:"id"`
+	Label              string         `protobuf:"bytes,2,opt,name=label,proto3" json:"label" xml:"label"`
+	ReadOnly           bool           `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"readOnly" xml:"readOnly"`
+	IgnorePermissions  bool           `protobuf:"varint,4,opt,name=ignore_permissions,json=ignorePermissions,proto3" json:"ignorePermissions" xml:"ignorePermissions"`
+	IgnoreDelete       bool           `protobuf:"varint,5,opt,name=ignore_delete,json=ignoreDelete,proto3" json:"ignoreDelete" xml:"ignoreDelete"`
+	DisableTempIndexes bool           `protobuf:"varint,6,opt,name=disable_temp_indexes,json=disableTempIndexes,proto3" json:"disableTempIndexes" xml:"disableTempIndexes"`
+	Paused             bool           `protobuf:"varint,7,opt,name=paused,proto3" json:"paused" xml:"paused"`
+	Notes              string         `protobuf:"bytes,8,opt,name=notes,proto3" json:"notes" xml:"notes"`
+	Metadata           FolderMetadata `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata" xml:"metadata"`
+	Devices            []Device       `protobuf:"bytes,16,rep,name=devices,proto3" json:"devices" xml:"device"`
 }
 
 func (m *Folder) Reset()         { *m = Folder{} }
@@ -368,6 +407,48 @@ func (m *Folder) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Folder proto.InternalMessageInfo
 
+// Metadata presented with the folder on all devices. The most recently
+// updated metadata announced for a folder wins.
+type FolderMetadata struct {
+	Description string `protobuf:"bytes,1,opt,name=description,proto3" json:"description" xml:"description"`
+	Icon        string `protobuf:"bytes,2,opt,name=icon,proto3" json:"icon" xml:"icon"`
+	Color       string `protobuf:"bytes,3,opt,name=color,proto3" json:"color" xml:"color"`
+	Updated     int64  `protobuf:"varint,4,opt,name=updated,proto3" json:"updated" xml:"updated"`
+}
+
+func (m *FolderMetadata) Reset()         { *m = FolderMetadata{} }
+func (m *FolderMetadata) String() string { return proto.CompactTextString(m) }
+func (*FolderMetadata) ProtoMessage()    {}
+func (*FolderMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_311ef540e10d9705, []int{4}
+}
+func (m *FolderMetadata) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FolderMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FolderMetadata.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FolderMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FolderMetadata.Merge(m, src)
+}
+func (m *FolderMetadata) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *FolderMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_FolderMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FolderMetadata proto.InternalMessageInfo
+
 type Device struct {
 	ID                       DeviceID    `protobuf:"bytes,1,opt,name=id,proto3,customtype=DeviceID" json:"id" xml:"id"`
 	Name                     string      `protobuf:"bytes,2,opt,name=name,proto3" json:"name" xml:"name"`
@@ -385,7 +466,7 @@ func (m *Device) Reset()         { *m = Device{} }
 func (m *Device) String() string { return proto.CompactTextString(m) }
 func (*Device) ProtoMessage()    {}
 func (*Device) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{4}
+	return fileDescriptor_311ef540e10d9705, []int{5}
 }
 func (m *Device) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -423,7 +504,7 @@ func (m *Index) Reset()         { *m = Index{} }
 func (m *Index) String() string { return proto.CompactTextString(m) }
 func (*Index) ProtoMessage()    {}
 func (*Index) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{5}
+	return fileDescriptor_311ef540e10d9705, []int{6}
 }
 func (m *Index) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -461,7 +542,7 @@ func (m *IndexUpdate) Reset()         { *m = IndexUpdate{} }
 func (m *IndexUpdate) String() string { return proto.CompactTextString(m) }
 func (*IndexUpdate) ProtoMessage()    {}
 func (*IndexUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{6}
+	return fileDescriptor_311ef540e10d9705, []int{7}
 }
 func (m *IndexUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -497,6 +578,7 @@ type FileInfo struct {
 	ModifiedBy    ShortID      `protobuf:"varint,12,opt,name=modified_by,json=modifiedBy,proto3,customtype=ShortID" json:"modifiedBy" xml:"modifiedBy"`
 	Version       Vector       `protobuf:"bytes,9,opt,name=version,proto3" json:"version" xml:"version"`
 	Sequence      int64        `protobuf:"varint,10,opt,name=sequence,proto3" json:"sequence" xml:"sequence"`
+	TransactionID int64        `protobuf:"varint,20,opt,name=transaction_id,json=transactionId,proto3" json:"transactionId" xml:"transactionId"`
 	Blocks        []BlockInfo  `protobuf:"bytes,16,rep,name=blocks,proto3" json:"blocks" xml:"block"`
 	SymlinkTarget string       `protobuf:"bytes,17,opt,name=symlink_target,json=symlinkTarget,proto3" json:"symlinkTarget" xml:"symlinkTarget"`
 	BlocksHash    []byte       `protobuf:"bytes,18,opt,name=blocks_hash,json=blocksHash,proto3" json:"blocksHash" xml:"blocksHash"`
@@ -519,16 +601,21 @@ type FileInfo struct {
 	InodeChangeNs int64 `protobuf:"varint,1002,opt,name=inode_change_ns,json=inodeChangeNs,proto3" json:"inodeChangeNs" xml:"inodeChangeNs"`
 	// The size of the data appended to the encrypted file on disk. This is
 	// host-local, not sent over the wire.
-	EncryptionTrailerSize int  `protobuf:"varint,1003,opt,name=encryption_trailer_size,json=encryptionTrailerSize,proto3,casttype=int" json:"encryptionTrailerSize" xml:"encryptionTrailerSize"`
-	Deleted               bool `protobuf:"varint,6,opt,name=deleted,proto3" json:"deleted" xml:"deleted"`
-	RawInvalid            bool `protobuf:"varint,7,opt,name=invalid,proto3" json:"invalid" xml:"invalid"`
-	NoPermissions         bool `protobuf:"varint,8,opt,name=no_permissions,json=noPermissions,proto3" json:"noPermissions" xml:"noPermissions"`
+	EncryptionTrailerSize int `protobuf:"varint,1003,opt,name=encryption_trailer_size,json=encryptionTrailerSize,proto3,casttype=int" json:"encryptionTrailerSize" xml:"encryptionTrailerSize"`
+	// The inode number and generation of the file, identifying it on the
+	// device together with the inode change time. Zero when not supported.
+	// This is host-local, not sent over the wire.
+	Inode           uint64 `protobuf:"varint,1004,opt,name=inode,proto3" json:"inode" xml:"inode"`
+	InodeGeneration uint64 `protobuf:"varint,1005,opt,name=inode_generation,json=inodeGeneration,proto3" json:"inodeGeneration" xml:"inodeGeneration"`
+	Deleted         bool   `protobuf:"varint,6,opt,name=deleted,proto3" json:"deleted" xml:"deleted"`
+	RawInvalid      bool   `protobuf:"varint,7,opt,name=invalid,proto3" json:"invalid" xml:"invalid"`
+	NoPermissions   bool   `protobuf:"varint,8,opt,name=no_permissions,json=noPermissions,proto3" json:"noPermissions" xml:"noPermissions"`
 }
 
 func (m *FileInfo) Reset()      { *m = FileInfo{} }
 func (*FileInfo) ProtoMessage() {}
 func (*FileInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{7}
+	return fileDescriptor_311ef540e10d9705, []int{8}
 }
 func (m *FileInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -567,7 +654,7 @@ type BlockInfo struct {
 func (m *BlockInfo) Reset()      { *m = BlockInfo{} }
 func (*BlockInfo) ProtoMessage() {}
 func (*BlockInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{8}
+	return fileDescriptor_311ef540e10d9705, []int{9}
 }
 func (m *BlockInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -604,7 +691,7 @@ func (m *Vector) Reset()         { *m = Vector{} }
 func (m *Vector) String() string { return proto.CompactTextString(m) }
 func (*Vector) ProtoMessage()    {}
 func (*Vector) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{9}
+	return fileDescriptor_311ef540e10d9705, []int{10}
 }
 func (m *Vector) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -642,7 +729,7 @@ func (m *Counter) Reset()         { *m = Counter{} }
 func (m *Counter) String() string { return proto.CompactTextString(m) }
 func (*Counter) ProtoMessage()    {}
 func (*Counter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{10}
+	return fileDescriptor_311ef540e10d9705, []int{11}
 }
 func (m *Counter) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -684,7 +771,7 @@ func (m *PlatformData) Reset()         { *m = PlatformData{} }
 func (m *PlatformData) String() string { return proto.CompactTextString(m) }
 func (*PlatformData) ProtoMessage()    {}
 func (*PlatformData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{11}
+	return fileDescriptor_311ef540e10d9705, []int{12}
 }
 func (m *PlatformData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -727,7 +814,7 @@ func (m *UnixData) Reset()         { *m = UnixData{} }
 func (m *UnixData) String() string { return proto.CompactTextString(m) }
 func (*UnixData) ProtoMessage()    {}
 func (*UnixData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{12}
+	return fileDescriptor_311ef540e10d9705, []int{13}
 }
 func (m *UnixData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -768,7 +855,7 @@ func (m *WindowsData) Reset()         { *m = WindowsData{} }
 func (m *WindowsData) String() string { return proto.CompactTextString(m) }
 func (*WindowsData) ProtoMessage()    {}
 func (*WindowsData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{13}
+	return fileDescriptor_311ef540e10d9705, []int{14}
 }
 func (m *WindowsData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -805,7 +892,7 @@ func (m *XattrData) Reset()         { *m = XattrData{} }
 func (m *XattrData) String() string { return proto.CompactTextString(m) }
 func (*XattrData) ProtoMessage()    {}
 func (*XattrData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{14}
+	return fileDescriptor_311ef540e10d9705, []int{15}
 }
 func (m *XattrData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -843,7 +930,7 @@ func (m *Xattr) Reset()         { *m = Xattr{} }
 func (m *Xattr) String() string { return proto.CompactTextString(m) }
 func (*Xattr) ProtoMessage()    {}
 func (*Xattr) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{15}
+	return fileDescriptor_311ef540e10d9705, []int{16}
 }
 func (m *Xattr) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -888,7 +975,7 @@ func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{16}
+	return fileDescriptor_311ef540e10d9705, []int{17}
 }
 func (m *Request) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -927,7 +1014,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{17}
+	return fileDescriptor_311ef540e10d9705, []int{18}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -965,7 +1052,7 @@ func (m *DownloadProgress) Reset()         { *m = DownloadProgress{} }
 func (m *DownloadProgress) String() string { return proto.CompactTextString(m) }
 func (*DownloadProgress) ProtoMessage()    {}
 func (*DownloadProgress) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{18}
+	return fileDescriptor_311ef540e10d9705, []int{19}
 }
 func (m *DownloadProgress) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1006,7 +1093,7 @@ func (m *FileDownloadProgressUpdate) Reset()         { *m = FileDownloadProgress
 func (m *FileDownloadProgressUpdate) String() string { return proto.CompactTextString(m) }
 func (*FileDownloadProgressUpdate) ProtoMessage()    {}
 func (*FileDownloadProgressUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{19}
+	return fileDescriptor_311ef540e10d9705, []int{20}
 }
 func (m *FileDownloadProgressUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1042,7 +1129,7 @@ func (m *Ping) Reset()         { *m = Ping{} }
 func (m *Ping) String() string { return proto.CompactTextString(m) }
 func (*Ping) ProtoMessage()    {}
 func (*Ping) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{20}
+	return fileDescriptor_311ef540e10d9705, []int{21}
 }
 func (m *Ping) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1072,14 +1159,16 @@ func (m *Ping) XXX_DiscardUnknown() {
 var xxx_messageInfo_Ping proto.InternalMessageInfo
 
 type Close struct {
-	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason" xml:"reason"`
+	Reason string      `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason" xml:"reason"`
+	Code   CloseReason `protobuf:"varint,2,opt,name=code,proto3,enum=protocol.CloseReason" json:"code" xml:"code"`
+	Folder string      `protobuf:"bytes,3,opt,name=folder,proto3" json:"folder" xml:"folder"`
 }
 
 func (m *Close) Reset()         { *m = Close{} }
 func (m *Close) String() string { return proto.CompactTextString(m) }
 func (*Close) ProtoMessage()    {}
 func (*Close) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{21}
+	return fileDescriptor_311ef540e10d9705, []int{22}
 }
 func (m *Close) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1108,6 +1197,48 @@ func (m *Close) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Close proto.InternalMessageInfo
 
+type Control struct {
+	ID         int    `protobuf:"varint,1,opt,name=id,proto3,casttype=int" json:"id" xml:"id"`
+	ResponseTo int    `protobuf:"varint,2,opt,name=response_to,json=responseTo,proto3,casttype=int" json:"responseTo" xml:"responseTo"`
+	Type       string `protobuf:"bytes,3,opt,name=type,proto3" json:"type" xml:"type"`
+	Token      string `protobuf:"bytes,4,opt,name=token,proto3" json:"token" xml:"token"`
+	Payload    []byte `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload" xml:"payload"`
+	Error      string `protobuf:"bytes,6,opt,name=error,proto3" json:"error" xml:"error"`
+}
+
+func (m *Control) Reset()         { *m = Control{} }
+func (m *Control) String() string { return proto.CompactTextString(m) }
+func (*Control) ProtoMessage()    {}
+func (*Control) Descriptor() ([]byte, []int) {
+	return fileDescriptor_311ef540e10d9705, []int{23}
+}
+func (m *Control) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Control) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Control.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Control) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Control.Merge(m, src)
+}
+func (m *Control) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *Control) XXX_DiscardUnknown() {
+	xxx_messageInfo_Control.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Control proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterEnum("protocol.MessageType", MessageType_name, MessageType_value)
 	proto.RegisterEnum("protocol.MessageCompression", MessageCompression_name, MessageCompression_value)
@@ -1115,10 +1246,12 @@ func init() {
 	proto.RegisterEnum("protocol.FileInfoType", FileInfoType_name, FileInfoType_value)
 	proto.RegisterEnum("protocol.ErrorCode", ErrorCode_name, ErrorCode_value)
 	proto.RegisterEnum("protocol.FileDownloadProgressUpdateType", FileDownloadProgressUpdateType_name, FileDownloadProgressUpdateType_value)
+	proto.RegisterEnum("protocol.CloseReason", CloseReason_name, CloseReason_value)
 	proto.RegisterType((*Hello)(nil), "protocol.Hello")
 	proto.RegisterType((*Header)(nil), "protocol.Header")
 	proto.RegisterType((*ClusterConfig)(nil), "protocol.ClusterConfig")
 	proto.RegisterType((*Folder)(nil), "protocol.Folder")
+	proto.RegisterType((*FolderMetadata)(nil), "protocol.FolderMetadata")
 	proto.RegisterType((*Device)(nil), "protocol.Device")
 	proto.RegisterType((*Index)(nil), "protocol.Index")
 	proto.RegisterType((*IndexUpdate)(nil), "protocol.IndexUpdate")
@@ -1137,210 +1270,244 @@ func init() {
 	proto.RegisterType((*FileDownloadProgressUpdate)(nil), "protocol.FileDownloadProgressUpdate")
 	proto.RegisterType((*Ping)(nil), "protocol.Ping")
 	proto.RegisterType((*Close)(nil), "protocol.Close")
+	proto.RegisterType((*Control)(nil), "protocol.Control")
 }
 
 func init() { proto.RegisterFile("lib/protocol/bep.proto", fileDescriptor_311ef540e10d9705) }
 
 var fileDescriptor_311ef540e10d9705 = []byte{
-	// 3163 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x5a, 0x4d, 0x6c, 0x1b, 0xc7,
-	0xbd, 0x17, 0xc5, 0x0f, 0x51, 0x23, 0xc9, 0xa6, 0xc6, 0x5f, 0x0c, 0x6d, 0x6b, 0xf9, 0x26, 0xce,
-	0x7b, 0x8a, 0xf2, 0x62, 0x27, 0xca, 0xc7, 0xcb, 0x8b, 0xf3, 0x1c, 0x88, 0x22, 0x25, 0x33, 0x96,
-	0x49, 0x65, 0x28, 0xdb, 0xb1, 0xf1, 0x1e, 0x88, 0x15, 0x77, 0x44, 0x2d, 0x4c, 0xee, 0xf2, 0xed,
-	0x52, 0x5f, 0x41, 0x2f, 0x6d, 0x80, 0x20, 0xd0, 0xa1, 0x28, 0x72, 0x2a, 0x8a, 0x0a, 0x0d, 0x7a,
-	0xe9, 0xad, 0x40, 0x0f, 0xbd, 0xe4, 0xd4, 0xa3, 0x8f, 0x46, 0x80, 0x02, 0x45, 0x0f, 0x0b, 0xc4,
-	0xbe, 0xb4, 0xec, 0x8d, 0xc7, 0x9e, 0x8a, 0xf9, 0xcf, 0xec, 0xec, 0xac, 0x3e, 0x52, 0x39, 0x39,
-	0xf4, 0x64, 0xfe, 0x7f, 0xff, 0xdf, 0xff, 0xbf, 0xb3, 0xf3, 0xff, 0x9a, 0x59, 0x19, 0x5d, 0xec,
-	0xd8, 0xeb, 0x37, 0x7a, 0x9e, 0xdb, 0x77, 0x5b, 0x6e, 0xe7, 0xc6, 0x3a, 0xeb, 0x5d, 0x07, 0x01,
-	0x67, 0x43, 0xac, 0x30, 0xce, 0x76, 0xfb, 0x02, 0x2c, 0xbc, 0xec, 0xb1, 0x9e, 0xeb, 0x0b, 0xfa,
-	0xfa, 0xd6, 0xc6, 0x8d, 0xb6, 0xdb, 0x76, 0x41, 0x80, 0x5f, 0x82, 0x44, 0x9e, 0x25, 0x50, 0xfa,
-	0x36, 0xeb, 0x74, 0x5c, 0xbc, 0x88, 0x26, 0x2c, 0xb6, 0x6d, 0xb7, 0x58, 0xd3, 0x31, 0xbb, 0x2c,
-	0x9f, 0x28, 0x26, 0x66, 0xc7, 0x4b, 0x64, 0x10, 0x18, 0x48, 0xc0, 0x35, 0xb3, 0xcb, 0x86, 0x81,
-	0x91, 0xdb, 0xed, 0x76, 0xde, 0x27, 0x11, 0x44, 0xa8, 0xa6, 0xe7, 0x4e, 0x5a, 0x1d, 0x9b, 0x39,
-	0x7d, 0xe1, 0x64, 0x34, 0x72, 0x22, 0xe0, 0x98, 0x93, 0x08, 0x22, 0x54, 0xd3, 0xe3, 0x3a, 0x3a,
-	0x23, 0x9d, 0x6c, 0x33, 0xcf, 0xb7, 0x5d, 0x27, 0x9f, 0x04, 0x3f, 0xb3, 0x83, 0xc0, 0x98, 0x12,
-	0x9a, 0xfb, 0x42, 0x31, 0x0c, 0x8c, 0x73, 0x9a, 0x2b, 0x89, 0x12, 0x1a, 0x67, 0x91, 0xdf, 0x25,
-	0x50, 0xe6, 0x36, 0x33, 0x2d, 0xe6, 0xe1, 0x05, 0x94, 0xea, 0xef, 0xf5, 0xc4, 0xeb, 0x9d, 0x99,
-	0xbf, 0x70, 0x3d, 0xdc, 0xb8, 0xeb, 0x77, 0x99, 0xef, 0x9b, 0x6d, 0xb6, 0xb6, 0xd7, 0x63, 0xa5,
-	0x8b, 0x83, 0xc0, 0x00, 0xda, 0x30, 0x30, 0x10, 0xf8, 0xe7, 0x02, 0xa1, 0x80, 0x61, 0x0b, 0x4d,
-	0xb4, 0xdc, 0x6e, 0xcf, 0x63, 0x3e, 0xac, 0x6d, 0x14, 0x3c, 0x5d, 0x39, 0xe2, 0x69, 0x31, 0xe2,
-	0x94, 0xae, 0x0d, 0x02, 0x43, 0x37, 0x1a, 0x06, 0xc6, 0xb4, 0x58, 0x77, 0x84, 0x11, 0xaa, 0x33,
-	0xc8, 0xff, 0xa2, 0xa9, 0xc5, 0xce, 0x96, 0xdf, 0x67, 0xde, 0xa2, 0xeb, 0x6c, 0xd8, 0x6d, 0x7c,
-	0x07, 0x8d, 0x6d, 0xb8, 0x1d, 0x8b, 0x79, 0x7e, 0x3e, 0x51, 0x4c, 0xce, 0x4e, 0xcc, 0xe7, 0xa2,
-	0x47, 0x2e, 0x81, 0xa2, 0x64, 0x3c, 0x09, 0x8c, 0x91, 0x41, 0x60, 0x84, 0xc4, 0x61, 0x60, 0x4c,
-	0xc2, 0x63, 0x84, 0x4c, 0x68, 0xa8, 0x20, 0x5f, 0xa7, 0x50, 0x46, 0x18, 0xe1, 0xeb, 0x68, 0xd4,
-	0xb6, 0x64, 0xb8, 0x67, 0x9e, 0x05, 0xc6, 0x68, 0xb5, 0x3c, 0x08, 0x8c, 0x51, 0xdb, 0x1a, 0x06,
-	0x46, 0x16, 0xac, 0x6d, 0x8b, 0x7c, 0xf9, 0xf4, 0xda, 0x68, 0xb5, 0x4c, 0x47, 0x6d, 0x0b, 0x5f,
-	0x47, 0xe9, 0x8e, 0xb9, 0xce, 0x3a, 0x32, 0xb8, 0xf9, 0x41, 0x60, 0x08, 0x60, 0x18, 0x18, 0x13,
-	0xc0, 0x07, 0x89, 0x50, 0x81, 0xe2, 0x9b, 0x68, 0xdc, 0x63, 0xa6, 0xd5, 0x74, 0x9d, 0xce, 0x1e,
-	0x04, 0x32, 0x5b, 0x9a, 0x19, 0x04, 0x46, 0x96, 0x83, 0x75, 0xa7, 0xb3, 0x37, 0x0c, 0x8c, 0x33,
-	0x60, 0x16, 0x02, 0x84, 0x2a, 0x1d, 0x6e, 0x22, 0x6c, 0xb7, 0x1d, 0xd7, 0x63, 0xcd, 0x1e, 0xf3,
-	0xba, 0x36, 0x6c, 0x8d, 0x9f, 0x4f, 0x81, 0x97, 0x37, 0x06, 0x81, 0x31, 0x2d, 0xb4, 0xab, 0x91,
-	0x72, 0x18, 0x18, 0x97, 0xc4, 0xaa, 0x0f, 0x6b, 0x08, 0x3d, 0xca, 0xc6, 0x77, 0xd0, 0x94, 0x7c,
-	0x80, 0xc5, 0x3a, 0xac, 0xcf, 0xf2, 0x69, 0xf0, 0xfd, 0xef, 0x83, 0xc0, 0x98, 0x14, 0x8a, 0x32,
-	0xe0, 0xc3, 0xc0, 0xc0, 0x9a, 0x5b, 0x01, 0x12, 0x1a, 0xe3, 0x60, 0x0b, 0x9d, 0xb7, 0x6c, 0xdf,
-	0x5c, 0xef, 0xb0, 0x66, 0x9f, 0x75, 0x7b, 0x4d, 0xdb, 0xb1, 0xd8, 0x2e, 0xf3, 0xf3, 0x19, 0xf0,
-	0x39, 0x3f, 0x08, 0x0c, 0x2c, 0xf5, 0x6b, 0xac, 0xdb, 0xab, 0x0a, 0xed, 0x30, 0x30, 0xf2, 0xa2,
-	0xa6, 0x8e, 0xa8, 0x08, 0x3d, 0x86, 0x8f, 0xe7, 0x51, 0xa6, 0x67, 0x6e, 0xf9, 0xcc, 0xca, 0x8f,
-	0x81, 0xdf, 0xc2, 0x20, 0x30, 0x24, 0xa2, 0x02, 0x2e, 0x44, 0x42, 0x25, 0xce, 0x93, 0x47, 0x54,
-	0xa9, 0x9f, 0xcf, 0x1d, 0x4e, 0x9e, 0x32, 0x28, 0xa2, 0xe4, 0x91, 0x44, 0xe5, 0x4b, 0xc8, 0x84,
-	0x86, 0x0a, 0xf2, 0x87, 0x0c, 0xca, 0x08, 0x23, 0x5c, 0x52, 0xc9, 0x33, 0x59, 0x9a, 0xe7, 0x0e,
-	0xfe, 0x1c, 0x18, 0x59, 0xa1, 0xab, 0x96, 0x4f, 0x4a, 0xa6, 0x2f, 0x9e, 0x5e, 0x4b, 0x68, 0x09,
-	0x35, 0x87, 0x52, 0x5a, 0xb3, 0x80, 0xda, 0x73, 0x44, 0x9b, 0x10, 0xb5, 0xe7, 0x40, 0x83, 0x00,
-	0x0c, 0x7f, 0x80, 0xc6, 0x4d, 0xcb, 0xe2, 0x35, 0xc2, 0xfc, 0x7c, 0xb2, 0x98, 0xe4, 0x39, 0x3b,
-	0x08, 0x8c, 0x08, 0x1c, 0x06, 0xc6, 0x14, 0x58, 0x49, 0x84, 0xd0, 0x48, 0x87, 0xff, 0x2f, 0x5e,
-	0xb9, 0xa9, 0xc3, 0x3d, 0xe0, 0x87, 0x95, 0x2c, 0xcf, 0xf4, 0x16, 0xf3, 0x64, 0xeb, 0x4b, 0x8b,
-	0x82, 0xe2, 0x99, 0xce, 0x41, 0xd9, 0xf8, 0x44, 0xa6, 0x87, 0x00, 0xa1, 0x4a, 0x87, 0x97, 0xd1,
-	0x64, 0xd7, 0xdc, 0x6d, 0xfa, 0xec, 0xff, 0xb7, 0x98, 0xd3, 0x62, 0x90, 0x33, 0x49, 0xb1, 0x8a,
-	0xae, 0xb9, 0xdb, 0x90, 0xb0, 0x5a, 0x85, 0x86, 0x11, 0xaa, 0x33, 0x70, 0x09, 0x21, 0xdb, 0xe9,
-	0x7b, 0xae, 0xb5, 0xd5, 0x62, 0x9e, 0x4c, 0x11, 0xe8, 0xc0, 0x11, 0xaa, 0x3a, 0x70, 0x04, 0x11,
-	0xaa, 0xe9, 0x71, 0x1b, 0x65, 0x21, 0x77, 0x9b, 0xb6, 0x95, 0xcf, 0x16, 0x13, 0xb3, 0xa9, 0xd2,
-	0x8a, 0x0c, 0xee, 0x18, 0x64, 0x21, 0xc4, 0x36, 0xfc, 0xc9, 0x73, 0x06, 0xd8, 0x55, 0x4b, 0xed,
-	0xbe, 0x94, 0x79, 0xdf, 0x08, 0x69, 0xbf, 0x88, 0x7e, 0xd2, 0x90, 0x8f, 0x7f, 0x84, 0x0a, 0xfe,
-	0x63, 0x9b, 0x57, 0x8a, 0x78, 0x76, 0xdf, 0x76, 0x9d, 0xa6, 0xc7, 0xba, 0xee, 0xb6, 0xd9, 0xf1,
-	0xf3, 0xe3, 0xb0, 0xf8, 0x5b, 0x83, 0xc0, 0xc8, 0x73, 0x56, 0x55, 0x23, 0x51, 0xc9, 0x19, 0x06,
-	0xc6, 0x0c, 0x3c, 0xf1, 0x24, 0x02, 0xa1, 0x27, 0xda, 0xe2, 0x5d, 0xf4, 0x12, 0x73, 0x5a, 0xde,
-	0x5e, 0x0f, 0x1e, 0xdb, 0x33, 0x7d, 0x7f, 0xc7, 0xf5, 0xac, 0x66, 0xdf, 0x7d, 0xcc, 0x9c, 0x3c,
-	0x82, 0xa4, 0xfe, 0x60, 0x10, 0x18, 0x97, 0x22, 0xd2, 0xaa, 0xe4, 0xac, 0x71, 0xca, 0x30, 0x30,
-	0xae, 0xc2, 0xb3, 0x4f, 0xd0, 0x13, 0x7a, 0x92, 0x25, 0xf9, 0x49, 0x02, 0xa5, 0x61, 0x33, 0x78,
-	0x35, 0x8b, 0xa6, 0x2c, 0x5b, 0x30, 0x54, 0xb3, 0x40, 0x8e, 0xb4, 0x6f, 0x89, 0xe3, 0x0a, 0x4a,
-	0x6f, 0xd8, 0x1d, 0xe6, 0xe7, 0x47, 0xa1, 0x96, 0xb1, 0x36, 0x08, 0xec, 0x0e, 0xab, 0x3a, 0x1b,
-	0x6e, 0xe9, 0xb2, 0xac, 0x66, 0x41, 0x54, 0xb5, 0xc4, 0x25, 0x42, 0x05, 0x48, 0xbe, 0x48, 0xa0,
-	0x09, 0x58, 0xc4, 0xbd, 0x9e, 0x65, 0xf6, 0xd9, 0xbf, 0x72, 0x29, 0x9f, 0x4f, 0xa1, 0x6c, 0x68,
-	0xa0, 0x1a, 0x42, 0xe2, 0x14, 0x0d, 0x61, 0x0e, 0xa5, 0x7c, 0xfb, 0x53, 0x06, 0x83, 0x25, 0x29,
-	0xb8, 0x5c, 0x56, 0x5c, 0x2e, 0x10, 0x0a, 0x18, 0xfe, 0x10, 0xa1, 0xae, 0x6b, 0xd9, 0x1b, 0x36,
-	0xb3, 0x9a, 0x3e, 0x14, 0x68, 0xb2, 0x54, 0xe4, 0xdd, 0x23, 0x44, 0x1b, 0xc3, 0xc0, 0x38, 0x2b,
-	0xca, 0x2b, 0x44, 0x08, 0x8d, 0xb4, 0xbc, 0x7f, 0x28, 0x07, 0xeb, 0x7b, 0xf9, 0x49, 0xa8, 0x8c,
-	0x0f, 0xc2, 0xca, 0x68, 0x6c, 0xba, 0x5e, 0x1f, 0xca, 0x41, 0x3d, 0xa6, 0xb4, 0xa7, 0x4a, 0x2d,
-	0x82, 0x08, 0xaf, 0x04, 0x49, 0xa6, 0x1a, 0x15, 0xaf, 0xa0, 0xb1, 0xf0, 0xc0, 0xc3, 0x33, 0x3f,
-	0xd6, 0xa4, 0xef, 0xb3, 0x56, 0xdf, 0xf5, 0x4a, 0xc5, 0xb0, 0x49, 0x6f, 0xab, 0x03, 0x90, 0x28,
-	0xb8, 0xed, 0xf0, 0xe8, 0x13, 0x6a, 0xf0, 0xfb, 0x28, 0xab, 0x9a, 0x09, 0x82, 0x77, 0x85, 0x66,
-	0xe4, 0x47, 0x9d, 0x44, 0x34, 0x23, 0x5f, 0xb5, 0x11, 0xa5, 0xc3, 0x1f, 0xa1, 0xcc, 0x7a, 0xc7,
-	0x6d, 0x3d, 0x0e, 0xa7, 0xc5, 0xb9, 0x68, 0x21, 0x25, 0x8e, 0x43, 0x5c, 0xaf, 0xca, 0xb5, 0x48,
-	0xaa, 0x1a, 0xff, 0x20, 0x12, 0x2a, 0x61, 0x7e, 0x9a, 0xf3, 0xf7, 0xba, 0x1d, 0xdb, 0x79, 0xdc,
-	0xec, 0x9b, 0x5e, 0x9b, 0xf5, 0xf3, 0xd3, 0xd1, 0x69, 0x4e, 0x6a, 0xd6, 0x40, 0xa1, 0x4e, 0x73,
-	0x31, 0x94, 0xd0, 0x38, 0x8b, 0x9f, 0x31, 0x85, 0xeb, 0xe6, 0xa6, 0xe9, 0x6f, 0xe6, 0x31, 0xd4,
-	0x29, 0x74, 0x38, 0x01, 0xdf, 0x36, 0xfd, 0x4d, 0xb5, 0xed, 0x11, 0x44, 0xa8, 0xa6, 0xc7, 0xb7,
-	0xd0, 0xb8, 0xac, 0x4d, 0x66, 0xe5, 0xcf, 0x81, 0x0b, 0x48, 0x05, 0x05, 0xaa, 0x54, 0x50, 0x08,
-	0xa1, 0x91, 0x16, 0x97, 0xe4, 0x39, 0x52, 0x9c, 0xfe, 0x2e, 0x1e, 0x4d, 0xfb, 0x53, 0x1c, 0x24,
-	0x97, 0xd0, 0xc4, 0xe1, 0x53, 0xcd, 0x94, 0xe8, 0xf8, 0xbd, 0xd8, 0x79, 0x46, 0x74, 0xfc, 0x9e,
-	0x7e, 0x92, 0xd1, 0x19, 0xf8, 0x23, 0x2d, 0x2d, 0x1d, 0x3f, 0x3f, 0x51, 0x4c, 0xcc, 0xa6, 0x4b,
-	0xaf, 0xea, 0x79, 0x58, 0xf3, 0x8f, 0xe4, 0x61, 0xcd, 0x27, 0x7f, 0x0f, 0x8c, 0xa4, 0xed, 0xf4,
-	0xa9, 0x46, 0xc3, 0x1b, 0x48, 0xec, 0x52, 0x13, 0xaa, 0x6a, 0x0a, 0x5c, 0x2d, 0x3f, 0x0b, 0x8c,
-	0x49, 0x6a, 0xee, 0x40, 0xe8, 0x1b, 0xf6, 0xa7, 0x8c, 0x6f, 0xd4, 0x7a, 0x28, 0xa8, 0x8d, 0x52,
-	0x48, 0xe8, 0xf8, 0xcb, 0xa7, 0xd7, 0x62, 0x66, 0x34, 0x32, 0xc2, 0xf7, 0x51, 0xb6, 0xd7, 0x31,
-	0xfb, 0x1b, 0xae, 0xd7, 0xcd, 0x9f, 0x81, 0x64, 0xd7, 0xf6, 0x70, 0x55, 0x6a, 0xca, 0x66, 0xdf,
-	0x2c, 0x11, 0x99, 0x66, 0x8a, 0xaf, 0x32, 0x37, 0x04, 0x08, 0x55, 0x3a, 0x5c, 0x46, 0x13, 0x1d,
-	0xb7, 0x65, 0x76, 0x9a, 0x1b, 0x1d, 0xb3, 0xed, 0xe7, 0xff, 0x32, 0x06, 0x9b, 0x0a, 0xd9, 0x01,
-	0xf8, 0x12, 0x87, 0xd5, 0x66, 0x44, 0x10, 0xa1, 0x9a, 0x1e, 0xdf, 0x46, 0x93, 0xb2, 0x8c, 0x44,
-	0x8e, 0xfd, 0x75, 0x0c, 0x32, 0x04, 0x62, 0x23, 0x15, 0x32, 0xcb, 0xa6, 0xf5, 0xea, 0x13, 0x69,
-	0xa6, 0x33, 0xf0, 0xc7, 0xe8, 0xac, 0xed, 0xb8, 0x16, 0x6b, 0xb6, 0x36, 0x4d, 0xa7, 0xcd, 0x78,
-	0x7c, 0x06, 0x63, 0x50, 0x8d, 0x90, 0xff, 0xa0, 0x5b, 0x04, 0x15, 0xc4, 0xe8, 0x9c, 0x9c, 0x9e,
-	0x1a, 0x4a, 0x68, 0x9c, 0x85, 0x77, 0x91, 0x36, 0x56, 0x9a, 0x7d, 0xcf, 0xb4, 0x3b, 0xcc, 0x13,
-	0xf1, 0xfa, 0xdb, 0x18, 0x04, 0xec, 0xc3, 0x41, 0x60, 0x5c, 0x88, 0x38, 0x6b, 0x82, 0x22, 0x83,
-	0x75, 0xf9, 0xd0, 0xc8, 0xd2, 0xb4, 0x2a, 0x23, 0x8e, 0x37, 0xc6, 0xef, 0xf2, 0x53, 0x24, 0x3f,
-	0xe9, 0x5a, 0xf2, 0x48, 0x7b, 0x45, 0x9c, 0x17, 0x01, 0x52, 0xad, 0x48, 0xca, 0x70, 0x60, 0x84,
-	0x5f, 0x98, 0xa2, 0x31, 0xdb, 0xd9, 0x36, 0x3b, 0x76, 0x78, 0x64, 0x7d, 0xef, 0x59, 0x60, 0x20,
-	0x6a, 0xee, 0x54, 0x05, 0x2a, 0x4e, 0x10, 0xf0, 0x53, 0x3b, 0x41, 0x80, 0xcc, 0x4f, 0x10, 0x1a,
-	0x93, 0x86, 0x3c, 0xde, 0x56, 0x1c, 0x37, 0x76, 0x2b, 0xc8, 0x82, 0x6b, 0xd8, 0x56, 0xc7, 0x8d,
-	0xdf, 0x08, 0xc4, 0xb6, 0xc6, 0x50, 0x42, 0xe3, 0xac, 0xf7, 0x53, 0x3f, 0xff, 0xca, 0x18, 0x21,
-	0xdf, 0x26, 0xd0, 0xb8, 0x6a, 0x71, 0x7c, 0xba, 0x40, 0xfc, 0x93, 0x10, 0x7e, 0xa8, 0xe6, 0x4d,
-	0x11, 0x77, 0x51, 0xcd, 0x9b, 0x10, 0x70, 0xc0, 0xf8, 0xf4, 0x74, 0x37, 0x36, 0x7c, 0xd6, 0x87,
-	0xb9, 0x95, 0x14, 0xd3, 0x53, 0x20, 0x6a, 0x7a, 0x0a, 0x91, 0x50, 0x89, 0xe3, 0x37, 0xe5, 0xf4,
-	0x1a, 0x85, 0xb0, 0x5d, 0x3d, 0x7e, 0x7a, 0x85, 0x41, 0x11, 0x43, 0xec, 0x26, 0x1a, 0xdf, 0x61,
-	0xe6, 0x63, 0x91, 0x97, 0xa2, 0x65, 0x40, 0x5f, 0xe7, 0xa0, 0xcc, 0x49, 0x51, 0x1d, 0x21, 0x40,
-	0xa8, 0xd2, 0xc9, 0x77, 0x7c, 0x84, 0x32, 0x62, 0x9c, 0xe0, 0x55, 0x94, 0x6d, 0xb9, 0x5b, 0x4e,
-	0x3f, 0xba, 0x54, 0x4e, 0xeb, 0xa7, 0x61, 0xd0, 0x94, 0xfe, 0x2d, 0x2c, 0xc0, 0x90, 0xaa, 0x62,
-	0x24, 0x01, 0x7e, 0x8c, 0x95, 0x2a, 0xf2, 0x59, 0x02, 0x8d, 0x49, 0x43, 0x7c, 0x5b, 0x5d, 0x0e,
-	0x52, 0xa5, 0xf7, 0x0e, 0x4d, 0xc9, 0xef, 0xbe, 0x68, 0xea, 0x13, 0x52, 0xde, 0x39, 0xb7, 0xcd,
-	0xce, 0x96, 0xd8, 0xa8, 0x94, 0xb8, 0x73, 0x02, 0xa0, 0x86, 0x0e, 0x48, 0x84, 0x0a, 0x94, 0x7c,
-	0x96, 0x42, 0x93, 0x7a, 0x13, 0xe1, 0xed, 0x7a, 0xcb, 0xb1, 0x77, 0x61, 0x31, 0xb1, 0x53, 0xca,
-	0x3d, 0xc7, 0xde, 0x85, 0x36, 0x53, 0x78, 0x12, 0x18, 0x09, 0x1e, 0x00, 0xce, 0x53, 0x01, 0xe0,
-	0x02, 0xa1, 0x80, 0xe1, 0x8f, 0xd1, 0xd8, 0x8e, 0xed, 0x58, 0xee, 0x8e, 0x0f, 0xcb, 0x98, 0xd0,
-	0x6f, 0x0e, 0x0f, 0x84, 0x02, 0x3c, 0x15, 0xa5, 0xa7, 0x90, 0xad, 0xb6, 0x4b, 0xca, 0x84, 0x86,
-	0x1a, 0xbc, 0x8c, 0xd2, 0x1d, 0xdb, 0xd9, 0xda, 0x85, 0x04, 0x8b, 0x8d, 0xd9, 0x4f, 0xcc, 0x7e,
-	0xdf, 0x03, 0x77, 0x57, 0xa4, 0x3b, 0xc1, 0x8c, 0x2e, 0xd9, 0x5c, 0xe2, 0x97, 0x6c, 0xfe, 0x2f,
-	0xbe, 0x83, 0x32, 0x96, 0xe9, 0xed, 0xd8, 0xe2, 0x52, 0x73, 0x82, 0xa7, 0x19, 0xe9, 0x49, 0x52,
-	0xa3, 0x0b, 0x1e, 0x88, 0x84, 0x4a, 0x1c, 0x33, 0x34, 0xb6, 0xe1, 0x31, 0xb6, 0xee, 0x5b, 0x70,
-	0x48, 0x3a, 0xc1, 0xdb, 0xbb, 0xdc, 0x1b, 0xbf, 0x06, 0x2c, 0x79, 0x8c, 0x95, 0x1a, 0x70, 0x0d,
-	0x90, 0x66, 0xea, 0x8d, 0xa5, 0x0c, 0xd7, 0x00, 0x49, 0xa3, 0x21, 0x09, 0x37, 0x51, 0xc6, 0x61,
-	0x7d, 0xfe, 0x94, 0xcc, 0xc9, 0x4f, 0x99, 0x97, 0x4f, 0xc9, 0xd4, 0x58, 0x5f, 0x3c, 0x44, 0x1a,
-	0xa9, 0xd5, 0x0b, 0x91, 0x3f, 0x42, 0x72, 0xa8, 0x64, 0x90, 0xcf, 0x47, 0x51, 0x36, 0x8c, 0x2f,
-	0x3f, 0xfc, 0xb9, 0x3b, 0x0e, 0xf3, 0xf4, 0xaf, 0x5b, 0x30, 0xf1, 0x01, 0x95, 0xd7, 0x33, 0x31,
-	0xc8, 0x14, 0x42, 0x68, 0xa4, 0xe5, 0x0e, 0xda, 0x9e, 0xbb, 0xd5, 0xd3, 0xbf, 0x6c, 0x81, 0x03,
-	0x40, 0x63, 0x0e, 0x14, 0x42, 0x68, 0xa4, 0xc5, 0x37, 0x51, 0x72, 0xcb, 0xb6, 0x20, 0xd4, 0xe9,
-	0xd2, 0xab, 0xcf, 0x02, 0x23, 0x79, 0x0f, 0x2a, 0x80, 0xa3, 0xc3, 0xc0, 0x18, 0x17, 0x09, 0x67,
-	0x5b, 0xda, 0xf8, 0xe4, 0x0c, 0xca, 0xf5, 0xdc, 0xb8, 0x6d, 0x5b, 0x10, 0x5d, 0x69, 0xbc, 0x2c,
-	0x8c, 0xdb, 0x9a, 0x71, 0x3b, 0x6e, 0xbc, 0xcc, 0x8d, 0x39, 0xf6, 0xcb, 0x04, 0x9a, 0xd0, 0x32,
-	0xf4, 0x87, 0xef, 0xc5, 0x0a, 0x3a, 0x23, 0x1c, 0xd8, 0x7e, 0x13, 0x5e, 0x10, 0xf6, 0x43, 0x7e,
-	0x36, 0x01, 0x4d, 0xd5, 0x5f, 0xe6, 0xb8, 0xfa, 0x6c, 0xa2, 0x83, 0x84, 0xc6, 0x38, 0xa4, 0x81,
-	0xc6, 0x55, 0xc0, 0xf1, 0x12, 0xca, 0xec, 0x72, 0x21, 0x6c, 0x48, 0x67, 0x0f, 0x65, 0x45, 0x74,
-	0xec, 0x14, 0x34, 0x55, 0x10, 0x20, 0x12, 0x2a, 0x61, 0xd2, 0x42, 0x69, 0xe0, 0xbf, 0xd0, 0x6d,
-	0x22, 0xd6, 0x67, 0x26, 0xff, 0x79, 0x9f, 0xf9, 0x71, 0x0a, 0x8d, 0x51, 0x7e, 0x68, 0xf6, 0xfb,
-	0xf8, 0x1d, 0xd5, 0xed, 0xd2, 0xa5, 0x57, 0x4e, 0x6a, 0x6f, 0x51, 0x74, 0xc2, 0xaf, 0x1f, 0xd1,
-	0xa5, 0x6b, 0xf4, 0xd4, 0x97, 0xae, 0xf0, 0x95, 0x92, 0xa7, 0x78, 0xa5, 0x68, 0x2c, 0xa5, 0x5e,
-	0x78, 0x2c, 0xa5, 0x4f, 0x3f, 0x96, 0xc2, 0x49, 0x99, 0x39, 0xc5, 0xa4, 0xac, 0xa3, 0x33, 0x1b,
-	0x9e, 0xdb, 0x85, 0x6f, 0x64, 0xae, 0x67, 0x7a, 0x7b, 0xf2, 0x54, 0x00, 0xa3, 0x9b, 0x6b, 0xd6,
-	0x42, 0x85, 0x1a, 0xdd, 0x31, 0x94, 0xd0, 0x38, 0x2b, 0x3e, 0x13, 0xb3, 0x2f, 0x36, 0x13, 0xf1,
-	0x2d, 0x94, 0x15, 0x27, 0x5e, 0xc7, 0x85, 0x6b, 0x57, 0xba, 0xf4, 0x32, 0x6f, 0x65, 0x80, 0xd5,
-	0x5c, 0xd5, 0xca, 0xa4, 0xac, 0x5e, 0x3b, 0x24, 0x90, 0xdf, 0x26, 0x50, 0x96, 0x32, 0xbf, 0xe7,
-	0x3a, 0x3e, 0xfb, 0xbe, 0x49, 0x30, 0x87, 0x52, 0x96, 0xd9, 0x37, 0x65, 0xda, 0xc1, 0xee, 0x71,
-	0x59, 0xed, 0x1e, 0x17, 0x08, 0x05, 0x0c, 0x7f, 0x88, 0x52, 0x2d, 0xd7, 0x12, 0xc1, 0x3f, 0xa3,
-	0x37, 0xcd, 0x8a, 0xe7, 0xb9, 0xde, 0xa2, 0x6b, 0xc9, 0x6b, 0x07, 0x27, 0x29, 0x07, 0x5c, 0x20,
-	0x14, 0x30, 0xf2, 0x9b, 0x04, 0xca, 0x95, 0xdd, 0x1d, 0xa7, 0xe3, 0x9a, 0xd6, 0xaa, 0xe7, 0xb6,
-	0x3d, 0xe6, 0xfb, 0xdf, 0xeb, 0xee, 0xdf, 0x44, 0x63, 0x5b, 0xf0, 0xe5, 0x20, 0xbc, 0xfd, 0x5f,
-	0x8b, 0x5f, 0x83, 0x0e, 0x3f, 0x44, 0x7c, 0x66, 0x88, 0x3e, 0x34, 0x4a, 0x63, 0xe5, 0x5f, 0xc8,
-	0x84, 0x86, 0x0a, 0xf2, 0xeb, 0x24, 0x2a, 0x9c, 0xec, 0x08, 0x77, 0xd1, 0x84, 0x60, 0x36, 0xb5,
-	0x4f, 0xfa, 0xb3, 0xa7, 0x59, 0x03, 0x5c, 0xce, 0xe0, 0x52, 0xb0, 0xa5, 0x64, 0x75, 0x29, 0x88,
-	0x20, 0x42, 0x35, 0xfd, 0x0b, 0x7d, 0xa7, 0xd4, 0xae, 0xf2, 0xc9, 0x1f, 0x7e, 0x95, 0x6f, 0xa0,
-	0x29, 0x91, 0xa2, 0xe1, 0x07, 0xe5, 0x54, 0x31, 0x39, 0x9b, 0x2e, 0x5d, 0xe7, 0xdd, 0x76, 0x5d,
-	0x1c, 0x56, 0xc3, 0x4f, 0xc9, 0xd3, 0x51, 0xb2, 0x0a, 0x30, 0xcc, 0xb6, 0xdc, 0x08, 0x8d, 0x71,
-	0xf1, 0x52, 0xec, 0xa6, 0x27, 0x4a, 0xfd, 0x3f, 0x4e, 0x79, 0xb3, 0xd3, 0x6e, 0x72, 0x24, 0x83,
-	0x52, 0xab, 0xb6, 0xd3, 0x26, 0x37, 0x51, 0x7a, 0xb1, 0xe3, 0xfa, 0xd0, 0x71, 0x3c, 0x66, 0xfa,
-	0xae, 0xa3, 0xa7, 0x92, 0x40, 0x54, 0xa8, 0x85, 0x48, 0xa8, 0xc4, 0xe7, 0xbe, 0x4e, 0xa2, 0x09,
-	0xed, 0x2f, 0x30, 0xf8, 0x7f, 0xd0, 0xe5, 0xbb, 0x95, 0x46, 0x63, 0x61, 0xb9, 0xd2, 0x5c, 0x7b,
-	0xb8, 0x5a, 0x69, 0x2e, 0xae, 0xdc, 0x6b, 0xac, 0x55, 0x68, 0x73, 0xb1, 0x5e, 0x5b, 0xaa, 0x2e,
-	0xe7, 0x46, 0x0a, 0x57, 0xf6, 0x0f, 0x8a, 0x79, 0xcd, 0x22, 0xfe, 0xb7, 0x92, 0xff, 0x44, 0x38,
-	0x66, 0x5e, 0xad, 0x95, 0x2b, 0x9f, 0xe4, 0x12, 0x85, 0xf3, 0xfb, 0x07, 0xc5, 0x9c, 0x66, 0x25,
-	0x3e, 0xc1, 0xfd, 0x37, 0x7a, 0xe9, 0x28, 0xbb, 0x79, 0x6f, 0xb5, 0xbc, 0xb0, 0x56, 0xc9, 0x8d,
-	0x16, 0x0a, 0xfb, 0x07, 0xc5, 0x8b, 0x87, 0x8d, 0x64, 0x0a, 0xbe, 0x81, 0xce, 0xc7, 0x4c, 0x69,
-	0xe5, 0xe3, 0x7b, 0x95, 0xc6, 0x5a, 0x2e, 0x59, 0xb8, 0xb8, 0x7f, 0x50, 0xc4, 0x9a, 0x55, 0x38,
-	0x26, 0xe6, 0xd1, 0x85, 0x43, 0x16, 0x8d, 0xd5, 0x7a, 0xad, 0x51, 0xc9, 0xa5, 0x0a, 0x97, 0xf6,
-	0x0f, 0x8a, 0xe7, 0x62, 0x26, 0xb2, 0xab, 0x2c, 0xa2, 0x99, 0x98, 0x4d, 0xb9, 0xfe, 0xa0, 0xb6,
-	0x52, 0x5f, 0x28, 0x37, 0x57, 0x69, 0x7d, 0x99, 0x56, 0x1a, 0x8d, 0x5c, 0xba, 0x60, 0xec, 0x1f,
-	0x14, 0x2f, 0x6b, 0xc6, 0x47, 0x2a, 0x7c, 0x0e, 0x4d, 0xc7, 0x9c, 0xac, 0x56, 0x6b, 0xcb, 0xb9,
-	0x4c, 0xe1, 0xdc, 0xfe, 0x41, 0xf1, 0xac, 0x66, 0xc7, 0x63, 0x79, 0x64, 0xff, 0x16, 0x57, 0xea,
-	0x8d, 0x4a, 0x6e, 0xec, 0xc8, 0xfe, 0x41, 0xc0, 0xe7, 0x7e, 0x95, 0x40, 0xf8, 0xe8, 0x1f, 0xbd,
-	0xf0, 0x7b, 0x28, 0x1f, 0x3a, 0x59, 0xac, 0xdf, 0x5d, 0xe5, 0xeb, 0xac, 0xd6, 0x6b, 0xcd, 0x5a,
-	0xbd, 0x56, 0xc9, 0x8d, 0xc4, 0x76, 0x55, 0xb3, 0xaa, 0xb9, 0x0e, 0xc3, 0x75, 0x74, 0xe9, 0x38,
-	0xcb, 0x95, 0x47, 0x6f, 0xe7, 0x12, 0x85, 0xf9, 0xfd, 0x83, 0xe2, 0x85, 0xa3, 0x86, 0x2b, 0x8f,
-	0xde, 0xfe, 0xe6, 0xa7, 0xaf, 0x1c, 0xaf, 0x98, 0xe3, 0x07, 0x20, 0x7d, 0x69, 0x6f, 0xa2, 0xf3,
-	0xba, 0xe3, 0xbb, 0x95, 0xb5, 0x85, 0xf2, 0xc2, 0xda, 0x42, 0x6e, 0x44, 0xc4, 0x40, 0xa3, 0xde,
-	0x65, 0x7d, 0x13, 0xda, 0xee, 0x6b, 0x68, 0x3a, 0xf6, 0x16, 0x95, 0xfb, 0x15, 0x1a, 0x66, 0x94,
-	0xbe, 0x7e, 0xb6, 0xcd, 0x3c, 0xfc, 0x3a, 0xc2, 0x3a, 0x79, 0x61, 0xe5, 0xc1, 0xc2, 0xc3, 0x46,
-	0x6e, 0xb4, 0x70, 0x61, 0xff, 0xa0, 0x38, 0xad, 0xb1, 0x17, 0x3a, 0x3b, 0xe6, 0x9e, 0x3f, 0xf7,
-	0xfb, 0x51, 0x34, 0xa9, 0x7f, 0x37, 0xc2, 0xaf, 0xa3, 0x73, 0x4b, 0xd5, 0x15, 0x9e, 0x89, 0x4b,
-	0x75, 0x11, 0x01, 0x2e, 0xe6, 0x46, 0xc4, 0xe3, 0x74, 0x2a, 0xff, 0x8d, 0xff, 0x0b, 0xe5, 0x0f,
-	0xd1, 0xcb, 0x55, 0x5a, 0x59, 0x5c, 0xab, 0xd3, 0x87, 0xb9, 0x44, 0xe1, 0x25, 0xbe, 0x61, 0xba,
-	0x4d, 0xd9, 0xf6, 0xa0, 0x05, 0xed, 0xe1, 0x5b, 0xe8, 0xf2, 0x21, 0xc3, 0xc6, 0xc3, 0xbb, 0x2b,
-	0xd5, 0xda, 0x1d, 0xf1, 0xbc, 0xd1, 0xc2, 0xd5, 0xfd, 0x83, 0xe2, 0x25, 0xdd, 0xb6, 0x21, 0x3e,
-	0xc5, 0x71, 0x28, 0x9b, 0xc0, 0xb7, 0x51, 0xf1, 0x04, 0xfb, 0x68, 0x01, 0xc9, 0x02, 0xd9, 0x3f,
-	0x28, 0x5e, 0x39, 0xc6, 0x89, 0x5a, 0x47, 0x36, 0x81, 0xdf, 0x42, 0x17, 0x8f, 0xf7, 0x14, 0xd6,
-	0xc5, 0x31, 0xf6, 0x73, 0x7f, 0x4c, 0xa0, 0x71, 0x35, 0xf5, 0xf8, 0xa6, 0x55, 0x28, 0xad, 0xf3,
-	0x26, 0x51, 0xae, 0x34, 0x6b, 0xf5, 0x26, 0x48, 0xe1, 0xa6, 0x29, 0x5e, 0xcd, 0x85, 0x9f, 0x3c,
-	0xc7, 0x35, 0xfa, 0x72, 0xa5, 0x56, 0xa1, 0xd5, 0xc5, 0x30, 0xa2, 0x8a, 0xbd, 0xcc, 0x1c, 0xe6,
-	0xd9, 0x2d, 0xfc, 0x36, 0xba, 0x14, 0x77, 0xde, 0xb8, 0xb7, 0x78, 0x3b, 0xdc, 0x25, 0x58, 0xa0,
-	0xf6, 0x80, 0xc6, 0x56, 0x6b, 0x13, 0x02, 0xf3, 0x4e, 0xcc, 0xaa, 0x5a, 0xbb, 0xbf, 0xb0, 0x52,
-	0x2d, 0x0b, 0xab, 0x64, 0x21, 0xbf, 0x7f, 0x50, 0x3c, 0xaf, 0xac, 0xe4, 0x07, 0x0e, 0x6e, 0x36,
-	0xf7, 0x4d, 0x02, 0xcd, 0x7c, 0xf7, 0xf0, 0xc2, 0x0f, 0xd0, 0xab, 0xb0, 0x5f, 0x47, 0x5a, 0x81,
-	0xec, 0x5b, 0x62, 0x0f, 0x17, 0x56, 0x57, 0x2b, 0xb5, 0x72, 0x6e, 0xa4, 0x30, 0xbb, 0x7f, 0x50,
-	0xbc, 0xf6, 0xdd, 0x2e, 0x17, 0x7a, 0x3d, 0xe6, 0x58, 0xa7, 0x74, 0xbc, 0x54, 0xa7, 0xcb, 0x95,
-	0xb5, 0x5c, 0xe2, 0x34, 0x8e, 0x97, 0x5c, 0xaf, 0xcd, 0xfa, 0xa5, 0xbb, 0x4f, 0xbe, 0x9d, 0x19,
-	0x79, 0xfa, 0xed, 0xcc, 0xc8, 0x93, 0x67, 0x33, 0x89, 0xa7, 0xcf, 0x66, 0x12, 0x3f, 0x7b, 0x3e,
-	0x33, 0xf2, 0xd5, 0xf3, 0x99, 0xc4, 0xd3, 0xe7, 0x33, 0x23, 0x7f, 0x7a, 0x3e, 0x33, 0xf2, 0xe8,
-	0xb5, 0xb6, 0xdd, 0xdf, 0xdc, 0x5a, 0xbf, 0xde, 0x72, 0xbb, 0x37, 0xfc, 0x3d, 0xa7, 0xd5, 0xdf,
-	0xb4, 0x9d, 0xb6, 0xf6, 0x4b, 0xff, 0xcf, 0x0f, 0xeb, 0x19, 0xf8, 0xf5, 0xd6, 0x3f, 0x02, 0x00,
-	0x00, 0xff, 0xff, 0x68, 0x4a, 0x6e, 0xeb, 0x13, 0x21, 0x00, 0x00,
+	// 3693 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x5a, 0x4b, 0x6c, 0x24, 0xc7,
+	0x79, 0xe6, 0xbc, 0xc8, 0x61, 0x91, 0xbb, 0x3b, 0x5b, 0xfb, 0x1a, 0xcd, 0xee, 0xb2, 0x47, 0xe5,
+	0x75, 0xb2, 0xa2, 0xed, 0x5d, 0x9b, 0x96, 0x1d, 0x45, 0x96, 0x25, 0x70, 0x1e, 0x24, 0x47, 0x4b,
+	0xce, 0x50, 0x35, 0xc3, 0x5d, 0xad, 0x90, 0xa0, 0xd1, 0x9c, 0x2e, 0x0e, 0x1b, 0x3b, 0xd3, 0x3d,
+	0xee, 0x6e, 0x2e, 0x49, 0x23, 0x97, 0xc4, 0x41, 0x60, 0xf0, 0x10, 0x04, 0x3e, 0x05, 0x41, 0x88,
+	0x18, 0xb9, 0xe4, 0x16, 0x20, 0x01, 0x72, 0xcf, 0x21, 0x07, 0x9d, 0x82, 0x85, 0x81, 0x00, 0x41,
+	0x0e, 0x0d, 0x68, 0x75, 0x49, 0x26, 0xca, 0x85, 0xc7, 0x9c, 0x82, 0xfa, 0xab, 0xba, 0xba, 0x9a,
+	0x0f, 0x85, 0x92, 0x0e, 0x3e, 0x71, 0xfe, 0xef, 0x7f, 0xd4, 0xeb, 0x7f, 0x54, 0xfd, 0x4d, 0x74,
+	0x7b, 0xe8, 0x6c, 0x3f, 0x1e, 0xfb, 0x5e, 0xe8, 0xf5, 0xbd, 0xe1, 0xe3, 0x6d, 0x36, 0x7e, 0x04,
+	0x04, 0x2e, 0xc6, 0x58, 0x65, 0x96, 0x1d, 0x84, 0x02, 0xac, 0x7c, 0xcb, 0x67, 0x63, 0x2f, 0x10,
+	0xe2, 0xdb, 0x7b, 0x3b, 0x8f, 0x07, 0xde, 0xc0, 0x03, 0x02, 0x7e, 0x09, 0x21, 0xf2, 0x3a, 0x83,
+	0x0a, 0x6b, 0x6c, 0x38, 0xf4, 0x70, 0x1d, 0xcd, 0xd9, 0xec, 0xa5, 0xd3, 0x67, 0xa6, 0x6b, 0x8d,
+	0x58, 0x39, 0x53, 0xcd, 0x3c, 0x9c, 0xad, 0x91, 0x49, 0x64, 0x20, 0x01, 0xb7, 0xad, 0x11, 0x3b,
+	0x89, 0x8c, 0xd2, 0xc1, 0x68, 0xf8, 0x2e, 0x49, 0x20, 0x42, 0x35, 0x3e, 0x37, 0xd2, 0x1f, 0x3a,
+	0xcc, 0x0d, 0x85, 0x91, 0x6c, 0x62, 0x44, 0xc0, 0x29, 0x23, 0x09, 0x44, 0xa8, 0xc6, 0xc7, 0x1d,
+	0x74, 0x55, 0x1a, 0x79, 0xc9, 0xfc, 0xc0, 0xf1, 0xdc, 0x72, 0x0e, 0xec, 0x3c, 0x9c, 0x44, 0xc6,
+	0x15, 0xc1, 0x79, 0x2a, 0x18, 0x27, 0x91, 0x71, 0x43, 0x33, 0x25, 0x51, 0x42, 0xd3, 0x52, 0xe4,
+	0x1f, 0x32, 0x68, 0x7a, 0x8d, 0x59, 0x36, 0xf3, 0xf1, 0x32, 0xca, 0x87, 0x87, 0x63, 0xb1, 0xbc,
+	0xab, 0x4b, 0xb7, 0x1e, 0xc5, 0x1b, 0xf7, 0x68, 0x83, 0x05, 0x81, 0x35, 0x60, 0xbd, 0xc3, 0x31,
+	0xab, 0xdd, 0x9e, 0x44, 0x06, 0x88, 0x9d, 0x44, 0x06, 0x02, 0xfb, 0x9c, 0x20, 0x14, 0x30, 0x6c,
+	0xa3, 0xb9, 0xbe, 0x37, 0x1a, 0xfb, 0x2c, 0x80, 0xb9, 0x65, 0xc1, 0xd2, 0xbd, 0x33, 0x96, 0xea,
+	0x89, 0x4c, 0xed, 0xc1, 0x24, 0x32, 0x74, 0xa5, 0x93, 0xc8, 0xb8, 0x2e, 0xe6, 0x9d, 0x60, 0x84,
+	0xea, 0x12, 0xe4, 0x0f, 0xd0, 0x95, 0xfa, 0x70, 0x2f, 0x08, 0x99, 0x5f, 0xf7, 0xdc, 0x1d, 0x67,
+	0x80, 0x9f, 0xa0, 0x99, 0x1d, 0x6f, 0x68, 0x33, 0x3f, 0x28, 0x67, 0xaa, 0xb9, 0x87, 0x73, 0x4b,
+	0xa5, 0x64, 0xc8, 0x15, 0x60, 0xd4, 0x8c, 0x4f, 0x23, 0x63, 0x6a, 0x12, 0x19, 0xb1, 0xe0, 0x49,
+	0x64, 0xcc, 0xc3, 0x30, 0x82, 0x26, 0x34, 0x66, 0x90, 0x7f, 0x29, 0xa0, 0x69, 0xa1, 0x84, 0x1f,
+	0xa1, 0xac, 0x63, 0xcb, 0xe3, 0x5e, 0x78, 0x1d, 0x19, 0xd9, 0x56, 0x63, 0x12, 0x19, 0x59, 0xc7,
+	0x3e, 0x89, 0x8c, 0x22, 0x68, 0x3b, 0x36, 0xf9, 0xd5, 0xab, 0x07, 0xd9, 0x56, 0x83, 0x66, 0x1d,
+	0x1b, 0x3f, 0x42, 0x85, 0xa1, 0xb5, 0xcd, 0x86, 0xf2, 0x70, 0xcb, 0x93, 0xc8, 0x10, 0xc0, 0x49,
+	0x64, 0xcc, 0x81, 0x3c, 0x50, 0x84, 0x0a, 0x14, 0xff, 0x04, 0xcd, 0xfa, 0xcc, 0xb2, 0x4d, 0xcf,
+	0x1d, 0x1e, 0xc2, 0x41, 0x16, 0x6b, 0x0b, 0x93, 0xc8, 0x28, 0x72, 0xb0, 0xe3, 0x0e, 0x0f, 0x4f,
+	0x22, 0xe3, 0x2a, 0xa8, 0xc5, 0x00, 0xa1, 0x8a, 0x87, 0x4d, 0x84, 0x9d, 0x81, 0xeb, 0xf9, 0xcc,
+	0x1c, 0x33, 0x7f, 0xe4, 0xc0, 0xd6, 0x04, 0xe5, 0x3c, 0x58, 0xf9, 0xfe, 0x24, 0x32, 0xae, 0x0b,
+	0xee, 0x66, 0xc2, 0x3c, 0x89, 0x8c, 0x3b, 0x62, 0xd6, 0xa7, 0x39, 0x84, 0x9e, 0x95, 0xc6, 0x4f,
+	0xd0, 0x15, 0x39, 0x80, 0xcd, 0x86, 0x2c, 0x64, 0xe5, 0x02, 0xd8, 0xfe, 0x9d, 0x49, 0x64, 0xcc,
+	0x0b, 0x46, 0x03, 0xf0, 0x93, 0xc8, 0xc0, 0x9a, 0x59, 0x01, 0x12, 0x9a, 0x92, 0xc1, 0x36, 0xba,
+	0x69, 0x3b, 0x81, 0xb5, 0x3d, 0x64, 0x66, 0xc8, 0x46, 0x63, 0xd3, 0x71, 0x6d, 0x76, 0xc0, 0x82,
+	0xf2, 0x34, 0xd8, 0x5c, 0x9a, 0x44, 0x06, 0x96, 0xfc, 0x1e, 0x1b, 0x8d, 0x5b, 0x82, 0x7b, 0x12,
+	0x19, 0x65, 0x11, 0x53, 0x67, 0x58, 0x84, 0x9e, 0x23, 0x8f, 0x97, 0xd0, 0xf4, 0xd8, 0xda, 0x0b,
+	0x98, 0x5d, 0x9e, 0x01, 0xbb, 0x95, 0x49, 0x64, 0x48, 0x44, 0x1d, 0xb8, 0x20, 0x09, 0x95, 0x38,
+	0x3f, 0x34, 0xd7, 0x0b, 0x59, 0x50, 0x2e, 0x26, 0x87, 0x06, 0x80, 0x3a, 0x34, 0xa0, 0x08, 0x15,
+	0x28, 0xfe, 0x18, 0x15, 0x47, 0x2c, 0xb4, 0x6c, 0x2b, 0xb4, 0xca, 0xb3, 0xd5, 0xcc, 0xc3, 0xb9,
+	0xa5, 0xf2, 0x69, 0x6f, 0xdb, 0x90, 0xfc, 0x1a, 0x91, 0x5e, 0xa7, 0x34, 0xd4, 0x89, 0xc6, 0x00,
+	0xa1, 0x8a, 0xc7, 0xdd, 0x58, 0xe4, 0x8b, 0xa0, 0x5c, 0x3a, 0xed, 0xc6, 0x0d, 0x60, 0x24, 0x6e,
+	0x2c, 0x05, 0xd5, 0xaa, 0x04, 0x4d, 0x68, 0xcc, 0x20, 0x5f, 0x64, 0xd0, 0xd5, 0xf4, 0x6c, 0xf0,
+	0x0a, 0x4f, 0x63, 0x41, 0xdf, 0x77, 0xc6, 0x21, 0x8f, 0x4e, 0xe1, 0xd7, 0x10, 0x7f, 0x1a, 0xac,
+	0xe2, 0x4f, 0xc3, 0x08, 0xd5, 0x25, 0xf0, 0x22, 0xca, 0x3b, 0x7d, 0x19, 0xde, 0xb3, 0x22, 0x23,
+	0x70, 0x5a, 0x65, 0x04, 0x4e, 0x10, 0x0a, 0x18, 0xdf, 0xdd, 0xbe, 0x37, 0xf4, 0xfc, 0x72, 0x2e,
+	0xd9, 0x5d, 0x00, 0xd4, 0xee, 0x02, 0x45, 0xa8, 0x40, 0xf1, 0x8f, 0xd1, 0xcc, 0xde, 0xd8, 0xb6,
+	0x42, 0x66, 0x83, 0x2b, 0xe7, 0x6a, 0xf7, 0xf8, 0x6a, 0x25, 0x74, 0x12, 0x19, 0x57, 0x40, 0x47,
+	0xd2, 0x84, 0xc6, 0x1c, 0xf2, 0xcf, 0xd3, 0x68, 0x5a, 0xec, 0x11, 0xae, 0xa9, 0xa8, 0x9d, 0xaf,
+	0x2d, 0xf1, 0xfd, 0xfa, 0x8f, 0xc8, 0x28, 0x0a, 0x5e, 0xab, 0x71, 0x51, 0x14, 0xff, 0xf2, 0xd5,
+	0x83, 0x8c, 0x16, 0xc9, 0x8b, 0x28, 0xaf, 0x65, 0x69, 0x58, 0xa2, 0x2b, 0xf2, 0xb3, 0x58, 0xa2,
+	0x0b, 0x99, 0x19, 0x30, 0xfc, 0x1e, 0x9a, 0xb5, 0x6c, 0x9b, 0x27, 0x27, 0x16, 0x94, 0x73, 0xd5,
+	0x1c, 0x4f, 0x16, 0x93, 0xc8, 0x48, 0x40, 0x35, 0x6d, 0x89, 0x10, 0x9a, 0xf0, 0xf0, 0x1f, 0xa6,
+	0x53, 0x66, 0xfe, 0x74, 0xf2, 0xfd, 0x66, 0xb9, 0x92, 0xa7, 0x98, 0x3e, 0xf3, 0x65, 0xcd, 0x29,
+	0x88, 0x4c, 0xc6, 0x1d, 0x92, 0x83, 0xb2, 0xe2, 0x08, 0x87, 0x8c, 0x01, 0x42, 0x15, 0x0f, 0xaf,
+	0xa2, 0xf9, 0x91, 0x75, 0x60, 0x06, 0xec, 0x67, 0x7b, 0xcc, 0xed, 0x33, 0x08, 0xd6, 0x9c, 0x98,
+	0xc5, 0xc8, 0x3a, 0xe8, 0x4a, 0x58, 0xcd, 0x42, 0xc3, 0x08, 0xd5, 0x25, 0x70, 0x0d, 0x21, 0xc7,
+	0x0d, 0x7d, 0xcf, 0xde, 0xeb, 0x33, 0x5f, 0xc6, 0x26, 0x94, 0xbe, 0x04, 0x55, 0xa5, 0x2f, 0x81,
+	0x08, 0xd5, 0xf8, 0x78, 0x80, 0x8a, 0x90, 0x34, 0x4c, 0xc7, 0x86, 0x50, 0xcd, 0xd7, 0xd6, 0xe5,
+	0xe1, 0xce, 0x40, 0xf8, 0xc3, 0xd9, 0xc6, 0x3f, 0xb9, 0xd3, 0x80, 0x74, 0x2b, 0x71, 0x1a, 0x49,
+	0xf3, 0x84, 0x1d, 0x8b, 0xfd, 0x55, 0xf2, 0x93, 0xc6, 0xf2, 0xf8, 0x8f, 0x50, 0x25, 0x78, 0xe1,
+	0x8c, 0xcd, 0x78, 0x6c, 0xee, 0xf3, 0xa6, 0xcf, 0x46, 0xde, 0x4b, 0x6b, 0x18, 0x40, 0xc8, 0x17,
+	0x6b, 0xef, 0x4f, 0x22, 0xa3, 0xcc, 0xa5, 0x5a, 0x9a, 0x10, 0x95, 0x32, 0x27, 0x91, 0xb1, 0x00,
+	0x23, 0x5e, 0x24, 0x40, 0xe8, 0x85, 0xba, 0xf8, 0x00, 0xbd, 0xc1, 0xdc, 0xbe, 0x7f, 0x08, 0xa1,
+	0x66, 0x8e, 0xad, 0x20, 0xd8, 0xf7, 0x7c, 0xdb, 0x0c, 0xbd, 0x17, 0xcc, 0x2d, 0x23, 0x70, 0xea,
+	0xf7, 0x26, 0x91, 0x71, 0x27, 0x11, 0xda, 0x94, 0x32, 0x3d, 0x2e, 0x72, 0x12, 0x19, 0xf7, 0x61,
+	0xec, 0x0b, 0xf8, 0x84, 0x5e, 0xa4, 0x49, 0xfe, 0x24, 0x83, 0x0a, 0xb0, 0x19, 0x3c, 0x8d, 0x8a,
+	0x6a, 0x28, 0x73, 0x04, 0xa4, 0x51, 0x81, 0x9c, 0xa9, 0x9b, 0x12, 0xc7, 0x4d, 0x54, 0xd8, 0x71,
+	0x86, 0x2c, 0x28, 0x67, 0x21, 0x75, 0x61, 0x2d, 0x27, 0x3a, 0x43, 0xd6, 0x72, 0x77, 0xbc, 0xda,
+	0x5d, 0x99, 0xbc, 0x84, 0xa0, 0x8a, 0x25, 0x4e, 0x11, 0x2a, 0x40, 0xf2, 0xcb, 0x0c, 0x9a, 0x83,
+	0x49, 0x6c, 0x41, 0x60, 0xff, 0x36, 0xa7, 0xf2, 0xa7, 0xd7, 0x50, 0x31, 0x56, 0x50, 0x09, 0x21,
+	0x73, 0x89, 0x84, 0xb0, 0x88, 0xf2, 0x81, 0xf3, 0x73, 0x06, 0x29, 0x2f, 0x27, 0x64, 0x39, 0xad,
+	0x64, 0x39, 0x41, 0x28, 0x60, 0xf8, 0x03, 0x84, 0x46, 0x9e, 0xed, 0xec, 0x38, 0xcc, 0x36, 0x03,
+	0x08, 0xd0, 0x5c, 0xad, 0xca, 0xb3, 0x47, 0x8c, 0x76, 0x4f, 0x22, 0xe3, 0x9a, 0x08, 0xaf, 0x18,
+	0x21, 0x34, 0xe1, 0xf2, 0xfc, 0xa1, 0x0c, 0x6c, 0x1f, 0x96, 0xe7, 0x21, 0x32, 0xde, 0x8b, 0x23,
+	0xa3, 0xbb, 0xeb, 0xf9, 0x21, 0x84, 0x83, 0x1a, 0xa6, 0x76, 0xa8, 0x42, 0x2d, 0x81, 0x08, 0x8f,
+	0x04, 0x29, 0x4c, 0x35, 0x51, 0xbc, 0x8e, 0x66, 0xe2, 0x9b, 0xa6, 0x28, 0x76, 0x5a, 0x4d, 0x7a,
+	0xca, 0xfa, 0xa1, 0xe7, 0xd7, 0xaa, 0x71, 0x4d, 0x7a, 0xa9, 0x6e, 0x9e, 0x22, 0xe0, 0x5e, 0xc6,
+	0x77, 0xce, 0x98, 0x83, 0xdf, 0x45, 0x45, 0x95, 0x4c, 0x10, 0xac, 0x15, 0x92, 0x51, 0x90, 0x64,
+	0x12, 0x91, 0x8c, 0x02, 0x95, 0x46, 0x14, 0x0f, 0xff, 0x0c, 0x5d, 0x0d, 0x7d, 0xcb, 0x0d, 0x2c,
+	0x11, 0x90, 0x8e, 0x5d, 0xbe, 0x09, 0x16, 0x3e, 0x7c, 0x1d, 0x19, 0x57, 0x7a, 0x09, 0x07, 0x56,
+	0x7b, 0x45, 0x13, 0x6d, 0xd9, 0xea, 0x2e, 0x9c, 0x42, 0x79, 0x22, 0x48, 0x2b, 0xd2, 0xb4, 0x1a,
+	0xfe, 0x10, 0x4d, 0x6f, 0x0f, 0xbd, 0xfe, 0x8b, 0xb8, 0x1e, 0xdf, 0x48, 0xd6, 0x5e, 0xe3, 0x38,
+	0xb8, 0xd2, 0x7d, 0xb9, 0x7c, 0x29, 0xaa, 0xea, 0x1a, 0x90, 0x84, 0x4a, 0x98, 0xdf, 0xdc, 0x83,
+	0xc3, 0xd1, 0xd0, 0x71, 0x5f, 0x98, 0xa1, 0xe5, 0x0f, 0x58, 0x58, 0xbe, 0x9e, 0xdc, 0xdc, 0x25,
+	0xa7, 0x07, 0x0c, 0x35, 0xdb, 0x14, 0x4a, 0x68, 0x5a, 0x8a, 0xbf, 0x27, 0x84, 0x69, 0x73, 0xd7,
+	0x0a, 0x76, 0xcb, 0x18, 0x52, 0x03, 0x24, 0x55, 0x01, 0xaf, 0x59, 0xc1, 0xae, 0x3a, 0xe9, 0x04,
+	0x22, 0x54, 0xe3, 0xe3, 0xf7, 0xd1, 0xac, 0x4c, 0x07, 0xcc, 0x2e, 0xdf, 0x00, 0x13, 0xe0, 0x7d,
+	0x0a, 0x54, 0xde, 0xa7, 0x10, 0x42, 0x13, 0x2e, 0xae, 0xc9, 0x37, 0x83, 0xb8, 0xe9, 0xdf, 0x3e,
+	0x1b, 0x69, 0x97, 0x78, 0x34, 0xac, 0xa0, 0xb9, 0xd3, 0x37, 0xd8, 0x2b, 0xa2, 0xc8, 0x8c, 0x53,
+	0x77, 0x57, 0x51, 0x64, 0xc6, 0xfa, 0xad, 0x55, 0x97, 0xc0, 0x1f, 0x6a, 0x91, 0xe0, 0x06, 0xe5,
+	0xb9, 0x6a, 0xe6, 0x61, 0xa1, 0xf6, 0x96, 0xee, 0xfa, 0xed, 0xe0, 0x8c, 0xeb, 0xb7, 0x03, 0xf2,
+	0xbf, 0x91, 0x91, 0x73, 0xdc, 0x90, 0x6a, 0x62, 0x78, 0x07, 0x89, 0x5d, 0x32, 0x21, 0x90, 0xaf,
+	0x80, 0xa9, 0xd5, 0xd7, 0x91, 0x31, 0x4f, 0xad, 0x7d, 0x38, 0xfa, 0xae, 0xf3, 0x73, 0xc6, 0x37,
+	0x6a, 0x3b, 0x26, 0xd4, 0x46, 0x29, 0x24, 0x36, 0xfc, 0xab, 0x57, 0x0f, 0x52, 0x6a, 0x34, 0x51,
+	0xc2, 0x4f, 0x51, 0x71, 0x3c, 0xb4, 0xc2, 0x1d, 0xcf, 0x1f, 0x95, 0xaf, 0x42, 0x7c, 0x69, 0x7b,
+	0xb8, 0x29, 0x39, 0x8d, 0xd4, 0x55, 0x32, 0x96, 0x57, 0xc1, 0x12, 0x03, 0x84, 0x2a, 0x1e, 0x6e,
+	0xa0, 0xb9, 0xa1, 0xd7, 0xb7, 0x86, 0xe6, 0xce, 0xd0, 0x1a, 0x04, 0xe5, 0xff, 0x9c, 0x81, 0x4d,
+	0x05, 0xef, 0x00, 0x7c, 0x85, 0xc3, 0x6a, 0x33, 0x12, 0x88, 0x50, 0x8d, 0x8f, 0xd7, 0xd0, 0xbc,
+	0x8c, 0x5c, 0xe1, 0x63, 0xff, 0x35, 0x03, 0x1e, 0x02, 0x67, 0x23, 0x19, 0xd2, 0xcb, 0xae, 0xeb,
+	0x01, 0x2f, 0xdc, 0x4c, 0x97, 0xc0, 0x1f, 0xa1, 0x6b, 0x8e, 0xeb, 0xd9, 0xcc, 0xec, 0xef, 0x5a,
+	0xee, 0x80, 0xf1, 0xf3, 0x99, 0xcc, 0x40, 0xf8, 0x82, 0xff, 0x03, 0xaf, 0x0e, 0xac, 0x76, 0xa0,
+	0xfc, 0x3f, 0x85, 0x12, 0x9a, 0x96, 0xc2, 0x07, 0x48, 0xab, 0x64, 0x66, 0xe8, 0x5b, 0xce, 0x90,
+	0xf9, 0xe2, 0xbc, 0xfe, 0x7b, 0x06, 0x0e, 0xec, 0x83, 0x49, 0x64, 0xdc, 0x4a, 0x64, 0x7a, 0x42,
+	0x44, 0x1e, 0xd6, 0xdd, 0x53, 0x55, 0x52, 0xe3, 0x2a, 0x8f, 0x38, 0x5f, 0x19, 0x3f, 0x46, 0x05,
+	0x98, 0x4a, 0xf9, 0x8b, 0x19, 0xc8, 0xb6, 0x70, 0xa9, 0x05, 0x44, 0x05, 0x3f, 0x50, 0x84, 0x0a,
+	0x14, 0x7f, 0x8c, 0x4a, 0x62, 0xf5, 0x03, 0xe6, 0x32, 0xdf, 0x82, 0xdb, 0xf7, 0xff, 0x08, 0xdd,
+	0xef, 0x4e, 0x22, 0x43, 0x6c, 0xcd, 0xaa, 0xe2, 0x9d, 0x44, 0xc6, 0xad, 0xc4, 0x4a, 0x82, 0x13,
+	0x7a, 0x5a, 0x92, 0x5f, 0x97, 0xc5, 0xe3, 0xcc, 0x96, 0x2f, 0xa9, 0x7b, 0xe2, 0x71, 0x00, 0x90,
+	0x4a, 0xc4, 0x92, 0x86, 0xd7, 0x01, 0xfc, 0xc2, 0x14, 0xcd, 0x38, 0xee, 0x4b, 0x6b, 0xe8, 0xc4,
+	0x2f, 0xa5, 0x77, 0x5e, 0x47, 0x06, 0xa2, 0xd6, 0x7e, 0x4b, 0xa0, 0xe2, 0xfe, 0x04, 0x3f, 0xb5,
+	0xfb, 0x13, 0xd0, 0x3c, 0x6d, 0x6a, 0x92, 0x34, 0x96, 0xe3, 0x19, 0xce, 0xf5, 0x52, 0x8f, 0xd1,
+	0x22, 0x98, 0x86, 0x13, 0x76, 0xbd, 0xf4, 0x43, 0xf4, 0x86, 0x7c, 0x59, 0xa5, 0x1e, 0xa1, 0x69,
+	0xa9, 0x77, 0xf3, 0x7f, 0xf9, 0x6b, 0x63, 0x8a, 0x7c, 0x96, 0x41, 0xb3, 0x2a, 0xdb, 0xf2, 0xda,
+	0x0a, 0xae, 0x98, 0x03, 0x4f, 0x84, 0xc4, 0xb2, 0x2b, 0x5c, 0x50, 0x24, 0x96, 0x5d, 0xf0, 0x3d,
+	0xc0, 0xf8, 0xdd, 0xc1, 0xdb, 0xd9, 0x09, 0x58, 0x08, 0x55, 0x3b, 0x27, 0xee, 0x0e, 0x02, 0x51,
+	0x77, 0x07, 0x41, 0x12, 0x2a, 0x71, 0xfc, 0x03, 0x59, 0xbb, 0xb3, 0xe0, 0x41, 0xf7, 0xcf, 0xaf,
+	0xdd, 0xb1, 0x7f, 0x00, 0x8b, 0x5f, 0xb1, 0xf7, 0x99, 0xf5, 0x42, 0x84, 0x88, 0xc8, 0x5e, 0x50,
+	0xd5, 0x38, 0x28, 0xc3, 0x43, 0x04, 0x6a, 0x0c, 0x10, 0xaa, 0x78, 0x72, 0x8d, 0x9f, 0xa0, 0x69,
+	0x51, 0x4c, 0xf1, 0x26, 0x2a, 0xf6, 0xbd, 0x3d, 0x37, 0x4c, 0x7a, 0x19, 0xd7, 0xf5, 0xb7, 0x00,
+	0x70, 0x6a, 0x6f, 0xc6, 0xb9, 0x20, 0x16, 0x55, 0x67, 0x24, 0x01, 0x7e, 0x89, 0x97, 0x2c, 0xf2,
+	0x8b, 0x0c, 0x9a, 0x91, 0x8a, 0x78, 0x4d, 0x3d, 0x8d, 0xf2, 0xb5, 0x77, 0x4e, 0xdd, 0x11, 0xbe,
+	0xbc, 0xbf, 0xa1, 0xdf, 0x0f, 0x64, 0xab, 0xe3, 0xa5, 0x35, 0xdc, 0x13, 0x1b, 0x25, 0x43, 0x00,
+	0x00, 0x15, 0x02, 0x40, 0x11, 0x2a, 0x50, 0xf2, 0x8b, 0x3c, 0x9a, 0xd7, 0xf3, 0x19, 0xaf, 0x1c,
+	0x7b, 0xae, 0x73, 0x00, 0x93, 0x49, 0xdd, 0xd1, 0xb6, 0x5c, 0xe7, 0x00, 0x32, 0x5e, 0xe5, 0xd3,
+	0xc8, 0xc8, 0xf0, 0x03, 0xe0, 0x72, 0xea, 0x00, 0x38, 0x41, 0x28, 0x60, 0xf8, 0x23, 0x34, 0xb3,
+	0xef, 0xb8, 0xb6, 0xb7, 0x1f, 0xc0, 0x34, 0xe6, 0xf4, 0x77, 0xd3, 0x33, 0xc1, 0x00, 0x4b, 0x55,
+	0x69, 0x29, 0x96, 0x56, 0xdb, 0x25, 0x69, 0x42, 0x63, 0x0e, 0x5e, 0x45, 0x85, 0xa1, 0xe3, 0xee,
+	0x1d, 0x80, 0x83, 0xa5, 0x2a, 0xfe, 0xc7, 0x56, 0x18, 0xfa, 0x60, 0xee, 0x9e, 0x34, 0x27, 0x24,
+	0xd5, 0x82, 0x81, 0xe2, 0xbd, 0x1d, 0xfe, 0x17, 0x3f, 0x41, 0xd3, 0xb6, 0xe5, 0xef, 0x3b, 0xe2,
+	0x49, 0x77, 0x81, 0xa5, 0x05, 0x69, 0x49, 0x8a, 0x26, 0xaf, 0x79, 0x20, 0x09, 0x95, 0x38, 0x66,
+	0x68, 0x66, 0xc7, 0x67, 0x6c, 0x3b, 0xb0, 0xcb, 0x85, 0x8b, 0xad, 0xfd, 0x98, 0x5b, 0xe3, 0x8f,
+	0xa0, 0x15, 0x9f, 0xb1, 0x5a, 0x17, 0x1e, 0x41, 0x52, 0x4d, 0xad, 0x58, 0xd2, 0xf0, 0x08, 0x92,
+	0x62, 0x34, 0x16, 0xc2, 0x26, 0x9a, 0x76, 0x59, 0xb8, 0x1d, 0x88, 0x64, 0x72, 0xc1, 0x28, 0x4b,
+	0x72, 0x94, 0xe9, 0x36, 0x0b, 0xc5, 0x20, 0x52, 0x49, 0xcd, 0x5e, 0x90, 0x7c, 0x08, 0x29, 0x43,
+	0xa5, 0x04, 0xf9, 0xb3, 0x2c, 0x2a, 0xc6, 0xe7, 0xcb, 0xaf, 0xbe, 0xde, 0xbe, 0xcb, 0x7c, 0xbd,
+	0xa9, 0x0a, 0x97, 0x0f, 0x40, 0xe5, 0xe3, 0x54, 0xd4, 0x54, 0x85, 0x10, 0x9a, 0x70, 0xb9, 0x81,
+	0x81, 0xef, 0xed, 0x8d, 0xf5, 0x86, 0x2a, 0x18, 0x00, 0x34, 0x65, 0x40, 0x21, 0x84, 0x26, 0x5c,
+	0xfc, 0x13, 0x94, 0xdb, 0x73, 0x6c, 0x38, 0xea, 0x42, 0xed, 0xad, 0xd7, 0x91, 0x91, 0xdb, 0x82,
+	0x08, 0xe0, 0xe8, 0x49, 0x64, 0xcc, 0x0a, 0x87, 0x73, 0x6c, 0xad, 0x92, 0x73, 0x09, 0xca, 0xf9,
+	0x5c, 0x79, 0xe0, 0x88, 0x2e, 0x85, 0x54, 0x5e, 0x15, 0xca, 0x03, 0x4d, 0x79, 0x90, 0x56, 0x5e,
+	0xe5, 0xca, 0x1c, 0xfb, 0xeb, 0x0c, 0x9a, 0xd3, 0x3c, 0xf4, 0x9b, 0xef, 0xc5, 0x3a, 0xba, 0x2a,
+	0x0c, 0x38, 0x81, 0x09, 0x0b, 0x84, 0xfd, 0x90, 0xdd, 0x3a, 0xe0, 0xb4, 0x82, 0x55, 0x8e, 0xab,
+	0x6e, 0x9d, 0x0e, 0x12, 0x9a, 0x92, 0x21, 0x5d, 0x34, 0xab, 0x0e, 0x1c, 0xaf, 0xa0, 0xe9, 0x03,
+	0x4e, 0xc4, 0x09, 0xe9, 0xda, 0x29, 0xaf, 0x48, 0x6e, 0xc0, 0x42, 0x4c, 0x05, 0x04, 0x90, 0x84,
+	0x4a, 0x98, 0xf4, 0x51, 0x01, 0xe4, 0xbf, 0xd2, 0x5b, 0x2a, 0x95, 0x67, 0xe6, 0xff, 0xff, 0x3c,
+	0xf3, 0xc7, 0x79, 0x34, 0x43, 0xf9, 0x93, 0x21, 0x08, 0xf1, 0x8f, 0x54, 0xb6, 0x2b, 0xd4, 0xbe,
+	0x7d, 0x51, 0x7a, 0x4b, 0x4e, 0x27, 0xee, 0xfd, 0x24, 0x4f, 0xce, 0xec, 0xa5, 0x9f, 0x9c, 0xf1,
+	0x92, 0x72, 0x97, 0x58, 0x52, 0x52, 0x96, 0xf2, 0x5f, 0xb9, 0x2c, 0x15, 0x2e, 0x5f, 0x96, 0xe2,
+	0x4a, 0x39, 0x7d, 0x89, 0x4a, 0xd9, 0x41, 0x57, 0x77, 0x7c, 0x6f, 0x04, 0xad, 0x59, 0xcf, 0xb7,
+	0xfc, 0xc3, 0xf2, 0x4c, 0x52, 0xba, 0x39, 0xa7, 0x17, 0x33, 0x54, 0xe9, 0x4e, 0xa1, 0x84, 0xa6,
+	0xa5, 0xd2, 0x35, 0xb1, 0xf8, 0xd5, 0x6a, 0x22, 0x7e, 0x1f, 0x15, 0xc5, 0xe5, 0xdb, 0xf5, 0xe0,
+	0xd1, 0x59, 0xa8, 0x7d, 0x8b, 0xa7, 0x32, 0xc0, 0xda, 0x9e, 0x4a, 0x65, 0x92, 0x56, 0xcb, 0x8e,
+	0x05, 0xc8, 0xdf, 0x67, 0x50, 0x91, 0xb2, 0x60, 0xec, 0xb9, 0x01, 0xfb, 0xba, 0x4e, 0xb0, 0x88,
+	0xf2, 0xd0, 0xe1, 0xcd, 0x26, 0xbb, 0x27, 0xfb, 0xb7, 0x48, 0x66, 0x68, 0xde, 0xbb, 0x05, 0x0c,
+	0x7f, 0x80, 0xf2, 0x7d, 0xcf, 0x16, 0x87, 0x7f, 0x55, 0x4f, 0x9a, 0x4d, 0xdf, 0xf7, 0xfc, 0xba,
+	0x67, 0xcb, 0x17, 0x50, 0x5f, 0xdc, 0x10, 0x91, 0xac, 0xd4, 0xfc, 0x82, 0x08, 0x18, 0xf9, 0xbb,
+	0x0c, 0x2a, 0x35, 0xbc, 0x7d, 0x77, 0xe8, 0x59, 0xf6, 0xa6, 0xef, 0x0d, 0x78, 0xf3, 0xee, 0x6b,
+	0x75, 0x3e, 0xcc, 0xb8, 0x7b, 0x1a, 0xf7, 0x3e, 0x1e, 0xa4, 0x5f, 0x64, 0xa7, 0x07, 0x11, 0x4d,
+	0x96, 0xa4, 0xab, 0x2c, 0x95, 0x95, 0x7d, 0x41, 0xab, 0x36, 0x6b, 0x40, 0xfe, 0x36, 0x87, 0x2a,
+	0x17, 0x1b, 0xc2, 0x23, 0x34, 0x27, 0x24, 0x4d, 0xed, 0x4b, 0xd2, 0xc3, 0xcb, 0xcc, 0x01, 0xde,
+	0x89, 0xf0, 0x3e, 0xd9, 0x53, 0xb4, 0x7a, 0x9f, 0x24, 0x10, 0xa1, 0x1a, 0xff, 0x2b, 0x75, 0x69,
+	0xb5, 0x46, 0x46, 0xee, 0x9b, 0x37, 0x32, 0xba, 0xe8, 0x8a, 0x70, 0xd1, 0xf8, 0x3b, 0x46, 0xbe,
+	0x9a, 0x7b, 0x58, 0xa8, 0x3d, 0xe2, 0xd9, 0x76, 0x5b, 0x5c, 0x56, 0xe3, 0x2f, 0x18, 0xd7, 0x13,
+	0x67, 0x15, 0x60, 0xec, 0x6d, 0xa5, 0x29, 0x9a, 0x92, 0xc5, 0x2b, 0xa9, 0x47, 0xa7, 0x08, 0xf5,
+	0xdf, 0xbd, 0xe4, 0x23, 0x53, 0x7b, 0x54, 0x92, 0x69, 0x94, 0xdf, 0x74, 0xdc, 0x01, 0xf9, 0xc7,
+	0x0c, 0x2a, 0xd4, 0x87, 0x5e, 0x00, 0x29, 0xc7, 0x67, 0x56, 0xe0, 0xb9, 0xba, 0x2f, 0x09, 0x44,
+	0x9d, 0xb5, 0x20, 0x09, 0x95, 0x38, 0xff, 0x1c, 0x08, 0x5e, 0x9d, 0x3d, 0xd3, 0x91, 0xe6, 0x26,
+	0x29, 0x08, 0x7d, 0xb9, 0x5f, 0x6b, 0x2e, 0x9c, 0xbb, 0xac, 0x0b, 0x93, 0x57, 0x59, 0x7e, 0x5d,
+	0xe5, 0x9d, 0xd1, 0xe1, 0xd7, 0x8d, 0xdd, 0x0f, 0xd1, 0x9c, 0x2f, 0xc3, 0xdf, 0x0c, 0xbd, 0x72,
+	0x36, 0x69, 0x04, 0xc4, 0x70, 0xcf, 0x53, 0xbe, 0x95, 0x40, 0x49, 0x23, 0x20, 0xc1, 0xb8, 0x8b,
+	0x81, 0x2b, 0x6b, 0x89, 0xfd, 0xc2, 0x46, 0xc6, 0x23, 0x54, 0x10, 0x6d, 0xda, 0x7c, 0xf2, 0xad,
+	0x23, 0x94, 0x4d, 0x59, 0x51, 0xab, 0x42, 0xd1, 0x82, 0x15, 0x28, 0x7f, 0xbc, 0x8d, 0xad, 0x43,
+	0x1e, 0x0b, 0x70, 0xd8, 0xf3, 0xe2, 0xf1, 0x26, 0x21, 0xe5, 0x7c, 0x92, 0x26, 0x34, 0xe6, 0xf0,
+	0x71, 0x18, 0xcf, 0x2c, 0xe5, 0xe9, 0x64, 0x1c, 0x00, 0xd4, 0x38, 0x40, 0x11, 0x2a, 0xd0, 0xc5,
+	0x2f, 0x72, 0x68, 0x4e, 0xfb, 0x86, 0x8b, 0x7f, 0x8a, 0xee, 0x6e, 0x34, 0xbb, 0xdd, 0xe5, 0xd5,
+	0xa6, 0xd9, 0x7b, 0xbe, 0xd9, 0x34, 0xeb, 0xeb, 0x5b, 0xdd, 0x5e, 0x93, 0x9a, 0xf5, 0x4e, 0x7b,
+	0xa5, 0xb5, 0x5a, 0x9a, 0xaa, 0xdc, 0x3b, 0x3a, 0xae, 0x96, 0x35, 0x8d, 0xf4, 0xd7, 0xd6, 0xef,
+	0x22, 0x9c, 0x52, 0x6f, 0xb5, 0x1b, 0xcd, 0x8f, 0x4b, 0x99, 0xca, 0xcd, 0xa3, 0xe3, 0x6a, 0x49,
+	0xd3, 0x12, 0xbd, 0xe4, 0xdf, 0x47, 0x6f, 0x9c, 0x95, 0x36, 0xb7, 0x36, 0x1b, 0xcb, 0xbd, 0x66,
+	0x29, 0x5b, 0xa9, 0x1c, 0x1d, 0x57, 0x6f, 0x9f, 0x56, 0x92, 0xd9, 0xe4, 0xfb, 0xe8, 0x66, 0x4a,
+	0x95, 0x36, 0x3f, 0xda, 0x6a, 0x76, 0x7b, 0xa5, 0x5c, 0xe5, 0xf6, 0xd1, 0x71, 0x15, 0x6b, 0x5a,
+	0x71, 0xc5, 0x5f, 0x42, 0xb7, 0x4e, 0x69, 0x74, 0x37, 0x3b, 0xed, 0x6e, 0xb3, 0x94, 0xaf, 0xdc,
+	0x39, 0x3a, 0xae, 0xde, 0x48, 0xa9, 0xc8, 0x02, 0x51, 0x47, 0x0b, 0x29, 0x9d, 0x46, 0xe7, 0x59,
+	0x7b, 0xbd, 0xb3, 0xdc, 0x30, 0x37, 0x69, 0x67, 0x95, 0x36, 0xbb, 0xdd, 0x52, 0xa1, 0x62, 0x1c,
+	0x1d, 0x57, 0xef, 0x6a, 0xca, 0x67, 0x92, 0xf5, 0x22, 0xba, 0x9e, 0x32, 0xb2, 0xd9, 0x6a, 0xaf,
+	0x96, 0xa6, 0x2b, 0x37, 0x8e, 0x8e, 0xab, 0xd7, 0x34, 0x3d, 0x1e, 0x96, 0x67, 0xf6, 0xaf, 0xbe,
+	0xde, 0xe9, 0x36, 0x4b, 0x33, 0x67, 0xf6, 0x4f, 0x84, 0xee, 0xe9, 0x4d, 0xa8, 0x77, 0xda, 0x3d,
+	0xda, 0x59, 0x2f, 0x15, 0xcf, 0x6c, 0x82, 0x8c, 0x9a, 0xc5, 0xbf, 0xc9, 0x20, 0x7c, 0xf6, 0x43,
+	0x3b, 0x7e, 0x07, 0x95, 0x63, 0x43, 0xf5, 0xce, 0xc6, 0x26, 0x5f, 0x59, 0xab, 0xd3, 0x36, 0xdb,
+	0x9d, 0x76, 0xb3, 0x34, 0x95, 0x3a, 0x07, 0x4d, 0xab, 0xed, 0xb9, 0xfc, 0x9f, 0x0e, 0xee, 0x9c,
+	0xa7, 0xb9, 0xfe, 0xc9, 0xdb, 0xa5, 0x4c, 0x65, 0xe9, 0xe8, 0xb8, 0x7a, 0xeb, 0xac, 0xe2, 0xfa,
+	0x27, 0x6f, 0xff, 0xe6, 0xcf, 0xbf, 0x7d, 0x3e, 0x63, 0x91, 0xdf, 0x7e, 0xf5, 0xa9, 0xfd, 0x00,
+	0xdd, 0xd4, 0x0d, 0x6f, 0x34, 0x7b, 0xcb, 0x8d, 0xe5, 0xde, 0x72, 0x69, 0x4a, 0x9c, 0x9a, 0x26,
+	0xaa, 0xbe, 0x65, 0x7e, 0x07, 0x5d, 0x4f, 0xad, 0xa2, 0xf9, 0xb4, 0x49, 0x63, 0x1f, 0xd4, 0xe7,
+	0xcf, 0x5e, 0x32, 0x1f, 0x7f, 0x0f, 0x61, 0x5d, 0x78, 0x79, 0xfd, 0xd9, 0xf2, 0xf3, 0x6e, 0x29,
+	0x5b, 0xb9, 0x75, 0x74, 0x5c, 0xbd, 0xae, 0x49, 0x2f, 0x0f, 0xf7, 0xad, 0xc3, 0x60, 0xf1, 0x9f,
+	0xb2, 0x68, 0x5e, 0xef, 0x5f, 0xe2, 0xef, 0xa1, 0x1b, 0x2b, 0xad, 0x75, 0xee, 0xbb, 0x2b, 0x1d,
+	0x71, 0x0a, 0x9c, 0x2c, 0x4d, 0x89, 0xe1, 0x74, 0x51, 0xfe, 0x1b, 0xff, 0x1e, 0x2a, 0x9f, 0x12,
+	0x6f, 0xb4, 0x68, 0xb3, 0xde, 0xeb, 0xd0, 0xe7, 0xa5, 0x4c, 0xe5, 0x0d, 0xbe, 0x61, 0xba, 0x4e,
+	0xc3, 0xf1, 0xa1, 0xfe, 0x1c, 0xe2, 0xf7, 0xd1, 0xdd, 0x53, 0x8a, 0xdd, 0xe7, 0x1b, 0xeb, 0xad,
+	0xf6, 0x13, 0x31, 0x5e, 0xb6, 0x72, 0xff, 0xe8, 0xb8, 0x7a, 0x47, 0xd7, 0xed, 0x8a, 0x96, 0x30,
+	0x87, 0x8a, 0x19, 0xbc, 0x86, 0xaa, 0x17, 0xe8, 0x27, 0x13, 0xc8, 0x55, 0xc8, 0xd1, 0x71, 0xf5,
+	0xde, 0x39, 0x46, 0xd4, 0x3c, 0x8a, 0x19, 0xfc, 0x43, 0x74, 0xfb, 0x7c, 0x4b, 0x71, 0x24, 0x9d,
+	0xa3, 0xbf, 0xf8, 0x6f, 0x19, 0x34, 0xab, 0xae, 0x3c, 0x7c, 0xd3, 0x9a, 0x94, 0x76, 0x78, 0x5a,
+	0x69, 0x34, 0xcd, 0x76, 0xc7, 0x04, 0x2a, 0xde, 0x34, 0x25, 0xd7, 0xf6, 0xe0, 0x27, 0x8f, 0x0a,
+	0x4d, 0x7c, 0xb5, 0xd9, 0x6e, 0xd2, 0x56, 0x3d, 0x3e, 0x51, 0x25, 0x0d, 0xad, 0x2f, 0xa7, 0x8f,
+	0xdf, 0x46, 0x77, 0xd2, 0xc6, 0xbb, 0x5b, 0xf5, 0xb5, 0x78, 0x97, 0x60, 0x82, 0xda, 0x00, 0xdd,
+	0xbd, 0xfe, 0x2e, 0x1c, 0xcc, 0x8f, 0x52, 0x5a, 0xad, 0xf6, 0xd3, 0xe5, 0xf5, 0x56, 0x43, 0x68,
+	0xe5, 0x2a, 0xe5, 0xa3, 0xe3, 0xea, 0x4d, 0xa5, 0x25, 0xbb, 0x5b, 0x5c, 0x6d, 0xf1, 0x37, 0x19,
+	0xb4, 0xf0, 0xe5, 0x37, 0x17, 0xfc, 0x0c, 0xbd, 0x05, 0xfb, 0x75, 0x26, 0x79, 0xc8, 0x4c, 0x27,
+	0xf6, 0x70, 0x79, 0x73, 0xb3, 0xd9, 0x6e, 0x94, 0xa6, 0x2a, 0x0f, 0x8f, 0x8e, 0xab, 0x0f, 0xbe,
+	0xdc, 0xe4, 0xf2, 0x78, 0xcc, 0x5c, 0xfb, 0x92, 0x86, 0x57, 0x3a, 0x74, 0xb5, 0xd9, 0x2b, 0x65,
+	0x2e, 0x63, 0x78, 0xc5, 0xe3, 0x9f, 0x0f, 0x16, 0xff, 0x35, 0x8b, 0xe6, 0xb4, 0x4a, 0xce, 0xf3,
+	0x0c, 0x24, 0x22, 0x93, 0x36, 0x97, 0xbb, 0x9d, 0xb6, 0xb9, 0xd5, 0x7e, 0xd2, 0xee, 0x3c, 0x6b,
+	0x97, 0xa6, 0x44, 0x9e, 0xd1, 0x44, 0xb7, 0xdc, 0x17, 0xae, 0xb7, 0xcf, 0x3f, 0x2d, 0x57, 0x52,
+	0x1a, 0x2b, 0x9d, 0xf5, 0x46, 0x93, 0x9a, 0x9b, 0xcb, 0x5b, 0xdd, 0x66, 0xa3, 0x94, 0xa9, 0xdc,
+	0xe5, 0xce, 0xaa, 0xe9, 0x89, 0xff, 0x46, 0xd8, 0x14, 0xff, 0x75, 0xf1, 0x53, 0x74, 0xf7, 0x3c,
+	0xe5, 0x8d, 0x56, 0xb7, 0xcb, 0x53, 0x67, 0x56, 0xd4, 0xa0, 0x33, 0xda, 0x1b, 0xbc, 0x37, 0xe8,
+	0x0e, 0xf0, 0x2a, 0xaa, 0xa6, 0xd4, 0x9b, 0xed, 0x3a, 0x7d, 0xbe, 0xd9, 0x83, 0xec, 0xd1, 0xea,
+	0x6e, 0x2c, 0xf7, 0xea, 0x6b, 0xa5, 0x5c, 0xe5, 0xcd, 0xa3, 0xe3, 0xea, 0x7d, 0xcd, 0x46, 0x53,
+	0xb5, 0x73, 0x37, 0x9c, 0x60, 0x64, 0x85, 0xfd, 0x5d, 0xbc, 0x86, 0xde, 0x4c, 0x19, 0xea, 0x75,
+	0x3a, 0xe6, 0xc6, 0x72, 0xfb, 0x39, 0xcf, 0xb3, 0xed, 0x66, 0x9d, 0x5b, 0xec, 0x96, 0xf2, 0x67,
+	0x2c, 0xf5, 0x3c, 0x6f, 0xc3, 0x72, 0x0f, 0xeb, 0x9e, 0xeb, 0x32, 0xf8, 0x5a, 0x14, 0xd4, 0x36,
+	0x3e, 0xfd, 0x6c, 0x61, 0xea, 0xd5, 0x67, 0x0b, 0x53, 0x9f, 0xbe, 0x5e, 0xc8, 0xbc, 0x7a, 0xbd,
+	0x90, 0xf9, 0x8b, 0xcf, 0x17, 0xa6, 0x7e, 0xfd, 0xf9, 0x42, 0xe6, 0xd5, 0xe7, 0x0b, 0x53, 0xff,
+	0xfe, 0xf9, 0xc2, 0xd4, 0x27, 0xdf, 0x19, 0x38, 0xe1, 0xee, 0xde, 0xf6, 0xa3, 0xbe, 0x37, 0x7a,
+	0x1c, 0x1c, 0xba, 0xfd, 0x70, 0xd7, 0x71, 0x07, 0xda, 0x2f, 0xfd, 0x3f, 0xd8, 0xb6, 0xa7, 0xe1,
+	0xd7, 0x0f, 0xff, 0x6f, 0x00, 0x00, 0x20, 0xf6, 0x84, 0xd8, 0x26, 0x00, 0x00,
 }
 
 func (m *Hello) Marshal() (dAtA []byte, err error) {
@@ -1493,6 +1660,23 @@ func (m *Folder) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			dAtA[i] = 0x82
 		}
 	}
+	{
+		size, err := m.Metadata.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintBep(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x4a
+	if len(m.Notes) > 0 {
+		i -= len(m.Notes)
+		copy(dAtA[i:], m.Notes)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Notes)))
+		i--
+		dAtA[i] = 0x42
+	}
 	if m.Paused {
 		i--
 		if m.Paused {
@@ -1560,6 +1744,55 @@ func (m *Folder) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *FolderMetadata) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FolderMetadata) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FolderMetadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Updated != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.Updated))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Color) > 0 {
+		i -= len(m.Color)
+		copy(dAtA[i:], m.Color)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Color)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Icon) > 0 {
+		i -= len(m.Icon)
+		copy(dAtA[i:], m.Icon)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Icon)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Device) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
@@ -1766,6 +1999,20 @@ func (m *FileInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.InodeGeneration != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.InodeGeneration))
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xe8
+	}
+	if m.Inode != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.Inode))
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xe0
+	}
 	if m.EncryptionTrailerSize != 0 {
 		i = encodeVarintBep(dAtA, i, uint64(m.EncryptionTrailerSize))
 		i--
@@ -1796,6 +2043,13 @@ func (m *FileInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xc0
 	}
+	if m.TransactionID != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.TransactionID))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa0
+	}
 	if len(m.Encrypted) > 0 {
 		i -= len(m.Encrypted)
 		copy(dAtA[i:], m.Encrypted)
@@ -2573,6 +2827,18 @@ func (m *Close) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Folder) > 0 {
+		i -= len(m.Folder)
+		copy(dAtA[i:], m.Folder)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Folder)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Code != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x10
+	}
 	if len(m.Reason) > 0 {
 		i -= len(m.Reason)
 		copy(dAtA[i:], m.Reason)
@@ -2583,6 +2849,67 @@ func (m *Close) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Control) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Control) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Control) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ResponseTo != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.ResponseTo))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ID != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintBep(dAtA []byte, offset int, v uint64) int {
 	offset -= sovBep(v)
 	base := offset
@@ -2674,6 +3001,12 @@ func (m *Folder) ProtoSize() (n int) {
 	if m.Paused {
 		n += 2
 	}
+	l = len(m.Notes)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	l = m.Metadata.ProtoSize()
+	n += 1 + l + sovBep(uint64(l))
 	if len(m.Devices) > 0 {
 		for _, e := range m.Devices {
 			l = e.ProtoSize()
@@ -2683,6 +3016,30 @@ func (m *Folder) ProtoSize() (n int) {
 	return n
 }
 
+func (m *FolderMetadata) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	l = len(m.Icon)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	l = len(m.Color)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	if m.Updated != 0 {
+		n += 1 + sovBep(uint64(m.Updated))
+	}
+	return n
+}
+
 func (m *Device) ProtoSize() (n int) {
 	if m == nil {
 		return 0
@@ -2830,6 +3187,9 @@ func (m *FileInfo) ProtoSize() (n int) {
 	if l > 0 {
 		n += 2 + l + sovBep(uint64(l))
 	}
+	if m.TransactionID != 0 {
+		n += 2 + sovBep(uint64(m.TransactionID))
+	}
 	if m.LocalFlags != 0 {
 		n += 2 + sovBep(uint64(m.LocalFlags))
 	}
@@ -2843,6 +3203,12 @@ func (m *FileInfo) ProtoSize() (n int) {
 	if m.EncryptionTrailerSize != 0 {
 		n += 2 + sovBep(uint64(m.EncryptionTrailerSize))
 	}
+	if m.Inode != 0 {
+		n += 2 + sovBep(uint64(m.Inode))
+	}
+	if m.InodeGeneration != 0 {
+		n += 2 + sovBep(uint64(m.InodeGeneration))
+	}
 	return n
 }
 
@@ -3124,6 +3490,44 @@ func (m *Close) ProtoSize() (n int) {
 	if l > 0 {
 		n += 1 + l + sovBep(uint64(l))
 	}
+	if m.Code != 0 {
+		n += 1 + sovBep(uint64(m.Code))
+	}
+	l = len(m.Folder)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	return n
+}
+
+func (m *Control) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovBep(uint64(m.ID))
+	}
+	if m.ResponseTo != 0 {
+		n += 1 + sovBep(uint64(m.ResponseTo))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
 	return n
 }
 
@@ -3644,7 +4048,72 @@ func (m *Folder) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Paused = bool(v != 0)
-		case 16:
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Notes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Notes = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
 			}
@@ -3699,6 +4168,171 @@ func (m *Folder) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *FolderMetadata) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FolderMetadata: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FolderMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Icon", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Icon = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Color", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Color = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+			}
+			m.Updated = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Updated |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Device) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -4714,6 +5348,25 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 				m.Encrypted = []byte{}
 			}
 			iNdEx = postIndex
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransactionID", wireType)
+			}
+			m.TransactionID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TransactionID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 1000:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field LocalFlags", wireType)
@@ -4805,6 +5458,44 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 1004:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Inode", wireType)
+			}
+			m.Inode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Inode |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 1005:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InodeGeneration", wireType)
+			}
+			m.InodeGeneration = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InodeGeneration |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBep(dAtA[iNdEx:])
@@ -6700,6 +7391,275 @@ func (m *Close) Unmarshal(dAtA []byte) error {
 			}
 			m.Reason = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= CloseReason(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Folder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Folder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Control) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Control: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Control: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResponseTo", wireType)
+			}
+			m.ResponseTo = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResponseTo |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Token = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBep(dAtA[iNdEx:])
@@ -65,6 +65,10 @@ func (*TestModel) DownloadProgress(Connection, string, []FileDownloadProgressUpd
 	return nil
 }
 
+func (*TestModel) Control(Connection, Control) error {
+	return nil
+}
+
 func (t *TestModel) closedError() error {
 	select {
 	case <-t.closedCh:
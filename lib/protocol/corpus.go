@@ -0,0 +1,180 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package protocol
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// When STPROTOCOLCORPUS is set to a directory, received messages are
+// sanitized and recorded there, each in a file in the corpus format of the
+// Go fuzzer. The files can be added to testdata/fuzz/FuzzReadMessage to
+// have the fuzz test and the regular tests exercise them.
+var corpus = newCorpusRecorder(os.Getenv("STPROTOCOLCORPUS"))
+
+// The number of messages recorded at most, to not fill the disk.
+const maxCorpusEntries = 10000
+
+type corpusRecorder struct {
+	dir string
+
+	mut     sync.Mutex
+	entries int
+}
+
+func newCorpusRecorder(dir string) *corpusRecorder {
+	if dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		l.Warnln("Protocol corpus recording disabled:", err)
+		return nil
+	}
+	l.Infoln("Recording sanitized protocol messages to", dir)
+	return &corpusRecorder{dir: dir}
+}
+
+// record writes the message to the corpus, after sanitizing a copy of it.
+func (r *corpusRecorder) record(msg message) {
+	r.mut.Lock()
+	if r.entries >= maxCorpusEntries {
+		r.mut.Unlock()
+		return
+	}
+	r.entries++
+	r.mut.Unlock()
+
+	bs, err := corpusEntry(msg)
+	if err != nil {
+		l.Debugln("Not recording message to corpus:", err)
+		return
+	}
+	sum := sha256.Sum256(bs)
+	name := filepath.Join(r.dir, hex.EncodeToString(sum[:8]))
+	if err := os.WriteFile(name, bs, 0o644); err != nil {
+		l.Debugln("Recording message to corpus:", err)
+	}
+}
+
+// corpusEntry returns the sanitized message in wire format, as a corpus
+// file for FuzzReadMessage.
+func corpusEntry(msg message) ([]byte, error) {
+	// The message belongs to the connection, so we work on a copy.
+	bs, err := msg.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	cp, err := newMessage(typeOf(msg))
+	if err != nil {
+		return nil, err
+	}
+	if err := cp.Unmarshal(bs); err != nil {
+		return nil, err
+	}
+	sanitizeMessage(cp)
+
+	wire, err := marshalUncompressed(cp)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("go test fuzz v1\n[]byte(%q)\n", wire)), nil
+}
+
+// marshalUncompressed returns the message as it's put on the wire when not
+// compressed.
+func marshalUncompressed(msg message) ([]byte, error) {
+	hdr := Header{Type: typeOf(msg)}
+	hdrSize := hdr.ProtoSize()
+	size := msg.ProtoSize()
+	buf := make([]byte, 2+hdrSize+4+size)
+	binary.BigEndian.PutUint16(buf, uint16(hdrSize))
+	if _, err := hdr.MarshalTo(buf[2:]); err != nil {
+		return nil, err
+	}
+	binary.BigEndian.PutUint32(buf[2+hdrSize:], uint32(size))
+	if _, err := msg.MarshalTo(buf[2+hdrSize+4:]); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// sanitizeMessage replaces anything revealing about the user's data in the
+// message: Names are replaced by hashes, keeping their structure, file
+// data is zeroed and device details besides the ID are removed.
+func sanitizeMessage(msg message) {
+	switch msg := msg.(type) {
+	case *ClusterConfig:
+		for i := range msg.Folders {
+			f := &msg.Folders[i]
+			f.ID = sanitizeName(f.ID)
+			f.Label = sanitizeName(f.Label)
+			for j := range f.Devices {
+				d := &f.Devices[j]
+				d.Name = sanitizeName(d.Name)
+				d.Addresses = nil
+				d.CertName = ""
+				d.EncryptionPasswordToken = nil
+			}
+		}
+	case *Index:
+		msg.Folder = sanitizeName(msg.Folder)
+		sanitizeFiles(msg.Files)
+	case *IndexUpdate:
+		msg.Folder = sanitizeName(msg.Folder)
+		sanitizeFiles(msg.Files)
+	case *Request:
+		msg.Folder = sanitizeName(msg.Folder)
+		msg.Name = sanitizePath(msg.Name)
+	case *Response:
+		for i := range msg.Data {
+			msg.Data[i] = 0
+		}
+	case *DownloadProgress:
+		msg.Folder = sanitizeName(msg.Folder)
+		for i := range msg.Updates {
+			msg.Updates[i].Name = sanitizePath(msg.Updates[i].Name)
+		}
+	case *Control:
+		msg.Token = ""
+		msg.Payload = nil
+	}
+}
+
+func sanitizeFiles(fs []FileInfo) {
+	for i := range fs {
+		f := &fs[i]
+		f.Name = sanitizePath(f.Name)
+		if f.SymlinkTarget != "" {
+			f.SymlinkTarget = sanitizePath(f.SymlinkTarget)
+		}
+		f.Platform = PlatformData{}
+	}
+}
+
+// sanitizePath hashes each component of the path.
+func sanitizePath(p string) string {
+	parts := strings.Split(p, "/")
+	for i, part := range parts {
+		parts[i] = sanitizeName(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+func sanitizeName(s string) string {
+	if s == "" || s == "." || s == ".." {
+		return s
+	}
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:6])
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package protocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func FuzzReadMessage(f *testing.F) {
+	seeds := []message{
+		&ClusterConfig{Folders: []Folder{{ID: "folder", Devices: []Device{{ID: c0ID, Name: "name"}}}}},
+		&Index{Folder: "folder", Files: []FileInfo{{Name: "a/b", Size: 1, Version: Vector{}.Update(1), Blocks: []BlockInfo{{Size: 1}}}}},
+		&IndexUpdate{Folder: "folder", Files: []FileInfo{{Name: "c", Deleted: true}}},
+		&Request{ID: 1, Folder: "folder", Name: "a/b", Size: 1},
+		&Response{ID: 1, Data: []byte{1}},
+		&DownloadProgress{Folder: "folder", Updates: []FileDownloadProgressUpdate{{Name: "a/b", BlockIndexes: []int{1}}}},
+		&Ping{},
+		&Close{Reason: "reason"},
+	}
+	for _, msg := range seeds {
+		bs, err := marshalUncompressed(msg)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(bs)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Don't let the fuzzer make us allocate for lengths far beyond
+		// the data it gave us.
+		if len(data) < 2 {
+			return
+		}
+		hdrLen := int(binary.BigEndian.Uint16(data))
+		if len(data) < 2+hdrLen+4 || int(binary.BigEndian.Uint32(data[2+hdrLen:])) > len(data) {
+			return
+		}
+
+		msg, err := readTestMessage(data)
+		if err != nil {
+			return
+		}
+
+		// What we read must survive a round trip.
+		bs, err := marshalUncompressed(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg2, err := readTestMessage(bs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m1, _ := msg.Marshal()
+		m2, _ := msg2.Marshal()
+		if !bytes.Equal(m1, m2) {
+			t.Error("message changed in round trip")
+		}
+	})
+}
+
+func readTestMessage(data []byte) (message, error) {
+	c := &rawConnection{cr: &countingReader{Reader: bytes.NewReader(data)}}
+	return c.readMessage(make([]byte, 4))
+}
+
+func TestCorpusRecorder(t *testing.T) {
+	dir := t.TempDir()
+	r := newCorpusRecorder(dir)
+
+	idx := &Index{
+		Folder: "private-folder",
+		Files:  []FileInfo{{Name: "secret/plans.txt", SymlinkTarget: "../secret", Size: 3}},
+	}
+	resp := &Response{ID: 1, Data: []byte("abc")}
+	r.record(idx)
+	r.record(resp)
+
+	// The messages of the connection are untouched.
+	if idx.Files[0].Name != "secret/plans.txt" || string(resp.Data) != "abc" {
+		t.Fatal("recording modified the message")
+	}
+
+	names, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 {
+		t.Fatalf("expected two corpus entries, got %d", len(names))
+	}
+	for _, name := range names {
+		bs, err := os.ReadFile(filepath.Join(dir, name.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(string(bs), "\n")
+		if lines[0] != "go test fuzz v1" || !strings.HasPrefix(lines[1], "[]byte(") {
+			t.Fatalf("unexpected corpus entry %q", bs)
+		}
+		data, err := strconv.Unquote(strings.TrimSuffix(strings.TrimPrefix(lines[1], "[]byte("), ")"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(data, "secret") || strings.Contains(data, "private") || strings.Contains(data, "abc") {
+			t.Errorf("corpus entry not sanitized: %q", data)
+		}
+
+		msg, err := readTestMessage([]byte(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch msg := msg.(type) {
+		case *Index:
+			if parts := strings.Split(msg.Files[0].Name, "/"); len(parts) != 2 || msg.Files[0].Size != 3 {
+				t.Errorf("unexpected sanitized file %v", msg.Files[0])
+			}
+		case *Response:
+			if !bytes.Equal(msg.Data, []byte{0, 0, 0}) {
+				t.Errorf("unexpected sanitized data %v", msg.Data)
+			}
+		default:
+			t.Errorf("unexpected message %T", msg)
+		}
+	}
+}
@@ -151,6 +151,10 @@ func (e encryptedModel) DownloadProgress(folder string, updates []FileDownloadPr
 	return nil
 }
 
+func (e encryptedModel) Control(ctrl Control) error {
+	return e.model.Control(ctrl)
+}
+
 func (e encryptedModel) ClusterConfig(config ClusterConfig) error {
 	return e.model.ClusterConfig(config)
 }
@@ -246,6 +250,10 @@ func (e encryptedConnection) DownloadProgress(ctx context.Context, folder string
 	// No need to send these
 }
 
+func (e encryptedConnection) Control(ctx context.Context, ctrl Control) {
+	e.conn.Control(ctx, ctrl)
+}
+
 func (e encryptedConnection) ClusterConfig(config ClusterConfig) {
 	e.conn.ClusterConfig(config)
 }
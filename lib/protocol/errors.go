@@ -35,3 +35,41 @@ func errorToCode(err error) ErrorCode {
 		return ErrorCodeGeneric
 	}
 }
+
+// CloseError is an error closing a connection with a reason code, sent to
+// the other side so it can tell the user why it was dropped.
+type CloseError struct {
+	Code   CloseReason
+	Folder string // the folder the reason concerns, if any
+	Err    error
+}
+
+func (e *CloseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CloseError) Unwrap() error {
+	return e.Err
+}
+
+// RemoteCloseError is the error a connection is closed with when the other
+// side closed it.
+type RemoteCloseError struct {
+	Code   CloseReason
+	Folder string
+	Reason string
+}
+
+func (e *RemoteCloseError) Error() string {
+	return "closed by remote: " + e.Reason
+}
+
+func newCloseMessage(err error) *Close {
+	msg := &Close{Reason: err.Error()}
+	var cerr *CloseError
+	if errors.As(err, &cerr) {
+		msg.Code = cerr.Code
+		msg.Folder = cerr.Folder
+	}
+	return msg
+}
@@ -31,6 +31,12 @@ type Connection struct {
 	clusterConfigArgsForCall []struct {
 		arg1 protocol.ClusterConfig
 	}
+	ControlStub        func(context.Context, protocol.Control)
+	controlMutex       sync.RWMutex
+	controlArgsForCall []struct {
+		arg1 context.Context
+		arg2 protocol.Control
+	}
 	CryptoStub        func() string
 	cryptoMutex       sync.RWMutex
 	cryptoArgsForCall []struct {
@@ -104,6 +110,16 @@ type Connection struct {
 	isLocalReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	KeyExchangeStub        func() string
+	keyExchangeMutex       sync.RWMutex
+	keyExchangeArgsForCall []struct {
+	}
+	keyExchangeReturns struct {
+		result1 string
+	}
+	keyExchangeReturnsOnCall map[int]struct {
+		result1 string
+	}
 	PriorityStub        func() int
 	priorityMutex       sync.RWMutex
 	priorityArgsForCall []struct {
@@ -315,6 +331,39 @@ func (fake *Connection) ClusterConfigArgsForCall(i int) protocol.ClusterConfig {
 	return argsForCall.arg1
 }
 
+func (fake *Connection) Control(arg1 context.Context, arg2 protocol.Control) {
+	fake.controlMutex.Lock()
+	fake.controlArgsForCall = append(fake.controlArgsForCall, struct {
+		arg1 context.Context
+		arg2 protocol.Control
+	}{arg1, arg2})
+	stub := fake.ControlStub
+	fake.recordInvocation("Control", []interface{}{arg1, arg2})
+	fake.controlMutex.Unlock()
+	if stub != nil {
+		fake.ControlStub(arg1, arg2)
+	}
+}
+
+func (fake *Connection) ControlCallCount() int {
+	fake.controlMutex.RLock()
+	defer fake.controlMutex.RUnlock()
+	return len(fake.controlArgsForCall)
+}
+
+func (fake *Connection) ControlCalls(stub func(context.Context, protocol.Control)) {
+	fake.controlMutex.Lock()
+	defer fake.controlMutex.Unlock()
+	fake.ControlStub = stub
+}
+
+func (fake *Connection) ControlArgsForCall(i int) (context.Context, protocol.Control) {
+	fake.controlMutex.RLock()
+	defer fake.controlMutex.RUnlock()
+	argsForCall := fake.controlArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *Connection) Crypto() string {
 	fake.cryptoMutex.Lock()
 	ret, specificReturn := fake.cryptoReturnsOnCall[len(fake.cryptoArgsForCall)]
@@ -702,6 +751,59 @@ func (fake *Connection) IsLocalReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *Connection) KeyExchange() string {
+	fake.keyExchangeMutex.Lock()
+	ret, specificReturn := fake.keyExchangeReturnsOnCall[len(fake.keyExchangeArgsForCall)]
+	fake.keyExchangeArgsForCall = append(fake.keyExchangeArgsForCall, struct {
+	}{})
+	stub := fake.KeyExchangeStub
+	fakeReturns := fake.keyExchangeReturns
+	fake.recordInvocation("KeyExchange", []interface{}{})
+	fake.keyExchangeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Connection) KeyExchangeCallCount() int {
+	fake.keyExchangeMutex.RLock()
+	defer fake.keyExchangeMutex.RUnlock()
+	return len(fake.keyExchangeArgsForCall)
+}
+
+func (fake *Connection) KeyExchangeCalls(stub func() string) {
+	fake.keyExchangeMutex.Lock()
+	defer fake.keyExchangeMutex.Unlock()
+	fake.KeyExchangeStub = stub
+}
+
+func (fake *Connection) KeyExchangeReturns(result1 string) {
+	fake.keyExchangeMutex.Lock()
+	defer fake.keyExchangeMutex.Unlock()
+	fake.KeyExchangeStub = nil
+	fake.keyExchangeReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *Connection) KeyExchangeReturnsOnCall(i int, result1 string) {
+	fake.keyExchangeMutex.Lock()
+	defer fake.keyExchangeMutex.Unlock()
+	fake.KeyExchangeStub = nil
+	if fake.keyExchangeReturnsOnCall == nil {
+		fake.keyExchangeReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.keyExchangeReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *Connection) Priority() int {
 	fake.priorityMutex.Lock()
 	ret, specificReturn := fake.priorityReturnsOnCall[len(fake.priorityArgsForCall)]
@@ -1162,6 +1264,8 @@ func (fake *Connection) Invocations() map[string][][]interface{} {
 	defer fake.closedMutex.RUnlock()
 	fake.clusterConfigMutex.RLock()
 	defer fake.clusterConfigMutex.RUnlock()
+	fake.controlMutex.RLock()
+	defer fake.controlMutex.RUnlock()
 	fake.cryptoMutex.RLock()
 	defer fake.cryptoMutex.RUnlock()
 	fake.deviceIDMutex.RLock()
@@ -1176,6 +1280,8 @@ func (fake *Connection) Invocations() map[string][][]interface{} {
 	defer fake.indexUpdateMutex.RUnlock()
 	fake.isLocalMutex.RLock()
 	defer fake.isLocalMutex.RUnlock()
+	fake.keyExchangeMutex.RLock()
+	defer fake.keyExchangeMutex.RUnlock()
 	fake.priorityMutex.RLock()
 	defer fake.priorityMutex.RUnlock()
 	fake.remoteAddrMutex.RLock()
@@ -40,6 +40,16 @@ type ConnectionInfo struct {
 	isLocalReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	KeyExchangeStub        func() string
+	keyExchangeMutex       sync.RWMutex
+	keyExchangeArgsForCall []struct {
+	}
+	keyExchangeReturns struct {
+		result1 string
+	}
+	keyExchangeReturnsOnCall map[int]struct {
+		result1 string
+	}
 	PriorityStub        func() int
 	priorityMutex       sync.RWMutex
 	priorityArgsForCall []struct {
@@ -253,6 +263,59 @@ func (fake *ConnectionInfo) IsLocalReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *ConnectionInfo) KeyExchange() string {
+	fake.keyExchangeMutex.Lock()
+	ret, specificReturn := fake.keyExchangeReturnsOnCall[len(fake.keyExchangeArgsForCall)]
+	fake.keyExchangeArgsForCall = append(fake.keyExchangeArgsForCall, struct {
+	}{})
+	stub := fake.KeyExchangeStub
+	fakeReturns := fake.keyExchangeReturns
+	fake.recordInvocation("KeyExchange", []interface{}{})
+	fake.keyExchangeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ConnectionInfo) KeyExchangeCallCount() int {
+	fake.keyExchangeMutex.RLock()
+	defer fake.keyExchangeMutex.RUnlock()
+	return len(fake.keyExchangeArgsForCall)
+}
+
+func (fake *ConnectionInfo) KeyExchangeCalls(stub func() string) {
+	fake.keyExchangeMutex.Lock()
+	defer fake.keyExchangeMutex.Unlock()
+	fake.KeyExchangeStub = stub
+}
+
+func (fake *ConnectionInfo) KeyExchangeReturns(result1 string) {
+	fake.keyExchangeMutex.Lock()
+	defer fake.keyExchangeMutex.Unlock()
+	fake.KeyExchangeStub = nil
+	fake.keyExchangeReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *ConnectionInfo) KeyExchangeReturnsOnCall(i int, result1 string) {
+	fake.keyExchangeMutex.Lock()
+	defer fake.keyExchangeMutex.Unlock()
+	fake.KeyExchangeStub = nil
+	if fake.keyExchangeReturnsOnCall == nil {
+		fake.keyExchangeReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.keyExchangeReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *ConnectionInfo) Priority() int {
 	fake.priorityMutex.Lock()
 	ret, specificReturn := fake.priorityReturnsOnCall[len(fake.priorityArgsForCall)]
@@ -527,6 +590,8 @@ func (fake *ConnectionInfo) Invocations() map[string][][]interface{} {
 	defer fake.establishedAtMutex.RUnlock()
 	fake.isLocalMutex.RLock()
 	defer fake.isLocalMutex.RUnlock()
+	fake.keyExchangeMutex.RLock()
+	defer fake.keyExchangeMutex.RUnlock()
 	fake.priorityMutex.RLock()
 	defer fake.priorityMutex.RUnlock()
 	fake.remoteAddrMutex.RLock()
@@ -0,0 +1,121 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package protocol
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	pairingScheme = "syncthing"
+	pairingHost   = "pair"
+
+	// The number of symbols in a short authentication string, each
+	// carrying six bits.
+	sasLength = 7
+)
+
+var errNotPairingURI = errors.New("not a pairing URI")
+
+// PairingURI returns the payload to put in a QR code for pairing with the
+// device, e.g. "syncthing://pair/<device ID>?name=<name>".
+func PairingURI(id DeviceID, name string) string {
+	u := url.URL{Scheme: pairingScheme, Host: pairingHost, Path: "/" + id.String()}
+	if name != "" {
+		u.RawQuery = url.Values{"name": {name}}.Encode()
+	}
+	return u.String()
+}
+
+// ParsePairingURI returns the device ID and name from a pairing payload. A
+// plain device ID, as in older QR codes, is accepted as well.
+func ParsePairingURI(s string) (DeviceID, string, error) {
+	if !strings.HasPrefix(s, pairingScheme+"://") {
+		id, err := DeviceIDFromString(s)
+		return id, "", err
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return EmptyDeviceID, "", err
+	}
+	if u.Host != pairingHost {
+		return EmptyDeviceID, "", errNotPairingURI
+	}
+	id, err := DeviceIDFromString(strings.TrimPrefix(u.Path, "/"))
+	if err != nil {
+		return EmptyDeviceID, "", err
+	}
+	return id, u.Query().Get("name"), nil
+}
+
+// PairingSAS is a short authentication string for a pair of devices, which
+// both sides show the same of, to compare out of band instead of the long
+// device IDs.
+type PairingSAS struct {
+	Emoji []string `json:"emoji"`
+	Words []string `json:"words"`
+}
+
+func (s PairingSAS) String() string {
+	return fmt.Sprintf("%s (%s)", strings.Join(s.Emoji, " "), strings.Join(s.Words, " "))
+}
+
+// NewPairingSAS returns the short authentication string for the two
+// devices, the same regardless of their order.
+func NewPairingSAS(a, b DeviceID) PairingSAS {
+	if a.Compare(b) > 0 {
+		a, b = b, a
+	}
+	h := sha256.New()
+	h.Write([]byte("syncthing pairing sas"))
+	h.Write(a[:])
+	h.Write(b[:])
+	sum := h.Sum(nil)
+
+	// Take six bits at a time from the start of the hash.
+	var bits uint64
+	for _, c := range sum[:8] {
+		bits = bits<<8 | uint64(c)
+	}
+	sas := PairingSAS{
+		Emoji: make([]string, sasLength),
+		Words: make([]string, sasLength),
+	}
+	for i := 0; i < sasLength; i++ {
+		sym := sasSymbols[bits>>(64-6*(i+1))&0x3f]
+		sas.Emoji[i] = sym.emoji
+		sas.Words[i] = sym.word
+	}
+	return sas
+}
+
+// sasSymbols are 64 emoji that are easy to tell apart, with their names.
+var sasSymbols = [64]struct {
+	emoji string
+	word  string
+}{
+	{"🐶", "dog"}, {"🐱", "cat"}, {"🦁", "lion"}, {"🐎", "horse"},
+	{"🦄", "unicorn"}, {"🐷", "pig"}, {"🐘", "elephant"}, {"🐰", "rabbit"},
+	{"🐼", "panda"}, {"🐓", "rooster"}, {"🐧", "penguin"}, {"🐢", "turtle"},
+	{"🐟", "fish"}, {"🐙", "octopus"}, {"🦋", "butterfly"}, {"🌷", "flower"},
+	{"🌳", "tree"}, {"🌵", "cactus"}, {"🍄", "mushroom"}, {"🌏", "globe"},
+	{"🌙", "moon"}, {"☁️", "cloud"}, {"🔥", "fire"}, {"🍌", "banana"},
+	{"🍎", "apple"}, {"🍓", "strawberry"}, {"🌽", "corn"}, {"🍕", "pizza"},
+	{"🎂", "cake"}, {"❤️", "heart"}, {"😀", "smiley"}, {"🤖", "robot"},
+	{"🎩", "hat"}, {"👓", "glasses"}, {"🔧", "spanner"}, {"🎅", "santa"},
+	{"👍", "thumbs up"}, {"☂️", "umbrella"}, {"⌛", "hourglass"}, {"⏰", "clock"},
+	{"🎁", "gift"}, {"💡", "light bulb"}, {"📕", "book"}, {"✏️", "pencil"},
+	{"📎", "paperclip"}, {"✂️", "scissors"}, {"🔒", "lock"}, {"🔑", "key"},
+	{"🔨", "hammer"}, {"☎️", "telephone"}, {"🏁", "flag"}, {"🚂", "train"},
+	{"🚲", "bicycle"}, {"✈️", "aeroplane"}, {"🚀", "rocket"}, {"🏆", "trophy"},
+	{"⚽", "ball"}, {"🎸", "guitar"}, {"🎺", "trumpet"}, {"🔔", "bell"},
+	{"⚓", "anchor"}, {"🎧", "headphones"}, {"📁", "folder"}, {"📌", "pin"},
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package protocol
+
+import (
+	"testing"
+)
+
+func TestPairingURI(t *testing.T) {
+	id := NewDeviceID([]byte("some cert"))
+
+	uri := PairingURI(id, "my laptop & more")
+	pid, name, err := ParsePairingURI(uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pid != id || name != "my laptop & more" {
+		t.Errorf("got %v %q from %q", pid, name, uri)
+	}
+
+	// Plain device IDs are pairing payloads too.
+	pid, name, err = ParsePairingURI(id.String())
+	if err != nil || pid != id || name != "" {
+		t.Errorf("got %v %q %v from plain device ID", pid, name, err)
+	}
+
+	for _, s := range []string{"syncthing://other/" + id.String(), "syncthing://pair/foo", "https://example.com"} {
+		if _, _, err := ParsePairingURI(s); err == nil {
+			t.Errorf("expected %q to be rejected", s)
+		}
+	}
+}
+
+func TestPairingSAS(t *testing.T) {
+	a := NewDeviceID([]byte("device a"))
+	b := NewDeviceID([]byte("device b"))
+	c := NewDeviceID([]byte("device c"))
+
+	ab := NewPairingSAS(a, b)
+	if len(ab.Emoji) != sasLength || len(ab.Words) != sasLength {
+		t.Fatalf("unexpected length of %v", ab)
+	}
+	if ba := NewPairingSAS(b, a); ba.String() != ab.String() {
+		t.Errorf("order matters: %v != %v", ab, ba)
+	}
+	if ac := NewPairingSAS(a, c); ac.String() == ab.String() {
+		t.Errorf("different pairs have the same string %v", ab)
+	}
+}
@@ -25,6 +25,8 @@ import (
 	"time"
 
 	lz4 "github.com/pierrec/lz4/v4"
+
+	"github.com/syncthing/syncthing/lib/chaos"
 )
 
 const (
@@ -134,6 +136,8 @@ type Model interface {
 	Closed(conn Connection, err error)
 	// The peer device sent progress updates for the files it is currently downloading
 	DownloadProgress(conn Connection, folder string, updates []FileDownloadProgressUpdate) error
+	// The peer device sent a control message
+	Control(conn Connection, ctrl Control) error
 }
 
 // contextLessModel is the Model interface, but without the initial
@@ -145,6 +149,7 @@ type contextLessModel interface {
 	ClusterConfig(config ClusterConfig) error
 	Closed(err error)
 	DownloadProgress(folder string, updates []FileDownloadProgressUpdate) error
+	Control(ctrl Control) error
 }
 
 type RequestResponse interface {
@@ -163,6 +168,7 @@ type Connection interface {
 	Request(ctx context.Context, folder string, name string, blockNo int, offset int64, size int, hash []byte, weakHash uint32, fromTemporary bool) ([]byte, error)
 	ClusterConfig(config ClusterConfig)
 	DownloadProgress(ctx context.Context, folder string, updates []FileDownloadProgressUpdate)
+	Control(ctx context.Context, ctrl Control)
 	Statistics() Statistics
 	Closed() <-chan struct{}
 	ConnectionInfo
@@ -176,6 +182,7 @@ type ConnectionInfo interface {
 	Priority() int
 	String() string
 	Crypto() string
+	KeyExchange() string
 	EstablishedAt() time.Time
 }
 
@@ -199,8 +206,11 @@ type rawConnection struct {
 
 	inbox                 chan message
 	outbox                chan asyncMessage
+	indexBox              chan asyncMessage // lower priority than outbox
 	closeBox              chan asyncMessage
-	clusterConfigBox      chan *ClusterConfig
+	clusterConfigBox      chan struct{}  // signals a pending cluster config
+	clusterConfigMut      sync.Mutex     // protects pendingClusterConfig
+	pendingClusterConfig  *ClusterConfig // latest cluster config not yet sent
 	dispatcherLoopStopped chan struct{}
 	closed                chan struct{}
 	closeOnce             sync.Once
@@ -280,8 +290,9 @@ func newRawConnection(deviceID DeviceID, reader io.Reader, writer io.Writer, clo
 		awaiting:              make(map[int]chan asyncResult),
 		inbox:                 make(chan message),
 		outbox:                make(chan asyncMessage),
+		indexBox:              make(chan asyncMessage),
 		closeBox:              make(chan asyncMessage),
-		clusterConfigBox:      make(chan *ClusterConfig),
+		clusterConfigBox:      make(chan struct{}, 1),
 		dispatcherLoopStopped: make(chan struct{}),
 		closed:                make(chan struct{}),
 		compression:           compress,
@@ -329,10 +340,10 @@ func (c *rawConnection) Index(ctx context.Context, folder string, idx []FileInfo
 	default:
 	}
 	c.idxMut.Lock()
-	c.send(ctx, &Index{
+	c.sendIndex(ctx, &Index{
 		Folder: folder,
 		Files:  idx,
-	}, nil)
+	})
 	c.idxMut.Unlock()
 	return nil
 }
@@ -345,10 +356,10 @@ func (c *rawConnection) IndexUpdate(ctx context.Context, folder string, idx []Fi
 	default:
 	}
 	c.idxMut.Lock()
-	c.send(ctx, &IndexUpdate{
+	c.sendIndex(ctx, &IndexUpdate{
 		Folder: folder,
 		Files:  idx,
-	}, nil)
+	})
 	c.idxMut.Unlock()
 	return nil
 }
@@ -394,10 +405,16 @@ func (c *rawConnection) Request(ctx context.Context, folder string, name string,
 }
 
 // ClusterConfig sends the cluster configuration message to the peer.
+// ClusterConfig queues the cluster config for sending. A cluster config
+// that is still waiting to be sent is replaced, so that a peer only gets
+// the latest one when several are queued in quick succession.
 func (c *rawConnection) ClusterConfig(config ClusterConfig) {
+	c.clusterConfigMut.Lock()
+	c.pendingClusterConfig = &config
+	c.clusterConfigMut.Unlock()
 	select {
-	case c.clusterConfigBox <- &config:
-	case <-c.closed:
+	case c.clusterConfigBox <- struct{}{}:
+	default:
 	}
 }
 
@@ -413,6 +430,11 @@ func (c *rawConnection) DownloadProgress(ctx context.Context, folder string, upd
 	}, nil)
 }
 
+// Control sends a control message to the peer.
+func (c *rawConnection) Control(ctx context.Context, ctrl Control) {
+	c.send(ctx, &ctrl, nil)
+}
+
 func (c *rawConnection) ping() bool {
 	return c.send(context.Background(), &Ping{}, nil)
 }
@@ -429,6 +451,9 @@ func (c *rawConnection) readerLoop() {
 			c.internalClose(err)
 			return
 		}
+		if corpus != nil {
+			corpus.record(msg)
+		}
 		select {
 		case c.inbox <- msg:
 		case <-c.closed:
@@ -463,7 +488,7 @@ func (c *rawConnection) dispatcherLoop() (err error) {
 				state = stateReady
 			}
 		case *Close:
-			return fmt.Errorf("closed by remote: %v", msg.Reason)
+			return &RemoteCloseError{Code: msg.Code, Folder: msg.Folder, Reason: msg.Reason}
 		default:
 			if state != stateReady {
 				return newProtocolError(fmt.Errorf("invalid state %d", state), msgContext)
@@ -502,6 +527,9 @@ func (c *rawConnection) dispatcherLoop() (err error) {
 
 		case *DownloadProgress:
 			err = c.model.DownloadProgress(msg.Folder, msg.Updates)
+
+		case *Control:
+			err = c.model.Control(*msg)
 		}
 		if err != nil {
 			return newHandleError(err, msgContext)
@@ -720,12 +748,27 @@ func (c *rawConnection) send(ctx context.Context, msg message, done chan struct{
 	return false
 }
 
+// sendIndex queues an index message, which is sent with lower priority than
+// messages queued by send.
+func (c *rawConnection) sendIndex(ctx context.Context, msg message) bool {
+	select {
+	case c.indexBox <- asyncMessage{msg, nil}:
+		return true
+	case <-c.closed:
+	case <-ctx.Done():
+	}
+	return false
+}
+
+// maxPriorityInARow is the number of messages from the outbox that are
+// sent in a row while index data is waiting, before letting an index
+// message through.
+const maxPriorityInARow = 16
+
 func (c *rawConnection) writerLoop() {
 	select {
-	case cc := <-c.clusterConfigBox:
-		err := c.writeMessage(cc)
-		if err != nil {
-			c.internalClose(err)
+	case <-c.clusterConfigBox:
+		if !c.writePendingClusterConfig() {
 			return
 		}
 	case hm := <-c.closeBox:
@@ -735,21 +778,34 @@ func (c *rawConnection) writerLoop() {
 	case <-c.closed:
 		return
 	}
+	priorityInARow := 0
 	for {
+		// Requests and responses take precedence over index data, as
+		// otherwise large index transfers hold up syncing.
+		if priorityInARow < maxPriorityInARow {
+			select {
+			case hm := <-c.outbox:
+				if !c.writeAsyncMessage(hm) {
+					return
+				}
+				priorityInARow++
+				continue
+			default:
+			}
+		}
+		priorityInARow = 0
+
 		select {
-		case cc := <-c.clusterConfigBox:
-			err := c.writeMessage(cc)
-			if err != nil {
-				c.internalClose(err)
+		case <-c.clusterConfigBox:
+			if !c.writePendingClusterConfig() {
 				return
 			}
 		case hm := <-c.outbox:
-			err := c.writeMessage(hm.msg)
-			if hm.done != nil {
-				close(hm.done)
+			if !c.writeAsyncMessage(hm) {
+				return
 			}
-			if err != nil {
-				c.internalClose(err)
+		case hm := <-c.indexBox:
+			if !c.writeAsyncMessage(hm) {
 				return
 			}
 
@@ -764,6 +820,52 @@ func (c *rawConnection) writerLoop() {
 	}
 }
 
+// writePendingClusterConfig writes the latest queued cluster config, if
+// any, closing the connection and returning false on error.
+func (c *rawConnection) writePendingClusterConfig() bool {
+	c.clusterConfigMut.Lock()
+	cc := c.pendingClusterConfig
+	c.pendingClusterConfig = nil
+	c.clusterConfigMut.Unlock()
+	if cc == nil {
+		return true
+	}
+	if err := c.writeMessage(cc); err != nil {
+		c.internalClose(err)
+		return false
+	}
+	return true
+}
+
+// writeAsyncMessage writes the message and signals completion, closing the
+// connection and returning false on error.
+func (c *rawConnection) writeAsyncMessage(hm asyncMessage) bool {
+	if chaos.Enabled {
+		if d := chaos.MessageDelay(); d > 0 {
+			select {
+			case <-time.After(d):
+			case <-c.closed:
+			}
+		}
+		if chaos.DropMessage() {
+			l.Debugf("Dropping message to %v by fault injection", c.DeviceID())
+			if hm.done != nil {
+				close(hm.done)
+			}
+			return true
+		}
+	}
+	err := c.writeMessage(hm.msg)
+	if hm.done != nil {
+		close(hm.done)
+	}
+	if err != nil {
+		c.internalClose(err)
+		return false
+	}
+	return true
+}
+
 func (c *rawConnection) writeMessage(msg message) error {
 	msgContext, _ := messageContext(msg)
 	l.Debugf("Writing %v", msgContext)
@@ -884,6 +986,8 @@ func typeOf(msg message) MessageType {
 		return MessageTypePing
 	case *Close:
 		return MessageTypeClose
+	case *Control:
+		return MessageTypeControl
 	default:
 		panic("bug: unknown message type")
 	}
@@ -907,6 +1011,8 @@ func newMessage(t MessageType) (message, error) {
 		return new(Ping), nil
 	case MessageTypeClose:
 		return new(Close), nil
+	case MessageTypeControl:
+		return new(Control), nil
 	default:
 		return nil, errUnknownMessage
 	}
@@ -939,7 +1045,7 @@ func (c *rawConnection) Close(err error) {
 		done := make(chan struct{})
 		timeout := time.NewTimer(CloseTimeout)
 		select {
-		case c.closeBox <- asyncMessage{&Close{err.Error()}, done}:
+		case c.closeBox <- asyncMessage{newCloseMessage(err), done}:
 			select {
 			case <-done:
 			case <-timeout.C:
@@ -957,6 +1063,26 @@ func (c *rawConnection) Close(err error) {
 	go c.internalClose(err)
 }
 
+// WriteClose writes a Close message for the error directly to w, to reject a
+// connection after exchanging hello messages without setting it up.
+func WriteClose(w io.Writer, err error) error {
+	msg := newCloseMessage(err)
+	hdr := Header{Type: MessageTypeClose}
+	hdrSize := hdr.ProtoSize()
+	size := msg.ProtoSize()
+	buf := make([]byte, 2+hdrSize+4+size)
+	binary.BigEndian.PutUint16(buf, uint16(hdrSize))
+	if _, err := hdr.MarshalTo(buf[2:]); err != nil {
+		return fmt.Errorf("marshalling header: %w", err)
+	}
+	binary.BigEndian.PutUint32(buf[2+hdrSize:], uint32(size))
+	if _, err := msg.MarshalTo(buf[2+hdrSize+4:]); err != nil {
+		return fmt.Errorf("marshalling message: %w", err)
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
 // internalClose is called if there is an unexpected error during normal operation.
 func (c *rawConnection) internalClose(err error) {
 	c.closeOnce.Do(func() {
@@ -1101,6 +1227,8 @@ func messageContext(msg message) (string, error) {
 		return "ping", nil
 	case *Close:
 		return "close", nil
+	case *Control:
+		return fmt.Sprintf("control %v", msg.Type), nil
 	default:
 		return "", errors.New("unknown or empty message")
 	}
@@ -1137,3 +1265,7 @@ func (c *connectionWrappingModel) Closed(err error) {
 func (c *connectionWrappingModel) DownloadProgress(folder string, updates []FileDownloadProgressUpdate) error {
 	return c.model.DownloadProgress(c.conn, folder, updates)
 }
+
+func (c *connectionWrappingModel) Control(ctrl Control) error {
+	return c.model.Control(c.conn, ctrl)
+}
@@ -90,6 +90,44 @@ func TestClose(t *testing.T) {
 	}
 }
 
+func TestCloseReason(t *testing.T) {
+	oldCloseTimeout := CloseTimeout
+	CloseTimeout = 100 * time.Millisecond
+	defer func() {
+		CloseTimeout = oldCloseTimeout
+	}()
+
+	m0 := newTestModel()
+	m1 := newTestModel()
+
+	ar, aw := io.Pipe()
+	br, bw := io.Pipe()
+
+	c0 := getRawConnection(NewConnection(c0ID, ar, bw, testutil.NoopCloser{}, m0, new(mockedConnectionInfo), CompressionAlways, nil, testKeyGen))
+	c0.Start()
+	defer closeAndWait(c0, ar, bw)
+	c1 := NewConnection(c1ID, br, aw, testutil.NoopCloser{}, m1, new(mockedConnectionInfo), CompressionAlways, nil, testKeyGen)
+	c1.Start()
+	defer closeAndWait(c1, ar, bw)
+	c0.ClusterConfig(ClusterConfig{})
+	c1.ClusterConfig(ClusterConfig{})
+
+	c0.Close(&CloseError{Code: CloseReasonFolderPaused, Folder: "default", Err: errManual})
+
+	select {
+	case <-m1.closedCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the remote to close")
+	}
+	var rerr *RemoteCloseError
+	if !errors.As(m1.closedError(), &rerr) {
+		t.Fatalf("expected a remote close error, got %v", m1.closedError())
+	}
+	if rerr.Code != CloseReasonFolderPaused || rerr.Folder != "default" || rerr.Reason != errManual.Error() {
+		t.Errorf("unexpected close reason %+v", rerr)
+	}
+}
+
 // TestCloseOnBlockingSend checks that the connection does not deadlock when
 // Close is called while the underlying connection is broken (send blocks).
 // https://github.com/syncthing/syncthing/pull/5442
@@ -234,6 +272,61 @@ func TestClusterConfigFirst(t *testing.T) {
 	}
 }
 
+// TestIndexLowPriority checks that queued requests and responses are sent
+// before queued index data.
+func TestIndexLowPriority(t *testing.T) {
+	m := newTestModel()
+
+	rw := testutil.NewBlockingRW()
+	w := &gatedWriter{release: make(chan struct{})}
+	c := getRawConnection(NewConnection(c0ID, rw, w, testutil.NoopCloser{}, m, new(mockedConnectionInfo), CompressionNever, nil, testKeyGen))
+	c.Start()
+	defer closeAndWait(c, rw)
+
+	// The writer blocks on the cluster config, while both an index update
+	// and a ping get queued.
+	c.ClusterConfig(ClusterConfig{})
+	indexSent := make(chan struct{})
+	go func() {
+		c.sendIndex(context.Background(), &IndexUpdate{Folder: "default"})
+		close(indexSent)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	pingDone := make(chan struct{})
+	go c.send(context.Background(), &Ping{}, pingDone)
+	time.Sleep(50 * time.Millisecond)
+
+	close(w.release)
+	for _, ch := range []chan struct{}{pingDone, indexSent} {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for messages to be sent")
+		}
+	}
+
+	// Cluster config, ping, index update; the ping is by far the smallest.
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	if len(w.sizes) != 3 || w.sizes[1] >= w.sizes[2] {
+		t.Errorf("expected ping to be written before index, got writes of sizes %v", w.sizes)
+	}
+}
+
+type gatedWriter struct {
+	release chan struct{}
+	mut     sync.Mutex
+	sizes   []int
+}
+
+func (w *gatedWriter) Write(bs []byte) (int, error) {
+	<-w.release
+	w.mut.Lock()
+	w.sizes = append(w.sizes, len(bs))
+	w.mut.Unlock()
+	return len(bs), nil
+}
+
 // TestCloseTimeout checks that calling Close times out and proceeds, if sending
 // the close message does not succeed.
 func TestCloseTimeout(t *testing.T) {
@@ -0,0 +1,127 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package ratecontrol paces requests to remote servers, such as discovery
+// announcements and relay pool lookups. A token bucket caps the request
+// rate, and failed requests back off exponentially with jitter, honouring
+// any retry time given by the server, until a request succeeds again.
+package ratecontrol
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/syncthing/syncthing/lib/rand"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// A Controller paces the requests to one server.
+type Controller struct {
+	limiter *rate.Limiter
+	base    time.Duration
+	max     time.Duration
+	timeNow func() time.Time
+
+	mut      sync.Mutex
+	failures int
+	next     time.Time
+}
+
+// New returns a Controller that allows a request every interval with
+// bursts of up to burst requests, and backs off from base up to max after
+// failed requests.
+func New(interval time.Duration, burst int, base, max time.Duration) *Controller {
+	return &Controller{
+		limiter: rate.NewLimiter(rate.Every(interval), burst),
+		base:    base,
+		max:     max,
+		timeNow: time.Now,
+		mut:     sync.NewMutex(),
+	}
+}
+
+// Delay returns how long to wait until a request is allowed, or zero if it
+// may be made now, taking a token from the bucket.
+func (c *Controller) Delay() time.Duration {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	now := c.timeNow()
+	if c.failures > 0 {
+		if d := c.next.Sub(now); d > 0 {
+			return d
+		}
+	}
+	r := c.limiter.ReserveN(now, 1)
+	if d := r.DelayFrom(now); d > 0 {
+		r.CancelAt(now)
+		return d
+	}
+	return 0
+}
+
+// Wait blocks until a request is allowed.
+func (c *Controller) Wait(ctx context.Context) error {
+	for {
+		d := c.Delay()
+		if d == 0 {
+			return nil
+		}
+		t := time.NewTimer(d)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Success records a successful request, ending any backoff, with the next
+// request due after interval. It returns the interval.
+func (c *Controller) Success(interval time.Duration) time.Duration {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.failures = 0
+	c.next = c.timeNow().Add(interval)
+	return interval
+}
+
+// Failure records a failed request and returns how long to back off until
+// the next one, at least retryAfter if the server asked for that.
+func (c *Controller) Failure(retryAfter time.Duration) time.Duration {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.failures++
+	d := c.max
+	if shift := c.failures - 1; shift < 32 && c.base<<shift < c.max {
+		d = c.base << shift
+	}
+	// Half of the delay is random, so that clients failing at the same
+	// time spread out their retries.
+	d = d/2 + time.Duration(rand.Int63()%int64(d/2+1))
+	if retryAfter > d {
+		d = retryAfter
+	}
+	c.next = c.timeNow().Add(d)
+	return d
+}
+
+// Failures returns the number of requests that failed in a row.
+func (c *Controller) Failures() int {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.failures
+}
+
+// NextAttempt returns when the next request is due, or the zero time if
+// none is scheduled.
+func (c *Controller) NextAttempt() time.Time {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.next
+}
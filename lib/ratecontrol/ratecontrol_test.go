@@ -0,0 +1,68 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package ratecontrol
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	now := time.Now()
+	c := New(time.Minute, 2, time.Minute, time.Hour)
+	c.timeNow = func() time.Time { return now }
+
+	prev := time.Duration(0)
+	for i := 0; i < 10; i++ {
+		d := c.Failure(0)
+		if d > time.Hour {
+			t.Fatalf("backoff %v exceeds the maximum", d)
+		}
+		if i < 6 && d < prev/2 {
+			t.Errorf("backoff %v shrank from %v", d, prev)
+		}
+		if c.NextAttempt() != now.Add(d) {
+			t.Errorf("next attempt %v doesn't match the backoff %v", c.NextAttempt(), d)
+		}
+		if delay := c.Delay(); delay != d {
+			t.Errorf("expected to wait %v while backing off, got %v", d, delay)
+		}
+		prev = d
+	}
+	if c.Failures() != 10 {
+		t.Errorf("expected 10 failures, got %d", c.Failures())
+	}
+
+	// The server's retry time takes precedence over a shorter backoff.
+	if d := c.Failure(2 * time.Hour); d != 2*time.Hour {
+		t.Errorf("expected the server's retry time, got %v", d)
+	}
+
+	if d := c.Success(30 * time.Minute); d != 30*time.Minute || c.Failures() != 0 {
+		t.Errorf("unexpected interval %v or failures %d after success", d, c.Failures())
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	c := New(time.Minute, 2, time.Minute, time.Hour)
+	c.timeNow = func() time.Time { return now }
+
+	// A burst of two requests is allowed, then one a minute.
+	for i := 0; i < 2; i++ {
+		if d := c.Delay(); d != 0 {
+			t.Fatalf("request %d delayed by %v", i, d)
+		}
+	}
+	if d := c.Delay(); d != time.Minute {
+		t.Errorf("expected the third request to wait a minute, got %v", d)
+	}
+	now = now.Add(time.Minute)
+	if d := c.Delay(); d != 0 {
+		t.Errorf("request delayed by %v after the refill", d)
+	}
+}
@@ -11,20 +11,51 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/rand"
+	"github.com/syncthing/syncthing/lib/ratecontrol"
 	"github.com/syncthing/syncthing/lib/relay/protocol"
 )
 
+// Lookups in a relay pool are limited to a burst of a few and one a minute
+// on average. Failed lookups, including those where no relay was
+// connectable, back off from ten seconds up to half an hour.
+const (
+	poolLookupInterval    = time.Minute
+	poolLookupBurst       = 3
+	poolLookupBackoffBase = 10 * time.Second
+	poolLookupBackoffMax  = 30 * time.Minute
+)
+
+var (
+	poolRatesMut sync.Mutex
+	poolRates    = make(map[string]*ratecontrol.Controller) // pool URL -> rate
+)
+
+// poolRate returns the rate controller of the pool, which lives on across
+// the dynamic clients created for it.
+func poolRate(uri *url.URL) *ratecontrol.Controller {
+	poolRatesMut.Lock()
+	defer poolRatesMut.Unlock()
+	rc, ok := poolRates[uri.String()]
+	if !ok {
+		rc = ratecontrol.New(poolLookupInterval, poolLookupBurst, poolLookupBackoffBase, poolLookupBackoffMax)
+		poolRates[uri.String()] = rc
+	}
+	return rc
+}
+
 type dynamicClient struct {
 	commonClient
 
 	pooladdr *url.URL
 	certs    []tls.Certificate
 	timeout  time.Duration
+	rate     *ratecontrol.Controller
 
 	mut    sync.RWMutex // Protects client.
 	client *staticClient
@@ -35,49 +66,25 @@ func newDynamicClient(uri *url.URL, certs []tls.Certificate, invitations chan pr
 		pooladdr: uri,
 		certs:    certs,
 		timeout:  timeout,
+		rate:     poolRate(uri),
 	}
 	c.commonClient = newCommonClient(invitations, c.serve, fmt.Sprintf("dynamicClient@%p", c))
 	return c
 }
 
 func (c *dynamicClient) serve(ctx context.Context) error {
-	uri := *c.pooladdr
-
-	// Trim off the `dynamic+` prefix
-	uri.Scheme = uri.Scheme[8:]
-
-	l.Debugln(c, "looking up dynamic relays")
-
-	req, err := http.NewRequestWithContext(ctx, "GET", uri.String(), nil)
-	if err != nil {
-		l.Debugln(c, "failed to lookup dynamic relays", err)
-		return err
-	}
-	data, err := http.DefaultClient.Do(req)
-	if err != nil {
-		l.Debugln(c, "failed to lookup dynamic relays", err)
+	if err := c.rate.Wait(ctx); err != nil {
 		return err
 	}
 
-	var ann dynamicAnnouncement
-	err = json.NewDecoder(data.Body).Decode(&ann)
-	data.Body.Close()
+	addrs, retryAfter, err := c.lookup(ctx)
 	if err != nil {
 		l.Debugln(c, "failed to lookup dynamic relays", err)
+		c.rate.Failure(retryAfter)
 		return err
 	}
 
-	var addrs []string
-	for _, relayAnn := range ann.Relays {
-		ruri, err := url.Parse(relayAnn.URL)
-		if err != nil {
-			l.Debugln(c, "failed to parse dynamic relay address", relayAnn.URL, err)
-			continue
-		}
-		l.Debugln(c, "found", ruri)
-		addrs = append(addrs, ruri.String())
-	}
-
+	joined := false
 	for _, addr := range relayAddressesOrder(ctx, addrs) {
 		select {
 		case <-ctx.Done():
@@ -96,16 +103,72 @@ func (c *dynamicClient) serve(ctx context.Context) error {
 
 			err = c.client.Serve(ctx)
 			l.Debugf("Disconnected from %s://%s: %v", c.client.URI().Scheme, c.client.URI().Host, err)
+			if c.client.joined.Load() {
+				joined = true
+			}
 
 			c.mut.Lock()
 			c.client = nil
 			c.mut.Unlock()
 		}
 	}
+	if joined {
+		// We were served by a relay from the pool; look up a fresh
+		// list right away.
+		c.rate.Success(0)
+	} else {
+		c.rate.Failure(0)
+	}
 	l.Debugln(c, "could not find a connectable relay")
 	return errors.New("could not find a connectable relay")
 }
 
+// lookup returns the addresses of the relays in the pool, or an error with
+// the retry time the pool asked for, if any.
+func (c *dynamicClient) lookup(ctx context.Context) ([]string, time.Duration, error) {
+	uri := *c.pooladdr
+
+	// Trim off the `dynamic+` prefix
+	uri.Scheme = uri.Scheme[8:]
+
+	l.Debugln(c, "looking up dynamic relays")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", uri.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer data.Body.Close()
+	if data.StatusCode != http.StatusOK {
+		var retryAfter time.Duration
+		if secs, err := strconv.Atoi(data.Header.Get("Retry-After")); err == nil && secs > 0 {
+			retryAfter = time.Duration(secs) * time.Second
+		}
+		return nil, retryAfter, errors.New(data.Status)
+	}
+
+	var ann dynamicAnnouncement
+	if err := json.NewDecoder(data.Body).Decode(&ann); err != nil {
+		return nil, 0, err
+	}
+
+	var addrs []string
+	for _, relayAnn := range ann.Relays {
+		ruri, err := url.Parse(relayAnn.URL)
+		if err != nil {
+			l.Debugln(c, "failed to parse dynamic relay address", relayAnn.URL, err)
+			continue
+		}
+		l.Debugln(c, "found", ruri)
+		addrs = append(addrs, ruri.String())
+	}
+
+	return addrs, 0, nil
+}
+
 func (c *dynamicClient) Error() error {
 	c.mut.RLock()
 	defer c.mut.RUnlock()
@@ -115,6 +178,15 @@ func (c *dynamicClient) Error() error {
 	return c.client.Error()
 }
 
+// NextAttempt returns when the pool may be looked up again, if lookups are
+// backing off.
+func (c *dynamicClient) NextAttempt() time.Time {
+	if c.rate.Failures() == 0 {
+		return time.Time{}
+	}
+	return c.rate.NextAttempt()
+}
+
 func (c *dynamicClient) String() string {
 	return fmt.Sprintf("DynamicClient:%p:%s@%s", c, c.URI(), c.pooladdr)
 }
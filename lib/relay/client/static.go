@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"sync/atomic"
 	"time"
 
 	"github.com/syncthing/syncthing/lib/dialer"
@@ -27,8 +28,9 @@ type staticClient struct {
 	messageTimeout time.Duration
 	connectTimeout time.Duration
 
-	conn  *tls.Conn
-	token string
+	conn   *tls.Conn
+	token  string
+	joined atomic.Bool
 }
 
 func newStaticClient(uri *url.URL, certs []tls.Certificate, invitations chan protocol.SessionInvitation, timeout time.Duration) *staticClient {
@@ -66,6 +68,7 @@ func (c *staticClient) serve(ctx context.Context) error {
 	}
 
 	l.Infof("Joined relay %s://%s", c.uri.Scheme, c.uri.Host)
+	c.joined.Store(true)
 
 	messages := make(chan interface{})
 	errorsc := make(chan error, 1)
@@ -127,6 +127,7 @@ func (fakeInfo) Owner() int                 { return 0 }
 func (fakeInfo) Group() int                 { return 0 }
 func (fakeInfo) Sys() interface{}           { return nil }
 func (fakeInfo) InodeChangeTime() time.Time { return time.Time{} }
+func (fakeInfo) Inode() uint64              { return 0 }
 
 type fakeFile struct {
 	name       string
@@ -64,8 +64,15 @@ type Config struct {
 	ScanXattrs bool
 	// Filter for extended attributes
 	XattrFilter XattrFilter
+	// If KeepBlockSize is true, changed files retain their current block
+	// size unless it is off by more than keepBlockSizeMaxFactor.
+	KeepBlockSize bool
 }
 
+// keepBlockSizeMaxFactor is how much the block size picked for a file may
+// differ from its current one before it's changed, with KeepBlockSize.
+const keepBlockSizeMaxFactor = 8
+
 type CurrentFiler interface {
 	// CurrentFile returns the file as seen at last scan.
 	CurrentFile(name string) (protocol.FileInfo, bool)
@@ -370,27 +377,36 @@ func (w *walker) handleItem(ctx context.Context, path string, info fs.FileInfo,
 		err = w.walkDir(ctx, path, info, finishedChan)
 
 	case info.IsRegular():
-		err = w.walkRegular(ctx, path, info, toHashChan)
+		err = w.walkRegular(ctx, path, info, toHashChan, finishedChan)
 	}
 
 	return err
 }
 
-func (w *walker) walkRegular(ctx context.Context, relPath string, info fs.FileInfo, toHashChan chan<- protocol.FileInfo) error {
+func (w *walker) walkRegular(ctx context.Context, relPath string, info fs.FileInfo, toHashChan chan<- protocol.FileInfo, finishedChan chan<- ScanResult) error {
 	curFile, hasCurFile := w.CurrentFiler.CurrentFile(relPath)
 
+	if hasCurFile && w.inodeUnchanged(curFile, relPath, info) {
+		l.Debugln(w, "unchanged inode:", curFile)
+		return nil
+	}
+
 	blockSize := protocol.BlockSize(info.Size())
 
 	if hasCurFile {
 		// Check if we should retain current block size.
 		curBlockSize := curFile.BlockSize()
-		if blockSize > curBlockSize && blockSize/curBlockSize <= 2 {
-			// New block size is larger, but not more than twice larger.
-			// Retain.
+		maxFactor := 2
+		if w.KeepBlockSize {
+			maxFactor = keepBlockSizeMaxFactor
+		}
+		if blockSize > curBlockSize && blockSize/curBlockSize <= maxFactor {
+			// New block size is larger, but not more than maxFactor
+			// times larger. Retain.
 			blockSize = curBlockSize
-		} else if curBlockSize > blockSize && curBlockSize/blockSize <= 2 {
-			// Old block size is larger, but not more than twice larger.
-			// Retain.
+		} else if curBlockSize > blockSize && curBlockSize/blockSize <= maxFactor {
+			// Old block size is larger, but not more than maxFactor
+			// times larger. Retain.
 			blockSize = curBlockSize
 		}
 	}
@@ -413,8 +429,28 @@ func (w *walker) walkRegular(ctx context.Context, relPath string, info fs.FileIn
 			IgnoreOwnership: !w.ScanOwnership,
 			IgnoreXattrs:    !w.ScanXattrs,
 		}) {
-			l.Debugln(w, "unchanged:", curFile)
-			return nil
+			switch {
+			case curFile.Inode == 0 && f.Inode != 0:
+				// Start tracking the inode of files indexed before we
+				// did so, without a new version.
+				curFile.Inode = f.Inode
+				curFile.InodeGeneration = w.inodeGeneration(relPath)
+				curFile.InodeChangeNs = f.InodeChangeNs
+				l.Debugln(w, "inode recorded:", curFile)
+				select {
+				case finishedChan <- ScanResult{File: curFile}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				return nil
+			case inodeChanged(curFile, f):
+				// The file was replaced or written to in place, keeping
+				// size and modification time. Hashing tells.
+				l.Debugln(w, "inode changed:", curFile)
+			default:
+				l.Debugln(w, "unchanged:", curFile)
+				return nil
+			}
 		}
 		if curFile.ShouldConflict() {
 			// The old file was invalid for whatever reason and probably not
@@ -427,6 +463,9 @@ func (w *walker) walkRegular(ctx context.Context, relPath string, info fs.FileIn
 		l.Debugln(w, "rescan:", curFile)
 	}
 
+	if f.Inode != 0 {
+		f.InodeGeneration = w.inodeGeneration(relPath)
+	}
 	l.Debugln(w, "to hash:", relPath, f)
 
 	select {
@@ -712,5 +751,74 @@ func CreateFileInfo(fi fs.FileInfo, name string, filesystem fs.Filesystem, scanO
 	} else {
 		f.InodeChangeNs = 0
 	}
+	f.Inode = fi.Inode()
 	return f, nil
 }
+
+// inodeUnchanged returns true when the file is known to be unchanged, as
+// it's still the same inode, which hasn't changed since it was scanned. As
+// that saves reading the ownership and extended attributes, it's only worth
+// checking the inode generation for when scanning those.
+func (w *walker) inodeUnchanged(curFile protocol.FileInfo, relPath string, info fs.FileInfo) bool {
+	if !w.ScanOwnership && !w.ScanXattrs {
+		return false
+	}
+	if curFile.Type != protocol.FileInfoTypeFile || curFile.IsDeleted() || curFile.MustRescan() || curFile.LocalFlags != w.LocalFlags || curFile.NoPermissions != w.IgnorePerms {
+		return false
+	}
+	if curFile.Inode == 0 || curFile.InodeGeneration == 0 || curFile.InodeChangeNs == 0 {
+		return false
+	}
+	if info.Inode() != curFile.Inode || info.InodeChangeTime().UnixNano() != curFile.InodeChangeNs {
+		return false
+	}
+	if info.Size() != curFile.Size || !protocol.ModTimeEqual(info.ModTime(), curFile.ModTime(), w.ModTimeWindow) {
+		return false
+	}
+	// The platform data must have been scanned with the current settings.
+	if w.ScanOwnership && curFile.Platform.Unix == nil {
+		return false
+	}
+	if w.ScanXattrs && !hasXattrData(curFile.Platform) {
+		return false
+	}
+	return w.inodeGeneration(relPath) == curFile.InodeGeneration
+}
+
+// inodeGeneration returns the inode generation of the file, or zero if
+// unknown.
+func (w *walker) inodeGeneration(relPath string) uint64 {
+	gen, err := fs.InodeGeneration(w.Filesystem, relPath)
+	if err != nil {
+		return 0
+	}
+	return gen
+}
+
+// inodeChanged returns true if the file is a different inode or the inode
+// changed, when that is known for both files.
+func inodeChanged(a, b protocol.FileInfo) bool {
+	if a.Inode == 0 || b.Inode == 0 {
+		return false
+	}
+	if a.Inode != b.Inode {
+		return true
+	}
+	return a.InodeChangeNs != 0 && b.InodeChangeNs != 0 && a.InodeChangeNs != b.InodeChangeNs
+}
+
+// hasXattrData returns true if the platform data holds extended attributes
+// for the current platform, even if none.
+func hasXattrData(pd protocol.PlatformData) bool {
+	switch {
+	case build.IsLinux:
+		return pd.Linux != nil
+	case build.IsDarwin:
+		return pd.Darwin != nil
+	case build.IsFreeBSD:
+		return pd.FreeBSD != nil
+	case build.IsNetBSD:
+		return pd.NetBSD != nil
+	}
+	return false
+}
@@ -18,6 +18,7 @@ import (
 	"sort"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/d4l3k/messagediff"
 	"github.com/syncthing/syncthing/lib/build"
@@ -561,6 +562,75 @@ func TestScanOwnershipWindows(t *testing.T) {
 	}
 }
 
+func TestWalkInodeChanged(t *testing.T) {
+	if build.IsWindows {
+		t.Skip("no inode numbers on Windows")
+	}
+
+	testFs := fs.NewFilesystem(fs.FilesystemTypeBasic, t.TempDir())
+	write := func(data string) {
+		t.Helper()
+		fd, err := testFs.OpenFile("file", fs.OptReadWrite|fs.OptCreate, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fd.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+		fd.Close()
+		mtime := time.Unix(1234567890, 0)
+		if err := testFs.Chtimes("file", mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	walk := func(current fakeCurrentFiler) []protocol.FileInfo {
+		t.Helper()
+		cfg, cancel := testConfig()
+		defer cancel()
+		cfg.Filesystem = testFs
+		cfg.CurrentFiler = current
+		var files []protocol.FileInfo
+		for res := range Walk(context.Background(), cfg) {
+			if res.Err != nil {
+				t.Fatal(res.Err)
+			}
+			if res.File.Name == "file" {
+				files = append(files, res.File)
+			}
+		}
+		return files
+	}
+
+	write("aaaa")
+	files := walk(make(fakeCurrentFiler))
+	if len(files) != 1 || files[0].Inode == 0 {
+		t.Fatalf("expected the file with its inode, got %v", files)
+	}
+	cur := files[0]
+	current := fakeCurrentFiler{"file": cur}
+	if files := walk(current); len(files) != 0 {
+		t.Fatalf("expected no changes, got %v", files)
+	}
+
+	// Files indexed without their inode get it recorded, keeping the
+	// version.
+	untracked := cur
+	untracked.Inode = 0
+	files = walk(fakeCurrentFiler{"file": untracked})
+	if len(files) != 1 || files[0].Inode != cur.Inode || !files[0].Version.Equal(cur.Version) {
+		t.Fatalf("expected the inode to be recorded, got %v", files)
+	}
+
+	// Writing in place, keeping size and modification time, changes the
+	// inode change time. Coarse timestamps need us to wait a bit.
+	time.Sleep(50 * time.Millisecond)
+	write("bbbb")
+	files = walk(current)
+	if len(files) != 1 || files[0].BlocksEqual(cur) {
+		t.Fatalf("expected the in place edit to be detected, got %v", files)
+	}
+}
+
 func walkDir(fs fs.Filesystem, dir string, cfiler CurrentFiler, matcher *ignore.Matcher, localFlags uint32) []protocol.FileInfo {
 	cfg, cancel := testConfig()
 	defer cancel()
@@ -0,0 +1,192 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package selfcheck implements quick consistency checks that are run at
+// startup, collecting the problems found together with suggestions on how
+// to fix them in a single report.
+package selfcheck
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+)
+
+// How long before a certificate expires it's reported.
+const certExpiryWarningTime = 30 * 24 * time.Hour
+
+type Severity string
+
+const (
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Problem is something found to be wrong, with the actions that can be
+// taken to fix it.
+type Problem struct {
+	Check       string   `json:"check"`
+	Subject     string   `json:"subject"`
+	Severity    Severity `json:"severity"`
+	Message     string   `json:"message"`
+	Remediation []string `json:"remediation"`
+}
+
+// Report is the result of a self-check.
+type Report struct {
+	Time     time.Time `json:"time"`
+	Problems []Problem `json:"problems"`
+}
+
+// Healthy returns true if no problems were found.
+func (r Report) Healthy() bool {
+	return len(r.Problems) == 0
+}
+
+// Certificate is a certificate to check for expiry.
+type Certificate struct {
+	// Name describes the certificate, e.g. "device".
+	Name string
+	// Path is the certificate file.
+	Path string
+	// Leaf is the certificate itself, when already loaded. Otherwise it's
+	// read from Path, if that exists.
+	Leaf *x509.Certificate
+	// Remediation is what to do about an expiring certificate.
+	Remediation []string
+}
+
+// Run checks the configuration against the filesystem and the database,
+// and the certificates for expiry.
+func Run(cfg config.Configuration, ldb *db.Lowlevel, certs []Certificate) Report {
+	c := &checker{now: time.Now()}
+	for _, folder := range cfg.Folders {
+		c.checkFolder(folder, ldb)
+	}
+	for _, cert := range certs {
+		c.checkCertificate(cert)
+	}
+	return Report{
+		Time:     c.now,
+		Problems: c.problems,
+	}
+}
+
+type checker struct {
+	now      time.Time
+	problems []Problem
+}
+
+func (c *checker) add(check, subject string, severity Severity, message string, remediation ...string) {
+	c.problems = append(c.problems, Problem{
+		Check:       check,
+		Subject:     subject,
+		Severity:    severity,
+		Message:     message,
+		Remediation: remediation,
+	})
+}
+
+func (c *checker) checkFolder(folder config.FolderConfiguration, ldb *db.Lowlevel) {
+	if folder.Paused {
+		return
+	}
+
+	var localFiles int
+	if ldb != nil {
+		counts, ok, err := ldb.CheckMetadata(folder.ID)
+		switch {
+		case err != nil:
+			c.add("folderDatabase", folder.ID, SeverityError,
+				fmt.Sprintf("Checking the database for folder %s failed: %v", folder.Description(), err),
+				"Make sure the disk holding the database is not full or failing.",
+				"Reset the database for this folder, causing it to be rescanned.")
+		case !ok && counts.Sequence > 0:
+			c.add("folderDatabase", folder.ID, SeverityWarning,
+				fmt.Sprintf("The stored metadata of folder %s does not match the database", folder.Description()),
+				"No action needed, the metadata is recalculated while starting the folder, which may take a while for large folders.",
+				"If this is reported at every start, reset the database for this folder.")
+		}
+		localFiles = counts.Files + counts.Directories + counts.Symlinks
+	}
+
+	err := folder.CheckPath()
+	switch {
+	case err == nil:
+		return
+	case errors.Is(err, config.ErrPathMissing):
+		if localFiles == 0 {
+			// A new folder, it will be created when started.
+			return
+		}
+		c.add("folderPath", folder.ID, SeverityError,
+			fmt.Sprintf("The path %s of folder %s does not exist, but the database has %d items for it", folder.Path, folder.Description(), localFiles),
+			"If the path is on removable or network storage, make sure it is mounted.",
+			"If the folder was moved, change its path in the folder settings.",
+			"If the folder is no longer needed, remove it.")
+	case errors.Is(err, config.ErrMarkerMissing):
+		if localFiles == 0 {
+			return
+		}
+		c.add("folderMarker", folder.ID, SeverityError,
+			fmt.Sprintf("The folder marker %s is missing in %s for folder %s", folder.MarkerName, folder.Path, folder.Description()),
+			"If the path is on removable or network storage, make sure it is mounted.",
+			fmt.Sprintf("If the contents of the folder are intact, e.g. after restoring a backup, create an empty directory named %s in it.", folder.MarkerName))
+	case errors.Is(err, config.ErrPathNotDirectory):
+		c.add("folderPath", folder.ID, SeverityError,
+			fmt.Sprintf("The path %s of folder %s is not a directory", folder.Path, folder.Description()),
+			"Change the path in the folder settings to point to a directory.")
+	default:
+		c.add("folderPath", folder.ID, SeverityError,
+			fmt.Sprintf("The path %s of folder %s can not be accessed: %v", folder.Path, folder.Description(), err),
+			"Check the permissions of the path and the directories above it.")
+	}
+}
+
+func (c *checker) checkCertificate(cert Certificate) {
+	leaf := cert.Leaf
+	if leaf == nil {
+		bs, err := os.ReadFile(cert.Path)
+		if errors.Is(err, os.ErrNotExist) {
+			return
+		} else if err != nil {
+			c.add("certificate", cert.Name, SeverityWarning,
+				fmt.Sprintf("Reading the %s certificate failed: %v", cert.Name, err),
+				fmt.Sprintf("Check the permissions of %s.", cert.Path))
+			return
+		}
+		block, _ := pem.Decode(bs)
+		if block == nil {
+			c.add("certificate", cert.Name, SeverityWarning,
+				fmt.Sprintf("The %s certificate in %s is not valid PEM", cert.Name, cert.Path),
+				cert.Remediation...)
+			return
+		}
+		if leaf, err = x509.ParseCertificate(block.Bytes); err != nil {
+			c.add("certificate", cert.Name, SeverityWarning,
+				fmt.Sprintf("Parsing the %s certificate failed: %v", cert.Name, err),
+				cert.Remediation...)
+			return
+		}
+	}
+
+	switch {
+	case c.now.After(leaf.NotAfter):
+		c.add("certificateExpiry", cert.Name, SeverityError,
+			fmt.Sprintf("The %s certificate expired on %s", cert.Name, leaf.NotAfter.Format(time.DateOnly)),
+			cert.Remediation...)
+	case c.now.Add(certExpiryWarningTime).After(leaf.NotAfter):
+		c.add("certificateExpiry", cert.Name, SeverityWarning,
+			fmt.Sprintf("The %s certificate expires on %s", cert.Name, leaf.NotAfter.Format(time.DateOnly)),
+			cert.Remediation...)
+	}
+}
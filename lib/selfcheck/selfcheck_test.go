@@ -0,0 +1,104 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package selfcheck
+
+import (
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestFolderChecks(t *testing.T) {
+	ldb, err := db.NewLowlevel(backend.OpenMemory(), events.NoopLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ldb.Close()
+
+	dir := t.TempDir()
+	defaults := config.New(protocol.LocalDeviceID).Defaults.Folder
+	newFolder := func(id string, files bool) config.FolderConfiguration {
+		fcfg := defaults.Copy()
+		fcfg.ID = id
+		fcfg.FilesystemType = fs.FilesystemTypeBasic
+		fcfg.Path = filepath.Join(dir, id)
+		if files {
+			fset, err := db.NewFileSet(id, ldb)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fset.Update(protocol.LocalDeviceID, []protocol.FileInfo{{Name: "a", Version: protocol.Vector{}.Update(1), Sequence: 1}})
+		}
+		return fcfg
+	}
+
+	healthy := newFolder("healthy", true)
+	if err := healthy.CreateRoot(); err != nil {
+		t.Fatal(err)
+	}
+	if err := healthy.CreateMarker(); err != nil {
+		t.Fatal(err)
+	}
+	unmounted := newFolder("unmounted", true)
+	noMarker := newFolder("nomarker", true)
+	if err := os.Mkdir(noMarker.Path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	fresh := newFolder("fresh", false)
+
+	cfg := config.Configuration{Folders: []config.FolderConfiguration{healthy, unmounted, noMarker, fresh}}
+	report := Run(cfg, ldb, nil)
+
+	expected := map[string]string{
+		"unmounted": "folderPath",
+		"nomarker":  "folderMarker",
+	}
+	if len(report.Problems) != len(expected) {
+		t.Fatalf("expected %d problems, got %v", len(expected), report.Problems)
+	}
+	for _, p := range report.Problems {
+		if expected[p.Subject] != p.Check {
+			t.Errorf("unexpected problem %v", p)
+		}
+		if p.Severity != SeverityError || len(p.Remediation) == 0 {
+			t.Errorf("expected an error with remediation, got %v", p)
+		}
+	}
+}
+
+func TestCertificateExpiry(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		notAfter time.Time
+		severity Severity
+	}{
+		{now.Add(365 * 24 * time.Hour), ""},
+		{now.Add(24 * time.Hour), SeverityWarning},
+		{now.Add(-time.Hour), SeverityError},
+	}
+	for _, tc := range cases {
+		report := Run(config.Configuration{}, nil, []Certificate{{
+			Name: "test",
+			Leaf: &x509.Certificate{NotAfter: tc.notAfter},
+		}})
+		switch {
+		case tc.severity == "" && !report.Healthy():
+			t.Errorf("%v: unexpected problems %v", tc.notAfter, report.Problems)
+		case tc.severity != "" && (len(report.Problems) != 1 || report.Problems[0].Severity != tc.severity):
+			t.Errorf("%v: expected a single %v, got %v", tc.notAfter, tc.severity, report.Problems)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package simulation
+
+import (
+	"time"
+
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// Clock is a virtual clock, which only moves when advanced. It provides the
+// modification times of the files written in a simulation, so that those
+// don't depend on when the simulation runs.
+type Clock struct {
+	mut sync.Mutex
+	now time.Time
+}
+
+func NewClock(start time.Time) *Clock {
+	return &Clock{
+		mut: sync.NewMutex(),
+		now: start,
+	}
+}
+
+// Now returns the current virtual time.
+func (c *Clock) Now() time.Time {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward and returns the new virtual time.
+func (c *Clock) Advance(d time.Duration) time.Time {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.now = c.now.Add(d)
+	return c.now
+}
@@ -0,0 +1,442 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package simulation runs several devices within one process, for testing
+// multi device sync scenarios quickly. Folders are kept on the fake
+// filesystem, devices are connected over in-memory connections, and device
+// IDs, file contents and modification times derive from the seed and a
+// virtual clock, so that a scenario sets up the same way on every run.
+//
+// The devices still sync concurrently, so scenarios wait for the network
+// to converge using InSync or WaitInSync instead of relying on timing.
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/model"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// The virtual time at which every simulation starts.
+var StartTime = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var errNotInSync = errors.New("devices are not in sync")
+
+// Networks are numbered, to keep their folders apart on the fake
+// filesystem.
+var networks atomic.Int64
+
+// A Network is a set of simulated devices and the connections between them.
+type Network struct {
+	Clock *Clock
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	index   int64
+	seed    int64
+	rnd     *rand.Rand
+	mut     sync.Mutex
+	devices []*Device
+	conns   map[[2]protocol.DeviceID][]net.Conn
+}
+
+// A Device is a simulated Syncthing instance.
+type Device struct {
+	ID       protocol.DeviceID
+	Name     string
+	Config   config.Wrapper
+	Model    model.Model
+	EvLogger events.Logger
+
+	network *Network
+	ldb     *db.Lowlevel
+}
+
+// New returns an empty network. The seed determines device IDs and
+// generated file contents.
+func New(seed int64) *Network {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Network{
+		Clock:  NewClock(StartTime),
+		ctx:    ctx,
+		cancel: cancel,
+		index:  networks.Add(1),
+		seed:   seed,
+		rnd:    rand.New(rand.NewSource(seed)),
+		mut:    sync.NewMutex(),
+		conns:  make(map[[2]protocol.DeviceID][]net.Conn),
+	}
+}
+
+// Close disconnects and stops all devices.
+func (n *Network) Close() {
+	n.mut.Lock()
+	for key, conns := range n.conns {
+		for _, c := range conns {
+			c.Close()
+		}
+		delete(n.conns, key)
+	}
+	n.mut.Unlock()
+	n.cancel()
+	for _, d := range n.Devices() {
+		d.ldb.Close()
+	}
+}
+
+// Devices returns the devices in the order they were added.
+func (n *Network) Devices() []*Device {
+	n.mut.Lock()
+	defer n.mut.Unlock()
+	return append([]*Device{}, n.devices...)
+}
+
+// RandomData returns pseudorandom data, determined by the seed and the
+// calls made before.
+func (n *Network) RandomData(size int) []byte {
+	n.mut.Lock()
+	defer n.mut.Unlock()
+	bs := make([]byte, size)
+	n.rnd.Read(bs)
+	return bs
+}
+
+// AddDevice creates and starts a new device.
+func (n *Network) AddDevice(name string) (*Device, error) {
+	id := protocol.NewDeviceID([]byte(fmt.Sprintf("simulation-%d-%s", n.seed, name)))
+
+	evLogger := events.NewLogger()
+	go evLogger.Serve(n.ctx)
+
+	cfg := config.New(id)
+	cfg.Devices[0].Name = name
+	cfg.Options.GlobalAnnEnabled = false
+	cfg.Options.LocalAnnEnabled = false
+	cfg.Options.RelaysEnabled = false
+	cfg.Options.NATEnabled = false
+	cfg.Options.URAccepted = -1
+	cfg.Options.CREnabled = false
+	cfg.Options.RawListenAddresses = []string{}
+	wrapper := config.Wrap("", cfg, id, evLogger)
+	go wrapper.Serve(n.ctx)
+
+	ldb, err := db.NewLowlevel(backend.OpenMemory(), evLogger)
+	if err != nil {
+		return nil, err
+	}
+	m := model.NewModel(wrapper, id, "syncthing", "simulation", ldb, nil, nil, evLogger, protocol.NewKeyGenerator(), nil)
+	go m.Serve(n.ctx)
+
+	d := &Device{
+		ID:       id,
+		Name:     name,
+		Config:   wrapper,
+		Model:    m,
+		EvLogger: evLogger,
+		network:  n,
+		ldb:      ldb,
+	}
+	n.mut.Lock()
+	n.devices = append(n.devices, d)
+	n.mut.Unlock()
+	return d, nil
+}
+
+// Connect adds the devices to each other's configuration and connects
+// them.
+func (n *Network) Connect(a, b *Device) error {
+	if err := a.addDevice(b); err != nil {
+		return err
+	}
+	if err := b.addDevice(a); err != nil {
+		return err
+	}
+
+	ca, cb := net.Pipe()
+	n.mut.Lock()
+	n.conns[connKey(a, b)] = []net.Conn{ca, cb}
+	n.mut.Unlock()
+	a.connect(b, ca)
+	b.connect(a, cb)
+	return nil
+}
+
+// Disconnect closes the connection between the devices, if any.
+func (n *Network) Disconnect(a, b *Device) {
+	n.mut.Lock()
+	conns := n.conns[connKey(a, b)]
+	delete(n.conns, connKey(a, b))
+	n.mut.Unlock()
+	for _, c := range conns {
+		c.Close()
+	}
+}
+
+func connKey(a, b *Device) [2]protocol.DeviceID {
+	if b.ID.Compare(a.ID) < 0 {
+		a, b = b, a
+	}
+	return [2]protocol.DeviceID{a.ID, b.ID}
+}
+
+// Share adds the folder on all the given devices, shared between them, and
+// waits for it to be running. The devices are added to each other's
+// configuration as needed, but not connected.
+func (n *Network) Share(folder string, devices ...*Device) error {
+	for _, d := range devices {
+		for _, other := range devices {
+			if other != d {
+				if err := d.addDevice(other); err != nil {
+					return err
+				}
+			}
+		}
+		fcfg := d.Config.DefaultFolder()
+		fcfg.ID = folder
+		fcfg.Label = folder
+		fcfg.FilesystemType = fs.FilesystemTypeFake
+		fcfg.Path = fmt.Sprintf("simulation-%d-%s-%s?content=true", n.index, d.ID.Short(), folder)
+		fcfg.FSWatcherEnabled = false
+		fcfg.RescanIntervalS = 0
+		for _, other := range devices {
+			fcfg.Devices = append(fcfg.Devices, config.FolderDeviceConfiguration{DeviceID: other.ID})
+		}
+		if err := d.modify(func(cfg *config.Configuration) {
+			cfg.SetFolder(fcfg)
+		}); err != nil {
+			return err
+		}
+	}
+	for _, d := range devices {
+		if err := d.waitRunning(folder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InSync returns true if all devices sharing the folder have the same
+// global state and need nothing.
+func (n *Network) InSync(folder string) bool {
+	var expected map[string]protocol.Vector
+	for _, d := range n.Devices() {
+		if _, ok := d.Config.Folder(folder); !ok {
+			continue
+		}
+		snap, err := d.Model.DBSnapshot(folder)
+		if err != nil {
+			return false
+		}
+		need := snap.NeedSize(protocol.LocalDeviceID)
+		global := make(map[string]protocol.Vector)
+		snap.WithGlobal(func(f protocol.FileIntf) bool {
+			global[f.FileName()] = f.FileVersion()
+			return true
+		})
+		snap.Release()
+		if need.TotalItems() > 0 {
+			return false
+		}
+		if expected == nil {
+			expected = global
+			continue
+		}
+		if len(global) != len(expected) {
+			return false
+		}
+		for name, version := range global {
+			if exp, ok := expected[name]; !ok || !exp.Equal(version) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// WaitInSync waits until InSync returns true for the folder, or the
+// timeout passes.
+func (n *Network) WaitInSync(folder string, timeout time.Duration) error {
+	t0 := time.Now()
+	for !n.InSync(folder) {
+		if time.Since(t0) > timeout {
+			return fmt.Errorf("%w after %v", errNotInSync, timeout)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return nil
+}
+
+// Filesystem returns the filesystem of the folder on the device.
+func (d *Device) Filesystem(folder string) (fs.Filesystem, error) {
+	fcfg, ok := d.Config.Folder(folder)
+	if !ok {
+		return nil, fmt.Errorf("%v: no folder %q", d.Name, folder)
+	}
+	return fcfg.Filesystem(nil), nil
+}
+
+// WriteFile writes the file with the current virtual time as modification
+// time, advances the clock by a second and scans the file.
+func (d *Device) WriteFile(folder, name string, data []byte) error {
+	ffs, err := d.Filesystem(folder)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(name); dir != "." {
+		if err := ffs.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	fd, err := ffs.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := fd.Write(data); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	mtime := d.network.Clock.Now()
+	if err := ffs.Chtimes(name, mtime, mtime); err != nil {
+		return err
+	}
+	d.network.Clock.Advance(time.Second)
+	return d.Model.ScanFolderSubdirs(folder, []string{name})
+}
+
+// RemoveFile removes the file and scans it.
+func (d *Device) RemoveFile(folder, name string) error {
+	ffs, err := d.Filesystem(folder)
+	if err != nil {
+		return err
+	}
+	if err := ffs.Remove(name); err != nil {
+		return err
+	}
+	return d.Model.ScanFolderSubdirs(folder, []string{name})
+}
+
+// ReadFile returns the contents of the file.
+func (d *Device) ReadFile(folder, name string) ([]byte, error) {
+	ffs, err := d.Filesystem(folder)
+	if err != nil {
+		return nil, err
+	}
+	fd, err := ffs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+	info, err := fd.Stat()
+	if err != nil {
+		return nil, err
+	}
+	bs := make([]byte, info.Size())
+	if _, err := fd.ReadAt(bs, 0); err != nil && info.Size() > 0 {
+		return nil, err
+	}
+	return bs, nil
+}
+
+func (d *Device) addDevice(other *Device) error {
+	if _, ok := d.Config.Device(other.ID); ok {
+		return nil
+	}
+	return d.modify(func(cfg *config.Configuration) {
+		dev := cfg.Defaults.Device.Copy()
+		dev.DeviceID = other.ID
+		dev.Name = other.Name
+		cfg.SetDevice(dev)
+	})
+}
+
+func (d *Device) connect(other *Device, c net.Conn) {
+	info := &pipeConnection{Conn: c, established: time.Now()}
+	conn := protocol.NewConnection(other.ID, c, c, c, d.Model, info, protocol.CompressionMetadata, nil, protocol.NewKeyGenerator())
+	d.Model.AddConnection(conn, protocol.Hello{
+		DeviceName:    other.Name,
+		ClientName:    "syncthing",
+		ClientVersion: "simulation",
+	})
+}
+
+func (d *Device) modify(fn config.ModifyFunction) error {
+	waiter, err := d.Config.Modify(fn)
+	if err != nil {
+		return err
+	}
+	waiter.Wait()
+	return nil
+}
+
+func (d *Device) waitRunning(folder string) error {
+	for i := 0; ; i++ {
+		// The state is empty until the folder runs.
+		if state, _, err := d.Model.State(folder); err != nil {
+			return err
+		} else if state != "" {
+			return nil
+		}
+		if i > 1000 {
+			return fmt.Errorf("%v: folder %q not started", d.Name, folder)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// pipeConnection provides the connection info for in-memory connections.
+type pipeConnection struct {
+	net.Conn
+	established time.Time
+}
+
+func (*pipeConnection) Type() string {
+	return "simulation"
+}
+
+func (*pipeConnection) Transport() string {
+	return "pipe"
+}
+
+func (*pipeConnection) IsLocal() bool {
+	return true
+}
+
+func (*pipeConnection) Priority() int {
+	return 0
+}
+
+func (c *pipeConnection) String() string {
+	return fmt.Sprintf("simulation-%p", c)
+}
+
+func (*pipeConnection) Crypto() string {
+	return "none"
+}
+
+func (*pipeConnection) KeyExchange() string {
+	return ""
+}
+
+func (c *pipeConnection) EstablishedAt() time.Time {
+	return c.established
+}
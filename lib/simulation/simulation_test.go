@@ -0,0 +1,95 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/fs"
+)
+
+func TestChain(t *testing.T) {
+	n := New(1)
+	defer n.Close()
+
+	// Three devices in a chain, so that changes on one end reach the other
+	// end through the middle.
+	var devices []*Device
+	for i := 0; i < 3; i++ {
+		d, err := n.AddDevice(fmt.Sprint("dev", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		devices = append(devices, d)
+	}
+	if err := n.Share("folder", devices...); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < len(devices); i++ {
+		if err := n.Connect(devices[i-1], devices[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data := n.RandomData(300 << 10)
+	if err := devices[0].WriteFile("folder", "dir/file", data); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.WaitInSync("folder", 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	last := devices[len(devices)-1]
+	if bs, err := last.ReadFile("folder", "dir/file"); err != nil || !bytes.Equal(bs, data) {
+		t.Fatalf("unexpected contents on %v: %v", last.Name, err)
+	}
+	ffs, _ := last.Filesystem("folder")
+	if info, err := ffs.Lstat("dir/file"); err != nil || !info.ModTime().Equal(StartTime) {
+		t.Errorf("unexpected modification time: %v", err)
+	}
+
+	// With the middle device gone the ends don't sync, until it's back.
+	n.Disconnect(devices[0], devices[1])
+	if err := last.RemoveFile("folder", "dir/file"); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.WaitInSync("folder", 100*time.Millisecond); err == nil {
+		t.Fatal("expected devices not to be in sync")
+	}
+	if err := n.Connect(devices[0], devices[1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.WaitInSync("folder", 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	ffs, _ = devices[0].Filesystem("folder")
+	if _, err := ffs.Lstat("dir/file"); !fs.IsNotExist(err) {
+		t.Errorf("expected file to be removed, got %v", err)
+	}
+}
+
+func TestDeterministicDevices(t *testing.T) {
+	a, b := New(1), New(1)
+	defer a.Close()
+	defer b.Close()
+	da, err := a.AddDevice("dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := b.AddDevice("dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if da.ID != db.ID {
+		t.Error("device IDs differ")
+	}
+	if !bytes.Equal(a.RandomData(16), b.RandomData(16)) {
+		t.Error("random data differs")
+	}
+}
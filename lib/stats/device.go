@@ -7,6 +7,7 @@
 package stats
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/syncthing/syncthing/lib/db"
@@ -17,11 +18,31 @@ import (
 const (
 	lastSeenKey     = "lastSeen"
 	connDurationKey = "lastConnDuration"
+	completionKey   = "lastCompletion"
 )
 
 type DeviceStatistics struct {
-	LastSeen                time.Time `json:"lastSeen"`
-	LastConnectionDurationS float64   `json:"lastConnectionDurationS"`
+	LastSeen                time.Time       `json:"lastSeen"`
+	LastConnectionDurationS float64         `json:"lastConnectionDurationS"`
+	LastCompletion          *LastCompletion `json:"lastCompletion,omitempty"`
+}
+
+// CompletionSummary is how far a device is in sync with the global state.
+type CompletionSummary struct {
+	CompletionPct float64 `json:"completion"`
+	GlobalBytes   int64   `json:"globalBytes"`
+	NeedBytes     int64   `json:"needBytes"`
+	GlobalItems   int     `json:"globalItems"`
+	NeedItems     int     `json:"needItems"`
+	NeedDeletes   int     `json:"needDeletes"`
+}
+
+// LastCompletion is how far the device was in sync when it disconnected,
+// in total and per folder.
+type LastCompletion struct {
+	At time.Time `json:"at"`
+	CompletionSummary
+	Folders map[string]CompletionSummary `json:"folders"`
 }
 
 type DeviceStatisticsReference struct {
@@ -70,6 +91,27 @@ func (s *DeviceStatisticsReference) LastConnectionDuration(d time.Duration) erro
 	return s.ns.PutInt64(connDurationKey, d.Nanoseconds())
 }
 
+func (s *DeviceStatisticsReference) GetLastCompletion() (*LastCompletion, error) {
+	bs, ok, err := s.ns.Bytes(completionKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var comp LastCompletion
+	if err := json.Unmarshal(bs, &comp); err != nil {
+		return nil, err
+	}
+	return &comp, nil
+}
+
+func (s *DeviceStatisticsReference) LastCompletion(comp LastCompletion) error {
+	l.Debugln("stats.DeviceStatisticsReference.LastCompletion:", s.device, comp.CompletionPct)
+	bs, err := json.Marshal(comp)
+	if err != nil {
+		return err
+	}
+	return s.ns.PutBytes(completionKey, bs)
+}
+
 func (s *DeviceStatisticsReference) GetStatistics() (DeviceStatistics, error) {
 	lastSeen, err := s.GetLastSeen()
 	if err != nil {
@@ -79,8 +121,13 @@ func (s *DeviceStatisticsReference) GetStatistics() (DeviceStatistics, error) {
 	if err != nil {
 		return DeviceStatistics{}, err
 	}
+	lastCompletion, err := s.GetLastCompletion()
+	if err != nil {
+		return DeviceStatistics{}, err
+	}
 	return DeviceStatistics{
 		LastSeen:                lastSeen,
 		LastConnectionDurationS: lastConnDuration.Seconds(),
+		LastCompletion:          lastCompletion,
 	}, nil
 }
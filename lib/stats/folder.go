@@ -7,19 +7,44 @@
 package stats
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// The number of conflict and revert entries kept in the folder history.
+const maxFolderHistory = 100
+
+// The types of folder history entries.
+const (
+	HistoryConflictCreated  = "conflictCreated"
+	HistoryConflictResolved = "conflictResolved"
+	HistoryReverted         = "reverted"
 )
 
 type FolderStatistics struct {
-	LastFile LastFile  `json:"lastFile"`
-	LastScan time.Time `json:"lastScan"`
+	LastFile          LastFile       `json:"lastFile"`
+	LastScan          time.Time      `json:"lastScan"`
+	ConflictsCreated  int64          `json:"conflictsCreated"`
+	ConflictsResolved int64          `json:"conflictsResolved"`
+	Reverts           int64          `json:"reverts"`
+	History           []HistoryEntry `json:"history"`
+}
+
+// HistoryEntry is a conflict created or resolved, or a revert of local
+// changes in a receive only folder. Item is empty for reverts.
+type HistoryEntry struct {
+	At   time.Time `json:"at"`
+	Type string    `json:"type"`
+	Item string    `json:"item,omitempty"`
 }
 
 type FolderStatisticsReference struct {
 	ns     *db.NamespacedKV
 	folder string
+	mut    sync.Mutex // for the history and counters
 }
 
 type LastFile struct {
@@ -32,6 +57,7 @@ func NewFolderStatisticsReference(ldb *db.Lowlevel, folder string) *FolderStatis
 	return &FolderStatisticsReference{
 		ns:     db.NewFolderStatisticsNamespace(ldb, folder),
 		folder: folder,
+		mut:    sync.NewMutex(),
 	}
 }
 
@@ -87,6 +113,66 @@ func (s *FolderStatisticsReference) GetLastScanTime() (time.Time, error) {
 	return lastScan, nil
 }
 
+// ConflictCreated records that a conflict copy was created for the file.
+func (s *FolderStatisticsReference) ConflictCreated(file string) error {
+	return s.addHistory("conflictsCreated", HistoryConflictCreated, file)
+}
+
+// ConflictResolved records that the conflict copy was removed.
+func (s *FolderStatisticsReference) ConflictResolved(file string) error {
+	return s.addHistory("conflictsResolved", HistoryConflictResolved, file)
+}
+
+// Reverted records that the local changes of a receive only folder were
+// reverted.
+func (s *FolderStatisticsReference) Reverted() error {
+	return s.addHistory("reverts", HistoryReverted, "")
+}
+
+func (s *FolderStatisticsReference) addHistory(counter, typ, item string) error {
+	l.Debugln("stats.FolderStatisticsReference.addHistory:", s.folder, typ, item)
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	count, _, err := s.ns.Int64(counter)
+	if err != nil {
+		return err
+	}
+	if err := s.ns.PutInt64(counter, count+1); err != nil {
+		return err
+	}
+
+	history, err := s.getHistory()
+	if err != nil {
+		return err
+	}
+	history = append(history, HistoryEntry{
+		At:   time.Now().Truncate(time.Second),
+		Type: typ,
+		Item: item,
+	})
+	if len(history) > maxFolderHistory {
+		history = history[len(history)-maxFolderHistory:]
+	}
+	bs, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	return s.ns.PutBytes("history", bs)
+}
+
+func (s *FolderStatisticsReference) getHistory() ([]HistoryEntry, error) {
+	bs, ok, err := s.ns.Bytes("history")
+	if err != nil || !ok {
+		return nil, err
+	}
+	var history []HistoryEntry
+	if err := json.Unmarshal(bs, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
 func (s *FolderStatisticsReference) GetStatistics() (FolderStatistics, error) {
 	lastFile, err := s.GetLastFile()
 	if err != nil {
@@ -96,8 +182,28 @@ func (s *FolderStatisticsReference) GetStatistics() (FolderStatistics, error) {
 	if err != nil {
 		return FolderStatistics{}, err
 	}
-	return FolderStatistics{
+	stats := FolderStatistics{
 		LastFile: lastFile,
 		LastScan: lastScanTime,
-	}, nil
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	counters := []struct {
+		key string
+		val *int64
+	}{
+		{"conflictsCreated", &stats.ConflictsCreated},
+		{"conflictsResolved", &stats.ConflictsResolved},
+		{"reverts", &stats.Reverts},
+	}
+	for _, c := range counters {
+		if *c.val, _, err = s.ns.Int64(c.key); err != nil {
+			return FolderStatistics{}, err
+		}
+	}
+	if stats.History, err = s.getHistory(); err != nil {
+		return FolderStatistics{}, err
+	}
+	return stats, nil
 }
@@ -10,10 +10,17 @@
 package stats
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
 	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/protocol"
 )
 
@@ -41,3 +48,243 @@ func TestDeviceStat(t *testing.T) {
 		t.Error("Bad last duration:", d)
 	}
 }
+
+func TestDeviceStatLastCompletion(t *testing.T) {
+	db := backend.OpenLevelDBMemory()
+	defer db.Close()
+
+	sr := NewDeviceStatisticsReference(db, protocol.LocalDeviceID)
+	stat, err := sr.GetStatistics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat.LastCompletion != nil {
+		t.Error("Unexpected last completion:", stat.LastCompletion)
+	}
+
+	comp := LastCompletion{
+		At:                time.Now().Truncate(time.Second),
+		CompletionSummary: CompletionSummary{CompletionPct: 93, GlobalBytes: 100, NeedBytes: 7},
+		Folders: map[string]CompletionSummary{
+			"default": {CompletionPct: 93, GlobalBytes: 100, NeedBytes: 7},
+		},
+	}
+	if err := sr.LastCompletion(comp); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new reference, as after a restart.
+	sr = NewDeviceStatisticsReference(db, protocol.LocalDeviceID)
+	stat, err = sr.GetStatistics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stat.LastCompletion; got == nil || !got.At.Equal(comp.At) || got.CompletionPct != 93 || got.Folders["default"].NeedBytes != 7 {
+		t.Error("Bad last completion:", got)
+	}
+}
+
+func TestFolderStatHistory(t *testing.T) {
+	ldb, err := db.NewLowlevel(backend.OpenMemory(), events.NoopLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ldb.Close()
+
+	sr := NewFolderStatisticsReference(ldb, "folder")
+	for i := 0; i < maxFolderHistory; i++ {
+		if err := sr.ConflictCreated("a.sync-conflict-x.txt"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sr.ConflictResolved("a.sync-conflict-x.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sr.Reverted(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The counts and history survive a new reference to the same folder.
+	stat, err := NewFolderStatisticsReference(ldb, "folder").GetStatistics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat.ConflictsCreated != maxFolderHistory || stat.ConflictsResolved != 1 || stat.Reverts != 1 {
+		t.Errorf("unexpected counts %d, %d, %d", stat.ConflictsCreated, stat.ConflictsResolved, stat.Reverts)
+	}
+	if len(stat.History) != maxFolderHistory {
+		t.Fatalf("expected %d history entries, got %d", maxFolderHistory, len(stat.History))
+	}
+	last := stat.History[len(stat.History)-2:]
+	if last[0].Type != HistoryConflictResolved || last[0].Item != "a.sync-conflict-x.txt" || last[1].Type != HistoryReverted {
+		t.Errorf("unexpected last history entries %v", last)
+	}
+}
+
+func TestTransferStatistics(t *testing.T) {
+	db := backend.OpenLevelDBMemory()
+	defer db.Close()
+
+	dir := t.TempDir()
+	cfg := config.Configuration{}
+	cfg.Options.TransferStatsDumpPath = dir
+	w := config.Wrap("", cfg, protocol.LocalDeviceID, events.NoopLogger)
+
+	day1 := time.Date(2023, 5, 1, 12, 0, 0, 0, time.Local)
+	day2 := day1.AddDate(0, 0, 1)
+	now := day1
+	ts := NewTransferStatistics(db, w)
+	ts.timeNow = func() time.Time { return now }
+
+	ts.Received("a", 100)
+	ts.Sent("a", 10)
+	ts.Changed("a", true, false)
+	if err := ts.flush(); err != nil {
+		t.Fatal(err)
+	}
+	ts.Received("a", 100)
+	ts.Changed("b", false, true)
+	now = day2
+	ts.Changed("b", false, false)
+
+	res, err := ts.Get(day1, day2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []DailyTransferStatistics{
+		{Date: "2023-05-01", Folder: "a", TransferCounts: TransferCounts{BytesReceived: 200, BytesSent: 10, LocalChanges: 1}},
+		{Date: "2023-05-01", Folder: "b", TransferCounts: TransferCounts{RemoteDeletions: 1}},
+		{Date: "2023-05-02", Folder: "b", TransferCounts: TransferCounts{RemoteChanges: 1}},
+	}
+	if len(res) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, res)
+	}
+	for i := range res {
+		if res[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], res[i])
+		}
+	}
+
+	// The previous day is dumped once.
+	if err := ts.dumpIfDue(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "transfers-2023-05-01.csv")
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(bs)), "\n"); len(lines) != 3 || lines[1] != "2023-05-01,a,200,10,1,0,0,0" {
+		t.Errorf("unexpected dump: %q", bs)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := ts.dumpIfDue(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		t.Error("dumped the same day twice")
+	}
+}
+
+func TestTransferStatisticsRange(t *testing.T) {
+	db := backend.OpenLevelDBMemory()
+	defer db.Close()
+	w := config.Wrap("", config.Configuration{}, protocol.LocalDeviceID, events.NoopLogger)
+	ts := NewTransferStatistics(db, w)
+
+	from := time.Date(2023, 1, 1, 12, 0, 0, 0, time.Local)
+	if _, err := ts.Get(from, from.AddDate(0, 0, MaxTransferStatisticsDays-1)); err != nil {
+		t.Error("expected the largest range to be allowed, got", err)
+	}
+	if _, err := ts.Get(from, from.AddDate(0, 0, MaxTransferStatisticsDays)); !errors.Is(err, ErrTransferRangeTooLarge) {
+		t.Error("expected a too large range to be rejected, got", err)
+	}
+}
+
+func TestTransferStatisticsDumpCatchUp(t *testing.T) {
+	db := backend.OpenLevelDBMemory()
+	defer db.Close()
+
+	dir := t.TempDir()
+	cfg := config.Configuration{}
+	cfg.Options.TransferStatsDumpPath = dir
+	w := config.Wrap("", cfg, protocol.LocalDeviceID, events.NoopLogger)
+
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.Local)
+	ts := NewTransferStatistics(db, w)
+	ts.timeNow = func() time.Time { return now }
+
+	ts.Received("a", 100)
+	now = now.AddDate(0, 0, 1)
+	if err := ts.dumpIfDue(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Being down for a few days, all of them are written when coming back.
+	ts.Received("a", 200)
+	now = now.AddDate(0, 0, 4)
+	if err := ts.dumpIfDue(); err != nil {
+		t.Fatal(err)
+	}
+	for _, date := range []string{"2023-05-01", "2023-05-02", "2023-05-03", "2023-05-04", "2023-05-05"} {
+		if _, err := os.Stat(filepath.Join(dir, "transfers-"+date+".csv")); err != nil {
+			t.Errorf("expected the statistics of %s to be written: %v", date, err)
+		}
+	}
+	bs, err := os.ReadFile(filepath.Join(dir, "transfers-2023-05-02.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(bs)), "\n"); len(lines) != 2 || lines[1] != "2023-05-02,a,200,0,0,0,0,0" {
+		t.Errorf("unexpected dump: %q", bs)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "transfers-2023-05-06.csv")); err == nil {
+		t.Error("dumped the current day")
+	}
+}
+
+func TestTransferStatisticsPrune(t *testing.T) {
+	db := backend.OpenLevelDBMemory()
+	defer db.Close()
+	w := config.Wrap("", config.Configuration{}, protocol.LocalDeviceID, events.NoopLogger)
+
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.Local)
+	ts := NewTransferStatistics(db, w)
+	ts.timeNow = func() time.Time { return now }
+
+	old := now
+	ts.Received("a", 100)
+	now = now.AddDate(0, 0, transferRetentionDays)
+	ts.Received("a", 100)
+	if err := ts.flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ts.ns.PutString(lastDumpKey, old.Format(TransferDateFormat)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Everything is still within the retention period.
+	if err := ts.pruneIfDue(); err != nil {
+		t.Fatal(err)
+	}
+	if res, err := ts.Get(old, old); err != nil || len(res) != 1 {
+		t.Fatalf("expected the old statistics to be kept, got %v, %v", res, err)
+	}
+
+	// A day later, the oldest day falls out of it.
+	now = now.AddDate(0, 0, 1)
+	if err := ts.pruneIfDue(); err != nil {
+		t.Fatal(err)
+	}
+	if res, err := ts.Get(old, old); err != nil || len(res) != 0 {
+		t.Errorf("expected the old statistics to be removed, got %v, %v", res, err)
+	}
+	if res, err := ts.Get(now.AddDate(0, 0, -1), now); err != nil || len(res) != 1 {
+		t.Errorf("expected the recent statistics to be kept, got %v, %v", res, err)
+	}
+	if last, _, err := ts.ns.String(lastDumpKey); err != nil || last != old.Format(TransferDateFormat) {
+		t.Errorf("expected the last dump date to be kept, got %q, %v", last, err)
+	}
+}
@@ -0,0 +1,345 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package stats
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/osutil"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+const (
+	// TransferDateFormat is the format of the dates transfer statistics
+	// are kept per, in local time.
+	TransferDateFormat = "2006-01-02"
+
+	// MaxTransferStatisticsDays is the largest number of days that can be
+	// requested at once.
+	MaxTransferStatisticsDays = 366
+
+	lastDumpKey           = "lastDump"
+	transferFlushInterval = time.Minute
+	// Daily statistics older than this many days are removed.
+	transferRetentionDays = 2 * MaxTransferStatisticsDays
+)
+
+var ErrTransferRangeTooLarge = fmt.Errorf("range spans more than %d days", MaxTransferStatisticsDays)
+
+// TransferCounts are the amounts of data transferred and files changed.
+type TransferCounts struct {
+	BytesReceived   int64 `json:"bytesReceived"`
+	BytesSent       int64 `json:"bytesSent"`
+	LocalChanges    int64 `json:"localChanges"`
+	LocalDeletions  int64 `json:"localDeletions"`
+	RemoteChanges   int64 `json:"remoteChanges"`
+	RemoteDeletions int64 `json:"remoteDeletions"`
+}
+
+func (c *TransferCounts) add(other TransferCounts) {
+	c.BytesReceived += other.BytesReceived
+	c.BytesSent += other.BytesSent
+	c.LocalChanges += other.LocalChanges
+	c.LocalDeletions += other.LocalDeletions
+	c.RemoteChanges += other.RemoteChanges
+	c.RemoteDeletions += other.RemoteDeletions
+}
+
+// DailyTransferStatistics are the transfer counts of one folder on one day.
+type DailyTransferStatistics struct {
+	Date   string `json:"date"`
+	Folder string `json:"folder"`
+	TransferCounts
+}
+
+// TransferStatistics keeps per day and folder transfer statistics in the
+// database. Counts are collected in memory and written to the database
+// periodically.
+type TransferStatistics struct {
+	ns      *db.NamespacedKV
+	cfg     config.Wrapper
+	mut     sync.Mutex
+	pending map[string]map[string]*TransferCounts // date -> folder -> counts
+	timeNow func() time.Time
+	pruned  string // date of the last pruning
+}
+
+func NewTransferStatistics(dba backend.Backend, cfg config.Wrapper) *TransferStatistics {
+	return &TransferStatistics{
+		ns:      db.NewTransferStatisticsNamespace(dba),
+		cfg:     cfg,
+		mut:     sync.NewMutex(),
+		pending: make(map[string]map[string]*TransferCounts),
+		timeNow: time.Now,
+	}
+}
+
+func (s *TransferStatistics) Serve(ctx context.Context) error {
+	ticker := time.NewTicker(transferFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.flush(); err != nil {
+				l.Warnln("Failed to save transfer statistics:", err)
+			}
+			if err := s.dumpIfDue(); err != nil {
+				l.Warnln("Failed to write transfer statistics:", err)
+			}
+			if err := s.pruneIfDue(); err != nil {
+				l.Warnln("Failed to remove old transfer statistics:", err)
+			}
+		case <-ctx.Done():
+			if err := s.flush(); err != nil {
+				l.Warnln("Failed to save transfer statistics:", err)
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *TransferStatistics) String() string {
+	return fmt.Sprintf("TransferStatistics@%p", s)
+}
+
+// Received records data received for the given folder.
+func (s *TransferStatistics) Received(folder string, bytes int) {
+	s.update(folder, func(c *TransferCounts) { c.BytesReceived += int64(bytes) })
+}
+
+// Sent records data sent for the given folder.
+func (s *TransferStatistics) Sent(folder string, bytes int) {
+	s.update(folder, func(c *TransferCounts) { c.BytesSent += int64(bytes) })
+}
+
+// Changed records a changed or deleted file, that was either detected
+// locally or pulled from a remote device.
+func (s *TransferStatistics) Changed(folder string, local, deleted bool) {
+	s.update(folder, func(c *TransferCounts) {
+		switch {
+		case local && deleted:
+			c.LocalDeletions++
+		case local:
+			c.LocalChanges++
+		case deleted:
+			c.RemoteDeletions++
+		default:
+			c.RemoteChanges++
+		}
+	})
+}
+
+func (s *TransferStatistics) update(folder string, fn func(*TransferCounts)) {
+	date := s.timeNow().Format(TransferDateFormat)
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	folders, ok := s.pending[date]
+	if !ok {
+		folders = make(map[string]*TransferCounts)
+		s.pending[date] = folders
+	}
+	counts, ok := folders[folder]
+	if !ok {
+		counts = new(TransferCounts)
+		folders[folder] = counts
+	}
+	fn(counts)
+}
+
+// flush adds the pending counts to those in the database.
+func (s *TransferStatistics) flush() error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	for date, folders := range s.pending {
+		stored, err := s.load(date)
+		if err != nil {
+			return err
+		}
+		for folder, counts := range folders {
+			c := stored[folder]
+			c.add(*counts)
+			stored[folder] = c
+		}
+		bs, err := json.Marshal(stored)
+		if err != nil {
+			return err
+		}
+		if err := s.ns.PutBytes(date, bs); err != nil {
+			return err
+		}
+		delete(s.pending, date)
+	}
+	return nil
+}
+
+func (s *TransferStatistics) load(date string) (map[string]TransferCounts, error) {
+	stored := make(map[string]TransferCounts)
+	bs, ok, err := s.ns.Bytes(date)
+	if err != nil || !ok {
+		return stored, err
+	}
+	if err := json.Unmarshal(bs, &stored); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// Get returns the statistics for all days between from and to, inclusive,
+// sorted by date and folder. At most MaxTransferStatisticsDays days can be
+// requested, ErrTransferRangeTooLarge is returned otherwise.
+func (s *TransferStatistics) Get(from, to time.Time) ([]DailyTransferStatistics, error) {
+	from = startOfDay(from)
+	if !from.AddDate(0, 0, MaxTransferStatisticsDays).After(to) {
+		return nil, ErrTransferRangeTooLarge
+	}
+	if err := s.flush(); err != nil {
+		return nil, err
+	}
+	var res []DailyTransferStatistics
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(TransferDateFormat)
+		stored, err := s.load(date)
+		if err != nil {
+			return nil, err
+		}
+		folders := make([]string, 0, len(stored))
+		for folder := range stored {
+			folders = append(folders, folder)
+		}
+		sort.Strings(folders)
+		for _, folder := range folders {
+			res = append(res, DailyTransferStatistics{
+				Date:           date,
+				Folder:         folder,
+				TransferCounts: stored[folder],
+			})
+		}
+	}
+	return res, nil
+}
+
+// dumpIfDue writes the statistics of each day since the last dump up to and
+// including the previous day to the configured directory, one file per day.
+// Days that are no longer retained aren't written.
+func (s *TransferStatistics) dumpIfDue() error {
+	dir := s.cfg.Options().TransferStatsDumpPath
+	if dir == "" {
+		return nil
+	}
+	dir, err := fs.ExpandTilde(dir)
+	if err != nil {
+		return err
+	}
+
+	today := startOfDay(s.timeNow())
+	day := today.AddDate(0, 0, -1)
+	if last, ok, err := s.ns.String(lastDumpKey); err != nil {
+		return err
+	} else if ok {
+		lastDay, err := time.ParseInLocation(TransferDateFormat, last, time.Local)
+		if err != nil {
+			return err
+		}
+		day = lastDay.AddDate(0, 0, 1)
+	}
+	if oldest := today.AddDate(0, 0, -transferRetentionDays); day.Before(oldest) {
+		day = oldest
+	}
+
+	for ; day.Before(today); day = day.AddDate(0, 0, 1) {
+		if err := s.dump(dir, day); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *TransferStatistics) dump(dir string, day time.Time) error {
+	stats, err := s.Get(day, day)
+	if err != nil {
+		return err
+	}
+	date := day.Format(TransferDateFormat)
+	path := filepath.Join(dir, "transfers-"+date+".csv")
+	fd, err := osutil.CreateAtomic(path)
+	if err != nil {
+		return err
+	}
+	// Write errors are returned by Close.
+	_ = WriteTransferStatisticsCSV(fd, stats)
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	l.Debugln("Wrote transfer statistics to", path)
+	return s.ns.PutString(lastDumpKey, date)
+}
+
+// pruneIfDue removes the statistics of days older than the retention
+// period, once per day.
+func (s *TransferStatistics) pruneIfDue() error {
+	today := startOfDay(s.timeNow())
+	date := today.Format(TransferDateFormat)
+	if s.pruned == date {
+		return nil
+	}
+	oldest := today.AddDate(0, 0, -transferRetentionDays).Format(TransferDateFormat)
+	keys, err := s.ns.Keys()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, err := time.Parse(TransferDateFormat, key); err != nil {
+			// Not a day of statistics.
+			continue
+		}
+		if key < oldest {
+			l.Debugln("Removing transfer statistics of", key)
+			if err := s.ns.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+	s.pruned = date
+	return nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// WriteTransferStatisticsCSV writes the statistics as CSV, with a header
+// row.
+func WriteTransferStatisticsCSV(w io.Writer, stats []DailyTransferStatistics) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"date", "folder", "bytesReceived", "bytesSent", "localChanges", "localDeletions", "remoteChanges", "remoteDeletions"})
+	for _, st := range stats {
+		_ = cw.Write([]string{
+			st.Date,
+			st.Folder,
+			strconv.FormatInt(st.BytesReceived, 10),
+			strconv.FormatInt(st.BytesSent, 10),
+			strconv.FormatInt(st.LocalChanges, 10),
+			strconv.FormatInt(st.LocalDeletions, 10),
+			strconv.FormatInt(st.RemoteChanges, 10),
+			strconv.FormatInt(st.RemoteDeletions, 10),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
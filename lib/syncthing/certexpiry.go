@@ -0,0 +1,64 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package syncthing
+
+import (
+	"context"
+	"crypto/x509"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/svcutil"
+)
+
+const (
+	// How long before the device certificate expires it's warned about.
+	deviceCertWarningTime   = 30 * 24 * time.Hour
+	deviceCertCheckInterval = 24 * time.Hour
+)
+
+// deviceCertificateLeaf returns the parsed device certificate, or nil if it
+// can't be parsed.
+func (a *App) deviceCertificateLeaf() *x509.Certificate {
+	if a.cert.Leaf != nil {
+		return a.cert.Leaf
+	}
+	if len(a.cert.Certificate) == 0 {
+		return nil
+	}
+	leaf, err := x509.ParseCertificate(a.cert.Certificate[0])
+	if err != nil {
+		return nil
+	}
+	return leaf
+}
+
+// watchDeviceCertificate warns ahead of the device certificate expiring.
+// The certificate can't be renewed automatically, as the device ID is
+// derived from it. Other devices don't check its expiry though, so syncing
+// isn't affected.
+func watchDeviceCertificate(leaf *x509.Certificate, evLogger events.Logger) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ticker := time.NewTicker(deviceCertCheckInterval)
+		defer ticker.Stop()
+		for {
+			if time.Until(leaf.NotAfter) < deviceCertWarningTime {
+				l.Warnf("The device certificate expires at %v. Other devices don't check its expiry, but a new one can be generated, changing the device ID.", leaf.NotAfter.Format(time.RFC3339))
+				evLogger.Log(events.CertificateExpiring, map[string]interface{}{
+					"certificate": "device",
+					"expires":     leaf.NotAfter,
+				})
+				return svcutil.NoRestartErr(nil)
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package syncthing
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrInstanceRunning is returned by LockInstance when another process holds
+// the lock.
+var ErrInstanceRunning = errors.New("another instance is running")
+
+// An InstanceLock guards the configuration and database of an instance
+// against being used by a second process at the same time. The lock is held
+// by the operating system and hence released when the process exits, also
+// when it crashes.
+type InstanceLock struct {
+	fd *os.File
+}
+
+// LockInstance takes the lock at the given path and records the process ID
+// in it.
+func LockInstance(path string) (*InstanceLock, error) {
+	fd, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(fd); err != nil {
+		fd.Close()
+		return nil, err
+	}
+	if err := fd.Truncate(0); err == nil {
+		_, _ = fd.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0)
+	}
+	return &InstanceLock{fd: fd}, nil
+}
+
+// Release releases the lock.
+func (l *InstanceLock) Release() error {
+	_ = unlockFile(l.fd)
+	return l.fd.Close()
+}
+
+// InstancePID returns the process ID recorded by the holder of the lock at
+// the given path, or zero if it's unknown.
+func InstancePID(path string) int {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, _ := strconv.Atoi(strings.TrimSpace(string(bs)))
+	return pid
+}
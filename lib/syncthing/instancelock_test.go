@@ -0,0 +1,37 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package syncthing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInstanceLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "syncthing.lock")
+
+	lock, err := LockInstance(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pid := InstancePID(path); pid != os.Getpid() {
+		t.Errorf("expected pid %d in the lock, got %d", os.Getpid(), pid)
+	}
+	if _, err := LockInstance(path); err != ErrInstanceRunning {
+		t.Errorf("expected %v taking the lock twice, got %v", ErrInstanceRunning, err)
+	}
+
+	if err := lock.Release(); err != nil {
+		t.Fatal(err)
+	}
+	lock, err = LockInstance(path)
+	if err != nil {
+		t.Fatal("taking the released lock:", err)
+	}
+	lock.Release()
+}
@@ -0,0 +1,28 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build !windows
+// +build !windows
+
+package syncthing
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+func lockFile(fd *os.File) error {
+	err := unix.Flock(int(fd.Fd()), unix.LOCK_EX|unix.LOCK_NB)
+	if err == unix.EWOULDBLOCK {
+		return ErrInstanceRunning
+	}
+	return err
+}
+
+func unlockFile(fd *os.File) error {
+	return unix.Flock(int(fd.Fd()), unix.LOCK_UN)
+}
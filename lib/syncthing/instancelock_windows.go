@@ -0,0 +1,31 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package syncthing
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// Locks on Windows are mandatory, so we lock a byte far beyond the recorded
+// process ID to keep it readable by others.
+const lockOffset = 1 << 30
+
+func lockFile(fd *os.File) error {
+	ol := &windows.Overlapped{Offset: lockOffset}
+	err := windows.LockFileEx(windows.Handle(fd.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, ol)
+	if err == windows.ERROR_LOCK_VIOLATION {
+		return ErrInstanceRunning
+	}
+	return err
+}
+
+func unlockFile(fd *os.File) error {
+	ol := &windows.Overlapped{Offset: lockOffset}
+	return windows.UnlockFileEx(windows.Handle(fd.Fd()), 0, 1, 0, ol)
+}
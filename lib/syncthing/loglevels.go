@@ -0,0 +1,47 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package syncthing
+
+import (
+	"reflect"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/logger"
+)
+
+// The logLevelCommitter applies the log levels from the options whenever
+// they change.
+type logLevelCommitter struct {
+	logger logger.Logger
+}
+
+func (c *logLevelCommitter) CommitConfiguration(from, to config.Configuration) bool {
+	if !reflect.DeepEqual(from.Options.LogLevels, to.Options.LogLevels) {
+		applyLogLevels(c.logger, from.Options.FacilityLogLevels(), to.Options.FacilityLogLevels())
+	}
+	return true
+}
+
+func (*logLevelCommitter) String() string {
+	return "logLevelCommitter"
+}
+
+// applyLogLevels sets the given levels, and resets the facilities that had
+// a level before but no longer do.
+func applyLogLevels(log logger.Logger, from, to map[string]logger.LogLevel) {
+	for facility := range from {
+		if _, ok := to[facility]; !ok {
+			log.SetLevel(facility, logger.DefaultLevel)
+			if log.IsTraced(facility) {
+				log.SetDebug(facility, true)
+			}
+		}
+	}
+	for facility, level := range to {
+		log.SetLevel(facility, level)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package syncthing
+
+import (
+	"io"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/svcutil"
+)
+
+// An Option configures the app, see New.
+type Option interface {
+	apply(*Options)
+}
+
+func (o Options) apply(opts *Options) {
+	*opts = o
+}
+
+type optionFunc func(*Options)
+
+func (fn optionFunc) apply(opts *Options) {
+	fn(opts)
+}
+
+// WithAuditWriter writes audit events to w.
+func WithAuditWriter(w io.Writer) Option {
+	return optionFunc(func(opts *Options) {
+		opts.AuditWriter = w
+	})
+}
+
+// WithDeadlockTimeout sets the timeout of the deadlock detector.
+func WithDeadlockTimeout(d time.Duration) Option {
+	return optionFunc(func(opts *Options) {
+		opts.DeadlockTimeoutS = int(d / time.Second)
+	})
+}
+
+// WithNoUpgrade disables upgrade checks.
+func WithNoUpgrade() Option {
+	return optionFunc(func(opts *Options) {
+		opts.NoUpgrade = true
+	})
+}
+
+// WithProfiler serves the profiler on the given address.
+func WithProfiler(addr string) Option {
+	return optionFunc(func(opts *Options) {
+		opts.ProfilerAddr = addr
+	})
+}
+
+// WithResetDeltaIdxs resets delta index IDs on startup.
+func WithResetDeltaIdxs() Option {
+	return optionFunc(func(opts *Options) {
+		opts.ResetDeltaIdxs = true
+	})
+}
+
+// WithVerbose logs events verbosely.
+func WithVerbose() Option {
+	return optionFunc(func(opts *Options) {
+		opts.Verbose = true
+	})
+}
+
+// WithDBIntervals sets the database recheck and indirect GC intervals,
+// where zero means the default.
+func WithDBIntervals(recheck, indirectGC time.Duration) Option {
+	return optionFunc(func(opts *Options) {
+		opts.DBRecheckInterval = recheck
+		opts.DBIndirectGCInterval = indirectGC
+	})
+}
+
+// WithProtectedFiles sets the files that must never be synced over,
+// instead of the default locations.
+func WithProtectedFiles(files ...string) Option {
+	return optionFunc(func(opts *Options) {
+		opts.ProtectedFiles = append([]string{}, files...)
+	})
+}
+
+// WithGUIAssetsDir serves GUI assets from dir, instead of the default
+// location.
+func WithGUIAssetsDir(dir string) Option {
+	return optionFunc(func(opts *Options) {
+		opts.GUIAssetsDir = dir
+	})
+}
+
+// WithProfile uses the locations of the profile, instead of the process
+// wide ones, for serving several profiles from one process.
+func WithProfile(profile *locations.Profile) Option {
+	return optionFunc(func(opts *Options) {
+		opts.Profile = profile
+	})
+}
+
+// WithStartupHook calls fn at the end of startup, which fails if fn
+// returns an error. It's the place to get at the app's services, or add
+// one.
+func WithStartupHook(fn func(*App) error) Option {
+	return optionFunc(func(opts *Options) {
+		opts.StartupHooks = append(opts.StartupHooks, fn)
+	})
+}
+
+// WithStopHook calls fn once the app has stopped.
+func WithStopHook(fn func(svcutil.ExitStatus, error)) Option {
+	return optionFunc(func(opts *Options) {
+		opts.StopHooks = append(opts.StopHooks, fn)
+	})
+}
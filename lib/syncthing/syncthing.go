@@ -31,17 +31,21 @@ import (
 	"github.com/syncthing/syncthing/lib/db/backend"
 	"github.com/syncthing/syncthing/lib/discover"
 	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/jobs"
 	"github.com/syncthing/syncthing/lib/locations"
 	"github.com/syncthing/syncthing/lib/logger"
 	"github.com/syncthing/syncthing/lib/model"
 	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/rand"
+	"github.com/syncthing/syncthing/lib/selfcheck"
 	"github.com/syncthing/syncthing/lib/sha256"
 	"github.com/syncthing/syncthing/lib/svcutil"
 	"github.com/syncthing/syncthing/lib/tlsutil"
 	"github.com/syncthing/syncthing/lib/upgrade"
 	"github.com/syncthing/syncthing/lib/ur"
+	"github.com/syncthing/syncthing/lib/warnings"
+	"github.com/syncthing/syncthing/lib/webhook"
 )
 
 const (
@@ -63,6 +67,19 @@ type Options struct {
 	// null duration means use default value
 	DBRecheckInterval    time.Duration
 	DBIndirectGCInterval time.Duration
+	// Files that must never be synced over. Defaults to the database,
+	// config and certificate locations.
+	ProtectedFiles []string
+	// Defaults to the GUI assets location.
+	GUIAssetsDir string
+	// The locations of a profile served next to others by this process,
+	// or nil for the process wide locations.
+	Profile *locations.Profile
+	// Called in order at the end of startup, with all services set up. An
+	// error fails the startup.
+	StartupHooks []func(*App) error
+	// Called in order once the app has stopped.
+	StopHooks []func(svcutil.ExitStatus, error)
 }
 
 type App struct {
@@ -71,6 +88,7 @@ type App struct {
 	cfg               config.Wrapper
 	ll                *db.Lowlevel
 	evLogger          events.Logger
+	jobs              jobs.Scheduler
 	cert              tls.Certificate
 	opts              Options
 	exitStatus        svcutil.ExitStatus
@@ -78,10 +96,35 @@ type App struct {
 	stopOnce          sync.Once
 	mainServiceCancel context.CancelFunc
 	stopped           chan struct{}
+
+	// Set during startup
+	model       model.Model
+	connections connections.Service
+	discoverer  discover.Manager
+	selfCheck   selfcheck.Report
 }
 
-func New(cfg config.Wrapper, dbBackend backend.Backend, evLogger events.Logger, cert tls.Certificate, opts Options) (*App, error) {
-	ll, err := db.NewLowlevel(dbBackend, evLogger, db.WithRecheckInterval(opts.DBRecheckInterval), db.WithIndirectGCInterval(opts.DBIndirectGCInterval))
+// New creates the app, configured by the given options. An Options struct
+// is an option itself, replacing all previous options.
+func New(cfg config.Wrapper, dbBackend backend.Backend, evLogger events.Logger, cert tls.Certificate, options ...Option) (*App, error) {
+	var opts Options
+	for _, opt := range options {
+		opt.apply(&opts)
+	}
+	if opts.ProtectedFiles == nil {
+		opts.ProtectedFiles = []string{
+			opts.Profile.Get(locations.Database),
+			opts.Profile.Get(locations.ConfigFile),
+			opts.Profile.Get(locations.CertFile),
+			opts.Profile.Get(locations.KeyFile),
+		}
+	}
+	if opts.GUIAssetsDir == "" {
+		opts.GUIAssetsDir = locations.Get(locations.GUIAssets)
+	}
+
+	scheduler := jobs.New()
+	ll, err := db.NewLowlevel(dbBackend, evLogger, db.WithRecheckInterval(opts.DBRecheckInterval), db.WithIndirectGCInterval(opts.DBIndirectGCInterval), db.WithScheduler(scheduler))
 	if err != nil {
 		return nil, err
 	}
@@ -89,6 +132,7 @@ func New(cfg config.Wrapper, dbBackend backend.Backend, evLogger events.Logger,
 		cfg:      cfg,
 		ll:       ll,
 		evLogger: evLogger,
+		jobs:     scheduler,
 		opts:     opts,
 		cert:     cert,
 		stopped:  make(chan struct{}),
@@ -124,6 +168,7 @@ func (a *App) Start() error {
 func (a *App) startup() error {
 	a.mainService.Add(ur.NewFailureHandler(a.cfg, a.evLogger))
 
+	a.mainService.Add(a.jobs)
 	a.mainService.Add(a.ll)
 
 	if a.opts.AuditWriter != nil {
@@ -134,6 +179,9 @@ func (a *App) startup() error {
 		a.mainService.Add(newVerboseService(a.evLogger))
 	}
 
+	applyLogLevels(logger.DefaultLogger, nil, a.cfg.Options().FacilityLogLevels())
+	a.cfg.Subscribe(&logLevelCommitter{logger.DefaultLogger})
+
 	errors := logger.NewRecorder(l, logger.LevelWarn, maxSystemErrors, 0)
 	systemLog := logger.NewRecorder(l, logger.LevelDebug, maxSystemLog, initialSystemLog)
 
@@ -161,7 +209,7 @@ func (a *App) startup() error {
 	// Emit the Starting event, now that we know who we are.
 
 	a.evLogger.Log(events.Starting, map[string]string{
-		"home": locations.GetBaseDir(locations.ConfigBaseDir),
+		"home": a.opts.Profile.GetBaseDir(locations.ConfigBaseDir),
 		"myID": a.myID.String(),
 	})
 
@@ -195,13 +243,6 @@ func (a *App) startup() error {
 		db.DropDeltaIndexIDs(a.ll)
 	}
 
-	protectedFiles := []string{
-		locations.Get(locations.Database),
-		locations.Get(locations.ConfigFile),
-		locations.Get(locations.CertFile),
-		locations.Get(locations.KeyFile),
-	}
-
 	// Remove database entries for folders that no longer exist in the config
 	folders := a.cfg.Folders()
 	for _, folder := range a.ll.ListFolders() {
@@ -248,8 +289,11 @@ func (a *App) startup() error {
 		return err
 	}
 
+	// Before the folders are started and the stored metadata is fixed.
+	a.selfCheck = a.runSelfCheck()
+
 	keyGen := protocol.NewKeyGenerator()
-	m := model.NewModel(a.cfg, a.myID, "syncthing", build.Version, a.ll, protectedFiles, a.evLogger, keyGen)
+	m := model.NewModel(a.cfg, a.myID, "syncthing", build.Version, a.ll, a.opts.ProtectedFiles, a.opts.Profile, a.evLogger, keyGen, a.jobs)
 
 	if a.opts.DeadlockTimeoutS > 0 {
 		m.StartDeadlockDetector(time.Duration(a.opts.DeadlockTimeoutS) * time.Second)
@@ -258,6 +302,11 @@ func (a *App) startup() error {
 	}
 
 	a.mainService.Add(m)
+	a.model = m
+
+	if leaf := a.deviceCertificateLeaf(); leaf != nil {
+		a.mainService.Add(svcutil.AsService(watchDeviceCertificate(leaf, a.evLogger), "deviceCertificateExpiry"))
+	}
 
 	// The TLS configuration is used for both the listening socket and outgoing
 	// connections.
@@ -274,6 +323,14 @@ func (a *App) startup() error {
 	tlsCfg.ClientAuth = tls.RequestClientCert
 	tlsCfg.SessionTicketsDisabled = true
 	tlsCfg.InsecureSkipVerify = true
+	if a.cfg.Options().PostQuantumKeyExchange {
+		if tlsutil.HybridKeyExchangeSupported {
+			l.Infoln("Preferring hybrid post-quantum key exchange on sync connections.")
+			tlsutil.EnableHybridKeyExchange(tlsCfg)
+		} else {
+			l.Warnln("Hybrid post-quantum key exchange is not supported by this build; using classic key exchange.")
+		}
+	}
 
 	// Start discovery and connection management
 
@@ -287,6 +344,8 @@ func (a *App) startup() error {
 	connectionsService := connections.NewService(a.cfg, a.myID, m, tlsCfg, discoveryManager, bepProtocolName, tlsDefaultCommonName, a.evLogger, connRegistry, keyGen)
 
 	addrLister.AddressLister = connectionsService
+	a.discoverer = discoveryManager
+	a.connections = connectionsService
 
 	a.mainService.Add(discoveryManager)
 	a.mainService.Add(connectionsService)
@@ -307,12 +366,18 @@ func (a *App) startup() error {
 		}
 	})
 
-	usageReportingSvc := ur.New(a.cfg, m, connectionsService, a.opts.NoUpgrade)
+	usageReportingSvc := ur.New(a.cfg, m, connectionsService, a.jobs, a.opts.NoUpgrade)
 	a.mainService.Add(usageReportingSvc)
 
+	webhookSvc := webhook.New(a.cfg, a.ll, a.evLogger)
+	a.mainService.Add(webhookSvc)
+
+	warningsSvc := warnings.New(a.cfg, a.evLogger)
+	a.mainService.Add(warningsSvc)
+
 	// GUI
 
-	if err := a.setupGUI(m, defaultSub, diskSub, discoveryManager, connectionsService, usageReportingSvc, errors, systemLog); err != nil {
+	if err := a.setupGUI(m, defaultSub, diskSub, discoveryManager, connectionsService, usageReportingSvc, webhookSvc, warningsSvc, errors, systemLog); err != nil {
 		l.Warnln("Failed starting API:", err)
 		return err
 	}
@@ -325,6 +390,13 @@ func (a *App) startup() error {
 		}
 	}
 
+	for _, hook := range a.opts.StartupHooks {
+		if err := hook(a); err != nil {
+			l.Warnln("Startup hook:", err)
+			return err
+		}
+	}
+
 	if isSuperUser() {
 		l.Warnln("Syncthing should not run as a privileged or system user. Please consider using a normal user account.")
 	}
@@ -359,6 +431,10 @@ func (a *App) wait(errChan <-chan error) {
 
 	l.Infoln("Exiting")
 
+	for _, hook := range a.opts.StopHooks {
+		hook(a.exitStatus, a.err)
+	}
+
 	close(a.stopped)
 }
 
@@ -414,7 +490,45 @@ func (a *App) stopWithErr(stopReason svcutil.ExitStatus, err error) svcutil.Exit
 	return a.exitStatus
 }
 
-func (a *App) setupGUI(m model.Model, defaultSub, diskSub events.BufferedSubscription, discoverer discover.Manager, connectionsService connections.Service, urService *ur.Service, errors, systemLog logger.Recorder) error {
+// DeviceID returns the ID of this device. It's valid once Start has
+// returned.
+func (a *App) DeviceID() protocol.DeviceID {
+	return a.myID
+}
+
+// Config returns the configuration wrapper the app was created with.
+func (a *App) Config() config.Wrapper {
+	return a.cfg
+}
+
+// EventLogger returns the event logger the app was created with.
+func (a *App) EventLogger() events.Logger {
+	return a.evLogger
+}
+
+// Model returns the model, or nil before startup.
+func (a *App) Model() model.Model {
+	return a.model
+}
+
+// Connections returns the connection service, or nil before startup.
+func (a *App) Connections() connections.Service {
+	return a.connections
+}
+
+// Discovery returns the discovery manager, or nil before startup.
+func (a *App) Discovery() discover.Manager {
+	return a.discoverer
+}
+
+// AddService runs the service under the app's supervisor, until the app
+// stops. It must only be called once Start has returned, or from a startup
+// hook.
+func (a *App) AddService(svc suture.Service) {
+	a.mainService.Add(svc)
+}
+
+func (a *App) setupGUI(m model.Model, defaultSub, diskSub events.BufferedSubscription, discoverer discover.Manager, connectionsService connections.Service, urService *ur.Service, webhooks webhook.Service, warningsSvc warnings.Service, errors, systemLog logger.Recorder) error {
 	guiCfg := a.cfg.GUI()
 
 	if !guiCfg.Enabled {
@@ -428,7 +542,7 @@ func (a *App) setupGUI(m model.Model, defaultSub, diskSub events.BufferedSubscri
 	summaryService := model.NewFolderSummaryService(a.cfg, m, a.myID, a.evLogger)
 	a.mainService.Add(summaryService)
 
-	apiSvc := api.New(a.myID, a.cfg, locations.Get(locations.GUIAssets), tlsDefaultCommonName, m, defaultSub, diskSub, a.evLogger, discoverer, connectionsService, urService, summaryService, errors, systemLog, a.opts.NoUpgrade)
+	apiSvc := api.New(a.myID, a.cfg, a.opts.GUIAssetsDir, tlsDefaultCommonName, m, defaultSub, diskSub, a.evLogger, discoverer, connectionsService, urService, summaryService, webhooks, warningsSvc, a.jobs, a.selfCheck, errors, systemLog, a.opts.NoUpgrade, a.opts.Profile)
 	a.mainService.Add(apiSvc)
 
 	if err := apiSvc.WaitForStart(); err != nil {
@@ -437,6 +551,29 @@ func (a *App) setupGUI(m model.Model, defaultSub, diskSub events.BufferedSubscri
 	return nil
 }
 
+// runSelfCheck checks the configuration, database and device certificate
+// for problems, which are logged and published as a HealthReport event.
+func (a *App) runSelfCheck() selfcheck.Report {
+	var certs []selfcheck.Certificate
+	if leaf := a.deviceCertificateLeaf(); leaf != nil {
+		certs = append(certs, selfcheck.Certificate{
+			Name: "device",
+			Leaf: leaf,
+			Remediation: []string{
+				"Other Syncthing devices don't check the expiry of device certificates, so syncing is not affected.",
+				"A new certificate can be generated with \"syncthing generate\", which changes the device ID. It must then be updated on all other devices.",
+			},
+		})
+	}
+
+	report := selfcheck.Run(a.cfg.RawCopy(), a.ll, certs)
+	for _, p := range report.Problems {
+		l.Warnf("Self-check: %s. %s", p.Message, strings.Join(p.Remediation, " "))
+	}
+	a.evLogger.Log(events.HealthReport, report)
+	return report
+}
+
 // checkShortIDs verifies that the configuration won't result in duplicate
 // short ID:s; that is, that the devices in the cluster all have unique
 // initial 64 bits.
@@ -72,7 +72,11 @@ func TestStartupFail(t *testing.T) {
 	defer os.Remove(cfg.ConfigPath())
 
 	db := backend.OpenMemory()
-	app, err := New(cfg, db, events.NoopLogger, cert, Options{})
+	var hookE svcutil.ExitStatus
+	stopHook := WithStopHook(func(status svcutil.ExitStatus, _ error) {
+		hookE = status
+	})
+	app, err := New(cfg, db, events.NoopLogger, cert, Options{}, stopHook)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -97,6 +101,9 @@ func TestStartupFail(t *testing.T) {
 	if waitE != svcutil.ExitError {
 		t.Errorf("Got exit status %v, expected %v", waitE, svcutil.ExitError)
 	}
+	if hookE != waitE {
+		t.Errorf("Got exit status %v in stop hook, expected %v", hookE, waitE)
+	}
 
 	if err = app.Error(); err != startErr {
 		t.Errorf(`Got different errors "%v" from Start and "%v" from Error`, startErr, err)
@@ -109,3 +116,30 @@ func TestStartupFail(t *testing.T) {
 		t.Error("Expected error due to db being closed, got", err)
 	}
 }
+
+func TestOptions(t *testing.T) {
+	cert, err := tlsutil.NewCertificateInMemory("syncthing", 365)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.Wrap(tempCfgFilename(t), config.New(protocol.NewDeviceID(cert.Certificate[0])), protocol.LocalDeviceID, events.NoopLogger)
+	defer os.Remove(cfg.ConfigPath())
+
+	app, err := New(cfg, backend.OpenMemory(), events.NoopLogger, cert, WithVerbose(), Options{NoUpgrade: true}, WithProtectedFiles("protected"), WithDeadlockTimeout(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The Options struct replaces what came before it.
+	if app.opts.Verbose || !app.opts.NoUpgrade || app.opts.DeadlockTimeoutS != 60 {
+		t.Errorf("unexpected options %+v", app.opts)
+	}
+	if len(app.opts.ProtectedFiles) != 1 || app.opts.ProtectedFiles[0] != "protected" {
+		t.Errorf("unexpected protected files %v", app.opts.ProtectedFiles)
+	}
+	if app.opts.GUIAssetsDir == "" {
+		t.Error("expected default GUI assets dir")
+	}
+	if app.Config() != cfg || app.Model() != nil {
+		t.Error("unexpected app accessors before startup")
+	}
+}
@@ -45,12 +45,27 @@ func EnsureDir(dir string, mode fs.FileMode) error {
 	return nil
 }
 
+// LoadOrGenerateCertificate loads the certificate and key, generating them
+// if they can't be loaded. The key may also be a signer reference (see
+// tlsutil.NewSigner), in which case only a missing certificate is
+// generated, for the signer's key.
 func LoadOrGenerateCertificate(certFile, keyFile string) (tls.Certificate, error) {
-	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
-	if err != nil {
+	cert, err := tlsutil.LoadX509KeyPair(certFile, keyFile)
+	if err == nil {
+		return cert, nil
+	}
+	if !tlsutil.IsSignerReference(keyFile) {
 		return GenerateCertificate(certFile, keyFile)
 	}
-	return cert, nil
+	if _, statErr := os.Stat(certFile); !os.IsNotExist(statErr) {
+		return tls.Certificate{}, err
+	}
+	signer, err := tlsutil.NewSigner(keyFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	l.Infof("Generating certificate for %s with external key...", tlsDefaultCommonName)
+	return tlsutil.NewCertificateForSigner(certFile, signer, tlsDefaultCommonName, deviceCertLifetimeDays)
 }
 
 func GenerateCertificate(certFile, keyFile string) (tls.Certificate, error) {
@@ -0,0 +1,26 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build !go1.25
+// +build !go1.25
+
+package tlsutil
+
+import "crypto/tls"
+
+// HybridKeyExchangeSupported is true when the TLS stack supports a hybrid
+// post-quantum key exchange and reports the negotiated one.
+const HybridKeyExchangeSupported = false
+
+// EnableHybridKeyExchange does nothing, as hybrid key exchange requires a
+// newer Go version.
+func EnableHybridKeyExchange(_ *tls.Config) {}
+
+// KeyExchange returns the empty string, as the negotiated key exchange
+// mechanism isn't available with this Go version.
+func KeyExchange(_ tls.ConnectionState) string {
+	return ""
+}
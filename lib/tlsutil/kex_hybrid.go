@@ -0,0 +1,33 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build go1.25
+// +build go1.25
+
+package tlsutil
+
+import "crypto/tls"
+
+// HybridKeyExchangeSupported is true when the TLS stack supports a hybrid
+// post-quantum key exchange and reports the negotiated one.
+const HybridKeyExchangeSupported = true
+
+// EnableHybridKeyExchange makes the configuration prefer X25519 combined
+// with ML-KEM-768 for the key exchange, falling back to the classic
+// mechanisms for peers that don't support it. It only has an effect on
+// TLS 1.3 connections.
+func EnableHybridKeyExchange(cfg *tls.Config) {
+	cfg.CurvePreferences = []tls.CurveID{tls.X25519MLKEM768, tls.X25519, tls.CurveP256, tls.CurveP384, tls.CurveP521}
+}
+
+// KeyExchange returns the name of the key exchange mechanism negotiated on
+// the connection, or the empty string if it's unknown.
+func KeyExchange(cs tls.ConnectionState) string {
+	if cs.CurveID == 0 {
+		return ""
+	}
+	return cs.CurveID.String()
+}
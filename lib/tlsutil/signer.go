@@ -0,0 +1,202 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tlsutil
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/rand"
+)
+
+// A SignerProvider returns the signer for a key reference, which is of the
+// form "scheme:opaque" with the scheme the provider is registered for.
+// This allows the private key to live somewhere it can't be copied from,
+// such as a TPM, secure enclave or PKCS#11 token.
+type SignerProvider func(ref string) (crypto.Signer, error)
+
+var (
+	signerProviders = map[string]SignerProvider{
+		"exec": newExecSigner,
+	}
+	signerProvidersMut sync.Mutex
+)
+
+// RegisterSignerProvider makes keys with references of the given scheme
+// available through the provider.
+func RegisterSignerProvider(scheme string, provider SignerProvider) {
+	signerProvidersMut.Lock()
+	signerProviders[scheme] = provider
+	signerProvidersMut.Unlock()
+}
+
+// IsSignerReference returns true if the key is given as a reference to a
+// signer, rather than as the path of a key file.
+func IsSignerReference(key string) bool {
+	scheme, _, ok := strings.Cut(key, ":")
+	// A single letter is a drive letter on Windows.
+	if !ok || len(scheme) < 2 {
+		return false
+	}
+	for _, r := range scheme {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// NewSigner returns the signer for the given key reference.
+func NewSigner(ref string) (crypto.Signer, error) {
+	scheme, _, _ := strings.Cut(ref, ":")
+	signerProvidersMut.Lock()
+	provider, ok := signerProviders[scheme]
+	signerProvidersMut.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no signer available for %q keys", scheme)
+	}
+	return provider(ref)
+}
+
+// LoadX509KeyPair is like tls.LoadX509KeyPair, except that the key may also
+// be given as a signer reference.
+func LoadX509KeyPair(certFile, key string) (tls.Certificate, error) {
+	if !IsSignerReference(key) {
+		return tls.LoadX509KeyPair(certFile, key)
+	}
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	signer, err := NewSigner(key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return certificateWithSigner(certPEM, signer)
+}
+
+func certificateWithSigner(certPEM []byte, signer crypto.Signer) (tls.Certificate, error) {
+	var cert tls.Certificate
+	for {
+		var block *pem.Block
+		block, certPEM = pem.Decode(certPEM)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			cert.Certificate = append(cert.Certificate, block.Bytes)
+		}
+	}
+	if len(cert.Certificate) == 0 {
+		return tls.Certificate{}, errors.New("no certificate found")
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(leaf.PublicKey) {
+		return tls.Certificate{}, errors.New("certificate does not match the signer's public key")
+	}
+	cert.PrivateKey = signer
+	cert.Leaf = leaf
+	return cert, nil
+}
+
+// NewCertificateForSigner generates a certificate for the key held by the
+// signer and saves it to the given PEM file.
+func NewCertificateForSigner(certFile string, signer crypto.Signer, commonName string, lifetimeDays int) (tls.Certificate, error) {
+	template := certificateTemplate(commonName, lifetimeDays)
+	// Let the signature algorithm follow from the type of key.
+	template.SignatureAlgorithm = x509.UnknownSignatureAlgorithm
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("create cert: %w", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+	if err := os.WriteFile(certFile, certPEM, 0o644); err != nil {
+		return tls.Certificate{}, fmt.Errorf("save cert: %w", err)
+	}
+	return certificateWithSigner(certPEM, signer)
+}
+
+const execSignerTimeout = time.Minute
+
+// The execSigner uses a helper program for keys referenced as "exec:<command
+// line>", making the tools of the platform for TPMs, secure enclaves and
+// PKCS#11 tokens usable. The helper is called with the additional arguments
+//
+//	public              - to write the PEM encoded public key to stdout
+//	sign <hash> [pss]   - to sign the digest read from stdin and write the
+//	                      signature to stdout, with hash as in "SHA-256"
+//
+// Signatures are ASN.1 encoded for ECDSA keys, and PKCS #1 v1.5 or, if pss
+// is given, PSS with a salt as long as the hash for RSA keys.
+type execSigner struct {
+	argv []string
+	pub  crypto.PublicKey
+}
+
+func newExecSigner(ref string) (crypto.Signer, error) {
+	argv := strings.Fields(strings.TrimPrefix(ref, "exec:"))
+	if len(argv) == 0 {
+		return nil, errors.New("exec signer: missing command")
+	}
+	s := &execSigner{argv: argv}
+	out, err := s.run(nil, "public")
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(out)
+	if block == nil {
+		return nil, errors.New("exec signer: no public key returned")
+	}
+	if s.pub, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+		return nil, fmt.Errorf("exec signer: %w", err)
+	}
+	return s, nil
+}
+
+func (s *execSigner) Public() crypto.PublicKey {
+	return s.pub
+}
+
+func (s *execSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	args := []string{"sign", opts.HashFunc().String()}
+	if _, ok := opts.(*rsa.PSSOptions); ok {
+		args = append(args, "pss")
+	}
+	return s.run(digest, args...)
+}
+
+func (s *execSigner) run(stdin []byte, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), execSignerTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, s.argv[0], append(s.argv[1:len(s.argv):len(s.argv)], args...)...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("exec signer: %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
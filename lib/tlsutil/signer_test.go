@@ -0,0 +1,138 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const signerHelperKeyEnv = "STTEST_SIGNER_KEY"
+
+// TestExecSignerHelper isn't a real test, but the helper program used by
+// the exec signer in TestExecSigner.
+func TestExecSignerHelper(t *testing.T) {
+	keyFile := os.Getenv(signerHelperKeyEnv)
+	if keyFile == "" {
+		t.Skip("only run as signer helper")
+	}
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	bs, err := os.ReadFile(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(bs)
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	switch args[1] {
+	case "public":
+		der, err := x509.MarshalPKIXPublicKey(key.Public())
+		if err != nil {
+			t.Fatal(err)
+		}
+		pem.Encode(os.Stdout, &pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	case "sign":
+		digest, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := ecdsa.SignASN1(rand.Reader, key, digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		os.Stdout.Write(sig)
+	}
+	os.Exit(0)
+}
+
+func TestExecSigner(t *testing.T) {
+	dir := t.TempDir()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyBlock, err := pemBlockForKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(keyBlock), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(signerHelperKeyEnv, keyFile)
+
+	ref := "exec:" + os.Args[0] + " -test.run=^TestExecSignerHelper$ --"
+	if !IsSignerReference(ref) {
+		t.Fatal("should be a signer reference")
+	}
+	signer, err := NewSigner(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile := filepath.Join(dir, "cert.pem")
+	if _, err := NewCertificateForSigner(certFile, signer, "syncthing", 1); err != nil {
+		t.Fatal(err)
+	}
+	cert, err := LoadX509KeyPair(certFile, ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Do a handshake that requires the signer on both sides.
+	c0, c1 := net.Pipe()
+	defer c0.Close()
+	defer c1.Close()
+	cfg := SecureDefaultTLS13()
+	cfg.Certificates = []tls.Certificate{cert}
+	cfg.ClientAuth = tls.RequireAnyClientCert
+	cfg.InsecureSkipVerify = true
+	server := tls.Server(c0, cfg)
+	client := tls.Client(c1, cfg)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Handshake()
+	}()
+	if err := client.Handshake(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIsSignerReference(t *testing.T) {
+	cases := map[string]bool{
+		"key.pem":                  false,
+		"/home/user/key.pem":       false,
+		`C:\Users\user\key.pem`:    false,
+		"exec:/usr/bin/tpm-signer": true,
+		"pkcs11:token=syncthing":   true,
+	}
+	for ref, expected := range cases {
+		if IsSignerReference(ref) != expected {
+			t.Errorf("IsSignerReference(%q) != %v", ref, expected)
+		}
+	}
+	if _, err := NewSigner("pkcs11:token=syncthing"); err == nil {
+		t.Error("expected an error for a scheme without provider")
+	}
+}
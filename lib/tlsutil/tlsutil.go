@@ -93,13 +93,29 @@ func generateCertificate(commonName string, lifetimeDays int) (*pem.Block, *pem.
 		return nil, nil, fmt.Errorf("generate key: %w", err)
 	}
 
+	template := certificateTemplate(commonName, lifetimeDays)
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, template, priv.Public(), priv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create cert: %w", err)
+	}
+
+	certBlock := &pem.Block{Type: "CERTIFICATE", Bytes: derBytes}
+	keyBlock, err := pemBlockForKey(priv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("save key: %w", err)
+	}
+
+	return certBlock, keyBlock, nil
+}
+
+func certificateTemplate(commonName string, lifetimeDays int) *x509.Certificate {
 	notBefore := time.Now().Truncate(24 * time.Hour)
 	notAfter := notBefore.Add(time.Duration(lifetimeDays*24) * time.Hour)
 
 	// NOTE: update lib/api.shouldRegenerateCertificate() appropriately if
 	// you add or change attributes in here, especially DNSNames or
 	// IPAddresses.
-	template := x509.Certificate{
+	return &x509.Certificate{
 		SerialNumber: new(big.Int).SetUint64(rand.Uint64()),
 		Subject: pkix.Name{
 			CommonName:         commonName,
@@ -114,19 +130,6 @@ func generateCertificate(commonName string, lifetimeDays int) (*pem.Block, *pem.
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
 		BasicConstraintsValid: true,
 	}
-
-	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, priv.Public(), priv)
-	if err != nil {
-		return nil, nil, fmt.Errorf("create cert: %w", err)
-	}
-
-	certBlock := &pem.Block{Type: "CERTIFICATE", Bytes: derBytes}
-	keyBlock, err := pemBlockForKey(priv)
-	if err != nil {
-		return nil, nil, fmt.Errorf("save key: %w", err)
-	}
-
-	return certBlock, keyBlock, nil
 }
 
 // NewCertificate generates and returns a new TLS certificate, saved to the given PEM files.
@@ -155,3 +155,36 @@ func (*fakeConn) RemoteAddr() net.Addr             { return nil }
 func (*fakeConn) SetDeadline(time.Time) error      { return nil }
 func (*fakeConn) SetReadDeadline(time.Time) error  { return nil }
 func (*fakeConn) SetWriteDeadline(time.Time) error { return nil }
+
+func TestHybridKeyExchange(t *testing.T) {
+	if !HybridKeyExchangeSupported {
+		t.Skip("hybrid key exchange not supported")
+	}
+	cert, err := NewCertificateInMemory("syncthing", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := SecureDefaultTLS13()
+	cfg.Certificates = []tls.Certificate{cert}
+	cfg.InsecureSkipVerify = true
+	EnableHybridKeyExchange(cfg)
+
+	c0, c1 := net.Pipe()
+	defer c0.Close()
+	defer c1.Close()
+	server := tls.Server(c0, cfg)
+	client := tls.Client(c1, cfg)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Handshake()
+	}()
+	if err := client.Handshake(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	if kex := KeyExchange(client.ConnectionState()); kex != "X25519MLKEM768" {
+		t.Errorf("got key exchange %q, expected X25519MLKEM768", kex)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/syncthing/syncthing/lib/connections"
 	"github.com/syncthing/syncthing/lib/db"
 	"github.com/syncthing/syncthing/lib/dialer"
+	"github.com/syncthing/syncthing/lib/jobs"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/scanner"
 	"github.com/syncthing/syncthing/lib/upgrade"
@@ -38,6 +39,11 @@ const Version = 3
 
 var StartTime = time.Now().Truncate(time.Second)
 
+const (
+	reportJobName  = "usageReport"
+	reportInterval = 24 * time.Hour
+)
+
 type Model interface {
 	DBSnapshot(folder string) (*db.Snapshot, error)
 	UsageReportingStats(report *contract.Report, version int, preview bool)
@@ -47,17 +53,17 @@ type Service struct {
 	cfg                config.Wrapper
 	model              Model
 	connectionsService connections.Service
+	scheduler          jobs.Scheduler
 	noUpgrade          bool
-	forceRun           chan struct{}
 }
 
-func New(cfg config.Wrapper, m Model, connectionsService connections.Service, noUpgrade bool) *Service {
+func New(cfg config.Wrapper, m Model, connectionsService connections.Service, scheduler jobs.Scheduler, noUpgrade bool) *Service {
 	return &Service{
 		cfg:                cfg,
 		model:              m,
 		connectionsService: connectionsService,
+		scheduler:          scheduler,
 		noUpgrade:          noUpgrade,
-		forceRun:           make(chan struct{}, 1), // Buffered to prevent locking
 	}
 }
 
@@ -372,35 +378,33 @@ func (s *Service) Serve(ctx context.Context) error {
 	s.cfg.Subscribe(s)
 	defer s.cfg.Unsubscribe(s)
 
-	t := time.NewTimer(time.Duration(s.cfg.Options().URInitialDelayS) * time.Second)
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-s.forceRun:
-			t.Reset(0)
-		case <-t.C:
-			if s.cfg.Options().URAccepted >= 2 {
-				err := s.sendUsageReport(ctx)
-				if err != nil {
-					l.Infoln("Usage report:", err)
-				} else {
-					l.Infof("Sent usage report (version %d)", s.cfg.Options().URAccepted)
-				}
-			}
-			t.Reset(24 * time.Hour) // next report tomorrow
-		}
+	s.scheduler.Add(jobs.Job{
+		Name:     reportJobName,
+		First:    time.Duration(s.cfg.Options().URInitialDelayS) * time.Second,
+		Interval: func() time.Duration { return reportInterval },
+		Run:      s.runUsageReport,
+	})
+	defer s.scheduler.Remove(reportJobName)
+
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (s *Service) runUsageReport(ctx context.Context) error {
+	if s.cfg.Options().URAccepted < 2 {
+		return nil
+	}
+	if err := s.sendUsageReport(ctx); err != nil {
+		l.Infoln("Usage report:", err)
+		return err
 	}
+	l.Infof("Sent usage report (version %d)", s.cfg.Options().URAccepted)
+	return nil
 }
 
 func (s *Service) CommitConfiguration(from, to config.Configuration) bool {
 	if from.Options.URAccepted != to.Options.URAccepted || from.Options.URUniqueID != to.Options.URUniqueID || from.Options.URURL != to.Options.URURL {
-		select {
-		case s.forceRun <- struct{}{}:
-		default:
-			// s.forceRun is one buffered, so even though nothing
-			// was sent, a run will still happen after this point.
-		}
+		s.scheduler.Trigger(reportJobName)
 	}
 	return true
 }
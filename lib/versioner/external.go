@@ -122,6 +122,10 @@ func (external) Restore(_ string, _ time.Time) error {
 	return ErrRestorationNotSupported
 }
 
+func (external) Purge(_ string, _ time.Time) error {
+	return ErrRestorationNotSupported
+}
+
 func (external) Clean(_ context.Context) error {
 	return nil
 }
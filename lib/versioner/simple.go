@@ -42,7 +42,7 @@ func newSimple(cfg config.FolderConfiguration) Versioner {
 		keep:            keep,
 		cleanoutDays:    cleanoutDays,
 		folderFs:        cfg.Filesystem(nil),
-		versionsFs:      versionerFsFromFolderCfg(cfg),
+		versionsFs:      VersionsFilesystem(cfg),
 		copyRangeMethod: cfg.CopyRangeMethod,
 	}
 
@@ -71,6 +71,10 @@ func (v simple) Restore(filepath string, versionTime time.Time) error {
 	return restoreFile(v.copyRangeMethod, v.versionsFs, v.folderFs, filepath, versionTime, TagFilename)
 }
 
+func (v simple) Purge(filepath string, versionTime time.Time) error {
+	return purgeFile(v.versionsFs, filepath, versionTime, TagFilename)
+}
+
 func (v simple) Clean(ctx context.Context) error {
 	return clean(ctx, v.versionsFs, v.toRemove)
 }
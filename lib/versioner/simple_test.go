@@ -93,3 +93,39 @@ func TestSimpleVersioningVersionCount(t *testing.T) {
 		time.Sleep(time.Second)
 	}
 }
+
+func TestSimpleVersioningPurge(t *testing.T) {
+	cfg := config.FolderConfiguration{
+		FilesystemType: fs.FilesystemTypeBasic,
+		Path:           t.TempDir(),
+		Versioning: config.VersioningConfiguration{
+			Params: map[string]string{
+				"keep": "5",
+			},
+		},
+	}
+	ffs := cfg.Filesystem(nil)
+	v := newSimple(cfg)
+
+	fd, err := ffs.Create("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd.Close()
+	if err := v.Archive("test"); err != nil {
+		t.Fatal(err)
+	}
+	versions, err := v.GetVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions["test"]) != 1 {
+		t.Fatalf("unexpected versions %v", versions)
+	}
+	if err := v.Purge("test", versions["test"][0].VersionTime); err != nil {
+		t.Fatal(err)
+	}
+	if names, _ := ffs.DirNames(DefaultPath); len(names) != 0 {
+		t.Errorf("expected archive to be empty, got %v", names)
+	}
+}
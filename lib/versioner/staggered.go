@@ -41,7 +41,7 @@ func newStaggered(cfg config.FolderConfiguration) Versioner {
 		maxAge = 31536000 // Default: ~1 year
 	}
 
-	versionsFs := versionerFsFromFolderCfg(cfg)
+	versionsFs := VersionsFilesystem(cfg)
 
 	s := &staggered{
 		folderFs:   cfg.Filesystem(nil),
@@ -133,6 +133,10 @@ func (v *staggered) Restore(filepath string, versionTime time.Time) error {
 	return restoreFile(v.copyRangeMethod, v.versionsFs, v.folderFs, filepath, versionTime, TagFilename)
 }
 
+func (v *staggered) Purge(filepath string, versionTime time.Time) error {
+	return purgeFile(v.versionsFs, filepath, versionTime, TagFilename)
+}
+
 func (v *staggered) String() string {
 	return fmt.Sprintf("Staggered/@%p", v)
 }
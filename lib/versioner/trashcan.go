@@ -34,7 +34,7 @@ func newTrashcan(cfg config.FolderConfiguration) Versioner {
 
 	s := &trashcan{
 		folderFs:        cfg.Filesystem(nil),
-		versionsFs:      versionerFsFromFolderCfg(cfg),
+		versionsFs:      VersionsFilesystem(cfg),
 		cleanoutDays:    cleanoutDays,
 		copyRangeMethod: cfg.CopyRangeMethod,
 	}
@@ -107,6 +107,12 @@ func (t *trashcan) GetVersions() (map[string][]FileVersion, error) {
 	return retrieveVersions(t.versionsFs)
 }
 
+func (t *trashcan) Purge(filepath string, versionTime time.Time) error {
+	return purgeFile(t.versionsFs, filepath, versionTime, func(name, _ string) string {
+		return name
+	})
+}
+
 func (t *trashcan) Restore(filepath string, versionTime time.Time) error {
 	// If we have an untagged file A and want to restore it on top of existing file A, we can't first archive the
 	// existing A as we'd overwrite the old A version, therefore when we archive existing file, we archive it with a
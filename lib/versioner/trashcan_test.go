@@ -262,3 +262,35 @@ func TestTrashcanCleanOut(t *testing.T) {
 		}
 	})
 }
+
+func TestRecycleBinPurge(t *testing.T) {
+	cfg := config.FolderConfiguration{
+		FilesystemType: fs.FilesystemTypeBasic,
+		Path:           t.TempDir(),
+	}
+	folderFs := cfg.Filesystem(nil)
+	versioner := NewRecycleBin(cfg, 7)
+
+	writeFile(t, folderFs, "file", "Some content")
+	if err := versioner.Archive("file"); err != nil {
+		t.Fatal(err)
+	}
+	versions, err := versioner.GetVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions["file"]) != 1 {
+		t.Fatalf("unexpected versions %v", versions)
+	}
+
+	versionTime := versions["file"][0].VersionTime
+	if err := versioner.Purge("file", versionTime.Add(-time.Second)); err == nil {
+		t.Error("purging nonexistent version should fail")
+	}
+	if err := versioner.Purge("file", versionTime); err != nil {
+		t.Fatal(err)
+	}
+	if versions, err := versioner.GetVersions(); err != nil || len(versions) != 0 {
+		t.Errorf("expected no versions after purge, got %v, %v", versions, err)
+	}
+}
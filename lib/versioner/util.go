@@ -258,7 +258,27 @@ func restoreFile(method fs.CopyRangeMethod, src, dst fs.Filesystem, filePath str
 	return err
 }
 
-func versionerFsFromFolderCfg(cfg config.FolderConfiguration) (versionsFs fs.Filesystem) {
+// purgeFile removes the given version of the file from the archive.
+func purgeFile(versionsFs fs.Filesystem, filePath string, versionTime time.Time, tagger fileTagger) error {
+	filePath = osutil.NativeFilename(filePath)
+	tag := versionTime.In(time.Local).Truncate(time.Second).Format(TimeFormat)
+	taggedFilePath := tagger(filePath, tag)
+
+	if info, err := versionsFs.Lstat(taggedFilePath); err == nil && info.IsRegular() && taggedFilePath != filePath {
+		return versionsFs.Remove(taggedFilePath)
+	}
+
+	// Check for untagged file
+	if info, err := versionsFs.Lstat(filePath); err == nil && info.IsRegular() && info.ModTime().Truncate(time.Second).Equal(versionTime) {
+		return versionsFs.Remove(filePath)
+	}
+
+	return errNotFound
+}
+
+// VersionsFilesystem returns the filesystem the versioners keeping versions
+// in a directory keep those of the folder in.
+func VersionsFilesystem(cfg config.FolderConfiguration) (versionsFs fs.Filesystem) {
 	folderFs := cfg.Filesystem(nil)
 	if cfg.Versioning.FSPath == "" {
 		versionsFs = fs.NewFilesystem(folderFs.Type(), filepath.Join(folderFs.URI(), DefaultPath))
@@ -21,6 +21,7 @@ type Versioner interface {
 	Archive(filePath string) error
 	GetVersions() (map[string][]FileVersion, error)
 	Restore(filePath string, versionTime time.Time) error
+	Purge(filePath string, versionTime time.Time) error
 	Clean(context.Context) error
 }
 
@@ -53,6 +54,22 @@ func New(cfg config.FolderConfiguration) (Versioner, error) {
 	}, nil
 }
 
+// NewRecycleBin returns a versioner keeping deleted and replaced files for
+// the given number of days, like the trash can versioner. It's used for
+// receive-only folders without versioning, so that what was overridden by
+// remote changes can still be restored.
+func NewRecycleBin(cfg config.FolderConfiguration, days int) Versioner {
+	return &versionerWithErrorContext{
+		Versioner: &trashcan{
+			folderFs:        cfg.Filesystem(nil),
+			versionsFs:      VersionsFilesystem(cfg),
+			cleanoutDays:    days,
+			copyRangeMethod: cfg.CopyRangeMethod,
+		},
+		vtype: "recycle bin",
+	}
+}
+
 type versionerWithErrorContext struct {
 	Versioner
 	vtype string
@@ -78,6 +95,10 @@ func (v *versionerWithErrorContext) Restore(filePath string, versionTime time.Ti
 	return v.wrapError(v.Versioner.Restore(filePath, versionTime), "restore")
 }
 
+func (v *versionerWithErrorContext) Purge(filePath string, versionTime time.Time) error {
+	return v.wrapError(v.Versioner.Purge(filePath, versionTime), "purge")
+}
+
 func (v *versionerWithErrorContext) Clean(ctx context.Context) error {
 	return v.wrapError(v.Versioner.Clean(ctx), "clean")
 }
@@ -0,0 +1,263 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package warnings keeps track of what on this device is deprecated or
+// about to change: configuration options, peers running old versions and
+// defaults that will be different in a future release. Each warning has a
+// stable ID, so that it can be audited and dealt with before an upgrade.
+package warnings
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thejerf/suture/v4"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/sync"
+	"github.com/syncthing/syncthing/lib/upgrade"
+)
+
+// Peers running Syncthing older than this are reported. They lack support
+// for untrusted devices and later protocol additions, and connecting to
+// them may stop working in a future release.
+const legacyPeerVersion = "v1.12.0"
+
+type Kind string
+
+const (
+	KindDeprecatedOption Kind = "deprecatedOption"
+	KindLegacyPeer       Kind = "legacyPeer"
+	KindDefaultChange    Kind = "defaultChange"
+)
+
+// Warning is something that works now, but is deprecated or will change
+// in a future release. The ID is stable across releases, the subject tells
+// which device it's about, for warnings that can apply to several.
+type Warning struct {
+	ID          string    `json:"id"`
+	Kind        Kind      `json:"kind"`
+	Subject     string    `json:"subject,omitempty"`
+	Message     string    `json:"message"`
+	Remediation []string  `json:"remediation"`
+	Since       time.Time `json:"since"`
+}
+
+func (w Warning) key() string {
+	return w.ID + "/" + w.Subject
+}
+
+type Service interface {
+	suture.Service
+	config.Committer
+	// Warnings returns the current warnings, ordered by kind and ID.
+	Warnings() []Warning
+}
+
+type service struct {
+	cfg      config.Wrapper
+	evLogger events.Logger
+	timeNow  func() time.Time
+	mut      sync.Mutex
+	warnings map[string]Warning
+}
+
+func New(cfg config.Wrapper, evLogger events.Logger) Service {
+	return &service{
+		cfg:      cfg,
+		evLogger: evLogger,
+		timeNow:  time.Now,
+		mut:      sync.NewMutex(),
+		warnings: make(map[string]Warning),
+	}
+}
+
+func (s *service) Serve(ctx context.Context) error {
+	sub := s.evLogger.Subscribe(events.DeviceConnected)
+	defer sub.Unsubscribe()
+
+	cfg := s.cfg.Subscribe(s)
+	defer s.cfg.Unsubscribe(s)
+	s.replace(isConfigWarning, checkConfig(cfg))
+
+	for {
+		select {
+		case ev, ok := <-sub.C():
+			if !ok {
+				return nil
+			}
+			if data, ok := ev.Data.(map[string]string); ok {
+				s.deviceConnected(data)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *service) Warnings() []Warning {
+	s.mut.Lock()
+	res := make([]Warning, 0, len(s.warnings))
+	for _, w := range s.warnings {
+		res = append(res, w)
+	}
+	s.mut.Unlock()
+	sort.Slice(res, func(a, b int) bool {
+		if res[a].Kind != res[b].Kind {
+			return res[a].Kind < res[b].Kind
+		}
+		return res[a].key() < res[b].key()
+	})
+	return res
+}
+
+func (s *service) VerifyConfiguration(_, _ config.Configuration) error {
+	return nil
+}
+
+func (s *service) CommitConfiguration(_, to config.Configuration) bool {
+	s.replace(isConfigWarning, checkConfig(to))
+
+	// Forget about peers that were removed.
+	devices := make(map[string]struct{}, len(to.Devices))
+	for _, dev := range to.Devices {
+		devices[dev.DeviceID.String()] = struct{}{}
+	}
+	s.replace(func(w Warning) bool {
+		_, ok := devices[w.Subject]
+		return w.Kind == KindLegacyPeer && !ok
+	}, nil)
+	return true
+}
+
+func (*service) String() string {
+	return "warnings.Service"
+}
+
+// replace removes the current warnings selected by match and adds the
+// given ones instead. Warnings that were already present keep the time
+// they were first seen, new ones are logged and published as an event.
+func (s *service) replace(match func(Warning) bool, fresh []Warning) {
+	now := s.timeNow().Truncate(time.Second)
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	seen := make(map[string]struct{}, len(fresh))
+	for _, w := range fresh {
+		key := w.key()
+		seen[key] = struct{}{}
+		if old, ok := s.warnings[key]; ok {
+			w.Since = old.Since
+			s.warnings[key] = w
+			continue
+		}
+		w.Since = now
+		s.warnings[key] = w
+		l.Warnf("%s %s", w.Message, strings.Join(w.Remediation, " "))
+		s.evLogger.Log(events.DeprecationWarning, w)
+	}
+	for key, w := range s.warnings {
+		if _, ok := seen[key]; !ok && match(w) {
+			l.Debugln("Warning no longer applies:", key)
+			delete(s.warnings, key)
+		}
+	}
+}
+
+// deviceConnected reports the peer if it runs a legacy version, or clears
+// the warning if it has since been upgraded. The data is that of the
+// DeviceConnected event.
+func (s *service) deviceConnected(data map[string]string) {
+	id := data["id"]
+	match := func(w Warning) bool {
+		return w.Kind == KindLegacyPeer && w.Subject == id
+	}
+	if !isLegacyPeer(data["clientName"], data["clientVersion"]) {
+		s.replace(match, nil)
+		return
+	}
+	name := data["deviceName"]
+	if name == "" {
+		name = id
+	}
+	s.replace(match, []Warning{{
+		ID:      "peer.legacyVersion",
+		Kind:    KindLegacyPeer,
+		Subject: id,
+		Message: fmt.Sprintf("Device %s runs %s %s, which is older than %s. Connecting to it may stop working in a future release.", name, data["clientName"], data["clientVersion"], legacyPeerVersion),
+		Remediation: []string{
+			"Upgrade Syncthing on the device.",
+			"If the device is no longer used, remove it.",
+		},
+	}})
+}
+
+func isLegacyPeer(clientName, clientVersion string) bool {
+	// Development builds and other clients don't have comparable versions.
+	if clientName != "syncthing" || !strings.HasPrefix(clientVersion, "v") {
+		return false
+	}
+	return upgrade.CompareVersions(clientVersion, legacyPeerVersion) < upgrade.Equal
+}
+
+func isConfigWarning(w Warning) bool {
+	return w.Kind == KindDeprecatedOption || w.Kind == KindDefaultChange
+}
+
+// checkConfig returns the warnings for deprecated options that are in use,
+// and for defaults about to change that the configuration relies on.
+func checkConfig(cfg config.Configuration) []Warning {
+	var res []Warning
+	add := func(id string, kind Kind, message string, remediation ...string) {
+		res = append(res, Warning{
+			ID:          id,
+			Kind:        kind,
+			Message:     message,
+			Remediation: remediation,
+		})
+	}
+
+	if cfg.Options.InsecureAllowOldTLSVersions {
+		add("options.insecureAllowOldTLSVersions", KindDeprecatedOption,
+			"Allowing TLS 1.2 on sync connections is deprecated and will be removed in a future release.",
+			"Upgrade devices that only support TLS 1.2 to a current version of Syncthing.",
+			"Then disable insecureAllowOldTLSVersions in the advanced settings.")
+	}
+	if cfg.Options.URAccepted > 0 && cfg.Options.URPostInsecurely {
+		add("options.urPostInsecurely", KindDeprecatedOption,
+			"Sending usage reports without verifying the certificate of the server is deprecated and will be removed in a future release.",
+			"Disable urPostInsecurely in the advanced settings.",
+			"If the usage reporting server uses a self-signed certificate, make it trusted by the system instead.")
+	}
+
+	gui := cfg.GUI
+	if gui.Enabled && gui.Network() == "tcp" && !gui.UseTLS() && !isLoopback(gui.Address()) {
+		add("gui.insecureHTTP", KindDefaultChange,
+			fmt.Sprintf("The GUI is reachable at %s over plain HTTP. A future release will use HTTPS by default for the GUI on addresses other than localhost.", gui.Address()),
+			"Enable \"Use HTTPS for GUI\" in the GUI settings.",
+			"If the GUI is only used on this computer, change the GUI listen address to 127.0.0.1.")
+	}
+
+	return res
+}
+
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package warnings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestConfigWarnings(t *testing.T) {
+	cfg := config.New(protocol.LocalDeviceID)
+	if ws := checkConfig(cfg); len(ws) != 0 {
+		t.Fatalf("expected no warnings for the default config, got %v", ws)
+	}
+
+	cfg.Options.InsecureAllowOldTLSVersions = true
+	cfg.GUI.RawAddress = "0.0.0.0:8384"
+	cfg.GUI.RawUseTLS = false
+	ws := checkConfig(cfg)
+	if len(ws) != 2 {
+		t.Fatalf("expected two warnings, got %v", ws)
+	}
+	if ws[0].ID != "options.insecureAllowOldTLSVersions" || ws[0].Kind != KindDeprecatedOption {
+		t.Errorf("unexpected warning %+v", ws[0])
+	}
+	if ws[1].ID != "gui.insecureHTTP" || ws[1].Kind != KindDefaultChange {
+		t.Errorf("unexpected warning %+v", ws[1])
+	}
+
+	for addr, loopback := range map[string]bool{
+		"127.0.0.1:8384": true,
+		"[::1]:8384":     true,
+		"localhost:8384": true,
+		":8384":          false,
+		"[::]:8384":      false,
+		"192.0.2.1:8384": false,
+	} {
+		if got := isLoopback(addr); got != loopback {
+			t.Errorf("isLoopback(%q) = %v, expected %v", addr, got, loopback)
+		}
+	}
+}
+
+func TestLegacyPeerWarnings(t *testing.T) {
+	cfg := config.New(protocol.LocalDeviceID)
+	dev := config.DeviceConfiguration{DeviceID: protocol.DeviceID{1}, Name: "peer"}
+	cfg.Devices = append(cfg.Devices, dev)
+	w := config.Wrap("", cfg, protocol.LocalDeviceID, events.NoopLogger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	evLogger := events.NewLogger()
+	go evLogger.Serve(ctx)
+	sub := evLogger.Subscribe(events.DeprecationWarning)
+	defer sub.Unsubscribe()
+
+	svc := New(w, evLogger).(*service)
+	connected := func(version string) {
+		svc.deviceConnected(map[string]string{
+			"id":            dev.DeviceID.String(),
+			"deviceName":    "peer",
+			"clientName":    "syncthing",
+			"clientVersion": version,
+		})
+	}
+
+	connected("v1.11.1")
+	ws := svc.Warnings()
+	if len(ws) != 1 || ws[0].Kind != KindLegacyPeer || ws[0].Subject != dev.DeviceID.String() {
+		t.Fatalf("expected a legacy peer warning, got %v", ws)
+	}
+	ev, err := sub.Poll(time.Second)
+	if err != nil {
+		t.Fatal("expected an event for the new warning:", err)
+	}
+	if got := ev.Data.(Warning); got.ID != "peer.legacyVersion" {
+		t.Errorf("unexpected event data %+v", got)
+	}
+
+	// Reconnecting with the same version doesn't report it again.
+	connected("v1.11.1")
+	if _, err := sub.Poll(100 * time.Millisecond); err != events.ErrTimeout {
+		t.Error("expected no further event, got", err)
+	}
+
+	connected("v1.23.0")
+	if ws := svc.Warnings(); len(ws) != 0 {
+		t.Errorf("expected the warning to be cleared after upgrading, got %v", ws)
+	}
+
+	// Removing the device clears its warning too.
+	connected("v0.14.52")
+	if ws := svc.Warnings(); len(ws) != 1 {
+		t.Fatalf("expected a legacy peer warning, got %v", ws)
+	}
+	cfg.Devices = cfg.Devices[:len(cfg.Devices)-1]
+	svc.CommitConfiguration(cfg, cfg)
+	if ws := svc.Warnings(); len(ws) != 0 {
+		t.Errorf("expected the warning to be cleared after removing the device, got %v", ws)
+	}
+
+	for _, version := range []string{"unknown-dev", "v1.12.0", "v2.0.0"} {
+		if isLegacyPeer("syncthing", version) {
+			t.Errorf("expected %s to not be a legacy version", version)
+		}
+	}
+	if isLegacyPeer("other", "v0.1.0") {
+		t.Error("expected other clients to not be reported")
+	}
+}
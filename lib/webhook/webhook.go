@@ -0,0 +1,521 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package webhook delivers events as JSON to configured HTTP endpoints.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/thejerf/suture/v4"
+
+	"github.com/syncthing/syncthing/lib/build"
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+var (
+	// After a failed delivery the next attempt is made after minRetryDelay,
+	// doubling with each further failure up to maxRetryDelay.
+	minRetryDelay = 10 * time.Second
+	maxRetryDelay = time.Hour
+	sendTimeout   = 30 * time.Second
+	checkInterval = time.Second
+	// Beyond this many undelivered batches per webhook, the oldest ones are
+	// dropped.
+	maxQueuedBatches int64 = 1000
+)
+
+const (
+	// Without an explicit list of event types, all events except the
+	// noisy ones are delivered.
+	defaultEventMask = events.AllEvents &^ events.LocalChangeDetected &^ events.RemoteChangeDetected &^ events.DownloadProgress &^ events.RemoteDownloadProgress
+
+	signatureHeader = "X-Syncthing-Signature"
+	webhookHeader   = "X-Syncthing-Webhook"
+	queueHeadKey    = "head"
+	queueTailKey    = "tail"
+)
+
+type Service interface {
+	suture.Service
+	config.Committer
+	// Status returns the delivery status of all configured webhooks.
+	Status() []Status
+}
+
+// Status describes the delivery state of a webhook.
+type Status struct {
+	ID                  string    `json:"id"`
+	URL                 string    `json:"url"`
+	Paused              bool      `json:"paused"`
+	Pending             int       `json:"pending"` // events not yet batched
+	Queued              int64     `json:"queued"`  // batches awaiting delivery
+	Delivered           int64     `json:"delivered"`
+	Dropped             int64     `json:"dropped"`
+	LastAttempt         time.Time `json:"lastAttempt"`
+	LastSuccess         time.Time `json:"lastSuccess"`
+	LastError           string    `json:"lastError,omitempty"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	NextAttempt         time.Time `json:"nextAttempt"`
+}
+
+type service struct {
+	cfg      config.Wrapper
+	dba      backend.Backend
+	evLogger events.Logger
+	client   *http.Client
+	changed  chan struct{}
+	mut      sync.Mutex
+	targets  map[string]*target
+}
+
+func New(cfg config.Wrapper, dba backend.Backend, evLogger events.Logger) Service {
+	return &service{
+		cfg:      cfg,
+		dba:      dba,
+		evLogger: evLogger,
+		client:   &http.Client{Timeout: sendTimeout},
+		changed:  make(chan struct{}, 1),
+		mut:      sync.NewMutex(),
+		targets:  make(map[string]*target),
+	}
+}
+
+func (s *service) Serve(ctx context.Context) error {
+	s.cfg.Subscribe(s)
+	defer s.cfg.Unsubscribe(s)
+
+	var sub events.Subscription
+	var evChan <-chan events.Event
+	mask := s.applyConfig(ctx)
+	if mask != 0 {
+		sub = s.evLogger.Subscribe(mask)
+		evChan = sub.C()
+	}
+	defer func() {
+		if sub != nil {
+			sub.Unsubscribe()
+		}
+		s.stopAll()
+	}()
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.changed:
+			if newMask := s.applyConfig(ctx); newMask != mask {
+				mask = newMask
+				if sub != nil {
+					sub.Unsubscribe()
+					sub, evChan = nil, nil
+				}
+				if mask != 0 {
+					sub = s.evLogger.Subscribe(mask)
+					evChan = sub.C()
+				}
+			}
+		case ev, ok := <-evChan:
+			if !ok {
+				evChan = nil
+				continue
+			}
+			s.mut.Lock()
+			for _, t := range s.targets {
+				t.add(ev)
+			}
+			s.mut.Unlock()
+		case now := <-ticker.C:
+			s.mut.Lock()
+			for _, t := range s.targets {
+				t.batchIfDue(now)
+			}
+			s.mut.Unlock()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// applyConfig starts, stops and restarts targets according to the current
+// config and returns the union of their event masks.
+func (s *service) applyConfig(ctx context.Context) events.EventType {
+	hooks := s.cfg.RawCopy().Webhooks
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	seen := make(map[string]struct{}, len(hooks))
+	var mask events.EventType
+	for _, hook := range hooks {
+		seen[hook.ID] = struct{}{}
+		t, ok := s.targets[hook.ID]
+		if ok && !reflect.DeepEqual(t.cfg, hook) {
+			t.stop()
+			ok = false
+		}
+		if !ok {
+			t = newTarget(hook, db.NewWebhookQueueNamespace(s.dba, hook.ID), s.client)
+			t.start(ctx)
+			s.targets[hook.ID] = t
+		}
+		if !hook.Paused {
+			mask |= t.mask
+		}
+	}
+	for id, t := range s.targets {
+		if _, ok := seen[id]; !ok {
+			t.stop()
+			t.dropQueue()
+			delete(s.targets, id)
+		}
+	}
+	return mask
+}
+
+func (s *service) stopAll() {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	for _, t := range s.targets {
+		t.stop()
+	}
+}
+
+func (s *service) Status() []Status {
+	s.mut.Lock()
+	res := make([]Status, 0, len(s.targets))
+	for _, t := range s.targets {
+		res = append(res, t.getStatus())
+	}
+	s.mut.Unlock()
+	sort.Slice(res, func(a, b int) bool {
+		return res[a].ID < res[b].ID
+	})
+	return res
+}
+
+func (s *service) VerifyConfiguration(_, _ config.Configuration) error {
+	return nil
+}
+
+func (s *service) CommitConfiguration(from, to config.Configuration) bool {
+	if !reflect.DeepEqual(from.Webhooks, to.Webhooks) {
+		select {
+		case s.changed <- struct{}{}:
+		default:
+		}
+	}
+	return true
+}
+
+func (*service) String() string {
+	return "webhook.Service"
+}
+
+// A target collects events for one webhook into batches, which are persisted
+// in a queue and delivered in order.
+type target struct {
+	cfg    config.WebhookConfiguration
+	mask   events.EventType
+	queue  *db.NamespacedKV
+	client *http.Client
+	wake   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mut          sync.Mutex // protects everything below and the queue
+	pending      []events.Event
+	firstPending time.Time
+	status       Status
+}
+
+func newTarget(cfg config.WebhookConfiguration, queue *db.NamespacedKV, client *http.Client) *target {
+	mask := defaultEventMask
+	if len(cfg.Events) > 0 {
+		mask = 0
+		for _, ev := range cfg.Events {
+			mask |= events.UnmarshalEventType(ev)
+		}
+	}
+	return &target{
+		cfg:    cfg,
+		mask:   mask,
+		queue:  queue,
+		client: client,
+		wake:   make(chan struct{}, 1),
+		mut:    sync.NewMutex(),
+		status: Status{
+			ID:     cfg.ID,
+			URL:    cfg.URL,
+			Paused: cfg.Paused,
+		},
+	}
+}
+
+func (t *target) start(ctx context.Context) {
+	if t.cfg.Paused {
+		return
+	}
+	ctx, t.cancel = context.WithCancel(ctx)
+	t.done = make(chan struct{})
+	go t.serve(ctx)
+	// Deliver whatever is left from before.
+	t.signal()
+}
+
+// stop persists pending events and waits for deliveries to stop.
+func (t *target) stop() {
+	t.mut.Lock()
+	t.enqueueLocked()
+	t.mut.Unlock()
+	if t.cancel != nil {
+		t.cancel()
+		<-t.done
+	}
+}
+
+func (t *target) add(ev events.Event) {
+	if t.cfg.Paused || ev.Type&t.mask == 0 {
+		return
+	}
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	if len(t.pending) == 0 {
+		t.firstPending = ev.Time
+	}
+	t.pending = append(t.pending, ev)
+	if len(t.pending) >= t.cfg.MaxBatchSize {
+		t.enqueueLocked()
+	}
+}
+
+func (t *target) batchIfDue(now time.Time) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	if len(t.pending) > 0 && now.Sub(t.firstPending) >= time.Duration(t.cfg.BatchIntervalS)*time.Second {
+		t.enqueueLocked()
+	}
+}
+
+// enqueueLocked moves the pending events as a batch to the queue.
+func (t *target) enqueueLocked() {
+	if len(t.pending) == 0 {
+		return
+	}
+	bs, err := json.Marshal(t.pending)
+	t.pending = nil
+	if err != nil {
+		l.Warnf("Webhook %s: failed to encode events: %v", t.cfg.ID, err)
+		return
+	}
+	head, tail, err := t.boundsLocked()
+	if err == nil {
+		err = t.queue.PutBytes(queueKey(tail), bs)
+	}
+	if err == nil {
+		err = t.queue.PutInt64(queueTailKey, tail+1)
+	}
+	if err != nil {
+		l.Warnf("Webhook %s: failed to queue events: %v", t.cfg.ID, err)
+		return
+	}
+	for ; tail+1-head > maxQueuedBatches; head++ {
+		l.Infof("Webhook %s: dropping undelivered events as the queue is full", t.cfg.ID)
+		_ = t.queue.Delete(queueKey(head))
+		t.status.Dropped++
+	}
+	_ = t.queue.PutInt64(queueHeadKey, head)
+	t.signal()
+}
+
+func (t *target) signal() {
+	select {
+	case t.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (t *target) serve(ctx context.Context) {
+	defer close(t.done)
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-t.wake:
+			if retry != nil {
+				// Backing off; new batches wait for the next retry.
+				continue
+			}
+		case <-retry:
+			retry = nil
+		case <-ctx.Done():
+			return
+		}
+		if err := t.deliverQueued(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			retry = time.After(t.failed(err))
+		}
+	}
+}
+
+// deliverQueued delivers batches from the queue until it is empty or a
+// delivery fails.
+func (t *target) deliverQueued(ctx context.Context) error {
+	for {
+		t.mut.Lock()
+		head, tail, err := t.boundsLocked()
+		var bs []byte
+		if err == nil && head < tail {
+			bs, _, err = t.queue.Bytes(queueKey(head))
+		}
+		t.mut.Unlock()
+		if err != nil {
+			return err
+		}
+		if head >= tail {
+			return nil
+		}
+
+		if err := t.post(ctx, bs); err != nil {
+			return err
+		}
+
+		t.mut.Lock()
+		// The queue might have been trimmed meanwhile.
+		if cur, _, _ := t.queue.Int64(queueHeadKey); cur == head {
+			_ = t.queue.Delete(queueKey(head))
+			_ = t.queue.PutInt64(queueHeadKey, head+1)
+		}
+		var n []json.RawMessage
+		if json.Unmarshal(bs, &n) == nil {
+			t.status.Delivered += int64(len(n))
+		}
+		t.status.LastSuccess = t.status.LastAttempt
+		t.status.LastError = ""
+		t.status.ConsecutiveFailures = 0
+		t.status.NextAttempt = time.Time{}
+		t.mut.Unlock()
+	}
+}
+
+func (t *target) post(ctx context.Context, body []byte) error {
+	t.mut.Lock()
+	t.status.LastAttempt = time.Now().Truncate(time.Second)
+	t.mut.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "syncthing/"+build.Version)
+	req.Header.Set(webhookHeader, t.cfg.ID)
+	if t.cfg.Secret != "" {
+		req.Header.Set(signatureHeader, "sha256="+Sign(t.cfg.Secret, body))
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
+
+// failed records a failed delivery and returns the time to wait before the
+// next attempt.
+func (t *target) failed(err error) time.Duration {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	t.status.ConsecutiveFailures++
+	t.status.LastError = err.Error()
+	delay := minRetryDelay
+	for i := 1; i < t.status.ConsecutiveFailures && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	t.status.NextAttempt = time.Now().Add(delay).Truncate(time.Second)
+	if t.status.ConsecutiveFailures == 1 {
+		l.Infof("Webhook %s: delivery failed, will retry: %v", t.cfg.ID, err)
+	} else {
+		l.Debugf("Webhook %s: delivery failed %d times: %v", t.cfg.ID, t.status.ConsecutiveFailures, err)
+	}
+	return delay
+}
+
+func (t *target) getStatus() Status {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	status := t.status
+	status.Pending = len(t.pending)
+	if head, tail, err := t.boundsLocked(); err == nil {
+		status.Queued = tail - head
+	}
+	return status
+}
+
+// dropQueue removes all queued batches.
+func (t *target) dropQueue() {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	head, tail, err := t.boundsLocked()
+	if err != nil {
+		return
+	}
+	for ; head < tail; head++ {
+		_ = t.queue.Delete(queueKey(head))
+	}
+	_ = t.queue.Delete(queueHeadKey)
+	_ = t.queue.Delete(queueTailKey)
+}
+
+func (t *target) boundsLocked() (int64, int64, error) {
+	head, _, err := t.queue.Int64(queueHeadKey)
+	if err != nil {
+		return 0, 0, err
+	}
+	tail, _, err := t.queue.Int64(queueTailKey)
+	if err != nil {
+		return 0, 0, err
+	}
+	return head, tail, nil
+}
+
+func queueKey(n int64) string {
+	return fmt.Sprintf("%020d", n)
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the body, as sent in the
+// X-Syncthing-Signature header.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2023 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestDelivery(t *testing.T) {
+	oldRetry, oldCheck := minRetryDelay, checkInterval
+	minRetryDelay, checkInterval = 10*time.Millisecond, 10*time.Millisecond
+	defer func() { minRetryDelay, checkInterval = oldRetry, oldCheck }()
+
+	type delivery struct {
+		events    []events.Event
+		signature string
+		id        string
+	}
+	received := make(chan delivery, 10)
+	fail := make(chan struct{}, 1)
+	fail <- struct{}{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-fail:
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		default:
+		}
+		body, _ := io.ReadAll(r.Body)
+		if got, exp := r.Header.Get(signatureHeader), "sha256="+Sign("secret", body); got != exp {
+			t.Errorf("bad signature %q, expected %q", got, exp)
+		}
+		var d delivery
+		if err := json.Unmarshal(body, &d.events); err != nil {
+			t.Error(err)
+		}
+		d.id = r.Header.Get(webhookHeader)
+		received <- d
+	}))
+	defer srv.Close()
+
+	cfg := config.New(protocol.LocalDeviceID)
+	cfg.Webhooks = []config.WebhookConfiguration{{
+		ID:             "hook",
+		URL:            srv.URL,
+		Secret:         "secret",
+		Events:         []string{"FolderPaused"},
+		BatchIntervalS: 1,
+		MaxBatchSize:   2,
+	}}
+	w := config.Wrap("", cfg, protocol.LocalDeviceID, events.NoopLogger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	evLogger := events.NewLogger()
+	go evLogger.Serve(ctx)
+
+	svc := New(w, backend.OpenMemory(), evLogger)
+	go svc.Serve(ctx)
+	// Wait for the service to start up and subscribe to events.
+	for len(svc.Status()) != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	// Two matching events fill a batch, the other one is filtered out. The
+	// first delivery fails and is retried.
+	evLogger.Log(events.FolderPaused, "a")
+	evLogger.Log(events.FolderResumed, "b")
+	evLogger.Log(events.FolderPaused, "c")
+
+	select {
+	case d := <-received:
+		if d.id != "hook" {
+			t.Errorf("unexpected webhook ID %q", d.id)
+		}
+		if len(d.events) != 2 || d.events[0].Data != "a" || d.events[1].Data != "c" {
+			t.Errorf("unexpected batch %v", d.events)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+
+	for i := 0; ; i++ {
+		st := svc.Status()[0]
+		if st.Delivered == 2 {
+			if st.ConsecutiveFailures != 0 || st.LastError != "" || st.Queued != 0 {
+				t.Errorf("unexpected status after delivery: %+v", st)
+			}
+			break
+		}
+		if i > 1000 {
+			t.Fatalf("delivery not recorded: %+v", st)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// A single event is delivered after the batch interval.
+	evLogger.Log(events.FolderPaused, "d")
+	select {
+	case d := <-received:
+		if len(d.events) != 1 || d.events[0].Data != "d" {
+			t.Errorf("unexpected batch %v", d.events)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+}
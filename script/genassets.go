@@ -26,6 +26,9 @@ import (
 
 var tpl = template.Must(template.New("assets").Parse(`// Code generated by genassets.go - DO NOT EDIT.
 
+//go:build !noassets && !mobile
+// +build !noassets,!mobile
+
 package auto
 
 import (